@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/cmd/syft/cli/attach"
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/config"
+)
+
+const attachExample = `  {{.appName}} {{.command}} yourrepo/yourimage:tag sbom.spdx.json    attach the SBOM to the image within the registry as an OCI referrer
+  {{.appName}} {{.command}} yourrepo/yourimage@sha256:... sbom.syft.json
+
+  The attached SBOM is used instead of cataloging the image with:
+    {{.appName}} yourrepo/yourimage:tag --use-existing-sbom
+`
+
+func Attach(v *viper.Viper, app *config.Application, ro *options.RootOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "attach [IMAGE] [SBOM]",
+		Short: "Attach an SBOM to the given container image within a registry",
+		Long:  "Attach an SBOM file (in syft-json, SPDX or CycloneDX format) to a container image within a registry as an OCI referrer of the image, so the SBOM can be found from the image",
+		Example: internal.Tprintf(attachExample, map[string]interface{}{
+			"appName": internal.ApplicationName,
+			"command": "attach",
+		}),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := app.LoadAllValues(v, ro.Config); err != nil {
+				return fmt.Errorf("invalid application config: %w", err)
+			}
+			// configure logging for command
+			newLogWrapper(app)
+			logApplicationConfig(app)
+			return cobra.ExactArgs(2)(cmd, args)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app.CheckForAppUpdate {
+				checkForApplicationUpdate()
+			}
+			return attach.Run(cmd.Context(), app, args)
+		},
+	}
+
+	return cmd
+}
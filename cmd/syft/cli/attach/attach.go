@@ -0,0 +1,76 @@
+package attach
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/referrers"
+	"github.com/anchore/syft/internal/registryclient"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// Run attaches the given SBOM file to an image within a registry as an OCI referrer of the image, writing the
+// reference of the attached SBOM to STDOUT.
+func Run(ctx context.Context, app *config.Application, args []string) error {
+	registryOptions := app.Registry.ToOptions()
+	ref, err := name.ParseReference(args[0], registryclient.NameOptions(registryOptions)...)
+	if err != nil {
+		return fmt.Errorf("attach command can only be used with images within a registry: %w", err)
+	}
+
+	b, err := os.ReadFile(args[1])
+	if err != nil {
+		return fmt.Errorf("failed to open SBOM file: %w", err)
+	}
+	artifactType, err := sbomArtifactType(b)
+	if err != nil {
+		return err
+	}
+
+	digest, err := referrers.Resolve(ctx, ref, app.Platform, registryOptions)
+	if err != nil {
+		return err
+	}
+
+	attached, err := referrers.Attach(ctx, digest, referrers.Artifact{
+		ArtifactType: artifactType,
+		Layer:        static.NewLayer(b, types.MediaType(artifactType)),
+	}, registryOptions)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("attached SBOM to image=%q", digest)
+	_, err = fmt.Fprintln(os.Stdout, attached.String())
+	return err
+}
+
+// sbomArtifactType returns the artifact type of the given SBOM, which must be in a format that can be attached.
+func sbomArtifactType(b []byte) (string, error) {
+	_, format, err := syft.Decode(bytes.NewReader(b))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode SBOM: %w", err)
+	}
+
+	artifactType := referrers.SBOMArtifactType(format.ID())
+	if artifactType == "" {
+		var formats []sbom.FormatID
+		for _, f := range syft.FormatIDs() {
+			if referrers.SBOMArtifactType(f) != "" {
+				formats = append(formats, f)
+			}
+		}
+		return "", fmt.Errorf("SBOMs in format %q cannot be attached. Available formats: %+v", options.FormatAliases(format.ID()), options.FormatAliases(formats...))
+	}
+	return artifactType, nil
+}
@@ -25,7 +25,7 @@ const (
 	attestHelp = attestExample + attestSchemeHelp
 )
 
-func Attest(v *viper.Viper, app *config.Application, ro *options.RootOptions) *cobra.Command {
+func Attest(v *viper.Viper, app *config.Application, ro *options.RootOptions, po *options.PackagesOptions) *cobra.Command {
 	ao := options.AttestOptions{}
 	cmd := &cobra.Command{
 		Use:   "attest --output [FORMAT] --key [KEY] [SOURCE]",
@@ -36,6 +36,9 @@ func Attest(v *viper.Viper, app *config.Application, ro *options.RootOptions) *c
 			"command": "attest",
 		}),
 		Args: func(cmd *cobra.Command, args []string) error {
+			if err := po.BindFlags(cmd.Flags(), v); err != nil {
+				return err
+			}
 			// run to unmarshal viper object onto app config
 			// the viper object correctly
 			if err := app.LoadAllValues(v, ro.Config); err != nil {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/in-toto/in-toto-golang/in_toto"
 	sigopts "github.com/sigstore/cosign/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/cmd/cosign/cli/rekor"
@@ -77,13 +78,9 @@ func Run(ctx context.Context, app *config.Application, ko sigopts.KeyOpts, args
 	if format == nil {
 		format = syft.FormatByID(syftjson.ID) // default attestation format
 	}
-	predicateType := formatPredicateType(format)
-	if predicateType == "" {
-		return fmt.Errorf(
-			"could not produce attestation predicate for given format: %q. Available formats: %+v",
-			options.FormatAliases(format.ID()),
-			options.FormatAliases(allowedAttestFormats...),
-		)
+	predicateType, err := attestPredicateType(format, app.Attest.PredicateType)
+	if err != nil {
+		return err
 	}
 
 	if app.Attest.KeyRef != "" {
@@ -107,11 +104,11 @@ func Run(ctx context.Context, app *config.Application, ko sigopts.KeyOpts, args
 	subscription := eventBus.Subscribe()
 
 	return eventloop.EventLoop(
-		execWorker(app, *si, format, predicateType, sv),
+		execWorker(ctx, app, *si, format, predicateType, sv),
 		eventloop.SetupSignals(),
 		subscription,
 		stereoscope.Cleanup,
-		ui.Select(options.IsVerbose(app), app.Quiet)...,
+		ui.Select(options.IsVerbose(app), app.Quiet, app.Progress)...,
 	)
 }
 
@@ -151,7 +148,7 @@ func parseImageSource(userInput string, app *config.Application) (s *source.Inpu
 	return si, nil
 }
 
-func execWorker(app *config.Application, sourceInput source.Input, format sbom.Format, predicateType string, sv *sign.SignerVerifier) <-chan error {
+func execWorker(ctx context.Context, app *config.Application, sourceInput source.Input, format sbom.Format, predicateType string, sv *sign.SignerVerifier) <-chan error {
 	errs := make(chan error)
 	go func() {
 		defer close(errs)
@@ -165,7 +162,7 @@ func execWorker(app *config.Application, sourceInput source.Input, format sbom.F
 			return
 		}
 
-		s, err := packages.GenerateSBOM(src, errs, app)
+		s, err := packages.GenerateSBOM(ctx, src, errs, app)
 		if err != nil {
 			errs <- err
 			return
@@ -183,7 +180,7 @@ func execWorker(app *config.Application, sourceInput source.Input, format sbom.F
 			return
 		}
 
-		err = publishAttestation(app, signedPayload, src, sv)
+		entry, err := publishAttestation(ctx, app, signedPayload, predicateType, src, sv)
 		if err != nil {
 			errs <- err
 			return
@@ -192,7 +189,11 @@ func execWorker(app *config.Application, sourceInput source.Input, format sbom.F
 		bus.Publish(partybus.Event{
 			Type: event.Exit,
 			Value: func() error {
-				return nil
+				if entry == nil {
+					return nil
+				}
+				// the entry of the uploaded attestation within the transparency log is the output of the command
+				return entry.write(os.Stdout)
 			},
 		})
 	}()
@@ -247,31 +248,32 @@ func generateAttestation(si source.Input, predicate []byte, src *source.Source,
 	return wrapped.SignMessage(bytes.NewReader(payload), signatureoptions.WithContext(context.Background()))
 }
 
-// publishAttestation publishes signedPayload to the location specified by the user.
-func publishAttestation(app *config.Application, signedPayload []byte, src *source.Source, sv *sign.SignerVerifier) error {
+// publishAttestation publishes signedPayload to the location specified by the user, returning the entry of the
+// attestation within the transparency log when the attestation is uploaded.
+func publishAttestation(ctx context.Context, app *config.Application, signedPayload []byte, predicateType string, src *source.Source, sv *sign.SignerVerifier) (*transparencyLogEntry, error) {
 	switch {
 	// We want to give the option to not upload the generated attestation
-	// if passed or if the user is using local PKI
-	case app.Attest.NoUpload || app.Attest.KeyRef != "":
+	// if passed or if the user is using local PKI (unless asked to upload it as a referrer)
+	case app.Attest.NoUpload || (app.Attest.KeyRef != "" && !app.Attest.Referrer):
 		if app.File != "" {
-			return os.WriteFile(app.File, signedPayload, 0600)
+			return nil, os.WriteFile(app.File, signedPayload, 0600)
 		}
 
 		_, err := os.Stdout.Write(signedPayload)
-		return err
+		return nil, err
 
 	default:
 		ref, err := name.ParseReference(src.Metadata.ImageMetadata.UserInput)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		digest, err := ociremote.ResolveDigest(ref)
+		digest, err := ociremote.ResolveDigest(ref, ociremote.WithRemoteOptions(remote.WithContext(ctx)))
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		return uploadAttestation(app, signedPayload, digest, sv)
+		return uploadAttestation(ctx, app, signedPayload, predicateType, digest, sv)
 	}
 }
 
@@ -297,8 +299,9 @@ func trackUploadAttestation() (*progress.Stage, *progress.Manual) {
 // returns a bundle for attestation annotations
 // rekor bundle includes a signed payload and rekor timestamp;
 // the bundle is then wrapped onto an OCI signed entity and uploaded to
-// the user's image's OCI registry repository as *.att
-func uploadAttestation(app *config.Application, signedPayload []byte, digest name.Digest, sv *sign.SignerVerifier) error {
+// the user's image's OCI registry repository as *.att (or as a referrer of the image);
+// the entry within the transparency log is recorded within the annotations of the attestation and returned
+func uploadAttestation(ctx context.Context, app *config.Application, signedPayload []byte, predicateType string, digest name.Digest, sv *sign.SignerVerifier) (*transparencyLogEntry, error) {
 	// add application/vnd.dsse.envelope.v1+json as media type for other applications to decode attestation
 	opts := []static.Option{static.WithLayerMediaType(types.DssePayloadType)}
 	if sv.Cert != nil {
@@ -316,42 +319,88 @@ func uploadAttestation(app *config.Application, signedPayload []byte, digest nam
 	// rekor bundle includes a signed payload and rekor timestamp;
 	// the bundle is then wrapped onto an OCI signed entity and uploaded to
 	// the user's image's OCI registry repository as *.att
-	bundle, err := uploadToTlog(context.TODO(), sv, app.Attest.RekorURL, func(r *client.Rekor, b []byte) (*models.LogEntryAnon, error) {
-		return cosign.TLogUploadInTotoAttestation(context.TODO(), r, signedPayload, b)
+	tlogEntry, err := uploadToTlog(ctx, sv, app.Attest.RekorURL, func(r *client.Rekor, b []byte) (*models.LogEntryAnon, error) {
+		return cosign.TLogUploadInTotoAttestation(ctx, r, signedPayload, b)
 	})
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	entry, err := newTransparencyLogEntry(app.Attest.RekorURL, tlogEntry)
+	if err != nil {
+		return nil, err
 	}
+	log.Infof("transparency log entry created with index=%d uuid=%s", entry.LogIndex, entry.UUID)
 
 	prog.N = 1
 	stage.Current = "uploading attestation to OCI registry"
 
 	// add bundle OCI attestation that is uploaded to
-	opts = append(opts, static.WithBundle(bundle))
+	opts = append(opts, static.WithBundle(cbundle.EntryToBundle(tlogEntry)), static.WithAnnotations(entry.annotations()))
 	sig, err := static.NewAttestation(signedPayload, opts...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	se, err := ociremote.SignedEntity(digest)
+	if app.Attest.Referrer {
+		if err := writeReferrer(ctx, digest, sig, predicateType); err != nil {
+			return nil, err
+		}
+		prog.SetCompleted()
+		return entry, nil
+	}
+
+	se, err := ociremote.SignedEntity(digest, ociremote.WithRemoteOptions(remote.WithContext(ctx)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	newSE, err := mutate.AttachAttestationToEntity(se, sig)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Publish the attestations associated with this entity
-	err = ociremote.WriteAttestations(digest.Repository, newSE)
+	err = ociremote.WriteAttestations(digest.Repository, newSE, ociremote.WithRemoteOptions(remote.WithContext(ctx)))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	prog.SetCompleted()
 
-	return nil
+	return entry, nil
+}
+
+// attestPredicateType returns the predicate type of the attestation of an SBOM in the given format: the type given by
+// the user (a predicate type alias known to cosign, such as "custom", or a URI), otherwise the type of the format.
+func attestPredicateType(format sbom.Format, requested string) (string, error) {
+	formatType := formatPredicateType(format)
+	if formatType == "" {
+		return "", fmt.Errorf(
+			"could not produce attestation predicate for given format: %q. Available formats: %+v",
+			options.FormatAliases(format.ID()),
+			options.FormatAliases(allowedAttestFormats...),
+		)
+	}
+	if requested == "" {
+		return formatType, nil
+	}
+
+	predicateType, err := sigopts.ParsePredicateType(requested)
+	if err != nil {
+		return "", err
+	}
+
+	switch predicateType {
+	case in_toto.PredicateSPDX, in_toto.PredicateCycloneDX:
+		// an SBOM predicate type must describe the SBOM within the attestation
+		if predicateType != formatType {
+			return "", fmt.Errorf("predicate type %q cannot be used for format %q", requested, format.ID())
+		}
+	case sigopts.PredicateTypeMap[sigopts.PredicateSLSA], sigopts.PredicateTypeMap[sigopts.PredicateLink], sigopts.PredicateTypeMap[sigopts.PredicateVuln]:
+		return "", fmt.Errorf("predicate type %q cannot describe an SBOM", requested)
+	}
+	return predicateType, nil
 }
 
 func formatPredicateType(format sbom.Format) string {
@@ -369,7 +418,7 @@ func formatPredicateType(format sbom.Format) string {
 
 type tlogUploadFn func(*client.Rekor, []byte) (*models.LogEntryAnon, error)
 
-func uploadToTlog(ctx context.Context, sv *sign.SignerVerifier, rekorURL string, upload tlogUploadFn) (*cbundle.RekorBundle, error) {
+func uploadToTlog(ctx context.Context, sv *sign.SignerVerifier, rekorURL string, upload tlogUploadFn) (*models.LogEntryAnon, error) {
 	var rekorBytes []byte
 	// Upload the cert or the public key, depending on what we have
 	if sv.Cert != nil {
@@ -391,8 +440,5 @@ func uploadToTlog(ctx context.Context, sv *sign.SignerVerifier, rekorURL string,
 		return nil, err
 	}
 
-	if entry.LogIndex != nil {
-		log.Debugf("transparency log entry created with index: %v", *entry.LogIndex)
-	}
-	return cbundle.EntryToBundle(entry), nil
+	return entry, nil
 }
@@ -0,0 +1,135 @@
+package attest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/sigstore/cosign/pkg/cosign/attestation"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/formats/cyclonedxjson"
+	"github.com/anchore/syft/syft/formats/spdx22json"
+	"github.com/anchore/syft/syft/formats/syftjson"
+	"github.com/anchore/syft/syft/formats/table"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func TestAttestPredicateType(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    sbom.FormatID
+		requested string
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:   "syft-json",
+			format: syftjson.ID,
+			want:   "https://syft.dev/bom",
+		},
+		{
+			name:   "spdx-json",
+			format: spdx22json.ID,
+			want:   in_toto.PredicateSPDX,
+		},
+		{
+			name:   "cyclonedx-json",
+			format: cyclonedxjson.ID,
+			want:   in_toto.PredicateCycloneDX,
+		},
+		{
+			name:      "matching alias",
+			format:    cyclonedxjson.ID,
+			requested: "cyclonedx",
+			want:      in_toto.PredicateCycloneDX,
+		},
+		{
+			name:      "custom",
+			format:    syftjson.ID,
+			requested: "custom",
+			want:      attestation.CosignCustomProvenanceV01,
+		},
+		{
+			name:      "URI",
+			format:    syftjson.ID,
+			requested: "https://example.com/sbom/v1",
+			want:      "https://example.com/sbom/v1",
+		},
+		{
+			name:      "alias not matching the format",
+			format:    syftjson.ID,
+			requested: "spdx",
+			wantErr:   true,
+		},
+		{
+			name:      "not an SBOM predicate type",
+			format:    syftjson.ID,
+			requested: "slsaprovenance",
+			wantErr:   true,
+		},
+		{
+			name:      "unknown predicate type",
+			format:    syftjson.ID,
+			requested: "bogus",
+			wantErr:   true,
+		},
+		{
+			name:    "format that cannot be attested",
+			format:  table.ID,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := attestPredicateType(syft.FormatByID(test.format), test.requested)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestNewTransparencyLogEntry(t *testing.T) {
+	body := []byte(`{"apiVersion":"0.0.1","kind":"intoto"}`)
+	index := int64(12345)
+	integrated := int64(1670000000)
+
+	entry, err := newTransparencyLogEntry("https://rekor.example.com", &models.LogEntryAnon{
+		Body:           base64.StdEncoding.EncodeToString(body),
+		LogIndex:       &index,
+		IntegratedTime: &integrated,
+	})
+	require.NoError(t, err)
+
+	// the UUID is the RFC 6962 leaf hash of the entry body
+	leafHash := sha256.Sum256(append([]byte{0}, body...))
+	expectedUUID := hex.EncodeToString(leafHash[:])
+
+	assert.Equal(t, &transparencyLogEntry{
+		RekorURL:       "https://rekor.example.com",
+		LogIndex:       12345,
+		UUID:           expectedUUID,
+		IntegratedTime: 1670000000,
+	}, entry)
+	assert.Equal(t, map[string]string{
+		rekorLogIndexAnnotation: "12345",
+		rekorUUIDAnnotation:     expectedUUID,
+	}, entry.annotations())
+
+	var out bytes.Buffer
+	require.NoError(t, entry.write(&out))
+	assert.JSONEq(t, `{"rekorUrl":"https://rekor.example.com","logIndex":12345,"uuid":"`+expectedUUID+`","integratedTime":1670000000}`, out.String())
+
+	_, err = newTransparencyLogEntry("https://rekor.example.com", &models.LogEntryAnon{LogIndex: &index})
+	assert.Error(t, err)
+}
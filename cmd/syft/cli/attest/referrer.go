@@ -0,0 +1,38 @@
+package attest
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/sigstore/cosign/pkg/oci"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/referrers"
+)
+
+// predicateTypeAnnotationKey records the predicate type of the attestation on the referrer manifest
+const predicateTypeAnnotationKey = "in-toto.io/predicate-type"
+
+// writeReferrer pushes the signed attestation to the repository of the image with the given digest as an OCI referrer
+// of the image (rather than as the attestation tag used by cosign).
+func writeReferrer(ctx context.Context, digest name.Digest, att oci.Signature, predicateType string) error {
+	annotations, err := att.Annotations()
+	if err != nil {
+		return err
+	}
+
+	ref, err := referrers.Attach(ctx, digest, referrers.Artifact{
+		ArtifactType:     intotoJSONDsseType,
+		Layer:            att,
+		LayerAnnotations: annotations,
+		Annotations: map[string]string{
+			predicateTypeAnnotationKey: predicateType,
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("attestation uploaded as referrer=%q", ref)
+	return nil
+}
@@ -0,0 +1,66 @@
+package attest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/sigstore/cosign/pkg/cosign"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+const (
+	// the annotations of the uploaded attestation that record its entry within the transparency log
+	rekorLogIndexAnnotation = "dev.syft.rekor.log-index"
+	rekorUUIDAnnotation     = "dev.syft.rekor.uuid"
+)
+
+// transparencyLogEntry describes the entry of an uploaded attestation within the Rekor transparency log, so that the
+// entry can be looked up later (e.g. with "rekor-cli get --uuid <uuid>").
+type transparencyLogEntry struct {
+	RekorURL       string `json:"rekorUrl"`
+	LogIndex       int64  `json:"logIndex"`
+	UUID           string `json:"uuid"`
+	IntegratedTime int64  `json:"integratedTime,omitempty"`
+}
+
+func newTransparencyLogEntry(rekorURL string, entry *models.LogEntryAnon) (*transparencyLogEntry, error) {
+	if entry == nil || entry.LogIndex == nil {
+		return nil, fmt.Errorf("transparency log entry has no log index")
+	}
+	if _, ok := entry.Body.(string); !ok {
+		return nil, fmt.Errorf("transparency log entry has no body")
+	}
+
+	// the UUID of an entry is the hash of the entry as a leaf of the transparency log (the merkle tree)
+	leafHash, err := cosign.ComputeLeafHash(entry)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine the UUID of the transparency log entry: %w", err)
+	}
+
+	e := &transparencyLogEntry{
+		RekorURL: rekorURL,
+		LogIndex: *entry.LogIndex,
+		UUID:     hex.EncodeToString(leafHash),
+	}
+	if entry.IntegratedTime != nil {
+		e.IntegratedTime = *entry.IntegratedTime
+	}
+	return e, nil
+}
+
+func (e transparencyLogEntry) annotations() map[string]string {
+	return map[string]string{
+		rekorLogIndexAnnotation: strconv.FormatInt(e.LogIndex, 10),
+		rekorUUIDAnnotation:     e.UUID,
+	}
+}
+
+// write describes the entry as a single JSON document.
+func (e transparencyLogEntry) write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(e)
+}
@@ -48,9 +48,14 @@ func New() (*cobra.Command, error) {
 	packagesCmd := Packages(v, app, ro, po)
 
 	// root options are also passed to the attestCmd so that a user provided config location can be discovered
-	attestCmd := Attest(v, app, ro)
-	poweruserCmd := PowerUser(v, app, ro)
+	attestCmd := Attest(v, app, ro, po)
+	poweruserCmd := PowerUser(v, app, ro, po)
 	convertCmd := Convert(v, app, ro, po)
+	serveCmd := Serve(v, app, ro)
+	watchCmd := Watch(v, app, ro, po)
+	verifyCmd := Verify(v, app, ro, po)
+	attachCmd := Attach(v, app, ro)
+	validateCmd := Validate(v, app, ro)
 
 	// rootCmd is currently an alias for the packages command
 	rootCmd := &cobra.Command{
@@ -93,6 +98,11 @@ func New() (*cobra.Command, error) {
 		convertCmd,
 		poweruserCmd,
 		poweruserCmd,
+		serveCmd,
+		watchCmd,
+		verifyCmd,
+		attachCmd,
+		validateCmd,
 		Completion(),
 		Version(v, app),
 		cranecmd.NewCmdAuthLogin("syft"),
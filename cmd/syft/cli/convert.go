@@ -16,11 +16,13 @@ import (
 const (
 	convertExample = `  {{.appName}} {{.command}} img.syft.json -o spdx-json                      convert a syft SBOM to spdx-json, output goes to stdout in table format, by default
   {{.appName}} {{.command}} img.syft.json -o cyclonedx-json=img.cdx.json    convert a syft SBOM to CycloneDX, output goes to a file named img.cdx.json
+  {{.appName}} {{.command}} img.spdx.json -o json --strict                  convert an SPDX SBOM to syft JSON, failing if it does not conform to the SPDX JSON schema
 `
 )
 
 //nolint:dupl
 func Convert(v *viper.Viper, app *config.Application, ro *options.RootOptions, po *options.PackagesOptions) *cobra.Command {
+	co := options.ConvertOptions{}
 	cmd := &cobra.Command{
 		Use:   "convert [SOURCE-SBOM] -o [FORMAT]",
 		Short: "Convert between SBOM formats",
@@ -52,5 +54,9 @@ func Convert(v *viper.Viper, app *config.Application, ro *options.RootOptions, p
 		log.Fatal(err)
 	}
 
+	if err := co.AddFlags(cmd, v); err != nil {
+		log.Fatal(err)
+	}
+
 	return cmd
 }
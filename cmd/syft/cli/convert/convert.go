@@ -1,19 +1,24 @@
 package convert
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/anchore/syft/cmd/syft/cli/options"
 	"github.com/anchore/syft/internal/config"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/formats/common/jsonschema"
+	"github.com/anchore/syft/syft/sbom"
 )
 
 func Run(ctx context.Context, app *config.Application, args []string) error {
 	log.Warn("convert is an experimental feature, run `syft convert -h` for help")
-	writer, err := options.MakeWriter(app.Outputs, app.File, "")
+	writer, err := options.MakeWriter(app.Outputs, app.File, "", "", app.Baseline, app.Archive)
 	if err != nil {
 		return err
 	}
@@ -32,10 +37,51 @@ func Run(ctx context.Context, app *config.Application, args []string) error {
 	}
 	defer f.Close()
 
-	sbom, _, err := syft.Decode(f)
+	by, err := io.ReadAll(f)
+	if err != nil {
+		return fmt.Errorf("failed to read SBOM file: %w", err)
+	}
+
+	if format := syft.IdentifyFormat(by); format != nil {
+		if err := validateSchema(format.ID(), by, app.Convert.Strict); err != nil {
+			return err
+		}
+	}
+
+	s, _, err := syft.Decode(bytes.NewReader(by))
 	if err != nil {
 		return fmt.Errorf("failed to decode SBOM: %w", err)
 	}
 
-	return writer.Write(*sbom)
+	return writer.Write(*s)
+}
+
+// validateSchema checks the input SBOM against the JSON schema of its format. Violations are logged, unless strict,
+// in which case they are returned as an error.
+func validateSchema(id sbom.FormatID, document []byte, strict bool) error {
+	violations, err := jsonschema.Validate(id, document)
+	if err != nil {
+		if strict {
+			return err
+		}
+		log.Warnf("unable to validate SBOM against the %s schema: %+v", id, err)
+		return nil
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if strict {
+		var lines []string
+		for _, v := range violations {
+			lines = append(lines, "  "+v.String())
+		}
+		return fmt.Errorf("SBOM does not conform to the %s schema (%d violations):\n%s", id, len(violations), strings.Join(lines, "\n"))
+	}
+
+	for _, v := range violations {
+		log.WithFields("format", id, "pointer", v.Pointer).Warnf("SBOM does not conform to schema: %s", v.Message)
+	}
+	log.Warnf("SBOM has %d %s schema violations, data may be lost or misread (use --strict to reject such SBOMs)", len(violations), id)
+	return nil
 }
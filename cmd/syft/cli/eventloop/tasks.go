@@ -1,18 +1,18 @@
 package eventloop
 
 import (
-	"crypto"
-	"fmt"
+	"context"
 
 	"github.com/anchore/syft/internal/config"
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg/cataloger"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
 
-type Task func(*sbom.Artifacts, *source.Source) ([]artifact.Relationship, error)
+type Task func(context.Context, *sbom.Artifacts, *source.Source) ([]artifact.Relationship, error)
 
 func Tasks(app *config.Application) ([]Task, error) {
 	var tasks []Task
@@ -24,6 +24,8 @@ func Tasks(app *config.Application) ([]Task, error) {
 		generateCatalogSecretsTask,
 		generateCatalogFileClassificationsTask,
 		generateCatalogContentsTask,
+		generateCatalogELFTask,
+		generateCatalogExecutablesTask,
 	}
 
 	for _, generator := range generators {
@@ -45,16 +47,20 @@ func generateCatalogPackagesTask(app *config.Application) (Task, error) {
 		return nil, nil
 	}
 
-	task := func(results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
-		packageCatalog, relationships, theDistro, err := syft.CatalogPackages(src, app.ToCatalogerConfig())
-		if err != nil {
+	task := func(ctx context.Context, results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
+		s, err := syft.CreateSBOM(ctx, src, app.ToCatalogerConfig())
+		if err != nil && !cataloger.IsPartialResults(err) {
 			return nil, err
 		}
 
-		results.PackageCatalog = packageCatalog
-		results.LinuxDistribution = theDistro
+		// note: the results of all other catalogers are kept when some fail, recording the failures in the SBOM
+		results.PackageCatalog = s.Artifacts.PackageCatalog
+		results.LinuxDistribution = s.Artifacts.LinuxDistribution
+		results.LayerHistory = s.Artifacts.LayerHistory
+		results.MinimalImage = s.Artifacts.MinimalImage
+		results.Diagnostics = append(results.Diagnostics, s.Artifacts.Diagnostics...)
 
-		return relationships, nil
+		return s.Relationships, nil
 	}
 
 	return task, nil
@@ -67,7 +73,7 @@ func generateCatalogFileMetadataTask(app *config.Application) (Task, error) {
 
 	metadataCataloger := file.NewMetadataCataloger()
 
-	task := func(results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
+	task := func(_ context.Context, results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
 		resolver, err := src.FileResolver(app.FileMetadata.Cataloger.ScopeOpt)
 		if err != nil {
 			return nil, err
@@ -89,31 +95,12 @@ func generateCatalogFileDigestsTask(app *config.Application) (Task, error) {
 		return nil, nil
 	}
 
-	supportedHashAlgorithms := make(map[string]crypto.Hash)
-	for _, h := range []crypto.Hash{
-		crypto.MD5,
-		crypto.SHA1,
-		crypto.SHA256,
-	} {
-		supportedHashAlgorithms[file.DigestAlgorithmName(h)] = h
-	}
-
-	var hashes []crypto.Hash
-	for _, hashStr := range app.FileMetadata.Digests {
-		name := file.CleanDigestAlgorithmName(hashStr)
-		hashObj, ok := supportedHashAlgorithms[name]
-		if !ok {
-			return nil, fmt.Errorf("unsupported hash algorithm: %s", hashStr)
-		}
-		hashes = append(hashes, hashObj)
-	}
-
-	digestsCataloger, err := file.NewDigestsCataloger(hashes)
+	digestsCataloger, err := file.NewDigestsCataloger(app.FileMetadata.DigestsOpt)
 	if err != nil {
 		return nil, err
 	}
 
-	task := func(results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
+	task := func(_ context.Context, results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
 		resolver, err := src.FileResolver(app.FileMetadata.Cataloger.ScopeOpt)
 		if err != nil {
 			return nil, err
@@ -135,17 +122,17 @@ func generateCatalogSecretsTask(app *config.Application) (Task, error) {
 		return nil, nil
 	}
 
-	patterns, err := file.GenerateSearchPatterns(file.DefaultSecretsPatterns, app.Secrets.AdditionalPatterns, app.Secrets.ExcludePatternNames)
+	cfg, err := app.Secrets.ToCatalogerConfig()
 	if err != nil {
 		return nil, err
 	}
 
-	secretsCataloger, err := file.NewSecretsCataloger(patterns, app.Secrets.RevealValues, app.Secrets.SkipFilesAboveSize)
+	secretsCataloger, err := file.NewSecretsCatalogerFromConfig(cfg)
 	if err != nil {
 		return nil, err
 	}
 
-	task := func(results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
+	task := func(_ context.Context, results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
 		resolver, err := src.FileResolver(app.Secrets.Cataloger.ScopeOpt)
 		if err != nil {
 			return nil, err
@@ -173,7 +160,7 @@ func generateCatalogFileClassificationsTask(app *config.Application) (Task, erro
 		return nil, err
 	}
 
-	task := func(results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
+	task := func(_ context.Context, results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
 		resolver, err := src.FileResolver(app.FileClassification.Cataloger.ScopeOpt)
 		if err != nil {
 			return nil, err
@@ -195,12 +182,12 @@ func generateCatalogContentsTask(app *config.Application) (Task, error) {
 		return nil, nil
 	}
 
-	contentsCataloger, err := file.NewContentsCataloger(app.FileContents.Globs, app.FileContents.SkipFilesAboveSize)
+	contentsCataloger, err := file.NewContentsCataloger(app.FileContents.Globs, app.FileContents.SkipFilesAboveSize, app.FileContents.EncodingOpt)
 	if err != nil {
 		return nil, err
 	}
 
-	task := func(results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
+	task := func(_ context.Context, results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
 		resolver, err := src.FileResolver(app.FileContents.Cataloger.ScopeOpt)
 		if err != nil {
 			return nil, err
@@ -211,16 +198,65 @@ func generateCatalogContentsTask(app *config.Application) (Task, error) {
 			return nil, err
 		}
 		results.FileContents = result
+		results.FileContentsEncoding = contentsCataloger.Encoding()
+		return nil, nil
+	}
+
+	return task, nil
+}
+
+func generateCatalogELFTask(app *config.Application) (Task, error) {
+	if !app.FileELF.Cataloger.Enabled {
+		return nil, nil
+	}
+
+	elfCataloger := file.NewELFCataloger()
+
+	task := func(_ context.Context, results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
+		resolver, err := src.FileResolver(app.FileELF.Cataloger.ScopeOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		result, relationships, err := elfCataloger.Catalog(resolver)
+		if err != nil {
+			return nil, err
+		}
+		results.FileELFMetadata = result
+		return relationships, nil
+	}
+
+	return task, nil
+}
+
+func generateCatalogExecutablesTask(app *config.Application) (Task, error) {
+	if !app.FileExecutable.Cataloger.Enabled {
+		return nil, nil
+	}
+
+	executableCataloger := file.NewExecutableCataloger()
+
+	task := func(_ context.Context, results *sbom.Artifacts, src *source.Source) ([]artifact.Relationship, error) {
+		resolver, err := src.FileResolver(app.FileExecutable.Cataloger.ScopeOpt)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err := executableCataloger.Catalog(resolver)
+		if err != nil {
+			return nil, err
+		}
+		results.Executables = result
 		return nil, nil
 	}
 
 	return task, nil
 }
 
-func RunTask(t Task, a *sbom.Artifacts, src *source.Source, c chan<- artifact.Relationship, errs chan<- error) {
+func RunTask(ctx context.Context, t Task, a *sbom.Artifacts, src *source.Source, c chan<- artifact.Relationship, errs chan<- error) {
 	defer close(c)
 
-	relationships, err := t(a, src)
+	relationships, err := t(ctx, a, src)
 	if err != nil {
 		errs <- err
 		return
@@ -0,0 +1,16 @@
+package cli
+
+// ExitError is returned by commands that exit with a specific exit code, such as to tell a failed check apart from a
+// failure to run the check.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
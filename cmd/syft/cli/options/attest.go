@@ -16,6 +16,9 @@ type AttestOptions struct {
 	Force     bool
 	Recursive bool
 
+	PredicateType string
+	Referrer      bool
+
 	Rekor  RekorOptions
 	Fulcio FulcioOptions
 	OIDC   OIDCOptions
@@ -49,6 +52,12 @@ func (o *AttestOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
 	cmd.Flags().BoolVarP(&o.Recursive, "recursive", "", false,
 		"if a multi-arch image is specified, additionally sign each discrete image")
 
+	cmd.Flags().StringVarP(&o.PredicateType, "predicate-type", "", "",
+		"the predicate type of the attestation: spdx, cyclonedx, custom or a URI (derived from the output format when not given)")
+
+	cmd.Flags().BoolVarP(&o.Referrer, "referrer", "", false,
+		"upload the attestation as an OCI referrer of the image (instead of as the image's attestation tag)")
+
 	return bindAttestationConfigOptions(cmd.Flags(), v)
 }
 
@@ -73,5 +82,13 @@ func bindAttestationConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
 		return err
 	}
 
+	if err := v.BindPFlag("attest.predicate_type", flags.Lookup("predicate-type")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("attest.referrer", flags.Lookup("referrer")); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,24 @@
+package options
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+type ConvertOptions struct {
+	Strict bool
+}
+
+var _ Interface = (*ConvertOptions)(nil)
+
+func (o *ConvertOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
+	cmd.Flags().BoolVarP(&o.Strict, "strict", "", false,
+		"reject an input SBOM that does not conform to the JSON schema of its format (otherwise the schema violations are only logged)")
+
+	return bindConvertConfigOptions(cmd.Flags(), v)
+}
+
+func bindConvertConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
+	return v.BindPFlag("convert.strict", flags.Lookup("strict"))
+}
@@ -24,6 +24,10 @@ func FormatAliases(ids ...sbom.FormatID) (aliases []string) {
 			aliases = append(aliases, "cyclonedx-json")
 		case syft.GitHubID:
 			aliases = append(aliases, "github", "github-json")
+		case syft.HTMLFormatID:
+			aliases = append(aliases, "html")
+		case syft.MarkdownFormatID:
+			aliases = append(aliases, "markdown")
 		default:
 			aliases = append(aliases, string(id))
 		}
@@ -2,13 +2,16 @@ package options
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/formats/table"
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger"
 	"github.com/anchore/syft/syft/source"
 )
@@ -17,10 +20,29 @@ type PackagesOptions struct {
 	Scope              string
 	Output             []string
 	OutputTemplatePath string
+	OutputTemplateDir  string
+	Baseline           string
 	File               string
+	Archive            string
 	Platform           string
+	AllPlatforms       bool
+	BaseImage          string
+	ExcludeBaseImage   bool
+	Provenance         bool
 	Exclude            []string
+	IncludePackages    []string
+	ExcludePackages    []string
+	MinConfidence      string
 	Catalogers         []string
+	Select             []string
+	Digests            []string
+	Parallelism        int
+	Incremental        bool
+	Timeout            time.Duration
+	MetricsFile        string
+	SummaryFile        string
+	UseExistingSBOM    bool
+	FailOn             []string
 }
 
 var _ Interface = (*PackagesOptions)(nil)
@@ -35,21 +57,85 @@ func (o *PackagesOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
 	cmd.Flags().StringVarP(&o.File, "file", "", "",
 		"file to write the default report output to (default is STDOUT)")
 
+	cmd.Flags().StringVarP(&o.Archive, "archive", "", "",
+		"bundle the outputs written to files into a single archive (.zip, .tar, .tar.gz, or .tar.zst)")
+
 	cmd.Flags().StringVarP(&o.OutputTemplatePath, "template", "t", "",
 		"specify the path to a Go template file")
 
+	cmd.Flags().StringVarP(&o.OutputTemplateDir, "template-dir", "", "",
+		"specify the path to a directory of Go templates that the template file can include")
+
+	cmd.Flags().StringVarP(&o.Baseline, "baseline", "", "",
+		"a previous SBOM of the same source (in any format syft can decode), used by the markdown output to list the packages that are new since then")
+
 	cmd.Flags().StringVarP(&o.Platform, "platform", "", "",
 		"an optional platform specifier for container image sources (e.g. 'linux/arm64', 'linux/arm64/v8', 'arm64', 'linux')")
 
+	cmd.Flags().BoolVarP(&o.AllPlatforms, "all-platforms", "", false,
+		"catalog every platform of a multi-platform image within a registry, producing an SBOM for each platform")
+
+	cmd.Flags().StringVarP(&o.BaseImage, "base-image", "", "",
+		"the base image (within a registry) the image was built from, used to mark packages from the base image (defaults to the base image declared by the image)")
+
+	cmd.Flags().BoolVarP(&o.ExcludeBaseImage, "exclude-base-image", "", false,
+		"exclude packages from the base image the image was built from, producing an SBOM of the application only")
+
+	cmd.Flags().BoolVarP(&o.Provenance, "provenance", "", false,
+		"record how an image within a registry was built (builder and source repository) from the SLSA provenance attached to the image")
+
 	cmd.Flags().StringArrayVarP(&o.Exclude, "exclude", "", nil,
 		"exclude paths from being scanned using a glob expression")
 
+	cmd.Flags().StringArrayVarP(&o.IncludePackages, "include-package", "", nil,
+		"only keep the cataloged packages matching an expression of the name (glob), 'type:', 'purl:', 'license:', or 'path:' (e.g. 'type:npm')")
+
+	cmd.Flags().StringArrayVarP(&o.ExcludePackages, "exclude-package", "", nil,
+		"remove the cataloged packages matching an expression of the name (glob), 'type:', 'purl:', 'license:', or 'path:' (e.g. 'path:**/test/**')")
+
+	cmd.Flags().StringVarP(&o.MinConfidence, "min-confidence", "", "",
+		fmt.Sprintf("remove the cataloged packages identified with less confidence, options=%v (e.g. 'authoritative' drops packages guessed from file contents)", pkg.AllConfidences))
+
 	cmd.Flags().StringArrayVarP(&o.Catalogers, "catalogers", "", nil,
-		"enable one or more package catalogers")
+		"enable one or more package catalogers (deprecated: use --select)")
+
+	cmd.Flags().StringArrayVarP(&o.Select, "select", "", nil,
+		"select the package catalogers to use by group or name, adding (+) to or removing (-) from the default selection (e.g. 'image,+go-mod-file,-binary')")
+
+	cmd.Flags().StringArrayVarP(&o.Digests, "file-metadata-digests", "", nil,
+		fmt.Sprintf("the digest algorithms to calculate for files and java archives, options=%v", file.SupportedDigestAlgorithms()))
+
+	cmd.Flags().IntVarP(&o.Parallelism, "parallelism", "", 1,
+		"the number of package catalogers to run concurrently")
+
+	cmd.Flags().BoolVarP(&o.Incremental, "incremental", "", false,
+		"only catalog the files of a directory that changed since the previous scan of the same directory")
+
+	cmd.Flags().DurationVarP(&o.Timeout, "timeout", "", 0,
+		"stop cataloging after the given duration (e.g. '10m'), reporting the packages found so far (no timeout when 0)")
+
+	cmd.Flags().StringVarP(&o.MetricsFile, "metrics-file", "", "",
+		"file to write the measurements of each cataloger to as JSON (e.g. duration, files and bytes read, packages found)")
+
+	cmd.Flags().StringVarP(&o.SummaryFile, "summary-file", "", "",
+		"file to write the outcome of the scan to as JSON (status, exit code, package counts, warnings, and policy violations)")
+
+	cmd.Flags().BoolVarP(&o.UseExistingSBOM, "use-existing-sbom", "", false,
+		"use the SBOM attached to an image within a registry as an OCI referrer (when there is one) instead of cataloging the image")
+
+	cmd.Flags().StringArrayVarP(&o.FailOn, "fail-on", "", nil,
+		"fail the scan (with exit code 2) when any package matches a condition: 'no-version', 'no-license', or a package filter expression (e.g. 'license:GPL-3.0*')")
 
 	return bindPackageConfigOptions(cmd.Flags(), v)
 }
 
+// BindFlags binds the flags of the command being run to the application config. Since these flags are added to several
+// commands (and viper only reads the flag bound last, unless it was given), this must be called before the config is
+// loaded, otherwise flags given to any other command lose to the config defaults.
+func (o *PackagesOptions) BindFlags(flags *pflag.FlagSet, v *viper.Viper) error {
+	return bindPackageConfigOptions(flags, v)
+}
+
 func bindPackageConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
 	// Formatting & Input options //////////////////////////////////////////////
 
@@ -65,10 +151,30 @@ func bindPackageConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
 		return err
 	}
 
+	if err := v.BindPFlag("package.include", flags.Lookup("include-package")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("package.exclude", flags.Lookup("exclude-package")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("package.min-confidence", flags.Lookup("min-confidence")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("catalogers", flags.Lookup("catalogers")); err != nil {
 		return err
 	}
 
+	if err := v.BindPFlag("select", flags.Lookup("select")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("file-metadata.digests", flags.Lookup("file-metadata-digests")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("output", flags.Lookup("output")); err != nil {
 		return err
 	}
@@ -77,9 +183,65 @@ func bindPackageConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
 		return err
 	}
 
+	if err := v.BindPFlag("output-template-dir", flags.Lookup("template-dir")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("baseline", flags.Lookup("baseline")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("archive", flags.Lookup("archive")); err != nil {
+		return err
+	}
+
 	if err := v.BindPFlag("platform", flags.Lookup("platform")); err != nil {
 		return err
 	}
 
+	if err := v.BindPFlag("all-platforms", flags.Lookup("all-platforms")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("base-image", flags.Lookup("base-image")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("exclude-base-image", flags.Lookup("exclude-base-image")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("provenance", flags.Lookup("provenance")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("parallelism", flags.Lookup("parallelism")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("incremental", flags.Lookup("incremental")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("limits.timeout", flags.Lookup("timeout")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("metrics-file", flags.Lookup("metrics-file")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("summary-file", flags.Lookup("summary-file")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("use-existing-sbom", flags.Lookup("use-existing-sbom")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("policy.fail-on", flags.Lookup("fail-on")); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -7,9 +7,10 @@ import (
 )
 
 type RootOptions struct {
-	Config  string
-	Quiet   bool
-	Verbose int
+	Config   string
+	Quiet    bool
+	Verbose  int
+	Progress string
 }
 
 var _ Interface = (*RootOptions)(nil)
@@ -18,6 +19,8 @@ func (o *RootOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
 	cmd.PersistentFlags().StringVarP(&o.Config, "config", "c", "", "application config file")
 	cmd.PersistentFlags().CountVarP(&o.Verbose, "verbose", "v", "increase verbosity (-v = info, -vv = debug)")
 	cmd.PersistentFlags().BoolVarP(&o.Quiet, "quiet", "q", false, "suppress all logging output")
+	cmd.PersistentFlags().StringVarP(&o.Progress, "progress", "", "auto",
+		"how to show progress: 'auto' (a terminal UI when possible) or 'json' (progress events as JSON lines on stderr)")
 
 	return bindRootConfigOptions(cmd.PersistentFlags(), v)
 }
@@ -32,5 +35,8 @@ func bindRootConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
 	if err := v.BindPFlag("quiet", flags.Lookup("quiet")); err != nil {
 		return err
 	}
+	if err := v.BindPFlag("progress", flags.Lookup("progress")); err != nil {
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,36 @@
+package options
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+type ServeOptions struct {
+	Address            string
+	MaxConcurrentScans int
+}
+
+var _ Interface = (*ServeOptions)(nil)
+
+func (o *ServeOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
+	cmd.Flags().StringVarP(&o.Address, "address", "", "localhost:8080",
+		"the address to listen on for scan requests")
+
+	cmd.Flags().IntVarP(&o.MaxConcurrentScans, "max-concurrent-scans", "", 4,
+		"the number of scans to run at once, further requests wait for a running scan to finish")
+
+	return bindServeConfigOptions(cmd.Flags(), v)
+}
+
+func bindServeConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
+	if err := v.BindPFlag("serve.address", flags.Lookup("address")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("serve.max-concurrent-scans", flags.Lookup("max-concurrent-scans")); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package options
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/syft/compliance"
+)
+
+type ValidateOptions struct {
+	Profile string
+	Output  string
+}
+
+var _ Interface = (*ValidateOptions)(nil)
+
+func (o *ValidateOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
+	cmd.Flags().StringVarP(&o.Profile, "profile", "", compliance.NTIA,
+		fmt.Sprintf("the profile of required SBOM fields to check against (%s)", strings.Join(compliance.ProfileNames(), ", ")))
+
+	cmd.Flags().StringVarP(&o.Output, "output", "o", "text",
+		"the format of the report of missing fields (text, json)")
+
+	return bindValidateConfigOptions(cmd.Flags(), v)
+}
+
+func bindValidateConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
+	if err := v.BindPFlag("validate.profile", flags.Lookup("profile")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("validate.output", flags.Lookup("output")); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package options
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+type VerifyOptions struct {
+	Key            string
+	CertIdentity   string
+	CertOIDCIssuer string
+	RekorURL       string
+	PredicateType  string
+}
+
+var _ Interface = (*VerifyOptions)(nil)
+
+func (o *VerifyOptions) AddFlags(cmd *cobra.Command, v *viper.Viper) error {
+	cmd.Flags().StringVarP(&o.Key, "key", "", "",
+		"path to the public key file (or KMS URI) the attestations must be signed with")
+
+	cmd.Flags().StringVarP(&o.CertIdentity, "certificate-identity", "", "",
+		"the identity (e.g. an email address) keyless attestations must be signed by")
+
+	cmd.Flags().StringVarP(&o.CertOIDCIssuer, "certificate-oidc-issuer", "", "",
+		"the OIDC issuer of the identity keyless attestations must be signed by (e.g. https://accounts.google.com)")
+
+	cmd.Flags().StringVarP(&o.RekorURL, "rekor-url", "", DefaultRekorURL,
+		"address of rekor STL server")
+
+	cmd.Flags().StringVarP(&o.PredicateType, "predicate-type", "", "",
+		"the predicate type of the attestation to verify: spdx, cyclonedx, custom or a URI (any SBOM attestation when not given)")
+
+	return bindVerifyConfigOptions(cmd.Flags(), v)
+}
+
+func bindVerifyConfigOptions(flags *pflag.FlagSet, v *viper.Viper) error {
+	if err := v.BindPFlag("verify.key", flags.Lookup("key")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("verify.certificate_identity", flags.Lookup("certificate-identity")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("verify.certificate_oidc_issuer", flags.Lookup("certificate-oidc-issuer")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("verify.rekor_url", flags.Lookup("rekor-url")); err != nil {
+		return err
+	}
+
+	if err := v.BindPFlag("verify.predicate_type", flags.Lookup("predicate-type")); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -2,24 +2,32 @@ package options
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
 
 	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/formats/markdown"
 	"github.com/anchore/syft/syft/formats/table"
 	"github.com/anchore/syft/syft/formats/template"
 	"github.com/anchore/syft/syft/sbom"
 )
 
 // makeWriter creates a sbom.Writer for output or returns an error. this will either return a valid writer
-// or an error but neither both and if there is no error, sbom.Writer.Close() should be called
-func MakeWriter(outputs []string, defaultFile, templateFilePath string) (sbom.Writer, error) {
-	outputOptions, err := parseOutputs(outputs, defaultFile, templateFilePath)
+// or an error but neither both and if there is no error, sbom.Writer.Close() should be called. When an archive file
+// is given, all outputs to files are bundled into the archive instead.
+func MakeWriter(outputs []string, defaultFile, templateFilePath, templateDir, baselineFile, archiveFile string) (sbom.Writer, error) {
+	outputOptions, err := parseOutputs(outputs, defaultFile, templateFilePath, templateDir, baselineFile)
 	if err != nil {
 		return nil, err
 	}
 
+	if archiveFile != "" {
+		return sbom.NewArchiveWriter(archiveFile, outputOptions...)
+	}
+
 	writer, err := sbom.NewWriter(outputOptions...)
 	if err != nil {
 		return nil, err
@@ -28,28 +36,99 @@ func MakeWriter(outputs []string, defaultFile, templateFilePath string) (sbom.Wr
 	return writer, nil
 }
 
-// parseOptions utility to parse command-line option strings and retain the existing behavior of default format and file
-func parseOutputs(outputs []string, defaultFile, templateFilePath string) (out []sbom.WriterOption, errs error) {
-	// always should have one option -- we generally get the default of "table", but just make sure
+// MakePlatformWriter creates a sbom.Writer for the SBOM of a single platform of a multi-platform image. The platform is
+// added to the name of each output file (e.g. "sbom.json" becomes "sbom.linux-arm64-v8.json"), while output to STDOUT
+// is left as is.
+func MakePlatformWriter(outputs []string, defaultFile, templateFilePath, templateDir, baselineFile, archiveFile, platform string) (sbom.Writer, error) {
+	outputs, defaultFile = platformOutputs(outputs, defaultFile, platform)
+	return MakeWriter(outputs, defaultFile, templateFilePath, templateDir, baselineFile, platformArchive(archiveFile, platform))
+}
+
+func platformOutputs(outputs []string, defaultFile, platform string) ([]string, string) {
+	if platform == "" {
+		return outputs, defaultFile
+	}
+
+	var out []string
+	for _, output := range outputs {
+		format, file, hasFile := splitOutput(output)
+		if hasFile {
+			output = format + "=" + platformFile(strings.TrimSpace(file), platform)
+		}
+		out = append(out, output)
+	}
+	return out, platformFile(defaultFile, platform)
+}
+
+// platformArchive adds the platform to the name of the archive, ahead of any compressed tar extension
+// (e.g. "sboms.tar.gz" becomes "sboms.linux-amd64.tar.gz").
+func platformArchive(file, platform string) string {
+	if file == "" || platform == "" {
+		return file
+	}
+	lower := strings.ToLower(file)
+	for _, ext := range []string{".tar.gz", ".tar.zst"} {
+		if strings.HasSuffix(lower, ext) {
+			return platformFile(file[:len(file)-len(ext)], platform) + file[len(file)-len(ext):]
+		}
+	}
+	return platformFile(file, platform)
+}
+
+func platformFile(file, platform string) string {
+	if file == "" {
+		return ""
+	}
+	ext := filepath.Ext(file)
+	return strings.TrimSuffix(file, ext) + "." + strings.ReplaceAll(platform, "/", "-") + ext
+}
+
+// OutputFiles returns the files the given outputs are written to (outputs to STDOUT are left out), which is only the
+// archive when one is given.
+func OutputFiles(outputs []string, defaultFile, archiveFile string) []string {
+	if archiveFile != "" {
+		return []string{archiveFile}
+	}
 	if len(outputs) == 0 {
 		outputs = append(outputs, string(table.ID))
 	}
 
-	for _, name := range outputs {
-		name = strings.TrimSpace(name)
+	var files []string
+	for _, output := range outputs {
+		_, outputFile, hasFile := splitOutput(strings.TrimSpace(output))
+		file := defaultFile
+		if hasFile {
+			file = strings.TrimSpace(outputFile)
+		}
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return files
+}
 
-		// split to at most two parts for <format>=<file>
-		parts := strings.SplitN(name, "=", 2)
+// parseOptions utility to parse command-line option strings and retain the existing behavior of default format and file
+func parseOutputs(outputs []string, defaultFile, templateFilePath, templateDir, baselineFile string) (out []sbom.WriterOption, errs error) {
+	// always should have one option -- we generally get the default of "table", but just make sure
+	if len(outputs) == 0 {
+		outputs = append(outputs, string(table.ID))
+	}
 
-		// the format name is the first part
-		name = parts[0]
+	for _, output := range outputs {
+		spec, outputFile, hasFile := splitOutput(strings.TrimSpace(output))
 
 		// default to the --file or empty string if not specified
 		file := defaultFile
 
 		// If a file is specified as part of the output formatName, use that
-		if len(parts) > 1 {
-			file = parts[1]
+		if hasFile {
+			file = outputFile
+		}
+
+		name, formatOptions, err := parseFormatOptions(spec)
+		if err != nil {
+			errs = multierror.Append(errs, err)
+			continue
 		}
 
 		format := syft.FormatByName(name)
@@ -60,10 +139,104 @@ func parseOutputs(outputs []string, defaultFile, templateFilePath string) (out [
 
 		if tmpl, ok := format.(template.OutputFormat); ok {
 			tmpl.SetTemplatePath(templateFilePath)
+			tmpl.SetIncludesDir(templateDir)
+			// files written by the template go next to its output file (or to the working directory for STDOUT)
+			tmpl.SetOutputDir(filepath.Dir(file))
 			format = tmpl
 		}
 
+		if md, ok := format.(markdown.OutputFormat); ok && baselineFile != "" {
+			baseline, err := readBaseline(baselineFile)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			md.SetBaseline(baseline)
+			format = md
+		}
+
+		// options given with the output itself take precedence over the global ones
+		if formatOptions != nil {
+			configurable, ok := format.(sbom.ConfigurableFormat)
+			if !ok {
+				errs = multierror.Append(errs, fmt.Errorf("output format %q does not support options", name))
+				continue
+			}
+			format, err = configurable.WithOptions(formatOptions)
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("invalid options for output format %q: %w", name, err))
+				continue
+			}
+		}
+
 		out = append(out, sbom.NewWriterOption(format, file))
 	}
 	return out, errs
 }
+
+// splitOutput splits an output given as <format>[<options>]=<file> into the format (with any options) and the file.
+// An "=" within the options does not end the format.
+func splitOutput(output string) (format string, file string, hasFile bool) {
+	inOptions := false
+	for i, c := range output {
+		switch c {
+		case '[':
+			inOptions = true
+		case ']':
+			inOptions = false
+		case '=':
+			if !inOptions {
+				return output[:i], output[i+1:], true
+			}
+		}
+	}
+	return output, "", false
+}
+
+// parseFormatOptions splits a format given as <name>[<key>=<value>,...] into the format name and its options (nil
+// when none are given).
+func parseFormatOptions(spec string) (string, map[string]string, error) {
+	start := strings.Index(spec, "[")
+	if start < 0 {
+		return strings.TrimSpace(spec), nil, nil
+	}
+	name := strings.TrimSpace(spec[:start])
+	if !strings.HasSuffix(spec, "]") {
+		return name, nil, fmt.Errorf("invalid options for output format %q: expected %s[key=value,...]", name, name)
+	}
+
+	options := make(map[string]string)
+	for _, option := range strings.Split(spec[start+1:len(spec)-1], ",") {
+		if strings.TrimSpace(option) == "" {
+			continue
+		}
+		parts := strings.SplitN(option, "=", 2)
+		key := strings.TrimSpace(parts[0])
+		if len(parts) != 2 || key == "" {
+			return name, nil, fmt.Errorf("invalid option for output format %q: %q (expected key=value)", name, option)
+		}
+		if _, ok := options[key]; ok {
+			return name, nil, fmt.Errorf("option %q given more than once for output format %q", key, name)
+		}
+		options[key] = strings.TrimSpace(parts[1])
+	}
+	if len(options) == 0 {
+		return name, nil, nil
+	}
+	return name, options, nil
+}
+
+// readBaseline decodes the given SBOM file, which the new SBOM is compared against.
+func readBaseline(baselineFile string) (*sbom.SBOM, error) {
+	f, err := os.Open(baselineFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open baseline SBOM: %w", err)
+	}
+	defer f.Close()
+
+	s, _, err := syft.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode baseline SBOM %s: %w", baselineFile, err)
+	}
+	return s, nil
+}
@@ -25,10 +25,219 @@ func TestIsSupportedFormat(t *testing.T) {
 				return assert.ErrorContains(t, err, `unsupported output format "unknown", supported formats are: [`)
 			},
 		},
+		{
+			outputs: []string{"spdx-json[namespace=https://example.com/sboms]", "spdx-tag-value[namespace=https://example.com/other]"},
+			wantErr: assert.NoError,
+		},
+		{
+			outputs: []string{"spdx-json[namespace=example.com]"},
+			wantErr: func(t assert.TestingT, err error, bla ...interface{}) bool {
+				return assert.ErrorContains(t, err, `invalid options for output format "spdx-json"`)
+			},
+		},
+		{
+			outputs: []string{"table[columns=name]"},
+			wantErr: func(t assert.TestingT, err error, bla ...interface{}) bool {
+				return assert.ErrorContains(t, err, `output format "table" does not support options`)
+			},
+		},
 	}
 
 	for _, tt := range tests {
-		_, err := MakeWriter(tt.outputs, "", "")
+		_, err := MakeWriter(tt.outputs, "", "", "", "", "")
 		tt.wantErr(t, err)
 	}
 }
+
+func TestPlatformOutputs(t *testing.T) {
+	tests := []struct {
+		name                string
+		outputs             []string
+		defaultFile         string
+		platform            string
+		expectedOutputs     []string
+		expectedDefaultFile string
+	}{
+		{
+			name:                "no platform",
+			outputs:             []string{"json=sbom.json"},
+			defaultFile:         "sbom.txt",
+			expectedOutputs:     []string{"json=sbom.json"},
+			expectedDefaultFile: "sbom.txt",
+		},
+		{
+			name:                "stdout",
+			outputs:             []string{"table"},
+			platform:            "linux/amd64",
+			expectedOutputs:     []string{"table"},
+			expectedDefaultFile: "",
+		},
+		{
+			name:                "files",
+			outputs:             []string{"table", "spdx-json=out/sbom.spdx.json", "cyclonedx-xml=sbom"},
+			defaultFile:         "sbom.txt",
+			platform:            "linux/arm64/v8",
+			expectedOutputs:     []string{"table", "spdx-json=out/sbom.spdx.linux-arm64-v8.json", "cyclonedx-xml=sbom.linux-arm64-v8"},
+			expectedDefaultFile: "sbom.linux-arm64-v8.txt",
+		},
+		{
+			name:                "format options",
+			outputs:             []string{"spdx-json[namespace=https://example.com]=sbom.json", "spdx-json[namespace=https://example.com]"},
+			platform:            "linux/amd64",
+			expectedOutputs:     []string{"spdx-json[namespace=https://example.com]=sbom.linux-amd64.json", "spdx-json[namespace=https://example.com]"},
+			expectedDefaultFile: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputs, defaultFile := platformOutputs(test.outputs, test.defaultFile, test.platform)
+			assert.Equal(t, test.expectedOutputs, outputs)
+			assert.Equal(t, test.expectedDefaultFile, defaultFile)
+		})
+	}
+}
+
+func TestPlatformArchive(t *testing.T) {
+	tests := []struct {
+		file     string
+		platform string
+		expected string
+	}{
+		{file: "", platform: "linux/amd64", expected: ""},
+		{file: "sboms.zip", platform: "", expected: "sboms.zip"},
+		{file: "sboms.zip", platform: "linux/amd64", expected: "sboms.linux-amd64.zip"},
+		{file: "out/sboms.tar", platform: "linux/arm64/v8", expected: "out/sboms.linux-arm64-v8.tar"},
+		{file: "sboms.tar.gz", platform: "linux/amd64", expected: "sboms.linux-amd64.tar.gz"},
+		{file: "sboms.TAR.ZST", platform: "linux/amd64", expected: "sboms.linux-amd64.TAR.ZST"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			assert.Equal(t, test.expected, platformArchive(test.file, test.platform))
+		})
+	}
+}
+
+func TestOutputFiles(t *testing.T) {
+	tests := []struct {
+		name        string
+		outputs     []string
+		defaultFile string
+		archiveFile string
+		expected    []string
+	}{
+		{
+			name:    "stdout",
+			outputs: []string{"table"},
+		},
+		{
+			name:        "default file",
+			defaultFile: "sbom.txt",
+			expected:    []string{"sbom.txt"},
+		},
+		{
+			name:        "files",
+			outputs:     []string{"json=sbom.json", "spdx-json", "table"},
+			defaultFile: "sbom.txt",
+			expected:    []string{"sbom.json", "sbom.txt", "sbom.txt"},
+		},
+		{
+			name:     "file per output",
+			outputs:  []string{"json=sbom.json", "spdx-json=sbom.spdx.json", "table"},
+			expected: []string{"sbom.json", "sbom.spdx.json"},
+		},
+		{
+			name:        "format options",
+			outputs:     []string{"spdx-json[namespace=https://example.com]=sbom.spdx.json", "spdx-json[namespace=https://example.com]"},
+			defaultFile: "sbom.txt",
+			expected:    []string{"sbom.spdx.json", "sbom.txt"},
+		},
+		{
+			name:        "archive",
+			outputs:     []string{"json=sbom.json", "spdx-json=sbom.spdx.json", "table"},
+			archiveFile: "sboms.tar.gz",
+			expected:    []string{"sboms.tar.gz"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, OutputFiles(tt.outputs, tt.defaultFile, tt.archiveFile))
+		})
+	}
+}
+
+func TestParseFormatOptions(t *testing.T) {
+	tests := []struct {
+		name            string
+		output          string
+		expectedFormat  string
+		expectedOptions map[string]string
+		expectedFile    string
+		wantErr         assert.ErrorAssertionFunc
+	}{
+		{
+			name:           "format only",
+			output:         "spdx-json",
+			expectedFormat: "spdx-json",
+		},
+		{
+			name:           "format and file",
+			output:         "spdx-json=sbom.json",
+			expectedFormat: "spdx-json",
+			expectedFile:   "sbom.json",
+		},
+		{
+			name:            "options",
+			output:          "spdx-json[namespace=https://example.com/sboms?a=b, other = value]",
+			expectedFormat:  "spdx-json",
+			expectedOptions: map[string]string{"namespace": "https://example.com/sboms?a=b", "other": "value"},
+		},
+		{
+			name:            "options and file",
+			output:          "template[template=report.tmpl,dir=includes]=out/report=1.txt",
+			expectedFormat:  "template",
+			expectedOptions: map[string]string{"template": "report.tmpl", "dir": "includes"},
+			expectedFile:    "out/report=1.txt",
+		},
+		{
+			name:           "empty options",
+			output:         "spdx-json[]",
+			expectedFormat: "spdx-json",
+		},
+		{
+			name:           "unterminated options",
+			output:         "spdx-json[namespace=https://example.com",
+			expectedFormat: "spdx-json",
+			wantErr:        assert.Error,
+		},
+		{
+			name:           "option without value",
+			output:         "spdx-json[namespace]",
+			expectedFormat: "spdx-json",
+			wantErr:        assert.Error,
+		},
+		{
+			name:           "repeated option",
+			output:         "spdx-json[namespace=a,namespace=b]",
+			expectedFormat: "spdx-json",
+			wantErr:        assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = assert.NoError
+			}
+			spec, file, _ := splitOutput(tt.output)
+			assert.Equal(t, tt.expectedFile, file)
+
+			format, options, err := parseFormatOptions(spec)
+			tt.wantErr(t, err)
+			assert.Equal(t, tt.expectedFormat, format)
+			assert.Equal(t, tt.expectedOptions, options)
+		})
+	}
+}
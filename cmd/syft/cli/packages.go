@@ -22,6 +22,12 @@ const (
   {{.appName}} {{.command}} alpine:latest -o spdx-json                   show a SPDX 2.2 JSON formatted SBOM
   {{.appName}} {{.command}} alpine:latest -vv                            show verbose debug information
   {{.appName}} {{.command}} alpine:latest -o template -t my_format.tmpl  show a SBOM formatted according to given template file
+  {{.appName}} {{.command}} alpine:latest --all-platforms                show a SBOM for each platform of a multi-platform image
+  {{.appName}} {{.command}} myapp:latest --exclude-base-image            show a SBOM without the packages from the base image
+  {{.appName}} {{.command}} alpine:latest --fail-on 'license:GPL-3.0*'   exit with 2 when any package has a GPL-3.0 license
+
+  Exits with 0 when the SBOM is written, 1 when the scan fails, 2 when packages match a --fail-on condition, and 3 when
+  some catalogers failed or timed out (the SBOM is written in both cases).
 
   Supports the following image sources:
     {{.appName}} {{.command}} yourrepo/yourimage:tag     defaults to using images from a Docker daemon. If Docker is not present, the image is pulled directly from the registry.
@@ -31,6 +37,7 @@ const (
 	schemeHelpHeader = "You can also explicitly specify the scheme to use:"
 	imageSchemeHelp  = `    {{.appName}} {{.command}} docker:yourrepo/yourimage:tag            explicitly use the Docker daemon
     {{.appName}} {{.command}} podman:yourrepo/yourimage:tag            explicitly use the Podman daemon
+    {{.appName}} {{.command}} containerd:yourrepo/yourimage:tag        explicitly use the containerd image store
     {{.appName}} {{.command}} registry:yourrepo/yourimage:tag          pull image directly from a registry (no container runtime required)
     {{.appName}} {{.command}} docker-archive:path/to/yourimage.tar     use a tarball from disk for archives created from "docker save"
     {{.appName}} {{.command}} oci-archive:path/to/yourimage.tar        use a tarball from disk for OCI archives (from Skopeo or otherwise)
@@ -39,6 +46,11 @@ const (
 `
 	nonImageSchemeHelp = `    {{.appName}} {{.command}} dir:path/to/yourproject                  read directly from a path on disk (any directory)
     {{.appName}} {{.command}} file:path/to/yourproject/file            read directly from a path on disk (any single file)
+    {{.appName}} {{.command}} host:                                    read the root filesystem of the running host (excluding /proc, /sys and /dev)
+    {{.appName}} {{.command}} host:<pid>                               read the root filesystem of a running process, such as a container (via /proc/<pid>/root)
+    {{.appName}} {{.command}} ssh://user@host/path                     read a directory on a remote host over SFTP (nothing is installed on the remote host)
+    {{.appName}} {{.command}} git+https://github.com/org/repo@ref      read a shallow clone of a git repository at a branch, tag, or commit
+    {{.appName}} {{.command}} s3://bucket/path/to/artifact.tar.gz      download an artifact (also gs:// and https://) and read it like a file, unarchiving it if needed
 `
 	packagesSchemeHelp = "\n" + indent + schemeHelpHeader + "\n" + imageSchemeHelp + nonImageSchemeHelp
 
@@ -56,6 +68,9 @@ func Packages(v *viper.Viper, app *config.Application, ro *options.RootOptions,
 			"command": "packages",
 		}),
 		Args: func(cmd *cobra.Command, args []string) error {
+			if err := po.BindFlags(cmd.Flags(), v); err != nil {
+				return err
+			}
 			if err := app.LoadAllValues(v, ro.Config); err != nil {
 				return fmt.Errorf("invalid application config: %v", err)
 			}
@@ -70,7 +85,10 @@ func Packages(v *viper.Viper, app *config.Application, ro *options.RootOptions,
 			if app.CheckForAppUpdate {
 				checkForApplicationUpdate()
 			}
-			return packages.Run(cmd.Context(), app, args)
+			if err := packages.Run(cmd.Context(), app, args); err != nil {
+				return &ExitError{Code: packages.ExitCode(err), Err: err}
+			}
+			return nil
 		},
 	}
 
@@ -0,0 +1,56 @@
+package packages
+
+import (
+	"context"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/referrers"
+	"github.com/anchore/syft/internal/registryclient"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// existingSBOM returns the SBOM attached to the given image within a registry as an OCI referrer. Nil is returned when
+// the input is not an image within a registry, the image has no attached SBOM, or the SBOM cannot be retrieved (in
+// which case the image is cataloged as usual).
+func existingSBOM(ctx context.Context, app *config.Application, userInput string) *sbom.SBOM {
+	si, err := source.ParseInput(userInput, app.Platform, false)
+	if err != nil {
+		return nil
+	}
+	isImage := si.Scheme == source.ImageScheme || si.Scheme == source.UnknownScheme
+	isRegistry := si.ImageSource == image.UnknownSource || si.ImageSource == image.OciRegistrySource
+	if !isImage || !isRegistry || si.Containerd != nil {
+		log.Debugf("not looking for an attached SBOM of source=%q: not an image within a registry", userInput)
+		return nil
+	}
+
+	registryOptions := app.Registry.ToOptions()
+	ref, err := name.ParseReference(si.Location, registryclient.NameOptions(registryOptions)...)
+	if err != nil {
+		log.Debugf("not looking for an attached SBOM of source=%q: %+v", userInput, err)
+		return nil
+	}
+
+	digest, err := referrers.Resolve(ctx, ref, app.Platform, registryOptions)
+	if err != nil {
+		log.Warnf("unable to look for an attached SBOM of image=%q: %+v", userInput, err)
+		return nil
+	}
+	s, err := referrers.FindSBOM(ctx, digest, registryOptions)
+	if err != nil {
+		log.Warnf("unable to retrieve the attached SBOM of image=%q: %+v", userInput, err)
+		return nil
+	}
+	if s == nil {
+		log.Infof("no SBOM attached to image=%q, cataloging the image", userInput)
+		return nil
+	}
+
+	log.Infof("using the SBOM attached to image=%q", digest)
+	return s
+}
@@ -0,0 +1,78 @@
+package packages
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+)
+
+// scanMetrics is the document written to the metrics file, describing where the time (and I/O) of a scan was spent.
+type scanMetrics struct {
+	DurationSeconds float64            `json:"durationSeconds"`
+	Totals          metricsTotals      `json:"totals"`
+	Catalogers      []catalogerMetrics `json:"catalogers"`
+}
+
+type metricsTotals struct {
+	FilesRead int64 `json:"filesRead"`
+	BytesRead int64 `json:"bytesRead"`
+	Packages  int   `json:"packages"`
+}
+
+type catalogerMetrics struct {
+	Name            string  `json:"name"`
+	Runs            int     `json:"runs"`
+	Failures        int     `json:"failures"`
+	DurationSeconds float64 `json:"durationSeconds"`
+	FilesRead       int64   `json:"filesRead"`
+	BytesRead       int64   `json:"bytesRead"`
+	Packages        int     `json:"packages"`
+}
+
+// enableMetrics starts recording the measurements of each cataloger when the user has asked for a metrics file.
+func enableMetrics(app *config.Application) {
+	if app.MetricsFile != "" {
+		app.Metrics = cataloger.NewMetrics()
+	}
+}
+
+// writeMetrics writes the measurements of each cataloger (recorded since the scan started) to the metrics file.
+func writeMetrics(app *config.Application, start time.Time) error {
+	if app.MetricsFile == "" || app.Metrics == nil {
+		return nil
+	}
+
+	doc := scanMetrics{
+		DurationSeconds: time.Since(start).Seconds(),
+		Catalogers:      []catalogerMetrics{},
+	}
+	for _, m := range app.Metrics.Catalogers() {
+		doc.Catalogers = append(doc.Catalogers, catalogerMetrics{
+			Name:            m.Cataloger,
+			Runs:            m.Runs,
+			Failures:        m.Failures,
+			DurationSeconds: m.Duration.Seconds(),
+			FilesRead:       m.FilesRead,
+			BytesRead:       m.BytesRead,
+			Packages:        m.Packages,
+		})
+		doc.Totals.FilesRead += m.FilesRead
+		doc.Totals.BytesRead += m.BytesRead
+		doc.Totals.Packages += m.Packages
+	}
+
+	contents, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode metrics: %w", err)
+	}
+	if err := os.WriteFile(app.MetricsFile, contents, 0600); err != nil {
+		return fmt.Errorf("unable to write metrics file=%q: %w", app.MetricsFile, err)
+	}
+	log.Debugf("wrote metrics to file=%q", app.MetricsFile)
+	return nil
+}
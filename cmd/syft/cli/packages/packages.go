@@ -3,7 +3,9 @@ package packages
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/go-multierror"
 	"github.com/wagoodman/go-partybus"
 
 	"github.com/anchore/stereoscope"
@@ -18,18 +20,38 @@ import (
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/event"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/formats/template"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
 
+// Run catalogs the given source, writing the SBOM to the configured outputs. When the SBOM is written but packages
+// violate the policy or the results are incomplete, an error wrapping ErrPolicyViolation or ErrPartialResults is
+// returned (see ExitCode).
 func Run(ctx context.Context, app *config.Application, args []string) error {
+	start := time.Now()
+	results := &scanResults{}
+	err := run(ctx, app, args, results)
+	if summaryErr := writeSummary(app, start, results, err); summaryErr != nil {
+		log.Warn(summaryErr)
+	}
+	return err
+}
+
+func run(ctx context.Context, app *config.Application, args []string, results *scanResults) error {
 	err := validateOutputOptions(app)
 	if err != nil {
 		return err
 	}
 
-	writer, err := options.MakeWriter(app.Outputs, app.File, app.OutputTemplatePath)
+	enableMetrics(app)
+
+	if app.AllPlatforms {
+		return runAllPlatforms(ctx, app, args[0], results)
+	}
+
+	writer, err := options.MakeWriter(app.Outputs, app.File, app.OutputTemplatePath, app.OutputTemplateDir, app.Baseline, app.Archive)
 	if err != nil {
 		return err
 	}
@@ -42,10 +64,29 @@ func Run(ctx context.Context, app *config.Application, args []string) error {
 
 	// could be an image or a directory, with or without a scheme
 	userInput := args[0]
+	if app.UseExistingSBOM {
+		if s := existingSBOM(ctx, app, userInput); s != nil {
+			if err := writer.Write(*s); err != nil {
+				return err
+			}
+			return results.check(app, *s)
+		}
+	}
+
 	si, err := source.ParseInput(userInput, app.Platform, true)
 	if err != nil {
 		return fmt.Errorf("could not generate source input for packages command: %w", err)
 	}
+	si.LazyLayers = app.Registry.LazyLayers
+	if si.Containerd != nil {
+		si.Containerd.Address = app.Containerd.Address
+		si.Containerd.Namespace = app.Containerd.Namespace
+	}
+	if si.SSH != nil {
+		si.SSH.IdentityFile = app.SSH.IdentityFile
+		si.SSH.KnownHostsFile = app.SSH.KnownHostsFile
+		si.SSH.InsecureIgnoreHostKey = app.SSH.InsecureIgnoreHostKey
+	}
 
 	eventBus := partybus.NewBus()
 	stereoscope.SetBus(eventBus)
@@ -53,20 +94,21 @@ func Run(ctx context.Context, app *config.Application, args []string) error {
 	subscription := eventBus.Subscribe()
 
 	return eventloop.EventLoop(
-		execWorker(app, *si, writer),
+		execWorker(ctx, app, *si, writer, results),
 		eventloop.SetupSignals(),
 		subscription,
 		stereoscope.Cleanup,
-		ui.Select(options.IsVerbose(app), app.Quiet)...,
+		ui.Select(options.IsVerbose(app), app.Quiet, app.Progress)...,
 	)
 }
 
-func execWorker(app *config.Application, si source.Input, writer sbom.Writer) <-chan error {
+func execWorker(ctx context.Context, app *config.Application, si source.Input, writer sbom.Writer, results *scanResults) <-chan error {
 	errs := make(chan error)
 	go func() {
 		defer close(errs)
 
-		src, cleanup, err := source.New(si, app.Registry.ToOptions(), app.Exclusions)
+		start := time.Now()
+		src, cleanup, err := NewSource(ctx, app, si)
 		if cleanup != nil {
 			defer cleanup()
 		}
@@ -75,7 +117,13 @@ func execWorker(app *config.Application, si source.Input, writer sbom.Writer) <-
 			return
 		}
 
-		s, err := GenerateSBOM(src, errs, app)
+		if err := detectBaseImage(ctx, app, src); err != nil {
+			errs <- err
+			return
+		}
+		detectProvenance(ctx, app, src)
+
+		s, err := GenerateSBOM(ctx, src, errs, app)
 		if err != nil {
 			errs <- err
 			return
@@ -85,15 +133,40 @@ func execWorker(app *config.Application, si source.Input, writer sbom.Writer) <-
 			errs <- fmt.Errorf("no SBOM produced for %q", si.UserInput)
 		}
 
+		if err := writeMetrics(app, start); err != nil {
+			log.Warn(err)
+		}
+
 		bus.Publish(partybus.Event{
-			Type:  event.Exit,
-			Value: func() error { return writer.Write(*s) },
+			Type: event.Exit,
+			Value: func() error {
+				if err := writer.Write(*s); err != nil {
+					return err
+				}
+				return results.check(app, *s)
+			},
 		})
 	}()
 	return errs
 }
 
-func GenerateSBOM(src *source.Source, errs chan error, app *config.Application) (*sbom.SBOM, error) {
+// NewSource creates the source of the given input, where pulling the image (and fetching image layers lazily while
+// cataloging) stops when the given context is canceled or the configured timeout passes.
+func NewSource(ctx context.Context, app *config.Application, si source.Input) (*source.Source, func(), error) {
+	cancel := func() {}
+	if app.Limits.Timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, app.Limits.Timeout)
+	}
+	src, cleanup, err := source.NewWithContext(ctx, si, app.Registry.ToOptions(), app.Exclusions)
+	return src, func() {
+		if cleanup != nil {
+			cleanup()
+		}
+		cancel()
+	}, err
+}
+
+func GenerateSBOM(ctx context.Context, src *source.Source, errs chan error, app *config.Application) (*sbom.SBOM, error) {
 	tasks, err := eventloop.Tasks(app)
 	if err != nil {
 		return nil, err
@@ -108,20 +181,79 @@ func GenerateSBOM(src *source.Source, errs chan error, app *config.Application)
 		},
 	}
 
-	buildRelationships(&s, src, tasks, errs)
+	buildRelationships(ctx, &s, src, tasks, errs)
 
 	return &s, nil
 }
 
-func buildRelationships(s *sbom.SBOM, src *source.Source, tasks []eventloop.Task, errs chan error) {
+// Generate catalogs the given source as with GenerateSBOM, for callers without an event loop: the errors of any
+// cataloging tasks that failed are returned (together) rather than sent on a channel.
+func Generate(ctx context.Context, src *source.Source, app *config.Application) (*sbom.SBOM, error) {
+	errs := make(chan error)
+	var taskErrs error
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for err := range errs {
+			taskErrs = multierror.Append(taskErrs, err)
+		}
+	}()
+
+	s, err := GenerateSBOM(ctx, src, errs, app)
+	close(errs)
+	<-done
+
+	if err != nil {
+		return nil, err
+	}
+	if taskErrs != nil {
+		return nil, taskErrs
+	}
+	return s, nil
+}
+
+// detectBaseImage determines the layers provided by the base image of an image source when the user has asked for
+// base image packages to be marked or excluded.
+func detectBaseImage(ctx context.Context, app *config.Application, src *source.Source) error {
+	if app.BaseImage == "" && !app.ExcludeBaseImage {
+		return nil
+	}
+	if src.Metadata.Scheme != source.ImageScheme {
+		return fmt.Errorf("a base image can only be used with image sources")
+	}
+	if err := src.DetectBaseImage(ctx, app.BaseImage, app.Registry.ToOptions()); err != nil {
+		return fmt.Errorf("unable to detect base image: %w", err)
+	}
+	return nil
+}
+
+// detectProvenance records the SLSA provenance of an image source when the user has asked for it. Since not every image
+// has provenance attached, failing to find any is not an error.
+func detectProvenance(ctx context.Context, app *config.Application, src *source.Source) {
+	if !app.Provenance {
+		return
+	}
+	if src.Metadata.Scheme != source.ImageScheme {
+		log.Warnf("provenance can only be detected for image sources")
+		return
+	}
+	if err := src.DetectProvenance(ctx, app.Registry.ToOptions()); err != nil {
+		log.Warnf("unable to detect provenance: %+v", err)
+	}
+}
+
+func buildRelationships(ctx context.Context, s *sbom.SBOM, src *source.Source, tasks []eventloop.Task, errs chan error) {
 	var relationships []<-chan artifact.Relationship
 	for _, task := range tasks {
 		c := make(chan artifact.Relationship)
 		relationships = append(relationships, c)
-		go eventloop.RunTask(task, &s.Artifacts, src, c, errs)
+		go eventloop.RunTask(ctx, task, &s.Artifacts, src, c, errs)
 	}
 
 	s.Relationships = append(s.Relationships, MergeRelationships(relationships...)...)
+
+	// relate packages owning shared libraries to the binaries that link against them (requires all tasks to be complete)
+	s.Relationships = append(s.Relationships, file.RelationshipsByELFLinkage(s.Relationships)...)
 }
 
 func MergeRelationships(cs ...<-chan artifact.Relationship) (relationships []artifact.Relationship) {
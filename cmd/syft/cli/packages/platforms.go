@@ -0,0 +1,150 @@
+package packages
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/anchore/stereoscope"
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/cmd/syft/cli/eventloop"
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/internal/bus"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/ui"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/event"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// runAllPlatforms catalogs every platform of a multi-platform image within a registry, writing a separate SBOM for each
+// platform. Images that are not multi-platform are cataloged as usual.
+func runAllPlatforms(ctx context.Context, app *config.Application, userInput string, results *scanResults) error {
+	if app.Platform != "" {
+		return fmt.Errorf("cannot specify a platform when cataloging all platforms")
+	}
+
+	si, err := source.ParseInput(userInput, "", true)
+	if err != nil {
+		return fmt.Errorf("could not generate source input for packages command: %w", err)
+	}
+	if si.Scheme != source.ImageScheme || si.Containerd != nil {
+		return fmt.Errorf("cataloging all platforms is only supported for images within a registry")
+	}
+	if si.ImageSource != image.OciRegistrySource {
+		// a container runtime or image archive only holds the image for a single platform, so unless the user explicitly
+		// asked for one of these sources the image index is read from the registry instead
+		if explicitSource, _, _ := image.DetectSource(userInput); explicitSource != image.UnknownSource {
+			return fmt.Errorf("cataloging all platforms is only supported for images within a registry (source=%q)", explicitSource)
+		}
+		si.ImageSource = image.OciRegistrySource
+	}
+	si.LazyLayers = app.Registry.LazyLayers
+
+	platforms, err := source.ImagePlatforms(ctx, *si, app.Registry.ToOptions())
+	if err != nil {
+		return err
+	}
+	if len(platforms) == 0 {
+		log.Warnf("image=%q is not a multi-platform image, cataloging the image for the default platform only", si.Location)
+		// an empty platform selects the default platform (and does not change the output file names)
+		platforms = []string{""}
+	}
+	log.Debugf("cataloging image=%q platforms=%+v", si.Location, platforms)
+
+	writers := make([]sbom.Writer, 0, len(platforms))
+	defer func() {
+		for _, writer := range writers {
+			if err := writer.Close(); err != nil {
+				log.Warnf("unable to write to report destination: %+v", err)
+			}
+		}
+	}()
+	for _, platform := range platforms {
+		writer, err := options.MakePlatformWriter(app.Outputs, app.File, app.OutputTemplatePath, app.OutputTemplateDir, app.Baseline, app.Archive, platform)
+		if err != nil {
+			return err
+		}
+		writers = append(writers, writer)
+	}
+
+	eventBus := partybus.NewBus()
+	stereoscope.SetBus(eventBus)
+	syft.SetBus(eventBus)
+	subscription := eventBus.Subscribe()
+
+	return eventloop.EventLoop(
+		execAllPlatformsWorker(ctx, app, *si, platforms, writers, results),
+		eventloop.SetupSignals(),
+		subscription,
+		stereoscope.Cleanup,
+		ui.Select(options.IsVerbose(app), app.Quiet, app.Progress)...,
+	)
+}
+
+func execAllPlatformsWorker(ctx context.Context, app *config.Application, si source.Input, platforms []string, writers []sbom.Writer, results *scanResults) <-chan error {
+	errs := make(chan error)
+	go func() {
+		defer close(errs)
+
+		start := time.Now()
+		var sboms []sbom.SBOM
+		for _, platform := range platforms {
+			s, err := generatePlatformSBOM(ctx, app, si, platform, errs)
+			if err != nil {
+				errs <- err
+				return
+			}
+			sboms = append(sboms, *s)
+		}
+
+		if err := writeMetrics(app, start); err != nil {
+			log.Warn(err)
+		}
+
+		bus.Publish(partybus.Event{
+			Type: event.Exit,
+			Value: func() error {
+				for idx, s := range sboms {
+					if err := writers[idx].Write(s); err != nil {
+						return err
+					}
+				}
+				return results.check(app, sboms...)
+			},
+		})
+	}()
+	return errs
+}
+
+// generatePlatformSBOM catalogs the image for a single platform, cleaning up the image before the next platform is
+// cataloged.
+func generatePlatformSBOM(ctx context.Context, app *config.Application, si source.Input, platform string, errs chan error) (*sbom.SBOM, error) {
+	si.Platform = platform
+
+	src, cleanup, err := NewSource(ctx, app, si)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct source from user input %q (platform=%q): %w", si.UserInput, platform, err)
+	}
+
+	if err := detectBaseImage(ctx, app, src); err != nil {
+		return nil, err
+	}
+	detectProvenance(ctx, app, src)
+
+	s, err := GenerateSBOM(ctx, src, errs, app)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, fmt.Errorf("no SBOM produced for %q (platform=%q)", si.UserInput, platform)
+	}
+	return s, nil
+}
@@ -0,0 +1,73 @@
+package packages
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/syft/policy"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+const (
+	// ScanErrorExitCode is the exit code when the scan fails (no SBOM is written).
+	ScanErrorExitCode = 1
+	// PolicyViolationExitCode is the exit code when packages match a --fail-on condition (the SBOM is written).
+	PolicyViolationExitCode = 2
+	// PartialResultsExitCode is the exit code when some catalogers failed or timed out, so the SBOM written is
+	// incomplete (see the diagnostics of the SBOM).
+	PartialResultsExitCode = 3
+)
+
+var (
+	// ErrPolicyViolation is returned (after the SBOM is written) when packages match a --fail-on condition.
+	ErrPolicyViolation = errors.New("packages violate the policy")
+	// ErrPartialResults is returned (after the SBOM is written) when some catalogers failed or timed out.
+	ErrPartialResults = errors.New("cataloging results are incomplete")
+)
+
+// ExitCode returns the exit code for the error returned by Run. Policy violations take precedence over partial results,
+// since the violations found are not affected by the packages that are missing.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return 0
+	case errors.Is(err, ErrPolicyViolation):
+		return PolicyViolationExitCode
+	case errors.Is(err, ErrPartialResults):
+		return PartialResultsExitCode
+	default:
+		return ScanErrorExitCode
+	}
+}
+
+// scanResults records the SBOMs written by a scan (along with their policy violations), from which the exit code and
+// the summary of the scan are derived.
+type scanResults struct {
+	sboms      []sbom.SBOM
+	violations []policy.Violation
+}
+
+// check records the SBOMs written by the scan and evaluates their packages against the configured --fail-on
+// conditions, returning an error when there are violations (listing each of them) or when the results are incomplete.
+func (r *scanResults) check(app *config.Application, sboms ...sbom.SBOM) error {
+	var failures int
+	for _, s := range sboms {
+		r.sboms = append(r.sboms, s)
+		r.violations = append(r.violations, app.Policy.PolicyOpt.Evaluate(s)...)
+		failures += len(s.Artifacts.Diagnostics)
+	}
+
+	if len(r.violations) > 0 {
+		var violations []string
+		for _, v := range r.violations {
+			violations = append(violations, v.String())
+		}
+		return fmt.Errorf("%w: %d violation(s) of --fail-on conditions:\n  - %s", ErrPolicyViolation, len(violations), strings.Join(violations, "\n  - "))
+	}
+	if failures > 0 {
+		return fmt.Errorf("%w: %d cataloger(s) failed or timed out (see the diagnostics of the SBOM)", ErrPartialResults, failures)
+	}
+	return nil
+}
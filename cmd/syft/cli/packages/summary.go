@@ -0,0 +1,90 @@
+package packages
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/syft/policy"
+)
+
+// runSummary is the document written to the summary file, describing the outcome of a scan for wrappers (e.g. CI
+// pipelines) so that they don't need to parse the logs.
+type runSummary struct {
+	// Status is one of "success", "policy-violation", "partial-results", or "error" (matching the exit code)
+	Status          string             `json:"status"`
+	ExitCode        int                `json:"exitCode"`
+	Error           string             `json:"error,omitempty"`
+	DurationSeconds float64            `json:"durationSeconds"`
+	Counts          summaryCounts      `json:"counts"`
+	Warnings        []string           `json:"warnings"`
+	Violations      []policy.Violation `json:"violations"`
+}
+
+type summaryCounts struct {
+	// SBOMs is the number of SBOMs written (one for each platform when cataloging all platforms)
+	SBOMs          int            `json:"sboms"`
+	Packages       int            `json:"packages"`
+	PackagesByType map[string]int `json:"packagesByType"`
+	Relationships  int            `json:"relationships"`
+}
+
+// writeSummary writes the outcome of the scan (given the error returned by the scan) to the summary file.
+func writeSummary(app *config.Application, start time.Time, results *scanResults, scanErr error) error {
+	if app.SummaryFile == "" {
+		return nil
+	}
+
+	doc := runSummary{
+		Status:          summaryStatus(scanErr),
+		ExitCode:        ExitCode(scanErr),
+		DurationSeconds: time.Since(start).Seconds(),
+		Counts: summaryCounts{
+			SBOMs:          len(results.sboms),
+			PackagesByType: map[string]int{},
+		},
+		Warnings:   []string{},
+		Violations: []policy.Violation{},
+	}
+	if scanErr != nil {
+		doc.Error = scanErr.Error()
+	}
+	doc.Violations = append(doc.Violations, results.violations...)
+
+	for _, s := range results.sboms {
+		doc.Counts.Relationships += len(s.Relationships)
+		if s.Artifacts.PackageCatalog != nil {
+			for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+				doc.Counts.Packages++
+				doc.Counts.PackagesByType[string(p.Type)]++
+			}
+		}
+		for _, d := range s.Artifacts.Diagnostics {
+			doc.Warnings = append(doc.Warnings, fmt.Sprintf("cataloger=%q failed: %s", d.Cataloger, d.Message))
+		}
+	}
+
+	contents, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode summary: %w", err)
+	}
+	if err := os.WriteFile(app.SummaryFile, contents, 0600); err != nil {
+		return fmt.Errorf("unable to write summary file=%q: %w", app.SummaryFile, err)
+	}
+	return nil
+}
+
+func summaryStatus(scanErr error) string {
+	switch ExitCode(scanErr) {
+	case 0:
+		return "success"
+	case PolicyViolationExitCode:
+		return "policy-violation"
+	case PartialResultsExitCode:
+		return "partial-results"
+	default:
+		return "error"
+	}
+}
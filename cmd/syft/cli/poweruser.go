@@ -18,7 +18,7 @@ const powerUserExample = `  {{.appName}} {{.command}} <image>
   All behavior is controlled via application configuration and environment variables (see https://github.com/anchore/syft#configuration)
 `
 
-func PowerUser(v *viper.Viper, app *config.Application, ro *options.RootOptions) *cobra.Command {
+func PowerUser(v *viper.Viper, app *config.Application, ro *options.RootOptions, po *options.PackagesOptions) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "power-user [IMAGE]",
 		Short: "Run bulk operations on container images",
@@ -27,6 +27,9 @@ func PowerUser(v *viper.Viper, app *config.Application, ro *options.RootOptions)
 			"command": "power-user",
 		}),
 		Args: func(cmd *cobra.Command, args []string) error {
+			if err := po.BindFlags(cmd.Flags(), v); err != nil {
+				return err
+			}
 			if err := app.LoadAllValues(v, ro.Config); err != nil {
 				return fmt.Errorf("invalid application config: %v", err)
 			}
@@ -21,6 +21,7 @@ import (
 	"github.com/anchore/syft/syft"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/event"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/formats/syftjson"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
@@ -50,6 +51,16 @@ func Run(ctx context.Context, app *config.Application, args []string) error {
 	if err != nil {
 		return fmt.Errorf("could not generate source input for packages command: %w", err)
 	}
+	si.LazyLayers = app.Registry.LazyLayers
+	if si.Containerd != nil {
+		si.Containerd.Address = app.Containerd.Address
+		si.Containerd.Namespace = app.Containerd.Namespace
+	}
+	if si.SSH != nil {
+		si.SSH.IdentityFile = app.SSH.IdentityFile
+		si.SSH.KnownHostsFile = app.SSH.KnownHostsFile
+		si.SSH.InsecureIgnoreHostKey = app.SSH.InsecureIgnoreHostKey
+	}
 
 	eventBus := partybus.NewBus()
 	stereoscope.SetBus(eventBus)
@@ -57,15 +68,15 @@ func Run(ctx context.Context, app *config.Application, args []string) error {
 	subscription := eventBus.Subscribe()
 
 	return eventloop.EventLoop(
-		execWorker(app, *si, writer),
+		execWorker(ctx, app, *si, writer),
 		eventloop.SetupSignals(),
 		subscription,
 		stereoscope.Cleanup,
-		ui.Select(options.IsVerbose(app), app.Quiet)...,
+		ui.Select(options.IsVerbose(app), app.Quiet, app.Progress)...,
 	)
 }
 
-func execWorker(app *config.Application, si source.Input, writer sbom.Writer) <-chan error {
+func execWorker(ctx context.Context, app *config.Application, si source.Input, writer sbom.Writer) <-chan error {
 	errs := make(chan error)
 	go func() {
 		defer close(errs)
@@ -74,13 +85,15 @@ func execWorker(app *config.Application, si source.Input, writer sbom.Writer) <-
 		app.FileMetadata.Cataloger.Enabled = true
 		app.FileContents.Cataloger.Enabled = true
 		app.FileClassification.Cataloger.Enabled = true
+		app.FileELF.Cataloger.Enabled = true
+		app.FileExecutable.Cataloger.Enabled = true
 		tasks, err := eventloop.Tasks(app)
 		if err != nil {
 			errs <- err
 			return
 		}
 
-		src, cleanup, err := source.New(si, app.Registry.ToOptions(), app.Exclusions)
+		src, cleanup, err := packages.NewSource(ctx, app, si)
 		if err != nil {
 			errs <- err
 			return
@@ -103,10 +116,11 @@ func execWorker(app *config.Application, si source.Input, writer sbom.Writer) <-
 			c := make(chan artifact.Relationship)
 			relationships = append(relationships, c)
 
-			go eventloop.RunTask(task, &s.Artifacts, src, c, errs)
+			go eventloop.RunTask(ctx, task, &s.Artifacts, src, c, errs)
 		}
 
 		s.Relationships = append(s.Relationships, packages.MergeRelationships(relationships...)...)
+		s.Relationships = append(s.Relationships, file.RelationshipsByELFLinkage(s.Relationships)...)
 
 		bus.Publish(partybus.Event{
 			Type:  event.Exit,
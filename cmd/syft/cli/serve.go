@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/cmd/syft/cli/serve"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/config"
+)
+
+const serveExample = `  {{.appName}} {{.command}}                                  listen for scan requests on localhost:8080
+  {{.appName}} {{.command}} --address :8080 --max-concurrent-scans 8
+
+  Request a SBOM of an image within a registry (the SBOM is returned in the requested format, syft-json by default):
+    curl -X POST localhost:8080/v1/scan -d '{"source": "alpine:latest", "output": "spdx-json"}'
+
+  Request a SBOM of an uploaded image archive (e.g. from "docker save") or other file:
+    curl -X POST 'localhost:8080/v1/scan/archive?output=cyclonedx-json' --data-binary @image.tar
+`
+
+func Serve(v *viper.Viper, app *config.Application, ro *options.RootOptions) *cobra.Command {
+	so := options.ServeOptions{}
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Run a service that generates SBOMs for scan requests over HTTP",
+		Long:  "Run a long-running service that accepts scan requests (an image within a registry or an uploaded archive) over HTTP and returns the SBOMs, sharing the cataloging cache between requests",
+		Example: internal.Tprintf(serveExample, map[string]interface{}{
+			"appName": internal.ApplicationName,
+			"command": "serve",
+		}),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := app.LoadAllValues(v, ro.Config); err != nil {
+				return fmt.Errorf("invalid application config: %v", err)
+			}
+			// configure logging for command
+			newLogWrapper(app)
+			logApplicationConfig(app)
+			return cobra.NoArgs(cmd, args)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app.CheckForAppUpdate {
+				checkForApplicationUpdate()
+			}
+			return serve.Run(cmd.Context(), app)
+		},
+	}
+
+	err := so.AddFlags(cmd, v)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return cmd
+}
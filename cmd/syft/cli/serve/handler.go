@@ -0,0 +1,217 @@
+package serve
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/cmd/syft/cli/packages"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/formats/template"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// maxRequestSize is the largest scan request body (other than an uploaded archive).
+const maxRequestSize = 1 << 20
+
+// scanRequest is the body of a request to scan an image within a registry.
+type scanRequest struct {
+	// Source is the image to scan (e.g. "alpine:latest")
+	Source string `json:"source"`
+	// Platform optionally selects the platform of a multi-platform image (e.g. "linux/arm64")
+	Platform string `json:"platform"`
+	// Output is the format of the returned SBOM (syft-json when empty)
+	Output string `json:"output"`
+}
+
+type handler struct {
+	app *config.Application
+	// scans holds a token for each running scan, bounding the number of concurrent scans
+	scans chan struct{}
+}
+
+// newHandler creates the handler of all scan requests, along with the health check and the metrics of the given
+// gatherer (when given).
+func newHandler(app *config.Application, metrics prometheus.Gatherer) http.Handler {
+	h := &handler{
+		app:   app,
+		scans: make(chan struct{}, app.Serve.MaxConcurrentScans),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v1/scan", h.scanImage)
+	mux.HandleFunc("/v1/scan/archive", h.scanArchive)
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	if metrics != nil {
+		mux.Handle("/metrics", promhttp.HandlerFor(metrics, promhttp.HandlerOpts{}))
+	}
+	return mux
+}
+
+// scanImage returns the SBOM of an image within a registry, described by the scanRequest within the body.
+func (h *handler) scanImage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, http.StatusMethodNotAllowed, fmt.Errorf("scan requests must be POSTed"))
+		return
+	}
+
+	var req scanRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(&req); err != nil {
+		httpError(w, http.StatusBadRequest, fmt.Errorf("invalid scan request: %w", err))
+		return
+	}
+
+	format, err := outputFormat(req.Output)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	si, err := registryInput(req.Source, req.Platform)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.scan(w, r, *si, format)
+}
+
+// scanArchive returns the SBOM of the archive uploaded as the body (e.g. an image archive from "docker save"), with
+// the output format given by the "output" query parameter.
+func (h *handler) scanArchive(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpError(w, http.StatusMethodNotAllowed, fmt.Errorf("archives must be POSTed"))
+		return
+	}
+
+	format, err := outputFormat(r.URL.Query().Get("output"))
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	f, err := os.CreateTemp("", "syft-upload-*")
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, fmt.Errorf("unable to store the uploaded archive: %w", err))
+		return
+	}
+	defer os.Remove(f.Name())
+
+	_, err = io.Copy(f, http.MaxBytesReader(w, r.Body, h.app.Serve.MaxUploadSizeBytes))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		httpError(w, http.StatusBadRequest, fmt.Errorf("unable to read the uploaded archive (max size is %d bytes): %w", h.app.Serve.MaxUploadSizeBytes, err))
+		return
+	}
+
+	// note: the kind of archive (image archive or otherwise) is detected from the contents
+	si, err := source.ParseInput(f.Name(), "", false)
+	if err != nil {
+		httpError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.scan(w, r, *si, format)
+}
+
+// scan generates the SBOM of the given source, writing it to the response as it is encoded. Scans beyond the max
+// number of concurrent scans wait for a running scan to finish (or for the request to be canceled).
+func (h *handler) scan(w http.ResponseWriter, r *http.Request, si source.Input, format sbom.Format) {
+	select {
+	case h.scans <- struct{}{}:
+		defer func() { <-h.scans }()
+	case <-r.Context().Done():
+		return
+	}
+
+	// each scan has its own copy of the configuration, sharing the cache and instrumentation
+	app := *h.app
+	si.LazyLayers = app.Registry.LazyLayers
+
+	log.Debugf("scanning source=%q", si.UserInput)
+	src, cleanup, err := packages.NewSource(r.Context(), &app, si)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		httpError(w, http.StatusBadRequest, fmt.Errorf("failed to construct source from %q: %w", si.UserInput, err))
+		return
+	}
+
+	s, err := packages.Generate(r.Context(), src, &app)
+	if err != nil {
+		httpError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType(format))
+	if err := format.Encode(w, *s); err != nil {
+		// note: the response has already started, so the status can no longer be changed
+		log.Warnf("unable to write SBOM of source=%q: %+v", si.UserInput, err)
+	}
+}
+
+// registryInput returns the source input of the given image, which must be within a registry: the service does not
+// scan anything local to where it is running (e.g. directories or images within a container runtime).
+func registryInput(userInput, platform string) (*source.Input, error) {
+	if userInput == "" {
+		return nil, fmt.Errorf("a source to scan is required")
+	}
+
+	si, err := source.ParseInput(userInput, "", false)
+	if err != nil {
+		return nil, err
+	}
+
+	isImage := si.Scheme == source.ImageScheme || si.Scheme == source.UnknownScheme
+	isRegistry := si.ImageSource == image.UnknownSource || si.ImageSource == image.OciRegistrySource
+	if !isImage || !isRegistry || si.Containerd != nil {
+		return nil, fmt.Errorf("only images within a registry can be scanned (other sources can be uploaded as an archive): %q", userInput)
+	}
+
+	si.Scheme = source.ImageScheme
+	si.ImageSource = image.OciRegistrySource
+	si.Platform = platform
+	return si, nil
+}
+
+// outputFormat returns the SBOM format with the given name (syft-json when empty).
+func outputFormat(name string) (sbom.Format, error) {
+	if name == "" {
+		return syft.FormatByID(syft.JSONFormatID), nil
+	}
+	format := syft.FormatByName(name)
+	if format == nil || format.ID() == template.ID {
+		return nil, fmt.Errorf("bad output format %q", name)
+	}
+	return format, nil
+}
+
+func contentType(format sbom.Format) string {
+	switch format.ID() {
+	case syft.JSONFormatID, syft.SPDXJSONFormatID, syft.CycloneDxJSONFormatID, syft.GitHubID:
+		return "application/json"
+	case syft.CycloneDxXMLFormatID:
+		return "application/xml"
+	default:
+		return "text/plain"
+	}
+}
+
+func httpError(w http.ResponseWriter, status int, err error) {
+	log.Debugf("scan request failed (status=%d): %+v", status, err)
+	http.Error(w, err.Error(), status)
+}
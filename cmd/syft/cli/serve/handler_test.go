@@ -0,0 +1,164 @@
+package serve
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestRegistryInput(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		platform string
+		wantErr  bool
+	}{
+		{
+			name:  "image reference",
+			input: "alpine:latest",
+		},
+		{
+			name:     "image reference with platform",
+			input:    "alpine:latest",
+			platform: "linux/arm64",
+		},
+		{
+			name:  "explicit registry",
+			input: "registry:alpine:latest",
+		},
+		{
+			name:    "missing source",
+			wantErr: true,
+		},
+		{
+			name:    "container runtime",
+			input:   "docker:alpine:latest",
+			wantErr: true,
+		},
+		{
+			name:    "containerd",
+			input:   "containerd:alpine:latest",
+			wantErr: true,
+		},
+		{
+			name:    "directory",
+			input:   "dir:" + t.TempDir(),
+			wantErr: true,
+		},
+		{
+			name:    "local directory",
+			input:   t.TempDir(),
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			si, err := registryInput(test.input, test.platform)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, source.ImageScheme, si.Scheme)
+			assert.Equal(t, image.OciRegistrySource, si.ImageSource)
+			assert.Equal(t, test.platform, si.Platform)
+		})
+	}
+}
+
+func TestOutputFormat(t *testing.T) {
+	format, err := outputFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, syft.JSONFormatID, format.ID())
+
+	format, err = outputFormat("spdx-json")
+	require.NoError(t, err)
+	assert.Equal(t, syft.SPDXJSONFormatID, format.ID())
+
+	_, err = outputFormat("template")
+	assert.Error(t, err)
+
+	_, err = outputFormat("bogus")
+	assert.Error(t, err)
+}
+
+func TestHandler_badRequests(t *testing.T) {
+	h := newHandler(&config.Application{}, nil)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{
+			name:   "health check",
+			method: http.MethodGet,
+			path:   "/healthz",
+			status: http.StatusOK,
+		},
+		{
+			name:   "scan must be posted",
+			method: http.MethodGet,
+			path:   "/v1/scan",
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "invalid request",
+			method: http.MethodPost,
+			path:   "/v1/scan",
+			body:   "not json",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "bad output format",
+			method: http.MethodPost,
+			path:   "/v1/scan",
+			body:   `{"source": "alpine:latest", "output": "bogus"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "local source",
+			method: http.MethodPost,
+			path:   "/v1/scan",
+			body:   `{"source": "dir:/"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "archive must be posted",
+			method: http.MethodGet,
+			path:   "/v1/scan/archive",
+			status: http.StatusMethodNotAllowed,
+		},
+		{
+			name:   "bad archive output format",
+			method: http.MethodPost,
+			path:   "/v1/scan/archive?output=bogus",
+			body:   "contents",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "metrics are not served without a gatherer",
+			method: http.MethodGet,
+			path:   "/metrics",
+			status: http.StatusNotFound,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			h.ServeHTTP(recorder, httptest.NewRequest(test.method, test.path, strings.NewReader(test.body)))
+			assert.Equal(t, test.status, recorder.Code, recorder.Body.String())
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/anchore/stereoscope"
+	"github.com/anchore/syft/cmd/syft/cli/eventloop"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+)
+
+// shutdownTimeout is how long running scans are given to finish once the service is interrupted.
+const shutdownTimeout = time.Minute
+
+// Run listens for scan requests until the process is interrupted, finishing running scans before returning. Running
+// scans are canceled when the given context is canceled, or when they do not finish within the shutdown timeout.
+func Run(ctx context.Context, app *config.Application) error {
+	// scans share the cataloging cache, so the layers common to many images are only cataloged once
+	app.Cache.Enabled = true
+
+	registry := prometheus.NewRegistry()
+	instrumentation, err := cataloger.NewInstrumentation(nil, registry)
+	if err != nil {
+		return err
+	}
+	app.Instrumentation = instrumentation
+
+	defer stereoscope.Cleanup()
+
+	scanCtx, cancelScans := context.WithCancel(ctx)
+	defer cancelScans()
+	server := newServer(scanCtx, app, registry)
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+	log.Infof("listening for scan requests on %s", app.Serve.Address)
+
+	select {
+	case err := <-errs:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("unable to serve scan requests: %w", err)
+	case <-ctx.Done():
+		log.Info("shutting down, canceling running scans")
+		cancelScans()
+		return server.Close()
+	case <-eventloop.SetupSignals():
+		log.Info("shutting down, waiting for running scans to finish")
+		shutdownCtx, cancel := context.WithTimeout(ctx, shutdownTimeout)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// newServer returns the server of scan requests, where the context of each request (and so of each scan) is derived
+// from the given context.
+func newServer(ctx context.Context, app *config.Application, registry *prometheus.Registry) *http.Server {
+	return &http.Server{
+		Addr:              app.Serve.Address,
+		Handler:           newHandler(app, registry),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return ctx
+		},
+	}
+}
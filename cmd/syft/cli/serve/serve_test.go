@@ -0,0 +1,45 @@
+package serve
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal/config"
+)
+
+func TestRun_returnsWhenContextIsCanceled(t *testing.T) {
+	app := &config.Application{}
+	app.Serve.Address = "127.0.0.1:0"
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		errs <- Run(ctx, app)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errs:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the service did not stop when the context was canceled")
+	}
+}
+
+func TestNewServer_requestContextIsDerivedFromContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	server := newServer(ctx, &config.Application{}, prometheus.NewRegistry())
+
+	requestCtx := server.BaseContext(nil)
+	require.NoError(t, requestCtx.Err())
+
+	cancel()
+	assert.ErrorIs(t, requestCtx.Err(), context.Canceled)
+}
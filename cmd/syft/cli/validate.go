@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/cmd/syft/cli/validate"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/config"
+)
+
+const validateExample = `  {{.appName}} {{.command}} sbom.spdx.json                         check an SBOM for the NTIA minimum elements
+  {{.appName}} {{.command}} alpine:latest --profile bsi-tr-03183   generate the SBOM of an image and check it against BSI TR-03183
+  {{.appName}} {{.command}} sbom.cdx.json -o json                  report the missing fields as JSON
+
+  Exits with 0 when the SBOM has all required fields, 2 when fields are missing, and 1 when the SBOM cannot be checked.
+`
+
+func Validate(v *viper.Viper, app *config.Application, ro *options.RootOptions) *cobra.Command {
+	vo := options.ValidateOptions{}
+	cmd := &cobra.Command{
+		Use:   "validate [SBOM|SOURCE]",
+		Short: "Check an SBOM for the fields required by the NTIA minimum elements or BSI TR-03183",
+		Long:  "Check an SBOM file (or the SBOM generated from an image or directory) against a profile of required fields, reporting each field missing from the document or from a package",
+		Example: internal.Tprintf(validateExample, map[string]interface{}{
+			"appName": internal.ApplicationName,
+			"command": "validate",
+		}),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := app.LoadAllValues(v, ro.Config); err != nil {
+				return fmt.Errorf("invalid application config: %w", err)
+			}
+			// configure logging for command
+			newLogWrapper(app)
+			logApplicationConfig(app)
+			return validateArgs(cmd, args)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app.CheckForAppUpdate {
+				checkForApplicationUpdate()
+			}
+			err := validate.Run(cmd.Context(), app, args)
+			if errors.Is(err, validate.ErrNotCompliant) {
+				return &ExitError{Code: validate.NotCompliantExitCode, Err: err}
+			}
+			return err
+		},
+	}
+
+	if err := vo.AddFlags(cmd, v); err != nil {
+		log.Fatal(err)
+	}
+
+	return cmd
+}
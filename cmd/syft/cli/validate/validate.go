@@ -0,0 +1,155 @@
+package validate
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/anchore/syft/cmd/syft/cli/packages"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/compliance"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// NotCompliantExitCode is the exit code when the SBOM is missing fields required by the profile (as opposed to 1, when
+// the SBOM cannot be checked at all).
+const NotCompliantExitCode = 2
+
+// ErrNotCompliant is returned when the SBOM is missing fields required by the profile.
+var ErrNotCompliant = errors.New("SBOM is missing required fields")
+
+// Run checks the SBOM of the given file (or the SBOM generated from the given source) against the configured profile,
+// writing a report of the missing fields.
+func Run(ctx context.Context, app *config.Application, args []string) error {
+	profile, err := compliance.ProfileByName(app.Validate.Profile)
+	if err != nil {
+		return err
+	}
+
+	s, err := getSBOM(ctx, app, args[0])
+	if err != nil {
+		return err
+	}
+
+	report := compliance.Check(*s, profile)
+	if err := writeReport(os.Stdout, app.Validate.Output, profile, report); err != nil {
+		return err
+	}
+
+	if !report.Compliant() {
+		return fmt.Errorf("%w: %d fields required by the %s profile are missing", ErrNotCompliant, len(report.Gaps), profile.Name)
+	}
+	return nil
+}
+
+// getSBOM decodes the given SBOM file, or otherwise generates the SBOM of the given source (e.g. an image or directory).
+func getSBOM(ctx context.Context, app *config.Application, userInput string) (*sbom.SBOM, error) {
+	if isSBOMFile(userInput) {
+		f, err := os.Open(userInput)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open SBOM file: %w", err)
+		}
+		defer f.Close()
+
+		s, format, err := syft.Decode(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode SBOM: %w", err)
+		}
+		log.WithFields("file", userInput, "format", format.ID()).Debug("checking SBOM file")
+		return s, nil
+	}
+
+	si, err := source.ParseInput(userInput, app.Platform, true)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate source input for validate command: %w", err)
+	}
+	src, cleanup, err := packages.NewSource(ctx, app, *si)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to construct source from user input %q: %w", userInput, err)
+	}
+
+	log.WithFields("source", userInput).Debug("checking generated SBOM")
+	return packages.Generate(ctx, src, app)
+}
+
+// isSBOMFile indicates if the given input is a file that looks like an SBOM document (JSON, XML, or SPDX tag-value),
+// rather than a file to be cataloged (e.g. an image archive).
+func isSBOMFile(userInput string) bool {
+	info, err := os.Stat(userInput)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	f, err := os.Open(userInput)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	header, err := bufio.NewReader(f).Peek(512)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false
+	}
+	return looksLikeSBOM(header)
+}
+
+func looksLikeSBOM(header []byte) bool {
+	header = bytes.TrimLeft(header, " \t\r\n\ufeff")
+	return bytes.HasPrefix(header, []byte("{")) ||
+		bytes.HasPrefix(header, []byte("<")) ||
+		bytes.HasPrefix(header, []byte("SPDXVersion:"))
+}
+
+func writeReport(w io.Writer, output string, profile compliance.Profile, report compliance.Report) error {
+	switch output {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", " ")
+		return enc.Encode(report)
+	case "text", "":
+		return writeTextReport(w, profile, report)
+	}
+	return fmt.Errorf("unsupported report output %q", output)
+}
+
+func writeTextReport(w io.Writer, profile compliance.Profile, report compliance.Report) error {
+	if report.Compliant() {
+		_, err := fmt.Fprintf(w, "%d packages checked: no fields required by the %s profile (%s) are missing\n", report.Packages, profile.Name, profile.Description)
+		return err
+	}
+
+	counts := report.GapsByField()
+	var fields []string
+	for field := range counts {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	var summary []string
+	for _, field := range fields {
+		summary = append(summary, fmt.Sprintf("%s: %d", field, counts[field]))
+	}
+
+	if _, err := fmt.Fprintf(w, "%d packages checked: %d fields required by the %s profile (%s) are missing (%s)\n\n", report.Packages, len(report.Gaps), profile.Name, profile.Description, strings.Join(summary, ", ")); err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ELEMENT\tFIELD\tMESSAGE")
+	for _, g := range report.Gaps {
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", g.Element, g.Field, g.Message)
+	}
+	return tw.Flush()
+}
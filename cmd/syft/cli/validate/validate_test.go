@@ -0,0 +1,102 @@
+package validate
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/compliance"
+)
+
+func TestLooksLikeSBOM(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected bool
+	}{
+		{name: "json", header: "{\n  \"artifacts\": []", expected: true},
+		{name: "json with leading whitespace", header: "\n\n  {\"bomFormat\": \"CycloneDX\"", expected: true},
+		{name: "json with byte order mark", header: "\ufeff{\"spdxVersion\": \"SPDX-2.2\"", expected: true},
+		{name: "xml", header: "<?xml version=\"1.0\" encoding=\"UTF-8\"?>", expected: true},
+		{name: "spdx tag-value", header: "SPDXVersion: SPDX-2.2\nDataLicense: CC0-1.0", expected: true},
+		{name: "tar archive", header: "manifest.json\x00\x00\x00\x00", expected: false},
+		{name: "empty", header: "", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, looksLikeSBOM([]byte(test.header)))
+		})
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	profile, err := compliance.ProfileByName(compliance.NTIA)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		output   string
+		report   compliance.Report
+		expected string
+	}{
+		{
+			name:     "compliant",
+			output:   "text",
+			report:   compliance.Report{Profile: compliance.NTIA, Packages: 3, Gaps: []compliance.Gap{}},
+			expected: "3 packages checked: no fields required by the ntia profile (NTIA minimum elements for a software bill of materials) are missing\n",
+		},
+		{
+			name:   "gaps",
+			output: "text",
+			report: compliance.Report{
+				Profile:  compliance.NTIA,
+				Packages: 2,
+				Gaps: []compliance.Gap{
+					{Element: compliance.DocumentElement, Field: "author", Message: "no author"},
+					{Element: "lib@2.0.0", PackageID: "abc", Field: "supplier", Message: "no supplier"},
+					{Element: "other@1.0.0", PackageID: "def", Field: "supplier", Message: "no supplier"},
+				},
+			},
+			expected: `2 packages checked: 3 fields required by the ntia profile (NTIA minimum elements for a software bill of materials) are missing (author: 1, supplier: 2)
+
+ELEMENT      FIELD     MESSAGE
+document     author    no author
+lib@2.0.0    supplier  no supplier
+other@1.0.0  supplier  no supplier
+`,
+		},
+		{
+			name:   "json",
+			output: "json",
+			report: compliance.Report{
+				Profile:  compliance.NTIA,
+				Packages: 1,
+				Gaps: []compliance.Gap{
+					{Element: "lib@2.0.0", PackageID: "abc", Field: "supplier", Message: "no supplier"},
+				},
+			},
+			expected: `{
+ "profile": "ntia",
+ "packages": 1,
+ "gaps": [
+  {
+   "element": "lib@2.0.0",
+   "packageId": "abc",
+   "field": "supplier",
+   "message": "no supplier"
+  }
+ ]
+}
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, writeReport(&buf, test.output, profile, test.report))
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
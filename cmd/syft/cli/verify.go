@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/cmd/syft/cli/verify"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/config"
+)
+
+const verifyExample = `  {{.appName}} {{.command}} --key cosign.pub yourrepo/yourimage:tag                         verify the SBOM attestation of the image was signed with the key, showing the SBOM
+  {{.appName}} {{.command}} --certificate-identity you@example.com --certificate-oidc-issuer https://accounts.google.com yourrepo/yourimage:tag -o spdx-json
+`
+
+func Verify(v *viper.Viper, app *config.Application, ro *options.RootOptions, po *options.PackagesOptions) *cobra.Command {
+	vo := options.VerifyOptions{}
+	cmd := &cobra.Command{
+		Use:   "verify [IMAGE]",
+		Short: "Verify the SBOM attestation of the given container image, showing the SBOM",
+		Long:  "Verify the signature of the SBOM attestation attached to a container image within a registry (against a public key, or the identity that signed a keyless attestation), writing the attested SBOM to the outputs",
+		Example: internal.Tprintf(verifyExample, map[string]interface{}{
+			"appName": internal.ApplicationName,
+			"command": "verify",
+		}),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := app.LoadAllValues(v, ro.Config); err != nil {
+				return fmt.Errorf("invalid application config: %w", err)
+			}
+			// configure logging for command
+			newLogWrapper(app)
+			logApplicationConfig(app)
+			return validateArgs(cmd, args)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app.CheckForAppUpdate {
+				checkForApplicationUpdate()
+			}
+			return verify.Run(cmd.Context(), app, args)
+		},
+	}
+
+	if err := vo.AddFlags(cmd, v); err != nil {
+		log.Fatal(err)
+	}
+
+	// the SBOM is written the same way as by the packages command
+	if err := po.AddFlags(cmd, v); err != nil {
+		log.Fatal(err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,210 @@
+package verify
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/sigstore/cosign/cmd/cosign/cli/fulcio"
+	sigopts "github.com/sigstore/cosign/cmd/cosign/cli/options"
+	"github.com/sigstore/cosign/cmd/cosign/cli/rekor"
+	"github.com/sigstore/cosign/pkg/cosign"
+	"github.com/sigstore/cosign/pkg/cosign/attestation"
+	"github.com/sigstore/cosign/pkg/oci"
+	sigs "github.com/sigstore/cosign/pkg/signature"
+
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// sbomPredicateTypes are the predicate types of the attestations generated by the attest command.
+var sbomPredicateTypes = map[string]struct{}{
+	"https://syft.dev/bom":                {},
+	in_toto.PredicateSPDX:                 {},
+	in_toto.PredicateCycloneDX:            {},
+	attestation.CosignCustomProvenanceV01: {},
+}
+
+// envelope is the DSSE envelope of a signed attestation.
+type envelope struct {
+	PayloadType string `json:"payloadType"`
+	Payload     string `json:"payload"`
+}
+
+// statement is an in-toto statement, keeping the predicate as-is to be decoded as an SBOM.
+type statement struct {
+	in_toto.StatementHeader
+	Predicate json.RawMessage `json:"predicate"`
+}
+
+// Run verifies the SBOM attestations attached to an image within a registry, writing the SBOM of the verified
+// attestation to the outputs.
+func Run(ctx context.Context, app *config.Application, args []string) error {
+	ref, err := name.ParseReference(args[0])
+	if err != nil {
+		return fmt.Errorf("verify command can only be used with images within a registry: %w", err)
+	}
+
+	predicateType := app.Verify.PredicateType
+	if predicateType != "" {
+		predicateType, err = sigopts.ParsePredicateType(predicateType)
+		if err != nil {
+			return err
+		}
+	}
+
+	co, err := checkOpts(ctx, app)
+	if err != nil {
+		return err
+	}
+
+	verified, _, err := cosign.VerifyImageAttestations(ctx, ref, co)
+	if err != nil {
+		return fmt.Errorf("unable to verify the attestations of image=%q: %w", ref, err)
+	}
+
+	s, err := decodeSBOM(verified, predicateType)
+	if err != nil {
+		return err
+	}
+
+	writer, err := options.MakeWriter(app.Outputs, app.File, app.OutputTemplatePath, app.OutputTemplateDir, app.Baseline, app.Archive)
+	if err != nil {
+		return err
+	}
+	err = writer.Write(*s)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// checkOpts returns how attestations are verified: signed with the configured public key, otherwise signed (keyless)
+// by the configured identity with a certificate issued by fulcio.
+func checkOpts(ctx context.Context, app *config.Application) (*cosign.CheckOpts, error) {
+	co := &cosign.CheckOpts{
+		// the subject of the attestation must be the image being verified
+		ClaimVerifier: cosign.IntotoSubjectClaimVerifier,
+	}
+
+	rekorClient, err := rekor.NewClient(app.Verify.RekorURL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create rekor client: %w", err)
+	}
+	co.RekorClient = rekorClient
+
+	if app.Verify.Key != "" {
+		co.SigVerifier, err = sigs.PublicKeyFromKeyRef(ctx, app.Verify.Key)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load public key: %w", err)
+		}
+		return co, nil
+	}
+
+	// note: any identity could have signed a keyless attestation, so the expected identity is required
+	if app.Verify.CertIdentity == "" || app.Verify.CertOIDCIssuer == "" {
+		return nil, fmt.Errorf("either a public key (--key) or the identity that signed the attestation (--certificate-identity and --certificate-oidc-issuer) is required")
+	}
+	co.Identities = []cosign.Identity{
+		{
+			Subject: app.Verify.CertIdentity,
+			Issuer:  app.Verify.CertOIDCIssuer,
+		},
+	}
+
+	co.RootCerts, err = fulcio.GetRoots()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get fulcio roots: %w", err)
+	}
+	co.IntermediateCerts, err = fulcio.GetIntermediates()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get fulcio intermediates: %w", err)
+	}
+	return co, nil
+}
+
+// decodeSBOM returns the SBOM of the verified attestations with the given predicate type (or any SBOM predicate type
+// when empty). When there are several, the SBOM of the most recently attached attestation is returned.
+func decodeSBOM(attestations []oci.Signature, predicateType string) (*sbom.SBOM, error) {
+	var found []*sbom.SBOM
+	for _, att := range attestations {
+		st, err := decodeStatement(att)
+		if err != nil {
+			return nil, err
+		}
+
+		if predicateType != "" && st.PredicateType != predicateType {
+			continue
+		}
+		if _, ok := sbomPredicateTypes[st.PredicateType]; predicateType == "" && !ok {
+			log.Debugf("skipping attestation with predicate type=%q", st.PredicateType)
+			continue
+		}
+
+		predicate := []byte(st.Predicate)
+		if st.PredicateType == attestation.CosignCustomProvenanceV01 {
+			// the SBOM is wrapped within a custom predicate
+			var custom attestation.CosignPredicate
+			if err := json.Unmarshal(predicate, &custom); err != nil {
+				return nil, fmt.Errorf("unable to decode custom predicate: %w", err)
+			}
+			switch data := custom.Data.(type) {
+			case string:
+				predicate = []byte(data)
+			default:
+				// the SBOM may also be embedded as a JSON document rather than as a string
+				if predicate, err = json.Marshal(data); err != nil {
+					return nil, fmt.Errorf("unable to read custom predicate data: %w", err)
+				}
+			}
+		}
+
+		s, _, err := syft.Decode(bytes.NewReader(predicate))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode SBOM of attestation with predicate type=%q: %w", st.PredicateType, err)
+		}
+		found = append(found, s)
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("no verified SBOM attestations found")
+	case 1:
+	default:
+		log.Infof("found %d verified SBOM attestations, using the most recent", len(found))
+	}
+	return found[len(found)-1], nil
+}
+
+func decodeStatement(att oci.Signature) (*statement, error) {
+	payload, err := att.Payload()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read attestation: %w", err)
+	}
+
+	var env envelope
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return nil, fmt.Errorf("unable to decode attestation envelope: %w", err)
+	}
+	if env.PayloadType != in_toto.PayloadType {
+		return nil, fmt.Errorf("unexpected attestation payload type=%q", env.PayloadType)
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decode attestation payload: %w", err)
+	}
+
+	var st statement
+	if err := json.Unmarshal(raw, &st); err != nil {
+		return nil, fmt.Errorf("unable to decode in-toto statement: %w", err)
+	}
+	return &st, nil
+}
@@ -0,0 +1,130 @@
+package verify
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/in-toto/in-toto-golang/in_toto"
+	"github.com/sigstore/cosign/pkg/cosign/attestation"
+	"github.com/sigstore/cosign/pkg/oci"
+	"github.com/sigstore/cosign/pkg/oci/static"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/formats/syftjson"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func newAttestation(t *testing.T, predicateType string, predicate interface{}) oci.Signature {
+	t.Helper()
+
+	raw, err := json.Marshal(in_toto.Statement{
+		StatementHeader: in_toto.StatementHeader{
+			Type:          in_toto.StatementInTotoV01,
+			PredicateType: predicateType,
+		},
+		Predicate: predicate,
+	})
+	require.NoError(t, err)
+
+	payload, err := json.Marshal(envelope{
+		PayloadType: in_toto.PayloadType,
+		Payload:     base64.StdEncoding.EncodeToString(raw),
+	})
+	require.NoError(t, err)
+
+	att, err := static.NewAttestation(payload)
+	require.NoError(t, err)
+	return att
+}
+
+func encodeSBOM(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := syft.Encode(sbom.SBOM{
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput: name,
+			},
+		},
+	}, syft.FormatByID(syftjson.ID))
+	require.NoError(t, err)
+	return b
+}
+
+func TestDecodeSBOM(t *testing.T) {
+	first := json.RawMessage(encodeSBOM(t, "first"))
+	second := json.RawMessage(encodeSBOM(t, "second"))
+	custom := attestation.CosignPredicate{Data: string(encodeSBOM(t, "custom"))}
+	provenance := map[string]interface{}{"builder": map[string]interface{}{"id": "builder"}}
+
+	tests := []struct {
+		name          string
+		attestations  []oci.Signature
+		predicateType string
+		want          string
+		wantErr       bool
+	}{
+		{
+			name: "SBOM attestation",
+			attestations: []oci.Signature{
+				newAttestation(t, "https://syft.dev/bom", first),
+			},
+			want: "first",
+		},
+		{
+			name: "most recent SBOM attestation",
+			attestations: []oci.Signature{
+				newAttestation(t, "https://syft.dev/bom", first),
+				newAttestation(t, "https://syft.dev/bom", second),
+			},
+			want: "second",
+		},
+		{
+			name: "custom predicate",
+			attestations: []oci.Signature{
+				newAttestation(t, attestation.CosignCustomProvenanceV01, custom),
+			},
+			want: "custom",
+		},
+		{
+			name: "requested predicate type",
+			attestations: []oci.Signature{
+				newAttestation(t, "https://syft.dev/bom", first),
+				newAttestation(t, "https://example.com/sbom/v1", second),
+			},
+			predicateType: "https://syft.dev/bom",
+			want:          "first",
+		},
+		{
+			name: "other attestations are skipped",
+			attestations: []oci.Signature{
+				newAttestation(t, "https://syft.dev/bom", first),
+				newAttestation(t, "https://slsa.dev/provenance/v0.2", provenance),
+			},
+			want: "first",
+		},
+		{
+			name: "no SBOM attestation",
+			attestations: []oci.Signature{
+				newAttestation(t, "https://slsa.dev/provenance/v0.2", provenance),
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, err := decodeSBOM(test.attestations, test.predicateType)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, s.Source.ImageMetadata.UserInput)
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/cmd/syft/cli/watch"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/config"
+)
+
+const watchExample = `  {{.appName}} {{.command}} dir:path/to/yourproject -o syft-json=sbom.json    write the SBOM of the directory to sbom.json each time the directory changes
+  {{.appName}} {{.command}} . -o spdx-json=sbom.spdx.json -o cyclonedx-json=sbom.cdx.json
+`
+
+//nolint:dupl
+func Watch(v *viper.Viper, app *config.Application, ro *options.RootOptions, po *options.PackagesOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch [SOURCE]",
+		Short: "Generate a package SBOM of a directory each time it changes",
+		Long:  "Watch a directory for changes, generating the SBOM again (cataloging only the files that changed) each time the contents of the directory change",
+		Example: internal.Tprintf(watchExample, map[string]interface{}{
+			"appName": internal.ApplicationName,
+			"command": "watch",
+		}),
+		Args: func(cmd *cobra.Command, args []string) error {
+			if err := app.LoadAllValues(v, ro.Config); err != nil {
+				return fmt.Errorf("invalid application config: %v", err)
+			}
+			// configure logging for command
+			newLogWrapper(app)
+			logApplicationConfig(app)
+			return validateArgs(cmd, args)
+		},
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if app.CheckForAppUpdate {
+				checkForApplicationUpdate()
+			}
+			return watch.Run(cmd.Context(), app, args)
+		},
+	}
+
+	err := po.AddFlags(cmd, v)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return cmd
+}
@@ -0,0 +1,165 @@
+package watch
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/anchore/syft/cmd/syft/cli/eventloop"
+	"github.com/anchore/syft/cmd/syft/cli/options"
+	"github.com/anchore/syft/cmd/syft/cli/packages"
+	"github.com/anchore/syft/internal/config"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source"
+)
+
+// debounce is how long the directory must be left unchanged before the SBOM is generated again, so that a burst of
+// changes (e.g. a git checkout) results in a single regeneration.
+const debounce = time.Second
+
+// Run generates the SBOM of a directory, then generates it again each time the contents of the directory change
+// until the process is interrupted.
+func Run(ctx context.Context, app *config.Application, args []string) error {
+	si, root, err := directoryInput(args[0])
+	if err != nil {
+		return err
+	}
+
+	// after the first scan, only the files that changed are cataloged again
+	app.Incremental = true
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch directory=%q: %w", root, err)
+	}
+	defer watcher.Close()
+
+	if err := addDirectories(watcher, root); err != nil {
+		return err
+	}
+
+	// the SBOM may be written within the directory, which must not be considered a change
+	ignored := make(map[string]struct{})
+	for _, file := range options.OutputFiles(app.Outputs, app.File, app.Archive) {
+		if path, err := filepath.Abs(file); err == nil {
+			ignored[path] = struct{}{}
+		}
+	}
+
+	if err := generate(ctx, app, *si); err != nil {
+		return err
+	}
+	log.Infof("watching directory=%q for changes", root)
+
+	signals := eventloop.SetupSignals()
+	var changed <-chan time.Time
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			if _, ok := ignored[event.Name]; ok {
+				continue
+			}
+			if event.Op&fsnotify.Create != 0 {
+				// directories are not watched recursively, so new directories are watched as they are created
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := addDirectories(watcher, event.Name); err != nil {
+						log.Warn(err)
+					}
+				}
+			}
+			log.Debugf("change detected: %s", event)
+			changed = time.After(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Warnf("error while watching directory=%q: %+v", root, err)
+		case <-changed:
+			changed = nil
+			if err := generate(ctx, app, *si); err != nil {
+				log.Errorf("unable to generate SBOM: %+v", err)
+			}
+		case <-signals:
+			return nil
+		}
+	}
+}
+
+// directoryInput returns the source input of the given directory along with its absolute path, which must be a local
+// directory (e.g. not a remote directory or git repository).
+func directoryInput(userInput string) (*source.Input, string, error) {
+	si, err := source.ParseInput(userInput, "", false)
+	if err != nil {
+		return nil, "", fmt.Errorf("could not generate source input for watch command: %w", err)
+	}
+	if si.Scheme != source.DirectoryScheme || si.SSH != nil {
+		return nil, "", fmt.Errorf("only local directories can be watched: %q", userInput)
+	}
+	if info, err := os.Stat(si.Location); err != nil || !info.IsDir() {
+		return nil, "", fmt.Errorf("only local directories can be watched: %q", userInput)
+	}
+
+	root, err := filepath.Abs(si.Location)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to determine absolute path of directory=%q: %w", si.Location, err)
+	}
+	return si, root, nil
+}
+
+// addDirectories watches the given directory and all directories below it.
+func addDirectories(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// note: directories that cannot be read are not watched, as they would not be cataloged either
+			log.Debugf("unable to watch path=%q: %+v", path, err)
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if err := watcher.Add(path); err != nil {
+			return fmt.Errorf("unable to watch directory=%q: %w", path, err)
+		}
+		return nil
+	})
+}
+
+// generate catalogs the directory and writes the SBOM to all outputs.
+func generate(ctx context.Context, app *config.Application, si source.Input) error {
+	start := time.Now()
+	src, cleanup, err := packages.NewSource(ctx, app, si)
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		return fmt.Errorf("failed to construct source from user input %q: %w", si.UserInput, err)
+	}
+
+	s, err := packages.Generate(ctx, src, app)
+	if err != nil {
+		return err
+	}
+
+	writer, err := options.MakeWriter(app.Outputs, app.File, app.OutputTemplatePath, app.OutputTemplateDir, app.Baseline, app.Archive)
+	if err != nil {
+		return err
+	}
+	err = writer.Write(*s)
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write SBOM: %w", err)
+	}
+
+	log.Infof("generated SBOM of directory=%q in %s", si.Location, time.Since(start))
+	return nil
+}
@@ -0,0 +1,84 @@
+package watch
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDirectoryInput(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, []byte("contents"), 0644))
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{
+			name:  "directory",
+			input: dir,
+		},
+		{
+			name:  "directory scheme",
+			input: "dir:" + dir,
+		},
+		{
+			name:    "file",
+			input:   file,
+			wantErr: true,
+		},
+		{
+			name:    "image",
+			input:   "alpine:latest",
+			wantErr: true,
+		},
+		{
+			name:    "remote directory",
+			input:   "ssh://user@host/path",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, root, err := directoryInput(test.input)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, dir, root)
+		})
+	}
+}
+
+func TestAddDirectories(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "a", "b")
+	require.NoError(t, os.MkdirAll(nested, 0755))
+
+	watcher, err := fsnotify.NewWatcher()
+	require.NoError(t, err)
+	defer watcher.Close()
+
+	require.NoError(t, addDirectories(watcher, dir))
+
+	// changes within nested directories are seen
+	path := filepath.Join(nested, "file")
+	require.NoError(t, os.WriteFile(path, []byte("contents"), 0644))
+
+	select {
+	case event := <-watcher.Events:
+		assert.Equal(t, path, event.Name)
+	case err := <-watcher.Errors:
+		t.Fatal(err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("no change seen within a nested directory")
+	}
+}
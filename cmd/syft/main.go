@@ -1,18 +1,25 @@
 package main
 
 import (
+	"errors"
 	"log"
+	"os"
 
 	"github.com/anchore/syft/cmd/syft/cli"
 )
 
 func main() {
-	cli, err := cli.New()
+	cmd, err := cli.New()
 	if err != nil {
 		log.Fatalf("error during command construction: %v", err)
 	}
 
-	if err := cli.Execute(); err != nil {
+	if err := cmd.Execute(); err != nil {
+		var exitErr *cli.ExitError
+		if errors.As(err, &exitErr) {
+			log.Printf("error during command execution: %v", err)
+			os.Exit(exitErr.Code)
+		}
 		log.Fatalf("error during command execution: %v", err)
 	}
 }
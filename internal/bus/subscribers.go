@@ -0,0 +1,28 @@
+package bus
+
+import (
+	"context"
+
+	"github.com/wagoodman/go-partybus"
+)
+
+type subscribersKey struct{}
+
+// WithSubscriber returns a context carrying the given function, which is called with every event published with the
+// context (see PublishWithContext), along with any functions carried by the parent context. This exists only for
+// library users (see syft.Subscribe): as with the bus itself, the library must not subscribe to its own events.
+func WithSubscriber(ctx context.Context, fn func(partybus.Event)) context.Context {
+	parent, _ := ctx.Value(subscribersKey{}).([]func(partybus.Event))
+	fns := append(append([]func(partybus.Event){}, parent...), fn)
+	return context.WithValue(ctx, subscribersKey{}, fns)
+}
+
+// PublishWithContext publishes an event onto the bus (see Publish) and to the subscribers carried by the given context,
+// so that the events of one cataloging run are only received by the subscribers of that run.
+func PublishWithContext(ctx context.Context, event partybus.Event) {
+	Publish(event)
+	fns, _ := ctx.Value(subscribersKey{}).([]func(partybus.Event))
+	for _, fn := range fns {
+		fn(event)
+	}
+}
@@ -16,7 +16,11 @@ import (
 	"github.com/anchore/go-logger"
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/log"
+	syftPkg "github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/pkg/cataloger/binary"
+	"github.com/anchore/syft/syft/pkg/cataloger/cache"
+	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
 )
 
 var (
@@ -39,22 +43,52 @@ type Application struct {
 	Verbosity  uint   `yaml:"verbosity,omitempty" json:"verbosity" mapstructure:"verbosity"`
 	// -q, indicates to not show any status output to stderr (ETUI or logging UI)
 	Quiet              bool               `yaml:"quiet" json:"quiet" mapstructure:"quiet"`
+	Progress           string             `yaml:"progress" json:"progress" mapstructure:"progress"`                                     // --progress, how to show progress (auto or json)
 	Outputs            []string           `yaml:"output" json:"output" mapstructure:"output"`                                           // -o, the format to use for output
 	OutputTemplatePath string             `yaml:"output-template-path" json:"output-template-path" mapstructure:"output-template-path"` // -t template file to use for output
+	Baseline           string             `yaml:"baseline" json:"baseline" mapstructure:"baseline"`                                     // --baseline, a previous SBOM of the same source that the markdown summary lists new packages against
+	OutputTemplateDir  string             `yaml:"output-template-dir" json:"output-template-dir" mapstructure:"output-template-dir"`    // --template-dir, directory of templates the template file can include
 	File               string             `yaml:"file" json:"file" mapstructure:"file"`                                                 // --file, the file to write report output to
+	Archive            string             `yaml:"archive" json:"archive" mapstructure:"archive"`                                        // --archive, a tar or zip archive to bundle the output files into
 	CheckForAppUpdate  bool               `yaml:"check-for-app-update" json:"check-for-app-update" mapstructure:"check-for-app-update"` // whether to check for an application update on start up or not
 	Dev                development        `yaml:"dev" json:"dev" mapstructure:"dev"`
 	Log                logging            `yaml:"log" json:"log" mapstructure:"log"` // all logging-related options
 	Catalogers         []string           `yaml:"catalogers" json:"catalogers" mapstructure:"catalogers"`
+	Select             []string           `yaml:"select" json:"select" mapstructure:"select"`
 	Package            pkg                `yaml:"package" json:"package" mapstructure:"package"`
 	FileMetadata       FileMetadata       `yaml:"file-metadata" json:"file-metadata" mapstructure:"file-metadata"`
 	FileClassification fileClassification `yaml:"file-classification" json:"file-classification" mapstructure:"file-classification"`
 	FileContents       fileContents       `yaml:"file-contents" json:"file-contents" mapstructure:"file-contents"`
+	FileELF            fileELF            `yaml:"file-elf" json:"file-elf" mapstructure:"file-elf"`
+	FileExecutable     fileExecutable     `yaml:"file-executable" json:"file-executable" mapstructure:"file-executable"`
 	Secrets            secrets            `yaml:"secrets" json:"secrets" mapstructure:"secrets"`
 	Registry           registry           `yaml:"registry" json:"registry" mapstructure:"registry"`
+	Containerd         containerd         `yaml:"containerd" json:"containerd" mapstructure:"containerd"`
+	SSH                ssh                `yaml:"ssh" json:"ssh" mapstructure:"ssh"`
 	Exclusions         []string           `yaml:"exclude" json:"exclude" mapstructure:"exclude"`
 	Attest             attest             `yaml:"attest" json:"attest" mapstructure:"attest"`
 	Platform           string             `yaml:"platform" json:"platform" mapstructure:"platform"`
+	AllPlatforms       bool               `yaml:"all-platforms" json:"all-platforms" mapstructure:"all-platforms"`
+	BaseImage          string             `yaml:"base-image" json:"base-image" mapstructure:"base-image"`
+	ExcludeBaseImage   bool               `yaml:"exclude-base-image" json:"exclude-base-image" mapstructure:"exclude-base-image"`
+	Provenance         bool               `yaml:"provenance" json:"provenance" mapstructure:"provenance"` // --provenance, record the SLSA provenance attached to an image within a registry
+	Plugins            plugins            `yaml:"plugins" json:"plugins" mapstructure:"plugins"`
+	Parallelism        int                `yaml:"parallelism" json:"parallelism" mapstructure:"parallelism"` // --parallelism, the number of catalogers that may run concurrently
+	Cache              catalogCache       `yaml:"cache" json:"cache" mapstructure:"cache"`
+	Incremental        bool               `yaml:"incremental" json:"incremental" mapstructure:"incremental"` // --incremental, only catalog the files of a directory that changed since the previous scan
+	Limits             limits             `yaml:"limits" json:"limits" mapstructure:"limits"`
+	MetricsFile        string             `yaml:"metrics-file" json:"metrics-file" mapstructure:"metrics-file"`                // --metrics-file, the file to write the measurements of each cataloger to
+	SummaryFile        string             `yaml:"summary-file" json:"summary-file" mapstructure:"summary-file"`                // --summary-file, the file to write the outcome of the scan to (status, exit code, counts, and warnings)
+	UseExistingSBOM    bool               `yaml:"use-existing-sbom" json:"use-existing-sbom" mapstructure:"use-existing-sbom"` // --use-existing-sbom, use the SBOM attached to an image within a registry (when there is one) instead of cataloging the image
+	Serve              serve              `yaml:"serve" json:"serve" mapstructure:"serve"`
+	Verify             verify             `yaml:"verify" json:"verify" mapstructure:"verify"`
+	Validate           validate           `yaml:"validate" json:"validate" mapstructure:"validate"`
+	Convert            convert            `yaml:"convert" json:"convert" mapstructure:"convert"`
+	Policy             policy             `yaml:"policy" json:"policy" mapstructure:"policy"`
+	// Metrics records the measurements of each cataloger when a metrics file is requested (set at runtime)
+	Metrics *cataloger.Metrics `yaml:"-" json:"-" mapstructure:"-"`
+	// Instrumentation reports cataloging as traces and metrics when running as a service (set at runtime)
+	Instrumentation *cataloger.Instrumentation `yaml:"-" json:"-" mapstructure:"-"`
 }
 
 func (cfg Application) ToCatalogerConfig() cataloger.Config {
@@ -64,7 +98,43 @@ func (cfg Application) ToCatalogerConfig() cataloger.Config {
 			IncludeUnindexedArchives: cfg.Package.SearchUnindexedArchives,
 			Scope:                    cfg.Package.Cataloger.ScopeOpt,
 		},
-		Catalogers: cfg.Catalogers,
+		Catalogers:            cfg.Catalogers,
+		Select:                cfg.Select,
+		Plugins:               cfg.Plugins.toConfig(),
+		ExcludeBaseImage:      cfg.ExcludeBaseImage,
+		ArchiveDigests:        cfg.FileMetadata.DigestsOpt,
+		Parallelism:           cfg.Parallelism,
+		Incremental:           cfg.Incremental,
+		MaxFileSize:           cfg.Limits.MaxFileSizeBytes,
+		MaxFilesPerCataloger:  cfg.Limits.MaxFilesPerCataloger,
+		MaxNestedArchiveDepth: cfg.Limits.MaxNestedArchiveDepth,
+		MaxNestedArchiveSize:  cfg.Limits.MaxNestedArchiveSizeBytes,
+		Timeout:               cfg.Limits.Timeout,
+		NestedImageDepth:      cfg.Package.NestedImageDepth,
+		IDScheme:              cfg.Package.IDSchemeOpt,
+		Enrichment:            cfg.Package.Enrichment.toConfig(),
+		Filter:                cfg.Package.FilterOpt,
+		Metrics:               cfg.Metrics,
+		Instrumentation:       cfg.Instrumentation,
+		Cache: cache.Config{
+			Enabled:   cfg.Cache.Enabled,
+			Directory: cfg.Cache.Dir,
+			TTL:       cfg.Cache.TTL,
+		},
+		Binary: binary.Config{
+			AdditionalClassifiers: cfg.Package.Binary.Resolved,
+			MaxFileSize:           cfg.Package.Binary.MaxFileSizeBytes,
+		},
+		JavaScript: javascript.Config{
+			SearchRemoteLicenses: cfg.Package.JavaScript.SearchRemoteLicenses,
+			NPMRegistry:          cfg.Package.JavaScript.NPMRegistry,
+		},
+		Deduplication: syftPkg.DeduplicationConfig{
+			Enabled:               cfg.Package.Deduplicate.Enabled,
+			Identity:              cfg.Package.Deduplicate.IdentityOpt,
+			AcrossTypes:           cfg.Package.Deduplicate.AcrossTypes,
+			RequireSharedLocation: cfg.Package.Deduplicate.RequireSharedLocation,
+		},
 	}
 }
 
@@ -111,8 +181,11 @@ func (cfg *Application) parseConfigValues() error {
 
 	// parse application config options
 	for _, optionFn := range []func() error{
+		cfg.parseCatalogerSelection,
 		cfg.parseLogLevelOption,
 		cfg.parseFile,
+		cfg.parseParallelism,
+		cfg.parseProgress,
 	} {
 		if err := optionFn(); err != nil {
 			return err
@@ -134,6 +207,30 @@ func (cfg *Application) parseConfigValues() error {
 	return nil
 }
 
+func (cfg *Application) parseCatalogerSelection() error {
+	// the deprecated catalogers option replaces the selection entirely, so the two cannot be combined
+	if len(cfg.Catalogers) > 0 && len(cfg.Select) > 0 {
+		return fmt.Errorf("catalogers (deprecated) cannot be combined with select: use select only")
+	}
+	return nil
+}
+
+func (cfg *Application) parseParallelism() error {
+	if cfg.Parallelism < 1 {
+		return fmt.Errorf("parallelism must be at least 1 (got %d)", cfg.Parallelism)
+	}
+	return nil
+}
+
+func (cfg *Application) parseProgress() error {
+	switch cfg.Progress {
+	case "auto", "json":
+	default:
+		return fmt.Errorf("bad progress value %q: must be one of auto, json", cfg.Progress)
+	}
+	return nil
+}
+
 func (cfg *Application) parseLogLevelOption() error {
 	switch {
 	case cfg.Quiet:
@@ -177,8 +274,20 @@ func (cfg *Application) parseFile() error {
 func loadDefaultValues(v *viper.Viper) {
 	// set the default values for primitive fields in this struct
 	v.SetDefault("quiet", false)
+	v.SetDefault("progress", "auto")
 	v.SetDefault("check-for-app-update", true)
 	v.SetDefault("catalogers", nil)
+	v.SetDefault("all-platforms", false)
+	v.SetDefault("base-image", "")
+	v.SetDefault("exclude-base-image", false)
+	v.SetDefault("parallelism", 1)
+	v.SetDefault("incremental", false)
+	v.SetDefault("metrics-file", "")
+	v.SetDefault("summary-file", "")
+	v.SetDefault("baseline", "")
+	v.SetDefault("archive", "")
+	v.SetDefault("use-existing-sbom", false)
+	v.SetDefault("provenance", false)
 
 	// for each field in the configuration struct, see if the field implements the defaultValueLoader interface and invoke it if it does
 	value := reflect.ValueOf(Application{})
@@ -25,6 +25,8 @@ type attest struct {
 	OIDCIssuer               string `yaml:"oidc_issuer" json:"oidcIssuer" mapstructure:"oidc_issuer"`
 	OIDCClientID             string `yaml:"oidc_client_id" json:"oidcClientId" mapstructure:"oidc_client_id"`
 	OIDCRedirectURL          string `yaml:"oidc_redirect_url" json:"OIDCRedirectURL" mapstructure:"oidc_redirect_url"`
+	PredicateType            string `yaml:"predicate_type" json:"predicateType" mapstructure:"predicate_type"` // same as --predicate-type, derived from the output format when empty
+	Referrer                 bool   `yaml:"referrer" json:"referrer" mapstructure:"referrer"`                  // same as --referrer
 }
 
 func (cfg *attest) parseConfigValues() error {
@@ -53,4 +55,6 @@ func (cfg attest) loadDefaultValues(v *viper.Viper) {
 	v.SetDefault("attest.rekor_url", options.DefaultRekorURL)
 	v.SetDefault("attest.oidc_issuer", options.DefaultOIDCIssuerURL)
 	v.SetDefault("attest.oidc_client_id", "sigstore")
+	v.SetDefault("attest.predicate_type", "")
+	v.SetDefault("attest.referrer", false)
 }
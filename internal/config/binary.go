@@ -0,0 +1,58 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/syft/pkg/cataloger/binary"
+)
+
+type binaryClassifiers struct {
+	Classifiers     []binary.Classifier `yaml:"classifiers" json:"classifiers" mapstructure:"classifiers"`
+	ClassifierFiles []string            `yaml:"classifier-files" json:"classifier-files" mapstructure:"classifier-files"`
+	MaxFileSize     string              `yaml:"max-file-size" json:"max-file-size" mapstructure:"max-file-size"`
+	// all user-defined classifiers, both inline and those read from the classifier files
+	Resolved []binary.Classifier `yaml:"-" json:"-" mapstructure:"-"`
+	// the max file size in bytes (0 when there is no limit)
+	MaxFileSizeBytes int64 `yaml:"-" json:"-" mapstructure:"-"`
+}
+
+func (cfg binaryClassifiers) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("package.binary.classifier-files", []string{})
+	v.SetDefault("package.binary.max-file-size", "")
+}
+
+func (cfg *binaryClassifiers) parseConfigValues() error {
+	cfg.MaxFileSizeBytes = 0
+	if cfg.MaxFileSize != "" {
+		size, err := humanize.ParseBytes(cfg.MaxFileSize)
+		if err != nil {
+			return fmt.Errorf("bad binary max-file-size value %q: %w", cfg.MaxFileSize, err)
+		}
+		cfg.MaxFileSizeBytes = int64(size)
+	}
+
+	cfg.Resolved = nil
+	for _, c := range cfg.Classifiers {
+		if err := c.Validate(); err != nil {
+			return err
+		}
+		cfg.Resolved = append(cfg.Resolved, c)
+	}
+
+	for _, f := range cfg.ClassifierFiles {
+		expandedPath, err := homedir.Expand(f)
+		if err != nil {
+			return err
+		}
+		classifiers, err := binary.ReadClassifiersFile(expandedPath)
+		if err != nil {
+			return err
+		}
+		cfg.Resolved = append(cfg.Resolved, classifiers...)
+	}
+	return nil
+}
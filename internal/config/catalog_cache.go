@@ -0,0 +1,46 @@
+package config
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/internal"
+)
+
+type catalogCache struct {
+	// cache the results of cataloging image layers, reusing them for images that share the same layers
+	Enabled bool `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	// the directory where cached results are stored
+	Dir string `yaml:"dir" json:"dir" mapstructure:"dir"`
+	// how long cached results are used after they are written (0 means cached results never expire)
+	TTL time.Duration `yaml:"ttl" json:"ttl" mapstructure:"ttl"`
+}
+
+func (cfg catalogCache) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("cache.enabled", false)
+	v.SetDefault("cache.dir", path.Join(xdg.CacheHome, internal.ApplicationName, "catalog"))
+	v.SetDefault("cache.ttl", 7*24*time.Hour)
+}
+
+func (cfg *catalogCache) parseConfigValues() error {
+	if cfg.TTL < 0 {
+		return fmt.Errorf("cache TTL must not be negative (got %s)", cfg.TTL)
+	}
+	if cfg.Dir == "" {
+		if cfg.Enabled {
+			return fmt.Errorf("cache is enabled without a cache directory")
+		}
+		return nil
+	}
+	dir, err := homedir.Expand(cfg.Dir)
+	if err != nil {
+		return err
+	}
+	cfg.Dir = dir
+	return nil
+}
@@ -0,0 +1,15 @@
+package config
+
+import "github.com/spf13/viper"
+
+// containerd are the options used for images given with the "containerd:" scheme. Empty values defer to the
+// CONTAINERD_ADDRESS and CONTAINERD_NAMESPACE environment variables (the same as used by ctr) and then to the defaults.
+type containerd struct {
+	Address   string `yaml:"address" json:"address" mapstructure:"address"`
+	Namespace string `yaml:"namespace" json:"namespace" mapstructure:"namespace"`
+}
+
+func (cfg containerd) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("containerd.address", "")
+	v.SetDefault("containerd.namespace", "")
+}
@@ -0,0 +1,11 @@
+package config
+
+import "github.com/spf13/viper"
+
+type convert struct {
+	Strict bool `yaml:"strict" json:"strict" mapstructure:"strict"` // --strict, reject input SBOMs that do not conform to the JSON schema of their format
+}
+
+func (cfg convert) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("convert.strict", false)
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+
+	syftPkg "github.com/anchore/syft/syft/pkg"
+)
+
+type deduplicate struct {
+	Enabled               bool                          `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	Identity              string                        `yaml:"identity" json:"identity" mapstructure:"identity"`
+	AcrossTypes           bool                          `yaml:"across-types" json:"across-types" mapstructure:"across-types"`
+	RequireSharedLocation bool                          `yaml:"require-shared-location" json:"require-shared-location" mapstructure:"require-shared-location"`
+	IdentityOpt           syftPkg.DeduplicationIdentity `yaml:"-" json:"-"`
+}
+
+func (cfg deduplicate) loadDefaultValues(v *viper.Viper) {
+	c := syftPkg.DefaultDeduplicationConfig()
+	v.SetDefault("package.deduplicate.enabled", c.Enabled)
+	v.SetDefault("package.deduplicate.identity", string(c.Identity))
+	v.SetDefault("package.deduplicate.across-types", c.AcrossTypes)
+	v.SetDefault("package.deduplicate.require-shared-location", c.RequireSharedLocation)
+}
+
+func (cfg *deduplicate) parseConfigValues() error {
+	identity, err := syftPkg.ParseDeduplicationIdentity(cfg.Identity)
+	if err != nil {
+		return err
+	}
+	cfg.IdentityOpt = identity
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"time"
+
+	"github.com/adrg/xdg"
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/pkg/enrich"
+)
+
+type enrichment struct {
+	// look up the supplier, homepage, repository, and publish date of packages within the npm, PyPI, and crates.io registries
+	Enabled     bool   `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	NPMRegistry string `yaml:"npm-registry" json:"npm-registry" mapstructure:"npm-registry"`
+	PyPI        string `yaml:"pypi" json:"pypi" mapstructure:"pypi"`
+	CratesIO    string `yaml:"crates-io" json:"crates-io" mapstructure:"crates-io"`
+	// the directory where the details looked up are stored for reuse
+	CacheDir string `yaml:"cache-dir" json:"cache-dir" mapstructure:"cache-dir"`
+	// how long the stored details are used after they are looked up (0 means the details never expire)
+	CacheTTL time.Duration `yaml:"cache-ttl" json:"cache-ttl" mapstructure:"cache-ttl"`
+}
+
+func (cfg enrichment) loadDefaultValues(v *viper.Viper) {
+	c := enrich.DefaultConfig()
+	v.SetDefault("package.enrichment.enabled", c.Enabled)
+	v.SetDefault("package.enrichment.npm-registry", c.NPMRegistry)
+	v.SetDefault("package.enrichment.pypi", c.PyPI)
+	v.SetDefault("package.enrichment.crates-io", c.CratesIO)
+	v.SetDefault("package.enrichment.cache-dir", path.Join(xdg.CacheHome, internal.ApplicationName, "registry"))
+	v.SetDefault("package.enrichment.cache-ttl", c.CacheTTL)
+}
+
+func (cfg *enrichment) parseConfigValues() error {
+	for name, value := range map[string]string{"npm-registry": cfg.NPMRegistry, "pypi": cfg.PyPI, "crates-io": cfg.CratesIO} {
+		if value == "" {
+			continue
+		}
+		u, err := url.Parse(value)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("bad package enrichment %s value %q: must be an http or https URL", name, value)
+		}
+	}
+	if cfg.CacheTTL < 0 {
+		return fmt.Errorf("package enrichment cache-ttl must not be negative (got %s)", cfg.CacheTTL)
+	}
+	if cfg.CacheDir == "" {
+		return nil
+	}
+	dir, err := homedir.Expand(cfg.CacheDir)
+	if err != nil {
+		return err
+	}
+	cfg.CacheDir = dir
+	return nil
+}
+
+func (cfg enrichment) toConfig() enrich.Config {
+	c := enrich.DefaultConfig()
+	c.Enabled = cfg.Enabled
+	c.NPMRegistry = cfg.NPMRegistry
+	c.PyPI = cfg.PyPI
+	c.CratesIO = cfg.CratesIO
+	c.CacheDirectory = cfg.CacheDir
+	c.CacheTTL = cfg.CacheTTL
+	return c
+}
@@ -1,16 +1,21 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/spf13/viper"
 
 	"github.com/anchore/syft/internal/file"
+	syftFile "github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/source"
 )
 
 type fileContents struct {
-	Cataloger          catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
-	SkipFilesAboveSize int64            `yaml:"skip-files-above-size" json:"skip-files-above-size" mapstructure:"skip-files-above-size"`
-	Globs              []string         `yaml:"globs" json:"globs" mapstructure:"globs"`
+	Cataloger          catalogerOptions          `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
+	SkipFilesAboveSize int64                     `yaml:"skip-files-above-size" json:"skip-files-above-size" mapstructure:"skip-files-above-size"`
+	Globs              []string                  `yaml:"globs" json:"globs" mapstructure:"globs"`
+	Encoding           string                    `yaml:"encoding" json:"encoding" mapstructure:"encoding"`
+	EncodingOpt        syftFile.ContentsEncoding `yaml:"-" json:"-"`
 }
 
 func (cfg fileContents) loadDefaultValues(v *viper.Viper) {
@@ -18,8 +23,15 @@ func (cfg fileContents) loadDefaultValues(v *viper.Viper) {
 	v.SetDefault("file-contents.cataloger.scope", source.SquashedScope)
 	v.SetDefault("file-contents.skip-files-above-size", 1*file.MB)
 	v.SetDefault("file-contents.globs", []string{})
+	v.SetDefault("file-contents.encoding", syftFile.Base64ContentsEncoding)
 }
 
 func (cfg *fileContents) parseConfigValues() error {
+	encoding := syftFile.ParseContentsEncoding(cfg.Encoding)
+	if encoding == syftFile.UnknownContentsEncoding {
+		return fmt.Errorf("bad file contents encoding value %q (options: %v)", cfg.Encoding, syftFile.AllContentsEncodings)
+	}
+	cfg.EncodingOpt = encoding
+
 	return cfg.Cataloger.parseConfigValues()
 }
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+type fileELF struct {
+	Cataloger catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
+}
+
+func (cfg fileELF) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("file-elf.cataloger.enabled", catalogerEnabledDefault)
+	v.SetDefault("file-elf.cataloger.scope", source.SquashedScope)
+}
+
+func (cfg *fileELF) parseConfigValues() error {
+	return cfg.Cataloger.parseConfigValues()
+}
@@ -0,0 +1,20 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+type fileExecutable struct {
+	Cataloger catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
+}
+
+func (cfg fileExecutable) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("file-executable.cataloger.enabled", catalogerEnabledDefault)
+	v.SetDefault("file-executable.cataloger.scope", source.SquashedScope)
+}
+
+func (cfg *fileExecutable) parseConfigValues() error {
+	return cfg.Cataloger.parseConfigValues()
+}
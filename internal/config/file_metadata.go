@@ -1,14 +1,19 @@
 package config
 
 import (
+	"crypto"
+	"strings"
+
 	"github.com/spf13/viper"
 
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/source"
 )
 
 type FileMetadata struct {
-	Cataloger catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
-	Digests   []string         `yaml:"digests" json:"digests" mapstructure:"digests"`
+	Cataloger  catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
+	Digests    []string         `yaml:"digests" json:"digests" mapstructure:"digests"`
+	DigestsOpt []crypto.Hash    `yaml:"-" json:"-"`
 }
 
 func (cfg FileMetadata) loadDefaultValues(v *viper.Viper) {
@@ -18,5 +23,19 @@ func (cfg FileMetadata) loadDefaultValues(v *viper.Viper) {
 }
 
 func (cfg *FileMetadata) parseConfigValues() error {
+	var digests []string
+	for _, d := range cfg.Digests {
+		for _, f := range strings.Split(d, ",") {
+			digests = append(digests, strings.TrimSpace(f))
+		}
+	}
+	cfg.Digests = digests
+
+	hashes, err := file.DigestHashes(cfg.Digests)
+	if err != nil {
+		return err
+	}
+	cfg.DigestsOpt = hashes
+
 	return cfg.Cataloger.parseConfigValues()
 }
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
+)
+
+type javascriptOptions struct {
+	SearchRemoteLicenses bool   `yaml:"search-remote-licenses" json:"search-remote-licenses" mapstructure:"search-remote-licenses"`
+	NPMRegistry          string `yaml:"npm-registry" json:"npm-registry" mapstructure:"npm-registry"`
+}
+
+func (cfg javascriptOptions) loadDefaultValues(v *viper.Viper) {
+	c := javascript.DefaultConfig()
+	v.SetDefault("package.javascript.search-remote-licenses", c.SearchRemoteLicenses)
+	v.SetDefault("package.javascript.npm-registry", c.NPMRegistry)
+}
+
+func (cfg *javascriptOptions) parseConfigValues() error {
+	if cfg.NPMRegistry == "" {
+		return nil
+	}
+	u, err := url.Parse(cfg.NPMRegistry)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("bad javascript npm-registry value %q: must be an http or https URL", cfg.NPMRegistry)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/syft/pkg/cataloger"
+)
+
+type limits struct {
+	// the largest file that catalogers are given, e.g. "100MB" (empty means there is no limit)
+	MaxFileSize string `yaml:"max-file-size" json:"max-file-size" mapstructure:"max-file-size"`
+	// the most files that each cataloger is given (0 means there is no limit)
+	MaxFilesPerCataloger int `yaml:"max-files-per-cataloger" json:"max-files-per-cataloger" mapstructure:"max-files-per-cataloger"`
+	// how long cataloging may take before returning the packages found so far (0 means there is no limit)
+	Timeout time.Duration `yaml:"timeout" json:"timeout" mapstructure:"timeout"` // --timeout
+	// how many levels of archives nested within a cataloged archive are searched (0 means there is no limit)
+	MaxNestedArchiveDepth int `yaml:"max-nested-archive-depth" json:"max-nested-archive-depth" mapstructure:"max-nested-archive-depth"`
+	// how much may be extracted from the archives nested within a cataloged archive, e.g. "1GB" (empty means there is no limit)
+	MaxNestedArchiveSize string `yaml:"max-nested-archive-size" json:"max-nested-archive-size" mapstructure:"max-nested-archive-size"`
+	// the max file size in bytes (0 when there is no limit)
+	MaxFileSizeBytes int64 `yaml:"-" json:"-" mapstructure:"-"`
+	// the max nested archive size in bytes (0 when there is no limit)
+	MaxNestedArchiveSizeBytes int64 `yaml:"-" json:"-" mapstructure:"-"`
+}
+
+func (cfg limits) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("limits.max-file-size", "")
+	v.SetDefault("limits.max-files-per-cataloger", 0)
+	v.SetDefault("limits.timeout", time.Duration(0))
+	v.SetDefault("limits.max-nested-archive-depth", cataloger.DefaultMaxNestedArchiveDepth)
+	v.SetDefault("limits.max-nested-archive-size", humanize.Bytes(cataloger.DefaultMaxNestedArchiveSize))
+}
+
+func (cfg *limits) parseConfigValues() error {
+	cfg.MaxFileSizeBytes = 0
+	if cfg.MaxFileSize != "" {
+		size, err := humanize.ParseBytes(cfg.MaxFileSize)
+		if err != nil {
+			return fmt.Errorf("bad limits max-file-size value %q: %w", cfg.MaxFileSize, err)
+		}
+		cfg.MaxFileSizeBytes = int64(size)
+	}
+	cfg.MaxNestedArchiveSizeBytes = 0
+	if cfg.MaxNestedArchiveSize != "" {
+		size, err := humanize.ParseBytes(cfg.MaxNestedArchiveSize)
+		if err != nil {
+			return fmt.Errorf("bad limits max-nested-archive-size value %q: %w", cfg.MaxNestedArchiveSize, err)
+		}
+		cfg.MaxNestedArchiveSizeBytes = int64(size)
+	}
+	if cfg.MaxFilesPerCataloger < 0 {
+		return fmt.Errorf("limits max-files-per-cataloger must not be negative (got %d)", cfg.MaxFilesPerCataloger)
+	}
+	if cfg.MaxNestedArchiveDepth < 0 {
+		return fmt.Errorf("limits max-nested-archive-depth must not be negative (got %d)", cfg.MaxNestedArchiveDepth)
+	}
+	if cfg.Timeout < 0 {
+		return fmt.Errorf("limits timeout must not be negative (got %s)", cfg.Timeout)
+	}
+	return nil
+}
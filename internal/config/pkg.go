@@ -1,15 +1,32 @@
 package config
 
 import (
+	"fmt"
+
 	"github.com/spf13/viper"
 
+	syftPkg "github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger"
 )
 
 type pkg struct {
-	Cataloger               catalogerOptions `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
-	SearchUnindexedArchives bool             `yaml:"search-unindexed-archives" json:"search-unindexed-archives" mapstructure:"search-unindexed-archives"`
-	SearchIndexedArchives   bool             `yaml:"search-indexed-archives" json:"search-indexed-archives" mapstructure:"search-indexed-archives"`
+	Cataloger               catalogerOptions  `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
+	SearchUnindexedArchives bool              `yaml:"search-unindexed-archives" json:"search-unindexed-archives" mapstructure:"search-unindexed-archives"`
+	SearchIndexedArchives   bool              `yaml:"search-indexed-archives" json:"search-indexed-archives" mapstructure:"search-indexed-archives"`
+	Binary                  binaryClassifiers `yaml:"binary" json:"binary" mapstructure:"binary"`
+	JavaScript              javascriptOptions `yaml:"javascript" json:"javascript" mapstructure:"javascript"`
+	Deduplicate             deduplicate       `yaml:"deduplicate" json:"deduplicate" mapstructure:"deduplicate"`
+	Enrichment              enrichment        `yaml:"enrichment" json:"enrichment" mapstructure:"enrichment"`
+	Include                 []string          `yaml:"include" json:"include" mapstructure:"include"`
+	Exclude                 []string          `yaml:"exclude" json:"exclude" mapstructure:"exclude"`
+	MinConfidence           string            `yaml:"min-confidence" json:"min-confidence" mapstructure:"min-confidence"`
+	FilterOpt               *syftPkg.Filter   `yaml:"-" json:"-"`
+	// how many levels of container images stored within the scanned filesystem are cataloged (0 means the images
+	// are cataloged as packages, but not the packages within them)
+	NestedImageDepth int `yaml:"nested-image-depth" json:"nested-image-depth" mapstructure:"nested-image-depth"`
+	// the version of the algorithm deriving package IDs (see syftPkg.IDScheme)
+	IDScheme    string           `yaml:"id-scheme" json:"id-scheme" mapstructure:"id-scheme"`
+	IDSchemeOpt syftPkg.IDScheme `yaml:"-" json:"-"`
 }
 
 func (cfg pkg) loadDefaultValues(v *viper.Viper) {
@@ -17,8 +34,48 @@ func (cfg pkg) loadDefaultValues(v *viper.Viper) {
 	c := cataloger.DefaultSearchConfig()
 	v.SetDefault("package.search-unindexed-archives", c.IncludeUnindexedArchives)
 	v.SetDefault("package.search-indexed-archives", c.IncludeIndexedArchives)
+	cfg.Binary.loadDefaultValues(v)
+	cfg.JavaScript.loadDefaultValues(v)
+	cfg.Deduplicate.loadDefaultValues(v)
+	cfg.Enrichment.loadDefaultValues(v)
+	v.SetDefault("package.nested-image-depth", 0)
+	v.SetDefault("package.id-scheme", string(syftPkg.DefaultIDScheme))
+	v.SetDefault("package.include", []string{})
+	v.SetDefault("package.exclude", []string{})
+	v.SetDefault("package.min-confidence", "")
 }
 
 func (cfg *pkg) parseConfigValues() error {
-	return cfg.Cataloger.parseConfigValues()
+	if err := cfg.Cataloger.parseConfigValues(); err != nil {
+		return err
+	}
+	if err := cfg.Binary.parseConfigValues(); err != nil {
+		return err
+	}
+	if err := cfg.JavaScript.parseConfigValues(); err != nil {
+		return err
+	}
+	if err := cfg.Enrichment.parseConfigValues(); err != nil {
+		return err
+	}
+	if cfg.NestedImageDepth < 0 {
+		return fmt.Errorf("package nested-image-depth must not be negative (got %d)", cfg.NestedImageDepth)
+	}
+	scheme, err := syftPkg.ParseIDScheme(cfg.IDScheme)
+	if err != nil {
+		return err
+	}
+	cfg.IDSchemeOpt = scheme
+	var minConfidence syftPkg.Confidence
+	if cfg.MinConfidence != "" {
+		if minConfidence, err = syftPkg.ParseConfidence(cfg.MinConfidence); err != nil {
+			return err
+		}
+	}
+	filter, err := syftPkg.NewFilter(syftPkg.FilterConfig{Include: cfg.Include, Exclude: cfg.Exclude, MinConfidence: minConfidence})
+	if err != nil {
+		return err
+	}
+	cfg.FilterOpt = filter
+	return cfg.Deduplicate.parseConfigValues()
 }
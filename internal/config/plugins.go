@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/syft/pkg/cataloger/plugin"
+)
+
+type plugins struct {
+	// the directory of cataloger plugin executables (see the syft/pkg/cataloger/plugin package), plugins are only run
+	// when a directory is configured
+	Directory      string        `yaml:"directory" json:"directory" mapstructure:"directory"`
+	Timeout        time.Duration `yaml:"timeout" json:"timeout" mapstructure:"timeout"`
+	MaxFileSize    string        `yaml:"max-file-size" json:"max-file-size" mapstructure:"max-file-size"`
+	MaxRequestSize string        `yaml:"max-request-size" json:"max-request-size" mapstructure:"max-request-size"`
+	// the max sizes in bytes (0 when there is no limit)
+	MaxFileSizeBytes    int64 `yaml:"-" json:"-" mapstructure:"-"`
+	MaxRequestSizeBytes int64 `yaml:"-" json:"-" mapstructure:"-"`
+}
+
+func (cfg plugins) loadDefaultValues(v *viper.Viper) {
+	def := plugin.DefaultConfig()
+	v.SetDefault("plugins.directory", "")
+	v.SetDefault("plugins.timeout", def.Timeout)
+	v.SetDefault("plugins.max-file-size", humanize.IBytes(uint64(def.MaxFileSize)))
+	v.SetDefault("plugins.max-request-size", humanize.IBytes(uint64(def.MaxRequestSize)))
+}
+
+func (cfg *plugins) parseConfigValues() error {
+	if cfg.Timeout < 0 {
+		return fmt.Errorf("plugins timeout must not be negative (got %s)", cfg.Timeout)
+	}
+
+	cfg.MaxFileSizeBytes = 0
+	if cfg.MaxFileSize != "" {
+		size, err := humanize.ParseBytes(cfg.MaxFileSize)
+		if err != nil {
+			return fmt.Errorf("bad plugins max-file-size value %q: %w", cfg.MaxFileSize, err)
+		}
+		cfg.MaxFileSizeBytes = int64(size)
+	}
+
+	cfg.MaxRequestSizeBytes = 0
+	if cfg.MaxRequestSize != "" {
+		size, err := humanize.ParseBytes(cfg.MaxRequestSize)
+		if err != nil {
+			return fmt.Errorf("bad plugins max-request-size value %q: %w", cfg.MaxRequestSize, err)
+		}
+		cfg.MaxRequestSizeBytes = int64(size)
+	}
+
+	if cfg.Directory == "" {
+		return nil
+	}
+	dir, err := homedir.Expand(cfg.Directory)
+	if err != nil {
+		return err
+	}
+	cfg.Directory = dir
+	return nil
+}
+
+func (cfg plugins) toConfig() plugin.Config {
+	return plugin.Config{
+		Directory:      cfg.Directory,
+		Timeout:        cfg.Timeout,
+		MaxFileSize:    cfg.MaxFileSizeBytes,
+		MaxRequestSize: cfg.MaxRequestSizeBytes,
+	}
+}
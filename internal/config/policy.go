@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/spf13/viper"
+
+	syftPolicy "github.com/anchore/syft/syft/policy"
+)
+
+type policy struct {
+	FailOn    []string           `yaml:"fail-on" json:"fail-on" mapstructure:"fail-on"` // --fail-on, the conditions that fail the scan when any package matches
+	PolicyOpt *syftPolicy.Policy `yaml:"-" json:"-"`
+}
+
+func (cfg policy) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("policy.fail-on", []string{})
+}
+
+func (cfg *policy) parseConfigValues() error {
+	p, err := syftPolicy.New(cfg.FailOn)
+	if err != nil {
+		return err
+	}
+	cfg.PolicyOpt = p
+	return nil
+}
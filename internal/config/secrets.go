@@ -1,29 +1,93 @@
 package config
 
 import (
+	"fmt"
+	"regexp"
+
 	"github.com/spf13/viper"
 
 	"github.com/anchore/syft/internal/file"
+	syftFile "github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/source"
 )
 
 type secrets struct {
 	Cataloger           catalogerOptions  `yaml:"cataloger" json:"cataloger" mapstructure:"cataloger"`
+	RulePacks           []string          `yaml:"rule-packs" json:"rule-packs" mapstructure:"rule-packs"`
 	AdditionalPatterns  map[string]string `yaml:"additional-patterns" json:"additional-patterns" mapstructure:"additional-patterns"`
 	ExcludePatternNames []string          `yaml:"exclude-pattern-names" json:"exclude-pattern-names" mapstructure:"exclude-pattern-names"`
+	Entropy             secretsEntropy    `yaml:"entropy" json:"entropy" mapstructure:"entropy"`
+	Allowlist           secretsAllowlist  `yaml:"allowlist" json:"allowlist" mapstructure:"allowlist"`
 	RevealValues        bool              `yaml:"reveal-values" json:"reveal-values" mapstructure:"reveal-values"`
 	SkipFilesAboveSize  int64             `yaml:"skip-files-above-size" json:"skip-files-above-size" mapstructure:"skip-files-above-size"`
 }
 
+type secretsEntropy struct {
+	Enabled   bool    `yaml:"enabled" json:"enabled" mapstructure:"enabled"`
+	MinLength int     `yaml:"min-length" json:"min-length" mapstructure:"min-length"`
+	Threshold float64 `yaml:"threshold" json:"threshold" mapstructure:"threshold"`
+}
+
+type secretsAllowlist struct {
+	Paths     []string         `yaml:"paths" json:"paths" mapstructure:"paths"`
+	Values    []string         `yaml:"values" json:"values" mapstructure:"values"`
+	ValuesOpt []*regexp.Regexp `yaml:"-" json:"-"`
+}
+
 func (cfg secrets) loadDefaultValues(v *viper.Viper) {
+	entropy := syftFile.DefaultEntropyConfig()
 	v.SetDefault("secrets.cataloger.enabled", catalogerEnabledDefault)
 	v.SetDefault("secrets.cataloger.scope", source.AllLayersScope)
+	v.SetDefault("secrets.rule-packs", syftFile.SecretsRulePackNames())
 	v.SetDefault("secrets.reveal-values", false)
 	v.SetDefault("secrets.skip-files-above-size", 1*file.MB)
 	v.SetDefault("secrets.additional-patterns", map[string]string{})
 	v.SetDefault("secrets.exclude-pattern-names", []string{})
+	v.SetDefault("secrets.entropy.enabled", entropy.Enabled)
+	v.SetDefault("secrets.entropy.min-length", entropy.MinLength)
+	v.SetDefault("secrets.entropy.threshold", entropy.Threshold)
+	v.SetDefault("secrets.allowlist.paths", []string{})
+	v.SetDefault("secrets.allowlist.values", []string{})
 }
 
 func (cfg *secrets) parseConfigValues() error {
+	cfg.Allowlist.ValuesOpt = nil
+	for _, value := range cfg.Allowlist.Values {
+		obj, err := regexp.Compile(value)
+		if err != nil {
+			return fmt.Errorf("unable to parse secrets allowlist value %q regular expression: %w", value, err)
+		}
+		cfg.Allowlist.ValuesOpt = append(cfg.Allowlist.ValuesOpt, obj)
+	}
+
 	return cfg.Cataloger.parseConfigValues()
 }
+
+// ToCatalogerConfig returns the secrets cataloger configuration, combining the selected rule packs with any additional
+// patterns.
+func (cfg secrets) ToCatalogerConfig() (syftFile.SecretsCatalogerConfig, error) {
+	basePatterns, err := syftFile.SecretsPatternsFromRulePacks(cfg.RulePacks)
+	if err != nil {
+		return syftFile.SecretsCatalogerConfig{}, err
+	}
+
+	patterns, err := syftFile.GenerateSearchPatterns(basePatterns, cfg.AdditionalPatterns, cfg.ExcludePatternNames)
+	if err != nil {
+		return syftFile.SecretsCatalogerConfig{}, err
+	}
+
+	return syftFile.SecretsCatalogerConfig{
+		Patterns: patterns,
+		Entropy: syftFile.EntropyConfig{
+			Enabled:   cfg.Entropy.Enabled,
+			MinLength: cfg.Entropy.MinLength,
+			Threshold: cfg.Entropy.Threshold,
+		},
+		Allowlist: syftFile.SecretsAllowlist{
+			Paths:  cfg.Allowlist.Paths,
+			Values: cfg.Allowlist.ValuesOpt,
+		},
+		RevealValues:       cfg.RevealValues,
+		SkipFilesAboveSize: cfg.SkipFilesAboveSize,
+	}, nil
+}
@@ -0,0 +1,37 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/spf13/viper"
+)
+
+type serve struct {
+	// the address the scanning service listens on
+	Address string `yaml:"address" json:"address" mapstructure:"address"` // --address
+	// the most scans that are run at once, further requests wait for a running scan to finish
+	MaxConcurrentScans int `yaml:"max-concurrent-scans" json:"max-concurrent-scans" mapstructure:"max-concurrent-scans"` // --max-concurrent-scans
+	// the largest archive that may be uploaded to be scanned, e.g. "2GB"
+	MaxUploadSize string `yaml:"max-upload-size" json:"max-upload-size" mapstructure:"max-upload-size"`
+	// the max upload size in bytes
+	MaxUploadSizeBytes int64 `yaml:"-" json:"-" mapstructure:"-"`
+}
+
+func (cfg serve) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("serve.address", "localhost:8080")
+	v.SetDefault("serve.max-concurrent-scans", 4)
+	v.SetDefault("serve.max-upload-size", "2GB")
+}
+
+func (cfg *serve) parseConfigValues() error {
+	if cfg.MaxConcurrentScans < 1 {
+		return fmt.Errorf("serve max-concurrent-scans must be at least 1 (got %d)", cfg.MaxConcurrentScans)
+	}
+	size, err := humanize.ParseBytes(cfg.MaxUploadSize)
+	if err != nil {
+		return fmt.Errorf("bad serve max-upload-size value %q: %w", cfg.MaxUploadSize, err)
+	}
+	cfg.MaxUploadSizeBytes = int64(size)
+	return nil
+}
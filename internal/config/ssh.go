@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/spf13/viper"
+)
+
+// ssh are the options used for remote directories given with the "ssh://" scheme.
+type ssh struct {
+	IdentityFile          string `yaml:"identity-file" json:"identity-file" mapstructure:"identity-file"`
+	KnownHostsFile        string `yaml:"known-hosts-file" json:"known-hosts-file" mapstructure:"known-hosts-file"`
+	InsecureIgnoreHostKey bool   `yaml:"insecure-ignore-host-key" json:"insecure-ignore-host-key" mapstructure:"insecure-ignore-host-key"`
+}
+
+func (cfg ssh) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("ssh.identity-file", "")
+	v.SetDefault("ssh.known-hosts-file", "")
+	v.SetDefault("ssh.insecure-ignore-host-key", false)
+}
+
+func (cfg *ssh) parseConfigValues() error {
+	for _, p := range []*string{&cfg.IdentityFile, &cfg.KnownHostsFile} {
+		expanded, err := homedir.Expand(*p)
+		if err != nil {
+			return fmt.Errorf("unable to expand path=%q: %w", *p, err)
+		}
+		*p = expanded
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/viper"
+
+	"github.com/anchore/syft/syft/compliance"
+)
+
+type validate struct {
+	Profile string `yaml:"profile" json:"profile" mapstructure:"profile"` // --profile, the profile the SBOM is checked against (ntia or bsi-tr-03183)
+	Output  string `yaml:"output" json:"output" mapstructure:"output"`    // --output, the format of the report (text or json)
+}
+
+func (cfg validate) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("validate.profile", compliance.NTIA)
+	v.SetDefault("validate.output", "text")
+}
+
+func (cfg *validate) parseConfigValues() error {
+	if _, err := compliance.ProfileByName(cfg.Profile); err != nil {
+		return err
+	}
+	switch cfg.Output {
+	case "text", "json":
+	default:
+		return fmt.Errorf("bad validate output value %q: must be one of text, json", cfg.Output)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/sigstore/cosign/cmd/cosign/cli/options"
+	"github.com/spf13/viper"
+)
+
+type verify struct {
+	Key            string `yaml:"key" json:"key" mapstructure:"key"`                                                           // same as --key, file path (or KMS URI) of the public key the attestations must be signed with
+	CertIdentity   string `yaml:"certificate_identity" json:"certificateIdentity" mapstructure:"certificate_identity"`         // same as --certificate-identity, the identity keyless attestations must be signed by
+	CertOIDCIssuer string `yaml:"certificate_oidc_issuer" json:"certificateOidcIssuer" mapstructure:"certificate_oidc_issuer"` // same as --certificate-oidc-issuer, the OIDC issuer of the identity
+	RekorURL       string `yaml:"rekor_url" json:"rekorUrl" mapstructure:"rekor_url"`
+	PredicateType  string `yaml:"predicate_type" json:"predicateType" mapstructure:"predicate_type"` // same as --predicate-type, any SBOM predicate type when empty
+}
+
+func (cfg *verify) parseConfigValues() error {
+	if cfg.Key != "" {
+		expandedPath, err := homedir.Expand(cfg.Key)
+		if err != nil {
+			return fmt.Errorf("unable to expand key path=%q: %w", cfg.Key, err)
+		}
+		cfg.Key = expandedPath
+	}
+	return nil
+}
+
+func (cfg verify) loadDefaultValues(v *viper.Viper) {
+	v.SetDefault("verify.key", "")
+	v.SetDefault("verify.certificate_identity", "")
+	v.SetDefault("verify.certificate_oidc_issuer", "")
+	v.SetDefault("verify.rekor_url", options.DefaultRekorURL)
+	v.SetDefault("verify.predicate_type", "")
+}
@@ -0,0 +1,112 @@
+package file
+
+import (
+	"errors"
+	"io"
+	"regexp"
+)
+
+const (
+	// windowSize is the amount of content searched at once by FindFirstSubmatches
+	windowSize = 8 * MB
+	// windowOverlap is the amount of content carried over between windows, allowing matches that span the boundary of
+	// two windows to be found (as long as the match is shorter than the overlap)
+	windowOverlap = 64 * KB
+)
+
+// FindFirstSubmatches returns the submatches (see regexp.FindSubmatch) of the first match of each pattern within the
+// contents of the given reader, or nil for patterns that do not match. Contents are searched within a sliding window,
+// so memory usage is bounded regardless of the size of the contents, however matches longer than the window overlap
+// (64 KiB) may not be found.
+func FindFirstSubmatches(reader io.Reader, patterns []*regexp.Regexp) ([][][]byte, error) {
+	matches, err := FindFirstSubmatchesWithOffsets(reader, patterns)
+	if err != nil {
+		return nil, err
+	}
+	results := make([][][]byte, len(matches))
+	for i, m := range matches {
+		if m != nil {
+			results[i] = m.Groups
+		}
+	}
+	return results, nil
+}
+
+// Submatches are the submatches of a match (see regexp.FindSubmatch) along with where the match is within the contents.
+type Submatches struct {
+	// Offset is the position of the first byte of the match within the contents
+	Offset int64
+	// Groups are the whole match followed by the submatch of each group of the pattern (nil for unmatched groups)
+	Groups [][]byte
+}
+
+// FindFirstSubmatchesWithOffsets is FindFirstSubmatches, additionally describing where each match is within the contents.
+func FindFirstSubmatchesWithOffsets(reader io.Reader, patterns []*regexp.Regexp) ([]*Submatches, error) {
+	return findFirstSubmatches(reader, patterns, windowSize, windowOverlap)
+}
+
+func findFirstSubmatches(reader io.Reader, patterns []*regexp.Regexp, size, overlap int) ([]*Submatches, error) {
+	results := make([]*Submatches, len(patterns))
+	if len(patterns) == 0 {
+		return results, nil
+	}
+	remaining := len(patterns)
+
+	// offset is the position of the start of the window within the contents
+	var offset int64
+	window := make([]byte, 0, size)
+	for {
+		n, err := io.ReadFull(reader, window[len(window):cap(window)])
+		window = window[:len(window)+n]
+		eof := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !eof {
+			return nil, err
+		}
+
+		// the tail of the window is searched again as the start of the next window
+		tail := len(window) - overlap
+		if tail < 0 {
+			tail = 0
+		}
+
+		for i, pattern := range patterns {
+			if results[i] != nil {
+				continue
+			}
+			indexes := pattern.FindSubmatchIndex(window)
+			if indexes == nil {
+				continue
+			}
+			if !eof && indexes[0] >= tail {
+				// the match may continue past the end of the window, so wait for the next window to see all of it
+				continue
+			}
+			results[i] = &Submatches{
+				Offset: offset + int64(indexes[0]),
+				Groups: copySubmatches(window, indexes),
+			}
+			remaining--
+		}
+
+		if remaining == 0 || eof {
+			return results, nil
+		}
+
+		copy(window, window[tail:])
+		window = window[:len(window)-tail]
+		offset += int64(tail)
+	}
+}
+
+// copySubmatches returns the submatches described by the given indexes (see regexp.FindSubmatchIndex), copied from the
+// window since the window is reused.
+func copySubmatches(window []byte, indexes []int) [][]byte {
+	results := make([][]byte, len(indexes)/2)
+	for i := range results {
+		start, end := indexes[2*i], indexes[2*i+1]
+		if start >= 0 {
+			results[i] = append([]byte{}, window[start:end]...)
+		}
+	}
+	return results
+}
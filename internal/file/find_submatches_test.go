@@ -0,0 +1,28 @@
+package file
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindFirstSubmatches(t *testing.T) {
+	// a small window makes each pattern match in a different window (or across a window boundary)
+	contents := strings.Repeat(".", 10) + "alpha 1.0" + strings.Repeat(" ", 20) + "beta 2.0" + strings.Repeat(" ", 20) + "alpha 3.0"
+	patterns := []*regexp.Regexp{
+		regexp.MustCompile(`alpha (?P<version>[0-9.]+)`),
+		regexp.MustCompile(`beta (?P<version>[0-9.]+)`),
+		regexp.MustCompile(`gamma (?P<version>[0-9.]+)`),
+	}
+
+	actual, err := findFirstSubmatches(strings.NewReader(contents), patterns, 16, 10)
+	require.NoError(t, err)
+	require.Len(t, actual, 3)
+
+	assert.Equal(t, &Submatches{Offset: 10, Groups: [][]byte{[]byte("alpha 1.0"), []byte("1.0")}}, actual[0])
+	assert.Equal(t, &Submatches{Offset: 39, Groups: [][]byte{[]byte("beta 2.0"), []byte("2.0")}}, actual[1])
+	assert.Nil(t, actual[2])
+}
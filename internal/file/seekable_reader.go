@@ -0,0 +1,97 @@
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// maxInMemorySize is the largest amount of file content buffered in memory by NewSeekableReader, beyond which the
+// contents are spilled to a temporary file.
+var maxInMemorySize int64 = 32 * MB
+
+// SeekableReadCloser is a reader of file contents that supports random access.
+type SeekableReadCloser interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+}
+
+// NewSeekableReader returns a random access reader for the contents of the given reader. Readers that already support
+// random access are returned as-is. Otherwise small contents are buffered in memory, and larger contents are copied to
+// a temporary file (keeping memory usage bounded regardless of the file size). Closing the returned reader closes the
+// given reader and removes any temporary file.
+func NewSeekableReader(reader io.ReadCloser) (SeekableReadCloser, error) {
+	if seekable, ok := reader.(SeekableReadCloser); ok {
+		return seekable, nil
+	}
+
+	// read one byte past the limit to determine if the contents fit in memory
+	contents, err := io.ReadAll(io.LimitReader(reader, maxInMemorySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read contents: %w", err)
+	}
+
+	if int64(len(contents)) <= maxInMemorySize {
+		return &bufferedReader{
+			Reader: bytes.NewReader(contents),
+			source: reader,
+		}, nil
+	}
+
+	tempFile, err := os.CreateTemp("", "syft-contents-")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temp file for contents: %w", err)
+	}
+
+	spilled := &spilledReader{
+		File:   tempFile,
+		source: reader,
+	}
+
+	if _, err := io.Copy(tempFile, io.MultiReader(bytes.NewReader(contents), reader)); err != nil {
+		spilled.remove()
+		return nil, fmt.Errorf("unable to copy contents to temp file: %w", err)
+	}
+
+	if _, err := tempFile.Seek(0, io.SeekStart); err != nil {
+		spilled.remove()
+		return nil, fmt.Errorf("unable to seek temp file: %w", err)
+	}
+
+	return spilled, nil
+}
+
+// bufferedReader provides random access to contents held in memory.
+type bufferedReader struct {
+	*bytes.Reader
+	source io.Closer
+}
+
+func (r *bufferedReader) Close() error {
+	return r.source.Close()
+}
+
+// spilledReader provides random access to contents copied to a temporary file.
+type spilledReader struct {
+	*os.File
+	source io.Closer
+}
+
+func (r *spilledReader) Close() error {
+	r.remove()
+	return r.source.Close()
+}
+
+func (r *spilledReader) remove() {
+	if err := r.File.Close(); err != nil {
+		log.Debugf("unable to close temp file=%q: %+v", r.File.Name(), err)
+	}
+	if err := os.Remove(r.File.Name()); err != nil {
+		log.Warnf("unable to remove temp file=%q: %+v", r.File.Name(), err)
+	}
+}
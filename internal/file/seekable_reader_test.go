@@ -0,0 +1,82 @@
+package file
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSeekableReader(t *testing.T) {
+	original := maxInMemorySize
+	t.Cleanup(func() {
+		maxInMemorySize = original
+	})
+	maxInMemorySize = 8
+
+	tests := []struct {
+		name     string
+		contents string
+		spilled  bool
+	}{
+		{
+			name:     "contents fit in memory",
+			contents: "12345678",
+		},
+		{
+			name:     "contents spill to a temp file",
+			contents: "123456789",
+			spilled:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			source := &trackingCloser{Reader: strings.NewReader(test.contents)}
+
+			reader, err := NewSeekableReader(source)
+			require.NoError(t, err)
+
+			spilled, isSpilled := reader.(*spilledReader)
+			require.Equal(t, test.spilled, isSpilled)
+
+			buf := make([]byte, 3)
+			_, err = reader.ReadAt(buf, 5)
+			require.NoError(t, err)
+			assert.Equal(t, test.contents[5:8], string(buf))
+
+			contents, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, test.contents, string(contents))
+
+			require.NoError(t, reader.Close())
+			assert.True(t, source.closed)
+			if isSpilled {
+				_, err := os.Stat(spilled.Name())
+				assert.True(t, os.IsNotExist(err), "temp file was not removed")
+			}
+		})
+	}
+}
+
+func TestNewSeekableReader_alreadySeekable(t *testing.T) {
+	f, err := os.Open("test-fixtures/generate-zip-fixture-from-source-dir.sh")
+	require.NoError(t, err)
+	defer f.Close()
+
+	reader, err := NewSeekableReader(f)
+	require.NoError(t, err)
+	assert.Same(t, f, reader)
+}
+
+type trackingCloser struct {
+	io.Reader
+	closed bool
+}
+
+func (c *trackingCloser) Close() error {
+	c.closed = true
+	return nil
+}
@@ -11,6 +11,11 @@ import (
 
 // ExtractGlobsFromTarToUniqueTempFile extracts paths matching the given globs within the given archive to a temporary directory, returning file openers for each file extracted.
 func ExtractGlobsFromTarToUniqueTempFile(archivePath, dir string, globs ...string) (map[string]Opener, error) {
+	return ExtractSelectedGlobsFromTarToUniqueTempFile(archivePath, dir, nil, globs...)
+}
+
+// ExtractSelectedGlobsFromTarToUniqueTempFile extracts paths matching the given globs within the given archive to a temporary directory, returning file openers for each file extracted. Before a matching file is extracted it must be accepted by the given selector (given the path and size of the file), when provided.
+func ExtractSelectedGlobsFromTarToUniqueTempFile(archivePath, dir string, selector func(name string, size int64) bool, globs ...string) (map[string]Opener, error) {
 	results := make(map[string]Opener)
 
 	// don't allow for full traversal, only select traversal from given paths
@@ -31,6 +36,10 @@ func ExtractGlobsFromTarToUniqueTempFile(archivePath, dir string, globs ...strin
 			return nil
 		}
 
+		if selector != nil && !selector(file.Name(), file.Size()) {
+			return nil
+		}
+
 		// we have a file we want to extract....
 		tempfilePrefix := filepath.Base(filepath.Clean(file.Name())) + "-"
 		tempFile, err := os.CreateTemp(dir, tempfilePrefix)
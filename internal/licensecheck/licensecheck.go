@@ -0,0 +1,98 @@
+/*
+Package licensecheck detects licenses within the text of license files (e.g. LICENSE, COPYING, or NOTICE) by looking
+for the key phrases of well-known license texts, scoring each license by how many of its key phrases are present.
+*/
+package licensecheck
+
+import (
+	"bufio"
+	"bytes"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/internal/spdxlicense"
+)
+
+// MinConfidence is the lowest confidence for which a license is considered detected
+const MinConfidence = 0.75
+
+var (
+	nonWordPattern          = regexp.MustCompile(`[^a-z0-9]+`)
+	spdxIdentifierTagPrefix = "SPDX-License-Identifier:"
+)
+
+// Match is a license detected within a text.
+type Match struct {
+	ID         string  // the SPDX license expression
+	Confidence float64 // the fraction of the license key phrases found within the text (1 for SPDX license identifier tags)
+}
+
+// Detect returns every license detected within the given text with a confidence of at least MinConfidence, ordered by
+// descending confidence (then ID).
+func Detect(contents []byte) []Match {
+	text := " " + normalize(string(contents)) + " "
+	scores := make(map[*template]float64)
+	for i := range templates {
+		if confidence := templates[i].score(text); confidence >= MinConfidence {
+			scores[&templates[i]] = confidence
+		}
+	}
+
+	matches := make(map[string]float64)
+	for t, confidence := range scores {
+		if t.isPartOfMatch(scores) {
+			continue
+		}
+		if confidence > matches[t.id] {
+			matches[t.id] = confidence
+		}
+	}
+
+	// explicit tags take precedence over any license text
+	for _, id := range spdxIdentifierTags(contents) {
+		matches[id] = 1
+	}
+
+	results := make([]Match, 0, len(matches))
+	for id, confidence := range matches {
+		results = append(results, Match{
+			ID:         id,
+			Confidence: math.Round(confidence*100) / 100,
+		})
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Confidence == results[j].Confidence {
+			return results[i].ID < results[j].ID
+		}
+		return results[i].Confidence > results[j].Confidence
+	})
+	return results
+}
+
+// spdxIdentifierTags returns the normalized license expressions of all "SPDX-License-Identifier:" tags within the text.
+func spdxIdentifierTags(contents []byte) []string {
+	var ids []string
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := scanner.Text()
+		idx := strings.Index(line, spdxIdentifierTagPrefix)
+		if idx < 0 {
+			continue
+		}
+		value := strings.TrimSpace(line[idx+len(spdxIdentifierTagPrefix):])
+		// tags are often placed within comments, e.g. "/* SPDX-License-Identifier: MIT */"
+		value = strings.TrimSpace(strings.TrimSuffix(value, "*/"))
+		if expression, err := spdxlicense.NormalizeExpression(value); err == nil {
+			ids = append(ids, expression)
+		}
+	}
+	return ids
+}
+
+// normalize lowercases the text and replaces all punctuation and whitespace with single spaces, so that phrases match
+// regardless of line wrapping, quoting, or comment markers.
+func normalize(text string) string {
+	return strings.TrimSpace(nonWordPattern.ReplaceAllString(strings.ToLower(text), " "))
+}
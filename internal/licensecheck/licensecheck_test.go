@@ -0,0 +1,68 @@
+package licensecheck
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected []Match
+	}{
+		{
+			fixture:  "MIT",
+			expected: []Match{{ID: "MIT", Confidence: 1}},
+		},
+		{
+			fixture:  "BSD-2-Clause",
+			expected: []Match{{ID: "BSD-2-Clause", Confidence: 1}},
+		},
+		{
+			// the BSD-2-Clause text is part of the BSD-3-Clause text
+			fixture:  "BSD-3-Clause",
+			expected: []Match{{ID: "BSD-3-Clause", Confidence: 1}},
+		},
+		{
+			// the GPL-3.0 text is referenced by the LGPL-3.0 text
+			fixture:  "LGPL-3.0",
+			expected: []Match{{ID: "LGPL-3.0-only", Confidence: 1}},
+		},
+		{
+			fixture:  "NOTICE",
+			expected: []Match{{ID: "Apache-2.0", Confidence: 1}},
+		},
+		{
+			fixture:  "spdx-tag.c",
+			expected: []Match{{ID: "GPL-2.0-or-later OR MIT", Confidence: 1}},
+		},
+		{
+			fixture:  "proprietary",
+			expected: []Match{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			contents, err := os.ReadFile(filepath.Join("test-fixtures", test.fixture))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, Detect(contents))
+		})
+	}
+}
+
+func TestDetect_PartialMatch(t *testing.T) {
+	// a modified MIT license missing one of the key phrases
+	contents := []byte(`Permission is hereby granted, free of charge, to any person obtaining a copy of this software.
+The above copyright notice and this permission notice shall be included in all copies or substantial portions of the Software.`)
+
+	assert.Empty(t, Detect(contents))
+
+	contents = append(contents, []byte(`
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND.`)...)
+	assert.Equal(t, []Match{{ID: "MIT", Confidence: 1}}, Detect(contents))
+}
@@ -0,0 +1,218 @@
+package licensecheck
+
+import "strings"
+
+// template describes a license text by the key phrases that are present in every copy of the text (and the phrases
+// that are only present in the texts of similar licenses).
+type template struct {
+	id       string   // the SPDX license ID
+	phrases  []string // the normalized key phrases of the license text
+	excludes []string // normalized phrases that indicate a similar (but different) license
+}
+
+// score returns the fraction of key phrases present in the given normalized text (0 when any excluded phrase is
+// present).
+func (t template) score(text string) float64 {
+	for _, phrase := range t.excludes {
+		if containsPhrase(text, phrase) {
+			return 0
+		}
+	}
+
+	var found int
+	for _, phrase := range t.phrases {
+		if containsPhrase(text, phrase) {
+			found++
+		}
+	}
+	return float64(found) / float64(len(t.phrases))
+}
+
+// isPartOfMatch indicates if all key phrases of the template are contained within another template that matched at
+// least as well (e.g. the BSD-2-Clause phrases within a BSD-3-Clause text), in which case the template is not a
+// license of its own.
+func (t *template) isPartOfMatch(scores map[*template]float64) bool {
+	for other, confidence := range scores {
+		if other.id == t.id || confidence < scores[t] || len(other.phrases) <= len(t.phrases) {
+			continue
+		}
+		if t.isSubsetOf(*other) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t template) isSubsetOf(other template) bool {
+	for _, phrase := range t.phrases {
+		var found bool
+		for _, otherPhrase := range other.phrases {
+			if phrase == otherPhrase {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+func containsPhrase(text, phrase string) bool {
+	// both are padded with spaces so that phrases only match on word boundaries
+	return strings.Contains(text, " "+phrase+" ")
+}
+
+// templates are the license texts that can be detected. Phrases are normalized with normalize().
+var templates = []template{
+	{
+		id: "MIT",
+		phrases: []string{
+			"permission is hereby granted free of charge to any person obtaining a copy",
+			"the above copyright notice and this permission notice shall be included in all copies or substantial portions of the software",
+			"the software is provided as is without warranty of any kind",
+		},
+	},
+	{
+		id: "ISC",
+		phrases: []string{
+			"permission to use copy modify and or distribute this software for any purpose with or without fee is hereby granted",
+			"the software is provided as is and the author disclaims all warranties",
+		},
+	},
+	{
+		id: "BSD-2-Clause",
+		phrases: []string{
+			"redistribution and use in source and binary forms with or without modification are permitted provided that the following conditions are met",
+			"redistributions of source code must retain the above copyright notice",
+			"redistributions in binary form must reproduce the above copyright notice",
+			"this software is provided by the copyright holders and contributors as is",
+		},
+	},
+	{
+		id: "BSD-3-Clause",
+		phrases: []string{
+			"redistribution and use in source and binary forms with or without modification are permitted provided that the following conditions are met",
+			"redistributions of source code must retain the above copyright notice",
+			"redistributions in binary form must reproduce the above copyright notice",
+			"neither the name of",
+			"may be used to endorse or promote products derived from this software without specific prior written permission",
+			"this software is provided by the copyright holders and contributors as is",
+		},
+	},
+	{
+		id: "Apache-2.0",
+		phrases: []string{
+			"apache license",
+			"version 2 0 january 2004",
+			"terms and conditions for use reproduction and distribution",
+			"grant of copyright license",
+			"grant of patent license",
+		},
+	},
+	{
+		// the standard license header (commonly found within NOTICE files)
+		id: "Apache-2.0",
+		phrases: []string{
+			"licensed under the apache license version 2 0",
+			"you may not use this file except in compliance with the license",
+		},
+	},
+	{
+		id: "GPL-2.0-only",
+		phrases: []string{
+			"gnu general public license",
+			"version 2 june 1991",
+			"terms and conditions for copying distribution and modification",
+		},
+		excludes: []string{
+			// the LGPL-2.0 text is largely the same as the GPL-2.0 text
+			"this license the library general public license applies to some specially designated free software foundation software",
+		},
+	},
+	{
+		id: "GPL-3.0-only",
+		phrases: []string{
+			"gnu general public license",
+			"version 3 29 june 2007",
+			"terms and conditions",
+		},
+		excludes: []string{
+			// the LGPL-3.0 text is made of additional permissions on top of the GPL-3.0 text
+			"incorporates the terms and conditions of version 3 of the gnu general public license",
+		},
+	},
+	{
+		id: "LGPL-2.0-only",
+		phrases: []string{
+			"gnu library general public license",
+			"version 2 june 1991",
+			"this license the library general public license applies to some specially designated free software foundation software",
+		},
+	},
+	{
+		id: "LGPL-2.1-only",
+		phrases: []string{
+			"gnu lesser general public license",
+			"version 2 1 february 1999",
+			"terms and conditions for copying distribution and modification",
+		},
+	},
+	{
+		id: "LGPL-3.0-only",
+		phrases: []string{
+			"gnu lesser general public license",
+			"version 3 29 june 2007",
+			"incorporates the terms and conditions of version 3 of the gnu general public license",
+			"supplemented by the additional permissions listed below",
+			"additional definitions",
+		},
+	},
+	{
+		id: "AGPL-3.0-only",
+		phrases: []string{
+			"gnu affero general public license",
+			"version 3 19 november 2007",
+			"remote network interaction",
+		},
+	},
+	{
+		id: "MPL-2.0",
+		phrases: []string{
+			"mozilla public license version 2 0",
+			"covered software",
+			"this source code form is subject to the terms of the mozilla public license v 2 0",
+		},
+	},
+	{
+		id: "Unlicense",
+		phrases: []string{
+			"this is free and unencumbered software released into the public domain",
+			"anyone is free to copy modify publish use compile sell or distribute this software",
+		},
+	},
+	{
+		id: "CC0-1.0",
+		phrases: []string{
+			"creative commons legal code",
+			"cc0 1 0 universal",
+			"statement of purpose",
+		},
+	},
+	{
+		id: "Zlib",
+		phrases: []string{
+			"this software is provided as is without any express or implied warranty",
+			"the origin of this software must not be misrepresented",
+			"altered source versions must be plainly marked as such",
+		},
+	},
+	{
+		id: "BSL-1.0",
+		phrases: []string{
+			"boost software license version 1 0",
+			"permission is hereby granted free of charge to any person or organization obtaining a copy of the software and accompanying documentation covered by this license",
+		},
+	},
+}
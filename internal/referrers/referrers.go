@@ -0,0 +1,359 @@
+/*
+Package referrers attaches artifacts (such as SBOMs and attestations) to images within a registry as OCI referrers, and
+finds the artifacts attached to an image. For registries without the referrers API, the referrers of an image are listed
+within the index tagged with the digest of the image (the referrers tag schema of the OCI distribution spec).
+*/
+package referrers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/registryclient"
+)
+
+// EmptyConfigMediaType is the media type of the (empty) config of an artifact manifest.
+const EmptyConfigMediaType = "application/vnd.oci.empty.v1+json"
+
+// maxManifestSize is the largest manifest (or referrers index) that is read.
+const maxManifestSize = 4 << 20
+
+// Artifact is the content to attach to an image.
+type Artifact struct {
+	// ArtifactType is the type of the artifact (e.g. "application/spdx+json")
+	ArtifactType string
+	// Layer holds the content of the artifact
+	Layer v1.Layer
+	// LayerAnnotations are the annotations of the layer within the artifact manifest
+	LayerAnnotations map[string]string
+	// Annotations are the annotations of the artifact manifest
+	Annotations map[string]string
+}
+
+// Descriptor describes an artifact attached to an image.
+type Descriptor struct {
+	v1.Descriptor
+	ArtifactType string `json:"artifactType,omitempty"`
+}
+
+// manifest is an OCI image manifest of an artifact that refers to another manifest (its subject).
+type manifest struct {
+	SchemaVersion int64             `json:"schemaVersion"`
+	MediaType     types.MediaType   `json:"mediaType"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        v1.Descriptor     `json:"config"`
+	Layers        []v1.Descriptor   `json:"layers"`
+	Subject       *v1.Descriptor    `json:"subject,omitempty"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
+
+// index is an OCI image index listing the referrers of an image.
+type index struct {
+	SchemaVersion int64           `json:"schemaVersion"`
+	MediaType     types.MediaType `json:"mediaType"`
+	Manifests     []Descriptor    `json:"manifests"`
+}
+
+// rawManifest is a manifest that is pushed as-is.
+type rawManifest struct {
+	raw       []byte
+	mediaType types.MediaType
+}
+
+func (m rawManifest) RawManifest() ([]byte, error) {
+	return m.raw, nil
+}
+
+func (m rawManifest) MediaType() (types.MediaType, error) {
+	return m.mediaType, nil
+}
+
+// Resolve returns the digest of the given image within the registry, or of the image for the given platform (e.g.
+// "linux/arm64") when the image is a multi-platform image.
+func Resolve(ctx context.Context, ref name.Reference, platform string, registryOptions *image.RegistryOptions) (name.Digest, error) {
+	if platform == "" {
+		desc, err := remote.Head(ref, registryclient.RemoteOptions(ctx, registryOptions)...)
+		if err != nil {
+			return name.Digest{}, fmt.Errorf("unable to find image=%q: %w", ref, err)
+		}
+		return ref.Context().Digest(desc.Digest.String()), nil
+	}
+
+	p, err := v1.ParsePlatform(platform)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("invalid platform=%q: %w", platform, err)
+	}
+	img, err := remote.Image(ref, append(registryclient.RemoteOptions(ctx, registryOptions), remote.WithPlatform(*p))...)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("unable to find image=%q: %w", ref, err)
+	}
+	digest, err := img.Digest()
+	if err != nil {
+		return name.Digest{}, err
+	}
+	return ref.Context().Digest(digest.String()), nil
+}
+
+// Attach uploads the artifact to the repository of the image with the given digest as a referrer of the image,
+// returning the digest of the artifact manifest.
+func Attach(ctx context.Context, subject name.Digest, artifact Artifact, registryOptions *image.RegistryOptions) (name.Digest, error) {
+	remoteOpts := registryclient.RemoteOptions(ctx, registryOptions)
+
+	subjectDesc, err := remote.Head(subject, remoteOpts...)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("unable to find the image to attach to: %w", err)
+	}
+
+	config := static.NewLayer([]byte("{}"), EmptyConfigMediaType)
+	for _, layer := range []v1.Layer{config, artifact.Layer} {
+		if err := remote.WriteLayer(subject.Repository, layer, remoteOpts...); err != nil {
+			return name.Digest{}, fmt.Errorf("unable to upload artifact: %w", err)
+		}
+	}
+
+	configDesc, err := layerDescriptor(config, nil)
+	if err != nil {
+		return name.Digest{}, err
+	}
+	layerDesc, err := layerDescriptor(artifact.Layer, artifact.LayerAnnotations)
+	if err != nil {
+		return name.Digest{}, err
+	}
+
+	raw, err := json.Marshal(manifest{
+		SchemaVersion: 2,
+		MediaType:     types.OCIManifestSchema1,
+		ArtifactType:  artifact.ArtifactType,
+		Config:        *configDesc,
+		Layers:        []v1.Descriptor{*layerDesc},
+		Subject: &v1.Descriptor{
+			MediaType: subjectDesc.MediaType,
+			Size:      subjectDesc.Size,
+			Digest:    subjectDesc.Digest,
+		},
+		Annotations: artifact.Annotations,
+	})
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("unable to encode artifact manifest: %w", err)
+	}
+
+	digest, size, err := v1.SHA256(bytes.NewReader(raw))
+	if err != nil {
+		return name.Digest{}, err
+	}
+	ref := subject.Context().Digest(digest.String())
+	if err := remote.Put(ref, rawManifest{raw: raw, mediaType: types.OCIManifestSchema1}, remoteOpts...); err != nil {
+		return name.Digest{}, fmt.Errorf("unable to upload artifact manifest: %w", err)
+	}
+
+	// registries with the referrers API list the artifact from its subject, otherwise the index of the referrers
+	// tag must be updated
+	_, supported, err := listFromAPI(ctx, subject, "", registryOptions)
+	if err != nil {
+		return name.Digest{}, err
+	}
+	if !supported {
+		desc := Descriptor{
+			Descriptor: v1.Descriptor{
+				MediaType:   types.OCIManifestSchema1,
+				Size:        size,
+				Digest:      digest,
+				Annotations: artifact.Annotations,
+			},
+			ArtifactType: artifact.ArtifactType,
+		}
+		if err := addToTagIndex(ctx, subject, desc, registryOptions); err != nil {
+			return name.Digest{}, err
+		}
+	}
+	return ref, nil
+}
+
+// List returns the artifacts of the given type (or all artifacts when empty) attached to the image with the given digest.
+func List(ctx context.Context, subject name.Digest, artifactType string, registryOptions *image.RegistryOptions) ([]Descriptor, error) {
+	descs, supported, err := listFromAPI(ctx, subject, artifactType, registryOptions)
+	if err != nil {
+		return nil, err
+	}
+	if !supported {
+		idx, err := tagIndex(ctx, subject, registryOptions)
+		if err != nil {
+			return nil, err
+		}
+		descs = idx.Manifests
+	}
+
+	// note: registries are not required to filter by artifact type
+	var matching []Descriptor
+	for _, desc := range descs {
+		if artifactType == "" || desc.ArtifactType == artifactType {
+			matching = append(matching, desc)
+		}
+	}
+	return matching, nil
+}
+
+// Fetch returns the content of the given artifact attached to an image within the given repository.
+func Fetch(ctx context.Context, repo name.Repository, desc Descriptor, registryOptions *image.RegistryOptions) ([]byte, error) {
+	remoteOpts := registryclient.RemoteOptions(ctx, registryOptions)
+
+	got, err := remote.Get(repo.Digest(desc.Digest.String()), remoteOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch artifact manifest=%q: %w", desc.Digest, err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(got.Manifest, &m); err != nil {
+		return nil, fmt.Errorf("unable to decode artifact manifest=%q: %w", desc.Digest, err)
+	}
+	if len(m.Layers) != 1 {
+		return nil, fmt.Errorf("artifact manifest=%q must have a single layer (found %d)", desc.Digest, len(m.Layers))
+	}
+
+	layer, err := remote.Layer(repo.Digest(m.Layers[0].Digest.String()), remoteOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch artifact=%q: %w", desc.Digest, err)
+	}
+	rc, err := layer.Compressed()
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch artifact=%q: %w", desc.Digest, err)
+	}
+	defer internal.CloseAndLogError(rc, desc.Digest.String())
+
+	return io.ReadAll(rc)
+}
+
+// listFromAPI returns the artifacts attached to the image with the given digest using the referrers API, and whether
+// the registry supports the referrers API at all.
+func listFromAPI(ctx context.Context, subject name.Digest, artifactType string, registryOptions *image.RegistryOptions) ([]Descriptor, bool, error) {
+	repo := subject.Context()
+	auth, err := registryclient.NewKeychain(registryOptions).Resolve(repo.Registry)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to resolve credentials for registry=%q: %w", repo.RegistryStr(), err)
+	}
+	t, err := transport.NewWithContext(ctx, repo.Registry, auth, registryclient.Transport(registryOptions), []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to connect to registry=%q: %w", repo.RegistryStr(), err)
+	}
+
+	u := url.URL{
+		Scheme: repo.Registry.Scheme(),
+		Host:   repo.RegistryStr(),
+		Path:   fmt.Sprintf("/v2/%s/referrers/%s", repo.RepositoryStr(), subject.DigestStr()),
+	}
+	if artifactType != "" {
+		u.RawQuery = url.Values{"artifactType": []string{artifactType}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Accept", string(types.OCIImageIndex))
+
+	resp, err := (&http.Client{Transport: t}).Do(req)
+	if err != nil {
+		return nil, false, fmt.Errorf("unable to list referrers: %w", err)
+	}
+	defer internal.CloseAndLogError(resp.Body, u.String())
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, false, nil
+	}
+	if err := transport.CheckError(resp, http.StatusOK); err != nil {
+		return nil, false, fmt.Errorf("unable to list referrers: %w", err)
+	}
+
+	var idx index
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&idx); err != nil {
+		return nil, false, fmt.Errorf("unable to decode referrers: %w", err)
+	}
+	return idx.Manifests, true, nil
+}
+
+// tagIndex returns the index of the referrers tag of the image with the given digest (empty when there is no such tag).
+func tagIndex(ctx context.Context, subject name.Digest, registryOptions *image.RegistryOptions) (*index, error) {
+	idx := &index{
+		SchemaVersion: 2,
+		MediaType:     types.OCIImageIndex,
+	}
+
+	got, err := remote.Get(tagFor(subject), registryclient.RemoteOptions(ctx, registryOptions)...)
+	if err != nil {
+		var terr *transport.Error
+		if errors.As(err, &terr) && terr.StatusCode == http.StatusNotFound {
+			return idx, nil
+		}
+		return nil, fmt.Errorf("unable to fetch referrers index: %w", err)
+	}
+
+	if err := json.Unmarshal(got.Manifest, idx); err != nil {
+		return nil, fmt.Errorf("unable to decode referrers index: %w", err)
+	}
+	return idx, nil
+}
+
+// addToTagIndex adds the artifact to the index of the referrers tag of the image with the given digest.
+func addToTagIndex(ctx context.Context, subject name.Digest, desc Descriptor, registryOptions *image.RegistryOptions) error {
+	idx, err := tagIndex(ctx, subject, registryOptions)
+	if err != nil {
+		return err
+	}
+	for _, existing := range idx.Manifests {
+		if existing.Digest == desc.Digest {
+			return nil
+		}
+	}
+	idx.Manifests = append(idx.Manifests, desc)
+
+	raw, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("unable to encode referrers index: %w", err)
+	}
+	if err := remote.Put(tagFor(subject), rawManifest{raw: raw, mediaType: types.OCIImageIndex}, registryclient.RemoteOptions(ctx, registryOptions)...); err != nil {
+		return fmt.Errorf("unable to upload referrers index: %w", err)
+	}
+	return nil
+}
+
+// tagFor returns the referrers tag of the image with the given digest (e.g. "sha256-<hex>").
+func tagFor(subject name.Digest) name.Tag {
+	return subject.Context().Tag(strings.Replace(subject.DigestStr(), ":", "-", 1))
+}
+
+func layerDescriptor(layer v1.Layer, annotations map[string]string) (*v1.Descriptor, error) {
+	digest, err := layer.Digest()
+	if err != nil {
+		return nil, err
+	}
+	size, err := layer.Size()
+	if err != nil {
+		return nil, err
+	}
+	mediaType, err := layer.MediaType()
+	if err != nil {
+		return nil, err
+	}
+	return &v1.Descriptor{
+		MediaType:   mediaType,
+		Size:        size,
+		Digest:      digest,
+		Annotations: annotations,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package referrers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/formats/spdx22json"
+	"github.com/anchore/syft/syft/formats/syftjson"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// pushImage pushes a random image to the given registry, returning its digest.
+func pushImage(t *testing.T, host string) name.Digest {
+	t.Helper()
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+
+	ref, err := name.ParseReference(fmt.Sprintf("%s/test/image:latest", host))
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	digest, err := img.Digest()
+	require.NoError(t, err)
+	return ref.Context().Digest(digest.String())
+}
+
+func encodeSBOM(t *testing.T, format sbom.FormatID, userInput string) []byte {
+	t.Helper()
+
+	b, err := syft.Encode(sbom.SBOM{
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput: userInput,
+			},
+		},
+	}, syft.FormatByID(format))
+	require.NoError(t, err)
+	return b
+}
+
+func attachSBOM(t *testing.T, subject name.Digest, format sbom.FormatID, b []byte) name.Digest {
+	t.Helper()
+
+	artifactType := SBOMArtifactType(format)
+	ref, err := Attach(context.Background(), subject, Artifact{
+		ArtifactType: artifactType,
+		Layer:        static.NewLayer(b, types.MediaType(artifactType)),
+	}, nil)
+	require.NoError(t, err)
+	return ref
+}
+
+func TestAttach_referrersTag(t *testing.T) {
+	// note: this registry does not support the referrers API, so the referrers tag is used
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	subject := pushImage(t, u.Host)
+	ctx := context.Background()
+
+	descs, err := List(ctx, subject, "", nil)
+	require.NoError(t, err)
+	assert.Empty(t, descs)
+
+	s, err := FindSBOM(ctx, subject, nil)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+
+	spdx := encodeSBOM(t, spdx22json.ID, "spdx")
+	attachSBOM(t, subject, spdx22json.ID, spdx)
+	attachSBOM(t, subject, syftjson.ID, encodeSBOM(t, syftjson.ID, "first"))
+	ref := attachSBOM(t, subject, syftjson.ID, encodeSBOM(t, syftjson.ID, "second"))
+
+	descs, err = List(ctx, subject, "", nil)
+	require.NoError(t, err)
+	assert.Len(t, descs, 3)
+
+	descs, err = List(ctx, subject, SBOMArtifactType(spdx22json.ID), nil)
+	require.NoError(t, err)
+	require.Len(t, descs, 1)
+
+	got, err := Fetch(ctx, subject.Context(), descs[0], nil)
+	require.NoError(t, err)
+	assert.Equal(t, spdx, got)
+
+	// the artifact refers to the image
+	desc, err := remote.Get(ref)
+	require.NoError(t, err)
+	var m manifest
+	require.NoError(t, json.Unmarshal(desc.Manifest, &m))
+	require.NotNil(t, m.Subject)
+	assert.Equal(t, subject.DigestStr(), m.Subject.Digest.String())
+	assert.Equal(t, SBOMArtifactType(syftjson.ID), m.ArtifactType)
+
+	// the most recently attached SBOM in the preferred format is found
+	s, err = FindSBOM(ctx, subject, nil)
+	require.NoError(t, err)
+	require.NotNil(t, s)
+	assert.Equal(t, "second", s.Source.ImageMetadata.UserInput)
+}
+
+func TestList_referrersAPI(t *testing.T) {
+	artifact, err := v1.NewHash("sha256:" + strings.Repeat("a", 64))
+	require.NoError(t, err)
+
+	reg := registry.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/referrers/") {
+			reg.ServeHTTP(w, r)
+			return
+		}
+		// note: the artifact type filter is ignored, as is allowed of registries
+		w.Header().Set("Content-Type", string(types.OCIImageIndex))
+		_ = json.NewEncoder(w).Encode(index{
+			SchemaVersion: 2,
+			MediaType:     types.OCIImageIndex,
+			Manifests: []Descriptor{
+				{
+					Descriptor:   v1.Descriptor{MediaType: types.OCIManifestSchema1, Digest: artifact, Size: 100},
+					ArtifactType: SBOMArtifactType(syftjson.ID),
+				},
+				{
+					Descriptor:   v1.Descriptor{MediaType: types.OCIManifestSchema1, Digest: artifact, Size: 100},
+					ArtifactType: "application/vnd.example+json",
+				},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	subject := pushImage(t, u.Host)
+
+	descs, err := List(context.Background(), subject, SBOMArtifactType(syftjson.ID), nil)
+	require.NoError(t, err)
+	require.Len(t, descs, 1)
+	assert.Equal(t, artifact, descs[0].Digest)
+}
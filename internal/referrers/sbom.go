@@ -0,0 +1,78 @@
+package referrers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/syft"
+	"github.com/anchore/syft/syft/formats/cyclonedxjson"
+	"github.com/anchore/syft/syft/formats/cyclonedxxml"
+	"github.com/anchore/syft/syft/formats/spdx22json"
+	"github.com/anchore/syft/syft/formats/spdx22tagvalue"
+	"github.com/anchore/syft/syft/formats/syftjson"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// sbomArtifactTypes are the artifact types of SBOMs attached to images, by format (in order of preference when
+// retrieving an attached SBOM).
+var sbomArtifactTypes = []struct {
+	format       sbom.FormatID
+	artifactType string
+}{
+	{format: syftjson.ID, artifactType: "application/vnd.syft+json"},
+	{format: spdx22json.ID, artifactType: "application/spdx+json"},
+	{format: cyclonedxjson.ID, artifactType: "application/vnd.cyclonedx+json"},
+	{format: cyclonedxxml.ID, artifactType: "application/vnd.cyclonedx+xml"},
+	{format: spdx22tagvalue.ID, artifactType: "text/spdx"},
+}
+
+// SBOMArtifactType returns the artifact type of an attached SBOM in the given format (empty when SBOMs in the format
+// cannot be attached).
+func SBOMArtifactType(format sbom.FormatID) string {
+	for _, t := range sbomArtifactTypes {
+		if t.format == format {
+			return t.artifactType
+		}
+	}
+	return ""
+}
+
+// SBOMArtifactTypes returns the artifact types of all SBOMs that can be attached, in order of preference.
+func SBOMArtifactTypes() []string {
+	var types []string
+	for _, t := range sbomArtifactTypes {
+		types = append(types, t.artifactType)
+	}
+	return types
+}
+
+// FindSBOM returns the SBOM attached to the image with the given digest, or nil when there is none. When several SBOMs
+// are attached, the most recently attached SBOM in the preferred format is returned.
+func FindSBOM(ctx context.Context, subject name.Digest, registryOptions *image.RegistryOptions) (*sbom.SBOM, error) {
+	descs, err := List(ctx, subject, "", registryOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, t := range sbomArtifactTypes {
+		for i := len(descs) - 1; i >= 0; i-- {
+			if descs[i].ArtifactType != t.artifactType {
+				continue
+			}
+			b, err := Fetch(ctx, subject.Context(), descs[i], registryOptions)
+			if err != nil {
+				return nil, err
+			}
+			s, _, err := syft.Decode(bytes.NewReader(b))
+			if err != nil {
+				return nil, fmt.Errorf("unable to decode attached SBOM=%q: %w", descs[i].Digest, err)
+			}
+			return s, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,80 @@
+/*
+Package registryclient configures access to container registries (credentials, TLS and plain HTTP) from the registry
+options of the application.
+*/
+package registryclient
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/anchore/stereoscope/pkg/image"
+)
+
+// NameOptions returns the options for parsing image references within the registry.
+func NameOptions(registryOptions *image.RegistryOptions) []name.Option {
+	if registryOptions != nil && registryOptions.InsecureUseHTTP {
+		return []name.Option{name.Insecure}
+	}
+	return nil
+}
+
+// RemoteOptions returns the options for requests to the registry.
+func RemoteOptions(ctx context.Context, registryOptions *image.RegistryOptions) []remote.Option {
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(NewKeychain(registryOptions)),
+		remote.WithTransport(Transport(registryOptions)),
+	}
+}
+
+// Transport returns the transport for requests to the registry.
+func Transport(registryOptions *image.RegistryOptions) http.RoundTripper {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if registryOptions != nil && registryOptions.InsecureSkipTLSVerify {
+		t.TLSClientConfig = &tls.Config{
+			InsecureSkipVerify: true, //nolint:gosec
+		}
+	}
+	return t
+}
+
+// NewKeychain returns the keychain of the configured registry credentials.
+func NewKeychain(registryOptions *image.RegistryOptions) authn.Keychain {
+	var k keychain
+	if registryOptions != nil {
+		k.credentials = registryOptions.Credentials
+	}
+	return k
+}
+
+var _ authn.Keychain = (*keychain)(nil)
+
+// keychain provides the configured registry credentials, falling back to the default docker keychain (docker
+// config and credential helpers) for registries without any configured credentials.
+type keychain struct {
+	credentials []image.RegistryCredentials
+}
+
+func (k keychain) Resolve(resource authn.Resource) (authn.Authenticator, error) {
+	for _, c := range k.credentials {
+		if c.Authority != "" {
+			registry, err := name.NewRegistry(c.Authority)
+			if err != nil || registry.RegistryStr() != resource.RegistryStr() {
+				continue
+			}
+		}
+		switch {
+		case c.Token != "":
+			return authn.FromConfig(authn.AuthConfig{RegistryToken: c.Token}), nil
+		case c.Username != "" && c.Password != "":
+			return authn.FromConfig(authn.AuthConfig{Username: c.Username, Password: c.Password}), nil
+		}
+	}
+	return authn.DefaultKeychain.Resolve(resource)
+}
@@ -0,0 +1,85 @@
+package spdxlicense
+
+// licenseAliases maps common (lowercase, single-spaced) license names that are not on the SPDX license list to their
+// SPDX license ID. Names that only differ from an SPDX license ID by spaces instead of dashes (e.g. "Apache 2.0") do
+// not need an alias.
+var licenseAliases = map[string]string{
+	// GNU licenses
+	"gplv1":        "GPL-1.0-only",
+	"gplv1+":       "GPL-1.0-or-later",
+	"gpl1":         "GPL-1.0-only",
+	"gpl1+":        "GPL-1.0-or-later",
+	"gplv2":        "GPL-2.0-only",
+	"gplv2+":       "GPL-2.0-or-later",
+	"gpl2":         "GPL-2.0-only",
+	"gpl2+":        "GPL-2.0-or-later",
+	"gplv3":        "GPL-3.0-only",
+	"gplv3+":       "GPL-3.0-or-later",
+	"gpl3":         "GPL-3.0-only",
+	"gpl3+":        "GPL-3.0-or-later",
+	"lgplv2":       "LGPL-2.0-only",
+	"lgplv2+":      "LGPL-2.0-or-later",
+	"lgpl2":        "LGPL-2.0-only",
+	"lgpl2+":       "LGPL-2.0-or-later",
+	"lgplv2.1":     "LGPL-2.1-only",
+	"lgplv2.1+":    "LGPL-2.1-or-later",
+	"lgpl2.1":      "LGPL-2.1-only",
+	"lgpl2.1+":     "LGPL-2.1-or-later",
+	"lgplv3":       "LGPL-3.0-only",
+	"lgplv3+":      "LGPL-3.0-or-later",
+	"lgpl3":        "LGPL-3.0-only",
+	"lgpl3+":       "LGPL-3.0-or-later",
+	"agplv3":       "AGPL-3.0-only",
+	"agplv3+":      "AGPL-3.0-or-later",
+	"agpl3":        "AGPL-3.0-only",
+	"agpl3+":       "AGPL-3.0-or-later",
+	"fdlv1.3":      "GFDL-1.3-only",
+	"gfdlv1.3":     "GFDL-1.3-only",
+	"gnu gplv2":    "GPL-2.0-only",
+	"gnu gplv3":    "GPL-3.0-only",
+	"gnu lgplv2.1": "LGPL-2.1-only",
+	"gnu lgplv3":   "LGPL-3.0-only",
+
+	// Apache licenses
+	"apache2":                                  "Apache-2.0",
+	"apache 2":                                 "Apache-2.0",
+	"apache license 2.0":                       "Apache-2.0",
+	"apache license, version 2.0":              "Apache-2.0",
+	"apache license version 2.0":               "Apache-2.0",
+	"apache software license":                  "Apache-2.0",
+	"apache software license 2.0":              "Apache-2.0",
+	"the apache software license, version 2.0": "Apache-2.0",
+	"asl 2.0":                                  "Apache-2.0",
+	"asl2":                                     "Apache-2.0",
+
+	// BSD licenses
+	"simplified bsd": "BSD-2-Clause",
+	"freebsd":        "BSD-2-Clause",
+	"2-clause bsd":   "BSD-2-Clause",
+	"bsd 2-clause":   "BSD-2-Clause",
+	"new bsd":        "BSD-3-Clause",
+	"modified bsd":   "BSD-3-Clause",
+	"revised bsd":    "BSD-3-Clause",
+	"3-clause bsd":   "BSD-3-Clause",
+	"bsd 3-clause":   "BSD-3-Clause",
+
+	// other permissive licenses
+	"mit license":                        "MIT",
+	"the mit license":                    "MIT",
+	"expat":                              "MIT",
+	"isc license":                        "ISC",
+	"zlib license":                       "Zlib",
+	"boost":                              "BSL-1.0",
+	"boost software license":             "BSL-1.0",
+	"boost software license 1.0":         "BSL-1.0",
+	"cc0":                                "CC0-1.0",
+	"the unlicense":                      "Unlicense",
+	"psf":                                "PSF-2.0",
+	"python software foundation license": "PSF-2.0",
+
+	// weak copyleft licenses
+	"mpl2":                       "MPL-2.0",
+	"mozilla public license 2.0": "MPL-2.0",
+	"eclipse public license 1.0": "EPL-1.0",
+	"eclipse public license 2.0": "EPL-2.0",
+}
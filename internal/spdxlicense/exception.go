@@ -0,0 +1,57 @@
+package spdxlicense
+
+import "strings"
+
+// exceptionIDs maps lowercase SPDX license exception IDs to their canonical form
+// using data from https://spdx.org/licenses/exceptions.json
+var exceptionIDs = map[string]string{
+	"389-exception":                     "389-exception",
+	"autoconf-exception-2.0":            "Autoconf-exception-2.0",
+	"autoconf-exception-3.0":            "Autoconf-exception-3.0",
+	"bison-exception-2.2":               "Bison-exception-2.2",
+	"bootloader-exception":              "Bootloader-exception",
+	"classpath-exception-2.0":           "Classpath-exception-2.0",
+	"clisp-exception-2.0":               "CLISP-exception-2.0",
+	"digirule-foss-exception":           "DigiRule-FOSS-exception",
+	"ecos-exception-2.0":                "eCos-exception-2.0",
+	"fawkes-runtime-exception":          "Fawkes-Runtime-exception",
+	"fltk-exception":                    "FLTK-exception",
+	"font-exception-2.0":                "Font-exception-2.0",
+	"freertos-exception-2.0":            "freertos-exception-2.0",
+	"gcc-exception-2.0":                 "GCC-exception-2.0",
+	"gcc-exception-3.1":                 "GCC-exception-3.1",
+	"gnu-javamail-exception":            "gnu-javamail-exception",
+	"gpl-3.0-linking-exception":         "GPL-3.0-linking-exception",
+	"gpl-3.0-linking-source-exception":  "GPL-3.0-linking-source-exception",
+	"gpl-cc-1.0":                        "GPL-CC-1.0",
+	"gstreamer-exception-2005":          "GStreamer-exception-2005",
+	"gstreamer-exception-2008":          "GStreamer-exception-2008",
+	"i2p-gpl-java-exception":            "i2p-gpl-java-exception",
+	"kicad-libraries-exception":         "KiCad-libraries-exception",
+	"lgpl-3.0-linking-exception":        "LGPL-3.0-linking-exception",
+	"libtool-exception":                 "Libtool-exception",
+	"linux-syscall-note":                "Linux-syscall-note",
+	"llvm-exception":                    "LLVM-exception",
+	"lzma-exception":                    "LZMA-exception",
+	"mif-exception":                     "mif-exception",
+	"ocaml-lgpl-linking-exception":      "OCaml-LGPL-linking-exception",
+	"occt-exception-1.0":                "OCCT-exception-1.0",
+	"openjdk-assembly-exception-1.0":    "OpenJDK-assembly-exception-1.0",
+	"openvpn-openssl-exception":         "openvpn-openssl-exception",
+	"ps-or-pdf-font-exception-20170817": "PS-or-PDF-font-exception-20170817",
+	"qt-gpl-exception-1.0":              "Qt-GPL-exception-1.0",
+	"qt-lgpl-exception-1.1":             "Qt-LGPL-exception-1.1",
+	"qwt-exception-1.0":                 "Qwt-exception-1.0",
+	"shl-2.0":                           "SHL-2.0",
+	"shl-2.1":                           "SHL-2.1",
+	"swift-exception":                   "Swift-exception",
+	"u-boot-exception-2.0":              "u-boot-exception-2.0",
+	"universal-foss-exception-1.0":      "Universal-FOSS-exception-1.0",
+	"wxwindows-exception-3.1":           "WxWindows-exception-3.1",
+}
+
+// ExceptionID returns the SPDX license exception ID for the given exception (case-insensitive).
+func ExceptionID(id string) (string, bool) {
+	value, exists := exceptionIDs[strings.ToLower(strings.TrimSpace(id))]
+	return value, exists
+}
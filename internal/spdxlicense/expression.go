@@ -0,0 +1,256 @@
+package spdxlicense
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	licenseRefPrefix  = "LicenseRef-"
+	documentRefPrefix = "DocumentRef-"
+)
+
+// Operator combines the operands of a compound license expression.
+type Operator string
+
+const (
+	AndOperator Operator = "AND"
+	OrOperator  Operator = "OR"
+)
+
+// Expression is a parsed SPDX license expression (see https://spdx.github.io/spdx-spec/SPDX-license-expressions/).
+// Simple expressions describe a single license (optionally with an exception), while compound expressions combine
+// other expressions with an operator.
+type Expression struct {
+	License   string        // the license ID (or LicenseRef-) of a simple expression
+	OrLater   bool          // the "+" operator: the given license version or any later version
+	Exception string        // the exception ID of a "WITH" expression
+	Operator  Operator      // the operator of a compound expression
+	Operands  []*Expression // the operands of a compound expression
+}
+
+// ParseExpression parses the given SPDX license expression, validating every license and exception ID and normalizing
+// them to their canonical form (correcting common aliases, e.g. "GPLv2" to "GPL-2.0-only"). Operators are accepted in
+// any case and "/" is accepted as a legacy form of the "OR" operator.
+func ParseExpression(expression string) (*Expression, error) {
+	// the whole expression may be a single license name containing spaces (e.g. "Apache License 2.0")
+	if id, exists := ID(expression); exists {
+		return &Expression{License: id}, nil
+	}
+
+	p := expressionParser{tokens: tokenizeExpression(expression)}
+	if len(p.tokens) == 0 {
+		return nil, errors.New("empty license expression")
+	}
+
+	result, err := p.parseOr()
+	if err != nil {
+		return nil, fmt.Errorf("invalid license expression %q: %w", expression, err)
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("invalid license expression %q: unexpected %q", expression, p.tokens[p.pos])
+	}
+	return result, nil
+}
+
+// NormalizeExpression returns the canonical form of the given SPDX license expression (see ParseExpression).
+func NormalizeExpression(expression string) (string, error) {
+	result, err := ParseExpression(expression)
+	if err != nil {
+		return "", err
+	}
+	return result.String(), nil
+}
+
+// IsLicenseID indicates if the expression is a single license from the SPDX license list (without any operators,
+// exceptions, or license references).
+func (e Expression) IsLicenseID() bool {
+	if e.Operator != "" || e.OrLater || e.Exception != "" {
+		return false
+	}
+	return !strings.HasPrefix(e.License, licenseRefPrefix) && !strings.HasPrefix(e.License, documentRefPrefix)
+}
+
+// String returns the canonical form of the expression, grouping every compound operand within parentheses.
+func (e Expression) String() string {
+	if e.Operator == "" {
+		value := e.License
+		if e.OrLater {
+			value += "+"
+		}
+		if e.Exception != "" {
+			value += " WITH " + e.Exception
+		}
+		return value
+	}
+
+	operands := make([]string, len(e.Operands))
+	for i, operand := range e.Operands {
+		operands[i] = operand.String()
+		if operand.Operator != "" {
+			operands[i] = "(" + operands[i] + ")"
+		}
+	}
+	return strings.Join(operands, " "+string(e.Operator)+" ")
+}
+
+func tokenizeExpression(expression string) []string {
+	var tokens []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, r := range expression {
+		switch {
+		case r == '(' || r == ')' || r == '/':
+			flush()
+			tokens = append(tokens, string(r))
+		case unicode.IsSpace(r):
+			flush()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+// expressionParser is a recursive descent parser over expression tokens, where (from highest to lowest precedence)
+// "WITH" binds tighter than "AND", which binds tighter than "OR".
+type expressionParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *expressionParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *expressionParser) next() string {
+	token := p.peek()
+	if token != "" {
+		p.pos++
+	}
+	return token
+}
+
+func (p *expressionParser) parseOr() (*Expression, error) {
+	return p.parseCompound(OrOperator, p.parseAnd)
+}
+
+func (p *expressionParser) parseAnd() (*Expression, error) {
+	return p.parseCompound(AndOperator, p.parseWith)
+}
+
+func (p *expressionParser) parseCompound(operator Operator, parseOperand func() (*Expression, error)) (*Expression, error) {
+	first, err := parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := []*Expression{first}
+	for isOperator(p.peek(), operator) {
+		p.pos++
+		operand, err := parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, operand)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+
+	// flatten nested expressions with the same operator, e.g. "(MIT AND ISC) AND Zlib" ---> "MIT AND ISC AND Zlib"
+	var flattened []*Expression
+	for _, operand := range operands {
+		if operand.Operator == operator {
+			flattened = append(flattened, operand.Operands...)
+			continue
+		}
+		flattened = append(flattened, operand)
+	}
+	return &Expression{Operator: operator, Operands: flattened}, nil
+}
+
+func (p *expressionParser) parseWith() (*Expression, error) {
+	result, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(p.peek(), "WITH") {
+		return result, nil
+	}
+	p.pos++
+
+	if result.Operator != "" || result.Exception != "" {
+		return nil, errors.New("WITH must follow a single license")
+	}
+
+	token := p.next()
+	if token == "" {
+		return nil, errors.New("missing exception after WITH")
+	}
+	exception, exists := ExceptionID(token)
+	if !exists {
+		return nil, fmt.Errorf("unknown license exception %q", token)
+	}
+	result.Exception = exception
+	return result, nil
+}
+
+func (p *expressionParser) parsePrimary() (*Expression, error) {
+	token := p.next()
+	switch {
+	case token == "":
+		return nil, errors.New("unexpected end of expression")
+	case token == "(":
+		result, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.next() != ")" {
+			return nil, errors.New("missing closing parenthesis")
+		}
+		return result, nil
+	case token == ")" || token == "/" || isOperator(token, AndOperator) || isOperator(token, OrOperator) || strings.EqualFold(token, "WITH"):
+		return nil, fmt.Errorf("unexpected %q", token)
+	}
+	return parseLicense(token)
+}
+
+func parseLicense(token string) (*Expression, error) {
+	for _, prefix := range []string{licenseRefPrefix, documentRefPrefix} {
+		if len(token) > len(prefix) && strings.EqualFold(token[:len(prefix)], prefix) {
+			return &Expression{License: prefix + token[len(prefix):]}, nil
+		}
+	}
+
+	if id, exists := ID(token); exists {
+		return &Expression{License: id}, nil
+	}
+
+	if strings.HasSuffix(token, "+") {
+		if id, exists := ID(strings.TrimSuffix(token, "+")); exists {
+			return &Expression{License: id, OrLater: true}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown license %q", token)
+}
+
+func isOperator(token string, operator Operator) bool {
+	return strings.EqualFold(token, string(operator)) || (operator == OrOperator && token == "/")
+}
@@ -0,0 +1,110 @@
+package spdxlicense
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeExpression(t *testing.T) {
+	tests := []struct {
+		expression string
+		expected   string
+		wantErr    require.ErrorAssertionFunc
+	}{
+		{
+			expression: "MIT",
+			expected:   "MIT",
+		},
+		{
+			expression: "mit",
+			expected:   "MIT",
+		},
+		{
+			expression: "GPLv2",
+			expected:   "GPL-2.0-only",
+		},
+		{
+			expression: "Apache License 2.0",
+			expected:   "Apache-2.0",
+		},
+		{
+			expression: "Apache 2.0",
+			expected:   "Apache-2.0",
+		},
+		{
+			expression: "MIT or apache-2.0",
+			expected:   "MIT OR Apache-2.0",
+		},
+		{
+			expression: "MIT/Apache-2.0",
+			expected:   "MIT OR Apache-2.0",
+		},
+		{
+			expression: "GPLv2+ AND (BSD-3-Clause OR MIT)",
+			expected:   "GPL-2.0-or-later AND (BSD-3-Clause OR MIT)",
+		},
+		{
+			expression: "(MIT AND ISC) AND Zlib",
+			expected:   "MIT AND ISC AND Zlib",
+		},
+		{
+			expression: "MIT OR ISC AND Zlib",
+			expected:   "MIT OR (ISC AND Zlib)",
+		},
+		{
+			expression: "GPL-2.0 with classpath-exception-2.0",
+			expected:   "GPL-2.0-only WITH Classpath-exception-2.0",
+		},
+		{
+			expression: "Apache-2.0+",
+			expected:   "Apache-2.0+",
+		},
+		{
+			expression: "licenseref-acme AND MIT",
+			expected:   "LicenseRef-acme AND MIT",
+		},
+		{
+			expression: "",
+			wantErr:    require.Error,
+		},
+		{
+			expression: "MIT AND",
+			wantErr:    require.Error,
+		},
+		{
+			expression: "(MIT OR ISC",
+			wantErr:    require.Error,
+		},
+		{
+			expression: "MIT ISC",
+			wantErr:    require.Error,
+		},
+		{
+			expression: "made-up OR MIT",
+			wantErr:    require.Error,
+		},
+		{
+			expression: "MIT WITH made-up-exception",
+			wantErr:    require.Error,
+		},
+		{
+			expression: "(MIT OR ISC) WITH Classpath-exception-2.0",
+			wantErr:    require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.expression, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			got, err := NormalizeExpression(test.expression)
+			test.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
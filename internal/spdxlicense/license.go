@@ -14,7 +14,20 @@ import (
 
 //go:generate go run ./generate
 
+// ID returns the SPDX license ID for the given license, resolving both SPDX license IDs (case-insensitive) and common
+// aliases that are not on the SPDX license list (e.g. "GPLv2" or "Apache License 2.0").
 func ID(id string) (string, bool) {
-	value, exists := licenseIDs[strings.ToLower(id)]
+	key := strings.ToLower(strings.TrimSpace(id))
+	if value, exists := licenseIDs[key]; exists {
+		return value, exists
+	}
+
+	key = strings.Join(strings.Fields(key), " ")
+	if value, exists := licenseAliases[key]; exists {
+		return value, exists
+	}
+
+	// e.g. "Apache 2.0" ---> apache-2.0
+	value, exists := licenseIDs[strings.ReplaceAll(key, " ", "-")]
 	return value, exists
 }
@@ -35,6 +35,22 @@ func TestIDParse(t *testing.T) {
 			"CC-by-nc-3-de",
 			"CC-BY-NC-3.0-DE",
 		},
+		{
+			"GPLv2",
+			"GPL-2.0-only",
+		},
+		{
+			"lgplv2.1+",
+			"LGPL-2.1-or-later",
+		},
+		{
+			"The Apache Software License, Version 2.0",
+			"Apache-2.0",
+		},
+		{
+			"Apache  2.0",
+			"Apache-2.0",
+		},
 		// the below few cases are NOT expected, however, seem unavoidable given the current approach
 		{
 			"w3c-20150513.0.0",
@@ -9,7 +9,7 @@ import (
 )
 
 // handleExit is a UI function for processing the Exit bus event,
-// and calling the given function to output the contents.
+// and calling the given function to output the contents (returning the error of the function).
 func handleExit(event partybus.Event) error {
 	// show the report to stdout
 	fn, err := syftEventParsers.ParseExit(event)
@@ -17,8 +17,7 @@ func handleExit(event partybus.Event) error {
 		return fmt.Errorf("bad CatalogerFinished event: %w", err)
 	}
 
-	if err := fn(); err != nil {
-		return fmt.Errorf("unable to show package catalog report: %v", err)
-	}
-	return nil
+	// note: the error is returned as-is, since it may describe the outcome of the command (e.g. a policy violation)
+	// rather than a failure to show the report
+	return fn()
 }
@@ -17,6 +17,7 @@ import (
 	"github.com/anchore/go-logger"
 	"github.com/anchore/syft/internal/log"
 	syftEvent "github.com/anchore/syft/syft/event"
+	syftEventParsers "github.com/anchore/syft/syft/event/parsers"
 	"github.com/anchore/syft/ui"
 )
 
@@ -43,6 +44,7 @@ type ephemeralTerminalUI struct {
 	frame       *frame.Frame
 	logBuffer   *bytes.Buffer
 	uiOutput    *os.File
+	summary     *catalogSummary
 }
 
 // NewEphemeralTerminalUI writes all events to a TUI and writes the final report to the given writer.
@@ -51,6 +53,7 @@ func NewEphemeralTerminalUI() UI {
 		handler:   ui.NewHandler(),
 		waitGroup: &sync.WaitGroup{},
 		uiOutput:  os.Stderr,
+		summary:   &catalogSummary{},
 	}
 }
 
@@ -70,6 +73,13 @@ func (h *ephemeralTerminalUI) Setup(unsubscribe func() error) error {
 
 func (h *ephemeralTerminalUI) Handle(event partybus.Event) error {
 	ctx := context.Background()
+	if event.Type == syftEvent.PackageCatalogerStarted {
+		// remember the state of each cataloger for the summary shown before the report
+		if monitor, err := syftEventParsers.ParsePackageCatalogerStarted(event); err == nil {
+			h.summary.add(monitor)
+		}
+	}
+
 	switch {
 	case h.handler.RespondsTo(event):
 		if err := h.handler.Handle(ctx, h.frame, event, h.waitGroup); err != nil {
@@ -85,13 +95,15 @@ func (h *ephemeralTerminalUI) Handle(event partybus.Event) error {
 		// we need to close the screen now since signaling the sbom is ready means that we
 		// are about to write bytes to stdout, so we should reset the terminal state first
 		h.closeScreen(false)
+		h.summary.write(h.uiOutput)
 
-		if err := handleExit(event); err != nil {
-			log.Errorf("unable to show %s event: %+v", event.Type, err)
+		// this is the last expected event, stop listening to events (the error of the exit function, such as a policy
+		// violation, is returned to the event loop)
+		err := handleExit(event)
+		if unsubscribeErr := h.unsubscribe(); unsubscribeErr != nil {
+			log.Warnf("unable to unsubscribe from events: %+v", unsubscribeErr)
 		}
-
-		// this is the last expected event, stop listening to events
-		return h.unsubscribe()
+		return err
 	}
 	return nil
 }
@@ -0,0 +1,77 @@
+//go:build linux || darwin
+// +build linux darwin
+
+package ui
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/gookit/color"
+
+	"github.com/anchore/syft/syft/pkg/cataloger"
+)
+
+// catalogSummary accumulates the final state of each cataloger over every package cataloging run (e.g. one run for
+// each image layer), in the order the catalogers were first run.
+type catalogSummary struct {
+	monitors []*cataloger.Monitor
+}
+
+func (s *catalogSummary) add(monitor *cataloger.Monitor) {
+	if monitor.Catalogers != nil {
+		s.monitors = append(s.monitors, monitor)
+	}
+}
+
+func (s *catalogSummary) states() []cataloger.CatalogerState {
+	var states []cataloger.CatalogerState
+	byName := make(map[string]int)
+	for _, monitor := range s.monitors {
+		for _, state := range monitor.Catalogers.States() {
+			idx, ok := byName[state.Name]
+			if !ok {
+				byName[state.Name] = len(states)
+				states = append(states, state)
+				continue
+			}
+			existing := &states[idx]
+			existing.Packages += state.Packages
+			existing.FilesRead += state.FilesRead
+			existing.Duration += state.Duration
+			if state.Status == cataloger.CatalogerFailed {
+				existing.Status = state.Status
+				existing.Error = state.Error
+			}
+		}
+	}
+	return states
+}
+
+// write shows a table of the catalogers that found packages (or failed) before the report is written, so that the
+// results of a long scan can be checked at a glance.
+func (s *catalogSummary) write(w io.Writer) {
+	var rows []cataloger.CatalogerState
+	for _, state := range s.states() {
+		if state.Packages > 0 || state.Status == cataloger.CatalogerFailed {
+			rows = append(rows, state)
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	// note: only the last column is colored, since escape codes would throw off the alignment of the others
+	fmt.Fprintln(tw, " CATALOGER\tPACKAGES\tFILES READ\tDURATION\tSTATUS")
+	for _, state := range rows {
+		status := string(state.Status)
+		if state.Status == cataloger.CatalogerFailed {
+			status = color.Red.Sprintf("%s: %s", state.Status, state.Error)
+		}
+		fmt.Fprintf(tw, " %s\t%d\t%d\t%s\t%s\n", state.Name, state.Packages, state.FilesRead, state.Duration.Round(time.Millisecond), status)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/wagoodman/go-partybus"
+	"github.com/wagoodman/go-progress"
+
+	stereoscopeEvent "github.com/anchore/stereoscope/pkg/event"
+	stereoEventParsers "github.com/anchore/stereoscope/pkg/event/parsers"
+	"github.com/anchore/syft/internal/log"
+	syftEvent "github.com/anchore/syft/syft/event"
+	syftEventParsers "github.com/anchore/syft/syft/event/parsers"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+)
+
+const jsonProgressInterval = 500 * time.Millisecond
+
+// progress event statuses
+const (
+	progressStarted  = "started"
+	progressRunning  = "running"
+	progressFinished = "finished"
+	progressFailed   = "failed"
+)
+
+// progressEvent is a single line written by the JSON progress UI, describing the state of one stage of the command.
+type progressEvent struct {
+	// Time is when the state was observed (RFC 3339)
+	Time string `json:"time"`
+	// Stage identifies the work being done (e.g. "package-cataloging", "file-indexing", "image-read")
+	Stage string `json:"stage"`
+	// Status is one of "started", "running", "finished", or "failed"
+	Status string `json:"status"`
+	// Subject is what the stage is working on, when there is more than one of the stage (e.g. the path being indexed)
+	Subject string `json:"subject,omitempty"`
+	// Detail is the current step within the stage (e.g. the file being read)
+	Detail string `json:"detail,omitempty"`
+	// Current is the amount of work done (e.g. catalogers finished, files read, or bytes fetched)
+	Current int64 `json:"current"`
+	// Total is the amount of work to do (0 when not known)
+	Total int64 `json:"total"`
+	// Percent is the percentage of work done (only when the total is known)
+	Percent *float64 `json:"percent,omitempty"`
+	// ETASeconds is the estimated time until the stage finishes (only once some, but not all, work is done)
+	ETASeconds *float64 `json:"etaSeconds,omitempty"`
+	// Packages is the number of packages discovered so far (package cataloging only)
+	Packages *int64 `json:"packages,omitempty"`
+	// Files is the number of files read so far (package cataloging only)
+	Files *int64 `json:"files,omitempty"`
+	// Running are the names of the catalogers that are running (package cataloging only)
+	Running []string `json:"running,omitempty"`
+	// Failed are the names of the catalogers that failed or timed out (package cataloging only)
+	Failed []string `json:"failed,omitempty"`
+	// Error describes why the stage failed
+	Error string `json:"error,omitempty"`
+}
+
+// jsonProgressUI writes the progress of each stage as a JSON object per line (to stderr), so that applications
+// embedding syft can render their own progress. Each stage is polled until complete, emitting an event only when its
+// state changes.
+type jsonProgressUI struct {
+	unsubscribe func() error
+	output      io.Writer
+	lock        *sync.Mutex
+	waitGroup   *sync.WaitGroup
+	ctx         context.Context
+	cancel      context.CancelFunc
+}
+
+// NewJSONProgressUI writes the progress of each stage to the given writer as JSON lines and writes the final report
+// as usual.
+func NewJSONProgressUI(output io.Writer) UI {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &jsonProgressUI{
+		output:    output,
+		lock:      &sync.Mutex{},
+		waitGroup: &sync.WaitGroup{},
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+}
+
+func (u *jsonProgressUI) Setup(unsubscribe func() error) error {
+	u.unsubscribe = unsubscribe
+	return nil
+}
+
+func (u *jsonProgressUI) Handle(event partybus.Event) error {
+	var err error
+	switch event.Type {
+	case stereoscopeEvent.FetchImage:
+		var name string
+		var prog progress.StagedProgressable
+		if name, prog, err = stereoEventParsers.ParseFetchImage(event); err == nil {
+			u.track("image-fetch", name, prog, prog, nil)
+		}
+
+	case stereoscopeEvent.ReadImage:
+		var prog progress.Progressable
+		if _, prog, err = stereoEventParsers.ParseReadImage(event); err == nil {
+			u.track("image-read", "", prog, nil, nil)
+		}
+
+	case syftEvent.FileIndexingStarted:
+		var path string
+		var prog progress.StagedProgressable
+		if path, prog, err = syftEventParsers.ParseFileIndexingStarted(event); err == nil {
+			u.track("file-indexing", path, prog, prog, nil)
+		}
+
+	case syftEvent.PackageCatalogerStarted:
+		monitor, parseErr := syftEventParsers.ParsePackageCatalogerStarted(event)
+		if err = parseErr; err == nil {
+			u.track("package-cataloging", "", monitor.CatalogersProcessed, nil, func(e *progressEvent) {
+				packages, files := monitor.PackagesDiscovered.Current(), monitor.FilesProcessed.Current()
+				e.Packages, e.Files = &packages, &files
+				if monitor.Catalogers == nil {
+					return
+				}
+				for _, state := range monitor.Catalogers.States() {
+					switch state.Status {
+					case cataloger.CatalogerRunning:
+						e.Running = append(e.Running, state.Name)
+					case cataloger.CatalogerFailed:
+						e.Failed = append(e.Failed, state.Name)
+					}
+				}
+			})
+		}
+
+	case syftEvent.SecretsCatalogerStarted:
+		monitor, parseErr := syftEventParsers.ParseSecretsCatalogingStarted(event)
+		if err = parseErr; err == nil {
+			u.track("secrets-cataloging", "", monitor, monitor, nil)
+		}
+
+	case syftEvent.FileMetadataCatalogerStarted:
+		var prog progress.StagedProgressable
+		if prog, err = syftEventParsers.ParseFileMetadataCatalogingStarted(event); err == nil {
+			u.track("file-metadata-cataloging", "", prog, prog, nil)
+		}
+
+	case syftEvent.FileDigestsCatalogerStarted:
+		var prog progress.StagedProgressable
+		if prog, err = syftEventParsers.ParseFileDigestsCatalogingStarted(event); err == nil {
+			u.track("file-digests-cataloging", "", prog, prog, nil)
+		}
+
+	case syftEvent.ImportStarted:
+		var host string
+		var prog progress.StagedProgressable
+		if host, prog, err = syftEventParsers.ParseImportStarted(event); err == nil {
+			u.track("import", host, prog, prog, nil)
+		}
+
+	case syftEvent.UploadAttestation:
+		var prog progress.StagedProgressable
+		if prog, err = syftEventParsers.ParseUploadAttestation(event); err == nil {
+			u.track("attestation-upload", "", prog, prog, nil)
+		}
+
+	case syftEvent.Exit:
+		// report the final state of every stage before the report is written
+		u.waitGroup.Wait()
+
+		// this is the last expected event, stop listening to events (the error of the exit function, such as a policy
+		// violation, is returned to the event loop)
+		err := handleExit(event)
+		if unsubscribeErr := u.unsubscribe(); unsubscribeErr != nil {
+			log.Warnf("unable to unsubscribe from events: %+v", unsubscribeErr)
+		}
+		return err
+	}
+
+	if err != nil {
+		log.Errorf("unable to show %s event: %+v", event.Type, err)
+	}
+	return nil
+}
+
+// track polls the given progress (and stage, when there is one) until it is complete, writing an event each time the
+// state changes. The extra function may add stage-specific fields to each event.
+func (u *jsonProgressUI) track(stage, subject string, prog progress.Progressable, stager progress.Stager, extra func(e *progressEvent)) {
+	start := time.Now()
+	observe := func() progressEvent {
+		e := progressEvent{
+			Stage:   stage,
+			Subject: subject,
+			Current: prog.Current(),
+			Total:   prog.Size(),
+		}
+		if e.Total < 0 {
+			e.Total = 0
+		}
+		if stager != nil {
+			e.Detail = stager.Stage()
+		}
+		if e.Total > 0 {
+			ratio := float64(e.Current) / float64(e.Total)
+			percent := ratio * 100
+			e.Percent = &percent
+			if ratio > 0 && ratio < 1 {
+				eta := time.Since(start).Seconds() * (1 - ratio) / ratio
+				e.ETASeconds = &eta
+			}
+		}
+		if extra != nil {
+			extra(&e)
+		}
+		return e
+	}
+
+	first := observe()
+	first.Status = progressStarted
+	u.write(first)
+
+	u.waitGroup.Add(1)
+	go func() {
+		defer u.waitGroup.Done()
+
+		ticker := time.NewTicker(jsonProgressInterval)
+		defer ticker.Stop()
+
+		last := first
+		for {
+			select {
+			case <-u.ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			e := observe()
+			err := prog.Error()
+			switch {
+			case progress.IsErrCompleted(err):
+				e.Status = progressFinished
+				e.Percent, e.ETASeconds = nil, nil
+				if e.Total > 0 {
+					percent := float64(100)
+					e.Percent = &percent
+				}
+				u.write(e)
+				return
+			case err != nil:
+				e.Status = progressFailed
+				e.Error = err.Error()
+				u.write(e)
+				return
+			}
+
+			e.Status = progressRunning
+			if !sameProgress(last, e) {
+				u.write(e)
+				last = e
+			}
+		}
+	}()
+}
+
+// sameProgress indicates that nothing but the timing of the two events differ.
+func sameProgress(a, b progressEvent) bool {
+	return a.Current == b.Current && a.Total == b.Total && a.Detail == b.Detail &&
+		equalCounts(a.Packages, b.Packages) && equalCounts(a.Files, b.Files) &&
+		equalNames(a.Running, b.Running) && equalNames(a.Failed, b.Failed)
+}
+
+func equalNames(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalCounts(a, b *int64) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func (u *jsonProgressUI) write(e progressEvent) {
+	e.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	contents, err := json.Marshal(e)
+	if err != nil {
+		log.Errorf("unable to encode progress event: %+v", err)
+		return
+	}
+
+	u.lock.Lock()
+	defer u.lock.Unlock()
+	if _, err := fmt.Fprintln(u.output, string(contents)); err != nil {
+		log.Debugf("unable to write progress event: %+v", err)
+	}
+}
+
+func (u *jsonProgressUI) Teardown(force bool) error {
+	if force {
+		u.cancel()
+	}
+	u.waitGroup.Wait()
+	u.cancel()
+	return nil
+}
@@ -27,12 +27,13 @@ func (l loggerUI) Handle(event partybus.Event) error {
 		return nil
 	}
 
-	if err := handleExit(event); err != nil {
-		log.Warnf("unable to show catalog image finished event: %+v", err)
+	// this is the last expected event, stop listening to events (the error of the exit function, such as a policy
+	// violation, is returned to the event loop)
+	err := handleExit(event)
+	if unsubscribeErr := l.unsubscribe(); unsubscribeErr != nil {
+		log.Warnf("unable to unsubscribe from events: %+v", unsubscribeErr)
 	}
-
-	// this is the last expected event, stop listening to events
-	return l.unsubscribe()
+	return err
 }
 
 func (l loggerUI) Teardown(_ bool) error {
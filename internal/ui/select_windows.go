@@ -3,11 +3,18 @@
 
 package ui
 
+import "os"
+
 // Select is responsible for determining the specific UI function given select user option, the current platform
 // config values, and environment status (such as a TTY being present). The first UI in the returned slice of UIs
 // is intended to be used and the UIs that follow are meant to be attempted only in a fallback posture when there
 // are environmental problems (e.g. cannot write to the terminal). A writer is provided to capture the output of
-// the final SBOM report.
-func Select(verbose, quiet bool) (uis []UI) {
+// the final SBOM report. A JSON progress UI is used whenever asked for, since the progress is meant to be read by
+// another application rather than a user.
+func Select(verbose, quiet bool, progressFormat string) (uis []UI) {
+	if progressFormat == JSONProgress {
+		return append(uis, NewJSONProgressUI(os.Stderr))
+	}
+
 	return append(uis, NewLoggerUI())
 }
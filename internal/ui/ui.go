@@ -4,6 +4,13 @@ import (
 	"github.com/wagoodman/go-partybus"
 )
 
+const (
+	// AutoProgress shows progress in a terminal UI when possible (otherwise only logging)
+	AutoProgress = "auto"
+	// JSONProgress writes progress events to stderr as JSON lines (see NewJSONProgressUI)
+	JSONProgress = "json"
+)
+
 type UI interface {
 	Setup(unsubscribe func() error) error
 	partybus.Handler
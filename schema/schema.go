@@ -0,0 +1,26 @@
+// Package schema embeds the JSON schemas of the SBOM formats syft decodes, so that documents can be checked against
+// them at runtime.
+package schema
+
+import (
+	_ "embed"
+)
+
+// SyftJSONVersion is the version of the embedded syft JSON schema, which should always be the version written by the
+// syft JSON encoder (internal.JSONSchemaVersion).
+const SyftJSONVersion = "5.1.21"
+
+// SyftJSON is the JSON schema of the syft JSON format.
+//
+//go:embed json/schema-5.1.21.json
+var SyftJSON []byte
+
+// SPDXJSON is the JSON schema of the SPDX 2.2 JSON format.
+//
+//go:embed spdx-json/spdx-schema-2.2.json
+var SPDXJSON []byte
+
+// CycloneDXJSON is the JSON schema of the CycloneDX 1.4 JSON format.
+//
+//go:embed cyclonedx/cyclonedx.json
+var CycloneDXJSON []byte
@@ -0,0 +1,248 @@
+package artifact
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DependencyRelationshipTypes are the relationship types where the "from" artifact is a dependency of the "to" artifact.
+var DependencyRelationshipTypes = []RelationshipType{
+	DependencyOfRelationship,
+	RuntimeDependencyOfRelationship,
+	DevDependencyOfRelationship,
+	BuildDependencyOfRelationship,
+}
+
+// Graph indexes relationships by the artifacts on either side of the relationship, allowing for traversal in both
+// directions without each consumer re-indexing the relationships.
+type Graph struct {
+	nodes         map[ID]Identifiable
+	outgoing      map[ID][]Relationship
+	incoming      map[ID][]Relationship
+	edges         map[edgeKey]struct{}
+	relationships []Relationship
+}
+
+type edgeKey struct {
+	from ID
+	to   ID
+	typ  RelationshipType
+}
+
+// NewGraph returns a graph of the given relationships.
+func NewGraph(relationships ...Relationship) *Graph {
+	g := &Graph{
+		nodes:    make(map[ID]Identifiable),
+		outgoing: make(map[ID][]Relationship),
+		incoming: make(map[ID][]Relationship),
+		edges:    make(map[edgeKey]struct{}),
+	}
+	g.Add(relationships...)
+	return g
+}
+
+// Add indexes the given relationships, ignoring relationships of the same type between the same artifacts as a
+// relationship already within the graph.
+func (g *Graph) Add(relationships ...Relationship) {
+	for _, r := range relationships {
+		if r.From == nil || r.To == nil {
+			continue
+		}
+		fromID, toID := r.From.ID(), r.To.ID()
+		key := edgeKey{from: fromID, to: toID, typ: r.Type}
+		if _, exists := g.edges[key]; exists {
+			continue
+		}
+		g.edges[key] = struct{}{}
+
+		if _, exists := g.nodes[fromID]; !exists {
+			g.nodes[fromID] = r.From
+		}
+		if _, exists := g.nodes[toID]; !exists {
+			g.nodes[toID] = r.To
+		}
+		g.outgoing[fromID] = append(g.outgoing[fromID], r)
+		g.incoming[toID] = append(g.incoming[toID], r)
+		g.relationships = append(g.relationships, r)
+	}
+}
+
+// Relationships returns all relationships within the graph, in the order they were added.
+func (g *Graph) Relationships() []Relationship {
+	return g.relationships
+}
+
+// Node returns the artifact with the given ID, or nil if the artifact is not part of any relationship in the graph.
+func (g *Graph) Node(id ID) Identifiable {
+	return g.nodes[id]
+}
+
+// Nodes returns every artifact that is part of a relationship in the graph, ordered by ID.
+func (g *Graph) Nodes() []Identifiable {
+	nodes := make([]Identifiable, 0, len(g.nodes))
+	for _, n := range g.nodes {
+		nodes = append(nodes, n)
+	}
+	sortByID(nodes)
+	return nodes
+}
+
+// Has indicates if there is a relationship from one artifact to another (optionally constrained to the given types).
+func (g *Graph) Has(from, to ID, types ...RelationshipType) bool {
+	if len(types) == 0 {
+		for _, r := range g.outgoing[from] {
+			if r.To.ID() == to {
+				return true
+			}
+		}
+		return false
+	}
+	for _, t := range types {
+		if _, exists := g.edges[edgeKey{from: from, to: to, typ: t}]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// From returns the relationships where the given artifact is the "from" side (optionally constrained to the given types).
+func (g *Graph) From(id ID, types ...RelationshipType) []Relationship {
+	return filterByType(g.outgoing[id], types)
+}
+
+// To returns the relationships where the given artifact is the "to" side (optionally constrained to the given types).
+func (g *Graph) To(id ID, types ...RelationshipType) []Relationship {
+	return filterByType(g.incoming[id], types)
+}
+
+// Dependencies returns the direct dependencies of the given artifact, ordered by ID.
+func (g *Graph) Dependencies(id ID) []Identifiable {
+	return g.walk(id, false, false, DependencyRelationshipTypes)
+}
+
+// TransitiveDependencies returns the direct and indirect dependencies of the given artifact, ordered by ID.
+func (g *Graph) TransitiveDependencies(id ID) []Identifiable {
+	return g.walk(id, false, true, DependencyRelationshipTypes)
+}
+
+// Dependents returns the artifacts that directly depend on the given artifact, ordered by ID.
+func (g *Graph) Dependents(id ID) []Identifiable {
+	return g.walk(id, true, false, DependencyRelationshipTypes)
+}
+
+// TransitiveDependents returns the artifacts that directly or indirectly depend on the given artifact, ordered by ID.
+func (g *Graph) TransitiveDependents(id ID) []Identifiable {
+	return g.walk(id, true, true, DependencyRelationshipTypes)
+}
+
+// Owners returns the artifacts that contain the given artifact (e.g. the packages that own a file), ordered by ID.
+func (g *Graph) Owners(id ID) []Identifiable {
+	var owners []Identifiable
+	for _, r := range g.To(id, ContainsRelationship) {
+		owners = append(owners, r.From)
+	}
+	sortByID(owners)
+	return owners
+}
+
+// TopologicalSort orders the artifacts related by the given relationship types (all types by default) such that the
+// "from" side of every relationship is ordered before the "to" side (e.g. dependencies before their dependents). Ties
+// are ordered by ID. An error is returned if the relationships form a cycle.
+func (g *Graph) TopologicalSort(types ...RelationshipType) ([]Identifiable, error) {
+	inDegree := make(map[ID]int)
+	for _, r := range filterByType(g.relationships, types) {
+		if _, exists := inDegree[r.From.ID()]; !exists {
+			inDegree[r.From.ID()] = 0
+		}
+		inDegree[r.To.ID()]++
+	}
+
+	var ready []Identifiable
+	for id, degree := range inDegree {
+		if degree == 0 {
+			ready = append(ready, g.nodes[id])
+		}
+	}
+	sortByID(ready)
+
+	result := make([]Identifiable, 0, len(inDegree))
+	for len(ready) > 0 {
+		n := ready[0]
+		ready = ready[1:]
+		result = append(result, n)
+
+		var next []Identifiable
+		for _, r := range g.From(n.ID(), types...) {
+			inDegree[r.To.ID()]--
+			if inDegree[r.To.ID()] == 0 {
+				next = append(next, r.To)
+			}
+		}
+		ready = append(ready, next...)
+		sortByID(ready)
+	}
+
+	if len(result) != len(inDegree) {
+		return nil, fmt.Errorf("unable to order %d artifacts: relationships form a cycle", len(inDegree)-len(result))
+	}
+	return result, nil
+}
+
+// walk returns the artifacts related to the given artifact by the given relationship types, following relationships
+// towards the "from" side (or towards the "to" side when reversed), optionally following the relationships of the
+// related artifacts too.
+func (g *Graph) walk(id ID, reverse, transitive bool, types []RelationshipType) []Identifiable {
+	visited := map[ID]struct{}{id: {}}
+	var results []Identifiable
+	queue := []ID{id}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		var related []Identifiable
+		if reverse {
+			for _, r := range g.From(current, types...) {
+				related = append(related, r.To)
+			}
+		} else {
+			for _, r := range g.To(current, types...) {
+				related = append(related, r.From)
+			}
+		}
+
+		for _, n := range related {
+			if _, exists := visited[n.ID()]; exists {
+				continue
+			}
+			visited[n.ID()] = struct{}{}
+			results = append(results, n)
+			if transitive {
+				queue = append(queue, n.ID())
+			}
+		}
+	}
+	sortByID(results)
+	return results
+}
+
+func filterByType(relationships []Relationship, types []RelationshipType) []Relationship {
+	if len(types) == 0 {
+		return relationships
+	}
+	var results []Relationship
+	for _, r := range relationships {
+		for _, t := range types {
+			if r.Type == t {
+				results = append(results, r)
+				break
+			}
+		}
+	}
+	return results
+}
+
+func sortByID(nodes []Identifiable) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].ID() < nodes[j].ID()
+	})
+}
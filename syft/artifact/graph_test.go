@@ -0,0 +1,114 @@
+package artifact
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type node string
+
+func (n node) ID() ID {
+	return ID(n)
+}
+
+func ids(nodes []Identifiable) []ID {
+	var results []ID
+	for _, n := range nodes {
+		results = append(results, n.ID())
+	}
+	return results
+}
+
+func dependencyGraph() *Graph {
+	// app -> lib-a -> lib-c
+	//     -> lib-b -> lib-c
+	//              -> lib-d (build only)
+	return NewGraph(
+		Relationship{From: node("lib-a"), To: node("app"), Type: DependencyOfRelationship},
+		Relationship{From: node("lib-b"), To: node("app"), Type: RuntimeDependencyOfRelationship},
+		Relationship{From: node("lib-c"), To: node("lib-a"), Type: DependencyOfRelationship},
+		Relationship{From: node("lib-c"), To: node("lib-b"), Type: DependencyOfRelationship},
+		Relationship{From: node("lib-d"), To: node("lib-b"), Type: BuildDependencyOfRelationship},
+		Relationship{From: node("app"), To: node("/usr/bin/app"), Type: ContainsRelationship},
+		Relationship{From: node("lib-a"), To: node("/usr/lib/liba.so"), Type: ContainsRelationship},
+		Relationship{From: node("lib-a-dev"), To: node("/usr/lib/liba.so"), Type: ContainsRelationship},
+	)
+}
+
+func TestGraph_Add(t *testing.T) {
+	r := Relationship{From: node("a"), To: node("b"), Type: DependencyOfRelationship}
+	g := NewGraph(r, r, Relationship{From: node("a"), To: node("b"), Type: ContainsRelationship}, Relationship{From: node("a")})
+
+	assert.Len(t, g.Relationships(), 2)
+	assert.Equal(t, []ID{"a", "b"}, ids(g.Nodes()))
+	assert.Equal(t, node("a"), g.Node("a"))
+	assert.Nil(t, g.Node("c"))
+}
+
+func TestGraph_Has(t *testing.T) {
+	g := dependencyGraph()
+
+	assert.True(t, g.Has("lib-a", "app"))
+	assert.True(t, g.Has("lib-a", "app", DependencyOfRelationship))
+	assert.False(t, g.Has("lib-a", "app", ContainsRelationship))
+	assert.False(t, g.Has("app", "lib-a"))
+}
+
+func TestGraph_FromAndTo(t *testing.T) {
+	g := dependencyGraph()
+
+	assert.Len(t, g.From("lib-c"), 2)
+	assert.Len(t, g.To("app"), 2)
+	assert.Len(t, g.To("app", RuntimeDependencyOfRelationship), 1)
+	assert.Empty(t, g.From("missing"))
+}
+
+func TestGraph_Dependencies(t *testing.T) {
+	g := dependencyGraph()
+
+	assert.Equal(t, []ID{"lib-a", "lib-b"}, ids(g.Dependencies("app")))
+	assert.Equal(t, []ID{"lib-a", "lib-b", "lib-c", "lib-d"}, ids(g.TransitiveDependencies("app")))
+	assert.Empty(t, g.TransitiveDependencies("lib-c"))
+}
+
+func TestGraph_Dependents(t *testing.T) {
+	g := dependencyGraph()
+
+	assert.Equal(t, []ID{"lib-a", "lib-b"}, ids(g.Dependents("lib-c")))
+	assert.Equal(t, []ID{"app", "lib-a", "lib-b"}, ids(g.TransitiveDependents("lib-c")))
+	assert.Empty(t, g.Dependents("app"))
+}
+
+func TestGraph_Owners(t *testing.T) {
+	g := dependencyGraph()
+
+	assert.Equal(t, []ID{"lib-a", "lib-a-dev"}, ids(g.Owners("/usr/lib/liba.so")))
+	assert.Equal(t, []ID{"app"}, ids(g.Owners("/usr/bin/app")))
+	assert.Empty(t, g.Owners("/usr/bin/missing"))
+}
+
+func TestGraph_TopologicalSort(t *testing.T) {
+	g := dependencyGraph()
+
+	actual, err := g.TopologicalSort(DependencyRelationshipTypes...)
+	require.NoError(t, err)
+	assert.Equal(t, []ID{"lib-c", "lib-a", "lib-d", "lib-b", "app"}, ids(actual))
+
+	actual, err = g.TopologicalSort(ContainsRelationship)
+	require.NoError(t, err)
+	assert.Equal(t, []ID{"app", "/usr/bin/app", "lib-a", "lib-a-dev", "/usr/lib/liba.so"}, ids(actual))
+}
+
+func TestGraph_TopologicalSort_cycle(t *testing.T) {
+	g := NewGraph(
+		Relationship{From: node("a"), To: node("b"), Type: DependencyOfRelationship},
+		Relationship{From: node("b"), To: node("c"), Type: DependencyOfRelationship},
+		Relationship{From: node("c"), To: node("a"), Type: DependencyOfRelationship},
+		Relationship{From: node("d"), To: node("a"), Type: DependencyOfRelationship},
+	)
+
+	_, err := g.TopologicalSort()
+	assert.Error(t, err)
+}
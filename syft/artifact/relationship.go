@@ -21,6 +21,14 @@ const (
 
 	// DependencyOfRelationship is a proxy for the SPDX 2.2.1 DEPENDENCY_OF	relationship.
 	DependencyOfRelationship RelationshipType = "dependency-of"
+
+	// IntroducesRelationship (supports layer-to-package linkages) indicates that the parent container image layer
+	// introduced the child package (that is, the instruction that created the layer installed or upgraded the package).
+	IntroducesRelationship RelationshipType = "introduces"
+
+	// DescribedByRelationship (supports package-to-file linkages) indicates that the parent package was found within an
+	// SBOM document (the child file) within the source, rather than by the files that make up the package.
+	DescribedByRelationship RelationshipType = "described-by"
 )
 
 type RelationshipType string
@@ -30,4 +38,7 @@ type Relationship struct {
 	To   Identifiable
 	Type RelationshipType
 	Data interface{}
+	// Annotations are free-form notes about the relationship (e.g. the evidence for it attached by an enrichment
+	// pipeline). Unlike Data, annotations are kept by the formats that can only describe relationships with text.
+	Annotations map[string]string
 }
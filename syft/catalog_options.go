@@ -0,0 +1,93 @@
+package syft
+
+import (
+	"crypto"
+	"time"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/source"
+)
+
+// CatalogOption customizes the configuration of a single CreateSBOM (or CatalogPackages) call, so that callers only
+// need to describe what differs from the given configuration (see NewCatalogConfig). Options are applied in order, so
+// later options take precedence over earlier ones.
+type CatalogOption func(*cataloger.Config)
+
+// NewCatalogConfig returns the default cataloging configuration (see cataloger.DefaultConfig) with the given options
+// applied.
+func NewCatalogConfig(opts ...CatalogOption) cataloger.Config {
+	return applyCatalogOptions(cataloger.DefaultConfig(), opts)
+}
+
+// WithScope sets the parts of an image to search for packages (e.g. source.AllLayersScope). Sources other than images
+// only have a single layer, so the scope makes no difference to them.
+func WithScope(scope source.Scope) CatalogOption {
+	return func(cfg *cataloger.Config) {
+		cfg.Search.Scope = scope
+	}
+}
+
+// WithParallelism sets the maximum number of catalogers to run concurrently (1 or less runs each cataloger in turn).
+func WithParallelism(parallelism int) CatalogOption {
+	return func(cfg *cataloger.Config) {
+		cfg.Parallelism = parallelism
+	}
+}
+
+// WithCatalogers runs the given catalogers alongside the built-in catalogers (see cataloger.Register to add catalogers
+// to every call instead).
+func WithCatalogers(catalogers ...pkg.Cataloger) CatalogOption {
+	return func(cfg *cataloger.Config) {
+		cfg.Additional = append(cfg.Additional, catalogers...)
+	}
+}
+
+// WithSelection selects the catalogers to run with the given selection expressions (see cataloger.SelectCatalogers).
+func WithSelection(expressions ...string) CatalogOption {
+	return func(cfg *cataloger.Config) {
+		cfg.Select = append(cfg.Select, expressions...)
+	}
+}
+
+// WithFileDigests calculates the digests of package archives (e.g. java archives) with the given hash algorithms, in
+// addition to any already configured.
+func WithFileDigests(hashes ...crypto.Hash) CatalogOption {
+	return func(cfg *cataloger.Config) {
+		cfg.ArchiveDigests = append(cfg.ArchiveDigests, hashes...)
+	}
+}
+
+// WithTimeout sets how long cataloging may take before returning the packages found so far (unlimited when zero). Use
+// a context with a deadline instead to stop cataloging without any results.
+func WithTimeout(timeout time.Duration) CatalogOption {
+	return func(cfg *cataloger.Config) {
+		cfg.Timeout = timeout
+	}
+}
+
+// WithPackageFilter only keeps the cataloged packages selected by the given filter (see pkg.NewFilter).
+func WithPackageFilter(filter *pkg.Filter) CatalogOption {
+	return func(cfg *cataloger.Config) {
+		cfg.Filter = filter
+	}
+}
+
+// WithIDScheme derives the package IDs with the given scheme, so that IDs remain comparable with SBOMs produced by
+// other syft releases using the same scheme (see pkg.IDScheme).
+func WithIDScheme(scheme pkg.IDScheme) CatalogOption {
+	return func(cfg *cataloger.Config) {
+		cfg.IDScheme = scheme
+	}
+}
+
+func applyCatalogOptions(cfg cataloger.Config, opts []CatalogOption) cataloger.Config {
+	// note: the slices are copied so that options do not modify the configuration of the caller
+	cfg.Additional = append([]pkg.Cataloger(nil), cfg.Additional...)
+	cfg.Select = append([]string(nil), cfg.Select...)
+	cfg.ArchiveDigests = append([]crypto.Hash(nil), cfg.ArchiveDigests...)
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
@@ -0,0 +1,44 @@
+package syft
+
+import (
+	"crypto"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestNewCatalogConfig(t *testing.T) {
+	cfg := NewCatalogConfig(
+		WithScope(source.AllLayersScope),
+		WithParallelism(4),
+		WithSelection("+sbom-cataloger"),
+		WithFileDigests(crypto.SHA256),
+		WithTimeout(time.Minute),
+		WithParallelism(8),
+	)
+
+	expected := cataloger.DefaultConfig()
+	expected.Search.Scope = source.AllLayersScope
+	expected.Parallelism = 8
+	expected.Select = []string{"+sbom-cataloger"}
+	expected.ArchiveDigests = []crypto.Hash{crypto.SHA256}
+	expected.Timeout = time.Minute
+	assert.Equal(t, expected, cfg)
+}
+
+func TestApplyCatalogOptions_doesNotModifyConfig(t *testing.T) {
+	cfg := cataloger.DefaultConfig()
+	cfg.Select = make([]string, 1, 10)
+	cfg.ArchiveDigests = make([]crypto.Hash, 1, 10)
+
+	applied := applyCatalogOptions(cfg, []CatalogOption{WithSelection("java"), WithFileDigests(crypto.SHA512)})
+
+	assert.Equal(t, []string{"", "java"}, applied.Select)
+	assert.Equal(t, []crypto.Hash{0, crypto.SHA512}, applied.ArchiveDigests)
+	assert.Equal(t, "", cfg.Select[:2][1], "the slices of the given config should not be written to")
+	assert.Equal(t, crypto.Hash(0), cfg.ArchiveDigests[:2][1])
+}
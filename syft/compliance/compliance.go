@@ -0,0 +1,141 @@
+/*
+Package compliance checks SBOMs against the minimum elements required by SBOM profiles (the NTIA minimum elements and
+BSI TR-03183), reporting each element that is missing from the document or from a package.
+*/
+package compliance
+
+import (
+	"fmt"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// DocumentElement is the element of gaps found within the document (instead of within a package).
+const DocumentElement = "document"
+
+// Gap is a required field that is missing from an element of an SBOM.
+type Gap struct {
+	// Element is the package missing the field (as "name@version"), or DocumentElement
+	Element string `json:"element"`
+	// PackageID is the ID of the package missing the field (empty for the document)
+	PackageID artifact.ID `json:"packageId,omitempty"`
+	// Field is the name of the missing field, as named by the profile (e.g. "supplier")
+	Field string `json:"field"`
+	// Message describes what is missing
+	Message string `json:"message"`
+}
+
+// Report is the result of checking an SBOM against a profile.
+type Report struct {
+	// Profile is the name of the profile checked against
+	Profile string `json:"profile"`
+	// Packages is the number of packages checked
+	Packages int `json:"packages"`
+	// Gaps are the required fields missing from the SBOM (the gaps of the document, followed by the gaps of each
+	// package in order)
+	Gaps []Gap `json:"gaps"`
+}
+
+// Compliant indicates that the SBOM has all fields required by the profile.
+func (r Report) Compliant() bool {
+	return len(r.Gaps) == 0
+}
+
+// GapsByField returns the number of gaps for each required field.
+func (r Report) GapsByField() map[string]int {
+	counts := make(map[string]int)
+	for _, g := range r.Gaps {
+		counts[g.Field]++
+	}
+	return counts
+}
+
+// Check checks the given SBOM against the given profile.
+func Check(s sbom.SBOM, profile Profile) Report {
+	c := newChecker(s)
+	report := Report{
+		Profile: profile.Name,
+		Gaps:    []Gap{},
+	}
+
+	for _, check := range profile.document {
+		if !check.present(c) {
+			report.Gaps = append(report.Gaps, Gap{
+				Element: DocumentElement,
+				Field:   check.field,
+				Message: check.message,
+			})
+		}
+	}
+
+	if s.Artifacts.PackageCatalog != nil {
+		for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+			report.Packages++
+			for _, check := range profile.packages {
+				if !check.present(c, p) {
+					report.Gaps = append(report.Gaps, Gap{
+						Element:   fmt.Sprintf("%s@%s", p.Name, p.Version),
+						PackageID: p.ID(),
+						Field:     check.field,
+						Message:   check.message,
+					})
+				}
+			}
+		}
+	}
+	return report
+}
+
+// checker holds what is derived from an SBOM once for all checks.
+type checker struct {
+	sbom sbom.SBOM
+	// related are the packages that are related to another package or to the source
+	related map[artifact.ID]bool
+}
+
+func newChecker(s sbom.SBOM) *checker {
+	related := make(map[artifact.ID]bool)
+	for _, r := range s.Relationships {
+		if r.From == nil || r.To == nil {
+			continue
+		}
+		// note: a package that only contains files is not described in relation to any other component
+		if isFile(r.From) || isFile(r.To) {
+			continue
+		}
+		related[r.From.ID()] = true
+		related[r.To.ID()] = true
+	}
+	return &checker{
+		sbom:    s,
+		related: related,
+	}
+}
+
+func isFile(i artifact.Identifiable) bool {
+	switch i.(type) {
+	case source.Coordinates, source.Location:
+		return true
+	}
+	return false
+}
+
+// digestAlgorithms returns the algorithms of the digests of the files the package was found from, along with the
+// digests of the package archive.
+func (c *checker) digestAlgorithms(p pkg.Package) []string {
+	var algorithms []string
+	for _, l := range p.Locations.ToSlice() {
+		for _, d := range c.sbom.Artifacts.FileDigests[l.Coordinates] {
+			algorithms = append(algorithms, d.Algorithm)
+		}
+	}
+	if metadata, ok := p.Metadata.(pkg.JavaMetadata); ok {
+		for _, d := range metadata.ArchiveDigests {
+			algorithms = append(algorithms, d.Algorithm)
+		}
+	}
+	return algorithms
+}
@@ -0,0 +1,226 @@
+package compliance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func compliantPackages() (pkg.Package, pkg.Package) {
+	app := pkg.Package{
+		Name:      "app",
+		Version:   "1.0.0",
+		Type:      pkg.NpmPkg,
+		PURL:      "pkg:npm/app@1.0.0",
+		Locations: source.NewLocationSet(source.NewLocation("/app/package.json")),
+		Licenses:  []pkg.License{{Value: "MIT", SPDXExpression: "MIT", Type: pkg.DeclaredLicense}},
+		Metadata:  pkg.NpmPackageJSONMetadata{Author: "someone"},
+	}
+	app.SetID()
+	lib := pkg.Package{
+		Name:      "lib",
+		Version:   "2.0.0",
+		Type:      pkg.NpmPkg,
+		PURL:      "pkg:npm/lib@2.0.0",
+		Locations: source.NewLocationSet(source.NewLocation("/app/node_modules/lib/package.json")),
+		Licenses:  []pkg.License{{Value: "MIT", SPDXExpression: "MIT", Type: pkg.DeclaredLicense}},
+		Registry:  &pkg.RegistryInfo{Registry: "https://registry.npmjs.org", Supplier: "someone else"},
+	}
+	lib.SetID()
+	return app, lib
+}
+
+func newSBOM(packages ...pkg.Package) sbom.SBOM {
+	var relationships []artifact.Relationship
+	for i := 1; i < len(packages); i++ {
+		relationships = append(relationships, artifact.Relationship{
+			From: packages[i],
+			To:   packages[0],
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+	digests := make(map[source.Coordinates][]file.Digest)
+	for _, p := range packages {
+		for _, l := range p.Locations.ToSlice() {
+			digests[l.Coordinates] = []file.Digest{{Algorithm: "sha512", Value: "abc"}}
+		}
+	}
+	return sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(packages...),
+			FileDigests:    digests,
+		},
+		Relationships: relationships,
+		Descriptor:    sbom.Descriptor{Name: "syft", Version: "v0.0.0"},
+	}
+}
+
+func TestCheck_Compliant(t *testing.T) {
+	app, lib := compliantPackages()
+	s := newSBOM(app, lib)
+
+	for _, name := range ProfileNames() {
+		t.Run(name, func(t *testing.T) {
+			profile, err := ProfileByName(name)
+			require.NoError(t, err)
+
+			report := Check(s, profile)
+			assert.True(t, report.Compliant(), "unexpected gaps: %+v", report.Gaps)
+			assert.Equal(t, name, report.Profile)
+			assert.Equal(t, 2, report.Packages)
+		})
+	}
+}
+
+func TestCheck_Gaps(t *testing.T) {
+	app, lib := compliantPackages()
+	lib.Registry = nil
+	lib.PURL = ""
+	lib.Licenses = nil
+	lib.SetID()
+	s := newSBOM(app, lib)
+	s.Descriptor = sbom.Descriptor{}
+	// without any relationships neither package is related to another
+	s.Relationships = nil
+	// sha256 does not satisfy the hash required by BSI TR-03183
+	s.Artifacts.FileDigests[app.Locations.ToSlice()[0].Coordinates] = []file.Digest{{Algorithm: "sha256", Value: "abc"}}
+
+	tests := []struct {
+		profile  string
+		expected []Gap
+	}{
+		{
+			profile: NTIA,
+			expected: []Gap{
+				{Element: DocumentElement, Field: "author", Message: authorCheck.message},
+				{Element: "app@1.0.0", PackageID: app.ID(), Field: "dependency-relationship", Message: relationshipCheck.message},
+				{Element: "lib@2.0.0", PackageID: lib.ID(), Field: "supplier", Message: supplierCheck.message},
+				{Element: "lib@2.0.0", PackageID: lib.ID(), Field: "unique-identifier", Message: identifierCheck.message},
+				{Element: "lib@2.0.0", PackageID: lib.ID(), Field: "dependency-relationship", Message: relationshipCheck.message},
+			},
+		},
+		{
+			profile: "BSI",
+			expected: []Gap{
+				{Element: DocumentElement, Field: "author", Message: authorCheck.message},
+				{Element: "app@1.0.0", PackageID: app.ID(), Field: "dependency-relationship", Message: relationshipCheck.message},
+				{Element: "app@1.0.0", PackageID: app.ID(), Field: "hash", Message: hashCheck.message},
+				{Element: "lib@2.0.0", PackageID: lib.ID(), Field: "supplier", Message: supplierCheck.message},
+				{Element: "lib@2.0.0", PackageID: lib.ID(), Field: "unique-identifier", Message: identifierCheck.message},
+				{Element: "lib@2.0.0", PackageID: lib.ID(), Field: "dependency-relationship", Message: relationshipCheck.message},
+				{Element: "lib@2.0.0", PackageID: lib.ID(), Field: "license", Message: licenseCheck.message},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.profile, func(t *testing.T) {
+			profile, err := ProfileByName(test.profile)
+			require.NoError(t, err)
+
+			report := Check(s, profile)
+			assert.False(t, report.Compliant())
+			assert.Equal(t, test.expected, report.Gaps)
+		})
+	}
+}
+
+func TestCheck_FileRelationshipsAreNotDependencies(t *testing.T) {
+	app, _ := compliantPackages()
+	s := newSBOM(app)
+	s.Relationships = []artifact.Relationship{
+		{
+			From: app,
+			To:   source.NewLocation("/app/index.js").Coordinates,
+			Type: artifact.ContainsRelationship,
+		},
+	}
+
+	profile, err := ProfileByName(NTIA)
+	require.NoError(t, err)
+	report := Check(s, profile)
+	assert.Equal(t, map[string]int{"dependency-relationship": 1}, report.GapsByField())
+}
+
+func TestProfileByName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{name: "ntia", expected: NTIA},
+		{name: " NTIA ", expected: NTIA},
+		{name: "bsi", expected: BSI},
+		{name: "bsi-tr-03183", expected: BSI},
+		{name: "tr-03183", expected: BSI},
+		{name: "fda", wantErr: require.Error},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			profile, err := ProfileByName(test.name)
+			test.wantErr(t, err)
+			assert.Equal(t, test.expected, profile.Name)
+		})
+	}
+}
+
+func TestSupplier(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected string
+	}{
+		{
+			name:     "unknown",
+			input:    pkg.Package{},
+			expected: "",
+		},
+		{
+			name: "registry preferred over metadata",
+			input: pkg.Package{
+				Metadata: pkg.PythonPackageMetadata{Author: "author"},
+				Registry: &pkg.RegistryInfo{Registry: "https://pypi.org", Supplier: "publisher"},
+			},
+			expected: "publisher",
+		},
+		{
+			name:     "python author email",
+			input:    pkg.Package{Metadata: pkg.PythonPackageMetadata{AuthorEmail: "author@example.com"}},
+			expected: "author@example.com",
+		},
+		{
+			name:     "rpm vendor",
+			input:    pkg.Package{Metadata: pkg.RpmMetadata{Vendor: "Red Hat, Inc."}},
+			expected: "Red Hat, Inc.",
+		},
+		{
+			name:     "deb maintainer",
+			input:    pkg.Package{Metadata: pkg.DpkgMetadata{Maintainer: "Debian Maintainers <debian@example.com>"}},
+			expected: "Debian Maintainers <debian@example.com>",
+		},
+		{
+			name:     "gem authors",
+			input:    pkg.Package{Metadata: pkg.GemMetadata{Authors: []string{"first", "second"}}},
+			expected: "first",
+		},
+		{
+			name:     "blank author",
+			input:    pkg.Package{Metadata: pkg.NpmPackageJSONMetadata{Author: "  "}},
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Supplier(test.input))
+		})
+	}
+}
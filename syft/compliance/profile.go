@@ -0,0 +1,160 @@
+package compliance
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+const (
+	// NTIA is the profile of the minimum elements for an SBOM published by the NTIA
+	// (see https://www.ntia.doc.gov/report/2021/minimum-elements-software-bill-materials-sbom)
+	NTIA = "ntia"
+	// BSI is the profile of the SBOM requirements of the BSI technical guideline TR-03183 (part 2), which requires the
+	// NTIA minimum elements along with the licenses, file names, and SHA-512 hashes of components
+	BSI = "bsi-tr-03183"
+)
+
+// Profile is a set of fields that an SBOM (and each package within the SBOM) is required to have.
+type Profile struct {
+	// Name identifies the profile (e.g. "ntia")
+	Name string
+	// Description describes where the requirements of the profile come from
+	Description string
+
+	document []documentCheck
+	packages []packageCheck
+}
+
+// documentCheck checks for a field required of the SBOM document.
+type documentCheck struct {
+	field   string
+	message string
+	present func(c *checker) bool
+}
+
+// packageCheck checks for a field required of each package within the SBOM.
+type packageCheck struct {
+	field   string
+	message string
+	present func(c *checker, p pkg.Package) bool
+}
+
+var (
+	authorCheck = documentCheck{
+		field:   "author",
+		message: "the author of the SBOM data (the tool that generated the SBOM) is not known",
+		present: func(c *checker) bool {
+			return c.sbom.Descriptor.Name != ""
+		},
+	}
+	supplierCheck = packageCheck{
+		field:   "supplier",
+		message: "the supplier of the package is not known (consider enabling package.enrichment for npm, python, and rust packages)",
+		present: func(_ *checker, p pkg.Package) bool {
+			return Supplier(p) != ""
+		},
+	}
+	nameCheck = packageCheck{
+		field:   "name",
+		message: "the package has no name",
+		present: func(_ *checker, p pkg.Package) bool {
+			return strings.TrimSpace(p.Name) != ""
+		},
+	}
+	versionCheck = packageCheck{
+		field:   "version",
+		message: "the package has no version",
+		present: func(_ *checker, p pkg.Package) bool {
+			return strings.TrimSpace(p.Version) != ""
+		},
+	}
+	identifierCheck = packageCheck{
+		field:   "unique-identifier",
+		message: "the package has neither a package URL nor a CPE",
+		present: func(_ *checker, p pkg.Package) bool {
+			return p.PURL != "" || len(p.CPEs) > 0
+		},
+	}
+	relationshipCheck = packageCheck{
+		field:   "dependency-relationship",
+		message: "the package is not related to the source or to any other package",
+		present: func(c *checker, p pkg.Package) bool {
+			return c.related[p.ID()]
+		},
+	}
+	licenseCheck = packageCheck{
+		field:   "license",
+		message: "the license of the package is not known",
+		present: func(_ *checker, p pkg.Package) bool {
+			return len(p.Licenses) > 0
+		},
+	}
+	filenameCheck = packageCheck{
+		field:   "filename",
+		message: "the file the package was found from is not known",
+		present: func(_ *checker, p pkg.Package) bool {
+			return len(p.Locations.ToSlice()) > 0
+		},
+	}
+	hashCheck = packageCheck{
+		field:   "hash",
+		message: "the package has no SHA-512 digest (consider adding sha512 to file-metadata.digests)",
+		present: func(c *checker, p pkg.Package) bool {
+			for _, algorithm := range c.digestAlgorithms(p) {
+				if file.CleanDigestAlgorithmName(algorithm) == "sha512" {
+					return true
+				}
+			}
+			return false
+		},
+	}
+)
+
+var profiles = map[string]Profile{
+	NTIA: {
+		Name:        NTIA,
+		Description: "NTIA minimum elements for a software bill of materials",
+		document:    []documentCheck{authorCheck},
+		packages:    []packageCheck{supplierCheck, nameCheck, versionCheck, identifierCheck, relationshipCheck},
+	},
+	BSI: {
+		Name:        BSI,
+		Description: "BSI TR-03183-2 software bill of materials requirements",
+		document:    []documentCheck{authorCheck},
+		packages:    []packageCheck{supplierCheck, nameCheck, versionCheck, identifierCheck, relationshipCheck, licenseCheck, filenameCheck, hashCheck},
+	},
+}
+
+// profileAliases are the other names a profile may be selected by.
+var profileAliases = map[string]string{
+	"bsi":          BSI,
+	"tr-03183":     BSI,
+	"ntia-minimum": NTIA,
+}
+
+// ProfileByName returns the profile with the given name (case-insensitively, including aliases such as "bsi").
+func ProfileByName(name string) (Profile, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if alias, ok := profileAliases[name]; ok {
+		name = alias
+	}
+	p, ok := profiles[name]
+	if !ok {
+		return Profile{}, fmt.Errorf("unknown compliance profile %q (available profiles: %s)", name, strings.Join(ProfileNames(), ", "))
+	}
+	return p, nil
+}
+
+// ProfileNames returns the names of all profiles.
+func ProfileNames() []string {
+	var names []string
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,48 @@
+package compliance
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// Supplier returns who supplied the package: the publisher looked up from the registry of the package ecosystem, or
+// otherwise the author, maintainer, or vendor named by the package metadata. Returns an empty string when the supplier
+// is not known.
+func Supplier(p pkg.Package) string {
+	if p.Registry != nil && strings.TrimSpace(p.Registry.Supplier) != "" {
+		return strings.TrimSpace(p.Registry.Supplier)
+	}
+
+	var supplier string
+	switch metadata := p.Metadata.(type) {
+	case pkg.AlpmMetadata:
+		supplier = metadata.Packager
+	case pkg.ApkMetadata:
+		supplier = metadata.Maintainer
+	case pkg.DpkgMetadata:
+		supplier = metadata.Maintainer
+	case pkg.RpmMetadata:
+		supplier = metadata.Vendor
+	case pkg.NpmPackageJSONMetadata:
+		supplier = metadata.Author
+	case pkg.PythonPackageMetadata:
+		supplier = metadata.Author
+		if strings.TrimSpace(supplier) == "" {
+			supplier = metadata.AuthorEmail
+		}
+	case pkg.GemMetadata:
+		if len(metadata.Authors) > 0 {
+			supplier = metadata.Authors[0]
+		}
+	case pkg.PhpComposerJSONMetadata:
+		if len(metadata.Authors) > 0 {
+			supplier = metadata.Authors[0].Name
+		}
+	case pkg.HelmChartMetadata:
+		if len(metadata.Maintainers) > 0 {
+			supplier = metadata.Maintainers[0].Name
+		}
+	}
+	return strings.TrimSpace(supplier)
+}
@@ -0,0 +1,77 @@
+package syft
+
+import (
+	"context"
+	"errors"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// CreateSBOM catalogs the packages of the given source with the given configuration (see NewCatalogConfig) and options,
+// returning an SBOM that describes the packages, the Linux distribution, and the source itself, ready to be encoded
+// with Encode. Images cataloged with the per-layer scope also include the history of package changes made by each
+// layer. Cataloging stops when the given context is canceled, as with CatalogPackagesWithContext.
+//
+// When some catalogers fail, the failures are recorded as diagnostics of the SBOM, which is returned along with a
+// *cataloger.PartialResultsError (see cataloger.IsPartialResults) since the SBOM is usable, though incomplete.
+func CreateSBOM(ctx context.Context, src *source.Source, cfg cataloger.Config, opts ...CatalogOption) (*sbom.SBOM, error) {
+	cfg = applyCatalogOptions(cfg, opts)
+
+	var catalog *pkg.Catalog
+	var relationships []artifact.Relationship
+	var release *linux.Release
+	var history []pkg.LayerHistory
+	var err error
+	if cfg.Search.Scope == source.PerLayerScope {
+		catalog, relationships, release, history, err = CatalogPackagesPerLayerWithContext(ctx, src, cfg)
+	} else {
+		catalog, relationships, release, err = CatalogPackagesWithContext(ctx, src, cfg)
+	}
+	var partial *cataloger.PartialResultsError
+	if err != nil && !errors.As(err, &partial) {
+		return nil, err
+	}
+
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog:    catalog,
+			LinuxDistribution: release,
+			LayerHistory:      history,
+		},
+		Relationships: relationships,
+		Source:        src.Metadata,
+		Descriptor: sbom.Descriptor{
+			Name:          internal.ApplicationName,
+			Version:       version.FromBuild().Version,
+			Configuration: cfg,
+		},
+	}
+
+	if partial != nil {
+		for _, f := range partial.Failures {
+			s.Artifacts.Diagnostics = append(s.Artifacts.Diagnostics, sbom.Diagnostic{
+				Cataloger: f.Cataloger,
+				Message:   f.Err.Error(),
+			})
+		}
+	}
+
+	// images without any operating system packages are described, rather than only producing a (nearly) empty SBOM
+	report, describeErr := DescribeMinimalImage(src, catalog, release)
+	if describeErr != nil {
+		log.Warnf("unable to describe image without operating system packages: %+v", describeErr)
+	} else if report != nil {
+		log.Infof("no operating system packages found within %s image: %s", report.Kind, report.Summary())
+		s.Artifacts.MinimalImage = report
+	}
+
+	return &s, err
+}
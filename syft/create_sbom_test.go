@@ -0,0 +1,51 @@
+package syft
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestCreateSBOM(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "requirements.txt"), []byte("requests==2.28.1\n"), 0644))
+	src, err := source.NewFromDirectory(dir)
+	require.NoError(t, err)
+
+	s, err := CreateSBOM(context.Background(), &src, cataloger.DefaultConfig())
+	require.NoError(t, err)
+
+	require.Len(t, s.Artifacts.PackageCatalog.PackagesByName("requests"), 1)
+	assert.NotEmpty(t, s.Relationships)
+	assert.Equal(t, src.Metadata, s.Source)
+	assert.Equal(t, "syft", s.Descriptor.Name)
+	assert.Empty(t, s.Artifacts.Diagnostics)
+
+	// the SBOM can be encoded and decoded with the registered formats
+	encoded, err := Encode(*s, FormatByName("json"))
+	require.NoError(t, err)
+	decoded, format, err := Decode(bytes.NewReader(encoded))
+	require.NoError(t, err)
+	assert.Equal(t, JSONFormatID, format.ID())
+	assert.Len(t, decoded.Artifacts.PackageCatalog.PackagesByName("requests"), 1)
+}
+
+func TestCreateSBOM_canceled(t *testing.T) {
+	src, err := source.NewFromDirectory(t.TempDir())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s, err := CreateSBOM(ctx, &src, cataloger.DefaultConfig())
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, s)
+}
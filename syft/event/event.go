@@ -1,6 +1,7 @@
 /*
 Package event provides event types for all events that the syft library published onto the event bus. By convention, for each event
-defined here there should be a corresponding event parser defined in the parsers/ child package.
+defined here there should be a corresponding event parser defined in the parsers/ child package, or a typed event payload
+defined in the monitor/ child package (which library users can receive without a bus, see syft.Subscribe).
 */
 package event
 
@@ -34,4 +35,19 @@ const (
 
 	// UploadAttestation is a partybus event that occurs when syft uploads an attestation to an OCI registry (+ any transparency log)
 	UploadAttestation partybus.EventType = "syft-upload-attestation"
+
+	// CatalogerStarted is a partybus event that occurs when a single package cataloger starts (see monitor.CatalogerStarted)
+	CatalogerStarted partybus.EventType = "syft-cataloger-started-event"
+
+	// CatalogerFinished is a partybus event that occurs when a single package cataloger has found all of its packages
+	// (see monitor.CatalogerFinished)
+	CatalogerFinished partybus.EventType = "syft-cataloger-finished-event"
+
+	// CatalogerFailed is a partybus event that occurs when a single package cataloger fails, panics, or does not finish
+	// before cataloging times out or is canceled (see monitor.CatalogerFailed)
+	CatalogerFailed partybus.EventType = "syft-cataloger-failed-event"
+
+	// PackageDiscovered is a partybus event that occurs for each package found by a package cataloger (see
+	// monitor.PackageDiscovered)
+	PackageDiscovered partybus.EventType = "syft-package-discovered-event"
 )
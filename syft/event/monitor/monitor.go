@@ -0,0 +1,82 @@
+/*
+Package monitor provides the typed payloads of the events that the syft library publishes as cataloging progresses.
+Library users can receive these events with syft.Subscribe (without setting up an event bus), switching on the type of
+each event, while applications with an event bus receive them as the value of partybus events of the matching type.
+
+The events of a single cataloger are published in order: CatalogerStarted, then either each PackageDiscovered followed
+by CatalogerFinished, or CatalogerFailed. The events of different catalogers may interleave (even when the catalogers
+run one at a time), since a cataloger may start before the results of the previous cataloger are published.
+*/
+package monitor
+
+import (
+	"time"
+
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/anchore/syft/syft/event"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// Event is the payload of an event published by the syft library, which knows its own event type.
+type Event interface {
+	Type() partybus.EventType
+}
+
+var (
+	_ Event = CatalogerStarted{}
+	_ Event = CatalogerFinished{}
+	_ Event = CatalogerFailed{}
+	_ Event = PackageDiscovered{}
+)
+
+// CatalogerStarted describes a single package cataloger that has started looking for packages.
+type CatalogerStarted struct {
+	// Cataloger is the name of the cataloger
+	Cataloger string
+}
+
+func (CatalogerStarted) Type() partybus.EventType {
+	return event.CatalogerStarted
+}
+
+// CatalogerFinished describes a single package cataloger that has found all of its packages.
+type CatalogerFinished struct {
+	// Cataloger is the name of the cataloger
+	Cataloger string
+	// Packages is the number of packages found by the cataloger
+	Packages int
+	// Duration is how long the cataloger ran for
+	Duration time.Duration
+}
+
+func (CatalogerFinished) Type() partybus.EventType {
+	return event.CatalogerFinished
+}
+
+// CatalogerFailed describes a single package cataloger that failed (or did not finish before cataloging timed out or
+// was canceled), whose packages are missing from the results.
+type CatalogerFailed struct {
+	// Cataloger is the name of the cataloger
+	Cataloger string
+	// Err describes why the cataloger failed
+	Err error
+}
+
+func (CatalogerFailed) Type() partybus.EventType {
+	return event.CatalogerFailed
+}
+
+// PackageDiscovered describes a package found by a package cataloger. Packages are only discovered once the cataloger
+// that found them has finished, and may later be removed from the results (e.g. when merged with the same package found
+// by another cataloger, or excluded by a package filter).
+type PackageDiscovered struct {
+	// Cataloger is the name of the cataloger that found the package
+	Cataloger string
+	// Package is the package found
+	Package pkg.Package
+}
+
+func (PackageDiscovered) Type() partybus.EventType {
+	return event.PackageDiscovered
+}
@@ -10,6 +10,11 @@ type ClassificationCataloger struct {
 }
 
 func NewClassificationCataloger(classifiers []Classifier) (*ClassificationCataloger, error) {
+	for _, classifier := range classifiers {
+		if err := classifier.validate(); err != nil {
+			return nil, err
+		}
+	}
 	return &ClassificationCataloger{
 		classifiers: classifiers,
 	}, nil
@@ -18,84 +18,56 @@ func TestClassifierCataloger_DefaultClassifiers_PositiveCases(t *testing.T) {
 		expectedErr func(assert.TestingT, error, ...interface{}) bool
 	}{
 		{
-			name:       "positive-libpython3.7.so",
+			name:       "positive-flask",
 			fixtureDir: "test-fixtures/classifiers/positive",
-			location:   "libpython3.7.so",
+			location:   "flask/__init__.py",
 			expected: []Classification{
 				{
-					Class: "python-binary",
+					Class: "flask-framework",
 					Metadata: map[string]string{
-						"version": "3.7.4a-vZ9",
+						"version": "2.2.2",
 					},
 				},
 			},
 			expectedErr: assert.NoError,
 		},
 		{
-			name:       "positive-python3.6",
+			name:       "positive-express",
 			fixtureDir: "test-fixtures/classifiers/positive",
-			location:   "python3.6",
+			location:   "node_modules/express/package.json",
 			expected: []Classification{
 				{
-					Class: "python-binary",
+					Class: "express-framework",
 					Metadata: map[string]string{
-						"version": "3.6.3a-vZ9",
+						"version": "4.18.2",
 					},
 				},
 			},
 			expectedErr: assert.NoError,
 		},
 		{
-			name:       "positive-patchlevel.h",
+			name:       "positive-script",
 			fixtureDir: "test-fixtures/classifiers/positive",
-			location:   "patchlevel.h",
+			location:   "script",
 			expected: []Classification{
 				{
-					Class: "cpython-source",
+					Class: "interpreter-script",
 					Metadata: map[string]string{
-						"version": "3.9-aZ5",
+						"interpreter": "python3",
 					},
 				},
 			},
 			expectedErr: assert.NoError,
 		},
 		{
-			name:       "positive-go",
+			name:       "positive-elf",
 			fixtureDir: "test-fixtures/classifiers/positive",
-			location:   "go",
+			location:   "hello",
 			expected: []Classification{
 				{
-					Class: "go-binary",
+					Class: "elf-binary",
 					Metadata: map[string]string{
-						"version": "1.14",
-					},
-				},
-			},
-			expectedErr: assert.NoError,
-		},
-		{
-			name:       "positive-go-hint",
-			fixtureDir: "test-fixtures/classifiers/positive",
-			location:   "VERSION",
-			expected: []Classification{
-				{
-					Class: "go-binary-hint",
-					Metadata: map[string]string{
-						"version": "1.15",
-					},
-				},
-			},
-			expectedErr: assert.NoError,
-		},
-		{
-			name:       "positive-busybox",
-			fixtureDir: "test-fixtures/classifiers/positive",
-			location:   "[", // note: busybox is a link to [
-			expected: []Classification{
-				{
-					Class: "busybox-binary",
-					Metadata: map[string]string{
-						"version": "3.33.3",
+						"mimeType": "application/x-executable",
 					},
 				},
 			},
@@ -148,9 +120,9 @@ func TestClassifierCataloger_DefaultClassifiers_PositiveCases_Image(t *testing.T
 			location:     "/bin/[",
 			expected: []Classification{
 				{
-					Class: "busybox-binary",
+					Class: "elf-binary",
 					Metadata: map[string]string{
-						"version": "1.35.0",
+						"mimeType": "application/x-executable",
 					},
 				},
 			},
@@ -190,19 +162,34 @@ func TestClassifierCataloger_DefaultClassifiers_PositiveCases_Image(t *testing.T
 	}
 }
 
-func TestClassifierCataloger_DefaultClassifiers_NegativeCases(t *testing.T) {
-
-	c, err := NewClassificationCataloger(DefaultClassifiers)
-	assert.NoError(t, err)
-
-	src, err := source.NewFromDirectory("test-fixtures/classifiers/negative")
-	assert.NoError(t, err)
-
-	resolver, err := src.FileResolver(source.SquashedScope)
-	assert.NoError(t, err)
-
-	actualResults, err := c.Catalog(resolver)
-	assert.NoError(t, err)
-	assert.Equal(t, 0, len(actualResults))
+func TestNewClassificationCataloger_InvalidClassifiers(t *testing.T) {
+	tests := []struct {
+		name        string
+		classifiers []Classifier
+	}{
+		{
+			name: "missing class",
+			classifiers: []Classifier{
+				{
+					MIMETypes: []string{"application/x-executable"},
+				},
+			},
+		},
+		{
+			name: "missing criteria",
+			classifiers: []Classifier{
+				{
+					Class:                    "go-binary",
+					EvidencePatternTemplates: []string{`go(?P<version>[0-9.]+)`},
+				},
+			},
+		},
+	}
 
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewClassificationCataloger(test.classifiers)
+			assert.Error(t, err)
+		})
+	}
 }
@@ -8,61 +8,101 @@ import (
 	"text/template"
 
 	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/file"
 	"github.com/anchore/syft/syft/source"
 )
 
+// DefaultClassifiers are the classifiers run by the file classification cataloger. Note: classifiers that identify the
+// version of a package (e.g. python or go binaries) are run by the binary package cataloger instead (see
+// binary.DefaultClassifiers), which reports the files matched as evidence of the packages found.
 var DefaultClassifiers = []Classifier{
+	// frameworks
+
 	{
-		Class: "python-binary",
+		Class: "flask-framework",
 		FilepathPatterns: []*regexp.Regexp{
-			regexp.MustCompile(`(.*/|^)python(?P<version>[0-9]+\.[0-9]+)$`),
-			regexp.MustCompile(`(.*/|^)libpython(?P<version>[0-9]+\.[0-9]+).so.*$`),
+			regexp.MustCompile(`(.*/|^)flask/__init__\.py$`),
 		},
 		EvidencePatternTemplates: []string{
-			`(?m)(?P<version>{{ .version }}\.[0-9]+[-_a-zA-Z0-9]*)`,
+			`(?m)^__version__\s*=\s*["'](?P<version>[^"']+)["']`,
 		},
 	},
 	{
-		Class: "cpython-source",
+		Class: "express-framework",
 		FilepathPatterns: []*regexp.Regexp{
-			regexp.MustCompile(`(.*/|^)patchlevel.h$`),
+			regexp.MustCompile(`(.*/|^)node_modules/express/package\.json$`),
 		},
 		EvidencePatternTemplates: []string{
-			`(?m)#define\s+PY_VERSION\s+"?(?P<version>[0-9\.\-_a-zA-Z]+)"?`,
+			`(?m)^\s*"version"\s*:\s*"(?P<version>[^"]+)"`,
 		},
 	},
 	{
-		Class: "go-binary",
+		Class: "spring-framework",
 		FilepathPatterns: []*regexp.Regexp{
-			regexp.MustCompile(`(.*/|^)go$`),
-		},
-		EvidencePatternTemplates: []string{
-			`(?m)go(?P<version>[0-9]+\.[0-9]+(\.[0-9]+|beta[0-9]+|alpha[0-9]+|rc[0-9]+)?)`,
+			regexp.MustCompile(`(.*/|^)spring-core-(?P<version>[0-9]+\.[0-9]+\.[0-9]+[^/]*)\.jar$`),
 		},
 	},
 	{
-		Class: "go-binary-hint",
+		Class: "rails-framework",
 		FilepathPatterns: []*regexp.Regexp{
-			regexp.MustCompile(`(.*/|^)VERSION$`),
+			regexp.MustCompile(`(.*/|^)railties-(?P<version>[0-9]+\.[0-9]+\.[0-9]+[^/]*)/lib/rails\.rb$`),
 		},
-		EvidencePatternTemplates: []string{
-			`(?m)go(?P<version>[0-9]+\.[0-9]+(\.[0-9]+|beta[0-9]+|alpha[0-9]+|rc[0-9]+)?)`,
+	},
+
+	// interpreters
+
+	{
+		Class: "interpreter-script",
+		HeaderPatterns: []*regexp.Regexp{
+			// e.g. "#!/bin/sh", "#!/usr/bin/env python3", or "#!/usr/bin/env -S deno run"
+			regexp.MustCompile(`^#!\s*(\S*/env\s+(-\S+\s+)*)?(\S*/)?(?P<interpreter>[^\s/]+)`),
+		},
+	},
+
+	// binary types
+
+	{
+		Class: "elf-binary",
+		MIMETypes: []string{
+			"application/x-executable",
+			"application/x-elf",
+			"application/x-sharedlib",
+			"application/x-object",
 		},
 	},
 	{
-		Class: "busybox-binary",
-		FilepathPatterns: []*regexp.Regexp{
-			regexp.MustCompile(`(.*/|^)busybox$`),
+		Class: "macho-binary",
+		MIMETypes: []string{
+			"application/x-mach-binary",
 		},
-		EvidencePatternTemplates: []string{
-			`(?m)BusyBox\s+v(?P<version>[0-9]+\.[0-9]+\.[0-9]+)`,
+	},
+	{
+		Class: "pe-binary",
+		MIMETypes: []string{
+			"application/vnd.microsoft.portable-executable",
+		},
+	},
+	{
+		Class: "wasm-binary",
+		MIMETypes: []string{
+			"application/wasm",
 		},
 	},
 }
 
+// classifierHeaderSize is the number of bytes from the start of a file that header patterns are matched against.
+const classifierHeaderSize = 256
+
+// Classifier describes how to identify a class of file. A file is considered for classification when it matches every
+// kind of criteria given (filepath patterns, MIME types, and header patterns), at least one of which is required. When
+// evidence pattern templates are given the file contents must also match the evidence, in which case the metadata is
+// taken from the evidence; otherwise the metadata is taken from the named capture groups of the criteria.
+
 type Classifier struct {
 	Class                    string
 	FilepathPatterns         []*regexp.Regexp
+	MIMETypes                []string
+	HeaderPatterns           []*regexp.Regexp
 	EvidencePatternTemplates []string
 }
 
@@ -71,25 +111,66 @@ type Classification struct {
 	Metadata map[string]string `json:"metadata"`
 }
 
+func (c Classifier) validate() error {
+	if c.Class == "" {
+		return fmt.Errorf("classifier has no class")
+	}
+	if len(c.FilepathPatterns) == 0 && len(c.MIMETypes) == 0 && len(c.HeaderPatterns) == 0 {
+		return fmt.Errorf("classifier class=%q has no filepath patterns, MIME types, or header patterns", c.Class)
+	}
+	return nil
+}
+
 func (c Classifier) Classify(resolver source.FileResolver, location source.Location) (*Classification, error) {
-	doesFilepathMatch, filepathNamedGroupValues := filepathMatches(c.FilepathPatterns, location)
-	if !doesFilepathMatch {
-		return nil, nil
+	namedGroupValues := make(map[string]string)
+	if len(c.FilepathPatterns) > 0 {
+		doesFilepathMatch, filepathNamedGroupValues := filepathMatches(c.FilepathPatterns, location)
+		if !doesFilepathMatch {
+			return nil, nil
+		}
+		for key, value := range filepathNamedGroupValues {
+			namedGroupValues[key] = value
+		}
 	}
 
-	contentReader, err := resolver.FileContentsByLocation(location)
-	if err != nil {
-		return nil, err
+	if len(c.MIMETypes) > 0 {
+		metadata, err := resolver.FileMetadataByLocation(location)
+		if err != nil {
+			return nil, err
+		}
+		if !internal.NewStringSet(c.MIMETypes...).Contains(metadata.MIMEType) {
+			return nil, nil
+		}
+		namedGroupValues["mimeType"] = metadata.MIMEType
 	}
-	defer internal.CloseAndLogError(contentReader, location.VirtualPath)
 
-	// TODO: there is room for improvement here, as this may use an excessive amount of memory. Alternate approach is to leverage a RuneReader.
-	contents, err := io.ReadAll(contentReader)
+	if len(c.HeaderPatterns) > 0 {
+		doesHeaderMatch, headerNamedGroupValues, err := headerMatches(c.HeaderPatterns, resolver, location)
+		if err != nil {
+			return nil, err
+		}
+		if !doesHeaderMatch {
+			return nil, nil
+		}
+		for key, value := range headerNamedGroupValues {
+			namedGroupValues[key] = value
+		}
+	}
+
+	if len(c.EvidencePatternTemplates) == 0 {
+		return &Classification{
+			Class:    c.Class,
+			Metadata: namedGroupValues,
+		}, nil
+	}
+
+	contentReader, err := resolver.FileContentsByLocation(location)
 	if err != nil {
 		return nil, err
 	}
+	defer internal.CloseAndLogError(contentReader, location.VirtualPath)
 
-	var result *Classification
+	var patterns []*regexp.Regexp
 	for _, patternTemplate := range c.EvidencePatternTemplates {
 		tmpl, err := template.New("").Parse(patternTemplate)
 		if err != nil {
@@ -97,7 +178,7 @@ func (c Classifier) Classify(resolver source.FileResolver, location source.Locat
 		}
 
 		patternBuf := &bytes.Buffer{}
-		err = tmpl.Execute(patternBuf, filepathNamedGroupValues)
+		err = tmpl.Execute(patternBuf, namedGroupValues)
 		if err != nil {
 			return nil, fmt.Errorf("unable to render template: %w", err)
 		}
@@ -106,12 +187,22 @@ func (c Classifier) Classify(resolver source.FileResolver, location source.Locat
 		if err != nil {
 			return nil, fmt.Errorf("unable to compile rendered regex=%q: %w", patternBuf.String(), err)
 		}
+		patterns = append(patterns, pattern)
+	}
 
-		if !pattern.Match(contents) {
+	// search all patterns within a single pass over the contents
+	allMatches, err := file.FindFirstSubmatches(contentReader, patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *Classification
+	for i, pattern := range patterns {
+		if allMatches[i] == nil {
 			continue
 		}
 
-		matchMetadata := internal.MatchNamedCaptureGroups(pattern, string(contents))
+		matchMetadata := namedSubmatches(pattern, allMatches[i])
 		if result == nil {
 			result = &Classification{
 				Class:    c.Class,
@@ -126,6 +217,21 @@ func (c Classifier) Classify(resolver source.FileResolver, location source.Locat
 	return result, nil
 }
 
+// namedSubmatches returns the values of the named capture groups of the given pattern from the given submatches.
+func namedSubmatches(pattern *regexp.Regexp, submatches [][]byte) map[string]string {
+	var results map[string]string
+	for idx, name := range pattern.SubexpNames() {
+		if name == "" || idx >= len(submatches) {
+			continue
+		}
+		if results == nil {
+			results = make(map[string]string)
+		}
+		results[name] = string(submatches[idx])
+	}
+	return results
+}
+
 func filepathMatches(patterns []*regexp.Regexp, location source.Location) (bool, map[string]string) {
 	for _, path := range []string{location.RealPath, location.VirtualPath} {
 		if path == "" {
@@ -139,3 +245,23 @@ func filepathMatches(patterns []*regexp.Regexp, location source.Location) (bool,
 	}
 	return false, nil
 }
+
+func headerMatches(patterns []*regexp.Regexp, resolver source.FileResolver, location source.Location) (bool, map[string]string, error) {
+	contentReader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return false, nil, err
+	}
+	defer internal.CloseAndLogError(contentReader, location.VirtualPath)
+
+	header, err := io.ReadAll(io.LimitReader(contentReader, classifierHeaderSize))
+	if err != nil {
+		return false, nil, err
+	}
+
+	for _, pattern := range patterns {
+		if pattern.Match(header) {
+			return true, internal.MatchNamedCaptureGroups(pattern, string(header)), nil
+		}
+	}
+	return false, nil, nil
+}
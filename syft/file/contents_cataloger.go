@@ -5,6 +5,8 @@ import (
 	"encoding/base64"
 	"fmt"
 	"io"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/log"
@@ -14,15 +16,33 @@ import (
 type ContentsCataloger struct {
 	globs                     []string
 	skipFilesAboveSizeInBytes int64
+	encoding                  ContentsEncoding
 }
 
-func NewContentsCataloger(globs []string, skipFilesAboveSize int64) (*ContentsCataloger, error) {
+// NewContentsCataloger creates a cataloger that captures the contents of files matching any of the given globs, skipping
+// files above the given size (when positive). Contents are base64 encoded unless the plain encoding is given, in which
+// case only text files are captured.
+func NewContentsCataloger(globs []string, skipFilesAboveSize int64, encoding ContentsEncoding) (*ContentsCataloger, error) {
+	switch encoding {
+	case "":
+		encoding = Base64ContentsEncoding
+	case Base64ContentsEncoding, PlainContentsEncoding:
+	default:
+		return nil, fmt.Errorf("unsupported file contents encoding=%q (options: %v)", encoding, AllContentsEncodings)
+	}
+
 	return &ContentsCataloger{
 		globs:                     globs,
 		skipFilesAboveSizeInBytes: skipFilesAboveSize,
+		encoding:                  encoding,
 	}, nil
 }
 
+// Encoding returns how the captured file contents are represented.
+func (i *ContentsCataloger) Encoding() ContentsEncoding {
+	return i.encoding
+}
+
 func (i *ContentsCataloger) Catalog(resolver source.FileResolver) (map[source.Coordinates]string, error) {
 	results := make(map[source.Coordinates]string)
 	var locations []source.Location
@@ -49,6 +69,10 @@ func (i *ContentsCataloger) Catalog(resolver source.FileResolver) (map[source.Co
 		if err != nil {
 			return nil, err
 		}
+		if i.encoding == PlainContentsEncoding && !isText(result) {
+			log.Warnf("file contents cataloger skipping binary file (cannot capture with plain encoding): %s", location.RealPath)
+			continue
+		}
 		results[location.Coordinates] = result
 	}
 	log.Debugf("file contents cataloger processed %d files", len(results))
@@ -63,6 +87,14 @@ func (i *ContentsCataloger) catalogLocation(resolver source.FileResolver, locati
 	}
 	defer internal.CloseAndLogError(contentReader, location.VirtualPath)
 
+	if i.encoding == PlainContentsEncoding {
+		contents, err := io.ReadAll(contentReader)
+		if err != nil {
+			return "", internal.ErrPath{Context: "contents-cataloger", Path: location.RealPath, Err: err}
+		}
+		return string(contents), nil
+	}
+
 	buf := &bytes.Buffer{}
 	encoder := base64.NewEncoder(base64.StdEncoding, buf)
 	if _, err = io.Copy(encoder, contentReader); err != nil {
@@ -75,3 +107,8 @@ func (i *ContentsCataloger) catalogLocation(resolver source.FileResolver, locati
 
 	return buf.String(), nil
 }
+
+// isText indicates if the given contents can be represented as text within the SBOM (valid UTF-8 without NUL bytes).
+func isText(contents string) bool {
+	return utf8.ValidString(contents) && !strings.ContainsRune(contents, 0)
+}
@@ -67,7 +67,7 @@ func TestContentsCataloger(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			c, err := NewContentsCataloger(test.globs, test.maxSize)
+			c, err := NewContentsCataloger(test.globs, test.maxSize, Base64ContentsEncoding)
 			assert.NoError(t, err)
 
 			resolver := source.NewMockResolverForPaths(test.files...)
@@ -78,3 +78,48 @@ func TestContentsCataloger(t *testing.T) {
 		})
 	}
 }
+
+func TestContentsCataloger_PlainEncoding(t *testing.T) {
+	c, err := NewContentsCataloger([]string{"**/*.txt", "**/*.bin"}, 0, PlainContentsEncoding)
+	assert.NoError(t, err)
+	assert.Equal(t, PlainContentsEncoding, c.Encoding())
+
+	resolver := source.NewMockResolverForPaths("test-fixtures/last/path.txt", "test-fixtures/a-path.txt", "test-fixtures/binary/contents.bin")
+	actual, err := c.Catalog(resolver)
+	assert.NoError(t, err)
+
+	// binary files cannot be represented as text and are not captured
+	expected := map[source.Coordinates]string{
+		source.NewLocation("test-fixtures/last/path.txt").Coordinates: "test-fixtures/last/path.txt file contents!",
+		source.NewLocation("test-fixtures/a-path.txt").Coordinates:    "test-fixtures/a-path.txt file contents!",
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestNewContentsCataloger_Encoding(t *testing.T) {
+	c, err := NewContentsCataloger(nil, 0, "")
+	assert.NoError(t, err)
+	assert.Equal(t, Base64ContentsEncoding, c.Encoding())
+
+	_, err = NewContentsCataloger(nil, 0, UnknownContentsEncoding)
+	assert.Error(t, err)
+}
+
+func TestParseContentsEncoding(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected ContentsEncoding
+	}{
+		{input: "", expected: Base64ContentsEncoding},
+		{input: "base64", expected: Base64ContentsEncoding},
+		{input: "Base64", expected: Base64ContentsEncoding},
+		{input: "plain", expected: PlainContentsEncoding},
+		{input: "text", expected: PlainContentsEncoding},
+		{input: "hex", expected: UnknownContentsEncoding},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, ParseContentsEncoding(test.input))
+		})
+	}
+}
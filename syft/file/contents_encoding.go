@@ -0,0 +1,33 @@
+package file
+
+import "strings"
+
+// ContentsEncoding describes how file contents are represented within the SBOM.
+type ContentsEncoding string
+
+const (
+	// UnknownContentsEncoding is returned when an encoding cannot be parsed
+	UnknownContentsEncoding ContentsEncoding = "unknown"
+	// Base64ContentsEncoding represents file contents as standard base64 (the default)
+	Base64ContentsEncoding ContentsEncoding = "base64"
+	// PlainContentsEncoding represents file contents as text, as found on disk (binary files are not captured)
+	PlainContentsEncoding ContentsEncoding = "plain"
+)
+
+// AllContentsEncodings is a slice containing all possible file contents encodings
+var AllContentsEncodings = []ContentsEncoding{
+	Base64ContentsEncoding,
+	PlainContentsEncoding,
+}
+
+// ParseContentsEncoding returns the file contents encoding indicated by the given string (defaulting to base64 when
+// no encoding is given).
+func ParseContentsEncoding(userStr string) ContentsEncoding {
+	switch strings.ToLower(strings.TrimSpace(userStr)) {
+	case "", string(Base64ContentsEncoding):
+		return Base64ContentsEncoding
+	case string(PlainContentsEncoding), "text":
+		return PlainContentsEncoding
+	}
+	return UnknownContentsEncoding
+}
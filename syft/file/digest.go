@@ -1,6 +1,72 @@
 package file
 
+import (
+	"crypto"
+	"fmt"
+	"sort"
+
+	// register the hash implementations of all supported digest algorithms
+	_ "crypto/md5"
+	_ "crypto/sha1"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+
+	_ "golang.org/x/crypto/blake2b"
+)
+
 type Digest struct {
 	Algorithm string `json:"algorithm"`
 	Value     string `json:"value"`
 }
+
+// supportedDigestHashes are the hash algorithms that digests can be calculated with
+var supportedDigestHashes = []crypto.Hash{
+	crypto.MD5,
+	crypto.SHA1,
+	crypto.SHA256,
+	crypto.SHA512,
+	crypto.BLAKE2b_256,
+	crypto.BLAKE2b_512,
+}
+
+// digestAlgorithmAliases are alternate names for digest algorithms (e.g. "blake2b" is shorthand for "blake2b256").
+var digestAlgorithmAliases = map[string]crypto.Hash{
+	"blake2b": crypto.BLAKE2b_256,
+}
+
+// SupportedDigestAlgorithms returns the names of all digest algorithms that can be calculated (sorted).
+func SupportedDigestAlgorithms() []string {
+	names := make([]string, 0, len(supportedDigestHashes))
+	for _, h := range supportedDigestHashes {
+		names = append(names, DigestAlgorithmName(h))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DigestHashes returns the hash algorithms for the given digest algorithm names (e.g. "sha256", "SHA-512", or "blake2b"),
+// dropping duplicates.
+func DigestHashes(names []string) ([]crypto.Hash, error) {
+	supported := make(map[string]crypto.Hash)
+	for _, h := range supportedDigestHashes {
+		supported[DigestAlgorithmName(h)] = h
+	}
+	for name, h := range digestAlgorithmAliases {
+		supported[name] = h
+	}
+
+	var hashes []crypto.Hash
+	seen := make(map[crypto.Hash]struct{})
+	for _, name := range names {
+		h, ok := supported[CleanDigestAlgorithmName(name)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported hash algorithm: %s (options: %v)", name, SupportedDigestAlgorithms())
+		}
+		if _, exists := seen[h]; exists {
+			continue
+		}
+		seen[h] = struct{}{}
+		hashes = append(hashes, h)
+	}
+	return hashes, nil
+}
@@ -84,6 +84,25 @@ func (i *DigestsCataloger) catalogLocation(resolver source.FileResolver, locatio
 }
 
 func DigestsFromFile(closer io.ReadCloser, hashes []crypto.Hash) ([]Digest, error) {
+	digester := NewDigester(hashes)
+	if _, err := io.Copy(digester, closer); err != nil {
+		return nil, err
+	}
+	return digester.Digests(), nil
+}
+
+// Digester calculates the digests of all content written to it with several hash algorithms at once, allowing
+// digests to be calculated while the content is being read for another purpose (e.g. with an io.TeeReader) instead of
+// reading the content again.
+type Digester struct {
+	hashes  []crypto.Hash
+	hashers []hash.Hash
+	writer  io.Writer
+	size    int64
+}
+
+// NewDigester creates a Digester for the given hash algorithms.
+func NewDigester(hashes []crypto.Hash) *Digester {
 	// create a set of hasher objects tied together with a single writer to feed content into
 	hashers := make([]hash.Hash, len(hashes))
 	writers := make([]io.Writer, len(hashes))
@@ -92,27 +111,37 @@ func DigestsFromFile(closer io.ReadCloser, hashes []crypto.Hash) ([]Digest, erro
 		writers[idx] = hashers[idx]
 	}
 
-	size, err := io.Copy(io.MultiWriter(writers...), closer)
-	if err != nil {
-		return nil, err
+	return &Digester{
+		hashes:  hashes,
+		hashers: hashers,
+		writer:  io.MultiWriter(writers...),
 	}
+}
+
+func (d *Digester) Write(p []byte) (int, error) {
+	n, err := d.writer.Write(p)
+	d.size += int64(n)
+	return n, err
+}
 
-	if size == 0 {
-		return make([]Digest, 0), nil
+// Digests returns the digests of the content written so far.
+func (d *Digester) Digests() []Digest {
+	if d.size == 0 {
+		return make([]Digest, 0)
 	}
 
-	result := make([]Digest, len(hashes))
+	result := make([]Digest, len(d.hashes))
 	// only capture digests when there is content. It is important to do this based on SIZE and not
 	// FILE TYPE. The reasoning is that it is possible for a tar to be crafted with a header-only
 	// file type but a body is still allowed.
-	for idx, hasher := range hashers {
+	for idx, hasher := range d.hashers {
 		result[idx] = Digest{
-			Algorithm: DigestAlgorithmName(hashes[idx]),
+			Algorithm: DigestAlgorithmName(d.hashes[idx]),
 			Value:     fmt.Sprintf("%+x", hasher.Sum(nil)),
 		}
 	}
 
-	return result, nil
+	return result
 }
 
 func DigestAlgorithmName(hash crypto.Hash) string {
@@ -0,0 +1,77 @@
+package file
+
+import (
+	"crypto"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigestHashes(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []string
+		want    []crypto.Hash
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:  "clean names",
+			input: []string{"sha1", "sha256", "sha512", "md5"},
+			want:  []crypto.Hash{crypto.SHA1, crypto.SHA256, crypto.SHA512, crypto.MD5},
+		},
+		{
+			name:  "unclean names",
+			input: []string{"SHA-1", "Sha-512", "BLAKE2b-512"},
+			want:  []crypto.Hash{crypto.SHA1, crypto.SHA512, crypto.BLAKE2b_512},
+		},
+		{
+			name:  "blake2b alias",
+			input: []string{"blake2b", "blake2b256"},
+			want:  []crypto.Hash{crypto.BLAKE2b_256},
+		},
+		{
+			name:    "unsupported",
+			input:   []string{"sha256", "sha3"},
+			wantErr: require.Error,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.wantErr == nil {
+				tt.wantErr = require.NoError
+			}
+			got, err := DigestHashes(tt.input)
+			tt.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestDigestsFromFile_AdditionalAlgorithms(t *testing.T) {
+	hashes, err := DigestHashes([]string{"sha512", "blake2b", "blake2b512"})
+	require.NoError(t, err)
+
+	digests, err := DigestsFromFile(io.NopCloser(strings.NewReader("hello world")), hashes)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Digest{
+		{
+			Algorithm: "sha512",
+			Value:     "309ecc489c12d6eb4cc40f50c902f2b4d0ed77ee511a7c7a9bcd3ca86d4cd86f989dd35bc5ff499670da34255b45b0cfd830e81f605dcf7dc5542e93ae9cd76f",
+		},
+		{
+			Algorithm: "blake2b256",
+			Value:     "256c83b297114d201b30179f3f0ef0cace9783622da5974326b436178aeef610",
+		},
+		{
+			Algorithm: "blake2b512",
+			Value:     "021ced8799296ceca557832ab941a50b4a11f83478cf141f51f933f653ab9fbcc05a037cddbed06e309bf334942c4e58cdf1a46e237911ccd7fcf9787cbc7fd0",
+		},
+	}, digests)
+}
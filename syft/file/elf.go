@@ -0,0 +1,97 @@
+package file
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/hex"
+	"fmt"
+	"io"
+)
+
+// gnuBuildIDNoteType is the NT_GNU_BUILD_ID note type (not provided by debug/elf)
+const gnuBuildIDNoteType = 3
+
+var elfMagic = []byte(elf.ELFMAG)
+
+// ELFMetadata represents the dynamic linking details of a single ELF binary or shared library.
+type ELFMetadata struct {
+	SOName  string   `json:"soname,omitempty"`  // the DT_SONAME of a shared library
+	BuildID string   `json:"buildID,omitempty"` // the hex-encoded GNU build-id note
+	Needed  []string `json:"needed,omitempty"`  // the DT_NEEDED shared libraries, in link order
+}
+
+// isELF indicates if the given header bytes begin with the ELF magic number.
+func isELF(header []byte) bool {
+	return bytes.HasPrefix(header, elfMagic)
+}
+
+// parseELF extracts the dynamic linking details from the given ELF content. A nil result is returned for ELF files
+// that are not executables or shared objects (e.g. relocatable object files or core dumps).
+func parseELF(r io.ReaderAt) (*ELFMetadata, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ELF file: %w", err)
+	}
+	defer f.Close()
+
+	if f.Type != elf.ET_EXEC && f.Type != elf.ET_DYN {
+		return nil, nil
+	}
+
+	needed, err := f.DynString(elf.DT_NEEDED)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read DT_NEEDED entries: %w", err)
+	}
+
+	var soname string
+	sonames, err := f.DynString(elf.DT_SONAME)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read DT_SONAME entry: %w", err)
+	}
+	if len(sonames) > 0 {
+		soname = sonames[0]
+	}
+
+	return &ELFMetadata{
+		SOName:  soname,
+		BuildID: elfBuildID(f),
+		Needed:  needed,
+	}, nil
+}
+
+// elfBuildID returns the hex-encoded GNU build-id from the ".note.gnu.build-id" section (if present).
+func elfBuildID(f *elf.File) string {
+	section := f.Section(".note.gnu.build-id")
+	if section == nil {
+		return ""
+	}
+
+	data, err := section.Data()
+	if err != nil {
+		return ""
+	}
+
+	// note layout: namesz (4 bytes), descsz (4 bytes), type (4 bytes), name (padded to 4 bytes), desc
+	for len(data) >= 12 {
+		nameSize := f.ByteOrder.Uint32(data[0:4])
+		descSize := f.ByteOrder.Uint32(data[4:8])
+		noteType := f.ByteOrder.Uint32(data[8:12])
+
+		nameEnd := 12 + align4(nameSize)
+		descEnd := nameEnd + align4(descSize)
+		if uint64(len(data)) < descEnd {
+			return ""
+		}
+
+		name := string(bytes.TrimRight(data[12:12+uint64(nameSize)], "\x00"))
+		if name == "GNU" && noteType == gnuBuildIDNoteType {
+			return hex.EncodeToString(data[nameEnd : nameEnd+uint64(descSize)])
+		}
+		data = data[descEnd:]
+	}
+	return ""
+}
+
+func align4(n uint32) uint64 {
+	return (uint64(n) + 3) &^ 3
+}
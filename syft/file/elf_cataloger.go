@@ -0,0 +1,137 @@
+package file
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/file"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+type ELFCataloger struct {
+}
+
+func NewELFCataloger() *ELFCataloger {
+	return &ELFCataloger{}
+}
+
+// Catalog records the dynamic linking details of every ELF executable and shared library found, returning relationships
+// from each resolved shared library file to every ELF file that lists it as DT_NEEDED.
+func (i *ELFCataloger) Catalog(resolver source.FileResolver) (map[source.Coordinates]ELFMetadata, []artifact.Relationship, error) {
+	results := make(map[source.Coordinates]ELFMetadata)
+
+	for _, location := range allRegularFiles(resolver) {
+		metadata, err := catalogELFLocation(resolver, location)
+		if internal.IsErrPathPermission(err) {
+			log.Debugf("ELF cataloger skipping %q: %+v", location.RealPath, err)
+			continue
+		}
+		if err != nil {
+			log.Warnf("ELF cataloger failed to process %q: %+v", location.RealPath, err)
+			continue
+		}
+		if metadata == nil {
+			continue
+		}
+		results[location.Coordinates] = *metadata
+	}
+	log.Debugf("ELF cataloger discovered %d ELF files", len(results))
+
+	return results, elfLinkageRelationships(results), nil
+}
+
+func catalogELFLocation(resolver source.FileResolver, location source.Location) (*ELFMetadata, error) {
+	reader, err := elfReader(resolver, location, "elf-cataloger")
+	if err != nil || reader == nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	metadata, err := parseELF(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %q: %w", location.RealPath, err)
+	}
+	return metadata, nil
+}
+
+// elfReader returns a random access reader for the contents of the file at the given location, or nil if the file is
+// not an ELF file. Contents are only buffered (in memory, or within a temporary file for large files) when the
+// underlying reader does not already support random access. The caller must close the returned reader.
+func elfReader(resolver source.FileResolver, location source.Location, context string) (file.SeekableReadCloser, error) {
+	contentReader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, len(elfMagic))
+	if _, err := io.ReadFull(contentReader, header); err != nil || !isELF(header) {
+		// too small or not an ELF file, nothing to record
+		internal.CloseAndLogError(contentReader, location.VirtualPath)
+		return nil, nil
+	}
+
+	if seekable, ok := contentReader.(file.SeekableReadCloser); ok {
+		return seekable, nil
+	}
+
+	reader, err := file.NewSeekableReader(struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: io.MultiReader(bytes.NewReader(header), contentReader),
+		Closer: contentReader,
+	})
+	if err != nil {
+		internal.CloseAndLogError(contentReader, location.VirtualPath)
+		return nil, internal.ErrPath{Context: context, Path: location.RealPath, Err: err}
+	}
+	return reader, nil
+}
+
+// elfLinkageRelationships resolves each DT_NEEDED entry to the cataloged shared libraries that provide it, preferring
+// libraries with a matching DT_SONAME and falling back to libraries with a matching file name.
+func elfLinkageRelationships(results map[source.Coordinates]ELFMetadata) []artifact.Relationship {
+	bySOName := make(map[string][]source.Coordinates)
+	byName := make(map[string][]source.Coordinates)
+	for coordinates, metadata := range results {
+		if metadata.SOName != "" {
+			bySOName[metadata.SOName] = append(bySOName[metadata.SOName], coordinates)
+		}
+		byName[path.Base(coordinates.RealPath)] = append(byName[path.Base(coordinates.RealPath)], coordinates)
+	}
+
+	var relationships []artifact.Relationship
+	for coordinates, metadata := range results {
+		for _, needed := range metadata.Needed {
+			libraries, ok := bySOName[needed]
+			if !ok {
+				libraries = byName[needed]
+			}
+			for _, library := range libraries {
+				if library == coordinates {
+					continue
+				}
+				relationships = append(relationships, artifact.Relationship{
+					From: library,
+					To:   coordinates,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(relationships, func(i, j int) bool {
+		if relationships[i].From.ID() == relationships[j].From.ID() {
+			return relationships[i].To.ID() < relationships[j].To.ID()
+		}
+		return relationships[i].From.ID() < relationships[j].From.ID()
+	})
+
+	return relationships
+}
@@ -0,0 +1,82 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestELFCataloger(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/elf")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	results, relationships, err := NewELFCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	app := source.Coordinates{RealPath: "usr/bin/app"}
+	lib := source.Coordinates{RealPath: "usr/lib/libfoo.so.1"}
+
+	assert.Equal(t, map[source.Coordinates]ELFMetadata{
+		app: {
+			BuildID: "d7e6356bd3a3870cfe8f14dee90657c4c683628a",
+			Needed:  []string{"libfoo.so.1", "libc.so.6"},
+		},
+		lib: {
+			SOName:  "libfoo.so.1",
+			BuildID: "53761697dc3ff3c217282be004be5905f86cff0f",
+		},
+	}, results)
+
+	// libc is not within the fixture, so only the libfoo linkage can be resolved
+	assert.Equal(t, []artifact.Relationship{
+		{
+			From: lib,
+			To:   app,
+			Type: artifact.DependencyOfRelationship,
+		},
+	}, relationships)
+}
+
+type testOwner struct {
+	id string
+}
+
+func (o testOwner) ID() artifact.ID {
+	return artifact.ID(o.id)
+}
+
+func TestRelationshipsByELFLinkage(t *testing.T) {
+	app := source.Coordinates{RealPath: "/usr/bin/app"}
+	tool := source.Coordinates{RealPath: "/usr/bin/foo-tool"}
+	lib := source.Coordinates{RealPath: "/usr/lib/libfoo.so.1"}
+
+	appPkg := testOwner{id: "app-pkg"}
+	fooPkg := testOwner{id: "foo-pkg"}
+
+	relationships := []artifact.Relationship{
+		{From: appPkg, To: app, Type: artifact.ContainsRelationship},
+		{From: fooPkg, To: lib, Type: artifact.ContainsRelationship},
+		{From: fooPkg, To: tool, Type: artifact.ContainsRelationship},
+		{From: lib, To: app, Type: artifact.DependencyOfRelationship},
+		// a package linking against its own library should not be related to itself
+		{From: lib, To: tool, Type: artifact.DependencyOfRelationship},
+	}
+
+	assert.Equal(t, []artifact.Relationship{
+		{
+			From: fooPkg,
+			To:   app,
+			Type: artifact.DependencyOfRelationship,
+			Data: elfLinkageMetadata{
+				Files: []string{"/usr/lib/libfoo.so.1"},
+			},
+		},
+	}, RelationshipsByELFLinkage(relationships))
+}
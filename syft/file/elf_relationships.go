@@ -0,0 +1,84 @@
+package file
+
+import (
+	"sort"
+
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+type elfLinkageMetadata struct {
+	Files []string `json:"files"`
+}
+
+// RelationshipsByELFLinkage creates relationships from the artifacts (typically packages) that contain a shared library
+// to each ELF binary that links against that library. This is derived from the library-to-binary relationships found
+// by the ELF cataloger and the artifact-contains-file relationships found while cataloging packages. Artifacts that
+// contain the linking binary themselves are not related (e.g. a package linking against its own libraries).
+func RelationshipsByELFLinkage(relationships []artifact.Relationship) []artifact.Relationship {
+	graph := artifact.NewGraph(relationships...)
+
+	type edge struct {
+		from artifact.Identifiable
+		to   source.Coordinates
+	}
+	edges := make(map[artifact.ID]map[artifact.ID]*edge)
+	files := make(map[artifact.ID]map[artifact.ID]*strset.Set)
+
+	for _, r := range graph.Relationships() {
+		if r.Type != artifact.DependencyOfRelationship {
+			continue
+		}
+		library, ok := r.From.(source.Coordinates)
+		if !ok {
+			continue
+		}
+		binary, ok := r.To.(source.Coordinates)
+		if !ok {
+			continue
+		}
+
+		for _, owner := range graph.Owners(library.ID()) {
+			ownerID := owner.ID()
+			if graph.Has(ownerID, binary.ID(), artifact.ContainsRelationship) {
+				continue
+			}
+			if _, exists := edges[ownerID]; !exists {
+				edges[ownerID] = make(map[artifact.ID]*edge)
+				files[ownerID] = make(map[artifact.ID]*strset.Set)
+			}
+			if _, exists := edges[ownerID][binary.ID()]; !exists {
+				edges[ownerID][binary.ID()] = &edge{from: owner, to: binary}
+				files[ownerID][binary.ID()] = strset.New()
+			}
+			files[ownerID][binary.ID()].Add(library.RealPath)
+		}
+	}
+
+	var results []artifact.Relationship
+	for ownerID, byBinary := range edges {
+		for binaryID, e := range byBinary {
+			fs := files[ownerID][binaryID].List()
+			sort.Strings(fs)
+			results = append(results, artifact.Relationship{
+				From: e.from,
+				To:   e.to,
+				Type: artifact.DependencyOfRelationship,
+				Data: elfLinkageMetadata{
+					Files: fs,
+				},
+			})
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].From.ID() == results[j].From.ID() {
+			return results[i].To.ID() < results[j].To.ID()
+		}
+		return results[i].From.ID() < results[j].From.ID()
+	})
+
+	return results
+}
@@ -0,0 +1,180 @@
+package file
+
+import (
+	"debug/elf"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// elfDF1Now is the DF_1_NOW flag of the DT_FLAGS_1 dynamic entry (not provided by debug/elf)
+const elfDF1Now = 0x1
+
+// stackCanarySymbols are the symbols referenced by code compiled with stack smashing protection.
+var stackCanarySymbols = []string{
+	"__stack_chk_fail",
+	"__stack_chk_guard",
+	"__intel_security_cookie",
+}
+
+// ExecutableFormat is the binary format of an executable file.
+type ExecutableFormat string
+
+const (
+	ELFExecutable ExecutableFormat = "elf"
+)
+
+// RELROLevel describes how much of the relocation data of an ELF file is made read-only after dynamic linking.
+type RELROLevel string
+
+const (
+	NoRELRO      RELROLevel = "none"
+	PartialRELRO RELROLevel = "partial"
+	FullRELRO    RELROLevel = "full"
+)
+
+// Executable represents the security hardening features of a single executable or shared library.
+type Executable struct {
+	Format      ExecutableFormat `json:"format"`      // the binary format of the file
+	PIE         bool             `json:"pie"`         // the code is position independent (always true for shared libraries)
+	RELRO       RELROLevel       `json:"relro"`       // how much of the relocation data is read-only after linking
+	StackCanary bool             `json:"stackCanary"` // the code was compiled with stack smashing protection
+	NX          bool             `json:"nx"`          // the stack is marked as non-executable
+	Stripped    bool             `json:"stripped"`    // the symbol table has been removed
+}
+
+// parseELFExecutable extracts the security hardening features from the given ELF content. A nil result is returned
+// for ELF files that are not executables or shared objects (e.g. relocatable object files or core dumps).
+func parseELFExecutable(r io.ReaderAt) (*Executable, error) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse ELF file: %w", err)
+	}
+	defer f.Close()
+
+	if f.Type != elf.ET_EXEC && f.Type != elf.ET_DYN {
+		return nil, nil
+	}
+
+	dynamic, err := elfDynamicEntries(f)
+	if err != nil {
+		return nil, err
+	}
+
+	canary, err := elfHasStackCanary(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Executable{
+		Format:      ELFExecutable,
+		PIE:         f.Type == elf.ET_DYN,
+		RELRO:       elfRELRO(f, dynamic),
+		StackCanary: canary,
+		NX:          elfHasNonExecutableStack(f),
+		Stripped:    f.Section(".symtab") == nil,
+	}, nil
+}
+
+// elfRELRO determines the RELRO level from the presence of a PT_GNU_RELRO segment (partial) combined with immediate
+// binding of all symbols at load time (full).
+func elfRELRO(f *elf.File, dynamic map[elf.DynTag][]uint64) RELROLevel {
+	if !elfHasProg(f, elf.PT_GNU_RELRO) {
+		return NoRELRO
+	}
+
+	if _, ok := dynamic[elf.DT_BIND_NOW]; ok {
+		return FullRELRO
+	}
+	for _, flags := range dynamic[elf.DT_FLAGS] {
+		if flags&uint64(elf.DF_BIND_NOW) != 0 {
+			return FullRELRO
+		}
+	}
+	for _, flags := range dynamic[elf.DT_FLAGS_1] {
+		if flags&elfDF1Now != 0 {
+			return FullRELRO
+		}
+	}
+	return PartialRELRO
+}
+
+// elfHasNonExecutableStack indicates if the PT_GNU_STACK segment is present and not executable. Without the segment
+// most loaders fall back to an executable stack.
+func elfHasNonExecutableStack(f *elf.File) bool {
+	for _, prog := range f.Progs {
+		if prog.Type == elf.PT_GNU_STACK {
+			return prog.Flags&elf.PF_X == 0
+		}
+	}
+	return false
+}
+
+// elfHasStackCanary indicates if any static or dynamic symbol is one used by stack smashing protection.
+func elfHasStackCanary(f *elf.File) (bool, error) {
+	for _, getSymbols := range []func() ([]elf.Symbol, error){f.DynamicSymbols, f.Symbols} {
+		symbols, err := getSymbols()
+		if err != nil && !errors.Is(err, elf.ErrNoSymbols) {
+			return false, fmt.Errorf("unable to read ELF symbols: %w", err)
+		}
+		for _, symbol := range symbols {
+			for _, name := range stackCanarySymbols {
+				// dynamic symbol names may carry a version suffix (e.g. "__stack_chk_fail@GLIBC_2.4")
+				if symbol.Name == name || strings.HasPrefix(symbol.Name, name+"@") {
+					return true, nil
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+func elfHasProg(f *elf.File, progType elf.ProgType) bool {
+	for _, prog := range f.Progs {
+		if prog.Type == progType {
+			return true
+		}
+	}
+	return false
+}
+
+// elfDynamicEntries returns the values of all entries in the ".dynamic" section, keyed by tag.
+func elfDynamicEntries(f *elf.File) (map[elf.DynTag][]uint64, error) {
+	entries := make(map[elf.DynTag][]uint64)
+
+	section := f.Section(".dynamic")
+	if section == nil {
+		return entries, nil
+	}
+
+	data, err := section.Data()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read ELF dynamic section: %w", err)
+	}
+
+	// each entry is a tag followed by a value, both of the native word size
+	wordSize := 8
+	if f.Class == elf.ELFCLASS32 {
+		wordSize = 4
+	}
+
+	for len(data) >= 2*wordSize {
+		var tag elf.DynTag
+		var value uint64
+		if wordSize == 8 {
+			tag = elf.DynTag(f.ByteOrder.Uint64(data[0:8]))
+			value = f.ByteOrder.Uint64(data[8:16])
+		} else {
+			tag = elf.DynTag(f.ByteOrder.Uint32(data[0:4]))
+			value = uint64(f.ByteOrder.Uint32(data[4:8]))
+		}
+		data = data[2*wordSize:]
+
+		if tag == elf.DT_NULL {
+			break
+		}
+		entries[tag] = append(entries[tag], value)
+	}
+	return entries, nil
+}
@@ -0,0 +1,55 @@
+package file
+
+import (
+	"fmt"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source"
+)
+
+type ExecutableCataloger struct {
+}
+
+func NewExecutableCataloger() *ExecutableCataloger {
+	return &ExecutableCataloger{}
+}
+
+// Catalog records the security hardening features (PIE, RELRO, stack canary, NX, stripped) of every ELF executable
+// and shared library found.
+func (i *ExecutableCataloger) Catalog(resolver source.FileResolver) (map[source.Coordinates]Executable, error) {
+	results := make(map[source.Coordinates]Executable)
+
+	for _, location := range allRegularFiles(resolver) {
+		executable, err := catalogExecutableLocation(resolver, location)
+		if internal.IsErrPathPermission(err) {
+			log.Debugf("executable cataloger skipping %q: %+v", location.RealPath, err)
+			continue
+		}
+		if err != nil {
+			log.Warnf("executable cataloger failed to process %q: %+v", location.RealPath, err)
+			continue
+		}
+		if executable == nil {
+			continue
+		}
+		results[location.Coordinates] = *executable
+	}
+	log.Debugf("executable cataloger discovered %d executables", len(results))
+
+	return results, nil
+}
+
+func catalogExecutableLocation(resolver source.FileResolver, location source.Location) (*Executable, error) {
+	reader, err := elfReader(resolver, location, "executable-cataloger")
+	if err != nil || reader == nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	executable, err := parseELFExecutable(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %q: %w", location.RealPath, err)
+	}
+	return executable, nil
+}
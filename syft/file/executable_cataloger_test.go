@@ -0,0 +1,72 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestExecutableCataloger(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected map[source.Coordinates]Executable
+	}{
+		{
+			name:    "hardening features",
+			fixture: "test-fixtures/executable",
+			expected: map[source.Coordinates]Executable{
+				{RealPath: "bin/hardened"}: {
+					Format:      ELFExecutable,
+					PIE:         true,
+					RELRO:       FullRELRO,
+					StackCanary: true,
+					NX:          true,
+				},
+				{RealPath: "bin/unhardened"}: {
+					Format:   ELFExecutable,
+					RELRO:    NoRELRO,
+					Stripped: true,
+				},
+			},
+		},
+		{
+			name:    "shared libraries",
+			fixture: "test-fixtures/elf",
+			expected: map[source.Coordinates]Executable{
+				{RealPath: "usr/bin/app"}: {
+					Format:   ELFExecutable,
+					PIE:      true,
+					RELRO:    PartialRELRO,
+					NX:       true,
+					Stripped: true,
+				},
+				{RealPath: "usr/lib/libfoo.so.1"}: {
+					Format:   ELFExecutable,
+					PIE:      true,
+					RELRO:    PartialRELRO,
+					NX:       true,
+					Stripped: true,
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, err := source.NewFromDirectory(test.fixture)
+			require.NoError(t, err)
+
+			resolver, err := src.FileResolver(source.SquashedScope)
+			require.NoError(t, err)
+
+			results, err := NewExecutableCataloger().Catalog(resolver)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expected, results)
+		})
+	}
+}
@@ -11,6 +11,7 @@ type SearchResult struct {
 	SeekPosition   int64  `json:"seekPosition"`
 	Length         int64  `json:"length"`
 	Value          string `json:"value,omitempty"`
+	Redacted       string `json:"redacted,omitempty"`
 }
 
 func (s SearchResult) String() string {
@@ -6,7 +6,9 @@ import (
 	"io"
 	"regexp"
 	"sort"
+	"strings"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/wagoodman/go-partybus"
 	"github.com/wagoodman/go-progress"
 
@@ -17,25 +19,61 @@ import (
 	"github.com/anchore/syft/syft/source"
 )
 
-var DefaultSecretsPatterns = map[string]string{
-	"aws-access-key":     `(?i)aws_access_key_id["'=:\s]*?(?P<value>(A3T[A-Z0-9]|AKIA|AGPA|AIDA|AROA|AIPA|ANPA|ANVA|ASIA)[A-Z0-9]{16})`,
-	"aws-secret-key":     `(?i)aws_secret_access_key["'=:\s]*?(?P<value>[0-9a-zA-Z/+]{40})`,
-	"pem-private-key":    `-----BEGIN (\S+ )?PRIVATE KEY(\sBLOCK)?-----((?P<value>(\n.*?)+)-----END (\S+ )?PRIVATE KEY(\sBLOCK)?-----)?`,
-	"docker-config-auth": `"auths"((.*\n)*.*?"auth"\s*:\s*"(?P<value>[^"]+)")?`,
-	"generic-api-key":    `(?i)api(-|_)?key["'=:\s]*?(?P<value>[A-Z0-9]{20,60})["']?(\s|$)`,
+// SecretsCatalogerConfig describes how to search files for secrets.
+type SecretsCatalogerConfig struct {
+	// Patterns are the named regular expressions matching secrets (see GenerateSearchPatterns)
+	Patterns map[string]*regexp.Regexp
+	// Entropy describes how to search for secrets by the randomness of strings
+	Entropy EntropyConfig
+	// Allowlist describes the files and values that are never considered secrets
+	Allowlist SecretsAllowlist
+	// RevealValues shows secret values in the results, otherwise only a redacted form of each value is shown
+	RevealValues bool
+	// SkipFilesAboveSize skips searching files above the given size in bytes (when positive)
+	SkipFilesAboveSize int64
+}
+
+// SecretsAllowlist describes findings that are known not to be secrets (e.g. test fixtures or example values).
+type SecretsAllowlist struct {
+	// Paths are globs of file paths that are not searched
+	Paths []string
+	// Values are regular expressions matching secret values that are ignored
+	Values []*regexp.Regexp
 }
 
 type SecretsCataloger struct {
 	patterns           map[string]*regexp.Regexp
+	entropy            EntropyConfig
+	allowlist          SecretsAllowlist
 	revealValues       bool
 	skipFilesAboveSize int64
 }
 
 func NewSecretsCataloger(patterns map[string]*regexp.Regexp, revealValues bool, maxFileSize int64) (*SecretsCataloger, error) {
+	return NewSecretsCatalogerFromConfig(SecretsCatalogerConfig{
+		Patterns:           patterns,
+		RevealValues:       revealValues,
+		SkipFilesAboveSize: maxFileSize,
+	})
+}
+
+// NewSecretsCatalogerFromConfig creates a secrets cataloger searching by the given patterns and (optionally) entropy.
+func NewSecretsCatalogerFromConfig(cfg SecretsCatalogerConfig) (*SecretsCataloger, error) {
+	if cfg.Entropy.Enabled && (cfg.Entropy.MinLength <= 0 || cfg.Entropy.Threshold <= 0) {
+		return nil, fmt.Errorf("entropy scanning requires a positive min length and threshold (min-length=%d threshold=%v)", cfg.Entropy.MinLength, cfg.Entropy.Threshold)
+	}
+	for _, glob := range cfg.Allowlist.Paths {
+		if !doublestar.ValidatePattern(glob) {
+			return nil, fmt.Errorf("invalid secrets allowlist path glob=%q", glob)
+		}
+	}
+
 	return &SecretsCataloger{
-		patterns:           patterns,
-		revealValues:       revealValues,
-		skipFilesAboveSize: maxFileSize,
+		patterns:           cfg.Patterns,
+		entropy:            cfg.Entropy,
+		allowlist:          cfg.Allowlist,
+		revealValues:       cfg.RevealValues,
+		skipFilesAboveSize: cfg.SkipFilesAboveSize,
 	}, nil
 }
 
@@ -45,6 +83,11 @@ func (i *SecretsCataloger) Catalog(resolver source.FileResolver) (map[source.Coo
 	stage, prog, secretsDiscovered := secretsCatalogingProgress(int64(len(locations)))
 	for _, location := range locations {
 		stage.Current = location.RealPath
+		if i.isAllowlistedPath(location.RealPath) {
+			log.Tracef("secrets cataloger skipping allowlisted path=%q", location.RealPath)
+			prog.N++
+			continue
+		}
 		result, err := i.catalogLocation(resolver, location)
 		if internal.IsErrPathPermission(err) {
 			log.Debugf("secrets cataloger skipping - %+v", err)
@@ -79,22 +122,42 @@ func (i *SecretsCataloger) catalogLocation(resolver source.FileResolver, locatio
 		return nil, nil
 	}
 
-	// TODO: in the future we can swap out search strategies here
 	secrets, err := catalogLocationByLine(resolver, location, i.patterns)
 	if err != nil {
 		return nil, internal.ErrPath{Context: "secrets-cataloger", Path: location.RealPath, Err: err}
 	}
 
-	if i.revealValues {
-		for idx, secret := range secrets {
-			value, err := extractValue(resolver, location, secret.SeekPosition, secret.Length)
-			if err != nil {
-				return nil, err
+	if i.entropy.Enabled {
+		highEntropySecrets, err := catalogLocationByEntropy(resolver, location, i.entropy)
+		if err != nil {
+			return nil, internal.ErrPath{Context: "secrets-cataloger", Path: location.RealPath, Err: err}
+		}
+		// strings already matched by a named pattern are not reported again
+		for _, candidate := range highEntropySecrets {
+			if !overlapsAny(candidate, secrets) {
+				secrets = append(secrets, candidate)
 			}
-			secrets[idx].Value = value
 		}
 	}
 
+	var results []SearchResult
+	for _, secret := range secrets {
+		value, err := extractValue(resolver, location, secret.SeekPosition, secret.Length)
+		if err != nil {
+			return nil, err
+		}
+		if i.isAllowlistedValue(value) {
+			continue
+		}
+		if i.revealValues {
+			secret.Value = value
+		} else {
+			secret.Redacted = redactSecretValue(value)
+		}
+		results = append(results, secret)
+	}
+	secrets = results
+
 	// sort by the start location of each secret as it appears in the location
 	sort.SliceStable(secrets, func(i, j int) bool {
 		return secrets[i].SeekPosition < secrets[j].SeekPosition
@@ -103,6 +166,46 @@ func (i *SecretsCataloger) catalogLocation(resolver source.FileResolver, locatio
 	return secrets, nil
 }
 
+func (i *SecretsCataloger) isAllowlistedPath(p string) bool {
+	for _, glob := range i.allowlist.Paths {
+		if matches, err := doublestar.Match(glob, p); err == nil && matches {
+			return true
+		}
+	}
+	return false
+}
+
+func (i *SecretsCataloger) isAllowlistedValue(value string) bool {
+	for _, pattern := range i.allowlist.Values {
+		if pattern.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// overlapsAny indicates if the given secret overlaps with any of the given secrets within the file.
+func overlapsAny(secret SearchResult, secrets []SearchResult) bool {
+	for _, other := range secrets {
+		if secret.SeekPosition < other.SeekPosition+other.Length && other.SeekPosition < secret.SeekPosition+secret.Length {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSecretValue returns a form of the secret value safe to show in a report: only the first few characters of longer
+// values are kept, enough to recognize a known credential without disclosing it.
+func redactSecretValue(value string) string {
+	const mask = "****"
+	value = strings.TrimSpace(value)
+	runes := []rune(value)
+	if len(runes) < 16 {
+		return mask
+	}
+	return string(runes[:4]) + mask
+}
+
 func extractValue(resolver source.FileResolver, location source.Location, start, length int64) (string, error) {
 	readCloser, err := resolver.FileContentsByLocation(location)
 	if err != nil {
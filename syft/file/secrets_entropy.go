@@ -0,0 +1,109 @@
+package file
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/source"
+)
+
+// HighEntropyClassification is the classification of secrets found by entropy scanning (as opposed to a named pattern).
+const HighEntropyClassification = "high-entropy-string"
+
+// candidateTokenPattern matches runs of characters typically found in encoded secrets (base64, base64url, and hex)
+var candidateTokenPattern = regexp.MustCompile(`[A-Za-z0-9+/=_\-]+`)
+
+// EntropyConfig describes how to search for secrets by the randomness (Shannon entropy) of strings, which finds
+// credentials that do not match any known pattern.
+type EntropyConfig struct {
+	Enabled bool
+	// MinLength is the shortest string considered (in bytes)
+	MinLength int
+	// Threshold is the lowest entropy considered a secret (in bits per byte). Note: a string can have at most log2(n)
+	// bits of entropy per byte, where n is the length of the string.
+	Threshold float64
+}
+
+// DefaultEntropyConfig returns the entropy scanning defaults (entropy scanning is disabled by default).
+func DefaultEntropyConfig() EntropyConfig {
+	return EntropyConfig{
+		Enabled:   false,
+		MinLength: 20,
+		Threshold: 4.5,
+	}
+}
+
+func catalogLocationByEntropy(resolver source.FileResolver, location source.Location, cfg EntropyConfig) ([]SearchResult, error) {
+	readCloser, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch reader for location=%q : %w", location, err)
+	}
+	defer internal.CloseAndLogError(readCloser, location.VirtualPath)
+
+	var reader = bufio.NewReader(readCloser)
+	var position int64
+	var results []SearchResult
+	var lineNo int64
+	var readErr error
+	for !errors.Is(readErr, io.EOF) {
+		lineNo++
+		var line []byte
+		line, readErr = reader.ReadBytes('\n')
+		if readErr != nil && readErr != io.EOF {
+			return nil, readErr
+		}
+
+		results = append(results, searchForHighEntropyWithinLine(line, lineNo, position, cfg)...)
+		position += int64(len(line))
+	}
+
+	return results, nil
+}
+
+func searchForHighEntropyWithinLine(line []byte, lineNo, position int64, cfg EntropyConfig) []SearchResult {
+	var results []SearchResult
+	for _, match := range candidateTokenPattern.FindAllIndex(line, -1) {
+		start, stop := match[0], match[1]
+		if stop-start < cfg.MinLength {
+			continue
+		}
+		if shannonEntropy(line[start:stop]) < cfg.Threshold {
+			continue
+		}
+		results = append(results, SearchResult{
+			Classification: HighEntropyClassification,
+			LineNumber:     lineNo,
+			LineOffset:     int64(start),
+			SeekPosition:   position + int64(start),
+			Length:         int64(stop - start),
+		})
+	}
+	return results
+}
+
+// shannonEntropy returns the entropy of the given bytes in bits per byte.
+func shannonEntropy(data []byte) float64 {
+	if len(data) == 0 {
+		return 0
+	}
+	var counts [256]int
+	for _, b := range data {
+		counts[b]++
+	}
+
+	var entropy float64
+	size := float64(len(data))
+	for _, count := range counts {
+		if count == 0 {
+			continue
+		}
+		p := float64(count) / size
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,68 @@
+package file
+
+import (
+	"fmt"
+	"sort"
+)
+
+// SecretsRulePacks are the built-in sets of named secret patterns, grouped by the service (or kind) of credential they
+// detect. Patterns with a named capture group of "value" consider only the captured text as the secret value.
+var SecretsRulePacks = map[string]map[string]string{
+	"aws": {
+		"aws-access-key": `(?i)aws_access_key_id["'=:\s]*?(?P<value>(A3T[A-Z0-9]|AKIA|AGPA|AIDA|AROA|AIPA|ANPA|ANVA|ASIA)[A-Z0-9]{16})`,
+		"aws-secret-key": `(?i)aws_secret_access_key["'=:\s]*?(?P<value>[0-9a-zA-Z/+]{40})`,
+	},
+	"gcp": {
+		"gcp-api-key":             `\b(?P<value>AIza[0-9A-Za-z\-_]{35})\b`,
+		"gcp-service-account-key": `"private_key_id"\s*:\s*"(?P<value>[a-f0-9]{40})"`,
+	},
+	"github": {
+		"github-token":              `\b(?P<value>gh[pousr]_[A-Za-z0-9]{36,251})\b`,
+		"github-fine-grained-token": `\b(?P<value>github_pat_[A-Za-z0-9_]{82})\b`,
+	},
+	"slack": {
+		"slack-token":   `\b(?P<value>xox[abposr]-[0-9A-Za-z-]{10,})`,
+		"slack-webhook": `(?P<value>https://hooks\.slack\.com/services/T[A-Z0-9]+/B[A-Z0-9]+/[A-Za-z0-9]+)`,
+	},
+	"generic": {
+		"pem-private-key":    `-----BEGIN (\S+ )?PRIVATE KEY(\sBLOCK)?-----((?P<value>(\n.*?)+)-----END (\S+ )?PRIVATE KEY(\sBLOCK)?-----)?`,
+		"docker-config-auth": `"auths"((.*\n)*.*?"auth"\s*:\s*"(?P<value>[^"]+)")?`,
+		"generic-api-key":    `(?i)api(-|_)?key["'=:\s]*?(?P<value>[A-Z0-9]{20,60})["']?(\s|$)`,
+	},
+}
+
+// DefaultSecretsPatterns are the named secret patterns from all built-in rule packs.
+var DefaultSecretsPatterns = mustSecretsPatternsFromRulePacks(SecretsRulePackNames())
+
+// SecretsRulePackNames returns the names of all built-in rule packs (sorted).
+func SecretsRulePackNames() []string {
+	names := make([]string, 0, len(SecretsRulePacks))
+	for name := range SecretsRulePacks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SecretsPatternsFromRulePacks returns the named secret patterns from the given built-in rule packs.
+func SecretsPatternsFromRulePacks(names []string) (map[string]string, error) {
+	patterns := make(map[string]string)
+	for _, name := range names {
+		pack, ok := SecretsRulePacks[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown secrets rule pack %q (options: %v)", name, SecretsRulePackNames())
+		}
+		for patternName, pattern := range pack {
+			patterns[patternName] = pattern
+		}
+	}
+	return patterns, nil
+}
+
+func mustSecretsPatternsFromRulePacks(names []string) map[string]string {
+	patterns, err := SecretsPatternsFromRulePacks(names)
+	if err != nil {
+		panic(err)
+	}
+	return patterns
+}
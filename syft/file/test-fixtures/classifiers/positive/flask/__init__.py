@@ -0,0 +1,4 @@
+"""
+A microframework based on Werkzeug.
+"""
+__version__ = "2.2.2"
@@ -0,0 +1,3 @@
+int foo(void);
+
+int main(void) { return foo(); }
@@ -0,0 +1,8 @@
+#include <string.h>
+
+int main(int argc, char **argv) {
+	char buf[16];
+	strncpy(buf, argc > 1 ? argv[1] : "", sizeof(buf) - 1);
+	buf[sizeof(buf) - 1] = 0;
+	return (int)strlen(buf);
+}
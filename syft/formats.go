@@ -3,10 +3,13 @@ package syft
 import (
 	"bytes"
 	"strings"
+	"sync"
 
 	"github.com/anchore/syft/syft/formats/cyclonedxjson"
 	"github.com/anchore/syft/syft/formats/cyclonedxxml"
 	"github.com/anchore/syft/syft/formats/github"
+	"github.com/anchore/syft/syft/formats/html"
+	"github.com/anchore/syft/syft/formats/markdown"
 	"github.com/anchore/syft/syft/formats/spdx22json"
 	"github.com/anchore/syft/syft/formats/spdx22tagvalue"
 	"github.com/anchore/syft/syft/formats/syftjson"
@@ -27,12 +30,18 @@ const (
 	SPDXTagValueFormatID  = spdx22tagvalue.ID
 	SPDXJSONFormatID      = spdx22json.ID
 	TemplateFormatID      = template.ID
+	HTMLFormatID          = html.ID
+	MarkdownFormatID      = markdown.ID
 )
 
-var formats []sbom.Format
+// formatRegistry holds the built-in formats followed by the formats added with RegisterFormat.
+var formatRegistry = struct {
+	sync.RWMutex
+	formats []sbom.Format
+}{}
 
 func init() {
-	formats = []sbom.Format{
+	formatRegistry.formats = []sbom.Format{
 		syftjson.Format(),
 		cyclonedxxml.Format(),
 		cyclonedxjson.Format(),
@@ -42,18 +51,45 @@ func init() {
 		table.Format(),
 		text.Format(),
 		template.Format(),
+		html.Format(),
+		markdown.Format(),
 	}
 }
 
+// RegisterFormat adds a format that can be encoded to (see FormatByID and FormatByName) and identified when decoding
+// (see Decode). Registering a format with the same ID as an already registered format replaces it, including the
+// built-in formats.
+func RegisterFormat(f sbom.Format) {
+	formatRegistry.Lock()
+	defer formatRegistry.Unlock()
+
+	for i, existing := range formatRegistry.formats {
+		if existing.ID() == f.ID() {
+			formatRegistry.formats[i] = f
+			return
+		}
+	}
+	formatRegistry.formats = append(formatRegistry.formats, f)
+}
+
+// Formats returns all registered formats, starting with the built-in formats.
+func Formats() []sbom.Format {
+	formatRegistry.RLock()
+	defer formatRegistry.RUnlock()
+	return append([]sbom.Format(nil), formatRegistry.formats...)
+}
+
+// FormatIDs returns the IDs of all registered formats.
 func FormatIDs() (ids []sbom.FormatID) {
-	for _, f := range formats {
+	for _, f := range Formats() {
 		ids = append(ids, f.ID())
 	}
 	return ids
 }
 
+// FormatByID returns the registered format with the given ID, or nil if there is no such format.
 func FormatByID(id sbom.FormatID) sbom.Format {
-	for _, f := range formats {
+	for _, f := range Formats() {
 		if f.ID() == id {
 			return f
 		}
@@ -61,9 +97,11 @@ func FormatByID(id sbom.FormatID) sbom.Format {
 	return nil
 }
 
+// FormatByName returns the registered format with the given name (its ID or a common alias, ignoring case, dashes, and
+// underscores), or nil if there is no such format.
 func FormatByName(name string) sbom.Format {
 	cleanName := cleanFormatName(name)
-	for _, f := range formats {
+	for _, f := range Formats() {
 		if cleanFormatName(string(f.ID())) == cleanName {
 			return f
 		}
@@ -89,6 +127,10 @@ func FormatByName(name string) sbom.Format {
 		return FormatByID(text.ID)
 	case "template":
 		FormatByID(template.ID)
+	case "html":
+		return FormatByID(html.ID)
+	case "markdown", "md":
+		return FormatByID(markdown.ID)
 	}
 
 	return nil
@@ -99,8 +141,9 @@ func cleanFormatName(name string) string {
 	return strings.ToLower(r.Replace(name))
 }
 
+// IdentifyFormat returns the registered format of the given SBOM document, or nil if the format is not recognized.
 func IdentifyFormat(by []byte) sbom.Format {
-	for _, f := range formats {
+	for _, f := range Formats() {
 		if err := f.Validate(bytes.NewReader(by)); err != nil {
 			continue
 		}
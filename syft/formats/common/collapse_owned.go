@@ -0,0 +1,33 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// CollapseOwnedOption is the format option that leaves out the packages owned by another package by file overlap
+// (e.g. the python packages installed by an rpm), reducing duplicate findings when the SBOM is matched against
+// vulnerabilities.
+const CollapseOwnedOption = "collapse-owned"
+
+// ParseCollapseOwned returns whether the given value of the collapse-owned option enables collapsing owned packages.
+func ParseCollapseOwned(value string) (bool, error) {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s %q: must be true or false", CollapseOwnedOption, value)
+	}
+	return enabled, nil
+}
+
+// CollapseOwnedPackages returns the SBOM without the packages owned by another package by file overlap, moving their
+// relationships to the owning package.
+func CollapseOwnedPackages(s sbom.SBOM) sbom.SBOM {
+	if s.Artifacts.PackageCatalog == nil {
+		return s
+	}
+	s.Artifacts.PackageCatalog, s.Relationships = pkg.CollapseOwnedPackages(s.Artifacts.PackageCatalog, s.Relationships)
+	return s
+}
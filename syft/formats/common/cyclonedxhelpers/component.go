@@ -21,6 +21,11 @@ func encodeComponent(p pkg.Package) cyclonedx.Component {
 	if hasMetadata(p) {
 		props = append(props, encodeProperties(p.Metadata, "syft:metadata")...)
 	}
+	if len(p.Evidence) > 0 {
+		// note: CycloneDX 1.4 component evidence only describes licenses and copyrights, so how the package was
+		// identified is described with properties instead
+		props = append(props, encodeProperties(p.Evidence, "syft:evidence")...)
+	}
 
 	var properties *[]cyclonedx.Property
 	if len(props) > 0 {
@@ -29,6 +34,7 @@ func encodeComponent(p pkg.Package) cyclonedx.Component {
 
 	return cyclonedx.Component{
 		Type:               cyclonedx.ComponentTypeLibrary,
+		Supplier:           encodeSupplier(p),
 		Name:               p.Name,
 		Group:              encodeGroup(p),
 		Version:            p.Version,
@@ -76,6 +82,7 @@ func decodeComponent(c *cyclonedx.Component) *pkg.Package {
 		Licenses:  decodeLicenses(c),
 		CPEs:      decodeCPEs(c),
 		PURL:      c.PackageURL,
+		Evidence:  decodeEvidence(values),
 	}
 
 	common.DecodeInto(p, values, "syft:package", CycloneDXFields)
@@ -104,6 +111,15 @@ func decodeLocations(vals map[string]string) source.LocationSet {
 	return source.NewLocationSet(out...)
 }
 
+func decodeEvidence(vals map[string]string) []pkg.Evidence {
+	v := common.Decode(reflect.TypeOf([]pkg.Evidence{}), vals, "syft:evidence", CycloneDXFields)
+	out, ok := v.([]pkg.Evidence)
+	if !ok || len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
 func decodePackageMetadata(vals map[string]string, c *cyclonedx.Component, typ pkg.MetadataType) interface{} {
 	if typ != "" && c.Properties != nil {
 		metaTyp, ok := pkg.MetadataTypeByName[typ]
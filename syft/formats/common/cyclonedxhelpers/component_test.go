@@ -132,6 +132,33 @@ func Test_encodeComponentProperties(t *testing.T) {
 				{Name: "syft:metadata:sourceRpm", Value: "dive-0.9.2-1.src.rpm"},
 			},
 		},
+		{
+			name: "with evidence",
+			input: pkg.Package{
+				Name:    "busybox",
+				Version: "1.35.0",
+				Type:    pkg.BinaryPkg,
+				Evidence: []pkg.Evidence{
+					{
+						Location:   source.Coordinates{RealPath: "/bin/busybox"},
+						Offset:     1024,
+						Length:     15,
+						Rule:       "busybox-binary",
+						Confidence: 0.6,
+					},
+				},
+				Confidence: pkg.HeuristicConfidence,
+			},
+			expected: &[]cyclonedx.Property{
+				{Name: "syft:package:confidence", Value: "heuristic"},
+				{Name: "syft:package:type", Value: "binary"},
+				{Name: "syft:evidence:0:confidence", Value: "0.600000"},
+				{Name: "syft:evidence:0:length", Value: "15"},
+				{Name: "syft:evidence:0:location:path", Value: "/bin/busybox"},
+				{Name: "syft:evidence:0:offset", Value: "1024"},
+				{Name: "syft:evidence:0:rule", Value: "busybox-binary"},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -241,3 +268,29 @@ func Test_decodeComponent(t *testing.T) {
 		})
 	}
 }
+
+func Test_decodeComponent_evidence(t *testing.T) {
+	evidence := []pkg.Evidence{
+		{
+			Location:   source.Coordinates{RealPath: "/bin/busybox", FileSystemID: "sha256:abc"},
+			Offset:     1024,
+			Length:     15,
+			Rule:       "busybox-binary",
+			Confidence: 0.6,
+		},
+		{
+			Location:   source.Coordinates{RealPath: "/lib/apk/db/installed"},
+			Confidence: 1,
+		},
+	}
+
+	c := encodeComponent(pkg.Package{Name: "busybox", Version: "1.35.0", Evidence: evidence, Confidence: pkg.HeuristicConfidence})
+	p := decodeComponent(&c)
+	assert.Equal(t, evidence, p.Evidence)
+	assert.Equal(t, pkg.HeuristicConfidence, p.Confidence)
+
+	c = encodeComponent(pkg.Package{Name: "busybox", Version: "1.35.0"})
+	p = decodeComponent(&c)
+	assert.Nil(t, p.Evidence)
+	assert.Empty(t, p.Confidence)
+}
@@ -3,10 +3,12 @@ package cyclonedxhelpers
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/CycloneDX/cyclonedx-go"
 
 	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/formats/common"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
@@ -85,6 +87,14 @@ func collectPackages(component *cyclonedx.Component, s *sbom.SBOM, idMap map[str
 	switch component.Type {
 	case cyclonedx.ComponentTypeOS:
 	case cyclonedx.ComponentTypeContainer:
+	case cyclonedx.ComponentTypeFile:
+		coordinates, classifications := decodeFileComponent(component)
+		if len(classifications) > 0 {
+			if s.Artifacts.FileClassifications == nil {
+				s.Artifacts.FileClassifications = make(map[source.Coordinates][]file.Classification)
+			}
+			s.Artifacts.FileClassifications[coordinates] = classifications
+		}
 	case cyclonedx.ComponentTypeApplication, cyclonedx.ComponentTypeFramework, cyclonedx.ComponentTypeLibrary:
 		p := decodeComponent(component)
 		idMap[component.BOMRef] = p
@@ -156,7 +166,11 @@ func linuxReleaseFromOSComponent(component *cyclonedx.Component) *linux.Release
 					rel.SupportURL = ref.URL
 				case "privacyPolicy":
 					rel.PrivacyPolicyURL = ref.URL
+				case "vendor":
+					rel.VendorURL = ref.URL
 				}
+			case cyclonedx.ERTypeDocumentation:
+				rel.DocumentationURL = ref.URL
 			}
 		}
 	}
@@ -167,6 +181,16 @@ func linuxReleaseFromOSComponent(component *cyclonedx.Component) *linux.Release
 			values[p.Name] = p.Value
 		}
 		common.DecodeInto(&rel, values, "syft:distro", CycloneDXFields)
+
+		for name, value := range values {
+			if !strings.HasPrefix(name, distroExtrasPrefix) {
+				continue
+			}
+			if rel.Extras == nil {
+				rel.Extras = make(map[string]string)
+			}
+			rel.Extras[strings.TrimPrefix(name, distroExtrasPrefix)] = value
+		}
 	}
 
 	return rel
@@ -221,6 +245,7 @@ func extractComponents(meta *cyclonedx.Metadata) source.Metadata {
 
 	switch c.Type {
 	case cyclonedx.ComponentTypeContainer:
+		decodeImageProperties(c, &image)
 		return source.Metadata{
 			Scheme:        source.ImageScheme,
 			ImageMetadata: image,
@@ -0,0 +1,72 @@
+package cyclonedxhelpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/syft/formats/common"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+// OwnershipOverlapOption is the format option that sets how package ownership by file overlap (a package installing
+// the files another package was found by, see artifact.OwnershipByFileOverlapRelationship) is expressed in the
+// dependency graph.
+const OwnershipOverlapOption = "ownership-overlap"
+
+// OwnershipOverlapMapping is how package ownership by file overlap is expressed in the dependency graph.
+type OwnershipOverlapMapping string
+
+const (
+	// OwnershipOverlapAsNone leaves out the ownership relationships (the default).
+	OwnershipOverlapAsNone OwnershipOverlapMapping = "none"
+	// OwnershipOverlapAsDependency makes the owner component depend on the owned component.
+	OwnershipOverlapAsDependency OwnershipOverlapMapping = "dependency"
+)
+
+// AllOwnershipOverlapMappings are the supported ways of expressing package ownership by file overlap.
+var AllOwnershipOverlapMappings = []OwnershipOverlapMapping{
+	OwnershipOverlapAsNone,
+	OwnershipOverlapAsDependency,
+}
+
+// ParseOwnershipOverlapMapping returns the mapping of package ownership by file overlap described by the given string.
+func ParseOwnershipOverlapMapping(value string) (OwnershipOverlapMapping, error) {
+	for _, m := range AllOwnershipOverlapMappings {
+		if strings.EqualFold(value, string(m)) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("invalid %s %q (options: %v)", OwnershipOverlapOption, value, AllOwnershipOverlapMappings)
+}
+
+// EncoderConfig is how an SBOM is encoded as a CycloneDX document, shared by the JSON and XML formats.
+type EncoderConfig struct {
+	// OwnershipOverlap is how package ownership by file overlap is expressed (left out when empty)
+	OwnershipOverlap OwnershipOverlapMapping
+	// CollapseOwned leaves out the packages owned by another package by file overlap
+	CollapseOwned bool
+}
+
+// WithOptions returns the config with the given options of the given format set, supporting "ownership-overlap"
+// (how package ownership by file overlap is expressed) and "collapse-owned" (leave out owned packages).
+func (cfg EncoderConfig) WithOptions(id sbom.FormatID, options map[string]string) (EncoderConfig, error) {
+	for key, value := range options {
+		switch key {
+		case OwnershipOverlapOption:
+			mapping, err := ParseOwnershipOverlapMapping(value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.OwnershipOverlap = mapping
+		case common.CollapseOwnedOption:
+			collapse, err := common.ParseCollapseOwned(value)
+			if err != nil {
+				return cfg, err
+			}
+			cfg.CollapseOwned = collapse
+		default:
+			return cfg, fmt.Errorf("unsupported option for %s format: %q", id, key)
+		}
+	}
+	return cfg, nil
+}
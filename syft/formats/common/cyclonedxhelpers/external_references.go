@@ -75,12 +75,49 @@ func encodeExternalReferences(p pkg.Package) *[]cyclonedx.ExternalReference {
 			}
 		}
 	}
+	refs = append(refs, encodeRegistryReferences(p, refs)...)
 	if len(refs) > 0 {
 		return &refs
 	}
 	return nil
 }
 
+// registryRefCommentPrefix marks the references looked up from a registry (instead of found within the package
+// metadata), so the references are not decoded into the package metadata.
+const registryRefCommentPrefix = "registry: "
+
+// encodeRegistryReferences describes the homepage and repository of the package as looked up from a registry, unless
+// the package metadata already provided a reference of the same type.
+func encodeRegistryReferences(p pkg.Package, existing []cyclonedx.ExternalReference) []cyclonedx.ExternalReference {
+	if p.Registry == nil {
+		return nil
+	}
+	has := func(typ cyclonedx.ExternalReferenceType) bool {
+		for _, r := range existing {
+			if r.Type == typ {
+				return true
+			}
+		}
+		return false
+	}
+	var refs []cyclonedx.ExternalReference
+	if p.Registry.Homepage != "" && !has(cyclonedx.ERTypeWebsite) {
+		refs = append(refs, cyclonedx.ExternalReference{
+			URL:     p.Registry.Homepage,
+			Type:    cyclonedx.ERTypeWebsite,
+			Comment: registryRefCommentPrefix + p.Registry.Registry,
+		})
+	}
+	if p.Registry.Repository != "" && !has(cyclonedx.ERTypeVCS) {
+		refs = append(refs, cyclonedx.ExternalReference{
+			URL:     p.Registry.Repository,
+			Type:    cyclonedx.ERTypeVCS,
+			Comment: registryRefCommentPrefix + p.Registry.Registry,
+		})
+	}
+	return refs
+}
+
 // supported algorithm in cycloneDX as of 1.4
 // "MD5", "SHA-1", "SHA-256", "SHA-384", "SHA-512",
 // "SHA3-256", "SHA3-384", "SHA3-512", "BLAKE2b-256", "BLAKE2b-384", "BLAKE2b-512", "BLAKE3"
@@ -136,7 +173,7 @@ func decodeExternalReferences(c *cyclonedx.Component, metadata interface{}) {
 func findExternalRef(c *cyclonedx.Component, typ cyclonedx.ExternalReferenceType) *cyclonedx.ExternalReference {
 	if c.ExternalReferences != nil {
 		for _, r := range *c.ExternalReferences {
-			if r.Type == typ {
+			if r.Type == typ && !strings.HasPrefix(r.Comment, registryRefCommentPrefix) {
 				return &r
 			}
 		}
@@ -125,6 +125,23 @@ func Test_encodeExternalReferences(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "from registry",
+			input: pkg.Package{
+				Metadata: pkg.NpmPackageJSONMetadata{
+					Homepage: "http://a-place.gov",
+				},
+				Registry: &pkg.RegistryInfo{
+					Registry:   "https://registry.npmjs.org",
+					Homepage:   "http://another-place.gov",
+					Repository: "https://github.com/a/repo.git",
+				},
+			},
+			expected: &[]cyclonedx.ExternalReference{
+				{URL: "http://a-place.gov", Type: cyclonedx.ERTypeWebsite},
+				{URL: "https://github.com/a/repo.git", Type: cyclonedx.ERTypeVCS, Comment: "registry: https://registry.npmjs.org"},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,115 @@
+package cyclonedxhelpers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/formats/common"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+const fileClassificationPropertyPrefix = "syft:file:classification"
+
+// toFileComponents returns a file component for each classified file. CycloneDX has no dedicated fields for file
+// classifications, so each classification is described with properties.
+func toFileComponents(s sbom.SBOM) []cyclonedx.Component {
+	coordinates := make([]source.Coordinates, 0, len(s.Artifacts.FileClassifications))
+	for c := range s.Artifacts.FileClassifications {
+		coordinates = append(coordinates, c)
+	}
+	sort.SliceStable(coordinates, func(i, j int) bool {
+		if coordinates[i].RealPath == coordinates[j].RealPath {
+			return coordinates[i].FileSystemID < coordinates[j].FileSystemID
+		}
+		return coordinates[i].RealPath < coordinates[j].RealPath
+	})
+
+	components := make([]cyclonedx.Component, 0, len(coordinates))
+	for _, c := range coordinates {
+		components = append(components, encodeFileComponent(c, s.Artifacts.FileClassifications[c]))
+	}
+	return components
+}
+
+func encodeFileComponent(coordinates source.Coordinates, classifications []file.Classification) cyclonedx.Component {
+	props := encodeProperties(coordinates, "syft:file")
+	props = append(props, encodeClassifications(classifications)...)
+
+	var properties *[]cyclonedx.Property
+	if len(props) > 0 {
+		properties = &props
+	}
+
+	return cyclonedx.Component{
+		BOMRef:     string(coordinates.ID()),
+		Type:       cyclonedx.ComponentTypeFile,
+		Name:       coordinates.RealPath,
+		Properties: properties,
+	}
+}
+
+// encodeClassifications describes classifications as properties, e.g. "syft:file:classification:0:class" and
+// "syft:file:classification:0:metadata:version".
+func encodeClassifications(classifications []file.Classification) (out []cyclonedx.Property) {
+	for idx, classification := range classifications {
+		prefix := fmt.Sprintf("%s:%d", fileClassificationPropertyPrefix, idx)
+		out = append(out, cyclonedx.Property{
+			Name:  prefix + ":class",
+			Value: classification.Class,
+		})
+		for _, p := range common.Sorted(classification.Metadata) {
+			out = append(out, cyclonedx.Property{
+				Name:  prefix + ":metadata:" + p.Name,
+				Value: p.Value,
+			})
+		}
+	}
+	return out
+}
+
+func decodeFileComponent(c *cyclonedx.Component) (source.Coordinates, []file.Classification) {
+	values := map[string]string{}
+	if c.Properties != nil {
+		for _, p := range *c.Properties {
+			values[p.Name] = p.Value
+		}
+	}
+
+	var coordinates source.Coordinates
+	common.DecodeInto(&coordinates, values, "syft:file", CycloneDXFields)
+	if coordinates.RealPath == "" {
+		coordinates.RealPath = c.Name
+	}
+
+	return coordinates, decodeClassifications(values)
+}
+
+func decodeClassifications(values map[string]string) []file.Classification {
+	var classifications []file.Classification
+	for idx := 0; ; idx++ {
+		prefix := fmt.Sprintf("%s:%d", fileClassificationPropertyPrefix, idx)
+		class, ok := values[prefix+":class"]
+		if !ok {
+			break
+		}
+
+		metadataPrefix := prefix + ":metadata:"
+		metadata := make(map[string]string)
+		for name, value := range values {
+			if strings.HasPrefix(name, metadataPrefix) {
+				metadata[strings.TrimPrefix(name, metadataPrefix)] = value
+			}
+		}
+
+		classifications = append(classifications, file.Classification{
+			Class:    class,
+			Metadata: metadata,
+		})
+	}
+	return classifications
+}
@@ -0,0 +1,74 @@
+package cyclonedxhelpers
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func Test_toFileComponents(t *testing.T) {
+	python := source.Coordinates{
+		RealPath:     "/usr/bin/python3.9",
+		FileSystemID: "sha256:abc",
+	}
+	script := source.Coordinates{
+		RealPath: "/usr/local/bin/run",
+	}
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			FileClassifications: map[source.Coordinates][]file.Classification{
+				script: {
+					{
+						Class: "interpreter-script",
+						Metadata: map[string]string{
+							"interpreter": "sh",
+						},
+					},
+				},
+				python: {
+					{
+						Class: "python-binary",
+						Metadata: map[string]string{
+							"version": "3.9.2",
+						},
+					},
+					{
+						Class: "elf-binary",
+						Metadata: map[string]string{
+							"mimeType": "application/x-executable",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	components := toFileComponents(s)
+	require.Len(t, components, 2)
+
+	assert.Equal(t, cyclonedx.Component{
+		BOMRef: string(python.ID()),
+		Type:   cyclonedx.ComponentTypeFile,
+		Name:   "/usr/bin/python3.9",
+		Properties: &[]cyclonedx.Property{
+			{Name: "syft:file:layerID", Value: "sha256:abc"},
+			{Name: "syft:file:path", Value: "/usr/bin/python3.9"},
+			{Name: "syft:file:classification:0:class", Value: "python-binary"},
+			{Name: "syft:file:classification:0:metadata:version", Value: "3.9.2"},
+			{Name: "syft:file:classification:1:class", Value: "elf-binary"},
+			{Name: "syft:file:classification:1:metadata:mimeType", Value: "application/x-executable"},
+		},
+	}, components[0])
+	assert.Equal(t, "/usr/local/bin/run", components[1].Name)
+
+	for i := range components {
+		coordinates, classifications := decodeFileComponent(&components[i])
+		assert.Equal(t, s.Artifacts.FileClassifications[coordinates], classifications)
+	}
+}
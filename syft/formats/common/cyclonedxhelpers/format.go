@@ -1,6 +1,7 @@
 package cyclonedxhelpers
 
 import (
+	"strings"
 	"time"
 
 	"github.com/CycloneDX/cyclonedx-go"
@@ -9,12 +10,22 @@ import (
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/formats/common"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
 
 func ToFormatModel(s sbom.SBOM) *cyclonedx.BOM {
+	return ToFormatModelWithConfig(s, EncoderConfig{})
+}
+
+// ToFormatModelWithConfig creates the CycloneDX document describing the given SBOM as set by the given config.
+func ToFormatModelWithConfig(s sbom.SBOM, cfg EncoderConfig) *cyclonedx.BOM {
+	if cfg.CollapseOwned {
+		s = common.CollapseOwnedPackages(s)
+	}
+
 	cdxBOM := cyclonedx.NewBOM()
 
 	// NOTE(jonasagx): cycloneDX requires URN uuids (URN returns the RFC 2141 URN form of uuid):
@@ -29,9 +40,10 @@ func ToFormatModel(s sbom.SBOM) *cyclonedx.BOM {
 		components[i] = encodeComponent(p)
 	}
 	components = append(components, toOSComponent(s.Artifacts.LinuxDistribution)...)
+	components = append(components, toFileComponents(s)...)
 	cdxBOM.Components = &components
 
-	dependencies := toDependencies(s.Relationships)
+	dependencies := toDependencies(s.Relationships, cfg.OwnershipOverlap)
 	if len(dependencies) > 0 {
 		cdxBOM.Dependencies = &dependencies
 	}
@@ -39,6 +51,9 @@ func ToFormatModel(s sbom.SBOM) *cyclonedx.BOM {
 	return cdxBOM
 }
 
+// distroExtrasPrefix is the prefix of the properties describing the os-release fields without a Release field
+const distroExtrasPrefix = "syft:distro:extras:"
+
 func toOSComponent(distro *linux.Release) []cyclonedx.Component {
 	if distro == nil {
 		return []cyclonedx.Component{}
@@ -70,10 +85,29 @@ func toOSComponent(distro *linux.Release) []cyclonedx.Component {
 			Comment: "privacyPolicy",
 		})
 	}
+	if distro.VendorURL != "" {
+		*eRefs = append(*eRefs, cyclonedx.ExternalReference{
+			URL:     distro.VendorURL,
+			Type:    cyclonedx.ERTypeOther,
+			Comment: "vendor",
+		})
+	}
+	if distro.DocumentationURL != "" {
+		*eRefs = append(*eRefs, cyclonedx.ExternalReference{
+			URL:  distro.DocumentationURL,
+			Type: cyclonedx.ERTypeDocumentation,
+		})
+	}
 	if len(*eRefs) == 0 {
 		eRefs = nil
 	}
 	props := encodeProperties(distro, "syft:distro")
+	for _, p := range common.Sorted(distro.Extras) {
+		props = append(props, cyclonedx.Property{
+			Name:  distroExtrasPrefix + p.Name,
+			Value: p.Value,
+		})
+	}
 	var properties *[]cyclonedx.Property
 	if len(props) > 0 {
 		properties = &props
@@ -117,7 +151,8 @@ func toBomDescriptor(name, version string, srcMetadata source.Metadata) *cyclone
 // NOTE: CycloneDX provides the ability to describe components and their dependency on other components.
 // The dependency graph is capable of representing both direct and transitive relationships.
 // If a relationship is either direct or transitive it can be included in this function.
-// An example of a relationship to not include would be: OwnershipByFileOverlapRelationship.
+// An example of a relationship to not include would be: OwnershipByFileOverlapRelationship (unless the ownership is
+// expressed as a dependency, see toDependencies).
 func isExpressiblePackageRelationship(ty artifact.RelationshipType) bool {
 	switch ty {
 	case artifact.RuntimeDependencyOfRelationship:
@@ -132,9 +167,18 @@ func isExpressiblePackageRelationship(ty artifact.RelationshipType) bool {
 	return false
 }
 
-func toDependencies(relationships []artifact.Relationship) []cyclonedx.Dependency {
+func toDependencies(relationships []artifact.Relationship, ownershipOverlap OwnershipOverlapMapping) []cyclonedx.Dependency {
 	result := make([]cyclonedx.Dependency, 0)
 	for _, r := range relationships {
+		if r.Type == artifact.OwnershipByFileOverlapRelationship && ownershipOverlap == OwnershipOverlapAsDependency {
+			// the owner depends on the package it installed
+			result = append(result, cyclonedx.Dependency{
+				Ref:          string(r.From.ID()),
+				Dependencies: &[]cyclonedx.Dependency{{Ref: string(r.To.ID())}},
+			})
+			continue
+		}
+
 		exists := isExpressiblePackageRelationship(r.Type)
 		if !exists {
 			log.Debugf("unable to convert relationship from CycloneDX 1.4 JSON, dropping: %+v", r)
@@ -158,23 +202,108 @@ func toBomDescriptorComponent(srcMetadata source.Metadata) *cyclonedx.Component
 		if err != nil {
 			log.Warnf("unable to get fingerprint of image metadata=%s: %+v", srcMetadata.ImageMetadata.ID, err)
 		}
-		return &cyclonedx.Component{
+		component := &cyclonedx.Component{
 			BOMRef:  string(bomRef),
 			Type:    cyclonedx.ComponentTypeContainer,
 			Name:    srcMetadata.ImageMetadata.UserInput,
 			Version: srcMetadata.ImageMetadata.ManifestDigest,
 		}
+		encodeImageProperties(component, srcMetadata.ImageMetadata)
+		if provenance := srcMetadata.ImageMetadata.Provenance; provenance != nil {
+			encodeProvenance(component, *provenance)
+		}
+		return component
 	case source.DirectoryScheme, source.FileScheme:
 		bomRef, err := artifact.IDByHash(srcMetadata.Path)
 		if err != nil {
 			log.Warnf("unable to get fingerprint of source metadata path=%s: %+v", srcMetadata.Path, err)
 		}
-		return &cyclonedx.Component{
+		component := &cyclonedx.Component{
 			BOMRef: string(bomRef),
 			Type:   cyclonedx.ComponentTypeFile,
 			Name:   srcMetadata.Path,
 		}
+		if srcMetadata.Git != nil {
+			// the commit cataloged is the most precise version of a repository
+			component.Version = srcMetadata.Git.Commit
+		}
+		return component
 	}
 
 	return nil
 }
+
+// imageProperties are the details of an image (beyond its name and manifest digest) that are carried as properties of
+// the image component.
+type imageProperties struct {
+	MediaType       string   `json:"mediaType"`
+	ConfigMediaType string   `json:"configMediaType"`
+	Tags            []string `json:"tags"`
+	RepoDigests     []string `json:"repoDigests"`
+	RegistryHost    string   `json:"registryHost"`
+}
+
+const imagePropertyPrefix = "syft:image"
+
+func encodeImageProperties(component *cyclonedx.Component, metadata source.ImageMetadata) {
+	props := imageProperties{
+		MediaType:       metadata.MediaType,
+		ConfigMediaType: metadata.ConfigMediaType,
+		Tags:            metadata.Tags,
+		RepoDigests:     metadata.RepoDigests,
+		RegistryHost:    metadata.RegistryHost,
+	}
+	appendProperties(component, common.Encode(props, imagePropertyPrefix, common.OptionalJSONTag))
+}
+
+func decodeImageProperties(component *cyclonedx.Component, metadata *source.ImageMetadata) {
+	if component.Properties == nil {
+		return
+	}
+	values := map[string]string{}
+	for _, p := range *component.Properties {
+		values[p.Name] = p.Value
+	}
+	var props imageProperties
+	common.DecodeInto(&props, values, imagePropertyPrefix, common.OptionalJSONTag)
+
+	metadata.MediaType = props.MediaType
+	metadata.ConfigMediaType = props.ConfigMediaType
+	metadata.Tags = props.Tags
+	metadata.RepoDigests = props.RepoDigests
+	metadata.RegistryHost = props.RegistryHost
+}
+
+// appendProperties adds the given (encoded) properties to the component in a stable order.
+func appendProperties(component *cyclonedx.Component, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	var props []cyclonedx.Property
+	if component.Properties != nil {
+		props = *component.Properties
+	}
+	for _, p := range common.Sorted(values) {
+		props = append(props, cyclonedx.Property{
+			Name:  p.Name,
+			Value: p.Value,
+		})
+	}
+	component.Properties = &props
+}
+
+// encodeProvenance describes how the image was built (as attested by its SLSA provenance) with the properties of the
+// image component, referring to the source repository the image was built from.
+func encodeProvenance(component *cyclonedx.Component, provenance source.ProvenanceMetadata) {
+	appendProperties(component, common.Encode(provenance, "syft:image:provenance", common.OptionalJSONTag))
+
+	if provenance.SourceRepository != "" {
+		component.ExternalReferences = &[]cyclonedx.ExternalReference{
+			{
+				URL:     strings.TrimPrefix(provenance.SourceRepository, "git+"),
+				Comment: provenance.SourceDigest,
+				Type:    cyclonedx.ERTypeVCS,
+			},
+		}
+	}
+}
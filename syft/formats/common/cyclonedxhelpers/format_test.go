@@ -0,0 +1,149 @@
+package cyclonedxhelpers
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func Test_toOSComponent(t *testing.T) {
+	distro := &linux.Release{
+		ID:               "flatcar",
+		VersionID:        "3510.2.1",
+		VendorName:       "Kinvolk",
+		VendorURL:        "https://kinvolk.io/",
+		DocumentationURL: "https://flatcar.org/docs/",
+		Extras: map[string]string{
+			"ANSI_COLOR":    "38;5;75",
+			"FLATCAR_BOARD": "amd64-usr",
+		},
+	}
+
+	components := toOSComponent(distro)
+	require.Len(t, components, 1)
+	require.NotNil(t, components[0].Properties)
+	assert.Contains(t, *components[0].Properties, cyclonedx.Property{Name: "syft:distro:extras:FLATCAR_BOARD", Value: "amd64-usr"})
+	assert.Contains(t, *components[0].Properties, cyclonedx.Property{Name: "syft:distro:vendorName", Value: "Kinvolk"})
+
+	// all fields survive decoding
+	decoded := linuxReleaseFromOSComponent(&components[0])
+	require.NotNil(t, decoded)
+	assert.Equal(t, distro.VendorName, decoded.VendorName)
+	assert.Equal(t, distro.VendorURL, decoded.VendorURL)
+	assert.Equal(t, distro.DocumentationURL, decoded.DocumentationURL)
+	assert.Equal(t, distro.Extras, decoded.Extras)
+}
+
+func Test_toBomDescriptorComponent_image(t *testing.T) {
+	image := source.ImageMetadata{
+		UserInput:       "docker.io/library/alpine:3.17",
+		ID:              "sha256:b2aa39c304c27b96c1fef0c06bee651ac9241d49c4fe34381cab8453f9a89c7d",
+		ManifestDigest:  "sha256:93d5a28ff72d288d69b5997b8ba47396d2cbb62a72b5d87cd3351094b5d578a0",
+		MediaType:       "application/vnd.docker.distribution.manifest.v2+json",
+		ConfigMediaType: "application/vnd.docker.container.image.v1+json",
+		Tags:            []string{"alpine:3.17", "alpine:latest"},
+		RepoDigests:     []string{"alpine@sha256:f271e74b17ced29b915d351685fd4644785c6d1559dd1f2d4189a5e851ef753a"},
+		RegistryHost:    "index.docker.io",
+	}
+
+	component := toBomDescriptorComponent(source.Metadata{Scheme: source.ImageScheme, ImageMetadata: image})
+	require.NotNil(t, component)
+	require.NotNil(t, component.Properties)
+	assert.Contains(t, *component.Properties, cyclonedx.Property{Name: "syft:image:tags:1", Value: "alpine:latest"})
+	assert.Contains(t, *component.Properties, cyclonedx.Property{Name: "syft:image:registryHost", Value: "index.docker.io"})
+
+	// all image details survive decoding
+	decoded := extractComponents(&cyclonedx.Metadata{Component: component})
+	assert.Equal(t, source.ImageScheme, decoded.Scheme)
+	assert.Equal(t, image.MediaType, decoded.ImageMetadata.MediaType)
+	assert.Equal(t, image.ConfigMediaType, decoded.ImageMetadata.ConfigMediaType)
+	assert.Equal(t, image.Tags, decoded.ImageMetadata.Tags)
+	assert.Equal(t, image.RepoDigests, decoded.ImageMetadata.RepoDigests)
+	assert.Equal(t, image.RegistryHost, decoded.ImageMetadata.RegistryHost)
+}
+
+func Test_toDependencies_ownershipOverlap(t *testing.T) {
+	owner := pkg.Package{Name: "python3-requests", Version: "2.25.1"}
+	owner.SetID()
+	owned := pkg.Package{Name: "requests", Version: "2.25.1"}
+	owned.SetID()
+	dependency := pkg.Package{Name: "urllib3", Version: "1.26.5"}
+	dependency.SetID()
+
+	relationships := []artifact.Relationship{
+		{From: owner, To: owned, Type: artifact.OwnershipByFileOverlapRelationship},
+		{From: dependency, To: owned, Type: artifact.DependencyOfRelationship},
+	}
+
+	tests := []struct {
+		mapping  OwnershipOverlapMapping
+		expected []cyclonedx.Dependency
+	}{
+		{
+			mapping: OwnershipOverlapAsNone,
+			expected: []cyclonedx.Dependency{
+				{Ref: string(owned.ID()), Dependencies: &[]cyclonedx.Dependency{{Ref: string(dependency.ID())}}},
+			},
+		},
+		{
+			mapping: OwnershipOverlapAsDependency,
+			expected: []cyclonedx.Dependency{
+				{Ref: string(owner.ID()), Dependencies: &[]cyclonedx.Dependency{{Ref: string(owned.ID())}}},
+				{Ref: string(owned.ID()), Dependencies: &[]cyclonedx.Dependency{{Ref: string(dependency.ID())}}},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.mapping), func(t *testing.T) {
+			assert.Equal(t, test.expected, toDependencies(relationships, test.mapping))
+		})
+	}
+}
+
+func TestEncoderConfig_WithOptions(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  map[string]string
+		expected EncoderConfig
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name: "no options",
+		},
+		{
+			name:     "ownership overlap and collapse owned",
+			options:  map[string]string{"ownership-overlap": "dependency", "collapse-owned": "true"},
+			expected: EncoderConfig{OwnershipOverlap: OwnershipOverlapAsDependency, CollapseOwned: true},
+		},
+		{
+			name:    "invalid ownership overlap",
+			options: map[string]string{"ownership-overlap": "contains"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "unsupported option",
+			options: map[string]string{"namespace": "https://example.com/sboms"},
+			wantErr: require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			cfg, err := EncoderConfig{}.WithOptions("cyclonedx-1-json", test.options)
+			test.wantErr(t, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, test.expected, cfg)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package cyclonedxhelpers
 
 import (
+	"strings"
+
 	"github.com/CycloneDX/cyclonedx-go"
 
 	"github.com/anchore/syft/internal/spdxlicense"
@@ -8,15 +10,38 @@ import (
 )
 
 func encodeLicenses(p pkg.Package) *cyclonedx.Licenses {
+	// CycloneDX does not distinguish declared from concluded licenses, so the concluded licenses are only used when
+	// the package metadata lacks any license
+	licenses := p.DeclaredLicenses()
+	if len(licenses) == 0 {
+		licenses = p.ConcludedLicenses()
+	}
+
 	lc := cyclonedx.Licenses{}
-	for _, licenseName := range p.Licenses {
-		if value, exists := spdxlicense.ID(licenseName); exists {
+	seen := make(map[string]struct{})
+	for _, l := range licenses {
+		if _, exists := seen[l.SPDXExpression]; exists || l.SPDXExpression == "" {
+			continue
+		}
+		seen[l.SPDXExpression] = struct{}{}
+
+		expression, err := spdxlicense.ParseExpression(l.SPDXExpression)
+		if err != nil {
+			continue
+		}
+		if expression.IsLicenseID() {
 			lc = append(lc, cyclonedx.LicenseChoice{
 				License: &cyclonedx.License{
-					ID: value,
+					ID:  expression.License,
+					URL: l.URL,
 				},
 			})
+			continue
 		}
+		// compound licenses, exceptions, and license references can only be described by an expression
+		lc = append(lc, cyclonedx.LicenseChoice{
+			Expression: expression.String(),
+		})
 	}
 	if len(lc) > 0 {
 		return &lc
@@ -24,11 +49,20 @@ func encodeLicenses(p pkg.Package) *cyclonedx.Licenses {
 	return nil
 }
 
-func decodeLicenses(c *cyclonedx.Component) (out []string) {
+func decodeLicenses(c *cyclonedx.Component) (out []pkg.License) {
 	if c.Licenses != nil {
 		for _, l := range *c.Licenses {
 			if l.License != nil {
-				out = append(out, l.License.ID)
+				value := l.License.ID
+				if value == "" {
+					value = l.License.Name
+				}
+				if strings.TrimSpace(value) != "" {
+					out = append(out, pkg.NewLicenseFromURL(value, l.License.URL))
+				}
+			}
+			if l.Expression != "" {
+				out = append(out, pkg.NewLicense(l.Expression))
 			}
 		}
 	}
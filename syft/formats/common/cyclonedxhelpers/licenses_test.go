@@ -23,18 +23,18 @@ func Test_encodeLicense(t *testing.T) {
 		{
 			name: "no SPDX licenses",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"made-up",
-				},
+				),
 			},
 			expected: nil,
 		},
 		{
 			name: "with SPDX license",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"MIT",
-				},
+				),
 			},
 			expected: &cyclonedx.Licenses{
 				{License: &cyclonedx.License{ID: "MIT"}},
@@ -43,10 +43,10 @@ func Test_encodeLicense(t *testing.T) {
 		{
 			name: "with SPDX license expression",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"MIT",
 					"GPL-3.0",
-				},
+				),
 			},
 			expected: &cyclonedx.Licenses{
 				{License: &cyclonedx.License{ID: "MIT"}},
@@ -56,20 +56,67 @@ func Test_encodeLicense(t *testing.T) {
 		{
 			name: "cap insensitive",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"gpl-3.0",
-				},
+				),
 			},
 			expected: &cyclonedx.Licenses{
 				{License: &cyclonedx.License{ID: "GPL-3.0-only"}},
 			},
 		},
+		{
+			name: "alias to spdx conversion",
+			input: pkg.Package{
+				Licenses: pkg.NewLicensesFromValues(
+					"GPLv2",
+				),
+			},
+			expected: &cyclonedx.Licenses{
+				{License: &cyclonedx.License{ID: "GPL-2.0-only"}},
+			},
+		},
+		{
+			name: "compound expression",
+			input: pkg.Package{
+				Licenses: pkg.NewLicensesFromValues(
+					"MIT",
+					"bsd-3-clause or apache-2.0",
+				),
+			},
+			expected: &cyclonedx.Licenses{
+				{License: &cyclonedx.License{ID: "MIT"}},
+				{Expression: "BSD-3-Clause OR Apache-2.0"},
+			},
+		},
+		{
+			name: "with license URL",
+			input: pkg.Package{
+				Licenses: []pkg.License{
+					pkg.NewLicenseFromURL("ISC", "https://opensource.org/licenses/ISC"),
+				},
+			},
+			expected: &cyclonedx.Licenses{
+				{License: &cyclonedx.License{ID: "ISC", URL: "https://opensource.org/licenses/ISC"}},
+			},
+		},
+		{
+			name: "concluded licenses when none are declared",
+			input: pkg.Package{
+				Licenses: []pkg.License{
+					{Value: "MIT", SPDXExpression: "MIT", Type: pkg.ConcludedLicense, Confidence: 1},
+					{Value: "MIT", SPDXExpression: "MIT", Type: pkg.ConcludedLicense, Confidence: 0.9},
+				},
+			},
+			expected: &cyclonedx.Licenses{
+				{License: &cyclonedx.License{ID: "MIT"}},
+			},
+		},
 		{
 			name: "debian to spdx conversion",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"GPL-2",
-				},
+				),
 			},
 			expected: &cyclonedx.Licenses{
 				{License: &cyclonedx.License{ID: "GPL-2.0-only"}},
@@ -0,0 +1,17 @@
+package cyclonedxhelpers
+
+import (
+	"github.com/CycloneDX/cyclonedx-go"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// encodeSupplier describes the person that published the package to a registry (as looked up when enriching packages).
+func encodeSupplier(p pkg.Package) *cyclonedx.OrganizationalEntity {
+	if p.Registry == nil || p.Registry.Supplier == "" {
+		return nil
+	}
+	return &cyclonedx.OrganizationalEntity{
+		Name: p.Registry.Supplier,
+	}
+}
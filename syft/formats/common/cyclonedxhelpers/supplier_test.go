@@ -0,0 +1,50 @@
+package cyclonedxhelpers
+
+import (
+	"testing"
+
+	"github.com/CycloneDX/cyclonedx-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func Test_encodeSupplier(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected *cyclonedx.OrganizationalEntity
+	}{
+		{
+			name:     "no registry details",
+			input:    pkg.Package{},
+			expected: nil,
+		},
+		{
+			name: "no supplier",
+			input: pkg.Package{
+				Registry: &pkg.RegistryInfo{
+					Registry: "https://crates.io",
+				},
+			},
+			expected: nil,
+		},
+		{
+			name: "from registry",
+			input: pkg.Package{
+				Registry: &pkg.RegistryInfo{
+					Registry: "https://crates.io",
+					Supplier: "someone",
+				},
+			},
+			expected: &cyclonedx.OrganizationalEntity{
+				Name: "someone",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, encodeSupplier(test.input))
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/schema"
+	"github.com/anchore/syft/syft/formats/cyclonedxjson"
+	"github.com/anchore/syft/syft/formats/spdx22json"
+	"github.com/anchore/syft/syft/formats/syftjson"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+const (
+	spdxVersion      = "SPDX-2.2"
+	cyclonedxVersion = "1.4"
+
+	// the CycloneDX schema references the SPDX license list and JSON signature schemas, which are not embedded: any
+	// license ID and signature object are accepted instead.
+	cyclonedxSPDXSchema      = `{"$id": "http://cyclonedx.org/schema/spdx.schema.json", "type": "string"}`
+	cyclonedxSignatureSchema = `{"$id": "http://cyclonedx.org/schema/jsf-0.82.schema.json", "definitions": {"signature": {"type": "object"}}}`
+
+	// the SPDX 2.2 schema describes the document as the "Document" property of the root object rather than as the root
+	// object itself, so documents are checked against that property alone.
+	spdxDocumentSchema = `{"$ref": "http://spdx.org/rdf/terms#/properties/Document"}`
+)
+
+// Violation is a part of a document that does not conform to the JSON schema of its format.
+type Violation struct {
+	// Pointer is the JSON pointer (RFC 6901) to the offending value, where "" is the whole document.
+	Pointer string `json:"pointer"`
+	// Message describes why the value does not conform to the schema.
+	Message string `json:"message"`
+}
+
+func (v Violation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+	return fmt.Sprintf("%s: %s", pointer, v.Message)
+}
+
+var schemas = struct {
+	sync.Mutex
+	compiled map[sbom.FormatID]*gojsonschema.Schema
+}{
+	compiled: make(map[sbom.FormatID]*gojsonschema.Schema),
+}
+
+// Validate checks the given document against the JSON schema of the given format, returning every violation found.
+// Documents of formats without an embedded JSON schema (e.g. XML or tag-value) or of a schema version other than the
+// embedded one are not checked.
+func Validate(id sbom.FormatID, document []byte) ([]Violation, error) {
+	supported, err := isSupported(id, document)
+	if err != nil || !supported {
+		return nil, err
+	}
+
+	s, err := compiledSchema(id)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.Validate(gojsonschema.NewBytesLoader(document))
+	if err != nil {
+		return nil, fmt.Errorf("unable to validate %s document: %w", id, err)
+	}
+
+	var violations []Violation
+	for _, e := range result.Errors() {
+		p := pointer(e.Context())
+		if property, ok := e.Details()["property"].(string); ok && e.Type() == "required" {
+			// point at the missing property rather than the object missing it
+			p += "/" + property
+		}
+		violations = append(violations, Violation{
+			Pointer: p,
+			Message: e.Description(),
+		})
+	}
+	return violations, nil
+}
+
+// isSupported indicates if the document can be checked against the embedded JSON schema of the format.
+func isSupported(id sbom.FormatID, document []byte) (bool, error) {
+	var versions struct {
+		Schema struct {
+			Version string `json:"version"`
+		} `json:"schema"`
+		SPDXVersion string `json:"spdxVersion"`
+		SpecVersion string `json:"specVersion"`
+	}
+
+	switch id {
+	case syftjson.ID, spdx22json.ID, cyclonedxjson.ID:
+		if err := json.Unmarshal(document, &versions); err != nil {
+			return false, fmt.Errorf("unable to read %s document: %w", id, err)
+		}
+	default:
+		log.WithFields("format", id).Trace("no JSON schema to validate document against")
+		return false, nil
+	}
+
+	var version, supported string
+	switch id {
+	case syftjson.ID:
+		// schema versions of the same model are compatible with each other (see schema/json/README.md)
+		version, supported = majorVersion(versions.Schema.Version), majorVersion(schema.SyftJSONVersion)
+	case spdx22json.ID:
+		version, supported = versions.SPDXVersion, spdxVersion
+	case cyclonedxjson.ID:
+		version, supported = versions.SpecVersion, cyclonedxVersion
+	}
+
+	if version != supported {
+		log.WithFields("format", id, "version", version).Debug("no JSON schema for document version, skipping schema validation")
+		return false, nil
+	}
+	return true, nil
+}
+
+func majorVersion(version string) string {
+	return strings.Split(version, ".")[0]
+}
+
+func compiledSchema(id sbom.FormatID) (*gojsonschema.Schema, error) {
+	schemas.Lock()
+	defer schemas.Unlock()
+
+	if s, ok := schemas.compiled[id]; ok {
+		return s, nil
+	}
+
+	var root []byte
+	var refs [][]byte
+	switch id {
+	case syftjson.ID:
+		root = schema.SyftJSON
+	case spdx22json.ID:
+		root = []byte(spdxDocumentSchema)
+		refs = [][]byte{schema.SPDXJSON}
+	case cyclonedxjson.ID:
+		root = schema.CycloneDXJSON
+		refs = [][]byte{[]byte(cyclonedxSPDXSchema), []byte(cyclonedxSignatureSchema)}
+	default:
+		return nil, fmt.Errorf("no JSON schema for format %s", id)
+	}
+
+	loader := gojsonschema.NewSchemaLoader()
+	for _, ref := range refs {
+		if err := loader.AddSchemas(gojsonschema.NewBytesLoader(ref)); err != nil {
+			return nil, fmt.Errorf("unable to load schemas referenced by %s schema: %w", id, err)
+		}
+	}
+
+	s, err := loader.Compile(gojsonschema.NewBytesLoader(root))
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile %s schema: %w", id, err)
+	}
+	schemas.compiled[id] = s
+	return s, nil
+}
+
+// pointer converts the context of a schema error (e.g. "(root).packages.0.name") to a JSON pointer (e.g.
+// "/packages/0/name").
+func pointer(context *gojsonschema.JsonContext) string {
+	if context == nil {
+		return ""
+	}
+	return strings.TrimPrefix(context.String("/"), gojsonschema.STRING_CONTEXT_ROOT)
+}
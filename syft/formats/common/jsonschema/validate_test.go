@@ -0,0 +1,149 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/schema"
+	"github.com/anchore/syft/syft/formats/cyclonedxjson"
+	"github.com/anchore/syft/syft/formats/cyclonedxxml"
+	"github.com/anchore/syft/syft/formats/spdx22json"
+	"github.com/anchore/syft/syft/formats/syftjson"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+const (
+	syftGolden      = "../../syftjson/test-fixtures/snapshot/TestDirectoryEncoder.golden"
+	spdxGolden      = "../../spdx22json/test-fixtures/snapshot/TestSPDXJSONDirectoryEncoder.golden"
+	cyclonedxGolden = "../../cyclonedxjson/test-fixtures/snapshot/TestCycloneDxDirectoryEncoder.golden"
+)
+
+// document reads the given golden file, applying the given changes to the decoded document.
+func document(t *testing.T, path string, change func(doc map[string]interface{})) []byte {
+	t.Helper()
+	by, err := os.ReadFile(path)
+	require.NoError(t, err)
+	if change == nil {
+		return by
+	}
+
+	var doc map[string]interface{}
+	require.NoError(t, json.Unmarshal(by, &doc))
+	change(doc)
+	by, err = json.Marshal(doc)
+	require.NoError(t, err)
+	return by
+}
+
+func first(doc map[string]interface{}, key string) map[string]interface{} {
+	return doc[key].([]interface{})[0].(map[string]interface{})
+}
+
+func TestSyftJSONVersion(t *testing.T) {
+	assert.Equal(t, internal.JSONSchemaVersion, schema.SyftJSONVersion, "the embedded syft JSON schema must be the one written by the encoder (see schema/schema.go)")
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   sbom.FormatID
+		document []byte
+		// expected maps the pointer of each expected violation to a part of its message
+		expected map[string]string
+	}{
+		{
+			name:     "valid syft json",
+			format:   syftjson.ID,
+			document: document(t, syftGolden, nil),
+		},
+		{
+			name:   "invalid syft json",
+			format: syftjson.ID,
+			document: document(t, syftGolden, func(doc map[string]interface{}) {
+				p := first(doc, "artifacts")
+				delete(p, "name")
+				p["version"] = 1
+			}),
+			expected: map[string]string{
+				"/artifacts/0/name":    "is required",
+				"/artifacts/0/version": "Invalid type",
+			},
+		},
+		{
+			name:   "syft json of another schema model is not checked",
+			format: syftjson.ID,
+			document: document(t, syftGolden, func(doc map[string]interface{}) {
+				doc["schema"].(map[string]interface{})["version"] = "4.1.15"
+				delete(first(doc, "artifacts"), "name")
+			}),
+		},
+		{
+			name:     "valid spdx json",
+			format:   spdx22json.ID,
+			document: document(t, spdxGolden, nil),
+		},
+		{
+			name:   "invalid spdx json",
+			format: spdx22json.ID,
+			document: document(t, spdxGolden, func(doc map[string]interface{}) {
+				first(doc, "packages")["name"] = true
+			}),
+			expected: map[string]string{
+				"/packages/0/name": "Invalid type",
+			},
+		},
+		{
+			name:     "valid cyclonedx json",
+			format:   cyclonedxjson.ID,
+			document: document(t, cyclonedxGolden, nil),
+		},
+		{
+			name:   "invalid cyclonedx json",
+			format: cyclonedxjson.ID,
+			document: document(t, cyclonedxGolden, func(doc map[string]interface{}) {
+				delete(first(doc, "components"), "type")
+			}),
+			expected: map[string]string{
+				"/components/0/type": "is required",
+			},
+		},
+		{
+			name:   "cyclonedx json of another spec version is not checked",
+			format: cyclonedxjson.ID,
+			document: document(t, cyclonedxGolden, func(doc map[string]interface{}) {
+				doc["specVersion"] = "1.3"
+				delete(first(doc, "components"), "type")
+			}),
+		},
+		{
+			name:     "format without a JSON schema is not checked",
+			format:   cyclonedxxml.ID,
+			document: []byte("<bom/>"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := Validate(test.format, test.document)
+			require.NoError(t, err)
+
+			actual := make(map[string]string)
+			for _, v := range violations {
+				actual[v.Pointer] = v.Message
+			}
+			require.Len(t, actual, len(test.expected), "unexpected violations: %+v", violations)
+			for pointer, message := range test.expected {
+				assert.Contains(t, actual[pointer], message, "violation at %s", pointer)
+			}
+		})
+	}
+}
+
+func TestViolation_String(t *testing.T) {
+	assert.Equal(t, "/packages/0/name: Invalid type", Violation{Pointer: "/packages/0/name", Message: "Invalid type"}.String())
+	assert.Equal(t, "/: unexpected", Violation{Message: "unexpected"}.String())
+}
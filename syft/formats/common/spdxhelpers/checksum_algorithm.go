@@ -0,0 +1,22 @@
+package spdxhelpers
+
+import "strings"
+
+// checksumAlgorithms are the SPDX names of digest algorithms whose names are not simply the uppercase syft name
+// (see https://spdx.github.io/spdx-spec/v2.3/file-information/#84-file-checksum-field).
+var checksumAlgorithms = map[string]string{
+	"blake2b256": "BLAKE2b-256",
+	"blake2b384": "BLAKE2b-384",
+	"blake2b512": "BLAKE2b-512",
+}
+
+// ChecksumAlgorithm returns the SPDX name of the given digest algorithm (e.g. "sha256" is "SHA256" and "blake2b256" is
+// "BLAKE2b-256").
+func ChecksumAlgorithm(algorithm string) string {
+	if name, ok := checksumAlgorithms[strings.ToLower(algorithm)]; ok {
+		return name
+	}
+	// basically, we need an uppercase version of our algorithm:
+	// https://github.com/spdx/spdx-spec/blob/development/v2.2.2/schemas/spdx-schema.json#L165
+	return strings.ToUpper(algorithm)
+}
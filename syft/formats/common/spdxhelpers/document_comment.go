@@ -0,0 +1,46 @@
+package spdxhelpers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// DocumentComment describes the image that was cataloged (beyond its name, which SPDX has a place for) as well as the
+// catalogers that failed while cataloging (so the document is incomplete). The comment is empty when there is nothing
+// to describe.
+func DocumentComment(srcMetadata source.Metadata, diagnostics []sbom.Diagnostic) string {
+	var lines []string
+	if srcMetadata.Scheme == source.ImageScheme {
+		lines = append(lines, imageComment(srcMetadata.ImageMetadata)...)
+	}
+	if len(diagnostics) > 0 {
+		lines = append(lines, "The cataloging results are incomplete, the following catalogers failed:")
+		for _, d := range diagnostics {
+			lines = append(lines, fmt.Sprintf("%s: %s", d.Cataloger, d.Message))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func imageComment(metadata source.ImageMetadata) []string {
+	var lines []string
+	add := func(label, value string) {
+		if value != "" {
+			lines = append(lines, fmt.Sprintf("%s: %s", label, value))
+		}
+	}
+	add("Image manifest digest", metadata.ManifestDigest)
+	add("Image manifest media type", metadata.MediaType)
+	add("Image config media type", metadata.ConfigMediaType)
+	for _, tag := range metadata.Tags {
+		add("Image tag", tag)
+	}
+	for _, repoDigest := range metadata.RepoDigests {
+		add("Image repo digest", repoDigest)
+	}
+	add("Image registry", metadata.RegistryHost)
+	return lines
+}
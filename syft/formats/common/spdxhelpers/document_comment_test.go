@@ -0,0 +1,73 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestDocumentComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		srcMetadata source.Metadata
+		diagnostics []sbom.Diagnostic
+		expected    string
+	}{
+		{
+			name:     "no diagnostics",
+			expected: "",
+		},
+		{
+			name: "failed catalogers",
+			diagnostics: []sbom.Diagnostic{
+				{Cataloger: "rpm-db-cataloger", Message: "panic: runtime error"},
+				{Cataloger: "java-cataloger", Message: "cataloging timed out before the cataloger finished"},
+			},
+			expected: "The cataloging results are incomplete, the following catalogers failed:\n" +
+				"rpm-db-cataloger: panic: runtime error\n" +
+				"java-cataloger: cataloging timed out before the cataloger finished",
+		},
+		{
+			name: "directory",
+			srcMetadata: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   "some/path",
+			},
+			expected: "",
+		},
+		{
+			name: "image",
+			srcMetadata: source.Metadata{
+				Scheme: source.ImageScheme,
+				ImageMetadata: source.ImageMetadata{
+					ManifestDigest:  "sha256:93d5a28ff72d288d69b5997b8ba47396d2cbb62a72b5d87cd3351094b5d578a0",
+					MediaType:       "application/vnd.docker.distribution.manifest.v2+json",
+					ConfigMediaType: "application/vnd.docker.container.image.v1+json",
+					Tags:            []string{"alpine:3.17", "alpine:latest"},
+					RepoDigests:     []string{"alpine@sha256:f271e74b17ced29b915d351685fd4644785c6d1559dd1f2d4189a5e851ef753a"},
+					RegistryHost:    "index.docker.io",
+				},
+			},
+			diagnostics: []sbom.Diagnostic{
+				{Cataloger: "rpm-db-cataloger", Message: "panic: runtime error"},
+			},
+			expected: "Image manifest digest: sha256:93d5a28ff72d288d69b5997b8ba47396d2cbb62a72b5d87cd3351094b5d578a0\n" +
+				"Image manifest media type: application/vnd.docker.distribution.manifest.v2+json\n" +
+				"Image config media type: application/vnd.docker.container.image.v1+json\n" +
+				"Image tag: alpine:3.17\n" +
+				"Image tag: alpine:latest\n" +
+				"Image repo digest: alpine@sha256:f271e74b17ced29b915d351685fd4644785c6d1559dd1f2d4189a5e851ef753a\n" +
+				"Image registry: index.docker.io\n" +
+				"The cataloging results are incomplete, the following catalogers failed:\n" +
+				"rpm-db-cataloger: panic: runtime error",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, DocumentComment(test.srcMetadata, test.diagnostics))
+		})
+	}
+}
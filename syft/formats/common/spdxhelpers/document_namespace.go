@@ -11,12 +11,24 @@ import (
 	"github.com/anchore/syft/syft/source"
 )
 
+// NamespaceOption is the format option that sets the base URL of the document namespace (instead of the syft one).
+const NamespaceOption = "namespace"
+
 func DocumentNameAndNamespace(srcMetadata source.Metadata) (string, string) {
 	name := DocumentName(srcMetadata)
 	return name, DocumentNamespace(name, srcMetadata)
 }
 
 func DocumentNamespace(name string, srcMetadata source.Metadata) string {
+	return DocumentNamespaceWithBase(&url.URL{
+		Scheme: "https",
+		Host:   "anchore.com",
+		Path:   internal.ApplicationName,
+	}, name, srcMetadata)
+}
+
+// DocumentNamespaceWithBase returns a unique namespace for the document under the given base URL.
+func DocumentNamespaceWithBase(base *url.URL, name string, srcMetadata source.Metadata) string {
 	input := "unknown-source-type"
 	switch srcMetadata.Scheme {
 	case source.ImageScheme:
@@ -33,11 +45,24 @@ func DocumentNamespace(name string, srcMetadata source.Metadata) string {
 		identifier = path.Join(input, fmt.Sprintf("%s-%s", name, uniqueID.String()))
 	}
 
-	u := url.URL{
-		Scheme: "https",
-		Host:   "anchore.com",
-		Path:   path.Join(internal.ApplicationName, identifier),
-	}
+	u := *base
+	u.Path = path.Join("/", base.Path, identifier)
+	u.RawPath = ""
 
 	return u.String()
 }
+
+// ParseNamespaceBase parses the base URL given for the document namespace, which must be absolute.
+func ParseNamespaceBase(value string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %w", NamespaceOption, value, err)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid %s %q: must be an absolute URL (e.g. https://example.com/sboms)", NamespaceOption, value)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, fmt.Errorf("invalid %s %q: must not have a query or fragment", NamespaceOption, value)
+	}
+	return u, nil
+}
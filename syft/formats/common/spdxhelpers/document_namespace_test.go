@@ -7,6 +7,7 @@ import (
 
 	"github.com/scylladb/go-set/strset"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/source"
 )
@@ -70,3 +71,45 @@ func Test_documentNamespace(t *testing.T) {
 	// assert all possible schemes were under test
 	assert.ElementsMatch(t, allSchemes.List(), testedSchemes.List(), "not all source.Schemes are under test")
 }
+
+func Test_documentNamespaceWithBase(t *testing.T) {
+	tests := []struct {
+		name     string
+		base     string
+		expected string
+	}{
+		{
+			name:     "host only",
+			base:     "https://example.com",
+			expected: "https://example.com/image/my-name-",
+		},
+		{
+			name:     "with path",
+			base:     "https://example.com/sboms",
+			expected: "https://example.com/sboms/image/my-name-",
+		},
+		{
+			name:     "with trailing slash",
+			base:     "https://example.com/sboms/",
+			expected: "https://example.com/sboms/image/my-name-",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			base, err := ParseNamespaceBase(test.base)
+			require.NoError(t, err)
+
+			actual := DocumentNamespaceWithBase(base, "my-name", source.Metadata{Scheme: source.ImageScheme})
+			assert.True(t, strings.HasPrefix(actual, test.expected), fmt.Sprintf("actual namespace %q", actual))
+		})
+	}
+}
+
+func Test_parseNamespaceBase_invalid(t *testing.T) {
+	for _, value := range []string{"", "example.com/sboms", "/sboms", "https://example.com/sboms?x=1", "https://example.com/sboms#x", "https://%zz"} {
+		t.Run(value, func(t *testing.T) {
+			_, err := ParseNamespaceBase(value)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -6,10 +6,17 @@ func Homepage(p pkg.Package) string {
 	if hasMetadata(p) {
 		switch metadata := p.Metadata.(type) {
 		case pkg.GemMetadata:
-			return metadata.Homepage
+			if metadata.Homepage != "" {
+				return metadata.Homepage
+			}
 		case pkg.NpmPackageJSONMetadata:
-			return metadata.Homepage
+			if metadata.Homepage != "" {
+				return metadata.Homepage
+			}
 		}
 	}
+	if p.Registry != nil {
+		return p.Registry.Homepage
+	}
 	return ""
 }
@@ -48,6 +48,30 @@ func Test_Homepage(t *testing.T) {
 			},
 			expected: "",
 		},
+		{
+			name: "from registry",
+			input: pkg.Package{
+				Metadata: pkg.NpmPackageJSONMetadata{},
+				Registry: &pkg.RegistryInfo{
+					Registry: "https://registry.npmjs.org",
+					Homepage: "http://a-place.gov",
+				},
+			},
+			expected: "http://a-place.gov",
+		},
+		{
+			name: "metadata preferred over registry",
+			input: pkg.Package{
+				Metadata: pkg.GemMetadata{
+					Homepage: "http://a-place.gov",
+				},
+				Registry: &pkg.RegistryInfo{
+					Registry: "https://rubygems.org",
+					Homepage: "http://another-place.gov",
+				},
+			},
+			expected: "http://a-place.gov",
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
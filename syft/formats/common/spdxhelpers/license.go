@@ -3,11 +3,50 @@ package spdxhelpers
 import (
 	"strings"
 
+	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/spdxlicense"
 	"github.com/anchore/syft/syft/pkg"
 )
 
-func License(p pkg.Package) string {
+// LicenseRefPrefix is the prefix of all license IDs defined by the SPDX document (as opposed to the SPDX license list)
+const LicenseRefPrefix = "LicenseRef-"
+
+// ExtractedLicense describes a license that is not on the SPDX license list, referenced by a LicenseRef- ID.
+type ExtractedLicense struct {
+	ID   string // the LicenseRef- ID used within license expressions
+	Name string // the license as declared by the package
+	Text string // the verbatim license text (or the license as declared when no text was captured)
+}
+
+// DeclaredLicense returns the SPDX license expression for the licenses declared by the package metadata.
+func DeclaredLicense(p pkg.Package) string {
+	return licenseExpression(p.DeclaredLicenses())
+}
+
+// ConcludedLicense returns the SPDX license expression for the licenses concluded from the package contents, falling
+// back to the declared licenses when no license was concluded.
+func ConcludedLicense(p pkg.Package) string {
+	if concluded := p.ConcludedLicenses(); len(concluded) > 0 {
+		return licenseExpression(concluded)
+	}
+	return DeclaredLicense(p)
+}
+
+// LicenseComments explains where the concluded license was determined from when it differs from the declared license.
+func LicenseComments(p pkg.Package) string {
+	paths := internal.NewStringSet()
+	for _, l := range p.ConcludedLicenses() {
+		if l.Location != nil {
+			paths.Add(l.Location.RealPath)
+		}
+	}
+	if len(paths) == 0 {
+		return ""
+	}
+	return "The concluded license was detected from the contents of: " + strings.Join(paths.ToSlice(), ", ")
+}
+
+func licenseExpression(licenses []pkg.License) string {
 	// source: https://spdx.github.io/spdx-spec/3-package-information/#313-concluded-license
 	// The options to populate this field are limited to:
 	// A valid SPDX License Expression as defined in Appendix IV;
@@ -17,16 +56,27 @@ func License(p pkg.Package) string {
 	//   (ii) the SPDX file creator has made no attempt to determine this field; or
 	//   (iii) the SPDX file creator has intentionally provided no information (no meaning should be implied by doing so).
 
-	if len(p.Licenses) == 0 {
+	if len(licenses) == 0 {
 		return NONE
 	}
 
 	// take all licenses and assume an AND expression; for information about license expressions see https://spdx.github.io/spdx-spec/appendix-IV-SPDX-license-expressions/
+	// licenses that are not on the SPDX license list are referenced by LicenseRef- IDs (see ExtractedLicenses)
 	var parsedLicenses []string
-	for _, l := range p.Licenses {
-		if value, exists := spdxlicense.ID(l); exists {
-			parsedLicenses = append(parsedLicenses, value)
+	seen := make(map[string]struct{})
+	for _, l := range licenses {
+		value := LicenseRef(l.Value)
+		if l.SPDXExpression != "" {
+			value = l.SPDXExpression
+			if expression, err := spdxlicense.ParseExpression(value); err == nil && expression.Operator == spdxlicense.OrOperator && len(licenses) > 1 {
+				value = "(" + value + ")"
+			}
+		}
+		if _, exists := seen[value]; exists || value == "" {
+			continue
 		}
+		seen[value] = struct{}{}
+		parsedLicenses = append(parsedLicenses, value)
 	}
 
 	if len(parsedLicenses) == 0 {
@@ -35,3 +85,51 @@ func License(p pkg.Package) string {
 
 	return strings.Join(parsedLicenses, " AND ")
 }
+
+// LicenseRef returns the LicenseRef- ID for the given license, which must only contain letters, numbers, "." and "-".
+// An empty string is returned if no ID can be derived from the license.
+func LicenseRef(license string) string {
+	id := strings.Trim(SanitizeElementID(strings.TrimSpace(license)), "-")
+	if id == "" {
+		return ""
+	}
+	return LicenseRefPrefix + id
+}
+
+// ExtractedLicenses returns the licensing info for every license within the catalog that is not on the SPDX license
+// list, which must accompany any LicenseRef- ID used within the document. When different licenses map to the same
+// LicenseRef- ID the first one found (by package order) is used.
+func ExtractedLicenses(catalog *pkg.Catalog) []ExtractedLicense {
+	if catalog == nil {
+		return nil
+	}
+
+	var results []ExtractedLicense
+	seen := make(map[string]struct{})
+	for _, p := range catalog.Sorted() {
+		for _, l := range p.Licenses {
+			if l.SPDXExpression != "" {
+				continue
+			}
+			ref := LicenseRef(l.Value)
+			if ref == "" {
+				continue
+			}
+			if _, exists := seen[ref]; exists {
+				continue
+			}
+			seen[ref] = struct{}{}
+
+			text, ok := p.LicenseTexts[l.Value]
+			if !ok || text == "" {
+				text = l.Value
+			}
+			results = append(results, ExtractedLicense{
+				ID:   ref,
+				Name: l.Value,
+				Text: text,
+			})
+		}
+	}
+	return results
+}
@@ -6,9 +6,34 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
 )
 
-func Test_License(t *testing.T) {
+var concludedLicenses = []pkg.License{
+	{
+		Value:          "MIT",
+		SPDXExpression: "MIT",
+		Type:           pkg.ConcludedLicense,
+		Location:       &source.Coordinates{RealPath: "/LICENSE"},
+		Confidence:     1,
+	},
+	{
+		Value:          "Apache-2.0",
+		SPDXExpression: "Apache-2.0",
+		Type:           pkg.ConcludedLicense,
+		Location:       &source.Coordinates{RealPath: "/NOTICE"},
+		Confidence:     0.8,
+	},
+	{
+		Value:          "MIT",
+		SPDXExpression: "MIT",
+		Type:           pkg.ConcludedLicense,
+		Location:       &source.Coordinates{RealPath: "/COPYING"},
+		Confidence:     1,
+	},
+}
+
+func Test_DeclaredLicense(t *testing.T) {
 	tests := []struct {
 		name     string
 		input    pkg.Package
@@ -22,53 +47,163 @@ func Test_License(t *testing.T) {
 		{
 			name: "no SPDX licenses",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"made-up",
-				},
+				),
+			},
+			expected: "LicenseRef-made-up",
+		},
+		{
+			name: "no usable licenses",
+			input: pkg.Package{
+				Licenses: pkg.NewLicensesFromValues(
+					"???",
+				),
 			},
 			expected: NOASSERTION,
 		},
+		{
+			name: "SPDX and non-SPDX licenses",
+			input: pkg.Package{
+				Licenses: pkg.NewLicensesFromValues(
+					"MIT",
+					"Acme Commercial License (v2)",
+				),
+			},
+			expected: "MIT AND LicenseRef-Acme-Commercial-License--v2",
+		},
 		{
 			name: "with SPDX license",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"MIT",
-				},
+				),
 			},
 			expected: "MIT",
 		},
 		{
 			name: "with SPDX license expression",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"MIT",
 					"GPL-3.0",
-				},
+				),
 			},
 			expected: "MIT AND GPL-3.0-only",
 		},
 		{
 			name: "cap insensitive",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"gpl-3.0",
-				},
+				),
 			},
 			expected: "GPL-3.0-only",
 		},
+		{
+			name: "compound expressions",
+			input: pkg.Package{
+				Licenses: pkg.NewLicensesFromValues(
+					"GPLv2+",
+					"MIT/Apache-2.0",
+				),
+			},
+			expected: "GPL-2.0-or-later AND (MIT OR Apache-2.0)",
+		},
+		{
+			name: "single compound expression",
+			input: pkg.Package{
+				Licenses: pkg.NewLicensesFromValues(
+					"MIT or Apache-2.0",
+				),
+			},
+			expected: "MIT OR Apache-2.0",
+		},
+		{
+			name: "concluded licenses are not declared",
+			input: pkg.Package{
+				Licenses: concludedLicenses,
+			},
+			expected: NONE,
+		},
 		{
 			name: "debian to spdx conversion",
 			input: pkg.Package{
-				Licenses: []string{
+				Licenses: pkg.NewLicensesFromValues(
 					"GPL-2",
-				},
+				),
 			},
 			expected: "GPL-2.0-only",
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			assert.Equal(t, test.expected, License(test.input))
+			assert.Equal(t, test.expected, DeclaredLicense(test.input))
+		})
+	}
+}
+
+func Test_ConcludedLicense(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            pkg.Package
+		expected         string
+		expectedComments string
+	}{
+		{
+			name:     "no licenses",
+			input:    pkg.Package{},
+			expected: NONE,
+		},
+		{
+			name: "declared licenses",
+			input: pkg.Package{
+				Licenses: pkg.NewLicensesFromValues("GPLv2+", "MIT/Apache-2.0"),
+			},
+			expected: "GPL-2.0-or-later AND (MIT OR Apache-2.0)",
+		},
+		{
+			name: "concluded licenses",
+			input: pkg.Package{
+				Licenses: concludedLicenses,
+			},
+			expected:         "MIT AND Apache-2.0",
+			expectedComments: "The concluded license was detected from the contents of: /COPYING, /LICENSE, /NOTICE",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ConcludedLicense(test.input))
+			assert.Equal(t, test.expectedComments, LicenseComments(test.input))
 		})
 	}
 }
+
+func Test_ExtractedLicenses(t *testing.T) {
+	catalog := pkg.NewCatalog(
+		pkg.Package{
+			Name:     "a",
+			Licenses: pkg.NewLicensesFromValues("MIT", "Acme License"),
+			LicenseTexts: map[string]string{
+				"Acme License": "Copyright Acme. All rights reserved.",
+			},
+		},
+		pkg.Package{
+			Name:     "b",
+			Licenses: pkg.NewLicensesFromValues("made-up", "Acme-License"),
+		},
+	)
+
+	assert.Equal(t, []ExtractedLicense{
+		{
+			ID:   "LicenseRef-Acme-License",
+			Name: "Acme License",
+			Text: "Copyright Acme. All rights reserved.",
+		},
+		{
+			ID:   "LicenseRef-made-up",
+			Name: "made-up",
+			Text: "made-up",
+		},
+	}, ExtractedLicenses(catalog))
+}
@@ -0,0 +1,43 @@
+package spdxhelpers
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OwnershipOverlapOption is the format option that sets how package ownership by file overlap (a package installing
+// the files another package was found by, see artifact.OwnershipByFileOverlapRelationship) is expressed as an SPDX relationship.
+const OwnershipOverlapOption = "ownership-overlap"
+
+// OwnershipOverlapMapping is how package ownership by file overlap is expressed as an SPDX relationship.
+type OwnershipOverlapMapping string
+
+const (
+	// OwnershipOverlapAsOther relates the owner to the owned package with an OTHER relationship, describing the
+	// ownership in the relationship comment (the default).
+	OwnershipOverlapAsOther OwnershipOverlapMapping = "other"
+	// OwnershipOverlapAsContains relates the owner to the owned package with a CONTAINS relationship.
+	OwnershipOverlapAsContains OwnershipOverlapMapping = "contains"
+	// OwnershipOverlapAsGeneratedFrom relates the owned package to the owner with a GENERATED_FROM relationship.
+	OwnershipOverlapAsGeneratedFrom OwnershipOverlapMapping = "generated-from"
+	// OwnershipOverlapAsNone leaves out the ownership relationships.
+	OwnershipOverlapAsNone OwnershipOverlapMapping = "none"
+)
+
+// AllOwnershipOverlapMappings are the supported ways of expressing package ownership by file overlap.
+var AllOwnershipOverlapMappings = []OwnershipOverlapMapping{
+	OwnershipOverlapAsOther,
+	OwnershipOverlapAsContains,
+	OwnershipOverlapAsGeneratedFrom,
+	OwnershipOverlapAsNone,
+}
+
+// ParseOwnershipOverlapMapping returns the mapping of package ownership by file overlap described by the given string.
+func ParseOwnershipOverlapMapping(value string) (OwnershipOverlapMapping, error) {
+	for _, m := range AllOwnershipOverlapMappings {
+		if strings.EqualFold(value, string(m)) {
+			return m, nil
+		}
+	}
+	return "", fmt.Errorf("invalid %s %q (options: %v)", OwnershipOverlapOption, value, AllOwnershipOverlapMappings)
+}
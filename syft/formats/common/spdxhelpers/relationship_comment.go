@@ -0,0 +1,46 @@
+package spdxhelpers
+
+import (
+	"sort"
+	"strings"
+)
+
+// relationshipAnnotationPrefix starts each annotation of a relationship within the relationship comment
+const relationshipAnnotationPrefix = "annotation: "
+
+// RelationshipComment returns the comment of an SPDX relationship describing the relationship (the given comment,
+// which may be empty) followed by its annotations, as "<comment>; annotation: <key>=<value>; ...".
+func RelationshipComment(comment string, annotations map[string]string) string {
+	var parts []string
+	if comment != "" {
+		parts = append(parts, comment)
+	}
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, relationshipAnnotationPrefix+key+"="+annotations[key])
+	}
+	return strings.Join(parts, "; ")
+}
+
+// ParseRelationshipComment splits the comment of an SPDX relationship into the description of the relationship and
+// the annotations encoded by RelationshipComment (nil when there are none).
+func ParseRelationshipComment(comment string) (string, map[string]string) {
+	parts := strings.Split("; "+comment, "; "+relationshipAnnotationPrefix)
+	description := strings.TrimPrefix(parts[0], "; ")
+	var annotations map[string]string
+	for _, part := range parts[1:] {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[key] = value
+	}
+	return description, annotations
+}
@@ -0,0 +1,51 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelationshipComment(t *testing.T) {
+	tests := []struct {
+		name        string
+		comment     string
+		annotations map[string]string
+		expected    string
+	}{
+		{
+			name:     "no comment",
+			expected: "",
+		},
+		{
+			name:     "comment only",
+			comment:  "ownership-by-file-overlap: indicates ownership",
+			expected: "ownership-by-file-overlap: indicates ownership",
+		},
+		{
+			name:        "annotations only",
+			annotations: map[string]string{"source": "enrichment-pipeline", "evidence": "requirements.txt:3"},
+			expected:    "annotation: evidence=requirements.txt:3; annotation: source=enrichment-pipeline",
+		},
+		{
+			name:        "comment and annotations",
+			comment:     "ownership-by-file-overlap: indicates ownership",
+			annotations: map[string]string{"confidence": "high"},
+			expected:    "ownership-by-file-overlap: indicates ownership; annotation: confidence=high",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual := RelationshipComment(test.comment, test.annotations)
+			assert.Equal(t, test.expected, actual)
+
+			comment, annotations := ParseRelationshipComment(actual)
+			assert.Equal(t, test.comment, comment)
+			if len(test.annotations) == 0 {
+				assert.Nil(t, annotations)
+			} else {
+				assert.Equal(t, test.annotations, annotations)
+			}
+		})
+	}
+}
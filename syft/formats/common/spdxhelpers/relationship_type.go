@@ -4,6 +4,10 @@ package spdxhelpers
 type RelationshipType string
 
 const (
+	// DescribesRelationship is to be used when SPDXRef-DOCUMENT describes SPDXRef-A.
+	// Example: The SPDX document WildFly.spdx describes the package 'WildFly'.
+	DescribesRelationship RelationshipType = "DESCRIBES"
+
 	// DescribedByRelationship is to be used when SPDXRef-A is described by SPDXREF-Document.
 	// Example: The package 'WildFly' is described by SPDX document WildFly.spdx.
 	DescribedByRelationship RelationshipType = "DESCRIBED_BY"
@@ -0,0 +1,90 @@
+package spdxhelpers
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/internal/spdxlicense"
+	"github.com/anchore/syft/syft/source"
+)
+
+// the OCI pre-defined annotations (set as image labels) describing an image (see
+// https://github.com/opencontainers/image-spec/blob/main/annotations.md)
+const (
+	ociAnnotationPrefix      = "org.opencontainers.image."
+	ociTitleAnnotation       = ociAnnotationPrefix + "title"
+	ociVersionAnnotation     = ociAnnotationPrefix + "version"
+	ociSourceAnnotation      = ociAnnotationPrefix + "source"
+	ociRevisionAnnotation    = ociAnnotationPrefix + "revision"
+	ociURLAnnotation         = ociAnnotationPrefix + "url"
+	ociDescriptionAnnotation = ociAnnotationPrefix + "description"
+	ociLicensesAnnotation    = ociAnnotationPrefix + "licenses"
+	ociVendorAnnotation      = ociAnnotationPrefix + "vendor"
+)
+
+// rootPackageIDPrefix prefixes the SPDX element ID of a root package, which distinguishes it from the cataloged packages
+const rootPackageIDPrefix = "DocumentRoot-"
+
+// RootPackage describes the subject of the document (e.g. the scanned image) as a package.
+type RootPackage struct {
+	ID               string // the SPDX element ID (without the "SPDXRef-" prefix)
+	Name             string
+	Version          string
+	Supplier         string // the organization supplying the package
+	DownloadLocation string
+	Homepage         string
+	SourceInfo       string
+	LicenseDeclared  string
+	Description      string
+}
+
+// ImageRootPackage describes the scanned image by the OCI annotations set as labels of the image, so that the source,
+// version, and license of the image itself are not lost. Nil is returned when the source is not an image or the image
+// is not described by any OCI annotation.
+func ImageRootPackage(srcMetadata source.Metadata) *RootPackage {
+	if srcMetadata.Scheme != source.ImageScheme || srcMetadata.ImageMetadata.Config == nil {
+		return nil
+	}
+	labels := srcMetadata.ImageMetadata.Config.Labels
+	if !hasOCIAnnotation(labels) {
+		return nil
+	}
+
+	name := labels[ociTitleAnnotation]
+	if name == "" {
+		name = cleanName(srcMetadata.ImageMetadata.UserInput)
+	}
+
+	p := RootPackage{
+		ID:               SanitizeElementID(rootPackageIDPrefix + "Image-" + name),
+		Name:             name,
+		Version:          labels[ociVersionAnnotation],
+		DownloadLocation: NOASSERTION,
+		Homepage:         labels[ociURLAnnotation],
+		SourceInfo:       "acquired package info from the labels of the image",
+		LicenseDeclared:  NOASSERTION,
+		Description:      labels[ociDescriptionAnnotation],
+		Supplier:         labels[ociVendorAnnotation],
+	}
+	if src := labels[ociSourceAnnotation]; src != "" {
+		p.DownloadLocation = src
+		if revision := labels[ociRevisionAnnotation]; revision != "" {
+			p.SourceInfo = "built from revision " + revision + " of " + src
+		}
+	}
+	// the licenses annotation is an SPDX license expression, although it is not validated when the image is built
+	if licenses := labels[ociLicensesAnnotation]; licenses != "" {
+		if expression, err := spdxlicense.ParseExpression(licenses); err == nil {
+			p.LicenseDeclared = expression.String()
+		}
+	}
+	return &p
+}
+
+func hasOCIAnnotation(labels map[string]string) bool {
+	for key := range labels {
+		if strings.HasPrefix(key, ociAnnotationPrefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestImageRootPackage(t *testing.T) {
+	imageWithLabels := func(labels map[string]string) source.Metadata {
+		return source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput: "ghcr.io/anchore/app:v1.2.3",
+				Config: &source.ImageConfigMetadata{
+					Labels: labels,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		srcMetadata source.Metadata
+		expected    *RootPackage
+	}{
+		{
+			name: "all annotations",
+			srcMetadata: imageWithLabels(map[string]string{
+				"org.opencontainers.image.title":       "app",
+				"org.opencontainers.image.version":     "1.2.3",
+				"org.opencontainers.image.source":      "https://github.com/anchore/app",
+				"org.opencontainers.image.revision":    "5b6c1ee0e0b6c39d8a3f7d9e5d8a9f7c1b2e3d4f",
+				"org.opencontainers.image.url":         "https://anchore.com/app",
+				"org.opencontainers.image.description": "an example application",
+				"org.opencontainers.image.licenses":    "apache-2.0",
+				"org.opencontainers.image.vendor":      "Anchore, Inc.",
+				"maintainer":                           "someone@example.com",
+			}),
+			expected: &RootPackage{
+				ID:               "DocumentRoot-Image-app",
+				Name:             "app",
+				Version:          "1.2.3",
+				Supplier:         "Anchore, Inc.",
+				DownloadLocation: "https://github.com/anchore/app",
+				Homepage:         "https://anchore.com/app",
+				SourceInfo:       "built from revision 5b6c1ee0e0b6c39d8a3f7d9e5d8a9f7c1b2e3d4f of https://github.com/anchore/app",
+				LicenseDeclared:  "Apache-2.0",
+				Description:      "an example application",
+			},
+		},
+		{
+			name: "named after the user input without a title",
+			srcMetadata: imageWithLabels(map[string]string{
+				"org.opencontainers.image.source":   "https://github.com/anchore/app",
+				"org.opencontainers.image.licenses": "not a license",
+			}),
+			expected: &RootPackage{
+				ID:               "DocumentRoot-Image-ghcr.io-anchore-app-v1.2.3",
+				Name:             "ghcr.io/anchore/app-v1.2.3",
+				DownloadLocation: "https://github.com/anchore/app",
+				SourceInfo:       "acquired package info from the labels of the image",
+				LicenseDeclared:  "NOASSERTION",
+			},
+		},
+		{
+			name: "no annotations",
+			srcMetadata: imageWithLabels(map[string]string{
+				"maintainer": "someone@example.com",
+			}),
+		},
+		{
+			name: "no image config",
+			srcMetadata: source.Metadata{
+				Scheme: source.ImageScheme,
+			},
+		},
+		{
+			name: "directory",
+			srcMetadata: source.Metadata{
+				Scheme: source.DirectoryScheme,
+				Path:   "some/path",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ImageRootPackage(test.srcMetadata))
+		})
+	}
+}
@@ -44,6 +44,34 @@ func SourceInfo(p pkg.Package) string {
 		answer = "acquired package info from portage DB"
 	case pkg.HackagePkg:
 		answer = "acquired package info from cabal or stack manifest files"
+	case pkg.BinaryPkg:
+		answer = "acquired package info from the predefined or user-defined binary classifiers"
+	case pkg.LinuxKernelPkg:
+		answer = "acquired package info from linux kernel archive"
+	case pkg.LinuxKernelModulePkg:
+		answer = "acquired package info from linux kernel module files"
+	case pkg.FirmwarePkg:
+		answer = "acquired package info from UEFI binary SBAT sections or CPU microcode headers"
+	case pkg.WasmPkg:
+		answer = "acquired package info from WebAssembly binary custom sections"
+	case pkg.HelmPkg:
+		answer = "acquired package info from helm chart manifest or lock file"
+	case pkg.ContainerImagePkg:
+		answer = "acquired package info from container image references within helm chart values or kubernetes manifests, or from images stored within the filesystem"
+	case pkg.TerraformPkg:
+		answer = "acquired package info from terraform dependency lock file or installed modules manifest"
+	case pkg.GithubActionPkg, pkg.GithubActionWorkflowPkg:
+		answer = "acquired package info from GitHub Actions workflow file or composite action file"
+	case pkg.VSCodeExtensionPkg:
+		answer = "acquired package info from installed VS Code extension manifest"
+	case pkg.ChromeExtensionPkg, pkg.FirefoxExtensionPkg:
+		answer = "acquired package info from browser extension manifest or browser profile extensions database"
+	case pkg.WordpressPluginPkg, pkg.WordpressThemePkg:
+		answer = "acquired package info from WordPress plugin file header or theme stylesheet header"
+	case pkg.DrupalModulePkg, pkg.DrupalThemePkg:
+		answer = "acquired package info from Drupal .info.yml file"
+	case pkg.AIModelPkg:
+		answer = "acquired package info from machine learning model file headers"
 	default:
 		answer = "acquired package info from the following paths"
 	}
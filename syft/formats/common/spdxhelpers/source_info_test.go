@@ -183,6 +183,150 @@ func Test_SourceInfo(t *testing.T) {
 				"from cabal or stack manifest files",
 			},
 		},
+		{
+			input: pkg.Package{
+				Type: pkg.BinaryPkg,
+			},
+			expected: []string{
+				"from the predefined or user-defined binary classifiers",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.LinuxKernelPkg,
+			},
+			expected: []string{
+				"from linux kernel archive",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.LinuxKernelModulePkg,
+			},
+			expected: []string{
+				"from linux kernel module files",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.FirmwarePkg,
+			},
+			expected: []string{
+				"from UEFI binary SBAT sections or CPU microcode headers",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.WasmPkg,
+			},
+			expected: []string{
+				"from WebAssembly binary custom sections",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.HelmPkg,
+			},
+			expected: []string{
+				"from helm chart manifest or lock file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.ContainerImagePkg,
+			},
+			expected: []string{
+				"from container image references",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.TerraformPkg,
+			},
+			expected: []string{
+				"from terraform dependency lock file or installed modules manifest",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.GithubActionPkg,
+			},
+			expected: []string{
+				"from GitHub Actions workflow file or composite action file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.GithubActionWorkflowPkg,
+			},
+			expected: []string{
+				"from GitHub Actions workflow file or composite action file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.VSCodeExtensionPkg,
+			},
+			expected: []string{
+				"from installed VS Code extension manifest",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.ChromeExtensionPkg,
+			},
+			expected: []string{
+				"from browser extension manifest or browser profile extensions database",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.FirefoxExtensionPkg,
+			},
+			expected: []string{
+				"from browser extension manifest or browser profile extensions database",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.WordpressPluginPkg,
+			},
+			expected: []string{
+				"from WordPress plugin file header or theme stylesheet header",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.WordpressThemePkg,
+			},
+			expected: []string{
+				"from WordPress plugin file header or theme stylesheet header",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.DrupalModulePkg,
+			},
+			expected: []string{
+				"from Drupal .info.yml file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.DrupalThemePkg,
+			},
+			expected: []string{
+				"from Drupal .info.yml file",
+			},
+		},
+		{
+			input: pkg.Package{
+				Type: pkg.AIModelPkg,
+			},
+			expected: []string{
+				"from machine learning model file headers",
+			},
+		},
 	}
 	var pkgTypes []pkg.Type
 	for _, test := range tests {
@@ -0,0 +1,13 @@
+package spdxhelpers
+
+import "github.com/anchore/syft/syft/pkg"
+
+// Supplier returns the person that published the package to a registry (as looked up when enriching packages), or an
+// empty string if the supplier is not known. Note: the name is returned without the "Person: " prefix of the SPDX
+// supplier field, since the tag-value format adds the prefix itself.
+func Supplier(p pkg.Package) string {
+	if p.Registry != nil {
+		return p.Registry.Supplier
+	}
+	return ""
+}
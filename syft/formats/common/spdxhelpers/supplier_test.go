@@ -0,0 +1,38 @@
+package spdxhelpers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func Test_Supplier(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    pkg.Package
+		expected string
+	}{
+		{
+			name:     "no registry details",
+			input:    pkg.Package{},
+			expected: "",
+		},
+		{
+			name: "from registry",
+			input: pkg.Package{
+				Registry: &pkg.RegistryInfo{
+					Registry: "https://pypi.org",
+					Supplier: "Jane Doe",
+				},
+			},
+			expected: "Jane Doe",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Supplier(test.input))
+		})
+	}
+}
@@ -10,11 +10,13 @@ import (
 
 	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/spdxlicense"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/formats/common/util"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
 	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
@@ -32,7 +34,8 @@ func ToSyftModel(doc *spdx.Document2_2) (*sbom.SBOM, error) {
 	}
 
 	s := &sbom.SBOM{
-		Source: src,
+		Source:     src,
+		Descriptor: extractDescriptor(doc.CreationInfo),
 		Artifacts: sbom.Artifacts{
 			PackageCatalog:    pkg.NewCatalog(),
 			FileMetadata:      map[source.Coordinates]source.FileMetadata{},
@@ -50,6 +53,23 @@ func ToSyftModel(doc *spdx.Document2_2) (*sbom.SBOM, error) {
 	return s, nil
 }
 
+// extractDescriptor describes the tool that created the document from the tool creators of the document, given as
+// "name-version" (e.g. "syft-0.62.1"). If there is more than one tool, the first is used.
+func extractDescriptor(info *spdx.CreationInfo2_2) (desc sbom.Descriptor) {
+	if info == nil || len(info.CreatorTools) == 0 {
+		return
+	}
+
+	tool := strings.TrimSpace(info.CreatorTools[0])
+	if i := strings.LastIndex(tool, "-"); i > 0 {
+		desc.Name = tool[:i]
+		desc.Version = tool[i+1:]
+		return
+	}
+	desc.Name = tool
+	return
+}
+
 // NOTE(jonas): SPDX doesn't inform what an SBOM is about,
 // image, directory, for example. This is our best effort to determine
 // the scheme. Syft-generated SBOMs have in the namespace
@@ -80,12 +100,12 @@ func findLinuxReleaseByPURL(doc *spdx.Document2_2) *linux.Release {
 		if purlValue == "" {
 			continue
 		}
-		purl, err := packageurl.FromString(purlValue)
+		pURL, err := packageurl.FromString(purlValue)
 		if err != nil {
 			log.Warnf("unable to parse purl: %s", purlValue)
 			continue
 		}
-		distro := findQualifierValue(purl, pkg.PURLQualifierDistro)
+		distro := findQualifierValue(pURL, purl.DistroQualifier)
 		if distro != "" {
 			parts := strings.Split(distro, "-")
 			name := parts[0]
@@ -108,8 +128,17 @@ func findLinuxReleaseByPURL(doc *spdx.Document2_2) *linux.Release {
 }
 
 func collectSyftPackages(s *sbom.SBOM, spdxIDMap map[string]interface{}, doc *spdx.Document2_2) {
+	otherLicenses := make(map[string]*spdx.OtherLicense2_2)
+	for _, l := range doc.OtherLicenses {
+		otherLicenses[l.LicenseIdentifier] = l
+	}
+
 	for _, p := range doc.Packages {
-		syftPkg := toSyftPackage(p)
+		// the package describing the subject of the document is not a cataloged package
+		if strings.HasPrefix(string(p.PackageSPDXIdentifier), rootPackageIDPrefix) {
+			continue
+		}
+		syftPkg := toSyftPackage(p, otherLicenses)
 		spdxIDMap[string(p.PackageSPDXIdentifier)] = syftPkg
 		s.Artifacts.PackageCatalog.Add(*syftPkg)
 	}
@@ -174,12 +203,17 @@ func toSyftRelationships(spdxIDMap map[string]interface{}, doc *spdx.Document2_2
 			log.Debugf("unable to find valid relationship mapping from SPDX 2.2 JSON, ignoring: (from: %+v) (to: %+v)", a, b)
 			continue
 		}
+		comment, annotations := ParseRelationshipComment(r.RelationshipComment)
 		var to artifact.Identifiable
 		var typ artifact.RelationshipType
 		if toLocationOk {
-			if r.Relationship == string(ContainsRelationship) {
+			switch RelationshipType(r.Relationship) {
+			case ContainsRelationship:
 				typ = artifact.ContainsRelationship
 				to = toLocation
+			case DescribedByRelationship:
+				typ = artifact.DescribedByRelationship
+				to = toLocation
 			}
 		} else {
 			switch RelationshipType(r.Relationship) {
@@ -194,7 +228,7 @@ func toSyftRelationships(spdxIDMap map[string]interface{}, doc *spdx.Document2_2
 				to = toPackage
 			case OtherRelationship:
 				// Encoding uses a specifically formatted comment...
-				if strings.Index(r.RelationshipComment, string(artifact.OwnershipByFileOverlapRelationship)) == 0 {
+				if strings.Index(comment, string(artifact.OwnershipByFileOverlapRelationship)) == 0 {
 					typ = artifact.RuntimeDependencyOfRelationship
 					to = toPackage
 				}
@@ -202,9 +236,10 @@ func toSyftRelationships(spdxIDMap map[string]interface{}, doc *spdx.Document2_2
 		}
 		if typ != "" && to != nil {
 			out = append(out, artifact.Relationship{
-				From: from,
-				To:   to,
-				Type: typ,
+				From:        from,
+				To:          to,
+				Type:        typ,
+				Annotations: annotations,
 			})
 		}
 	}
@@ -252,8 +287,8 @@ func (p *pkgInfo) qualifierValue(name string) string {
 	return findQualifierValue(p.purl, name)
 }
 
-func findQualifierValue(purl packageurl.PackageURL, qualifier string) string {
-	for _, q := range purl.Qualifiers {
+func findQualifierValue(pURL packageurl.PackageURL, qualifier string) string {
+	for _, q := range pURL.Qualifiers {
 		if q.Key == qualifier {
 			return q.Value
 		}
@@ -263,25 +298,27 @@ func findQualifierValue(purl packageurl.PackageURL, qualifier string) string {
 
 func extractPkgInfo(p *spdx.Package2_2) pkgInfo {
 	pu := findPURLValue(p)
-	purl, err := packageurl.FromString(pu)
+	pURL, err := packageurl.FromString(pu)
 	if err != nil {
 		return pkgInfo{}
 	}
 	return pkgInfo{
-		purl,
-		pkg.TypeByName(purl.Type),
-		pkg.LanguageByName(purl.Type),
+		pURL,
+		pkg.TypeByName(pURL.Type),
+		pkg.LanguageByName(pURL.Type),
 	}
 }
 
-func toSyftPackage(p *spdx.Package2_2) *pkg.Package {
+func toSyftPackage(p *spdx.Package2_2, otherLicenses map[string]*spdx.OtherLicense2_2) *pkg.Package {
 	info := extractPkgInfo(p)
 	metadataType, metadata := extractMetadata(p, info)
+	licenses, licenseTexts := toSyftLicenses(p, otherLicenses)
 	sP := pkg.Package{
 		Type:         info.typ,
 		Name:         p.PackageName,
 		Version:      p.PackageVersion,
-		Licenses:     parseLicense(p.PackageLicenseDeclared),
+		Licenses:     licenses,
+		LicenseTexts: licenseTexts,
 		CPEs:         extractCPEs(p),
 		PURL:         info.purl.String(),
 		Language:     info.lang,
@@ -296,8 +333,8 @@ func toSyftPackage(p *spdx.Package2_2) *pkg.Package {
 
 //nolint:funlen
 func extractMetadata(p *spdx.Package2_2, info pkgInfo) (pkg.MetadataType, interface{}) {
-	arch := info.qualifierValue(pkg.PURLQualifierArch)
-	upstreamValue := info.qualifierValue(pkg.PURLQualifierUpstream)
+	arch := info.qualifierValue(purl.ArchQualifier)
+	upstreamValue := info.qualifierValue(purl.UpstreamQualifier)
 	upstream := strings.SplitN(upstreamValue, "@", 2)
 	upstreamName := upstream[0]
 	upstreamVersion := ""
@@ -317,7 +354,7 @@ func extractMetadata(p *spdx.Package2_2, info pkgInfo) (pkg.MetadataType, interf
 			Description:   p.PackageDescription,
 		}
 	case pkg.RpmPkg:
-		converted, err := strconv.Atoi(info.qualifierValue(pkg.PURLQualifierEpoch))
+		converted, err := strconv.Atoi(info.qualifierValue(purl.EpochQualifier))
 		var epoch *int
 		if err != nil {
 			epoch = nil
@@ -395,9 +432,63 @@ func extractCPEs(p *spdx.Package2_2) (cpes []pkg.CPE) {
 	return cpes
 }
 
-func parseLicense(l string) []string {
+// toSyftLicenses returns the declared licenses of the given package, along with the concluded licenses when they differ
+// from the declared licenses.
+func toSyftLicenses(p *spdx.Package2_2, otherLicenses map[string]*spdx.OtherLicense2_2) ([]pkg.License, map[string]string) {
+	declared, licenseTexts := parseLicense(p.PackageLicenseDeclared, otherLicenses)
+	licenses := pkg.NewLicensesFromValues(declared...)
+
+	if p.PackageLicenseConcluded == p.PackageLicenseDeclared {
+		return licenses, licenseTexts
+	}
+
+	concluded, concludedTexts := parseLicense(p.PackageLicenseConcluded, otherLicenses)
+	for _, l := range pkg.NewLicensesFromValues(concluded...) {
+		l.Type = pkg.ConcludedLicense
+		licenses = append(licenses, l)
+	}
+	for license, text := range concludedTexts {
+		if licenseTexts == nil {
+			licenseTexts = make(map[string]string)
+		}
+		licenseTexts[license] = text
+	}
+	return licenses, licenseTexts
+}
+
+// parseLicense splits the given license expression into licenses (by the top-level AND operator), resolving LicenseRef- IDs back to the license names
+// (and text) described by the other licensing information of the document.
+func parseLicense(l string, otherLicenses map[string]*spdx.OtherLicense2_2) ([]string, map[string]string) {
 	if l == NOASSERTION || l == NONE {
-		return nil
+		return nil, nil
+	}
+
+	var licenses []string
+	expression, err := spdxlicense.ParseExpression(l)
+	switch {
+	case err != nil:
+		licenses = strings.Split(l, " AND ")
+	case expression.Operator == spdxlicense.AndOperator:
+		for _, operand := range expression.Operands {
+			licenses = append(licenses, operand.String())
+		}
+	default:
+		licenses = []string{expression.String()}
+	}
+
+	var licenseTexts map[string]string
+	for idx, license := range licenses {
+		other, ok := otherLicenses[license]
+		if !ok || other.LicenseName == "" || other.LicenseName == NOASSERTION {
+			continue
+		}
+		licenses[idx] = other.LicenseName
+		if other.ExtractedText != "" && other.ExtractedText != other.LicenseName {
+			if licenseTexts == nil {
+				licenseTexts = make(map[string]string)
+			}
+			licenseTexts[other.LicenseName] = other.ExtractedText
+		}
 	}
-	return strings.Split(l, " AND ")
+	return licenses, licenseTexts
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -88,9 +89,21 @@ func TestToSyftModel(t *testing.T) {
 				},
 				Files: nil,
 			},
+			// the package describing the image is not a cataloged package
+			"DocumentRoot-Image-app": {
+				PackageName:           "app",
+				PackageSPDXIdentifier: "DocumentRoot-Image-app",
+				PackageVersion:        "1.2.3",
+			},
 		},
 		UnpackagedFiles: map[spdx.ElementID]*spdx.File2_2{},
-		Relationships:   []*spdx.Relationship2_2{},
+		Relationships: []*spdx.Relationship2_2{
+			{
+				RefA:         spdx.MakeDocElementID("", "DOCUMENT"),
+				RefB:         spdx.MakeDocElementID("", "DocumentRoot-Image-app"),
+				Relationship: "DESCRIBES",
+			},
+		},
 	})
 
 	assert.NoError(t, err)
@@ -308,10 +321,139 @@ func TestH1Digest(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			p := toSyftPackage(&test.pkg)
+			p := toSyftPackage(&test.pkg, nil)
 			require.Equal(t, pkg.GolangBinMetadataType, p.MetadataType)
 			meta := p.Metadata.(pkg.GolangBinMetadata)
 			require.Equal(t, test.expectedDigest, meta.H1Digest)
 		})
 	}
 }
+
+func Test_parseLicense(t *testing.T) {
+	otherLicenses := map[string]*spdx.OtherLicense2_2{
+		"LicenseRef-Acme-License": {
+			LicenseIdentifier: "LicenseRef-Acme-License",
+			LicenseName:       "Acme License",
+			ExtractedText:     "Copyright Acme. All rights reserved.",
+		},
+		"LicenseRef-made-up": {
+			LicenseIdentifier: "LicenseRef-made-up",
+			LicenseName:       "made-up",
+			ExtractedText:     "made-up",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		input        string
+		licenses     []string
+		licenseTexts map[string]string
+	}{
+		{
+			name:  "no assertion",
+			input: NOASSERTION,
+		},
+		{
+			name:     "SPDX licenses",
+			input:    "MIT AND GPL-3.0-only",
+			licenses: []string{"MIT", "GPL-3.0-only"},
+		},
+		{
+			name:     "compound expression",
+			input:    "GPL-2.0-or-later AND (MIT OR Apache-2.0)",
+			licenses: []string{"GPL-2.0-or-later", "MIT OR Apache-2.0"},
+		},
+		{
+			name:     "extracted licenses",
+			input:    "MIT AND LicenseRef-Acme-License AND LicenseRef-made-up AND LicenseRef-unknown",
+			licenses: []string{"MIT", "Acme License", "made-up", "LicenseRef-unknown"},
+			licenseTexts: map[string]string{
+				"Acme License": "Copyright Acme. All rights reserved.",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			licenses, licenseTexts := parseLicense(test.input, otherLicenses)
+			assert.Equal(t, test.licenses, licenses)
+			assert.Equal(t, test.licenseTexts, licenseTexts)
+		})
+	}
+}
+
+func Test_toSyftLicenses(t *testing.T) {
+	concluded := pkg.NewLicense("Apache-2.0")
+	concluded.Type = pkg.ConcludedLicense
+
+	tests := []struct {
+		name     string
+		pkg      spdx.Package2_2
+		expected []pkg.License
+	}{
+		{
+			name: "same declared and concluded licenses",
+			pkg: spdx.Package2_2{
+				PackageLicenseDeclared:  "MIT",
+				PackageLicenseConcluded: "MIT",
+			},
+			expected: pkg.NewLicensesFromValues("MIT"),
+		},
+		{
+			name: "concluded license only",
+			pkg: spdx.Package2_2{
+				PackageLicenseDeclared:  NONE,
+				PackageLicenseConcluded: "Apache-2.0",
+			},
+			expected: []pkg.License{concluded},
+		},
+		{
+			name: "no licenses",
+			pkg: spdx.Package2_2{
+				PackageLicenseDeclared:  NOASSERTION,
+				PackageLicenseConcluded: NONE,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			licenses, _ := toSyftLicenses(&test.pkg, nil)
+			assert.Equal(t, test.expected, licenses)
+		})
+	}
+}
+
+func Test_extractDescriptor(t *testing.T) {
+	tests := []struct {
+		name     string
+		info     *spdx.CreationInfo2_2
+		expected sbom.Descriptor
+	}{
+		{
+			name: "no creation info",
+		},
+		{
+			name: "no tools",
+			info: &spdx.CreationInfo2_2{CreatorOrganizations: []string{"Anchore, Inc"}},
+		},
+		{
+			name:     "tool with version",
+			info:     &spdx.CreationInfo2_2{CreatorTools: []string{"syft-0.62.1", "other-tool-1.0"}},
+			expected: sbom.Descriptor{Name: "syft", Version: "0.62.1"},
+		},
+		{
+			name:     "tool with hyphenated name",
+			info:     &spdx.CreationInfo2_2{CreatorTools: []string{"spdx-sbom-generator-v0.0.15"}},
+			expected: sbom.Descriptor{Name: "spdx-sbom-generator", Version: "v0.0.15"},
+		},
+		{
+			name:     "tool without version",
+			info:     &spdx.CreationInfo2_2{CreatorTools: []string{"mytool"}},
+			expected: sbom.Descriptor{Name: "mytool"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, extractDescriptor(test.info))
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package cyclonedxjson
 
 import (
+	"io"
+
 	"github.com/CycloneDX/cyclonedx-go"
 
 	"github.com/anchore/syft/syft/formats/common/cyclonedxhelpers"
@@ -10,10 +12,38 @@ import (
 const ID sbom.FormatID = "cyclonedx-1-json"
 
 func Format() sbom.Format {
-	return sbom.NewFormat(
-		ID,
-		encoder,
-		cyclonedxhelpers.GetDecoder(cyclonedx.BOMFileFormatJSON),
-		cyclonedxhelpers.GetValidator(cyclonedx.BOMFileFormatJSON),
-	)
+	return OutputFormat{}
+}
+
+// implementation of sbom.ConfigurableFormat interface
+// to make use of per-output format options
+type OutputFormat struct {
+	cfg cyclonedxhelpers.EncoderConfig
+}
+
+func (f OutputFormat) ID() sbom.FormatID {
+	return ID
+}
+
+func (f OutputFormat) Decode(reader io.Reader) (*sbom.SBOM, error) {
+	return cyclonedxhelpers.GetDecoder(cyclonedx.BOMFileFormatJSON)(reader)
+}
+
+func (f OutputFormat) Encode(output io.Writer, s sbom.SBOM) error {
+	return encoder(output, s, f.cfg)
+}
+
+func (f OutputFormat) Validate(reader io.Reader) error {
+	return cyclonedxhelpers.GetValidator(cyclonedx.BOMFileFormatJSON)(reader)
+}
+
+// WithOptions returns the format with the given options set, supporting "ownership-overlap" (how package ownership by
+// file overlap is expressed) and "collapse-owned" (leave out the packages owned by another package)
+func (f OutputFormat) WithOptions(options map[string]string) (sbom.Format, error) {
+	cfg, err := f.cfg.WithOptions(ID, options)
+	if err != nil {
+		return nil, err
+	}
+	f.cfg = cfg
+	return f, nil
 }
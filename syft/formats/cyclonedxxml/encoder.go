@@ -9,8 +9,8 @@ import (
 	"github.com/anchore/syft/syft/sbom"
 )
 
-func encoder(output io.Writer, s sbom.SBOM) error {
-	bom := cyclonedxhelpers.ToFormatModel(s)
+func encoder(output io.Writer, s sbom.SBOM, cfg cyclonedxhelpers.EncoderConfig) error {
+	bom := cyclonedxhelpers.ToFormatModelWithConfig(s, cfg)
 	enc := cyclonedx.NewBOMEncoder(output, cyclonedx.BOMFileFormatXML)
 	enc.SetPretty(true)
 
@@ -0,0 +1,218 @@
+package html
+
+import (
+	_ "embed"
+	"fmt"
+	"html/template"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// unknownLicense is the license summarized for packages without any license.
+const unknownLicense = "(none)"
+
+//go:embed report.html.tmpl
+var reportTemplate string
+
+var reportExecutor = template.Must(template.New("report").Parse(reportTemplate))
+
+// report is the data rendered by the report template.
+type report struct {
+	Source   string
+	Distro   string
+	Tool     string
+	Packages []packageRow
+	Types    []count
+	Licenses []count
+	Files    *fileNode
+}
+
+type packageRow struct {
+	Name      string
+	Version   string
+	Type      string
+	Language  string
+	Licenses  string
+	PURL      string
+	FoundBy   string
+	Locations []string
+}
+
+// count is a bar of a summary chart, where Percent is relative to the largest bar.
+type count struct {
+	Name    string
+	Count   int
+	Percent int
+}
+
+// fileNode is a directory or file of the file tree, with the packages found at that file.
+type fileNode struct {
+	Name     string
+	Path     string
+	Packages []string
+	Children []*fileNode
+
+	byName map[string]*fileNode
+}
+
+func encoder(output io.Writer, s sbom.SBOM) error {
+	return reportExecutor.Execute(output, newReport(s))
+}
+
+func newReport(s sbom.SBOM) report {
+	r := report{
+		Source: describeSource(s.Source),
+		Tool:   strings.TrimSpace(s.Descriptor.Name + " " + s.Descriptor.Version),
+		Files:  &fileNode{Name: "/", Path: "/"},
+	}
+	if d := s.Artifacts.LinuxDistribution; d != nil {
+		r.Distro = d.PrettyName
+		if r.Distro == "" {
+			r.Distro = strings.TrimSpace(d.ID + " " + d.VersionID)
+		}
+	}
+
+	types := make(map[string]int)
+	licenses := make(map[string]int)
+	if s.Artifacts.PackageCatalog != nil {
+		for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+			names := licenseNames(p)
+			row := packageRow{
+				Name:     p.Name,
+				Version:  p.Version,
+				Type:     string(p.Type),
+				Language: string(p.Language),
+				Licenses: strings.Join(names, ", "),
+				PURL:     p.PURL,
+				FoundBy:  p.FoundBy,
+			}
+			for _, l := range p.Locations.ToSlice() {
+				row.Locations = append(row.Locations, l.RealPath)
+				r.Files.add(l.RealPath, p.Name+"@"+p.Version)
+			}
+			r.Packages = append(r.Packages, row)
+
+			types[row.Type]++
+			if len(names) == 0 {
+				names = []string{unknownLicense}
+			}
+			for _, name := range names {
+				licenses[name]++
+			}
+		}
+	}
+
+	for _, c := range s.AllCoordinates() {
+		r.Files.add(c.RealPath, "")
+	}
+	r.Files.sort()
+
+	r.Types = counts(types)
+	r.Licenses = counts(licenses)
+	return r
+}
+
+func describeSource(src source.Metadata) string {
+	switch src.Scheme {
+	case source.ImageScheme:
+		if src.ImageMetadata.ManifestDigest != "" {
+			return fmt.Sprintf("%s (%s)", src.ImageMetadata.UserInput, src.ImageMetadata.ManifestDigest)
+		}
+		return src.ImageMetadata.UserInput
+	case source.DirectoryScheme, source.FileScheme:
+		return src.Path
+	}
+	if src.Path != "" {
+		return src.Path
+	}
+	return string(src.Scheme)
+}
+
+// licenseNames returns the distinct licenses of the package, preferring the SPDX expression of each license.
+func licenseNames(p pkg.Package) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, l := range p.Licenses {
+		name := l.SPDXExpression
+		if name == "" {
+			name = l.Value
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// counts orders the given counts from largest to smallest (then by name).
+func counts(values map[string]int) []count {
+	var result []count
+	largest := 0
+	for name, n := range values {
+		result = append(result, count{Name: name, Count: n})
+		if n > largest {
+			largest = n
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	for i := range result {
+		result[i].Percent = result[i].Count * 100 / largest
+	}
+	return result
+}
+
+// add adds the file at the given path to the tree, recording the given package (if any) as found at that file.
+func (n *fileNode) add(filePath, packageName string) {
+	filePath = path.Clean("/" + filePath)
+	current := n
+	if filePath != "/" {
+		for _, name := range strings.Split(strings.TrimPrefix(filePath, "/"), "/") {
+			current = current.child(name)
+		}
+	}
+	if packageName == "" {
+		return
+	}
+	for _, existing := range current.Packages {
+		if existing == packageName {
+			return
+		}
+	}
+	current.Packages = append(current.Packages, packageName)
+}
+
+func (n *fileNode) child(name string) *fileNode {
+	if c, ok := n.byName[name]; ok {
+		return c
+	}
+	if n.byName == nil {
+		n.byName = make(map[string]*fileNode)
+	}
+	c := &fileNode{Name: name, Path: path.Join(n.Path, name)}
+	n.byName[name] = c
+	n.Children = append(n.Children, c)
+	return c
+}
+
+func (n *fileNode) sort() {
+	sort.Slice(n.Children, func(i, j int) bool {
+		return n.Children[i].Name < n.Children[j].Name
+	})
+	sort.Strings(n.Packages)
+	for _, c := range n.Children {
+		c.sort()
+	}
+}
@@ -0,0 +1,114 @@
+package html
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/file"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func testSBOM() sbom.SBOM {
+	return sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(
+				pkg.Package{
+					Name:      "musl",
+					Version:   "1.2.3-r4",
+					Type:      pkg.ApkPkg,
+					FoundBy:   "apkdb-cataloger",
+					Locations: source.NewLocationSet(source.NewLocation("/lib/apk/db/installed")),
+					Licenses:  pkg.NewLicensesFromValues("MIT"),
+				},
+				pkg.Package{
+					Name:      "busybox",
+					Version:   "1.35.0-r17",
+					Type:      pkg.ApkPkg,
+					FoundBy:   "apkdb-cataloger",
+					Locations: source.NewLocationSet(source.NewLocation("/lib/apk/db/installed")),
+					Licenses:  pkg.NewLicensesFromValues("GPL-2.0-only"),
+				},
+				pkg.Package{
+					Name:      "<script>alert(1)</script>",
+					Version:   "1.0.0",
+					Type:      pkg.NpmPkg,
+					Language:  pkg.JavaScript,
+					FoundBy:   "javascript-lock-cataloger",
+					Locations: source.NewLocationSet(source.NewLocation("/app/package-lock.json")),
+				},
+			),
+			FileDigests: map[source.Coordinates][]file.Digest{
+				{RealPath: "/etc/passwd"}: {{Algorithm: "sha256", Value: "abc"}},
+			},
+			LinuxDistribution: &linux.Release{PrettyName: "Alpine Linux v3.17"},
+		},
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput:      "alpine:3.17",
+				ManifestDigest: "sha256:123",
+			},
+		},
+		Descriptor: sbom.Descriptor{Name: "syft", Version: "v0.42.0"},
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	r := newReport(testSBOM())
+
+	assert.Equal(t, "alpine:3.17 (sha256:123)", r.Source)
+	assert.Equal(t, "Alpine Linux v3.17", r.Distro)
+	assert.Equal(t, "syft v0.42.0", r.Tool)
+	require.Len(t, r.Packages, 3)
+
+	assert.Equal(t, []count{
+		{Name: "apk", Count: 2, Percent: 100},
+		{Name: "npm", Count: 1, Percent: 50},
+	}, r.Types)
+	assert.Equal(t, []count{
+		{Name: unknownLicense, Count: 1, Percent: 100},
+		{Name: "GPL-2.0-only", Count: 1, Percent: 100},
+		{Name: "MIT", Count: 1, Percent: 100},
+	}, r.Licenses)
+
+	var names []string
+	for _, c := range r.Files.Children {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"app", "etc", "lib"}, names)
+
+	installed := r.Files.Children[2].Children[0].Children[0].Children[0]
+	assert.Equal(t, "/lib/apk/db/installed", installed.Path)
+	assert.Equal(t, []string{"busybox@1.35.0-r17", "musl@1.2.3-r4"}, installed.Packages)
+	assert.Empty(t, r.Files.Children[1].Children[0].Packages)
+}
+
+func TestEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, testSBOM()))
+	out := buf.String()
+
+	assert.Contains(t, out, "<title>SBOM report: alpine:3.17 (sha256:123)</title>")
+	assert.Contains(t, out, "<tr><td>musl</td><td>1.2.3-r4</td><td>apk</td>")
+	assert.Contains(t, out, `<span class="fill" style="width: 50%"></span>`)
+	assert.Contains(t, out, `<li>installed <span class="packages">(busybox@1.35.0-r17, musl@1.2.3-r4)</span></li>`)
+	// package data is escaped rather than rendered as markup
+	assert.NotContains(t, out, "<script>alert(1)</script>")
+	assert.Contains(t, out, "&lt;script&gt;alert(1)&lt;/script&gt;")
+}
+
+func TestEncoder_NoPackages(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, sbom.SBOM{
+		Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog()},
+		Source:    source.Metadata{Scheme: source.DirectoryScheme, Path: "/some/path"},
+	}))
+	assert.Contains(t, buf.String(), "No packages discovered")
+	assert.Contains(t, buf.String(), "<h1>/some/path</h1>")
+}
@@ -0,0 +1,16 @@
+package html
+
+import (
+	"github.com/anchore/syft/syft/sbom"
+)
+
+const ID sbom.FormatID = "syft-html"
+
+func Format() sbom.Format {
+	return sbom.NewFormat(
+		ID,
+		encoder,
+		nil,
+		nil,
+	)
+}
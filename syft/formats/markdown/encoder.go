@@ -0,0 +1,194 @@
+package markdown
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	// topLicenses is the number of licenses listed in the summary
+	topLicenses = 10
+	// unknownLicense is the license summarized for packages without any license
+	unknownLicense = "(none)"
+)
+
+type count struct {
+	name  string
+	count int
+}
+
+// newPackage is a package that is not in the baseline, possibly at another version of a package that is.
+type newPackage struct {
+	pkg.Package
+	previousVersions []string
+}
+
+func encoder(output io.Writer, s sbom.SBOM, baseline *sbom.SBOM) error {
+	var packages []pkg.Package
+	if s.Artifacts.PackageCatalog != nil {
+		packages = s.Artifacts.PackageCatalog.Sorted()
+	}
+
+	w := &writer{out: output}
+	w.printf("## SBOM summary: %s\n\n", escape(describeSource(s.Source)))
+
+	summary := fmt.Sprintf("**%d packages**", len(packages))
+	var added []newPackage
+	if baseline != nil {
+		added = newPackages(packages, baseline)
+		summary += fmt.Sprintf(", %d new since the baseline", len(added))
+	}
+	if s.Descriptor.Name != "" {
+		summary += fmt.Sprintf(" (cataloged by %s)", escape(strings.TrimSpace(s.Descriptor.Name+" "+s.Descriptor.Version)))
+	}
+	w.printf("%s\n", summary)
+
+	if len(packages) == 0 {
+		w.printf("\nNo packages discovered\n")
+		return w.err
+	}
+
+	types := make(map[string]int)
+	licenses := make(map[string]int)
+	for _, p := range packages {
+		types[string(p.Type)]++
+		names := licenseNames(p)
+		if len(names) == 0 {
+			names = []string{unknownLicense}
+		}
+		for _, name := range names {
+			licenses[name]++
+		}
+	}
+
+	w.printf("\n### Packages by type\n\n| Type | Packages |\n| --- | ---: |\n")
+	for _, c := range sortedCounts(types) {
+		w.printf("| %s | %d |\n", escape(c.name), c.count)
+	}
+
+	licenseCounts := sortedCounts(licenses)
+	heading := "Licenses"
+	if len(licenseCounts) > topLicenses {
+		heading = fmt.Sprintf("Top %d licenses (of %d)", topLicenses, len(licenseCounts))
+		licenseCounts = licenseCounts[:topLicenses]
+	}
+	w.printf("\n### %s\n\n| License | Packages |\n| --- | ---: |\n", heading)
+	for _, c := range licenseCounts {
+		w.printf("| %s | %d |\n", escape(c.name), c.count)
+	}
+
+	if baseline != nil {
+		w.printf("\n### New packages\n\n")
+		if len(added) == 0 {
+			w.printf("No new packages since the baseline\n")
+			return w.err
+		}
+		w.printf("| Name | Version | Previous version | Type |\n| --- | --- | --- | --- |\n")
+		for _, p := range added {
+			w.printf("| %s | %s | %s | %s |\n", escape(p.Name), escape(p.Version), escape(strings.Join(p.previousVersions, ", ")), escape(string(p.Type)))
+		}
+	}
+
+	return w.err
+}
+
+// newPackages returns the packages that are not in the baseline at the same version, along with the versions of the
+// same package (by name and type) in the baseline.
+func newPackages(packages []pkg.Package, baseline *sbom.SBOM) []newPackage {
+	existing := make(map[string]bool)
+	versions := make(map[string][]string)
+	if baseline.Artifacts.PackageCatalog != nil {
+		for _, p := range baseline.Artifacts.PackageCatalog.Sorted() {
+			if existing[packageKey(p)+"@"+p.Version] {
+				continue
+			}
+			existing[packageKey(p)+"@"+p.Version] = true
+			versions[packageKey(p)] = append(versions[packageKey(p)], p.Version)
+		}
+	}
+
+	var added []newPackage
+	for _, p := range packages {
+		// the same package may be found at several locations, but is only listed once
+		if existing[packageKey(p)+"@"+p.Version] {
+			continue
+		}
+		existing[packageKey(p)+"@"+p.Version] = true
+		added = append(added, newPackage{Package: p, previousVersions: versions[packageKey(p)]})
+	}
+	return added
+}
+
+func packageKey(p pkg.Package) string {
+	return string(p.Type) + "/" + p.Name
+}
+
+func describeSource(src source.Metadata) string {
+	switch src.Scheme {
+	case source.ImageScheme:
+		return src.ImageMetadata.UserInput
+	case source.DirectoryScheme, source.FileScheme:
+		return src.Path
+	}
+	if src.Path != "" {
+		return src.Path
+	}
+	return string(src.Scheme)
+}
+
+// licenseNames returns the distinct licenses of the package, preferring the SPDX expression of each license.
+func licenseNames(p pkg.Package) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, l := range p.Licenses {
+		name := l.SPDXExpression
+		if name == "" {
+			name = l.Value
+		}
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
+// sortedCounts orders the given counts from largest to smallest (then by name).
+func sortedCounts(values map[string]int) []count {
+	var result []count
+	for name, n := range values {
+		result = append(result, count{name: name, count: n})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].count != result[j].count {
+			return result[i].count > result[j].count
+		}
+		return result[i].name < result[j].name
+	})
+	return result
+}
+
+// escape makes the given text safe to use within a table cell.
+func escape(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r", " ", "\n", " ").Replace(text)
+}
+
+// writer keeps the first error of a sequence of writes.
+type writer struct {
+	out io.Writer
+	err error
+}
+
+func (w *writer) printf(format string, args ...interface{}) {
+	if w.err != nil {
+		return
+	}
+	_, w.err = fmt.Fprintf(w.out, format, args...)
+}
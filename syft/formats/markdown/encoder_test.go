@@ -0,0 +1,168 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func newPkg(name, version string, t pkg.Type, licenses ...string) pkg.Package {
+	return pkg.Package{
+		Name:      name,
+		Version:   version,
+		Type:      t,
+		Locations: source.NewLocationSet(source.NewLocation("/" + name)),
+		Licenses:  pkg.NewLicensesFromValues(licenses...),
+	}
+}
+
+func newSBOM(packages ...pkg.Package) sbom.SBOM {
+	return sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(packages...),
+		},
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput: "alpine:3.17",
+			},
+		},
+		Descriptor: sbom.Descriptor{Name: "syft", Version: "v0.42.0"},
+	}
+}
+
+func TestEncoder(t *testing.T) {
+	current := newSBOM(
+		newPkg("musl", "1.2.3-r5", pkg.ApkPkg, "MIT"),
+		newPkg("busybox", "1.35.0-r17", pkg.ApkPkg, "GPL-2.0-only"),
+		newPkg("left|pad", "1.3.0", pkg.NpmPkg, "MIT"),
+		newPkg("unlicensed", "0.1.0", pkg.NpmPkg),
+	)
+	baseline := newSBOM(
+		newPkg("musl", "1.2.3-r4", pkg.ApkPkg, "MIT"),
+		newPkg("busybox", "1.35.0-r17", pkg.ApkPkg, "GPL-2.0-only"),
+	)
+
+	tests := []struct {
+		name     string
+		baseline *sbom.SBOM
+		expected string
+	}{
+		{
+			name: "without baseline",
+			expected: `## SBOM summary: alpine:3.17
+
+**4 packages** (cataloged by syft v0.42.0)
+
+### Packages by type
+
+| Type | Packages |
+| --- | ---: |
+| apk | 2 |
+| npm | 2 |
+
+### Licenses
+
+| License | Packages |
+| --- | ---: |
+| MIT | 2 |
+| (none) | 1 |
+| GPL-2.0-only | 1 |
+`,
+		},
+		{
+			name:     "with baseline",
+			baseline: &baseline,
+			expected: `## SBOM summary: alpine:3.17
+
+**4 packages**, 3 new since the baseline (cataloged by syft v0.42.0)
+
+### Packages by type
+
+| Type | Packages |
+| --- | ---: |
+| apk | 2 |
+| npm | 2 |
+
+### Licenses
+
+| License | Packages |
+| --- | ---: |
+| MIT | 2 |
+| (none) | 1 |
+| GPL-2.0-only | 1 |
+
+### New packages
+
+| Name | Version | Previous version | Type |
+| --- | --- | --- | --- |
+| left\|pad | 1.3.0 |  | npm |
+| musl | 1.2.3-r5 | 1.2.3-r4 | apk |
+| unlicensed | 0.1.0 |  | npm |
+`,
+		},
+		{
+			name:     "same as baseline",
+			baseline: &current,
+			expected: `## SBOM summary: alpine:3.17
+
+**4 packages**, 0 new since the baseline (cataloged by syft v0.42.0)
+
+### Packages by type
+
+| Type | Packages |
+| --- | ---: |
+| apk | 2 |
+| npm | 2 |
+
+### Licenses
+
+| License | Packages |
+| --- | ---: |
+| MIT | 2 |
+| (none) | 1 |
+| GPL-2.0-only | 1 |
+
+### New packages
+
+No new packages since the baseline
+`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f := OutputFormat{}
+			f.SetBaseline(test.baseline)
+
+			var buf bytes.Buffer
+			require.NoError(t, f.Encode(&buf, current))
+			assert.Equal(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestEncoder_NoPackages(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, newSBOM()))
+	assert.Equal(t, "## SBOM summary: alpine:3.17\n\n**0 packages** (cataloged by syft v0.42.0)\n\nNo packages discovered\n", buf.String())
+}
+
+func TestEncoder_TopLicenses(t *testing.T) {
+	var packages []pkg.Package
+	for i := 0; i < topLicenses+2; i++ {
+		packages = append(packages, newPkg(fmt.Sprintf("package-%d", i), "1.0.0", pkg.GoModulePkg, fmt.Sprintf("license-%02d", i)))
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, newSBOM(packages...)))
+	assert.Contains(t, buf.String(), "### Top 10 licenses (of 12)")
+	assert.Contains(t, buf.String(), "| license-09 | 1 |")
+	assert.NotContains(t, buf.String(), "license-10")
+}
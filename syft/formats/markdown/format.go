@@ -0,0 +1,40 @@
+package markdown
+
+import (
+	"io"
+
+	"github.com/anchore/syft/syft/sbom"
+)
+
+const ID sbom.FormatID = "syft-markdown"
+
+func Format() sbom.Format {
+	return OutputFormat{}
+}
+
+// implementation of sbom.Format interface
+// to make use of format options
+type OutputFormat struct {
+	baseline *sbom.SBOM
+}
+
+func (f OutputFormat) ID() sbom.FormatID {
+	return ID
+}
+
+func (f OutputFormat) Decode(reader io.Reader) (*sbom.SBOM, error) {
+	return nil, sbom.ErrDecodingNotSupported
+}
+
+func (f OutputFormat) Encode(output io.Writer, s sbom.SBOM) error {
+	return encoder(output, s, f.baseline)
+}
+
+func (f OutputFormat) Validate(reader io.Reader) error {
+	return sbom.ErrValidationNotSupported
+}
+
+// SetBaseline sets a previous SBOM of the same source, so that the summary lists the packages that are new since then
+func (f *OutputFormat) SetBaseline(baseline *sbom.SBOM) {
+	f.baseline = baseline
+}
@@ -1,13 +1,18 @@
 package spdx22json
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
 )
 
 func TestSPDXJSONDecoder(t *testing.T) {
@@ -102,3 +107,40 @@ func TestSPDXJSONDecoder(t *testing.T) {
 		})
 	}
 }
+
+func TestSPDXJSONDecoder_relationshipAnnotations(t *testing.T) {
+	parent := pkg.Package{Name: "app", Version: "1.0"}
+	parent.SetID()
+	child := pkg.Package{Name: "guava", Version: "31.1"}
+	child.SetID()
+	annotations := map[string]string{
+		"evidence": "BOOT-INF/lib/guava-31.1.jar",
+		"source":   "enrichment-pipeline",
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Format().Encode(&buf, sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(parent, child),
+		},
+		Relationships: []artifact.Relationship{
+			{
+				From:        parent,
+				To:          child,
+				Type:        artifact.ContainsRelationship,
+				Annotations: annotations,
+			},
+		},
+		Source: source.Metadata{Scheme: source.DirectoryScheme, Path: "some/path"},
+	}))
+	assert.Contains(t, buf.String(), `"comment": "annotation: evidence=BOOT-INF/lib/guava-31.1.jar; annotation: source=enrichment-pipeline"`)
+
+	s, err := decoder(&buf)
+	require.NoError(t, err)
+	require.Len(t, s.Relationships, 1)
+	r := s.Relationships[0]
+	assert.Equal(t, artifact.ContainsRelationship, r.Type)
+	assert.Equal(t, "app", r.From.(*pkg.Package).Name)
+	assert.Equal(t, "guava", r.To.(*pkg.Package).Name)
+	assert.Equal(t, annotations, r.Annotations)
+}
@@ -3,12 +3,14 @@ package spdx22json
 import (
 	"encoding/json"
 	"io"
+	"net/url"
 
+	"github.com/anchore/syft/syft/formats/common/spdxhelpers"
 	"github.com/anchore/syft/syft/sbom"
 )
 
-func encoder(output io.Writer, s sbom.SBOM) error {
-	doc := toFormatModel(s)
+func encoder(output io.Writer, s sbom.SBOM, namespaceBase *url.URL, ownershipOverlap spdxhelpers.OwnershipOverlapMapping) error {
+	doc := toFormatModel(s, namespaceBase, ownershipOverlap)
 
 	enc := json.NewEncoder(output)
 	// prevent > and < from being escaped in the payload
@@ -1,6 +1,12 @@
 package spdx22json
 
 import (
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/anchore/syft/syft/formats/common"
+	"github.com/anchore/syft/syft/formats/common/spdxhelpers"
 	"github.com/anchore/syft/syft/sbom"
 )
 
@@ -8,10 +14,65 @@ const ID sbom.FormatID = "spdx-2-json"
 
 // note: this format is LOSSY relative to the syftjson format
 func Format() sbom.Format {
-	return sbom.NewFormat(
-		ID,
-		encoder,
-		decoder,
-		validator,
-	)
+	return OutputFormat{}
+}
+
+// implementation of sbom.ConfigurableFormat interface
+// to make use of per-output format options
+type OutputFormat struct {
+	// namespaceBase is the base URL of the document namespace (the syft one when nil)
+	namespaceBase *url.URL
+	// ownershipOverlap is how package ownership by file overlap is expressed (an OTHER relationship when empty)
+	ownershipOverlap spdxhelpers.OwnershipOverlapMapping
+	// collapseOwned leaves out the packages owned by another package by file overlap
+	collapseOwned bool
+}
+
+func (f OutputFormat) ID() sbom.FormatID {
+	return ID
+}
+
+func (f OutputFormat) Decode(reader io.Reader) (*sbom.SBOM, error) {
+	return decoder(reader)
+}
+
+func (f OutputFormat) Encode(output io.Writer, s sbom.SBOM) error {
+	if f.collapseOwned {
+		s = common.CollapseOwnedPackages(s)
+	}
+	return encoder(output, s, f.namespaceBase, f.ownershipOverlap)
+}
+
+func (f OutputFormat) Validate(reader io.Reader) error {
+	return validator(reader)
+}
+
+// WithOptions returns the format with the given options set, supporting "namespace" (the base URL of the document namespace),
+// "ownership-overlap" (how package ownership by file overlap is expressed) and "collapse-owned" (leave out owned packages)
+func (f OutputFormat) WithOptions(options map[string]string) (sbom.Format, error) {
+	for key, value := range options {
+		switch key {
+		case spdxhelpers.NamespaceOption:
+			base, err := spdxhelpers.ParseNamespaceBase(value)
+			if err != nil {
+				return nil, err
+			}
+			f.namespaceBase = base
+		case spdxhelpers.OwnershipOverlapOption:
+			mapping, err := spdxhelpers.ParseOwnershipOverlapMapping(value)
+			if err != nil {
+				return nil, err
+			}
+			f.ownershipOverlap = mapping
+		case common.CollapseOwnedOption:
+			collapse, err := common.ParseCollapseOwned(value)
+			if err != nil {
+				return nil, err
+			}
+			f.collapseOwned = collapse
+		default:
+			return nil, fmt.Errorf("unsupported option for %s format: %q", ID, key)
+		}
+	}
+	return f, nil
 }
@@ -0,0 +1,78 @@
+package spdx22json
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestFormat_WithOptions(t *testing.T) {
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{PackageCatalog: pkg.NewCatalog()},
+		Source:    source.Metadata{Scheme: source.DirectoryScheme, Path: "some/path"},
+	}
+
+	tests := []struct {
+		name              string
+		options           map[string]string
+		expectedNamespace string
+		wantErr           require.ErrorAssertionFunc
+	}{
+		{
+			name:              "no options",
+			expectedNamespace: "https://anchore.com/syft/dir/some/path-",
+		},
+		{
+			name:              "namespace",
+			options:           map[string]string{"namespace": "https://example.com/sboms"},
+			expectedNamespace: "https://example.com/sboms/dir/some/path-",
+		},
+		{
+			name:    "invalid namespace",
+			options: map[string]string{"namespace": "example.com"},
+			wantErr: require.Error,
+		},
+		{
+			name:              "ownership overlap and collapse owned",
+			options:           map[string]string{"ownership-overlap": "generated-from", "collapse-owned": "true"},
+			expectedNamespace: "https://anchore.com/syft/dir/some/path-",
+		},
+		{
+			name:    "invalid ownership overlap",
+			options: map[string]string{"ownership-overlap": "depends-on"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "invalid collapse owned",
+			options: map[string]string{"collapse-owned": "sometimes"},
+			wantErr: require.Error,
+		},
+		{
+			name:    "unsupported option",
+			options: map[string]string{"pretty": "true"},
+			wantErr: require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			f, err := Format().(OutputFormat).WithOptions(test.options)
+			test.wantErr(t, err)
+			if err != nil {
+				return
+			}
+
+			var buf bytes.Buffer
+			require.NoError(t, f.Encode(&buf, s))
+			assert.Contains(t, buf.String(), `"documentNamespace": "`+test.expectedNamespace)
+		})
+	}
+}
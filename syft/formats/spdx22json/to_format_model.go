@@ -2,6 +2,7 @@ package spdx22json
 
 import (
 	"fmt"
+	"net/url"
 	"sort"
 	"strings"
 	"time"
@@ -20,15 +21,30 @@ import (
 )
 
 // toFormatModel creates and populates a new JSON document struct that follows the SPDX 2.2 spec from the given cataloging results.
-func toFormatModel(s sbom.SBOM) *model.Document {
+func toFormatModel(s sbom.SBOM, namespaceBase *url.URL, ownershipOverlap spdxhelpers.OwnershipOverlapMapping) *model.Document {
 	name, namespace := spdxhelpers.DocumentNameAndNamespace(s.Source)
+	if namespaceBase != nil {
+		namespace = spdxhelpers.DocumentNamespaceWithBase(namespaceBase, name, s.Source)
+	}
 
 	relationships := s.RelationshipsSorted()
 
+	packages := toPackages(s.Artifacts.PackageCatalog, relationships)
+	formatRelationships := toRelationships(relationships, ownershipOverlap)
+	if root := spdxhelpers.ImageRootPackage(s.Source); root != nil {
+		packages = append([]model.Package{toRootPackage(*root)}, packages...)
+		formatRelationships = append([]model.Relationship{{
+			SpdxElementID:      model.ElementID("DOCUMENT").String(),
+			RelationshipType:   spdxhelpers.DescribesRelationship,
+			RelatedSpdxElement: model.ElementID(root.ID).String(),
+		}}, formatRelationships...)
+	}
+
 	return &model.Document{
 		Element: model.Element{
-			SPDXID: model.ElementID("DOCUMENT").String(),
-			Name:   name,
+			SPDXID:  model.ElementID("DOCUMENT").String(),
+			Name:    name,
+			Comment: spdxhelpers.DocumentComment(s.Source, s.Artifacts.Diagnostics),
 		},
 		SPDXVersion: model.Version,
 		CreationInfo: model.CreationInfo{
@@ -40,24 +56,36 @@ func toFormatModel(s sbom.SBOM) *model.Document {
 			},
 			LicenseListVersion: spdxlicense.Version,
 		},
-		DataLicense:       "CC0-1.0",
-		DocumentNamespace: namespace,
-		Packages:          toPackages(s.Artifacts.PackageCatalog, relationships),
-		Files:             toFiles(s),
-		Relationships:     toRelationships(relationships),
+		DataLicense:                "CC0-1.0",
+		HasExtractedLicensingInfos: toExtractedLicensingInfos(s.Artifacts.PackageCatalog),
+		DocumentNamespace:          namespace,
+		Packages:                   packages,
+		Files:                      toFiles(s),
+		Relationships:              formatRelationships,
+	}
+}
+
+// toExtractedLicensingInfos describes every LicenseRef- license referenced by the package license expressions.
+func toExtractedLicensingInfos(catalog *pkg.Catalog) (infos []model.HasExtractedLicensingInfo) {
+	for _, l := range spdxhelpers.ExtractedLicenses(catalog) {
+		infos = append(infos, model.HasExtractedLicensingInfo{
+			ExtractedText: l.Text,
+			LicenseID:     l.ID,
+			Name:          l.Name,
+		})
 	}
+	return infos
 }
 
 func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []model.Package {
 	packages := make([]model.Package, 0)
 
 	for _, p := range catalog.Sorted() {
-		license := spdxhelpers.License(p)
 		packageSpdxID := model.ElementID(p.ID()).String()
 		checksums, filesAnalyzed := toPackageChecksums(p)
 
-		// note: the license concluded and declared should be the same since we are collecting license information
-		// from the project data itself (the installed package files).
+		// note: the license concluded and declared are the same unless the license was concluded from the contents of
+		// the license files of the package (when the package metadata lacks any license).
 		packages = append(packages, model.Package{
 			Checksums:        checksums,
 			Description:      spdxhelpers.Description(p),
@@ -67,13 +95,15 @@ func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []m
 			HasFiles:         fileIDsForPackage(packageSpdxID, relationships),
 			Homepage:         spdxhelpers.Homepage(p),
 			// The Declared License is what the authors of a project believe govern the package
-			LicenseDeclared: license,
+			LicenseDeclared: spdxhelpers.DeclaredLicense(p),
 			Originator:      spdxhelpers.Originator(p),
 			SourceInfo:      spdxhelpers.SourceInfo(p),
+			Supplier:        toSupplier(p),
 			VersionInfo:     p.Version,
 			Item: model.Item{
 				// The Concluded License field is the license the SPDX file creator believes governs the package
-				LicenseConcluded: license,
+				LicenseConcluded: spdxhelpers.ConcludedLicense(p),
+				LicenseComments:  spdxhelpers.LicenseComments(p),
 				Element: model.Element{
 					SPDXID: packageSpdxID,
 					Name:   p.Name,
@@ -85,6 +115,39 @@ func toPackages(catalog *pkg.Catalog, relationships []artifact.Relationship) []m
 	return packages
 }
 
+// toSupplier describes the person that published the package to a registry (see spdxhelpers.Supplier).
+func toSupplier(p pkg.Package) string {
+	if supplier := spdxhelpers.Supplier(p); supplier != "" {
+		return "Person: " + supplier
+	}
+	return ""
+}
+
+// toRootPackage creates the package describing the subject of the document (see spdxhelpers.ImageRootPackage).
+func toRootPackage(p spdxhelpers.RootPackage) model.Package {
+	var supplier string
+	if p.Supplier != "" {
+		supplier = "Organization: " + p.Supplier
+	}
+	return model.Package{
+		Description:      p.Description,
+		DownloadLocation: p.DownloadLocation,
+		FilesAnalyzed:    false,
+		Homepage:         p.Homepage,
+		LicenseDeclared:  p.LicenseDeclared,
+		SourceInfo:       p.SourceInfo,
+		Supplier:         supplier,
+		VersionInfo:      p.Version,
+		Item: model.Item{
+			LicenseConcluded: spdxhelpers.NOASSERTION,
+			Element: model.Element{
+				SPDXID: model.ElementID(p.ID).String(),
+				Name:   p.Name,
+			},
+		},
+	}
+}
+
 func toPackageChecksums(p pkg.Package) ([]model.Checksum, bool) {
 	filesAnalyzed := false
 	var checksums []model.Checksum
@@ -97,7 +160,7 @@ func toPackageChecksums(p pkg.Package) ([]model.Checksum, bool) {
 			filesAnalyzed = true
 			for _, digest := range meta.ArchiveDigests {
 				checksums = append(checksums, model.Checksum{
-					Algorithm:     strings.ToUpper(digest.Algorithm),
+					Algorithm:     spdxhelpers.ChecksumAlgorithm(digest.Algorithm),
 					ChecksumValue: digest.Value,
 				})
 			}
@@ -155,25 +218,25 @@ func toFiles(s sbom.SBOM) []model.File {
 			digests = digestsForLocation
 		}
 
-		// TODO: add file classifications (?) and content as a snippet
-
-		var comment string
-		if coordinates.FileSystemID != "" {
-			comment = fmt.Sprintf("layerID: %s", coordinates.FileSystemID)
+		var classifications []file.Classification
+		if classificationsForLocation, exists := artifacts.FileClassifications[coordinates]; exists {
+			classifications = classificationsForLocation
 		}
 
+		// TODO: add content as a snippet
+
 		results = append(results, model.File{
 			Item: model.Item{
 				Element: model.Element{
 					SPDXID:  model.ElementID(coordinates.ID()).String(),
-					Comment: comment,
+					Comment: toFileComment(coordinates, classifications),
 				},
 				// required, no attempt made to determine license information
 				LicenseConcluded: "NOASSERTION",
 			},
 			Checksums: toFileChecksums(digests),
 			FileName:  coordinates.RealPath,
-			FileTypes: toFileTypes(metadata),
+			FileTypes: toFileTypes(metadata, classifications),
 		})
 	}
 
@@ -190,22 +253,50 @@ func toFiles(s sbom.SBOM) []model.File {
 func toFileChecksums(digests []file.Digest) (checksums []model.Checksum) {
 	for _, digest := range digests {
 		checksums = append(checksums, model.Checksum{
-			Algorithm:     toChecksumAlgorithm(digest.Algorithm),
+			Algorithm:     spdxhelpers.ChecksumAlgorithm(digest.Algorithm),
 			ChecksumValue: digest.Value,
 		})
 	}
 	return checksums
 }
 
-func toChecksumAlgorithm(algorithm string) string {
-	// basically, we need an uppercase version of our algorithm:
-	// https://github.com/spdx/spdx-spec/blob/development/v2.2.2/schemas/spdx-schema.json#L165
-	return strings.ToUpper(algorithm)
+// toFileComment describes the layer a file was found in (for images) and the classifications of the file, which SPDX
+// has no dedicated fields for.
+func toFileComment(coordinates source.Coordinates, classifications []file.Classification) string {
+	var parts []string
+	if coordinates.FileSystemID != "" {
+		parts = append(parts, fmt.Sprintf("layerID: %s", coordinates.FileSystemID))
+	}
+	for _, classification := range classifications {
+		keys := make([]string, 0, len(classification.Metadata))
+		for key := range classification.Metadata {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var fields []string
+		for _, key := range keys {
+			fields = append(fields, fmt.Sprintf("%s=%s", key, classification.Metadata[key]))
+		}
+
+		part := fmt.Sprintf("classification: %s", classification.Class)
+		if len(fields) > 0 {
+			part += fmt.Sprintf(" (%s)", strings.Join(fields, ", "))
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; ")
 }
 
-func toFileTypes(metadata *source.FileMetadata) (ty []string) {
+func toFileTypes(metadata *source.FileMetadata, classifications []file.Classification) (ty []string) {
+	for _, classification := range classifications {
+		if fileType, ok := classificationFileType(classification.Class); ok {
+			ty = appendFileType(ty, fileType)
+		}
+	}
+
 	if metadata == nil {
-		return nil
+		return ty
 	}
 
 	mimeTypePrefix := strings.Split(metadata.MIMEType, "/")[0]
@@ -223,14 +314,14 @@ func toFileTypes(metadata *source.FileMetadata) (ty []string) {
 	}
 
 	if internal.IsExecutable(metadata.MIMEType) {
-		ty = append(ty, string(spdxhelpers.BinaryFileType))
+		ty = appendFileType(ty, spdxhelpers.BinaryFileType)
 	}
 
 	if internal.IsArchive(metadata.MIMEType) {
 		ty = append(ty, string(spdxhelpers.ArchiveFileType))
 	}
 
-	// TODO: add support for source, spdx, and documentation file types
+	// TODO: add support for spdx and documentation file types
 	if len(ty) == 0 {
 		ty = append(ty, string(spdxhelpers.OtherFileType))
 	}
@@ -238,9 +329,50 @@ func toFileTypes(metadata *source.FileMetadata) (ty []string) {
 	return ty
 }
 
-func toRelationships(relationships []artifact.Relationship) (result []model.Relationship) {
+// classificationFileType returns the SPDX file type implied by a file classification (if any): classes of binaries
+// (e.g. "elf-binary" or "pe-binary") are binary files while source code and interpreted scripts are source files.
+func classificationFileType(class string) (spdxhelpers.FileType, bool) {
+	switch {
+	case strings.HasSuffix(class, "-binary"):
+		return spdxhelpers.BinaryFileType, true
+	case strings.HasSuffix(class, "-source"), strings.HasSuffix(class, "-script"):
+		return spdxhelpers.SourceFileType, true
+	}
+	return "", false
+}
+
+func appendFileType(ty []string, fileType spdxhelpers.FileType) []string {
+	for _, existing := range ty {
+		if existing == string(fileType) {
+			return ty
+		}
+	}
+	return append(ty, string(fileType))
+}
+
+func toRelationships(relationships []artifact.Relationship, ownershipOverlap spdxhelpers.OwnershipOverlapMapping) (result []model.Relationship) {
 	for _, r := range relationships {
-		exists, relationshipType, comment := lookupRelationship(r.Type)
+		if r.Type == artifact.IntroducesRelationship {
+			// image layers are not SPDX elements (the layer of each file is noted within the file comment instead)
+			continue
+		}
+
+		from, to := r.From, r.To
+		var exists bool
+		var relationshipType spdxhelpers.RelationshipType
+		var comment string
+		if r.Type == artifact.OwnershipByFileOverlapRelationship {
+			exists, relationshipType, comment = lookupOwnershipOverlapRelationship(ownershipOverlap)
+			if !exists {
+				continue
+			}
+			if relationshipType == spdxhelpers.GeneratedFromRelationship {
+				// the owned package is generated from (installed by) the owner
+				from, to = to, from
+			}
+		} else {
+			exists, relationshipType, comment = lookupRelationship(r.Type)
+		}
 
 		if !exists {
 			log.Warnf("unable to convert relationship from SPDX 2.2 JSON, dropping: %+v", r)
@@ -248,10 +380,10 @@ func toRelationships(relationships []artifact.Relationship) (result []model.Rela
 		}
 
 		result = append(result, model.Relationship{
-			SpdxElementID:      model.ElementID(r.From.ID()).String(),
+			SpdxElementID:      model.ElementID(from.ID()).String(),
 			RelationshipType:   relationshipType,
-			RelatedSpdxElement: model.ElementID(r.To.ID()).String(),
-			Comment:            comment,
+			RelatedSpdxElement: model.ElementID(to.ID()).String(),
+			Comment:            spdxhelpers.RelationshipComment(comment, r.Annotations),
 		})
 	}
 	return result
@@ -261,8 +393,25 @@ func lookupRelationship(ty artifact.RelationshipType) (bool, spdxhelpers.Relatio
 	switch ty {
 	case artifact.ContainsRelationship:
 		return true, spdxhelpers.ContainsRelationship, ""
+	case artifact.DescribedByRelationship:
+		return true, spdxhelpers.DescribedByRelationship, ""
 	case artifact.OwnershipByFileOverlapRelationship:
-		return true, spdxhelpers.OtherRelationship, fmt.Sprintf("%s: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by", ty)
+		return lookupOwnershipOverlapRelationship(spdxhelpers.OwnershipOverlapAsOther)
 	}
 	return false, "", ""
 }
+
+// lookupOwnershipOverlapRelationship returns the SPDX relationship expressing package ownership by file overlap with
+// the given mapping (from the owner to the owned package, except for GENERATED_FROM), or false when it is left out.
+func lookupOwnershipOverlapRelationship(mapping spdxhelpers.OwnershipOverlapMapping) (bool, spdxhelpers.RelationshipType, string) {
+	switch mapping {
+	case spdxhelpers.OwnershipOverlapAsNone:
+		return false, "", ""
+	case spdxhelpers.OwnershipOverlapAsContains:
+		return true, spdxhelpers.ContainsRelationship, ""
+	case spdxhelpers.OwnershipOverlapAsGeneratedFrom:
+		return true, spdxhelpers.GeneratedFromRelationship, ""
+	default:
+		return true, spdxhelpers.OtherRelationship, fmt.Sprintf("%s: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by", artifact.OwnershipByFileOverlapRelationship)
+	}
+}
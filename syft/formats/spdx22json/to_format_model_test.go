@@ -12,6 +12,7 @@ import (
 	"github.com/anchore/syft/syft/formats/common/spdxhelpers"
 	"github.com/anchore/syft/syft/formats/spdx22json/model"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -90,7 +91,110 @@ func Test_toFileTypes(t *testing.T) {
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			assert.ElementsMatch(t, test.expected, toFileTypes(&test.metadata))
+			assert.ElementsMatch(t, test.expected, toFileTypes(&test.metadata, nil))
+		})
+	}
+}
+
+func Test_toFileTypes_classifications(t *testing.T) {
+	tests := []struct {
+		name            string
+		metadata        *source.FileMetadata
+		classifications []file.Classification
+		expected        []string
+	}{
+		{
+			name: "binary classification without metadata",
+			classifications: []file.Classification{
+				{Class: "go-binary"},
+			},
+			expected: []string{
+				string(spdxhelpers.BinaryFileType),
+			},
+		},
+		{
+			name: "binary classification and executable MIME type",
+			metadata: &source.FileMetadata{
+				MIMEType: "application/x-executable",
+			},
+			classifications: []file.Classification{
+				{Class: "elf-binary"},
+			},
+			expected: []string{
+				string(spdxhelpers.ApplicationFileType),
+				string(spdxhelpers.BinaryFileType),
+			},
+		},
+		{
+			name: "script classification",
+			metadata: &source.FileMetadata{
+				MIMEType: "text/plain",
+			},
+			classifications: []file.Classification{
+				{Class: "interpreter-script"},
+			},
+			expected: []string{
+				string(spdxhelpers.SourceFileType),
+				string(spdxhelpers.TextFileType),
+			},
+		},
+		{
+			name: "framework classification",
+			metadata: &source.FileMetadata{
+				MIMEType: "text/plain",
+			},
+			classifications: []file.Classification{
+				{Class: "flask-framework"},
+			},
+			expected: []string{
+				string(spdxhelpers.TextFileType),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.ElementsMatch(t, test.expected, toFileTypes(test.metadata, test.classifications))
+		})
+	}
+}
+
+func Test_toFileComment(t *testing.T) {
+	tests := []struct {
+		name            string
+		coordinates     source.Coordinates
+		classifications []file.Classification
+		expected        string
+	}{
+		{
+			name:        "no layer or classifications",
+			coordinates: source.Coordinates{RealPath: "/bin/sh"},
+		},
+		{
+			name:        "layer only",
+			coordinates: source.Coordinates{RealPath: "/bin/sh", FileSystemID: "sha256:abc"},
+			expected:    "layerID: sha256:abc",
+		},
+		{
+			name:        "layer and classifications",
+			coordinates: source.Coordinates{RealPath: "/usr/bin/python3.9", FileSystemID: "sha256:abc"},
+			classifications: []file.Classification{
+				{
+					Class: "python-binary",
+					Metadata: map[string]string{
+						"version": "3.9.2",
+						"build":   "final",
+					},
+				},
+				{
+					Class: "elf-binary",
+				},
+			},
+			expected: "layerID: sha256:abc; classification: python-binary (build=final, version=3.9.2); classification: elf-binary",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, toFileComment(test.coordinates, test.classifications))
 		})
 	}
 }
@@ -129,6 +233,68 @@ func Test_lookupRelationship(t *testing.T) {
 	}
 }
 
+func Test_toRelationships_ownershipOverlap(t *testing.T) {
+	owner := pkg.Package{Name: "python3-requests", Version: "2.25.1"}
+	owner.SetID()
+	owned := pkg.Package{Name: "requests", Version: "2.25.1"}
+	owned.SetID()
+	ownerID := model.ElementID(owner.ID()).String()
+	ownedID := model.ElementID(owned.ID()).String()
+
+	relationships := []artifact.Relationship{
+		{
+			From: owner,
+			To:   owned,
+			Type: artifact.OwnershipByFileOverlapRelationship,
+		},
+	}
+
+	tests := []struct {
+		mapping  spdxhelpers.OwnershipOverlapMapping
+		expected []model.Relationship
+	}{
+		{
+			mapping: "",
+			expected: []model.Relationship{
+				{
+					SpdxElementID:      ownerID,
+					RelationshipType:   spdxhelpers.OtherRelationship,
+					RelatedSpdxElement: ownedID,
+					Comment:            "ownership-by-file-overlap: indicates that the parent package claims ownership of a child package since the parent metadata indicates overlap with a location that a cataloger found the child package by",
+				},
+			},
+		},
+		{
+			mapping: spdxhelpers.OwnershipOverlapAsContains,
+			expected: []model.Relationship{
+				{
+					SpdxElementID:      ownerID,
+					RelationshipType:   spdxhelpers.ContainsRelationship,
+					RelatedSpdxElement: ownedID,
+				},
+			},
+		},
+		{
+			mapping: spdxhelpers.OwnershipOverlapAsGeneratedFrom,
+			expected: []model.Relationship{
+				{
+					SpdxElementID:      ownedID,
+					RelationshipType:   spdxhelpers.GeneratedFromRelationship,
+					RelatedSpdxElement: ownerID,
+				},
+			},
+		},
+		{
+			mapping: spdxhelpers.OwnershipOverlapAsNone,
+		},
+	}
+	for _, test := range tests {
+		t.Run(string(test.mapping), func(t *testing.T) {
+			assert.Equal(t, test.expected, toRelationships(relationships, test.mapping))
+		})
+	}
+}
+
 func Test_toFileChecksums(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -161,6 +327,29 @@ func Test_toFileChecksums(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "has stronger digests",
+			digests: []file.Digest{
+				{
+					Algorithm: "sha512",
+					Value:     "deadbeefcafe",
+				},
+				{
+					Algorithm: "blake2b256",
+					Value:     "cafebabe",
+				},
+			},
+			expected: []model.Checksum{
+				{
+					Algorithm:     "SHA512",
+					ChecksumValue: "deadbeefcafe",
+				},
+				{
+					Algorithm:     "BLAKE2b-256",
+					ChecksumValue: "cafebabe",
+				},
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -316,3 +505,43 @@ func Test_H1Digest(t *testing.T) {
 		})
 	}
 }
+
+func Test_toFormatModel_imageRootPackage(t *testing.T) {
+	s := sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(pkg.Package{Name: "musl", Version: "1.2.4-r2"}),
+		},
+		Source: source.Metadata{
+			Scheme: source.ImageScheme,
+			ImageMetadata: source.ImageMetadata{
+				UserInput: "ghcr.io/anchore/app:v1.2.3",
+				Config: &source.ImageConfigMetadata{
+					Labels: map[string]string{
+						"org.opencontainers.image.source":  "https://github.com/anchore/app",
+						"org.opencontainers.image.version": "1.2.3",
+						"org.opencontainers.image.vendor":  "Anchore, Inc.",
+					},
+				},
+			},
+		},
+	}
+
+	doc := toFormatModel(s, nil, "")
+
+	// the image is described by a package ahead of the cataloged packages
+	require.Len(t, doc.Packages, 2)
+	root := doc.Packages[0]
+	assert.Equal(t, "SPDXRef-DocumentRoot-Image-ghcr.io-anchore-app-v1.2.3", root.SPDXID)
+	assert.Equal(t, "ghcr.io/anchore/app-v1.2.3", root.Name)
+	assert.Equal(t, "1.2.3", root.VersionInfo)
+	assert.Equal(t, "https://github.com/anchore/app", root.DownloadLocation)
+	assert.Equal(t, "Organization: Anchore, Inc.", root.Supplier)
+	assert.Equal(t, "musl", doc.Packages[1].Name)
+
+	require.NotEmpty(t, doc.Relationships)
+	assert.Equal(t, model.Relationship{
+		SpdxElementID:      "SPDXRef-DOCUMENT",
+		RelationshipType:   spdxhelpers.DescribesRelationship,
+		RelatedSpdxElement: root.SPDXID,
+	}, doc.Relationships[0])
+}
@@ -2,13 +2,14 @@ package spdx22tagvalue
 
 import (
 	"io"
+	"net/url"
 
 	"github.com/spdx/tools-golang/tvsaver"
 
 	"github.com/anchore/syft/syft/sbom"
 )
 
-func encoder(output io.Writer, s sbom.SBOM) error {
-	model := toFormatModel(s)
+func encoder(output io.Writer, s sbom.SBOM, namespaceBase *url.URL) error {
+	model := toFormatModel(s, namespaceBase)
 	return tvsaver.Save2_2(model, output)
 }
@@ -1,6 +1,12 @@
 package spdx22tagvalue
 
 import (
+	"fmt"
+	"io"
+	"net/url"
+
+	"github.com/anchore/syft/syft/formats/common"
+	"github.com/anchore/syft/syft/formats/common/spdxhelpers"
 	"github.com/anchore/syft/syft/sbom"
 )
 
@@ -8,10 +14,57 @@ const ID sbom.FormatID = "spdx-2-tag-value"
 
 // note: this format is LOSSY relative to the syftjson formation, which means that decoding and validation is not supported at this time
 func Format() sbom.Format {
-	return sbom.NewFormat(
-		ID,
-		encoder,
-		decoder,
-		validator,
-	)
+	return OutputFormat{}
+}
+
+// implementation of sbom.ConfigurableFormat interface
+// to make use of per-output format options
+type OutputFormat struct {
+	// namespaceBase is the base URL of the document namespace (the syft one when nil)
+	namespaceBase *url.URL
+	// collapseOwned leaves out the packages owned by another package by file overlap
+	collapseOwned bool
+}
+
+func (f OutputFormat) ID() sbom.FormatID {
+	return ID
+}
+
+func (f OutputFormat) Decode(reader io.Reader) (*sbom.SBOM, error) {
+	return decoder(reader)
+}
+
+func (f OutputFormat) Encode(output io.Writer, s sbom.SBOM) error {
+	if f.collapseOwned {
+		s = common.CollapseOwnedPackages(s)
+	}
+	return encoder(output, s, f.namespaceBase)
+}
+
+func (f OutputFormat) Validate(reader io.Reader) error {
+	return validator(reader)
+}
+
+// WithOptions returns the format with the given options set, supporting "namespace" (the base URL of the document namespace)
+// and "collapse-owned" (leave out the packages owned by another package)
+func (f OutputFormat) WithOptions(options map[string]string) (sbom.Format, error) {
+	for key, value := range options {
+		switch key {
+		case spdxhelpers.NamespaceOption:
+			base, err := spdxhelpers.ParseNamespaceBase(value)
+			if err != nil {
+				return nil, err
+			}
+			f.namespaceBase = base
+		case common.CollapseOwnedOption:
+			collapse, err := common.ParseCollapseOwned(value)
+			if err != nil {
+				return nil, err
+			}
+			f.collapseOwned = collapse
+		default:
+			return nil, fmt.Errorf("unsupported option for %s format: %q", ID, key)
+		}
+	}
+	return f, nil
 }
@@ -2,6 +2,7 @@ package spdx22tagvalue
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -19,8 +20,22 @@ import (
 // toFormatModel creates and populates a new JSON document struct that follows the SPDX 2.2 spec from the given cataloging results.
 //
 //nolint:funlen
-func toFormatModel(s sbom.SBOM) *spdx.Document2_2 {
+func toFormatModel(s sbom.SBOM, namespaceBase *url.URL) *spdx.Document2_2 {
 	name, namespace := spdxhelpers.DocumentNameAndNamespace(s.Source)
+	if namespaceBase != nil {
+		namespace = spdxhelpers.DocumentNamespaceWithBase(namespaceBase, name, s.Source)
+	}
+
+	packages := toFormatPackages(s.Artifacts.PackageCatalog)
+	var relationships []*spdx.Relationship2_2
+	if root := spdxhelpers.ImageRootPackage(s.Source); root != nil {
+		packages[spdx.ElementID(root.ID)] = toRootPackage(*root)
+		relationships = append(relationships, &spdx.Relationship2_2{
+			RefA:         spdx.MakeDocElementID("", "DOCUMENT"),
+			RefB:         spdx.MakeDocElementID("", root.ID),
+			Relationship: string(spdxhelpers.DescribesRelationship),
+		})
+	}
 
 	return &spdx.Document2_2{
 		CreationInfo: &spdx.CreationInfo2_2{
@@ -84,12 +99,35 @@ func toFormatModel(s sbom.SBOM) *spdx.Document2_2 {
 
 			// 2.11: Document Comment
 			// Cardinality: optional, one
-			DocumentComment: "",
+			DocumentComment: spdxhelpers.DocumentComment(s.Source, s.Artifacts.Diagnostics),
 		},
-		Packages: toFormatPackages(s.Artifacts.PackageCatalog),
+		Packages:      packages,
+		OtherLicenses: toOtherLicenses(s.Artifacts.PackageCatalog),
+		Relationships: relationships,
 	}
 }
 
+// toOtherLicenses populates the Other Licensing Information for every LicenseRef- license referenced by the package
+// license expressions (see https://spdx.github.io/spdx-spec/6-other-licensing-information-detected/)
+func toOtherLicenses(catalog *pkg.Catalog) (licenses []*spdx.OtherLicense2_2) {
+	for _, l := range spdxhelpers.ExtractedLicenses(catalog) {
+		licenses = append(licenses, &spdx.OtherLicense2_2{
+			// 6.1: License Identifier: "LicenseRef-[idstring]"
+			// Cardinality: conditional (mandatory, one) if license is not on SPDX License List
+			LicenseIdentifier: l.ID,
+
+			// 6.2: Extracted Text
+			// Cardinality: conditional (mandatory, one) if there is a License Identifier assigned
+			ExtractedText: l.Text,
+
+			// 6.3: License Name: single line of text or "NOASSERTION"
+			// Cardinality: conditional (mandatory, one) if license is not on SPDX License List
+			LicenseName: l.Name,
+		})
+	}
+	return licenses
+}
+
 // packages populates all Package Information from the package Catalog (see https://spdx.github.io/spdx-spec/3-package-information/)
 //
 //nolint:funlen
@@ -100,10 +138,6 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 		// name should be guaranteed to be unique, but semantically useful and stable
 		id := spdxhelpers.SanitizeElementID(fmt.Sprintf("Package-%+v-%s-%s", p.Type, p.Name, p.ID()))
 
-		// If the Concluded License is not the same as the Declared License, a written explanation should be provided
-		// in the Comments on License field (section 3.16). With respect to NOASSERTION, a written explanation in
-		// the Comments on License field (section 3.16) is preferred.
-		license := spdxhelpers.License(p)
 		checksums, filesAnalyzed := toPackageChecksums(p)
 
 		results[spdx.ElementID(id)] = &spdx.Package2_2{
@@ -132,7 +166,7 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 			// 3.5: Package Supplier: may have single result for either Person or Organization,
 			//                        or NOASSERTION
 			// Cardinality: optional, one
-			PackageSupplierPerson:       "",
+			PackageSupplierPerson:       spdxhelpers.Supplier(p),
 			PackageSupplierOrganization: "",
 			PackageSupplierNOASSERTION:  false,
 
@@ -195,7 +229,7 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 
 			// 3.11: Package Home Page
 			// Cardinality: optional, one
-			PackageHomePage: "",
+			PackageHomePage: spdxhelpers.Homepage(p),
 
 			// 3.12: Source Information
 			// Cardinality: optional, one
@@ -205,7 +239,7 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 			// Cardinality: mandatory, one
 			// Purpose: Contain the license the SPDX file creator has concluded as governing the
 			// package or alternative values, if the governing license cannot be determined.
-			PackageLicenseConcluded: license,
+			PackageLicenseConcluded: spdxhelpers.ConcludedLicense(p),
 
 			// 3.14: All Licenses Info from Files: SPDX License Expression, "NONE" or "NOASSERTION"
 			// Cardinality: mandatory, one or many if filesAnalyzed is true / omitted;
@@ -217,11 +251,14 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 			// Purpose: List the licenses that have been declared by the authors of the package.
 			// Any license information that does not originate from the package authors, e.g. license
 			// information from a third party repository, should not be included in this field.
-			PackageLicenseDeclared: license,
+			PackageLicenseDeclared: spdxhelpers.DeclaredLicense(p),
 
 			// 3.16: Comments on License
 			// Cardinality: optional, one
-			PackageLicenseComments: "",
+			// If the Concluded License is not the same as the Declared License, a written explanation should be provided
+			// in the Comments on License field (section 3.16). With respect to NOASSERTION, a written explanation in
+			// the Comments on License field (section 3.16) is preferred.
+			PackageLicenseComments: spdxhelpers.LicenseComments(p),
 
 			// 3.17: Copyright Text: copyright notice(s) text, "NONE" or "NOASSERTION"
 			// Cardinality: mandatory, one
@@ -266,6 +303,25 @@ func toFormatPackages(catalog *pkg.Catalog) map[spdx.ElementID]*spdx.Package2_2
 	return results
 }
 
+// toRootPackage creates the package describing the subject of the document (see spdxhelpers.ImageRootPackage).
+func toRootPackage(p spdxhelpers.RootPackage) *spdx.Package2_2 {
+	return &spdx.Package2_2{
+		PackageName:                 p.Name,
+		PackageSPDXIdentifier:       spdx.ElementID(p.ID),
+		PackageVersion:              p.Version,
+		PackageSupplierOrganization: p.Supplier,
+		PackageDownloadLocation:     p.DownloadLocation,
+		FilesAnalyzed:               false,
+		IsFilesAnalyzedTagPresent:   true,
+		PackageHomePage:             p.Homepage,
+		PackageSourceInfo:           p.SourceInfo,
+		PackageLicenseConcluded:     spdxhelpers.NOASSERTION,
+		PackageLicenseDeclared:      p.LicenseDeclared,
+		PackageCopyrightText:        spdxhelpers.NOASSERTION,
+		PackageDescription:          p.Description,
+	}
+}
+
 func toPackageChecksums(p pkg.Package) (map[spdx.ChecksumAlgorithm]spdx.Checksum, bool) {
 	filesAnalyzed := false
 	checksums := map[spdx.ChecksumAlgorithm]spdx.Checksum{}
@@ -277,8 +333,9 @@ func toPackageChecksums(p pkg.Package) (map[spdx.ChecksumAlgorithm]spdx.Checksum
 		if len(meta.ArchiveDigests) > 0 {
 			filesAnalyzed = true
 			for _, digest := range meta.ArchiveDigests {
-				checksums[spdx.ChecksumAlgorithm(digest.Algorithm)] = spdx.Checksum{
-					Algorithm: spdx.ChecksumAlgorithm(digest.Algorithm),
+				algorithm := spdx.ChecksumAlgorithm(spdxhelpers.ChecksumAlgorithm(digest.Algorithm))
+				checksums[algorithm] = spdx.Checksum{
+					Algorithm: algorithm,
 					Value:     digest.Value,
 				}
 			}
@@ -50,7 +50,7 @@ func TestEncodeFullJSONDocument(t *testing.T) {
 		FoundBy:      "the-cataloger-1",
 		Language:     pkg.Python,
 		MetadataType: pkg.PythonPackageMetadataType,
-		Licenses:     []string{"MIT"},
+		Licenses:     pkg.NewLicensesFromValues("MIT"),
 		Metadata: pkg.PythonPackageMetadata{
 			Name:    "package-1",
 			Version: "1.0.1",
@@ -60,6 +60,12 @@ func TestEncodeFullJSONDocument(t *testing.T) {
 		CPEs: []pkg.CPE{
 			pkg.MustCPE("cpe:2.3:*:some:package:1:*:*:*:*:*:*:*"),
 		},
+		CPEAnnotations: map[string]pkg.CPEAnnotation{
+			"cpe:2.3:*:some:package:1:*:*:*:*:*:*:*": {
+				Source:     pkg.GeneratedCPESource,
+				Confidence: 0.5,
+			},
+		},
 	}
 
 	p2 := pkg.Package{
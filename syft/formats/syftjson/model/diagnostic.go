@@ -0,0 +1,7 @@
+package model
+
+// Diagnostic represents the failure of a cataloger, whose results are missing from the document.
+type Diagnostic struct {
+	Cataloger string `json:"cataloger"`
+	Message   string `json:"message"`
+}
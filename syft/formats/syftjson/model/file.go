@@ -6,19 +6,27 @@ import (
 )
 
 type File struct {
-	ID              string                `json:"id"`
-	Location        source.Coordinates    `json:"location"`
-	Metadata        *FileMetadataEntry    `json:"metadata,omitempty"`
-	Contents        string                `json:"contents,omitempty"`
-	Digests         []file.Digest         `json:"digests,omitempty"`
-	Classifications []file.Classification `json:"classifications,omitempty"`
+	ID               string                `json:"id"`
+	Location         source.Coordinates    `json:"location"`
+	Metadata         *FileMetadataEntry    `json:"metadata,omitempty"`
+	Contents         string                `json:"contents,omitempty"`
+	ContentsEncoding string                `json:"contentsEncoding,omitempty"`
+	Digests          []file.Digest         `json:"digests,omitempty"`
+	Classifications  []file.Classification `json:"classifications,omitempty"`
+	ELF              *file.ELFMetadata     `json:"elf,omitempty"`
+	Executable       *file.Executable      `json:"executable,omitempty"`
+	Secrets          []file.SearchResult   `json:"secrets,omitempty"`
 }
 
 type FileMetadataEntry struct {
-	Mode            int             `json:"mode"`
-	Type            source.FileType `json:"type"`
-	LinkDestination string          `json:"linkDestination,omitempty"`
-	UserID          int             `json:"userID"`
-	GroupID         int             `json:"groupID"`
-	MIMEType        string          `json:"mimeType"`
+	Mode               int               `json:"mode"`
+	Type               source.FileType   `json:"type"`
+	LinkDestination    string            `json:"linkDestination,omitempty"`
+	UserID             int               `json:"userID"`
+	GroupID            int               `json:"groupID"`
+	MIMEType           string            `json:"mimeType"`
+	Setuid             bool              `json:"setuid,omitempty"`
+	Setgid             bool              `json:"setgid,omitempty"`
+	Capabilities       []string          `json:"capabilities,omitempty"`
+	ExtendedAttributes map[string]string `json:"extendedAttributes,omitempty"`
 }
@@ -0,0 +1,18 @@
+package model
+
+// LayerHistory represents the package changes made by a single image layer (only for the per-layer scope).
+type LayerHistory struct {
+	Digest  string        `json:"digest"`
+	Index   int           `json:"index"`
+	Changes []LayerChange `json:"changes"`
+}
+
+// LayerChange represents a single package change made by an image layer.
+type LayerChange struct {
+	Type            string `json:"type"`
+	Name            string `json:"name"`
+	Version         string `json:"version"`
+	PreviousVersion string `json:"previousVersion,omitempty"`
+	PackageType     string `json:"packageType"`
+	PURL            string `json:"purl,omitempty"`
+}
@@ -0,0 +1,10 @@
+package model
+
+// MinimalImageReport describes an image without any operating system packages (e.g. a distroless or static image).
+type MinimalImageReport struct {
+	Kind               string   `json:"kind"`
+	Markers            []string `json:"markers,omitempty"`
+	Binaries           int      `json:"binaries"`
+	ClassifiedBinaries int      `json:"classifiedBinaries"`
+	Summary            string   `json:"summary"`
+}
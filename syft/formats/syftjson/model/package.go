@@ -20,16 +20,22 @@ type Package struct {
 
 // PackageBasicData contains non-ambiguous values (type-wise) from pkg.Package.
 type PackageBasicData struct {
-	ID        string               `json:"id"`
-	Name      string               `json:"name"`
-	Version   string               `json:"version"`
-	Type      pkg.Type             `json:"type"`
-	FoundBy   string               `json:"foundBy"`
-	Locations []source.Coordinates `json:"locations"`
-	Licenses  []string             `json:"licenses"`
-	Language  pkg.Language         `json:"language"`
-	CPEs      []string             `json:"cpes"`
-	PURL      string               `json:"purl"`
+	ID             string                       `json:"id"`
+	Name           string                       `json:"name"`
+	Version        string                       `json:"version"`
+	Type           pkg.Type                     `json:"type"`
+	FoundBy        string                       `json:"foundBy"`
+	Locations      []source.Coordinates         `json:"locations"`
+	Licenses       Licenses                     `json:"licenses"`
+	LicenseTexts   map[string]string            `json:"licenseTexts,omitempty"`
+	Language       pkg.Language                 `json:"language"`
+	CPEs           []string                     `json:"cpes"`
+	CPEAnnotations map[string]pkg.CPEAnnotation `json:"cpeAnnotations,omitempty"`
+	PURL           string                       `json:"purl"`
+	Layer          *pkg.LayerAttribution        `json:"layer,omitempty"`
+	Registry       *pkg.RegistryInfo            `json:"registry,omitempty"`
+	Evidence       []pkg.Evidence               `json:"evidence,omitempty"`
+	Confidence     pkg.Confidence               `json:"confidence,omitempty"`
 }
 
 // PackageCustomData contains ambiguous values (type-wise) from pkg.Package.
@@ -38,6 +44,26 @@ type PackageCustomData struct {
 	Metadata     interface{}      `json:"metadata,omitempty"`
 }
 
+// Licenses are the licenses of a package, which are described by plain license strings in documents prior to schema
+// version 5.0.0 (these are all considered to be declared by the package metadata).
+type Licenses []pkg.License
+
+// UnmarshalJSON is a custom unmarshaller for handling both license objects and plain license strings.
+func (l *Licenses) UnmarshalJSON(b []byte) error {
+	var values []string
+	if err := json.Unmarshal(b, &values); err == nil {
+		*l = pkg.NewLicensesFromValues(values...)
+		return nil
+	}
+
+	var objects []pkg.License
+	if err := json.Unmarshal(b, &objects); err != nil {
+		return err
+	}
+	*l = objects
+	return nil
+}
+
 // packageMetadataUnpacker is all values needed from Package to disambiguate ambiguous fields during json unmarshaling.
 type packageMetadataUnpacker struct {
 	MetadataType pkg.MetadataType `json:"metadataType"`
@@ -193,6 +219,102 @@ func unpackMetadata(p *Package, unpacker packageMetadataUnpacker) error {
 			return err
 		}
 		p.Metadata = payload
+	case pkg.BinaryMetadataType:
+		var payload pkg.BinaryMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.StaticLibraryMetadataType:
+		var payload pkg.StaticLibraryMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.LinuxKernelMetadataType:
+		var payload pkg.LinuxKernelMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.LinuxKernelModuleMetadataType:
+		var payload pkg.LinuxKernelModuleMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.FirmwareMetadataType:
+		var payload pkg.FirmwareMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.WasmMetadataType:
+		var payload pkg.WasmMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.HelmChartMetadataType:
+		var payload pkg.HelmChartMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.TerraformProviderMetadataType:
+		var payload pkg.TerraformProviderMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.TerraformModuleMetadataType:
+		var payload pkg.TerraformModuleMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.GitHubActionsUseMetadataType:
+		var payload pkg.GitHubActionsUseMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.VSCodeExtensionMetadataType:
+		var payload pkg.VSCodeExtensionMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.BrowserExtensionMetadataType:
+		var payload pkg.BrowserExtensionMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.WordpressMetadataType:
+		var payload pkg.WordpressMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.DrupalMetadataType:
+		var payload pkg.DrupalMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.AIModelMetadataType:
+		var payload pkg.AIModelMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
+	case pkg.ContainerImageMetadataType:
+		var payload pkg.ContainerImageMetadata
+		if err := json.Unmarshal(unpacker.Metadata, &payload); err != nil {
+			return err
+		}
+		p.Metadata = payload
 	default:
 		return errUnknownMetadataType
 	}
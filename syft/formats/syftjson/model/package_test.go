@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
 )
 
 func TestUnmarshalPackageGolang(t *testing.T) {
@@ -218,3 +219,45 @@ func Test_unpackMetadata(t *testing.T) {
 		})
 	}
 }
+
+func TestLicenses_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected Licenses
+	}{
+		{
+			name: "license strings",
+			data: `["MIT", "GPLv2+"]`,
+			expected: Licenses{
+				{Value: "MIT", SPDXExpression: "MIT", Type: pkg.DeclaredLicense},
+				{Value: "GPLv2+", SPDXExpression: "GPL-2.0-or-later", Type: pkg.DeclaredLicense},
+			},
+		},
+		{
+			name: "license objects",
+			data: `[{"value": "MIT", "spdxExpression": "MIT", "type": "concluded", "location": {"path": "/LICENSE"}, "confidence": 0.9}]`,
+			expected: Licenses{
+				{
+					Value:          "MIT",
+					SPDXExpression: "MIT",
+					Type:           pkg.ConcludedLicense,
+					Location:       &source.Coordinates{RealPath: "/LICENSE"},
+					Confidence:     0.9,
+				},
+			},
+		},
+		{
+			name: "no licenses",
+			data: `[]`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var obj Licenses
+			require.NoError(t, json.Unmarshal([]byte(tt.data), &obj))
+
+			assert.Equal(t, tt.expected, obj)
+		})
+	}
+}
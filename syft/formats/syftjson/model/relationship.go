@@ -1,8 +1,9 @@
 package model
 
 type Relationship struct {
-	Parent   string      `json:"parent"`
-	Child    string      `json:"child"`
-	Type     string      `json:"type"`
-	Metadata interface{} `json:"metadata,omitempty"`
+	Parent      string            `json:"parent"`
+	Child       string            `json:"child"`
+	Type        string            `json:"type"`
+	Metadata    interface{}       `json:"metadata,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
@@ -10,16 +10,20 @@ import (
 
 // Source object represents the thing that was cataloged
 type Source struct {
-	ID     string      `json:"id"`
-	Type   string      `json:"type"`
-	Target interface{} `json:"target"`
+	ID     string                 `json:"id"`
+	Type   string                 `json:"type"`
+	Target interface{}            `json:"target"`
+	Git    *source.GitMetadata    `json:"git,omitempty"`
+	Origin *source.OriginMetadata `json:"origin,omitempty"`
 }
 
 // sourceUnpacker is used to unmarshal Source objects
 type sourceUnpacker struct {
-	ID     string          `json:"id,omitempty"`
-	Type   string          `json:"type"`
-	Target json.RawMessage `json:"target"`
+	ID     string                 `json:"id,omitempty"`
+	Type   string                 `json:"type"`
+	Target json.RawMessage        `json:"target"`
+	Git    *source.GitMetadata    `json:"git,omitempty"`
+	Origin *source.OriginMetadata `json:"origin,omitempty"`
 }
 
 // UnmarshalJSON populates a source object from JSON bytes.
@@ -31,6 +35,8 @@ func (s *Source) UnmarshalJSON(b []byte) error {
 
 	s.Type = unpacker.Type
 	s.ID = unpacker.ID
+	s.Git = unpacker.Git
+	s.Origin = unpacker.Origin
 
 	switch s.Type {
 	case "directory", "file":
@@ -28,6 +28,9 @@ func ToFormatModel(s sbom.SBOM) model.Document {
 		ArtifactRelationships: toRelationshipModel(s.Relationships),
 		Files:                 toFile(s),
 		Secrets:               toSecrets(s.Artifacts.Secrets),
+		LayerHistory:          toLayerHistory(s.Artifacts.LayerHistory),
+		Diagnostics:           toDiagnostics(s.Artifacts.Diagnostics),
+		MinimalImage:          toMinimalImageReport(s.Artifacts.MinimalImage),
 		Source:                src,
 		Distro:                toLinuxReleaser(s.Artifacts.LinuxDistribution),
 		Descriptor:            toDescriptor(s.Descriptor),
@@ -60,6 +63,11 @@ func toLinuxReleaser(d *linux.Release) model.LinuxRelease {
 		BugReportURL:     d.BugReportURL,
 		PrivacyPolicyURL: d.PrivacyPolicyURL,
 		CPEName:          d.CPEName,
+		SupportEnd:       d.SupportEnd,
+		VendorName:       d.VendorName,
+		VendorURL:        d.VendorURL,
+		DocumentationURL: d.DocumentationURL,
+		Extras:           d.Extras,
 	}
 }
 
@@ -71,6 +79,59 @@ func toDescriptor(d sbom.Descriptor) model.Descriptor {
 	}
 }
 
+func toDiagnostics(diagnostics []sbom.Diagnostic) []model.Diagnostic {
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	results := make([]model.Diagnostic, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		results = append(results, model.Diagnostic{
+			Cataloger: d.Cataloger,
+			Message:   d.Message,
+		})
+	}
+	return results
+}
+
+func toMinimalImageReport(report *sbom.MinimalImageReport) *model.MinimalImageReport {
+	if report == nil {
+		return nil
+	}
+	return &model.MinimalImageReport{
+		Kind:               report.Kind,
+		Markers:            report.Markers,
+		Binaries:           report.Binaries,
+		ClassifiedBinaries: report.ClassifiedBinaries,
+		Summary:            report.Summary(),
+	}
+}
+
+func toLayerHistory(history []pkg.LayerHistory) []model.LayerHistory {
+	if len(history) == 0 {
+		return nil
+	}
+	results := make([]model.LayerHistory, 0, len(history))
+	for _, layer := range history {
+		changes := make([]model.LayerChange, 0, len(layer.Changes))
+		for _, c := range layer.Changes {
+			changes = append(changes, model.LayerChange{
+				Type:            string(c.Type),
+				Name:            c.Name,
+				Version:         c.Version,
+				PreviousVersion: c.PreviousVersion,
+				PackageType:     string(c.PackageType),
+				PURL:            c.PURL,
+			})
+		}
+		results = append(results, model.LayerHistory{
+			Digest:  layer.Digest,
+			Index:   layer.Index,
+			Changes: changes,
+		})
+	}
+	return results
+}
+
 func toSecrets(data map[source.Coordinates][]file.SearchResult) []model.Secrets {
 	results := make([]model.Secrets, 0)
 	for coordinates, secrets := range data {
@@ -107,18 +168,38 @@ func toFile(s sbom.SBOM) []model.File {
 			classifications = classificationsForLocation
 		}
 
-		var contents string
+		var elfMetadata *file.ELFMetadata
+		if elfForLocation, exists := artifacts.FileELFMetadata[coordinates]; exists {
+			elfMetadata = &elfForLocation
+		}
+
+		var executable *file.Executable
+		if executableForLocation, exists := artifacts.Executables[coordinates]; exists {
+			executable = &executableForLocation
+		}
+
+		var secrets []file.SearchResult
+		if secretsForLocation, exists := artifacts.Secrets[coordinates]; exists {
+			secrets = secretsForLocation
+		}
+
+		var contents, contentsEncoding string
 		if contentsForLocation, exists := artifacts.FileContents[coordinates]; exists {
 			contents = contentsForLocation
+			contentsEncoding = string(artifacts.FileContentsEncoding)
 		}
 
 		results = append(results, model.File{
-			ID:              string(coordinates.ID()),
-			Location:        coordinates,
-			Metadata:        toFileMetadataEntry(coordinates, metadata),
-			Digests:         digests,
-			Classifications: classifications,
-			Contents:        contents,
+			ID:               string(coordinates.ID()),
+			Location:         coordinates,
+			Metadata:         toFileMetadataEntry(coordinates, metadata),
+			Digests:          digests,
+			Classifications:  classifications,
+			Contents:         contents,
+			ContentsEncoding: contentsEncoding,
+			ELF:              elfMetadata,
+			Executable:       executable,
+			Secrets:          secrets,
 		})
 	}
 
@@ -141,12 +222,16 @@ func toFileMetadataEntry(coordinates source.Coordinates, metadata *source.FileMe
 	}
 
 	return &model.FileMetadataEntry{
-		Mode:            mode,
-		Type:            metadata.Type,
-		LinkDestination: metadata.LinkDestination,
-		UserID:          metadata.UserID,
-		GroupID:         metadata.GroupID,
-		MIMEType:        metadata.MIMEType,
+		Mode:               mode,
+		Type:               metadata.Type,
+		LinkDestination:    metadata.LinkDestination,
+		UserID:             metadata.UserID,
+		GroupID:            metadata.GroupID,
+		MIMEType:           metadata.MIMEType,
+		Setuid:             metadata.IsSetuid(),
+		Setgid:             metadata.IsSetgid(),
+		Capabilities:       metadata.Capabilities,
+		ExtendedAttributes: metadata.ExtendedAttributes,
 	}
 }
 
@@ -168,7 +253,7 @@ func toPackageModel(p pkg.Package) model.Package {
 		cpes[i] = pkg.CPEString(c)
 	}
 
-	var licenses = make([]string, 0)
+	var licenses = make([]pkg.License, 0)
 	if p.Licenses != nil {
 		licenses = p.Licenses
 	}
@@ -181,16 +266,22 @@ func toPackageModel(p pkg.Package) model.Package {
 
 	return model.Package{
 		PackageBasicData: model.PackageBasicData{
-			ID:        string(p.ID()),
-			Name:      p.Name,
-			Version:   p.Version,
-			Type:      p.Type,
-			FoundBy:   p.FoundBy,
-			Locations: coordinates,
-			Licenses:  licenses,
-			Language:  p.Language,
-			CPEs:      cpes,
-			PURL:      p.PURL,
+			ID:             string(p.ID()),
+			Name:           p.Name,
+			Version:        p.Version,
+			Type:           p.Type,
+			FoundBy:        p.FoundBy,
+			Locations:      coordinates,
+			Licenses:       licenses,
+			LicenseTexts:   p.LicenseTexts,
+			Language:       p.Language,
+			CPEs:           cpes,
+			CPEAnnotations: p.CPEAnnotations,
+			PURL:           p.PURL,
+			Layer:          p.Layer,
+			Registry:       p.Registry,
+			Evidence:       p.Evidence,
+			Confidence:     p.Confidence,
 		},
 		PackageCustomData: model.PackageCustomData{
 			MetadataType: p.MetadataType,
@@ -203,10 +294,11 @@ func toRelationshipModel(relationships []artifact.Relationship) []model.Relation
 	result := make([]model.Relationship, len(relationships))
 	for i, r := range relationships {
 		result[i] = model.Relationship{
-			Parent:   string(r.From.ID()),
-			Child:    string(r.To.ID()),
-			Type:     string(r.Type),
-			Metadata: r.Data,
+			Parent:      string(r.From.ID()),
+			Child:       string(r.To.ID()),
+			Type:        string(r.Type),
+			Metadata:    r.Data,
+			Annotations: r.Annotations,
 		}
 	}
 	sort.Slice(result, func(i, j int) bool {
@@ -243,12 +335,14 @@ func toSourceModel(src source.Metadata) (model.Source, error) {
 			ID:     src.ID,
 			Type:   "directory",
 			Target: src.Path,
+			Git:    src.Git,
 		}, nil
 	case source.FileScheme:
 		return model.Source{
 			ID:     src.ID,
 			Type:   "file",
 			Target: src.Path,
+			Origin: src.Origin,
 		}, nil
 	default:
 		return model.Source{}, fmt.Errorf("unsupported source: %q", src.Scheme)
@@ -21,6 +21,9 @@ func toSyftModel(doc model.Document) (*sbom.SBOM, error) {
 		Artifacts: sbom.Artifacts{
 			PackageCatalog:    catalog,
 			LinuxDistribution: toSyftLinuxRelease(doc.Distro),
+			LayerHistory:      toSyftLayerHistory(doc.LayerHistory),
+			Diagnostics:       toSyftDiagnostics(doc.Diagnostics),
+			MinimalImage:      toSyftMinimalImageReport(doc.MinimalImage),
 		},
 		Source:        *toSyftSourceData(doc.Source),
 		Descriptor:    toSyftDescriptor(doc.Descriptor),
@@ -28,6 +31,58 @@ func toSyftModel(doc model.Document) (*sbom.SBOM, error) {
 	}, nil
 }
 
+func toSyftDiagnostics(diagnostics []model.Diagnostic) []sbom.Diagnostic {
+	if len(diagnostics) == 0 {
+		return nil
+	}
+	results := make([]sbom.Diagnostic, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		results = append(results, sbom.Diagnostic{
+			Cataloger: d.Cataloger,
+			Message:   d.Message,
+		})
+	}
+	return results
+}
+
+func toSyftMinimalImageReport(report *model.MinimalImageReport) *sbom.MinimalImageReport {
+	if report == nil {
+		return nil
+	}
+	return &sbom.MinimalImageReport{
+		Kind:               report.Kind,
+		Markers:            report.Markers,
+		Binaries:           report.Binaries,
+		ClassifiedBinaries: report.ClassifiedBinaries,
+	}
+}
+
+func toSyftLayerHistory(history []model.LayerHistory) []pkg.LayerHistory {
+	if len(history) == 0 {
+		return nil
+	}
+	results := make([]pkg.LayerHistory, 0, len(history))
+	for _, layer := range history {
+		var changes []pkg.LayerChange
+		for _, c := range layer.Changes {
+			changes = append(changes, pkg.LayerChange{
+				Type:            pkg.LayerChangeType(c.Type),
+				Name:            c.Name,
+				Version:         c.Version,
+				PreviousVersion: c.PreviousVersion,
+				PackageType:     pkg.Type(c.PackageType),
+				PURL:            c.PURL,
+			})
+		}
+		results = append(results, pkg.LayerHistory{
+			Digest:  layer.Digest,
+			Index:   layer.Index,
+			Changes: changes,
+		})
+	}
+	return results
+}
+
 func toSyftLinuxRelease(d model.LinuxRelease) *linux.Release {
 	if cmp.Equal(d, model.LinuxRelease{}) {
 		return nil
@@ -50,6 +105,11 @@ func toSyftLinuxRelease(d model.LinuxRelease) *linux.Release {
 		BugReportURL:     d.BugReportURL,
 		PrivacyPolicyURL: d.PrivacyPolicyURL,
 		CPEName:          d.CPEName,
+		SupportEnd:       d.SupportEnd,
+		VendorName:       d.VendorName,
+		VendorURL:        d.VendorURL,
+		DocumentationURL: d.DocumentationURL,
+		Extras:           d.Extras,
 	}
 }
 
@@ -67,6 +127,13 @@ func toSyftRelationships(doc *model.Document, catalog *pkg.Catalog, relationship
 	// set source metadata in identifier map
 	idMap[doc.Source.ID] = toSyftSource(doc.Source)
 
+	// image layers are referenced by the packages they introduced
+	if imageMetadata, ok := doc.Source.Target.(source.ImageMetadata); ok {
+		for _, l := range imageMetadata.Layers {
+			idMap[l.Digest] = l
+		}
+	}
+
 	for _, f := range doc.Files {
 		idMap[f.ID] = f.Location
 	}
@@ -116,15 +183,28 @@ func toSyftRelationship(idMap map[string]interface{}, relationship model.Relatio
 	case artifact.OwnershipByFileOverlapRelationship:
 		fallthrough
 	case artifact.ContainsRelationship:
+		fallthrough
+	case artifact.IntroducesRelationship:
+		fallthrough
+	case artifact.DescribedByRelationship:
+		fallthrough
+	case artifact.DependencyOfRelationship:
+		fallthrough
+	case artifact.RuntimeDependencyOfRelationship:
+		fallthrough
+	case artifact.DevDependencyOfRelationship:
+		fallthrough
+	case artifact.BuildDependencyOfRelationship:
 	default:
 		log.Warnf("unknown relationship type: %s", typ)
 		return nil
 	}
 	return &artifact.Relationship{
-		From: from,
-		To:   to,
-		Type: typ,
-		Data: relationship.Metadata,
+		From:        from,
+		To:          to,
+		Type:        typ,
+		Data:        relationship.Metadata,
+		Annotations: relationship.Annotations,
 	}
 }
 
@@ -143,12 +223,14 @@ func toSyftSourceData(s model.Source) *source.Metadata {
 			ID:     s.ID,
 			Scheme: source.DirectoryScheme,
 			Path:   s.Target.(string),
+			Git:    s.Git,
 		}
 	case "file":
 		return &source.Metadata{
 			ID:     s.ID,
 			Scheme: source.FileScheme,
 			Path:   s.Target.(string),
+			Origin: s.Origin,
 		}
 	case "image":
 		return &source.Metadata{
@@ -186,17 +268,23 @@ func toSyftPackage(p model.Package, idAliases map[string]string) pkg.Package {
 	}
 
 	out := pkg.Package{
-		Name:         p.Name,
-		Version:      p.Version,
-		FoundBy:      p.FoundBy,
-		Locations:    source.NewLocationSet(locations...),
-		Licenses:     p.Licenses,
-		Language:     p.Language,
-		Type:         p.Type,
-		CPEs:         cpes,
-		PURL:         p.PURL,
-		MetadataType: p.MetadataType,
-		Metadata:     p.Metadata,
+		Name:           p.Name,
+		Version:        p.Version,
+		FoundBy:        p.FoundBy,
+		Locations:      source.NewLocationSet(locations...),
+		Licenses:       p.Licenses,
+		LicenseTexts:   p.LicenseTexts,
+		Language:       p.Language,
+		Type:           p.Type,
+		CPEs:           cpes,
+		CPEAnnotations: p.CPEAnnotations,
+		PURL:           p.PURL,
+		MetadataType:   p.MetadataType,
+		Metadata:       p.Metadata,
+		Layer:          p.Layer,
+		Registry:       p.Registry,
+		Evidence:       p.Evidence,
+		Confidence:     p.Confidence,
 	}
 
 	// we don't know if this package ID is truly unique, however, we need to trust the user input in case there are
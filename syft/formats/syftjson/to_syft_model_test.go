@@ -5,9 +5,12 @@ import (
 
 	"github.com/scylladb/go-set/strset"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/formats/syftjson/model"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -124,3 +127,82 @@ func Test_idsHaveChanged(t *testing.T) {
 	assert.NotNil(t, to)
 	assert.Equal(t, "pkg-2", to.Name)
 }
+
+func Test_diagnosticsRoundTrip(t *testing.T) {
+	diagnostics := []sbom.Diagnostic{
+		{Cataloger: "rpm-db-cataloger", Message: "panic: runtime error"},
+	}
+
+	doc := ToFormatModel(sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(),
+			Diagnostics:    diagnostics,
+		},
+		Source: source.Metadata{Scheme: source.FileScheme, Path: "some/path"},
+	})
+	assert.Equal(t, []model.Diagnostic{{Cataloger: "rpm-db-cataloger", Message: "panic: runtime error"}}, doc.Diagnostics)
+
+	s, err := toSyftModel(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, diagnostics, s.Artifacts.Diagnostics)
+}
+
+func Test_minimalImageRoundTrip(t *testing.T) {
+	report := &sbom.MinimalImageReport{
+		Kind:               sbom.DistrolessImage,
+		Markers:            []string{"/var/lib/dpkg/status.d/base"},
+		Binaries:           3,
+		ClassifiedBinaries: 2,
+	}
+
+	doc := ToFormatModel(sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(),
+			MinimalImage:   report,
+		},
+		Source: source.Metadata{Scheme: source.FileScheme, Path: "some/path"},
+	})
+	require.NotNil(t, doc.MinimalImage)
+	assert.Equal(t, "no package manager database found, 2 of 3 binaries classified", doc.MinimalImage.Summary)
+
+	s, err := toSyftModel(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, report, s.Artifacts.MinimalImage)
+}
+
+func Test_relationshipAnnotationsRoundTrip(t *testing.T) {
+	p1 := pkg.Package{Name: "requests", Version: "2.25.1"}
+	p1.SetID()
+	p2 := pkg.Package{Name: "urllib3", Version: "1.26.5"}
+	p2.SetID()
+	annotations := map[string]string{
+		"evidence": "requirements.txt:3",
+		"source":   "enrichment-pipeline",
+	}
+
+	doc := ToFormatModel(sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(p1, p2),
+		},
+		Relationships: []artifact.Relationship{
+			{
+				From:        p2,
+				To:          p1,
+				Type:        artifact.DependencyOfRelationship,
+				Annotations: annotations,
+			},
+		},
+		Source: source.Metadata{Scheme: source.FileScheme, Path: "some/path"},
+	})
+	require.Len(t, doc.ArtifactRelationships, 1)
+	assert.Equal(t, annotations, doc.ArtifactRelationships[0].Annotations)
+
+	s, err := toSyftModel(doc)
+	require.NoError(t, err)
+	require.Len(t, s.Relationships, 1)
+	r := s.Relationships[0]
+	assert.Equal(t, artifact.DependencyOfRelationship, r.Type)
+	assert.Equal(t, "urllib3", r.From.(pkg.Package).Name)
+	assert.Equal(t, "requests", r.To.(pkg.Package).Name)
+	assert.Equal(t, annotations, r.Annotations)
+}
@@ -4,14 +4,16 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
 	"github.com/mitchellh/go-homedir"
 )
 
-func makeTemplateExecutor(templateFilePath string) (*template.Template, error) {
+func makeTemplateExecutor(templateFilePath, includesDir, outputDir string) (*template.Template, error) {
 	if templateFilePath == "" {
 		return nil, errors.New("no template file: please provide a template path")
 	}
@@ -27,16 +29,52 @@ func makeTemplateExecutor(templateFilePath string) (*template.Template, error) {
 	}
 
 	templateName := expandedPathToTemplateFile
-	tmpl, err := template.New(templateName).Funcs(funcMap).Parse(string(templateContents))
+	tmpl := template.New(templateName)
+	tmpl, err = tmpl.Funcs(funcMap(tmpl, outputDir)).Parse(string(templateContents))
 	if err != nil {
 		return nil, fmt.Errorf("unable to parse template: %w", err)
 	}
 
+	if includesDir != "" {
+		tmpl, err = parseIncludes(tmpl, includesDir)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return tmpl, nil
+}
+
+// parseIncludes adds each file of the given directory as a template named after the file (e.g. "header.tmpl"), which
+// can be rendered from the main template with {{ template "header.tmpl" . }} or {{ include "header.tmpl" . }}.
+func parseIncludes(tmpl *template.Template, includesDir string) (*template.Template, error) {
+	expandedIncludesDir, err := homedir.Expand(includesDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to expand path %s", includesDir)
+	}
+
+	entries, err := os.ReadDir(expandedIncludesDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read template includes: %w", err)
+	}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		contents, err := os.ReadFile(filepath.Join(expandedIncludesDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("unable to get template include content: %w", err)
+		}
+		if _, err := tmpl.New(entry.Name()).Parse(string(contents)); err != nil {
+			return nil, fmt.Errorf("unable to parse template include %s: %w", entry.Name(), err)
+		}
+	}
 	return tmpl, nil
 }
 
 // These are custom functions available to template authors.
-var funcMap = func() template.FuncMap {
+func funcMap(tmpl *template.Template, outputDir string) template.FuncMap {
 	f := sprig.HermeticTxtFuncMap()
 	f["getLastIndex"] = func(collection interface{}) int {
 		if v := reflect.ValueOf(collection); v.Kind() == reflect.Slice {
@@ -45,5 +83,67 @@ var funcMap = func() template.FuncMap {
 
 		return 0
 	}
+	f["groupBy"] = groupBy
+	// include renders a named template to a string, so that the result can be piped to other functions (unlike the
+	// "template" action, which writes straight to the output)
+	f["include"] = func(name string, data interface{}) (string, error) {
+		var buf strings.Builder
+		if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+			return "", err
+		}
+		return buf.String(), nil
+	}
+	f["writeFile"] = func(path, contents string) (string, error) {
+		return "", writeFile(outputDir, path, contents)
+	}
 	return f
-}()
+}
+
+// groupBy groups the elements of a slice by the value of the given field (e.g. {{ groupBy .Artifacts "Type" }}),
+// returning a map of each value to the elements that have it.
+func groupBy(collection interface{}, field string) (map[string][]interface{}, error) {
+	v := reflect.ValueOf(collection)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("groupBy: expected a list, got %T", collection)
+	}
+
+	groups := make(map[string][]interface{})
+	for i := 0; i < v.Len(); i++ {
+		element := v.Index(i)
+		item := reflect.Indirect(element)
+		if item.Kind() == reflect.Interface {
+			item = reflect.Indirect(item.Elem())
+		}
+		if item.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("groupBy: expected a list of objects, got %s", item.Kind())
+		}
+		value := item.FieldByName(field)
+		if !value.IsValid() {
+			return nil, fmt.Errorf("groupBy: %s has no field %q", item.Type(), field)
+		}
+		key := fmt.Sprint(value.Interface())
+		groups[key] = append(groups[key], element.Interface())
+	}
+	return groups, nil
+}
+
+// writeFile writes the given contents to a file at the given path relative to the output directory, so that a single
+// template can produce several files (e.g. one per package type).
+func writeFile(outputDir, path, contents string) error {
+	cleaned := filepath.Clean(filepath.FromSlash(path))
+	if filepath.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("writeFile: path %q must be relative to the output directory", path)
+	}
+	if outputDir == "" {
+		outputDir = "."
+	}
+
+	fullPath := filepath.Join(outputDir, cleaned)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("writeFile: unable to create directory: %w", err)
+	}
+	if err := os.WriteFile(fullPath, []byte(contents), 0644); err != nil { //nolint:gosec
+		return fmt.Errorf("writeFile: unable to write %s: %w", fullPath, err)
+	}
+	return nil
+}
@@ -1,10 +1,14 @@
 package template
 
 import (
+	"bytes"
 	"flag"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/formats/common/testutils"
 )
@@ -28,3 +32,54 @@ func TestFormatWithoutOptions(t *testing.T) {
 	err := f.Encode(nil, testutils.DirectoryInput(t))
 	assert.ErrorContains(t, err, "no template file: please provide a template path")
 }
+
+func TestFormatWithIncludesAndFiles(t *testing.T) {
+	outputDir := t.TempDir()
+	f := OutputFormat{}
+	f.SetTemplatePath("test-fixtures/by-type.template")
+	f.SetIncludesDir("test-fixtures/includes")
+	f.SetOutputDir(outputDir)
+
+	var buf bytes.Buffer
+	require.NoError(t, f.Encode(&buf, testutils.DirectoryInput(t)))
+	assert.Equal(t, "deb: 1\npython: 1\n", buf.String())
+
+	expected := map[string]string{
+		"deb/packages.csv":    "\"Package\",\"Version Installed\"\n\"package-2\",\"2.0.1\"\n",
+		"python/packages.csv": "\"Package\",\"Version Installed\"\n\"package-1\",\"1.0.1\"\n",
+	}
+	for path, contents := range expected {
+		actual, err := os.ReadFile(filepath.Join(outputDir, path))
+		require.NoError(t, err)
+		assert.Equal(t, contents, string(actual), path)
+	}
+}
+
+func TestWriteFile_OutsideOutputDir(t *testing.T) {
+	outputDir := t.TempDir()
+	for _, path := range []string{"../report.csv", "a/../../report.csv", "/tmp/report.csv", ".."} {
+		t.Run(path, func(t *testing.T) {
+			assert.ErrorContains(t, writeFile(outputDir, path, "contents"), "must be relative to the output directory")
+		})
+	}
+	assert.NoError(t, writeFile(outputDir, "a/../report.csv", "contents"))
+	assert.FileExists(t, filepath.Join(outputDir, "report.csv"))
+}
+
+func TestGroupBy(t *testing.T) {
+	type item struct {
+		Name string
+		Type string
+	}
+	a, b, c := item{Name: "a", Type: "x"}, item{Name: "b", Type: "y"}, &item{Name: "c", Type: "x"}
+
+	groups, err := groupBy([]interface{}{a, b, c}, "Type")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]interface{}{"x": {a, c}, "y": {b}}, groups)
+
+	_, err = groupBy([]item{a}, "Missing")
+	assert.ErrorContains(t, err, `has no field "Missing"`)
+
+	_, err = groupBy("not a list", "Type")
+	assert.ErrorContains(t, err, "expected a list")
+}
@@ -1,6 +1,7 @@
 package template
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/anchore/syft/syft/formats/syftjson"
@@ -13,10 +14,12 @@ func Format() sbom.Format {
 	return OutputFormat{}
 }
 
-// implementation of sbom.Format interface
+// implementation of sbom.ConfigurableFormat interface
 // to make use of format options
 type OutputFormat struct {
 	templateFilePath string
+	includesDir      string
+	outputDir        string
 }
 
 func (f OutputFormat) ID() sbom.FormatID {
@@ -28,7 +31,7 @@ func (f OutputFormat) Decode(reader io.Reader) (*sbom.SBOM, error) {
 }
 
 func (f OutputFormat) Encode(output io.Writer, s sbom.SBOM) error {
-	tmpl, err := makeTemplateExecutor(f.templateFilePath)
+	tmpl, err := makeTemplateExecutor(f.templateFilePath, f.includesDir, f.outputDir)
 	if err != nil {
 		return err
 	}
@@ -45,3 +48,29 @@ func (f OutputFormat) Validate(reader io.Reader) error {
 func (f *OutputFormat) SetTemplatePath(filePath string) {
 	f.templateFilePath = filePath
 }
+
+// SetIncludesDir sets the directory of templates that the template file can include
+func (f *OutputFormat) SetIncludesDir(dir string) {
+	f.includesDir = dir
+}
+
+// SetOutputDir sets the directory that files written by the template (with writeFile) are relative to
+func (f *OutputFormat) SetOutputDir(dir string) {
+	f.outputDir = dir
+}
+
+// WithOptions returns the format with the given options set, supporting "template" (the template file) and "dir" (the
+// directory of templates the template file can include)
+func (f OutputFormat) WithOptions(options map[string]string) (sbom.Format, error) {
+	for key, value := range options {
+		switch key {
+		case "template":
+			f.SetTemplatePath(value)
+		case "dir":
+			f.SetIncludesDir(value)
+		default:
+			return nil, fmt.Errorf("unsupported option for %s format: %q", ID, key)
+		}
+	}
+	return f, nil
+}
@@ -20,6 +20,24 @@ func encoder(output io.Writer, s sbom.SBOM) error {
 	case source.ImageScheme:
 		fmt.Fprintln(w, "[Image]")
 
+		image := s.Source.ImageMetadata
+		fmt.Fprintln(w, " Digest:\t", image.ManifestDigest)
+		fmt.Fprintln(w, " MediaType:\t", image.MediaType)
+		if image.ConfigMediaType != "" {
+			fmt.Fprintln(w, " ConfigMediaType:\t", image.ConfigMediaType)
+		}
+		for _, tag := range image.Tags {
+			fmt.Fprintln(w, " Tag:\t", tag)
+		}
+		for _, repoDigest := range image.RepoDigests {
+			fmt.Fprintln(w, " RepoDigest:\t", repoDigest)
+		}
+		if image.RegistryHost != "" {
+			fmt.Fprintln(w, " Registry:\t", image.RegistryHost)
+		}
+		fmt.Fprintln(w)
+		w.Flush()
+
 		for idx, l := range s.Source.ImageMetadata.Layers {
 			fmt.Fprintln(w, " Layer:\t", idx)
 			fmt.Fprintln(w, " Digest:\t", l.Digest)
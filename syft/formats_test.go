@@ -12,6 +12,8 @@ import (
 	"github.com/anchore/syft/syft/formats/cyclonedxjson"
 	"github.com/anchore/syft/syft/formats/cyclonedxxml"
 	"github.com/anchore/syft/syft/formats/github"
+	"github.com/anchore/syft/syft/formats/html"
+	"github.com/anchore/syft/syft/formats/markdown"
 	"github.com/anchore/syft/syft/formats/spdx22json"
 	"github.com/anchore/syft/syft/formats/spdx22tagvalue"
 	"github.com/anchore/syft/syft/formats/syftjson"
@@ -45,7 +47,7 @@ func TestIdentify(t *testing.T) {
 }
 
 func TestFormats_EmptyInput(t *testing.T) {
-	for _, format := range formats {
+	for _, format := range Formats() {
 		t.Run(format.ID().String(), func(t *testing.T) {
 			t.Run("format.Decode", func(t *testing.T) {
 				input := bytes.NewReader(nil)
@@ -187,6 +189,28 @@ func TestFormatByName(t *testing.T) {
 			name: "template",
 			want: template.ID,
 		},
+
+		// Syft HTML
+		{
+			name: "html",
+			want: html.ID,
+		},
+
+		{
+			name: "syft-html",
+			want: html.ID,
+		},
+
+		// Syft Markdown
+		{
+			name: "markdown",
+			want: markdown.ID,
+		},
+
+		{
+			name: "md",
+			want: markdown.ID,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -200,3 +224,36 @@ func TestFormatByName(t *testing.T) {
 		})
 	}
 }
+
+func TestRegisterFormat(t *testing.T) {
+	original := Formats()
+	t.Cleanup(func() {
+		formatRegistry.Lock()
+		formatRegistry.formats = original
+		formatRegistry.Unlock()
+	})
+
+	custom := sbom.NewFormat("custom-1", func(w io.Writer, _ sbom.SBOM) error {
+		_, err := io.WriteString(w, "custom")
+		return err
+	}, nil, func(r io.Reader) error {
+		by, err := io.ReadAll(r)
+		if err != nil {
+			return err
+		}
+		if string(by) != "custom" {
+			return io.ErrUnexpectedEOF
+		}
+		return nil
+	})
+	RegisterFormat(custom)
+
+	assert.Len(t, Formats(), len(original)+1)
+	assert.Contains(t, FormatIDs(), sbom.FormatID("custom-1"))
+	assert.Equal(t, custom, FormatByName("Custom_1"))
+	assert.Equal(t, custom, IdentifyFormat([]byte("custom")))
+
+	// formats with the same ID are replaced
+	RegisterFormat(sbom.NewFormat("custom-1", nil, nil, nil))
+	assert.Len(t, Formats(), len(original)+1)
+}
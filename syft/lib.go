@@ -13,11 +13,27 @@ throughout the main execution path. This abstraction allows for decoupling of wh
 image, a filesystem, etc) and how it is cataloged (the individual catalogers).
 
 Similar to the cataloging process, Linux distribution identification is also performed based on what is discovered within the image.
+
+Most applications embedding syft only need the following entrypoints of this package:
+
+  - CreateSBOM catalogs a source.Source (see source.New) into an sbom.SBOM
+  - Encode and Decode convert an sbom.SBOM to and from an SBOM document
+  - FormatByName, FormatByID, Formats, and RegisterFormat select (or add) the formats to encode with
+
+These entrypoints, along with the types they accept and return (within the syft/sbom, syft/source, syft/pkg,
+syft/artifact, syft/linux, and syft/file packages) and the cataloger.Config, follow semantic versioning: they are
+not changed incompatibly within a major version. Everything within the internal and cmd directories may change in any
+release and must not be relied upon.
 */
 package syft
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
 
 	"github.com/wagoodman/go-partybus"
 
@@ -25,58 +41,391 @@ import (
 	"github.com/anchore/syft/internal/bus"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/event/monitor"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/pkg/cataloger/cache"
+	"github.com/anchore/syft/syft/pkg/enrich"
 	"github.com/anchore/syft/syft/source"
 )
 
 // CatalogPackages takes an inventory of packages from the given image from a particular perspective
 // (e.g. squashed source, all-layers source). Returns the discovered  set of packages, the identified Linux
-// distribution, and the source object used to wrap the data source.
-func CatalogPackages(src *source.Source, cfg cataloger.Config) (*pkg.Catalog, []artifact.Relationship, *linux.Release, error) {
+// distribution, and the source object used to wrap the data source. When some catalogers fail, the results of all
+// other catalogers are returned along with a *cataloger.PartialResultsError (see cataloger.IsPartialResults).
+func CatalogPackages(src *source.Source, cfg cataloger.Config, opts ...CatalogOption) (*pkg.Catalog, []artifact.Relationship, *linux.Release, error) {
+	return CatalogPackagesWithContext(context.Background(), src, cfg, opts...)
+}
+
+// CatalogPackagesWithContext catalogs the packages of the given source as with CatalogPackages, stopping when the given
+// context is canceled (or its deadline passes). The running catalogers return (removing any temporary files they
+// created) before the error of the context is returned, without any results.
+func CatalogPackagesWithContext(ctx context.Context, src *source.Source, cfg cataloger.Config, opts ...CatalogOption) (*pkg.Catalog, []artifact.Relationship, *linux.Release, error) {
+	cfg = applyCatalogOptions(cfg, opts)
+	if cfg.Search.Scope == source.PerLayerScope && src.Metadata.Scheme == source.ImageScheme {
+		catalog, relationships, release, _, err := CatalogPackagesPerLayerWithContext(ctx, src, cfg)
+		return catalog, relationships, release, err
+	}
+
 	resolver, err := src.FileResolver(cfg.Search.Scope)
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("unable to determine resolver while cataloging packages: %w", err)
 	}
 
 	// find the distro
+	release := identifyRelease(resolver)
+
+	catalogers, err := selectCatalogers(src, cfg)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	catalogOpts := cfg.Options(time.Now())
+
+	var catalog *pkg.Catalog
+	var relationships []artifact.Relationship
+	if src.Metadata.Scheme == source.ImageScheme {
+		// the results only depend on the image layers, so they can be reused for any image with the same layers
+		key := layersCacheKey(cfg, cfg.Search.Scope, src.Exclusions, src.Metadata.ImageMetadata.Layers, catalogers)
+		catalog, relationships, err = catalogWithCache(ctx, newCatalogCache(cfg), key, resolver, release, cfg, catalogOpts, catalogers)
+	} else if src.Metadata.Scheme == source.DirectoryScheme && cfg.Incremental {
+		catalog, relationships, err = catalogIncrementally(ctx, src, resolver, release, cfg, catalogOpts, catalogers)
+	} else {
+		catalog, relationships, err = cataloger.CatalogWithContext(ctx, resolver, release, catalogOpts, catalogers...)
+	}
+	if err != nil && !cataloger.IsPartialResults(err) {
+		return nil, nil, nil, err
+	}
+
+	catalog, relationships = finalizeCatalog(ctx, src, cfg, catalog, relationships)
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, nil, nil, fmt.Errorf("cataloging canceled: %w", ctxErr)
+	}
+
+	return catalog, relationships, release, err
+}
+
+// CatalogPackagesPerLayer takes an inventory of packages visible from each layer of the given image independently
+// (the squashed representation of the layer and all layers below it). Returns the packages, relationships, and Linux
+// distribution of the final layer (as seen from within the container at runtime), along with the history of package
+// changes made by each layer. Sources that are not images are cataloged as with CatalogPackages, without any history.
+func CatalogPackagesPerLayer(src *source.Source, cfg cataloger.Config, opts ...CatalogOption) (*pkg.Catalog, []artifact.Relationship, *linux.Release, []pkg.LayerHistory, error) {
+	return CatalogPackagesPerLayerWithContext(context.Background(), src, cfg, opts...)
+}
+
+// CatalogPackagesPerLayerWithContext catalogs the packages of each image layer as with CatalogPackagesPerLayer,
+// stopping when the given context is canceled as with CatalogPackagesWithContext.
+func CatalogPackagesPerLayerWithContext(ctx context.Context, src *source.Source, cfg cataloger.Config, opts ...CatalogOption) (*pkg.Catalog, []artifact.Relationship, *linux.Release, []pkg.LayerHistory, error) {
+	cfg = applyCatalogOptions(cfg, opts)
+	if src.Metadata.Scheme != source.ImageScheme {
+		catalog, relationships, release, err := CatalogPackagesWithContext(ctx, src, cfg)
+		return catalog, relationships, release, nil, err
+	}
+
+	catalogers, err := selectCatalogers(src, cfg)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+
+	layers := src.Metadata.ImageMetadata.Layers
+	if len(layers) == 0 {
+		return nil, nil, nil, nil, fmt.Errorf("the image does not contain any layers")
+	}
+
+	catalogCache := newCatalogCache(cfg)
+	catalogOpts := cfg.Options(time.Now())
+
+	var release *linux.Release
+	var relationships []artifact.Relationship
+	var failures []cataloger.CatalogerError
+	catalogs := make([]*pkg.Catalog, len(layers))
+	for idx := range layers {
+		resolver, err := src.LayerFileResolver(idx)
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("unable to determine resolver while cataloging packages (layer=%d): %w", idx, err)
+		}
+
+		log.Debugf("cataloging packages from layer=%d digest=%s", idx, layers[idx].Digest)
+		release = linux.IdentifyRelease(resolver)
+		// each layer is cataloged as the squashed filesystem of the layer and all layers below it
+		key := layersCacheKey(cfg, source.SquashedScope, src.Exclusions, layers[:idx+1], catalogers)
+		catalogs[idx], relationships, err = catalogWithCache(ctx, catalogCache, key, resolver, release, cfg, catalogOpts, catalogers)
+		var partial *cataloger.PartialResultsError
+		if errors.As(err, &partial) {
+			failures = appendFailures(failures, partial.Failures)
+		} else if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("unable to catalog packages (layer=%d): %w", idx, err)
+		}
+
+		if catalogOpts.Limits.TimedOut() && idx < len(layers)-1 {
+			log.Warnf("cataloging timed out, skipping the remaining %d layers", len(layers)-idx-1)
+			layers, catalogs = layers[:idx+1], catalogs[:idx+1]
+			break
+		}
+	}
+
+	history := pkg.NewLayerHistory(layers, catalogs)
+
+	// the final layer is the squashed representation of the image
+	if release != nil {
+		log.Infof("identified distro: %s", release.String())
+	} else {
+		log.Info("could not identify distro")
+	}
+	catalog, relationships := finalizeCatalog(ctx, src, cfg, catalogs[len(catalogs)-1], relationships)
+	if err := ctx.Err(); err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("cataloging canceled: %w", err)
+	}
+
+	if len(failures) > 0 {
+		return catalog, relationships, release, history, &cataloger.PartialResultsError{Failures: failures}
+	}
+	return catalog, relationships, release, history, nil
+}
+
+// appendFailures adds the given cataloger failures, leaving out failures that have already been reported (e.g. a
+// cataloger that fails in the same way for every layer).
+func appendFailures(failures []cataloger.CatalogerError, additional []cataloger.CatalogerError) []cataloger.CatalogerError {
+	for _, f := range additional {
+		var found bool
+		for _, existing := range failures {
+			if existing.Cataloger == f.Cataloger && existing.Err.Error() == f.Err.Error() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			failures = append(failures, f)
+		}
+	}
+	return failures
+}
+
+// newCatalogCache returns the cache of cataloging results, or nil if caching is disabled (or the cache is unusable).
+func newCatalogCache(cfg cataloger.Config) *cache.Cache {
+	if !cfg.Cache.Enabled {
+		return nil
+	}
+	c, err := cache.New(cfg.Cache)
+	if err != nil {
+		log.Warnf("unable to use the catalog cache: %+v", err)
+		return nil
+	}
+	if err := c.Prune(); err != nil {
+		log.Debugf("unable to prune the catalog cache: %+v", err)
+	}
+	return c
+}
+
+// catalogWithCache returns the cataloging results stored in the cache with the given key, otherwise the packages are
+// cataloged with the given resolver and the results are stored in the cache for later use.
+func catalogWithCache(ctx context.Context, c *cache.Cache, key string, resolver source.FileResolver, release *linux.Release, cfg cataloger.Config, opts cataloger.Options, catalogers []pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+	if c != nil {
+		catalog, relationships, ok := c.Get(key)
+		cfg.Instrumentation.RecordCacheLookup(ok)
+		if ok {
+			log.Debugf("using cached packages (key=%s)", key)
+			return catalog, relationships, nil
+		}
+	}
+
+	catalog, relationships, err := cataloger.CatalogWithContext(ctx, resolver, release, opts, catalogers...)
+	if err != nil {
+		// note: incomplete results (e.g. due to a timeout) are not cached
+		return catalog, relationships, err
+	}
+
+	if c != nil {
+		if err := c.Set(key, catalog, relationships); err != nil {
+			log.Warnf("unable to cache packages: %+v", err)
+		}
+	}
+	return catalog, relationships, nil
+}
+
+// catalogIncrementally catalogs a directory by updating the results of the previous scan of the same directory (kept
+// in the cache directory) with only the files that have changed since. The first scan of a directory is a full scan.
+func catalogIncrementally(ctx context.Context, src *source.Source, resolver source.FileResolver, release *linux.Release, cfg cataloger.Config, opts cataloger.Options, catalogers []pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+	c, err := cache.New(cfg.Cache)
+	if err != nil {
+		log.Warnf("unable to scan incrementally: %+v", err)
+		return cataloger.CatalogWithContext(ctx, resolver, release, opts, catalogers...)
+	}
+
+	root, err := filepath.Abs(src.Metadata.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to determine absolute path of directory=%q: %w", src.Metadata.Path, err)
+	}
+	key := cacheKey(cfg, cfg.Search.Scope, src.Exclusions, catalogers, "directory="+root)
+
+	previous, previousRelationships, previousManifest, found := c.GetDirectory(key)
+	cfg.Instrumentation.RecordCacheLookup(found)
+	manifest := cache.NewManifest(resolver, previousManifest)
+
+	var catalog *pkg.Catalog
+	var relationships []artifact.Relationship
+	if found {
+		changed := manifest.Changes(previousManifest)
+		log.Debugf("%d files changed in directory=%q since the previous scan", len(changed), root)
+		catalog, relationships = previous, previousRelationships
+		if len(changed) > 0 {
+			catalog, relationships, err = cataloger.CatalogChanges(ctx, resolver, release, opts, previous, previousRelationships, changed, catalogers...)
+		}
+	} else {
+		log.Debugf("no previous scan of directory=%q, cataloging all files", root)
+		catalog, relationships, err = cataloger.CatalogWithContext(ctx, resolver, release, opts, catalogers...)
+	}
+	if err != nil {
+		// note: the state is not saved for incomplete results, since the next scan must not consider the files that
+		// were not cataloged as unchanged
+		return catalog, relationships, err
+	}
+
+	if err := c.SetDirectory(key, catalog, relationships, manifest); err != nil {
+		log.Warnf("unable to save the state of directory=%q for incremental scans: %+v", root, err)
+	}
+	return catalog, relationships, nil
+}
+
+// layersCacheKey returns the cache key for the results of cataloging the given image layers with the given scope,
+// path exclusions, catalogers, and the parts of the configuration that affect the results.
+func layersCacheKey(cfg cataloger.Config, scope source.Scope, exclusions []string, layers []source.LayerMetadata, catalogers []pkg.Cataloger) string {
+	var content []string
+	for _, l := range layers {
+		content = append(content, "layer="+l.Digest)
+	}
+	return cacheKey(cfg, scope, exclusions, catalogers, content...)
+}
+
+// cacheKey returns the cache key for the results of cataloging the described content with the given scope, path
+// exclusions, catalogers, and the parts of the configuration that affect the results.
+func cacheKey(cfg cataloger.Config, scope source.Scope, exclusions []string, catalogers []pkg.Cataloger, content ...string) string {
+	// excluded paths are not cataloged, so the order the exclusions are given in does not matter
+	exclusions = append([]string(nil), exclusions...)
+	sort.Strings(exclusions)
+
+	parts := []string{
+		"scope=" + scope.String(),
+		fmt.Sprintf("exclusions=%q", exclusions),
+		fmt.Sprintf("search=%+v", cfg.Search),
+		fmt.Sprintf("archive-digests=%v", cfg.ArchiveDigests),
+		fmt.Sprintf("binary=%+v", cfg.Binary),
+		fmt.Sprintf("javascript=%+v", cfg.JavaScript),
+		fmt.Sprintf("max-file-size=%d", cfg.MaxFileSize),
+		fmt.Sprintf("max-files-per-cataloger=%d", cfg.MaxFilesPerCataloger),
+		fmt.Sprintf("max-nested-archive-depth=%d", cfg.MaxNestedArchiveDepth),
+		fmt.Sprintf("max-nested-archive-size=%d", cfg.MaxNestedArchiveSize),
+		fmt.Sprintf("id-scheme=%s", cfg.IDScheme),
+	}
+	for _, c := range catalogers {
+		parts = append(parts, "cataloger="+c.Name())
+	}
+	return cache.Key(append(parts, content...)...)
+}
+
+func identifyRelease(resolver source.FileResolver) *linux.Release {
 	release := linux.IdentifyRelease(resolver)
 	if release != nil {
 		log.Infof("identified distro: %s", release.String())
 	} else {
 		log.Info("could not identify distro")
 	}
+	return release
+}
 
+func selectCatalogers(src *source.Source, cfg cataloger.Config) ([]pkg.Cataloger, error) {
 	// if the catalogers have been configured, use them regardless of input type
-	var catalogers []pkg.Cataloger
 	if len(cfg.Catalogers) > 0 {
-		catalogers = cataloger.AllCatalogers(cfg)
-	} else {
-		// otherwise conditionally use the correct set of loggers based on the input type (container image or directory)
-		switch src.Metadata.Scheme {
-		case source.ImageScheme:
-			log.Info("cataloging image")
-			catalogers = cataloger.ImageCatalogers(cfg)
-		case source.FileScheme:
-			log.Info("cataloging file")
-			catalogers = cataloger.AllCatalogers(cfg)
-		case source.DirectoryScheme:
-			log.Info("cataloging directory")
-			catalogers = cataloger.DirectoryCatalogers(cfg)
-		default:
-			return nil, nil, nil, fmt.Errorf("unable to determine cataloger set from scheme=%+v", src.Metadata.Scheme)
+		if len(cfg.Select) > 0 {
+			return nil, fmt.Errorf("unable to select catalogers: catalogers (deprecated) cannot be combined with select expressions")
 		}
+		return cataloger.AllCatalogers(cfg), nil
 	}
 
-	catalog, relationships, err := cataloger.Catalog(resolver, release, catalogers...)
-	if err != nil {
-		return nil, nil, nil, err
+	// otherwise select from the default set of catalogers based on the input type (container image or directory)
+	var defaultTag string
+	switch src.Metadata.Scheme {
+	case source.ImageScheme:
+		log.Info("cataloging image")
+		defaultTag = cataloger.ImageTag
+	case source.FileScheme:
+		log.Info("cataloging file")
+		defaultTag = cataloger.AllCatalogersPattern
+	case source.DirectoryScheme:
+		log.Info("cataloging directory")
+		defaultTag = cataloger.DirectoryTag
+	default:
+		return nil, fmt.Errorf("unable to determine cataloger set from scheme=%+v", src.Metadata.Scheme)
+	}
+	return cataloger.SelectCatalogers(cfg, defaultTag)
+}
+
+// finalizeCatalog merges duplicate packages (when configured), attributes packages to the image layers that introduced
+// them (including the base image), relates all packages to the source, catalogs the packages within any images
+// stored within the source (when configured), and finally removes the packages not kept by the package filter.
+func finalizeCatalog(ctx context.Context, src *source.Source, cfg cataloger.Config, catalog *pkg.Catalog, relationships []artifact.Relationship) (*pkg.Catalog, []artifact.Relationship) {
+	if cfg.Deduplication.Enabled {
+		before := catalog.PackageCount()
+		catalog, relationships = pkg.Deduplicate(catalog, relationships, cfg.Deduplication)
+		if merged := before - catalog.PackageCount(); merged > 0 {
+			log.Infof("merged %d duplicate packages", merged)
+		}
+	}
+
+	if src.Metadata.Scheme == source.ImageScheme {
+		// record which image layer introduced each package
+		var layerRelationships []artifact.Relationship
+		catalog, layerRelationships = pkg.AttributeLayers(catalog, src.Metadata.ImageMetadata.Layers, relationships)
+		relationships = append(relationships, layerRelationships...)
+
+		if baseImage := src.Metadata.ImageMetadata.BaseImage; baseImage != nil {
+			var excluded map[artifact.ID]struct{}
+			catalog, excluded = pkg.AttributeBaseImage(catalog, baseImage.Layers, cfg.ExcludeBaseImage)
+			if len(excluded) > 0 {
+				log.Infof("excluded %d packages from base image=%q", len(excluded), baseImage.Reference)
+				relationships = withoutRelationshipsTo(relationships, excluded)
+			}
+		} else if cfg.ExcludeBaseImage {
+			log.Warn("unable to exclude base image packages: the base image has not been detected")
+		}
+	}
+
+	if cfg.Enrichment.Enabled {
+		// note: the registry details do not change the package IDs, so the relationships are unaffected
+		catalog = enrich.New(cfg.Enrichment).EnrichWithContext(ctx, catalog)
 	}
 
 	relationships = append(relationships, newSourceRelationshipsFromCatalog(src, catalog)...)
 
-	return catalog, relationships, release, nil
+	// note: the packages within nested images are related to the images instead of the source
+	catalog, relationships = catalogNestedImages(ctx, src, cfg, catalog, relationships)
+
+	if cfg.Filter != nil {
+		var removed map[artifact.ID]struct{}
+		catalog, removed = cfg.Filter.Apply(catalog)
+		if len(removed) > 0 {
+			log.Infof("excluded %d packages by the package filter", len(removed))
+			relationships = withoutRelationshipsTo(relationships, removed)
+		}
+	}
+
+	return catalog, relationships
+}
+
+// withoutRelationshipsTo returns the relationships that do not reference any of the given artifacts.
+func withoutRelationshipsTo(relationships []artifact.Relationship, ids map[artifact.ID]struct{}) []artifact.Relationship {
+	var results []artifact.Relationship
+	for _, r := range relationships {
+		if _, ok := ids[r.From.ID()]; ok {
+			continue
+		}
+		if _, ok := ids[r.To.ID()]; ok {
+			continue
+		}
+		results = append(results, r)
+	}
+	return results
 }
 
 func newSourceRelationshipsFromCatalog(src *source.Source, c *pkg.Catalog) []artifact.Relationship {
@@ -101,3 +450,17 @@ func SetLogger(logger logger.Logger) {
 func SetBus(b *partybus.Bus) {
 	bus.SetPublisher(b)
 }
+
+// Subscribe returns a context carrying the given function, which is called with each typed event published by the
+// library while cataloging with the context (see the monitor package for the events, such as monitor.CatalogerStarted
+// or monitor.PackageDiscovered). Only the calls given the context (e.g. CreateSBOM or CatalogPackagesWithContext) have
+// their events received, so concurrent calls with other contexts are not observed. No event bus is needed (see
+// SetBus). The function is called from the goroutine publishing the event, which may be any goroutine running a
+// cataloger, so it must be safe for concurrent use and should return quickly.
+func Subscribe(ctx context.Context, fn func(monitor.Event)) context.Context {
+	return bus.WithSubscriber(ctx, func(e partybus.Event) {
+		if typed, ok := e.Value.(monitor.Event); ok {
+			fn(typed)
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package syft
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestLayersCacheKey(t *testing.T) {
+	cfg := cataloger.DefaultConfig()
+	layers := []source.LayerMetadata{{Digest: "sha256:a"}, {Digest: "sha256:b"}}
+	key := func(exclusions ...string) string {
+		return layersCacheKey(cfg, source.SquashedScope, exclusions, layers, nil)
+	}
+
+	assert.NotEqual(t, key(), key("/usr/**"), "excluded paths change what is cataloged")
+	assert.NotEqual(t, key("/usr/**"), key("/etc/**"))
+	assert.Equal(t, key("/usr/**", "/etc/**"), key("/etc/**", "/usr/**"), "the order of exclusions does not matter")
+	assert.NotEqual(t, key("/usr/**,/etc/**"), key("/usr/**", "/etc/**"))
+}
+
+func TestCatalogPackages_CacheKeyIncludesIDScheme(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "requirements.txt"), []byte("requests==2.28.1\n"), 0600))
+	src, err := source.NewFromDirectory(dir)
+	require.NoError(t, err)
+
+	ids := func(cfg cataloger.Config, scheme pkg.IDScheme) []artifact.ID {
+		t.Helper()
+		cfg.IDScheme = scheme
+		catalog, _, _, err := CatalogPackages(&src, cfg)
+		require.NoError(t, err)
+		var ids []artifact.ID
+		for _, p := range catalog.Sorted() {
+			ids = append(ids, p.ID())
+		}
+		require.NotEmpty(t, ids)
+		return ids
+	}
+
+	uncached := cataloger.DefaultConfig()
+	cached := cataloger.DefaultConfig()
+	cached.Cache.Enabled = true
+	cached.Cache.Directory = t.TempDir()
+	cached.Incremental = true
+
+	for _, scheme := range []pkg.IDScheme{pkg.IDSchemeV1, pkg.IDSchemeV2} {
+		// only the ID scheme changes between the cached runs, so the results of the first run must not be reused
+		assert.Equal(t, ids(uncached, scheme), ids(cached, scheme), "scheme=%s", scheme)
+	}
+	assert.NotEqual(t, ids(uncached, pkg.IDSchemeV1), ids(uncached, pkg.IDSchemeV2))
+}
+
+func TestSelectCatalogers_RejectsCatalogersWithSelect(t *testing.T) {
+	src, err := source.NewFromDirectory(t.TempDir())
+	require.NoError(t, err)
+
+	cfg := cataloger.DefaultConfig()
+	cfg.Catalogers = []string{"python"}
+	cfg.Select = []string{"-python"}
+	_, err = selectCatalogers(&src, cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be combined with select")
+
+	cfg.Select = nil
+	catalogers, err := selectCatalogers(&src, cfg)
+	require.NoError(t, err)
+	assert.NotEmpty(t, catalogers)
+}
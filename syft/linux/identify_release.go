@@ -43,6 +43,16 @@ var identityFiles = []parseEntry{
 		path: "/etc/redhat-release",
 		fn:   parseRedhatRelease,
 	},
+	{
+		// check for chrome os (and other distros without an os-release file)
+		path: "/etc/lsb-release",
+		fn:   parseLsbRelease,
+	},
+	{
+		// check for a freebsd userland (the os-release file is only generated at boot)
+		path: "/bin/freebsd-version",
+		fn:   parseFreeBSDVersion,
+	},
 	// /////////////////////////////////////////////////////////////////////////////////////////////////////
 	// IMPORTANT! checking busybox must be last since other distros contain the busybox binary
 	{
@@ -125,6 +135,11 @@ func parseOsRelease(contents string) (*Release, error) {
 		BugReportURL:     values["BUG_REPORT_URL"],
 		PrivacyPolicyURL: values["PRIVACY_POLICY_URL"],
 		CPEName:          values["CPE_NAME"],
+		SupportEnd:       values["SUPPORT_END"],
+		VendorName:       values["VENDOR_NAME"],
+		VendorURL:        values["VENDOR_URL"],
+		DocumentationURL: values["DOCUMENTATION_URL"],
+		Extras:           extraFields(values, osReleaseFields),
 	}
 
 	// don't allow for empty contents to result in a Release object being created
@@ -135,6 +150,89 @@ func parseOsRelease(contents string) (*Release, error) {
 	return &r, nil
 }
 
+// osReleaseFields are the os-release fields described by the Release fields (all others are extras)
+var osReleaseFields = internal.NewStringSet(
+	"PRETTY_NAME", "NAME", "ID", "ID_LIKE", "VERSION", "VERSION_ID", "VERSION_CODENAME", "BUILD_ID", "IMAGE_ID",
+	"IMAGE_VERSION", "VARIANT", "VARIANT_ID", "HOME_URL", "SUPPORT_URL", "BUG_REPORT_URL", "PRIVACY_POLICY_URL",
+	"CPE_NAME", "SUPPORT_END", "VENDOR_NAME", "VENDOR_URL", "DOCUMENTATION_URL",
+)
+
+// extraFields returns the non-empty values of the fields that are not already described elsewhere (or nil if none).
+func extraFields(values map[string]string, described internal.StringSet) map[string]string {
+	var extras map[string]string
+	for field, value := range values {
+		if described.Contains(field) || value == "" {
+			continue
+		}
+		if extras == nil {
+			extras = make(map[string]string)
+		}
+		extras[field] = value
+	}
+	return extras
+}
+
+// lsbReleaseFields are the lsb-release fields described by the Release fields (all others are extras)
+var lsbReleaseFields = internal.NewStringSet(
+	"DISTRIB_ID", "DISTRIB_RELEASE", "DISTRIB_CODENAME", "DISTRIB_DESCRIPTION",
+	"CHROMEOS_RELEASE_NAME", "CHROMEOS_RELEASE_VERSION", "CHROMEOS_RELEASE_BUILD_NUMBER", "CHROMEOS_RELEASE_TRACK",
+)
+
+// parseLsbRelease parses the /etc/lsb-release file, as found on chrome os (which describes itself with "CHROMEOS_"
+// fields) and on distros that predate the os-release file.
+func parseLsbRelease(contents string) (*Release, error) {
+	values, err := osrelease.ReadString(contents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read lsb-release file: %w", err)
+	}
+
+	if name := values["CHROMEOS_RELEASE_NAME"]; name != "" {
+		version := values["CHROMEOS_RELEASE_VERSION"]
+		return &Release{
+			PrettyName: strings.TrimSpace(name + " " + version),
+			Name:       name,
+			ID:         "chromeos",
+			Version:    version,
+			VersionID:  version,
+			BuildID:    values["CHROMEOS_RELEASE_BUILD_NUMBER"],
+			Variant:    values["CHROMEOS_RELEASE_TRACK"],
+			Extras:     extraFields(values, lsbReleaseFields),
+		}, nil
+	}
+
+	id := values["DISTRIB_ID"]
+	if id == "" {
+		return nil, nil
+	}
+	return &Release{
+		PrettyName:      values["DISTRIB_DESCRIPTION"],
+		Name:            id,
+		ID:              strings.ToLower(id),
+		Version:         values["DISTRIB_RELEASE"],
+		VersionID:       values["DISTRIB_RELEASE"],
+		VersionCodename: values["DISTRIB_CODENAME"],
+		Extras:          extraFields(values, lsbReleaseFields),
+	}, nil
+}
+
+// example: USERLAND_VERSION="13.2-RELEASE-p1"
+var freebsdVersionMatcher = regexp.MustCompile(`(?m)^USERLAND_VERSION="?((\d+\.\d+)[^"\s]*)"?`)
+
+// parseFreeBSDVersion parses the freebsd-version script, which records the version of the installed userland
+func parseFreeBSDVersion(contents string) (*Release, error) {
+	match := freebsdVersionMatcher.FindStringSubmatch(contents)
+	if match == nil {
+		return nil, nil
+	}
+	return &Release{
+		PrettyName: "FreeBSD " + match[1],
+		Name:       "FreeBSD",
+		ID:         "freebsd",
+		Version:    match[1],
+		VersionID:  match[2],
+	}, nil
+}
+
 var busyboxVersionMatcher = regexp.MustCompile(`BusyBox v[\d.]+`)
 
 func parseBusyBox(contents string) (*Release, error) {
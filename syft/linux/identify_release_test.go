@@ -43,6 +43,9 @@ func TestIdentifyRelease(t *testing.T) {
 				VersionID: "2",
 				HomeURL:   "https://amazonlinux.com/",
 				CPEName:   "cpe:2.3:o:amazon:amazon_linux:2",
+				Extras: map[string]string{
+					"ANSI_COLOR": "0;33",
+				},
 			},
 		},
 		{
@@ -70,6 +73,14 @@ func TestIdentifyRelease(t *testing.T) {
 				HomeURL:      "https://www.centos.org/",
 				BugReportURL: "https://bugs.centos.org/",
 				CPEName:      "cpe:/o:centos:centos:8",
+				Extras: map[string]string{
+					"PLATFORM_ID":                     "platform:el8",
+					"ANSI_COLOR":                      "0;31",
+					"CENTOS_MANTISBT_PROJECT":         "CentOS-8",
+					"CENTOS_MANTISBT_PROJECT_VERSION": "8",
+					"REDHAT_SUPPORT_PRODUCT":          "centos",
+					"REDHAT_SUPPORT_PRODUCT_VERSION":  "8",
+				},
 			},
 		},
 		{
@@ -102,6 +113,16 @@ func TestIdentifyRelease(t *testing.T) {
 				BugReportURL:     "https://bugzilla.redhat.com/",
 				PrivacyPolicyURL: "https://fedoraproject.org/wiki/Legal:PrivacyPolicy",
 				CPEName:          "cpe:/o:fedoraproject:fedora:31",
+				DocumentationURL: "https://docs.fedoraproject.org/en-US/fedora/f31/system-administrators-guide/",
+				Extras: map[string]string{
+					"PLATFORM_ID":                     "platform:f31",
+					"ANSI_COLOR":                      "0;34",
+					"LOGO":                            "fedora-logo-icon",
+					"REDHAT_BUGZILLA_PRODUCT":         "Fedora",
+					"REDHAT_BUGZILLA_PRODUCT_VERSION": "31",
+					"REDHAT_SUPPORT_PRODUCT":          "Fedora",
+					"REDHAT_SUPPORT_PRODUCT_VERSION":  "31",
+				},
 			},
 		},
 		{
@@ -116,6 +137,13 @@ func TestIdentifyRelease(t *testing.T) {
 				HomeURL:      "https://www.redhat.com/",
 				BugReportURL: "https://bugzilla.redhat.com/",
 				CPEName:      "cpe:/o:redhat:enterprise_linux:7.3:GA:server",
+				Extras: map[string]string{
+					"ANSI_COLOR":                      "0;31",
+					"REDHAT_BUGZILLA_PRODUCT":         "Red Hat Enterprise Linux 7",
+					"REDHAT_BUGZILLA_PRODUCT_VERSION": "7.3",
+					"REDHAT_SUPPORT_PRODUCT":          "Red Hat Enterprise Linux",
+					"REDHAT_SUPPORT_PRODUCT_VERSION":  "7.3",
+				},
 			},
 		},
 		{
@@ -132,6 +160,9 @@ func TestIdentifyRelease(t *testing.T) {
 				SupportURL:       "https://help.ubuntu.com/",
 				BugReportURL:     "https://bugs.launchpad.net/ubuntu/",
 				PrivacyPolicyURL: "https://www.ubuntu.com/legal/terms-and-policies/privacy-policy",
+				Extras: map[string]string{
+					"UBUNTU_CODENAME": "focal",
+				},
 			},
 		},
 		{
@@ -148,6 +179,14 @@ func TestIdentifyRelease(t *testing.T) {
 				HomeURL:      "https://linux.oracle.com/",
 				BugReportURL: "https://bugzilla.oracle.com/",
 				CPEName:      "cpe:/o:oracle:linux:8:3:server",
+				Extras: map[string]string{
+					"PLATFORM_ID":                     "platform:el8",
+					"ANSI_COLOR":                      "0;31",
+					"ORACLE_BUGZILLA_PRODUCT":         "Oracle Linux 8",
+					"ORACLE_BUGZILLA_PRODUCT_VERSION": "8.3",
+					"ORACLE_SUPPORT_PRODUCT":          "Oracle Linux",
+					"ORACLE_SUPPORT_PRODUCT_VERSION":  "8.3",
+				},
 			},
 		},
 		{
@@ -168,6 +207,14 @@ func TestIdentifyRelease(t *testing.T) {
 				HomeURL:      "https://www.centos.org/",
 				BugReportURL: "https://bugs.centos.org/",
 				CPEName:      "cpe:/o:centos:centos:8",
+				Extras: map[string]string{
+					"PLATFORM_ID":                     "platform:el8",
+					"ANSI_COLOR":                      "0;31",
+					"CENTOS_MANTISBT_PROJECT":         "CentOS-8",
+					"CENTOS_MANTISBT_PROJECT_VERSION": "8",
+					"REDHAT_SUPPORT_PRODUCT":          "centos",
+					"REDHAT_SUPPORT_PRODUCT_VERSION":  "8",
+				},
 			},
 		},
 		{
@@ -185,18 +232,25 @@ func TestIdentifyRelease(t *testing.T) {
 				HomeURL:      "https://www.opensuse.org/",
 				BugReportURL: "https://bugs.opensuse.org",
 				CPEName:      "cpe:/o:opensuse:leap:15.2",
+				Extras: map[string]string{
+					"ANSI_COLOR": "0;32",
+				},
 			},
 		},
 		{
 			fixture: "test-fixtures/os/sles",
 			release: &Release{
-				PrettyName: "SUSE Linux Enterprise Server 15 SP2",
-				Name:       "SLES",
-				ID:         "sles",
-				IDLike:     []string{"suse"},
-				Version:    "15-SP2",
-				VersionID:  "15.2",
-				CPEName:    "cpe:/o:suse:sles:15:sp2",
+				PrettyName:       "SUSE Linux Enterprise Server 15 SP2",
+				Name:             "SLES",
+				ID:               "sles",
+				IDLike:           []string{"suse"},
+				Version:          "15-SP2",
+				VersionID:        "15.2",
+				CPEName:          "cpe:/o:suse:sles:15:sp2",
+				DocumentationURL: "https://documentation.suse.com/",
+				Extras: map[string]string{
+					"ANSI_COLOR": "0;32",
+				},
 			},
 		},
 		{
@@ -210,19 +264,27 @@ func TestIdentifyRelease(t *testing.T) {
 				VersionID:    "2.0",
 				HomeURL:      "https://vmware.github.io/photon/",
 				BugReportURL: "https://github.com/vmware/photon/issues",
+				Extras: map[string]string{
+					"ANSI_COLOR": "1;34",
+				},
 			},
 		},
 		{
 			fixture: "test-fixtures/os/arch",
 			release: &Release{
-				PrettyName:   "Arch Linux",
-				Name:         "Arch Linux",
-				ID:           "arch",
-				IDLike:       nil,
-				BuildID:      "rolling",
-				HomeURL:      "https://www.archlinux.org/",
-				SupportURL:   "https://bbs.archlinux.org/",
-				BugReportURL: "https://bugs.archlinux.org/",
+				PrettyName:       "Arch Linux",
+				Name:             "Arch Linux",
+				ID:               "arch",
+				IDLike:           nil,
+				BuildID:          "rolling",
+				HomeURL:          "https://www.archlinux.org/",
+				SupportURL:       "https://bbs.archlinux.org/",
+				BugReportURL:     "https://bugs.archlinux.org/",
+				DocumentationURL: "https://wiki.archlinux.org/",
+				Extras: map[string]string{
+					"ANSI_COLOR": "38;2;23;147;209",
+					"LOGO":       "archlinux",
+				},
 			},
 		},
 		{
@@ -284,6 +346,9 @@ func TestIdentifyRelease(t *testing.T) {
 				HomeURL:      "https://aka.ms/cbl-mariner",
 				SupportURL:   "https://aka.ms/cbl-mariner",
 				BugReportURL: "https://aka.ms/cbl-mariner",
+				Extras: map[string]string{
+					"ANSI_COLOR": "1;34",
+				},
 			},
 		},
 		{
@@ -301,6 +366,12 @@ func TestIdentifyRelease(t *testing.T) {
 				HomeURL:      "https://rockylinux.org/",
 				BugReportURL: "https://bugs.rockylinux.org/",
 				CPEName:      "cpe:/o:rocky:rocky:8.4:GA",
+				Extras: map[string]string{
+					"PLATFORM_ID":                   "platform:el8",
+					"ANSI_COLOR":                    "0;32",
+					"ROCKY_SUPPORT_PRODUCT":         "Rocky Linux",
+					"ROCKY_SUPPORT_PRODUCT_VERSION": "8",
+				},
 			},
 		},
 		{
@@ -314,11 +385,92 @@ func TestIdentifyRelease(t *testing.T) {
 					"centos",
 					"fedora",
 				},
-				Version:      "8.4 (Electric Cheetah)",
-				VersionID:    "8.4",
-				HomeURL:      "https://almalinux.org/",
-				BugReportURL: "https://bugs.almalinux.org/",
-				CPEName:      "cpe:/o:almalinux:almalinux:8.4:GA",
+				Version:          "8.4 (Electric Cheetah)",
+				VersionID:        "8.4",
+				HomeURL:          "https://almalinux.org/",
+				BugReportURL:     "https://bugs.almalinux.org/",
+				CPEName:          "cpe:/o:almalinux:almalinux:8.4:GA",
+				DocumentationURL: "https://wiki.almalinux.org/",
+				Extras: map[string]string{
+					"PLATFORM_ID":                        "platform:el8",
+					"ANSI_COLOR":                         "0;34",
+					"ALMALINUX_MANTISBT_PROJECT":         "AlmaLinux-8",
+					"ALMALINUX_MANTISBT_PROJECT_VERSION": "8.4",
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/os/bottlerocket",
+			release: &Release{
+				PrettyName:   "Bottlerocket OS 1.13.1 (aws-k8s-1.25)",
+				Name:         "Bottlerocket",
+				ID:           "bottlerocket",
+				Version:      "1.13.1 (aws-k8s-1.25)",
+				VersionID:    "1.13.1",
+				BuildID:      "9a5bbc36",
+				VariantID:    "aws-k8s-1.25",
+				HomeURL:      "https://github.com/bottlerocket-os/bottlerocket",
+				SupportURL:   "https://github.com/bottlerocket-os/bottlerocket/discussions",
+				BugReportURL: "https://github.com/bottlerocket-os/bottlerocket/issues",
+			},
+		},
+		{
+			fixture: "test-fixtures/os/flatcar",
+			release: &Release{
+				PrettyName:   "Flatcar Container Linux by Kinvolk 3510.2.1 (Oklo)",
+				Name:         "Flatcar Container Linux by Kinvolk",
+				ID:           "flatcar",
+				IDLike:       []string{"coreos"},
+				Version:      "3510.2.1",
+				VersionID:    "3510.2.1",
+				BuildID:      "2023-04-17-1844",
+				HomeURL:      "https://flatcar.org/",
+				BugReportURL: "https://issues.flatcar.org",
+				CPEName:      "cpe:2.3:o:flatcar-linux:flatcar_linux:3510.2.1:*:*:*:*:*:*:*",
+				Extras: map[string]string{
+					"SYSEXT_LEVEL":  "1.0",
+					"ANSI_COLOR":    "38;5;75",
+					"FLATCAR_BOARD": "amd64-usr",
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/os/talos",
+			release: &Release{
+				PrettyName:   "Talos (v1.4.0)",
+				Name:         "Talos",
+				ID:           "talos",
+				VersionID:    "v1.4.0",
+				HomeURL:      "https://www.talos.dev/",
+				BugReportURL: "https://github.com/siderolabs/talos/issues",
+			},
+		},
+		{
+			fixture: "test-fixtures/os/chromeos",
+			release: &Release{
+				PrettyName: "Chrome OS 15359.58.0",
+				Name:       "Chrome OS",
+				ID:         "chromeos",
+				Version:    "15359.58.0",
+				VersionID:  "15359.58.0",
+				BuildID:    "15359",
+				Variant:    "stable-channel",
+				Extras: map[string]string{
+					"CHROMEOS_RELEASE_BOARD":            "hatch-signed-mp-v4keys",
+					"CHROMEOS_RELEASE_BUILD_TYPE":       "Official Build",
+					"CHROMEOS_RELEASE_CHROME_MILESTONE": "111",
+					"DEVICETYPE":                        "CHROMEBOOK",
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/os/freebsd",
+			release: &Release{
+				PrettyName: "FreeBSD 13.2-RELEASE-p1",
+				Name:       "FreeBSD",
+				ID:         "freebsd",
+				Version:    "13.2-RELEASE-p1",
+				VersionID:  "13.2",
 			},
 		},
 	}
@@ -357,6 +509,9 @@ func TestParseOsRelease(t *testing.T) {
 				SupportURL:       "https://help.ubuntu.com/",
 				BugReportURL:     "https://bugs.launchpad.net/ubuntu/",
 				PrivacyPolicyURL: "https://www.ubuntu.com/legal/terms-and-policies/privacy-policy",
+				Extras: map[string]string{
+					"UBUNTU_CODENAME": "focal",
+				},
 			},
 		},
 
@@ -392,6 +547,14 @@ func TestParseOsRelease(t *testing.T) {
 				HomeURL:      "https://www.centos.org/",
 				BugReportURL: "https://bugs.centos.org/",
 				CPEName:      "cpe:/o:centos:centos:8",
+				Extras: map[string]string{
+					"PLATFORM_ID":                     "platform:el8",
+					"ANSI_COLOR":                      "0;31",
+					"CENTOS_MANTISBT_PROJECT":         "CentOS-8",
+					"CENTOS_MANTISBT_PROJECT_VERSION": "8",
+					"REDHAT_SUPPORT_PRODUCT":          "centos",
+					"REDHAT_SUPPORT_PRODUCT_VERSION":  "8",
+				},
 			},
 		},
 
@@ -408,6 +571,14 @@ func TestParseOsRelease(t *testing.T) {
 				HomeURL:      "https://www.redhat.com/",
 				BugReportURL: "https://bugzilla.redhat.com/",
 				CPEName:      "cpe:/o:redhat:enterprise_linux:8.1:GA",
+				Extras: map[string]string{
+					"PLATFORM_ID":                     "platform:el8",
+					"ANSI_COLOR":                      "0;31",
+					"REDHAT_BUGZILLA_PRODUCT":         "Red Hat Enterprise Linux 8",
+					"REDHAT_BUGZILLA_PRODUCT_VERSION": "8.1",
+					"REDHAT_SUPPORT_PRODUCT":          "Red Hat Enterprise Linux",
+					"REDHAT_SUPPORT_PRODUCT_VERSION":  "8.1",
+				},
 			},
 		},
 
@@ -535,3 +706,40 @@ func retrieveFixtureContentsAsString(fixturePath string, t *testing.T) string {
 
 	return string(b)
 }
+
+func TestParseLsbRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		release  *Release
+	}{
+		{
+			name: "distro without an os-release file",
+			contents: `DISTRIB_ID=Ubuntu
+DISTRIB_RELEASE=10.04
+DISTRIB_CODENAME=lucid
+DISTRIB_DESCRIPTION="Ubuntu 10.04.4 LTS"`,
+			release: &Release{
+				PrettyName:      "Ubuntu 10.04.4 LTS",
+				Name:            "Ubuntu",
+				ID:              "ubuntu",
+				Version:         "10.04",
+				VersionID:       "10.04",
+				VersionCodename: "lucid",
+			},
+		},
+		{
+			name:     "no distro",
+			contents: `CHROMEOS_AUSERVER=https://tools.google.com/service/update2`,
+			release:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			release, err := parseLsbRelease(test.contents)
+			require.NoError(t, err)
+			assert.Equal(t, test.release, release)
+		})
+	}
+}
@@ -19,6 +19,13 @@ type Release struct {
 	BugReportURL     string
 	PrivacyPolicyURL string
 	CPEName          string // A CPE name for the operating system, in URI binding syntax
+	SupportEnd       string `cyclonedx:"supportEnd"` // the date at which support for this version of the OS ends (YYYY-MM-DD)
+	VendorName       string `cyclonedx:"vendorName"` // the name of the OS vendor
+	VendorURL        string
+	DocumentationURL string
+	// Extras are the fields not described above (keyed by field name, e.g. "ANSI_COLOR"), including any fields
+	// specific to the distribution (e.g. "REDHAT_SUPPORT_PRODUCT").
+	Extras map[string]string
 }
 
 func (r *Release) String() string {
@@ -0,0 +1,136 @@
+package syft
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// osPackageTypes are the types of packages that are found within package manager databases of the operating system
+var osPackageTypes = []pkg.Type{pkg.AlpmPkg, pkg.ApkPkg, pkg.DebPkg, pkg.PortagePkg, pkg.RpmPkg}
+
+const (
+	// distrolessStatusGlob matches the files describing the debian packages installed within distroless images (one
+	// file per package, instead of the status file of a dpkg database)
+	distrolessStatusGlob = "/var/lib/dpkg/status.d/*"
+)
+
+var (
+	osReleasePaths = []string{"/etc/os-release", "/usr/lib/os-release"}
+	busyboxPaths   = []string{"/bin/busybox", "/usr/bin/busybox", "/sbin/busybox"}
+)
+
+// DescribeMinimalImage describes the given image when no operating system packages were cataloged from it (as is the
+// case for distroless, busybox-based, and static images), detecting the kind of image from well-known markers and
+// counting the binaries that packages were found within. Returns nil for sources that are not images, and for images
+// with operating system packages.
+func DescribeMinimalImage(src *source.Source, catalog *pkg.Catalog, release *linux.Release) (*sbom.MinimalImageReport, error) {
+	if src == nil || src.Metadata.Scheme != source.ImageScheme {
+		return nil, nil
+	}
+
+	// the image is described as seen from within the container at runtime
+	resolver, err := src.FileResolver(source.SquashedScope)
+	if err != nil {
+		return nil, fmt.Errorf("unable to determine resolver while describing image: %w", err)
+	}
+	return describeMinimalImage(resolver, catalog, release)
+}
+
+func describeMinimalImage(resolver source.FileResolver, catalog *pkg.Catalog, release *linux.Release) (*sbom.MinimalImageReport, error) {
+	if catalog == nil {
+		catalog = pkg.NewCatalog()
+	}
+	if len(catalog.Sorted(osPackageTypes...)) > 0 {
+		return nil, nil
+	}
+
+	kind, markers, err := minimalImageKind(resolver, catalog, release)
+	if err != nil {
+		return nil, err
+	}
+
+	executables, err := resolver.FilesByMIMEType(internal.ExecutableMIMETypeSet.List()...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find executables: %w", err)
+	}
+	binaries := internal.NewStringSet()
+	for _, l := range executables {
+		binaries.Add(l.RealPath)
+	}
+	classified := internal.NewStringSet()
+	for p := range catalog.Enumerate() {
+		for _, l := range p.Locations.ToSlice() {
+			if binaries.Contains(l.RealPath) {
+				classified.Add(l.RealPath)
+			}
+		}
+	}
+
+	return &sbom.MinimalImageReport{
+		Kind:               kind,
+		Markers:            markers,
+		Binaries:           len(binaries),
+		ClassifiedBinaries: len(classified),
+	}, nil
+}
+
+// minimalImageKind detects the kind of image from the markers found within the image, returning the kind of image
+// along with the paths of the markers.
+func minimalImageKind(resolver source.FileResolver, catalog *pkg.Catalog, release *linux.Release) (string, []string, error) {
+	statusFiles, err := resolver.FilesByGlob(distrolessStatusGlob)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to find distroless package status files: %w", err)
+	}
+	markers := realPaths(statusFiles)
+	if isDistrolessRelease(release) {
+		osRelease, err := resolver.FilesByPath(osReleasePaths...)
+		if err != nil {
+			return "", nil, fmt.Errorf("unable to find os-release file: %w", err)
+		}
+		markers = append(markers, realPaths(osRelease)...)
+	}
+	if len(markers) > 0 {
+		return sbom.DistrolessImage, internal.NewStringSet(markers...).ToSlice(), nil
+	}
+
+	busybox, err := resolver.FilesByPath(busyboxPaths...)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to find busybox binary: %w", err)
+	}
+	markers = realPaths(busybox)
+	// the binary cataloger identifies busybox regardless of where the binary is
+	for _, p := range catalog.PackagesByName("busybox") {
+		if p.Type == pkg.BinaryPkg {
+			markers = append(markers, realPaths(p.Locations.ToSlice())...)
+		}
+	}
+	if len(markers) > 0 {
+		return sbom.BusyboxImage, internal.NewStringSet(markers...).ToSlice(), nil
+	}
+
+	return sbom.StaticImage, nil, nil
+}
+
+// isDistrolessRelease indicates if the given release was described by the os-release file of a distroless image (which
+// is named "Distroless", while identifying as the debian release the image was built from).
+func isDistrolessRelease(release *linux.Release) bool {
+	if release == nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(release.PrettyName), "distroless") ||
+		strings.Contains(strings.ToLower(release.HomeURL), "distroless")
+}
+
+func realPaths(locations []source.Location) []string {
+	var paths []string
+	for _, l := range locations {
+		paths = append(paths, l.RealPath)
+	}
+	return paths
+}
@@ -0,0 +1,103 @@
+package syft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestDescribeMinimalImage(t *testing.T) {
+	executable := source.FileMetadata{MIMEType: "application/x-executable"}
+	regular := source.FileMetadata{MIMEType: "text/plain"}
+
+	newPackage := func(name string, ty pkg.Type, path string) pkg.Package {
+		p := pkg.Package{
+			Name:      name,
+			Version:   "1.0.0",
+			Type:      ty,
+			Locations: source.NewLocationSet(source.NewLocation(path)),
+		}
+		p.SetID()
+		return p
+	}
+
+	tests := []struct {
+		name     string
+		files    map[source.Location]source.FileMetadata
+		packages []pkg.Package
+		release  *linux.Release
+		expected *sbom.MinimalImageReport
+	}{
+		{
+			name: "image with os packages",
+			files: map[source.Location]source.FileMetadata{
+				source.NewLocation("/bin/busybox"): executable,
+			},
+			packages: []pkg.Package{
+				newPackage("busybox", pkg.ApkPkg, "/lib/apk/db/installed"),
+			},
+			expected: nil,
+		},
+		{
+			name: "distroless image",
+			files: map[source.Location]source.FileMetadata{
+				source.NewLocation("/var/lib/dpkg/status.d/base"):   regular,
+				source.NewLocation("/var/lib/dpkg/status.d/tzdata"): regular,
+				source.NewLocation("/etc/os-release"):               regular,
+				source.NewLocation("/app/server"):                   executable,
+				source.NewLocation("/app/healthcheck"):              executable,
+			},
+			packages: []pkg.Package{
+				newPackage("github.com/example/server", pkg.GoModulePkg, "/app/server"),
+			},
+			release: &linux.Release{PrettyName: "Distroless", ID: "debian"},
+			expected: &sbom.MinimalImageReport{
+				Kind:               sbom.DistrolessImage,
+				Markers:            []string{"/etc/os-release", "/var/lib/dpkg/status.d/base", "/var/lib/dpkg/status.d/tzdata"},
+				Binaries:           2,
+				ClassifiedBinaries: 1,
+			},
+		},
+		{
+			name: "busybox image",
+			files: map[source.Location]source.FileMetadata{
+				source.NewLocation("/bin/busybox"): executable,
+			},
+			packages: []pkg.Package{
+				newPackage("busybox", pkg.BinaryPkg, "/bin/busybox"),
+			},
+			expected: &sbom.MinimalImageReport{
+				Kind:               sbom.BusyboxImage,
+				Markers:            []string{"/bin/busybox"},
+				Binaries:           1,
+				ClassifiedBinaries: 1,
+			},
+		},
+		{
+			name: "static image",
+			files: map[source.Location]source.FileMetadata{
+				source.NewLocation("/app"):                               executable,
+				source.NewLocation("/etc/ssl/certs/ca-certificates.crt"): regular,
+			},
+			expected: &sbom.MinimalImageReport{
+				Kind:     sbom.StaticImage,
+				Binaries: 1,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resolver := source.NewMockResolverForPathsWithMetadata(test.files)
+
+			actual, err := describeMinimalImage(resolver, pkg.NewCatalog(test.packages...), test.release)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package syft
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/stereoscope"
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger"
+	"github.com/anchore/syft/syft/source"
+)
+
+// catalogNestedImages catalogs the packages within the container images stored within the source (the image layouts
+// and archives found by the container image cataloger), adding the packages to the catalog along with a relationship
+// from each image to its packages. The images within those images are cataloged in turn, up to the configured depth.
+// Images that cannot be read are left as they are (only cataloged as packages themselves).
+func catalogNestedImages(ctx context.Context, src *source.Source, cfg cataloger.Config, catalog *pkg.Catalog, relationships []artifact.Relationship) (*pkg.Catalog, []artifact.Relationship) {
+	if cfg.NestedImageDepth <= 0 {
+		return catalog, relationships
+	}
+	images := nestedImages(catalog)
+	if len(images) == 0 {
+		return catalog, relationships
+	}
+
+	resolver, err := src.FileResolver(cfg.Search.Scope)
+	if err != nil {
+		log.Warnf("unable to catalog nested images: %+v", err)
+		return catalog, relationships
+	}
+
+	nestedCfg := cfg
+	nestedCfg.NestedImageDepth--
+	for _, p := range images {
+		if ctx.Err() != nil {
+			// note: the caller reports the cancellation
+			break
+		}
+		log.WithFields("image", p.Name, "version", p.Version).Debug("cataloging nested image")
+		nestedCatalog, nestedRelationships, err := catalogNestedImage(ctx, resolver, p, nestedCfg)
+		if err != nil {
+			log.WithFields("image", p.Name, "version", p.Version, "error", err).Warn("unable to catalog the packages within nested image")
+			continue
+		}
+		for nested := range nestedCatalog.Enumerate() {
+			catalog.Add(nested)
+			relationships = append(relationships, artifact.Relationship{
+				From: p,
+				To:   nested,
+				Type: artifact.ContainsRelationship,
+			})
+		}
+		relationships = append(relationships, nestedRelationships...)
+	}
+	return catalog, relationships
+}
+
+// nestedImages returns the cataloged images that the packages can be cataloged from, leaving out images that share
+// an image layout or archive with other images (since only a single image of a layout or archive can be read).
+func nestedImages(catalog *pkg.Catalog) []pkg.Package {
+	var candidates []pkg.Package
+	imagesByPath := make(map[string]int)
+	for _, p := range catalog.Sorted(pkg.ContainerImagePkg) {
+		metadata, ok := p.Metadata.(pkg.ContainerImageMetadata)
+		if !ok {
+			continue
+		}
+		if _, ok := nestedImageSource(metadata.Format); !ok || len(p.Locations.ToSlice()) == 0 {
+			continue
+		}
+		imagesByPath[p.Locations.ToSlice()[0].RealPath]++
+		candidates = append(candidates, p)
+	}
+
+	var images []pkg.Package
+	for _, p := range candidates {
+		location := p.Locations.ToSlice()[0]
+		if imagesByPath[location.RealPath] > 1 {
+			log.WithFields("image", p.Name, "path", location.RealPath).Debug("skipping nested image stored alongside other images")
+			continue
+		}
+		images = append(images, p)
+	}
+	return images
+}
+
+// nestedImageSource returns the kind of image source to read an image of the given format with.
+func nestedImageSource(format string) (image.Source, bool) {
+	switch format {
+	case pkg.OCILayoutImageFormat:
+		return image.OciDirectorySource, true
+	case pkg.OCIArchiveImageFormat:
+		return image.OciTarballSource, true
+	case pkg.DockerArchiveImageFormat:
+		return image.DockerTarballSource, true
+	}
+	return image.UnknownSource, false
+}
+
+// catalogNestedImage copies the given image out of the source to a temporary location and catalogs the packages
+// within the image, returning the packages and relationships (other than the relationships to the image source).
+func catalogNestedImage(ctx context.Context, resolver source.FileResolver, p pkg.Package, cfg cataloger.Config) (*pkg.Catalog, []artifact.Relationship, error) {
+	metadata := p.Metadata.(pkg.ContainerImageMetadata)
+	imageSource, _ := nestedImageSource(metadata.Format)
+	location := p.Locations.ToSlice()[0]
+
+	tempDir, err := os.MkdirTemp("", "syft-nested-image-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create temporary directory: %w", err)
+	}
+	defer func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			log.Warnf("unable to remove temporary directory=%q: %+v", tempDir, err)
+		}
+	}()
+
+	imagePath := tempDir
+	if imageSource == image.OciDirectorySource {
+		// the location of an image layout is the oci-layout file at the root of the layout
+		err = copyDirectory(resolver, path.Dir(location.RealPath), tempDir)
+	} else {
+		imagePath = filepath.Join(tempDir, "image.tar")
+		err = copyFile(resolver, location, imagePath)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	img, err := stereoscope.GetImageFromSource(ctx, imagePath, imageSource)
+	if err != nil || img == nil {
+		return nil, nil, fmt.Errorf("unable to read image at %q: %w", location.RealPath, err)
+	}
+	defer func() {
+		if err := img.Cleanup(); err != nil {
+			log.Warnf("unable to cleanup nested image=%q: %+v", location.RealPath, err)
+		}
+	}()
+
+	userInput := p.Name
+	if len(metadata.Tags) > 0 {
+		userInput = metadata.Tags[0]
+	}
+	nestedSrc, err := source.NewFromImage(img, userInput)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to populate source with nested image: %w", err)
+	}
+
+	catalog, relationships, _, err := CatalogPackagesWithContext(ctx, &nestedSrc, cfg)
+	if err != nil && !cataloger.IsPartialResults(err) {
+		return nil, nil, err
+	}
+
+	// the packages are related to the image package instead of the nested source
+	var results []artifact.Relationship
+	for _, r := range relationships {
+		if r.From.ID() == nestedSrc.ID() {
+			continue
+		}
+		results = append(results, r)
+	}
+	return catalog, results, nil
+}
+
+// copyDirectory copies all files of the given directory within the source to the given destination directory.
+func copyDirectory(resolver source.FileResolver, dir, destination string) error {
+	locations, err := resolver.FilesByGlob(path.Join(dir, "**"))
+	if err != nil {
+		return fmt.Errorf("unable to find the files of directory=%q: %w", dir, err)
+	}
+	copied := make(map[string]bool)
+	for _, l := range locations {
+		rel := strings.TrimPrefix(l.RealPath, strings.TrimSuffix(dir, "/")+"/")
+		if rel == l.RealPath || copied[rel] {
+			continue
+		}
+		copied[rel] = true
+		if err := copyFile(resolver, l, filepath.Join(destination, filepath.FromSlash(rel))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyFile copies the file at the given location within the source to the given destination path.
+func copyFile(resolver source.FileResolver, location source.Location, destination string) error {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return fmt.Errorf("unable to read %q: %w", location.RealPath, err)
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0700); err != nil {
+		return fmt.Errorf("unable to create directory for %q: %w", destination, err)
+	}
+	f, err := os.Create(destination)
+	if err != nil {
+		return fmt.Errorf("unable to create %q: %w", destination, err)
+	}
+	defer internal.CloseAndLogError(f, destination)
+
+	if _, err := io.Copy(f, reader); err != nil {
+		return fmt.Errorf("unable to copy %q: %w", location.RealPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package syft
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestNestedImages(t *testing.T) {
+	newImage := func(name, format, path string) pkg.Package {
+		p := pkg.Package{
+			Name:         name,
+			Locations:    source.NewLocationSet(source.NewLocation(path)),
+			Type:         pkg.ContainerImagePkg,
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata:     pkg.ContainerImageMetadata{Format: format},
+		}
+		p.SetID()
+		return p
+	}
+
+	// an image that is only referenced by name
+	referenced := pkg.Package{Name: "referenced", Type: pkg.ContainerImagePkg}
+	referenced.SetID()
+
+	catalog := pkg.NewCatalog(
+		newImage("layout", pkg.OCILayoutImageFormat, "/images/layout/oci-layout"),
+		newImage("archive", pkg.DockerArchiveImageFormat, "/images/archive.tar"),
+		// several images within the same archive cannot be read
+		newImage("first", pkg.DockerArchiveImageFormat, "/images/multiple.tar"),
+		newImage("second", pkg.DockerArchiveImageFormat, "/images/multiple.tar"),
+		// images within the image store of a docker daemon cannot be read
+		newImage("stored", pkg.DockerImageStoreFormat, "/var/lib/docker/image/overlay2/repositories.json"),
+		referenced,
+	)
+
+	var names []string
+	for _, p := range nestedImages(catalog) {
+		names = append(names, p.Name)
+	}
+	assert.ElementsMatch(t, []string{"layout", "archive"}, names)
+}
@@ -0,0 +1,15 @@
+package pkg
+
+// AIModelMetadata represents a machine learning model, as described by the model file (or directory) itself. The
+// architecture fields correspond to the model parameters of a CycloneDX model card.
+type AIModelMetadata struct {
+	Format             string   `mapstructure:"format" json:"format" cyclonedx:"format"`                                               // one of "onnx", "tensorflow-saved-model", "pytorch", "gguf", or "safetensors"
+	Framework          string   `mapstructure:"framework" json:"framework,omitempty" cyclonedx:"framework"`                            // the framework (or library) the model was produced with (e.g. "pytorch" or "transformers")
+	FrameworkVersion   string   `mapstructure:"frameworkVersion" json:"frameworkVersion,omitempty" cyclonedx:"frameworkVersion"`       // the version of the framework
+	ArchitectureFamily string   `mapstructure:"architectureFamily" json:"architectureFamily,omitempty" cyclonedx:"architectureFamily"` // e.g. "llama" or "bert"
+	ModelArchitecture  string   `mapstructure:"modelArchitecture" json:"modelArchitecture,omitempty" cyclonedx:"modelArchitecture"`    // e.g. "LlamaForCausalLM"
+	Quantization       string   `mapstructure:"quantization" json:"quantization,omitempty" cyclonedx:"quantization"`                   // the (predominant) type of the weights (e.g. "Q4_K_M" or "BF16")
+	OpsetVersion       int      `mapstructure:"opsetVersion" json:"opsetVersion,omitempty" cyclonedx:"opsetVersion"`                   // the version of the default operator set of an ONNX model
+	Tags               []string `mapstructure:"tags" json:"tags,omitempty" cyclonedx:"tags"`                                           // the tags of the meta graphs of a TensorFlow SavedModel (e.g. "serve")
+	Parameters         int64    `mapstructure:"parameters" json:"parameters,omitempty" cyclonedx:"parameters"`                         // the number of parameters (weights), when the model lists the shapes of its tensors
+}
@@ -31,6 +31,7 @@ type ApkMetadata struct {
 	PullDependencies string          `mapstructure:"D" json:"pullDependencies" cyclonedx:"pullDependencies"`
 	PullChecksum     string          `mapstructure:"C" json:"pullChecksum" cyclonedx:"pullChecksum"`
 	GitCommitOfAport string          `mapstructure:"c" json:"gitCommitOfApkPort" cyclonedx:"gitCommitOfApkPort"`
+	Provides         []string        `mapstructure:"-" json:"provides,omitempty"` // virtual packages and shared libraries provided by the package (e.g. "so:libz.so.1=1.2.13")
 	Files            []ApkFileRecord `json:"files"`
 }
 
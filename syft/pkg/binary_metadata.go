@@ -0,0 +1,14 @@
+package pkg
+
+import "github.com/anchore/syft/syft/source"
+
+// BinaryMetadata represents all classifier matches that lead to the discovery of a package from a binary file.
+type BinaryMetadata struct {
+	Matches []ClassifierMatch `mapstructure:"Matches" json:"matches"`
+}
+
+// ClassifierMatch is a single classifier rule that matched a file (and the file that it matched).
+type ClassifierMatch struct {
+	Classifier string          `mapstructure:"Classifier" json:"classifier"`
+	Location   source.Location `mapstructure:"Location" json:"location"`
+}
@@ -1,8 +1,8 @@
 package pkg
 
 import (
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 var _ urlIdentifier = (*CargoPackageMetadata)(nil)
@@ -29,12 +29,5 @@ func (p CargoPackageMetadata) Pkg() *Package {
 
 // PackageURL returns the PURL for the specific rust package (see https://github.com/package-url/purl-spec)
 func (p CargoPackageMetadata) PackageURL(_ *linux.Release) string {
-	return packageurl.NewPackageURL(
-		"cargo",
-		"",
-		p.Name,
-		p.Version,
-		nil,
-		"",
-	).ToString()
+	return purl.New(purl.TypeCargo, "", p.Name, p.Version, nil, "")
 }
@@ -1,11 +1,15 @@
 package pkg
 
 import (
+	"fmt"
 	"sort"
 	"sync"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"github.com/jinzhu/copier"
 
+	"github.com/anchore/packageurl-go"
+
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
@@ -27,13 +31,21 @@ loopNewIDs:
 	}
 }
 
-// Catalog represents a collection of Packages.
+func (s orderedIDSet) copy() orderedIDSet {
+	return orderedIDSet{slice: append([]artifact.ID(nil), s.slice...)}
+}
+
+// Catalog represents a collection of Packages. A catalog is safe for concurrent use, and a clone of a catalog shares
+// the packages and indexes of the catalog until either of them is modified (copy-on-write).
 type Catalog struct {
 	byID      map[artifact.ID]Package
 	idsByName map[string]orderedIDSet
 	idsByType map[Type]orderedIDSet
 	idsByPath map[string]orderedIDSet // note: this is real path or virtual path
+	idsByPURL map[string]orderedIDSet // note: this is the full pURL and the pURL without qualifiers or subpath
+	idsByCPE  map[string]orderedIDSet
 	lock      sync.RWMutex
+	shared    bool // whether the packages and indexes are shared with a clone (and must be copied before modifying them)
 }
 
 // NewCatalog returns a new empty Catalog
@@ -43,6 +55,8 @@ func NewCatalog(pkgs ...Package) *Catalog {
 		idsByName: make(map[string]orderedIDSet),
 		idsByType: make(map[Type]orderedIDSet),
 		idsByPath: make(map[string]orderedIDSet),
+		idsByPURL: make(map[string]orderedIDSet),
+		idsByCPE:  make(map[string]orderedIDSet),
 	}
 
 	for _, p := range pkgs {
@@ -54,12 +68,17 @@ func NewCatalog(pkgs ...Package) *Catalog {
 
 // PackageCount returns the total number of packages that have been added.
 func (c *Catalog) PackageCount() int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
 	return len(c.byID)
 }
 
 // Package returns the package with the given ID.
 func (c *Catalog) Package(id artifact.ID) *Package {
+	c.lock.RLock()
 	v, exists := c.byID[id]
+	c.lock.RUnlock()
 	if !exists {
 		return nil
 	}
@@ -74,16 +93,87 @@ func (c *Catalog) Package(id artifact.ID) *Package {
 
 // PackagesByPath returns all packages that were discovered from the given path.
 func (c *Catalog) PackagesByPath(path string) []Package {
-	return c.Packages(c.idsByPath[path].slice)
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.packages(c.idsByPath[path].slice)
 }
 
 // PackagesByName returns all packages that were discovered with a matching name.
 func (c *Catalog) PackagesByName(name string) []Package {
-	return c.Packages(c.idsByName[name].slice)
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.packages(c.idsByName[name].slice)
+}
+
+// PackagesByType returns all packages of the given type, in the order they were added.
+func (c *Catalog) PackagesByType(ty Type) []Package {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.packages(c.idsByType[ty].slice)
+}
+
+// PackagesByPURL returns all packages with the given pURL. When the given pURL has no qualifiers or subpath, packages
+// are matched regardless of their qualifiers (e.g. "pkg:deb/debian/libc6@2.31" matches a package with the pURL
+// "pkg:deb/debian/libc6@2.31?arch=amd64"). pURLs are compared in their canonical form, so the order of the qualifiers
+// (and the escaping of each part) does not matter.
+func (c *Catalog) PackagesByPURL(purl string) []Package {
+	if canonical, _, err := canonicalPURL(purl); err == nil {
+		purl = canonical
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.packages(c.idsByPURL[purl].slice)
+}
+
+// PackagesByCPE returns all packages that have the given CPE.
+func (c *Catalog) PackagesByCPE(cpe CPE) []Package {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.packages(c.idsByCPE[CPEString(cpe)].slice)
+}
+
+// PackagesByPathGlob returns all packages that were discovered from a (real or virtual) path matching the given glob,
+// using the doublestar syntax (e.g. "**/site-packages/*/METADATA").
+func (c *Catalog) PackagesByPathGlob(pattern string) ([]Package, error) {
+	if !doublestar.ValidatePattern(pattern) {
+		return nil, fmt.Errorf("invalid path glob: %q", pattern)
+	}
+
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	var paths []string
+	for path := range c.idsByPath {
+		if matches, _ := doublestar.Match(pattern, path); matches {
+			paths = append(paths, path)
+		}
+	}
+	// note: the paths are sorted so that the packages are returned in a stable order
+	sort.Strings(paths)
+
+	var ids orderedIDSet
+	for _, path := range paths {
+		ids.add(c.idsByPath[path].slice...)
+	}
+	return c.packages(ids.slice), nil
 }
 
 // Packages returns all packages for the given ID.
-func (c *Catalog) Packages(ids []artifact.ID) (result []Package) {
+func (c *Catalog) Packages(ids []artifact.ID) []Package {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	return c.packages(ids)
+}
+
+// packages returns all packages for the given ID (the caller must hold the lock).
+func (c *Catalog) packages(ids []artifact.ID) (result []Package) {
 	for _, i := range ids {
 		p, exists := c.byID[i]
 		if exists {
@@ -105,13 +195,19 @@ func (c *Catalog) Add(p Package) {
 		id = p.ID()
 	}
 
+	c.detach()
+
 	if existing, exists := c.byID[id]; exists {
 		// there is already a package with this fingerprint merge the existing record with the new one
+		// (note: the existing record may be shared with a clone of the catalog, so it is merged into a copy)
+		existing.unshare()
 		if err := existing.merge(p); err != nil {
 			log.Warnf("failed to merge packages: %+v", err)
 		} else {
 			c.byID[id] = existing
 			c.addPathsToIndex(p)
+			c.addPURLToIndex(existing)
+			c.addCPEsToIndex(existing)
 		}
 		return
 	}
@@ -119,11 +215,89 @@ func (c *Catalog) Add(p Package) {
 	c.addToIndex(p)
 }
 
+// Clone returns a copy of the catalog. The copy shares the packages and indexes of this catalog until either catalog
+// is modified, so cloning is cheap regardless of the number of packages.
+func (c *Catalog) Clone() *Catalog {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.shared = true
+	return &Catalog{
+		byID:      c.byID,
+		idsByName: c.idsByName,
+		idsByType: c.idsByType,
+		idsByPath: c.idsByPath,
+		idsByPURL: c.idsByPURL,
+		idsByCPE:  c.idsByCPE,
+		shared:    true,
+	}
+}
+
+// Merge returns a new catalog with the packages of this catalog and the given catalogs. The IDs of all packages are
+// regenerated from their content with the given scheme (see IDScheme), so the same package described by several
+// catalogs (e.g. decoded from SBOMs written by different syft versions) becomes a single package, and the same input
+// always results in the same IDs. Merging without any other catalogs regenerates the IDs of this catalog alone (e.g.
+// before comparing catalogs by ID). The returned map relates the original ID of each package to its regenerated ID,
+// for updating the relationships that refer to the packages.
+func (c *Catalog) Merge(scheme IDScheme, others ...*Catalog) (*Catalog, map[artifact.ID]artifact.ID) {
+	merged := NewCatalog()
+	ids := make(map[artifact.ID]artifact.ID)
+	for _, catalog := range append([]*Catalog{c}, others...) {
+		if catalog == nil {
+			continue
+		}
+		// note: the packages are added in sorted order so the merged packages (e.g. the order of their CPEs and
+		// licenses) do not depend on the order of enumerating the catalog
+		for _, p := range catalog.Sorted() {
+			original := p.ID()
+			p.SetIDWithScheme(scheme)
+			ids[original] = p.ID()
+			merged.Add(p)
+		}
+	}
+	return merged, ids
+}
+
+// detach copies the packages and indexes shared with a clone of the catalog (the caller must hold the write lock).
+func (c *Catalog) detach() {
+	if !c.shared {
+		return
+	}
+
+	byID := make(map[artifact.ID]Package, len(c.byID))
+	for id, p := range c.byID {
+		byID[id] = p
+	}
+	c.byID = byID
+
+	idsByType := make(map[Type]orderedIDSet, len(c.idsByType))
+	for ty, ids := range c.idsByType {
+		idsByType[ty] = ids.copy()
+	}
+	c.idsByType = idsByType
+
+	c.idsByName = copyStringIndex(c.idsByName)
+	c.idsByPath = copyStringIndex(c.idsByPath)
+	c.idsByPURL = copyStringIndex(c.idsByPURL)
+	c.idsByCPE = copyStringIndex(c.idsByCPE)
+	c.shared = false
+}
+
+func copyStringIndex(index map[string]orderedIDSet) map[string]orderedIDSet {
+	result := make(map[string]orderedIDSet, len(index))
+	for key, ids := range index {
+		result[key] = ids.copy()
+	}
+	return result
+}
+
 func (c *Catalog) addToIndex(p Package) {
 	c.byID[p.id] = p
 	c.addNameToIndex(p)
 	c.addTypeToIndex(p)
 	c.addPathsToIndex(p)
+	c.addPURLToIndex(p)
+	c.addCPEsToIndex(p)
 }
 
 func (c *Catalog) addNameToIndex(p Package) {
@@ -158,6 +332,48 @@ func (c *Catalog) addPathToIndex(id artifact.ID, path string) {
 	c.idsByPath[path] = pathIndex
 }
 
+func (c *Catalog) addPURLToIndex(p Package) {
+	if p.PURL == "" {
+		return
+	}
+
+	canonical, purl, err := canonicalPURL(p.PURL)
+	if err != nil {
+		log.Debugf("unable to index pURL=%q of package id=%q: %+v", p.PURL, p.id, err)
+		c.addToStringIndex(c.idsByPURL, p.id, p.PURL)
+		return
+	}
+	c.addToStringIndex(c.idsByPURL, p.id, canonical)
+
+	if len(purl.Qualifiers) > 0 || purl.Subpath != "" {
+		purl.Qualifiers = nil
+		purl.Subpath = ""
+		c.addToStringIndex(c.idsByPURL, p.id, purl.ToString())
+	}
+}
+
+// canonicalPURL parses the given pURL, returning the pURL in its canonical form (with the qualifiers sorted by key).
+func canonicalPURL(value string) (string, packageurl.PackageURL, error) {
+	purl, err := packageurl.FromString(value)
+	if err != nil {
+		return "", packageurl.PackageURL{}, err
+	}
+	purl.Qualifiers = packageurl.QualifiersFromMap(purl.Qualifiers.Map())
+	return purl.ToString(), purl, nil
+}
+
+func (c *Catalog) addCPEsToIndex(p Package) {
+	for _, cpe := range p.CPEs {
+		c.addToStringIndex(c.idsByCPE, p.id, CPEString(cpe))
+	}
+}
+
+func (c *Catalog) addToStringIndex(index map[string]orderedIDSet, id artifact.ID, key string) {
+	ids := index[key]
+	ids.add(id)
+	index[key] = ids
+}
+
 // Enumerate all packages for the given type(s), enumerating all packages if no type is specified.
 func (c *Catalog) Enumerate(types ...Type) <-chan Package {
 	channel := make(chan Package)
@@ -167,7 +383,7 @@ func (c *Catalog) Enumerate(types ...Type) <-chan Package {
 			// we should allow enumerating from a catalog that was never created (which will result in no packages enumerated)
 			return
 		}
-		for ty, ids := range c.idsByType {
+		for ty, ids := range c.typeIndex() {
 			if len(types) != 0 {
 				found := false
 			typeCheck:
@@ -192,6 +408,18 @@ func (c *Catalog) Enumerate(types ...Type) <-chan Package {
 	return channel
 }
 
+// typeIndex returns a copy of the package IDs of each type, so the catalog is not locked while enumerating.
+func (c *Catalog) typeIndex() map[Type]orderedIDSet {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	index := make(map[Type]orderedIDSet, len(c.idsByType))
+	for ty, ids := range c.idsByType {
+		index[ty] = ids.copy()
+	}
+	return index
+}
+
 // Sorted enumerates all packages for the given types sorted by package name. Enumerates all packages if no type
 // is specified.
 func (c *Catalog) Sorted(types ...Type) (pkgs []Package) {
@@ -1,6 +1,8 @@
 package pkg
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/scylladb/go-set/strset"
@@ -238,6 +240,193 @@ func TestCatalog_MergeRecords(t *testing.T) {
 	}
 }
 
+func TestCatalog_Queries(t *testing.T) {
+	libc := Package{
+		Name:      "libc6",
+		Version:   "2.31",
+		Type:      DebPkg,
+		PURL:      "pkg:deb/debian/libc6@2.31?distro=debian-11&arch=amd64",
+		CPEs:      []CPE{MustCPE("cpe:2.3:a:gnu:glibc:2.31:*:*:*:*:*:*:*")},
+		Locations: source.NewLocationSet(source.NewLocation("/var/lib/dpkg/status")),
+	}
+	requests := Package{
+		Name:      "requests",
+		Version:   "2.28.1",
+		Type:      PythonPkg,
+		PURL:      "pkg:pypi/requests@2.28.1",
+		CPEs:      []CPE{MustCPE("cpe:2.3:a:python:requests:2.28.1:*:*:*:*:*:*:*")},
+		Locations: source.NewLocationSet(source.NewVirtualLocation("/usr/lib/python3/site-packages/requests-2.28.1.dist-info/METADATA", "/usr/lib/python3/site-packages/requests-2.28.1.dist-info/METADATA")),
+	}
+	urllib := Package{
+		Name:      "urllib3",
+		Version:   "1.26.12",
+		Type:      PythonPkg,
+		PURL:      "pkg:pypi/urllib3@1.26.12",
+		Locations: source.NewLocationSet(source.NewLocation("/usr/lib/python3/site-packages/urllib3-1.26.12.dist-info/METADATA")),
+	}
+	for _, p := range []*Package{&libc, &requests, &urllib} {
+		p.SetID()
+	}
+
+	c := NewCatalog(libc, requests, urllib)
+
+	ids := func(pkgs []Package) (result []artifact.ID) {
+		for _, p := range pkgs {
+			result = append(result, p.ID())
+		}
+		return result
+	}
+
+	assert.Equal(t, []artifact.ID{requests.ID(), urllib.ID()}, ids(c.PackagesByType(PythonPkg)))
+	assert.Empty(t, c.PackagesByType(NpmPkg))
+
+	assert.Equal(t, []artifact.ID{libc.ID()}, ids(c.PackagesByPURL("pkg:deb/debian/libc6@2.31?distro=debian-11&arch=amd64")))
+	assert.Equal(t, []artifact.ID{libc.ID()}, ids(c.PackagesByPURL("pkg:deb/debian/libc6@2.31?arch=amd64&distro=debian-11")))
+	assert.Equal(t, []artifact.ID{libc.ID()}, ids(c.PackagesByPURL("pkg:deb/debian/libc6@2.31")))
+	assert.Empty(t, c.PackagesByPURL("pkg:deb/debian/libc6@2.31?arch=amd64"))
+	assert.Empty(t, c.PackagesByPURL("pkg:deb/debian/libc6@2.32"))
+
+	assert.Equal(t, []artifact.ID{requests.ID()}, ids(c.PackagesByCPE(MustCPE("cpe:2.3:a:python:requests:2.28.1:*:*:*:*:*:*:*"))))
+	assert.Empty(t, c.PackagesByCPE(MustCPE("cpe:2.3:a:python:urllib3:1.26.12:*:*:*:*:*:*:*")))
+
+	matches, err := c.PackagesByPathGlob("**/site-packages/*/METADATA")
+	require.NoError(t, err)
+	assert.Equal(t, []artifact.ID{requests.ID(), urllib.ID()}, ids(matches))
+
+	matches, err = c.PackagesByPathGlob("/var/lib/dpkg/*")
+	require.NoError(t, err)
+	assert.Equal(t, []artifact.ID{libc.ID()}, ids(matches))
+
+	_, err = c.PackagesByPathGlob("[")
+	assert.Error(t, err)
+
+	// CPEs gained when merging a package are indexed
+	urllibWithCPE := urllib
+	urllibWithCPE.CPEs = []CPE{MustCPE("cpe:2.3:a:python:urllib3:1.26.12:*:*:*:*:*:*:*")}
+	c.Add(urllibWithCPE)
+	assert.Equal(t, []artifact.ID{urllib.ID()}, ids(c.PackagesByCPE(MustCPE("cpe:2.3:a:python:urllib3:1.26.12:*:*:*:*:*:*:*"))))
+}
+
+func TestCatalog_ConcurrentQueries(t *testing.T) {
+	c := NewCatalog()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			p := Package{
+				Name:      fmt.Sprintf("pkg-%d", i),
+				Version:   "1.0.0",
+				Type:      PythonPkg,
+				PURL:      fmt.Sprintf("pkg:pypi/pkg-%d@1.0.0", i),
+				Locations: source.NewLocationSet(source.NewLocation(fmt.Sprintf("/site-packages/pkg-%d/METADATA", i))),
+			}
+			p.SetID()
+			c.Add(p)
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			c.PackagesByType(PythonPkg)
+			c.PackagesByName(fmt.Sprintf("pkg-%d", i))
+			c.PackagesByPURL(fmt.Sprintf("pkg:pypi/pkg-%d@1.0.0", i))
+			_, _ = c.PackagesByPathGlob("**/METADATA")
+			c.Sorted()
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			clone := c.Clone()
+			p := Package{Name: fmt.Sprintf("clone-%d", i), Version: "1.0.0", Type: PythonPkg}
+			p.SetID()
+			clone.Add(p)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Len(t, c.PackagesByType(PythonPkg), 10)
+}
+
+func TestCatalog_Clone(t *testing.T) {
+	requests := Package{
+		Name:      "requests",
+		Version:   "2.28.1",
+		Type:      PythonPkg,
+		Locations: source.NewLocationSet(source.NewLocation("/site-packages/requests/METADATA")),
+	}
+	requests.SetID()
+	original := NewCatalog(requests)
+
+	clone := original.Clone()
+
+	// the same package found through a symlink is merged into the package of the clone only...
+	other := requests
+	other.Locations = source.NewLocationSet(source.NewVirtualLocation("/site-packages/requests/METADATA", "/link/requests/METADATA"))
+	clone.Add(other)
+	urllib := Package{Name: "urllib3", Version: "1.26.12", Type: PythonPkg}
+	urllib.SetID()
+	clone.Add(urllib)
+
+	assert.Equal(t, 2, clone.PackageCount())
+	assert.Len(t, clone.Package(requests.ID()).Locations.ToSlice(), 2)
+	assert.Len(t, clone.PackagesByPath("/link/requests/METADATA"), 1)
+
+	// ...and the original catalog is unchanged
+	assert.Equal(t, 1, original.PackageCount())
+	assert.Len(t, original.Package(requests.ID()).Locations.ToSlice(), 1)
+	assert.Empty(t, original.PackagesByPath("/link/requests/METADATA"))
+	assert.Empty(t, original.PackagesByName("urllib3"))
+
+	// modifying the original does not affect the clone either
+	original.Add(Package{Name: "idna", Version: "3.4", Type: PythonPkg})
+	assert.Empty(t, clone.PackagesByName("idna"))
+}
+
+func TestCatalog_Merge(t *testing.T) {
+	newPackage := func(location string) Package {
+		p := Package{
+			Name:      "requests",
+			Version:   "2.28.1",
+			Type:      PythonPkg,
+			PURL:      "pkg:pypi/requests@2.28.1",
+			Locations: source.NewLocationSet(source.NewLocation(location)),
+		}
+		p.SetID()
+		return p
+	}
+	requests := newPackage("/site-packages/requests/METADATA")
+
+	// the same package decoded from an SBOM written by another syft version (with another ID)
+	decoded := newPackage("/site-packages/requests/METADATA")
+	decoded.OverrideID("0123456789abcdef")
+	urllib := Package{Name: "urllib3", Version: "1.26.12", Type: PythonPkg}
+	urllib.OverrideID("fedcba9876543210")
+
+	first := NewCatalog(requests)
+	second := NewCatalog(decoded, urllib)
+
+	merged, ids := first.Merge(DefaultIDScheme, second)
+
+	require.Equal(t, 2, merged.PackageCount())
+	assert.Equal(t, requests.ID(), ids[requests.ID()])
+	assert.Equal(t, requests.ID(), ids["0123456789abcdef"])
+	require.NotNil(t, merged.Package(requests.ID()))
+
+	regenerated := urllib
+	regenerated.SetID()
+	assert.Equal(t, regenerated.ID(), ids["fedcba9876543210"])
+	require.NotNil(t, merged.Package(regenerated.ID()))
+
+	// the IDs do not depend on the order of merging
+	reversed, _ := second.Merge(DefaultIDScheme, first)
+	for _, p := range merged.Sorted() {
+		assert.NotNil(t, reversed.Package(p.ID()), p.String())
+	}
+
+	// the merged catalogs are unchanged
+	assert.Equal(t, 1, first.PackageCount())
+	assert.NotNil(t, second.Package("fedcba9876543210"))
+}
+
 func TestCatalog_EnumerateNilCatalog(t *testing.T) {
 	var c *Catalog
 	assert.Empty(t, c.Enumerate())
@@ -1,6 +1,8 @@
 package pkg
 
 import (
+	"context"
+
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/source"
 )
@@ -14,3 +16,11 @@ type Cataloger interface {
 	// Catalog is given an object to resolve file references and content, this function returns any discovered Packages after analyzing the catalog source.
 	Catalog(resolver source.FileResolver) ([]Package, []artifact.Relationship, error)
 }
+
+// ContextCataloger is a Cataloger whose work outside of the file resolver (e.g. running processes or making network
+// requests) stops when the context of the cataloging run is canceled or times out.
+type ContextCataloger interface {
+	Cataloger
+	// CatalogWithContext is the same as Catalog, stopping early when the given context is done.
+	CatalogWithContext(ctx context.Context, resolver source.FileResolver) ([]Package, []artifact.Relationship, error)
+}
@@ -0,0 +1,22 @@
+/*
+Package aimodel provides a concrete Cataloger implementation for machine learning models (e.g. ONNX, GGUF, and
+safetensors files).
+*/
+package aimodel
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const catalogerName = "ai-model-cataloger"
+
+// NewAIModelCataloger returns a new cataloger object for machine learning models, describing the format, framework,
+// and architecture of each model as far as the model files record them.
+func NewAIModelCataloger() *generic.Cataloger {
+	return generic.NewCataloger(catalogerName).
+		WithParserByGlobs(parseONNXFile, "**/*.onnx").
+		WithParserByGlobs(parseSavedModel, "**/saved_model.pb").
+		WithParserByGlobs(parsePyTorchFile, "**/*.pt", "**/*.pth").
+		WithParserByGlobs(parseGGUFFile, "**/*.gguf").
+		WithParserByGlobs(parseSafetensorsFile, "**/*.safetensors")
+}
@@ -0,0 +1,140 @@
+package aimodel
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestAIModelCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/models/checkpoint.pth",
+		"test-fixtures/models/classifier/saved_model.pb",
+		"test-fixtures/models/hf/bert-tiny/config.json",
+		"test-fixtures/models/hf/bert-tiny/model-00001-of-00002.safetensors",
+		"test-fixtures/models/hf/bert-tiny/model-00002-of-00002.safetensors",
+		"test-fixtures/models/legacy.pt",
+		"test-fixtures/models/not-a-model.onnx",
+		"test-fixtures/models/not-a-model.pt",
+		"test-fixtures/models/resnet18.onnx",
+		"test-fixtures/models/sd/v1-5-pruned.safetensors",
+		"test-fixtures/models/tinyllama-1.1b-chat.Q4_K_M.gguf",
+	)
+
+	gguf := "test-fixtures/models/tinyllama-1.1b-chat.Q4_K_M.gguf"
+	// note: the packages are in the order of the parsers, and the files that are not models are skipped
+	expected := []pkg.Package{
+		{
+			Name:         "resnet18",
+			Version:      "3",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/models/resnet18.onnx")),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/resnet18@3",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:           "onnx",
+				Framework:        "pytorch",
+				FrameworkVersion: "2.1.0",
+				OpsetVersion:     17,
+			},
+		},
+		{
+			Name:         "classifier",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/models/classifier/saved_model.pb")),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/classifier",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:           "tensorflow-saved-model",
+				Framework:        "tensorflow",
+				FrameworkVersion: "2.14.0",
+				Tags:             []string{"gpu", "serve"},
+			},
+		},
+		{
+			Name:         "legacy",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/models/legacy.pt")),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/legacy",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:    "pytorch",
+				Framework: "pytorch",
+			},
+		},
+		{
+			Name:         "checkpoint",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/models/checkpoint.pth")),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/checkpoint",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:    "pytorch",
+				Framework: "pytorch",
+			},
+		},
+		{
+			Name:    "tinyllama-1.1b-chat.Q4_K_M",
+			Version: "v1.0",
+			FoundBy: catalogerName,
+			Licenses: []pkg.License{
+				pkg.NewLicenseFromLocation("apache-2.0", source.NewLocation(gguf)),
+			},
+			Locations:    source.NewLocationSet(source.NewLocation(gguf)),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/tinyllama-1.1b-chat.Q4_K_M@v1.0",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:             "gguf",
+				ArchitectureFamily: "llama",
+				Quantization:       "Q4_K_M",
+				Parameters:         3072,
+			},
+		},
+		{
+			Name:    "bert-tiny",
+			FoundBy: catalogerName,
+			Locations: source.NewLocationSet(
+				source.NewLocation("test-fixtures/models/hf/bert-tiny/model-00001-of-00002.safetensors"),
+				source.NewLocation("test-fixtures/models/hf/bert-tiny/model-00002-of-00002.safetensors"),
+			),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/bert-tiny",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:             "safetensors",
+				Framework:          "transformers",
+				FrameworkVersion:   "4.35.2",
+				ArchitectureFamily: "bert",
+				ModelArchitecture:  "BertForMaskedLM",
+				Quantization:       "BF16",
+				Parameters:         1872,
+			},
+		},
+		{
+			Name:         "v1-5-pruned",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/models/sd/v1-5-pruned.safetensors")),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/v1-5-pruned",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:       "safetensors",
+				Framework:    "pytorch",
+				Quantization: "F16",
+				Parameters:   20,
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		WithResolver(resolver).
+		Expects(expected, nil).
+		TestCataloger(t, NewAIModelCataloger())
+}
@@ -0,0 +1,26 @@
+package aimodel
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+// newAIModelPackage creates a package for a machine learning model, where the first location is the file the model
+// was described by (and any others are the remaining files of a sharded model).
+func newAIModelPackage(name, version, license string, metadata pkg.AIModelMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Licenses:     pkg.NewLicensesFromLocation(locations[0], license),
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.AIModelPkg,
+		PURL:         purl.New(purl.TypeGeneric, "", name, version, nil, ""),
+		MetadataType: pkg.AIModelMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
@@ -0,0 +1,272 @@
+package aimodel
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseGGUFFile
+
+const ggufFormat = "gguf"
+
+// the types of GGUF metadata values (see https://github.com/ggerganov/ggml/blob/master/docs/gguf.md)
+const (
+	ggufUint8 uint32 = iota
+	ggufInt8
+	ggufUint16
+	ggufInt16
+	ggufUint32
+	ggufInt32
+	ggufFloat32
+	ggufBool
+	ggufString
+	ggufArray
+	ggufUint64
+	ggufInt64
+	ggufFloat64
+)
+
+const (
+	// the version 1 format used 32-bit lengths and counts, and is no longer produced
+	minGGUFVersion = 2
+
+	// limits on the sizes read from the header, well beyond those of real models, to stop early on corrupt files
+	maxGGUFStringSize  = 64 * 1024
+	maxGGUFCount       = 1 << 24
+	maxGGUFDimensions  = 8
+	maxGGUFSkippedSize = 1 << 40
+)
+
+var ggufMagic = []byte("GGUF")
+
+// ggufFileTypes are the names of the values of the "general.file_type" key, which describe the predominant type of the
+// tensors (i.e. the quantization)
+var ggufFileTypes = map[uint32]string{
+	0:  "F32",
+	1:  "F16",
+	2:  "Q4_0",
+	3:  "Q4_1",
+	7:  "Q8_0",
+	8:  "Q5_0",
+	9:  "Q5_1",
+	10: "Q2_K",
+	11: "Q3_K_S",
+	12: "Q3_K_M",
+	13: "Q3_K_L",
+	14: "Q4_K_S",
+	15: "Q4_K_M",
+	16: "Q5_K_S",
+	17: "Q5_K_M",
+	18: "Q6_K",
+}
+
+// ggufHeader is the part of a GGUF file describing the model: the "general.*" metadata and the shapes of the tensors.
+type ggufHeader struct {
+	general    map[string]string // the "general.*" string values, e.g. "general.architecture"
+	fileType   *uint32
+	parameters int64
+}
+
+// parseGGUFFile catalogs a GGUF model, as used by llama.cpp and other GGML-based runtimes.
+func parseGGUFFile(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	name, shards, ok := modelFile(reader.RealPath)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	header, err := readGGUFHeader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read GGUF model: %w", err)
+	}
+
+	locations := []source.Location{reader.Location}
+	locations = append(locations, readShards(resolver, reader.Location, shards, func(r io.Reader) error {
+		shard, err := readGGUFHeader(r)
+		if err != nil {
+			return err
+		}
+		header.parameters += shard.parameters
+		return nil
+	})...)
+
+	metadata := pkg.AIModelMetadata{
+		Format:             ggufFormat,
+		ArchitectureFamily: header.general["general.architecture"],
+		Parameters:         header.parameters,
+	}
+	if header.fileType != nil {
+		metadata.Quantization = ggufFileTypes[*header.fileType]
+	}
+
+	return []pkg.Package{
+		newAIModelPackage(name, header.general["general.version"], header.general["general.license"], metadata, locations...),
+	}, nil, nil
+}
+
+// readGGUFHeader reads the metadata and tensor descriptions of a GGUF file, stopping before the tensor data.
+func readGGUFHeader(r io.Reader) (*ggufHeader, error) {
+	g := &ggufReader{r: bufio.NewReader(r)}
+	magic := g.bytes(uint64(len(ggufMagic)))
+	if g.err != nil || !bytes.Equal(magic, ggufMagic) {
+		return nil, fmt.Errorf("not a GGUF file")
+	}
+	if version := g.uint32(); version < minGGUFVersion {
+		return nil, fmt.Errorf("unsupported GGUF version %d", version)
+	}
+	tensors := g.count()
+	values := g.count()
+
+	header := ggufHeader{general: make(map[string]string)}
+	for i := uint64(0); i < values && g.err == nil; i++ {
+		key := string(g.bytes(g.uint64()))
+		valueType := g.uint32()
+		switch {
+		case valueType == ggufString && strings.HasPrefix(key, "general."):
+			header.general[key] = g.string()
+		case valueType == ggufUint32 && key == "general.file_type":
+			fileType := g.uint32()
+			header.fileType = &fileType
+		default:
+			g.skipValue(valueType)
+		}
+	}
+
+	for i := uint64(0); i < tensors && g.err == nil; i++ {
+		g.skip(g.uint64()) // the name of the tensor
+		dimensions := g.uint32()
+		if dimensions > maxGGUFDimensions {
+			g.err = fmt.Errorf("tensor with %d dimensions", dimensions)
+			break
+		}
+		size := int64(1)
+		for d := uint32(0); d < dimensions; d++ {
+			size *= int64(g.uint64())
+		}
+		g.uint32() // the type of the tensor
+		g.uint64() // the offset of the tensor data
+		header.parameters += size
+	}
+
+	if g.err != nil {
+		return nil, g.err
+	}
+	return &header, nil
+}
+
+// ggufReader decodes the (little-endian) values of a GGUF header, where the first error encountered is kept (and all
+// later reads return zero values).
+type ggufReader struct {
+	r   *bufio.Reader
+	err error
+}
+
+func (g *ggufReader) bytes(n uint64) []byte {
+	if g.err != nil {
+		return nil
+	}
+	if n > maxGGUFStringSize {
+		g.err = fmt.Errorf("value of %d bytes exceeds the limit of %d bytes", n, maxGGUFStringSize)
+		return nil
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(g.r, b); err != nil {
+		g.err = io.ErrUnexpectedEOF
+		return nil
+	}
+	return b
+}
+
+// string reads a string value, where values beyond the size limit (e.g. the full text of a license) are skipped.
+func (g *ggufReader) string() string {
+	n := g.uint64()
+	if n > maxGGUFStringSize {
+		g.skip(n)
+		return ""
+	}
+	return string(g.bytes(n))
+}
+
+func (g *ggufReader) uint32() uint32 {
+	b := g.bytes(4)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint32(b)
+}
+
+func (g *ggufReader) uint64() uint64 {
+	b := g.bytes(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (g *ggufReader) count() uint64 {
+	n := g.uint64()
+	if g.err == nil && n > maxGGUFCount {
+		g.err = fmt.Errorf("count of %d exceeds the limit of %d", n, maxGGUFCount)
+	}
+	return n
+}
+
+func (g *ggufReader) skip(n uint64) {
+	if g.err != nil {
+		return
+	}
+	if n > maxGGUFSkippedSize {
+		g.err = fmt.Errorf("value of %d bytes exceeds the limit of %d bytes", n, uint64(maxGGUFSkippedSize))
+		return
+	}
+	if _, err := io.CopyN(io.Discard, g.r, int64(n)); err != nil {
+		g.err = io.ErrUnexpectedEOF
+	}
+}
+
+// skipValue skips a metadata value of the given type, such as the (large) vocabulary of the tokenizer.
+func (g *ggufReader) skipValue(valueType uint32) {
+	switch valueType {
+	case ggufString:
+		g.skip(g.uint64())
+	case ggufArray:
+		elementType := g.uint32()
+		n := g.count()
+		if size := ggufValueSize(elementType); size > 0 {
+			g.skip(n * size)
+			return
+		}
+		for i := uint64(0); i < n && g.err == nil; i++ {
+			g.skipValue(elementType)
+		}
+	default:
+		size := ggufValueSize(valueType)
+		if size == 0 && g.err == nil {
+			g.err = fmt.Errorf("unknown value type %d", valueType)
+		}
+		g.skip(size)
+	}
+}
+
+// ggufValueSize returns the size of a fixed-size value of the given type, or zero for strings, arrays, and unknown types.
+func ggufValueSize(valueType uint32) uint64 {
+	switch valueType {
+	case ggufUint8, ggufInt8, ggufBool:
+		return 1
+	case ggufUint16, ggufInt16:
+		return 2
+	case ggufUint32, ggufInt32, ggufFloat32:
+		return 4
+	case ggufUint64, ggufInt64, ggufFloat64:
+		return 8
+	}
+	return 0
+}
@@ -0,0 +1,63 @@
+package aimodel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseGGUFFile(t *testing.T) {
+	fixture := "test-fixtures/models/tinyllama-1.1b-chat.Q4_K_M.gguf"
+	expected := []pkg.Package{
+		{
+			Name:    "tinyllama-1.1b-chat.Q4_K_M",
+			Version: "v1.0",
+			Licenses: []pkg.License{
+				pkg.NewLicenseFromLocation("apache-2.0", source.NewLocation(fixture)),
+			},
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/tinyllama-1.1b-chat.Q4_K_M@v1.0",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:             "gguf",
+				ArchitectureFamily: "llama",
+				Quantization:       "Q4_K_M",
+				Parameters:         3072,
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseGGUFFile, expected, nil)
+}
+
+func TestReadGGUFHeader_invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "not a GGUF file",
+			input: "GGML",
+		},
+		{
+			name:  "version 1",
+			input: "GGUF\x01\x00\x00\x00",
+		},
+		{
+			name:  "truncated",
+			input: "GGUF\x03\x00\x00\x00\x01\x00\x00\x00\x00\x00\x00\x00",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := readGGUFHeader(strings.NewReader(test.input))
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package aimodel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseONNXFile
+
+const onnxFormat = "onnx"
+
+// the fields of the ModelProto and OperatorSetIdProto messages (see https://github.com/onnx/onnx/blob/main/onnx/onnx.proto)
+const (
+	onnxIRVersionField       = 1
+	onnxProducerNameField    = 2
+	onnxProducerVersionField = 3
+	onnxModelVersionField    = 5
+	onnxOpsetImportField     = 8
+
+	onnxOpsetDomainField  = 1
+	onnxOpsetVersionField = 2
+)
+
+// onnxModel is the part of an ONNX model (ModelProto) describing the model, without the graph.
+type onnxModel struct {
+	irVersion       uint64
+	producerName    string // e.g. "pytorch", "tf2onnx", or "skl2onnx"
+	producerVersion string
+	modelVersion    int64
+	opsetVersion    int // the version of the default ("ai.onnx") operator set
+}
+
+// parseONNXFile catalogs an ONNX model.
+func parseONNXFile(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	model, err := readONNXModel(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read ONNX model: %w", err)
+	}
+
+	var version string
+	if model.modelVersion > 0 {
+		version = strconv.FormatInt(model.modelVersion, 10)
+	}
+
+	return []pkg.Package{
+		newAIModelPackage(
+			strings.TrimSuffix(path.Base(reader.RealPath), path.Ext(reader.RealPath)),
+			version,
+			"",
+			pkg.AIModelMetadata{
+				Format:           onnxFormat,
+				Framework:        model.producerName,
+				FrameworkVersion: model.producerVersion,
+				OpsetVersion:     model.opsetVersion,
+			},
+			reader.Location,
+		),
+	}, nil, nil
+}
+
+// readONNXModel reads the fields of an ONNX model of interest, skipping the graph.
+func readONNXModel(r io.Reader) (*onnxModel, error) {
+	p := newProtoReader(r)
+	var model onnxModel
+	for {
+		field, wireType, err := p.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case field == onnxIRVersionField && wireType == protoVarint:
+			model.irVersion, err = p.varint()
+		case field == onnxProducerNameField && wireType == protoBytes:
+			model.producerName, err = p.string()
+		case field == onnxProducerVersionField && wireType == protoBytes:
+			model.producerVersion, err = p.string()
+		case field == onnxModelVersionField && wireType == protoVarint:
+			var v uint64
+			v, err = p.varint()
+			model.modelVersion = int64(v)
+		case field == onnxOpsetImportField && wireType == protoBytes:
+			err = readONNXOpsetImport(p, &model)
+		default:
+			err = p.skip(wireType)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if model.irVersion == 0 {
+		return nil, fmt.Errorf("not an ONNX model")
+	}
+	return &model, nil
+}
+
+// readONNXOpsetImport reads an operator set the model imports, keeping the version of the default operator set.
+func readONNXOpsetImport(p *protoReader, model *onnxModel) error {
+	opset, err := p.message()
+	if err != nil {
+		return err
+	}
+
+	var domain string
+	var version uint64
+	for {
+		field, wireType, err := opset.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case field == onnxOpsetDomainField && wireType == protoBytes:
+			domain, err = opset.string()
+		case field == onnxOpsetVersionField && wireType == protoVarint:
+			version, err = opset.varint()
+		default:
+			err = opset.skip(wireType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if domain == "" || domain == "ai.onnx" {
+		model.opsetVersion = int(version)
+	}
+	return nil
+}
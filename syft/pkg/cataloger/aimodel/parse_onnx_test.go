@@ -0,0 +1,38 @@
+package aimodel
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseONNXFile(t *testing.T) {
+	fixture := "test-fixtures/models/resnet18.onnx"
+	expected := []pkg.Package{
+		{
+			Name:         "resnet18",
+			Version:      "3",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/resnet18@3",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:           "onnx",
+				Framework:        "pytorch",
+				FrameworkVersion: "2.1.0",
+				OpsetVersion:     17,
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseONNXFile, expected, nil)
+}
+
+func TestParseONNXFile_notAModel(t *testing.T) {
+	pkgtest.NewCatalogTester().
+		FromFile(t, "test-fixtures/models/not-a-model.onnx").
+		WithError().
+		TestParser(t, parseONNXFile)
+}
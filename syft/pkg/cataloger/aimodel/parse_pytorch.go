@@ -0,0 +1,88 @@
+package aimodel
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parsePyTorchFile
+
+const (
+	pytorchFormat    = "pytorch"
+	pytorchFramework = "pytorch"
+
+	// the size of the fixed part of a zip local file header, after the signature
+	zipLocalHeaderSize = 26
+)
+
+var (
+	zipLocalHeaderSignature = []byte("PK\x03\x04")
+
+	// the beginning of a file written by torch.save before PyTorch 1.6: a pickle (protocol 2) of the magic number
+	// 0x1950a86a20f9469cfc6c
+	pytorchLegacyMagic = []byte("\x80\x02\x8a\x0a\x6c\xfc\x9c\x46\xf9\x20\x6a\xa8\x50\x19")
+
+	// the entries of the zip archives written by torch.save and torch.jit.save, which are all within a single
+	// top-level directory (e.g. "archive/data.pkl")
+	pytorchArchiveEntryPattern = regexp.MustCompile(`^[^/]+/(data\.pkl|constants\.pkl|version|\.data/version|\.format_version|byteorder|code/.+)$`)
+)
+
+// parsePyTorchFile catalogs a PyTorch model (or checkpoint), which is either a zip archive or (for files written
+// before PyTorch 1.6) a pickle.
+func parsePyTorchFile(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	isModel, err := isPyTorchFile(bufio.NewReader(reader))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read PyTorch model: %w", err)
+	}
+	if !isModel {
+		log.WithFields("path", reader.RealPath).Trace("not a PyTorch model")
+		return nil, nil, nil
+	}
+
+	return []pkg.Package{
+		newAIModelPackage(
+			strings.TrimSuffix(path.Base(reader.RealPath), path.Ext(reader.RealPath)),
+			"",
+			"",
+			pkg.AIModelMetadata{
+				Format:    pytorchFormat,
+				Framework: pytorchFramework,
+			},
+			reader.Location,
+		),
+	}, nil, nil
+}
+
+// isPyTorchFile indicates whether the file was written by PyTorch, by the magic number of a legacy file or the name of
+// the first entry of a zip archive.
+func isPyTorchFile(r *bufio.Reader) (bool, error) {
+	if magic, _ := r.Peek(len(pytorchLegacyMagic)); bytes.Equal(magic, pytorchLegacyMagic) {
+		return true, nil
+	}
+
+	signature := make([]byte, len(zipLocalHeaderSignature))
+	if _, err := io.ReadFull(r, signature); err != nil || !bytes.Equal(signature, zipLocalHeaderSignature) {
+		return false, nil
+	}
+	header := make([]byte, zipLocalHeaderSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return false, fmt.Errorf("unable to read zip entry: %w", err)
+	}
+	name := make([]byte, binary.LittleEndian.Uint16(header[22:24]))
+	if _, err := io.ReadFull(r, name); err != nil {
+		return false, fmt.Errorf("unable to read zip entry: %w", err)
+	}
+	return pytorchArchiveEntryPattern.Match(name), nil
+}
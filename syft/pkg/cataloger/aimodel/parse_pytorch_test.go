@@ -0,0 +1,61 @@
+package aimodel
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParsePyTorchFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			name:    "zip archive",
+			fixture: "test-fixtures/models/checkpoint.pth",
+			expected: []pkg.Package{
+				{
+					Name:         "checkpoint",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/models/checkpoint.pth")),
+					Type:         pkg.AIModelPkg,
+					PURL:         "pkg:generic/checkpoint",
+					MetadataType: pkg.AIModelMetadataType,
+					Metadata: pkg.AIModelMetadata{
+						Format:    "pytorch",
+						Framework: "pytorch",
+					},
+				},
+			},
+		},
+		{
+			name:    "legacy pickle",
+			fixture: "test-fixtures/models/legacy.pt",
+			expected: []pkg.Package{
+				{
+					Name:         "legacy",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/models/legacy.pt")),
+					Type:         pkg.AIModelPkg,
+					PURL:         "pkg:generic/legacy",
+					MetadataType: pkg.AIModelMetadataType,
+					Metadata: pkg.AIModelMetadata{
+						Format:    "pytorch",
+						Framework: "pytorch",
+					},
+				},
+			},
+		},
+		{
+			name:    "not a model",
+			fixture: "test-fixtures/models/not-a-model.pt",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.TestFileParser(t, test.fixture, parsePyTorchFile, test.expected, nil)
+		})
+	}
+}
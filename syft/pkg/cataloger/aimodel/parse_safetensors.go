@@ -0,0 +1,188 @@
+package aimodel
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseSafetensorsFile
+
+const (
+	safetensorsFormat      = "safetensors"
+	transformersFramework  = "transformers"
+	transformersConfigName = "config.json"
+
+	// the limit of the header size set by the format, to stop early on corrupt files
+	maxSafetensorsHeaderSize = 100 * 1024 * 1024
+	// the key of the free-form metadata within the header, which is not a tensor
+	safetensorsMetadataKey = "__metadata__"
+)
+
+// safetensorsFrameworks are the frameworks of the values of the "format" metadata written by the safetensors library
+var safetensorsFrameworks = map[string]string{
+	"pt":   "pytorch",
+	"tf":   "tensorflow",
+	"flax": "flax",
+	"np":   "numpy",
+	"mlx":  "mlx",
+}
+
+// safetensorsHeader is the JSON header of a safetensors file, describing the type and shape of each tensor.
+type safetensorsHeader struct {
+	metadata   map[string]string
+	parameters map[string]int64 // the number of parameters by type, e.g. "BF16"
+}
+
+type safetensorsTensor struct {
+	DType string  `json:"dtype"`
+	Shape []int64 `json:"shape"`
+}
+
+// transformersConfig is the config.json file stored alongside the weights of a Hugging Face Transformers model.
+type transformersConfig struct {
+	ModelType           string   `json:"model_type"`
+	Architectures       []string `json:"architectures"`
+	TransformersVersion string   `json:"transformers_version"`
+}
+
+// parseSafetensorsFile catalogs a safetensors model, where a model stored within a Hugging Face Transformers model
+// directory (with a config.json file) is named by the directory.
+func parseSafetensorsFile(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	name, shards, ok := modelFile(reader.RealPath)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	header, err := readSafetensorsHeader(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read safetensors model: %w", err)
+	}
+
+	locations := []source.Location{reader.Location}
+	locations = append(locations, readShards(resolver, reader.Location, shards, func(r io.Reader) error {
+		shard, err := readSafetensorsHeader(r)
+		if err != nil {
+			return err
+		}
+		for dtype, n := range shard.parameters {
+			header.parameters[dtype] += n
+		}
+		return nil
+	})...)
+
+	metadata := pkg.AIModelMetadata{
+		Format:    safetensorsFormat,
+		Framework: safetensorsFrameworks[header.metadata["format"]],
+	}
+	metadata.Quantization, metadata.Parameters = predominantType(header.parameters)
+
+	if config := readTransformersConfig(resolver, reader.Location); config != nil {
+		name = path.Base(path.Dir(reader.RealPath))
+		metadata.Framework = transformersFramework
+		metadata.FrameworkVersion = config.TransformersVersion
+		metadata.ArchitectureFamily = config.ModelType
+		if len(config.Architectures) > 0 {
+			metadata.ModelArchitecture = config.Architectures[0]
+		}
+	}
+
+	return []pkg.Package{
+		newAIModelPackage(name, "", "", metadata, locations...),
+	}, nil, nil
+}
+
+// readSafetensorsHeader reads the header of a safetensors file, which is a JSON object (preceded by its size).
+func readSafetensorsHeader(r io.Reader) (*safetensorsHeader, error) {
+	var size uint64
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, fmt.Errorf("unable to read header size: %w", err)
+	}
+	if size > maxSafetensorsHeaderSize {
+		return nil, fmt.Errorf("header of %d bytes exceeds the limit of %d bytes", size, maxSafetensorsHeaderSize)
+	}
+
+	var entries map[string]json.RawMessage
+	if err := json.NewDecoder(io.LimitReader(r, int64(size))).Decode(&entries); err != nil {
+		return nil, fmt.Errorf("unable to parse header: %w", err)
+	}
+
+	header := safetensorsHeader{parameters: make(map[string]int64)}
+	for key, entry := range entries {
+		if key == safetensorsMetadataKey {
+			if err := json.Unmarshal(entry, &header.metadata); err != nil {
+				return nil, fmt.Errorf("unable to parse metadata: %w", err)
+			}
+			continue
+		}
+
+		var tensor safetensorsTensor
+		if err := json.Unmarshal(entry, &tensor); err != nil {
+			return nil, fmt.Errorf("unable to parse tensor %q: %w", key, err)
+		}
+		n := int64(1)
+		for _, d := range tensor.Shape {
+			n *= d
+		}
+		header.parameters[tensor.DType] += n
+	}
+	return &header, nil
+}
+
+// predominantType returns the type with the most parameters, along with the total number of parameters.
+func predominantType(parameters map[string]int64) (string, int64) {
+	types := make([]string, 0, len(parameters))
+	var total int64
+	for t, n := range parameters {
+		types = append(types, t)
+		total += n
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if parameters[types[i]] == parameters[types[j]] {
+			return types[i] < types[j]
+		}
+		return parameters[types[i]] > parameters[types[j]]
+	})
+	if len(types) == 0 {
+		return "", 0
+	}
+	return types[0], total
+}
+
+// readTransformersConfig reads the config.json file within the directory of the given model file, returning nil when
+// there is no such file (or it is not the configuration of a Transformers model).
+func readTransformersConfig(resolver source.FileResolver, location source.Location) *transformersConfig {
+	if resolver == nil {
+		return nil
+	}
+	configLocation := resolver.RelativeFileByPath(location, path.Join(path.Dir(location.RealPath), transformersConfigName))
+	if configLocation == nil {
+		return nil
+	}
+	reader, err := resolver.FileContentsByLocation(*configLocation)
+	if err != nil {
+		log.WithFields("path", configLocation.RealPath, "error", err).Debug("unable to read model configuration")
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, configLocation.RealPath)
+
+	var config transformersConfig
+	if err := json.NewDecoder(reader).Decode(&config); err != nil {
+		log.WithFields("path", configLocation.RealPath, "error", err).Debug("unable to parse model configuration")
+		return nil
+	}
+	if config.ModelType == "" && config.TransformersVersion == "" {
+		return nil
+	}
+	return &config
+}
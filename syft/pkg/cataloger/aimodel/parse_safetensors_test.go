@@ -0,0 +1,87 @@
+package aimodel
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseSafetensorsFile(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		paths    []string
+		expected []pkg.Package
+	}{
+		{
+			name:    "sharded transformers model",
+			fixture: "test-fixtures/models/hf/bert-tiny/model-00001-of-00002.safetensors",
+			paths: []string{
+				"test-fixtures/models/hf/bert-tiny/config.json",
+				"test-fixtures/models/hf/bert-tiny/model-00001-of-00002.safetensors",
+				"test-fixtures/models/hf/bert-tiny/model-00002-of-00002.safetensors",
+			},
+			expected: []pkg.Package{
+				{
+					Name: "bert-tiny",
+					Locations: source.NewLocationSet(
+						source.NewLocation("test-fixtures/models/hf/bert-tiny/model-00001-of-00002.safetensors"),
+						source.NewLocation("test-fixtures/models/hf/bert-tiny/model-00002-of-00002.safetensors"),
+					),
+					Type:         pkg.AIModelPkg,
+					PURL:         "pkg:generic/bert-tiny",
+					MetadataType: pkg.AIModelMetadataType,
+					Metadata: pkg.AIModelMetadata{
+						Format:             "safetensors",
+						Framework:          "transformers",
+						FrameworkVersion:   "4.35.2",
+						ArchitectureFamily: "bert",
+						ModelArchitecture:  "BertForMaskedLM",
+						Quantization:       "BF16",
+						Parameters:         1872,
+					},
+				},
+			},
+		},
+		{
+			name:    "remaining shard",
+			fixture: "test-fixtures/models/hf/bert-tiny/model-00002-of-00002.safetensors",
+			paths: []string{
+				"test-fixtures/models/hf/bert-tiny/config.json",
+				"test-fixtures/models/hf/bert-tiny/model-00001-of-00002.safetensors",
+				"test-fixtures/models/hf/bert-tiny/model-00002-of-00002.safetensors",
+			},
+		},
+		{
+			name:    "single file",
+			fixture: "test-fixtures/models/sd/v1-5-pruned.safetensors",
+			paths:   []string{"test-fixtures/models/sd/v1-5-pruned.safetensors"},
+			expected: []pkg.Package{
+				{
+					Name:         "v1-5-pruned",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/models/sd/v1-5-pruned.safetensors")),
+					Type:         pkg.AIModelPkg,
+					PURL:         "pkg:generic/v1-5-pruned",
+					MetadataType: pkg.AIModelMetadataType,
+					Metadata: pkg.AIModelMetadata{
+						Format:       "safetensors",
+						Framework:    "pytorch",
+						Quantization: "F16",
+						Parameters:   20,
+					},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromFile(t, test.fixture).
+				WithResolver(source.NewMockResolverForPaths(test.paths...)).
+				Expects(test.expected, nil).
+				TestParser(t, parseSafetensorsFile)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package aimodel
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseSavedModel
+
+const (
+	savedModelFormat    = "tensorflow-saved-model"
+	tensorflowFramework = "tensorflow"
+)
+
+// the fields of the SavedModel, MetaGraphDef, and MetaInfoDef messages (see
+// https://github.com/tensorflow/tensorflow/blob/master/tensorflow/core/protobuf/saved_model.proto and meta_graph.proto)
+const (
+	savedModelMetaGraphsField = 2
+
+	metaGraphMetaInfoDefField = 1
+
+	metaInfoTagsField              = 4
+	metaInfoTensorflowVersionField = 5
+)
+
+// savedModel is the part of a TensorFlow SavedModel (saved_model.pb) describing its meta graphs.
+type savedModel struct {
+	metaGraphs        int
+	tags              []string // the (unique) tags of all meta graphs
+	tensorflowVersion string
+}
+
+// parseSavedModel catalogs a TensorFlow SavedModel, which is the directory containing the saved_model.pb file.
+func parseSavedModel(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	model, err := readSavedModel(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read TensorFlow SavedModel: %w", err)
+	}
+
+	return []pkg.Package{
+		newAIModelPackage(
+			path.Base(path.Dir(reader.RealPath)),
+			"",
+			"",
+			pkg.AIModelMetadata{
+				Format:           savedModelFormat,
+				Framework:        tensorflowFramework,
+				FrameworkVersion: model.tensorflowVersion,
+				Tags:             model.tags,
+			},
+			reader.Location,
+		),
+	}, nil, nil
+}
+
+// readSavedModel reads the tags and TensorFlow version of each meta graph, skipping the graphs themselves.
+func readSavedModel(r io.Reader) (*savedModel, error) {
+	p := newProtoReader(r)
+	var model savedModel
+	tags := internal.NewStringSet()
+	for {
+		field, wireType, err := p.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if field == savedModelMetaGraphsField && wireType == protoBytes {
+			model.metaGraphs++
+			err = readMetaGraph(p, &model, tags)
+		} else {
+			err = p.skip(wireType)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if model.metaGraphs == 0 {
+		return nil, fmt.Errorf("no meta graphs")
+	}
+	if len(tags) > 0 {
+		model.tags = tags.ToSlice()
+	}
+	return &model, nil
+}
+
+func readMetaGraph(p *protoReader, model *savedModel, tags internal.StringSet) error {
+	metaGraph, err := p.message()
+	if err != nil {
+		return err
+	}
+
+	for {
+		field, wireType, err := metaGraph.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if field != metaGraphMetaInfoDefField || wireType != protoBytes {
+			if err := metaGraph.skip(wireType); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := readMetaInfoDef(metaGraph, model, tags); err != nil {
+			return err
+		}
+		// the graph itself (and everything else) follows the meta info
+		return metaGraph.discard()
+	}
+}
+
+func readMetaInfoDef(p *protoReader, model *savedModel, tags internal.StringSet) error {
+	metaInfo, err := p.message()
+	if err != nil {
+		return err
+	}
+
+	for {
+		field, wireType, err := metaInfo.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case field == metaInfoTagsField && wireType == protoBytes:
+			var tag string
+			tag, err = metaInfo.string()
+			tags.Add(tag)
+		case field == metaInfoTensorflowVersionField && wireType == protoBytes:
+			var version string
+			version, err = metaInfo.string()
+			if model.tensorflowVersion == "" {
+				model.tensorflowVersion = version
+			}
+		default:
+			err = metaInfo.skip(wireType)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
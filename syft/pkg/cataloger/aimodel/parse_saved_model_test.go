@@ -0,0 +1,31 @@
+package aimodel
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseSavedModel(t *testing.T) {
+	fixture := "test-fixtures/models/classifier/saved_model.pb"
+	expected := []pkg.Package{
+		{
+			// a SavedModel is named after its directory
+			Name:         "classifier",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Type:         pkg.AIModelPkg,
+			PURL:         "pkg:generic/classifier",
+			MetadataType: pkg.AIModelMetadataType,
+			Metadata: pkg.AIModelMetadata{
+				Format:           "tensorflow-saved-model",
+				Framework:        "tensorflow",
+				FrameworkVersion: "2.14.0",
+				Tags:             []string{"gpu", "serve"},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseSavedModel, expected, nil)
+}
@@ -0,0 +1,153 @@
+package aimodel
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// the wire types of protocol buffer fields (see https://protobuf.dev/programming-guides/encoding/#structure)
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// maxProtoStringSize is the largest string field that is read, which is well beyond the names and versions of interest
+const maxProtoStringSize = 64 * 1024
+
+// protoReader decodes the fields of a protocol buffer message from a stream, so that large fields (e.g. the weights
+// of a model) are skipped without being read into memory.
+type protoReader struct {
+	r *bufio.Reader
+	// the number of bytes left within the message, or -1 when the message ends with the stream
+	remaining int64
+}
+
+func newProtoReader(r io.Reader) *protoReader {
+	return &protoReader{r: bufio.NewReader(r), remaining: -1}
+}
+
+// next returns the number and wire type of the next field, or io.EOF at the end of the message.
+func (p *protoReader) next() (uint64, int, error) {
+	if p.remaining == 0 {
+		return 0, 0, io.EOF
+	}
+	if p.remaining < 0 {
+		if _, err := p.r.Peek(1); errors.Is(err, io.EOF) {
+			return 0, 0, io.EOF
+		}
+	}
+	key, err := p.varint()
+	if err != nil {
+		return 0, 0, err
+	}
+	return key >> 3, int(key & 0x7), nil
+}
+
+func (p *protoReader) ReadByte() (byte, error) {
+	if p.remaining == 0 {
+		return 0, io.ErrUnexpectedEOF
+	}
+	b, err := p.r.ReadByte()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		return 0, err
+	}
+	p.consume(1)
+	return b, nil
+}
+
+func (p *protoReader) varint() (uint64, error) {
+	return binary.ReadUvarint(p)
+}
+
+// length reads the length of a length-delimited field.
+func (p *protoReader) length() (int64, error) {
+	n, err := p.varint()
+	if err != nil {
+		return 0, err
+	}
+	if n > 1<<62 || (p.remaining >= 0 && int64(n) > p.remaining) {
+		return 0, fmt.Errorf("field of %d bytes exceeds the message", n)
+	}
+	return int64(n), nil
+}
+
+// string reads a length-delimited field as a string.
+func (p *protoReader) string() (string, error) {
+	n, err := p.length()
+	if err != nil {
+		return "", err
+	}
+	if n > maxProtoStringSize {
+		return "", fmt.Errorf("string field of %d bytes exceeds the limit of %d bytes", n, maxProtoStringSize)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(p.r, b); err != nil {
+		return "", io.ErrUnexpectedEOF
+	}
+	p.consume(n)
+	return string(b), nil
+}
+
+// message returns a reader for the embedded message of a length-delimited field, which must be read to the end (or
+// discarded) before the next field of this message is read.
+func (p *protoReader) message() (*protoReader, error) {
+	n, err := p.length()
+	if err != nil {
+		return nil, err
+	}
+	p.consume(n)
+	return &protoReader{r: p.r, remaining: n}, nil
+}
+
+// discard skips the remainder of an embedded message.
+func (p *protoReader) discard() error {
+	if p.remaining <= 0 {
+		return nil
+	}
+	return p.discardN(p.remaining)
+}
+
+// skip skips the value of a field of the given wire type.
+func (p *protoReader) skip(wireType int) error {
+	switch wireType {
+	case protoVarint:
+		_, err := p.varint()
+		return err
+	case protoFixed64:
+		return p.discardN(8)
+	case protoBytes:
+		n, err := p.length()
+		if err != nil {
+			return err
+		}
+		return p.discardN(n)
+	case protoFixed32:
+		return p.discardN(4)
+	}
+	return fmt.Errorf("unsupported wire type %d", wireType)
+}
+
+func (p *protoReader) discardN(n int64) error {
+	if p.remaining >= 0 && n > p.remaining {
+		return io.ErrUnexpectedEOF
+	}
+	if _, err := io.CopyN(io.Discard, p.r, n); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	p.consume(n)
+	return nil
+}
+
+func (p *protoReader) consume(n int64) {
+	if p.remaining > 0 {
+		p.remaining -= n
+	}
+}
@@ -0,0 +1,73 @@
+package aimodel
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source"
+)
+
+// shardPattern matches the name (without the extension) of a single file of a model split across several files, e.g.
+// "model-00001-of-00004" (for "model-00001-of-00004.safetensors")
+var shardPattern = regexp.MustCompile(`^(.+)-(\d{5})-of-(\d{5})$`)
+
+// modelFile returns the name of the model stored within the given file, which is the name of the file without the
+// extension (and shard number). For the first file of a sharded model, the paths of the remaining shards are returned
+// too, where the remaining shards are not cataloged themselves (ok is false) since they belong to the first.
+func modelFile(p string) (name string, shards []string, ok bool) {
+	ext := path.Ext(p)
+	name = strings.TrimSuffix(path.Base(p), ext)
+	match := shardPattern.FindStringSubmatch(name)
+	if match == nil {
+		return name, nil, true
+	}
+
+	shard, _ := strconv.Atoi(match[2])
+	total, _ := strconv.Atoi(match[3])
+	if shard != 1 {
+		return "", nil, false
+	}
+	for i := 2; i <= total; i++ {
+		shards = append(shards, path.Join(path.Dir(p), fmt.Sprintf("%s-%05d-of-%s%s", match[1], i, match[3], ext)))
+	}
+	return match[1], shards, true
+}
+
+// readShards reads each of the given remaining shards of a model (relative to the first) with the given function,
+// returning the locations of the shards that were read.
+func readShards(resolver source.FileResolver, first source.Location, shards []string, read func(io.Reader) error) []source.Location {
+	if resolver == nil {
+		return nil
+	}
+
+	var locations []source.Location
+	for _, shard := range shards {
+		location := resolver.RelativeFileByPath(first, shard)
+		if location == nil {
+			log.WithFields("path", shard).Trace("shard of model not found")
+			continue
+		}
+		if err := readShard(resolver, *location, read); err != nil {
+			log.WithFields("path", location.RealPath, "error", err).Debug("unable to read shard of model")
+			continue
+		}
+		locations = append(locations, *location)
+	}
+	return locations
+}
+
+func readShard(resolver source.FileResolver, location source.Location, read func(io.Reader) error) error {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	return read(reader)
+}
@@ -0,0 +1,47 @@
+package aimodel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_modelFile(t *testing.T) {
+	tests := []struct {
+		path       string
+		wantName   string
+		wantShards []string
+		wantOk     bool
+	}{
+		{
+			path:     "/models/model.safetensors",
+			wantName: "model",
+			wantOk:   true,
+		},
+		{
+			path:     "/models/llama-2-7b.Q4_K_M.gguf",
+			wantName: "llama-2-7b.Q4_K_M",
+			wantOk:   true,
+		},
+		{
+			path:     "/models/model-00001-of-00003.safetensors",
+			wantName: "model",
+			wantShards: []string{
+				"/models/model-00002-of-00003.safetensors",
+				"/models/model-00003-of-00003.safetensors",
+			},
+			wantOk: true,
+		},
+		{
+			path: "/models/model-00002-of-00003.safetensors",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			name, shards, ok := modelFile(test.path)
+			assert.Equal(t, test.wantName, name)
+			assert.Equal(t, test.wantShards, shards)
+			assert.Equal(t, test.wantOk, ok)
+		})
+	}
+}
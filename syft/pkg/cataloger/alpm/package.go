@@ -3,9 +3,9 @@ package alpm
 import (
 	"strings"
 
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -15,7 +15,7 @@ func newPackage(m pkg.AlpmMetadata, release *linux.Release, locations ...source.
 		Version:      m.Version,
 		Locations:    source.NewLocationSet(locations...),
 		Type:         pkg.AlpmPkg,
-		Licenses:     strings.Split(m.License, " "),
+		Licenses:     pkg.NewLicensesFromValues(strings.Split(m.License, " ")...),
 		PURL:         packageURL(m, release),
 		MetadataType: pkg.AlpmMetadataType,
 		Metadata:     m,
@@ -25,28 +25,5 @@ func newPackage(m pkg.AlpmMetadata, release *linux.Release, locations ...source.
 }
 
 func packageURL(m pkg.AlpmMetadata, distro *linux.Release) string {
-	if distro == nil || distro.ID != "arch" {
-		// note: there is no namespace variation (like with debian ID_LIKE for ubuntu ID, for example)
-		return ""
-	}
-
-	qualifiers := map[string]string{
-		pkg.PURLQualifierArch: m.Architecture,
-	}
-
-	if m.BasePackage != "" {
-		qualifiers[pkg.PURLQualifierUpstream] = m.BasePackage
-	}
-
-	return packageurl.NewPackageURL(
-		"alpm", // `alpm` for Arch Linux and other users of the libalpm/pacman package manager. (see https://github.com/package-url/purl-spec/pull/164)
-		distro.ID,
-		m.Package,
-		m.Version,
-		pkg.PURLQualifiers(
-			qualifiers,
-			distro,
-		),
-		"",
-	).ToString()
+	return purl.Alpm(distro, m.Package, m.Version, m.Architecture, m.BasePackage)
 }
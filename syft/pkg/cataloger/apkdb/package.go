@@ -3,9 +3,9 @@ package apkdb
 import (
 	"strings"
 
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -14,7 +14,7 @@ func newPackage(d pkg.ApkMetadata, release *linux.Release, locations ...source.L
 		Name:         d.Package,
 		Version:      d.Version,
 		Locations:    source.NewLocationSet(locations...),
-		Licenses:     strings.Split(d.License, " "),
+		Licenses:     pkg.NewLicensesFromValues(strings.Split(d.License, " ")...),
 		PURL:         packageURL(d, release),
 		Type:         pkg.ApkPkg,
 		MetadataType: pkg.ApkMetadataType,
@@ -28,30 +28,5 @@ func newPackage(d pkg.ApkMetadata, release *linux.Release, locations ...source.L
 
 // packageURL returns the PURL for the specific Alpine package (see https://github.com/package-url/purl-spec)
 func packageURL(m pkg.ApkMetadata, distro *linux.Release) string {
-	if distro == nil || distro.ID != "alpine" {
-		// note: there is no namespace variation (like with debian ID_LIKE for ubuntu ID, for example)
-		return ""
-	}
-
-	qualifiers := map[string]string{
-		pkg.PURLQualifierArch: m.Architecture,
-	}
-
-	if m.OriginPackage != "" {
-		qualifiers[pkg.PURLQualifierUpstream] = m.OriginPackage
-	}
-
-	return packageurl.NewPackageURL(
-		// note: this is currently a candidate and not technically within spec
-		// see https://github.com/package-url/purl-spec#other-candidate-types-to-define
-		"alpine",
-		"",
-		m.Package,
-		m.Version,
-		pkg.PURLQualifiers(
-			qualifiers,
-			distro,
-		),
-		"",
-	).ToString()
+	return purl.Apk(distro, m.Package, m.Version, m.Architecture, m.OriginPackage)
 }
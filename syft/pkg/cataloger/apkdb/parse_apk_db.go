@@ -16,6 +16,7 @@ import (
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/dependency"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -66,7 +67,45 @@ func parseApkDB(_ source.FileResolver, env *generic.Environment, reader source.L
 		return nil, nil, fmt.Errorf("failed to parse APK DB file: %w", err)
 	}
 
-	return pkgs, nil, nil
+	return pkgs, dependency.Resolve(dependencySpecification, pkgs), nil
+}
+
+// dependencySpecification describes the names that an alpine package provides (the package name, the "p" field
+// entries such as "so:libz.so.1" and "cmd:ls", and the files owned by the package) and the names listed as dependencies
+// within the "D" field. Conflicts (e.g. "!foo") are not dependencies and are ignored.
+func dependencySpecification(p pkg.Package) dependency.Specification {
+	metadata, ok := p.Metadata.(pkg.ApkMetadata)
+	if !ok {
+		return dependency.Specification{}
+	}
+
+	provides := []string{p.Name}
+	for _, name := range metadata.Provides {
+		provides = append(provides, stripVersionConstraint(name))
+	}
+	provides = append(provides, metadata.OwnedFiles()...)
+
+	var requires []dependency.Requirement
+	for _, name := range strings.Fields(metadata.PullDependencies) {
+		if strings.HasPrefix(name, "!") {
+			continue
+		}
+		requires = append(requires, dependency.Requirement{stripVersionConstraint(name)})
+	}
+
+	return dependency.Specification{
+		Provides: provides,
+		Requires: requires,
+	}
+}
+
+// stripVersionConstraint returns the name of a provides or dependency entry without the version constraint
+// (e.g. "so:libc.musl-x86_64.so.1" from "so:libc.musl-x86_64.so.1=1" or "busybox" from "busybox>=1.28").
+func stripVersionConstraint(entry string) string {
+	if idx := strings.IndexAny(entry, "<>=~"); idx >= 0 {
+		return entry[:idx]
+	}
+	return entry
 }
 
 // parseApkDBEntry reads and parses a single pkg.ApkMetadata element from the stream, returning nil if their are no more entries.
@@ -76,6 +115,8 @@ func parseApkDBEntry(reader io.Reader) (*pkg.ApkMetadata, error) {
 	var entry pkg.ApkMetadata
 	pkgFields := make(map[string]interface{})
 	files := make([]pkg.ApkFileRecord, 0)
+	// provides ("p") is decoded separately, otherwise it would be matched (case-insensitively) to the package name ("P")
+	var provides []string
 
 	var fileRecord *pkg.ApkFileRecord
 	lastFile := "/"
@@ -138,6 +179,8 @@ func parseApkDBEntry(reader io.Reader) (*pkg.ApkMetadata, error) {
 				return nil, fmt.Errorf("failed to parse APK int: '%+v'", value)
 			}
 			pkgFields[key] = iVal
+		case "p":
+			provides = strings.Fields(value)
 		default:
 			pkgFields[key] = value
 		}
@@ -150,6 +193,7 @@ func parseApkDBEntry(reader io.Reader) (*pkg.ApkMetadata, error) {
 		return nil, nil
 	}
 
+	entry.Provides = provides
 	entry.Files = files
 
 	return &entry, nil
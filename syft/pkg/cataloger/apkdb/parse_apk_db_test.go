@@ -94,6 +94,7 @@ func TestSinglePackageDetails(t *testing.T) {
 				Size:             37944,
 				InstalledSize:    151552,
 				PullDependencies: "scanelf so:libc.musl-x86_64.so.1",
+				Provides:         []string{"cmd:getconf", "cmd:getent", "cmd:iconv", "cmd:ldconfig", "cmd:ldd"},
 				PullChecksum:     "Q1bTtF5526tETKfL+lnigzIDvm+2o=",
 				GitCommitOfAport: "4024cc3b29ad4c65544ad068b8f59172b5494306",
 				Files: []pkg.ApkFileRecord{
@@ -173,6 +174,7 @@ func TestSinglePackageDetails(t *testing.T) {
 				Size:             19917,
 				InstalledSize:    409600,
 				PullDependencies: "/bin/sh so:libc.musl-x86_64.so.1",
+				Provides:         []string{"cmd:mkmntdirs"},
 				PullChecksum:     "Q1myMNfd7u5v5UTgNHeq1e31qTjZU=",
 				GitCommitOfAport: "e1c51734fa96fa4bac92e9f14a474324c67916fc",
 				Files: []pkg.ApkFileRecord{
@@ -637,9 +639,9 @@ func TestMultiplePackages(t *testing.T) {
 		{
 			Name:         "libc-utils",
 			Version:      "0.7.2-r0",
-			Licenses:     []string{"BSD"},
+			Licenses:     pkg.NewLicensesFromValues("BSD"),
 			Type:         pkg.ApkPkg,
-			PURL:         "pkg:alpine/libc-utils@0.7.2-r0?arch=x86_64&upstream=libc-dev&distro=alpine-3.12",
+			PURL:         "pkg:apk/alpine/libc-utils@0.7.2-r0?arch=x86_64&distro=alpine-3.12&upstream=libc-dev",
 			Locations:    fixtureLocationSet,
 			MetadataType: pkg.ApkMetadataType,
 			Metadata: pkg.ApkMetadata{
@@ -662,9 +664,9 @@ func TestMultiplePackages(t *testing.T) {
 		{
 			Name:         "musl-utils",
 			Version:      "1.1.24-r2",
-			Licenses:     []string{"MIT", "BSD", "GPL2+"},
+			Licenses:     pkg.NewLicensesFromValues("MIT", "BSD", "GPL2+"),
 			Type:         pkg.ApkPkg,
-			PURL:         "pkg:alpine/musl-utils@1.1.24-r2?arch=x86_64&upstream=musl&distro=alpine-3.12",
+			PURL:         "pkg:apk/alpine/musl-utils@1.1.24-r2?arch=x86_64&distro=alpine-3.12&upstream=musl",
 			Locations:    fixtureLocationSet,
 			MetadataType: pkg.ApkMetadataType,
 			Metadata: pkg.ApkMetadata{
@@ -679,6 +681,7 @@ func TestMultiplePackages(t *testing.T) {
 				Size:             37944,
 				InstalledSize:    151552,
 				PullDependencies: "scanelf so:libc.musl-x86_64.so.1",
+				Provides:         []string{"cmd:getconf", "cmd:getent", "cmd:iconv", "cmd:ldconfig", "cmd:ldd"},
 				PullChecksum:     "Q1bTtF5526tETKfL+lnigzIDvm+2o=",
 				GitCommitOfAport: "4024cc3b29ad4c65544ad068b8f59172b5494306",
 				Files: []pkg.ApkFileRecord{
@@ -746,8 +749,13 @@ func TestMultiplePackages(t *testing.T) {
 		},
 	}
 
-	// TODO: relationships are not under test
-	var expectedRelationships []artifact.Relationship
+	expectedRelationships := []artifact.Relationship{
+		{
+			From: expected[1],
+			To:   expected[0],
+			Type: artifact.DependencyOfRelationship,
+		},
+	}
 
 	env := generic.Environment{LinuxRelease: &linux.Release{
 		ID:        "alpine",
@@ -0,0 +1,97 @@
+/*
+Package binary provides a concrete Cataloger implementation for packages that can only be identified by examining the
+contents of binary files (e.g. language runtimes that are installed without a package manager).
+*/
+package binary
+
+import (
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+const catalogerName = "binary-cataloger"
+
+// Cataloger is the cataloger responsible for surfacing evidence of a very limited set of binary files, which have been
+// identified by the classifiers. The Cataloger is _NOT_ a place to catalog any and every binary, but rather the specific
+// set that has been curated to be important, predominantly runtimes and languages (plus any user-defined classifiers).
+type Cataloger struct {
+	classifiers []Classifier
+	maxFileSize int64
+}
+
+// NewCataloger returns a new binary cataloger object that runs the default classifiers as well as any additional
+// classifiers from the given configuration.
+func NewCataloger(cfg Config) *Cataloger {
+	classifiers := make([]Classifier, 0, len(DefaultClassifiers)+len(cfg.AdditionalClassifiers))
+	classifiers = append(classifiers, DefaultClassifiers...)
+	classifiers = append(classifiers, cfg.AdditionalClassifiers...)
+	return &Cataloger{
+		classifiers: classifiers,
+		maxFileSize: cfg.MaxFileSize,
+	}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c Cataloger) Name() string {
+	return catalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns any discovered Packages
+// after analyzing the catalog source.
+func (c Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	var packages []pkg.Package
+
+	for _, cls := range c.classifiers {
+		locations, err := resolver.FilesByGlob(cls.FileGlobs...)
+		if err != nil {
+			log.Warnf("unable to process globs for binary classifier=%q: %+v", cls.Class, err)
+			continue
+		}
+
+		for _, location := range uniqueLocations(locations) {
+			if c.exceedsMaxFileSize(resolver, location) {
+				log.WithFields("classifier", cls.Class, "location", location.RealPath).Debug("skipping binary larger than the max file size")
+				continue
+			}
+			p, err := cls.Classify(resolver, location)
+			if err != nil {
+				log.WithFields("classifier", cls.Class, "location", location.RealPath, "error", err).Warn("unable to classify binary")
+				continue
+			}
+			if p == nil {
+				continue
+			}
+			p.FoundBy = catalogerName
+			packages = append(packages, *p)
+		}
+	}
+
+	return packages, nil, nil
+}
+
+// exceedsMaxFileSize indicates if the file at the given location is larger than the configured max file size.
+func (c Cataloger) exceedsMaxFileSize(resolver source.FileResolver, location source.Location) bool {
+	if c.maxFileSize <= 0 {
+		return false
+	}
+	metadata, err := resolver.FileMetadataByLocation(location)
+	if err != nil {
+		return false
+	}
+	return metadata.Size > c.maxFileSize
+}
+
+func uniqueLocations(locations []source.Location) []source.Location {
+	seen := make(map[source.Location]struct{})
+	var results []source.Location
+	for _, l := range locations {
+		if _, ok := seen[l]; ok {
+			continue
+		}
+		seen[l] = struct{}{}
+		results = append(results, l)
+	}
+	return results
+}
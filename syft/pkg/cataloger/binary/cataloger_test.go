@@ -0,0 +1,205 @@
+package binary
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestClassifierCataloger_DefaultClassifiers_PositiveCases(t *testing.T) {
+	tests := []struct {
+		name            string
+		fixture         string
+		expectedName    string
+		expectedVersion string
+		expectedClass   string
+		expectedPURL    string
+	}{
+		{
+			name:            "positive-libpython3.7.so",
+			fixture:         "test-fixtures/classifiers/positive/libpython3.7.so",
+			expectedName:    "python",
+			expectedVersion: "3.7.4a-vZ9",
+			expectedClass:   "python-binary-lib",
+			expectedPURL:    "pkg:generic/python@3.7.4a-vZ9",
+		},
+		{
+			name:            "positive-python3.6",
+			fixture:         "test-fixtures/classifiers/positive/python3.6",
+			expectedName:    "python",
+			expectedVersion: "3.6.3a-vZ9",
+			expectedClass:   "python-binary",
+			expectedPURL:    "pkg:generic/python@3.6.3a-vZ9",
+		},
+		{
+			name:            "positive-patchlevel.h",
+			fixture:         "test-fixtures/classifiers/positive/patchlevel.h",
+			expectedName:    "python",
+			expectedVersion: "3.9-aZ5",
+			expectedClass:   "cpython-source",
+			expectedPURL:    "pkg:generic/python@3.9-aZ5",
+		},
+		{
+			name:            "positive-go",
+			fixture:         "test-fixtures/classifiers/positive/go",
+			expectedName:    "go",
+			expectedVersion: "1.14",
+			expectedClass:   "go-binary",
+			expectedPURL:    "pkg:generic/go@1.14",
+		},
+		{
+			name:            "positive-go-hint",
+			fixture:         "test-fixtures/classifiers/positive/VERSION",
+			expectedName:    "go",
+			expectedVersion: "1.15beta2",
+			expectedClass:   "go-binary-hint",
+			expectedPURL:    "pkg:generic/go@1.15beta2",
+		},
+		{
+			name:            "positive-node",
+			fixture:         "test-fixtures/classifiers/positive/node",
+			expectedName:    "node",
+			expectedVersion: "18.12.1",
+			expectedClass:   "nodejs-binary",
+			expectedPURL:    "pkg:generic/node@18.12.1",
+		},
+		{
+			name:            "positive-busybox",
+			fixture:         "test-fixtures/classifiers/positive/busybox",
+			expectedName:    "busybox",
+			expectedVersion: "3.33.3",
+			expectedClass:   "busybox-binary",
+			expectedPURL:    "pkg:generic/busybox@3.33.3",
+		},
+		{
+			name:            "positive-grub-modinfo",
+			fixture:         "test-fixtures/classifiers/positive/grub/i386-pc/modinfo.sh",
+			expectedName:    "grub2",
+			expectedVersion: "2.06-13+deb12u1",
+			expectedClass:   "grub-modinfo",
+			expectedPURL:    "pkg:generic/grub2@2.06-13+deb12u1",
+		},
+		{
+			name:            "positive-u-boot",
+			fixture:         "test-fixtures/classifiers/positive/u-boot.bin",
+			expectedName:    "u-boot",
+			expectedVersion: "2023.01-rc4",
+			expectedClass:   "u-boot-binary",
+			expectedPURL:    "pkg:generic/u-boot@2023.01-rc4",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewCataloger(Config{})
+
+			resolver := source.NewMockResolverForPaths(test.fixture)
+			packages, relationships, err := c.Catalog(resolver)
+			require.NoError(t, err)
+			assert.Empty(t, relationships)
+			require.Len(t, packages, 1)
+
+			p := packages[0]
+			assert.Equal(t, test.expectedName, p.Name)
+			assert.Equal(t, test.expectedVersion, p.Version)
+			assert.Equal(t, test.expectedPURL, p.PURL)
+			assert.Equal(t, pkg.BinaryPkg, p.Type)
+			assert.Equal(t, catalogerName, p.FoundBy)
+			for _, cpe := range p.CPEs {
+				assert.Equal(t, test.expectedVersion, cpe.Version)
+			}
+
+			metadata, ok := p.Metadata.(pkg.BinaryMetadata)
+			require.True(t, ok)
+			require.Len(t, metadata.Matches, 1)
+			assert.Equal(t, test.expectedClass, metadata.Matches[0].Classifier)
+			assert.Equal(t, test.fixture, metadata.Matches[0].Location.RealPath)
+
+			require.Len(t, p.Evidence, 1)
+			assert.Equal(t, test.fixture, p.Evidence[0].Location.RealPath)
+			assert.Equal(t, test.expectedClass, p.Evidence[0].Rule)
+			assert.NotZero(t, p.Evidence[0].Length)
+			assert.Equal(t, pkg.HeuristicConfidence, p.Confidence)
+		})
+	}
+}
+
+func TestClassifierCataloger_DefaultClassifiers_NegativeCases(t *testing.T) {
+	c := NewCataloger(Config{})
+
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/classifiers/negative/busybox",
+		"test-fixtures/classifiers/negative/go",
+		"test-fixtures/classifiers/negative/libpython2.7.so",
+		"test-fixtures/classifiers/negative/node",
+		"test-fixtures/classifiers/negative/python2.6",
+		"test-fixtures/classifiers/negative/u-boot.bin",
+	)
+	packages, _, err := c.Catalog(resolver)
+	require.NoError(t, err)
+	assert.Empty(t, packages)
+}
+
+func TestClassifierCataloger_AdditionalClassifiers(t *testing.T) {
+	classifiers, err := ReadClassifiersFile("test-fixtures/custom/classifiers.yaml")
+	require.NoError(t, err)
+	require.Len(t, classifiers, 1)
+
+	c := NewCataloger(Config{AdditionalClassifiers: classifiers})
+
+	resolver := source.NewMockResolverForPaths("test-fixtures/custom/opt/acme/bin/acme-agent")
+	packages, _, err := c.Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, packages, 1)
+
+	p := packages[0]
+	assert.Equal(t, "acme-agent", p.Name)
+	assert.Equal(t, "2.4.1-rc1", p.Version)
+	assert.Equal(t, "pkg:generic/acme/acme-agent@2.4.1-rc1", p.PURL)
+	require.Len(t, p.CPEs, 1)
+	assert.Equal(t, "cpe:2.3:a:acme:acme_agent:2.4.1-rc1:*:*:*:*:*:*:*", pkg.CPEString(p.CPEs[0]))
+}
+
+func TestClassifierCataloger_MaxFileSize(t *testing.T) {
+	classifiers, err := ReadClassifiersFile("test-fixtures/custom/classifiers.yaml")
+	require.NoError(t, err)
+
+	fixture := "test-fixtures/custom/opt/acme/bin/acme-agent"
+	info, err := os.Stat(fixture)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		maxFileSize int64
+		expected    int
+	}{
+		{
+			name:     "no limit",
+			expected: 1,
+		},
+		{
+			name:        "within limit",
+			maxFileSize: info.Size(),
+			expected:    1,
+		},
+		{
+			name:        "exceeds limit",
+			maxFileSize: info.Size() - 1,
+			expected:    0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewCataloger(Config{AdditionalClassifiers: classifiers, MaxFileSize: test.maxFileSize})
+
+			packages, _, err := c.Catalog(source.NewMockResolverForPaths(fixture))
+			require.NoError(t, err)
+			assert.Len(t, packages, test.expected)
+		})
+	}
+}
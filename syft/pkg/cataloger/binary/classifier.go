@@ -0,0 +1,179 @@
+package binary
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/file"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+const versionGroup = "version"
+
+// Classifier is a generic package classifier that can be used to match a package definition to a file that meets the
+// given content criteria. Classifiers can be expressed in YAML, allowing for custom or proprietary binaries to be
+// detected without code changes.
+type Classifier struct {
+	// Class is the name of the classifier (e.g. "python-binary")
+	Class string `yaml:"class" json:"class" mapstructure:"class"`
+	// Package is the name of the package to create when the classifier matches
+	Package string `yaml:"package" json:"package" mapstructure:"package"`
+	// FileGlobs select the files that should be considered by the classifier
+	FileGlobs []string `yaml:"file-globs" json:"file-globs" mapstructure:"file-globs"`
+	// FilepathPattern is an optional regular expression that selected paths must match. Any named capture groups are
+	// made available as template values to the evidence patterns (e.g. {{ .version }}).
+	FilepathPattern string `yaml:"filepath-pattern" json:"filepath-pattern" mapstructure:"filepath-pattern"`
+	// EvidencePatterns are regular expressions over the file contents, which must capture a "version" named group.
+	// The first pattern to match determines the package version.
+	EvidencePatterns []string `yaml:"evidence-patterns" json:"evidence-patterns" mapstructure:"evidence-patterns"`
+	// PURL is the package URL (without a version) to assign to matched packages
+	PURL string `yaml:"purl" json:"purl" mapstructure:"purl"`
+	// CPEs are the CPEs (without a version) to assign to matched packages
+	CPEs []string `yaml:"cpes" json:"cpes" mapstructure:"cpes"`
+}
+
+// Validate ensures that the classifier has all required fields and that all patterns, package URLs, and CPEs are
+// well formed.
+func (c Classifier) Validate() error {
+	switch {
+	case c.Class == "":
+		return fmt.Errorf("classifier is missing a class")
+	case c.Package == "":
+		return fmt.Errorf("classifier %q is missing a package name", c.Class)
+	case len(c.FileGlobs) == 0:
+		return fmt.Errorf("classifier %q must have at least one file glob", c.Class)
+	case len(c.EvidencePatterns) == 0:
+		return fmt.Errorf("classifier %q must have at least one evidence pattern", c.Class)
+	}
+
+	if c.FilepathPattern != "" {
+		if _, err := regexp.Compile(c.FilepathPattern); err != nil {
+			return fmt.Errorf("classifier %q has an invalid filepath pattern: %w", c.Class, err)
+		}
+	}
+
+	for _, p := range c.EvidencePatterns {
+		if _, err := template.New("").Parse(p); err != nil {
+			return fmt.Errorf("classifier %q has an invalid evidence pattern template=%q: %w", c.Class, p, err)
+		}
+		if !strings.Contains(p, "{{") {
+			pattern, err := regexp.Compile(p)
+			if err != nil {
+				return fmt.Errorf("classifier %q has an invalid evidence pattern=%q: %w", c.Class, p, err)
+			}
+			if pattern.SubexpIndex(versionGroup) < 0 {
+				return fmt.Errorf("classifier %q evidence pattern=%q does not capture a %q group", c.Class, p, versionGroup)
+			}
+		}
+	}
+
+	if c.PURL != "" {
+		if _, err := packageurl.FromString(c.PURL); err != nil {
+			return fmt.Errorf("classifier %q has an invalid package URL: %w", c.Class, err)
+		}
+	}
+
+	for _, cpe := range c.CPEs {
+		if _, err := pkg.NewCPE(cpe); err != nil {
+			return fmt.Errorf("classifier %q has an invalid CPE: %w", c.Class, err)
+		}
+	}
+
+	return nil
+}
+
+// Classify checks the given location against the classifier, returning a package if the file matched.
+func (c Classifier) Classify(resolver source.FileResolver, location source.Location) (*pkg.Package, error) {
+	doesFilepathMatch, filepathNamedGroupValues, err := c.filepathMatches(location)
+	if err != nil || !doesFilepathMatch {
+		return nil, err
+	}
+
+	contentReader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(contentReader, location.VirtualPath)
+
+	var patterns []*regexp.Regexp
+	for _, patternTemplate := range c.EvidencePatterns {
+		pattern, err := renderPattern(patternTemplate, filepathNamedGroupValues)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+
+	// search all patterns within a single pass over the contents
+	allMatches, err := file.FindFirstSubmatchesWithOffsets(contentReader, patterns)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, pattern := range patterns {
+		match := allMatches[i]
+		if match == nil {
+			continue
+		}
+
+		idx := pattern.SubexpIndex(versionGroup)
+		if idx < 0 || len(match.Groups[idx]) == 0 {
+			continue
+		}
+
+		p := newPackage(c, string(match.Groups[idx]), location, pkg.Evidence{
+			Location:   location.Coordinates,
+			Offset:     match.Offset,
+			Length:     int64(len(match.Groups[0])),
+			Rule:       c.Class,
+			Confidence: classifierConfidence,
+		})
+		return &p, nil
+	}
+	return nil, nil
+}
+
+func (c Classifier) filepathMatches(location source.Location) (bool, map[string]string, error) {
+	if c.FilepathPattern == "" {
+		return true, nil, nil
+	}
+
+	pattern, err := regexp.Compile(c.FilepathPattern)
+	if err != nil {
+		return false, nil, fmt.Errorf("unable to compile filepath pattern=%q: %w", c.FilepathPattern, err)
+	}
+
+	for _, path := range []string{location.RealPath, location.VirtualPath} {
+		if path == "" {
+			continue
+		}
+		if pattern.MatchString(path) {
+			return true, internal.MatchNamedCaptureGroups(pattern, path), nil
+		}
+	}
+	return false, nil, nil
+}
+
+func renderPattern(patternTemplate string, values map[string]string) (*regexp.Regexp, error) {
+	tmpl, err := template.New("").Parse(patternTemplate)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse classifier template=%q : %w", patternTemplate, err)
+	}
+
+	patternBuf := &bytes.Buffer{}
+	if err := tmpl.Execute(patternBuf, values); err != nil {
+		return nil, fmt.Errorf("unable to render template: %w", err)
+	}
+
+	pattern, err := regexp.Compile(patternBuf.String())
+	if err != nil {
+		return nil, fmt.Errorf("unable to compile rendered regex=%q: %w", patternBuf.String(), err)
+	}
+	return pattern, nil
+}
@@ -0,0 +1,112 @@
+package binary
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifier_Validate(t *testing.T) {
+	valid := func() Classifier {
+		return Classifier{
+			Class:            "thing-binary",
+			Package:          "thing",
+			FileGlobs:        []string{"**/thing"},
+			EvidencePatterns: []string{`thing v(?P<version>[0-9.]+)`},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(c *Classifier)
+		wantErr require.ErrorAssertionFunc
+	}{
+		{
+			name:    "valid",
+			mutate:  func(c *Classifier) {},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "missing class",
+			mutate:  func(c *Classifier) { c.Class = "" },
+			wantErr: require.Error,
+		},
+		{
+			name:    "missing package",
+			mutate:  func(c *Classifier) { c.Package = "" },
+			wantErr: require.Error,
+		},
+		{
+			name:    "missing globs",
+			mutate:  func(c *Classifier) { c.FileGlobs = nil },
+			wantErr: require.Error,
+		},
+		{
+			name:    "missing version group",
+			mutate:  func(c *Classifier) { c.EvidencePatterns = []string{`thing v([0-9.]+)`} },
+			wantErr: require.Error,
+		},
+		{
+			name:    "bad regex",
+			mutate:  func(c *Classifier) { c.EvidencePatterns = []string{`thing v(?P<version>[0-9.]+`} },
+			wantErr: require.Error,
+		},
+		{
+			name: "templated pattern is not checked for version group until rendered",
+			mutate: func(c *Classifier) {
+				c.FilepathPattern = `(.*/|^)thing(?P<version>[0-9]+)$`
+				c.EvidencePatterns = []string{`(?m)(?P<version>{{ .version }}\.[0-9]+)`}
+			},
+			wantErr: require.NoError,
+		},
+		{
+			name:    "bad purl",
+			mutate:  func(c *Classifier) { c.PURL = "not-a-purl" },
+			wantErr: require.Error,
+		},
+		{
+			name:    "bad cpe",
+			mutate:  func(c *Classifier) { c.CPEs = []string{"cpe:nope"} },
+			wantErr: require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := valid()
+			test.mutate(&c)
+			test.wantErr(t, c.Validate())
+		})
+	}
+}
+
+func TestDefaultClassifiers_AreValid(t *testing.T) {
+	for _, c := range DefaultClassifiers {
+		assert.NoError(t, c.Validate(), c.Class)
+	}
+}
+
+func TestParseClassifiers(t *testing.T) {
+	classifiers, err := ParseClassifiers(strings.NewReader(`
+- class: thing-binary
+  package: thing
+  file-globs: ["**/thing"]
+  evidence-patterns: ['thing v(?P<version>[0-9.]+)']
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []Classifier{
+		{
+			Class:            "thing-binary",
+			Package:          "thing",
+			FileGlobs:        []string{"**/thing"},
+			EvidencePatterns: []string{`thing v(?P<version>[0-9.]+)`},
+		},
+	}, classifiers)
+
+	_, err = ParseClassifiers(strings.NewReader(`
+- class: thing-binary
+  file-globs: ["**/thing"]
+`))
+	assert.Error(t, err)
+}
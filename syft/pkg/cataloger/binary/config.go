@@ -0,0 +1,42 @@
+package binary
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"gopkg.in/yaml.v2"
+)
+
+type Config struct {
+	// AdditionalClassifiers are user-defined classifiers that run alongside the DefaultClassifiers
+	AdditionalClassifiers []Classifier
+	// MaxFileSize is the size (in bytes) above which files are not classified (0 means there is no limit)
+	MaxFileSize int64
+}
+
+// ParseClassifiers reads a YAML document describing a list of classifiers, validating each classifier found.
+func ParseClassifiers(reader io.Reader) ([]Classifier, error) {
+	var classifiers []Classifier
+	if err := yaml.NewDecoder(reader).Decode(&classifiers); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to parse binary classifiers: %w", err)
+	}
+
+	for _, c := range classifiers {
+		if err := c.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	return classifiers, nil
+}
+
+// ReadClassifiersFile reads all classifiers from the given YAML file.
+func ReadClassifiersFile(path string) ([]Classifier, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open binary classifiers file=%q: %w", path, err)
+	}
+	defer f.Close()
+
+	return ParseClassifiers(f)
+}
@@ -0,0 +1,102 @@
+package binary
+
+// DefaultClassifiers are the classifiers that are always run by the binary cataloger (in addition to any user-defined
+// classifiers).
+var DefaultClassifiers = []Classifier{
+	{
+		Class:           "python-binary",
+		Package:         "python",
+		FileGlobs:       []string{"**/python*"},
+		FilepathPattern: `(.*/|^)python(?P<version>[0-9]+\.[0-9]+)$`,
+		EvidencePatterns: []string{
+			`(?m)(?P<version>{{ .version }}\.[0-9]+[-_a-zA-Z0-9]*)`,
+		},
+		PURL: "pkg:generic/python",
+		CPEs: []string{"cpe:2.3:a:python_software_foundation:python:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Class:           "python-binary-lib",
+		Package:         "python",
+		FileGlobs:       []string{"**/libpython*.so*"},
+		FilepathPattern: `(.*/|^)libpython(?P<version>[0-9]+\.[0-9]+).so.*$`,
+		EvidencePatterns: []string{
+			`(?m)(?P<version>{{ .version }}\.[0-9]+[-_a-zA-Z0-9]*)`,
+		},
+		PURL: "pkg:generic/python",
+		CPEs: []string{"cpe:2.3:a:python_software_foundation:python:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Class:     "cpython-source",
+		Package:   "python",
+		FileGlobs: []string{"**/patchlevel.h"},
+		EvidencePatterns: []string{
+			`(?m)#define\s+PY_VERSION\s+"?(?P<version>[0-9\.\-_a-zA-Z]+)"?`,
+		},
+		PURL: "pkg:generic/python",
+		CPEs: []string{"cpe:2.3:a:python_software_foundation:python:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Class:           "go-binary",
+		Package:         "go",
+		FileGlobs:       []string{"**/go"},
+		FilepathPattern: `(.*/|^)go$`,
+		EvidencePatterns: []string{
+			`(?m)go(?P<version>[0-9]+\.[0-9]+(\.[0-9]+|beta[0-9]+|alpha[0-9]+|rc[0-9]+)?)`,
+		},
+		PURL: "pkg:generic/go",
+		CPEs: []string{"cpe:2.3:a:golang:go:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Class:           "go-binary-hint",
+		Package:         "go",
+		FileGlobs:       []string{"**/VERSION"},
+		FilepathPattern: `(.*/|^)VERSION$`,
+		EvidencePatterns: []string{
+			`(?m)go(?P<version>[0-9]+\.[0-9]+(\.[0-9]+|beta[0-9]+|alpha[0-9]+|rc[0-9]+)?)`,
+		},
+		PURL: "pkg:generic/go",
+		CPEs: []string{"cpe:2.3:a:golang:go:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Class:           "nodejs-binary",
+		Package:         "node",
+		FileGlobs:       []string{"**/node"},
+		FilepathPattern: `(.*/|^)node$`,
+		EvidencePatterns: []string{
+			`(?m)node\.js/v(?P<version>[0-9]+\.[0-9]+\.[0-9]+)`,
+		},
+		PURL: "pkg:generic/node",
+		CPEs: []string{"cpe:2.3:a:nodejs:node.js:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Class:     "busybox-binary",
+		Package:   "busybox",
+		FileGlobs: []string{"**/busybox"},
+		EvidencePatterns: []string{
+			`(?m)BusyBox\s+v(?P<version>[0-9]+\.[0-9]+\.[0-9]+)`,
+		},
+		CPEs: []string{"cpe:2.3:a:busybox:busybox:*:*:*:*:*:*:*:*"},
+	},
+	{
+		// note: UEFI builds of GRUB are identified by their SBAT section (see the firmware cataloger), while the
+		// installed platform modules (e.g. /boot/grub/i386-pc for BIOS systems) describe the GRUB build they are from
+		Class:     "grub-modinfo",
+		Package:   "grub2",
+		FileGlobs: []string{"**/grub/*/modinfo.sh", "**/grub2/*/modinfo.sh"},
+		EvidencePatterns: []string{
+			`(?m)^grub_package_version="(?P<version>[^"]+)"`,
+		},
+		PURL: "pkg:generic/grub2",
+		CPEs: []string{"cpe:2.3:a:gnu:grub2:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Class:     "u-boot-binary",
+		Package:   "u-boot",
+		FileGlobs: []string{"**/u-boot*"},
+		EvidencePatterns: []string{
+			`(?m)U-Boot (?P<version>[0-9]{4}\.[0-9]{2}(-rc[0-9]+)?)`,
+		},
+		PURL: "pkg:generic/u-boot",
+		CPEs: []string{"cpe:2.3:a:denx:u-boot:*:*:*:*:*:*:*:*"},
+	},
+}
@@ -0,0 +1,104 @@
+package binary
+
+import "regexp"
+
+const semver = `[0-9]+\.[0-9]+\.[0-9]+`
+
+// DefaultSignatures are the signatures used by the static library cataloger to find commonly vendored C libraries
+// that have been statically linked into binaries.
+var DefaultSignatures = []Signature{
+	{
+		Name:    "zlib-static",
+		Package: "zlib",
+		Symbols: []string{
+			"deflate", "deflateInit_", "deflateEnd", "inflate", "inflateInit_", "inflateEnd", "adler32", "crc32",
+		},
+		Strings: []*regexp.Regexp{
+			regexp.MustCompile(`(?:de|in)flate (?P<version>` + semver + `(?:\.[0-9]+)?) Copyright [0-9]{4}-[0-9]{4} `),
+		},
+		LibraryFiles: []string{"libz.so*", "libz.a"},
+		PURL:         "pkg:generic/zlib",
+		CPEs:         []string{"cpe:2.3:a:zlib:zlib:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Name:    "openssl-static",
+		Package: "openssl",
+		Symbols: []string{
+			"SSL_CTX_new", "SSL_new", "SSL_connect", "SSL_read", "SSL_write", "EVP_DigestInit_ex",
+			"EVP_EncryptInit_ex", "OPENSSL_init_crypto",
+		},
+		Strings: []*regexp.Regexp{
+			regexp.MustCompile(`OpenSSL (?P<version>` + semver + `[a-z]?) +[0-9]{1,2} [A-Z][a-z]{2} [0-9]{4}`),
+		},
+		LibraryFiles: []string{"libssl.so*", "libssl.a", "libcrypto.so*", "libcrypto.a"},
+		PURL:         "pkg:generic/openssl",
+		CPEs:         []string{"cpe:2.3:a:openssl:openssl:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Name:    "curl-static",
+		Package: "curl",
+		Symbols: []string{
+			"curl_easy_init", "curl_easy_setopt", "curl_easy_perform", "curl_easy_cleanup", "curl_global_init",
+			"curl_multi_init", "curl_version",
+		},
+		Strings: []*regexp.Regexp{
+			regexp.MustCompile(`libcurl/(?P<version>` + semver + `)`),
+		},
+		LibraryFiles: []string{"libcurl.so*", "libcurl.a", "libcurl-*.so*"},
+		PURL:         "pkg:generic/curl",
+		CPEs:         []string{"cpe:2.3:a:haxx:libcurl:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Name:    "libpng-static",
+		Package: "libpng",
+		Symbols: []string{
+			"png_create_read_struct", "png_create_write_struct", "png_read_info", "png_write_info", "png_set_IHDR",
+			"png_get_libpng_ver",
+		},
+		Strings: []*regexp.Regexp{
+			regexp.MustCompile(`libpng version (?P<version>` + semver + `)`),
+		},
+		LibraryFiles: []string{"libpng*.so*", "libpng*.a"},
+		PURL:         "pkg:generic/libpng",
+		CPEs:         []string{"cpe:2.3:a:libpng:libpng:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Name:    "bzip2-static",
+		Package: "bzip2",
+		Symbols: []string{
+			"BZ2_bzCompressInit", "BZ2_bzCompress", "BZ2_bzCompressEnd", "BZ2_bzDecompressInit", "BZ2_bzDecompress",
+			"BZ2_bzDecompressEnd", "BZ2_bzlibVersion",
+		},
+		Strings: []*regexp.Regexp{
+			regexp.MustCompile(`bzip2/libbzip2: internal error number`),
+		},
+		LibraryFiles: []string{"libbz2.so*", "libbz2.a"},
+		PURL:         "pkg:generic/bzip2",
+		CPEs:         []string{"cpe:2.3:a:bzip:bzip2:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Name:    "sqlite-static",
+		Package: "sqlite",
+		Symbols: []string{
+			"sqlite3_open", "sqlite3_open_v2", "sqlite3_prepare_v2", "sqlite3_step", "sqlite3_finalize",
+			"sqlite3_close", "sqlite3_exec", "sqlite3_libversion",
+		},
+		LibraryFiles: []string{"libsqlite3.so*", "libsqlite3.a"},
+		PURL:         "pkg:generic/sqlite",
+		CPEs:         []string{"cpe:2.3:a:sqlite:sqlite:*:*:*:*:*:*:*:*"},
+	},
+	{
+		Name:    "expat-static",
+		Package: "expat",
+		Symbols: []string{
+			"XML_ParserCreate", "XML_ParserFree", "XML_Parse", "XML_SetElementHandler", "XML_SetCharacterDataHandler",
+			"XML_ExpatVersion",
+		},
+		Strings: []*regexp.Regexp{
+			regexp.MustCompile(`expat_(?P<version>` + semver + `)`),
+		},
+		LibraryFiles: []string{"libexpat.so*", "libexpat.a"},
+		PURL:         "pkg:generic/expat",
+		CPEs:         []string{"cpe:2.3:a:libexpat_project:libexpat:*:*:*:*:*:*:*:*"},
+	},
+}
@@ -0,0 +1,115 @@
+package binary
+
+import (
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+func newPackage(classifier Classifier, version string, location source.Location, evidence pkg.Evidence) pkg.Package {
+	p := pkg.Package{
+		Name:         classifier.Package,
+		Version:      version,
+		Locations:    source.NewLocationSet(location),
+		Type:         pkg.BinaryPkg,
+		CPEs:         cpes(classifier.CPEs, version),
+		PURL:         packageURL(classifier.PURL, classifier.Package, version),
+		MetadataType: pkg.BinaryMetadataType,
+		Metadata: pkg.BinaryMetadata{
+			Matches: []pkg.ClassifierMatch{
+				{
+					Classifier: classifier.Class,
+					Location:   location,
+				},
+			},
+		},
+		Evidence:   []pkg.Evidence{evidence},
+		Confidence: pkg.HeuristicConfidence,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func newStaticLibraryPackage(signature Signature, match signatureMatch, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         signature.Package,
+		Version:      match.version,
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.BinaryPkg,
+		CPEs:         cpes(signature.CPEs, match.version),
+		PURL:         packageURL(signature.PURL, signature.Package, match.version),
+		MetadataType: pkg.StaticLibraryMetadataType,
+		Metadata: pkg.StaticLibraryMetadata{
+			Signature:      signature.Name,
+			Confidence:     match.confidence,
+			MatchedSymbols: match.symbols,
+			MatchedStrings: match.strings,
+		},
+		Evidence:   signatureEvidence(signature, match, locations...),
+		Confidence: pkg.HeuristicConfidence,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// signatureEvidence describes each string of the signature found within the given files, or only the files when the
+// signature matched by symbols alone.
+func signatureEvidence(signature Signature, match signatureMatch, locations ...source.Location) []pkg.Evidence {
+	var evidence []pkg.Evidence
+	for _, l := range locations {
+		if len(match.strings) == 0 {
+			evidence = append(evidence, pkg.Evidence{
+				Location:   l.Coordinates,
+				Rule:       signature.Name,
+				Confidence: match.confidence,
+			})
+			continue
+		}
+		for i, s := range match.strings {
+			evidence = append(evidence, pkg.Evidence{
+				Location:   l.Coordinates,
+				Offset:     match.offsets[i],
+				Length:     int64(len(s)),
+				Rule:       signature.Name,
+				Confidence: match.confidence,
+			})
+		}
+	}
+	return evidence
+}
+
+// packageURL returns the given package URL (with the version set), falling back to a generic package URL when
+// no package URL was provided.
+func packageURL(purlStr, name, version string) string {
+	if purlStr == "" {
+		return purl.New(purl.TypeGeneric, "", name, version, nil, "")
+	}
+
+	pURL, err := packageurl.FromString(purlStr)
+	if err != nil {
+		log.Debugf("unable to parse package URL=%q for package=%q: %+v", purlStr, name, err)
+		return ""
+	}
+	pURL.Version = version
+	return pURL.ToString()
+}
+
+func cpes(cpeStrs []string, version string) []pkg.CPE {
+	var results []pkg.CPE
+	for _, cpeStr := range cpeStrs {
+		c, err := pkg.NewCPE(cpeStr)
+		if err != nil {
+			log.Debugf("unable to parse classifier CPE=%q: %+v", cpeStr, err)
+			continue
+		}
+		c.Version = version
+		results = append(results, c)
+	}
+	return results
+}
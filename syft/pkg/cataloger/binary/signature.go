@@ -0,0 +1,120 @@
+package binary
+
+import (
+	"io"
+	"math"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/anchore/syft/internal/file"
+)
+
+const (
+	// stringEvidenceWeight is the portion of the confidence score granted by any string pattern match
+	stringEvidenceWeight = 0.6
+	// symbolOnlyWeight caps the confidence score when only symbols (and no string patterns) matched
+	symbolOnlyWeight = 0.8
+	// defaultMinConfidence is the minimum confidence score required to report a static library
+	defaultMinConfidence = 0.5
+	// classifierConfidence is the confidence of a binary classifier match, which is string pattern evidence alone
+	classifierConfidence = stringEvidenceWeight
+)
+
+// Signature describes how to recognize a library that has been statically linked into a binary (or archived within
+// a static library) by the symbols it defines and the strings it embeds.
+type Signature struct {
+	// Name is the name of the signature (e.g. "zlib-static")
+	Name string
+	// Package is the name of the package to create when the signature matches
+	Package string
+	// Symbols are the names of functions and variables defined by the library
+	Symbols []string
+	// Strings are patterns over the binary contents, optionally capturing a "version" named group. The first pattern
+	// to capture a version determines the package version.
+	Strings []*regexp.Regexp
+	// LibraryFiles are glob patterns for the base name of the library itself (e.g. "libz.so*"), which are not
+	// considered, since a library defining its own symbols is not statically linked into anything.
+	LibraryFiles []string
+	// MinConfidence is the minimum confidence score (0-1) required for the signature to match (defaults to 0.5)
+	MinConfidence float64
+	// PURL is the package URL (without a version) to assign to matched packages
+	PURL string
+	// CPEs are the CPEs (without a version) to assign to matched packages
+	CPEs []string
+}
+
+type signatureMatch struct {
+	version    string
+	confidence float64
+	symbols    []string
+	strings    []string
+	// offsets are the positions of the matched strings within the binary
+	offsets []int64
+}
+
+// isLibraryFile indicates if the given path is the library described by the signature.
+func (s Signature) isLibraryFile(p string) bool {
+	base := path.Base(p)
+	for _, pattern := range s.LibraryFiles {
+		if matched, err := path.Match(pattern, base); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// match scores the signature against the defined symbols and raw contents of a binary, returning nil if the
+// confidence score does not meet the signature threshold.
+func (s Signature) match(symbols map[string]struct{}, contents io.Reader) (*signatureMatch, error) {
+	var result signatureMatch
+
+	for _, symbol := range s.Symbols {
+		if _, ok := symbols[symbol]; ok {
+			result.symbols = append(result.symbols, symbol)
+		}
+	}
+
+	stringMatches, err := file.FindFirstSubmatchesWithOffsets(contents, s.Strings)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, pattern := range s.Strings {
+		match := stringMatches[idx]
+		if match == nil {
+			continue
+		}
+		result.strings = append(result.strings, string(match.Groups[0]))
+		result.offsets = append(result.offsets, match.Offset)
+		if i := pattern.SubexpIndex(versionGroup); i >= 0 && result.version == "" {
+			result.version = string(match.Groups[i])
+		}
+	}
+
+	var symbolScore float64
+	if len(s.Symbols) > 0 {
+		symbolScore = float64(len(result.symbols)) / float64(len(s.Symbols))
+	}
+
+	switch {
+	case len(result.strings) > 0:
+		result.confidence = stringEvidenceWeight + (1-stringEvidenceWeight)*symbolScore
+	default:
+		result.confidence = symbolOnlyWeight * symbolScore
+	}
+
+	result.confidence = math.Round(result.confidence*100) / 100
+
+	minConfidence := s.MinConfidence
+	if minConfidence == 0 {
+		minConfidence = defaultMinConfidence
+	}
+
+	if result.confidence < minConfidence {
+		return nil, nil
+	}
+
+	sort.Strings(result.symbols)
+	return &result, nil
+}
@@ -0,0 +1,83 @@
+package binary
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/unionreader"
+	"github.com/anchore/syft/syft/source"
+)
+
+const staticLibraryCatalogerName = "static-library-cataloger"
+
+// staticLibraryMIMETypes are all binaries that may have a library statically linked into them, as well as object
+// files and static library archives that may have a library vendored within them.
+var staticLibraryMIMETypes = append(internal.ExecutableMIMETypeSet.List(),
+	"application/x-object",
+	"application/x-archive",
+)
+
+// NewStaticLibraryCataloger returns a new cataloger object that finds libraries that have been statically linked into
+// binaries by matching the given signatures against the symbols and strings within each binary.
+func NewStaticLibraryCataloger(signatures []Signature) *generic.Cataloger {
+	return generic.NewCataloger(staticLibraryCatalogerName).
+		WithParserByMimeTypes(newStaticLibraryParser(signatures), staticLibraryMIMETypes...)
+}
+
+func newStaticLibraryParser(signatures []Signature) generic.Parser {
+	return func(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+		var candidates []Signature
+		for _, s := range signatures {
+			if !s.isLibraryFile(reader.RealPath) {
+				candidates = append(candidates, s)
+			}
+		}
+		if len(candidates) == 0 {
+			return nil, nil, nil
+		}
+
+		unionReader, err := unionreader.GetUnionReader(reader.ReadCloser)
+		if err != nil {
+			return nil, nil, err
+		}
+		defer internal.CloseAndLogError(unionReader, reader.RealPath)
+
+		size, err := unionReader.Seek(0, io.SeekEnd)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to read binary: %w", err)
+		}
+		if _, err := unionReader.Seek(0, io.SeekStart); err != nil {
+			return nil, nil, fmt.Errorf("unable to read binary: %w", err)
+		}
+
+		readers, err := unionreader.GetReaders(unionReader)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		symbols := make(map[string]struct{})
+		for _, r := range readers {
+			for symbol := range definedSymbols(r) {
+				symbols[symbol] = struct{}{}
+			}
+		}
+
+		var pkgs []pkg.Package
+		for _, s := range candidates {
+			match, err := s.match(symbols, io.NewSectionReader(unionReader, 0, size))
+			if err != nil {
+				return nil, nil, fmt.Errorf("unable to read binary: %w", err)
+			}
+			if match == nil {
+				continue
+			}
+			pkgs = append(pkgs, newStaticLibraryPackage(s, *match, reader.Location))
+		}
+
+		return pkgs, nil, nil
+	}
+}
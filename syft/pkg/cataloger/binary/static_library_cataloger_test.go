@@ -0,0 +1,173 @@
+package binary
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestStaticLibraryParser(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			name:    "statically linked zlib",
+			fixture: "test-fixtures/static-libraries/zlib-app",
+			expected: []pkg.Package{
+				{
+					Name:         "zlib",
+					Version:      "1.2.13",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/static-libraries/zlib-app")),
+					Type:         pkg.BinaryPkg,
+					CPEs:         []pkg.CPE{pkg.MustCPE("cpe:2.3:a:zlib:zlib:1.2.13:*:*:*:*:*:*:*")},
+					PURL:         "pkg:generic/zlib@1.2.13",
+					MetadataType: pkg.StaticLibraryMetadataType,
+					Metadata: pkg.StaticLibraryMetadata{
+						Signature:  "zlib-static",
+						Confidence: 1,
+						MatchedSymbols: []string{
+							"adler32", "crc32", "deflate", "deflateEnd", "deflateInit_", "inflate", "inflateEnd", "inflateInit_",
+						},
+						MatchedStrings: []string{"deflate 1.2.13 Copyright 1995-2022 "},
+					},
+					Evidence: []pkg.Evidence{
+						{
+							Location:   source.Coordinates{RealPath: "test-fixtures/static-libraries/zlib-app"},
+							Offset:     74945,
+							Length:     35,
+							Rule:       "zlib-static",
+							Confidence: 1,
+						},
+					},
+					Confidence: pkg.HeuristicConfidence,
+				},
+			},
+		},
+		{
+			name:    "dynamically linked zlib is not reported",
+			fixture: "test-fixtures/static-libraries/dynamic-zlib-app",
+		},
+		{
+			name:    "vendored bzip2 within a static archive",
+			fixture: "test-fixtures/static-libraries/libvendor.a",
+			expected: []pkg.Package{
+				{
+					Name:         "bzip2",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/static-libraries/libvendor.a")),
+					Type:         pkg.BinaryPkg,
+					CPEs:         []pkg.CPE{pkg.MustCPE("cpe:2.3:a:bzip:bzip2:*:*:*:*:*:*:*:*")},
+					PURL:         "pkg:generic/bzip2",
+					MetadataType: pkg.StaticLibraryMetadataType,
+					Metadata: pkg.StaticLibraryMetadata{
+						Signature:  "bzip2-static",
+						Confidence: 1,
+						MatchedSymbols: []string{
+							"BZ2_bzCompress", "BZ2_bzCompressEnd", "BZ2_bzCompressInit", "BZ2_bzDecompress",
+							"BZ2_bzDecompressEnd", "BZ2_bzDecompressInit", "BZ2_bzlibVersion",
+						},
+						MatchedStrings: []string{"bzip2/libbzip2: internal error number"},
+					},
+					Evidence: []pkg.Evidence{
+						{
+							Location:   source.Coordinates{RealPath: "test-fixtures/static-libraries/libvendor.a"},
+							Offset:     497,
+							Length:     37,
+							Rule:       "bzip2-static",
+							Confidence: 1,
+						},
+					},
+					Confidence: pkg.HeuristicConfidence,
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.TestFileParser(t, test.fixture, newStaticLibraryParser(DefaultSignatures), test.expected, nil)
+		})
+	}
+}
+
+func TestSignature_Match(t *testing.T) {
+	signature := Signature{
+		Name:    "test",
+		Package: "test",
+		Symbols: []string{"a", "b", "c", "d"},
+		Strings: []*regexp.Regexp{
+			regexp.MustCompile(`test-lib (?P<version>` + semver + `)`),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		symbols  []string
+		contents string
+		expected *signatureMatch
+	}{
+		{
+			name:     "version string and all symbols",
+			symbols:  []string{"a", "b", "c", "d"},
+			contents: "...test-lib 1.2.3...",
+			expected: &signatureMatch{
+				version:    "1.2.3",
+				confidence: 1,
+				symbols:    []string{"a", "b", "c", "d"},
+				strings:    []string{"test-lib 1.2.3"},
+				offsets:    []int64{3},
+			},
+		},
+		{
+			name:     "version string only",
+			contents: "...test-lib 1.2.3...",
+			expected: &signatureMatch{
+				version:    "1.2.3",
+				confidence: 0.6,
+				strings:    []string{"test-lib 1.2.3"},
+				offsets:    []int64{3},
+			},
+		},
+		{
+			name:    "most symbols only",
+			symbols: []string{"a", "b", "c"},
+			expected: &signatureMatch{
+				confidence: 0.6,
+				symbols:    []string{"a", "b", "c"},
+			},
+		},
+		{
+			name:    "too few symbols",
+			symbols: []string{"a", "b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			symbols := make(map[string]struct{})
+			for _, s := range test.symbols {
+				symbols[s] = struct{}{}
+			}
+			actual, err := signature.match(symbols, strings.NewReader(test.contents))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestSignature_IsLibraryFile(t *testing.T) {
+	for _, s := range DefaultSignatures {
+		if s.Package != "zlib" {
+			continue
+		}
+		assert.True(t, s.isLibraryFile("/usr/lib/x86_64-linux-gnu/libz.so.1.2.13"))
+		assert.True(t, s.isLibraryFile("/usr/lib/libz.a"))
+		assert.False(t, s.isLibraryFile("/usr/bin/zlib-app"))
+	}
+}
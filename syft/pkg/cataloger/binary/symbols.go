@@ -0,0 +1,164 @@
+package binary
+
+import (
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+const (
+	arMagic        = "!<arch>\n"
+	arHeaderSize   = 60
+	machoNoSection = 0
+	machoStabMask  = 0xe0
+)
+
+// definedSymbols returns the names of all symbols that are defined (not imported) by the given ELF, Mach-O, or PE
+// binary, or by any of the object files within an ar archive (static library).
+func definedSymbols(r io.ReaderAt) map[string]struct{} {
+	symbols := make(map[string]struct{})
+
+	header := make([]byte, len(arMagic))
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return symbols
+	}
+
+	if string(header) == arMagic {
+		for _, member := range arMembers(r) {
+			addObjectSymbols(symbols, member)
+		}
+		return symbols
+	}
+
+	addObjectSymbols(symbols, r)
+	return symbols
+}
+
+func addObjectSymbols(symbols map[string]struct{}, r io.ReaderAt) {
+	header := make([]byte, 4)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return
+	}
+
+	switch {
+	case bytes.Equal(header, []byte(elf.ELFMAG)):
+		addELFSymbols(symbols, r)
+	case bytes.HasPrefix(header, []byte("MZ")):
+		addPESymbols(symbols, r)
+	default:
+		addMachOSymbols(symbols, r)
+	}
+}
+
+func addELFSymbols(symbols map[string]struct{}, r io.ReaderAt) {
+	f, err := elf.NewFile(r)
+	if err != nil {
+		log.Tracef("unable to parse ELF binary: %+v", err)
+		return
+	}
+	defer f.Close()
+
+	// note: stripped binaries will not have a symbol table, in which case the dynamic symbols are all that remain
+	var all []elf.Symbol
+	if syms, err := f.Symbols(); err == nil {
+		all = append(all, syms...)
+	}
+	if syms, err := f.DynamicSymbols(); err == nil {
+		all = append(all, syms...)
+	}
+
+	for _, sym := range all {
+		if sym.Section == elf.SHN_UNDEF || sym.Name == "" {
+			continue
+		}
+		switch elf.ST_TYPE(sym.Info) {
+		case elf.STT_FUNC, elf.STT_OBJECT:
+			// versioned dynamic symbols (e.g. "deflate@@ZLIB_1.2.0") are reduced to the symbol name
+			symbols[strings.SplitN(sym.Name, "@", 2)[0]] = struct{}{}
+		}
+	}
+}
+
+func addMachOSymbols(symbols map[string]struct{}, r io.ReaderAt) {
+	f, err := macho.NewFile(r)
+	if err != nil {
+		log.Tracef("unable to parse Mach-O binary: %+v", err)
+		return
+	}
+	defer f.Close()
+
+	if f.Symtab == nil {
+		return
+	}
+
+	for _, sym := range f.Symtab.Syms {
+		if sym.Sect == machoNoSection || sym.Type&machoStabMask != 0 {
+			continue
+		}
+		// C symbols are prefixed with an underscore within Mach-O binaries
+		symbols[strings.TrimPrefix(sym.Name, "_")] = struct{}{}
+	}
+}
+
+func addPESymbols(symbols map[string]struct{}, r io.ReaderAt) {
+	f, err := pe.NewFile(r)
+	if err != nil {
+		log.Tracef("unable to parse PE binary: %+v", err)
+		return
+	}
+	defer f.Close()
+
+	for _, sym := range f.Symbols {
+		if sym.SectionNumber <= 0 {
+			continue
+		}
+		symbols[strings.TrimPrefix(sym.Name, "_")] = struct{}{}
+	}
+}
+
+// arMembers returns a reader for each object file within a (GNU or BSD) ar archive.
+func arMembers(r io.ReaderAt) []io.ReaderAt {
+	var members []io.ReaderAt
+
+	offset := int64(len(arMagic))
+	header := make([]byte, arHeaderSize)
+	for {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return members
+		}
+		offset += arHeaderSize
+
+		name := strings.TrimSpace(string(header[0:16]))
+		size, err := strconv.ParseInt(strings.TrimSpace(string(header[48:58])), 10, 64)
+		if err != nil || size < 0 {
+			log.Tracef("invalid ar member size for %q: %+v", name, err)
+			return members
+		}
+
+		dataOffset, dataSize := offset, size
+		if strings.HasPrefix(name, "#1/") {
+			// BSD ar stores long names immediately after the header (and includes them within the size)
+			nameLength, err := strconv.ParseInt(strings.TrimPrefix(name, "#1/"), 10, 64)
+			if err == nil && nameLength <= size {
+				dataOffset += nameLength
+				dataSize -= nameLength
+			}
+		}
+
+		switch name {
+		case "/", "//", "/SYM64/", "__.SYMDEF", "__.SYMDEF SORTED":
+			// symbol index and long name tables, not object files
+		default:
+			members = append(members, io.NewSectionReader(r, dataOffset, dataSize))
+		}
+
+		// members are aligned to an even offset
+		offset += size + size%2
+	}
+}
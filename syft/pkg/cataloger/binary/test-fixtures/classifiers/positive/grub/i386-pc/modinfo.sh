@@ -0,0 +1,10 @@
+#!/bin/sh
+
+# note: this SHOULD match as grub2 2.06-13+deb12u1
+
+grub_modinfo_target_cpu=i386
+grub_modinfo_platform=pc
+grub_disk_cache_stats=0
+grub_boot_time_stats=0
+grub_have_font_source=1
+grub_package_version="2.06-13+deb12u1"
@@ -0,0 +1,5 @@
+#include <zlib.h>
+
+int main(void) {
+	return (int) crc32(adler32(0, 0, 0), 0, 0) & 1;
+}
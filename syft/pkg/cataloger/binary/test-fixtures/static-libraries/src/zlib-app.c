@@ -0,0 +1,10 @@
+#include <string.h>
+#include <zlib.h>
+
+int main(void) {
+	unsigned char in[] = "static", out[64], back[64];
+	uLongf outLen = sizeof(out), backLen = sizeof(back);
+	compress2(out, &outLen, in, sizeof(in), 9);
+	uncompress(back, &backLen, out, outLen);
+	return (int) crc32(adler32(0, back, backLen), in, sizeof(in)) & 1;
+}
@@ -0,0 +1,215 @@
+/*
+Package cache persists the results of cataloging packages on disk, keyed by the content that was cataloged (e.g. the
+digests of the image layers that make up a filesystem). Content that has already been cataloged, such as the base
+layers shared by many images, does not need to be cataloged again.
+*/
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// entryVersion is the version of the format of cache entries. Entries of any other version are ignored.
+const entryVersion = 3
+
+const entryExtension = ".json"
+
+// Config describes where cataloging results are cached and for how long.
+type Config struct {
+	// Enabled caches cataloging results and reuses them for content that has already been cataloged
+	Enabled bool
+	// Directory is where cache entries are stored
+	Directory string
+	// TTL is how long cache entries are used after they are written (entries do not expire when zero)
+	TTL time.Duration
+}
+
+// DefaultConfig returns the cache configuration used when no other configuration is provided.
+func DefaultConfig() Config {
+	return Config{
+		Enabled: false,
+		TTL:     7 * 24 * time.Hour,
+	}
+}
+
+// Cache stores cataloging results within a directory on disk.
+type Cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// New creates a cache with the given configuration, creating the cache directory if it does not exist.
+func New(cfg Config) (*Cache, error) {
+	if cfg.Directory == "" {
+		return nil, fmt.Errorf("no cache directory configured")
+	}
+	if err := os.MkdirAll(cfg.Directory, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory=%q: %w", cfg.Directory, err)
+	}
+	return &Cache{
+		dir: cfg.Directory,
+		ttl: cfg.TTL,
+		now: time.Now,
+	}, nil
+}
+
+// Key returns a cache key that identifies the results of cataloging with the given descriptions of the cataloged
+// content and the cataloging configuration. The application version is always part of the key, so results are not
+// shared between different versions.
+func Key(parts ...string) string {
+	v := version.FromBuild()
+	hasher := sha256.New()
+	for _, part := range append([]string{v.Version, v.GitCommit}, parts...) {
+		// note: the length prefix prevents different parts from producing the same key when joined
+		_, _ = fmt.Fprintf(hasher, "%d:%s;", len(part), part)
+	}
+	return fmt.Sprintf("%x", hasher.Sum(nil))
+}
+
+// Get returns the cataloging results stored with the given key. Expired, missing, and unreadable entries are reported
+// as not found.
+func (c *Cache) Get(key string) (*pkg.Catalog, []artifact.Relationship, bool) {
+	catalog, relationships, _, found := c.get(key)
+	return catalog, relationships, found
+}
+
+// Set stores the given cataloging results with the given key, replacing any existing entry.
+func (c *Cache) Set(key string, catalog *pkg.Catalog, relationships []artifact.Relationship) error {
+	return c.set(key, catalog, relationships, nil)
+}
+
+// GetDirectory returns the results of cataloging a directory stored with the given key, along with the manifest of
+// the directory when it was cataloged (see Get).
+func (c *Cache) GetDirectory(key string) (*pkg.Catalog, []artifact.Relationship, Manifest, bool) {
+	catalog, relationships, manifest, found := c.get(key)
+	if found && manifest == nil {
+		return nil, nil, nil, false
+	}
+	return catalog, relationships, manifest, found
+}
+
+// SetDirectory stores the results of cataloging a directory with the given key, along with the manifest of the
+// directory that was cataloged.
+func (c *Cache) SetDirectory(key string, catalog *pkg.Catalog, relationships []artifact.Relationship, manifest Manifest) error {
+	if manifest == nil {
+		manifest = make(Manifest)
+	}
+	return c.set(key, catalog, relationships, manifest)
+}
+
+func (c *Cache) get(key string) (*pkg.Catalog, []artifact.Relationship, Manifest, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Debugf("unable to read cache entry=%q: %+v", path, err)
+		}
+		return nil, nil, nil, false
+	}
+
+	if c.expired(info) {
+		log.Debugf("removing expired cache entry=%q", path)
+		c.remove(path)
+		return nil, nil, nil, false
+	}
+
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		log.Debugf("unable to read cache entry=%q: %+v", path, err)
+		return nil, nil, nil, false
+	}
+
+	var e entry
+	if err := json.Unmarshal(contents, &e); err != nil || e.Version != entryVersion {
+		log.Debugf("ignoring unusable cache entry=%q: %+v", path, err)
+		c.remove(path)
+		return nil, nil, nil, false
+	}
+
+	catalog, relationships, err := e.results()
+	if err != nil {
+		log.Debugf("ignoring unusable cache entry=%q: %+v", path, err)
+		c.remove(path)
+		return nil, nil, nil, false
+	}
+	return catalog, relationships, e.Manifest, true
+}
+
+func (c *Cache) set(key string, catalog *pkg.Catalog, relationships []artifact.Relationship, manifest Manifest) error {
+	e, err := newEntry(catalog, relationships)
+	if err != nil {
+		return err
+	}
+	e.Manifest = manifest
+
+	contents, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("unable to encode cache entry: %w", err)
+	}
+
+	// write to a temporary file first, so concurrent readers never see a partially written entry
+	tempFile, err := os.CreateTemp(c.dir, key+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create cache entry: %w", err)
+	}
+	_, err = tempFile.Write(contents)
+	if closeErr := tempFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tempFile.Name(), c.path(key))
+	}
+	if err != nil {
+		c.remove(tempFile.Name())
+		return fmt.Errorf("unable to write cache entry: %w", err)
+	}
+	return nil
+}
+
+// Prune removes all expired entries from the cache.
+func (c *Cache) Prune() error {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("unable to read cache directory=%q: %w", c.dir, err)
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), entryExtension) {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		if c.expired(info) {
+			c.remove(filepath.Join(c.dir, e.Name()))
+		}
+	}
+	return nil
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, key+entryExtension)
+}
+
+func (c *Cache) expired(info os.FileInfo) bool {
+	return c.ttl > 0 && c.now().Sub(info.ModTime()) > c.ttl
+}
+
+func (c *Cache) remove(path string) {
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Debugf("unable to remove cache entry=%q: %+v", path, err)
+	}
+}
@@ -0,0 +1,99 @@
+package cache
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestCache_roundTrip(t *testing.T) {
+	c, err := New(Config{Enabled: true, Directory: t.TempDir()})
+	require.NoError(t, err)
+
+	libc := pkg.Package{
+		Name:         "libc6",
+		Version:      "2.31-13",
+		FoundBy:      "dpkgdb-cataloger",
+		Locations:    source.NewLocationSet(source.NewLocationFromCoordinates(source.Coordinates{RealPath: "/var/lib/dpkg/status", FileSystemID: "sha256:abc"})),
+		Licenses:     []pkg.License{{Value: "GPL-2.0", Type: pkg.DeclaredLicense}},
+		Type:         pkg.DebPkg,
+		CPEs:         []pkg.CPE{pkg.MustCPE("cpe:2.3:a:libc6:libc6:2.31-13:*:*:*:*:*:*:*")},
+		PURL:         "pkg:deb/debian/libc6@2.31-13",
+		MetadataType: pkg.DpkgMetadataType,
+		Metadata: pkg.DpkgMetadata{
+			Package: "libc6",
+			Version: "2.31-13",
+		},
+	}
+	libc.SetID()
+
+	bash := pkg.Package{
+		Name:    "bash",
+		Version: "5.1-2",
+		Type:    pkg.DebPkg,
+	}
+	bash.SetID()
+
+	file := source.Coordinates{RealPath: "/lib/x86_64-linux-gnu/libc.so.6", FileSystemID: "sha256:abc"}
+	relationships := []artifact.Relationship{
+		{From: libc, To: file, Type: artifact.ContainsRelationship},
+		{From: libc, To: bash, Type: artifact.DependencyOfRelationship},
+	}
+
+	key := Key("layer=sha256:abc")
+	_, _, found := c.Get(key)
+	assert.False(t, found)
+
+	require.NoError(t, c.Set(key, pkg.NewCatalog(libc, bash), relationships))
+
+	catalog, actualRelationships, found := c.Get(key)
+	require.True(t, found)
+	require.Equal(t, 2, catalog.PackageCount())
+
+	actual := catalog.Package(libc.ID())
+	require.NotNil(t, actual)
+	assert.Equal(t, libc.Name, actual.Name)
+	assert.Equal(t, libc.Locations.ToSlice(), actual.Locations.ToSlice())
+	assert.Equal(t, libc.Licenses, actual.Licenses)
+	assert.Equal(t, libc.CPEs, actual.CPEs)
+	assert.Equal(t, libc.Metadata, actual.Metadata)
+
+	require.Len(t, actualRelationships, 2)
+	assert.Equal(t, libc.ID(), actualRelationships[0].From.ID())
+	assert.Equal(t, file, actualRelationships[0].To)
+	assert.Equal(t, bash.ID(), actualRelationships[1].To.ID())
+}
+
+func TestCache_expiredEntries(t *testing.T) {
+	dir := t.TempDir()
+	c, err := New(Config{Enabled: true, Directory: dir, TTL: time.Hour})
+	require.NoError(t, err)
+
+	key := Key("layer=sha256:abc")
+	require.NoError(t, c.Set(key, pkg.NewCatalog(), nil))
+
+	_, _, found := c.Get(key)
+	assert.True(t, found)
+
+	c.now = func() time.Time {
+		return time.Now().Add(2 * time.Hour)
+	}
+	_, _, found = c.Get(key)
+	assert.False(t, found)
+
+	_, err = os.Stat(c.path(key))
+	assert.True(t, os.IsNotExist(err), "expired entry was not removed")
+}
+
+func TestKey(t *testing.T) {
+	assert.Equal(t, Key("a", "b"), Key("a", "b"))
+	assert.NotEqual(t, Key("a", "b"), Key("b", "a"))
+	assert.NotEqual(t, Key("ab"), Key("a", "b"))
+}
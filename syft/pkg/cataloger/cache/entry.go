@@ -0,0 +1,227 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// entry is the stored form of the results of cataloging.
+type entry struct {
+	Version       int                  `json:"version"`
+	Packages      []cachedPackage      `json:"packages"`
+	Relationships []cachedRelationship `json:"relationships"`
+	// Manifest is the state of the cataloged directory (only for the results of cataloging a directory)
+	Manifest Manifest `json:"manifest,omitempty"`
+}
+
+type cachedPackage struct {
+	ID             artifact.ID                  `json:"id"`
+	Name           string                       `json:"name"`
+	Version        string                       `json:"version"`
+	FoundBy        string                       `json:"foundBy"`
+	Locations      []source.Location            `json:"locations"`
+	Licenses       []pkg.License                `json:"licenses,omitempty"`
+	LicenseTexts   map[string]string            `json:"licenseTexts,omitempty"`
+	Language       pkg.Language                 `json:"language"`
+	Type           pkg.Type                     `json:"type"`
+	CPEs           []string                     `json:"cpes,omitempty"`
+	CPEAnnotations map[string]pkg.CPEAnnotation `json:"cpeAnnotations,omitempty"`
+	PURL           string                       `json:"purl"`
+	MetadataType   pkg.MetadataType             `json:"metadataType,omitempty"`
+	Metadata       json.RawMessage              `json:"metadata,omitempty"`
+	Evidence       []pkg.Evidence               `json:"evidence,omitempty"`
+	Confidence     pkg.Confidence               `json:"confidence,omitempty"`
+}
+
+type cachedRelationship struct {
+	From        cachedEndpoint            `json:"from"`
+	To          cachedEndpoint            `json:"to"`
+	Type        artifact.RelationshipType `json:"type"`
+	Data        interface{}               `json:"data,omitempty"`
+	Annotations map[string]string         `json:"annotations,omitempty"`
+}
+
+// cachedEndpoint is either a package (by ID) or a file within the cataloged content.
+type cachedEndpoint struct {
+	Package     artifact.ID         `json:"package,omitempty"`
+	Coordinates *source.Coordinates `json:"coordinates,omitempty"`
+}
+
+func newEntry(catalog *pkg.Catalog, relationships []artifact.Relationship) (*entry, error) {
+	e := entry{
+		Version: entryVersion,
+	}
+
+	for _, p := range catalog.Sorted() {
+		cached, err := newCachedPackage(p)
+		if err != nil {
+			return nil, err
+		}
+		e.Packages = append(e.Packages, *cached)
+	}
+
+	for _, r := range relationships {
+		from, err := newCachedEndpoint(r.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := newCachedEndpoint(r.To)
+		if err != nil {
+			return nil, err
+		}
+		e.Relationships = append(e.Relationships, cachedRelationship{
+			From:        *from,
+			To:          *to,
+			Type:        r.Type,
+			Data:        r.Data,
+			Annotations: r.Annotations,
+		})
+	}
+	return &e, nil
+}
+
+func newCachedPackage(p pkg.Package) (*cachedPackage, error) {
+	var metadata json.RawMessage
+	if p.Metadata != nil {
+		if _, ok := pkg.MetadataTypeByName[p.MetadataType]; !ok {
+			return nil, fmt.Errorf("unable to cache package=%s with unknown metadata type=%q", p, p.MetadataType)
+		}
+		var err error
+		metadata, err = json.Marshal(p.Metadata)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode metadata of package=%s: %w", p, err)
+		}
+	}
+
+	var cpes []string
+	for _, c := range p.CPEs {
+		cpes = append(cpes, pkg.CPEString(c))
+	}
+
+	return &cachedPackage{
+		ID:             p.ID(),
+		Name:           p.Name,
+		Version:        p.Version,
+		FoundBy:        p.FoundBy,
+		Locations:      p.Locations.ToSlice(),
+		Licenses:       p.Licenses,
+		LicenseTexts:   p.LicenseTexts,
+		Language:       p.Language,
+		Type:           p.Type,
+		CPEs:           cpes,
+		CPEAnnotations: p.CPEAnnotations,
+		PURL:           p.PURL,
+		MetadataType:   p.MetadataType,
+		Metadata:       metadata,
+		Evidence:       p.Evidence,
+		Confidence:     p.Confidence,
+	}, nil
+}
+
+func newCachedEndpoint(identifiable artifact.Identifiable) (*cachedEndpoint, error) {
+	switch v := identifiable.(type) {
+	case pkg.Package:
+		return &cachedEndpoint{Package: v.ID()}, nil
+	case source.Coordinates:
+		return &cachedEndpoint{Coordinates: &v}, nil
+	case source.Location:
+		return &cachedEndpoint{Coordinates: &v.Coordinates}, nil
+	default:
+		return nil, fmt.Errorf("unable to cache relationship with %T", identifiable)
+	}
+}
+
+// results returns the cataloging results described by the entry.
+func (e entry) results() (*pkg.Catalog, []artifact.Relationship, error) {
+	packages := make(map[artifact.ID]pkg.Package)
+	catalog := pkg.NewCatalog()
+	for _, cached := range e.Packages {
+		p, err := cached.toPackage()
+		if err != nil {
+			return nil, nil, err
+		}
+		packages[p.ID()] = *p
+		catalog.Add(*p)
+	}
+
+	endpoint := func(cached cachedEndpoint) (artifact.Identifiable, error) {
+		if cached.Coordinates != nil {
+			return *cached.Coordinates, nil
+		}
+		p, ok := packages[cached.Package]
+		if !ok {
+			return nil, fmt.Errorf("relationship references unknown package=%q", cached.Package)
+		}
+		return p, nil
+	}
+
+	var relationships []artifact.Relationship
+	for _, cached := range e.Relationships {
+		from, err := endpoint(cached.From)
+		if err != nil {
+			return nil, nil, err
+		}
+		to, err := endpoint(cached.To)
+		if err != nil {
+			return nil, nil, err
+		}
+		relationships = append(relationships, artifact.Relationship{
+			From:        from,
+			To:          to,
+			Type:        cached.Type,
+			Data:        cached.Data,
+			Annotations: cached.Annotations,
+		})
+	}
+	return catalog, relationships, nil
+}
+
+func (c cachedPackage) toPackage() (*pkg.Package, error) {
+	var cpes []pkg.CPE
+	for _, s := range c.CPEs {
+		value, err := pkg.NewCPE(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CPE=%q: %w", s, err)
+		}
+		cpes = append(cpes, value)
+	}
+
+	var metadata interface{}
+	if len(c.Metadata) > 0 {
+		typ, ok := pkg.MetadataTypeByName[c.MetadataType]
+		if !ok {
+			return nil, fmt.Errorf("unknown metadata type=%q", c.MetadataType)
+		}
+		value := reflect.New(typ)
+		if err := json.Unmarshal(c.Metadata, value.Interface()); err != nil {
+			return nil, fmt.Errorf("unable to decode metadata of package=%q: %w", c.ID, err)
+		}
+		metadata = value.Elem().Interface()
+	}
+
+	p := pkg.Package{
+		Name:           c.Name,
+		Version:        c.Version,
+		FoundBy:        c.FoundBy,
+		Locations:      source.NewLocationSet(c.Locations...),
+		Licenses:       c.Licenses,
+		LicenseTexts:   c.LicenseTexts,
+		Language:       c.Language,
+		Type:           c.Type,
+		CPEs:           cpes,
+		CPEAnnotations: c.CPEAnnotations,
+		PURL:           c.PURL,
+		MetadataType:   c.MetadataType,
+		Metadata:       metadata,
+		Evidence:       c.Evidence,
+		Confidence:     c.Confidence,
+	}
+	// the ID is kept as-is, since relationships (and the results of other layers) refer to it
+	p.OverrideID(c.ID)
+	return &p, nil
+}
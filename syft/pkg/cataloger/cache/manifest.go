@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source"
+)
+
+// FileState is what is known about a file of a directory when the directory was cataloged, used to tell if the file
+// has changed since.
+type FileState struct {
+	ModTime time.Time `json:"modTime"`
+	Size    int64     `json:"size"`
+	Inode   uint64    `json:"inode,omitempty"`
+	// Digest is the SHA-256 digest of the file contents, which is only computed once the file has been touched (so
+	// files that are touched without being modified, e.g. by a fresh checkout, are not considered to be changed)
+	Digest string `json:"digest,omitempty"`
+}
+
+// Manifest is the state of all regular files of a directory, keyed by the real path of each file.
+type Manifest map[string]FileState
+
+// NewManifest records the state of all regular files of the given resolver. Files that have a different modification
+// time, size, or inode than in the previous manifest (if any) are digested.
+func NewManifest(resolver source.FileResolver, previous Manifest) Manifest {
+	manifest := make(Manifest)
+	for location := range resolver.AllLocations() {
+		if _, ok := manifest[location.RealPath]; ok {
+			continue
+		}
+		metadata, err := resolver.FileMetadataByLocation(location)
+		if err != nil {
+			log.Debugf("unable to get metadata for path=%q: %+v", location.RealPath, err)
+			continue
+		}
+		if metadata.Type != source.RegularFile {
+			continue
+		}
+
+		state := FileState{
+			ModTime: metadata.ModTime,
+			Size:    metadata.Size,
+			Inode:   metadata.Inode,
+		}
+
+		if before, ok := previous[location.RealPath]; ok {
+			if state.sameStat(before) {
+				state.Digest = before.Digest
+			} else {
+				state.Digest = digest(resolver, location)
+			}
+		}
+		manifest[location.RealPath] = state
+	}
+	return manifest
+}
+
+// Changes returns the paths of all files that were added, modified, or removed since the previous manifest.
+func (m Manifest) Changes(previous Manifest) map[string]struct{} {
+	changed := make(map[string]struct{})
+	for path, state := range m {
+		before, ok := previous[path]
+		if !ok || !state.same(before) {
+			changed[path] = struct{}{}
+		}
+	}
+	for path := range previous {
+		if _, ok := m[path]; !ok {
+			changed[path] = struct{}{}
+		}
+	}
+	return changed
+}
+
+func (s FileState) sameStat(other FileState) bool {
+	return s.ModTime.Equal(other.ModTime) && s.Size == other.Size && s.Inode == other.Inode
+}
+
+func (s FileState) same(other FileState) bool {
+	if s.sameStat(other) {
+		return true
+	}
+	// the file has been touched, but may still have the same contents
+	return s.Digest != "" && s.Digest == other.Digest
+}
+
+func digest(resolver source.FileContentResolver, location source.Location) string {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		log.Debugf("unable to read path=%q: %+v", location.RealPath, err)
+		return ""
+	}
+	defer reader.Close()
+
+	hasher := sha256.New()
+	if _, err := io.Copy(hasher, reader); err != nil {
+		log.Debugf("unable to digest path=%q: %+v", location.RealPath, err)
+		return ""
+	}
+	return fmt.Sprintf("%x", hasher.Sum(nil))
+}
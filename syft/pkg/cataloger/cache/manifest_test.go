@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestManifest_Changes(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, contents string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+		return path
+	}
+	// the resolver reports the given modification times, so touching a file is simulated by changing its time
+	resolver := func(modTimes map[string]time.Time) source.FileResolver {
+		metadata := make(map[source.Location]source.FileMetadata)
+		for path, modTime := range modTimes {
+			info, err := os.Stat(path)
+			require.NoError(t, err)
+			metadata[source.NewLocation(path)] = source.FileMetadata{
+				Type:    source.RegularFile,
+				Size:    info.Size(),
+				ModTime: modTime,
+			}
+		}
+		return source.NewMockResolverForPathsWithMetadata(metadata)
+	}
+
+	start := time.Now()
+	later := start.Add(time.Minute)
+	latest := start.Add(2 * time.Minute)
+
+	removed := write("removed", "removed")
+	modified := write("modified", "before")
+	touched := write("touched", "touched")
+	unchanged := write("unchanged", "unchanged")
+
+	first := NewManifest(resolver(map[string]time.Time{removed: start, modified: start, touched: start, unchanged: start}), nil)
+	assert.Len(t, first.Changes(nil), 4)
+	assert.Empty(t, first.Changes(first))
+
+	modified = write("modified", "after")
+	added := write("added", "added")
+
+	second := NewManifest(resolver(map[string]time.Time{modified: later, touched: later, unchanged: start, added: later}), first)
+	assert.Equal(t, map[string]struct{}{
+		removed:  {},
+		modified: {},
+		// the contents of the file were not known before it was touched, so it is assumed to have changed
+		touched: {},
+		added:   {},
+	}, second.Changes(first))
+	assert.NotEmpty(t, second[touched].Digest)
+	assert.Empty(t, second[unchanged].Digest)
+
+	third := NewManifest(resolver(map[string]time.Time{modified: later, touched: latest, unchanged: start, added: later}), second)
+	assert.Empty(t, third.Changes(second), "touched file with the same contents should not be changed")
+}
+
+func TestCache_directoryManifest(t *testing.T) {
+	c, err := New(Config{Directory: t.TempDir()})
+	require.NoError(t, err)
+
+	key := Key("directory=/src")
+	manifest := Manifest{
+		"/src/go.mod": {Size: 42, ModTime: time.Unix(1000, 0).UTC(), Digest: "abc"},
+	}
+	require.NoError(t, c.SetDirectory(key, pkg.NewCatalog(), nil, manifest))
+
+	_, _, actual, found := c.GetDirectory(key)
+	require.True(t, found)
+	assert.Equal(t, manifest, actual)
+
+	// results that were not stored with a manifest are not directory results
+	other := Key("layer=sha256:abc")
+	require.NoError(t, c.Set(other, pkg.NewCatalog(), nil))
+	_, _, _, found = c.GetDirectory(other)
+	assert.False(t, found)
+}
@@ -0,0 +1,114 @@
+package cataloger
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+// contextResolver stops giving files to catalogers once the context is done, so that running catalogers return soon
+// after cataloging is canceled (removing any temporary files they created) rather than running to completion.
+type contextResolver struct {
+	source.FileResolver
+	ctx context.Context
+}
+
+func newContextResolver(ctx context.Context, resolver source.FileResolver) source.FileResolver {
+	if ctx.Done() == nil {
+		// the context can never be canceled
+		return resolver
+	}
+	return &contextResolver{
+		FileResolver: resolver,
+		ctx:          ctx,
+	}
+}
+
+func (r *contextResolver) FileContentsByLocation(location source.Location) (io.ReadCloser, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read path=%q: %w", location.RealPath, err)
+	}
+	reader, err := r.FileResolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	return &contextReader{ReadCloser: reader, ctx: r.ctx}, nil
+}
+
+func (r *contextResolver) FileMetadataByLocation(location source.Location) (source.FileMetadata, error) {
+	if err := r.ctx.Err(); err != nil {
+		return source.FileMetadata{}, err
+	}
+	return r.FileResolver.FileMetadataByLocation(location)
+}
+
+func (r *contextResolver) HasPath(path string) bool {
+	if r.ctx.Err() != nil {
+		return false
+	}
+	return r.FileResolver.HasPath(path)
+}
+
+func (r *contextResolver) FilesByPath(paths ...string) ([]source.Location, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.FileResolver.FilesByPath(paths...)
+}
+
+func (r *contextResolver) FilesByGlob(patterns ...string) ([]source.Location, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.FileResolver.FilesByGlob(patterns...)
+}
+
+func (r *contextResolver) FilesByMIMEType(types ...string) ([]source.Location, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+	return r.FileResolver.FilesByMIMEType(types...)
+}
+
+func (r *contextResolver) RelativeFileByPath(location source.Location, path string) *source.Location {
+	if r.ctx.Err() != nil {
+		return nil
+	}
+	return r.FileResolver.RelativeFileByPath(location, path)
+}
+
+func (r *contextResolver) AllLocations() <-chan source.Location {
+	c := make(chan source.Location)
+	go func() {
+		defer close(c)
+		locations := r.FileResolver.AllLocations()
+		for location := range locations {
+			if r.ctx.Err() != nil {
+				break
+			}
+			select {
+			case c <- location:
+			case <-r.ctx.Done():
+			}
+		}
+		// note: the remaining locations are drained so that the goroutine of the underlying resolver finishes
+		for range locations {
+		}
+	}()
+	return c
+}
+
+// contextReader fails reads once the context is done, so that catalogers reading large files stop part way through.
+type contextReader struct {
+	io.ReadCloser
+	ctx context.Context
+}
+
+func (r *contextReader) Read(p []byte) (int, error) {
+	if err := r.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return r.ReadCloser.Read(p)
+}
@@ -0,0 +1,116 @@
+package cataloger
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/plugin"
+	"github.com/anchore/syft/syft/source"
+)
+
+// pollingCataloger reads the given path until the resolver stops giving it the file, recording when it has returned.
+type pollingCataloger struct {
+	path     string
+	returned *int32
+}
+
+func (c pollingCataloger) Name() string {
+	return "polling-cataloger"
+}
+
+func (c pollingCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	defer atomic.StoreInt32(c.returned, 1)
+	for {
+		locations, err := resolver.FilesByPath(c.path)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(locations) == 0 {
+			return nil, nil, errors.New("file not found")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCatalogWithContext_canceled(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.pkg")
+	require.NoError(t, os.WriteFile(path, []byte("contents"), 0644))
+
+	var returned int32
+	catalogers := []pkg.Cataloger{
+		pollingCataloger{path: path, returned: &returned},
+		delayedCataloger{name: "never-started"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	catalog, _, err := CatalogWithContext(ctx, source.NewMockResolverForPaths(path), nil, Options{Parallelism: 1}, catalogers...)
+	assert.True(t, time.Since(start) < 5*time.Second, "should stop soon after the context is done")
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, IsPartialResults(err))
+	assert.Nil(t, catalog)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&returned), "running catalogers should return before cataloging does")
+}
+
+func TestCatalogWithContext_canceledPlugin(t *testing.T) {
+	// note: the plugin never finishes cataloging on its own, and is not limited by a plugin timeout
+	c, err := plugin.NewCataloger("plugin/test-fixtures/extra/syft-cataloger-slow", plugin.Config{})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	catalog, _, err := CatalogWithContext(ctx, source.NewMockResolverForPaths("plugin/test-fixtures/acme/packages.lock"), nil, Options{Parallelism: 1}, c)
+	assert.True(t, time.Since(start) < 30*time.Second, "the plugin should be stopped soon after the context is done")
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, catalog)
+}
+
+func TestContextResolver(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.pkg")
+	require.NoError(t, os.WriteFile(path, []byte("contents"), 0644))
+
+	mock := source.NewMockResolverForPaths(path)
+	assert.Same(t, mock, newContextResolver(context.Background(), mock), "contexts that cannot be canceled should not be checked")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resolver := newContextResolver(ctx, mock)
+
+	locations, err := resolver.FilesByGlob("**/*.pkg")
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	reader, err := resolver.FileContentsByLocation(locations[0])
+	require.NoError(t, err)
+	defer reader.Close()
+
+	cancel()
+
+	_, err = reader.Read(make([]byte, 1))
+	assert.ErrorIs(t, err, context.Canceled, "reads of open files should fail")
+	_, err = resolver.FilesByGlob("**/*.pkg")
+	assert.ErrorIs(t, err, context.Canceled)
+	_, err = resolver.FileContentsByLocation(locations[0])
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, resolver.RelativeFileByPath(locations[0], path))
+
+	var all []source.Location
+	for l := range resolver.AllLocations() {
+		all = append(all, l)
+	}
+	assert.Empty(t, all)
+}
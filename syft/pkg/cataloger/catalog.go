@@ -1,9 +1,13 @@
 package cataloger
 
 import (
+	"context"
 	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/hashicorp/go-multierror"
 	"github.com/wagoodman/go-partybus"
 	"github.com/wagoodman/go-progress"
 
@@ -19,92 +23,362 @@ import (
 
 // Monitor provides progress-related data for observing the progress of a Catalog() call (published on the event bus).
 type Monitor struct {
-	FilesProcessed     progress.Monitorable // the number of files selected and contents analyzed from all registered catalogers
-	PackagesDiscovered progress.Monitorable // the number of packages discovered from all registered catalogers
+	FilesProcessed      progress.Monitorable  // the number of files read by all registered catalogers
+	PackagesDiscovered  progress.Monitorable  // the number of packages discovered from all registered catalogers
+	CatalogersProcessed progress.Progressable // the number of catalogers that have finished (out of all catalogers to run)
+	Catalogers          CatalogerStates       // the state of each registered cataloger
 }
 
 // newMonitor creates a new Monitor object and publishes the object on the bus as a PackageCatalogerStarted event.
-func newMonitor() (*progress.Manual, *progress.Manual) {
-	filesProcessed := progress.Manual{}
+func newMonitor(catalogers *catalogerProgress) (*progress.Manual, *progress.Manual) {
 	packagesDiscovered := progress.Manual{}
+	catalogersProcessed := progress.Manual{Total: int64(len(catalogers.states))}
 
 	bus.Publish(partybus.Event{
 		Type: event.PackageCatalogerStarted,
 		Value: Monitor{
-			FilesProcessed:     progress.Monitorable(&filesProcessed),
-			PackagesDiscovered: progress.Monitorable(&packagesDiscovered),
+			FilesProcessed:      progress.Monitorable(catalogers),
+			PackagesDiscovered:  progress.Monitorable(&packagesDiscovered),
+			CatalogersProcessed: progress.Progressable(&catalogersProcessed),
+			Catalogers:          catalogers,
 		},
 	})
-	return &filesProcessed, &packagesDiscovered
+	return &packagesDiscovered, &catalogersProcessed
+}
+
+// catalogResult is the outcome of running a single cataloger (and enriching the packages it found).
+type catalogResult struct {
+	packages      []pkg.Package
+	relationships []artifact.Relationship
+	err           error
 }
 
 // Catalog a given source (container image or filesystem) with the given catalogers, returning all discovered packages.
-// In order to efficiently retrieve contents from a underlying container image the content fetch requests are
-// done in bulk. Specifically, all files of interest are collected from each catalogers and accumulated into a single
-// request.
-func Catalog(resolver source.FileResolver, release *linux.Release, catalogers ...pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+// Catalogers are run as independent tasks over the shared resolver by a pool of at most the given number of workers
+// (a parallelism of 1 or less runs each cataloger in turn). Results are always added to the catalog in the order of the
+// given catalogers, so the output does not depend on the order in which the tasks finish. When any cataloger fails (or
+// panics), the results of all other catalogers are returned along with a *PartialResultsError describing the failures.
+func Catalog(resolver source.FileResolver, release *linux.Release, parallelism int, catalogers ...pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+	return CatalogWithOptions(resolver, release, Options{Parallelism: parallelism}, catalogers...)
+}
+
+// Options control how catalogers are run by CatalogWithOptions.
+type Options struct {
+	// Parallelism is the maximum number of catalogers to run concurrently (see Catalog)
+	Parallelism int
+	// Limits bound the resources used by the catalogers. When the deadline passes, the packages found by the catalogers
+	// that have finished are returned (along with a *PartialResultsError) rather than waiting for the remaining catalogers.
+	Limits Limits
+	// Metrics records the measurements of each cataloger run (nothing is measured when nil)
+	Metrics *Metrics
+	// Instrumentation reports each catalog call and cataloger run as traces and metrics (nothing is reported when nil)
+	Instrumentation *Instrumentation
+	// IDScheme derives the IDs of the cataloged packages (pkg.DefaultIDScheme when empty)
+	IDScheme pkg.IDScheme
+}
+
+// CatalogWithOptions catalogs a given source as with Catalog, running the catalogers with the given options.
+func CatalogWithOptions(resolver source.FileResolver, release *linux.Release, opts Options, catalogers ...pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+	return CatalogWithContext(context.Background(), resolver, release, opts, catalogers...)
+}
+
+// CatalogWithContext catalogs a given source as with CatalogWithOptions, stopping when the given context is canceled (or
+// its deadline passes). Once the context is done no further catalogers are started, and the running catalogers are
+// given no further files, so they return (cleaning up after themselves) before the context error is returned. Unlike
+// the deadline within the Limits, no results are returned when the context is done.
+func CatalogWithContext(ctx context.Context, resolver source.FileResolver, release *linux.Release, opts Options, catalogers ...pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+	return catalogSearch(ctx, resolver, resolver, release, opts, catalogers...)
+}
+
+// finishedTask is the result of the cataloger at the given index.
+type finishedTask struct {
+	idx    int
+	result catalogResult
+}
+
+// catalogSearch runs the given catalogers over the search resolver, while the packages found are enriched (licenses, file
+// ownership, etc.) from all files of the resolver.
+func catalogSearch(ctx context.Context, search, resolver source.FileResolver, release *linux.Release, opts Options, catalogers ...pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
 	catalog := pkg.NewCatalog()
 	var allRelationships []artifact.Relationship
 
-	filesProcessed, packagesDiscovered := newMonitor()
-
-	// perform analysis, accumulating errors for each failed analysis
-	var errs error
-	for _, c := range catalogers {
-		// find packages from the underlying raw data
-		log.Debugf("cataloging with %q", c.Name())
-		packages, relationships, err := c.Catalog(resolver)
-		if err != nil {
-			errs = multierror.Append(errs, err)
-			continue
-		}
+	catalogerStates := newCatalogerProgress(catalogers)
+	packagesDiscovered, catalogersProcessed := newMonitor(catalogerStates)
+	defer func() {
+		catalogerStates.setCompleted()
+		packagesDiscovered.SetCompleted()
+		catalogersProcessed.SetCompleted()
+	}()
 
-		catalogedPackages := len(packages)
+	spanCtx, finish := opts.Instrumentation.startCatalog(len(catalogers))
 
-		log.Debugf("discovered %d packages", catalogedPackages)
-		packagesDiscovered.N += int64(catalogedPackages)
+	search, resolver = newContextResolver(ctx, search), newContextResolver(ctx, resolver)
 
-		for _, p := range packages {
-			// generate CPEs (note: this is excluded from package ID, so is safe to mutate)
-			p.CPEs = cpe.Generate(p)
+	limits := opts.Limits
+	// catalogers doing work outside of the resolver stop at the deadline too (see pkg.ContextCataloger)
+	runCtx := ctx
+	if !limits.Deadline.IsZero() {
+		var cancelRun context.CancelFunc
+		runCtx, cancelRun = context.WithDeadline(ctx, limits.Deadline)
+		defer cancelRun()
+	}
+	parallelism := opts.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	if parallelism > len(catalogers) {
+		parallelism = len(catalogers)
+	}
 
-			// generate PURL (note: this is excluded from package ID, so is safe to mutate)
-			p.PURL = pkg.URL(p, release)
+	tasks := make(chan int)
+	// note: this is buffered so that workers never block when results are no longer collected (after the deadline)
+	finished := make(chan finishedTask, len(catalogers))
+	results := make([]catalogResult, len(catalogers))
 
-			// if we were not able to identify the language we have an opportunity
-			// to try and get this value from the PURL. Worst case we assert that
-			// we could not identify the language at either stage and set UnknownLanguage
-			if p.Language == "" {
-				p.Language = pkg.LanguageFromPURL(p.PURL)
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range tasks {
+				if limits.TimedOut() || ctx.Err() != nil {
+					continue
+				}
+				c := catalogers[idx]
+				counted := catalogerStates.start(idx, newLimitedResolver(search, limits, c.Name()))
+				publishCatalogerStarted(ctx, c)
+				result := opts.measure(spanCtx, c, counted, func(search source.FileResolver) catalogResult {
+					return runCataloger(runCtx, c, search, resolver, release, opts.IDScheme)
+				})
+				finished <- finishedTask{idx: idx, result: result}
 			}
+		}()
+	}
+
+	go func() {
+		for idx := range catalogers {
+			tasks <- idx
+		}
+		close(tasks)
+		wg.Wait()
+		close(finished)
+	}()
+
+	var timeout <-chan time.Time
+	if !limits.Deadline.IsZero() {
+		timer := time.NewTimer(time.Until(limits.Deadline))
+		defer timer.Stop()
+		timeout = timer.C
+	}
 
-			// create file-to-package relationships for files owned by the package
-			owningRelationships, err := packageFileOwnershipRelationships(p, resolver)
-			if err != nil {
-				log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
-			} else {
-				allRelationships = append(allRelationships, owningRelationships...)
+	// results and progress are only updated from this goroutine as each task completes
+	done := make([]bool, len(catalogers))
+collect:
+	for {
+		select {
+		case task, ok := <-finished:
+			if !ok {
+				break collect
 			}
+			results[task.idx] = task.result
+			done[task.idx] = true
+			state := catalogerStates.finish(task.idx, task.result)
+			publishCatalogerResult(ctx, catalogers[task.idx], task.result, state.Duration)
+			catalogersProcessed.N++
+			packagesDiscovered.N += int64(len(task.result.packages))
+		case <-timeout:
+			break collect
+		case <-ctx.Done():
+			break collect
+		}
+	}
 
-			// add to catalog
-			catalog.Add(p)
+	if err := ctx.Err(); err != nil {
+		return nil, nil, cancelCatalog(ctx, err, catalogers, done, catalogerStates, finished, finish)
+	}
+
+	var unfinished []string
+	for idx, c := range catalogers {
+		if !done[idx] {
+			unfinished = append(unfinished, c.Name())
+			results[idx].err = errTimedOut
+			catalogerStates.fail(idx, errTimedOut)
+			publishCatalogerResult(ctx, c, results[idx], 0)
 		}
+	}
+	if len(unfinished) > 0 {
+		log.Warnf("cataloging timed out, results are incomplete (unfinished catalogers: %s)", strings.Join(unfinished, ", "))
+	}
 
-		allRelationships = append(allRelationships, relationships...)
+	// record the failure of each cataloger, keeping the results of all other catalogers
+	var failures []CatalogerError
+	for idx, result := range results {
+		if result.err != nil {
+			if result.err != errTimedOut {
+				log.Warnf("cataloger=%q failed, results are incomplete: %+v", catalogers[idx].Name(), result.err)
+			}
+			failures = append(failures, CatalogerError{Cataloger: catalogers[idx].Name(), Err: result.err})
+			continue
+		}
+		for _, p := range result.packages {
+			catalog.Add(p)
+		}
+		allRelationships = append(allRelationships, result.relationships...)
 	}
 
 	allRelationships = append(allRelationships, pkg.NewRelationships(catalog)...)
+	finish(len(failures))
 
-	if errs != nil {
-		return nil, nil, errs
+	if len(failures) > 0 {
+		return catalog, allRelationships, &PartialResultsError{Failures: failures}
 	}
 
-	filesProcessed.SetCompleted()
-	packagesDiscovered.SetCompleted()
-
 	return catalog, allRelationships, nil
 }
 
+// cancelCatalog waits for the running catalogers to return once cataloging has been canceled (with the given context
+// error), so that no goroutines (or temporary files) are left behind, returning the error to report to the caller.
+func cancelCatalog(ctx context.Context, err error, catalogers []pkg.Cataloger, done []bool, catalogerStates *catalogerProgress, finished <-chan finishedTask, finish func(int)) error {
+	// note: running catalogers fail soon after cancellation since the resolver no longer gives them any files (and the
+	// processes and requests of context catalogers are stopped)
+	for task := range finished {
+		done[task.idx] = true
+		state := catalogerStates.finish(task.idx, task.result)
+		publishCatalogerResult(ctx, catalogers[task.idx], task.result, state.Duration)
+	}
+
+	var unfinished int
+	for idx, c := range catalogers {
+		if !done[idx] {
+			unfinished++
+			catalogerStates.fail(idx, err)
+			publishCatalogerResult(ctx, c, catalogResult{err: err}, 0)
+		}
+	}
+	log.Debugf("cataloging canceled (%d catalogers did not run)", unfinished)
+	finish(unfinished)
+
+	return fmt.Errorf("cataloging canceled: %w", err)
+}
+
+// runCataloger finds packages with the given cataloger (within the search resolver) and fills in the package fields that
+// are derived from the package itself or the files it owns. This is safe to call concurrently for different catalogers.
+// A panic within the cataloger is reported as the error of the result.
+func runCataloger(ctx context.Context, c pkg.Cataloger, search, resolver source.FileResolver, release *linux.Release, scheme pkg.IDScheme) (result catalogResult) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Debugf("cataloger=%q panicked: %v\n%s", c.Name(), r, debug.Stack())
+			result = catalogResult{err: fmt.Errorf("panic: %v", r)}
+		}
+	}()
+
+	// find packages from the underlying raw data
+	log.Debugf("cataloging with %q", c.Name())
+	var packages []pkg.Package
+	var relationships []artifact.Relationship
+	var err error
+	if cc, ok := c.(pkg.ContextCataloger); ok {
+		packages, relationships, err = cc.CatalogWithContext(ctx, search)
+	} else {
+		packages, relationships, err = c.Catalog(search)
+	}
+	if err != nil {
+		return catalogResult{err: err}
+	}
+
+	log.Debugf("discovered %d packages with %q", len(packages), c.Name())
+
+	var allRelationships []artifact.Relationship
+	reidentified := make(map[artifact.ID]pkg.Package)
+	for i := range packages {
+		p := &packages[i]
+
+		// generate CPEs (note: this is excluded from package ID, so is safe to mutate)
+		// we might have classifier-provided CPEs already with the package so we want to append here
+		generated := cpe.Generate(*p)
+		p.CPEAnnotations = cpe.Annotate(p.CPEs, generated)
+		p.CPEs = append(p.CPEs, generated...)
+
+		// generate PURL if the cataloger did not already provide one (note: this is excluded from package ID, so is safe to mutate)
+		if p.PURL == "" {
+			p.PURL = pkg.URL(*p, release)
+		}
+
+		// catalogers that match content (e.g. binary classifiers) describe the evidence of each package, otherwise the
+		// package was parsed from the files it was discovered by
+		if len(p.Evidence) == 0 {
+			p.Evidence = pkg.NewFileEvidence(p.Locations.ToSlice()...)
+		}
+
+		// catalogers that guess packages from file contents label them as heuristic, otherwise the confidence level
+		// follows from the evidence
+		if p.Confidence == "" {
+			p.Confidence = pkg.ConfidenceFromEvidence(p.Evidence)
+		}
+
+		// if we were not able to identify the language we have an opportunity
+		// to try and get this value from the PURL. Worst case we assert that
+		// we could not identify the language at either stage and set UnknownLanguage
+		if p.Language == "" {
+			p.Language = pkg.LanguageFromPURL(p.PURL)
+		}
+
+		// capture license text for licenses that are not SPDX license IDs (note: this is excluded from package ID, so is safe to mutate)
+		if p.LicenseTexts == nil {
+			p.LicenseTexts = licenseTexts(*p, resolver)
+		}
+
+		// conclude licenses from license files when the package metadata lacks any. Since licenses are part of the
+		// package ID, the ID is derived again from the concluded licenses.
+		reidentify := scheme != "" && scheme != pkg.DefaultIDScheme
+		if len(p.Licenses) == 0 {
+			if concluded := detectLicenses(*p, resolver); len(concluded) > 0 {
+				p.Licenses = concluded
+				reidentify = true
+			}
+		}
+
+		// catalogers derive package IDs with the default scheme, so the ID is derived again for any other scheme
+		if reidentify {
+			previousID := p.ID()
+			p.SetIDWithScheme(scheme)
+			if p.ID() != previousID {
+				reidentified[previousID] = *p
+			}
+		}
+
+		// create file-to-package relationships for files owned by the package
+		owningRelationships, err := packageFileOwnershipRelationships(*p, resolver)
+		if err != nil {
+			log.Warnf("unable to create any package-file relationships for package name=%q: %w", p.Name, err)
+		} else {
+			allRelationships = append(allRelationships, owningRelationships...)
+		}
+	}
+
+	return catalogResult{
+		packages:      packages,
+		relationships: append(allRelationships, reidentifyRelationships(relationships, reidentified)...),
+	}
+}
+
+// reidentifyRelationships updates the relationships found by a cataloger to refer to the packages whose IDs were
+// derived again after cataloging (keyed by the previous package ID).
+func reidentifyRelationships(relationships []artifact.Relationship, reidentified map[artifact.ID]pkg.Package) []artifact.Relationship {
+	if len(reidentified) == 0 {
+		return relationships
+	}
+	for i, r := range relationships {
+		if p, ok := reidentified[r.From.ID()]; ok {
+			relationships[i].From = p
+		}
+		if p, ok := reidentified[r.To.ID()]; ok {
+			relationships[i].To = p
+		}
+	}
+	return relationships
+}
+
 func packageFileOwnershipRelationships(p pkg.Package, resolver source.FilePathResolver) ([]artifact.Relationship, error) {
 	fileOwner, ok := p.Metadata.(pkg.FileOwner)
 	if !ok {
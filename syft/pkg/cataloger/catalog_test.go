@@ -0,0 +1,222 @@
+package cataloger
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/plugin"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ pkg.Cataloger = (*delayedCataloger)(nil)
+
+// delayedCataloger finds a single package (related to the file it was found in) after the given delay.
+type delayedCataloger struct {
+	name  string
+	delay time.Duration
+	err   error
+}
+
+func (c delayedCataloger) Name() string {
+	return c.name
+}
+
+func (c delayedCataloger) Catalog(_ source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	time.Sleep(c.delay)
+	if c.err != nil {
+		return nil, nil, c.err
+	}
+	p := pkg.Package{
+		Name:    c.name,
+		Version: "1.0",
+	}
+	p.SetID()
+	return []pkg.Package{p}, []artifact.Relationship{
+		{
+			From: p,
+			To:   source.NewLocation("/" + c.name).Coordinates,
+			Type: artifact.ContainsRelationship,
+		},
+	}, nil
+}
+
+var _ pkg.Cataloger = (*unlicensedCataloger)(nil)
+
+// unlicensedCataloger finds a single package without any licenses (related to the file it was found in).
+type unlicensedCataloger struct {
+	path string
+}
+
+func (c unlicensedCataloger) Name() string {
+	return "unlicensed-cataloger"
+}
+
+func (c unlicensedCataloger) Catalog(_ source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	p := pkg.Package{
+		Name:         "lib",
+		Version:      "1.0",
+		Locations:    source.NewLocationSet(source.NewLocation(c.path)),
+		MetadataType: pkg.NpmPackageJSONMetadataType,
+	}
+	p.SetID()
+	return []pkg.Package{p}, []artifact.Relationship{
+		{
+			From: p,
+			To:   source.NewLocation(c.path).Coordinates,
+			Type: artifact.ContainsRelationship,
+		},
+	}, nil
+}
+
+func TestCatalog_parallelism(t *testing.T) {
+	// later catalogers finish first, so results would be out of order if added as each task completes
+	var catalogers []pkg.Cataloger
+	var expected []string
+	for i := 0; i < 5; i++ {
+		name := fmt.Sprintf("cataloger-%d", i)
+		catalogers = append(catalogers, delayedCataloger{name: name, delay: time.Duration(5-i) * 10 * time.Millisecond})
+		expected = append(expected, name)
+	}
+
+	for _, parallelism := range []int{0, 1, 3, 10} {
+		t.Run(fmt.Sprintf("parallelism=%d", parallelism), func(t *testing.T) {
+			catalog, relationships, err := Catalog(source.NewMockResolverForPaths(), nil, parallelism, catalogers...)
+			require.NoError(t, err)
+			assert.Equal(t, len(catalogers), catalog.PackageCount())
+
+			var actual []string
+			for _, r := range relationships {
+				actual = append(actual, r.From.(pkg.Package).Name)
+			}
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
+
+func TestCatalog_accumulatesErrors(t *testing.T) {
+	catalogers := []pkg.Cataloger{
+		delayedCataloger{name: "good"},
+		delayedCataloger{name: "bad", err: errors.New("bad cataloger")},
+		delayedCataloger{name: "worse", err: errors.New("worse cataloger")},
+	}
+
+	catalog, _, err := Catalog(source.NewMockResolverForPaths(), nil, 2, catalogers...)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "bad cataloger")
+	assert.Contains(t, err.Error(), "worse cataloger")
+
+	// the results of the other catalogers are kept
+	require.True(t, IsPartialResults(err))
+	require.NotNil(t, catalog)
+	assert.Equal(t, 1, catalog.PackageCount())
+	assert.Len(t, catalog.PackagesByName("good"), 1)
+
+	var partial *PartialResultsError
+	require.ErrorAs(t, err, &partial)
+	require.Len(t, partial.Failures, 2)
+	assert.Equal(t, "bad", partial.Failures[0].Cataloger)
+	assert.Equal(t, "worse", partial.Failures[1].Cataloger)
+}
+
+type panickingCataloger struct{}
+
+func (c panickingCataloger) Name() string {
+	return "panicking"
+}
+
+func (c panickingCataloger) Catalog(_ source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	panic("boom")
+}
+
+func TestCatalog_recoversFromPanics(t *testing.T) {
+	catalog, _, err := Catalog(source.NewMockResolverForPaths(), nil, 1, panickingCataloger{}, delayedCataloger{name: "good"})
+	require.True(t, IsPartialResults(err))
+	assert.Contains(t, err.Error(), `cataloger="panicking" failed: panic: boom`)
+	assert.Equal(t, 1, catalog.PackageCount())
+}
+
+func TestCatalog_concludedLicensesIdentifyPackages(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/license-detection")
+	require.NoError(t, err)
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	catalog, relationships, err := Catalog(resolver, nil, 1, unlicensedCataloger{path: "/packages/lib/package.json"})
+	require.NoError(t, err)
+
+	packages := catalog.Sorted()
+	require.Len(t, packages, 1)
+	p := packages[0]
+	require.NotEmpty(t, p.ConcludedLicenses())
+
+	// the ID is derived from the concluded licenses, as it is for the same package decoded from an SBOM
+	expected := p
+	expected.SetID()
+	assert.Equal(t, expected.ID(), p.ID())
+
+	// the relationships found by the cataloger refer to the package by its final ID
+	require.Len(t, relationships, 1)
+	assert.Equal(t, p.ID(), relationships[0].From.ID())
+}
+
+func TestCatalog_fileEvidence(t *testing.T) {
+	catalog, _, err := Catalog(source.NewMockResolverForPaths(), nil, 1, unlicensedCataloger{path: "/packages/lib/package.json"})
+	require.NoError(t, err)
+
+	packages := catalog.Sorted()
+	require.Len(t, packages, 1)
+
+	// the cataloger did not describe the evidence, so the package was parsed from the files it was discovered by
+	assert.Equal(t, []pkg.Evidence{
+		{
+			Location:   source.Coordinates{RealPath: "/packages/lib/package.json"},
+			Confidence: 1,
+		},
+	}, packages[0].Evidence)
+	assert.Equal(t, pkg.AuthoritativeConfidence, packages[0].Confidence)
+}
+
+func TestCatalog_idScheme(t *testing.T) {
+	opts := Options{Parallelism: 1, IDScheme: pkg.IDSchemeV2}
+	catalog, relationships, err := CatalogWithOptions(source.NewMockResolverForPaths(), nil, opts, delayedCataloger{name: "lib"})
+	require.NoError(t, err)
+
+	packages := catalog.Sorted()
+	require.Len(t, packages, 1)
+	p := packages[0]
+
+	expected := p
+	expected.SetIDWithScheme(pkg.IDSchemeV2)
+	assert.Equal(t, expected.ID(), p.ID())
+	expected.SetID()
+	assert.NotEqual(t, expected.ID(), p.ID())
+
+	// the relationships found by the cataloger refer to the package by the ID of the scheme
+	require.Len(t, relationships, 1)
+	assert.Equal(t, p.ID(), relationships[0].From.ID())
+}
+
+func TestCatalog_pluginPackagesWithoutIdentifiers(t *testing.T) {
+	c, err := plugin.NewCataloger("plugin/test-fixtures/extra/syft-cataloger-bare", plugin.DefaultConfig())
+	require.NoError(t, err)
+
+	catalog, _, err := Catalog(source.NewMockResolverForPaths("plugin/test-fixtures/acme/packages.lock"), nil, 1, c)
+	require.NoError(t, err)
+
+	packages := catalog.Sorted()
+	require.Len(t, packages, 1)
+	// the pURL and CPEs that the plugin does not describe are generated like those of any other package
+	assert.Equal(t, "pkg:generic/acme-agent@2.4.1", packages[0].PURL)
+	var cpes []string
+	for _, value := range packages[0].CPEs {
+		cpes = append(cpes, pkg.CPEString(value))
+	}
+	assert.Contains(t, cpes, "cpe:2.3:a:acme_agent:acme_agent:2.4.1:*:*:*:*:*:*:*")
+}
@@ -10,101 +10,23 @@ import (
 
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/pkg"
-	"github.com/anchore/syft/syft/pkg/cataloger/alpm"
-	"github.com/anchore/syft/syft/pkg/cataloger/apkdb"
-	"github.com/anchore/syft/syft/pkg/cataloger/cpp"
-	"github.com/anchore/syft/syft/pkg/cataloger/dart"
-	"github.com/anchore/syft/syft/pkg/cataloger/deb"
-	"github.com/anchore/syft/syft/pkg/cataloger/dotnet"
-	"github.com/anchore/syft/syft/pkg/cataloger/golang"
-	"github.com/anchore/syft/syft/pkg/cataloger/haskell"
-	"github.com/anchore/syft/syft/pkg/cataloger/java"
-	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
-	"github.com/anchore/syft/syft/pkg/cataloger/php"
-	"github.com/anchore/syft/syft/pkg/cataloger/portage"
-	"github.com/anchore/syft/syft/pkg/cataloger/python"
-	"github.com/anchore/syft/syft/pkg/cataloger/rpm"
-	"github.com/anchore/syft/syft/pkg/cataloger/ruby"
-	"github.com/anchore/syft/syft/pkg/cataloger/rust"
-	"github.com/anchore/syft/syft/pkg/cataloger/swift"
 )
 
 const AllCatalogersPattern = "all"
 
 // ImageCatalogers returns a slice of locally implemented catalogers that are fit for detecting installations of packages.
 func ImageCatalogers(cfg Config) []pkg.Cataloger {
-	return filterCatalogers([]pkg.Cataloger{
-		alpm.NewAlpmdbCataloger(),
-		ruby.NewGemSpecCataloger(),
-		python.NewPythonPackageCataloger(),
-		php.NewPHPComposerInstalledCataloger(),
-		javascript.NewJavascriptPackageCataloger(),
-		deb.NewDpkgdbCataloger(),
-		rpm.NewRpmdbCataloger(),
-		java.NewJavaCataloger(cfg.Java()),
-		apkdb.NewApkdbCataloger(),
-		golang.NewGoModuleBinaryCataloger(),
-		dotnet.NewDotnetDepsCataloger(),
-		portage.NewPortageCataloger(),
-	}, cfg.Catalogers)
+	return filterCatalogers(taggedCatalogers(cfg, ImageTag), cfg.Catalogers)
 }
 
 // DirectoryCatalogers returns a slice of locally implemented catalogers that are fit for detecting packages from index files (and select installations)
 func DirectoryCatalogers(cfg Config) []pkg.Cataloger {
-	return filterCatalogers([]pkg.Cataloger{
-		alpm.NewAlpmdbCataloger(),
-		ruby.NewGemFileLockCataloger(),
-		python.NewPythonIndexCataloger(),
-		python.NewPythonPackageCataloger(),
-		php.NewPHPComposerLockCataloger(),
-		javascript.NewJavascriptLockCataloger(),
-		deb.NewDpkgdbCataloger(),
-		rpm.NewRpmdbCataloger(),
-		rpm.NewFileCataloger(),
-		java.NewJavaCataloger(cfg.Java()),
-		java.NewJavaPomCataloger(),
-		apkdb.NewApkdbCataloger(),
-		golang.NewGoModuleBinaryCataloger(),
-		golang.NewGoModFileCataloger(),
-		rust.NewCargoLockCataloger(),
-		dart.NewPubspecLockCataloger(),
-		dotnet.NewDotnetDepsCataloger(),
-		swift.NewCocoapodsCataloger(),
-		cpp.NewConanCataloger(),
-		portage.NewPortageCataloger(),
-		haskell.NewHackageCataloger(),
-	}, cfg.Catalogers)
+	return filterCatalogers(taggedCatalogers(cfg, DirectoryTag), cfg.Catalogers)
 }
 
 // AllCatalogers returns all implemented catalogers
 func AllCatalogers(cfg Config) []pkg.Cataloger {
-	return filterCatalogers([]pkg.Cataloger{
-		alpm.NewAlpmdbCataloger(),
-		ruby.NewGemFileLockCataloger(),
-		ruby.NewGemSpecCataloger(),
-		python.NewPythonIndexCataloger(),
-		python.NewPythonPackageCataloger(),
-		javascript.NewJavascriptLockCataloger(),
-		javascript.NewJavascriptPackageCataloger(),
-		deb.NewDpkgdbCataloger(),
-		rpm.NewRpmdbCataloger(),
-		rpm.NewFileCataloger(),
-		java.NewJavaCataloger(cfg.Java()),
-		java.NewJavaPomCataloger(),
-		apkdb.NewApkdbCataloger(),
-		golang.NewGoModuleBinaryCataloger(),
-		golang.NewGoModFileCataloger(),
-		rust.NewCargoLockCataloger(),
-		rust.NewRustAuditBinaryCataloger(),
-		dart.NewPubspecLockCataloger(),
-		dotnet.NewDotnetDepsCataloger(),
-		php.NewPHPComposerInstalledCataloger(),
-		php.NewPHPComposerLockCataloger(),
-		swift.NewCocoapodsCataloger(),
-		cpp.NewConanCataloger(),
-		portage.NewPortageCataloger(),
-		haskell.NewHackageCataloger(),
-	}, cfg.Catalogers)
+	return filterCatalogers(taggedCatalogers(cfg, AllCatalogersPattern), cfg.Catalogers)
 }
 
 func RequestedAllCatalogers(cfg Config) bool {
@@ -0,0 +1,164 @@
+package cataloger
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// CatalogerStatus is the state of a single cataloger within a Catalog() call.
+type CatalogerStatus string
+
+const (
+	// CatalogerPending is the status of a cataloger that has not started yet
+	CatalogerPending CatalogerStatus = "pending"
+	// CatalogerRunning is the status of a cataloger that is looking for packages
+	CatalogerRunning CatalogerStatus = "running"
+	// CatalogerFinished is the status of a cataloger that found all of its packages
+	CatalogerFinished CatalogerStatus = "finished"
+	// CatalogerFailed is the status of a cataloger that failed (or did not finish before cataloging timed out), whose
+	// packages are missing from the results
+	CatalogerFailed CatalogerStatus = "failed"
+)
+
+// CatalogerState describes the progress of a single cataloger within a Catalog() call.
+type CatalogerState struct {
+	// Name is the name of the cataloger
+	Name string
+	// Status is the state of the cataloger
+	Status CatalogerStatus
+	// Packages is the number of packages found by the cataloger (once finished)
+	Packages int
+	// FilesRead is the number of files read by the cataloger so far
+	FilesRead int64
+	// Duration is how long the cataloger has been running (or ran for)
+	Duration time.Duration
+	// Error describes why the cataloger failed
+	Error string
+}
+
+// CatalogerStates provides the state of each cataloger run by a Catalog() call (in the order of the given catalogers).
+type CatalogerStates interface {
+	States() []CatalogerState
+}
+
+// catalogerProgress tracks the state of each cataloger run by a Catalog() call, which is safe to read while the
+// catalogers are running. As a progress.Monitorable, it reports the number of files read by all catalogers.
+type catalogerProgress struct {
+	lock      sync.RWMutex
+	states    []CatalogerState
+	started   []time.Time
+	resolvers []*measuringResolver
+	completed bool
+}
+
+var _ progress.Monitorable = (*catalogerProgress)(nil)
+
+func newCatalogerProgress(catalogers []pkg.Cataloger) *catalogerProgress {
+	p := &catalogerProgress{
+		states:    make([]CatalogerState, len(catalogers)),
+		started:   make([]time.Time, len(catalogers)),
+		resolvers: make([]*measuringResolver, len(catalogers)),
+	}
+	for idx, c := range catalogers {
+		p.states[idx] = CatalogerState{Name: c.Name(), Status: CatalogerPending}
+	}
+	return p
+}
+
+// start marks the cataloger at the given index as running, returning the resolver that the cataloger should use so
+// that the files it reads are counted.
+func (p *catalogerProgress) start(idx int, resolver source.FileResolver) source.FileResolver {
+	counted := &measuringResolver{FileResolver: resolver}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.states[idx].Status = CatalogerRunning
+	p.started[idx] = time.Now()
+	p.resolvers[idx] = counted
+	return counted
+}
+
+// finish records the result of the cataloger at the given index, returning the final state of the cataloger.
+func (p *catalogerProgress) finish(idx int, result catalogResult) CatalogerState {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	state := &p.states[idx]
+	state.Duration = time.Since(p.started[idx])
+	state.FilesRead = p.filesRead(idx)
+	if result.err != nil {
+		state.Status = CatalogerFailed
+		state.Error = result.err.Error()
+		return *state
+	}
+	state.Status = CatalogerFinished
+	state.Packages = len(result.packages)
+	return *state
+}
+
+// fail records that the cataloger at the given index did not finish (e.g. cataloging timed out).
+func (p *catalogerProgress) fail(idx int, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	state := &p.states[idx]
+	if state.Status == CatalogerRunning {
+		state.Duration = time.Since(p.started[idx])
+		state.FilesRead = p.filesRead(idx)
+	}
+	state.Status = CatalogerFailed
+	state.Error = err.Error()
+}
+
+// setCompleted marks the end of the Catalog() call (no more files will be read).
+func (p *catalogerProgress) setCompleted() {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.completed = true
+}
+
+func (p *catalogerProgress) filesRead(idx int) int64 {
+	if p.resolvers[idx] == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&p.resolvers[idx].files)
+}
+
+// States returns the state of each cataloger (the files read and duration of running catalogers are as of now).
+func (p *catalogerProgress) States() []CatalogerState {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	states := make([]CatalogerState, len(p.states))
+	for idx, state := range p.states {
+		if state.Status == CatalogerRunning {
+			state.Duration = time.Since(p.started[idx])
+			state.FilesRead = p.filesRead(idx)
+		}
+		states[idx] = state
+	}
+	return states
+}
+
+// Current returns the number of files read by all catalogers so far.
+func (p *catalogerProgress) Current() int64 {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	var files int64
+	for idx := range p.resolvers {
+		files += p.filesRead(idx)
+	}
+	return files
+}
+
+func (p *catalogerProgress) Error() error {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	if p.completed {
+		return progress.ErrCompleted
+	}
+	return nil
+}
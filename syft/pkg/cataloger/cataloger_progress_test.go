@@ -0,0 +1,50 @@
+package cataloger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-progress"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestCatalogerProgress(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "a.pkg")
+	require.NoError(t, os.WriteFile(path, []byte("contents"), 0644))
+
+	p := newCatalogerProgress([]pkg.Cataloger{readingCataloger{}, delayedCataloger{name: "bad"}, delayedCataloger{name: "slow"}})
+	states := p.States()
+	require.Len(t, states, 3)
+	for _, s := range states {
+		assert.Equal(t, CatalogerPending, s.Status)
+	}
+
+	// files read through the resolver given to a cataloger are counted as the cataloger runs
+	resolver := p.start(0, source.NewMockResolverForPaths(path))
+	assert.Equal(t, CatalogerRunning, p.States()[0].Status)
+	packages, _, err := readingCataloger{}.Catalog(resolver)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), p.States()[0].FilesRead)
+	assert.Equal(t, int64(1), p.Current())
+
+	p.finish(0, catalogResult{packages: packages})
+	p.start(1, source.NewMockResolverForPaths())
+	p.finish(1, catalogResult{err: errors.New("bad cataloger")})
+	p.fail(2, errTimedOut)
+	assert.NoError(t, p.Error())
+	p.setCompleted()
+	assert.True(t, progress.IsErrCompleted(p.Error()))
+
+	states = p.States()
+	assert.Equal(t, CatalogerState{Name: "reading-cataloger", Status: CatalogerFinished, Packages: 1, FilesRead: 1, Duration: states[0].Duration}, states[0])
+	assert.Equal(t, CatalogerFailed, states[1].Status)
+	assert.Equal(t, "bad cataloger", states[1].Error)
+	assert.Equal(t, CatalogerState{Name: "slow", Status: CatalogerFailed, Error: errTimedOut.Error()}, states[2])
+}
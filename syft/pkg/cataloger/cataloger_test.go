@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
@@ -201,3 +202,119 @@ func Test_contains(t *testing.T) {
 		})
 	}
 }
+
+func TestSelectCatalogers(t *testing.T) {
+	tests := []struct {
+		name       string
+		defaultTag string
+		selection  []string
+		contains   []string
+		excludes   []string
+		wantErr    require.ErrorAssertionFunc
+	}{
+		{
+			name:       "default image catalogers",
+			defaultTag: ImageTag,
+			contains:   []string{"dpkgdb-cataloger", "python-package-cataloger", "binary-cataloger"},
+			excludes:   []string{"python-index-cataloger", "go-mod-file-cataloger"},
+		},
+		{
+			name:       "add and remove from the default selection",
+			defaultTag: ImageTag,
+			selection:  []string{"+go-mod-file,-binary"},
+			contains:   []string{"dpkgdb-cataloger", "go-mod-file-cataloger"},
+			excludes:   []string{"binary-cataloger", "static-library-cataloger", "go-module-binary-cataloger"},
+		},
+		{
+			name:       "replace the default selection",
+			defaultTag: ImageTag,
+			selection:  []string{"os", "python-index"},
+			contains:   []string{"dpkgdb-cataloger", "rpm-file-cataloger", "python-index-cataloger"},
+			excludes:   []string{"python-package-cataloger", "binary-cataloger"},
+		},
+		{
+			name:       "operations are applied in order",
+			defaultTag: DirectoryTag,
+			selection:  []string{"-python", "+python-package-cataloger"},
+			contains:   []string{"python-package-cataloger"},
+			excludes:   []string{"python-index-cataloger"},
+		},
+		{
+			name:       "unknown selection",
+			defaultTag: ImageTag,
+			selection:  []string{"+bogus"},
+			wantErr:    require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			cfg := DefaultConfig()
+			cfg.Select = test.selection
+
+			catalogers, err := SelectCatalogers(cfg, test.defaultTag)
+			test.wantErr(t, err)
+			if err != nil {
+				return
+			}
+
+			var names []string
+			for _, c := range catalogers {
+				names = append(names, c.Name())
+			}
+			for _, name := range test.contains {
+				assert.Contains(t, names, name)
+			}
+			for _, name := range test.excludes {
+				assert.NotContains(t, names, name)
+			}
+		})
+	}
+}
+
+func TestImageCatalogers_matchesTaggedSelection(t *testing.T) {
+	selected, err := SelectCatalogers(DefaultConfig(), ImageTag)
+	require.NoError(t, err)
+	assert.Equal(t, len(ImageCatalogers(DefaultConfig())), len(selected))
+}
+
+func TestRegister(t *testing.T) {
+	t.Cleanup(func() {
+		registry.catalogers = nil
+	})
+
+	Register(dummy{name: "acme-cataloger"}, "acme")
+	Register(dummy{name: "acme-cataloger"}, "acme", LanguageTag)
+
+	cfg := DefaultConfig()
+	cfg.Additional = []pkg.Cataloger{dummy{name: "other-cataloger"}}
+
+	names := func(catalogers []pkg.Cataloger) []string {
+		var results []string
+		for _, c := range catalogers {
+			results = append(results, c.Name())
+		}
+		return results
+	}
+
+	catalogers, err := SelectCatalogers(cfg, ImageTag)
+	require.NoError(t, err)
+	assert.Subset(t, names(catalogers), []string{"acme-cataloger", "other-cataloger"})
+
+	cfg.Select = []string{"custom"}
+	catalogers, err = SelectCatalogers(cfg, ImageTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme-cataloger", "other-cataloger"}, names(catalogers))
+
+	cfg.Select = []string{"acme"}
+	catalogers, err = SelectCatalogers(cfg, ImageTag)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"acme-cataloger"}, names(catalogers))
+
+	cfg.Select = []string{"-acme"}
+	catalogers, err = SelectCatalogers(cfg, ImageTag)
+	require.NoError(t, err)
+	assert.NotContains(t, names(catalogers), "acme-cataloger")
+}
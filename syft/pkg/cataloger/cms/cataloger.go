@@ -0,0 +1,29 @@
+/*
+Package cms provides concrete Cataloger implementations for the plugins, themes, and modules installed within content
+management systems (WordPress and Drupal).
+*/
+package cms
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const (
+	wordpressCatalogerName = "wordpress-cataloger"
+	drupalCatalogerName    = "drupal-cataloger"
+)
+
+// NewWordpressCataloger returns a new cataloger object for the plugins (including must-use plugins) and themes
+// installed within the wp-content directory of WordPress sites.
+func NewWordpressCataloger() *generic.Cataloger {
+	return generic.NewCataloger(wordpressCatalogerName).
+		WithParserByGlobs(parseWordpressPlugin, "**/wp-content/plugins/*.php", "**/wp-content/plugins/*/*.php", "**/wp-content/mu-plugins/*.php").
+		WithParserByGlobs(parseWordpressTheme, "**/wp-content/themes/*/style.css")
+}
+
+// NewDrupalCataloger returns a new cataloger object for the modules, themes, and installation profiles of Drupal sites
+// (including the core modules and themes), as described by their .info.yml files.
+func NewDrupalCataloger() *generic.Cataloger {
+	return generic.NewCataloger(drupalCatalogerName).
+		WithParserByGlobs(parseDrupalInfo, "**/*.info.yml")
+}
@@ -0,0 +1,69 @@
+package cms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestWordpressCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/wordpress/wp-content/mu-plugins/site-tweaks.php",
+		"test-fixtures/wordpress/wp-content/plugins/akismet/akismet.php",
+		"test-fixtures/wordpress/wp-content/plugins/akismet/index.php",
+		"test-fixtures/wordpress/wp-content/plugins/hello.php",
+		"test-fixtures/wordpress/wp-content/plugins/index.php",
+		"test-fixtures/wordpress/wp-content/plugins/woocommerce/includes/class-woocommerce.php",
+		"test-fixtures/wordpress/wp-content/plugins/woocommerce/woocommerce.php",
+		"test-fixtures/wordpress/wp-content/themes/storefront-child/style.css",
+		"test-fixtures/wordpress/wp-content/themes/twentytwentyfour/style.css",
+	)
+
+	pkgs, _, err := NewWordpressCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	var found []string
+	for _, p := range pkgs {
+		assert.Equal(t, wordpressCatalogerName, p.FoundBy)
+		found = append(found, string(p.Type)+":"+p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{
+		"wordpress-plugin:site-tweaks@1.0.0",
+		"wordpress-plugin:akismet@5.3",
+		"wordpress-plugin:hello@1.7.2",
+		"wordpress-plugin:woocommerce@8.2.1",
+		"wordpress-theme:storefront-child@1.0.0",
+		"wordpress-theme:twentytwentyfour@1.0",
+	}, found)
+}
+
+func TestDrupalCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/drupal/core/modules/views/tests/modules/views_test_config/views_test_config.info.yml",
+		"test-fixtures/drupal/core/modules/views/views.info.yml",
+		"test-fixtures/drupal/core/themes/stable9/stable9.info.yml",
+		"test-fixtures/drupal/modules/contrib/ctools/ctools.info.yml",
+		"test-fixtures/drupal/modules/contrib/ctools/modules/ctools_views/ctools_views.info.yml",
+		"test-fixtures/drupal/themes/custom/example/example.info.yml",
+		"test-fixtures/drupal/themes/custom/example/example.libraries.yml",
+	)
+
+	pkgs, _, err := NewDrupalCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	var found []string
+	for _, p := range pkgs {
+		assert.Equal(t, drupalCatalogerName, p.FoundBy)
+		found = append(found, string(p.Type)+":"+p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{
+		"drupal-module:views@10.1.6",
+		"drupal-module:ctools@4.0.4",
+		"drupal-module:ctools_views@4.0.4",
+		"drupal-theme:stable9@",
+		"drupal-theme:example@",
+	}, found)
+}
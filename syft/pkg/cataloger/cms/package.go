@@ -0,0 +1,44 @@
+package cms
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+// newWordpressPackage creates a package for a WordPress plugin or theme, which is named by its slug (e.g. "akismet").
+func newWordpressPackage(t pkg.Type, metadata pkg.WordpressMetadata, version, license string, location source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         metadata.Slug,
+		Version:      version,
+		Licenses:     pkg.NewLicensesFromLocation(location, license),
+		Locations:    source.NewLocationSet(location),
+		Type:         t,
+		Language:     pkg.PHP,
+		PURL:         purl.New(purl.TypeGeneric, "", metadata.Slug, version, nil, ""),
+		MetadataType: pkg.WordpressMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// newDrupalPackage creates a package for a Drupal module or theme, which is named by its machine name (e.g. "views").
+func newDrupalPackage(t pkg.Type, name, version string, metadata pkg.DrupalMetadata, location source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(location),
+		Type:         t,
+		Language:     pkg.PHP,
+		PURL:         purl.New(purl.TypeGeneric, "", name, version, nil, ""),
+		MetadataType: pkg.DrupalMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
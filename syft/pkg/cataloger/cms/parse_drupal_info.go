@@ -0,0 +1,92 @@
+package cms
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseDrupalInfo
+
+const (
+	drupalInfoSuffix = ".info.yml"
+
+	// drupalVersionPlaceholder is the version of the core modules and themes within a development checkout of Drupal,
+	// which is replaced by the version of the release when packaged
+	drupalVersionPlaceholder = "VERSION"
+
+	// drupalTestingPackage is the package of the modules and themes used by the tests of Drupal and contributed projects
+	drupalTestingPackage = "Testing"
+)
+
+// drupalInfo is the .info.yml file of a Drupal module, theme, or installation profile (see
+// https://www.drupal.org/docs/develop/creating-modules/let-drupal-know-about-your-module-with-an-infoyml-file).
+type drupalInfo struct {
+	Name                   string   `yaml:"name"`
+	Type                   string   `yaml:"type"` // one of "module", "theme", "profile", or "theme_engine"
+	Package                string   `yaml:"package"`
+	CoreVersionRequirement string   `yaml:"core_version_requirement"`
+	BaseTheme              string   `yaml:"base theme"`
+	Dependencies           []string `yaml:"dependencies"`
+	// added by the drupal.org packaging script
+	Version string `yaml:"version"`
+	Project string `yaml:"project"`
+}
+
+// parseDrupalInfo parses the .info.yml file of a Drupal module or theme (installation profiles are modules), skipping
+// the modules and themes used by tests.
+func parseDrupalInfo(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var info drupalInfo
+	if err := yaml.NewDecoder(reader).Decode(&info); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Drupal .info.yml file: %w", err)
+	}
+
+	var t pkg.Type
+	switch info.Type {
+	case "module", "profile":
+		t = pkg.DrupalModulePkg
+	case "theme":
+		t = pkg.DrupalThemePkg
+	default:
+		log.WithFields("path", reader.RealPath).Trace("not the .info.yml file of a Drupal module or theme")
+		return nil, nil, nil
+	}
+	if info.Name == "" || info.Package == drupalTestingPackage || strings.Contains(reader.RealPath, "/tests/") {
+		return nil, nil, nil
+	}
+
+	version := info.Version
+	if version == drupalVersionPlaceholder {
+		version = ""
+	}
+	baseTheme := info.BaseTheme
+	if baseTheme == "false" {
+		// the theme is not based on another theme
+		baseTheme = ""
+	}
+
+	return []pkg.Package{
+		newDrupalPackage(
+			t,
+			strings.TrimSuffix(path.Base(reader.RealPath), drupalInfoSuffix),
+			version,
+			pkg.DrupalMetadata{
+				Name:                   info.Name,
+				Project:                info.Project,
+				Package:                info.Package,
+				CoreVersionRequirement: info.CoreVersionRequirement,
+				BaseTheme:              baseTheme,
+				Dependencies:           info.Dependencies,
+			},
+			reader.Location,
+		),
+	}, nil, nil
+}
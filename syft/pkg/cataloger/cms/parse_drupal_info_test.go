@@ -0,0 +1,107 @@
+package cms
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseDrupalInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			name:    "packaged core module",
+			fixture: "test-fixtures/drupal/core/modules/views/views.info.yml",
+			expected: []pkg.Package{
+				{
+					Name:         "views",
+					Version:      "10.1.6",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/drupal/core/modules/views/views.info.yml")),
+					Type:         pkg.DrupalModulePkg,
+					Language:     pkg.PHP,
+					PURL:         "pkg:generic/views@10.1.6",
+					MetadataType: pkg.DrupalMetadataType,
+					Metadata: pkg.DrupalMetadata{
+						Name:         "Views",
+						Project:      "drupal",
+						Package:      "Core",
+						Dependencies: []string{"drupal:filter"},
+					},
+				},
+			},
+		},
+		{
+			name:    "contributed submodule",
+			fixture: "test-fixtures/drupal/modules/contrib/ctools/modules/ctools_views/ctools_views.info.yml",
+			expected: []pkg.Package{
+				{
+					Name:         "ctools_views",
+					Version:      "4.0.4",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/drupal/modules/contrib/ctools/modules/ctools_views/ctools_views.info.yml")),
+					Type:         pkg.DrupalModulePkg,
+					Language:     pkg.PHP,
+					PURL:         "pkg:generic/ctools_views@4.0.4",
+					MetadataType: pkg.DrupalMetadataType,
+					Metadata: pkg.DrupalMetadata{
+						Name:                   "Chaos Tools Views",
+						Project:                "ctools",
+						Package:                "Chaos tool suite",
+						CoreVersionRequirement: "^9.3 || ^10",
+						Dependencies:           []string{"ctools:ctools", "drupal:views (>=10.0)"},
+					},
+				},
+			},
+		},
+		{
+			name:    "unpackaged theme without a base theme",
+			fixture: "test-fixtures/drupal/core/themes/stable9/stable9.info.yml",
+			expected: []pkg.Package{
+				{
+					Name:         "stable9",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/drupal/core/themes/stable9/stable9.info.yml")),
+					Type:         pkg.DrupalThemePkg,
+					Language:     pkg.PHP,
+					PURL:         "pkg:generic/stable9",
+					MetadataType: pkg.DrupalMetadataType,
+					Metadata: pkg.DrupalMetadata{
+						Name:    "Stable 9",
+						Package: "Core",
+					},
+				},
+			},
+		},
+		{
+			name:    "custom sub-theme",
+			fixture: "test-fixtures/drupal/themes/custom/example/example.info.yml",
+			expected: []pkg.Package{
+				{
+					Name:         "example",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/drupal/themes/custom/example/example.info.yml")),
+					Type:         pkg.DrupalThemePkg,
+					Language:     pkg.PHP,
+					PURL:         "pkg:generic/example",
+					MetadataType: pkg.DrupalMetadataType,
+					Metadata: pkg.DrupalMetadata{
+						Name:                   "Example",
+						CoreVersionRequirement: "^10",
+						BaseTheme:              "olivero",
+					},
+				},
+			},
+		},
+		{
+			name:    "test module",
+			fixture: "test-fixtures/drupal/core/modules/views/tests/modules/views_test_config/views_test_config.info.yml",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.TestFileParser(t, test.fixture, parseDrupalInfo, test.expected, nil)
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package cms
+
+import (
+	"bufio"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var (
+	_ generic.Parser = parseWordpressPlugin
+	_ generic.Parser = parseWordpressTheme
+)
+
+// wordpressHeaderSize is the number of bytes of a file that WordPress reads the header from
+const wordpressHeaderSize = 8 * 1024
+
+const (
+	pluginsDir        = "plugins"
+	mustUsePluginsDir = "mu-plugins"
+)
+
+var (
+	// wordpressHeaderPattern matches a single field of a file header, within a comment (e.g. " * Version: 5.3")
+	wordpressHeaderPattern = regexp.MustCompile(`^(?:[ \t]*<\?php)?[ \t/*#@]*([A-Za-z][A-Za-z ]*?)[ \t]*:(.*)$`)
+
+	// wordpressHeaderEndPattern matches the end of the comment (or PHP code) after the value of a header field
+	wordpressHeaderEndPattern = regexp.MustCompile(`\s*(?:\*/|\?>).*`)
+)
+
+// parseWordpressPlugin parses the header of a PHP file within the plugins directory, returning the plugin when the file
+// is the main file of a plugin (with a "Plugin Name" header).
+func parseWordpressPlugin(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	headers, err := parseWordpressHeaders(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := headers["plugin name"]
+	if name == "" {
+		return nil, nil, nil
+	}
+
+	// a plugin is either a single file directly within the plugins directory, or a directory with the main file
+	slug := path.Base(path.Dir(reader.RealPath))
+	mustUse := slug == mustUsePluginsDir
+	if mustUse || slug == pluginsDir {
+		slug = strings.TrimSuffix(path.Base(reader.RealPath), path.Ext(reader.RealPath))
+	}
+
+	return []pkg.Package{
+		newWordpressPackage(
+			pkg.WordpressPluginPkg,
+			pkg.WordpressMetadata{
+				Slug:        slug,
+				Name:        name,
+				URI:         headers["plugin uri"],
+				Author:      headers["author"],
+				AuthorURI:   headers["author uri"],
+				RequiresWP:  headers["requires at least"],
+				RequiresPHP: headers["requires php"],
+				MustUse:     mustUse,
+			},
+			headers["version"],
+			headers["license"],
+			reader.Location,
+		),
+	}, nil, nil
+}
+
+// parseWordpressTheme parses the header of the style.css file of a theme.
+func parseWordpressTheme(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	headers, err := parseWordpressHeaders(reader)
+	if err != nil {
+		return nil, nil, err
+	}
+	name := headers["theme name"]
+	if name == "" {
+		return nil, nil, nil
+	}
+
+	return []pkg.Package{
+		newWordpressPackage(
+			pkg.WordpressThemePkg,
+			pkg.WordpressMetadata{
+				Slug:        path.Base(path.Dir(reader.RealPath)),
+				Name:        name,
+				URI:         headers["theme uri"],
+				Author:      headers["author"],
+				AuthorURI:   headers["author uri"],
+				RequiresWP:  headers["requires at least"],
+				RequiresPHP: headers["requires php"],
+				Template:    headers["template"],
+			},
+			headers["version"],
+			headers["license"],
+			reader.Location,
+		),
+	}, nil, nil
+}
+
+// parseWordpressHeaders returns the fields of the file header (keyed by the lowercase field name) from the beginning of
+// the file, as read by WordPress (see get_file_data()), where the first occurrence of a field is used.
+func parseWordpressHeaders(reader io.Reader) (map[string]string, error) {
+	headers := make(map[string]string)
+	scanner := bufio.NewScanner(io.LimitReader(reader, wordpressHeaderSize))
+	for scanner.Scan() {
+		match := wordpressHeaderPattern.FindStringSubmatch(scanner.Text())
+		if match == nil {
+			continue
+		}
+		key := strings.ToLower(match[1])
+		if _, exists := headers[key]; exists {
+			continue
+		}
+		value := strings.TrimSpace(wordpressHeaderEndPattern.ReplaceAllString(match[2], ""))
+		if value == "" {
+			continue
+		}
+		headers[key] = value
+	}
+	return headers, scanner.Err()
+}
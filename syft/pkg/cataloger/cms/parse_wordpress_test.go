@@ -0,0 +1,173 @@
+package cms
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseWordpressPlugin(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			name:    "plugin directory",
+			fixture: "test-fixtures/wordpress/wp-content/plugins/akismet/akismet.php",
+			expected: []pkg.Package{
+				{
+					Name:    "akismet",
+					Version: "5.3",
+					Licenses: []pkg.License{
+						pkg.NewLicenseFromLocation("GPLv2 or later", source.NewLocation("test-fixtures/wordpress/wp-content/plugins/akismet/akismet.php")),
+					},
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/wordpress/wp-content/plugins/akismet/akismet.php")),
+					Type:         pkg.WordpressPluginPkg,
+					Language:     pkg.PHP,
+					PURL:         "pkg:generic/akismet@5.3",
+					MetadataType: pkg.WordpressMetadataType,
+					Metadata: pkg.WordpressMetadata{
+						Slug:        "akismet",
+						Name:        "Akismet Anti-spam: Spam Protection",
+						URI:         "https://akismet.com/",
+						Author:      "Automattic - Anti-spam Team",
+						AuthorURI:   "https://automattic.com/wordpress-plugins/",
+						RequiresWP:  "5.8",
+						RequiresPHP: "5.6.20",
+					},
+				},
+			},
+		},
+		{
+			name:    "single file plugin",
+			fixture: "test-fixtures/wordpress/wp-content/plugins/hello.php",
+			expected: []pkg.Package{
+				{
+					Name:         "hello",
+					Version:      "1.7.2",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/wordpress/wp-content/plugins/hello.php")),
+					Type:         pkg.WordpressPluginPkg,
+					Language:     pkg.PHP,
+					PURL:         "pkg:generic/hello@1.7.2",
+					MetadataType: pkg.WordpressMetadataType,
+					Metadata: pkg.WordpressMetadata{
+						Slug:      "hello",
+						Name:      "Hello Dolly",
+						URI:       "http://wordpress.org/plugins/hello-dolly/",
+						Author:    "Matt Mullenweg",
+						AuthorURI: "http://ma.tt/",
+					},
+				},
+			},
+		},
+		{
+			name:    "must-use plugin",
+			fixture: "test-fixtures/wordpress/wp-content/mu-plugins/site-tweaks.php",
+			expected: []pkg.Package{
+				{
+					Name:         "site-tweaks",
+					Version:      "1.0.0",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/wordpress/wp-content/mu-plugins/site-tweaks.php")),
+					Type:         pkg.WordpressPluginPkg,
+					Language:     pkg.PHP,
+					PURL:         "pkg:generic/site-tweaks@1.0.0",
+					MetadataType: pkg.WordpressMetadataType,
+					Metadata: pkg.WordpressMetadata{
+						Slug:    "site-tweaks",
+						Name:    "Site Tweaks",
+						Author:  "Example, Inc.",
+						MustUse: true,
+					},
+				},
+			},
+		},
+		{
+			name:    "not the main plugin file",
+			fixture: "test-fixtures/wordpress/wp-content/plugins/akismet/index.php",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.TestFileParser(t, test.fixture, parseWordpressPlugin, test.expected, nil)
+		})
+	}
+}
+
+func TestParseWordpressTheme(t *testing.T) {
+	fixture := "test-fixtures/wordpress/wp-content/themes/twentytwentyfour/style.css"
+	expected := []pkg.Package{
+		{
+			Name:    "twentytwentyfour",
+			Version: "1.0",
+			Licenses: []pkg.License{
+				pkg.NewLicenseFromLocation("GNU General Public License v2 or later", source.NewLocation(fixture)),
+			},
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Type:         pkg.WordpressThemePkg,
+			Language:     pkg.PHP,
+			PURL:         "pkg:generic/twentytwentyfour@1.0",
+			MetadataType: pkg.WordpressMetadataType,
+			Metadata: pkg.WordpressMetadata{
+				Slug:        "twentytwentyfour",
+				Name:        "Twenty Twenty-Four",
+				URI:         "https://wordpress.org/themes/twentytwentyfour/",
+				Author:      "the WordPress team",
+				AuthorURI:   "https://wordpress.org",
+				RequiresWP:  "6.4",
+				RequiresPHP: "7.0",
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseWordpressTheme, expected, nil)
+}
+
+func Test_parseWordpressHeaders(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected map[string]string
+	}{
+		{
+			name:  "php doc comment",
+			input: "<?php\n/**\n * Plugin Name: Example\n * Version: 1.0\n */\n",
+			expected: map[string]string{
+				"plugin name": "Example",
+				"version":     "1.0",
+			},
+		},
+		{
+			name:  "header on the opening tag line",
+			input: "<?php // Plugin Name: Example ?>\n",
+			expected: map[string]string{
+				"plugin name": "Example",
+			},
+		},
+		{
+			name:  "first occurrence is used",
+			input: "/*\nVersion: 1.0\nversion: 2.0\n*/\n",
+			expected: map[string]string{
+				"version": "1.0",
+			},
+		},
+		{
+			name:     "header beyond the first 8KB is ignored",
+			input:    "<?php\n" + strings.Repeat("// padding\n", 1024) + "/* Plugin Name: Example */\n",
+			expected: map[string]string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			headers, err := parseWordpressHeaders(strings.NewReader(test.input))
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, headers)
+		})
+	}
+}
@@ -0,0 +1,6 @@
+<?php
+/**
+ * Plugin Name: Site Tweaks
+ * Description: Site specific tweaks that must always be enabled.
+ * Version: 1.0.0
+ * Author: Example, Inc. */
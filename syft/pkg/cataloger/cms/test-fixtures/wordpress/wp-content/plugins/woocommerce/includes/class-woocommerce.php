@@ -0,0 +1,7 @@
+<?php
+/**
+ * WooCommerce setup
+ *
+ * @package WooCommerce
+ * @since   3.2.0
+ */
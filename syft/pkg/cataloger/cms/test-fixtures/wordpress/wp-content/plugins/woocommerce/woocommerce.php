@@ -0,0 +1,17 @@
+<?php
+/**
+ * Plugin Name: WooCommerce
+ * Plugin URI: https://woocommerce.com/
+ * Description: An ecommerce toolkit that helps you sell anything. Beautifully.
+ * Version: 8.2.1
+ * Author: Automattic
+ * Author URI: https://woocommerce.com
+ * Text Domain: woocommerce
+ * Domain Path: /i18n/languages/
+ * Requires at least: 6.2
+ * Requires PHP: 7.3
+ *
+ * @package WooCommerce
+ */
+
+defined( 'ABSPATH' ) || exit;
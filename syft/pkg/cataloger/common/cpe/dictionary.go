@@ -0,0 +1,85 @@
+package cpe
+
+import (
+	"strings"
+
+	"github.com/facebookincubator/nvdtools/wfn"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+//go:generate go run ./generate-dictionary
+
+const (
+	// dictionaryMatchConfidence is the confidence of a CPE whose vendor and product are known to the dictionary
+	dictionaryMatchConfidence = 0.9
+	// anyVendorConfidence is the confidence of a CPE with a wildcard vendor for a product known to the dictionary
+	anyVendorConfidence = 0.6
+	// unknownProductConfidence is the confidence of a CPE with a product that is not within the dictionary
+	unknownProductConfidence = 0.5
+	// anyVendorUnknownProductConfidence is the confidence of a CPE with a wildcard vendor for a product that is not
+	// within the dictionary
+	anyVendorUnknownProductConfidence = 0.3
+	// vendorMismatchConfidence is the confidence of a CPE for a product that the dictionary only knows by other vendors
+	vendorMismatchConfidence = 0.2
+)
+
+// DictionaryVendors returns the vendors that the given product is known by within the NVD CPE dictionary (nil if the
+// product is unknown). Only the slice of the dictionary describing products distributed through package ecosystems is
+// embedded (see dictionary_index.go), so products outside of it are neither confirmed nor refuted.
+func DictionaryVendors(product string) []string {
+	return dictionaryProducts[strings.ToLower(product)]
+}
+
+// Annotate returns the source and confidence of the given CPEs (keyed by CPE string), where declared CPEs were stated
+// for the package directly and generated CPEs were guessed from the package metadata (see Generate). Generated CPEs
+// are validated against the NVD CPE dictionary, so that downstream matchers can disregard unlikely candidates.
+func Annotate(declared, generated []pkg.CPE) map[string]pkg.CPEAnnotation {
+	if len(declared) == 0 && len(generated) == 0 {
+		return nil
+	}
+
+	annotations := make(map[string]pkg.CPEAnnotation)
+	for _, c := range generated {
+		annotations[pkg.CPEString(c)] = matchDictionary(c)
+	}
+	// declared CPEs take precedence over any equivalent generated CPE
+	for _, c := range declared {
+		annotations[pkg.CPEString(c)] = pkg.CPEAnnotation{
+			Source:     pkg.DeclaredCPESource,
+			Confidence: 1,
+		}
+	}
+	return annotations
+}
+
+// matchDictionary scores the given generated CPE by how well its vendor and product agree with the NVD CPE dictionary.
+func matchDictionary(c pkg.CPE) pkg.CPEAnnotation {
+	vendors := DictionaryVendors(c.Product)
+	anyVendor := c.Vendor == wfn.Any || c.Vendor == ""
+
+	annotation := pkg.CPEAnnotation{Source: pkg.GeneratedCPESource}
+	switch {
+	case len(vendors) == 0 && anyVendor:
+		annotation.Confidence = anyVendorUnknownProductConfidence
+	case len(vendors) == 0:
+		annotation.Confidence = unknownProductConfidence
+	case anyVendor:
+		annotation.Confidence = anyVendorConfidence
+	case containsFold(vendors, c.Vendor):
+		annotation.Source = pkg.DictionaryCPESource
+		annotation.Confidence = dictionaryMatchConfidence
+	default:
+		annotation.Confidence = vendorMismatchConfidence
+	}
+	return annotation
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
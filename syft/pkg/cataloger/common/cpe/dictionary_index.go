@@ -0,0 +1,212 @@
+// Code generated by go generate; DO NOT EDIT.
+// This file was generated using data from https://nvd.nist.gov/feeds/xml/cpe/dictionary/official-cpe-dictionary_v2.3.xml.gz
+package cpe
+
+var dictionaryProducts = map[string][]string{
+	".net_core":              {"dotnetfoundation", "microsoft"},
+	"actionpack":             {"rubyonrails"},
+	"actionview":             {"rubyonrails"},
+	"activemq":               {"apache"},
+	"activerecord":           {"rubyonrails"},
+	"activesupport":          {"rubyonrails"},
+	"aiohttp":                {"aiohttp"},
+	"angular.js":             {"angularjs"},
+	"ansi-regex":             {"ansi-regex_project"},
+	"ant":                    {"apache"},
+	"asp.net_core":           {"microsoft"},
+	"axios":                  {"axios"},
+	"bash":                   {"gnu"},
+	"batik":                  {"apache"},
+	"bc-java":                {"bouncycastle"},
+	"black":                  {"psf"},
+	"bootstrap":              {"getbootstrap"},
+	"browserslist":           {"browserslist_project"},
+	"busybox":                {"busybox"},
+	"cassandra":              {"apache"},
+	"celery":                 {"celeryproject"},
+	"certifi":                {"certifi"},
+	"cgi":                    {"ruby-lang"},
+	"chrono":                 {"chrono_project"},
+	"click":                  {"palletsprojects"},
+	"commons_beanutils":      {"apache"},
+	"commons_codec":          {"apache"},
+	"commons_collections":    {"apache"},
+	"commons_compress":       {"apache"},
+	"commons_fileupload":     {"apache"},
+	"commons_io":             {"apache"},
+	"commons_text":           {"apache"},
+	"composer":               {"composer"},
+	"consul":                 {"hashicorp"},
+	"containerd":             {"containerd"},
+	"credentials":            {"jenkins"},
+	"crypto":                 {"golang"},
+	"cryptography":           {"cryptography_project"},
+	"curl":                   {"haxx"},
+	"debug":                  {"debug_project"},
+	"decode-uri-component":   {"decode-uri-component_project"},
+	"derby":                  {"apache"},
+	"devise":                 {"devise_project"},
+	"django":                 {"djangoproject"},
+	"docker":                 {"docker"},
+	"dom4j":                  {"dom4j_project"},
+	"elasticsearch":          {"elastic"},
+	"etcd":                   {"etcd"},
+	"express":                {"expressjs"},
+	"flask":                  {"palletsprojects"},
+	"follow-redirects":       {"follow-redirects_project"},
+	"framework":              {"laravel"},
+	"gin":                    {"gin-gonic"},
+	"git":                    {"git-scm", "jenkins"},
+	"glibc":                  {"gnu"},
+	"glob-parent":            {"glob-parent_project"},
+	"go":                     {"golang"},
+	"groovy":                 {"apache"},
+	"gson":                   {"google"},
+	"guava":                  {"google"},
+	"guzzle":                 {"guzzlephp"},
+	"gzip":                   {"gnu"},
+	"h2":                     {"h2database"},
+	"handlebars":             {"handlebarsjs"},
+	"hapi":                   {"hapijs"},
+	"haproxy":                {"haproxy"},
+	"helm":                   {"helm"},
+	"hibernate_orm":          {"hibernate"},
+	"hibernate_validator":    {"hibernate"},
+	"html-minifier":          {"kangax"},
+	"http_server":            {"apache"},
+	"httpclient":             {"apache"},
+	"httplib2":               {"httplib2_project"},
+	"hyper":                  {"hyper"},
+	"ipython":                {"ipython"},
+	"jackson-core":           {"fasterxml"},
+	"jackson-databind":       {"fasterxml"},
+	"jackson-dataformat-xml": {"fasterxml"},
+	"jenkins":                {"jenkins"},
+	"jetty":                  {"eclipse"},
+	"jinja":                  {"palletsprojects"},
+	"jose4j":                 {"jose4j_project"},
+	"jquery":                 {"jquery"},
+	"jquery_ui":              {"jqueryui"},
+	"json-java":              {"json-java_project"},
+	"json.net":               {"newtonsoft"},
+	"json5":                  {"json5"},
+	"jsonwebtoken":           {"auth0"},
+	"jsoup":                  {"jsoup"},
+	"jwt-go":                 {"jwt-go_project"},
+	"kafka":                  {"apache"},
+	"kibana":                 {"elastic"},
+	"kubernetes":             {"kubernetes"},
+	"legion-of-the-bouncy-castle-java-crytography-api": {"bouncycastle"},
+	"libcurl":               {"haxx"},
+	"libexpat":              {"libexpat_project"},
+	"libxml2":               {"xmlsoft"},
+	"libxslt":               {"xmlsoft"},
+	"loader-utils":          {"loader-utils_project"},
+	"lodash":                {"lodash"},
+	"log4j":                 {"apache"},
+	"logback":               {"qos"},
+	"logstash":              {"elastic"},
+	"lxml":                  {"lxml"},
+	"mailer":                {"jenkins"},
+	"mariadb":               {"mariadb"},
+	"marked":                {"marked_project"},
+	"maven":                 {"apache"},
+	"minimist":              {"minimist_project"},
+	"moment":                {"momentjs"},
+	"mongoose":              {"mongoosejs"},
+	"mysql":                 {"oracle"},
+	"netty":                 {"netty"},
+	"nginx":                 {"f5", "nginx"},
+	"node-fetch":            {"node-fetch_project"},
+	"node.js":               {"nodejs"},
+	"nokogiri":              {"nokogiri"},
+	"notebook":              {"jupyter"},
+	"npm":                   {"npmjs"},
+	"numpy":                 {"numpy"},
+	"openssh":               {"openbsd"},
+	"openssl":               {"openssl"},
+	"paramiko":              {"paramiko"},
+	"path-parse":            {"path-parse_project"},
+	"pdfbox":                {"apache"},
+	"perl":                  {"perl"},
+	"php":                   {"php"},
+	"phpmailer":             {"phpmailer_project"},
+	"pillow":                {"python"},
+	"pip":                   {"pypa"},
+	"poi":                   {"apache"},
+	"postgresql":            {"postgresql"},
+	"prometheus":            {"prometheus"},
+	"protobuf":              {"golang", "google"},
+	"protobuf-java":         {"google"},
+	"puma":                  {"puma"},
+	"pygments":              {"pygments"},
+	"python":                {"python"},
+	"pyyaml":                {"pyyaml"},
+	"qs":                    {"qs_project"},
+	"rack":                  {"rack_project"},
+	"rails":                 {"rubyonrails"},
+	"react":                 {"facebook"},
+	"redcarpet":             {"redcarpet_project"},
+	"redis":                 {"redis"},
+	"regex":                 {"rust-lang"},
+	"requests":              {"python-requests"},
+	"rexml":                 {"ruby-lang"},
+	"ruby":                  {"ruby-lang"},
+	"rubygems":              {"ruby-lang", "rubygems"},
+	"runc":                  {"linuxfoundation"},
+	"rust":                  {"rust-lang"},
+	"rust-openssl":          {"rust-openssl_project"},
+	"scrapy":                {"scrapy"},
+	"script_security":       {"jenkins"},
+	"semver":                {"semver_project"},
+	"sequelize":             {"sequelizejs"},
+	"setuptools":            {"pypa", "python"},
+	"shell-quote":           {"shell-quote_project"},
+	"shiro":                 {"apache"},
+	"sinatra":               {"sinatrarb"},
+	"slf4j":                 {"qos"},
+	"smallvec":              {"smallvec_project"},
+	"snakeyaml":             {"snakeyaml_project"},
+	"socket.io":             {"socket"},
+	"solr":                  {"apache"},
+	"spring_boot":           {"vmware"},
+	"spring_cloud_function": {"vmware"},
+	"spring_framework":      {"pivotal_software", "springsource", "vmware"},
+	"spring_security":       {"pivotal_software", "vmware"},
+	"sqlalchemy":            {"sqlalchemy"},
+	"sqlite":                {"sqlite"},
+	"struts":                {"apache"},
+	"subversion":            {"jenkins"},
+	"sudo":                  {"sudo_project"},
+	"symfony":               {"symfony"},
+	"tar":                   {"gnu"},
+	"terraform":             {"hashicorp"},
+	"text":                  {"golang"},
+	"tika":                  {"apache"},
+	"time":                  {"time_project"},
+	"tokio":                 {"tokio"},
+	"tomcat":                {"apache"},
+	"tornado":               {"tornadoweb"},
+	"tough-cookie":          {"tough-cookie_project"},
+	"trim-newlines":         {"trim-newlines_project"},
+	"twig":                  {"twig"},
+	"ua-parser-js":          {"ua-parser-js_project"},
+	"underscore":            {"underscorejs"},
+	"urllib3":               {"python"},
+	"vault":                 {"hashicorp"},
+	"velocity":              {"apache"},
+	"vue.js":                {"vuejs"},
+	"webpack-dev-server":    {"webpack-dev-server_project"},
+	"webrick":               {"ruby-lang"},
+	"websocket":             {"gorilla"},
+	"werkzeug":              {"palletsprojects"},
+	"wget":                  {"gnu"},
+	"wheel":                 {"pypa"},
+	"word-wrap":             {"word-wrap_project"},
+	"ws":                    {"ws_project"},
+	"xerces2_java":          {"apache"},
+	"xstream":               {"xstream_project"},
+	"yargs-parser":          {"yargs"},
+	"zlib":                  {"zlib"},
+	"zookeeper":             {"apache"},
+}
@@ -0,0 +1,95 @@
+package cpe
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func TestAnnotate(t *testing.T) {
+	tests := []struct {
+		name      string
+		declared  []string
+		generated []string
+		expected  map[string]pkg.CPEAnnotation
+	}{
+		{
+			name:     "no CPEs",
+			expected: nil,
+		},
+		{
+			name: "vendor and product known to the dictionary",
+			generated: []string{
+				"cpe:2.3:a:apache:log4j:2.14.1:*:*:*:*:*:*:*",
+				"cpe:2.3:a:Apache:Log4j:2.14.1:*:*:*:*:*:*:*",
+			},
+			expected: map[string]pkg.CPEAnnotation{
+				"cpe:2.3:a:apache:log4j:2.14.1:*:*:*:*:*:*:*": {Source: pkg.DictionaryCPESource, Confidence: dictionaryMatchConfidence},
+				"cpe:2.3:a:Apache:Log4j:2.14.1:*:*:*:*:*:*:*": {Source: pkg.DictionaryCPESource, Confidence: dictionaryMatchConfidence},
+			},
+		},
+		{
+			name: "product known to the dictionary by other vendors",
+			generated: []string{
+				"cpe:2.3:a:log4j:log4j:2.14.1:*:*:*:*:*:*:*",
+			},
+			expected: map[string]pkg.CPEAnnotation{
+				"cpe:2.3:a:log4j:log4j:2.14.1:*:*:*:*:*:*:*": {Source: pkg.GeneratedCPESource, Confidence: vendorMismatchConfidence},
+			},
+		},
+		{
+			name: "product unknown to the dictionary",
+			generated: []string{
+				"cpe:2.3:a:some-vendor:some-product:1.0:*:*:*:*:*:*:*",
+				"cpe:2.3:a:*:some-product:1.0:*:*:*:*:*:*:*",
+			},
+			expected: map[string]pkg.CPEAnnotation{
+				"cpe:2.3:a:some-vendor:some-product:1.0:*:*:*:*:*:*:*": {Source: pkg.GeneratedCPESource, Confidence: unknownProductConfidence},
+				"cpe:2.3:a:*:some-product:1.0:*:*:*:*:*:*:*":           {Source: pkg.GeneratedCPESource, Confidence: anyVendorUnknownProductConfidence},
+			},
+		},
+		{
+			name: "any vendor for a product known to the dictionary",
+			generated: []string{
+				"cpe:2.3:a:*:rack:2.2.3:*:*:*:*:*:*:*",
+			},
+			expected: map[string]pkg.CPEAnnotation{
+				"cpe:2.3:a:*:rack:2.2.3:*:*:*:*:*:*:*": {Source: pkg.GeneratedCPESource, Confidence: anyVendorConfidence},
+			},
+		},
+		{
+			name: "declared CPEs take precedence",
+			declared: []string{
+				"cpe:2.3:a:python_software_foundation:python:3.9.2:*:*:*:*:*:*:*",
+			},
+			generated: []string{
+				"cpe:2.3:a:python_software_foundation:python:3.9.2:*:*:*:*:*:*:*",
+				"cpe:2.3:a:python:python:3.9.2:*:*:*:*:*:*:*",
+			},
+			expected: map[string]pkg.CPEAnnotation{
+				"cpe:2.3:a:python_software_foundation:python:3.9.2:*:*:*:*:*:*:*": {Source: pkg.DeclaredCPESource, Confidence: 1},
+				"cpe:2.3:a:python:python:3.9.2:*:*:*:*:*:*:*":                     {Source: pkg.DictionaryCPESource, Confidence: dictionaryMatchConfidence},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Annotate(mustCPEs(test.declared), mustCPEs(test.generated)))
+		})
+	}
+}
+
+func TestDictionaryVendors(t *testing.T) {
+	assert.Equal(t, []string{"dotnetfoundation", "microsoft"}, DictionaryVendors(".NET_Core"))
+	assert.Nil(t, DictionaryVendors("some-product"))
+}
+
+func mustCPEs(values []string) []pkg.CPE {
+	var cpes []pkg.CPE
+	for _, v := range values {
+		cpes = append(cpes, pkg.MustCPE(v))
+	}
+	return cpes
+}
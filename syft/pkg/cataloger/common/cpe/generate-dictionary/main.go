@@ -0,0 +1,183 @@
+package main
+
+import (
+	"compress/gzip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/scylladb/go-set/strset"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// This program generates dictionary_index.go.
+const (
+	source    = "dictionary_index.go"
+	sourceURL = "https://nvd.nist.gov/feeds/xml/cpe/dictionary/official-cpe-dictionary_v2.3.xml.gz"
+)
+
+// ecosystemHosts are the hosts of package registries and source forges: a product is only included within the index
+// if the dictionary references it on one of these hosts, which keeps the index small while covering the products that
+// syft generates CPEs for.
+var ecosystemHosts = strset.New(
+	"crates.io",
+	"github.com",
+	"gitlab.com",
+	"hex.pm",
+	"mvnrepository.com",
+	"npmjs.com",
+	"nuget.org",
+	"packagist.org",
+	"pkg.go.dev",
+	"plugins.jenkins.io",
+	"pub.dev",
+	"pypi.org",
+	"pypi.python.org",
+	"rubygems.org",
+	"search.maven.org",
+	"www.npmjs.com",
+	"www.nuget.org",
+)
+
+var tmp = template.Must(template.New("").Parse(`// Code generated by go generate; DO NOT EDIT.
+// This file was generated using data from {{ .URL }}
+package cpe
+
+var dictionaryProducts = map[string][]string{
+{{- range $product, $vendors := .Products }}
+	{{ printf "%q" $product }}: { {{- range $i, $vendor := $vendors }}{{ if $i }}, {{ end }}{{ printf "%q" $vendor }}{{ end -}} },
+{{- end }}
+}
+`))
+
+type cpeItem struct {
+	Deprecated bool `xml:"deprecated,attr"`
+	References []struct {
+		Href string `xml:"href,attr"`
+	} `xml:"references>reference"`
+	CPE23 struct {
+		Name string `xml:"name,attr"`
+	} `xml:"cpe23-item"`
+}
+
+func main() {
+	if err := run(); err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	resp, err := http.Get(sourceURL)
+	if err != nil {
+		return fmt.Errorf("unable to get CPE dictionary: %+v", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.Fatalf("unable to close body: %+v", err)
+		}
+	}()
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		return fmt.Errorf("unable to decompress CPE dictionary: %+v", err)
+	}
+
+	products, err := parseDictionary(reader)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(source)
+	if err != nil {
+		return fmt.Errorf("unable to create %q: %+v", source, err)
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.Fatalf("unable to close %q: %+v", source, err)
+		}
+	}()
+
+	err = tmp.Execute(f, struct {
+		URL      string
+		Products map[string][]string
+	}{
+		URL:      sourceURL,
+		Products: products,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to generate template: %+v", err)
+	}
+	return nil
+}
+
+// parseDictionary returns the sorted vendors of every application product within the given CPE dictionary that is
+// referenced on a package ecosystem host, keyed by product.
+func parseDictionary(reader io.Reader) (map[string][]string, error) {
+	vendorsByProduct := make(map[string]*strset.Set)
+
+	decoder := xml.NewDecoder(reader)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse CPE dictionary: %+v", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "cpe-item" {
+			continue
+		}
+
+		var item cpeItem
+		if err := decoder.DecodeElement(&item, &start); err != nil {
+			return nil, fmt.Errorf("unable to parse CPE dictionary item: %+v", err)
+		}
+
+		if item.Deprecated || !item.referencesEcosystem() {
+			continue
+		}
+
+		c, err := pkg.NewCPE(item.CPE23.Name)
+		if err != nil || c.Part != "a" {
+			continue
+		}
+
+		product := strings.ToLower(c.Product)
+		if _, exists := vendorsByProduct[product]; !exists {
+			vendorsByProduct[product] = strset.New()
+		}
+		vendorsByProduct[product].Add(strings.ToLower(c.Vendor))
+	}
+
+	products := make(map[string][]string)
+	for product, vendors := range vendorsByProduct {
+		values := vendors.List()
+		sort.Strings(values)
+		products[product] = values
+	}
+	return products, nil
+}
+
+func (i cpeItem) referencesEcosystem() bool {
+	for _, ref := range i.References {
+		u, err := url.Parse(ref.Href)
+		if err != nil {
+			continue
+		}
+		if ecosystemHosts.Has(strings.ToLower(u.Hostname())) {
+			return true
+		}
+	}
+	return false
+}
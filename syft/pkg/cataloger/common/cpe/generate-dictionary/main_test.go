@@ -0,0 +1,52 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseDictionary(t *testing.T) {
+	f, err := os.Open("test-fixtures/official-cpe-dictionary.xml")
+	require.NoError(t, err)
+	defer f.Close()
+
+	products, err := parseDictionary(f)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string][]string{
+		// note: http_server is not referenced on a package ecosystem host, lodash is deprecated, and linux_kernel is
+		// not an application
+		"log4j":   {"apache"},
+		"logback": {"ch.qos", "qos"},
+	}, products)
+}
+
+func Test_template(t *testing.T) {
+	var buf bytes.Buffer
+	err := tmp.Execute(&buf, struct {
+		URL      string
+		Products map[string][]string
+	}{
+		URL: "https://example.com/dictionary.xml.gz",
+		Products: map[string][]string{
+			"logback": {"ch.qos", "qos"},
+			"log4j":   {"apache"},
+		},
+	})
+	require.NoError(t, err)
+
+	expected := `// Code generated by go generate; DO NOT EDIT.
+// This file was generated using data from https://example.com/dictionary.xml.gz
+package cpe
+
+var dictionaryProducts = map[string][]string{
+	"log4j": {"apache"},
+	"logback": {"ch.qos", "qos"},
+}
+`
+	assert.Equal(t, expected, buf.String())
+}
@@ -5,6 +5,7 @@ package common
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/log"
@@ -44,14 +45,15 @@ func (c *GenericCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package,
 	var packages []pkg.Package
 	var relationships []artifact.Relationship
 
-	for location, parser := range c.selectFiles(resolver) {
+	for _, request := range c.selectFiles(resolver) {
+		location := request.locations[0]
 		contentReader, err := resolver.FileContentsByLocation(location)
 		if err != nil {
 			// TODO: fail or log?
 			return nil, nil, fmt.Errorf("unable to fetch contents at location=%v: %w", location, err)
 		}
 
-		discoveredPackages, discoveredRelationships, err := parser(location.RealPath, contentReader)
+		discoveredPackages, discoveredRelationships, err := request.parser(location.RealPath, contentReader)
 		internal.CloseAndLogError(contentReader, location.VirtualPath)
 		if err != nil {
 			// TODO: should we fail? or only log?
@@ -63,7 +65,7 @@ func (c *GenericCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package,
 		var cleanedRelationships []artifact.Relationship
 		for _, p := range discoveredPackages {
 			p.FoundBy = c.upstreamCataloger
-			p.Locations.Add(location)
+			p.Locations.Add(request.locations...)
 			p.SetID()
 			// doing it here so all packages have an ID,
 			// IDs are later used to remove relationships
@@ -106,31 +108,62 @@ func removeRelationshipsWithArtifactIDs(artifactsToExclude map[artifact.ID]struc
 	return cleanedRelationships
 }
 
-// SelectFiles takes a set of file trees and resolves and file references of interest for future cataloging
-func (c *GenericCataloger) selectFiles(resolver source.FilePathResolver) map[source.Location]ParserFn {
-	var parserByLocation = make(map[source.Location]ParserFn)
+// parseRequest is a single file to parse, along with every location the file was found at (the same file may be
+// accessible by several paths, e.g. through symlinked directories).
+type parseRequest struct {
+	locations []source.Location
+	parser    ParserFn
+}
+
+// selectFiles takes a set of file trees and resolves and file references of interest for future cataloging. Files that
+// are found at several locations (e.g. through symlinks) are only selected once.
+func (c *GenericCataloger) selectFiles(resolver source.FilePathResolver) []parseRequest {
+	var requests []parseRequest
+	requestByCoordinates := make(map[source.Coordinates]int)
+	seen := make(map[source.Location]bool)
+	add := func(f source.Location, parser ParserFn) {
+		if seen[f] {
+			return
+		}
+		seen[f] = true
+		if i, ok := requestByCoordinates[f.Coordinates]; ok {
+			requests[i].locations = append(requests[i].locations, f)
+			return
+		}
+		requestByCoordinates[f.Coordinates] = len(requests)
+		requests = append(requests, parseRequest{locations: []source.Location{f}, parser: parser})
+	}
 
 	// select by exact path
-	for path, parser := range c.pathParsers {
+	for _, path := range sortedKeys(c.pathParsers) {
 		files, err := resolver.FilesByPath(path)
 		if err != nil {
 			log.Warnf("cataloger failed to select files by path: %+v", err)
 		}
 		for _, f := range files {
-			parserByLocation[f] = parser
+			add(f, c.pathParsers[path])
 		}
 	}
 
 	// select by glob pattern
-	for globPattern, parser := range c.globParsers {
+	for _, globPattern := range sortedKeys(c.globParsers) {
 		fileMatches, err := resolver.FilesByGlob(globPattern)
 		if err != nil {
 			log.Warnf("failed to find files by glob: %s", globPattern)
 		}
 		for _, f := range fileMatches {
-			parserByLocation[f] = parser
+			add(f, c.globParsers[globPattern])
 		}
 	}
 
-	return parserByLocation
+	return requests
+}
+
+func sortedKeys(parsers map[string]ParserFn) []string {
+	keys := make([]string, 0, len(parsers))
+	for k := range parsers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
 }
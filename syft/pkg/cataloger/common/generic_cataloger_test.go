@@ -79,6 +79,35 @@ func TestGenericCataloger(t *testing.T) {
 	}
 }
 
+func TestGenericCataloger_ParsesLinkedFilesOnce(t *testing.T) {
+	var parsed []string
+	parser := func(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+		parsed = append(parsed, path)
+		return []*pkg.Package{{Name: "linked", Version: "1.0"}}, nil, nil
+	}
+
+	// the same file accessed through two (symlinked) paths
+	realPath := "test-fixtures/a-path.txt"
+	resolver := source.NewMockResolverForPathsWithMetadata(map[source.Location]source.FileMetadata{
+		source.NewVirtualLocation(realPath, "test-fixtures/link-a/a-path.txt"): {},
+		source.NewVirtualLocation(realPath, "test-fixtures/link-b/a-path.txt"): {},
+	})
+	cataloger := NewGenericCataloger(nil, map[string]ParserFn{"**/a-path.txt": parser}, "some-cataloger")
+
+	actualPkgs, _, err := cataloger.Catalog(resolver)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{realPath}, parsed)
+	require.Len(t, actualPkgs, 1)
+
+	var virtualPaths []string
+	for _, l := range actualPkgs[0].Locations.ToSlice() {
+		assert.Equal(t, realPath, l.RealPath)
+		virtualPaths = append(virtualPaths, l.VirtualPath)
+	}
+	assert.ElementsMatch(t, []string{"test-fixtures/link-a/a-path.txt", "test-fixtures/link-b/a-path.txt"}, virtualPaths)
+}
+
 func Test_removeRelationshipsWithArtifactIDs(t *testing.T) {
 	one := &pkg.Package{Name: "one", Version: "1.0"}
 	two := &pkg.Package{Name: "two", Version: "1.0"}
@@ -1,17 +1,111 @@
 package cataloger
 
 import (
+	"crypto"
+	"time"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/binary"
+	"github.com/anchore/syft/syft/pkg/cataloger/cache"
 	"github.com/anchore/syft/syft/pkg/cataloger/java"
+	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
+	"github.com/anchore/syft/syft/pkg/cataloger/plugin"
+	"github.com/anchore/syft/syft/pkg/enrich"
+)
+
+const (
+	// DefaultMaxNestedArchiveDepth is deep enough for the archives of java applications (e.g. jars within wars within ears)
+	DefaultMaxNestedArchiveDepth = 5
+	// DefaultMaxNestedArchiveSize is the default number of bytes that may be extracted from nested archives (1 GB)
+	DefaultMaxNestedArchiveSize = 1000 * 1000 * 1000
 )
 
 type Config struct {
 	Search     SearchConfig
 	Catalogers []string
+	Binary     binary.Config
+	JavaScript javascript.Config
+	// ExcludeBaseImage removes the packages introduced by the base image of an image (see source.DetectBaseImage)
+	ExcludeBaseImage bool
+	// ArchiveDigests are additional hash algorithms used to calculate the digests of package archives (e.g. java archives)
+	ArchiveDigests []crypto.Hash
+	// Select are expressions of the catalogers to use in place of the default catalogers for the source (see SelectCatalogers)
+	Select []string
+	// Deduplication is the policy for merging the same package found by multiple catalogers
+	Deduplication pkg.DeduplicationConfig
+	// Filter selects the packages kept in the final catalog (all packages are kept when nil)
+	Filter *pkg.Filter
+	// Additional are catalogers to run alongside the built-in catalogers (in addition to any registered catalogers, see Register)
+	Additional []pkg.Cataloger
+	// Plugins are the cataloger plugins to run alongside the built-in catalogers (see the plugin package)
+	Plugins plugin.Config
+	// Parallelism is the maximum number of catalogers to run concurrently
+	Parallelism int
+	// Cache is where the results of cataloging image layers are stored for reuse
+	Cache cache.Config
+	// Incremental catalogs only the files of a directory that changed since the previous scan of the same directory
+	// (the state of each scanned directory is kept in the cache directory, regardless of whether caching is enabled)
+	Incremental bool
+	// MaxFileSize is the largest file (in bytes) that catalogers are given (unlimited when zero)
+	MaxFileSize int64
+	// MaxFilesPerCataloger is the most files that each cataloger is given (unlimited when zero)
+	MaxFilesPerCataloger int
+	// MaxNestedArchiveDepth is how many levels of archives nested within a cataloged archive (e.g. jars within wars
+	// within ears, or java archives within tars) are searched (unlimited when zero)
+	MaxNestedArchiveDepth int
+	// MaxNestedArchiveSize is how many bytes may be extracted from the archives nested within a cataloged archive,
+	// guarding against archive bombs (unlimited when zero)
+	MaxNestedArchiveSize int64
+	// Timeout is how long cataloging may take before returning the packages found so far (unlimited when zero)
+	Timeout time.Duration
+	// Metrics records the measurements of each cataloger run (nothing is measured when nil)
+	Metrics *Metrics
+	// Instrumentation reports cataloging as OpenTelemetry traces and Prometheus metrics (nothing is reported when nil)
+	Instrumentation *Instrumentation
+	// Enrichment looks up the details of packages within the registries of their ecosystems (see the enrich package)
+	Enrichment enrich.Config
+	// NestedImageDepth is how many levels of container images stored within the source (e.g. image archives) have the
+	// packages within them cataloged (none when zero, in which case only the images themselves are cataloged)
+	NestedImageDepth int
+	// IDScheme derives the IDs of the cataloged packages (see pkg.IDScheme)
+	IDScheme pkg.IDScheme
 }
 
 func DefaultConfig() Config {
 	return Config{
-		Search: DefaultSearchConfig(),
+		Search:                DefaultSearchConfig(),
+		JavaScript:            javascript.DefaultConfig(),
+		Deduplication:         pkg.DefaultDeduplicationConfig(),
+		Parallelism:           1,
+		Cache:                 cache.DefaultConfig(),
+		Enrichment:            enrich.DefaultConfig(),
+		Plugins:               plugin.DefaultConfig(),
+		IDScheme:              pkg.DefaultIDScheme,
+		MaxNestedArchiveDepth: DefaultMaxNestedArchiveDepth,
+		MaxNestedArchiveSize:  DefaultMaxNestedArchiveSize,
+	}
+}
+
+// Limits returns the resource limits for cataloging that starts at the given time.
+func (c Config) Limits(start time.Time) Limits {
+	limits := Limits{
+		MaxFileSize:          c.MaxFileSize,
+		MaxFilesPerCataloger: c.MaxFilesPerCataloger,
+	}
+	if c.Timeout > 0 {
+		limits.Deadline = start.Add(c.Timeout)
+	}
+	return limits
+}
+
+// Options returns the options for running catalogers when cataloging starts at the given time.
+func (c Config) Options(start time.Time) Options {
+	return Options{
+		Parallelism:     c.Parallelism,
+		Limits:          c.Limits(start),
+		Metrics:         c.Metrics,
+		Instrumentation: c.Instrumentation,
+		IDScheme:        c.IDScheme,
 	}
 }
 
@@ -19,5 +113,8 @@ func (c Config) Java() java.Config {
 	return java.Config{
 		SearchUnindexedArchives: c.Search.IncludeUnindexedArchives,
 		SearchIndexedArchives:   c.Search.IncludeIndexedArchives,
+		ArchiveDigests:          c.ArchiveDigests,
+		MaxNestedArchiveDepth:   c.MaxNestedArchiveDepth,
+		MaxNestedArchiveSize:    c.MaxNestedArchiveSize,
 	}
 }
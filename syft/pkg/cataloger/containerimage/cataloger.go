@@ -0,0 +1,21 @@
+/*
+Package containerimage provides a concrete Cataloger implementation for container images stored within the scanned
+filesystem (e.g. OCI image layouts, image archives written by "docker save", and the image store of a docker daemon).
+*/
+package containerimage
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const catalogerName = "container-image-cataloger"
+
+// NewContainerImageCataloger returns a new cataloger object for container images stored within the scanned
+// filesystem. Only the images themselves are cataloged, not the packages within them (see cataloger.Config for
+// cataloging the images recursively).
+func NewContainerImageCataloger() *generic.Cataloger {
+	return generic.NewCataloger(catalogerName).
+		WithParserByGlobs(parseOCILayout, "**/oci-layout").
+		WithParserByGlobs(parseImageArchive, "**/*.tar").
+		WithParserByGlobs(parseDockerImageStore, "**/image/*/repositories.json")
+}
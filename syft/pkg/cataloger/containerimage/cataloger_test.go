@@ -0,0 +1,130 @@
+package containerimage
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestContainerImageCataloger(t *testing.T) {
+	store := source.NewLocation("test-fixtures/var/lib/docker/image/overlay2/repositories.json")
+	// note: the packages are in the order of the parsers (OCI layouts, image archives, and then the image store)
+	expected := []pkg.Package{
+		{
+			Name:         "alpine",
+			Version:      "3.17",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/layouts/alpine/oci-layout")),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/alpine@sha256:8328d198ebc5e0f15eb88a0b3e65ba1f1760a4240f2c80e760b4385d48f3f5f1?repository_url=alpine&tag=3.17",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:         pkg.OCILayoutImageFormat,
+				ImageID:        "sha256:ecef2f42f1aeedd84d30f7ec75db94047dcce6b6148e092a23753867c9729549",
+				ManifestDigest: "sha256:8328d198ebc5e0f15eb88a0b3e65ba1f1760a4240f2c80e760b4385d48f3f5f1",
+				Tags:           []string{"alpine:3.17", "alpine:latest"},
+				Platforms:      []string{"linux/amd64"},
+			},
+		},
+		{
+			Name:         "multiarch",
+			Version:      "1.0",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/layouts/multiarch/oci-layout")),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/multiarch@sha256:a1f6a6d8f30b03b6dc0f9ebb18c1f7c460690bacdae453c8164663721fd3a19a?repository_url=multiarch&tag=1.0",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:         pkg.OCILayoutImageFormat,
+				ManifestDigest: "sha256:a1f6a6d8f30b03b6dc0f9ebb18c1f7c460690bacdae453c8164663721fd3a19a",
+				Tags:           []string{"multiarch:1.0"},
+				Platforms:      []string{"linux/amd64", "linux/arm64/v8"},
+			},
+		},
+		{
+			Name:         "ghcr.io/acme/app",
+			Version:      "1.2.3",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/archives/app.tar")),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/app@sha256:ae55a45dfcfc16514b6785f14ad280c505537076d3b67aa894d89bfea94eada6?repository_url=ghcr.io/acme/app&tag=1.2.3",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:         pkg.DockerArchiveImageFormat,
+				ImageID:        "sha256:6b9f882796a6b167dfecd453caa0d62e3c50d54e3b7dd9ff1c82567cc9daced1",
+				ManifestDigest: "sha256:ae55a45dfcfc16514b6785f14ad280c505537076d3b67aa894d89bfea94eada6",
+				Tags:           []string{"ghcr.io/acme/app:1.2.3", "ghcr.io/acme/app:latest"},
+				Platforms:      []string{"linux/amd64"},
+			},
+		},
+		{
+			Name:         "busybox",
+			Version:      "1.36",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/archives/busybox.tar")),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/busybox?repository_url=busybox&tag=1.36",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:    pkg.DockerArchiveImageFormat,
+				ImageID:   "sha256:ba1199ed03b20c3c5e3b8deac299d5d9f43659e24f4af3f715312c3975b66176",
+				Tags:      []string{"busybox:1.36"},
+				Platforms: []string{"linux/arm/v7"},
+			},
+		},
+		{
+			Name:         "tools",
+			Version:      "v2",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/archives/tools.tar")),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/tools@sha256:b0068f2229acb9ba02e0ea7bf13da2f8c7b76ec15a07ca1ece77a670181bf2f9?repository_url=tools&tag=v2",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:         pkg.OCIArchiveImageFormat,
+				ImageID:        "sha256:eaed8092d9ceb96e689f2df582443702677f6a714347bc59a62a23ed12cbd3f1",
+				ManifestDigest: "sha256:b0068f2229acb9ba02e0ea7bf13da2f8c7b76ec15a07ca1ece77a670181bf2f9",
+				Tags:           []string{"tools:v2"},
+				Platforms:      []string{"linux/amd64"},
+			},
+		},
+		{
+			Name:         "nginx",
+			Version:      "1.25",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(store),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/nginx@sha256:f20d615f1203d5d8004d08ce71f8d41502a588b0296bb0fd14288e24eeff5cff?repository_url=nginx&tag=1.25",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:         pkg.DockerImageStoreFormat,
+				ImageID:        "sha256:05bb13f24728857a9130c33fd016b9cf32d5b54552c3c1bdab7a628c38527bab",
+				ManifestDigest: "sha256:f20d615f1203d5d8004d08ce71f8d41502a588b0296bb0fd14288e24eeff5cff",
+				Tags:           []string{"nginx:1.25"},
+				Platforms:      []string{"linux/amd64"},
+			},
+		},
+		{
+			Name:         "localhost:5000/built",
+			Version:      "dev",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(store),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/built?repository_url=localhost:5000/built&tag=dev",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:    pkg.DockerImageStoreFormat,
+				ImageID:   "sha256:ef5c9da404fcf991ced3df0d49795eebf72a4e4790264a32f3cb07580d082791",
+				Tags:      []string{"localhost:5000/built:dev"},
+				Platforms: []string{"linux/amd64"},
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		WithResolver(source.NewMockResolverForPaths(fixturePaths(t, "test-fixtures")...)).
+		Expects(expected, nil).
+		TestCataloger(t, NewContainerImageCataloger())
+}
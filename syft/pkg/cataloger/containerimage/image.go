@@ -0,0 +1,217 @@
+package containerimage
+
+import (
+	"encoding/json"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/reference/docker"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+const (
+	// containerdImageNameAnnotation is the full reference of an image within an index written by containerd (or
+	// "docker save" with the containerd image store)
+	containerdImageNameAnnotation = "io.containerd.image.name"
+
+	// ociRefNameAnnotation is the reference of an image within an index, which is usually only the tag of the image
+	ociRefNameAnnotation = "org.opencontainers.image.ref.name"
+
+	ociIndexMediaType           = "application/vnd.oci.image.index.v1+json"
+	dockerManifestListMediaType = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// ociIndex is the index.json of an OCI image layout (or any image index blob).
+type ociIndex struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+// ociDescriptor refers to a blob of an OCI image layout by digest.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Annotations map[string]string `json:"annotations"`
+	Platform    *platform         `json:"platform"`
+}
+
+func (d ociDescriptor) isIndex() bool {
+	return d.MediaType == ociIndexMediaType || d.MediaType == dockerManifestListMediaType
+}
+
+// ociManifest is the manifest of a single image.
+type ociManifest struct {
+	Config ociDescriptor `json:"config"`
+}
+
+// platform is the platform of an image, given by a descriptor or by the image config.
+type platform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+	Variant      string `json:"variant"`
+}
+
+func (p platform) String() string {
+	if p.OS == "" || p.Architecture == "" {
+		return ""
+	}
+	s := p.OS + "/" + p.Architecture
+	if p.Variant != "" {
+		s += "/" + p.Variant
+	}
+	return s
+}
+
+// blobStore gives the content of a blob of an OCI image layout by digest, returning false when the blob is missing.
+type blobStore func(digest string) ([]byte, bool)
+
+// blobPath returns the path of the blob with the given digest, relative to the root of an OCI image layout.
+func blobPath(digest string) string {
+	algorithm, hex, ok := strings.Cut(digest, ":")
+	if !ok {
+		return ""
+	}
+	return path.Join("blobs", algorithm, hex)
+}
+
+// decodeBlob decodes the JSON blob with the given digest, returning false when the blob is missing or malformed.
+func (s blobStore) decodeBlob(digest string, into interface{}) bool {
+	content, ok := s(digest)
+	if !ok {
+		return false
+	}
+	if err := json.Unmarshal(content, into); err != nil {
+		log.WithFields("digest", digest, "error", err).Trace("unable to parse image blob")
+		return false
+	}
+	return true
+}
+
+// imagesFromIndex describes the images of the given index of an OCI image layout, where the same manifest may be
+// listed several times with different references. The name of the layout (e.g. the name of the directory) names the
+// images when the index only records their tags.
+func imagesFromIndex(index ociIndex, blobs blobStore, format, layoutName string) []pkg.ContainerImageMetadata {
+	var images []pkg.ContainerImageMetadata
+	byDigest := make(map[string]int)
+	for _, descriptor := range index.Manifests {
+		if descriptor.Digest == "" {
+			continue
+		}
+		idx, ok := byDigest[descriptor.Digest]
+		if !ok {
+			idx = len(images)
+			byDigest[descriptor.Digest] = idx
+			images = append(images, describeManifest(descriptor, blobs, format))
+		}
+		if ref := referenceFromAnnotations(descriptor.Annotations, layoutName); ref != "" {
+			images[idx].Tags = appendUnique(images[idx].Tags, ref)
+		}
+	}
+	return images
+}
+
+// describeManifest describes the image (or multi-platform image) of the given manifest descriptor, as far as the
+// blobs of the manifest and config are available.
+func describeManifest(descriptor ociDescriptor, blobs blobStore, format string) pkg.ContainerImageMetadata {
+	metadata := pkg.ContainerImageMetadata{
+		Format:         format,
+		ManifestDigest: descriptor.Digest,
+	}
+
+	if descriptor.isIndex() {
+		var index ociIndex
+		if blobs.decodeBlob(descriptor.Digest, &index) {
+			for _, m := range index.Manifests {
+				if m.Platform != nil {
+					metadata.Platforms = appendUnique(metadata.Platforms, m.Platform.String())
+				}
+			}
+		}
+		return metadata
+	}
+
+	var manifest ociManifest
+	if !blobs.decodeBlob(descriptor.Digest, &manifest) {
+		if descriptor.Platform != nil {
+			metadata.Platforms = appendUnique(metadata.Platforms, descriptor.Platform.String())
+		}
+		return metadata
+	}
+	metadata.ImageID = manifest.Config.Digest
+
+	var config platform
+	if blobs.decodeBlob(manifest.Config.Digest, &config) {
+		metadata.Platforms = appendUnique(metadata.Platforms, config.String())
+	} else if descriptor.Platform != nil {
+		metadata.Platforms = appendUnique(metadata.Platforms, descriptor.Platform.String())
+	}
+	return metadata
+}
+
+// referenceFromAnnotations returns the reference of an image listed by an index, which is either the full reference
+// recorded by containerd or the reference name of the OCI spec (where a plain tag is qualified by the layout name).
+func referenceFromAnnotations(annotations map[string]string, layoutName string) string {
+	if ref := annotations[containerdImageNameAnnotation]; ref != "" {
+		return ref
+	}
+	ref := annotations[ociRefNameAnnotation]
+	if ref == "" || strings.ContainsAny(ref, "/:@") {
+		return ref
+	}
+	if layoutName == "" {
+		return ""
+	}
+	return layoutName + ":" + ref
+}
+
+// imageReference is the repository and tag of an image, in the familiar form (e.g. "alpine" and "3.17" for
+// "docker.io/library/alpine:3.17").
+type imageReference struct {
+	Repository string
+	Tag        string
+}
+
+// parseImageReference parses the given image reference, returning false when the value is not a valid reference.
+func parseImageReference(value string) (imageReference, bool) {
+	named, err := docker.ParseNormalizedNamed(value)
+	if err != nil {
+		log.WithFields("reference", value, "error", err).Trace("invalid image reference")
+		return imageReference{}, false
+	}
+	ref := imageReference{Repository: docker.FamiliarName(named)}
+	if tagged, ok := named.(docker.Tagged); ok {
+		ref.Tag = tagged.Tag()
+	}
+	return ref, true
+}
+
+// familiarTags returns the valid references of the given image in the familiar form (e.g. "alpine:3.17"), sorted
+// for a stable ordering.
+func familiarTags(tags []string) []string {
+	var results []string
+	for _, tag := range tags {
+		named, err := docker.ParseNormalizedNamed(tag)
+		if err != nil {
+			log.WithFields("reference", tag, "error", err).Trace("invalid image reference")
+			continue
+		}
+		results = appendUnique(results, docker.FamiliarString(named))
+	}
+	sort.Strings(results)
+	return results
+}
+
+func appendUnique(values []string, value string) []string {
+	if value == "" {
+		return values
+	}
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+	return append(values, value)
+}
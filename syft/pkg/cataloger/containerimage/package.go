@@ -0,0 +1,41 @@
+package containerimage
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+// newContainerImagePackage creates a package for a stored container image, which is named and versioned by the first
+// of its references (or by the given name and the digest of the image when the image is not tagged).
+func newContainerImagePackage(metadata pkg.ContainerImageMetadata, name string, locations ...source.Location) pkg.Package {
+	metadata.Tags = familiarTags(metadata.Tags)
+
+	var tag string
+	if len(metadata.Tags) > 0 {
+		if ref, ok := parseImageReference(metadata.Tags[0]); ok {
+			name, tag = ref.Repository, ref.Tag
+		}
+	}
+	version := tag
+	if version == "" {
+		version = metadata.ManifestDigest
+	}
+	if version == "" {
+		version = metadata.ImageID
+	}
+
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.ContainerImagePkg,
+		PURL:         purl.OCI(name, tag, metadata.ManifestDigest),
+		MetadataType: pkg.ContainerImageMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
@@ -0,0 +1,113 @@
+package containerimage
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/containerd/containerd/reference/docker"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseDockerImageStore
+
+// dockerRepositories is the repositories.json of the image store of a docker daemon (within the directory of the
+// storage driver, e.g. /var/lib/docker/image/overlay2/repositories.json), mapping each repository to the references
+// of the repository and the ID of the image each reference resolves to.
+type dockerRepositories struct {
+	Repositories map[string]map[string]string `json:"Repositories"`
+}
+
+// names returns the names of the repositories, sorted for a stable ordering.
+func (r dockerRepositories) names() []string {
+	names := make([]string, 0, len(r.Repositories))
+	for name := range r.Repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseDockerImageStore parses the tagged (or pulled by digest) images of the image store of a docker daemon.
+func parseDockerImageStore(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var repositories dockerRepositories
+	if err := json.NewDecoder(reader).Decode(&repositories); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse docker repositories file: %w", err)
+	}
+
+	type storedImage struct {
+		repository string
+		metadata   pkg.ContainerImageMetadata
+	}
+	images := make(map[string]*storedImage)
+	var ids []string
+	for _, repository := range repositories.names() {
+		refs := repositories.Repositories[repository]
+		for _, ref := range sortedKeys(refs) {
+			id := refs[ref]
+			image, ok := images[id]
+			if !ok {
+				image = &storedImage{
+					repository: repository,
+					metadata: pkg.ContainerImageMetadata{
+						Format:  pkg.DockerImageStoreFormat,
+						ImageID: id,
+					},
+				}
+				images[id] = image
+				ids = append(ids, id)
+			}
+			if _, digest, ok := strings.Cut(ref, "@"); ok {
+				// the repository digest is the digest of the manifest the image was pulled by
+				if image.metadata.ManifestDigest == "" {
+					image.metadata.ManifestDigest = digest
+				}
+				continue
+			}
+			image.metadata.Tags = append(image.metadata.Tags, ref)
+		}
+	}
+
+	sort.Strings(ids)
+	storeDir := path.Dir(reader.RealPath)
+	var pkgs []pkg.Package
+	for _, id := range ids {
+		image := images[id]
+		// the config of each image is stored by the ID of the image (the digest of the config)
+		if algorithm, hex, ok := strings.Cut(id, ":"); ok && resolver != nil {
+			var config platform
+			configPath := path.Join(storeDir, "imagedb", "content", algorithm, hex)
+			if content, ok := readRelativeFile(resolver, reader.Location, configPath); ok && json.Unmarshal(content, &config) == nil {
+				image.metadata.Platforms = appendUnique(image.metadata.Platforms, config.String())
+			}
+		}
+		pkgs = append(pkgs, newContainerImagePackage(image.metadata, familiarRepository(image.repository), reader.Location))
+	}
+	return pkgs, nil, nil
+}
+
+// familiarRepository returns the given repository in the familiar form (e.g. "alpine" for "docker.io/library/alpine").
+func familiarRepository(repository string) string {
+	named, err := docker.ParseNormalizedNamed(repository)
+	if err != nil {
+		log.WithFields("repository", repository, "error", err).Trace("invalid image repository")
+		return repository
+	}
+	return docker.FamiliarName(named)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
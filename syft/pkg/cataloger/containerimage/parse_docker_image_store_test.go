@@ -0,0 +1,51 @@
+package containerimage
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseDockerImageStore(t *testing.T) {
+	fixture := "test-fixtures/var/lib/docker/image/overlay2/repositories.json"
+	location := source.NewLocation(fixture)
+	expected := []pkg.Package{
+		{
+			Name:         "nginx",
+			Version:      "1.25",
+			Locations:    source.NewLocationSet(location),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/nginx@sha256:f20d615f1203d5d8004d08ce71f8d41502a588b0296bb0fd14288e24eeff5cff?repository_url=nginx&tag=1.25",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:         pkg.DockerImageStoreFormat,
+				ImageID:        "sha256:05bb13f24728857a9130c33fd016b9cf32d5b54552c3c1bdab7a628c38527bab",
+				ManifestDigest: "sha256:f20d615f1203d5d8004d08ce71f8d41502a588b0296bb0fd14288e24eeff5cff",
+				Tags:           []string{"nginx:1.25"},
+				Platforms:      []string{"linux/amd64"},
+			},
+		},
+		{
+			Name:         "localhost:5000/built",
+			Version:      "dev",
+			Locations:    source.NewLocationSet(location),
+			Type:         pkg.ContainerImagePkg,
+			PURL:         "pkg:oci/built?repository_url=localhost:5000/built&tag=dev",
+			MetadataType: pkg.ContainerImageMetadataType,
+			Metadata: pkg.ContainerImageMetadata{
+				Format:    pkg.DockerImageStoreFormat,
+				ImageID:   "sha256:ef5c9da404fcf991ced3df0d49795eebf72a4e4790264a32f3cb07580d082791",
+				Tags:      []string{"localhost:5000/built:dev"},
+				Platforms: []string{"linux/amd64"},
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		FromFile(t, fixture).
+		WithResolver(source.NewMockResolverForPaths(fixturePaths(t, "test-fixtures/var/lib/docker/image/overlay2")...)).
+		Expects(expected, nil).
+		TestParser(t, parseDockerImageStore)
+}
@@ -0,0 +1,144 @@
+package containerimage
+
+import (
+	"archive/tar"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseImageArchive
+
+const (
+	// dockerManifestName is the file within an archive written by "docker save" listing the images of the archive
+	dockerManifestName = "manifest.json"
+
+	// ociLayoutName is the file marking the root of an OCI image layout (or an archive of a layout)
+	ociLayoutName = "oci-layout"
+
+	// maxArchiveMetadataSize is the most JSON content that is kept from a single archive (the manifests, indexes, and
+	// configs of the images, but none of the layers)
+	maxArchiveMetadataSize = 32 * 1024 * 1024
+)
+
+// dockerManifest is a single image within the manifest.json of an archive written by "docker save".
+type dockerManifest struct {
+	Config   string   `json:"Config"` // the path of the image config within the archive (named by the digest of the config)
+	RepoTags []string `json:"RepoTags"`
+}
+
+// parseImageArchive parses the images of an image archive, which is either written by "docker save" or is a tar of an
+// OCI image layout. Only the JSON files of the archive are kept while reading the archive, skipping the layers.
+func parseImageArchive(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	files, err := readArchiveMetadata(reader)
+	if err != nil {
+		log.WithFields("path", reader.RealPath, "error", err).Trace("not an image archive")
+		return nil, nil, nil
+	}
+
+	blobs := func(digest string) ([]byte, bool) {
+		content, ok := files[blobPath(digest)]
+		return content, ok
+	}
+	var index ociIndex
+	if content, ok := files[ociIndexName]; ok {
+		if err := json.Unmarshal(content, &index); err != nil {
+			log.WithFields("path", reader.RealPath, "error", err).Debug("unable to parse OCI image index of image archive")
+		}
+	}
+
+	archiveName := strings.TrimSuffix(path.Base(reader.RealPath), ".tar")
+	var pkgs []pkg.Package
+	if content, ok := files[dockerManifestName]; ok {
+		var manifests []dockerManifest
+		if err := json.Unmarshal(content, &manifests); err != nil {
+			log.WithFields("path", reader.RealPath, "error", err).Debug("unable to parse manifest of image archive")
+			return nil, nil, nil
+		}
+		for _, m := range manifests {
+			metadata := describeDockerManifest(m, files, index, blobs)
+			pkgs = append(pkgs, newContainerImagePackage(metadata, archiveName, reader.Location))
+		}
+		return pkgs, nil, nil
+	}
+
+	if _, ok := files[ociLayoutName]; !ok || index.Manifests == nil {
+		return nil, nil, nil
+	}
+	for _, metadata := range imagesFromIndex(index, blobs, pkg.OCIArchiveImageFormat, archiveName) {
+		pkgs = append(pkgs, newContainerImagePackage(metadata, archiveName, reader.Location))
+	}
+	return pkgs, nil, nil
+}
+
+// describeDockerManifest describes an image listed by the manifest.json of an archive written by "docker save". The
+// manifest digest is only known when the archive also holds an OCI image index (as written by newer versions of
+// docker), where the manifest of the image refers to the same config.
+func describeDockerManifest(m dockerManifest, files map[string][]byte, index ociIndex, blobs blobStore) pkg.ContainerImageMetadata {
+	metadata := pkg.ContainerImageMetadata{
+		Format: pkg.DockerArchiveImageFormat,
+		Tags:   m.RepoTags,
+	}
+
+	// the config is named by its digest, either as "<hex>.json" or as a blob ("blobs/sha256/<hex>")
+	hex := strings.TrimSuffix(path.Base(m.Config), ".json")
+	if hex != "" && hex != "." {
+		metadata.ImageID = "sha256:" + hex
+	}
+
+	var config platform
+	if content, ok := files[path.Clean(m.Config)]; ok && json.Unmarshal(content, &config) == nil {
+		metadata.Platforms = appendUnique(metadata.Platforms, config.String())
+	}
+
+	for _, descriptor := range index.Manifests {
+		var manifest ociManifest
+		if !descriptor.isIndex() && blobs.decodeBlob(descriptor.Digest, &manifest) && manifest.Config.Digest == metadata.ImageID {
+			metadata.ManifestDigest = descriptor.Digest
+			break
+		}
+	}
+	return metadata
+}
+
+// readArchiveMetadata reads the JSON files of the given tar archive by path (the layers of an image never being JSON),
+// returning an error when the content is not a tar archive.
+func readArchiveMetadata(reader io.Reader) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	var total int64
+	tr := tar.NewReader(reader)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if header.Typeflag != tar.TypeReg || header.Size > maxBlobSize || total+header.Size > maxArchiveMetadataSize {
+			continue
+		}
+
+		name := path.Clean(strings.TrimPrefix(header.Name, "./"))
+		buffered := bufio.NewReader(tr)
+		if first, err := buffered.Peek(1); err != nil || (first[0] != '{' && first[0] != '[') {
+			continue
+		}
+		content, err := io.ReadAll(buffered)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = content
+		total += int64(len(content))
+	}
+	return files, nil
+}
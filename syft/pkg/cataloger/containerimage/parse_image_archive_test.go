@@ -0,0 +1,94 @@
+package containerimage
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseImageArchive(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			name:    "docker archive with an OCI image index",
+			fixture: "test-fixtures/images/archives/app.tar",
+			expected: []pkg.Package{
+				{
+					Name:         "ghcr.io/acme/app",
+					Version:      "1.2.3",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/archives/app.tar")),
+					Type:         pkg.ContainerImagePkg,
+					PURL:         "pkg:oci/app@sha256:ae55a45dfcfc16514b6785f14ad280c505537076d3b67aa894d89bfea94eada6?repository_url=ghcr.io/acme/app&tag=1.2.3",
+					MetadataType: pkg.ContainerImageMetadataType,
+					Metadata: pkg.ContainerImageMetadata{
+						Format:         pkg.DockerArchiveImageFormat,
+						ImageID:        "sha256:6b9f882796a6b167dfecd453caa0d62e3c50d54e3b7dd9ff1c82567cc9daced1",
+						ManifestDigest: "sha256:ae55a45dfcfc16514b6785f14ad280c505537076d3b67aa894d89bfea94eada6",
+						Tags:           []string{"ghcr.io/acme/app:1.2.3", "ghcr.io/acme/app:latest"},
+						Platforms:      []string{"linux/amd64"},
+					},
+				},
+			},
+		},
+		{
+			name:    "legacy docker archive",
+			fixture: "test-fixtures/images/archives/busybox.tar",
+			expected: []pkg.Package{
+				{
+					Name:         "busybox",
+					Version:      "1.36",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/archives/busybox.tar")),
+					Type:         pkg.ContainerImagePkg,
+					PURL:         "pkg:oci/busybox?repository_url=busybox&tag=1.36",
+					MetadataType: pkg.ContainerImageMetadataType,
+					Metadata: pkg.ContainerImageMetadata{
+						Format:    pkg.DockerArchiveImageFormat,
+						ImageID:   "sha256:ba1199ed03b20c3c5e3b8deac299d5d9f43659e24f4af3f715312c3975b66176",
+						Tags:      []string{"busybox:1.36"},
+						Platforms: []string{"linux/arm/v7"},
+					},
+				},
+			},
+		},
+		{
+			name:    "OCI archive",
+			fixture: "test-fixtures/images/archives/tools.tar",
+			expected: []pkg.Package{
+				{
+					Name:         "tools",
+					Version:      "v2",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/archives/tools.tar")),
+					Type:         pkg.ContainerImagePkg,
+					PURL:         "pkg:oci/tools@sha256:b0068f2229acb9ba02e0ea7bf13da2f8c7b76ec15a07ca1ece77a670181bf2f9?repository_url=tools&tag=v2",
+					MetadataType: pkg.ContainerImageMetadataType,
+					Metadata: pkg.ContainerImageMetadata{
+						Format:         pkg.OCIArchiveImageFormat,
+						ImageID:        "sha256:eaed8092d9ceb96e689f2df582443702677f6a714347bc59a62a23ed12cbd3f1",
+						ManifestDigest: "sha256:b0068f2229acb9ba02e0ea7bf13da2f8c7b76ec15a07ca1ece77a670181bf2f9",
+						Tags:           []string{"tools:v2"},
+						Platforms:      []string{"linux/amd64"},
+					},
+				},
+			},
+		},
+		{
+			name:    "tar archive that is not an image",
+			fixture: "test-fixtures/images/archives/not-an-image.tar",
+		},
+		{
+			name:    "file that is not a tar archive",
+			fixture: "test-fixtures/images/layouts/alpine/oci-layout",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.TestFileParser(t, test.fixture, parseImageArchive, test.expected, nil)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package containerimage
+
+import (
+	"encoding/json"
+	"io"
+	"path"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseOCILayout
+
+const (
+	// ociIndexName is the file within an OCI image layout listing the images of the layout
+	ociIndexName = "index.json"
+
+	// maxBlobSize is the largest manifest, index, or config blob that is read (which are far smaller in practice)
+	maxBlobSize = 4 * 1024 * 1024
+)
+
+// ociLayoutFile is the oci-layout file marking the root of an OCI image layout.
+type ociLayoutFile struct {
+	ImageLayoutVersion string `json:"imageLayoutVersion"`
+}
+
+// parseOCILayout parses the images of an OCI image layout directory, given the oci-layout file at the root of the
+// layout.
+func parseOCILayout(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var layout ociLayoutFile
+	if err := json.NewDecoder(reader).Decode(&layout); err != nil || layout.ImageLayoutVersion == "" {
+		log.WithFields("path", reader.RealPath).Trace("not an OCI image layout")
+		return nil, nil, nil
+	}
+	if resolver == nil {
+		return nil, nil, nil
+	}
+
+	dir := path.Dir(reader.RealPath)
+	content, ok := readRelativeFile(resolver, reader.Location, path.Join(dir, ociIndexName))
+	if !ok {
+		log.WithFields("path", reader.RealPath).Debug("OCI image layout without an index")
+		return nil, nil, nil
+	}
+	var index ociIndex
+	if err := json.Unmarshal(content, &index); err != nil {
+		log.WithFields("path", path.Join(dir, ociIndexName), "error", err).Debug("unable to parse OCI image index")
+		return nil, nil, nil
+	}
+
+	blobs := func(digest string) ([]byte, bool) {
+		p := blobPath(digest)
+		if p == "" {
+			return nil, false
+		}
+		return readRelativeFile(resolver, reader.Location, path.Join(dir, p))
+	}
+
+	layoutName := path.Base(dir)
+	var pkgs []pkg.Package
+	for _, metadata := range imagesFromIndex(index, blobs, pkg.OCILayoutImageFormat, layoutName) {
+		pkgs = append(pkgs, newContainerImagePackage(metadata, layoutName, reader.Location))
+	}
+	return pkgs, nil, nil
+}
+
+// readRelativeFile reads the file at the given path (relative to the given location), returning false if the file
+// does not exist, cannot be read, or is larger than a blob may be.
+func readRelativeFile(resolver source.FileResolver, location source.Location, p string) ([]byte, bool) {
+	relative := resolver.RelativeFileByPath(location, p)
+	if relative == nil {
+		return nil, false
+	}
+	reader, err := resolver.FileContentsByLocation(*relative)
+	if err != nil {
+		log.WithFields("path", relative.RealPath, "error", err).Debug("unable to read image file")
+		return nil, false
+	}
+	defer internal.CloseAndLogError(reader, relative.RealPath)
+
+	content, err := io.ReadAll(io.LimitReader(reader, maxBlobSize+1))
+	if err != nil {
+		log.WithFields("path", relative.RealPath, "error", err).Debug("unable to read image file")
+		return nil, false
+	}
+	if len(content) > maxBlobSize {
+		log.WithFields("path", relative.RealPath).Debug("image file is too large to be a manifest or config")
+		return nil, false
+	}
+	return content, true
+}
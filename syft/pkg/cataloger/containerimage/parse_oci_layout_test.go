@@ -0,0 +1,87 @@
+package containerimage
+
+import (
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseOCILayout(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			name:    "image tagged by reference name and by containerd",
+			fixture: "test-fixtures/images/layouts/alpine",
+			expected: []pkg.Package{
+				{
+					Name:         "alpine",
+					Version:      "3.17",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/layouts/alpine/oci-layout")),
+					Type:         pkg.ContainerImagePkg,
+					PURL:         "pkg:oci/alpine@sha256:8328d198ebc5e0f15eb88a0b3e65ba1f1760a4240f2c80e760b4385d48f3f5f1?repository_url=alpine&tag=3.17",
+					MetadataType: pkg.ContainerImageMetadataType,
+					Metadata: pkg.ContainerImageMetadata{
+						Format:         pkg.OCILayoutImageFormat,
+						ImageID:        "sha256:ecef2f42f1aeedd84d30f7ec75db94047dcce6b6148e092a23753867c9729549",
+						ManifestDigest: "sha256:8328d198ebc5e0f15eb88a0b3e65ba1f1760a4240f2c80e760b4385d48f3f5f1",
+						Tags:           []string{"alpine:3.17", "alpine:latest"},
+						Platforms:      []string{"linux/amd64"},
+					},
+				},
+			},
+		},
+		{
+			name:    "multi-platform image",
+			fixture: "test-fixtures/images/layouts/multiarch",
+			expected: []pkg.Package{
+				{
+					Name:         "multiarch",
+					Version:      "1.0",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/images/layouts/multiarch/oci-layout")),
+					Type:         pkg.ContainerImagePkg,
+					PURL:         "pkg:oci/multiarch@sha256:a1f6a6d8f30b03b6dc0f9ebb18c1f7c460690bacdae453c8164663721fd3a19a?repository_url=multiarch&tag=1.0",
+					MetadataType: pkg.ContainerImageMetadataType,
+					Metadata: pkg.ContainerImageMetadata{
+						Format:         pkg.OCILayoutImageFormat,
+						ManifestDigest: "sha256:a1f6a6d8f30b03b6dc0f9ebb18c1f7c460690bacdae453c8164663721fd3a19a",
+						Tags:           []string{"multiarch:1.0"},
+						Platforms:      []string{"linux/amd64", "linux/arm64/v8"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromFile(t, test.fixture+"/oci-layout").
+				WithResolver(source.NewMockResolverForPaths(fixturePaths(t, test.fixture)...)).
+				Expects(test.expected, nil).
+				TestParser(t, parseOCILayout)
+		})
+	}
+}
+
+// fixturePaths returns the paths of all files within the given fixture directory.
+func fixturePaths(t *testing.T, dir string) []string {
+	t.Helper()
+	var paths []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			paths = append(paths, filepath.ToSlash(p))
+		}
+		return err
+	})
+	require.NoError(t, err)
+	return paths
+}
@@ -3,8 +3,8 @@ package cpp
 import (
 	"strings"
 
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -65,12 +65,5 @@ func newConanlockPackage(m pkg.ConanLockMetadata, locations ...source.Location)
 }
 
 func packageURL(name, version string) string {
-	return packageurl.NewPackageURL(
-		packageurl.TypeConan,
-		"",
-		name,
-		version,
-		nil, // TODO: no qualifiers (...yet)
-		"",
-	).ToString()
+	return purl.New(purl.TypeConan, "", name, version, nil, "")
 }
@@ -1,8 +1,8 @@
 package dart
 
 import (
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -31,26 +31,5 @@ func newPubspecLockPackage(name string, raw pubspecLockPackage, locations ...sou
 }
 
 func packageURL(m pkg.DartPubMetadata) string {
-	var qualifiers packageurl.Qualifiers
-
-	if m.HostedURL != "" {
-		qualifiers = append(qualifiers, packageurl.Qualifier{
-			Key:   "hosted_url",
-			Value: m.HostedURL,
-		})
-	} else if m.VcsURL != "" { // Default to using Hosted if somehow both are provided
-		qualifiers = append(qualifiers, packageurl.Qualifier{
-			Key:   "vcs_url",
-			Value: m.VcsURL,
-		})
-	}
-
-	return packageurl.NewPackageURL(
-		packageurl.TypePub,
-		"",
-		m.Name,
-		m.Version,
-		qualifiers,
-		"",
-	).ToString()
+	return purl.Pub(m.Name, m.Version, m.HostedURL, m.VcsURL)
 }
@@ -16,7 +16,7 @@ func TestParsePubspecLock(t *testing.T) {
 		{
 			Name:         "ale",
 			Version:      "3.3.0",
-			PURL:         "pkg:pub/ale@3.3.0?hosted_url=pub.hosted.org",
+			PURL:         "pkg:pub/ale@3.3.0?repository_url=pub.hosted.org",
 			Locations:    fixtureLocationSet,
 			Language:     pkg.Dart,
 			Type:         pkg.DartPubPkg,
@@ -12,10 +12,13 @@ import (
 func TestDpkgCataloger(t *testing.T) {
 	expected := []pkg.Package{
 		{
-			Name:     "libpam-runtime",
-			Version:  "1.1.8-3.6",
-			FoundBy:  "dpkgdb-cataloger",
-			Licenses: []string{"GPL-1", "GPL-2", "LGPL-2.1"},
+			Name:    "libpam-runtime",
+			Version: "1.1.8-3.6",
+			FoundBy: "dpkgdb-cataloger",
+			Licenses: pkg.NewLicensesFromLocation(
+				source.NewVirtualLocation("/usr/share/doc/libpam-runtime/copyright", "/usr/share/doc/libpam-runtime/copyright"),
+				"GPL-1", "GPL-2", "LGPL-2.1",
+			),
 			Locations: source.NewLocationSet(
 				source.NewVirtualLocation("/var/lib/dpkg/status", "/var/lib/dpkg/status"),
 				source.NewVirtualLocation("/var/lib/dpkg/info/libpam-runtime.md5sums", "/var/lib/dpkg/info/libpam-runtime.md5sums"),
@@ -31,6 +34,11 @@ func TestDpkgCataloger(t *testing.T) {
 				Architecture:  "all",
 				Maintainer:    "Steve Langasek <vorlon@debian.org>",
 				InstalledSize: 1016,
+				Depends: []string{
+					"debconf (>= 0.5) | debconf-2.0",
+					"debconf (>= 1.5.19) | cdebconf",
+					"libpam-modules (>= 1.0.1-6)",
+				},
 				Description: `Runtime support for the PAM library
  Contains configuration files and  directories required for
  authentication  to work on Debian systems.  This package is required
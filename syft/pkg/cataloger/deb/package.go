@@ -1,17 +1,16 @@
 package deb
 
 import (
-	"fmt"
 	"io"
 	"path"
 	"path/filepath"
 	"sort"
 
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -47,37 +46,7 @@ func newDpkgPackage(d pkg.DpkgMetadata, dbLocation source.Location, resolver sou
 
 // PackageURL returns the PURL for the specific Debian package (see https://github.com/package-url/purl-spec)
 func packageURL(m pkg.DpkgMetadata, distro *linux.Release) string {
-	if distro == nil {
-		return ""
-	}
-
-	if distro.ID != "debian" && !internal.StringInSlice("debian", distro.IDLike) {
-		return ""
-	}
-
-	qualifiers := map[string]string{
-		pkg.PURLQualifierArch: m.Architecture,
-	}
-
-	if m.Source != "" {
-		if m.SourceVersion != "" {
-			qualifiers[pkg.PURLQualifierUpstream] = fmt.Sprintf("%s@%s", m.Source, m.SourceVersion)
-		} else {
-			qualifiers[pkg.PURLQualifierUpstream] = m.Source
-		}
-	}
-
-	return packageurl.NewPackageURL(
-		packageurl.TypeDebian,
-		distro.ID,
-		m.Package,
-		m.Version,
-		pkg.PURLQualifiers(
-			qualifiers,
-			distro,
-		),
-		"",
-	).ToString()
+	return purl.Deb(distro, m.Package, m.Version, m.Architecture, m.Source, m.SourceVersion)
 }
 
 func addLicenses(resolver source.FileResolver, dbLocation source.Location, p *pkg.Package) {
@@ -89,7 +58,7 @@ func addLicenses(resolver source.FileResolver, dbLocation source.Location, p *pk
 	if copyrightReader != nil && copyrightLocation != nil {
 		defer internal.CloseAndLogError(copyrightReader, copyrightLocation.VirtualPath)
 		// attach the licenses
-		p.Licenses = parseLicensesFromCopyright(copyrightReader)
+		p.Licenses = pkg.NewLicensesFromLocation(*copyrightLocation, parseLicensesFromCopyright(copyrightReader)...)
 
 		// keep a record of the file where this was discovered
 		p.Locations.Add(*copyrightLocation)
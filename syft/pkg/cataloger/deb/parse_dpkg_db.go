@@ -16,14 +16,22 @@ import (
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/dependency"
 	"github.com/anchore/syft/syft/source"
 )
 
 var (
 	errEndOfPackages = fmt.Errorf("no more packages to read")
 	sourceRegexp     = regexp.MustCompile(`(?P<name>\S+)( \((?P<version>.*)\))?`)
+	// the package name of a relationship field entry, without the version constraint (e.g. " (>= 2.14)"),
+	// architecture qualifier (e.g. ":any"), or architecture restriction (e.g. " [amd64]")
+	relationshipNameRegexp = regexp.MustCompile(`^\s*([^\s:(\[]+)`)
 )
 
+// relationshipFields are the fields of a dpkg status entry that list other packages (see
+// https://www.debian.org/doc/debian-policy/ch-relationships.html)
+var relationshipFields = []string{"Provides", "Depends", "PreDepends"}
+
 func parseDpkgDB(resolver source.FileResolver, env *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
 	metadata, err := parseDpkgStatus(reader)
 	if err != nil {
@@ -35,7 +43,46 @@ func parseDpkgDB(resolver source.FileResolver, env *generic.Environment, reader
 		pkgs = append(pkgs, newDpkgPackage(m, reader.Location, resolver, env.LinuxRelease))
 	}
 
-	return pkgs, nil, nil
+	return pkgs, dependency.Resolve(dependencySpecification, pkgs), nil
+}
+
+// dependencySpecification describes the package names that a debian package provides and depends on, where any one
+// of the alternatives of a dependency (separated by "|") satisfies the dependency.
+func dependencySpecification(p pkg.Package) dependency.Specification {
+	metadata, ok := p.Metadata.(pkg.DpkgMetadata)
+	if !ok {
+		return dependency.Specification{}
+	}
+
+	provides := []string{p.Name}
+	for _, entry := range metadata.Provides {
+		provides = append(provides, relationshipName(entry))
+	}
+
+	var requires []dependency.Requirement
+	for _, entries := range [][]string{metadata.PreDepends, metadata.Depends} {
+		for _, entry := range entries {
+			var alternatives dependency.Requirement
+			for _, alternative := range strings.Split(entry, "|") {
+				alternatives = append(alternatives, relationshipName(alternative))
+			}
+			requires = append(requires, alternatives)
+		}
+	}
+
+	return dependency.Specification{
+		Provides: provides,
+		Requires: requires,
+	}
+}
+
+// relationshipName returns the package name of a single relationship field entry (e.g. "libc6" from "libc6 (>= 2.14)").
+func relationshipName(entry string) string {
+	match := relationshipNameRegexp.FindStringSubmatch(entry)
+	if len(match) < 2 {
+		return ""
+	}
+	return match[1]
 }
 
 // parseDpkgStatus is a parser function for Debian DB status contents, returning all Debian packages listed.
@@ -77,6 +124,12 @@ func parseDpkgStatusEntry(reader *bufio.Reader) (*pkg.DpkgMetadata, error) {
 		retErr = err
 	}
 
+	for _, key := range relationshipFields {
+		if value, ok := dpkgFields[key].(string); ok {
+			dpkgFields[key] = splitRelationshipField(value)
+		}
+	}
+
 	entry := pkg.DpkgMetadata{}
 	err = mapstructure.Decode(dpkgFields, &entry)
 	if err != nil {
@@ -172,6 +225,18 @@ func extractSourceVersion(source string) (string, string) {
 	return match["name"], match["version"]
 }
 
+// splitRelationshipField splits the comma separated entries of a relationship field (e.g. "libc6 (>= 2.14), debconf | debconf-2.0").
+func splitRelationshipField(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.Join(strings.Fields(entry), " ")
+		if entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
 // handleNewKeyValue parse a new key-value pair from the given unprocessed line
 func handleNewKeyValue(line string) (key string, val interface{}, err error) {
 	if i := strings.Index(line, ":"); i > 0 {
@@ -14,6 +14,7 @@ import (
 	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/linux"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/dependency"
 	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
 	"github.com/anchore/syft/syft/source"
 )
@@ -35,6 +36,18 @@ func Test_parseDpkgStatus(t *testing.T) {
 					Architecture:  "amd64",
 					InstalledSize: 4064,
 					Maintainer:    "APT Development Team <deity@lists.debian.org>",
+					Provides:      []string{"apt-transport-https (= 1.8.2)"},
+					Depends: []string{
+						"adduser",
+						"gpgv | gpgv2 | gpgv1",
+						"debian-archive-keyring",
+						"libapt-pkg5.0 (>= 1.7.0~alpha3~)",
+						"libc6 (>= 2.15)",
+						"libgcc1 (>= 1:3.0)",
+						"libgnutls30 (>= 3.6.6)",
+						"libseccomp2 (>= 1.0.1)",
+						"libstdc++6 (>= 5.2)",
+					},
 					Description: `commandline package manager
  This package provides commandline tools for searching and
  managing as well as querying information about packages
@@ -97,6 +110,18 @@ func Test_parseDpkgStatus(t *testing.T) {
 					Architecture:  "amd64",
 					InstalledSize: 4000,
 					Maintainer:    "APT Development Team <deity@lists.debian.org>",
+					Provides:      []string{"apt-transport-https (= 1.8.2)"},
+					Depends: []string{
+						"adduser",
+						"gpgv | gpgv2 | gpgv1",
+						"debian-archive-keyring",
+						"libapt-pkg5.0 (>= 1.7.0~alpha3~)",
+						"libc6 (>= 2.15)",
+						"libgcc1 (>= 1:3.0)",
+						"libgnutls30 (>= 3.6.6)",
+						"libseccomp2 (>= 1.0.1)",
+						"libstdc++6 (>= 5.2)",
+					},
 					Description: `commandline package manager
  This package provides commandline tools for searching and
  managing as well as querying information about packages
@@ -130,6 +155,8 @@ func Test_parseDpkgStatus(t *testing.T) {
 					Architecture:  "all",
 					InstalledSize: 3036,
 					Maintainer:    "GNU Libc Maintainers <debian-glibc@lists.debian.org>",
+					Provides:      []string{"tzdata-buster"},
+					Depends:       []string{"debconf (>= 0.5) | debconf-2.0"},
 					Description: `time zone and daylight-saving time data
  This package contains data required for the implementation of
  standard local time for many representative locations around the
@@ -144,6 +171,22 @@ func Test_parseDpkgStatus(t *testing.T) {
 					Architecture:  "amd64",
 					InstalledSize: 4327,
 					Maintainer:    "LaMont Jones <lamont@debian.org>",
+					Depends:       []string{"fdisk", "login (>= 1:4.5-1.1~)"},
+					PreDepends: []string{
+						"libaudit1 (>= 1:2.2.1)",
+						"libblkid1 (>= 2.31.1)",
+						"libc6 (>= 2.25)",
+						"libcap-ng0 (>= 0.7.9)",
+						"libmount1 (>= 2.25)",
+						"libpam0g (>= 0.99.7.1)",
+						"libselinux1 (>= 2.6-3~)",
+						"libsmartcols1 (>= 2.33)",
+						"libsystemd0",
+						"libtinfo6 (>= 6)",
+						"libudev1 (>= 183)",
+						"libuuid1 (>= 2.16)",
+						"zlib1g (>= 1:1.1.4)",
+					},
 					Description: `miscellaneous system utilities
  This package contains a number of important utilities, most of which
  are oriented towards maintenance of your system. Some of the more
@@ -223,6 +266,30 @@ func Test_parseDpkgStatus(t *testing.T) {
 	}
 }
 
+func Test_dependencySpecification(t *testing.T) {
+	p := pkg.Package{
+		Name: "apt",
+		Metadata: pkg.DpkgMetadata{
+			Package:    "apt",
+			Provides:   []string{"apt-transport-https (= 1.8.2)"},
+			Depends:    []string{"adduser", "gpgv | gpgv2 | gpgv1", "libc6 (>= 2.15)"},
+			PreDepends: []string{"python3:any (>= 3.5~)"},
+		},
+	}
+
+	expected := dependency.Specification{
+		Provides: []string{"apt", "apt-transport-https"},
+		Requires: []dependency.Requirement{
+			{"python3"},
+			{"adduser"},
+			{"gpgv", "gpgv2", "gpgv1"},
+			{"libc6"},
+		},
+	}
+
+	assert.Equal(t, expected, dependencySpecification(p))
+}
+
 func TestSourceVersionExtract(t *testing.T) {
 	tests := []struct {
 		name     string
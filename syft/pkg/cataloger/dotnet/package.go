@@ -3,8 +3,8 @@ package dotnet
 import (
 	"strings"
 
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -42,14 +42,5 @@ func newDotnetDepsPackage(nameVersion string, lib dotnetDepsLibrary, locations .
 }
 
 func packageURL(m pkg.DotnetDepsMetadata) string {
-	var qualifiers packageurl.Qualifiers
-
-	return packageurl.NewPackageURL(
-		packageurl.TypeDotnet,
-		"",
-		m.Name,
-		m.Version,
-		qualifiers,
-		"",
-	).ToString()
+	return purl.New(purl.TypeNuGet, "", m.Name, m.Version, nil, "")
 }
@@ -0,0 +1,46 @@
+package cataloger
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var errTimedOut = errors.New("cataloging timed out before the cataloger finished")
+
+// CatalogerError describes the failure of a single cataloger, whose packages are missing from the results.
+type CatalogerError struct {
+	// Cataloger is the name of the cataloger that failed
+	Cataloger string
+	// Err is the error returned by the cataloger (or describes the panic or timeout)
+	Err error
+}
+
+func (e CatalogerError) Error() string {
+	return fmt.Sprintf("cataloger=%q failed: %v", e.Cataloger, e.Err)
+}
+
+func (e CatalogerError) Unwrap() error {
+	return e.Err
+}
+
+// PartialResultsError is returned when some catalogers failed. Unlike other errors, it is returned along with the
+// results of all other catalogers, which remain usable (though incomplete).
+type PartialResultsError struct {
+	Failures []CatalogerError
+}
+
+func (e *PartialResultsError) Error() string {
+	var messages []string
+	for _, f := range e.Failures {
+		messages = append(messages, f.Error())
+	}
+	return fmt.Sprintf("cataloging results are incomplete: %s", strings.Join(messages, "; "))
+}
+
+// IsPartialResults indicates that the given error only reports that some catalogers failed, so the results returned
+// with the error are still usable.
+func IsPartialResults(err error) bool {
+	var partial *PartialResultsError
+	return errors.As(err, &partial)
+}
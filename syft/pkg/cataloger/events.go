@@ -0,0 +1,37 @@
+package cataloger
+
+import (
+	"context"
+	"time"
+
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/anchore/syft/internal/bus"
+	"github.com/anchore/syft/syft/event/monitor"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func publish(ctx context.Context, e monitor.Event) {
+	bus.PublishWithContext(ctx, partybus.Event{
+		Type:  e.Type(),
+		Value: e,
+	})
+}
+
+func publishCatalogerStarted(ctx context.Context, c pkg.Cataloger) {
+	publish(ctx, monitor.CatalogerStarted{Cataloger: c.Name()})
+}
+
+// publishCatalogerResult publishes the failure of the given cataloger, or each package it found followed by its
+// completion. Note: this is called after publishCatalogerStarted for the same cataloger, but possibly after other
+// catalogers have started (the order of events only holds per cataloger).
+func publishCatalogerResult(ctx context.Context, c pkg.Cataloger, result catalogResult, duration time.Duration) {
+	if result.err != nil {
+		publish(ctx, monitor.CatalogerFailed{Cataloger: c.Name(), Err: result.err})
+		return
+	}
+	for _, p := range result.packages {
+		publish(ctx, monitor.PackageDiscovered{Cataloger: c.Name(), Package: p})
+	}
+	publish(ctx, monitor.CatalogerFinished{Cataloger: c.Name(), Packages: len(result.packages), Duration: duration})
+}
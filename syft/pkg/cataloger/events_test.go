@@ -0,0 +1,71 @@
+package cataloger
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/wagoodman/go-partybus"
+
+	"github.com/anchore/syft/internal/bus"
+	"github.com/anchore/syft/syft/event/monitor"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestCatalog_publishesTypedEvents(t *testing.T) {
+	var lock sync.Mutex
+	var events []monitor.Event
+	ctx := bus.WithSubscriber(context.Background(), func(e partybus.Event) {
+		if typed, ok := e.Value.(monitor.Event); ok {
+			assert.Equal(t, typed.Type(), e.Type)
+			lock.Lock()
+			events = append(events, typed)
+			lock.Unlock()
+		}
+	})
+
+	failure := errors.New("bad cataloger")
+	_, _, err := CatalogWithContext(ctx, source.NewMockResolverForPaths(), nil, Options{Parallelism: 1}, delayedCataloger{name: "good"}, delayedCataloger{name: "bad", err: failure})
+	require.True(t, IsPartialResults(err))
+
+	// the events of different catalogers may interleave, so only the order of the events of each cataloger is fixed
+	require.Len(t, events, 5)
+	byCataloger := make(map[string][]monitor.Event)
+	for _, e := range events {
+		var name string
+		switch typed := e.(type) {
+		case monitor.CatalogerStarted:
+			name = typed.Cataloger
+		case monitor.PackageDiscovered:
+			name = typed.Cataloger
+		case monitor.CatalogerFinished:
+			name = typed.Cataloger
+		case monitor.CatalogerFailed:
+			name = typed.Cataloger
+		}
+		byCataloger[name] = append(byCataloger[name], e)
+	}
+
+	good := byCataloger["good"]
+	require.Len(t, good, 3)
+	assert.Equal(t, monitor.CatalogerStarted{Cataloger: "good"}, good[0])
+	discovered, ok := good[1].(monitor.PackageDiscovered)
+	require.True(t, ok)
+	assert.Equal(t, "good", discovered.Package.Name)
+	finished, ok := good[2].(monitor.CatalogerFinished)
+	require.True(t, ok)
+	assert.Equal(t, 1, finished.Packages)
+
+	assert.Equal(t, []monitor.Event{
+		monitor.CatalogerStarted{Cataloger: "bad"},
+		monitor.CatalogerFailed{Cataloger: "bad", Err: failure},
+	}, byCataloger["bad"])
+
+	// the events of cataloging with other contexts are not received
+	_, _, err = Catalog(source.NewMockResolverForPaths(), nil, 1, delayedCataloger{name: "good"})
+	require.NoError(t, err)
+	assert.Len(t, events, 5)
+}
@@ -0,0 +1,29 @@
+/*
+Package extension provides concrete Cataloger implementations for the extensions installed for editors and browsers,
+as found on the home directories of workstations.
+*/
+package extension
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const (
+	vscodeCatalogerName  = "vscode-extension-cataloger"
+	browserCatalogerName = "browser-extension-cataloger"
+)
+
+// NewVSCodeExtensionCataloger returns a new cataloger object for the extensions installed for VS Code (and editors
+// derived from it) within "~/.vscode/extensions" (or "~/.vscode-server/extensions", "~/.vscode-oss/extensions", etc).
+func NewVSCodeExtensionCataloger() *generic.Cataloger {
+	return generic.NewCataloger(vscodeCatalogerName).
+		WithParserByGlobs(parseVSCodeExtension, "**/.vscode*/extensions/*/package.json")
+}
+
+// NewBrowserExtensionCataloger returns a new cataloger object for the extensions installed within the profiles of
+// Chrome (and browsers based on Chromium) and Firefox.
+func NewBrowserExtensionCataloger() *generic.Cataloger {
+	return generic.NewCataloger(browserCatalogerName).
+		WithParserByGlobs(parseChromeExtension, "**/Extensions/*/*/manifest.json").
+		WithParserByGlobs(parseFirefoxExtensions, "**/.mozilla/firefox/*/extensions.json", "**/Firefox/Profiles/*/extensions.json")
+}
@@ -0,0 +1,57 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+var fixturePaths = []string{
+	"test-fixtures/home/.vscode/extensions/.obsolete",
+	"test-fixtures/home/.vscode/extensions/golang.go-0.40.0/package.json",
+	"test-fixtures/home/.vscode/extensions/ms-python.python-2023.18.0/package.json",
+	"test-fixtures/home/.vscode/extensions/ms-python.python-2023.20.0/package.json",
+	"test-fixtures/home/.vscode/extensions/ms-python.python-2023.20.0/package.nls.json",
+	"test-fixtures/home/.config/google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.52.2_0/_locales/en/messages.json",
+	"test-fixtures/home/.config/google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.52.2_0/manifest.json",
+	"test-fixtures/home/.config/google-chrome/Default/Extensions/nngceckbapebfimnlniiiahkandclblb/2023.10.2_0/manifest.json",
+	"test-fixtures/home/.mozilla/firefox/x7k2m4qp.default-release/extensions.json",
+	"test-fixtures/home/not-extensions/Extensions/settings/v1/manifest.json",
+}
+
+func TestVSCodeExtensionCataloger(t *testing.T) {
+	pkgs, _, err := NewVSCodeExtensionCataloger().Catalog(source.NewMockResolverForPaths(fixturePaths...))
+	require.NoError(t, err)
+
+	var found []string
+	for _, p := range pkgs {
+		assert.Equal(t, vscodeCatalogerName, p.FoundBy)
+		assert.Equal(t, pkg.VSCodeExtensionPkg, p.Type)
+		found = append(found, p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{
+		"golang.go@0.40.0",
+		"ms-python.python@2023.20.0",
+	}, found)
+}
+
+func TestBrowserExtensionCataloger(t *testing.T) {
+	pkgs, _, err := NewBrowserExtensionCataloger().Catalog(source.NewMockResolverForPaths(fixturePaths...))
+	require.NoError(t, err)
+
+	var found []string
+	for _, p := range pkgs {
+		assert.Equal(t, browserCatalogerName, p.FoundBy)
+		found = append(found, string(p.Type)+":"+p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{
+		"chrome-extension:uBlock Origin@1.52.2",
+		"chrome-extension:Bitwarden - Free Password Manager@2023.10.2",
+		"firefox-extension:uBlock Origin@1.52.2",
+		"firefox-extension:Bitwarden - Free Password Manager@2023.10.1",
+	}, found)
+}
@@ -0,0 +1,48 @@
+package extension
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+// newVSCodeExtensionPackage creates a package for a VS Code extension, which is named by the extension identifier
+// (e.g. "ms-python.python").
+func newVSCodeExtensionPackage(metadata pkg.VSCodeExtensionMetadata, version, license string, location source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         metadata.Publisher + "." + metadata.Name,
+		Version:      version,
+		Licenses:     pkg.NewLicensesFromLocation(location, license),
+		Locations:    source.NewLocationSet(location),
+		Type:         pkg.VSCodeExtensionPkg,
+		PURL:         purl.New(purl.TypeGeneric, metadata.Publisher, metadata.Name, version, nil, ""),
+		MetadataType: pkg.VSCodeExtensionMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// newBrowserExtensionPackage creates a package for a browser extension, which is named by the extension ID when the
+// name of the extension is unknown.
+func newBrowserExtensionPackage(t pkg.Type, name, version string, metadata pkg.BrowserExtensionMetadata, location source.Location) pkg.Package {
+	if name == "" {
+		name = metadata.ID
+	}
+
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(location),
+		Type:         t,
+		PURL:         purl.New(purl.TypeGeneric, "", metadata.ID, version, nil, ""),
+		MetadataType: pkg.BrowserExtensionMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
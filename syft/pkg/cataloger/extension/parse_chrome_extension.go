@@ -0,0 +1,135 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseChromeExtension
+
+// chromeExtensionIDPattern matches the ID of a Chrome extension, which is derived from the public key of the extension
+// (32 characters within "a" to "p")
+var chromeExtensionIDPattern = regexp.MustCompile(`^[a-p]{32}$`)
+
+// chromeMessagePattern matches a localized field value of a Chrome extension manifest (e.g. "__MSG_appName__")
+var chromeMessagePattern = regexp.MustCompile(`^__MSG_(\w+)__$`)
+
+// chromeManifest is the manifest (manifest.json) of a Chrome extension (see
+// https://developer.chrome.com/docs/extensions/reference/manifest).
+type chromeManifest struct {
+	Name            string        `json:"name"`
+	Version         string        `json:"version"`
+	ManifestVersion int           `json:"manifest_version"`
+	DefaultLocale   string        `json:"default_locale"`
+	Author          interface{}   `json:"author"`      // either a name or (with manifest V3) an object with an email
+	Permissions     []interface{} `json:"permissions"` // with manifest V2, the host permissions are listed as permissions too
+	HostPermissions []string      `json:"host_permissions"`
+}
+
+// parseChromeExtension parses the manifest of a Chrome extension installed within a browser profile, which is at
+// "Extensions/<extension ID>/<version>/manifest.json" of the profile directory.
+func parseChromeExtension(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	id := path.Base(path.Dir(path.Dir(reader.RealPath)))
+	if !chromeExtensionIDPattern.MatchString(id) {
+		log.WithFields("path", reader.RealPath).Trace("manifest is not within the directory of a Chrome extension")
+		return nil, nil, nil
+	}
+
+	var manifest chromeManifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Chrome extension manifest: %w", err)
+	}
+	if manifest.Version == "" {
+		log.WithFields("path", reader.RealPath).Trace("Chrome extension manifest without a version")
+		return nil, nil, nil
+	}
+
+	name := manifest.Name
+	if match := chromeMessagePattern.FindStringSubmatch(name); match != nil {
+		name = ""
+		if resolver != nil && manifest.DefaultLocale != "" {
+			name = chromeMessage(resolver, reader.Location, manifest.DefaultLocale, match[1])
+		}
+	}
+
+	permissions, hostPermissions := chromePermissions(manifest)
+
+	return []pkg.Package{
+		newBrowserExtensionPackage(
+			pkg.ChromeExtensionPkg,
+			name,
+			manifest.Version,
+			pkg.BrowserExtensionMetadata{
+				Browser:         "chrome",
+				ID:              id,
+				Author:          chromeAuthor(manifest.Author),
+				ManifestVersion: manifest.ManifestVersion,
+				Permissions:     permissions,
+				HostPermissions: hostPermissions,
+			},
+			reader.Location,
+		),
+	}, nil, nil
+}
+
+// chromeMessage returns the message of the given name for the given locale of the extension (from
+// "_locales/<locale>/messages.json"), where message names are case-insensitive.
+func chromeMessage(resolver source.FileResolver, location source.Location, locale, name string) string {
+	var messages map[string]struct {
+		Message string `json:"message"`
+	}
+	p := path.Join(path.Dir(location.RealPath), "_locales", locale, "messages.json")
+	if decodeRelativeFile(resolver, location, p, &messages) == nil {
+		return ""
+	}
+	for key, message := range messages {
+		if strings.EqualFold(key, name) {
+			return message.Message
+		}
+	}
+	return ""
+}
+
+// chromePermissions returns the API permissions and the host permissions (URL match patterns) of the extension.
+func chromePermissions(manifest chromeManifest) ([]string, []string) {
+	var permissions []string
+	hostPermissions := manifest.HostPermissions
+	for _, p := range manifest.Permissions {
+		// some permissions of Chrome apps are objects (e.g. {"fileSystem": ["write"]}), which are not extensions
+		permission, ok := p.(string)
+		if !ok {
+			continue
+		}
+		if isHostPermission(permission) {
+			hostPermissions = append(hostPermissions, permission)
+			continue
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, hostPermissions
+}
+
+func isHostPermission(permission string) bool {
+	return permission == "<all_urls>" || strings.Contains(permission, "://")
+}
+
+func chromeAuthor(author interface{}) string {
+	switch a := author.(type) {
+	case string:
+		return a
+	case map[string]interface{}:
+		if email, ok := a["email"].(string); ok {
+			return email
+		}
+	}
+	return ""
+}
@@ -0,0 +1,75 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseChromeExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			name:    "manifest V2 with a localized name",
+			fixture: "test-fixtures/home/.config/google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.52.2_0/manifest.json",
+			expected: []pkg.Package{
+				{
+					Name:         "uBlock Origin",
+					Version:      "1.52.2",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/home/.config/google-chrome/Default/Extensions/cjpalhdlnbpafiamejdnhcphjbkeiagm/1.52.2_0/manifest.json")),
+					Type:         pkg.ChromeExtensionPkg,
+					PURL:         "pkg:generic/cjpalhdlnbpafiamejdnhcphjbkeiagm@1.52.2",
+					MetadataType: pkg.BrowserExtensionMetadataType,
+					Metadata: pkg.BrowserExtensionMetadata{
+						Browser:         "chrome",
+						ID:              "cjpalhdlnbpafiamejdnhcphjbkeiagm",
+						Author:          "Raymond Hill & contributors",
+						ManifestVersion: 2,
+						Permissions:     []string{"contextMenus", "privacy", "storage", "tabs", "unlimitedStorage", "webNavigation", "webRequest", "webRequestBlocking"},
+						HostPermissions: []string{"<all_urls>"},
+					},
+				},
+			},
+		},
+		{
+			name:    "manifest V3",
+			fixture: "test-fixtures/home/.config/google-chrome/Default/Extensions/nngceckbapebfimnlniiiahkandclblb/2023.10.2_0/manifest.json",
+			expected: []pkg.Package{
+				{
+					Name:         "Bitwarden - Free Password Manager",
+					Version:      "2023.10.2",
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/home/.config/google-chrome/Default/Extensions/nngceckbapebfimnlniiiahkandclblb/2023.10.2_0/manifest.json")),
+					Type:         pkg.ChromeExtensionPkg,
+					PURL:         "pkg:generic/nngceckbapebfimnlniiiahkandclblb@2023.10.2",
+					MetadataType: pkg.BrowserExtensionMetadataType,
+					Metadata: pkg.BrowserExtensionMetadata{
+						Browser:         "chrome",
+						ID:              "nngceckbapebfimnlniiiahkandclblb",
+						Author:          "hello@bitwarden.com",
+						ManifestVersion: 3,
+						Permissions:     []string{"tabs", "contextMenus", "storage", "unlimitedStorage", "clipboardRead", "clipboardWrite", "idle", "scripting"},
+						HostPermissions: []string{"https://*/*", "http://*/*"},
+					},
+				},
+			},
+		},
+		{
+			name:    "not within an extension directory",
+			fixture: "test-fixtures/home/not-extensions/Extensions/settings/v1/manifest.json",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromFile(t, test.fixture).
+				WithResolver(source.NewMockResolverForPaths(fixturePaths...)).
+				Expects(test.expected, nil).
+				TestParser(t, parseChromeExtension)
+		})
+	}
+}
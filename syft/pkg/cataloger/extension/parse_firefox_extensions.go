@@ -0,0 +1,72 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseFirefoxExtensions
+
+// firefoxBuiltinLocations are the install locations of the add-ons that are part of Firefox itself (e.g. the built-in
+// themes), which are not cataloged
+var firefoxBuiltinLocations = map[string]bool{
+	"app-builtin":         true,
+	"app-system-defaults": true,
+	"app-system-addons":   true,
+}
+
+// firefoxExtensionsDB is the database of the add-ons installed within a Firefox profile (extensions.json).
+type firefoxExtensionsDB struct {
+	Addons []struct {
+		ID              string `json:"id"`
+		Version         string `json:"version"`
+		Type            string `json:"type"`     // e.g. "extension", "theme", "dictionary", or "locale"
+		Location        string `json:"location"` // e.g. "app-profile" (installed by the user) or "app-builtin"
+		Active          bool   `json:"active"`
+		ManifestVersion int    `json:"manifestVersion"`
+		DefaultLocale   struct {
+			Name    string `json:"name"`
+			Creator string `json:"creator"`
+		} `json:"defaultLocale"`
+		UserPermissions *struct {
+			Permissions []string `json:"permissions"`
+			Origins     []string `json:"origins"`
+		} `json:"userPermissions"`
+	} `json:"addons"`
+}
+
+// parseFirefoxExtensions parses the add-ons database of a Firefox profile, returning the add-ons installed within the
+// profile (or by the system administrator) but not those that are part of Firefox itself.
+func parseFirefoxExtensions(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var db firefoxExtensionsDB
+	if err := json.NewDecoder(reader).Decode(&db); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Firefox extensions database: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	for _, addon := range db.Addons {
+		if addon.ID == "" || addon.Version == "" || firefoxBuiltinLocations[addon.Location] {
+			continue
+		}
+
+		metadata := pkg.BrowserExtensionMetadata{
+			Browser:         "firefox",
+			ID:              addon.ID,
+			Author:          addon.DefaultLocale.Creator,
+			ManifestVersion: addon.ManifestVersion,
+			Disabled:        !addon.Active,
+		}
+		if addon.UserPermissions != nil {
+			metadata.Permissions = addon.UserPermissions.Permissions
+			metadata.HostPermissions = addon.UserPermissions.Origins
+		}
+
+		pkgs = append(pkgs, newBrowserExtensionPackage(pkg.FirefoxExtensionPkg, addon.DefaultLocale.Name, addon.Version, metadata, reader.Location))
+	}
+	return pkgs, nil, nil
+}
@@ -0,0 +1,51 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseFirefoxExtensions(t *testing.T) {
+	fixture := "test-fixtures/home/.mozilla/firefox/x7k2m4qp.default-release/extensions.json"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expected := []pkg.Package{
+		{
+			Name:         "uBlock Origin",
+			Version:      "1.52.2",
+			Locations:    locations,
+			Type:         pkg.FirefoxExtensionPkg,
+			PURL:         "pkg:generic/uBlock0%40raymondhill.net@1.52.2",
+			MetadataType: pkg.BrowserExtensionMetadataType,
+			Metadata: pkg.BrowserExtensionMetadata{
+				Browser:         "firefox",
+				ID:              "uBlock0@raymondhill.net",
+				Author:          "Raymond Hill & contributors",
+				ManifestVersion: 2,
+				Permissions:     []string{"dns", "menus", "privacy", "storage", "tabs", "unlimitedStorage", "webNavigation", "webRequest", "webRequestBlocking"},
+				HostPermissions: []string{"<all_urls>", "http://*/*", "https://*/*"},
+			},
+		},
+		{
+			Name:         "Bitwarden - Free Password Manager",
+			Version:      "2023.10.1",
+			Locations:    locations,
+			Type:         pkg.FirefoxExtensionPkg,
+			PURL:         "pkg:generic/{446900e4-71c2-419f-a6a7-df9c091e268b}@2023.10.1",
+			MetadataType: pkg.BrowserExtensionMetadataType,
+			Metadata: pkg.BrowserExtensionMetadata{
+				Browser:         "firefox",
+				ID:              "{446900e4-71c2-419f-a6a7-df9c091e268b}",
+				Author:          "Bitwarden Inc.",
+				ManifestVersion: 2,
+				Permissions:     []string{"tabs", "contextMenus", "storage"},
+				HostPermissions: []string{"https://*/*"},
+				Disabled:        true,
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseFirefoxExtensions, expected, nil)
+}
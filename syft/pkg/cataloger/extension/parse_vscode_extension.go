@@ -0,0 +1,170 @@
+package extension
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseVSCodeExtension
+
+const (
+	// vscodeObsoleteName is the file within the extensions directory listing the extensions that were uninstalled (or
+	// replaced by a newer version), which the editor deletes on the next start
+	vscodeObsoleteName = ".obsolete"
+
+	// vscodeNLSName is the file within an extension directory holding the (english) values of localized fields
+	vscodeNLSName = "package.nls.json"
+
+	// universalTargetPlatform is the target platform recorded for extensions that are not platform-specific
+	universalTargetPlatform = "undefined"
+)
+
+// vscodePackageJSON is the extension manifest (package.json) of a VS Code extension.
+type vscodePackageJSON struct {
+	Name        string     `json:"name"`
+	Publisher   string     `json:"publisher"`
+	Version     string     `json:"version"`
+	DisplayName string     `json:"displayName"`
+	License     string     `json:"license"`
+	Repository  repository `json:"repository"`
+	Engines     struct {
+		VSCode string `json:"vscode"`
+	} `json:"engines"`
+	// the details of the installation, added to the manifest by the editor when installing from a marketplace
+	Metadata struct {
+		TargetPlatform string `json:"targetPlatform"`
+	} `json:"__metadata"`
+}
+
+// repository is the repository field of an extension manifest, given as either a URL or an object with a URL.
+type repository struct {
+	URL string `json:"url"`
+}
+
+func (r *repository) UnmarshalJSON(b []byte) error {
+	var url string
+	if err := json.Unmarshal(b, &url); err == nil {
+		r.URL = url
+		return nil
+	}
+
+	type repositoryObject repository
+	var obj repositoryObject
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return fmt.Errorf("unable to parse repository: %w", err)
+	}
+	*r = repository(obj)
+	return nil
+}
+
+// parseVSCodeExtension parses the manifest of an installed VS Code extension, skipping extensions that are obsolete.
+func parseVSCodeExtension(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var manifest vscodePackageJSON
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse VS Code extension manifest: %w", err)
+	}
+	if manifest.Publisher == "" || manifest.Name == "" || manifest.Version == "" {
+		log.WithFields("path", reader.RealPath).Trace("VS Code extension manifest without a publisher, name, or version")
+		return nil, nil, nil
+	}
+
+	extensionDir := path.Dir(reader.RealPath)
+	displayName := manifest.DisplayName
+	if resolver != nil {
+		var obsolete map[string]bool
+		decodeRelativeFile(resolver, reader.Location, path.Join(path.Dir(extensionDir), vscodeObsoleteName), &obsolete)
+		if obsolete[path.Base(extensionDir)] {
+			log.WithFields("path", reader.RealPath).Trace("skipping obsolete VS Code extension")
+			return nil, nil, nil
+		}
+
+		if key, ok := nlsKey(displayName); ok {
+			var nls map[string]nlsValue
+			decodeRelativeFile(resolver, reader.Location, path.Join(extensionDir, vscodeNLSName), &nls)
+			displayName = nls[key].Message
+		}
+	}
+	if _, ok := nlsKey(displayName); ok {
+		displayName = ""
+	}
+	targetPlatform := manifest.Metadata.TargetPlatform
+	if targetPlatform == universalTargetPlatform {
+		targetPlatform = ""
+	}
+
+	return []pkg.Package{
+		newVSCodeExtensionPackage(
+			pkg.VSCodeExtensionMetadata{
+				Publisher:      manifest.Publisher,
+				Name:           manifest.Name,
+				DisplayName:    displayName,
+				VSCodeVersion:  manifest.Engines.VSCode,
+				TargetPlatform: targetPlatform,
+				Repository:     manifest.Repository.URL,
+			},
+			manifest.Version,
+			manifest.License,
+			reader.Location,
+		),
+	}, nil, nil
+}
+
+// nlsKey returns the key of a localized field value (e.g. "displayName" for "%displayName%").
+func nlsKey(value string) (string, bool) {
+	if len(value) < 3 || !strings.HasPrefix(value, "%") || !strings.HasSuffix(value, "%") {
+		return "", false
+	}
+	return strings.Trim(value, "%"), true
+}
+
+// nlsValue is a single localized value of a package.nls.json file, given as either the value or an object with the
+// value and a comment for translators.
+type nlsValue struct {
+	Message string `json:"message"`
+}
+
+func (v *nlsValue) UnmarshalJSON(b []byte) error {
+	var message string
+	if err := json.Unmarshal(b, &message); err == nil {
+		v.Message = message
+		return nil
+	}
+
+	type nlsObject nlsValue
+	var obj nlsObject
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return err
+	}
+	*v = nlsValue(obj)
+	return nil
+}
+
+// decodeRelativeFile decodes the JSON file at the given path (relative to the given location) into the given value,
+// returning the location of the file or nil if the file does not exist or cannot be decoded.
+func decodeRelativeFile(resolver source.FileResolver, location source.Location, p string, into interface{}) *source.Location {
+	relative := resolver.RelativeFileByPath(location, p)
+	if relative == nil {
+		return nil
+	}
+	reader, err := resolver.FileContentsByLocation(*relative)
+	if err != nil {
+		log.WithFields("path", relative.RealPath, "error", err).Debug("unable to read extension file")
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, relative.RealPath)
+
+	if err := json.NewDecoder(reader).Decode(into); err != nil {
+		log.WithFields("path", relative.RealPath, "error", err).Debug("unable to parse extension file")
+		return nil
+	}
+	return relative
+}
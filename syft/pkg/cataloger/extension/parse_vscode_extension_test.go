@@ -0,0 +1,80 @@
+package extension
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseVSCodeExtension(t *testing.T) {
+	tests := []struct {
+		name     string
+		fixture  string
+		expected []pkg.Package
+	}{
+		{
+			name:    "localized display name",
+			fixture: "test-fixtures/home/.vscode/extensions/ms-python.python-2023.20.0/package.json",
+			expected: []pkg.Package{
+				{
+					Name:    "ms-python.python",
+					Version: "2023.20.0",
+					Licenses: []pkg.License{
+						pkg.NewLicenseFromLocation("MIT", source.NewLocation("test-fixtures/home/.vscode/extensions/ms-python.python-2023.20.0/package.json")),
+					},
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/home/.vscode/extensions/ms-python.python-2023.20.0/package.json")),
+					Type:         pkg.VSCodeExtensionPkg,
+					PURL:         "pkg:generic/ms-python/python@2023.20.0",
+					MetadataType: pkg.VSCodeExtensionMetadataType,
+					Metadata: pkg.VSCodeExtensionMetadata{
+						Publisher:     "ms-python",
+						Name:          "python",
+						DisplayName:   "Python",
+						VSCodeVersion: "^1.82.0",
+						Repository:    "https://github.com/Microsoft/vscode-python",
+					},
+				},
+			},
+		},
+		{
+			name:    "platform-specific extension",
+			fixture: "test-fixtures/home/.vscode/extensions/golang.go-0.40.0/package.json",
+			expected: []pkg.Package{
+				{
+					Name:    "golang.go",
+					Version: "0.40.0",
+					Licenses: []pkg.License{
+						pkg.NewLicenseFromLocation("MIT", source.NewLocation("test-fixtures/home/.vscode/extensions/golang.go-0.40.0/package.json")),
+					},
+					Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/home/.vscode/extensions/golang.go-0.40.0/package.json")),
+					Type:         pkg.VSCodeExtensionPkg,
+					PURL:         "pkg:generic/golang/go@0.40.0",
+					MetadataType: pkg.VSCodeExtensionMetadataType,
+					Metadata: pkg.VSCodeExtensionMetadata{
+						Publisher:      "golang",
+						Name:           "go",
+						DisplayName:    "Go",
+						VSCodeVersion:  "^1.75.0",
+						TargetPlatform: "linux-x64",
+						Repository:     "https://github.com/golang/vscode-go",
+					},
+				},
+			},
+		},
+		{
+			name:    "obsolete extension",
+			fixture: "test-fixtures/home/.vscode/extensions/ms-python.python-2023.18.0/package.json",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromFile(t, test.fixture).
+				WithResolver(source.NewMockResolverForPaths(fixturePaths...)).
+				Expects(test.expected, nil).
+				TestParser(t, parseVSCodeExtension)
+		})
+	}
+}
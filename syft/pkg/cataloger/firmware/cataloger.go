@@ -0,0 +1,20 @@
+/*
+Package firmware provides a concrete Cataloger implementation for the components of the boot chain: UEFI binaries
+(e.g. shim, GRUB, and systemd-boot) and CPU microcode updates.
+*/
+package firmware
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const catalogerName = "firmware-cataloger"
+
+// NewFirmwareCataloger returns a new cataloger object for UEFI binaries (as identified by their SBAT section) and the
+// Intel and AMD CPU microcode updates loaded by the kernel.
+func NewFirmwareCataloger() *generic.Cataloger {
+	return generic.NewCataloger(catalogerName).
+		WithParserByGlobs(parseUEFIBinary, "**/*.efi", "**/*.EFI").
+		WithParserByGlobs(parseIntelMicrocode, "**/lib/firmware/intel-ucode/*").
+		WithParserByGlobs(parseAMDMicrocode, "**/lib/firmware/amd-ucode/*.bin")
+}
@@ -0,0 +1,146 @@
+package firmware
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestFirmwareCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/boot/efi/EFI/debian/grubx64.efi",
+		"test-fixtures/boot/efi/EFI/BOOT/BOOTX64.EFI",
+		"test-fixtures/usr/lib/systemd/boot/efi/systemd-bootx64.efi",
+		"test-fixtures/lib/firmware/intel-ucode/06-8e-0a",
+		"test-fixtures/lib/firmware/amd-ucode/microcode_amd_fam17h.bin",
+	)
+
+	sbatVersion := pkg.SBATEntry{
+		ComponentName:       "sbat",
+		ComponentGeneration: 1,
+		VendorName:          "SBAT Version",
+		VendorPackageName:   "sbat",
+		VendorVersion:       "1",
+		VendorURL:           "https://github.com/rhboot/shim/blob/main/SBAT.md",
+	}
+	amd := source.NewLocation("test-fixtures/lib/firmware/amd-ucode/microcode_amd_fam17h.bin")
+	// note: the UEFI binary without an SBAT section (the fallback loader) is not cataloged
+	expected := []pkg.Package{
+		{
+			Name:         "grub",
+			Version:      "2.06",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/boot/efi/EFI/debian/grubx64.efi")),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/grub@2.06",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format: "uefi",
+				Vendor: "Free Software Foundation",
+				SBAT: []pkg.SBATEntry{
+					sbatVersion,
+					{
+						ComponentName:       "grub",
+						ComponentGeneration: 3,
+						VendorName:          "Free Software Foundation",
+						VendorPackageName:   "grub",
+						VendorVersion:       "2.06",
+						VendorURL:           "https://www.gnu.org/software/grub/",
+					},
+					{
+						ComponentName:       "grub.debian",
+						ComponentGeneration: 4,
+						VendorName:          "Debian",
+						VendorPackageName:   "grub2",
+						VendorVersion:       "2.06-13+deb12u1",
+						VendorURL:           "https://tracker.debian.org/pkg/grub2",
+					},
+				},
+			},
+		},
+		{
+			Name:         "systemd-boot",
+			Version:      "252",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/usr/lib/systemd/boot/efi/systemd-bootx64.efi")),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/systemd-boot@252",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format: "uefi",
+				Vendor: "The systemd Developers",
+				SBAT: []pkg.SBATEntry{
+					sbatVersion,
+					{
+						ComponentName:       "systemd-boot",
+						ComponentGeneration: 1,
+						VendorName:          "The systemd Developers",
+						VendorPackageName:   "systemd",
+						VendorVersion:       "252",
+						VendorURL:           "https://systemd.io/",
+					},
+					{
+						ComponentName:       "systemd-boot.debian",
+						ComponentGeneration: 1,
+						VendorName:          "Debian",
+						VendorPackageName:   "systemd",
+						VendorVersion:       "252.17-1~deb12u1",
+						VendorURL:           "https://tracker.debian.org/pkg/systemd",
+					},
+				},
+			},
+		},
+		{
+			Name:         "intel-microcode",
+			Version:      "0xf4",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/lib/firmware/intel-ucode/06-8e-0a")),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/intel-microcode@0xf4",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format:              "intel-microcode",
+				Vendor:              "Intel",
+				Date:                "2023-02-23",
+				ProcessorSignatures: []string{"0x000806ea", "0x000806eb"},
+			},
+		},
+		{
+			Name:         "amd-microcode",
+			Version:      "0x8301055",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(amd),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/amd-microcode@0x8301055",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format:              "amd-microcode",
+				Vendor:              "AMD",
+				Date:                "2019-12-11",
+				ProcessorSignatures: []string{"0x00830f10"},
+			},
+		},
+		{
+			Name:         "amd-microcode",
+			Version:      "0x8600106",
+			FoundBy:      catalogerName,
+			Locations:    source.NewLocationSet(amd),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/amd-microcode@0x8600106",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format:              "amd-microcode",
+				Vendor:              "AMD",
+				Date:                "2020-01-13",
+				ProcessorSignatures: []string{"0x00860f01"},
+			},
+		},
+	}
+
+	pkgtest.NewCatalogTester().
+		WithResolver(resolver).
+		Expects(expected, nil).
+		TestCataloger(t, NewFirmwareCataloger())
+}
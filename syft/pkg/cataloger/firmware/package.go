@@ -0,0 +1,23 @@
+package firmware
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+func newPackage(name, version string, metadata pkg.FirmwareMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.FirmwarePkg,
+		PURL:         purl.New(purl.TypeGeneric, "", name, version, nil, ""),
+		MetadataType: pkg.FirmwareMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
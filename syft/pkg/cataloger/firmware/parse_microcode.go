@@ -0,0 +1,193 @@
+package firmware
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	intelMicrocodeName = "intel-microcode"
+	amdMicrocodeName   = "amd-microcode"
+
+	// the layout of an Intel microcode update (see arch/x86/include/asm/microcode_intel.h)
+	intelHeaderSize            = 48
+	intelExtendedHeaderSize    = 20
+	intelExtendedSignatureSize = 12
+	intelDefaultDataSize       = 2000
+	intelDefaultTotalSize      = intelDefaultDataSize + intelHeaderSize
+
+	// the layout of an AMD microcode container (see arch/x86/kernel/cpu/microcode/amd.c)
+	amdContainerMagic       = 0x00414d44
+	amdEquivalenceTableType = 0
+	amdPatchType            = 1
+	amdSectionHeaderSize    = 8
+	amdEquivalenceEntrySize = 16
+	amdPatchHeaderSize      = 32
+)
+
+// intelMicrocodeHeader mirrors struct microcode_header_intel.
+type intelMicrocodeHeader struct {
+	HeaderVersion      uint32
+	Revision           uint32
+	Date               uint32 // BCD encoded as 0xMMDDYYYY
+	ProcessorSignature uint32
+	Checksum           uint32
+	LoaderRevision     uint32
+	ProcessorFlags     uint32
+	DataSize           uint32
+	TotalSize          uint32
+	_                  [3]uint32
+}
+
+// parseIntelMicrocode catalogs the updates within an Intel microcode file (as loaded from /lib/firmware/intel-ucode by
+// the kernel), where a file may contain several updates for the processor family, model, and stepping that it is
+// named after (e.g. "06-8e-0a").
+func parseIntelMicrocode(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read microcode: %w", err)
+	}
+
+	signaturesByRevision := make(map[uint32][]string)
+	datesByRevision := make(map[uint32]string)
+	var revisions []uint32
+	for offset := 0; offset+intelHeaderSize <= len(data); {
+		var header intelMicrocodeHeader
+		if err := binary.Read(bytes.NewReader(data[offset:offset+intelHeaderSize]), binary.LittleEndian, &header); err != nil {
+			return nil, nil, fmt.Errorf("unable to read microcode header: %w", err)
+		}
+		if header.HeaderVersion != 1 || header.LoaderRevision != 1 {
+			if offset == 0 {
+				log.Debugf("not an intel microcode file: %q", reader.RealPath)
+				return nil, nil, nil
+			}
+			return nil, nil, fmt.Errorf("invalid microcode header at offset=%d", offset)
+		}
+
+		dataSize, totalSize := int(header.DataSize), int(header.TotalSize)
+		if dataSize == 0 {
+			dataSize, totalSize = intelDefaultDataSize, intelDefaultTotalSize
+		}
+		if totalSize < intelHeaderSize+dataSize || offset+totalSize > len(data) {
+			return nil, nil, fmt.Errorf("truncated microcode update at offset=%d", offset)
+		}
+
+		if _, ok := signaturesByRevision[header.Revision]; !ok {
+			revisions = append(revisions, header.Revision)
+			datesByRevision[header.Revision] = bcdDate(header.Date&0xffff, header.Date>>24, header.Date>>16&0xff)
+		}
+		signatures := append(signaturesByRevision[header.Revision], processorSignature(header.ProcessorSignature))
+		signatures = append(signatures, intelExtendedSignatures(data[offset+intelHeaderSize+dataSize:offset+totalSize])...)
+		signaturesByRevision[header.Revision] = signatures
+
+		offset += totalSize
+	}
+
+	var pkgs []pkg.Package
+	for _, revision := range revisions {
+		metadata := pkg.FirmwareMetadata{
+			Format:              intelMicrocodeName,
+			Vendor:              "Intel",
+			Date:                datesByRevision[revision],
+			ProcessorSignatures: internal.NewStringSet(signaturesByRevision[revision]...).ToSlice(),
+		}
+		pkgs = append(pkgs, newPackage(intelMicrocodeName, microcodeRevision(revision), metadata, reader.Location))
+	}
+	return pkgs, nil, nil
+}
+
+// intelExtendedSignatures returns the processor signatures within the extended signature table that follows the data
+// of a microcode update (for updates that apply to several processors).
+func intelExtendedSignatures(table []byte) []string {
+	if len(table) < intelExtendedHeaderSize {
+		return nil
+	}
+	count := int(binary.LittleEndian.Uint32(table))
+	var signatures []string
+	for i := 0; i < count; i++ {
+		offset := intelExtendedHeaderSize + i*intelExtendedSignatureSize
+		if offset+intelExtendedSignatureSize > len(table) {
+			break
+		}
+		signatures = append(signatures, processorSignature(binary.LittleEndian.Uint32(table[offset:])))
+	}
+	return signatures
+}
+
+// parseAMDMicrocode catalogs the patches within an AMD microcode container (as loaded from /lib/firmware/amd-ucode by
+// the kernel), relating each patch to the processors it applies to by the equivalence table of the container.
+func parseAMDMicrocode(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read microcode: %w", err)
+	}
+	if len(data) < 4 || binary.LittleEndian.Uint32(data) != amdContainerMagic {
+		log.Debugf("not an AMD microcode container: %q", reader.RealPath)
+		return nil, nil, nil
+	}
+
+	processorsByEquivalenceID := make(map[uint16][]string)
+	var pkgs []pkg.Package
+	for offset := 4; offset+amdSectionHeaderSize <= len(data); {
+		sectionType := binary.LittleEndian.Uint32(data[offset:])
+		size := int(binary.LittleEndian.Uint32(data[offset+4:]))
+		offset += amdSectionHeaderSize
+		if offset+size > len(data) {
+			return nil, nil, fmt.Errorf("truncated microcode section at offset=%d", offset)
+		}
+		section := data[offset : offset+size]
+		offset += size
+
+		switch sectionType {
+		case amdEquivalenceTableType:
+			for i := 0; i+amdEquivalenceEntrySize <= len(section); i += amdEquivalenceEntrySize {
+				installedCPU := binary.LittleEndian.Uint32(section[i:])
+				equivalenceID := binary.LittleEndian.Uint16(section[i+12:])
+				if installedCPU == 0 {
+					break
+				}
+				processorsByEquivalenceID[equivalenceID] = append(processorsByEquivalenceID[equivalenceID], processorSignature(installedCPU))
+			}
+		case amdPatchType:
+			if len(section) < amdPatchHeaderSize {
+				return nil, nil, fmt.Errorf("truncated microcode patch at offset=%d", offset-size)
+			}
+			date := binary.LittleEndian.Uint32(section)
+			revision := binary.LittleEndian.Uint32(section[4:])
+			equivalenceID := binary.LittleEndian.Uint16(section[24:])
+
+			metadata := pkg.FirmwareMetadata{
+				Format:              amdMicrocodeName,
+				Vendor:              "AMD",
+				Date:                bcdDate(date>>16, date>>8&0xff, date&0xff),
+				ProcessorSignatures: internal.NewStringSet(processorsByEquivalenceID[equivalenceID]...).ToSlice(),
+			}
+			pkgs = append(pkgs, newPackage(amdMicrocodeName, microcodeRevision(revision), metadata, reader.Location))
+		}
+	}
+	return pkgs, nil, nil
+}
+
+// microcodeRevision formats the revision of a microcode update as shown by the kernel (e.g. "microcode: 0xf4" within
+// /proc/cpuinfo).
+func microcodeRevision(revision uint32) string {
+	return fmt.Sprintf("0x%x", revision)
+}
+
+func processorSignature(signature uint32) string {
+	return fmt.Sprintf("0x%08x", signature)
+}
+
+// bcdDate formats the given binary-coded decimal year, month, and day as YYYY-MM-DD.
+func bcdDate(year, month, day uint32) string {
+	return fmt.Sprintf("%04x-%02x-%02x", year, month, day)
+}
@@ -0,0 +1,84 @@
+package firmware
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseIntelMicrocode(t *testing.T) {
+	fixture := "test-fixtures/lib/firmware/intel-ucode/06-8e-0a"
+	expected := []pkg.Package{
+		{
+			Name:         "intel-microcode",
+			Version:      "0xf4",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/intel-microcode@0xf4",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format: "intel-microcode",
+				Vendor: "Intel",
+				Date:   "2023-02-23",
+				// the second update of the revision (for another platform) is merged into the same package
+				ProcessorSignatures: []string{"0x000806ea", "0x000806eb"},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseIntelMicrocode, expected, nil)
+}
+
+func TestParseIntelMicrocode_notMicrocode(t *testing.T) {
+	pkgtest.NewCatalogTester().
+		FromString("/lib/firmware/intel-ucode/README", "this directory contains the intel microcode updates\n"+
+			"which are loaded early by the kernel.\n").
+		Expects(nil, nil).
+		TestParser(t, parseIntelMicrocode)
+}
+
+func TestParseAMDMicrocode(t *testing.T) {
+	fixture := "test-fixtures/lib/firmware/amd-ucode/microcode_amd_fam17h.bin"
+	location := source.NewLocation(fixture)
+	expected := []pkg.Package{
+		{
+			Name:         "amd-microcode",
+			Version:      "0x8301055",
+			Locations:    source.NewLocationSet(location),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/amd-microcode@0x8301055",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format:              "amd-microcode",
+				Vendor:              "AMD",
+				Date:                "2019-12-11",
+				ProcessorSignatures: []string{"0x00830f10"},
+			},
+		},
+		{
+			Name:         "amd-microcode",
+			Version:      "0x8600106",
+			Locations:    source.NewLocationSet(location),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/amd-microcode@0x8600106",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format:              "amd-microcode",
+				Vendor:              "AMD",
+				Date:                "2020-01-13",
+				ProcessorSignatures: []string{"0x00860f01"},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseAMDMicrocode, expected, nil)
+}
+
+func TestParseAMDMicrocode_truncated(t *testing.T) {
+	pkgtest.NewCatalogTester().
+		FromString("/lib/firmware/amd-ucode/microcode_amd.bin", "DMA\x00\x00\x00\x00\x00\xff\x00\x00\x00").
+		WithError().
+		TestParser(t, parseAMDMicrocode)
+}
@@ -0,0 +1,117 @@
+package firmware
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/unionreader"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	sbatSectionName = ".sbat"
+	// sbatComponentName is the first entry of every SBAT section, describing the version of the SBAT format itself
+	sbatComponentName = "sbat"
+)
+
+// parseUEFIBinary catalogs a UEFI binary from its SBAT section (see https://github.com/rhboot/shim/blob/main/SBAT.md),
+// where the first component after the SBAT format version is the upstream project of the binary (e.g. "grub") and the
+// remaining components are the vendor builds of the project (e.g. "grub.debian").
+func parseUEFIBinary(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	unionReader, err := unionreader.GetUnionReader(reader.ReadCloser)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get union reader for file: %w", err)
+	}
+	defer internal.CloseAndLogError(unionReader, reader.RealPath)
+
+	f, err := pe.NewFile(unionReader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read UEFI binary: %w", err)
+	}
+
+	section := f.Section(sbatSectionName)
+	if section == nil {
+		log.Debugf("no %s section found within UEFI binary=%q", sbatSectionName, reader.RealPath)
+		return nil, nil, nil
+	}
+	data, err := section.Data()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read %s section: %w", sbatSectionName, err)
+	}
+
+	entries, err := parseSBAT(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var upstream *pkg.SBATEntry
+	for i := range entries {
+		if entries[i].ComponentName != sbatComponentName {
+			upstream = &entries[i]
+			break
+		}
+	}
+	if upstream == nil {
+		log.Debugf("no components found within the %s section of UEFI binary=%q", sbatSectionName, reader.RealPath)
+		return nil, nil, nil
+	}
+
+	metadata := pkg.FirmwareMetadata{
+		Format: "uefi",
+		Vendor: upstream.VendorName,
+		SBAT:   entries,
+	}
+	return []pkg.Package{newPackage(upstream.ComponentName, upstream.VendorVersion, metadata, reader.Location)}, nil, nil
+}
+
+// parseSBAT reads the CSV records of an SBAT section: component_name, component_generation, vendor_name,
+// vendor_package_name, vendor_version, vendor_url.
+func parseSBAT(data []byte) ([]pkg.SBATEntry, error) {
+	// the section is padded with NUL bytes to the section alignment
+	if end := bytes.IndexByte(data, 0); end >= 0 {
+		data = data[:end]
+	}
+
+	r := csv.NewReader(bytes.NewReader(data))
+	r.FieldsPerRecord = -1
+
+	var entries []pkg.SBATEntry
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse %s section: %w", sbatSectionName, err)
+		}
+		if len(record) < 2 {
+			continue
+		}
+
+		generation, err := strconv.Atoi(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid generation=%q for SBAT component=%q", record[1], record[0])
+		}
+		entry := pkg.SBATEntry{
+			ComponentName:       record[0],
+			ComponentGeneration: generation,
+		}
+		fields := []*string{&entry.VendorName, &entry.VendorPackageName, &entry.VendorVersion, &entry.VendorURL}
+		for i, field := range fields {
+			if i+2 < len(record) {
+				*field = record[i+2]
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
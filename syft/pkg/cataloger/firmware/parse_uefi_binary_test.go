@@ -0,0 +1,108 @@
+package firmware
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseUEFIBinary(t *testing.T) {
+	fixture := "test-fixtures/boot/efi/EFI/debian/grubx64.efi"
+	expected := []pkg.Package{
+		{
+			Name:         "grub",
+			Version:      "2.06",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Type:         pkg.FirmwarePkg,
+			PURL:         "pkg:generic/grub@2.06",
+			MetadataType: pkg.FirmwareMetadataType,
+			Metadata: pkg.FirmwareMetadata{
+				Format: "uefi",
+				Vendor: "Free Software Foundation",
+				SBAT: []pkg.SBATEntry{
+					{
+						ComponentName:       "sbat",
+						ComponentGeneration: 1,
+						VendorName:          "SBAT Version",
+						VendorPackageName:   "sbat",
+						VendorVersion:       "1",
+						VendorURL:           "https://github.com/rhboot/shim/blob/main/SBAT.md",
+					},
+					{
+						ComponentName:       "grub",
+						ComponentGeneration: 3,
+						VendorName:          "Free Software Foundation",
+						VendorPackageName:   "grub",
+						VendorVersion:       "2.06",
+						VendorURL:           "https://www.gnu.org/software/grub/",
+					},
+					{
+						ComponentName:       "grub.debian",
+						ComponentGeneration: 4,
+						VendorName:          "Debian",
+						VendorPackageName:   "grub2",
+						VendorVersion:       "2.06-13+deb12u1",
+						VendorURL:           "https://tracker.debian.org/pkg/grub2",
+					},
+				},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseUEFIBinary, expected, nil)
+}
+
+func TestParseUEFIBinary_withoutSBAT(t *testing.T) {
+	pkgtest.TestFileParser(t, "test-fixtures/boot/efi/EFI/BOOT/BOOTX64.EFI", parseUEFIBinary, nil, nil)
+}
+
+func Test_parseSBAT(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected []pkg.SBATEntry
+		wantErr  require.ErrorAssertionFunc
+	}{
+		{
+			name: "padded section with partial records",
+			data: "sbat,1,SBAT Version,sbat,1,https://github.com/rhboot/shim/blob/main/SBAT.md\nshim,3,UEFI shim,shim,1\n\x00\x00\x00",
+			expected: []pkg.SBATEntry{
+				{
+					ComponentName:       "sbat",
+					ComponentGeneration: 1,
+					VendorName:          "SBAT Version",
+					VendorPackageName:   "sbat",
+					VendorVersion:       "1",
+					VendorURL:           "https://github.com/rhboot/shim/blob/main/SBAT.md",
+				},
+				{
+					ComponentName:       "shim",
+					ComponentGeneration: 3,
+					VendorName:          "UEFI shim",
+					VendorPackageName:   "shim",
+					VendorVersion:       "1",
+				},
+			},
+		},
+		{
+			name:    "invalid generation",
+			data:    "shim,three,UEFI shim,shim,1,https://github.com/rhboot/shim\n",
+			wantErr: require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			actual, err := parseSBAT([]byte(test.data))
+			test.wantErr(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
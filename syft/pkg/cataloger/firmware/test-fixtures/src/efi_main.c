@@ -0,0 +1 @@
+int efi_main(void) { return 0; }
@@ -0,0 +1,19 @@
+/*
+Package githubactions provides a concrete Cataloger implementation for the actions and reusable workflows used by
+GitHub Actions workflows.
+*/
+package githubactions
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const catalogerName = "github-actions-cataloger"
+
+// NewGithubActionsCataloger returns a new cataloger object for the actions and reusable workflows used by the workflows
+// (and composite actions) of a repository.
+func NewGithubActionsCataloger() *generic.Cataloger {
+	return generic.NewCataloger(catalogerName).
+		WithParserByGlobs(parseWorkflow, "**/.github/workflows/*.yml", "**/.github/workflows/*.yaml").
+		WithParserByGlobs(parseCompositeAction, "**/action.yml", "**/action.yaml")
+}
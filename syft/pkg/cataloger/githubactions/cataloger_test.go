@@ -0,0 +1,35 @@
+package githubactions
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestGithubActionsCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/.github/workflows/ci.yml",
+		"test-fixtures/composite/action.yml",
+		"test-fixtures/node/action.yml",
+	)
+
+	pkgs, _, err := NewGithubActionsCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	var found []string
+	for _, p := range pkgs {
+		assert.Equal(t, catalogerName, p.FoundBy)
+		found = append(found, p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{
+		"actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11",
+		"actions/setup-go@v5",
+		"aws-actions/configure-aws-credentials/subaction@main",
+		"golangci/golangci-lint-action@v3",
+		"octo-org/shared-workflows/.github/workflows/release.yml@v1.4.0",
+		"actions/setup-node@60edb5dd545a775178f52524783378180af0d1f8",
+	}, found)
+}
@@ -0,0 +1,53 @@
+package githubactions
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+// newPackageFromUseStatement creates a package for the action or reusable workflow referenced by the given "uses"
+// statement, returning nil for statements that do not reference a repository (local actions and docker images) or
+// are not resolved until the workflow runs (expressions).
+func newPackageFromUseStatement(statement pkg.GitHubActionsUseMetadata, location source.Location) *pkg.Package {
+	value := strings.TrimSpace(statement.Value)
+	if strings.HasPrefix(value, "./") || strings.HasPrefix(value, "docker://") || strings.Contains(value, "${{") {
+		return nil
+	}
+
+	// e.g. "actions/checkout@v4", "aws-actions/configure-aws-credentials/subdir@main", or
+	// "octo-org/repo/.github/workflows/reusable.yml@v1"
+	name, ref, ok := strings.Cut(value, "@")
+	if !ok || name == "" || ref == "" {
+		return nil
+	}
+	fields := strings.SplitN(name, "/", 3)
+	if len(fields) < 2 || fields[0] == "" || fields[1] == "" {
+		return nil
+	}
+	var subpath string
+	if len(fields) == 3 {
+		subpath = fields[2]
+	}
+
+	ty := pkg.GithubActionPkg
+	if strings.HasPrefix(subpath, ".github/workflows/") {
+		ty = pkg.GithubActionWorkflowPkg
+	}
+
+	p := pkg.Package{
+		Name:         name,
+		Version:      ref,
+		Locations:    source.NewLocationSet(location),
+		Type:         ty,
+		PURL:         purl.New(purl.TypeGitHub, fields[0], fields[1], ref, nil, subpath),
+		MetadataType: pkg.GitHubActionsUseMetadataType,
+		Metadata:     statement,
+	}
+
+	p.SetID()
+
+	return &p
+}
@@ -0,0 +1,139 @@
+package githubactions
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var (
+	_ generic.Parser = parseWorkflow
+	_ generic.Parser = parseCompositeAction
+)
+
+// parseWorkflow parses a workflow file for the actions used by the steps of its jobs, and for the reusable workflows
+// called by its jobs (see https://docs.github.com/en/actions/using-workflows/workflow-syntax-for-github-actions).
+func parseWorkflow(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	root, err := decodeYAML(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse workflow file: %w", err)
+	}
+
+	var statements []*yaml.Node
+	jobs := mappingValue(root, "jobs")
+	for _, job := range mappingValues(jobs) {
+		if uses := mappingValue(job, "uses"); uses != nil {
+			statements = append(statements, uses)
+		}
+		statements = append(statements, stepStatements(mappingValue(job, "steps"))...)
+	}
+
+	return newPackages(statements, reader.Location), nil, nil
+}
+
+// parseCompositeAction parses the metadata file of an action for the actions used by the steps of a composite action
+// (see https://docs.github.com/en/actions/creating-actions/metadata-syntax-for-github-actions#runs-for-composite-actions).
+// The metadata files of other kinds of actions (e.g. javascript or docker actions) do not use other actions.
+func parseCompositeAction(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	root, err := decodeYAML(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse action file: %w", err)
+	}
+
+	runs := mappingValue(root, "runs")
+	if using := mappingValue(runs, "using"); using == nil || using.Value != "composite" {
+		return nil, nil, nil
+	}
+
+	return newPackages(stepStatements(mappingValue(runs, "steps")), reader.Location), nil, nil
+}
+
+func decodeYAML(reader source.LocationReadCloser) (*yaml.Node, error) {
+	var document yaml.Node
+	if err := yaml.NewDecoder(reader).Decode(&document); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+	return document.Content[0], nil
+}
+
+// stepStatements returns the "uses" statements of the given steps.
+func stepStatements(steps *yaml.Node) []*yaml.Node {
+	if steps == nil || steps.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var statements []*yaml.Node
+	for _, step := range steps.Content {
+		if uses := mappingValue(step, "uses"); uses != nil {
+			statements = append(statements, uses)
+		}
+	}
+	return statements
+}
+
+// newPackages creates a package for each distinct statement, sorted for a stable ordering of packages.
+func newPackages(statements []*yaml.Node, location source.Location) []pkg.Package {
+	seen := make(map[string]bool)
+	var pkgs []pkg.Package
+	for _, node := range statements {
+		if node.Kind != yaml.ScalarNode || seen[node.Value] {
+			continue
+		}
+		seen[node.Value] = true
+
+		statement := pkg.GitHubActionsUseMetadata{
+			Value:   node.Value,
+			Comment: strings.TrimSpace(strings.TrimPrefix(node.LineComment, "#")),
+		}
+		if p := newPackageFromUseStatement(statement, location); p != nil {
+			pkgs = append(pkgs, *p)
+		}
+	}
+
+	sort.Slice(pkgs, func(i, j int) bool {
+		if pkgs[i].Name == pkgs[j].Name {
+			return pkgs[i].Version < pkgs[j].Version
+		}
+		return pkgs[i].Name < pkgs[j].Name
+	})
+	return pkgs
+}
+
+// mappingValue returns the value of the given key of a mapping node (or nil if there is no such key).
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mappingValues returns the values of a mapping node, in the order of the document.
+func mappingValues(node *yaml.Node) []*yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	var values []*yaml.Node
+	for i := 1; i < len(node.Content); i += 2 {
+		values = append(values, node.Content[i])
+	}
+	return values
+}
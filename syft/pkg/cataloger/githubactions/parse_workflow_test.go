@@ -0,0 +1,98 @@
+package githubactions
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseWorkflow(t *testing.T) {
+	fixture := "test-fixtures/.github/workflows/ci.yml"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expected := []pkg.Package{
+		{
+			Name:         "actions/checkout",
+			Version:      "b4ffde65f46336ab88eb53be808477a3936bae11",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			PURL:         "pkg:github/actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11",
+			MetadataType: pkg.GitHubActionsUseMetadataType,
+			Metadata: pkg.GitHubActionsUseMetadata{
+				Value:   "actions/checkout@b4ffde65f46336ab88eb53be808477a3936bae11",
+				Comment: "v4.1.1",
+			},
+		},
+		{
+			Name:         "actions/setup-go",
+			Version:      "v5",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			PURL:         "pkg:github/actions/setup-go@v5",
+			MetadataType: pkg.GitHubActionsUseMetadataType,
+			Metadata: pkg.GitHubActionsUseMetadata{
+				Value: "actions/setup-go@v5",
+			},
+		},
+		{
+			Name:         "aws-actions/configure-aws-credentials/subaction",
+			Version:      "main",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			PURL:         "pkg:github/aws-actions/configure-aws-credentials@main#subaction",
+			MetadataType: pkg.GitHubActionsUseMetadataType,
+			Metadata: pkg.GitHubActionsUseMetadata{
+				Value: "aws-actions/configure-aws-credentials/subaction@main",
+			},
+		},
+		{
+			Name:         "golangci/golangci-lint-action",
+			Version:      "v3",
+			Locations:    locations,
+			Type:         pkg.GithubActionPkg,
+			PURL:         "pkg:github/golangci/golangci-lint-action@v3",
+			MetadataType: pkg.GitHubActionsUseMetadataType,
+			Metadata: pkg.GitHubActionsUseMetadata{
+				Value: "golangci/golangci-lint-action@v3",
+			},
+		},
+		{
+			Name:         "octo-org/shared-workflows/.github/workflows/release.yml",
+			Version:      "v1.4.0",
+			Locations:    locations,
+			Type:         pkg.GithubActionWorkflowPkg,
+			PURL:         "pkg:github/octo-org/shared-workflows@v1.4.0#.github/workflows/release.yml",
+			MetadataType: pkg.GitHubActionsUseMetadataType,
+			Metadata: pkg.GitHubActionsUseMetadata{
+				Value: "octo-org/shared-workflows/.github/workflows/release.yml@v1.4.0",
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseWorkflow, expected, nil)
+}
+
+func TestParseCompositeAction(t *testing.T) {
+	fixture := "test-fixtures/composite/action.yml"
+	expected := []pkg.Package{
+		{
+			Name:         "actions/setup-node",
+			Version:      "60edb5dd545a775178f52524783378180af0d1f8",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Type:         pkg.GithubActionPkg,
+			PURL:         "pkg:github/actions/setup-node@60edb5dd545a775178f52524783378180af0d1f8",
+			MetadataType: pkg.GitHubActionsUseMetadataType,
+			Metadata: pkg.GitHubActionsUseMetadata{
+				Value:   "actions/setup-node@60edb5dd545a775178f52524783378180af0d1f8",
+				Comment: "v4.0.2",
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseCompositeAction, expected, nil)
+}
+
+func TestParseCompositeAction_notComposite(t *testing.T) {
+	pkgtest.TestFileParser(t, "test-fixtures/node/action.yml", parseCompositeAction, nil, nil)
+}
@@ -48,7 +48,7 @@ func parseGoBinary(_ source.FileResolver, _ *generic.Environment, reader source.
 	}
 
 	mods, archs := scanFile(unionReader, reader.RealPath)
-	internal.CloseAndLogError(reader.ReadCloser, reader.RealPath)
+	internal.CloseAndLogError(unionReader, reader.RealPath)
 
 	for i, mod := range mods {
 		pkgs = append(pkgs, buildGoPkgInfo(reader.Location, mod, archs[i])...)
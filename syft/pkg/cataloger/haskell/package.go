@@ -1,8 +1,8 @@
 package haskell
 
 import (
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -27,14 +27,5 @@ func newPackage(name, version string, m *pkg.HackageMetadata, locations ...sourc
 }
 
 func packageURL(name, version string) string {
-	var qualifiers packageurl.Qualifiers
-
-	return packageurl.NewPackageURL(
-		packageurl.TypeHackage,
-		"",
-		name,
-		version,
-		qualifiers,
-		"",
-	).ToString()
+	return purl.New(purl.TypeHackage, "", name, version, nil, "")
 }
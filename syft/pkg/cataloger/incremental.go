@@ -0,0 +1,117 @@
+package cataloger
+
+import (
+	"context"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// CatalogChanges updates the results of a previous Catalog() call over the same source, given the paths of the files
+// that were added, modified, or removed since. Packages found in (or owning) any changed file are discarded, and the
+// catalogers are run again over only the changed files and the files of the discarded packages. All other packages and
+// their relationships are kept as-is. Failures of catalogers are reported as with Catalog, and cancellation of the
+// context as with CatalogWithContext.
+func CatalogChanges(ctx context.Context, resolver source.FileResolver, release *linux.Release, opts Options, previous *pkg.Catalog, previousRelationships []artifact.Relationship, changed map[string]struct{}, catalogers ...pkg.Cataloger) (*pkg.Catalog, []artifact.Relationship, error) {
+	affected, search := affectedPackages(previous, previousRelationships, changed)
+	log.Debugf("incremental cataloging of %d changed files (%d packages affected)", len(changed), len(affected))
+
+	catalog, relationships, err := catalogSearch(ctx, newSearchResolver(resolver, search), resolver, release, opts, catalogers...)
+	if err != nil && !IsPartialResults(err) {
+		return nil, nil, err
+	}
+
+	for _, p := range previous.Sorted() {
+		if _, ok := affected[p.ID()]; !ok {
+			catalog.Add(p)
+		}
+	}
+
+	var allRelationships []artifact.Relationship
+	for _, r := range previousRelationships {
+		if r.Type == artifact.OwnershipByFileOverlapRelationship {
+			// these are derived from the whole catalog, so are created again below
+			continue
+		}
+		if isAffected(affected, r.From) || isAffected(affected, r.To) {
+			continue
+		}
+		allRelationships = append(allRelationships, r)
+	}
+
+	for _, r := range relationships {
+		if r.Type != artifact.OwnershipByFileOverlapRelationship {
+			allRelationships = append(allRelationships, r)
+		}
+	}
+
+	return catalog, append(allRelationships, pkg.NewRelationships(catalog)...), err
+}
+
+// affectedPackages returns the previously found packages that need to be cataloged again, along with the paths to
+// search for them. A package is affected when it was found in a path to search or owns a changed file, and all paths a
+// package was found in are searched (e.g. a change to one file of a package database affects all packages within it).
+func affectedPackages(previous *pkg.Catalog, previousRelationships []artifact.Relationship, changed map[string]struct{}) (map[artifact.ID]struct{}, map[string]struct{}) {
+	affected := make(map[artifact.ID]struct{})
+	search := make(map[string]struct{})
+	for path := range changed {
+		search[path] = struct{}{}
+	}
+
+	for _, r := range previousRelationships {
+		if r.Type != artifact.ContainsRelationship {
+			continue
+		}
+		if _, ok := r.From.(pkg.Package); !ok {
+			continue
+		}
+		if coordinates, ok := r.To.(source.Coordinates); ok {
+			if _, ok := changed[coordinates.RealPath]; ok {
+				affected[r.From.ID()] = struct{}{}
+			}
+		}
+	}
+
+	packages := previous.Sorted()
+	for {
+		var found bool
+		for _, p := range packages {
+			locations := p.Locations.ToSlice()
+			if _, ok := affected[p.ID()]; !ok {
+				if !inSearch(search, locations) {
+					continue
+				}
+				affected[p.ID()] = struct{}{}
+			}
+			for _, location := range locations {
+				if _, ok := search[location.RealPath]; !ok {
+					search[location.RealPath] = struct{}{}
+					found = true
+				}
+			}
+		}
+		if !found {
+			return affected, search
+		}
+	}
+}
+
+func inSearch(search map[string]struct{}, locations []source.Location) bool {
+	for _, location := range locations {
+		if _, ok := search[location.RealPath]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func isAffected(affected map[artifact.ID]struct{}, identifiable artifact.Identifiable) bool {
+	if _, ok := identifiable.(pkg.Package); !ok {
+		return false
+	}
+	_, ok := affected[identifiable.ID()]
+	return ok
+}
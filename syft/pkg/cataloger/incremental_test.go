@@ -0,0 +1,135 @@
+package cataloger
+
+import (
+	"context"
+	"path"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ pkg.Cataloger = (*globCataloger)(nil)
+
+// globCataloger finds a package for each file matching the glob (recording every file that it has searched).
+type globCataloger struct {
+	glob     string
+	lock     sync.Mutex
+	searched []string
+}
+
+func (c *globCataloger) Name() string {
+	return "glob-cataloger"
+}
+
+func (c *globCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob(c.glob)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var packages []pkg.Package
+	var relationships []artifact.Relationship
+	for _, location := range locations {
+		c.lock.Lock()
+		c.searched = append(c.searched, location.RealPath)
+		c.lock.Unlock()
+
+		p := pkg.Package{
+			Name:      path.Base(location.RealPath),
+			Version:   "1.0",
+			Locations: source.NewLocationSet(location),
+		}
+		p.SetID()
+		packages = append(packages, p)
+		relationships = append(relationships, artifact.Relationship{
+			From: p,
+			To:   location.Coordinates,
+			Type: artifact.ContainsRelationship,
+		})
+	}
+	return packages, relationships, nil
+}
+
+func TestCatalogChanges(t *testing.T) {
+	c := &globCataloger{glob: "**/*.pkg"}
+	previous, previousRelationships, err := Catalog(source.NewMockResolverForPaths("/a.pkg", "/b.pkg", "/other"), nil, 1, c)
+	require.NoError(t, err)
+	require.Equal(t, 2, previous.PackageCount())
+
+	c.searched = nil
+	resolver := source.NewMockResolverForPaths("/a.pkg", "/b.pkg", "/c.pkg", "/other")
+	changed := map[string]struct{}{
+		"/a.pkg": {},
+		"/c.pkg": {},
+	}
+	catalog, relationships, err := CatalogChanges(context.Background(), resolver, nil, Options{Parallelism: 1}, previous, previousRelationships, changed, c)
+	require.NoError(t, err)
+
+	sort.Strings(c.searched)
+	assert.Equal(t, []string{"/a.pkg", "/c.pkg"}, c.searched, "only changed files should be searched")
+
+	var names []string
+	for _, p := range catalog.Sorted() {
+		names = append(names, p.Name)
+	}
+	assert.Equal(t, []string{"a.pkg", "b.pkg", "c.pkg"}, names)
+
+	// each package relates to its own file, without duplicates for packages found again
+	assert.Len(t, relationships, 3)
+}
+
+func TestCatalogChanges_removedFile(t *testing.T) {
+	c := &globCataloger{glob: "**/*.pkg"}
+	previous, previousRelationships, err := Catalog(source.NewMockResolverForPaths("/a.pkg", "/b.pkg"), nil, 1, c)
+	require.NoError(t, err)
+
+	catalog, relationships, err := CatalogChanges(context.Background(), source.NewMockResolverForPaths("/b.pkg"), nil, Options{Parallelism: 1}, previous, previousRelationships, map[string]struct{}{"/a.pkg": {}}, c)
+	require.NoError(t, err)
+
+	require.Equal(t, 1, catalog.PackageCount())
+	assert.Len(t, catalog.PackagesByName("b.pkg"), 1)
+	require.Len(t, relationships, 1)
+	assert.Equal(t, "/b.pkg", relationships[0].To.(source.Coordinates).RealPath)
+}
+
+func TestAffectedPackages(t *testing.T) {
+	// a package database described by multiple files: a change to any file affects all packages found within them
+	status := source.NewLocation("/var/lib/db/status")
+	extra := source.NewLocation("/var/lib/db/extra")
+	first := pkg.Package{Name: "first", Locations: source.NewLocationSet(status)}
+	first.SetID()
+	second := pkg.Package{Name: "second", Locations: source.NewLocationSet(status, extra)}
+	second.SetID()
+	unrelated := pkg.Package{Name: "unrelated", Locations: source.NewLocationSet(source.NewLocation("/go.mod"))}
+	unrelated.SetID()
+	owner := pkg.Package{Name: "owner", Locations: source.NewLocationSet(source.NewLocation("/var/lib/other/status"))}
+	owner.SetID()
+
+	relationships := []artifact.Relationship{
+		{From: owner, To: source.NewLocation("/usr/bin/tool").Coordinates, Type: artifact.ContainsRelationship},
+	}
+
+	affected, search := affectedPackages(pkg.NewCatalog(first, second, unrelated, owner), relationships, map[string]struct{}{
+		"/var/lib/db/extra": {},
+		"/usr/bin/tool":     {},
+	})
+
+	assert.Equal(t, map[artifact.ID]struct{}{
+		first.ID():  {},
+		second.ID(): {},
+		owner.ID():  {},
+	}, affected)
+	assert.Equal(t, map[string]struct{}{
+		"/var/lib/db/status":    {},
+		"/var/lib/db/extra":     {},
+		"/usr/bin/tool":         {},
+		"/var/lib/other/status": {},
+	}, search)
+}
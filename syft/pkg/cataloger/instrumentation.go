@@ -0,0 +1,172 @@
+package cataloger
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/anchore/syft/syft/pkg/cataloger"
+
+// Instrumentation reports cataloging as OpenTelemetry traces and Prometheus metrics, so that services embedding syft
+// can monitor it. This is opt-in: nothing is reported unless given in the Config. A single Instrumentation may be shared
+// by all cataloging of a service (see WithParent), and is safe for concurrent use.
+type Instrumentation struct {
+	tracer            trace.Tracer
+	parent            context.Context
+	catalogDuration   prometheus.Histogram
+	catalogerDuration *prometheus.HistogramVec
+	catalogerRuns     *prometheus.CounterVec
+	filesRead         *prometheus.CounterVec
+	bytesRead         *prometheus.CounterVec
+	packages          *prometheus.CounterVec
+	cacheLookups      *prometheus.CounterVec
+}
+
+// NewInstrumentation creates instrumentation that creates trace spans with the given tracer provider and registers
+// metrics with the given registerer. Either may be nil to only report traces (or only metrics).
+func NewInstrumentation(tracerProvider trace.TracerProvider, registerer prometheus.Registerer) (*Instrumentation, error) {
+	if tracerProvider == nil {
+		tracerProvider = trace.NewNoopTracerProvider()
+	}
+
+	i := &Instrumentation{
+		tracer: tracerProvider.Tracer(instrumentationName),
+		parent: context.Background(),
+		catalogDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "syft",
+			Name:      "catalog_duration_seconds",
+			Help:      "Time spent running all catalogers over a source (or image layer).",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 12),
+		}),
+		catalogerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "syft",
+			Name:      "cataloger_duration_seconds",
+			Help:      "Time spent running a single cataloger.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 2, 14),
+		}, []string{"cataloger"}),
+		catalogerRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "syft",
+			Name:      "cataloger_runs_total",
+			Help:      "Number of cataloger runs, by result (success or failure).",
+		}, []string{"cataloger", "result"}),
+		filesRead: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "syft",
+			Name:      "cataloger_files_read_total",
+			Help:      "Number of files read by a cataloger.",
+		}, []string{"cataloger"}),
+		bytesRead: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "syft",
+			Name:      "cataloger_bytes_read_total",
+			Help:      "Number of bytes read by a cataloger.",
+		}, []string{"cataloger"}),
+		packages: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "syft",
+			Name:      "cataloger_packages_total",
+			Help:      "Number of packages found by a cataloger.",
+		}, []string{"cataloger"}),
+		cacheLookups: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "syft",
+			Name:      "cache_lookups_total",
+			Help:      "Number of lookups of cataloging results in the cache, by result (hit or miss).",
+		}, []string{"result"}),
+	}
+
+	if registerer != nil {
+		for _, c := range i.collectors() {
+			if err := registerer.Register(c); err != nil {
+				return nil, fmt.Errorf("unable to register metrics: %w", err)
+			}
+		}
+	}
+	return i, nil
+}
+
+func (i *Instrumentation) collectors() []prometheus.Collector {
+	return []prometheus.Collector{
+		i.catalogDuration,
+		i.catalogerDuration,
+		i.catalogerRuns,
+		i.filesRead,
+		i.bytesRead,
+		i.packages,
+		i.cacheLookups,
+	}
+}
+
+// WithParent returns instrumentation that reports the trace spans of cataloging as children of the span within the
+// given context (e.g. the span of the request being served), sharing the same metrics.
+func (i *Instrumentation) WithParent(ctx context.Context) *Instrumentation {
+	if i == nil {
+		return nil
+	}
+	child := *i
+	child.parent = ctx
+	return &child
+}
+
+// RecordCacheLookup records whether cataloging results were found in the cache.
+func (i *Instrumentation) RecordCacheLookup(hit bool) {
+	if i == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	i.cacheLookups.WithLabelValues(result).Inc()
+}
+
+// startCatalog starts the span of running the given number of catalogers, returning the context for the span of each
+// cataloger and a function to call with the number of failed catalogers once all catalogers have finished.
+func (i *Instrumentation) startCatalog(catalogers int) (context.Context, func(failures int)) {
+	if i == nil {
+		return context.Background(), func(int) {}
+	}
+
+	start := time.Now()
+	ctx, span := i.tracer.Start(i.parent, "syft.catalog", trace.WithAttributes(attribute.Int("syft.catalogers", catalogers)))
+	return ctx, func(failures int) {
+		i.catalogDuration.Observe(time.Since(start).Seconds())
+		span.SetAttributes(attribute.Int("syft.failures", failures))
+		if failures > 0 {
+			span.SetStatus(codes.Error, fmt.Sprintf("%d catalogers failed", failures))
+		}
+		span.End()
+	}
+}
+
+// startCataloger starts the span of running a single cataloger, returning a function to call with the measurements
+// of the run once the cataloger has finished.
+func (i *Instrumentation) startCataloger(ctx context.Context, cataloger string) func(CatalogerMetrics, error) {
+	if i == nil {
+		return func(CatalogerMetrics, error) {}
+	}
+
+	_, span := i.tracer.Start(ctx, "syft.cataloger", trace.WithAttributes(attribute.String("syft.cataloger", cataloger)))
+	return func(m CatalogerMetrics, err error) {
+		i.catalogerDuration.WithLabelValues(cataloger).Observe(m.Duration.Seconds())
+		i.filesRead.WithLabelValues(cataloger).Add(float64(m.FilesRead))
+		i.bytesRead.WithLabelValues(cataloger).Add(float64(m.BytesRead))
+		i.packages.WithLabelValues(cataloger).Add(float64(m.Packages))
+
+		span.SetAttributes(
+			attribute.Int64("syft.files_read", m.FilesRead),
+			attribute.Int64("syft.bytes_read", m.BytesRead),
+			attribute.Int("syft.packages", m.Packages),
+		)
+		if err != nil {
+			i.catalogerRuns.WithLabelValues(cataloger, "failure").Inc()
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		} else {
+			i.catalogerRuns.WithLabelValues(cataloger, "success").Inc()
+		}
+		span.End()
+	}
+}
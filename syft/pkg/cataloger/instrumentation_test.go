@@ -0,0 +1,54 @@
+package cataloger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestCatalogWithOptions_instrumentation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "a.pkg")
+	require.NoError(t, os.WriteFile(path, make([]byte, 42), 0644))
+
+	registry := prometheus.NewRegistry()
+	instrumentation, err := NewInstrumentation(nil, registry)
+	require.NoError(t, err)
+
+	opts := Options{Parallelism: 2, Instrumentation: instrumentation}
+	_, _, err = CatalogWithOptions(source.NewMockResolverForPaths(path), nil, opts, readingCataloger{}, panickingCataloger{})
+	require.True(t, IsPartialResults(err))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(instrumentation.catalogerRuns.WithLabelValues("reading-cataloger", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(instrumentation.catalogerRuns.WithLabelValues("panicking", "failure")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(instrumentation.filesRead.WithLabelValues("reading-cataloger")))
+	assert.Equal(t, 42.0, testutil.ToFloat64(instrumentation.bytesRead.WithLabelValues("reading-cataloger")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(instrumentation.packages.WithLabelValues("reading-cataloger")))
+	assert.Equal(t, 1, testutil.CollectAndCount(instrumentation.catalogDuration))
+
+	instrumentation.RecordCacheLookup(true)
+	instrumentation.RecordCacheLookup(false)
+	instrumentation.RecordCacheLookup(false)
+	assert.Equal(t, 1.0, testutil.ToFloat64(instrumentation.cacheLookups.WithLabelValues("hit")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(instrumentation.cacheLookups.WithLabelValues("miss")))
+
+	// the same metrics cannot be registered twice, so a service should share a single instrumentation
+	_, err = NewInstrumentation(nil, registry)
+	assert.Error(t, err)
+}
+
+func TestInstrumentation_nil(t *testing.T) {
+	var instrumentation *Instrumentation
+	assert.Nil(t, instrumentation.WithParent(context.Background()))
+	instrumentation.RecordCacheLookup(true)
+
+	_, _, err := CatalogWithOptions(source.NewMockResolverForPaths(), nil, Options{Instrumentation: instrumentation}, readingCataloger{})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,82 @@
+/*
+Package dependency resolves the dependency relationships between packages from the names that each package provides
+and requires, as declared within a package database (e.g. the Depends field of a dpkg status file).
+*/
+package dependency
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// Specification describes the names a package can be referred to by (e.g. the package name, virtual package names,
+// shared libraries, or owned file paths) and the requirements of the package on other packages.
+type Specification struct {
+	Provides []string
+	Requires []Requirement
+}
+
+// Requirement is a dependency of a package that is satisfied by a package providing any one of the alternative names.
+type Requirement []string
+
+// Specifier returns the dependency specification of the given package.
+type Specifier func(pkg.Package) Specification
+
+// Resolve creates a relationship from each package to every package that requires it (the "from" package is a
+// dependency of the "to" package). Every alternative of a requirement that is provided by a package is related, and
+// requirements that are not provided by any of the given packages are ignored.
+func Resolve(specifier Specifier, pkgs []pkg.Package) []artifact.Relationship {
+	specs := make([]Specification, len(pkgs))
+	providers := make(map[string][]int)
+	for i, p := range pkgs {
+		specs[i] = specifier(p)
+		for _, name := range specs[i].Provides {
+			if name == "" {
+				continue
+			}
+			providers[name] = appendUnique(providers[name], i)
+		}
+	}
+
+	type edge struct {
+		from artifact.ID
+		to   artifact.ID
+	}
+	seen := make(map[edge]struct{})
+
+	var relationships []artifact.Relationship
+	for i, spec := range specs {
+		dependent := pkgs[i]
+		for _, requirement := range spec.Requires {
+			for _, name := range requirement {
+				for _, j := range providers[name] {
+					dependency := pkgs[j]
+					if dependency.ID() == dependent.ID() {
+						continue
+					}
+					e := edge{from: dependency.ID(), to: dependent.ID()}
+					if _, exists := seen[e]; exists {
+						continue
+					}
+					seen[e] = struct{}{}
+
+					relationships = append(relationships, artifact.Relationship{
+						From: dependency,
+						To:   dependent,
+						Type: artifact.DependencyOfRelationship,
+					})
+				}
+			}
+		}
+	}
+	return relationships
+}
+
+func appendUnique(indexes []int, index int) []int {
+	for _, i := range indexes {
+		if i == index {
+			return indexes
+		}
+	}
+	return append(indexes, index)
+}
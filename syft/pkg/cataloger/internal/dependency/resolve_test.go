@@ -0,0 +1,62 @@
+package dependency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func TestResolve(t *testing.T) {
+	newPackage := func(name string) pkg.Package {
+		p := pkg.Package{
+			Name:    name,
+			Version: "1.0",
+		}
+		p.SetID()
+		return p
+	}
+
+	libc := newPackage("libc")
+	awk := newPackage("mawk")
+	shell := newPackage("bash")
+	app := newPackage("app")
+
+	specs := map[string]Specification{
+		"libc": {
+			Provides: []string{"libc", "libc.so.6"},
+		},
+		"mawk": {
+			Provides: []string{"mawk", "awk"},
+			Requires: []Requirement{{"libc.so.6"}},
+		},
+		"bash": {
+			Provides: []string{"bash", "/bin/sh"},
+			Requires: []Requirement{{"libc"}, {"bash"}},
+		},
+		"app": {
+			Provides: []string{"app"},
+			Requires: []Requirement{
+				{"gawk", "awk"},
+				{"/bin/sh"},
+				{"libc", "libc.so.6"},
+				{"missing"},
+			},
+		},
+	}
+	specifier := func(p pkg.Package) Specification {
+		return specs[p.Name]
+	}
+
+	expected := []artifact.Relationship{
+		{From: libc, To: awk, Type: artifact.DependencyOfRelationship},
+		{From: libc, To: shell, Type: artifact.DependencyOfRelationship},
+		{From: awk, To: app, Type: artifact.DependencyOfRelationship},
+		{From: shell, To: app, Type: artifact.DependencyOfRelationship},
+		{From: libc, To: app, Type: artifact.DependencyOfRelationship},
+	}
+
+	assert.Equal(t, expected, Resolve(specifier, []pkg.Package{libc, awk, shell, app}))
+}
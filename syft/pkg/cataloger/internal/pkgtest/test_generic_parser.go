@@ -106,6 +106,10 @@ func (p *CatalogTester) IgnoreLocationLayer() *CatalogTester {
 	p.locationComparer = func(x, y source.Location) bool {
 		return cmp.Equal(x.Coordinates.RealPath, y.Coordinates.RealPath) && cmp.Equal(x.VirtualPath, y.VirtualPath)
 	}
+	// coordinates outside of location sets (e.g. the license locations) are compared by path as well
+	p.compareOptions = append(p.compareOptions, cmp.Comparer(func(x, y source.Coordinates) bool {
+		return x.RealPath == y.RealPath
+	}))
 	return p
 }
 
@@ -1,11 +1,11 @@
 package unionreader
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 
 	macho "github.com/anchore/go-macholibre"
+	"github.com/anchore/syft/internal/file"
 	"github.com/anchore/syft/internal/log"
 )
 
@@ -38,28 +38,12 @@ func GetReaders(f UnionReader) ([]io.ReaderAt, error) {
 	return []io.ReaderAt{f}, nil
 }
 
+// GetUnionReader returns a union reader for the given reader. Readers without random access are buffered in memory
+// (or within a temporary file for large contents), so the returned reader must be closed in place of the given reader.
 func GetUnionReader(readerCloser io.ReadCloser) (UnionReader, error) {
-	reader, ok := readerCloser.(UnionReader)
-	if ok {
-		return reader, nil
-	}
-
-	b, err := io.ReadAll(readerCloser)
+	reader, err := file.NewSeekableReader(readerCloser)
 	if err != nil {
 		return nil, fmt.Errorf("unable to read contents from binary: %w", err)
 	}
-
-	bytesReader := bytes.NewReader(b)
-
-	reader = struct {
-		io.ReadCloser
-		io.ReaderAt
-		io.Seeker
-	}{
-		ReadCloser: io.NopCloser(bytesReader),
-		ReaderAt:   bytesReader,
-		Seeker:     bytesReader,
-	}
-
 	return reader, nil
 }
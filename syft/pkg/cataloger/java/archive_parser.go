@@ -4,7 +4,6 @@ import (
 	"crypto"
 	"fmt"
 	"io"
-	"os"
 	"path"
 	"strings"
 
@@ -17,7 +16,7 @@ import (
 )
 
 // integrity check
-var _ common.ParserFn = parseJavaArchive
+var _ javaArchiveParserFn = parseJavaArchive
 
 var archiveFormatGlobs = []string{
 	"**/*.jar",
@@ -37,7 +36,8 @@ var archiveFormatGlobs = []string{
 	// project that we can build in CI feel free to include it
 }
 
-// javaArchiveHashes are all the current hash algorithms used to calculate archive digests
+// javaArchiveHashes are the hash algorithms always used to calculate archive digests (additional algorithms may be
+// configured, see Config.ArchiveDigests)
 var javaArchiveHashes = []crypto.Hash{
 	crypto.SHA1,
 }
@@ -49,11 +49,43 @@ type archiveParser struct {
 	contentPath  string
 	fileInfo     archiveFilename
 	detectNested bool
+	search       *archiveSearch
+	digests      []syftFile.Digest
+}
+
+// javaArchiveParserFn is a parser function for java archive contents that searches nested archives (and calculates
+// archive digests) as described by the given search.
+type javaArchiveParserFn func(virtualPath string, reader io.Reader, search *archiveSearch) ([]*pkg.Package, []artifact.Relationship, error)
+
+// withArchiveSearch adapts the given parser function to search each cataloged archive as configured, with a budget
+// for the archives nested within it.
+func withArchiveSearch(fn javaArchiveParserFn, cfg Config) common.ParserFn {
+	return func(virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+		return fn(virtualPath, reader, newArchiveSearch(cfg))
+	}
+}
+
+// archiveDigestHashes returns the default archive hash algorithms along with the given additional algorithms.
+func archiveDigestHashes(additional []crypto.Hash) []crypto.Hash {
+	hashes := append([]crypto.Hash{}, javaArchiveHashes...)
+	for _, h := range additional {
+		exists := false
+		for _, existing := range hashes {
+			if existing == h {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			hashes = append(hashes, h)
+		}
+	}
+	return hashes
 }
 
 // parseJavaArchive is a parser function for java archive contents, returning all Java libraries and nested archives.
-func parseJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
-	parser, cleanupFn, err := newJavaArchiveParser(virtualPath, reader, true)
+func parseJavaArchive(virtualPath string, reader io.Reader, search *archiveSearch) ([]*pkg.Package, []artifact.Relationship, error) {
+	parser, cleanupFn, err := newJavaArchiveParser(virtualPath, reader, true, search)
 	// note: even on error, we should always run cleanup functions
 	defer cleanupFn()
 	if err != nil {
@@ -72,12 +104,14 @@ func uniquePkgKey(p *pkg.Package) string {
 
 // newJavaArchiveParser returns a new java archive parser object for the given archive. Can be configured to discover
 // and parse nested archives or ignore them.
-func newJavaArchiveParser(virtualPath string, reader io.Reader, detectNested bool) (*archiveParser, func(), error) {
+func newJavaArchiveParser(virtualPath string, reader io.Reader, detectNested bool, search *archiveSearch) (*archiveParser, func(), error) {
 	// fetch the last element of the virtual path
 	virtualElements := strings.Split(virtualPath, ":")
 	currentFilepath := virtualElements[len(virtualElements)-1]
 
-	contentPath, archivePath, cleanupFn, err := saveArchiveToTmp(currentFilepath, reader)
+	// calculate the archive digests while the archive is saved, instead of reading the archive again
+	digester := syftFile.NewDigester(search.hashes)
+	contentPath, archivePath, cleanupFn, err := saveArchiveToTmp(currentFilepath, io.TeeReader(reader, digester))
 	if err != nil {
 		return nil, cleanupFn, fmt.Errorf("unable to process java archive: %w", err)
 	}
@@ -94,6 +128,8 @@ func newJavaArchiveParser(virtualPath string, reader io.Reader, detectNested boo
 		contentPath:  contentPath,
 		fileInfo:     newJavaArchiveFilename(currentFilepath),
 		detectNested: detectNested,
+		search:       search,
+		digests:      digester.Digests(),
 	}, cleanupFn, nil
 }
 
@@ -167,18 +203,6 @@ func (j *archiveParser) discoverMainPackage() (*pkg.Package, error) {
 		return nil, nil
 	}
 
-	archiveCloser, err := os.Open(j.archivePath)
-	if err != nil {
-		return nil, fmt.Errorf("unable to open archive path (%s): %w", j.archivePath, err)
-	}
-	defer archiveCloser.Close()
-
-	// grab and assign digest for the entire archive
-	digests, err := syftFile.DigestsFromFile(archiveCloser, javaArchiveHashes)
-	if err != nil {
-		log.Warnf("failed to create digest for file=%q: %+v", j.archivePath, err)
-	}
-
 	return &pkg.Package{
 		Name:         selectName(manifest, j.fileInfo),
 		Version:      selectVersion(manifest, j.fileInfo),
@@ -188,7 +212,7 @@ func (j *archiveParser) discoverMainPackage() (*pkg.Package, error) {
 		Metadata: pkg.JavaMetadata{
 			VirtualPath:    j.virtualPath,
 			Manifest:       manifest,
-			ArchiveDigests: digests,
+			ArchiveDigests: j.digests,
 		},
 	}, nil
 }
@@ -233,28 +257,47 @@ func (j *archiveParser) discoverPkgsFromAllMavenFiles(parentPkg *pkg.Package) ([
 
 func (j *archiveParser) discoverPkgsFromNestedArchives(parentPkg *pkg.Package) ([]*pkg.Package, []artifact.Relationship, error) {
 	// we know that all java archives are zip formatted files, so we can use the shared zip helper
-	return discoverPkgsFromZip(j.virtualPath, j.archivePath, j.contentPath, j.fileManifest, parentPkg)
+	return discoverPkgsFromZip(j.virtualPath, j.archivePath, j.contentPath, j.fileManifest, parentPkg, j.search, archiveFormatGlobs...)
 }
 
-// discoverPkgsFromZip finds Java archives within Java archives, returning all listed Java packages found and
-// associating each discovered package to the given parent package.
-func discoverPkgsFromZip(virtualPath, archivePath, contentPath string, fileManifest file.ZipFileManifest, parentPkg *pkg.Package) ([]*pkg.Package, []artifact.Relationship, error) {
-	// search and parse pom.properties files & fetch the contents
-	openers, err := file.ExtractFromZipToUniqueTempFile(archivePath, contentPath, fileManifest.GlobMatch(archiveFormatGlobs...)...)
+// discoverPkgsFromZip finds the archives matching the given globs within a zip archive (e.g. Java archives within Java
+// archives), returning all listed Java packages found and associating each discovered package to the given parent
+// package. Nested archives are only extracted within the depth and size limits of the given search.
+func discoverPkgsFromZip(virtualPath, archivePath, contentPath string, fileManifest file.ZipFileManifest, parentPkg *pkg.Package, search *archiveSearch, globs ...string) ([]*pkg.Package, []artifact.Relationship, error) {
+	paths := fileManifest.GlobMatch(globs...)
+	if len(paths) == 0 {
+		return nil, nil, nil
+	}
+
+	nested := search.nested()
+	if nested == nil {
+		log.Warnf("skipping archives nested within %q: the maximum nested archive depth has been reached", virtualPath)
+		return nil, nil, nil
+	}
+
+	// the sizes within the central directory are enforced while extracting, so they can be checked up front
+	var extractPaths []string
+	for _, p := range paths {
+		if nested.reserve(virtualPath, p, fileManifest[p].Size()) {
+			extractPaths = append(extractPaths, p)
+		}
+	}
+
+	openers, err := file.ExtractFromZipToUniqueTempFile(archivePath, contentPath, extractPaths...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to extract files from zip: %w", err)
 	}
 
-	return discoverPkgsFromOpeners(virtualPath, openers, parentPkg)
+	return discoverPkgsFromOpeners(virtualPath, openers, parentPkg, nested)
 }
 
 // discoverPkgsFromOpeners finds Java archives within the given files and associates them with the given parent package.
-func discoverPkgsFromOpeners(virtualPath string, openers map[string]file.Opener, parentPkg *pkg.Package) ([]*pkg.Package, []artifact.Relationship, error) {
+func discoverPkgsFromOpeners(virtualPath string, openers map[string]file.Opener, parentPkg *pkg.Package, search *archiveSearch) ([]*pkg.Package, []artifact.Relationship, error) {
 	var pkgs []*pkg.Package
 	var relationships []artifact.Relationship
 
 	for pathWithinArchive, archiveOpener := range openers {
-		nestedPkgs, nestedRelationships, err := discoverPkgsFromOpener(virtualPath, pathWithinArchive, archiveOpener)
+		nestedPkgs, nestedRelationships, err := discoverPkgsFromOpener(virtualPath, pathWithinArchive, archiveOpener, search)
 		if err != nil {
 			log.Warnf("unable to discover java packages from opener (%s): %+v", virtualPath, err)
 			continue
@@ -278,7 +321,7 @@ func discoverPkgsFromOpeners(virtualPath string, openers map[string]file.Opener,
 }
 
 // discoverPkgsFromOpener finds Java archives within the given file.
-func discoverPkgsFromOpener(virtualPath, pathWithinArchive string, archiveOpener file.Opener) ([]*pkg.Package, []artifact.Relationship, error) {
+func discoverPkgsFromOpener(virtualPath, pathWithinArchive string, archiveOpener file.Opener, search *archiveSearch) ([]*pkg.Package, []artifact.Relationship, error) {
 	archiveReadCloser, err := archiveOpener.Open()
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to open archived file from tempdir: %w", err)
@@ -290,7 +333,7 @@ func discoverPkgsFromOpener(virtualPath, pathWithinArchive string, archiveOpener
 	}()
 
 	nestedPath := fmt.Sprintf("%s:%s", virtualPath, pathWithinArchive)
-	nestedPkgs, nestedRelationships, err := parseJavaArchive(nestedPath, archiveReadCloser)
+	nestedPkgs, nestedRelationships, err := nestedArchiveParser(pathWithinArchive)(nestedPath, archiveReadCloser, search)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to process nested java archive (%s): %w", pathWithinArchive, err)
 	}
@@ -385,6 +428,11 @@ func newPackageFromMavenData(pomProperties pkg.PomProperties, pomProject *pkg.Po
 		return nil
 	}
 
+	// the package is not the archive itself, so it was bundled into the classes of the archive (shaded)
+	metadata := p.Metadata.(pkg.JavaMetadata)
+	metadata.Shaded = true
+	p.Metadata = metadata
+
 	return &p
 }
 
@@ -2,6 +2,7 @@ package java
 
 import (
 	"bufio"
+	"crypto"
 	"fmt"
 	"io"
 	"os"
@@ -209,6 +210,7 @@ func TestParseJar(t *testing.T) {
 						// ensure that nested packages with different names than that of the parent are appended as
 						// a suffix on the virtual path
 						VirtualPath: "test-fixtures/java-builds/packages/example-java-app-maven-0.1.0.jar:joda-time",
+						Shaded:      true,
 						PomProperties: &pkg.PomProperties{
 							Path:       "META-INF/maven/joda-time/joda-time/pom.properties",
 							GroupID:    "joda-time",
@@ -242,7 +244,7 @@ func TestParseJar(t *testing.T) {
 				t.Fatalf("failed to open fixture: %+v", err)
 			}
 
-			parser, cleanupFn, err := newJavaArchiveParser(fixture.Name(), fixture, false)
+			parser, cleanupFn, err := newJavaArchiveParser(fixture.Name(), fixture, false, newArchiveSearch(Config{}))
 			defer cleanupFn()
 			if err != nil {
 				t.Fatalf("should not have filed... %+v", err)
@@ -516,7 +518,7 @@ func TestParseNestedJar(t *testing.T) {
 				t.Fatalf("failed to open fixture: %+v", err)
 			}
 
-			actual, _, err := parseJavaArchive(fixture.Name(), fixture)
+			actual, _, err := parseJavaArchive(fixture.Name(), fixture, newArchiveSearch(Config{}))
 			if err != nil {
 				t.Fatalf("failed to parse java archive: %+v", err)
 			}
@@ -633,6 +635,7 @@ func Test_newPackageFromMavenData(t *testing.T) {
 						ArtifactID: "some-artifact-id",
 						Version:    "1.0",
 					},
+					Shaded: true,
 					Parent: &pkg.Package{
 						Name:    "some-parent-name",
 						Version: "2.0",
@@ -715,6 +718,7 @@ func Test_newPackageFromMavenData(t *testing.T) {
 						Description: "desc",
 						URL:         "aweso.me",
 					},
+					Shaded: true,
 					Parent: &pkg.Package{
 						Name:    "some-parent-name",
 						Version: "2.0",
@@ -771,6 +775,7 @@ func Test_newPackageFromMavenData(t *testing.T) {
 						ArtifactID: "some-artifact-id",
 						Version:    "1.0",
 					},
+					Shaded: true,
 					Parent: &pkg.Package{
 						Name:    "some-parent-name",
 						Version: "2.0",
@@ -948,3 +953,26 @@ func Test_newPackageFromMavenData(t *testing.T) {
 		})
 	}
 }
+
+func Test_archiveDigestHashes(t *testing.T) {
+	tests := []struct {
+		name       string
+		additional []crypto.Hash
+		expected   []crypto.Hash
+	}{
+		{
+			name:     "defaults",
+			expected: []crypto.Hash{crypto.SHA1},
+		},
+		{
+			name:       "additional hashes",
+			additional: []crypto.Hash{crypto.SHA256, crypto.SHA1, crypto.SHA512},
+			expected:   []crypto.Hash{crypto.SHA1, crypto.SHA256, crypto.SHA512},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, archiveDigestHashes(test.additional))
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package java
+
+import (
+	"crypto"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// archiveSearch describes how the archives nested within a cataloged archive (e.g. jars within wars within ears, or
+// java archives within tars) are searched. Nested archives are extracted to disk, so the search is bounded by how many
+// levels of archives are descended into and by how many bytes are extracted in total, guarding against archive bombs.
+// The size budget is shared by all archives nested within the same cataloged archive.
+type archiveSearch struct {
+	// hashes are the algorithms used to calculate the digests of the java archives found
+	hashes []crypto.Hash
+	// indexed and unindexed are whether zip and tar archives nested within zip and tar archives are descended into
+	indexed   bool
+	unindexed bool
+	// remainingDepth is how many more levels of nested archives may be descended into (unlimited when negative)
+	remainingDepth int
+	// remainingSize is how many more bytes may be extracted from nested archives (unlimited when nil)
+	remainingSize *int64
+}
+
+// newArchiveSearch returns the search of the archives nested within a single cataloged archive.
+func newArchiveSearch(cfg Config) *archiveSearch {
+	search := &archiveSearch{
+		hashes:         archiveDigestHashes(cfg.ArchiveDigests),
+		indexed:        cfg.SearchIndexedArchives,
+		unindexed:      cfg.SearchUnindexedArchives,
+		remainingDepth: -1,
+	}
+	if cfg.MaxNestedArchiveDepth > 0 {
+		search.remainingDepth = cfg.MaxNestedArchiveDepth
+	}
+	if cfg.MaxNestedArchiveSize > 0 {
+		size := cfg.MaxNestedArchiveSize
+		search.remainingSize = &size
+	}
+	return search
+}
+
+// nested returns the search of the archives one level deeper, or nil when the maximum depth has been reached.
+func (s *archiveSearch) nested() *archiveSearch {
+	if s.remainingDepth == 0 {
+		return nil
+	}
+	nested := *s
+	if nested.remainingDepth > 0 {
+		nested.remainingDepth--
+	}
+	return &nested
+}
+
+// reserve claims the given number of bytes of the size budget to extract the given nested archive, returning false
+// (claiming nothing) when extracting the archive would exceed the budget.
+func (s *archiveSearch) reserve(virtualPath, pathWithinArchive string, size int64) bool {
+	if s.remainingSize == nil {
+		return true
+	}
+	if size > *s.remainingSize {
+		log.Warnf("skipping nested archive %q within %q: extracting it would exceed the nested archive size limit", pathWithinArchive, virtualPath)
+		return false
+	}
+	*s.remainingSize -= size
+	return true
+}
+
+// wrappedArchiveGlobs returns the globs of the archives descended into when found within a zip or tar archive: java
+// archives, along with zip and tar archives when searching them is enabled.
+func (s *archiveSearch) wrappedArchiveGlobs() []string {
+	globs := append([]string{}, archiveFormatGlobs...)
+	if s.indexed {
+		globs = append(globs, genericZipGlobs...)
+	}
+	if s.unindexed {
+		globs = append(globs, genericTarGlobs...)
+	}
+	return globs
+}
+
+// nestedArchiveParser returns the parser for the given archive found within another archive.
+func nestedArchiveParser(pathWithinArchive string) javaArchiveParserFn {
+	switch {
+	case matchesAnyGlob(pathWithinArchive, genericTarGlobs...):
+		return parseTarWrappedJavaArchive
+	case matchesAnyGlob(pathWithinArchive, genericZipGlobs...):
+		return parseZipWrappedJavaArchive
+	default:
+		return parseJavaArchive
+	}
+}
+
+func matchesAnyGlob(name string, globs ...string) bool {
+	for _, glob := range globs {
+		if matches, err := doublestar.PathMatch(glob, name); err == nil && matches {
+			return true
+		}
+	}
+	return false
+}
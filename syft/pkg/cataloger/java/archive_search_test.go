@@ -0,0 +1,169 @@
+package java
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+type testArchiveEntry struct {
+	name     string
+	contents []byte
+}
+
+// newTestJar returns an uncompressed java archive with a manifest and the given entries (so that the size of each
+// entry is exactly the length of its contents).
+func newTestJar(t *testing.T, entries ...testArchiveEntry) []byte {
+	t.Helper()
+	entries = append([]testArchiveEntry{{name: "META-INF/MANIFEST.MF", contents: []byte("Manifest-Version: 1.0\n")}}, entries...)
+	return newTestZip(t, entries...)
+}
+
+func newTestZip(t *testing.T, entries ...testArchiveEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, e := range entries {
+		f, err := w.CreateHeader(&zip.FileHeader{Name: e.name, Method: zip.Store})
+		require.NoError(t, err)
+		_, err = f.Write(e.contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func newTestTar(t *testing.T, entries ...testArchiveEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := tar.NewWriter(&buf)
+	for _, e := range entries {
+		require.NoError(t, w.WriteHeader(&tar.Header{Name: e.name, Mode: 0644, Size: int64(len(e.contents)), Typeflag: tar.TypeReg}))
+		_, err := w.Write(e.contents)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func packageNames(pkgs []*pkg.Package) []string {
+	var names []string
+	for _, p := range pkgs {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func Test_parseJavaArchive_nestedArchiveLimits(t *testing.T) {
+	nestedJar := newTestJar(t)
+	libJar := newTestJar(t, testArchiveEntry{name: "lib/nested-1.0.jar", contents: nestedJar})
+	webWar := newTestJar(t, testArchiveEntry{name: "WEB-INF/lib/lib-1.0.jar", contents: libJar})
+	appEar := newTestJar(t, testArchiveEntry{name: "web-1.0.war", contents: webWar})
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected []string
+	}{
+		{
+			name:     "unlimited",
+			expected: []string{"app", "web", "lib", "nested"},
+		},
+		{
+			name:     "depth of one level",
+			cfg:      Config{MaxNestedArchiveDepth: 1},
+			expected: []string{"app", "web"},
+		},
+		{
+			name:     "depth of two levels",
+			cfg:      Config{MaxNestedArchiveDepth: 2},
+			expected: []string{"app", "web", "lib"},
+		},
+		{
+			name:     "depth of all levels",
+			cfg:      Config{MaxNestedArchiveDepth: 3},
+			expected: []string{"app", "web", "lib", "nested"},
+		},
+		{
+			name:     "size smaller than the first nested archive",
+			cfg:      Config{MaxNestedArchiveSize: int64(len(webWar)) - 1},
+			expected: []string{"app"},
+		},
+		{
+			name:     "size shared by all levels",
+			cfg:      Config{MaxNestedArchiveSize: int64(len(webWar) + len(libJar))},
+			expected: []string{"app", "web", "lib"},
+		},
+		{
+			name:     "size of all nested archives",
+			cfg:      Config{MaxNestedArchiveSize: int64(len(webWar) + len(libJar) + len(nestedJar))},
+			expected: []string{"app", "web", "lib", "nested"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgs, _, err := parseJavaArchive("app-1.0.ear", bytes.NewReader(appEar), newArchiveSearch(test.cfg))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expected, packageNames(pkgs))
+		})
+	}
+}
+
+func Test_parseTarWrappedJavaArchive_nestedArchives(t *testing.T) {
+	libJar := newTestJar(t)
+	innerTar := newTestTar(t, testArchiveEntry{name: "inner/lib-1.0.jar", contents: libJar})
+	innerZip := newTestZip(t, testArchiveEntry{name: "zipped-1.0.jar", contents: libJar})
+	bundle := newTestTar(t,
+		testArchiveEntry{name: "app-1.0.jar", contents: libJar},
+		testArchiveEntry{name: "dist/inner.tar", contents: innerTar},
+		testArchiveEntry{name: "dist/inner.zip", contents: innerZip},
+	)
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		expected []string
+	}{
+		{
+			name:     "nested wrapping archives are not searched",
+			expected: []string{"app"},
+		},
+		{
+			name:     "nested tars",
+			cfg:      Config{SearchUnindexedArchives: true},
+			expected: []string{"app", "lib"},
+		},
+		{
+			name:     "nested tars and zips",
+			cfg:      Config{SearchUnindexedArchives: true, SearchIndexedArchives: true},
+			expected: []string{"app", "lib", "zipped"},
+		},
+		{
+			name:     "depth limits nested wrapping archives",
+			cfg:      Config{SearchUnindexedArchives: true, SearchIndexedArchives: true, MaxNestedArchiveDepth: 1},
+			expected: []string{"app"},
+		},
+		{
+			name: "size limits nested wrapping archives",
+			cfg: Config{
+				SearchUnindexedArchives: true,
+				SearchIndexedArchives:   true,
+				MaxNestedArchiveSize:    int64(len(libJar) + len(innerTar) + len(libJar)),
+			},
+			expected: []string{"app", "lib"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgs, _, err := parseTarWrappedJavaArchive("bundle.tar", bytes.NewReader(bundle), newArchiveSearch(test.cfg))
+			require.NoError(t, err)
+			assert.ElementsMatch(t, test.expected, packageNames(pkgs))
+		})
+	}
+}
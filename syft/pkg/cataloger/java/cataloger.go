@@ -1,34 +1,98 @@
 /*
-Package java provides a concrete Cataloger implementation for Java archives (jar, war, ear, par, sar, jpi, hpi formats).
+Package java provides concrete Cataloger implementations for Java archives (jar, war, ear, par, sar formats) and Jenkins plugins (jpi, hpi formats).
 */
 package java
 
 import (
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/source"
 )
 
+const javaCatalogerName = "java-cataloger"
+
+// integrity check
+var _ pkg.Cataloger = (*Cataloger)(nil)
+
+// Cataloger catalogs the packages within java archives along with the packaging hierarchy of the archives (which
+// packages are nested within or shaded into which archives).
+type Cataloger struct {
+	cataloger *common.GenericCataloger
+}
+
 // NewJavaCataloger returns a new Java archive cataloger object.
-func NewJavaCataloger(cfg Config) *common.GenericCataloger {
+func NewJavaCataloger(cfg Config) *Cataloger {
 	globParsers := make(map[string]common.ParserFn)
 
-	// java archive formats
+	// java archive formats (Jenkins plugins are cataloged by the Jenkins plugin cataloger instead)
 	for _, pattern := range archiveFormatGlobs {
-		globParsers[pattern] = parseJavaArchive
+		if internal.StringInSlice(pattern, jenkinsPluginArchiveGlobs) {
+			continue
+		}
+		globParsers[pattern] = withArchiveSearch(parseJavaArchive, cfg)
 	}
 
 	if cfg.SearchIndexedArchives {
 		// java archives wrapped within zip files
 		for _, pattern := range genericZipGlobs {
-			globParsers[pattern] = parseZipWrappedJavaArchive
+			globParsers[pattern] = withArchiveSearch(parseZipWrappedJavaArchive, cfg)
 		}
 	}
 
 	if cfg.SearchUnindexedArchives {
 		// java archives wrapped within tar files
 		for _, pattern := range genericTarGlobs {
-			globParsers[pattern] = parseTarWrappedJavaArchive
+			globParsers[pattern] = withArchiveSearch(parseTarWrappedJavaArchive, cfg)
 		}
 	}
 
-	return common.NewGenericCataloger(nil, globParsers, "java-cataloger")
+	return &Cataloger{
+		cataloger: common.NewGenericCataloger(nil, globParsers, javaCatalogerName),
+	}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *Cataloger) Name() string {
+	return javaCatalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns any discovered Packages
+// after analyzing java archives, along with the relationships between each archive and the packages within it.
+func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	pkgs, relationships, err := c.cataloger.Catalog(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pkgs, append(relationships, archiveContainsRelationships(pkgs)...), nil
+}
+
+// archiveContainsRelationships relates each java archive to the packages found within it (from nested archives and
+// from the maven metadata of shaded packages), as recorded by the parent of each package.
+func archiveContainsRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	byID := make(map[artifact.ID]pkg.Package)
+	for _, p := range pkgs {
+		byID[p.ID()] = p
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(pkg.JavaMetadata)
+		if !ok || metadata.Parent == nil {
+			continue
+		}
+		// the parent may not have been kept (e.g. when it is not a valid package)
+		parent, ok := byID[metadata.Parent.ID()]
+		if !ok || parent.ID() == p.ID() {
+			continue
+		}
+		relationships = append(relationships, artifact.Relationship{
+			From: parent,
+			To:   p,
+			Type: artifact.ContainsRelationship,
+		})
+	}
+	return relationships
 }
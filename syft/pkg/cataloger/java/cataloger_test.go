@@ -0,0 +1,62 @@
+package java
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+func Test_archiveContainsRelationships(t *testing.T) {
+	pomProperties := func(groupID, artifactID, version string) []byte {
+		return []byte(fmt.Sprintf("groupId=%s\nartifactId=%s\nversion=%s\n", groupID, artifactID, version))
+	}
+	nestedJar := newTestJar(t, testArchiveEntry{
+		name:     "META-INF/maven/org.acme/nested/pom.properties",
+		contents: pomProperties("org.acme", "nested", "2.0"),
+	})
+	appJar := newTestJar(t,
+		testArchiveEntry{
+			name:     "META-INF/maven/org.acme/app/pom.properties",
+			contents: pomProperties("org.acme", "app", "1.0"),
+		},
+		testArchiveEntry{
+			name:     "META-INF/maven/com.google.guava/guava/pom.properties",
+			contents: pomProperties("com.google.guava", "guava", "31.1"),
+		},
+		testArchiveEntry{
+			name:     "BOOT-INF/lib/nested-2.0.jar",
+			contents: nestedJar,
+		},
+	)
+
+	discovered, _, err := parseJavaArchive("app-1.0.jar", bytes.NewReader(appJar), newArchiveSearch(Config{}))
+	require.NoError(t, err)
+
+	var pkgs []pkg.Package
+	shaded := make(map[string]bool)
+	for _, p := range discovered {
+		p.SetID()
+		pkgs = append(pkgs, *p)
+		shaded[p.Name] = p.Metadata.(pkg.JavaMetadata).Shaded
+	}
+
+	assert.Equal(t, map[string]bool{
+		"app":    false,
+		"guava":  true,
+		"nested": false,
+	}, shaded)
+
+	var relationships []string
+	for _, r := range archiveContainsRelationships(pkgs) {
+		relationships = append(relationships, fmt.Sprintf("%s %s %s", r.From.(pkg.Package).Name, r.Type, r.To.(pkg.Package).Name))
+	}
+	assert.ElementsMatch(t, []string{
+		"app contains guava",
+		"app contains nested",
+	}, relationships)
+}
@@ -1,6 +1,16 @@
 package java
 
+import "crypto"
+
 type Config struct {
 	SearchUnindexedArchives bool
 	SearchIndexedArchives   bool
+	// ArchiveDigests are hash algorithms used to calculate archive digests in addition to SHA-1
+	ArchiveDigests []crypto.Hash
+	// MaxNestedArchiveDepth is how many levels of archives nested within a cataloged archive (e.g. jars within wars
+	// within ears) are searched (unlimited when zero)
+	MaxNestedArchiveDepth int
+	// MaxNestedArchiveSize is how many bytes may be extracted from the archives nested within a cataloged archive, larger
+	// nested archives are skipped once the limit is reached (unlimited when zero)
+	MaxNestedArchiveSize int64
 }
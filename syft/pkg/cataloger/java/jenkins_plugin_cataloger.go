@@ -0,0 +1,178 @@
+package java
+
+import (
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/dependency"
+	"github.com/anchore/syft/syft/source"
+)
+
+const jenkinsPluginCatalogerName = "jenkins-plugin-cataloger"
+
+// jenkinsPluginArchiveGlobs match packaged Jenkins plugins (e.g. /usr/share/jenkins/ref/plugins/git.jpi)
+var jenkinsPluginArchiveGlobs = []string{
+	"**/*.jpi",
+	"**/*.hpi",
+}
+
+// explodedJenkinsPluginGlob matches the manifest of a plugin that Jenkins extracted into the plugins directory of the
+// Jenkins home (e.g. /var/jenkins_home/plugins/git/META-INF/MANIFEST.MF)
+const explodedJenkinsPluginGlob = "**/plugins/*" + manifestGlob
+
+// integrity check
+var _ pkg.Cataloger = (*JenkinsPluginCataloger)(nil)
+
+// JenkinsPluginCataloger catalogs the plugins installed within a Jenkins controller along with the dependencies
+// between the plugins.
+type JenkinsPluginCataloger struct {
+	cataloger *common.GenericCataloger
+}
+
+// NewJenkinsPluginCataloger returns a new Jenkins plugin cataloger object.
+func NewJenkinsPluginCataloger(cfg Config) *JenkinsPluginCataloger {
+	globParsers := make(map[string]common.ParserFn)
+
+	for _, pattern := range jenkinsPluginArchiveGlobs {
+		globParsers[pattern] = withArchiveSearch(parseJavaArchive, cfg)
+	}
+	globParsers[explodedJenkinsPluginGlob] = parseExplodedJenkinsPlugin
+
+	return &JenkinsPluginCataloger{
+		cataloger: common.NewGenericCataloger(nil, globParsers, jenkinsPluginCatalogerName),
+	}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *JenkinsPluginCataloger) Name() string {
+	return jenkinsPluginCatalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns any discovered Packages
+// after analyzing the packaged and extracted Jenkins plugins.
+func (c *JenkinsPluginCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	pkgs, relationships, err := c.cataloger.Catalog(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pkgs = removeExtractedJenkinsPlugins(pkgs)
+
+	relationships = append(relationships, archiveContainsRelationships(pkgs)...)
+
+	return pkgs, append(relationships, dependency.Resolve(jenkinsPluginDependencySpecification, pkgs)...), nil
+}
+
+// parseExplodedJenkinsPlugin is a parser function for the manifest of a plugin extracted by Jenkins, returning the
+// plugin (when the manifest describes one).
+func parseExplodedJenkinsPlugin(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	manifest, err := parseJavaManifest(path, reader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	name := manifest.Main["Short-Name"]
+	if name == "" {
+		// this is not a Jenkins plugin
+		return nil, nil, nil
+	}
+
+	p := &pkg.Package{
+		Name:         name,
+		Version:      selectVersion(manifest, archiveFilename{}),
+		Language:     pkg.Java,
+		Type:         pkg.JenkinsPluginPkg,
+		MetadataType: pkg.JavaMetadataType,
+		Metadata: pkg.JavaMetadata{
+			VirtualPath: strings.TrimSuffix(path, manifestGlob),
+			Manifest:    manifest,
+		},
+	}
+	addPURL(p)
+
+	return []*pkg.Package{p}, nil, nil
+}
+
+// removeExtractedJenkinsPlugins removes the plugins that Jenkins extracted from a plugin archive that was cataloged as
+// well (Jenkins extracts "plugins/git.jpi" into "plugins/git/"), so that each installed plugin is only reported once.
+func removeExtractedJenkinsPlugins(pkgs []pkg.Package) []pkg.Package {
+	archives := make(map[string]struct{})
+	for _, p := range pkgs {
+		for _, l := range p.Locations.ToSlice() {
+			switch strings.ToLower(filepath.Ext(l.RealPath)) {
+			case ".jpi", ".hpi":
+				archives[strings.TrimSuffix(l.RealPath, filepath.Ext(l.RealPath))] = struct{}{}
+			}
+		}
+	}
+
+	var results []pkg.Package
+	for _, p := range pkgs {
+		if isExtractedFromArchive(p, archives) {
+			continue
+		}
+		results = append(results, p)
+	}
+	return results
+}
+
+func isExtractedFromArchive(p pkg.Package, archives map[string]struct{}) bool {
+	locations := p.Locations.ToSlice()
+	if len(locations) == 0 {
+		return false
+	}
+	for _, l := range locations {
+		if !strings.HasSuffix(l.RealPath, manifestGlob) {
+			return false
+		}
+		if _, exists := archives[strings.TrimSuffix(l.RealPath, manifestGlob)]; !exists {
+			return false
+		}
+	}
+	return true
+}
+
+// jenkinsPluginDependencySpecification describes the plugin name that a Jenkins plugin provides and the plugins that
+// it requires (from the Plugin-Dependencies of the manifest).
+func jenkinsPluginDependencySpecification(p pkg.Package) dependency.Specification {
+	metadata, ok := p.Metadata.(pkg.JavaMetadata)
+	if !ok || metadata.Manifest == nil || p.Type != pkg.JenkinsPluginPkg {
+		return dependency.Specification{}
+	}
+
+	name := metadata.Manifest.Main["Short-Name"]
+	if name == "" {
+		return dependency.Specification{}
+	}
+
+	var requires []dependency.Requirement
+	for _, dep := range parseJenkinsPluginDependencies(metadata.Manifest.Main["Plugin-Dependencies"]) {
+		requires = append(requires, dependency.Requirement{dep})
+	}
+
+	return dependency.Specification{
+		Provides: []string{name},
+		Requires: requires,
+	}
+}
+
+// parseJenkinsPluginDependencies returns the names of the plugins listed by a Plugin-Dependencies manifest entry
+// (e.g. "credentials:1139.veb_9579fca_33b_,structs:324.va_f5d6774f3a_d;resolution:=optional"). Optional dependencies
+// are included, since they are used whenever they are installed.
+func parseJenkinsPluginDependencies(value string) []string {
+	var names []string
+	for _, field := range strings.Split(value, ",") {
+		dep, _, _ := strings.Cut(field, ";")
+		name, _, _ := strings.Cut(dep, ":")
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
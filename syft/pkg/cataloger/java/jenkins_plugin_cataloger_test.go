@@ -0,0 +1,86 @@
+package java
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestJenkinsPluginCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/jenkins-home/plugins/credentials.jpi",
+		"test-fixtures/jenkins-home/plugins/credentials/META-INF/MANIFEST.MF",
+		"test-fixtures/jenkins-home/plugins/git/META-INF/MANIFEST.MF",
+		"test-fixtures/jenkins-home/plugins/structs/META-INF/MANIFEST.MF",
+		"test-fixtures/jenkins-home/plugins/not-a-plugin/META-INF/MANIFEST.MF",
+	)
+
+	pkgs, relationships, err := NewJenkinsPluginCataloger(Config{}).Catalog(resolver)
+	require.NoError(t, err)
+
+	var gotPkgs []string
+	for _, p := range pkgs {
+		assert.Equal(t, pkg.JenkinsPluginPkg, p.Type)
+		assert.Equal(t, jenkinsPluginCatalogerName, p.FoundBy)
+		metadata, ok := p.Metadata.(pkg.JavaMetadata)
+		require.True(t, ok)
+		gotPkgs = append(gotPkgs, fmt.Sprintf("%s@%s (%s)", p.Name, p.Version, metadata.VirtualPath))
+	}
+	assert.ElementsMatch(t, []string{
+		// the plugin extracted from credentials.jpi is only reported by the archive
+		"credentials@1139.veb_9579fca_33b_ (test-fixtures/jenkins-home/plugins/credentials.jpi)",
+		"git@5.0.0 (test-fixtures/jenkins-home/plugins/git)",
+		"structs@324.va_f5d6774f3a_d (test-fixtures/jenkins-home/plugins/structs)",
+	}, gotPkgs)
+
+	var gotRelationships []string
+	for _, r := range relationships {
+		gotRelationships = append(gotRelationships, fmt.Sprintf("%s %s %s", r.From.(pkg.Package).Name, r.Type, r.To.(pkg.Package).Name))
+	}
+	assert.ElementsMatch(t, []string{
+		"structs dependency-of credentials",
+		"credentials dependency-of git",
+		"structs dependency-of git",
+	}, gotRelationships)
+}
+
+func TestJavaCatalogerSkipsJenkinsPlugins(t *testing.T) {
+	resolver := source.NewMockResolverForPaths("test-fixtures/jenkins-home/plugins/credentials.jpi")
+
+	pkgs, _, err := NewJavaCataloger(Config{}).Catalog(resolver)
+	require.NoError(t, err)
+	assert.Empty(t, pkgs)
+}
+
+func Test_parseJenkinsPluginDependencies(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected []string
+	}{
+		{
+			value: "",
+		},
+		{
+			value:    "structs:324.va_f5d6774f3a_d",
+			expected: []string{"structs"},
+		},
+		{
+			value:    "credentials:1139.veb_9579fca_33b_,workflow-step-api:639.v6eca_cd8c04a_a;resolution:=optional",
+			expected: []string{"credentials", "workflow-step-api"},
+		},
+		{
+			value:    "credentials, structs:324.va_f5d6774f3a_d,",
+			expected: []string{"credentials", "structs"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			assert.Equal(t, test.expected, parseJenkinsPluginDependencies(test.value))
+		})
+	}
+}
@@ -1,9 +1,9 @@
 package java
 
 import (
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/pkg"
 	"github.com/anchore/syft/syft/pkg/cataloger/common/cpe"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 // PackageURL returns the PURL for the specific java package (see https://github.com/package-url/purl-spec)
@@ -14,12 +14,6 @@ func packageURL(p pkg.Package) string {
 		groupID = groupIDs[0]
 	}
 
-	pURL := packageurl.NewPackageURL(
-		packageurl.TypeMaven, // TODO: should we filter down by package types here?
-		groupID,
-		p.Name,
-		p.Version,
-		nil, // TODO: there are probably several qualifiers that can be specified here
-		"")
-	return pURL.ToString()
+	// TODO: there are probably several qualifiers that can be specified here
+	return purl.Maven(groupID, p.Name, p.Version)
 }
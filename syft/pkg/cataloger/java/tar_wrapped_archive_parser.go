@@ -5,13 +5,13 @@ import (
 	"io"
 
 	"github.com/anchore/syft/internal/file"
+	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
-	"github.com/anchore/syft/syft/pkg/cataloger/common"
 )
 
 // integrity check
-var _ common.ParserFn = parseTarWrappedJavaArchive
+var _ javaArchiveParserFn = parseTarWrappedJavaArchive
 
 var genericTarGlobs = []string{
 	"**/*.tar",
@@ -45,7 +45,7 @@ var genericTarGlobs = []string{
 // note: for compressed tars this is an extremely expensive operation and can lead to performance degradation. This is
 // due to the fact that there is no central directory header (say as in zip), which means that in order to get
 // a file listing within the archive you must decompress the entire archive and seek through all of the entries.
-func parseTarWrappedJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func parseTarWrappedJavaArchive(virtualPath string, reader io.Reader, search *archiveSearch) ([]*pkg.Package, []artifact.Relationship, error) {
 	contentPath, archivePath, cleanupFn, err := saveArchiveToTmp(virtualPath, reader)
 	// note: even on error, we should always run cleanup functions
 	defer cleanupFn()
@@ -53,15 +53,29 @@ func parseTarWrappedJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Pa
 		return nil, nil, err
 	}
 
-	// look for java archives within the tar archive
-	return discoverPkgsFromTar(virtualPath, archivePath, contentPath)
+	// look for java archives (and other wrapping archives) within the tar archive
+	return discoverPkgsFromTar(virtualPath, archivePath, contentPath, search)
 }
 
-func discoverPkgsFromTar(virtualPath, archivePath, contentPath string) ([]*pkg.Package, []artifact.Relationship, error) {
-	openers, err := file.ExtractGlobsFromTarToUniqueTempFile(archivePath, contentPath, archiveFormatGlobs...)
+// discoverPkgsFromTar finds the java archives (and zip and tar archives, when searching them is enabled) within a tar
+// archive, returning all listed Java packages found. Nested archives are only extracted within the depth and size
+// limits of the given search.
+func discoverPkgsFromTar(virtualPath, archivePath, contentPath string, search *archiveSearch) ([]*pkg.Package, []artifact.Relationship, error) {
+	nested := search.nested()
+	if nested == nil {
+		log.Warnf("skipping archives nested within %q: the maximum nested archive depth has been reached", virtualPath)
+		return nil, nil, nil
+	}
+
+	// the size of each file is recorded in its header, so it can be checked before the file is extracted
+	selector := func(name string, size int64) bool {
+		return nested.reserve(virtualPath, name, size)
+	}
+
+	openers, err := file.ExtractSelectedGlobsFromTarToUniqueTempFile(archivePath, contentPath, selector, search.wrappedArchiveGlobs()...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("unable to extract files from tar: %w", err)
 	}
 
-	return discoverPkgsFromOpeners(virtualPath, openers, nil)
+	return discoverPkgsFromOpeners(virtualPath, openers, nil, nested)
 }
@@ -38,7 +38,7 @@ func Test_parseTarWrappedJavaArchive(t *testing.T) {
 				t.Fatalf("failed to open fixture: %+v", err)
 			}
 
-			actualPkgs, _, err := parseTarWrappedJavaArchive(test.fixture, fixture)
+			actualPkgs, _, err := parseTarWrappedJavaArchive(test.fixture, fixture, newArchiveSearch(Config{}))
 			require.NoError(t, err)
 
 			var actualNames []string
@@ -7,11 +7,10 @@ import (
 	"github.com/anchore/syft/internal/file"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
-	"github.com/anchore/syft/syft/pkg/cataloger/common"
 )
 
 // integrity check
-var _ common.ParserFn = parseZipWrappedJavaArchive
+var _ javaArchiveParserFn = parseZipWrappedJavaArchive
 
 var genericZipGlobs = []string{
 	"**/*.zip",
@@ -20,7 +19,7 @@ var genericZipGlobs = []string{
 // TODO: when the generic archive cataloger is implemented, this should be removed (https://github.com/anchore/syft/issues/246)
 
 // parseZipWrappedJavaArchive is a parser function for java archive contents contained within arbitrary zip files.
-func parseZipWrappedJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+func parseZipWrappedJavaArchive(virtualPath string, reader io.Reader, search *archiveSearch) ([]*pkg.Package, []artifact.Relationship, error) {
 	contentPath, archivePath, cleanupFn, err := saveArchiveToTmp(virtualPath, reader)
 	// note: even on error, we should always run cleanup functions
 	defer cleanupFn()
@@ -37,6 +36,6 @@ func parseZipWrappedJavaArchive(virtualPath string, reader io.Reader) ([]*pkg.Pa
 		return nil, nil, fmt.Errorf("unable to read files from java archive: %w", err)
 	}
 
-	// look for java archives within the zip archive
-	return discoverPkgsFromZip(virtualPath, archivePath, contentPath, fileManifest, nil)
+	// look for java archives (and other wrapping archives) within the zip archive
+	return discoverPkgsFromZip(virtualPath, archivePath, contentPath, fileManifest, nil, search, search.wrappedArchiveGlobs()...)
 }
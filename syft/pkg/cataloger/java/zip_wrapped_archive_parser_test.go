@@ -31,7 +31,7 @@ func Test_parseZipWrappedJavaArchive(t *testing.T) {
 				t.Fatalf("failed to open fixture: %+v", err)
 			}
 
-			actualPkgs, _, err := parseZipWrappedJavaArchive(test.fixture, fixture)
+			actualPkgs, _, err := parseZipWrappedJavaArchive(test.fixture, fixture, newArchiveSearch(Config{}))
 			require.NoError(t, err)
 
 			var actualNames []string
@@ -21,18 +21,49 @@ func NewJavascriptPackageCataloger() *common.GenericCataloger {
 		"**/package.json": parsePackageJSON,
 	}
 
-	return common.NewGenericCataloger(nil, globParsers, "javascript-package-cataloger")
+	return common.NewGenericCataloger(nil, globParsers, "javascript-package-cataloger", addWorkspace)
 }
 
 // NewJavascriptLockCataloger returns a new Javascript cataloger object base on package lock files.
-func NewJavascriptLockCataloger() *common.GenericCataloger {
+func NewJavascriptLockCataloger(cfg Config) *common.GenericCataloger {
 	globParsers := map[string]common.ParserFn{
 		"**/package-lock.json": parsePackageLock,
 		"**/yarn.lock":         parseYarnLock,
 		"**/pnpm-lock.yaml":    parsePnpmLock,
 	}
 
-	return common.NewGenericCataloger(nil, globParsers, "javascript-lock-cataloger", addLicenses)
+	postProcessors := []common.PostProcessFunc{addLicenses}
+	if cfg.SearchRemoteLicenses {
+		postProcessors = append(postProcessors, newNPMRegistry(cfg.NPMRegistry).addLicenses)
+	}
+
+	return common.NewGenericCataloger(nil, globParsers, "javascript-lock-cataloger", postProcessors...)
+}
+
+// addWorkspace records the project (workspace) that an installed package was installed for.
+func addWorkspace(_ source.FileResolver, location source.Location, p *pkg.Package) error {
+	metadata, ok := p.Metadata.(pkg.NpmPackageJSONMetadata)
+	if !ok {
+		return nil
+	}
+	metadata.Workspace = workspaceDirectory(location.RealPath)
+	p.Metadata = metadata
+	return nil
+}
+
+// workspaceDirectory returns the directory of the project that the package.json at the given path belongs to: the
+// directory containing the outermost node_modules directory for installed packages (regardless of whether the
+// packages were hoisted, nested, or installed within a pnpm store), otherwise the directory of the package.json.
+// Paths relative to the source root (as found within directory sources) are treated as absolute.
+func workspaceDirectory(packageJSONPath string) string {
+	packageJSONPath = path.Clean("/" + packageJSONPath)
+	if i := strings.Index(packageJSONPath, "/node_modules/"); i >= 0 {
+		if i == 0 {
+			return "/"
+		}
+		return packageJSONPath[:i]
+	}
+	return path.Dir(packageJSONPath)
 }
 
 func addLicenses(resolver source.FileResolver, location source.Location, p *pkg.Package) error {
@@ -77,7 +108,11 @@ func addLicenses(resolver source.FileResolver, location source.Location, p *pkg.
 			return nil
 		}
 
-		p.Licenses = append(p.Licenses, licenses...)
+		for _, l := range pkgJSON.packageLicenses(licenses) {
+			coordinates := location.Coordinates
+			l.Location = &coordinates
+			p.Licenses = append(p.Licenses, l)
+		}
 	}
 
 	return nil
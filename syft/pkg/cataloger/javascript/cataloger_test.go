@@ -3,6 +3,7 @@ package javascript
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/pkg"
@@ -16,7 +17,9 @@ func Test_JavascriptCataloger(t *testing.T) {
 			Version:  "1.6.0",
 			Language: pkg.JavaScript,
 			Type:     pkg.NpmPkg,
-			Licenses: []string{"MIT"},
+			Licenses: []pkg.License{
+				licenseFromLocation("MIT", "node_modules/@actions/core/package.json"),
+			},
 		},
 		"wordwrap": {
 			Name:     "wordwrap",
@@ -77,7 +80,9 @@ func Test_JavascriptCataloger(t *testing.T) {
 			Version:  "1.4.0",
 			Language: pkg.JavaScript,
 			Type:     pkg.NpmPkg,
-			Licenses: []string{"MIT"},
+			Licenses: []pkg.License{
+				licenseFromLocation("MIT", "node_modules/cowsay/package.json"),
+			},
 		},
 	}
 
@@ -87,7 +92,7 @@ func Test_JavascriptCataloger(t *testing.T) {
 	resolver, err := s.FileResolver(source.AllLayersScope)
 	require.NoError(t, err)
 
-	actual, _, err := NewJavascriptLockCataloger().Catalog(resolver)
+	actual, _, err := NewJavascriptLockCataloger(DefaultConfig()).Catalog(resolver)
 	if err != nil {
 		t.Fatalf("failed to parse package-lock.json: %+v", err)
 	}
@@ -100,3 +105,79 @@ func Test_JavascriptCataloger(t *testing.T) {
 
 	assertPkgsEqual(t, pkgs, expected)
 }
+
+func licenseFromLocation(value, path string) pkg.License {
+	return pkg.NewLicenseFromLocation(value, source.NewLocation(path))
+}
+
+func Test_JavascriptPackageCataloger_Monorepo(t *testing.T) {
+	// name@version -> workspace
+	expected := map[string]string{
+		"monorepo@1.0.0":    "/",
+		"app@2.1.0":         "/packages/app",
+		"lodash@4.17.21":    "/",
+		"lodash@4.17.20":    "/packages/app",
+		"@scope/util@1.2.3": "/",
+	}
+
+	s, err := source.NewFromDirectory("test-fixtures/monorepo")
+	require.NoError(t, err)
+
+	resolver, err := s.FileResolver(source.AllLayersScope)
+	require.NoError(t, err)
+
+	actual, _, err := NewJavascriptPackageCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	// the workspace package linked within node_modules is cataloged once, from its real path
+	workspaces := make(map[string]string)
+	for _, p := range actual {
+		key := p.Name + "@" + p.Version
+		require.NotContains(t, workspaces, key, "duplicate package")
+		metadata, ok := p.Metadata.(pkg.NpmPackageJSONMetadata)
+		require.True(t, ok)
+		workspaces[key] = metadata.Workspace
+	}
+	assert.Equal(t, expected, workspaces)
+}
+
+func Test_workspaceDirectory(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{
+			path:     "/package.json",
+			expected: "/",
+		},
+		{
+			path:     "/app/packages/a/package.json",
+			expected: "/app/packages/a",
+		},
+		{
+			path:     "app/packages/a/package.json",
+			expected: "/app/packages/a",
+		},
+		{
+			path:     "/node_modules/lodash/package.json",
+			expected: "/",
+		},
+		{
+			path:     "/app/node_modules/a/node_modules/b/package.json",
+			expected: "/app",
+		},
+		{
+			path:     "/app/packages/a/node_modules/lodash/package.json",
+			expected: "/app/packages/a",
+		},
+		{
+			path:     "/app/node_modules/.pnpm/lodash@4.17.21/node_modules/lodash/package.json",
+			expected: "/app",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, workspaceDirectory(test.path))
+		})
+	}
+}
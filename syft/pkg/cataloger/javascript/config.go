@@ -0,0 +1,18 @@
+package javascript
+
+const defaultNPMRegistry = "https://registry.npmjs.org"
+
+type Config struct {
+	// SearchRemoteLicenses looks up the licenses of packages from lock files within the npm registry when the licenses
+	// cannot be found within the installed node_modules (note: this requires network access)
+	SearchRemoteLicenses bool
+	// NPMRegistry is the base URL of the npm registry used to look up licenses (defaults to the public npm registry)
+	NPMRegistry string
+}
+
+func DefaultConfig() Config {
+	return Config{
+		SearchRemoteLicenses: false,
+		NPMRegistry:          defaultNPMRegistry,
+	}
+}
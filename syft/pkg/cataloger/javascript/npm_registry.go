@@ -0,0 +1,80 @@
+package javascript
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// npmRegistry looks up the package.json of published packages from an npm registry.
+type npmRegistry struct {
+	baseURL string
+	client  *http.Client
+	cache   map[string][]pkg.License
+}
+
+func newNPMRegistry(baseURL string) *npmRegistry {
+	if baseURL == "" {
+		baseURL = defaultNPMRegistry
+	}
+	return &npmRegistry{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+		cache:   make(map[string][]pkg.License),
+	}
+}
+
+// addLicenses is a post-processor that adds the licenses declared within the npm registry to packages that do not
+// have any licenses from the installed node_modules.
+func (r *npmRegistry) addLicenses(_ source.FileResolver, _ source.Location, p *pkg.Package) error {
+	if len(p.Licenses) > 0 || p.Name == "" || p.Version == "" {
+		return nil
+	}
+
+	licenses, err := r.licenses(p.Name, p.Version)
+	if err != nil {
+		log.Debugf("unable to find licenses within the npm registry for %s@%s: %+v", p.Name, p.Version, err)
+		return nil
+	}
+	p.Licenses = append(p.Licenses, licenses...)
+	return nil
+}
+
+func (r *npmRegistry) licenses(name, version string) ([]pkg.License, error) {
+	key := name + "@" + version
+	if licenses, exists := r.cache[key]; exists {
+		return licenses, nil
+	}
+
+	// scoped package names are requested with an escaped separator (e.g. "@actions%2fcore")
+	u := fmt.Sprintf("%s/%s/%s", r.baseURL, strings.Replace(name, "/", "%2f", 1), url.PathEscape(version))
+	resp, err := r.client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status from %q: %s", u, resp.Status)
+	}
+
+	var pkgJSON packageJSON
+	if err := json.NewDecoder(resp.Body).Decode(&pkgJSON); err != nil {
+		return nil, fmt.Errorf("unable to parse package from %q: %w", u, err)
+	}
+
+	values, err := pkgJSON.licensesFromJSON()
+	if err != nil {
+		return nil, err
+	}
+	licenses := pkgJSON.packageLicenses(values)
+	r.cache[key] = licenses
+	return licenses, nil
+}
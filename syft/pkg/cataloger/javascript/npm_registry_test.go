@@ -0,0 +1,62 @@
+package javascript
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func Test_npmRegistry_addLicenses(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.EscapedPath())
+		switch r.URL.EscapedPath() {
+		case "/@actions%2fcore/1.6.0":
+			_, _ = w.Write([]byte(`{"name": "@actions/core", "version": "1.6.0", "license": "MIT"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	registry := newNPMRegistry(server.URL + "/")
+
+	tests := []struct {
+		name     string
+		pkg      pkg.Package
+		expected []pkg.License
+	}{
+		{
+			name:     "scoped package",
+			pkg:      pkg.Package{Name: "@actions/core", Version: "1.6.0"},
+			expected: []pkg.License{pkg.NewLicense("MIT")},
+		},
+		{
+			name:     "keep licenses from node_modules",
+			pkg:      pkg.Package{Name: "@actions/core", Version: "1.6.0", Licenses: []pkg.License{pkg.NewLicense("Apache-2.0")}},
+			expected: []pkg.License{pkg.NewLicense("Apache-2.0")},
+		},
+		{
+			name: "package not found",
+			pkg:  pkg.Package{Name: "missing", Version: "1.0.0"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := test.pkg
+			require.NoError(t, registry.addLicenses(nil, source.Location{}, &p))
+			assert.Equal(t, test.expected, p.Licenses)
+		})
+	}
+
+	// the registry is only asked once for each package
+	p := pkg.Package{Name: "@actions/core", Version: "1.6.0"}
+	require.NoError(t, registry.addLicenses(nil, source.Location{}, &p))
+	assert.Equal(t, []string{"/@actions%2fcore/1.6.0", "/missing/1.0.0"}, requests)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"regexp"
+	"strings"
 
 	"github.com/mitchellh/mapstructure"
 
@@ -162,6 +163,30 @@ func (p packageJSON) licensesFromJSON() ([]string, error) {
 	return nil, err
 }
 
+// packageLicenses creates the package licenses for the given license values, attaching the license URLs described by
+// the (deprecated) license objects.
+func (p packageJSON) packageLicenses(values []string) []pkg.License {
+	urls := make(map[string]string)
+	var licenseObject license
+	if err := json.Unmarshal(p.License, &licenseObject); err == nil {
+		urls[licenseObject.Type] = licenseObject.URL
+	}
+	if licenseObjects, err := licensesFromJSON(p.Licenses); err == nil {
+		for _, l := range licenseObjects {
+			urls[l.Type] = l.URL
+		}
+	}
+
+	var licenses []pkg.License
+	for _, value := range values {
+		if strings.TrimSpace(value) == "" {
+			continue
+		}
+		licenses = append(licenses, pkg.NewLicenseFromURL(value, urls[value]))
+	}
+	return licenses
+}
+
 func licensesFromJSON(b []byte) ([]license, error) {
 	var licenseObject []license
 	err := json.Unmarshal(b, &licenseObject)
@@ -174,6 +199,13 @@ func licensesFromJSON(b []byte) ([]license, error) {
 
 // parsePackageJSON parses a package.json and returns the discovered JavaScript packages.
 func parsePackageJSON(path string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
+	// installed packages may contain package.json files of their own (e.g. within bundled build output or test
+	// fixtures), which do not describe installed packages
+	if withinInstalledPackage(path) {
+		log.Debugf("skipping package.json file within an installed package (path=%q)", path)
+		return nil, nil, nil
+	}
+
 	var packages []*pkg.Package
 	dec := json.NewDecoder(reader)
 
@@ -205,7 +237,7 @@ func newPackageJSONPackage(p packageJSON) *pkg.Package {
 	return &pkg.Package{
 		Name:         p.Name,
 		Version:      p.Version,
-		Licenses:     licenses,
+		Licenses:     p.packageLicenses(licenses),
 		Language:     pkg.JavaScript,
 		Type:         pkg.NpmPkg,
 		MetadataType: pkg.NpmPackageJSONMetadataType,
@@ -236,3 +268,21 @@ func pathContainsNodeModulesDirectory(p string) bool {
 	}
 	return false
 }
+
+// withinInstalledPackage indicates if the given package.json path is within (but not at the root of) a package
+// installed within a node_modules directory, e.g. node_modules/lodash/fp/package.json.
+func withinInstalledPackage(p string) bool {
+	segments := filepathSeparator.Split(p, -1)
+	for i := len(segments) - 2; i >= 0; i-- {
+		if segments[i] != "node_modules" {
+			continue
+		}
+		// the directories between the node_modules directory and the package.json file
+		dirs := segments[i+1 : len(segments)-1]
+		if len(dirs) > 0 && strings.HasPrefix(dirs[0], "@") {
+			return len(dirs) > 2
+		}
+		return len(dirs) > 1
+	}
+	return false
+}
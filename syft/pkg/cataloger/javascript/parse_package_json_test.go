@@ -21,7 +21,7 @@ func TestParsePackageJSON(t *testing.T) {
 				Name:         "npm",
 				Version:      "6.14.6",
 				Type:         pkg.NpmPkg,
-				Licenses:     []string{"Artistic-2.0"},
+				Licenses:     pkg.NewLicensesFromValues("Artistic-2.0"),
 				Language:     pkg.JavaScript,
 				MetadataType: pkg.NpmPackageJSONMetadataType,
 				Metadata: pkg.NpmPackageJSONMetadata{
@@ -40,7 +40,7 @@ func TestParsePackageJSON(t *testing.T) {
 				Name:         "npm",
 				Version:      "6.14.6",
 				Type:         pkg.NpmPkg,
-				Licenses:     []string{"ISC"},
+				Licenses:     []pkg.License{pkg.NewLicenseFromURL("ISC", "https://opensource.org/licenses/ISC")},
 				Language:     pkg.JavaScript,
 				MetadataType: pkg.NpmPackageJSONMetadataType,
 				Metadata: pkg.NpmPackageJSONMetadata{
@@ -56,10 +56,13 @@ func TestParsePackageJSON(t *testing.T) {
 		{
 			Fixture: "test-fixtures/pkg-json/package-license-objects.json",
 			ExpectedPkg: pkg.Package{
-				Name:         "npm",
-				Version:      "6.14.6",
-				Type:         pkg.NpmPkg,
-				Licenses:     []string{"MIT", "Apache-2.0"},
+				Name:    "npm",
+				Version: "6.14.6",
+				Type:    pkg.NpmPkg,
+				Licenses: []pkg.License{
+					pkg.NewLicenseFromURL("MIT", "https://www.opensource.org/licenses/mit-license.php"),
+					pkg.NewLicenseFromURL("Apache-2.0", "https://opensource.org/licenses/apache2.0.php"),
+				},
 				Language:     pkg.JavaScript,
 				MetadataType: pkg.NpmPackageJSONMetadataType,
 				Metadata: pkg.NpmPackageJSONMetadata{
@@ -97,7 +100,7 @@ func TestParsePackageJSON(t *testing.T) {
 				Name:         "npm",
 				Version:      "6.14.6",
 				Type:         pkg.NpmPkg,
-				Licenses:     []string{},
+				Licenses:     nil,
 				Language:     pkg.JavaScript,
 				MetadataType: pkg.NpmPackageJSONMetadataType,
 				Metadata: pkg.NpmPackageJSONMetadata{
@@ -116,7 +119,7 @@ func TestParsePackageJSON(t *testing.T) {
 				Name:         "npm",
 				Version:      "6.14.6",
 				Type:         pkg.NpmPkg,
-				Licenses:     []string{"Artistic-2.0"},
+				Licenses:     pkg.NewLicensesFromValues("Artistic-2.0"),
 				Language:     pkg.JavaScript,
 				MetadataType: pkg.NpmPackageJSONMetadataType,
 				Metadata: pkg.NpmPackageJSONMetadata{
@@ -135,7 +138,7 @@ func TestParsePackageJSON(t *testing.T) {
 				Name:         "function-bind",
 				Version:      "1.1.1",
 				Type:         pkg.NpmPkg,
-				Licenses:     []string{"MIT"},
+				Licenses:     pkg.NewLicensesFromValues("MIT"),
 				Language:     pkg.JavaScript,
 				MetadataType: pkg.NpmPackageJSONMetadataType,
 				Metadata: pkg.NpmPackageJSONMetadata{
@@ -154,7 +157,7 @@ func TestParsePackageJSON(t *testing.T) {
 				Name:         "npm",
 				Version:      "6.14.6",
 				Type:         pkg.NpmPkg,
-				Licenses:     []string{"Artistic-2.0"},
+				Licenses:     pkg.NewLicensesFromValues("Artistic-2.0"),
 				Language:     pkg.JavaScript,
 				MetadataType: pkg.NpmPackageJSONMetadataType,
 				Metadata: pkg.NpmPackageJSONMetadata{
@@ -260,3 +263,58 @@ func Test_pathContainsNodeModulesDirectory(t *testing.T) {
 		})
 	}
 }
+
+func Test_withinInstalledPackage(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		// positive
+		{
+			path:     "/node_modules/lodash/fp/package.json",
+			expected: true,
+		},
+		{
+			path:     "/app/node_modules/@scope/util/esm/package.json",
+			expected: true,
+		},
+		{
+			path:     "/node_modules/a/node_modules/b/dist/package.json",
+			expected: true,
+		},
+		{
+			path:     "\\app\\node_modules\\lodash\\fp\\package.json",
+			expected: true,
+		},
+		// negative
+		{
+			path:     "/package.json",
+			expected: false,
+		},
+		{
+			path:     "/app/packages/a/package.json",
+			expected: false,
+		},
+		{
+			path:     "/node_modules/lodash/package.json",
+			expected: false,
+		},
+		{
+			path:     "/node_modules/@scope/util/package.json",
+			expected: false,
+		},
+		{
+			path:     "/node_modules/a/node_modules/b/package.json",
+			expected: false,
+		},
+		{
+			path:     "/node_modules/.pnpm/lodash@4.17.21/node_modules/lodash/package.json",
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, withinInstalledPackage(test.path))
+		})
+	}
+}
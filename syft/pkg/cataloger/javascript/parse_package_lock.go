@@ -63,12 +63,17 @@ func parsePackageLock(path string, reader io.Reader) ([]*pkg.Package, []artifact
 		}
 
 		for name, pkgMeta := range lock.Dependencies {
+			if strings.HasPrefix(pkgMeta.Version, "file:") {
+				// links to local packages (e.g. the packages of the workspaces of a monorepo) are not installs
+				// themselves, the linked packages are cataloged from their package.json files instead
+				continue
+			}
 			var sb strings.Builder
 			sb.WriteString(pkgMeta.Resolved)
 			sb.WriteString(pkgMeta.Integrity)
-			var licenses []string
+			var licenses []pkg.License
 			if license, exists := licenseMap[sb.String()]; exists {
-				licenses = append(licenses, license)
+				licenses = pkg.NewLicensesFromValues(license)
 			}
 			packages = append(packages, &pkg.Package{
 				Name:     name,
@@ -119,28 +119,28 @@ func TestParsePackageLockV2(t *testing.T) {
 			Version:  "15.7.5",
 			Language: pkg.JavaScript,
 			Type:     pkg.NpmPkg,
-			Licenses: []string{"MIT"},
+			Licenses: pkg.NewLicensesFromValues("MIT"),
 		},
 		"@types/react": {
 			Name:     "@types/prop-types",
 			Version:  "18.0.17",
 			Language: pkg.JavaScript,
 			Type:     pkg.NpmPkg,
-			Licenses: []string{"MIT"},
+			Licenses: pkg.NewLicensesFromValues("MIT"),
 		},
 		"@types/scheduler": {
 			Name:     "@types/scheduler",
 			Version:  "0.16.2",
 			Language: pkg.JavaScript,
 			Type:     pkg.NpmPkg,
-			Licenses: []string{"MIT"},
+			Licenses: pkg.NewLicensesFromValues("MIT"),
 		},
 		"csstype": {
 			Name:     "csstype",
 			Version:  "3.1.0",
 			Language: pkg.JavaScript,
 			Type:     pkg.NpmPkg,
-			Licenses: []string{"MIT"},
+			Licenses: pkg.NewLicensesFromValues("MIT"),
 		},
 	}
 	fixture, err := os.Open("test-fixtures/pkg-lock/package-lock-2.json")
@@ -155,3 +155,27 @@ func TestParsePackageLockV2(t *testing.T) {
 
 	assertPkgsEqual(t, actual, expected)
 }
+
+func TestParsePackageLock_Workspaces(t *testing.T) {
+	// the workspace packages are linked (not installed), so only the installed dependencies are expected
+	expected := map[string]pkg.Package{
+		"lodash": {
+			Name:     "lodash",
+			Version:  "4.17.21",
+			Language: pkg.JavaScript,
+			Type:     pkg.NpmPkg,
+			Licenses: pkg.NewLicensesFromValues("MIT"),
+		},
+	}
+	fixture, err := os.Open("test-fixtures/pkg-lock/package-lock-workspaces.json")
+	if err != nil {
+		t.Fatalf("failed to open fixture: %+v", err)
+	}
+
+	actual, _, err := parsePackageLock(fixture.Name(), fixture)
+	if err != nil {
+		t.Fatalf("failed to parse package-lock.json: %+v", err)
+	}
+
+	assertPkgsEqual(t, actual, expected)
+}
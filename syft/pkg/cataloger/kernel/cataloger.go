@@ -0,0 +1,77 @@
+/*
+Package kernel provides a concrete Cataloger implementation for Linux kernel images and loadable kernel modules.
+*/
+package kernel
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+const catalogerName = "linux-kernel-cataloger"
+
+var kernelArchiveGlobs = []string{
+	"**/vmlinuz",
+	"**/vmlinuz-*",
+}
+
+var kernelModuleGlobs = []string{
+	"**/lib/modules/**/*.ko",
+}
+
+// Cataloger finds Linux kernel images and the kernel modules that were built for them.
+type Cataloger struct {
+	cataloger *generic.Cataloger
+}
+
+// NewLinuxKernelCataloger returns a new cataloger object for Linux kernel images and loadable kernel modules.
+func NewLinuxKernelCataloger() *Cataloger {
+	return &Cataloger{
+		cataloger: generic.NewCataloger(catalogerName).
+			WithParserByGlobs(parseLinuxKernelFile, kernelArchiveGlobs...).
+			WithParserByGlobs(parseLinuxKernelModuleFile, kernelModuleGlobs...),
+	}
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *Cataloger) Name() string {
+	return catalogerName
+}
+
+// Catalog is given an object to resolve file references and content, this function returns any discovered Packages
+// after analyzing the kernel images and kernel modules, relating each module to the kernel it was built for.
+func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	pkgs, relationships, err := c.cataloger.Catalog(resolver)
+	if err != nil {
+		return nil, nil, err
+	}
+	return pkgs, append(relationships, kernelModuleRelationships(pkgs)...), nil
+}
+
+// kernelModuleRelationships relates each kernel module to the kernel (of the same version) that it was built for.
+func kernelModuleRelationships(pkgs []pkg.Package) []artifact.Relationship {
+	kernelsByVersion := make(map[string][]pkg.Package)
+	for _, p := range pkgs {
+		if metadata, ok := p.Metadata.(pkg.LinuxKernelMetadata); ok {
+			kernelsByVersion[metadata.Version] = append(kernelsByVersion[metadata.Version], p)
+		}
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs {
+		metadata, ok := p.Metadata.(pkg.LinuxKernelModuleMetadata)
+		if !ok {
+			continue
+		}
+		for _, kernel := range kernelsByVersion[metadata.KernelVersion] {
+			relationships = append(relationships, artifact.Relationship{
+				From: p,
+				To:   kernel,
+				Type: artifact.DependencyOfRelationship,
+			})
+		}
+	}
+	return relationships
+}
@@ -0,0 +1,123 @@
+package kernel
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestLinuxKernelCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/boot/vmlinuz-6.1.0-13-amd64",
+		"test-fixtures/boot/vmlinuz-6.5.0-1005-raspi",
+		"test-fixtures/lib/modules/6.1.0-13-amd64/kernel/drivers/net/dummy.ko",
+		"test-fixtures/lib/modules/6.1.0-13-amd64/extra/hello.ko",
+	)
+
+	kernel := pkg.Package{
+		Name:         "linux-kernel",
+		Version:      "6.1.0-13-amd64",
+		FoundBy:      catalogerName,
+		Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/boot/vmlinuz-6.1.0-13-amd64")),
+		Type:         pkg.LinuxKernelPkg,
+		PURL:         "pkg:generic/linux-kernel@6.1.0-13-amd64",
+		CPEs:         []pkg.CPE{pkg.MustCPE("cpe:2.3:o:linux:linux_kernel:6.1.0-13-amd64:*:*:*:*:*:*:*")},
+		MetadataType: pkg.LinuxKernelMetadataType,
+		Metadata: pkg.LinuxKernelMetadata{
+			Name:            "linux-kernel",
+			Architecture:    "x86",
+			Version:         "6.1.0-13-amd64",
+			ExtendedVersion: "6.1.0-13-amd64 (debian-kernel@lists.debian.org) #1 SMP PREEMPT_DYNAMIC Debian 6.1.55-1 (2023-09-29)",
+			BuildTime:       "2023-09-29",
+			Author:          "debian-kernel@lists.debian.org",
+			Format:          "bzImage",
+			VideoMode:       "normal",
+		},
+	}
+	raspi := pkg.Package{
+		Name:         "linux-kernel",
+		Version:      "6.5.0-1005-raspi",
+		FoundBy:      catalogerName,
+		Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/boot/vmlinuz-6.5.0-1005-raspi")),
+		Type:         pkg.LinuxKernelPkg,
+		PURL:         "pkg:generic/linux-kernel@6.5.0-1005-raspi",
+		CPEs:         []pkg.CPE{pkg.MustCPE("cpe:2.3:o:linux:linux_kernel:6.5.0-1005-raspi:*:*:*:*:*:*:*")},
+		MetadataType: pkg.LinuxKernelMetadataType,
+		Metadata: pkg.LinuxKernelMetadata{
+			Name:            "linux-kernel",
+			Architecture:    "arm64",
+			Version:         "6.5.0-1005-raspi",
+			ExtendedVersion: "6.5.0-1005-raspi (buildd@bos03-arm64-018) (aarch64-linux-gnu-gcc-13 (Ubuntu 13.2.0-4ubuntu3) 13.2.0, GNU ld (GNU Binutils for Ubuntu) 2.41) #7-Ubuntu SMP PREEMPT_DYNAMIC Tue Oct 10 14:17:21 UTC 2023",
+			BuildTime:       "Tue Oct 10 14:17:21 UTC 2023",
+			Author:          "buildd@bos03-arm64-018",
+			Format:          "Image",
+		},
+	}
+
+	dummyLocation := source.NewLocation("test-fixtures/lib/modules/6.1.0-13-amd64/kernel/drivers/net/dummy.ko")
+	dummy := pkg.Package{
+		Name:         "dummy",
+		FoundBy:      catalogerName,
+		Locations:    source.NewLocationSet(dummyLocation),
+		Licenses:     pkg.NewLicensesFromLocation(dummyLocation, "GPL"),
+		Type:         pkg.LinuxKernelModulePkg,
+		PURL:         "pkg:generic/dummy",
+		MetadataType: pkg.LinuxKernelModuleMetadataType,
+		Metadata: pkg.LinuxKernelModuleMetadata{
+			Name:          "dummy",
+			Path:          dummyLocation.RealPath,
+			Description:   "Dummy netdevice driver which discards all packets sent to it",
+			License:       "GPL",
+			KernelVersion: "6.1.0-13-amd64",
+			VersionMagic:  "6.1.0-13-amd64 SMP preempt mod_unload modversions",
+			Parameters: []pkg.LinuxKernelModuleParameter{
+				{
+					Name:        "numdummies",
+					Type:        "int",
+					Description: "Number of dummy pseudo devices",
+				},
+			},
+			Signature: &pkg.LinuxKernelModuleSignature{
+				Type:          "PKCS#7",
+				Signer:        "Debian Secure Boot CA",
+				KeyID:         "62:78:8D:7F:F4:42:65:B0:EB:D9:76:88:A7:0D:8C:D8:44:CA:43:75",
+				HashAlgorithm: "sha256",
+			},
+		},
+	}
+
+	helloLocation := source.NewLocation("test-fixtures/lib/modules/6.1.0-13-amd64/extra/hello.ko")
+	hello := pkg.Package{
+		Name:         "hello",
+		Version:      "1.2.0",
+		FoundBy:      catalogerName,
+		Locations:    source.NewLocationSet(helloLocation),
+		Licenses:     pkg.NewLicensesFromLocation(helloLocation, "Dual MIT/GPL"),
+		Type:         pkg.LinuxKernelModulePkg,
+		PURL:         "pkg:generic/hello@1.2.0",
+		MetadataType: pkg.LinuxKernelModuleMetadataType,
+		Metadata: pkg.LinuxKernelModuleMetadata{
+			Name:          "hello",
+			Version:       "1.2.0",
+			SourceVersion: "6A4D4A5B08C2E2F3A7C8E2B",
+			Path:          helloLocation.RealPath,
+			Description:   "Hello world module",
+			Author:        "Jane Doe <jane@example.com>",
+			License:       "Dual MIT/GPL",
+			KernelVersion: "6.1.0-13-amd64",
+			VersionMagic:  "6.1.0-13-amd64 SMP preempt mod_unload modversions",
+		},
+	}
+
+	// the modules are related to the kernel they were built for (but not to the other kernel)
+	pkgtest.NewCatalogTester().
+		WithResolver(resolver).
+		Expects([]pkg.Package{kernel, raspi, dummy, hello}, []artifact.Relationship{
+			{From: dummy, To: kernel, Type: artifact.DependencyOfRelationship},
+			{From: hello, To: kernel, Type: artifact.DependencyOfRelationship},
+		}).
+		TestCataloger(t, NewLinuxKernelCataloger())
+}
@@ -0,0 +1,194 @@
+package kernel
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+const (
+	// moduleSignatureMagic trails the signature appended to a kernel module (see include/linux/module_signature.h)
+	moduleSignatureMagic = "~Module signature appended~\n"
+	// moduleSignatureInfoSize is the size of struct module_signature, which precedes the magic
+	moduleSignatureInfoSize = 12
+)
+
+// the id_type values of struct module_signature
+const (
+	pgpSignature   = 0
+	x509Signature  = 1
+	pkcs7Signature = 2
+)
+
+var signatureTypes = map[byte]string{
+	pgpSignature:   "PGP",
+	x509Signature:  "X.509",
+	pkcs7Signature: "PKCS#7",
+}
+
+// hashAlgorithms are the hash_algo values of struct module_signature (see include/uapi/linux/hash_info.h)
+var hashAlgorithms = []string{"md4", "md5", "sha1", "rmd160", "sha256", "sha384", "sha512", "sha224"}
+
+var digestAlgorithmsByOID = map[string]string{
+	"1.3.14.3.2.26":          "sha1",
+	"2.16.840.1.101.3.4.2.1": "sha256",
+	"2.16.840.1.101.3.4.2.2": "sha384",
+	"2.16.840.1.101.3.4.2.3": "sha512",
+	"2.16.840.1.101.3.4.2.4": "sha224",
+}
+
+// moduleSignatureInfo mirrors struct module_signature.
+type moduleSignatureInfo struct {
+	Algorithm    uint8
+	Hash         uint8
+	IDType       uint8
+	SignerLen    uint8
+	KeyIDLen     uint8
+	_            [3]uint8
+	SignatureLen uint32
+}
+
+// parseModuleSignature reads the signature appended to the given kernel module (as by the kernel's scripts/sign-file),
+// returning nil when the module is not signed.
+func parseModuleSignature(r io.ReadSeeker) (*pkg.LinuxKernelModuleSignature, error) {
+	size, err := r.Seek(0, io.SeekEnd)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read kernel module: %w", err)
+	}
+	trailerSize := int64(moduleSignatureInfoSize + len(moduleSignatureMagic))
+	if size < trailerSize {
+		return nil, nil
+	}
+
+	if _, err := r.Seek(size-trailerSize, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to read kernel module signature: %w", err)
+	}
+	trailer := make([]byte, trailerSize)
+	if _, err := io.ReadFull(r, trailer); err != nil {
+		return nil, fmt.Errorf("unable to read kernel module signature: %w", err)
+	}
+	if !bytes.Equal(trailer[moduleSignatureInfoSize:], []byte(moduleSignatureMagic)) {
+		return nil, nil
+	}
+
+	var info moduleSignatureInfo
+	if err := binary.Read(bytes.NewReader(trailer[:moduleSignatureInfoSize]), binary.BigEndian, &info); err != nil {
+		return nil, fmt.Errorf("unable to read kernel module signature: %w", err)
+	}
+
+	// the signer name and key ID (when present) precede the signature
+	dataSize := int64(info.SignerLen) + int64(info.KeyIDLen) + int64(info.SignatureLen)
+	if dataSize > size-trailerSize {
+		return nil, fmt.Errorf("kernel module signature is larger than the module")
+	}
+	if _, err := r.Seek(size-trailerSize-dataSize, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to read kernel module signature: %w", err)
+	}
+	data := make([]byte, dataSize)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, fmt.Errorf("unable to read kernel module signature: %w", err)
+	}
+
+	signature := pkg.LinuxKernelModuleSignature{
+		Type:   signatureTypes[info.IDType],
+		Signer: string(data[:info.SignerLen]),
+		KeyID:  hexString(data[info.SignerLen : int(info.SignerLen)+int(info.KeyIDLen)]),
+	}
+	switch {
+	case info.IDType == pkcs7Signature:
+		// the signer and hash algorithm are only identified within the PKCS#7 message
+		if err := parsePKCS7Signer(data[len(data)-int(info.SignatureLen):], &signature); err != nil {
+			return nil, fmt.Errorf("unable to read kernel module signature: %w", err)
+		}
+	case int(info.Hash) < len(hashAlgorithms):
+		signature.HashAlgorithm = hashAlgorithms[info.Hash]
+	}
+	return &signature, nil
+}
+
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"optional,tag:0"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      asn1.RawValue
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	CRLs             asn1.RawValue `asn1:"optional,tag:1"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type signerInfo struct {
+	Version            int
+	SignerIdentifier   asn1.RawValue
+	DigestAlgorithm    pkix.AlgorithmIdentifier
+	SignedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	SignatureAlgorithm pkix.AlgorithmIdentifier
+	Signature          []byte
+	UnsignedAttributes asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+type issuerAndSerialNumber struct {
+	Issuer       pkix.RDNSequence
+	SerialNumber *big.Int
+}
+
+// parsePKCS7Signer records the signer (the issuer and serial number, or the subject key identifier, of the signing
+// certificate) and digest algorithm of the given PKCS#7 signature.
+func parsePKCS7Signer(by []byte, signature *pkg.LinuxKernelModuleSignature) error {
+	var ci contentInfo
+	if _, err := asn1.Unmarshal(by, &ci); err != nil {
+		return fmt.Errorf("unable to decode PKCS#7 message: %w", err)
+	}
+	var sd signedData
+	if _, err := asn1.Unmarshal(ci.Content.Bytes, &sd); err != nil {
+		return fmt.Errorf("unable to decode PKCS#7 signed data: %w", err)
+	}
+	if len(sd.SignerInfos) == 0 {
+		return fmt.Errorf("no signer found within PKCS#7 signed data")
+	}
+	si := sd.SignerInfos[0]
+
+	if algorithm, ok := digestAlgorithmsByOID[si.DigestAlgorithm.Algorithm.String()]; ok {
+		signature.HashAlgorithm = algorithm
+	}
+
+	sid := si.SignerIdentifier
+	switch {
+	case sid.Class == asn1.ClassUniversal && sid.Tag == asn1.TagSequence:
+		var issuer issuerAndSerialNumber
+		if _, err := asn1.Unmarshal(sid.FullBytes, &issuer); err != nil {
+			return fmt.Errorf("unable to decode PKCS#7 signer: %w", err)
+		}
+		var name pkix.Name
+		name.FillFromRDNSequence(&issuer.Issuer)
+		signature.Signer = name.CommonName
+		if signature.Signer == "" {
+			signature.Signer = name.String()
+		}
+		if issuer.SerialNumber != nil {
+			signature.KeyID = hexString(issuer.SerialNumber.Bytes())
+		}
+	case sid.Class == asn1.ClassContextSpecific && sid.Tag == 0:
+		signature.KeyID = hexString(sid.Bytes)
+	}
+	return nil
+}
+
+// hexString formats the given bytes as colon separated hex (as shown by modinfo, e.g. "62:78:8D:7F").
+func hexString(by []byte) string {
+	parts := make([]string, len(by))
+	for i, b := range by {
+		parts[i] = fmt.Sprintf("%02X", b)
+	}
+	return strings.Join(parts, ":")
+}
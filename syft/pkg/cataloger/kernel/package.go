@@ -0,0 +1,56 @@
+package kernel
+
+import (
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+const linuxKernelPackageName = "linux-kernel"
+
+func newLinuxKernelPackage(metadata pkg.LinuxKernelMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         linuxKernelPackageName,
+		Version:      metadata.Version,
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.LinuxKernelPkg,
+		PURL:         purl.New(purl.TypeGeneric, "", linuxKernelPackageName, metadata.Version, nil, ""),
+		CPEs:         kernelCPEs(metadata.Version),
+		MetadataType: pkg.LinuxKernelMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func newLinuxKernelModulePackage(metadata pkg.LinuxKernelModuleMetadata, location source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         metadata.Name,
+		Version:      metadata.Version,
+		Locations:    source.NewLocationSet(location),
+		Type:         pkg.LinuxKernelModulePkg,
+		PURL:         purl.New(purl.TypeGeneric, "", metadata.Name, metadata.Version, nil, ""),
+		MetadataType: pkg.LinuxKernelModuleMetadataType,
+		Metadata:     metadata,
+	}
+
+	if metadata.License != "" {
+		p.Licenses = pkg.NewLicensesFromLocation(location, metadata.License)
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func kernelCPEs(version string) []pkg.CPE {
+	c, err := pkg.NewCPE("cpe:2.3:o:linux:linux_kernel:" + version + ":*:*:*:*:*:*:*")
+	if err != nil {
+		log.Debugf("unable to create CPE for linux kernel version=%q: %+v", version, err)
+		return nil
+	}
+	return []pkg.CPE{c}
+}
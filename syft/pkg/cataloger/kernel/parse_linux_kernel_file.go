@@ -0,0 +1,209 @@
+package kernel
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/unionreader"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	// the offsets of the fields within the x86 boot protocol header (see https://www.kernel.org/doc/html/latest/x86/boot.html)
+	bzImageRootFlagsOffset     = 0x1F2
+	bzImageVideoModeOffset     = 0x1FA
+	bzImageRootDeviceOffset    = 0x1FC
+	bzImageHeaderOffset        = 0x202
+	bzImageKernelVersionOffset = 0x20E
+	bzImageHeaderSize          = 0x210
+
+	// the offset of the magic number within the arm64 boot image header (see https://www.kernel.org/doc/html/latest/arm64/booting.html)
+	arm64ImageMagicOffset = 0x38
+
+	// the kernel version string is bounded in length, so only so much is read when finding it
+	maxKernelVersionLength = 512
+)
+
+var (
+	bzImageMagic    = []byte("HdrS")
+	arm64ImageMagic = []byte("ARM\x64")
+
+	// linuxBanner prefixes the kernel version string (as in /proc/version) within an uncompressed kernel image
+	linuxBanner = []byte("Linux version ")
+
+	// e.g. "6.1.0-13-amd64 (debian-kernel@lists.debian.org) #1 SMP PREEMPT_DYNAMIC Debian 6.1.55-1 (2023-09-29)"
+	kernelVersionPattern = regexp.MustCompile(`^(?P<version>\S+)(?:\s+\((?P<author>[^)]*)\))?`)
+	// e.g. "Tue Oct 10 14:17:21 UTC 2023", or as a date (e.g. "(2023-09-29)") for debian kernels
+	buildTimePattern = regexp.MustCompile(`(?:Mon|Tue|Wed|Thu|Fri|Sat|Sun) (?:Jan|Feb|Mar|Apr|May|Jun|Jul|Aug|Sep|Oct|Nov|Dec) +\d+ \d{2}:\d{2}:\d{2} \S+ \d{4}|\((\d{4}-\d{2}-\d{2})\)$`)
+)
+
+func parseLinuxKernelFile(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	unionReader, err := unionreader.GetUnionReader(reader.ReadCloser)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get union reader for file: %w", err)
+	}
+	defer internal.CloseAndLogError(unionReader, reader.RealPath)
+
+	metadata, err := parseLinuxKernelMetadata(unionReader)
+	if err != nil {
+		return nil, nil, err
+	}
+	if metadata == nil {
+		log.Debugf("no linux kernel version found within %q", reader.RealPath)
+		return nil, nil, nil
+	}
+
+	return []pkg.Package{newLinuxKernelPackage(*metadata, reader.Location)}, nil, nil
+}
+
+// parseLinuxKernelMetadata reads the kernel version string (and other boot parameters) from the boot image header when
+// the image is an x86 bzImage, otherwise from the "Linux version" banner within the (uncompressed) image. Nil is
+// returned when no version string is found (e.g. the kernel image is compressed).
+func parseLinuxKernelMetadata(r io.ReadSeeker) (*pkg.LinuxKernelMetadata, error) {
+	header := make([]byte, bzImageHeaderSize)
+	n, err := io.ReadFull(r, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("unable to read kernel image header: %w", err)
+	}
+	header = header[:n]
+
+	if len(header) == bzImageHeaderSize && bytes.Equal(header[bzImageHeaderOffset:bzImageHeaderOffset+len(bzImageMagic)], bzImageMagic) {
+		return parseBzImage(r, header)
+	}
+
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to read kernel image: %w", err)
+	}
+	versionString, err := findLinuxBanner(r)
+	if err != nil {
+		return nil, err
+	}
+	if versionString == "" {
+		return nil, nil
+	}
+	metadata := parseKernelVersionString(versionString)
+	if len(header) >= arm64ImageMagicOffset+len(arm64ImageMagic) && bytes.Equal(header[arm64ImageMagicOffset:arm64ImageMagicOffset+len(arm64ImageMagic)], arm64ImageMagic) {
+		metadata.Architecture = "arm64"
+		metadata.Format = "Image"
+	}
+	return metadata, nil
+}
+
+func parseBzImage(r io.ReadSeeker, header []byte) (*pkg.LinuxKernelMetadata, error) {
+	versionPointer := binary.LittleEndian.Uint16(header[bzImageKernelVersionOffset:])
+	if versionPointer == 0 {
+		return nil, nil
+	}
+	if _, err := r.Seek(int64(versionPointer)+0x200, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("unable to read kernel version: %w", err)
+	}
+	versionString, err := readCString(r)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read kernel version: %w", err)
+	}
+	if versionString == "" {
+		return nil, nil
+	}
+
+	metadata := parseKernelVersionString(versionString)
+	metadata.Architecture = "x86"
+	metadata.Format = "bzImage"
+	metadata.RWRootFS = binary.LittleEndian.Uint16(header[bzImageRootFlagsOffset:]) == 0
+	metadata.RootDevice = int(binary.LittleEndian.Uint16(header[bzImageRootDeviceOffset:]))
+	metadata.VideoMode = videoMode(binary.LittleEndian.Uint16(header[bzImageVideoModeOffset:]))
+	return metadata, nil
+}
+
+// parseKernelVersionString extracts the version, author, and build time from the given kernel version string.
+func parseKernelVersionString(versionString string) *pkg.LinuxKernelMetadata {
+	versionString = strings.TrimSpace(versionString)
+	metadata := pkg.LinuxKernelMetadata{
+		Name:            linuxKernelPackageName,
+		ExtendedVersion: versionString,
+	}
+
+	groups := internal.MatchNamedCaptureGroups(kernelVersionPattern, versionString)
+	metadata.Version = groups["version"]
+	metadata.Author = groups["author"]
+
+	if match := buildTimePattern.FindStringSubmatch(versionString); match != nil {
+		metadata.BuildTime = match[0]
+		if match[1] != "" {
+			metadata.BuildTime = match[1]
+		}
+	}
+	return &metadata
+}
+
+func videoMode(mode uint16) string {
+	switch mode {
+	case 0xFFFF:
+		return "normal"
+	case 0xFFFE:
+		return "extended"
+	case 0xFFFD:
+		return "ask"
+	}
+	return fmt.Sprintf("0x%04x", mode)
+}
+
+// findLinuxBanner returns the kernel version string following the "Linux version" banner within the given image.
+func findLinuxBanner(r io.Reader) (string, error) {
+	const chunkSize = 64 * 1024
+	buf := make([]byte, 0, chunkSize+maxKernelVersionLength)
+	chunk := make([]byte, chunkSize)
+	for {
+		n, err := r.Read(chunk)
+		buf = append(buf, chunk[:n]...)
+		if idx := bytes.Index(buf, linuxBanner); idx >= 0 {
+			rest := buf[idx+len(linuxBanner):]
+			if end := bytes.IndexAny(rest, "\n\x00"); end >= 0 {
+				return string(rest[:end]), nil
+			}
+			if len(rest) >= maxKernelVersionLength || err == io.EOF {
+				return string(rest[:minInt(len(rest), maxKernelVersionLength)]), nil
+			}
+			// the version string continues within the next chunk
+			buf = buf[idx:]
+		} else if len(buf) > len(linuxBanner) {
+			// keep enough of the chunk to find a banner that spans chunks
+			buf = append(buf[:0], buf[len(buf)-len(linuxBanner):]...)
+		}
+
+		if err == io.EOF {
+			return "", nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("unable to read kernel image: %w", err)
+		}
+	}
+}
+
+func readCString(r io.Reader) (string, error) {
+	by := make([]byte, maxKernelVersionLength)
+	n, err := io.ReadFull(r, by)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return "", err
+	}
+	by = by[:n]
+	if end := bytes.IndexByte(by, 0); end >= 0 {
+		by = by[:end]
+	}
+	return string(by), nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
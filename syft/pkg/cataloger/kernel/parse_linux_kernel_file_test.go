@@ -0,0 +1,86 @@
+package kernel
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseLinuxKernelFile(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected pkg.LinuxKernelMetadata
+	}{
+		{
+			fixture: "test-fixtures/boot/vmlinuz-6.1.0-13-amd64",
+			expected: pkg.LinuxKernelMetadata{
+				Name:            "linux-kernel",
+				Architecture:    "x86",
+				Version:         "6.1.0-13-amd64",
+				ExtendedVersion: "6.1.0-13-amd64 (debian-kernel@lists.debian.org) #1 SMP PREEMPT_DYNAMIC Debian 6.1.55-1 (2023-09-29)",
+				BuildTime:       "2023-09-29",
+				Author:          "debian-kernel@lists.debian.org",
+				Format:          "bzImage",
+				VideoMode:       "normal",
+			},
+		},
+		{
+			fixture: "test-fixtures/boot/vmlinuz-6.5.0-1005-raspi",
+			expected: pkg.LinuxKernelMetadata{
+				Name:            "linux-kernel",
+				Architecture:    "arm64",
+				Version:         "6.5.0-1005-raspi",
+				ExtendedVersion: "6.5.0-1005-raspi (buildd@bos03-arm64-018) (aarch64-linux-gnu-gcc-13 (Ubuntu 13.2.0-4ubuntu3) 13.2.0, GNU ld (GNU Binutils for Ubuntu) 2.41) #7-Ubuntu SMP PREEMPT_DYNAMIC Tue Oct 10 14:17:21 UTC 2023",
+				BuildTime:       "Tue Oct 10 14:17:21 UTC 2023",
+				Author:          "buildd@bos03-arm64-018",
+				Format:          "Image",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			version := test.expected.Version
+			expected := []pkg.Package{
+				{
+					Name:         "linux-kernel",
+					Version:      version,
+					Locations:    source.NewLocationSet(source.NewLocation(test.fixture)),
+					Type:         pkg.LinuxKernelPkg,
+					PURL:         "pkg:generic/linux-kernel@" + version,
+					CPEs:         []pkg.CPE{pkg.MustCPE("cpe:2.3:o:linux:linux_kernel:" + version + ":*:*:*:*:*:*:*")},
+					MetadataType: pkg.LinuxKernelMetadataType,
+					Metadata:     test.expected,
+				},
+			}
+
+			pkgtest.TestFileParser(t, test.fixture, parseLinuxKernelFile, expected, nil)
+		})
+	}
+}
+
+func TestParseLinuxKernelFile_compressed(t *testing.T) {
+	// the version of a compressed (non-bzImage) kernel image is not found
+	pkgtest.NewCatalogTester().
+		FromString("/boot/vmlinuz", "\x1f\x8b\x08\x00compressed").
+		Expects(nil, nil).
+		TestParser(t, parseLinuxKernelFile)
+}
+
+func Test_findLinuxBanner(t *testing.T) {
+	// the banner spans the chunks read from the image
+	image := strings.Repeat("\x00", 64*1024-5) + "Linux version 5.10.0 (root@build) #1 SMP\n" + strings.Repeat("\x00", 100)
+
+	version, err := findLinuxBanner(strings.NewReader(image))
+	require.NoError(t, err)
+	assert.Equal(t, "5.10.0 (root@build) #1 SMP", version)
+
+	version, err = findLinuxBanner(strings.NewReader(strings.Repeat("\x00", 100)))
+	require.NoError(t, err)
+	assert.Empty(t, version)
+}
@@ -0,0 +1,123 @@
+package kernel
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/unionreader"
+	"github.com/anchore/syft/syft/source"
+)
+
+const modinfoSectionName = ".modinfo"
+
+func parseLinuxKernelModuleFile(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	unionReader, err := unionreader.GetUnionReader(reader.ReadCloser)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to get union reader for file: %w", err)
+	}
+	defer internal.CloseAndLogError(unionReader, reader.RealPath)
+
+	f, err := elf.NewFile(unionReader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read kernel module: %w", err)
+	}
+
+	section := f.Section(modinfoSectionName)
+	if section == nil {
+		return nil, nil, fmt.Errorf("kernel module has no %s section", modinfoSectionName)
+	}
+	modinfo, err := section.Data()
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read %s section: %w", modinfoSectionName, err)
+	}
+
+	metadata := parseModinfo(modinfo)
+	metadata.Path = reader.RealPath
+	if metadata.Name == "" {
+		metadata.Name = strings.TrimSuffix(path.Base(reader.RealPath), ".ko")
+	}
+	if metadata.KernelVersion == "" {
+		metadata.KernelVersion = kernelVersionFromPath(reader.RealPath)
+	}
+
+	metadata.Signature, err = parseModuleSignature(unionReader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return []pkg.Package{newLinuxKernelModulePackage(metadata, reader.Location)}, nil, nil
+}
+
+// parseModinfo reads the (NUL separated) "key=value" entries of the .modinfo section of a kernel module.
+func parseModinfo(modinfo []byte) pkg.LinuxKernelModuleMetadata {
+	var metadata pkg.LinuxKernelModuleMetadata
+	var authors []string
+	params := make(map[string]*pkg.LinuxKernelModuleParameter)
+	var paramNames []string
+	param := func(name string) *pkg.LinuxKernelModuleParameter {
+		if _, ok := params[name]; !ok {
+			params[name] = &pkg.LinuxKernelModuleParameter{Name: name}
+			paramNames = append(paramNames, name)
+		}
+		return params[name]
+	}
+
+	for _, entry := range bytes.Split(modinfo, []byte{0}) {
+		key, value, ok := strings.Cut(string(entry), "=")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "name":
+			metadata.Name = value
+		case "version":
+			metadata.Version = value
+		case "srcversion":
+			metadata.SourceVersion = value
+		case "description":
+			metadata.Description = value
+		case "author":
+			authors = append(authors, value)
+		case "license":
+			metadata.License = value
+		case "vermagic":
+			metadata.VersionMagic = strings.TrimSpace(value)
+			if fields := strings.Fields(value); len(fields) > 0 {
+				metadata.KernelVersion = fields[0]
+			}
+		case "parm":
+			// e.g. "numdummies:Number of dummy pseudo devices"
+			name, description, _ := strings.Cut(value, ":")
+			param(name).Description = description
+		case "parmtype":
+			// e.g. "numdummies:int"
+			name, typ, _ := strings.Cut(value, ":")
+			param(name).Type = typ
+		}
+	}
+
+	metadata.Author = strings.Join(authors, ", ")
+	for _, name := range paramNames {
+		metadata.Parameters = append(metadata.Parameters, *params[name])
+	}
+	return metadata
+}
+
+// kernelVersionFromPath returns the kernel version from the path of a kernel module within /lib/modules/<version>/.
+func kernelVersionFromPath(p string) string {
+	fields := strings.Split(filepath.ToSlash(p), "/")
+	for i := 0; i+2 < len(fields); i++ {
+		if fields[i] == "lib" && fields[i+1] == "modules" {
+			return fields[i+2]
+		}
+	}
+	return ""
+}
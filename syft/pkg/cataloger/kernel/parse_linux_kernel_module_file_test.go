@@ -0,0 +1,89 @@
+package kernel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseLinuxKernelModuleFile(t *testing.T) {
+	tests := []struct {
+		fixture  string
+		expected pkg.LinuxKernelModuleMetadata
+	}{
+		{
+			fixture: "test-fixtures/lib/modules/6.1.0-13-amd64/kernel/drivers/net/dummy.ko",
+			expected: pkg.LinuxKernelModuleMetadata{
+				Name:          "dummy",
+				Path:          "test-fixtures/lib/modules/6.1.0-13-amd64/kernel/drivers/net/dummy.ko",
+				Description:   "Dummy netdevice driver which discards all packets sent to it",
+				License:       "GPL",
+				KernelVersion: "6.1.0-13-amd64",
+				VersionMagic:  "6.1.0-13-amd64 SMP preempt mod_unload modversions",
+				Parameters: []pkg.LinuxKernelModuleParameter{
+					{
+						Name:        "numdummies",
+						Type:        "int",
+						Description: "Number of dummy pseudo devices",
+					},
+				},
+				Signature: &pkg.LinuxKernelModuleSignature{
+					Type:          "PKCS#7",
+					Signer:        "Debian Secure Boot CA",
+					KeyID:         "62:78:8D:7F:F4:42:65:B0:EB:D9:76:88:A7:0D:8C:D8:44:CA:43:75",
+					HashAlgorithm: "sha256",
+				},
+			},
+		},
+		{
+			fixture: "test-fixtures/lib/modules/6.1.0-13-amd64/extra/hello.ko",
+			expected: pkg.LinuxKernelModuleMetadata{
+				Name:          "hello",
+				Version:       "1.2.0",
+				SourceVersion: "6A4D4A5B08C2E2F3A7C8E2B",
+				Path:          "test-fixtures/lib/modules/6.1.0-13-amd64/extra/hello.ko",
+				Description:   "Hello world module",
+				Author:        "Jane Doe <jane@example.com>",
+				License:       "Dual MIT/GPL",
+				KernelVersion: "6.1.0-13-amd64",
+				VersionMagic:  "6.1.0-13-amd64 SMP preempt mod_unload modversions",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.fixture, func(t *testing.T) {
+			location := source.NewLocation(test.fixture)
+			p := pkg.Package{
+				Name:         test.expected.Name,
+				Version:      test.expected.Version,
+				Locations:    source.NewLocationSet(location),
+				Licenses:     pkg.NewLicensesFromLocation(location, test.expected.License),
+				Type:         pkg.LinuxKernelModulePkg,
+				PURL:         "pkg:generic/" + test.expected.Name,
+				MetadataType: pkg.LinuxKernelModuleMetadataType,
+				Metadata:     test.expected,
+			}
+			if test.expected.Version != "" {
+				p.PURL += "@" + test.expected.Version
+			}
+
+			pkgtest.TestFileParser(t, test.fixture, parseLinuxKernelModuleFile, []pkg.Package{p}, nil)
+		})
+	}
+}
+
+func TestParseLinuxKernelModuleFile_notAModule(t *testing.T) {
+	pkgtest.NewCatalogTester().
+		FromString("/lib/modules/6.1.0-13-amd64/kernel/bogus.ko", "not an ELF file").
+		WithError().
+		TestParser(t, parseLinuxKernelModuleFile)
+}
+
+func Test_kernelVersionFromPath(t *testing.T) {
+	assert.Equal(t, "6.1.0-13-amd64", kernelVersionFromPath("/usr/lib/modules/6.1.0-13-amd64/kernel/fs/ext4/ext4.ko"))
+	assert.Equal(t, "", kernelVersionFromPath("/opt/driver.ko"))
+}
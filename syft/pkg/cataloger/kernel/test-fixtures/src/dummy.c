@@ -0,0 +1,13 @@
+/* mimics the .modinfo section of the in-tree dummy network driver (as generated by the MODULE_* macros) */
+#define MODINFO(tag, info) static const char __modinfo_##tag[] __attribute__((section(".modinfo"), used, aligned(1))) = #tag "=" info
+
+MODINFO(alias, "rtnl-link-dummy");
+MODINFO(description, "Dummy netdevice driver which discards all packets sent to it");
+MODINFO(license, "GPL");
+MODINFO(parm, "numdummies:Number of dummy pseudo devices");
+MODINFO(parmtype, "numdummies:int");
+MODINFO(depends, "");
+MODINFO(retpoline, "Y");
+MODINFO(intree, "Y");
+MODINFO(name, "dummy");
+MODINFO(vermagic, "6.1.0-13-amd64 SMP preempt mod_unload modversions ");
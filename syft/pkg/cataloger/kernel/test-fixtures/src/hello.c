@@ -0,0 +1,11 @@
+/* mimics the .modinfo section of an out-of-tree module (as generated by the MODULE_* macros) */
+#define MODINFO(tag, info) static const char __modinfo_##tag[] __attribute__((section(".modinfo"), used, aligned(1))) = #tag "=" info
+
+MODINFO(version, "1.2.0");
+MODINFO(description, "Hello world module");
+MODINFO(author, "Jane Doe <jane@example.com>");
+MODINFO(license, "Dual MIT/GPL");
+MODINFO(srcversion, "6A4D4A5B08C2E2F3A7C8E2B");
+MODINFO(depends, "dummy");
+MODINFO(name, "hello");
+MODINFO(vermagic, "6.1.0-13-amd64 SMP preempt mod_unload modversions ");
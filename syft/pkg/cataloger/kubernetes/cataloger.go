@@ -0,0 +1,30 @@
+/*
+Package kubernetes provides concrete Cataloger implementations for Helm charts and Kubernetes manifests, describing the
+charts they depend on and the container images they deploy.
+*/
+package kubernetes
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const (
+	helmChartCatalogerName = "helm-chart-cataloger"
+	manifestCatalogerName  = "kubernetes-manifest-cataloger"
+)
+
+// NewHelmChartCataloger returns a new cataloger object for Helm charts, either as a chart directory (Chart.yaml) or as
+// a packaged chart (as written by "helm package", or as the chart layer of an OCI-stored chart within an OCI layout or
+// the registry cache of helm).
+func NewHelmChartCataloger() *generic.Cataloger {
+	return generic.NewCataloger(helmChartCatalogerName).
+		WithParserByGlobs(parseChartYAML, "**/Chart.yaml").
+		WithParserByGlobs(parseChartArchive, "**/*.tgz", "**/blobs/sha256/*")
+}
+
+// NewKubernetesManifestCataloger returns a new cataloger object for the container images referenced by the workloads
+// of Kubernetes manifests.
+func NewKubernetesManifestCataloger() *generic.Cataloger {
+	return generic.NewCataloger(manifestCatalogerName).
+		WithParserByGlobs(parseKubernetesManifest, "**/*.yaml", "**/*.yml")
+}
@@ -0,0 +1,55 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestHelmChartCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/chart/Chart.yaml",
+		"test-fixtures/chart/Chart.lock",
+		"test-fixtures/chart/values.yaml",
+		"test-fixtures/chart/templates/deployment.yaml",
+		"test-fixtures/packaged/web-1.2.3.tgz",
+		"test-fixtures/not-a-chart.tgz",
+	)
+
+	pkgs, relationships, err := NewHelmChartCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	// the chart directory and the packaged chart both describe the same chart (with 2 dependencies and 4 images)
+	assert.Len(t, pkgs, 14)
+	assert.Len(t, relationships, 12)
+	for _, p := range pkgs {
+		assert.Equal(t, helmChartCatalogerName, p.FoundBy)
+	}
+}
+
+func TestKubernetesManifestCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/manifests/workloads.yaml",
+		"test-fixtures/manifests/docker-compose.yaml",
+		"test-fixtures/chart/Chart.yaml",
+		"test-fixtures/chart/templates/deployment.yaml",
+	)
+
+	pkgs, relationships, err := NewKubernetesManifestCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	assert.Empty(t, relationships)
+
+	var found []string
+	for _, p := range pkgs {
+		assert.Equal(t, manifestCatalogerName, p.FoundBy)
+		found = append(found, p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{
+		"ghcr.io/example/migrate@v3.2.0",
+		"nginx@1.25.3",
+		"nginx/nginx-prometheus-exporter@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+		"registry.example.com:5000/tools/cleanup@2024.01",
+	}, found)
+}
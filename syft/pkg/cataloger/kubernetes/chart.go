@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	chartFileName = "Chart.yaml"
+	valuesName    = "values.yaml"
+)
+
+// the lock files of chart dependencies, for charts of API version "v2" (Helm 3) and "v1" (Helm 2)
+var chartLockNames = []string{"Chart.lock", "requirements.lock"}
+
+// chartFile is the Chart.yaml of a chart (see https://helm.sh/docs/topics/charts/#the-chartyaml-file).
+type chartFile struct {
+	APIVersion  string               `yaml:"apiVersion"`
+	Name        string               `yaml:"name"`
+	Version     string               `yaml:"version"`
+	KubeVersion string               `yaml:"kubeVersion"`
+	Description string               `yaml:"description"`
+	Type        string               `yaml:"type"`
+	Home        string               `yaml:"home"`
+	Sources     []string             `yaml:"sources"`
+	Maintainers []pkg.HelmMaintainer `yaml:"maintainers"`
+	AppVersion  string               `yaml:"appVersion"`
+	Annotations map[string]string    `yaml:"annotations"`
+}
+
+// chartLock is the Chart.lock (or requirements.lock) of a chart, resolving the versions of the dependencies.
+type chartLock struct {
+	Dependencies []chartLockDependency `yaml:"dependencies"`
+}
+
+type chartLockDependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+}
+
+// chartSources are the files describing a chart, where the lock and values of a chart are optional.
+type chartSources struct {
+	chart          chartFile
+	chartLocation  source.Location
+	lock           *chartLock
+	lockLocation   source.Location
+	values         *yaml.Node
+	valuesLocation source.Location
+}
+
+// newChartPackages creates the package of a chart, along with the packages of the charts and images it depends on.
+func newChartPackages(sources chartSources) ([]pkg.Package, []artifact.Relationship) {
+	var images []imageReference
+	if sources.values != nil {
+		images = imagesFromValues(sources.values, sources.chart.AppVersion)
+	}
+
+	chart := newHelmChartPackage(sources.chart, images, sources.chartLocation)
+	pkgs := []pkg.Package{chart}
+	var relationships []artifact.Relationship
+
+	var dependencies []pkg.Package
+	if sources.lock != nil {
+		for _, dependency := range sources.lock.Dependencies {
+			if dependency.Name == "" {
+				continue
+			}
+			dependencies = append(dependencies, newHelmDependencyPackage(dependency, sources.lockLocation))
+		}
+	}
+	for _, image := range images {
+		dependencies = append(dependencies, newContainerImagePackage(image, sources.valuesLocation))
+	}
+
+	for _, dependency := range dependencies {
+		pkgs = append(pkgs, dependency)
+		relationships = append(relationships, artifact.Relationship{
+			From: dependency,
+			To:   chart,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+	return pkgs, relationships
+}
@@ -0,0 +1,183 @@
+package kubernetes
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	imageRepositoryPattern = regexp.MustCompile(`^[a-zA-Z0-9]+([._-]+[a-zA-Z0-9]+)*(:[0-9]+)?(/[a-z0-9]+([._-]+[a-z0-9]+)*)*$`)
+	imageTagPattern        = regexp.MustCompile(`^\w[\w.-]{0,127}$`)
+	imageDigestPattern     = regexp.MustCompile(`^[a-z0-9]+([+._-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+)
+
+// imageReference is a reference to a container image (e.g. "docker.io/bitnami/nginx:1.25.3@sha256:...").
+type imageReference struct {
+	Repository string // the registry (if any) and path of the image (e.g. "docker.io/bitnami/nginx")
+	Tag        string
+	Digest     string // e.g. "sha256:0d17b565..."
+}
+
+func (r imageReference) String() string {
+	s := r.Repository
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// parseImageReference parses the given image reference, returning false when the value is not a reference (e.g. when
+// it is a template expression or a URL).
+func parseImageReference(value string) (imageReference, bool) {
+	value = strings.TrimSpace(value)
+
+	var ref imageReference
+	if i := strings.Index(value, "@"); i >= 0 {
+		ref.Digest = value[i+1:]
+		value = value[:i]
+	}
+	// the last colon either separates the tag or the port of the registry (e.g. "localhost:5000/app")
+	if i := strings.LastIndex(value, ":"); i > strings.LastIndex(value, "/") {
+		ref.Tag = value[i+1:]
+		value = value[:i]
+	}
+	ref.Repository = value
+
+	if !imageRepositoryPattern.MatchString(ref.Repository) {
+		return imageReference{}, false
+	}
+	if ref.Tag != "" && !imageTagPattern.MatchString(ref.Tag) {
+		return imageReference{}, false
+	}
+	if ref.Digest != "" && !imageDigestPattern.MatchString(ref.Digest) {
+		return imageReference{}, false
+	}
+	return ref, true
+}
+
+// imagesFromValues finds the container images referenced by the values of a chart, following the conventions of most
+// charts: either the whole reference as a string (e.g. "image: nginx:1.25.3") or a map of the parts of the reference
+// (e.g. "image: {registry: docker.io, repository: bitnami/nginx, tag: 1.25.3}"). Without a tag or digest, the image
+// is tagged by the app version of the chart, as done by the templates created by "helm create".
+func imagesFromValues(values *yaml.Node, appVersion string) []imageReference {
+	var refs []imageReference
+	walkValues(values, "", func(key string, node *yaml.Node) {
+		if !isImageKey(key) && node.Kind != yaml.MappingNode {
+			return
+		}
+		var ref imageReference
+		var ok bool
+		switch node.Kind {
+		case yaml.ScalarNode:
+			if node.Tag == "!!str" {
+				ref, ok = parseImageReference(node.Value)
+			}
+		case yaml.MappingNode:
+			ref, ok = imageFromMapping(key, node, appVersion)
+		}
+		if ok {
+			refs = append(refs, ref)
+		}
+	})
+	return uniqueImages(refs)
+}
+
+// imageFromMapping assembles an image reference from a map of the parts of the reference, where the map is either the
+// value of an image key or holds a tag or digest along with the repository (e.g. "{repository: nginx, tag: 1.25.3}").
+func imageFromMapping(key string, node *yaml.Node, appVersion string) (imageReference, bool) {
+	fields := scalarFields(node)
+	repository, ok := fields["repository"]
+	if !ok {
+		return imageReference{}, false
+	}
+	_, hasTag := fields["tag"]
+	_, hasDigest := fields["digest"]
+	if !isImageKey(key) && !hasTag && !hasDigest {
+		// e.g. the repository of a chart dependency
+		return imageReference{}, false
+	}
+
+	value := repository
+	if registry := fields["registry"]; registry != "" {
+		value = strings.TrimSuffix(registry, "/") + "/" + repository
+	}
+	ref, ok := parseImageReference(value)
+	if !ok || ref.Tag != "" || ref.Digest != "" {
+		// the repository should not hold the tag or digest
+		return imageReference{}, false
+	}
+
+	ref.Tag = fields["tag"]
+	ref.Digest = fields["digest"]
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = appVersion
+	}
+	if (ref.Tag != "" && !imageTagPattern.MatchString(ref.Tag)) || (ref.Digest != "" && !imageDigestPattern.MatchString(ref.Digest)) {
+		return imageReference{}, false
+	}
+	return ref, true
+}
+
+// isImageKey indicates if the given key conventionally holds an image (e.g. "image" or "initImage").
+func isImageKey(key string) bool {
+	return strings.HasSuffix(strings.ToLower(key), "image")
+}
+
+// walkValues calls the given function for every value within the given node, along with the key the value is found
+// at (the key of a sequence item is the key of the sequence).
+func walkValues(node *yaml.Node, key string, fn func(key string, node *yaml.Node)) {
+	if node == nil {
+		return
+	}
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, n := range node.Content {
+			walkValues(n, key, fn)
+		}
+	case yaml.SequenceNode:
+		for _, n := range node.Content {
+			walkValues(n, key, fn)
+		}
+	case yaml.MappingNode:
+		fn(key, node)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			walkValues(node.Content[i+1], node.Content[i].Value, fn)
+		}
+	case yaml.ScalarNode:
+		fn(key, node)
+	}
+}
+
+// scalarFields returns the scalar values of the given mapping node by key.
+func scalarFields(node *yaml.Node) map[string]string {
+	fields := make(map[string]string)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if value := node.Content[i+1]; value.Kind == yaml.ScalarNode && value.Tag != "!!null" {
+			fields[node.Content[i].Value] = value.Value
+		}
+	}
+	return fields
+}
+
+// uniqueImages removes duplicate references, sorting the references for a stable ordering of packages.
+func uniqueImages(refs []imageReference) []imageReference {
+	seen := make(map[string]bool)
+	var result []imageReference
+	for _, ref := range refs {
+		if seen[ref.String()] {
+			continue
+		}
+		seen[ref.String()] = true
+		result = append(result, ref)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+	return result
+}
@@ -0,0 +1,60 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseImageReference(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected imageReference
+		wantOK   bool
+	}{
+		{
+			value:    "nginx",
+			expected: imageReference{Repository: "nginx"},
+			wantOK:   true,
+		},
+		{
+			value:    "nginx:1.25.3",
+			expected: imageReference{Repository: "nginx", Tag: "1.25.3"},
+			wantOK:   true,
+		},
+		{
+			value:    "docker.io/bitnami/nginx:1.25.3-debian-11-r4",
+			expected: imageReference{Repository: "docker.io/bitnami/nginx", Tag: "1.25.3-debian-11-r4"},
+			wantOK:   true,
+		},
+		{
+			value:    "localhost:5000/app",
+			expected: imageReference{Repository: "localhost:5000/app"},
+			wantOK:   true,
+		},
+		{
+			value:    "localhost:5000/app:v1@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+			expected: imageReference{Repository: "localhost:5000/app", Tag: "v1", Digest: "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"},
+			wantOK:   true,
+		},
+		{
+			value: "{{ .Values.image.repository }}:{{ .Values.image.tag }}",
+		},
+		{
+			value: "https://charts.bitnami.com/bitnami",
+		},
+		{
+			value: "nginx@latest",
+		},
+		{
+			value: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			actual, ok := parseImageReference(test.value)
+			assert.Equal(t, test.wantOK, ok)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
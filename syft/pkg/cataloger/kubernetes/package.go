@@ -0,0 +1,88 @@
+package kubernetes
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+// artifactHubLicenseAnnotation is the chart annotation declaring the license of a chart (see
+// https://artifacthub.io/docs/topics/annotations/helm/).
+const artifactHubLicenseAnnotation = "artifacthub.io/license"
+
+func newHelmChartPackage(chart chartFile, images []imageReference, location source.Location) pkg.Package {
+	metadata := pkg.HelmChartMetadata{
+		Name:        chart.Name,
+		Version:     chart.Version,
+		AppVersion:  chart.AppVersion,
+		APIVersion:  chart.APIVersion,
+		Type:        chart.Type,
+		Description: chart.Description,
+		Home:        chart.Home,
+		Sources:     chart.Sources,
+		KubeVersion: chart.KubeVersion,
+		Maintainers: chart.Maintainers,
+	}
+	for _, image := range images {
+		metadata.Images = append(metadata.Images, image.String())
+	}
+
+	p := pkg.Package{
+		Name:         chart.Name,
+		Version:      chart.Version,
+		Locations:    source.NewLocationSet(location),
+		Type:         pkg.HelmPkg,
+		PURL:         purl.New(purl.TypeGeneric, "", chart.Name, chart.Version, nil, ""),
+		MetadataType: pkg.HelmChartMetadataType,
+		Metadata:     metadata,
+	}
+	if license := chart.Annotations[artifactHubLicenseAnnotation]; license != "" {
+		p.Licenses = pkg.NewLicensesFromLocation(location, license)
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// newHelmDependencyPackage creates a package for a chart dependency, as resolved by the lock file of the depending chart.
+func newHelmDependencyPackage(dependency chartLockDependency, location source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         dependency.Name,
+		Version:      dependency.Version,
+		Locations:    source.NewLocationSet(location),
+		Type:         pkg.HelmPkg,
+		PURL:         purl.New(purl.TypeGeneric, "", dependency.Name, dependency.Version, nil, ""),
+		MetadataType: pkg.HelmChartMetadataType,
+		Metadata: pkg.HelmChartMetadata{
+			Name:       dependency.Name,
+			Version:    dependency.Version,
+			Repository: dependency.Repository,
+		},
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// newContainerImagePackage creates a package for a referenced container image, which is versioned by the tag of the
+// reference (or by the digest when there is no tag).
+func newContainerImagePackage(ref imageReference, location source.Location) pkg.Package {
+	version := ref.Tag
+	if version == "" {
+		version = ref.Digest
+	}
+
+	p := pkg.Package{
+		Name:      ref.Repository,
+		Version:   version,
+		Locations: source.NewLocationSet(location),
+		Type:      pkg.ContainerImagePkg,
+		PURL:      purl.OCI(ref.Repository, ref.Tag, ref.Digest),
+	}
+
+	p.SetID()
+
+	return p
+}
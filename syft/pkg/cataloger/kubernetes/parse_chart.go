@@ -0,0 +1,75 @@
+package kubernetes
+
+import (
+	"fmt"
+	"io"
+	"path"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseChartYAML
+
+// parseChartYAML parses the Chart.yaml of a chart directory, along with the lock file and values next to it.
+func parseChartYAML(resolver source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var chart chartFile
+	if err := yaml.NewDecoder(reader).Decode(&chart); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Chart.yaml file: %w", err)
+	}
+	if chart.Name == "" || chart.Version == "" {
+		log.WithFields("path", reader.RealPath).Trace("Chart.yaml without a chart name or version")
+		return nil, nil, nil
+	}
+
+	sources := chartSources{
+		chart:         chart,
+		chartLocation: reader.Location,
+	}
+	if resolver != nil {
+		dir := path.Dir(reader.RealPath)
+		for _, name := range chartLockNames {
+			var lock chartLock
+			if location := decodeRelativeFile(resolver, reader.Location, path.Join(dir, name), &lock); location != nil {
+				sources.lock = &lock
+				sources.lockLocation = *location
+				break
+			}
+		}
+		var values yaml.Node
+		if location := decodeRelativeFile(resolver, reader.Location, path.Join(dir, valuesName), &values); location != nil {
+			sources.values = &values
+			sources.valuesLocation = *location
+		}
+	}
+
+	pkgs, relationships := newChartPackages(sources)
+	return pkgs, relationships, nil
+}
+
+// decodeRelativeFile decodes the YAML file at the given path (relative to the given location) into the given value,
+// returning the location of the file or nil if the file does not exist or cannot be decoded.
+func decodeRelativeFile(resolver source.FileResolver, location source.Location, p string, into interface{}) *source.Location {
+	relative := resolver.RelativeFileByPath(location, p)
+	if relative == nil {
+		return nil
+	}
+	reader, err := resolver.FileContentsByLocation(*relative)
+	if err != nil {
+		log.WithFields("path", relative.RealPath, "error", err).Debug("unable to read helm chart file")
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, relative.RealPath)
+
+	if err := yaml.NewDecoder(reader).Decode(into); err != nil && err != io.EOF {
+		log.WithFields("path", relative.RealPath, "error", err).Debug("unable to parse helm chart file")
+		return nil
+	}
+	return relative
+}
@@ -0,0 +1,94 @@
+package kubernetes
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseChartArchive
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// parseChartArchive parses a packaged chart, which is a gzipped tarball of the chart directory. Since helm writes the
+// Chart.yaml as the first entry of the archive, any other gzipped tarball is dismissed without reading further than
+// the first entry.
+func parseChartArchive(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	buffered := bufio.NewReader(reader)
+	if magic, err := buffered.Peek(len(gzipMagic)); err != nil || !bytes.Equal(magic, gzipMagic) {
+		return nil, nil, nil
+	}
+	gz, err := gzip.NewReader(buffered)
+	if err != nil {
+		return nil, nil, nil
+	}
+	defer gz.Close()
+
+	archive := tar.NewReader(gz)
+	header, err := archive.Next()
+	if err != nil {
+		return nil, nil, nil
+	}
+	dir, name, ok := strings.Cut(strings.TrimPrefix(header.Name, "./"), "/")
+	if !ok || name != chartFileName {
+		return nil, nil, nil
+	}
+
+	sources := chartSources{
+		chartLocation:  reader.Location,
+		lockLocation:   reader.Location,
+		valuesLocation: reader.Location,
+	}
+	if err := yaml.NewDecoder(archive).Decode(&sources.chart); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse Chart.yaml file: %w", err)
+	}
+	if sources.chart.Name == "" || sources.chart.Version == "" {
+		log.WithFields("path", reader.RealPath).Trace("packaged chart without a chart name or version")
+		return nil, nil, nil
+	}
+
+	for {
+		header, err = archive.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read packaged chart: %w", err)
+		}
+
+		switch strings.TrimPrefix(header.Name, "./") {
+		case dir + "/" + valuesName:
+			var values yaml.Node
+			if err := yaml.NewDecoder(archive).Decode(&values); err != nil {
+				log.WithFields("path", reader.RealPath, "error", err).Debug("unable to parse values of packaged chart")
+				continue
+			}
+			sources.values = &values
+		case dir + "/" + chartLockNames[0], dir + "/" + chartLockNames[1]:
+			var lock chartLock
+			if err := yaml.NewDecoder(archive).Decode(&lock); err != nil {
+				log.WithFields("path", reader.RealPath, "error", err).Debug("unable to parse lock file of packaged chart")
+				continue
+			}
+			if sources.lock == nil {
+				sources.lock = &lock
+			}
+		}
+	}
+
+	pkgs, relationships := newChartPackages(sources)
+	return pkgs, relationships, nil
+}
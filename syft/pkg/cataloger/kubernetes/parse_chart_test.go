@@ -0,0 +1,156 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+// expectedChartPackages are the packages of the "web" fixture chart, along with the relationships to the chart.
+func expectedChartPackages(chartLocation, lockLocation, valuesLocation source.Location) ([]pkg.Package, []artifact.Relationship) {
+	chart := pkg.Package{
+		Name:         "web",
+		Version:      "1.2.3",
+		Locations:    source.NewLocationSet(chartLocation),
+		Licenses:     pkg.NewLicensesFromLocation(chartLocation, "Apache-2.0"),
+		Type:         pkg.HelmPkg,
+		PURL:         "pkg:generic/web@1.2.3",
+		MetadataType: pkg.HelmChartMetadataType,
+		Metadata: pkg.HelmChartMetadata{
+			Name:        "web",
+			Version:     "1.2.3",
+			AppVersion:  "1.25.3",
+			APIVersion:  "v2",
+			Type:        "application",
+			Description: "A web server with a redis cache",
+			Home:        "https://example.com/charts/web",
+			Sources:     []string{"https://github.com/example/charts"},
+			KubeVersion: ">= 1.23.0-0",
+			Maintainers: []pkg.HelmMaintainer{
+				{Name: "Example Maintainer", Email: "maintainer@example.com"},
+			},
+			Images: []string{
+				"busybox:1.36@sha256:6d9ac9237a84afe1516540f40a0fafdc86859b2141954b4d643af7066d598b74",
+				"docker.io/bitnami/nginx:1.25.3-debian-11-r4",
+				"envoyproxy/envoy:1.10",
+				"nginx/nginx-prometheus-exporter:1.25.3",
+			},
+		},
+	}
+	dependencies := []pkg.Package{
+		{
+			Name:         "redis",
+			Version:      "18.6.1",
+			Locations:    source.NewLocationSet(lockLocation),
+			Type:         pkg.HelmPkg,
+			PURL:         "pkg:generic/redis@18.6.1",
+			MetadataType: pkg.HelmChartMetadataType,
+			Metadata: pkg.HelmChartMetadata{
+				Name:       "redis",
+				Version:    "18.6.1",
+				Repository: "https://charts.bitnami.com/bitnami",
+			},
+		},
+		{
+			Name:         "common",
+			Version:      "2.14.1",
+			Locations:    source.NewLocationSet(lockLocation),
+			Type:         pkg.HelmPkg,
+			PURL:         "pkg:generic/common@2.14.1",
+			MetadataType: pkg.HelmChartMetadataType,
+			Metadata: pkg.HelmChartMetadata{
+				Name:       "common",
+				Version:    "2.14.1",
+				Repository: "oci://registry-1.docker.io/bitnamicharts",
+			},
+		},
+		{
+			Name:      "busybox",
+			Version:   "1.36",
+			Locations: source.NewLocationSet(valuesLocation),
+			Type:      pkg.ContainerImagePkg,
+			PURL:      "pkg:oci/busybox@sha256:6d9ac9237a84afe1516540f40a0fafdc86859b2141954b4d643af7066d598b74?repository_url=busybox&tag=1.36",
+		},
+		{
+			Name:      "docker.io/bitnami/nginx",
+			Version:   "1.25.3-debian-11-r4",
+			Locations: source.NewLocationSet(valuesLocation),
+			Type:      pkg.ContainerImagePkg,
+			PURL:      "pkg:oci/nginx?repository_url=docker.io/bitnami/nginx&tag=1.25.3-debian-11-r4",
+		},
+		{
+			Name:      "envoyproxy/envoy",
+			Version:   "1.10",
+			Locations: source.NewLocationSet(valuesLocation),
+			Type:      pkg.ContainerImagePkg,
+			PURL:      "pkg:oci/envoy?repository_url=envoyproxy/envoy&tag=1.10",
+		},
+		{
+			Name:      "nginx/nginx-prometheus-exporter",
+			Version:   "1.25.3",
+			Locations: source.NewLocationSet(valuesLocation),
+			Type:      pkg.ContainerImagePkg,
+			PURL:      "pkg:oci/nginx-prometheus-exporter?repository_url=nginx/nginx-prometheus-exporter&tag=1.25.3",
+		},
+	}
+
+	pkgs := []pkg.Package{chart}
+	var relationships []artifact.Relationship
+	for _, dependency := range dependencies {
+		pkgs = append(pkgs, dependency)
+		relationships = append(relationships, artifact.Relationship{
+			From: dependency,
+			To:   chart,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+	return pkgs, relationships
+}
+
+func TestParseChartYAML(t *testing.T) {
+	fixture := "test-fixtures/chart/Chart.yaml"
+	lockFixture := "test-fixtures/chart/Chart.lock"
+	valuesFixture := "test-fixtures/chart/values.yaml"
+	expectedPkgs, expectedRelationships := expectedChartPackages(
+		source.NewLocation(fixture),
+		source.NewLocation(lockFixture),
+		source.NewLocation(valuesFixture),
+	)
+
+	pkgtest.NewCatalogTester().
+		FromFile(t, fixture).
+		WithResolver(source.NewMockResolverForPaths(fixture, lockFixture, valuesFixture)).
+		Expects(expectedPkgs, expectedRelationships).
+		TestParser(t, parseChartYAML)
+}
+
+func TestParseChartYAML_withoutLockOrValues(t *testing.T) {
+	fixture := "test-fixtures/chart/Chart.yaml"
+	expectedPkgs, _ := expectedChartPackages(source.NewLocation(fixture), source.Location{}, source.Location{})
+	chart := expectedPkgs[0]
+	metadata := chart.Metadata.(pkg.HelmChartMetadata)
+	metadata.Images = nil
+	chart.Metadata = metadata
+
+	pkgtest.NewCatalogTester().
+		FromFile(t, fixture).
+		WithResolver(source.NewMockResolverForPaths(fixture)).
+		Expects([]pkg.Package{chart}, nil).
+		TestParser(t, parseChartYAML)
+}
+
+func TestParseChartArchive(t *testing.T) {
+	fixture := "test-fixtures/packaged/web-1.2.3.tgz"
+	location := source.NewLocation(fixture)
+	expectedPkgs, expectedRelationships := expectedChartPackages(location, location, location)
+
+	pkgtest.TestFileParser(t, fixture, parseChartArchive, expectedPkgs, expectedRelationships)
+}
+
+func TestParseChartArchive_notAChart(t *testing.T) {
+	pkgtest.TestFileParser(t, "test-fixtures/not-a-chart.tgz", parseChartArchive, nil, nil)
+	pkgtest.TestFileParser(t, "test-fixtures/chart/values.yaml", parseChartArchive, nil, nil)
+}
@@ -0,0 +1,78 @@
+package kubernetes
+
+import (
+	"errors"
+	"io"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseKubernetesManifest
+
+// the fields of a pod spec listing containers (see https://kubernetes.io/docs/reference/kubernetes-api/workload-resources/pod-v1/#PodSpec)
+var containerListKeys = map[string]bool{
+	"containers":          true,
+	"initContainers":      true,
+	"ephemeralContainers": true,
+}
+
+// parseKubernetesManifest finds the container images of the workloads (e.g. deployments or cron jobs) of a manifest
+// file, which may hold several resources as separate YAML documents. Since any YAML file is considered, files that are
+// not valid YAML (e.g. the templates of a helm chart) or are not Kubernetes resources are skipped without an error.
+func parseKubernetesManifest(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var refs []imageReference
+	dec := yaml.NewDecoder(reader)
+	for {
+		var document yaml.Node
+		err := dec.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			log.WithFields("path", reader.RealPath, "error", err).Trace("unable to parse YAML file as a kubernetes manifest")
+			return nil, nil, nil
+		}
+		if !isKubernetesResource(&document) {
+			continue
+		}
+		refs = append(refs, imagesFromResource(&document)...)
+	}
+
+	var pkgs []pkg.Package
+	for _, ref := range uniqueImages(refs) {
+		pkgs = append(pkgs, newContainerImagePackage(ref, reader.Location))
+	}
+	return pkgs, nil, nil
+}
+
+// isKubernetesResource indicates if the given document describes a Kubernetes resource (with an API version and kind).
+func isKubernetesResource(document *yaml.Node) bool {
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return false
+	}
+	fields := scalarFields(document.Content[0])
+	return fields["apiVersion"] != "" && fields["kind"] != ""
+}
+
+// imagesFromResource finds the images of the containers of any pod spec within the given resource, which covers every
+// kind of workload (and lists of resources) regardless of where the pod spec is nested.
+func imagesFromResource(document *yaml.Node) []imageReference {
+	var refs []imageReference
+	walkValues(document, "", func(key string, node *yaml.Node) {
+		if node.Kind != yaml.MappingNode || !containerListKeys[key] {
+			return
+		}
+		if image, ok := scalarFields(node)["image"]; ok {
+			if ref, ok := parseImageReference(image); ok {
+				refs = append(refs, ref)
+			}
+		}
+	})
+	return refs
+}
@@ -0,0 +1,60 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseKubernetesManifest(t *testing.T) {
+	fixture := "test-fixtures/manifests/workloads.yaml"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expected := []pkg.Package{
+		{
+			Name:      "ghcr.io/example/migrate",
+			Version:   "v3.2.0",
+			Locations: locations,
+			Type:      pkg.ContainerImagePkg,
+			PURL:      "pkg:oci/migrate?repository_url=ghcr.io/example/migrate&tag=v3.2.0",
+		},
+		{
+			// without a tag, the image is versioned by the digest
+			Name:      "nginx/nginx-prometheus-exporter",
+			Version:   "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31",
+			Locations: locations,
+			Type:      pkg.ContainerImagePkg,
+			PURL:      "pkg:oci/nginx-prometheus-exporter@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31?repository_url=nginx/nginx-prometheus-exporter",
+		},
+		{
+			Name:      "nginx",
+			Version:   "1.25.3",
+			Locations: locations,
+			Type:      pkg.ContainerImagePkg,
+			PURL:      "pkg:oci/nginx?repository_url=nginx&tag=1.25.3",
+		},
+		{
+			Name:      "registry.example.com:5000/tools/cleanup",
+			Version:   "2024.01",
+			Locations: locations,
+			Type:      pkg.ContainerImagePkg,
+			PURL:      "pkg:oci/cleanup?repository_url=registry.example.com:5000/tools/cleanup&tag=2024.01",
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseKubernetesManifest, expected, nil)
+}
+
+func TestParseKubernetesManifest_notAManifest(t *testing.T) {
+	for _, fixture := range []string{
+		"test-fixtures/manifests/docker-compose.yaml",
+		"test-fixtures/chart/Chart.yaml",
+		"test-fixtures/chart/values.yaml",
+		"test-fixtures/chart/templates/deployment.yaml",
+	} {
+		t.Run(fixture, func(t *testing.T) {
+			pkgtest.TestFileParser(t, fixture, parseKubernetesManifest, nil, nil)
+		})
+	}
+}
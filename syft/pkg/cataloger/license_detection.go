@@ -0,0 +1,113 @@
+package cataloger
+
+import (
+	"io"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/licensecheck"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// noticeFileGlob matches the conventional (case-insensitive) name of notice files, e.g. NOTICE or NOTICE.txt
+const noticeFileGlob = "[Nn][Oo][Tt][Ii][Cc][Ee]*"
+
+// dependencyDirs are the directories that hold third-party packages, which are never covered by the license of the
+// project at the source root.
+var dependencyDirs = internal.NewStringSet(
+	"node_modules",
+	"site-packages",
+	"dist-packages",
+	"vendor",
+	"bower_components",
+	"gems",
+)
+
+// packageRootMetadataTypes are the metadata types of packages discovered from a metadata file at the package root
+// (alongside the license files of the package), as opposed to lock files or databases that describe many packages.
+var packageRootMetadataTypes = map[pkg.MetadataType]struct{}{
+	pkg.NpmPackageJSONMetadataType: {},
+	pkg.PythonPackageMetadataType:  {},
+}
+
+// detectLicenses concludes the licenses of the given package from the contents of the LICENSE, COPYING, and NOTICE
+// files at the package root (the directory of the package metadata). When no license is found at the package root,
+// the license files at the source root are considered for packages that are not within a dependency directory.
+func detectLicenses(p pkg.Package, resolver source.FileResolver) []pkg.License {
+	if _, ok := packageRootMetadataTypes[p.MetadataType]; !ok {
+		return nil
+	}
+
+	locations := p.Locations.ToSlice()
+
+	licenses := detectLicensesInDirs(locationDirs(locations), resolver)
+	if len(licenses) > 0 || withinDependencyDir(locations) {
+		return licenses
+	}
+	return detectLicensesInDirs([]string{"/"}, resolver)
+}
+
+func detectLicensesInDirs(dirs []string, resolver source.FileResolver) []pkg.License {
+	globs := append(append([]string{}, licenseFileGlobs...), noticeFileGlob)
+
+	var licenses []pkg.License
+	seen := source.NewCoordinateSet()
+	for _, dir := range dirs {
+		for _, glob := range globs {
+			matches, err := filesInDir(resolver, dir, glob)
+			if err != nil {
+				log.Debugf("unable to search for license files in %q: %+v", dir, err)
+				continue
+			}
+			for _, location := range matches {
+				if seen.Contains(location.Coordinates) {
+					continue
+				}
+				seen.Add(location.Coordinates)
+				licenses = append(licenses, detectLicensesInFile(location, resolver)...)
+			}
+		}
+	}
+	return licenses
+}
+
+func detectLicensesInFile(location source.Location, resolver source.FileResolver) []pkg.License {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		log.Debugf("unable to read license file %q: %+v", location.RealPath, err)
+		return nil
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	contents, err := io.ReadAll(io.LimitReader(reader, maxLicenseTextSize))
+	if err != nil {
+		log.Debugf("unable to read license file %q: %+v", location.RealPath, err)
+		return nil
+	}
+
+	var licenses []pkg.License
+	for _, match := range licensecheck.Detect(contents) {
+		coordinates := location.Coordinates
+		licenses = append(licenses, pkg.License{
+			Value:          match.ID,
+			SPDXExpression: match.ID,
+			Type:           pkg.ConcludedLicense,
+			Location:       &coordinates,
+			Confidence:     match.Confidence,
+		})
+	}
+	return licenses
+}
+
+func withinDependencyDir(locations []source.Location) bool {
+	for _, location := range locations {
+		for _, segment := range strings.Split(location.RealPath, "/") {
+			if dependencyDirs.Contains(segment) {
+				return true
+			}
+		}
+	}
+	return false
+}
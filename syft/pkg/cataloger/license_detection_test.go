@@ -0,0 +1,73 @@
+package cataloger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func Test_detectLicenses(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/license-detection")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		path         string
+		metadataType pkg.MetadataType
+		expected     []pkg.License
+	}{
+		{
+			name:         "license files at the package root",
+			path:         "/packages/lib/package.json",
+			metadataType: pkg.NpmPackageJSONMetadataType,
+			expected: []pkg.License{
+				concludedLicense("BSD-2-Clause", "packages/lib/COPYING", 1),
+				concludedLicense("Apache-2.0", "packages/lib/NOTICE", 1),
+			},
+		},
+		{
+			name:         "license files at the source root",
+			path:         "/packages/app/package.json",
+			metadataType: pkg.NpmPackageJSONMetadataType,
+			expected: []pkg.License{
+				concludedLicense("MIT", "LICENSE", 1),
+			},
+		},
+		{
+			name:         "dependencies are not covered by the source root license",
+			path:         "/node_modules/dep/package.json",
+			metadataType: pkg.NpmPackageJSONMetadataType,
+		},
+		{
+			name:         "packages from lock files are not at their package root",
+			path:         "/packages/lib/package.json",
+			metadataType: pkg.DartPubMetadataType,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := pkg.Package{
+				Locations:    source.NewLocationSet(source.NewLocation(test.path)),
+				MetadataType: test.metadataType,
+			}
+			assert.Equal(t, test.expected, detectLicenses(p, resolver))
+		})
+	}
+}
+
+func concludedLicense(value, path string, confidence float64) pkg.License {
+	return pkg.License{
+		Value:          value,
+		SPDXExpression: value,
+		Type:           pkg.ConcludedLicense,
+		Location:       &source.Coordinates{RealPath: path},
+		Confidence:     confidence,
+	}
+}
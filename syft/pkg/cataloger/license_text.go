@@ -0,0 +1,124 @@
+package cataloger
+
+import (
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// maxLicenseTextSize is the largest amount of license file content captured for a single package
+const maxLicenseTextSize = 512 * 1024
+
+// licenseFileGlobs match the conventional (case-insensitive) names of license files, e.g. LICENSE, LICENCE.md, COPYING
+var licenseFileGlobs = []string{
+	"[Ll][Ii][Cc][Ee][Nn][CcSs][Ee]*",
+	"[Cc][Oo][Pp][Yy][Ii][Nn][Gg]*",
+}
+
+// licenseTexts captures the contents of the license file found alongside the package metadata for every declared
+// license of the given package that is not an SPDX license expression. Nil is returned when all licenses are SPDX
+// license expressions or no license file could be found.
+func licenseTexts(p pkg.Package, resolver source.FileResolver) map[string]string {
+	var unmatched []string
+	for _, l := range p.DeclaredLicenses() {
+		if l.SPDXExpression == "" && l.Value != "" {
+			unmatched = append(unmatched, l.Value)
+		}
+	}
+	if len(unmatched) == 0 {
+		return nil
+	}
+
+	text := findLicenseText(p.Locations.ToSlice(), resolver)
+	if text == "" {
+		return nil
+	}
+
+	// license files are not attributed to any single license, so every license that needs extracted licensing info
+	// refers to the same text
+	texts := make(map[string]string, len(unmatched))
+	for _, l := range unmatched {
+		texts[l] = text
+	}
+	return texts
+}
+
+// findLicenseText returns the contents of the first license file (by path) within the directories of the given
+// locations.
+func findLicenseText(locations []source.Location, resolver source.FileResolver) string {
+	var candidates []source.Location
+	for _, dir := range locationDirs(locations) {
+		for _, glob := range licenseFileGlobs {
+			matches, err := filesInDir(resolver, dir, glob)
+			if err != nil {
+				log.Debugf("unable to search for license files in %q: %+v", dir, err)
+				continue
+			}
+			candidates = append(candidates, matches...)
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].RealPath < candidates[j].RealPath
+	})
+
+	for _, location := range candidates {
+		if text := readLicenseText(location, resolver); text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+func readLicenseText(location source.Location, resolver source.FileResolver) string {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		log.Debugf("unable to read license file %q: %+v", location.RealPath, err)
+		return ""
+	}
+	defer internal.CloseAndLogError(reader, location.VirtualPath)
+
+	contents, err := io.ReadAll(io.LimitReader(reader, maxLicenseTextSize))
+	if err != nil {
+		log.Debugf("unable to read license file %q: %+v", location.RealPath, err)
+		return ""
+	}
+	return strings.TrimSpace(string(contents))
+}
+
+// locationDirs returns the unique parent directories of the given locations (sorted).
+func locationDirs(locations []source.Location) []string {
+	dirs := internal.NewStringSet()
+	for _, location := range locations {
+		dirs.Add(path.Dir(location.RealPath))
+	}
+	return dirs.ToSlice()
+}
+
+// filesInDir returns the files matching the given glob directly within the given directory. Directory resolvers
+// respond with paths relative to the scan root (without a leading "/"), so the directory is searched for anywhere in
+// the tree and the directory of each match is compared to the given directory.
+func filesInDir(resolver source.FileResolver, dir, glob string) ([]source.Location, error) {
+	matches, err := resolver.FilesByGlob(path.Join("**", dir, glob))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []source.Location
+	for _, location := range matches {
+		if rootedPath(path.Dir(location.RealPath)) == rootedPath(dir) {
+			results = append(results, location)
+		}
+	}
+	return results, nil
+}
+
+func rootedPath(p string) string {
+	return path.Clean("/" + p)
+}
@@ -0,0 +1,54 @@
+package cataloger
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func Test_licenseTexts(t *testing.T) {
+	src, err := source.NewFromDirectory("test-fixtures/license-text")
+	require.NoError(t, err)
+
+	resolver, err := src.FileResolver(source.SquashedScope)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		path     string
+		licenses []string
+		expected map[string]string
+	}{
+		{
+			name:     "non-SPDX license with license file",
+			path:     "/acme/package.json",
+			licenses: []string{"MIT", "Acme Commercial License"},
+			expected: map[string]string{
+				"Acme Commercial License": "Acme Commercial License\n\nCopyright (c) Acme Corp. All rights reserved.",
+			},
+		},
+		{
+			name:     "SPDX licenses only",
+			path:     "/acme/package.json",
+			licenses: []string{"MIT", "apache-2.0"},
+		},
+		{
+			name:     "no license file",
+			path:     "/plain/package.json",
+			licenses: []string{"Acme Commercial License"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := pkg.Package{
+				Licenses:  pkg.NewLicensesFromValues(test.licenses...),
+				Locations: source.NewLocationSet(source.NewLocation(test.path)),
+			}
+			assert.Equal(t, test.expected, licenseTexts(p, resolver))
+		})
+	}
+}
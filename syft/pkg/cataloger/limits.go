@@ -0,0 +1,149 @@
+package cataloger
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source"
+)
+
+// Limits bound the resources used while cataloging, so that pathological content (e.g. tar bombs or layers with
+// millions of files) results in partial results rather than a scan that does not finish. Zero values are unlimited.
+type Limits struct {
+	// MaxFileSize is the largest file (in bytes) that catalogers are given, larger files are skipped
+	MaxFileSize int64
+	// MaxFilesPerCataloger is the most files that each cataloger is given, further files are skipped
+	MaxFilesPerCataloger int
+	// Deadline is when cataloging stops, keeping only the results of the catalogers that have finished
+	Deadline time.Time
+}
+
+// TimedOut indicates that the deadline has passed.
+func (l Limits) TimedOut() bool {
+	return !l.Deadline.IsZero() && time.Now().After(l.Deadline)
+}
+
+func (l Limits) unlimited() bool {
+	return l.MaxFileSize <= 0 && l.MaxFilesPerCataloger <= 0 && l.Deadline.IsZero()
+}
+
+// limitedResolver enforces limits on the files a single cataloger is given. Files beyond the limits are left out of
+// search results, so the cataloger continues with the files it has been given rather than failing.
+type limitedResolver struct {
+	source.FileResolver
+	limits    Limits
+	cataloger string
+	lock      sync.Mutex
+	given     map[source.Coordinates]struct{}
+	warned    bool
+}
+
+func newLimitedResolver(resolver source.FileResolver, limits Limits, cataloger string) source.FileResolver {
+	if limits.unlimited() {
+		return resolver
+	}
+	return &limitedResolver{
+		FileResolver: resolver,
+		limits:       limits,
+		cataloger:    cataloger,
+		given:        make(map[source.Coordinates]struct{}),
+	}
+}
+
+func (r *limitedResolver) FileContentsByLocation(location source.Location) (io.ReadCloser, error) {
+	if r.limits.TimedOut() {
+		return nil, fmt.Errorf("unable to read path=%q: cataloging timed out", location.RealPath)
+	}
+	if r.tooLarge(location) {
+		return nil, fmt.Errorf("unable to read path=%q: file is larger than the max file size (%d bytes)", location.RealPath, r.limits.MaxFileSize)
+	}
+	return r.FileResolver.FileContentsByLocation(location)
+}
+
+func (r *limitedResolver) FilesByPath(paths ...string) ([]source.Location, error) {
+	locations, err := r.FileResolver.FilesByPath(paths...)
+	return r.filter(locations), err
+}
+
+func (r *limitedResolver) FilesByGlob(patterns ...string) ([]source.Location, error) {
+	locations, err := r.FileResolver.FilesByGlob(patterns...)
+	return r.filter(locations), err
+}
+
+func (r *limitedResolver) FilesByMIMEType(types ...string) ([]source.Location, error) {
+	locations, err := r.FileResolver.FilesByMIMEType(types...)
+	return r.filter(locations), err
+}
+
+func (r *limitedResolver) RelativeFileByPath(location source.Location, path string) *source.Location {
+	l := r.FileResolver.RelativeFileByPath(location, path)
+	if l != nil && !r.allowed(*l) {
+		return nil
+	}
+	return l
+}
+
+func (r *limitedResolver) AllLocations() <-chan source.Location {
+	c := make(chan source.Location)
+	go func() {
+		defer close(c)
+		for location := range r.FileResolver.AllLocations() {
+			if r.allowed(location) {
+				c <- location
+			}
+		}
+	}()
+	return c
+}
+
+func (r *limitedResolver) filter(locations []source.Location) []source.Location {
+	var allowed []source.Location
+	for _, location := range locations {
+		if r.allowed(location) {
+			allowed = append(allowed, location)
+		}
+	}
+	return allowed
+}
+
+func (r *limitedResolver) allowed(location source.Location) bool {
+	if r.limits.TimedOut() || r.tooLarge(location) {
+		return false
+	}
+	if r.limits.MaxFilesPerCataloger <= 0 {
+		return true
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if _, ok := r.given[location.Coordinates]; ok {
+		return true
+	}
+	if len(r.given) >= r.limits.MaxFilesPerCataloger {
+		if !r.warned {
+			log.Warnf("cataloger=%q reached the max number of files (%d), skipping the remaining files", r.cataloger, r.limits.MaxFilesPerCataloger)
+			r.warned = true
+		}
+		return false
+	}
+	r.given[location.Coordinates] = struct{}{}
+	return true
+}
+
+func (r *limitedResolver) tooLarge(location source.Location) bool {
+	if r.limits.MaxFileSize <= 0 {
+		return false
+	}
+	metadata, err := r.FileMetadataByLocation(location)
+	if err != nil {
+		return false
+	}
+	if metadata.Size > r.limits.MaxFileSize {
+		log.Debugf("skipping path=%q for cataloger=%q: file is larger than the max file size (%d bytes)", location.RealPath, r.cataloger, r.limits.MaxFileSize)
+		return true
+	}
+	return false
+}
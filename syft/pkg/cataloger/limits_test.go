@@ -0,0 +1,76 @@
+package cataloger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestLimitedResolver(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for _, f := range []struct {
+		name string
+		size int
+	}{
+		{name: "a.pkg", size: 10},
+		{name: "b.pkg", size: 1000},
+		{name: "c.pkg", size: 10},
+		{name: "d.pkg", size: 10},
+	} {
+		path := filepath.Join(dir, f.name)
+		require.NoError(t, os.WriteFile(path, make([]byte, f.size), 0644))
+		paths = append(paths, path)
+	}
+
+	resolver := newLimitedResolver(source.NewMockResolverForPaths(paths...), Limits{MaxFileSize: 100, MaxFilesPerCataloger: 2}, "test")
+
+	locations, err := resolver.FilesByGlob("**/*.pkg")
+	require.NoError(t, err)
+	var names []string
+	for _, l := range locations {
+		names = append(names, filepath.Base(l.RealPath))
+	}
+	assert.Equal(t, []string{"a.pkg", "c.pkg"}, names, "large files and files beyond the max count should be skipped")
+
+	// files already given are still available
+	locations, err = resolver.FilesByPath(paths[0], paths[3])
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, paths[0], locations[0].RealPath)
+
+	_, err = resolver.FileContentsByLocation(source.NewLocation(paths[1]))
+	assert.Error(t, err)
+}
+
+func TestLimitedResolver_unlimited(t *testing.T) {
+	resolver := source.NewMockResolverForPaths("/a")
+	assert.Same(t, resolver, newLimitedResolver(resolver, Limits{}, "test"))
+}
+
+func TestCatalogWithOptions_timeout(t *testing.T) {
+	catalogers := []pkg.Cataloger{
+		delayedCataloger{name: "fast"},
+		delayedCataloger{name: "slow", delay: 5 * time.Second},
+	}
+
+	start := time.Now()
+	catalog, _, err := CatalogWithOptions(source.NewMockResolverForPaths(), nil, Options{Parallelism: 2, Limits: Limits{Deadline: start.Add(100 * time.Millisecond)}}, catalogers...)
+	assert.True(t, time.Since(start) < 5*time.Second, "should not wait for unfinished catalogers")
+
+	// the unfinished cataloger is reported as failed, keeping the results of the finished cataloger
+	var partial *PartialResultsError
+	require.ErrorAs(t, err, &partial)
+	require.Len(t, partial.Failures, 1)
+	assert.Equal(t, "slow", partial.Failures[0].Cataloger)
+
+	require.Equal(t, 1, catalog.PackageCount())
+	assert.Len(t, catalog.PackagesByName("fast"), 1)
+}
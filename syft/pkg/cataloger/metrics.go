@@ -0,0 +1,125 @@
+package cataloger
+
+import (
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+// CatalogerMetrics are the measurements of all runs of a single cataloger (e.g. once for each image layer).
+type CatalogerMetrics struct {
+	// Cataloger is the name of the cataloger
+	Cataloger string
+	// Runs is the number of times the cataloger was run
+	Runs int
+	// Failures is the number of runs that failed (see CatalogerError)
+	Failures int
+	// Duration is the total time spent running the cataloger (including enriching the packages it found)
+	Duration time.Duration
+	// FilesRead is the number of times the cataloger read the contents of a file
+	FilesRead int64
+	// BytesRead is the total size of the file contents read by the cataloger
+	BytesRead int64
+	// Packages is the number of packages found by the cataloger
+	Packages int
+}
+
+// Metrics records the measurements of catalogers as they are run. This is safe for concurrent use.
+type Metrics struct {
+	lock       sync.Mutex
+	catalogers []CatalogerMetrics
+}
+
+// NewMetrics creates an empty set of measurements.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// Catalogers returns the measurements of each cataloger, in the order the catalogers were first run.
+func (m *Metrics) Catalogers() []CatalogerMetrics {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return append([]CatalogerMetrics{}, m.catalogers...)
+}
+
+// measure runs the given cataloger with the given resolver, recording the measurements of the run in the metrics and
+// instrumentation of the options. Nothing is measured when neither is given.
+func (o Options) measure(ctx context.Context, c pkg.Cataloger, resolver source.FileResolver, run func(source.FileResolver) catalogResult) catalogResult {
+	if o.Metrics == nil && o.Instrumentation == nil {
+		return run(resolver)
+	}
+
+	finish := o.Instrumentation.startCataloger(ctx, c.Name())
+	measured := &measuringResolver{FileResolver: resolver}
+	start := time.Now()
+	result := run(measured)
+
+	measurement := CatalogerMetrics{
+		Cataloger: c.Name(),
+		Runs:      1,
+		Duration:  time.Since(start),
+		FilesRead: atomic.LoadInt64(&measured.files),
+		BytesRead: atomic.LoadInt64(&measured.bytes),
+		Packages:  len(result.packages),
+	}
+	if result.err != nil {
+		measurement.Failures = 1
+	}
+	finish(measurement, result.err)
+	if o.Metrics != nil {
+		o.Metrics.add(measurement)
+	}
+	return result
+}
+
+func (m *Metrics) add(run CatalogerMetrics) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	for i := range m.catalogers {
+		existing := &m.catalogers[i]
+		if existing.Cataloger != run.Cataloger {
+			continue
+		}
+		existing.Runs += run.Runs
+		existing.Failures += run.Failures
+		existing.Duration += run.Duration
+		existing.FilesRead += run.FilesRead
+		existing.BytesRead += run.BytesRead
+		existing.Packages += run.Packages
+		return
+	}
+	m.catalogers = append(m.catalogers, run)
+}
+
+// measuringResolver counts the files (and bytes) read through the resolver.
+type measuringResolver struct {
+	source.FileResolver
+	files int64
+	bytes int64
+}
+
+func (r *measuringResolver) FileContentsByLocation(location source.Location) (io.ReadCloser, error) {
+	reader, err := r.FileResolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	atomic.AddInt64(&r.files, 1)
+	return &countingReader{ReadCloser: reader, count: &r.bytes}, nil
+}
+
+// countingReader adds the number of bytes read to the given count.
+type countingReader struct {
+	io.ReadCloser
+	count *int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	atomic.AddInt64(r.count, int64(n))
+	return n, err
+}
@@ -0,0 +1,89 @@
+package cataloger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ pkg.Cataloger = (*readingCataloger)(nil)
+
+// readingCataloger reads every file it is given, finding a package for each.
+type readingCataloger struct{}
+
+func (c readingCataloger) Name() string {
+	return "reading-cataloger"
+}
+
+func (c readingCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	var packages []pkg.Package
+	for location := range resolver.AllLocations() {
+		reader, err := resolver.FileContentsByLocation(location)
+		if err != nil {
+			return nil, nil, err
+		}
+		_, err = io.Copy(io.Discard, reader)
+		reader.Close()
+		if err != nil {
+			return nil, nil, err
+		}
+		packages = append(packages, pkg.Package{Name: filepath.Base(location.RealPath)})
+	}
+	return packages, nil, nil
+}
+
+func TestCatalogWithOptions_metrics(t *testing.T) {
+	dir := t.TempDir()
+	var paths []string
+	for i, size := range []int{10, 100} {
+		path := filepath.Join(dir, fmt.Sprintf("%d.pkg", i))
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0644))
+		paths = append(paths, path)
+	}
+
+	metrics := NewMetrics()
+	opts := Options{Parallelism: 1, Metrics: metrics}
+	catalogers := []pkg.Cataloger{readingCataloger{}, panickingCataloger{}}
+
+	// running the catalogers again (e.g. for another image layer) adds to the measurements of each cataloger
+	for i := 0; i < 2; i++ {
+		_, _, err := CatalogWithOptions(source.NewMockResolverForPaths(paths...), nil, opts, catalogers...)
+		require.True(t, IsPartialResults(err))
+	}
+
+	measurements := metrics.Catalogers()
+	require.Len(t, measurements, 2)
+
+	reading := measurements[0]
+	assert.Equal(t, "reading-cataloger", reading.Cataloger)
+	assert.Equal(t, 2, reading.Runs)
+	assert.Equal(t, 0, reading.Failures)
+	assert.Equal(t, int64(4), reading.FilesRead)
+	assert.Equal(t, int64(220), reading.BytesRead)
+	assert.Equal(t, 4, reading.Packages)
+
+	panicking := measurements[1]
+	assert.Equal(t, "panicking", panicking.Cataloger)
+	assert.Equal(t, 2, panicking.Runs)
+	assert.Equal(t, 2, panicking.Failures)
+	assert.Equal(t, 0, panicking.Packages)
+}
+
+func TestOptions_measure_nothingMeasured(t *testing.T) {
+	resolver := source.NewMockResolverForPaths()
+	result := Options{}.measure(context.Background(), readingCataloger{}, resolver, func(search source.FileResolver) catalogResult {
+		assert.Same(t, resolver, search, "the resolver should not be wrapped when nothing is measured")
+		return catalogResult{}
+	})
+	assert.NoError(t, result.err)
+}
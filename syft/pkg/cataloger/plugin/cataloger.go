@@ -0,0 +1,243 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+const describeTimeout = 10 * time.Second
+
+// Cataloger runs a plugin executable to discover packages.
+type Cataloger struct {
+	path        string
+	description Description
+	config      Config
+}
+
+// NewCataloger returns a cataloger for the plugin executable at the given path, describing the plugin to learn the
+// cataloger name and the files the plugin is interested in.
+func NewCataloger(path string, cfg Config) (*Cataloger, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), describeTimeout)
+	defer cancel()
+
+	output, err := run(ctx, path, "describe", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var description Description
+	if err := json.Unmarshal(output, &description); err != nil {
+		return nil, fmt.Errorf("unable to parse description of plugin=%q: %w", path, err)
+	}
+	if description.ProtocolVersion != ProtocolVersion {
+		return nil, fmt.Errorf("plugin=%q implements protocol version %d (expected %d)", path, description.ProtocolVersion, ProtocolVersion)
+	}
+	if description.Name == "" {
+		return nil, fmt.Errorf("plugin=%q does not describe a cataloger name", path)
+	}
+	if len(description.Globs) == 0 {
+		return nil, fmt.Errorf("plugin=%q does not describe any file globs", path)
+	}
+
+	return &Cataloger{
+		path:        path,
+		description: description,
+		config:      cfg,
+	}, nil
+}
+
+// Name returns a string that uniquely describes a cataloger
+func (c *Cataloger) Name() string {
+	return c.description.Name
+}
+
+// Catalog is given an object to resolve file references and content, this function sends the files matching the globs
+// of the plugin to the plugin, returning the packages the plugin discovered.
+func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	return c.CatalogWithContext(context.Background(), resolver)
+}
+
+// CatalogWithContext is the same as Catalog, where the plugin is stopped when the given context is done or the
+// configured timeout passes.
+func (c *Cataloger) CatalogWithContext(ctx context.Context, resolver source.FileResolver) ([]pkg.Package, []artifact.Relationship, error) {
+	locations, err := resolver.FilesByGlob(c.description.Globs...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to find files for plugin cataloger=%q: %w", c.Name(), err)
+	}
+	if len(locations) == 0 {
+		return nil, nil, nil
+	}
+
+	request, locationsByPath := c.newRequest(resolver, locations)
+	if len(request.Files) == 0 {
+		return nil, nil, nil
+	}
+
+	input, err := json.Marshal(request)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to encode request for plugin cataloger=%q: %w", c.Name(), err)
+	}
+
+	if c.config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.config.Timeout)
+		defer cancel()
+	}
+
+	output, err := run(ctx, c.path, "catalog", input)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var response CatalogResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, nil, fmt.Errorf("unable to parse response of plugin cataloger=%q: %w", c.Name(), err)
+	}
+
+	var packages []pkg.Package
+	for _, p := range response.Packages {
+		if p.Name == "" {
+			log.Warnf("ignoring package without a name from plugin cataloger=%q", c.Name())
+			continue
+		}
+		packages = append(packages, c.newPackage(p, request.Files, locationsByPath))
+	}
+	return packages, nil, nil
+}
+
+// newRequest reads the given files for the plugin, skipping the files that are larger than the configured limits.
+func (c *Cataloger) newRequest(resolver source.FileResolver, locations []source.Location) (CatalogRequest, map[string][]source.Location) {
+	var request CatalogRequest
+	var requestSize int64
+	locationsByPath := make(map[string][]source.Location)
+	for _, location := range locations {
+		if _, exists := locationsByPath[location.RealPath]; exists {
+			locationsByPath[location.RealPath] = append(locationsByPath[location.RealPath], location)
+			continue
+		}
+
+		contents, err := c.readContents(resolver, location)
+		if err != nil {
+			log.Warnf("unable to read %q for plugin cataloger=%q: %+v", location.RealPath, c.Name(), err)
+			continue
+		}
+		size := int64(len(contents))
+		if c.config.MaxRequestSize > 0 && requestSize+size > c.config.MaxRequestSize {
+			log.Warnf("skipping %q for plugin cataloger=%q: the files exceed the max request size (%d bytes)", location.RealPath, c.Name(), c.config.MaxRequestSize)
+			continue
+		}
+		requestSize += size
+
+		request.Files = append(request.Files, File{
+			Path:     location.RealPath,
+			Contents: contents,
+		})
+		locationsByPath[location.RealPath] = append(locationsByPath[location.RealPath], location)
+	}
+	return request, locationsByPath
+}
+
+func (c *Cataloger) newPackage(p Package, files []File, locationsByPath map[string][]source.Location) pkg.Package {
+	paths := p.Locations
+	if len(paths) == 0 {
+		for _, f := range files {
+			paths = append(paths, f.Path)
+		}
+	}
+
+	var locations []source.Location
+	for _, path := range paths {
+		found, ok := locationsByPath[path]
+		if !ok {
+			log.Debugf("plugin cataloger=%q described package=%q at a path that was not requested: %q", c.Name(), p.Name, path)
+			continue
+		}
+		locations = append(locations, found...)
+	}
+
+	var licenses []pkg.License
+	for _, l := range p.Licenses {
+		licenses = append(licenses, pkg.NewLicense(l))
+	}
+
+	var cpes []pkg.CPE
+	for _, value := range p.CPEs {
+		cpe, err := pkg.NewCPE(value)
+		if err != nil {
+			log.Debugf("plugin cataloger=%q described package=%q with an invalid CPE=%q: %+v", c.Name(), p.Name, value, err)
+			continue
+		}
+		cpes = append(cpes, cpe)
+	}
+
+	typ := pkg.UnknownPkg
+	if p.Type != "" {
+		typ = pkg.Type(p.Type)
+	}
+
+	result := pkg.Package{
+		Name:      p.Name,
+		Version:   p.Version,
+		FoundBy:   c.Name(),
+		Locations: source.NewLocationSet(locations...),
+		Licenses:  licenses,
+		Language:  pkg.Language(p.Language),
+		Type:      typ,
+		CPEs:      cpes,
+		PURL:      p.PURL,
+	}
+	result.SetID()
+	return result
+}
+
+// readContents reads the given file, failing when the file is larger than the configured max file size.
+func (c *Cataloger) readContents(resolver source.FileResolver, location source.Location) ([]byte, error) {
+	reader, err := resolver.FileContentsByLocation(location)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(reader, location.RealPath)
+
+	if c.config.MaxFileSize <= 0 {
+		return io.ReadAll(reader)
+	}
+	// note: one byte beyond the limit is read to tell whether the file is larger than the limit
+	contents, err := io.ReadAll(io.LimitReader(reader, c.config.MaxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(contents)) > c.config.MaxFileSize {
+		return nil, fmt.Errorf("the file is larger than the max file size (%d bytes)", c.config.MaxFileSize)
+	}
+	return contents, nil
+}
+
+// run executes the plugin with the given command, returning the output written to stdout.
+func run(ctx context.Context, path, command string, input []byte) ([]byte, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, command)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		log.WithFields("plugin", path, "command", command).Debug(msg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("plugin=%q failed to %s: %w", path, command, err)
+	}
+	return stdout.Bytes(), nil
+}
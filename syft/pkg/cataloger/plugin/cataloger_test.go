@@ -0,0 +1,113 @@
+package plugin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestDiscover(t *testing.T) {
+	catalogers, err := Discover(Config{Directory: "test-fixtures/plugins"})
+	require.NoError(t, err)
+	require.Len(t, catalogers, 1)
+	assert.Equal(t, "acme-lock-cataloger", catalogers[0].Name())
+
+	catalogers, err = Discover(Config{Directory: "test-fixtures/missing"})
+	require.NoError(t, err)
+	assert.Empty(t, catalogers)
+
+	// plugins are opt-in: nothing is run without a configured directory
+	catalogers, err = Discover(DefaultConfig())
+	require.NoError(t, err)
+	assert.Empty(t, catalogers)
+}
+
+func TestCataloger_Catalog(t *testing.T) {
+	c, err := NewCataloger("test-fixtures/plugins/syft-cataloger-acme", DefaultConfig())
+	require.NoError(t, err)
+
+	fixture := "test-fixtures/acme/packages.lock"
+	packages, relationships, err := c.Catalog(source.NewMockResolverForPaths(fixture))
+	require.NoError(t, err)
+	assert.Empty(t, relationships)
+	require.Len(t, packages, 1)
+
+	p := packages[0]
+	assert.NotEmpty(t, p.ID())
+	assert.Equal(t, "acme-agent", p.Name)
+	assert.Equal(t, "2.4.1", p.Version)
+	assert.Equal(t, pkg.Type("acme"), p.Type)
+	assert.Equal(t, "acme-lock-cataloger", p.FoundBy)
+	assert.Equal(t, []pkg.License{pkg.NewLicense("MIT")}, p.Licenses)
+	require.Len(t, p.CPEs, 1)
+	assert.Equal(t, "cpe:2.3:a:acme:acme_agent:2.4.1:*:*:*:*:*:*:*", pkg.CPEString(p.CPEs[0]))
+	require.Len(t, p.Locations.ToSlice(), 1)
+	assert.Equal(t, fixture, p.Locations.ToSlice()[0].RealPath)
+}
+
+func TestNewCataloger_unsupportedProtocolVersion(t *testing.T) {
+	_, err := NewCataloger("test-fixtures/plugins/syft-cataloger-future", DefaultConfig())
+	assert.Error(t, err)
+}
+
+func TestCataloger_CatalogWithContext_timeout(t *testing.T) {
+	c, err := NewCataloger("test-fixtures/extra/syft-cataloger-slow", Config{Timeout: 100 * time.Millisecond})
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, _, err = c.CatalogWithContext(context.Background(), source.NewMockResolverForPaths("test-fixtures/acme/packages.lock"))
+	require.Error(t, err)
+	assert.Less(t, time.Since(start), 30*time.Second)
+}
+
+func TestCataloger_newRequest_limits(t *testing.T) {
+	// note: each file is 17 bytes
+	fixtures := []string{
+		"test-fixtures/acme/packages.lock",
+		"test-fixtures/limits/a/packages.lock",
+		"test-fixtures/limits/b/packages.lock",
+	}
+
+	tests := []struct {
+		name     string
+		config   Config
+		expected []string
+	}{
+		{
+			name:     "unlimited",
+			config:   Config{},
+			expected: fixtures,
+		},
+		{
+			name:     "files larger than the max file size are skipped",
+			config:   Config{MaxFileSize: 16},
+			expected: nil,
+		},
+		{
+			name:     "files beyond the max request size are skipped",
+			config:   Config{MaxFileSize: 17, MaxRequestSize: 40},
+			expected: fixtures[:2],
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Cataloger{description: Description{Name: "acme-lock-cataloger"}, config: test.config}
+			resolver := source.NewMockResolverForPaths(fixtures...)
+			locations, err := resolver.FilesByPath(fixtures...)
+			require.NoError(t, err)
+
+			request, _ := c.newRequest(resolver, locations)
+			var paths []string
+			for _, f := range request.Files {
+				paths = append(paths, f.Path)
+			}
+			assert.Equal(t, test.expected, paths)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package plugin
+
+import "time"
+
+const (
+	defaultTimeout        = 5 * time.Minute
+	defaultMaxFileSize    = 10 * 1024 * 1024
+	defaultMaxRequestSize = 100 * 1024 * 1024
+)
+
+type Config struct {
+	// Directory is the directory of plugin executables to run (no plugins are run when empty)
+	Directory string
+	// Timeout is how long each plugin may take to catalog the files sent to it (unlimited when zero)
+	Timeout time.Duration
+	// MaxFileSize is the largest file (in bytes) sent to a plugin, larger files are skipped (unlimited when zero)
+	MaxFileSize int64
+	// MaxRequestSize is the largest total size (in bytes) of the files sent to a plugin at once, the files beyond this
+	// size are skipped (unlimited when zero)
+	MaxRequestSize int64
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Timeout:        defaultTimeout,
+		MaxFileSize:    defaultMaxFileSize,
+		MaxRequestSize: defaultMaxRequestSize,
+	}
+}
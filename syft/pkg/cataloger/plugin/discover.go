@@ -0,0 +1,51 @@
+package plugin
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// ExecutablePrefix is the prefix of the file name of every plugin executable.
+const ExecutablePrefix = "syft-cataloger-"
+
+// Discover returns a cataloger for each plugin executable within the configured directory (ordered by file name, as read from the directory). Plugins
+// that cannot be described are skipped, and a missing (or unset) directory has no plugins.
+func Discover(cfg Config) ([]*Cataloger, error) {
+	dir := cfg.Directory
+	if dir == "" {
+		return nil, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to read plugin directory=%q: %w", dir, err)
+	}
+
+	var catalogers []*Cataloger
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), ExecutablePrefix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode().Perm()&0o111 == 0 {
+			log.Debugf("skipping plugin=%q: not an executable", entry.Name())
+			continue
+		}
+
+		c, err := NewCataloger(filepath.Join(dir, entry.Name()), cfg)
+		if err != nil {
+			log.Warnf("unable to load cataloger plugin: %+v", err)
+			continue
+		}
+		log.Debugf("discovered cataloger plugin=%q (%s)", entry.Name(), c.Name())
+		catalogers = append(catalogers, c)
+	}
+	return catalogers, nil
+}
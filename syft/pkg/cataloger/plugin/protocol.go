@@ -0,0 +1,53 @@
+/*
+Package plugin provides catalogers implemented by external executables (plugins), allowing packages to be discovered
+from formats that syft does not support (e.g. proprietary package formats).
+
+Plugins are executables named with the "syft-cataloger-" prefix within the plugin directory, which communicate with
+syft using JSON over stdio:
+
+  - "<plugin> describe" writes a Description to stdout, naming the cataloger and the file globs it is interested in.
+  - "<plugin> catalog" reads a CatalogRequest from stdin, holding the contents of each file matching the globs, and
+    writes a CatalogResponse to stdout describing the packages found within the files.
+
+Anything written to stderr is logged by syft, and a non-zero exit code is treated as a failure of the cataloger.
+*/
+package plugin
+
+// ProtocolVersion is the version of the plugin protocol described within this package. Plugins describing a different
+// protocol version are not run.
+const ProtocolVersion = 1
+
+// Description describes a plugin cataloger (the response to the "describe" command).
+type Description struct {
+	ProtocolVersion int      `json:"protocolVersion"` // the plugin protocol version the plugin implements
+	Name            string   `json:"name"`            // the name of the cataloger (e.g. "acme-package-cataloger")
+	Globs           []string `json:"globs"`           // the globs of the files to send to the cataloger
+}
+
+// CatalogRequest holds the files to catalog (the input of the "catalog" command).
+type CatalogRequest struct {
+	Files []File `json:"files"`
+}
+
+// File is a file matching the globs of the plugin.
+type File struct {
+	Path     string `json:"path"`     // the path of the file within the source
+	Contents []byte `json:"contents"` // the file contents (base64 encoded within JSON)
+}
+
+// CatalogResponse holds the packages found by the plugin (the output of the "catalog" command).
+type CatalogResponse struct {
+	Packages []Package `json:"packages"`
+}
+
+// Package is a package found by the plugin.
+type Package struct {
+	Name      string   `json:"name"`
+	Version   string   `json:"version"`
+	Type      string   `json:"type,omitempty"`      // the package type (e.g. "rpm"), defaults to an unknown package type
+	Language  string   `json:"language,omitempty"`  // the language of the package (e.g. "java")
+	PURL      string   `json:"purl,omitempty"`      // the package URL (generated by syft when not provided)
+	CPEs      []string `json:"cpes,omitempty"`      // the CPEs of the package (generated by syft when not provided)
+	Licenses  []string `json:"licenses,omitempty"`  // the licenses declared by the package
+	Locations []string `json:"locations,omitempty"` // the paths of the requested files the package was found in (defaults to all requested files)
+}
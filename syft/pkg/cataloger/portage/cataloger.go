@@ -9,7 +9,6 @@ import (
 	"path"
 	"path/filepath"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
 
@@ -121,9 +120,7 @@ func addLicenses(resolver source.FileResolver, dbLocation source.Location, p *pk
 					findings.Add(token)
 				}
 			}
-			p.Licenses = findings.ToSlice()
-
-			sort.Strings(p.Licenses)
+			p.Licenses = pkg.NewLicensesFromLocation(*location, findings.ToSlice()...)
 		}
 	}
 }
@@ -20,10 +20,13 @@ func TestPortageCataloger(t *testing.T) {
 			name: "go-case",
 			expected: []pkg.Package{
 				{
-					Name:         "app-containers/skopeo",
-					Version:      "1.5.1",
-					FoundBy:      "portage-cataloger",
-					Licenses:     []string{"Apache-2.0", "BSD", "BSD-2", "CC-BY-SA-4.0", "ISC", "MIT"},
+					Name:    "app-containers/skopeo",
+					Version: "1.5.1",
+					FoundBy: "portage-cataloger",
+					Licenses: pkg.NewLicensesFromLocation(
+						source.NewLocation("/var/db/pkg/app-containers/skopeo-1.5.1/LICENSE"),
+						"Apache-2.0", "BSD", "BSD-2", "CC-BY-SA-4.0", "ISC", "MIT",
+					),
 					Type:         pkg.PortagePkg,
 					MetadataType: pkg.PortageMetadataType,
 					Metadata: pkg.PortageMetadata{
@@ -95,6 +98,15 @@ func TestPortageCataloger(t *testing.T) {
 				t.Fatalf("unexpected package count: %d!=%d", len(actual), len(test.expected))
 			}
 
+			// the fixture image can be rebuilt, thus the layer ID of the license file will change
+			for _, p := range actual {
+				for _, l := range p.Licenses {
+					if l.Location != nil {
+						l.Location.FileSystemID = ""
+					}
+				}
+			}
+
 			// test remaining fields...
 			for _, d := range deep.Equal(actual, test.expected) {
 				t.Errorf("diff: %+v", d)
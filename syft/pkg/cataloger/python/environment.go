@@ -0,0 +1,130 @@
+package python
+
+import (
+	"bufio"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	// virtualEnvConfigName is the file at the root of a virtual environment (created by venv or virtualenv)
+	virtualEnvConfigName = "pyvenv.cfg"
+
+	// maxVirtualEnvDepth is how many directories above the site-packages directory the root of a virtual environment
+	// may be (e.g. "<venv>/lib/python3.11/site-packages", or "<venv>/Lib/site-packages" on windows)
+	maxVirtualEnvDepth = 3
+)
+
+var (
+	// libPythonPattern matches the directory of the standard library of an interpreter, which the site-packages
+	// directory is within (e.g. "lib/python3.11")
+	libPythonPattern = regexp.MustCompile(`^python(\d+(\.\d+)*)$`)
+
+	// pyenvVersionPattern matches the installation directory of an interpreter installed by pyenv (e.g.
+	// "/root/.pyenv/versions/3.11.4")
+	pyenvVersionPattern = regexp.MustCompile(`/\.pyenv/versions/(\d+\.\d+(\.\d+)?[a-z0-9]*)(/|$)`)
+)
+
+// pythonEnvironment is the python interpreter (or virtual environment) that a site-packages directory belongs to,
+// which tells apart the packages of an application from the packages of the system python when there are several
+// interpreters and virtual environments.
+type pythonEnvironment struct {
+	Version        string // the version of the interpreter (e.g. "3.11.4", or only "3.11" when unknown)
+	VirtualEnvPath string // the root of the virtual environment, if any
+}
+
+// environmentFinder finds the python environment of site-packages directories, remembering the environment of each
+// directory (since the packages of a directory share the environment).
+type environmentFinder struct {
+	resolver source.FileResolver
+	found    map[string]pythonEnvironment
+}
+
+func newEnvironmentFinder(resolver source.FileResolver) *environmentFinder {
+	return &environmentFinder{
+		resolver: resolver,
+		found:    make(map[string]pythonEnvironment),
+	}
+}
+
+// find returns the python environment of the given site-packages directory, where the location is a file within the
+// directory (used to find the virtual environment within the same layer).
+func (f *environmentFinder) find(location source.Location, sitePackagesPath string) pythonEnvironment {
+	sitePackagesPath = path.Clean(strings.ReplaceAll(sitePackagesPath, "\\", "/"))
+	if env, ok := f.found[sitePackagesPath]; ok {
+		return env
+	}
+
+	env := pythonEnvironment{
+		Version: interpreterVersionFromPath(sitePackagesPath),
+	}
+	dir := sitePackagesPath
+	for i := 0; i < maxVirtualEnvDepth && dir != "/" && dir != "."; i++ {
+		dir = path.Dir(dir)
+		cfg := path.Join(dir, virtualEnvConfigName)
+		if version, ok := f.virtualEnvVersion(location, cfg); ok {
+			env.VirtualEnvPath = dir
+			if version != "" {
+				env.Version = version
+			}
+			break
+		}
+	}
+
+	f.found[sitePackagesPath] = env
+	return env
+}
+
+// virtualEnvVersion returns the version of the interpreter of the virtual environment with the given config file,
+// returning false when there is no such file.
+func (f *environmentFinder) virtualEnvVersion(location source.Location, cfgPath string) (string, bool) {
+	cfgLocation := f.resolver.RelativeFileByPath(location, cfgPath)
+	if cfgLocation == nil {
+		return "", false
+	}
+	reader, err := f.resolver.FileContentsByLocation(*cfgLocation)
+	if err != nil {
+		log.WithFields("path", cfgLocation.RealPath, "error", err).Debug("unable to read python virtual environment config")
+		return "", true
+	}
+	defer internal.CloseAndLogError(reader, cfgLocation.RealPath)
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(reader)
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if ok {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.WithFields("path", cfgLocation.RealPath, "error", err).Debug("unable to read python virtual environment config")
+	}
+
+	// venv records "version", while virtualenv records "version_info" (e.g. "3.11.4.final.0")
+	if version := values["version"]; version != "" {
+		return version, true
+	}
+	if parts := strings.Split(values["version_info"], "."); len(parts) >= 3 {
+		return strings.Join(parts[:3], "."), true
+	}
+	return "", true
+}
+
+// interpreterVersionFromPath returns the version of the interpreter that the given site-packages directory belongs to,
+// as far as the path tells (e.g. "3.11" for "/usr/lib/python3.11/site-packages", or "3.11.4" for an interpreter
+// installed by pyenv).
+func interpreterVersionFromPath(sitePackagesPath string) string {
+	if match := pyenvVersionPattern.FindStringSubmatch(sitePackagesPath); match != nil {
+		return match[1]
+	}
+	if match := libPythonPattern.FindStringSubmatch(path.Base(path.Dir(sitePackagesPath))); match != nil {
+		return match[1]
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestPythonPackageCataloger_Environments(t *testing.T) {
+	fixture := "test-fixtures/environments"
+	resolver := source.NewMockResolverForPaths(
+		fixture+"/app/venv/lib/python3.11/site-packages/requests-2.31.0.dist-info/METADATA",
+		fixture+"/app/venv/pyvenv.cfg",
+		fixture+"/opt/tool/env/Lib/site-packages/click-8.1.7.dist-info/METADATA",
+		fixture+"/opt/tool/env/pyvenv.cfg",
+		fixture+"/root/.pyenv/versions/3.12.1/lib/python3.12/site-packages/pip-23.3.1.dist-info/METADATA",
+		fixture+"/usr/lib/python3.9/site-packages/six-1.16.0.dist-info/METADATA",
+	)
+
+	pkgs, _, err := NewPythonPackageCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	expected := map[string]pythonEnvironment{
+		"requests": {Version: "3.11.4", VirtualEnvPath: fixture + "/app/venv"},
+		"click":    {Version: "3.10.11", VirtualEnvPath: fixture + "/opt/tool/env"},
+		"pip":      {Version: "3.12.1"},
+		"six":      {Version: "3.9"},
+	}
+	actual := make(map[string]pythonEnvironment)
+	for _, p := range pkgs {
+		metadata := p.Metadata.(pkg.PythonPackageMetadata)
+		actual[p.Name] = pythonEnvironment{
+			Version:        metadata.PythonVersion,
+			VirtualEnvPath: metadata.VirtualEnvPath,
+		}
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestInterpreterVersionFromPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected string
+	}{
+		{path: "/usr/lib/python3.11/site-packages", expected: "3.11"},
+		{path: "/usr/local/lib/python2.7/dist-packages", expected: "2.7"},
+		{path: "/usr/lib/python3/dist-packages", expected: "3"},
+		{path: "/root/.pyenv/versions/3.12.1/lib/python3.12/site-packages", expected: "3.12.1"},
+		{path: "/root/.pyenv/versions/3.13.0rc1/lib/python3.13/site-packages", expected: "3.13.0rc1"},
+		{path: "/opt/app/Lib/site-packages", expected: ""},
+		{path: "site-packages", expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, interpreterVersionFromPath(test.path))
+		})
+	}
+}
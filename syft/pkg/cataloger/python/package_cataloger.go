@@ -44,9 +44,12 @@ func (c *PackageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package,
 		fileMatches = append(fileMatches, matches...)
 	}
 
+	// note: a single source may hold several interpreters and virtual environments
+	environments := newEnvironmentFinder(resolver)
+
 	var pkgs []pkg.Package
 	for _, location := range fileMatches {
-		p, err := c.catalogEggOrWheel(resolver, location)
+		p, err := c.catalogEggOrWheel(resolver, environments, location)
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to catalog python package=%+v: %w", location.RealPath, err)
 		}
@@ -57,30 +60,30 @@ func (c *PackageCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package,
 	return pkgs, nil, nil
 }
 
-// catalogEggOrWheel takes the primary metadata file reference and returns the python package it represents.
-func (c *PackageCataloger) catalogEggOrWheel(resolver source.FileResolver, metadataLocation source.Location) (*pkg.Package, error) {
+// catalogEggOrWheel takes the primary metadata file reference and returns the python package it represents, along with
+// the interpreter (and virtual environment) the package is installed for.
+func (c *PackageCataloger) catalogEggOrWheel(resolver source.FileResolver, environments *environmentFinder, metadataLocation source.Location) (*pkg.Package, error) {
 	metadata, sources, err := c.assembleEggOrWheelMetadata(resolver, metadataLocation)
 	if err != nil {
 		return nil, err
 	}
 
+	env := environments.find(metadataLocation, metadata.SitePackagesRootPath)
+	metadata.PythonVersion = env.Version
+	metadata.VirtualEnvPath = env.VirtualEnvPath
+
 	// This can happen for Python 2.7 where it is reported from an egg-info, but Python is
 	// the actual runtime, it isn't a "package". The special-casing here allows to skip it
 	if metadata.Name == "Python" {
 		return nil, nil
 	}
 
-	var licenses []string
-	if metadata.License != "" {
-		licenses = []string{metadata.License}
-	}
-
 	p := &pkg.Package{
 		Name:         metadata.Name,
 		Version:      metadata.Version,
 		FoundBy:      c.Name(),
 		Locations:    source.NewLocationSet(sources...),
-		Licenses:     licenses,
+		Licenses:     pkg.NewLicensesFromValues(metadata.License),
 		Language:     pkg.Python,
 		Type:         pkg.PythonPkg,
 		MetadataType: pkg.PythonPackageMetadataType,
@@ -42,7 +42,7 @@ func TestPythonPackageWheelCataloger(t *testing.T) {
 				Version:      "2.22.0",
 				Type:         pkg.PythonPkg,
 				Language:     pkg.Python,
-				Licenses:     []string{"Apache 2.0"},
+				Licenses:     pkg.NewLicensesFromValues("Apache 2.0"),
 				FoundBy:      "python-package-cataloger",
 				MetadataType: pkg.PythonPackageMetadataType,
 				Metadata: pkg.PythonPackageMetadata{
@@ -78,7 +78,7 @@ func TestPythonPackageWheelCataloger(t *testing.T) {
 				Version:      "2.6.1",
 				Type:         pkg.PythonPkg,
 				Language:     pkg.Python,
-				Licenses:     []string{"BSD License"},
+				Licenses:     pkg.NewLicensesFromValues("BSD License"),
 				FoundBy:      "python-package-cataloger",
 				MetadataType: pkg.PythonPackageMetadataType,
 				Metadata: pkg.PythonPackageMetadata{
@@ -113,7 +113,7 @@ func TestPythonPackageWheelCataloger(t *testing.T) {
 				Version:      "2.6.1",
 				Type:         pkg.PythonPkg,
 				Language:     pkg.Python,
-				Licenses:     []string{"BSD License"},
+				Licenses:     pkg.NewLicensesFromValues("BSD License"),
 				FoundBy:      "python-package-cataloger",
 				MetadataType: pkg.PythonPackageMetadataType,
 				Metadata: pkg.PythonPackageMetadata{
@@ -135,7 +135,7 @@ func TestPythonPackageWheelCataloger(t *testing.T) {
 				Version:      "2.22.0",
 				Type:         pkg.PythonPkg,
 				Language:     pkg.Python,
-				Licenses:     []string{"Apache 2.0"},
+				Licenses:     pkg.NewLicensesFromValues("Apache 2.0"),
 				FoundBy:      "python-package-cataloger",
 				MetadataType: pkg.PythonPackageMetadataType,
 				Metadata: pkg.PythonPackageMetadata{
@@ -0,0 +1,51 @@
+package cataloger
+
+import (
+	"sync"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// CustomTag selects the catalogers provided by library users (see Register and Config.Additional)
+const CustomTag = "custom"
+
+var registry = struct {
+	sync.RWMutex
+	catalogers []selectableCataloger
+}{}
+
+// Register adds a cataloger to run alongside the built-in catalogers for every source. The cataloger belongs to the
+// "custom" group and any of the given groups, and can be selected by name like any other cataloger. Registering a
+// cataloger with the same name as an already registered cataloger replaces it.
+func Register(c pkg.Cataloger, tags ...string) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	entry := customCataloger(c, tags...)
+	for i, existing := range registry.catalogers {
+		if existing.Name() == c.Name() {
+			registry.catalogers[i] = entry
+			return
+		}
+	}
+	registry.catalogers = append(registry.catalogers, entry)
+}
+
+// registeredCatalogers returns the registered catalogers followed by the additional catalogers of the configuration.
+func registeredCatalogers(cfg Config) []selectableCataloger {
+	registry.RLock()
+	catalogers := append([]selectableCataloger(nil), registry.catalogers...)
+	registry.RUnlock()
+
+	for _, c := range cfg.Additional {
+		catalogers = append(catalogers, customCataloger(c))
+	}
+	return catalogers
+}
+
+func customCataloger(c pkg.Cataloger, tags ...string) selectableCataloger {
+	return selectableCataloger{
+		Cataloger: c,
+		tags:      append([]string{ImageTag, DirectoryTag, CustomTag}, tags...),
+	}
+}
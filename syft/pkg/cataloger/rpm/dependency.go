@@ -0,0 +1,35 @@
+package rpm
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/dependency"
+)
+
+// dependencySpecification describes the capabilities that an RPM package provides (the package name, the provides
+// tag entries such as "libc.so.6()(64bit)", and the files owned by the package) and the capabilities it requires.
+// Requirements on features of rpm itself (e.g. "rpmlib(PayloadIsXz)") cannot be provided by a package and are ignored.
+func dependencySpecification(p pkg.Package) dependency.Specification {
+	metadata, ok := p.Metadata.(pkg.RpmMetadata)
+	if !ok {
+		return dependency.Specification{}
+	}
+
+	provides := []string{p.Name}
+	provides = append(provides, metadata.Provides...)
+	provides = append(provides, metadata.OwnedFiles()...)
+
+	var requires []dependency.Requirement
+	for _, capability := range metadata.Requires {
+		if strings.HasPrefix(capability, "rpmlib(") {
+			continue
+		}
+		requires = append(requires, dependency.Requirement{capability})
+	}
+
+	return dependency.Specification{
+		Provides: provides,
+		Requires: requires,
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/dependency"
 	"github.com/anchore/syft/syft/source"
 )
 
@@ -61,12 +62,14 @@ func (c *FileCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []
 		digestAlgorithm := getDigestAlgorithm(rpm.Header)
 		size, _ := rpm.Header.InstalledSize()
 		files, _ := rpm.Header.GetFiles()
+		provides, _ := rpm.Header.GetStrings(rpmutils.PROVIDENAME)
+		requires, _ := rpm.Header.GetStrings(rpmutils.REQUIRENAME)
 
 		p := pkg.Package{
 			Name:         nevra.Name,
 			Version:      nevra.Version,
 			FoundBy:      c.Name(),
-			Licenses:     licenses,
+			Licenses:     pkg.NewLicensesFromValues(licenses...),
 			Locations:    source.NewLocationSet(location),
 			Type:         pkg.RpmPkg,
 			MetadataType: pkg.RpmMetadataType,
@@ -80,6 +83,8 @@ func (c *FileCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []
 				Vendor:    vendor,
 				License:   strings.Join(licenses, " AND "),
 				Size:      int(size),
+				Provides:  provides,
+				Requires:  requires,
 				Files:     mapFiles(files, digestAlgorithm),
 			},
 		}
@@ -92,7 +97,7 @@ func (c *FileCataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []
 		}
 	}
 
-	return pkgs, nil, nil
+	return pkgs, dependency.Resolve(dependencySpecification, pkgs), nil
 }
 
 func getDigestAlgorithm(header *rpmutils.RpmHeader) string {
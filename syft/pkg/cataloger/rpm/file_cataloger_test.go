@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/anchore/syft/syft/file"
@@ -26,7 +27,7 @@ func TestParseRpmFiles(t *testing.T) {
 					FoundBy:      "rpm-file-cataloger",
 					Type:         pkg.RpmPkg,
 					MetadataType: pkg.RpmMetadataType,
-					Licenses:     []string{"MIT"},
+					Licenses:     pkg.NewLicensesFromValues("MIT"),
 					Metadata: pkg.RpmMetadata{
 						Name:      "abc",
 						Epoch:     intRef(0),
@@ -53,7 +54,7 @@ func TestParseRpmFiles(t *testing.T) {
 					FoundBy:      "rpm-file-cataloger",
 					Type:         pkg.RpmPkg,
 					MetadataType: pkg.RpmMetadataType,
-					Licenses:     []string{"Public Domain"},
+					Licenses:     pkg.NewLicensesFromValues("Public Domain"),
 					Metadata: pkg.RpmMetadata{
 						Name:      "zork",
 						Epoch:     intRef(0),
@@ -96,6 +97,15 @@ func TestParseRpmFiles(t *testing.T) {
 			require.NoError(t, err)
 
 			for _, a := range packages {
+				// the provided and required capabilities are generated by rpmbuild (e.g. shared library
+				// dependencies) and are not listed in the expected metadata; the package is always
+				// provided by name, however.
+				metadata := a.Metadata.(pkg.RpmMetadata)
+				assert.Contains(t, metadata.Provides, a.Name)
+				metadata.Provides = nil
+				metadata.Requires = nil
+				a.Metadata = metadata
+
 				e := test.expected[a.Name]
 				diffs := deep.Equal(e, a)
 				if len(diffs) > 0 {
@@ -61,6 +61,8 @@ func parseRpmDB(resolver source.FilePathResolver, dbLocation source.Location, re
 }
 
 func newPkg(resolver source.FilePathResolver, dbLocation source.Location, entry *rpmdb.PackageInfo) pkg.Package {
+	// TODO: go-rpmdb does not expose the provides and requires tags of DB entries (yet), so unlike RPM files, the
+	// dependency relationships between installed packages cannot be resolved.
 	metadata := pkg.RpmMetadata{
 		Name:            entry.Name,
 		Version:         entry.Version,
@@ -86,7 +88,7 @@ func newPkg(resolver source.FilePathResolver, dbLocation source.Location, entry
 	}
 
 	if entry.License != "" {
-		p.Licenses = append(p.Licenses, entry.License)
+		p.Licenses = pkg.NewLicensesFromValues(entry.License)
 	}
 
 	p.SetID()
@@ -73,7 +73,7 @@ func TestParseRpmDB(t *testing.T) {
 					FoundBy:      dbCatalogerName,
 					Type:         pkg.RpmPkg,
 					MetadataType: pkg.RpmMetadataType,
-					Licenses:     []string{"MIT"},
+					Licenses:     pkg.NewLicensesFromValues("MIT"),
 					Metadata: pkg.RpmMetadata{
 						Name:      "dive",
 						Epoch:     nil,
@@ -101,7 +101,7 @@ func TestParseRpmDB(t *testing.T) {
 					FoundBy:      dbCatalogerName,
 					Type:         pkg.RpmPkg,
 					MetadataType: pkg.RpmMetadataType,
-					Licenses:     []string{"MIT"},
+					Licenses:     pkg.NewLicensesFromValues("MIT"),
 					Metadata: pkg.RpmMetadata{
 						Name:      "dive",
 						Epoch:     nil,
@@ -0,0 +1,62 @@
+package ruby
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+func newGemfileLockPackage(name, version string, m *pkg.GemMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:      name,
+		Version:   version,
+		PURL:      packageURL(name, version, m),
+		Locations: source.NewLocationSet(locations...),
+		Language:  pkg.Ruby,
+		Type:      pkg.GemPkg,
+	}
+
+	if m != nil {
+		p.MetadataType = pkg.GemMetadataType
+		p.Metadata = *m
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func newGemspecPackage(m pkg.GemMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         m.Name,
+		Version:      m.Version,
+		PURL:         packageURL(m.Name, m.Version, &m),
+		Locations:    source.NewLocationSet(locations...),
+		Licenses:     pkg.NewLicensesFromValues(m.Licenses...),
+		Language:     pkg.Ruby,
+		Type:         pkg.GemPkg,
+		MetadataType: pkg.GemMetadataType,
+		Metadata:     m,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+func packageURL(name, version string, m *pkg.GemMetadata) string {
+	var vcsURL string
+	if m != nil && m.Source == pkg.GemGitSource && m.Remote != "" {
+		vcsURL = m.Remote
+		if !strings.HasPrefix(vcsURL, "git") {
+			vcsURL = "git+" + vcsURL
+		}
+		if m.Revision != "" {
+			vcsURL += "@" + m.Revision
+		}
+	}
+
+	return purl.Gem(name, version, vcsURL)
+}
@@ -2,26 +2,35 @@ package ruby
 
 import (
 	"bufio"
-	"io"
 	"strings"
 
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
-	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
 )
 
-// integrity check
-var _ common.ParserFn = parseGemFileLockEntries
+var _ generic.Parser = parseGemFileLockEntries
 
-var sectionsOfInterest = internal.NewStringSet("GEM")
+var sectionsOfInterest = internal.NewStringSet("GEM", "GIT", "PATH")
+
+// gemfileLockSpec is a single gem entry found under a "specs:" block along with the names of the gems it depends on.
+type gemfileLockSpec struct {
+	name         string
+	version      string
+	source       *pkg.GemMetadata
+	dependencies []string
+}
 
 // parseGemFileLockEntries is a parser function for Gemfile.lock contents, returning all Gems discovered.
-func parseGemFileLockEntries(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
-	pkgs := make([]*pkg.Package, 0)
+func parseGemFileLockEntries(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var specs []*gemfileLockSpec
 	scanner := bufio.NewScanner(reader)
 
 	var currentSection string
+	var currentSource *pkg.GemMetadata
+	var currentSpec *gemfileLockSpec
 
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -30,34 +39,113 @@ func parseGemFileLockEntries(_ string, reader io.Reader) ([]*pkg.Package, []arti
 		if len(line) > 1 && line[0] != ' ' {
 			// start of section
 			currentSection = sanitizedLine
+			currentSource = newSectionSource(currentSection)
+			currentSpec = nil
 			continue
 		} else if !sectionsOfInterest.Contains(currentSection) {
 			// skip this line, we're in the wrong section
 			continue
 		}
 
-		if isDependencyLine(line) {
+		switch indentation(line) {
+		case 2:
+			// source attributes (remote, revision, branch, etc.)
+			if currentSource != nil {
+				applySourceAttribute(currentSource, sanitizedLine)
+			}
+		case 4:
 			candidate := strings.Fields(sanitizedLine)
 			if len(candidate) != 2 {
+				currentSpec = nil
 				continue
 			}
-			pkgs = append(pkgs, &pkg.Package{
-				Name:     candidate[0],
-				Version:  strings.Trim(candidate[1], "()"),
-				Language: pkg.Ruby,
-				Type:     pkg.GemPkg,
-			})
+			currentSpec = &gemfileLockSpec{
+				name:    candidate[0],
+				version: strings.Trim(candidate[1], "()"),
+				source:  currentSource,
+			}
+			specs = append(specs, currentSpec)
+		case 6:
+			// dependencies of the last spec seen
+			candidate := strings.Fields(sanitizedLine)
+			if currentSpec == nil || len(candidate) == 0 {
+				continue
+			}
+			currentSpec.dependencies = append(currentSpec.dependencies, candidate[0])
 		}
 	}
 	if err := scanner.Err(); err != nil {
 		return nil, nil, err
 	}
-	return pkgs, nil, nil
+
+	pkgs := make([]pkg.Package, 0, len(specs))
+	pkgsByName := make(map[string][]pkg.Package)
+	for _, spec := range specs {
+		var metadata *pkg.GemMetadata
+		if spec.source != nil {
+			m := *spec.source
+			m.Name = spec.name
+			m.Version = spec.version
+			metadata = &m
+		}
+		p := newGemfileLockPackage(spec.name, spec.version, metadata, reader.Location)
+		pkgs = append(pkgs, p)
+		pkgsByName[p.Name] = append(pkgsByName[p.Name], p)
+	}
+
+	return pkgs, dependencyRelationships(specs, pkgs, pkgsByName), nil
+}
+
+// dependencyRelationships creates a relationship from each gem to every gem that declares it as a dependency within
+// the specs graph. Dependencies that are not themselves listed as a spec (e.g. bundler) are ignored.
+func dependencyRelationships(specs []*gemfileLockSpec, pkgs []pkg.Package, pkgsByName map[string][]pkg.Package) []artifact.Relationship {
+	var relationships []artifact.Relationship
+	for i, spec := range specs {
+		parent := pkgs[i]
+		for _, name := range spec.dependencies {
+			for _, dep := range pkgsByName[name] {
+				if dep.ID() == parent.ID() {
+					continue
+				}
+				relationships = append(relationships, artifact.Relationship{
+					From: dep,
+					To:   parent,
+					Type: artifact.DependencyOfRelationship,
+				})
+			}
+		}
+	}
+	return relationships
 }
 
-func isDependencyLine(line string) bool {
-	if len(line) < 5 {
-		return false
+// newSectionSource returns the source details to attach to all specs within the given section. Gems resolved from a
+// registry (the GEM section) do not carry any additional source information.
+func newSectionSource(section string) *pkg.GemMetadata {
+	switch section {
+	case "GIT":
+		return &pkg.GemMetadata{Source: pkg.GemGitSource}
+	case "PATH":
+		return &pkg.GemMetadata{Source: pkg.GemPathSource}
 	}
-	return strings.Count(line[:5], " ") == 4
+	return nil
+}
+
+func applySourceAttribute(m *pkg.GemMetadata, line string) {
+	fields := strings.SplitN(line, ":", 2)
+	if len(fields) != 2 {
+		return
+	}
+	value := strings.TrimSpace(fields[1])
+	switch fields[0] {
+	case "remote":
+		m.Remote = value
+	case "revision":
+		m.Revision = value
+	case "branch", "tag", "ref":
+		m.Ref = value
+	}
+}
+
+func indentation(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
 }
@@ -4,7 +4,13 @@ import (
 	"os"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
 )
 
 func TestParseGemfileLockEntries(t *testing.T) {
@@ -68,8 +74,10 @@ func TestParseGemfileLockEntries(t *testing.T) {
 		t.Fatalf("failed to open fixture: %+v", err)
 	}
 
-	// TODO: no relationships are under test yet
-	actual, _, err := parseGemFileLockEntries(fixture.Name(), fixture)
+	actual, relationships, err := parseGemFileLockEntries(nil, nil, source.LocationReadCloser{
+		Location:   source.NewLocation(fixture.Name()),
+		ReadCloser: fixture,
+	})
 	if err != nil {
 		t.Fatalf("failed to parse gemfile lock: %+v", err)
 	}
@@ -99,4 +107,81 @@ func TestParseGemfileLockEntries(t *testing.T) {
 			t.Errorf("bad package type (pkg=%+v): %+v", a.Name, a.Type)
 		}
 	}
+
+	// spot check the dependency graph: actionmailer (4.1.1) depends on actionpack, actionview, and mail
+	var actionmailerDeps []string
+	for _, r := range relationships {
+		require.Equal(t, artifact.DependencyOfRelationship, r.Type)
+		to, ok := r.To.(pkg.Package)
+		require.True(t, ok)
+		if to.Name != "actionmailer" {
+			continue
+		}
+		from, ok := r.From.(pkg.Package)
+		require.True(t, ok)
+		actionmailerDeps = append(actionmailerDeps, from.Name)
+	}
+	assert.Equal(t, []string{"actionpack", "actionview", "mail"}, actionmailerDeps)
+}
+
+func TestParseGemfileLockEntries_GitAndPathSources(t *testing.T) {
+	fixture := "test-fixtures/git-and-path/Gemfile.lock"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
+	activesupport := pkg.Package{
+		Name:         "activesupport",
+		Version:      "7.1.0.alpha",
+		PURL:         "pkg:gem/activesupport@7.1.0.alpha?vcs_url=git+https://github.com/rails/rails.git%405b4cb1aa5d7d3d1ca4cd3a1f2a3e4b7c6d8e9f0a",
+		Locations:    locations,
+		Language:     pkg.Ruby,
+		Type:         pkg.GemPkg,
+		MetadataType: pkg.GemMetadataType,
+		Metadata: pkg.GemMetadata{
+			Name:     "activesupport",
+			Version:  "7.1.0.alpha",
+			Source:   pkg.GemGitSource,
+			Remote:   "https://github.com/rails/rails.git",
+			Revision: "5b4cb1aa5d7d3d1ca4cd3a1f2a3e4b7c6d8e9f0a",
+			Ref:      "main",
+		},
+	}
+	localGem := pkg.Package{
+		Name:         "local-gem",
+		Version:      "0.1.0",
+		PURL:         "pkg:gem/local-gem@0.1.0",
+		Locations:    locations,
+		Language:     pkg.Ruby,
+		Type:         pkg.GemPkg,
+		MetadataType: pkg.GemMetadataType,
+		Metadata: pkg.GemMetadata{
+			Name:    "local-gem",
+			Version: "0.1.0",
+			Source:  pkg.GemPathSource,
+			Remote:  "vendor/gems/local-gem",
+		},
+	}
+	concurrentRuby := pkg.Package{
+		Name:      "concurrent-ruby",
+		Version:   "1.1.10",
+		PURL:      "pkg:gem/concurrent-ruby@1.1.10",
+		Locations: locations,
+		Language:  pkg.Ruby,
+		Type:      pkg.GemPkg,
+	}
+
+	expectedPkgs := []pkg.Package{activesupport, localGem, concurrentRuby}
+	expectedRelationships := []artifact.Relationship{
+		{
+			From: concurrentRuby,
+			To:   activesupport,
+			Type: artifact.DependencyOfRelationship,
+		},
+		{
+			From: activesupport,
+			To:   localGem,
+			Type: artifact.DependencyOfRelationship,
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseGemFileLockEntries, expectedPkgs, expectedRelationships)
 }
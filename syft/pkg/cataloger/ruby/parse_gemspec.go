@@ -4,7 +4,6 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
-	"io"
 	"regexp"
 	"strings"
 
@@ -13,11 +12,11 @@ import (
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/syft/artifact"
 	"github.com/anchore/syft/syft/pkg"
-	"github.com/anchore/syft/syft/pkg/cataloger/common"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
 )
 
-// integrity check
-var _ common.ParserFn = parseGemFileLockEntries
+var _ generic.Parser = parseGemSpecEntries
 
 type postProcessor func(string) []string
 
@@ -60,8 +59,8 @@ func processList(s string) []string {
 	return results
 }
 
-func parseGemSpecEntries(_ string, reader io.Reader) ([]*pkg.Package, []artifact.Relationship, error) {
-	var pkgs []*pkg.Package
+func parseGemSpecEntries(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var pkgs []pkg.Package
 	var fields = make(map[string]interface{})
 	scanner := bufio.NewScanner(reader)
 
@@ -96,15 +95,9 @@ func parseGemSpecEntries(_ string, reader io.Reader) ([]*pkg.Package, []artifact
 			return nil, nil, fmt.Errorf("unable to decode gem metadata: %w", err)
 		}
 
-		pkgs = append(pkgs, &pkg.Package{
-			Name:         metadata.Name,
-			Version:      metadata.Version,
-			Licenses:     metadata.Licenses,
-			Language:     pkg.Ruby,
-			Type:         pkg.GemPkg,
-			MetadataType: pkg.GemMetadataType,
-			Metadata:     metadata,
-		})
+		if metadata.Name != "" {
+			pkgs = append(pkgs, newGemspecPackage(metadata, reader.Location))
+		}
 	}
 
 	return pkgs, nil, nil
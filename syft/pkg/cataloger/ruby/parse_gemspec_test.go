@@ -1,20 +1,25 @@
 package ruby
 
 import (
-	"os"
 	"testing"
 
-	"github.com/go-test/deep"
-
 	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
 )
 
 func TestParseGemspec(t *testing.T) {
+	fixture := "test-fixtures/bundler.gemspec"
+
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+
 	var expectedPkg = pkg.Package{
 		Name:         "bundler",
 		Version:      "2.1.4",
+		PURL:         "pkg:gem/bundler@2.1.4",
+		Locations:    locations,
 		Type:         pkg.GemPkg,
-		Licenses:     []string{"MIT"},
+		Licenses:     pkg.NewLicensesFromValues("MIT"),
 		Language:     pkg.Ruby,
 		MetadataType: pkg.GemMetadataType,
 		Metadata: pkg.GemMetadata{
@@ -27,25 +32,5 @@ func TestParseGemspec(t *testing.T) {
 		},
 	}
 
-	fixture, err := os.Open("test-fixtures/bundler.gemspec")
-	if err != nil {
-		t.Fatalf("failed to open fixture: %+v", err)
-	}
-
-	// TODO: no relationships are under test yet
-	actual, _, err := parseGemSpecEntries(fixture.Name(), fixture)
-	if err != nil {
-		t.Fatalf("failed to parse gemspec: %+v", err)
-	}
-
-	if len(actual) != 1 {
-		for _, a := range actual {
-			t.Log("   ", a)
-		}
-		t.Fatalf("unexpected package count: %d!=1", len(actual))
-	}
-
-	for _, d := range deep.Equal(actual[0], &expectedPkg) {
-		t.Errorf("diff: %+v", d)
-	}
+	pkgtest.TestFileParser(t, fixture, parseGemSpecEntries, []pkg.Package{expectedPkg}, nil)
 }
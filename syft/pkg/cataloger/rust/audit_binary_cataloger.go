@@ -46,11 +46,12 @@ func (c *Cataloger) Catalog(resolver source.FileResolver) ([]pkg.Package, []arti
 
 		reader, err := unionreader.GetUnionReader(readerCloser)
 		if err != nil {
+			internal.CloseAndLogError(readerCloser, location.RealPath)
 			return nil, nil, err
 		}
 
 		versionInfos := scanFile(reader, location.RealPath)
-		internal.CloseAndLogError(readerCloser, location.RealPath)
+		internal.CloseAndLogError(reader, location.RealPath)
 
 		for _, versionInfo := range versionInfos {
 			pkgs = append(pkgs, buildRustPkgInfo(location, versionInfo)...)
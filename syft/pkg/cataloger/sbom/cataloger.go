@@ -0,0 +1,23 @@
+/*
+Package sbom provides a concrete Cataloger implementation for SBOM documents found within the source (e.g. embedded
+within a base image by its vendor).
+*/
+package sbom
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const catalogerName = "sbom-cataloger"
+
+// NewSBOMCataloger returns a new cataloger object for the packages described by SBOM documents within the source.
+func NewSBOMCataloger() *generic.Cataloger {
+	return generic.NewCataloger(catalogerName).
+		WithParserByGlobs(parseSBOM,
+			"**/*.syft.json",
+			"**/*.spdx.json",
+			"**/*.spdx",
+			"**/*.cdx.json",
+			"**/*.cdx.xml",
+		)
+}
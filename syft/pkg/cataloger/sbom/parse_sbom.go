@@ -0,0 +1,100 @@
+package sbom
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/formats/cyclonedxjson"
+	"github.com/anchore/syft/syft/formats/cyclonedxxml"
+	"github.com/anchore/syft/syft/formats/spdx22json"
+	"github.com/anchore/syft/syft/formats/spdx22tagvalue"
+	"github.com/anchore/syft/syft/formats/syftjson"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/sbom"
+	"github.com/anchore/syft/syft/source"
+)
+
+// formats are the SBOM formats that can be decoded, in the order they are identified by syft.Decode (which cannot be
+// used here since the syft package depends on the catalogers)
+var formats = []sbom.Format{
+	syftjson.Format(),
+	cyclonedxxml.Format(),
+	cyclonedxjson.Format(),
+	spdx22tagvalue.Format(),
+	spdx22json.Format(),
+}
+
+// parseSBOM returns the packages described by the SBOM document, each noting that it was described by the document
+// (rather than by the files that make up the package).
+func parseSBOM(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	by, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read SBOM: %w", err)
+	}
+
+	s, err := decode(by)
+	if err != nil {
+		return nil, nil, err
+	}
+	if s.Artifacts.PackageCatalog == nil {
+		return nil, nil, nil
+	}
+
+	var pkgs []pkg.Package
+	var relationships []artifact.Relationship
+	// the package IDs change with their locations, so the relationships between packages are remapped
+	ids := make(map[artifact.ID]pkg.Package)
+	for _, p := range s.Artifacts.PackageCatalog.Sorted() {
+		original := p.ID()
+
+		// the package is found by the SBOM document, not by the files noted within the document (which may not exist)
+		p.Locations = source.NewLocationSet(reader.Location)
+		p.Layer = nil
+		p.SetID()
+
+		ids[original] = p
+		pkgs = append(pkgs, p)
+		relationships = append(relationships, artifact.Relationship{
+			From: p,
+			To:   reader.Location.Coordinates,
+			Type: artifact.DescribedByRelationship,
+		})
+	}
+
+	for _, r := range s.Relationships {
+		from, ok := ids[r.From.ID()]
+		if !ok {
+			continue
+		}
+		to, ok := ids[r.To.ID()]
+		if !ok {
+			continue
+		}
+		relationships = append(relationships, artifact.Relationship{
+			From:        from,
+			To:          to,
+			Type:        r.Type,
+			Data:        r.Data,
+			Annotations: r.Annotations,
+		})
+	}
+
+	return pkgs, relationships, nil
+}
+
+func decode(by []byte) (*sbom.SBOM, error) {
+	for _, f := range formats {
+		if err := f.Validate(bytes.NewReader(by)); err != nil {
+			continue
+		}
+		s, err := f.Decode(bytes.NewReader(by))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s SBOM: %w", f.ID(), err)
+		}
+		return s, nil
+	}
+	return nil, fmt.Errorf("unable to identify SBOM format")
+}
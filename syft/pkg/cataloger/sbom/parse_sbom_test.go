@@ -0,0 +1,84 @@
+package sbom
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+func parseFixture(t *testing.T, fixture string) ([]pkg.Package, []artifact.Relationship, error) {
+	t.Helper()
+
+	f, err := os.Open(fixture)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, f.Close()) })
+
+	return parseSBOM(nil, nil, source.LocationReadCloser{
+		Location:   source.NewLocation(fixture),
+		ReadCloser: f,
+	})
+}
+
+func TestParseSBOM(t *testing.T) {
+	fixture := "test-fixtures/usr/share/sbom/vendor.cdx.json"
+	pkgs, relationships, err := parseFixture(t, fixture)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+
+	byName := make(map[string]pkg.Package)
+	for _, p := range pkgs {
+		byName[p.Name] = p
+		assert.Equal(t, []source.Location{source.NewLocation(fixture)}, p.Locations.ToSlice())
+		assert.NotEmpty(t, p.ID())
+	}
+	openssl, zlib := byName["openssl"], byName["zlib"]
+	assert.Equal(t, "3.0.7", openssl.Version)
+	assert.Equal(t, "pkg:generic/openssl@3.0.7", openssl.PURL)
+	assert.Equal(t, "1.2.13", zlib.Version)
+
+	coordinates := source.NewLocation(fixture).Coordinates
+	var describedBy []string
+	var dependencies []artifact.Relationship
+	for _, r := range relationships {
+		switch r.Type {
+		case artifact.DescribedByRelationship:
+			assert.Equal(t, coordinates, r.To)
+			describedBy = append(describedBy, r.From.(pkg.Package).Name)
+		case artifact.DependencyOfRelationship:
+			dependencies = append(dependencies, r)
+		default:
+			t.Errorf("unexpected relationship: %+v", r)
+		}
+	}
+	assert.ElementsMatch(t, []string{"openssl", "zlib"}, describedBy)
+
+	// the relationships between the packages refer to the packages found within the source
+	require.Len(t, dependencies, 1)
+	assert.Equal(t, openssl.ID(), dependencies[0].From.ID())
+	assert.Equal(t, zlib.ID(), dependencies[0].To.ID())
+}
+
+func TestParseSBOM_unknownFormat(t *testing.T) {
+	_, _, err := parseFixture(t, "test-fixtures/usr/share/sbom/bogus.spdx.json")
+	require.Error(t, err)
+}
+
+func TestSBOMCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/usr/share/sbom/vendor.cdx.json",
+		"test-fixtures/usr/share/sbom/bogus.spdx.json",
+	)
+
+	pkgs, _, err := NewSBOMCataloger().Catalog(resolver)
+	require.NoError(t, err)
+	require.Len(t, pkgs, 2)
+	for _, p := range pkgs {
+		assert.Equal(t, catalogerName, p.FoundBy)
+	}
+}
@@ -0,0 +1,63 @@
+package cataloger
+
+import (
+	"github.com/anchore/syft/syft/source"
+)
+
+// searchResolver limits the files that catalogers search for packages to a set of paths. Files outside of the set can
+// still be read when referenced by a file within it (e.g. with RelativeFileByPath), since packages found within the
+// set may be described by other files.
+type searchResolver struct {
+	source.FileResolver
+	paths map[string]struct{}
+}
+
+func newSearchResolver(resolver source.FileResolver, paths map[string]struct{}) source.FileResolver {
+	return &searchResolver{
+		FileResolver: resolver,
+		paths:        paths,
+	}
+}
+
+func (r *searchResolver) HasPath(path string) bool {
+	_, ok := r.paths[path]
+	return ok && r.FileResolver.HasPath(path)
+}
+
+func (r *searchResolver) FilesByPath(paths ...string) ([]source.Location, error) {
+	locations, err := r.FileResolver.FilesByPath(paths...)
+	return r.filter(locations), err
+}
+
+func (r *searchResolver) FilesByGlob(patterns ...string) ([]source.Location, error) {
+	locations, err := r.FileResolver.FilesByGlob(patterns...)
+	return r.filter(locations), err
+}
+
+func (r *searchResolver) FilesByMIMEType(types ...string) ([]source.Location, error) {
+	locations, err := r.FileResolver.FilesByMIMEType(types...)
+	return r.filter(locations), err
+}
+
+func (r *searchResolver) AllLocations() <-chan source.Location {
+	c := make(chan source.Location)
+	go func() {
+		defer close(c)
+		for location := range r.FileResolver.AllLocations() {
+			if _, ok := r.paths[location.RealPath]; ok {
+				c <- location
+			}
+		}
+	}()
+	return c
+}
+
+func (r *searchResolver) filter(locations []source.Location) []source.Location {
+	var filtered []source.Location
+	for _, location := range locations {
+		if _, ok := r.paths[location.RealPath]; ok {
+			filtered = append(filtered, location)
+		}
+	}
+	return filtered
+}
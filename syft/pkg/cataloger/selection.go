@@ -0,0 +1,366 @@
+package cataloger
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/aimodel"
+	"github.com/anchore/syft/syft/pkg/cataloger/alpm"
+	"github.com/anchore/syft/syft/pkg/cataloger/apkdb"
+	"github.com/anchore/syft/syft/pkg/cataloger/binary"
+	"github.com/anchore/syft/syft/pkg/cataloger/cms"
+	"github.com/anchore/syft/syft/pkg/cataloger/containerimage"
+	"github.com/anchore/syft/syft/pkg/cataloger/cpp"
+	"github.com/anchore/syft/syft/pkg/cataloger/dart"
+	"github.com/anchore/syft/syft/pkg/cataloger/deb"
+	"github.com/anchore/syft/syft/pkg/cataloger/dotnet"
+	"github.com/anchore/syft/syft/pkg/cataloger/extension"
+	"github.com/anchore/syft/syft/pkg/cataloger/firmware"
+	"github.com/anchore/syft/syft/pkg/cataloger/githubactions"
+	"github.com/anchore/syft/syft/pkg/cataloger/golang"
+	"github.com/anchore/syft/syft/pkg/cataloger/haskell"
+	"github.com/anchore/syft/syft/pkg/cataloger/java"
+	"github.com/anchore/syft/syft/pkg/cataloger/javascript"
+	"github.com/anchore/syft/syft/pkg/cataloger/kernel"
+	"github.com/anchore/syft/syft/pkg/cataloger/kubernetes"
+	"github.com/anchore/syft/syft/pkg/cataloger/php"
+	"github.com/anchore/syft/syft/pkg/cataloger/plugin"
+	"github.com/anchore/syft/syft/pkg/cataloger/portage"
+	"github.com/anchore/syft/syft/pkg/cataloger/python"
+	"github.com/anchore/syft/syft/pkg/cataloger/rpm"
+	"github.com/anchore/syft/syft/pkg/cataloger/ruby"
+	"github.com/anchore/syft/syft/pkg/cataloger/rust"
+	"github.com/anchore/syft/syft/pkg/cataloger/sbom"
+	"github.com/anchore/syft/syft/pkg/cataloger/swift"
+	"github.com/anchore/syft/syft/pkg/cataloger/terraform"
+	"github.com/anchore/syft/syft/pkg/cataloger/wasm"
+)
+
+// tags that describe groups of catalogers that can be selected together
+const (
+	// ImageTag selects the catalogers used by default for container images
+	ImageTag = "image"
+	// DirectoryTag selects the catalogers used by default for directories
+	DirectoryTag = "directory"
+	// InstalledTag selects the catalogers that find packages as they are installed (e.g. package databases)
+	InstalledTag = "installed"
+	// DeclaredTag selects the catalogers that find packages declared to be installed (e.g. lock files and manifests)
+	DeclaredTag = "declared"
+	// OSTag selects the catalogers of operating system packages
+	OSTag = "os"
+	// LanguageTag selects the catalogers of language ecosystem packages
+	LanguageTag = "language"
+	// BinaryTag selects the catalogers that find packages from the contents of binary files
+	BinaryTag = "binary"
+	// PluginTag selects the catalogers implemented by plugins (see the plugin package)
+	PluginTag = "plugin"
+)
+
+type catalogerEntry struct {
+	constructor func(Config) pkg.Cataloger
+	tags        []string
+}
+
+// catalogerEntries are all implemented catalogers along with the groups they belong to (besides the "all" group).
+var catalogerEntries = []catalogerEntry{
+	{
+		constructor: func(Config) pkg.Cataloger { return alpm.NewAlpmdbCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, OSTag, "alpm"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return ruby.NewGemFileLockCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "ruby"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return ruby.NewGemSpecCataloger() },
+		tags:        []string{ImageTag, InstalledTag, LanguageTag, "ruby"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return python.NewPythonIndexCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "python"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return python.NewPythonPackageCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, LanguageTag, "python"},
+	},
+	{
+		constructor: func(cfg Config) pkg.Cataloger { return javascript.NewJavascriptLockCataloger(cfg.JavaScript) },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "javascript"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return javascript.NewJavascriptPackageCataloger() },
+		tags:        []string{ImageTag, InstalledTag, LanguageTag, "javascript"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return deb.NewDpkgdbCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, OSTag, "deb"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return rpm.NewRpmdbCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, OSTag, "rpm"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return rpm.NewFileCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, OSTag, "rpm"},
+	},
+	{
+		constructor: func(cfg Config) pkg.Cataloger { return java.NewJavaCataloger(cfg.Java()) },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, LanguageTag, "java"},
+	},
+	{
+		constructor: func(cfg Config) pkg.Cataloger { return java.NewJenkinsPluginCataloger(cfg.Java()) },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, LanguageTag, "java", "jenkins"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return java.NewJavaPomCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "java"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return apkdb.NewApkdbCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, OSTag, "apk"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return golang.NewGoModuleBinaryCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, LanguageTag, BinaryTag, "go"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return golang.NewGoModFileCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "go"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return rust.NewCargoLockCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "rust"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return rust.NewRustAuditBinaryCataloger() },
+		tags:        []string{InstalledTag, LanguageTag, BinaryTag, "rust"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return dart.NewPubspecLockCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "dart"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return dotnet.NewDotnetDepsCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, LanguageTag, "dotnet"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return php.NewPHPComposerInstalledCataloger() },
+		tags:        []string{ImageTag, InstalledTag, LanguageTag, "php"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return php.NewPHPComposerLockCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "php"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return swift.NewCocoapodsCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "swift"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return cpp.NewConanCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "cpp"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return portage.NewPortageCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, OSTag, "portage"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return haskell.NewHackageCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, LanguageTag, "haskell"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return kernel.NewLinuxKernelCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, OSTag, BinaryTag, "linux-kernel"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return firmware.NewFirmwareCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, BinaryTag, "firmware"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return wasm.NewWasmCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, BinaryTag, "wasm"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return kubernetes.NewHelmChartCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, DeclaredTag, "helm"},
+	},
+	{
+		// note: only for directories, since every YAML file is considered
+		constructor: func(Config) pkg.Cataloger { return kubernetes.NewKubernetesManifestCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, "kubernetes"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return terraform.NewTerraformCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, "terraform"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return githubactions.NewGithubActionsCataloger() },
+		tags:        []string{DirectoryTag, DeclaredTag, "github-actions"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return extension.NewVSCodeExtensionCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, "vscode"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return extension.NewBrowserExtensionCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, "browser"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return cms.NewWordpressCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, LanguageTag, "php", "wordpress"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return cms.NewDrupalCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, LanguageTag, "php", "drupal"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return aimodel.NewAIModelCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, "ai-model"},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return containerimage.NewContainerImageCataloger() },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, "container-image"},
+	},
+	{
+		// note: only for images, since the SBOMs written by syft into a scanned directory would otherwise be cataloged
+		constructor: func(Config) pkg.Cataloger { return sbom.NewSBOMCataloger() },
+		tags:        []string{ImageTag, InstalledTag, "sbom"},
+	},
+	{
+		constructor: func(cfg Config) pkg.Cataloger { return binary.NewCataloger(cfg.Binary) },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, BinaryTag},
+	},
+	{
+		constructor: func(Config) pkg.Cataloger { return binary.NewStaticLibraryCataloger(binary.DefaultSignatures) },
+		tags:        []string{ImageTag, DirectoryTag, InstalledTag, BinaryTag},
+	},
+}
+
+type selectableCataloger struct {
+	pkg.Cataloger
+	tags []string
+}
+
+// matches indicates if the given group tag or cataloger name refers to this cataloger.
+func (c selectableCataloger) matches(token string) bool {
+	if token == AllCatalogersPattern {
+		return true
+	}
+	name := c.Name()
+	if token == name || token+"-cataloger" == name {
+		return true
+	}
+	for _, tag := range c.tags {
+		if token == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func selectableCatalogers(cfg Config) []selectableCataloger {
+	var catalogers []selectableCataloger
+	for _, entry := range catalogerEntries {
+		catalogers = append(catalogers, selectableCataloger{
+			Cataloger: entry.constructor(cfg),
+			tags:      entry.tags,
+		})
+	}
+
+	// custom and plugin catalogers run alongside the built-in catalogers for every source
+	catalogers = append(catalogers, registeredCatalogers(cfg)...)
+
+	plugins, err := plugin.Discover(cfg.Plugins)
+	if err != nil {
+		log.Warnf("unable to discover cataloger plugins: %+v", err)
+	}
+	for _, p := range plugins {
+		catalogers = append(catalogers, selectableCataloger{
+			Cataloger: p,
+			tags:      []string{ImageTag, DirectoryTag, PluginTag},
+		})
+	}
+	return catalogers
+}
+
+// taggedCatalogers returns the catalogers belonging to the given group.
+func taggedCatalogers(cfg Config, tag string) []pkg.Cataloger {
+	var catalogers []pkg.Cataloger
+	for _, c := range selectableCatalogers(cfg) {
+		if c.matches(tag) {
+			catalogers = append(catalogers, c.Cataloger)
+		}
+	}
+	return catalogers
+}
+
+// SelectCatalogers returns the catalogers described by the selection expressions of the given configuration. Each
+// expression is a comma-separated list of group tags (e.g. "image", "os", "python") or cataloger names (with or without
+// the "-cataloger" suffix). Groups and names without a prefix replace the default group as the base selection, while
+// "+" and "-" prefixes add to or remove from the selection (applied in order). For example "image,+go-mod-file,-binary"
+// selects the image catalogers and the go.mod cataloger, without any catalogers of binary files.
+func SelectCatalogers(cfg Config, defaultTag string) ([]pkg.Cataloger, error) {
+	catalogers := selectableCatalogers(cfg)
+
+	type operation struct {
+		remove bool
+		token  string
+	}
+	var base []string
+	var operations []operation
+	for _, expression := range cfg.Select {
+		for _, token := range strings.Split(expression, ",") {
+			token = strings.ToLower(strings.TrimSpace(token))
+			switch {
+			case token == "":
+				continue
+			case strings.HasPrefix(token, "+"):
+				operations = append(operations, operation{token: strings.TrimSpace(token[1:])})
+			case strings.HasPrefix(token, "-"):
+				operations = append(operations, operation{remove: true, token: strings.TrimSpace(token[1:])})
+			default:
+				base = append(base, token)
+			}
+		}
+	}
+	if len(base) == 0 {
+		base = []string{defaultTag}
+	}
+
+	selected := make([]bool, len(catalogers))
+	for _, token := range base {
+		if err := applySelection(catalogers, selected, token, true); err != nil {
+			return nil, err
+		}
+	}
+	for _, op := range operations {
+		if err := applySelection(catalogers, selected, op.token, !op.remove); err != nil {
+			return nil, err
+		}
+	}
+
+	var results []pkg.Cataloger
+	for i, c := range catalogers {
+		if !selected[i] {
+			log.Debugf("skipping cataloger %q", c.Name())
+			continue
+		}
+		results = append(results, c.Cataloger)
+	}
+	return results, nil
+}
+
+func applySelection(catalogers []selectableCataloger, selected []bool, token string, value bool) error {
+	found := false
+	for i, c := range catalogers {
+		if c.matches(token) {
+			selected[i] = value
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("no catalogers match the selection %q", token)
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+/*
+Package terraform provides a concrete Cataloger implementation for the providers and modules of Terraform configurations.
+*/
+package terraform
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const catalogerName = "terraform-cataloger"
+
+// NewTerraformCataloger returns a new cataloger object for the providers locked by the dependency lock files of
+// Terraform configurations, and the modules installed by "terraform init".
+func NewTerraformCataloger() *generic.Cataloger {
+	return generic.NewCataloger(catalogerName).
+		WithParserByGlobs(parseTerraformLock, "**/.terraform.lock.hcl").
+		WithParserByGlobs(parseTerraformModules, "**/.terraform/modules/modules.json")
+}
@@ -0,0 +1,34 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestTerraformCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/.terraform.lock.hcl",
+		"test-fixtures/.terraform/modules/modules.json",
+	)
+
+	pkgs, _, err := NewTerraformCataloger().Catalog(resolver)
+	require.NoError(t, err)
+
+	var found []string
+	for _, p := range pkgs {
+		assert.Equal(t, catalogerName, p.FoundBy)
+		found = append(found, p.Name+"@"+p.Version)
+	}
+	assert.ElementsMatch(t, []string{
+		"registry.terraform.io/hashicorp/aws@5.31.0",
+		"registry.terraform.io/hashicorp/random@3.6.0",
+		"registry.opentofu.org/integrations/github@5.42.0",
+		"registry.terraform.io/terraform-aws-modules/vpc/aws@5.4.0",
+		"github.com/example/terraform-dns@v1.2.0",
+		"github.com/example/terraform-tags@",
+	}, found)
+}
@@ -0,0 +1,65 @@
+package terraform
+
+import (
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+func newProviderPackage(metadata pkg.TerraformProviderMetadata, locations ...source.Location) pkg.Package {
+	namespace, name := splitAddress(metadata.Source)
+
+	p := pkg.Package{
+		Name:         metadata.Source,
+		Version:      metadata.Version,
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.TerraformPkg,
+		PURL:         purl.New(purl.TypeGeneric, namespace, name, metadata.Version, nil, ""),
+		MetadataType: pkg.TerraformProviderMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// newModulePackage creates a package for an installed module, which is named by the source address of the module
+// (without the ref of modules fetched from version control, which is the version instead).
+func newModulePackage(metadata pkg.TerraformModuleMetadata, locations ...source.Location) pkg.Package {
+	name, ref := parseModuleSource(metadata.Source)
+	version := metadata.Version
+	var qualifiers purl.Qualifiers
+	if version == "" {
+		version = ref
+		if ref != "" {
+			qualifiers = purl.Qualifiers{purl.VCSURLQualifier: metadata.Source}
+		}
+	}
+	namespace, base := splitAddress(name)
+
+	p := pkg.Package{
+		Name:         name,
+		Version:      version,
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.TerraformPkg,
+		PURL:         purl.New(purl.TypeGeneric, namespace, base, version, qualifiers, ""),
+		MetadataType: pkg.TerraformModuleMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// splitAddress splits the given address into the namespace (every element but the last) and the name (the last
+// element), as with go modules (e.g. "registry.terraform.io/hashicorp" and "aws").
+func splitAddress(address string) (string, string) {
+	if i := strings.LastIndex(address, "/"); i >= 0 {
+		return address[:i], address[i+1:]
+	}
+	return "", address
+}
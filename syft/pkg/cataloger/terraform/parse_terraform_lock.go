@@ -0,0 +1,104 @@
+package terraform
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseTerraformLock
+
+// parseTerraformLock parses the dependency lock file of a Terraform configuration. The lock file is written by
+// "terraform init" (and is not meant to be edited), so rather than parsing any HCL, the fixed layout of the file is
+// relied upon:
+//
+//	provider "registry.terraform.io/hashicorp/aws" {
+//	  version     = "5.31.0"
+//	  constraints = "~> 5.0"
+//	  hashes = [
+//	    "h1:...",
+//	    "zh:...",
+//	  ]
+//	}
+func parseTerraformLock(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var pkgs []pkg.Package
+	var provider *pkg.TerraformProviderMetadata
+	var inHashes bool
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "//"):
+			continue
+		case provider == nil:
+			// e.g. provider "registry.terraform.io/hashicorp/aws" {
+			fields := strings.Fields(line)
+			if len(fields) != 3 || fields[0] != "provider" || fields[2] != "{" {
+				return nil, nil, fmt.Errorf("unexpected line %d of terraform lock file: %q", lineNumber, line)
+			}
+			address, err := strconv.Unquote(fields[1])
+			if err != nil {
+				return nil, nil, fmt.Errorf("unexpected provider address on line %d of terraform lock file: %w", lineNumber, err)
+			}
+			provider = &pkg.TerraformProviderMetadata{Source: address}
+		case inHashes:
+			if line == "]" {
+				inHashes = false
+				continue
+			}
+			provider.Hashes = append(provider.Hashes, unquoteValues(line)...)
+		case line == "}":
+			pkgs = append(pkgs, newProviderPackage(*provider, reader.Location))
+			provider = nil
+		default:
+			key, value, ok := strings.Cut(line, "=")
+			if !ok {
+				return nil, nil, fmt.Errorf("unexpected line %d of terraform lock file: %q", lineNumber, line)
+			}
+			value = strings.TrimSpace(value)
+			switch strings.TrimSpace(key) {
+			case "version":
+				provider.Version = unquote(value)
+			case "constraints":
+				provider.Constraints = unquote(value)
+			case "hashes":
+				// either a list over several lines, or (when written by hand) within a single line
+				inHashes = !strings.HasSuffix(value, "]")
+				provider.Hashes = append(provider.Hashes, unquoteValues(strings.Trim(value, "[]"))...)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read terraform lock file: %w", err)
+	}
+	if provider != nil {
+		return nil, nil, fmt.Errorf("unterminated provider block %q in terraform lock file", provider.Source)
+	}
+
+	return pkgs, nil, nil
+}
+
+// unquoteValues returns the quoted strings of a comma-separated list of values (e.g. `"h1:...", "zh:...",`).
+func unquoteValues(s string) []string {
+	var values []string
+	for _, field := range strings.Split(s, ",") {
+		if value := unquote(strings.TrimSpace(field)); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+func unquote(s string) string {
+	if value, err := strconv.Unquote(s); err == nil {
+		return value
+	}
+	return s
+}
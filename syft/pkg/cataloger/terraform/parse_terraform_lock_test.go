@@ -0,0 +1,91 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseTerraformLock(t *testing.T) {
+	fixture := "test-fixtures/.terraform.lock.hcl"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expected := []pkg.Package{
+		{
+			Name:         "registry.terraform.io/hashicorp/aws",
+			Version:      "5.31.0",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			PURL:         "pkg:generic/registry.terraform.io/hashicorp/aws@5.31.0",
+			MetadataType: pkg.TerraformProviderMetadataType,
+			Metadata: pkg.TerraformProviderMetadata{
+				Source:      "registry.terraform.io/hashicorp/aws",
+				Version:     "5.31.0",
+				Constraints: ">= 4.0.0, ~> 5.0",
+				Hashes: []string{
+					"h1:ltxyuBWIy9cq0kIKDJH1jeWJy/y7XJLjS4QrsQK4plA=",
+					"zh:0cdb9c2083bf0902442384f7309367791e4640581652dda456f2d6d7abf0de8d",
+					"zh:2fe4884cb9642f48a5889f8dff8f5f511418a18537a9dfa77ada3bcdad391e4e",
+				},
+			},
+		},
+		{
+			Name:         "registry.terraform.io/hashicorp/random",
+			Version:      "3.6.0",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			PURL:         "pkg:generic/registry.terraform.io/hashicorp/random@3.6.0",
+			MetadataType: pkg.TerraformProviderMetadataType,
+			Metadata: pkg.TerraformProviderMetadata{
+				Source:  "registry.terraform.io/hashicorp/random",
+				Version: "3.6.0",
+				Hashes: []string{
+					"h1:R5Ucn26riKIEijcsiOMBR3uOAjuOMfI1x7XvH4P6B1w=",
+				},
+			},
+		},
+		{
+			Name:         "registry.opentofu.org/integrations/github",
+			Version:      "5.42.0",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			PURL:         "pkg:generic/registry.opentofu.org/integrations/github@5.42.0",
+			MetadataType: pkg.TerraformProviderMetadataType,
+			Metadata: pkg.TerraformProviderMetadata{
+				Source:      "registry.opentofu.org/integrations/github",
+				Version:     "5.42.0",
+				Constraints: "~> 5.0",
+				Hashes: []string{
+					"h1:vhwR1fOvKJQ9Gsm7+Ygu5Q3a5JBxFEsBLhnVVtfnmWY=",
+				},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseTerraformLock, expected, nil)
+}
+
+func TestParseTerraformLock_invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "not a provider block",
+			data: "resource \"aws_instance\" \"web\" {\n}\n",
+		},
+		{
+			name: "unterminated provider block",
+			data: "provider \"registry.terraform.io/hashicorp/aws\" {\n  version = \"5.31.0\"\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pkgtest.NewCatalogTester().
+				FromString(".terraform.lock.hcl", test.data).
+				WithError().
+				TestParser(t, parseTerraformLock)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package terraform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+var _ generic.Parser = parseTerraformModules
+
+// modulesManifest is the manifest of the modules installed by "terraform init" (.terraform/modules/modules.json).
+type modulesManifest struct {
+	Modules []struct {
+		Key     string `json:"Key"`
+		Source  string `json:"Source"`
+		Version string `json:"Version"`
+		Dir     string `json:"Dir"`
+	} `json:"Modules"`
+}
+
+// parseTerraformModules parses the manifest of the installed modules of a Terraform configuration, which includes the
+// root module (without a key) and the local modules of the configuration (with a relative source path), which are not
+// dependencies of the configuration and are skipped.
+func parseTerraformModules(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	var manifest modulesManifest
+	if err := json.NewDecoder(reader).Decode(&manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse terraform modules manifest: %w", err)
+	}
+
+	var pkgs []pkg.Package
+	for _, m := range manifest.Modules {
+		if m.Key == "" || m.Source == "" || isLocalSource(m.Source) {
+			continue
+		}
+		pkgs = append(pkgs, newModulePackage(pkg.TerraformModuleMetadata{
+			Key:     m.Key,
+			Source:  m.Source,
+			Version: m.Version,
+			Dir:     m.Dir,
+		}, reader.Location))
+	}
+	return pkgs, nil, nil
+}
+
+func isLocalSource(address string) bool {
+	return strings.HasPrefix(address, "./") || strings.HasPrefix(address, "../")
+}
+
+// parseModuleSource returns the address of a module source without the scheme, forced getter (e.g. "git::"), ref, or
+// subdirectory (e.g. "github.com/org/network" for "git::https://github.com/org/network.git//vpc?ref=v1.2.0"), along with
+// the ref to fetch from version control (if any).
+func parseModuleSource(address string) (string, string) {
+	if i := strings.Index(address, "::"); i >= 0 {
+		address = address[i+2:]
+	}
+
+	var ref string
+	if i := strings.Index(address, "?"); i >= 0 {
+		if query, err := url.ParseQuery(address[i+1:]); err == nil {
+			ref = query.Get("ref")
+		}
+		address = address[:i]
+	}
+
+	if i := strings.Index(address, "://"); i >= 0 {
+		address = address[i+3:]
+	}
+	// e.g. the scp-like address "git@github.com:org/network.git"
+	if user, rest, ok := strings.Cut(address, "@"); ok && !strings.Contains(user, "/") {
+		address = strings.Replace(rest, ":", "/", 1)
+	}
+	if i := strings.Index(address, "//"); i >= 0 {
+		address = address[:i]
+	}
+	address = strings.TrimSuffix(address, ".git")
+
+	return address, ref
+}
@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseTerraformModules(t *testing.T) {
+	fixture := "test-fixtures/.terraform/modules/modules.json"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	expected := []pkg.Package{
+		{
+			Name:         "registry.terraform.io/terraform-aws-modules/vpc/aws",
+			Version:      "5.4.0",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			PURL:         "pkg:generic/registry.terraform.io/terraform-aws-modules/vpc/aws@5.4.0",
+			MetadataType: pkg.TerraformModuleMetadataType,
+			Metadata: pkg.TerraformModuleMetadata{
+				Key:     "network.vpc",
+				Source:  "registry.terraform.io/terraform-aws-modules/vpc/aws",
+				Version: "5.4.0",
+				Dir:     ".terraform/modules/network.vpc",
+			},
+		},
+		{
+			Name:         "github.com/example/terraform-dns",
+			Version:      "v1.2.0",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			PURL:         "pkg:generic/github.com/example/terraform-dns@v1.2.0?vcs_url=git::https://github.com/example/terraform-dns.git//zones%3Fref=v1.2.0",
+			MetadataType: pkg.TerraformModuleMetadataType,
+			Metadata: pkg.TerraformModuleMetadata{
+				Key:    "dns",
+				Source: "git::https://github.com/example/terraform-dns.git//zones?ref=v1.2.0",
+				Dir:    ".terraform/modules/dns/zones",
+			},
+		},
+		{
+			Name:         "github.com/example/terraform-tags",
+			Locations:    locations,
+			Type:         pkg.TerraformPkg,
+			PURL:         "pkg:generic/github.com/example/terraform-tags",
+			MetadataType: pkg.TerraformModuleMetadataType,
+			Metadata: pkg.TerraformModuleMetadata{
+				Key:    "tags",
+				Source: "git@github.com:example/terraform-tags.git",
+				Dir:    ".terraform/modules/tags",
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseTerraformModules, expected, nil)
+}
+
+func Test_parseModuleSource(t *testing.T) {
+	tests := []struct {
+		source          string
+		expectedAddress string
+		expectedRef     string
+	}{
+		{
+			source:          "terraform-aws-modules/vpc/aws",
+			expectedAddress: "terraform-aws-modules/vpc/aws",
+		},
+		{
+			source:          "github.com/example/network?ref=v1.2.0",
+			expectedAddress: "github.com/example/network",
+			expectedRef:     "v1.2.0",
+		},
+		{
+			source:          "git::https://example.com/network.git//modules/vpc?ref=51d462976d84fdea54b47d80dcabbf680badcdb8",
+			expectedAddress: "example.com/network",
+			expectedRef:     "51d462976d84fdea54b47d80dcabbf680badcdb8",
+		},
+		{
+			source:          "git::ssh://git@example.com/network.git?ref=main",
+			expectedAddress: "example.com/network",
+			expectedRef:     "main",
+		},
+		{
+			source:          "git@github.com:example/network.git",
+			expectedAddress: "github.com/example/network",
+		},
+		{
+			source:          "s3::https://s3-eu-west-1.amazonaws.com/modules/vpc.zip",
+			expectedAddress: "s3-eu-west-1.amazonaws.com/modules/vpc.zip",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.source, func(t *testing.T) {
+			address, ref := parseModuleSource(test.source)
+			assert.Equal(t, test.expectedAddress, address)
+			assert.Equal(t, test.expectedRef, ref)
+		})
+	}
+}
@@ -0,0 +1,17 @@
+/*
+Package wasm provides a concrete Cataloger implementation for WebAssembly modules and components.
+*/
+package wasm
+
+import (
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+)
+
+const catalogerName = "wasm-cataloger"
+
+// NewWasmCataloger returns a new cataloger object for WebAssembly binaries, describing the toolchain they were built
+// with and (for components) the WIT packages they import.
+func NewWasmCataloger() *generic.Cataloger {
+	return generic.NewCataloger(catalogerName).
+		WithParserByGlobs(parseWasmFile, "**/*.wasm")
+}
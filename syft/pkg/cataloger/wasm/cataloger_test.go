@@ -0,0 +1,110 @@
+package wasm
+
+import (
+	"testing"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestWasmCataloger(t *testing.T) {
+	resolver := source.NewMockResolverForPaths(
+		"test-fixtures/app/rust.wasm",
+		"test-fixtures/app/emscripten.wasm",
+		"test-fixtures/app/component.wasm",
+		"test-fixtures/not-wasm.wasm",
+	)
+
+	rust := pkg.Package{
+		Name:         "hello_rust",
+		Locations:    source.NewLocationSet(source.NewLocation("test-fixtures/app/rust.wasm")),
+		Type:         pkg.WasmPkg,
+		PURL:         "pkg:generic/hello_rust",
+		MetadataType: pkg.WasmMetadataType,
+		Metadata: pkg.WasmMetadata{
+			Name:        "hello_rust",
+			Format:      "module",
+			Languages:   []pkg.WasmProducer{{Name: "Rust"}},
+			ProcessedBy: []pkg.WasmProducer{{Name: "rustc", Version: "1.74.0 (79e9716c9 2023-11-13)"}},
+			Imports:     []string{"wasi_snapshot_preview1"},
+		},
+	}
+
+	emscriptenLocations := source.NewLocationSet(source.NewLocation("test-fixtures/app/emscripten.wasm"))
+	emscripten := pkg.Package{
+		Name:         "emscripten",
+		Locations:    emscriptenLocations,
+		Type:         pkg.WasmPkg,
+		PURL:         "pkg:generic/emscripten",
+		MetadataType: pkg.WasmMetadataType,
+		Metadata: pkg.WasmMetadata{
+			Format:      "module",
+			Languages:   []pkg.WasmProducer{{Name: "C11"}},
+			ProcessedBy: []pkg.WasmProducer{{Name: "clang", Version: "18.0.0git"}},
+			SDKs:        []pkg.WasmProducer{{Name: "Emscripten", Version: "3.1.45"}},
+			Imports:     []string{"env", "wasi_snapshot_preview1"},
+		},
+	}
+	sdk := pkg.Package{
+		Name:      "Emscripten",
+		Version:   "3.1.45",
+		Locations: emscriptenLocations,
+		Type:      pkg.WasmPkg,
+		PURL:      "pkg:generic/Emscripten@3.1.45",
+	}
+
+	componentLocations := source.NewLocationSet(source.NewLocation("test-fixtures/app/component.wasm"))
+	component := pkg.Package{
+		Name:         "hello-component",
+		Locations:    componentLocations,
+		Type:         pkg.WasmPkg,
+		PURL:         "pkg:generic/hello-component",
+		MetadataType: pkg.WasmMetadataType,
+		Metadata: pkg.WasmMetadata{
+			Name:   "hello-component",
+			Format: "component",
+			ProcessedBy: []pkg.WasmProducer{
+				{Name: "wit-component", Version: "0.18.2"},
+				{Name: "cargo-component", Version: "0.5.0"},
+			},
+			Imports: []string{
+				"wasi:cli/environment@0.2.0",
+				"wasi:io/streams@0.2.0",
+				"wasi:cli/stdout@0.2.0",
+				"log",
+			},
+		},
+	}
+	cli := pkg.Package{
+		Name:      "wasi:cli",
+		Version:   "0.2.0",
+		Locations: componentLocations,
+		Type:      pkg.WasmPkg,
+		PURL:      "pkg:generic/wasi/cli@0.2.0",
+	}
+	io := pkg.Package{
+		Name:      "wasi:io",
+		Version:   "0.2.0",
+		Locations: componentLocations,
+		Type:      pkg.WasmPkg,
+		PURL:      "pkg:generic/wasi/io@0.2.0",
+	}
+
+	// note: the cataloger only sets FoundBy on the packages it returns, not on the packages of the relationships
+	var expected []pkg.Package
+	for _, p := range []pkg.Package{rust, emscripten, sdk, component, cli, io} {
+		p.FoundBy = catalogerName
+		expected = append(expected, p)
+	}
+
+	pkgtest.NewCatalogTester().
+		WithResolver(resolver).
+		Expects(expected, []artifact.Relationship{
+			{From: sdk, To: emscripten, Type: artifact.DependencyOfRelationship},
+			{From: cli, To: component, Type: artifact.DependencyOfRelationship},
+			{From: io, To: component, Type: artifact.DependencyOfRelationship},
+		}).
+		TestCataloger(t, NewWasmCataloger())
+}
@@ -0,0 +1,53 @@
+package wasm
+
+import (
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/purl"
+	"github.com/anchore/syft/syft/source"
+)
+
+func newWasmPackage(name string, metadata pkg.WasmMetadata, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:         name,
+		Locations:    source.NewLocationSet(locations...),
+		Type:         pkg.WasmPkg,
+		PURL:         purl.New(purl.TypeGeneric, "", name, "", nil, ""),
+		MetadataType: pkg.WasmMetadataType,
+		Metadata:     metadata,
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// newWitPackage creates a package for a WIT package (e.g. "wasi:cli@0.2.0") imported by a component.
+func newWitPackage(namespace, name, version string, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:      namespace + ":" + name,
+		Version:   version,
+		Locations: source.NewLocationSet(locations...),
+		Type:      pkg.WasmPkg,
+		PURL:      purl.New(purl.TypeGeneric, namespace, name, version, nil, ""),
+	}
+
+	p.SetID()
+
+	return p
+}
+
+// newSDKPackage creates a package for an SDK the binary was built with, since its runtime (e.g. the libc of
+// Emscripten) is linked into the binary.
+func newSDKPackage(producer pkg.WasmProducer, locations ...source.Location) pkg.Package {
+	p := pkg.Package{
+		Name:      producer.Name,
+		Version:   producer.Version,
+		Locations: source.NewLocationSet(locations...),
+		Type:      pkg.WasmPkg,
+		PURL:      purl.New(purl.TypeGeneric, "", producer.Name, producer.Version, nil, ""),
+	}
+
+	p.SetID()
+
+	return p
+}
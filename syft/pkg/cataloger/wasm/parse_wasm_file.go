@@ -0,0 +1,428 @@
+package wasm
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/generic"
+	"github.com/anchore/syft/syft/source"
+)
+
+const (
+	moduleFormat    = "module"
+	componentFormat = "component"
+
+	// the section IDs of core modules (see https://webassembly.github.io/spec/core/binary/modules.html) and components
+	// (see https://github.com/WebAssembly/component-model/blob/main/design/mvp/Binary.md)
+	customSectionID          = 0
+	moduleImportSectionID    = 2
+	componentImportSectionID = 10
+
+	nameSectionName          = "name"
+	componentNameSectionName = "component-name"
+	producersSectionName     = "producers"
+	// enough to read the name (and its length) of all custom sections of interest
+	maxSectionNameLength = 32
+
+	// the field names of the producers section (see https://github.com/WebAssembly/tool-conventions/blob/main/ProducersSection.md)
+	languageField    = "language"
+	processedByField = "processed-by"
+	sdkField         = "sdk"
+)
+
+var (
+	wasmMagic = []byte("\x00asm")
+
+	// e.g. "wasi:cli/environment@0.2.0" (see https://github.com/WebAssembly/component-model/blob/main/design/mvp/WIT.md)
+	interfaceNamePattern = regexp.MustCompile(`^(?P<namespace>[^:/@]+):(?P<package>[^:/@]+)/(?P<interface>[^@]+)(?:@(?P<version>.+))?$`)
+)
+
+// parseWasmFile catalogs a WebAssembly module or component, along with the SDKs it was built with and (for components)
+// the WIT packages of the interfaces it imports.
+func parseWasmFile(_ source.FileResolver, _ *generic.Environment, reader source.LocationReadCloser) ([]pkg.Package, []artifact.Relationship, error) {
+	metadata, err := parseWasmBinary(bufio.NewReader(reader))
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read WebAssembly binary: %w", err)
+	}
+
+	name := metadata.Name
+	if name == "" {
+		name = strings.TrimSuffix(path.Base(reader.RealPath), ".wasm")
+	}
+	binary := newWasmPackage(name, *metadata, reader.Location)
+	pkgs := []pkg.Package{binary}
+
+	for _, sdk := range metadata.SDKs {
+		pkgs = append(pkgs, newSDKPackage(sdk, reader.Location))
+	}
+
+	seen := internal.NewStringSet()
+	for _, imported := range metadata.Imports {
+		groups := internal.MatchNamedCaptureGroups(interfaceNamePattern, imported)
+		if groups["namespace"] == "" {
+			continue
+		}
+		// a package exposes many interfaces, so the package is only recorded once
+		id := groups["namespace"] + ":" + groups["package"] + "@" + groups["version"]
+		if seen.Contains(id) {
+			continue
+		}
+		seen.Add(id)
+		pkgs = append(pkgs, newWitPackage(groups["namespace"], groups["package"], groups["version"], reader.Location))
+	}
+
+	var relationships []artifact.Relationship
+	for _, p := range pkgs[1:] {
+		relationships = append(relationships, artifact.Relationship{
+			From: p,
+			To:   binary,
+			Type: artifact.DependencyOfRelationship,
+		})
+	}
+
+	return pkgs, relationships, nil
+}
+
+// parseWasmBinary reads the custom and import sections of the given WebAssembly binary, skipping all other sections.
+func parseWasmBinary(r *bufio.Reader) (*pkg.WasmMetadata, error) {
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("unable to read header: %w", err)
+	}
+	if !bytes.Equal(header[:4], wasmMagic) {
+		return nil, fmt.Errorf("not a WebAssembly binary")
+	}
+
+	// the version of a core module is 1, where a component has a layer of 1 (in the upper half of the version)
+	metadata := pkg.WasmMetadata{Format: moduleFormat}
+	importSectionID := byte(moduleImportSectionID)
+	if header[6] == 1 && header[7] == 0 {
+		metadata.Format = componentFormat
+		importSectionID = componentImportSectionID
+	}
+
+	for {
+		id, err := r.ReadByte()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read section: %w", err)
+		}
+		size, err := readUint32(r)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read section: %w", err)
+		}
+
+		if !isSectionOfInterest(r, id, importSectionID, size) {
+			if _, err := r.Discard(int(size)); err != nil {
+				return nil, fmt.Errorf("unable to read section: %w", err)
+			}
+			continue
+		}
+
+		data, err := io.ReadAll(io.LimitReader(r, int64(size)))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read section: %w", err)
+		}
+		if len(data) != int(size) {
+			return nil, fmt.Errorf("unable to read section: %w", io.ErrUnexpectedEOF)
+		}
+		section := &sectionReader{data: data}
+
+		switch {
+		case id == customSectionID:
+			err = parseCustomSection(section, &metadata)
+		case metadata.Format == componentFormat:
+			metadata.Imports, err = parseComponentImports(section)
+		default:
+			metadata.Imports, err = parseModuleImports(section)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &metadata, nil
+}
+
+// isSectionOfInterest indicates whether the upcoming section is the import section or a custom section of interest, where
+// the name of a custom section is peeked at so that others (e.g. DWARF debug info) are skipped without being read.
+func isSectionOfInterest(r *bufio.Reader, id, importSectionID byte, size uint32) bool {
+	switch id {
+	case importSectionID:
+		return true
+	case customSectionID:
+		peeked, _ := r.Peek(minInt(int(size), maxSectionNameLength))
+		switch (&sectionReader{data: peeked}).name() {
+		case nameSectionName, componentNameSectionName, producersSectionName:
+			return true
+		}
+	}
+	return false
+}
+
+func parseCustomSection(section *sectionReader, metadata *pkg.WasmMetadata) error {
+	name := section.name()
+	switch name {
+	case nameSectionName, componentNameSectionName:
+		metadata.Name = parseNameSection(section)
+	case producersSectionName:
+		parseProducersSection(section, metadata)
+	}
+	if section.err != nil {
+		return fmt.Errorf("unable to read %q section: %w", name, section.err)
+	}
+	return nil
+}
+
+// parseNameSection returns the name of the module (or component) from the "name" (or "component-name") section, which
+// is the first (and optional) subsection.
+func parseNameSection(section *sectionReader) string {
+	for !section.done() {
+		id := section.byte()
+		size := section.uint32()
+		if section.err != nil {
+			return ""
+		}
+		if id == 0 {
+			return section.name()
+		}
+		section.skip(size)
+	}
+	return ""
+}
+
+func parseProducersSection(section *sectionReader, metadata *pkg.WasmMetadata) {
+	fields := section.uint32()
+	for i := uint32(0); i < fields && section.err == nil; i++ {
+		field := section.name()
+		values := section.uint32()
+		var producers []pkg.WasmProducer
+		for j := uint32(0); j < values && section.err == nil; j++ {
+			producers = append(producers, pkg.WasmProducer{
+				Name:    section.name(),
+				Version: section.name(),
+			})
+		}
+
+		switch field {
+		case languageField:
+			metadata.Languages = producers
+		case processedByField:
+			metadata.ProcessedBy = producers
+		case sdkField:
+			metadata.SDKs = producers
+		}
+	}
+}
+
+// parseModuleImports returns the (unique) names of the modules imported by a core module (e.g. "wasi_snapshot_preview1").
+func parseModuleImports(section *sectionReader) ([]string, error) {
+	var modules []string
+	seen := internal.NewStringSet()
+	count := section.uint32()
+	for i := uint32(0); i < count && section.err == nil; i++ {
+		module := section.name()
+		section.name() // the name of the imported item within the module
+		section.skipImportDescription()
+		if section.err == nil && !seen.Contains(module) {
+			seen.Add(module)
+			modules = append(modules, module)
+		}
+	}
+	if section.err != nil {
+		return nil, fmt.Errorf("unable to read import section: %w", section.err)
+	}
+	return modules, nil
+}
+
+// parseComponentImports returns the names of the items imported by a component, which for interfaces are fully
+// qualified (e.g. "wasi:cli/environment@0.2.0").
+func parseComponentImports(section *sectionReader) ([]string, error) {
+	var names []string
+	count := section.uint32()
+	for i := uint32(0); i < count && section.err == nil; i++ {
+		switch section.byte() {
+		case 0x00:
+			names = append(names, section.name())
+		case 0x01:
+			// the name is followed by a version suffix (or, in earlier versions of the binary format, a URL)
+			names = append(names, section.name())
+			section.name()
+		default:
+			section.err = fmt.Errorf("unknown import name")
+		}
+		section.skipExternDescription()
+	}
+	if section.err != nil {
+		return nil, fmt.Errorf("unable to read import section: %w", section.err)
+	}
+	return names, nil
+}
+
+// sectionReader decodes the contents of a single section, where the first error encountered is kept (and all later
+// reads return zero values).
+type sectionReader struct {
+	data []byte
+	pos  int
+	err  error
+}
+
+func (s *sectionReader) done() bool {
+	return s.err != nil || s.pos >= len(s.data)
+}
+
+func (s *sectionReader) byte() byte {
+	if s.err != nil {
+		return 0
+	}
+	if s.pos >= len(s.data) {
+		s.err = io.ErrUnexpectedEOF
+		return 0
+	}
+	b := s.data[s.pos]
+	s.pos++
+	return b
+}
+
+func (s *sectionReader) uint32() uint32 {
+	if s.err != nil {
+		return 0
+	}
+	v, err := readUint32(s)
+	if err != nil {
+		s.err = err
+	}
+	return v
+}
+
+func (s *sectionReader) name() string {
+	size := s.uint32()
+	if s.err != nil {
+		return ""
+	}
+	if uint64(s.pos)+uint64(size) > uint64(len(s.data)) {
+		s.err = io.ErrUnexpectedEOF
+		return ""
+	}
+	name := string(s.data[s.pos : s.pos+int(size)])
+	s.pos += int(size)
+	return name
+}
+
+func (s *sectionReader) skip(size uint32) {
+	if s.err != nil {
+		return
+	}
+	if uint64(s.pos)+uint64(size) > uint64(len(s.data)) {
+		s.err = io.ErrUnexpectedEOF
+		return
+	}
+	s.pos += int(size)
+}
+
+// skipImportDescription skips the description of an item imported by a core module.
+func (s *sectionReader) skipImportDescription() {
+	switch s.byte() {
+	case 0x00: // function
+		s.uint32()
+	case 0x01: // table
+		s.byte()
+		s.skipLimits()
+	case 0x02: // memory
+		s.skipLimits()
+	case 0x03: // global
+		s.byte()
+		s.byte()
+	case 0x04: // tag
+		s.byte()
+		s.uint32()
+	default:
+		s.err = fmt.Errorf("unknown import description")
+	}
+}
+
+func (s *sectionReader) skipLimits() {
+	flags := s.byte()
+	s.uint32()
+	if flags&0x01 != 0 {
+		s.uint32()
+	}
+}
+
+// skipExternDescription skips the description of an item imported by a component.
+func (s *sectionReader) skipExternDescription() {
+	switch s.byte() {
+	case 0x00: // core module
+		s.byte()
+		s.uint32()
+	case 0x01, 0x04, 0x05: // function, component, or instance
+		s.uint32()
+	case 0x02: // value
+		if s.byte() == 0x01 {
+			s.skipValueType()
+		} else {
+			s.uint32()
+		}
+	case 0x03: // type
+		if s.byte() == 0x00 {
+			s.uint32()
+		}
+	default:
+		s.err = fmt.Errorf("unknown extern description")
+	}
+}
+
+// skipValueType skips a value type, which is either a (single byte) primitive type or the (non-negative) index of a type.
+func (s *sectionReader) skipValueType() {
+	if s.done() {
+		s.byte()
+		return
+	}
+	if b := s.data[s.pos]; b >= 0x40 && b < 0x80 {
+		s.pos++
+		return
+	}
+	s.uint32()
+}
+
+func (s *sectionReader) ReadByte() (byte, error) {
+	b := s.byte()
+	return b, s.err
+}
+
+// readUint32 reads an unsigned LEB128 encoded integer.
+func readUint32(r io.ByteReader) (uint32, error) {
+	var v uint32
+	for shift := 0; shift < 35; shift += 7 {
+		b, err := r.ReadByte()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		v |= uint32(b&0x7f) << shift
+		if b&0x80 == 0 {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("integer is too large")
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+package wasm
+
+import (
+	"bufio"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/pkg/cataloger/internal/pkgtest"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestParseWasmFile_module(t *testing.T) {
+	fixture := "test-fixtures/app/rust.wasm"
+	expected := []pkg.Package{
+		{
+			Name:         "hello_rust",
+			Locations:    source.NewLocationSet(source.NewLocation(fixture)),
+			Type:         pkg.WasmPkg,
+			PURL:         "pkg:generic/hello_rust",
+			MetadataType: pkg.WasmMetadataType,
+			Metadata: pkg.WasmMetadata{
+				Name:   "hello_rust",
+				Format: "module",
+				Languages: []pkg.WasmProducer{
+					{Name: "Rust"},
+				},
+				ProcessedBy: []pkg.WasmProducer{
+					{Name: "rustc", Version: "1.74.0 (79e9716c9 2023-11-13)"},
+				},
+				Imports: []string{"wasi_snapshot_preview1"},
+			},
+		},
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseWasmFile, expected, nil)
+}
+
+func TestParseWasmFile_moduleWithSDK(t *testing.T) {
+	fixture := "test-fixtures/app/emscripten.wasm"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	module := pkg.Package{
+		// without a name section, the module is named after the file
+		Name:         "emscripten",
+		Locations:    locations,
+		Type:         pkg.WasmPkg,
+		PURL:         "pkg:generic/emscripten",
+		MetadataType: pkg.WasmMetadataType,
+		Metadata: pkg.WasmMetadata{
+			Format: "module",
+			Languages: []pkg.WasmProducer{
+				{Name: "C11"},
+			},
+			ProcessedBy: []pkg.WasmProducer{
+				{Name: "clang", Version: "18.0.0git"},
+			},
+			SDKs: []pkg.WasmProducer{
+				{Name: "Emscripten", Version: "3.1.45"},
+			},
+			Imports: []string{"env", "wasi_snapshot_preview1"},
+		},
+	}
+	sdk := pkg.Package{
+		Name:      "Emscripten",
+		Version:   "3.1.45",
+		Locations: locations,
+		Type:      pkg.WasmPkg,
+		PURL:      "pkg:generic/Emscripten@3.1.45",
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseWasmFile, []pkg.Package{module, sdk}, []artifact.Relationship{
+		{From: sdk, To: module, Type: artifact.DependencyOfRelationship},
+	})
+}
+
+func TestParseWasmFile_component(t *testing.T) {
+	fixture := "test-fixtures/app/component.wasm"
+	locations := source.NewLocationSet(source.NewLocation(fixture))
+	component := pkg.Package{
+		Name:         "hello-component",
+		Locations:    locations,
+		Type:         pkg.WasmPkg,
+		PURL:         "pkg:generic/hello-component",
+		MetadataType: pkg.WasmMetadataType,
+		Metadata: pkg.WasmMetadata{
+			Name:   "hello-component",
+			Format: "component",
+			ProcessedBy: []pkg.WasmProducer{
+				{Name: "wit-component", Version: "0.18.2"},
+				{Name: "cargo-component", Version: "0.5.0"},
+			},
+			Imports: []string{
+				"wasi:cli/environment@0.2.0",
+				"wasi:io/streams@0.2.0",
+				"wasi:cli/stdout@0.2.0",
+				"log",
+			},
+		},
+	}
+	// the interfaces of the same package are cataloged as a single package
+	cli := pkg.Package{
+		Name:      "wasi:cli",
+		Version:   "0.2.0",
+		Locations: locations,
+		Type:      pkg.WasmPkg,
+		PURL:      "pkg:generic/wasi/cli@0.2.0",
+	}
+	io := pkg.Package{
+		Name:      "wasi:io",
+		Version:   "0.2.0",
+		Locations: locations,
+		Type:      pkg.WasmPkg,
+		PURL:      "pkg:generic/wasi/io@0.2.0",
+	}
+
+	pkgtest.TestFileParser(t, fixture, parseWasmFile, []pkg.Package{component, cli, io}, []artifact.Relationship{
+		{From: cli, To: component, Type: artifact.DependencyOfRelationship},
+		{From: io, To: component, Type: artifact.DependencyOfRelationship},
+	})
+}
+
+func TestParseWasmFile_notWasm(t *testing.T) {
+	pkgtest.NewCatalogTester().
+		FromFile(t, "test-fixtures/not-wasm.wasm").
+		WithError().
+		TestParser(t, parseWasmFile)
+}
+
+func TestParseWasmBinary_truncated(t *testing.T) {
+	// a custom section which is larger than the rest of the binary
+	data := []byte("\x00asm\x01\x00\x00\x00\x00\x20\x04name\x00")
+	_, err := parseWasmBinary(bufio.NewReader(bytes.NewReader(data)))
+	require.Error(t, err)
+}
+
+func Test_readUint32(t *testing.T) {
+	tests := []struct {
+		input    []byte
+		expected uint32
+		wantErr  bool
+	}{
+		{input: []byte{0x00}, expected: 0},
+		{input: []byte{0x7f}, expected: 127},
+		{input: []byte{0xe5, 0x8e, 0x26}, expected: 624485},
+		{input: []byte{0xff, 0xff, 0xff, 0xff, 0x0f}, expected: 0xffffffff},
+		{input: []byte{0x80}, wantErr: true},
+		{input: []byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x01}, wantErr: true},
+	}
+	for _, test := range tests {
+		v, err := readUint32(bytes.NewReader(test.input))
+		if test.wantErr {
+			assert.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, v)
+	}
+}
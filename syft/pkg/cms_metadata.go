@@ -0,0 +1,25 @@
+package pkg
+
+// WordpressMetadata represents a WordPress plugin or theme, as described by the header of the main plugin file (or
+// the style.css file of a theme).
+type WordpressMetadata struct {
+	Slug        string `mapstructure:"slug" json:"slug" cyclonedx:"slug"` // the directory (or file) name the plugin or theme is installed as, which identifies it within the wordpress.org directory
+	Name        string `mapstructure:"name" json:"name" cyclonedx:"name"` // the "Plugin Name" or "Theme Name"
+	URI         string `mapstructure:"uri" json:"uri,omitempty" cyclonedx:"uri"`
+	Author      string `mapstructure:"author" json:"author,omitempty" cyclonedx:"author"`
+	AuthorURI   string `mapstructure:"authorURI" json:"authorURI,omitempty" cyclonedx:"authorURI"`
+	RequiresWP  string `mapstructure:"requiresWordpress" json:"requiresWordpress,omitempty" cyclonedx:"requiresWordpress"` // the minimum supported WordPress version
+	RequiresPHP string `mapstructure:"requiresPHP" json:"requiresPHP,omitempty" cyclonedx:"requiresPHP"`                   // the minimum supported PHP version
+	Template    string `mapstructure:"template" json:"template,omitempty" cyclonedx:"template"`                            // the parent theme of a child theme
+	MustUse     bool   `mapstructure:"mustUse" json:"mustUse,omitempty" cyclonedx:"mustUse"`                               // whether the plugin is a must-use plugin (always enabled)
+}
+
+// DrupalMetadata represents a Drupal module or theme, as described by its .info.yml file.
+type DrupalMetadata struct {
+	Name                   string   `mapstructure:"name" json:"name" cyclonedx:"name"`                    // the human-readable name
+	Project                string   `mapstructure:"project" json:"project,omitempty" cyclonedx:"project"` // the drupal.org project the module or theme is released by (e.g. "drupal" for the core modules)
+	Package                string   `mapstructure:"package" json:"package,omitempty" cyclonedx:"package"` // the group the module is listed within (e.g. "Core")
+	CoreVersionRequirement string   `mapstructure:"coreVersionRequirement" json:"coreVersionRequirement,omitempty" cyclonedx:"coreVersionRequirement"`
+	BaseTheme              string   `mapstructure:"baseTheme" json:"baseTheme,omitempty" cyclonedx:"baseTheme"`
+	Dependencies           []string `mapstructure:"dependencies" json:"dependencies,omitempty" cyclonedx:"dependencies"` // e.g. "drupal:views" or "ctools:ctools (>=8.x-3.0)"
+}
@@ -1,8 +1,8 @@
 package pkg
 
 import (
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 var _ urlIdentifier = (*CocoapodsMetadata)(nil)
@@ -14,14 +14,5 @@ type CocoapodsMetadata struct {
 }
 
 func (m CocoapodsMetadata) PackageURL(_ *linux.Release) string {
-	var qualifiers packageurl.Qualifiers
-
-	return packageurl.NewPackageURL(
-		packageurl.TypeCocoapods,
-		"",
-		m.Name,
-		m.Version,
-		qualifiers,
-		"",
-	).ToString()
+	return purl.New(purl.TypeCocoapods, "", m.Name, m.Version, nil, "")
 }
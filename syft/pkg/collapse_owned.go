@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+)
+
+// CollapseOwnedPackages removes the packages that are owned by another package by file overlap (see
+// artifact.OwnershipByFileOverlapRelationship), e.g. the python packages installed by an rpm, so that the same software
+// is not reported (and matched against vulnerabilities) twice. The relationships of the removed packages are moved to
+// the package that owns them.
+func CollapseOwnedPackages(catalog *Catalog, relationships []artifact.Relationship) (*Catalog, []artifact.Relationship) {
+	owners := make(map[artifact.ID]Package)
+	for _, r := range relationships {
+		if r.Type != artifact.OwnershipByFileOverlapRelationship {
+			continue
+		}
+		owner, ok := r.From.(Package)
+		if !ok {
+			continue
+		}
+		owners[r.To.ID()] = owner
+	}
+	if len(owners) == 0 {
+		return catalog, relationships
+	}
+
+	var packages []Package
+	replacements := make(map[artifact.ID]Package)
+	for _, p := range catalog.Sorted() {
+		owner, ok := rootOwner(p, owners)
+		if !ok {
+			packages = append(packages, p)
+			continue
+		}
+		log.Debugf("collapsing package %s into its owner %s", p, owner)
+		replacements[p.ID()] = owner
+	}
+
+	if len(replacements) == 0 {
+		return catalog, relationships
+	}
+
+	return NewCatalog(packages...), replaceRelationships(relationships, replacements)
+}
+
+// rootOwner follows the ownership of the given package to the package that is not owned itself. Packages that own
+// each other (directly or not) are all kept.
+func rootOwner(p Package, owners map[artifact.ID]Package) (Package, bool) {
+	owner, ok := owners[p.ID()]
+	if !ok {
+		return Package{}, false
+	}
+	seen := map[artifact.ID]struct{}{p.ID(): {}}
+	for {
+		if _, exists := seen[owner.ID()]; exists {
+			return Package{}, false
+		}
+		seen[owner.ID()] = struct{}{}
+		next, ok := owners[owner.ID()]
+		if !ok {
+			return owner, true
+		}
+		owner = next
+	}
+}
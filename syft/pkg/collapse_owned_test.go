@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestCollapseOwnedPackages(t *testing.T) {
+	newPackage := func(name string, ty Type) Package {
+		p := Package{Name: name, Version: "1.0", Type: ty}
+		p.SetID()
+		return p
+	}
+
+	rpm := newPackage("python3-requests", RpmPkg)
+	python := newPackage("requests", PythonPkg)
+	binary := newPackage("requests-binary", BinaryPkg)
+	urllib := newPackage("urllib3", PythonPkg)
+	apk := newPackage("busybox", ApkPkg)
+	other := newPackage("ssl_client", ApkPkg)
+	file := source.Coordinates{RealPath: "/usr/lib/python3/site-packages/requests/__init__.py"}
+
+	tests := []struct {
+		name                  string
+		relationships         []artifact.Relationship
+		expectedIDs           []artifact.ID
+		expectedRelationships []artifact.Relationship
+	}{
+		{
+			name: "no ownership",
+			relationships: []artifact.Relationship{
+				{From: urllib, To: python, Type: artifact.DependencyOfRelationship},
+			},
+			expectedIDs: []artifact.ID{rpm.ID(), python.ID(), binary.ID(), urllib.ID(), apk.ID(), other.ID()},
+			expectedRelationships: []artifact.Relationship{
+				{From: urllib, To: python, Type: artifact.DependencyOfRelationship},
+			},
+		},
+		{
+			name: "owned packages are collapsed into the owner",
+			relationships: []artifact.Relationship{
+				{From: rpm, To: python, Type: artifact.OwnershipByFileOverlapRelationship},
+				{From: python, To: file, Type: artifact.ContainsRelationship},
+				{From: urllib, To: python, Type: artifact.DependencyOfRelationship},
+			},
+			expectedIDs: []artifact.ID{rpm.ID(), binary.ID(), urllib.ID(), apk.ID(), other.ID()},
+			expectedRelationships: []artifact.Relationship{
+				{From: rpm, To: file, Type: artifact.ContainsRelationship},
+				{From: urllib, To: rpm, Type: artifact.DependencyOfRelationship},
+			},
+		},
+		{
+			name: "ownership is followed to the root owner",
+			relationships: []artifact.Relationship{
+				{From: rpm, To: python, Type: artifact.OwnershipByFileOverlapRelationship},
+				{From: python, To: binary, Type: artifact.OwnershipByFileOverlapRelationship},
+				{From: urllib, To: binary, Type: artifact.DependencyOfRelationship},
+			},
+			expectedIDs: []artifact.ID{rpm.ID(), urllib.ID(), apk.ID(), other.ID()},
+			expectedRelationships: []artifact.Relationship{
+				{From: urllib, To: rpm, Type: artifact.DependencyOfRelationship},
+			},
+		},
+		{
+			name: "packages owning each other are kept",
+			relationships: []artifact.Relationship{
+				{From: apk, To: other, Type: artifact.OwnershipByFileOverlapRelationship},
+				{From: other, To: apk, Type: artifact.OwnershipByFileOverlapRelationship},
+			},
+			expectedIDs: []artifact.ID{rpm.ID(), python.ID(), binary.ID(), urllib.ID(), apk.ID(), other.ID()},
+			expectedRelationships: []artifact.Relationship{
+				{From: apk, To: other, Type: artifact.OwnershipByFileOverlapRelationship},
+				{From: other, To: apk, Type: artifact.OwnershipByFileOverlapRelationship},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			catalog, relationships := CollapseOwnedPackages(NewCatalog(rpm, python, binary, urllib, apk, other), test.relationships)
+
+			var ids []artifact.ID
+			for _, p := range catalog.Sorted() {
+				ids = append(ids, p.ID())
+			}
+			assert.ElementsMatch(t, test.expectedIDs, ids)
+			assert.ElementsMatch(t, test.expectedRelationships, relationships)
+		})
+	}
+}
@@ -3,8 +3,8 @@ package pkg
 import (
 	"strings"
 
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 type ConanLockMetadata struct {
@@ -20,18 +20,8 @@ type ConanLockMetadata struct {
 }
 
 func (m ConanLockMetadata) PackageURL(_ *linux.Release) string {
-	var qualifiers packageurl.Qualifiers
-
 	name, version := m.NameAndVersion()
-
-	return packageurl.NewPackageURL(
-		packageurl.TypeConan,
-		"",
-		name,
-		version,
-		qualifiers,
-		"",
-	).ToString()
+	return purl.New(purl.TypeConan, "", name, version, nil, "")
 }
 
 // NameAndVersion returns the name and version of the package.
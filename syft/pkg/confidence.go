@@ -0,0 +1,64 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Confidence describes how a package was identified, so that consumers can tell packages recorded by a package
+// manager from packages guessed from file contents.
+type Confidence string
+
+const (
+	// HeuristicConfidence is for packages guessed from file contents (e.g. a binary classifier matching a version
+	// string), which may be misidentified.
+	HeuristicConfidence Confidence = "heuristic"
+	// AuthoritativeConfidence is for packages parsed from a record of what is installed or resolved (e.g. a package
+	// manager database or a lock file).
+	AuthoritativeConfidence Confidence = "authoritative"
+)
+
+// AllConfidences are the supported confidence levels, from the lowest to the highest.
+var AllConfidences = []Confidence{
+	HeuristicConfidence,
+	AuthoritativeConfidence,
+}
+
+// ParseConfidence returns the confidence level described by the given string.
+func ParseConfidence(value string) (Confidence, error) {
+	for _, c := range AllConfidences {
+		if strings.EqualFold(strings.TrimSpace(value), string(c)) {
+			return c, nil
+		}
+	}
+	return "", fmt.Errorf("invalid package confidence %q (options: %v)", value, AllConfidences)
+}
+
+// ConfidenceFromEvidence returns the confidence level of a package identified from the given evidence: authoritative
+// when any evidence identifies the package with certainty, otherwise heuristic.
+func ConfidenceFromEvidence(evidence []Evidence) Confidence {
+	for _, e := range evidence {
+		if e.Confidence >= 1 {
+			return AuthoritativeConfidence
+		}
+	}
+	return HeuristicConfidence
+}
+
+// AtLeast indicates whether the confidence level is the same as or higher than the given level. An unknown confidence
+// level (e.g. of a package decoded from an SBOM that does not record it) satisfies any minimum.
+func (c Confidence) AtLeast(minimum Confidence) bool {
+	if c == "" {
+		return true
+	}
+	return c.rank() >= minimum.rank()
+}
+
+func (c Confidence) rank() int {
+	for i, other := range AllConfidences {
+		if c == other {
+			return i
+		}
+	}
+	return -1
+}
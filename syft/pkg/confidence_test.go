@@ -0,0 +1,59 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConfidence(t *testing.T) {
+	c, err := ParseConfidence(" Heuristic ")
+	require.NoError(t, err)
+	assert.Equal(t, HeuristicConfidence, c)
+
+	_, err = ParseConfidence("certain")
+	assert.ErrorContains(t, err, "invalid package confidence")
+}
+
+func TestConfidenceFromEvidence(t *testing.T) {
+	tests := []struct {
+		name     string
+		evidence []Evidence
+		expected Confidence
+	}{
+		{
+			name:     "parsed from a file",
+			evidence: []Evidence{{Confidence: 1}},
+			expected: AuthoritativeConfidence,
+		},
+		{
+			name:     "matched content only",
+			evidence: []Evidence{{Rule: "busybox-binary", Confidence: 0.6}},
+			expected: HeuristicConfidence,
+		},
+		{
+			name:     "matched content and parsed from a file",
+			evidence: []Evidence{{Rule: "busybox-binary", Confidence: 0.6}, {Confidence: 1}},
+			expected: AuthoritativeConfidence,
+		},
+		{
+			name:     "no evidence",
+			expected: HeuristicConfidence,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, ConfidenceFromEvidence(test.evidence))
+		})
+	}
+}
+
+func TestPackage_combineConfidence(t *testing.T) {
+	p := Package{Name: "zlib", Confidence: HeuristicConfidence}
+	p.combine(Package{Name: "zlib", Confidence: AuthoritativeConfidence})
+	assert.Equal(t, AuthoritativeConfidence, p.Confidence)
+
+	p.combine(Package{Name: "zlib", Confidence: HeuristicConfidence})
+	assert.Equal(t, AuthoritativeConfidence, p.Confidence)
+}
@@ -0,0 +1,22 @@
+package pkg
+
+const (
+	// OCILayoutImageFormat is an image within an OCI image layout directory
+	OCILayoutImageFormat = "oci-layout"
+	// OCIArchiveImageFormat is an image within a tar archive of an OCI image layout
+	OCIArchiveImageFormat = "oci-archive"
+	// DockerArchiveImageFormat is an image within a tar archive written by "docker save"
+	DockerArchiveImageFormat = "docker-archive"
+	// DockerImageStoreFormat is an image within the image store of a docker daemon (e.g. /var/lib/docker)
+	DockerImageStoreFormat = "docker-image-store"
+)
+
+// ContainerImageMetadata represents a container image stored within the scanned filesystem (e.g. an image archive or
+// the image store of a docker daemon), as opposed to an image that is only referenced by name.
+type ContainerImageMetadata struct {
+	Format         string   `mapstructure:"format" json:"format" cyclonedx:"format"`                                   // one of "oci-layout", "oci-archive", "docker-archive", or "docker-image-store"
+	ImageID        string   `mapstructure:"imageID" json:"imageID,omitempty" cyclonedx:"imageID"`                      // the digest of the image config (e.g. "sha256:...")
+	ManifestDigest string   `mapstructure:"manifestDigest" json:"manifestDigest,omitempty" cyclonedx:"manifestDigest"` // the digest of the image manifest (or index, for multi-platform images)
+	Tags           []string `mapstructure:"tags" json:"tags,omitempty" cyclonedx:"tags"`                               // all references the image is tagged with (e.g. "docker.io/library/alpine:3.17")
+	Platforms      []string `mapstructure:"platforms" json:"platforms,omitempty" cyclonedx:"platforms"`                // e.g. "linux/amd64"
+}
@@ -0,0 +1,34 @@
+package pkg
+
+// CPESource describes how a CPE of a package was determined.
+type CPESource string
+
+const (
+	// DeclaredCPESource is a CPE stated for the package directly (e.g. by a binary classifier).
+	DeclaredCPESource CPESource = "declared"
+	// DictionaryCPESource is a CPE generated from the package metadata whose vendor and product are known to the NVD
+	// CPE dictionary.
+	DictionaryCPESource CPESource = "nvd-cpe-dictionary"
+	// GeneratedCPESource is a CPE generated from the package metadata that could not be validated against the NVD CPE
+	// dictionary.
+	GeneratedCPESource CPESource = "generated"
+)
+
+// CPEAnnotation describes where a CPE of a package came from and how likely it is to describe the package.
+type CPEAnnotation struct {
+	Source     CPESource `json:"source"`     // how the CPE was determined
+	Confidence float64   `json:"confidence"` // how likely the CPE describes the package, from 0 (unlikely) to 1 (certain)
+}
+
+func mergeCPEAnnotations(annotations, others map[string]CPEAnnotation) map[string]CPEAnnotation {
+	for cpe, annotation := range others {
+		if _, exists := annotations[cpe]; exists {
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]CPEAnnotation)
+		}
+		annotations[cpe] = annotation
+	}
+	return annotations
+}
@@ -0,0 +1,240 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+// DeduplicationIdentity describes which package fields determine if two packages are the same package.
+type DeduplicationIdentity string
+
+const (
+	// NameVersionIdentity considers packages with the same normalized name and version to be the same package.
+	NameVersionIdentity DeduplicationIdentity = "name-version"
+	// PURLIdentity considers packages with the same package URL (ignoring qualifiers and subpath) to be the same package.
+	PURLIdentity DeduplicationIdentity = "purl"
+)
+
+// AllDeduplicationIdentities are the supported ways of identifying duplicate packages.
+var AllDeduplicationIdentities = []DeduplicationIdentity{
+	NameVersionIdentity,
+	PURLIdentity,
+}
+
+// ParseDeduplicationIdentity returns the deduplication identity described by the given string.
+func ParseDeduplicationIdentity(s string) (DeduplicationIdentity, error) {
+	for _, identity := range AllDeduplicationIdentities {
+		if strings.EqualFold(s, string(identity)) {
+			return identity, nil
+		}
+	}
+	return "", fmt.Errorf("unknown package deduplication identity: %q (options: %v)", s, AllDeduplicationIdentities)
+}
+
+// DeduplicationConfig is the policy for merging the same package when found by multiple catalogers (e.g. a python
+// package found from the dist-info directory and from a binary classifier).
+type DeduplicationConfig struct {
+	// Enabled merges duplicate packages after cataloging
+	Enabled bool
+	// Identity determines the fields that must match for packages to be considered the same
+	Identity DeduplicationIdentity
+	// AcrossTypes allows packages of different types to be merged (otherwise the package type must match too)
+	AcrossTypes bool
+	// RequireSharedLocation only merges packages that were discovered from at least one common path
+	RequireSharedLocation bool
+}
+
+// DefaultDeduplicationConfig returns the deduplication policy used when duplicate packages are merged.
+func DefaultDeduplicationConfig() DeduplicationConfig {
+	return DeduplicationConfig{
+		Enabled:  false,
+		Identity: NameVersionIdentity,
+	}
+}
+
+// Deduplicate merges the packages within the catalog that are considered the same by the given policy. For each set
+// of duplicates a single package is kept (preferring packages with a specific type and metadata over generic binary
+// packages), combining the locations, CPEs, licenses, and (when missing) the metadata of the other packages. The
+// relationships of the removed packages are moved to the kept package.
+func Deduplicate(catalog *Catalog, relationships []artifact.Relationship, cfg DeduplicationConfig) (*Catalog, []artifact.Relationship) {
+	var groups [][]Package
+	groupsByKey := make(map[string][]int)
+	for _, p := range catalog.Sorted() {
+		key := cfg.identityKey(p)
+		if key == "" {
+			groups = append(groups, []Package{p})
+			continue
+		}
+		idx := -1
+		for _, candidate := range groupsByKey[key] {
+			if !cfg.RequireSharedLocation || sharesLocation(groups[candidate], p) {
+				idx = candidate
+				break
+			}
+		}
+		if idx < 0 {
+			groupsByKey[key] = append(groupsByKey[key], len(groups))
+			groups = append(groups, []Package{p})
+			continue
+		}
+		groups[idx] = append(groups[idx], p)
+	}
+
+	var packages []Package
+	replacements := make(map[artifact.ID]Package)
+	for _, group := range groups {
+		kept := mergeDuplicates(group)
+		for _, p := range group {
+			if p.ID() != kept.ID() {
+				log.Debugf("merging duplicate package %s into %s", p, kept)
+				replacements[p.ID()] = kept
+			}
+		}
+		packages = append(packages, kept)
+	}
+
+	if len(replacements) == 0 {
+		return catalog, relationships
+	}
+
+	return NewCatalog(packages...), replaceRelationships(relationships, replacements)
+}
+
+// identityKey returns the value that is shared by all packages considered the same by the policy (or an empty string
+// if the package does not have enough information to be identified).
+func (cfg DeduplicationConfig) identityKey(p Package) string {
+	var key string
+	switch cfg.Identity {
+	case PURLIdentity:
+		key = p.PURL
+		if idx := strings.IndexAny(key, "?#"); idx >= 0 {
+			key = key[:idx]
+		}
+		key = strings.ToLower(key)
+	default:
+		if p.Version == "" {
+			return ""
+		}
+		key = normalizePackageName(p.Name) + "@" + p.Version
+	}
+	if key == "" {
+		return ""
+	}
+	if !cfg.AcrossTypes {
+		key = string(p.Type) + ":" + key
+	}
+	return key
+}
+
+// normalizePackageName folds the differences in package names that are commonly ignored by package ecosystems
+// (e.g. "Flask_Cors" and "flask-cors" are the same python package).
+func normalizePackageName(name string) string {
+	return strings.NewReplacer("_", "-", ".", "-").Replace(strings.ToLower(name))
+}
+
+func sharesLocation(group []Package, p Package) bool {
+	paths := make(map[string]struct{})
+	for _, other := range group {
+		for _, l := range other.Locations.ToSlice() {
+			paths[l.RealPath] = struct{}{}
+		}
+	}
+	for _, l := range p.Locations.ToSlice() {
+		if _, exists := paths[l.RealPath]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeDuplicates combines the given duplicate packages into the most descriptive of the packages.
+func mergeDuplicates(group []Package) Package {
+	kept := 0
+	for i := 1; i < len(group); i++ {
+		if deduplicationRank(group[i]) > deduplicationRank(group[kept]) {
+			kept = i
+		}
+	}
+
+	// the location set and license texts are shared with the given package, so they are copied before being combined
+	// to leave the original catalog untouched
+	p := group[kept]
+	p.Locations = source.NewLocationSet(p.Locations.ToSlice()...)
+	if p.LicenseTexts != nil {
+		texts := make(map[string]string, len(p.LicenseTexts))
+		for license, text := range p.LicenseTexts {
+			texts[license] = text
+		}
+		p.LicenseTexts = texts
+	}
+	for i, other := range group {
+		if i == kept {
+			continue
+		}
+		p.combine(other)
+		if p.Metadata == nil && other.Metadata != nil {
+			p.MetadataType = other.MetadataType
+			p.Metadata = other.Metadata
+		}
+		if p.Language == UnknownLanguage {
+			p.Language = other.Language
+		}
+	}
+	return p
+}
+
+// deduplicationRank orders duplicate packages by how much they describe the package: packages of a specific type
+// are preferred over generic binary packages, and packages with metadata over packages without.
+func deduplicationRank(p Package) int {
+	rank := 0
+	if p.Type != BinaryPkg && p.Type != UnknownPkg {
+		rank += 2
+	}
+	if p.Metadata != nil {
+		rank++
+	}
+	return rank
+}
+
+// replaceRelationships moves the relationships of replaced packages to their replacements, dropping any relationships
+// that become redundant (a package related to itself, or the same relationship as another package).
+func replaceRelationships(relationships []artifact.Relationship, replacements map[artifact.ID]Package) []artifact.Relationship {
+	type edge struct {
+		from artifact.ID
+		to   artifact.ID
+		typ  artifact.RelationshipType
+	}
+	seen := make(map[edge]struct{})
+	for _, r := range relationships {
+		seen[edge{from: r.From.ID(), to: r.To.ID(), typ: r.Type}] = struct{}{}
+	}
+
+	var results []artifact.Relationship
+	for _, r := range relationships {
+		replaced := false
+		if p, ok := replacements[r.From.ID()]; ok {
+			r.From = p
+			replaced = true
+		}
+		if p, ok := replacements[r.To.ID()]; ok {
+			r.To = p
+			replaced = true
+		}
+		if replaced {
+			if r.From.ID() == r.To.ID() {
+				continue
+			}
+			e := edge{from: r.From.ID(), to: r.To.ID(), typ: r.Type}
+			if _, exists := seen[e]; exists {
+				continue
+			}
+			seen[e] = struct{}{}
+		}
+		results = append(results, r)
+	}
+	return results
+}
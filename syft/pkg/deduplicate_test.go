@@ -0,0 +1,150 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestDeduplicate(t *testing.T) {
+	newPackage := func(p Package) Package {
+		p.SetID()
+		return p
+	}
+
+	python := newPackage(Package{
+		Name:         "Flask_Cors",
+		Version:      "3.0.10",
+		Type:         PythonPkg,
+		Language:     Python,
+		Locations:    source.NewLocationSet(source.NewLocation("/usr/lib/python3/site-packages/Flask_Cors-3.0.10.dist-info/METADATA")),
+		PURL:         "pkg:pypi/flask-cors@3.0.10",
+		MetadataType: PythonPackageMetadataType,
+		Metadata:     PythonPackageMetadata{Name: "Flask_Cors", Version: "3.0.10"},
+	})
+
+	binary := newPackage(Package{
+		Name:         "flask-cors",
+		Version:      "3.0.10",
+		Type:         BinaryPkg,
+		Locations:    source.NewLocationSet(source.NewLocation("/usr/lib/python3/site-packages/flask_cors/version.py")),
+		PURL:         "pkg:pypi/flask-cors@3.0.10?os=linux",
+		Licenses:     []License{{Value: "MIT"}},
+		MetadataType: BinaryMetadataType,
+		Metadata:     BinaryMetadata{},
+	})
+
+	otherVersion := newPackage(Package{
+		Name:      "flask-cors",
+		Version:   "3.0.9",
+		Type:      BinaryPkg,
+		Locations: source.NewLocationSet(source.NewLocation("/opt/flask_cors/version.py")),
+		PURL:      "pkg:pypi/flask-cors@3.0.9",
+	})
+
+	file := source.Coordinates{RealPath: "/usr/lib/python3/site-packages/flask_cors/version.py"}
+	relationships := []artifact.Relationship{
+		{From: python, To: file, Type: artifact.ContainsRelationship},
+		{From: binary, To: file, Type: artifact.ContainsRelationship},
+		{From: python, To: binary, Type: artifact.OwnershipByFileOverlapRelationship},
+		{From: otherVersion, To: binary, Type: artifact.DependencyOfRelationship},
+	}
+
+	tests := []struct {
+		name                  string
+		cfg                   DeduplicationConfig
+		expectedIDs           []artifact.ID
+		expectedRelationships int
+	}{
+		{
+			name:                  "types must match",
+			cfg:                   DeduplicationConfig{Identity: NameVersionIdentity},
+			expectedIDs:           []artifact.ID{python.ID(), binary.ID(), otherVersion.ID()},
+			expectedRelationships: 4,
+		},
+		{
+			name:                  "across types by name and version",
+			cfg:                   DeduplicationConfig{Identity: NameVersionIdentity, AcrossTypes: true},
+			expectedIDs:           []artifact.ID{python.ID(), otherVersion.ID()},
+			expectedRelationships: 2,
+		},
+		{
+			name:                  "across types by purl",
+			cfg:                   DeduplicationConfig{Identity: PURLIdentity, AcrossTypes: true},
+			expectedIDs:           []artifact.ID{python.ID(), otherVersion.ID()},
+			expectedRelationships: 2,
+		},
+		{
+			name:                  "require a shared location",
+			cfg:                   DeduplicationConfig{Identity: NameVersionIdentity, AcrossTypes: true, RequireSharedLocation: true},
+			expectedIDs:           []artifact.ID{python.ID(), binary.ID(), otherVersion.ID()},
+			expectedRelationships: 4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			catalog, actualRelationships := Deduplicate(NewCatalog(python, binary, otherVersion), relationships, test.cfg)
+
+			var actualIDs []artifact.ID
+			for _, p := range catalog.Sorted() {
+				actualIDs = append(actualIDs, p.ID())
+			}
+			assert.ElementsMatch(t, test.expectedIDs, actualIDs)
+			assert.Len(t, actualRelationships, test.expectedRelationships)
+		})
+	}
+}
+
+func TestDeduplicate_mergesIntoMostDescriptivePackage(t *testing.T) {
+	binary := Package{
+		Name:      "flask-cors",
+		Version:   "3.0.10",
+		Type:      BinaryPkg,
+		Locations: source.NewLocationSet(source.NewLocation("/flask_cors/version.py")),
+		Licenses:  []License{{Value: "MIT"}},
+	}
+	binary.SetID()
+
+	python := Package{
+		Name:      "Flask_Cors",
+		Version:   "3.0.10",
+		Type:      PythonPkg,
+		Language:  Python,
+		Locations: source.NewLocationSet(source.NewLocation("/Flask_Cors-3.0.10.dist-info/METADATA")),
+	}
+	python.SetID()
+
+	file := source.Coordinates{RealPath: "/flask_cors/version.py"}
+	relationships := []artifact.Relationship{
+		{From: binary, To: file, Type: artifact.ContainsRelationship},
+	}
+
+	catalog, actualRelationships := Deduplicate(NewCatalog(binary, python), relationships, DeduplicationConfig{
+		Identity:    NameVersionIdentity,
+		AcrossTypes: true,
+	})
+
+	require.Equal(t, 1, catalog.PackageCount())
+	actual := catalog.Sorted()[0]
+	assert.Equal(t, python.ID(), actual.ID())
+	assert.Equal(t, PythonPkg, actual.Type)
+	assert.Len(t, actual.Locations.ToSlice(), 2)
+	assert.Equal(t, []License{{Value: "MIT"}}, actual.Licenses)
+
+	require.Len(t, actualRelationships, 1)
+	assert.Equal(t, python.ID(), actualRelationships[0].From.ID())
+	assert.Equal(t, file.ID(), actualRelationships[0].To.ID())
+}
+
+func TestParseDeduplicationIdentity(t *testing.T) {
+	identity, err := ParseDeduplicationIdentity("PURL")
+	require.NoError(t, err)
+	assert.Equal(t, PURLIdentity, identity)
+
+	_, err = ParseDeduplicationIdentity("name")
+	assert.Error(t, err)
+}
@@ -23,6 +23,9 @@ type DpkgMetadata struct {
 	Maintainer    string           `mapstructure:"Maintainer" json:"maintainer"`
 	InstalledSize int              `mapstructure:"InstalledSize" json:"installedSize" cyclonedx:"installedSize"`
 	Description   string           `mapstructure:"Description" hash:"ignore" json:"-"`
+	Provides      []string         `mapstructure:"Provides" json:"provides,omitempty"`     // virtual packages provided by the package (e.g. "awk")
+	Depends       []string         `mapstructure:"Depends" json:"depends,omitempty"`       // packages required by the package, where alternatives are separated by "|" (e.g. "libc6 (>= 2.14)")
+	PreDepends    []string         `mapstructure:"PreDepends" json:"preDepends,omitempty"` // packages required to be installed (and configured) before the package is installed
 	Files         []DpkgFileRecord `json:"files"`
 }
 
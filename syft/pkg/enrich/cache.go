@@ -0,0 +1,90 @@
+package enrich
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// cache stores the details looked up from registries within a directory on disk (a nil cache stores nothing).
+type cache struct {
+	dir string
+	ttl time.Duration
+	now func() time.Time
+}
+
+// cacheEntry is the details of a single version of a package, where the details are nil when the version is not
+// published within the registry.
+type cacheEntry struct {
+	Written time.Time         `json:"written"`
+	Info    *pkg.RegistryInfo `json:"info"`
+}
+
+func newCache(dir string, ttl time.Duration) (*cache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("unable to create cache directory=%q: %w", dir, err)
+	}
+	return &cache{
+		dir: dir,
+		ttl: ttl,
+		now: time.Now,
+	}, nil
+}
+
+func (c *cache) path(registry, name, version string) string {
+	// note: package names may contain characters that are not allowed within file names (e.g. npm scopes)
+	return filepath.Join(c.dir, registry, fmt.Sprintf("%x.json", sha256.Sum256([]byte(name+"@"+version))))
+}
+
+// get returns the stored details of the given package (which are nil for packages that are not published), and
+// whether there are unexpired details stored.
+func (c *cache) get(registry, name, version string) (*pkg.RegistryInfo, bool) {
+	if c == nil {
+		return nil, false
+	}
+	contents, err := os.ReadFile(c.path(registry, name, version))
+	if err != nil {
+		return nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(contents, &entry); err != nil {
+		log.Debugf("ignoring unreadable registry cache entry for %s@%s: %+v", name, version, err)
+		return nil, false
+	}
+	if c.ttl > 0 && c.now().Sub(entry.Written) > c.ttl {
+		return nil, false
+	}
+	return entry.Info, true
+}
+
+// put stores the details of the given package (nil for packages that are not published).
+func (c *cache) put(registry, name, version string, info *pkg.RegistryInfo) {
+	if c == nil {
+		return
+	}
+	contents, err := json.Marshal(cacheEntry{Written: c.now(), Info: info})
+	if err != nil {
+		log.Debugf("unable to encode registry cache entry for %s@%s: %+v", name, version, err)
+		return
+	}
+	p := c.path(registry, name, version)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		log.Debugf("unable to create registry cache directory: %+v", err)
+		return
+	}
+	// note: the entry is written to a temporary file first, so concurrent readers never see a partial entry
+	tmp := p + ".tmp"
+	if err := os.WriteFile(tmp, contents, 0600); err != nil {
+		log.Debugf("unable to write registry cache entry for %s@%s: %+v", name, version, err)
+		return
+	}
+	if err := os.Rename(tmp, p); err != nil {
+		log.Debugf("unable to write registry cache entry for %s@%s: %+v", name, version, err)
+	}
+}
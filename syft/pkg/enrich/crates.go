@@ -0,0 +1,62 @@
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// cratesRegistry looks up packages within a rust crate registry (with the web API of crates.io).
+type cratesRegistry struct {
+	url string
+}
+
+// cratesCrate is the document describing a crate along with all of its published versions.
+type cratesCrate struct {
+	Crate struct {
+		Homepage   string `json:"homepage"`
+		Repository string `json:"repository"`
+	} `json:"crate"`
+	Versions []struct {
+		Num         string `json:"num"`
+		CreatedAt   string `json:"created_at"`
+		PublishedBy *struct {
+			Login string `json:"login"`
+			Name  string `json:"name"`
+		} `json:"published_by"`
+	} `json:"versions"`
+}
+
+func (r cratesRegistry) name() string {
+	return "crates"
+}
+
+func (r cratesRegistry) baseURL() string {
+	return r.url
+}
+
+func (r cratesRegistry) lookup(ctx context.Context, c *client, name, version string) (*pkg.RegistryInfo, error) {
+	var crate cratesCrate
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/api/v1/crates/%s", r.url, url.PathEscape(name)), &crate); err != nil {
+		return nil, err
+	}
+
+	for _, v := range crate.Versions {
+		if v.Num != version {
+			continue
+		}
+		var supplier string
+		if v.PublishedBy != nil {
+			supplier = firstNonEmpty(v.PublishedBy.Name, v.PublishedBy.Login)
+		}
+		return &pkg.RegistryInfo{
+			Supplier:   supplier,
+			Homepage:   crate.Crate.Homepage,
+			Repository: repositoryURL(crate.Crate.Repository),
+			Published:  publishedTime(v.CreatedAt),
+		}, nil
+	}
+	return nil, errNotFound
+}
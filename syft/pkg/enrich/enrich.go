@@ -0,0 +1,282 @@
+/*
+Package enrich fills in the details of cataloged packages that are only known to the registries the packages were
+published to (e.g. who published the package, the project homepage and repository, and when the version was published),
+looking up packages within the npm, PyPI, and crates.io registries. Looking up packages requires network access, so
+enrichment is opt-in, and the details looked up are cached on disk to avoid asking the registries again.
+*/
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/version"
+	"github.com/anchore/syft/syft/pkg"
+)
+
+const (
+	defaultNPMRegistry = "https://registry.npmjs.org"
+	defaultPyPI        = "https://pypi.org"
+	defaultCratesIO    = "https://crates.io"
+)
+
+// errNotFound is returned when a package (or the version of a package) is not published within a registry.
+var errNotFound = errors.New("package not found within registry")
+
+// Config describes whether (and from where) the details of packages are looked up.
+type Config struct {
+	// Enabled looks up the details of npm, python, and rust packages within their registries (note: this requires
+	// network access)
+	Enabled bool
+	// NPMRegistry is the base URL of the npm registry
+	NPMRegistry string
+	// PyPI is the base URL of the python package index
+	PyPI string
+	// CratesIO is the base URL of the rust crate registry
+	CratesIO string
+	// CacheDirectory is where the details looked up are stored for reuse (nothing is stored when empty)
+	CacheDirectory string
+	// CacheTTL is how long the stored details are used after they are looked up (the details do not expire when zero)
+	CacheTTL time.Duration
+	// Parallelism is the maximum number of packages looked up concurrently
+	Parallelism int
+	// Timeout is how long each request to a registry may take
+	Timeout time.Duration
+}
+
+// DefaultConfig returns the enrichment configuration used when no other configuration is provided.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:     false,
+		NPMRegistry: defaultNPMRegistry,
+		PyPI:        defaultPyPI,
+		CratesIO:    defaultCratesIO,
+		CacheTTL:    7 * 24 * time.Hour,
+		Parallelism: 4,
+		Timeout:     10 * time.Second,
+	}
+}
+
+// registry looks up the details of packages published within the registry of an ecosystem.
+type registry interface {
+	// name identifies the registry within the cache (e.g. "npm")
+	name() string
+	// baseURL is the URL that the registry is reached at
+	baseURL() string
+	// lookup returns the details of the given version of a package, or errNotFound when the version is not published
+	lookup(ctx context.Context, c *client, name, version string) (*pkg.RegistryInfo, error)
+}
+
+// Enricher looks up the details of packages within the registries of their ecosystems.
+type Enricher struct {
+	registries  map[pkg.Type]registry
+	client      *client
+	cache       *cache
+	parallelism int
+}
+
+// New creates an enricher with the given configuration. When the cache directory cannot be used, the details
+// are looked up without being cached.
+func New(cfg Config) *Enricher {
+	var c *cache
+	if cfg.CacheDirectory != "" {
+		var err error
+		c, err = newCache(cfg.CacheDirectory, cfg.CacheTTL)
+		if err != nil {
+			log.Warnf("unable to cache package registry details: %+v", err)
+		}
+	}
+	parallelism := cfg.Parallelism
+	if parallelism < 1 {
+		parallelism = 1
+	}
+	return &Enricher{
+		registries: map[pkg.Type]registry{
+			pkg.NpmPkg:    npmRegistry{url: urlOrDefault(cfg.NPMRegistry, defaultNPMRegistry)},
+			pkg.PythonPkg: pypiRegistry{url: urlOrDefault(cfg.PyPI, defaultPyPI)},
+			pkg.RustPkg:   cratesRegistry{url: urlOrDefault(cfg.CratesIO, defaultCratesIO)},
+		},
+		client:      newClient(cfg.Timeout),
+		cache:       c,
+		parallelism: parallelism,
+	}
+}
+
+// Enrich returns a catalog of the given packages, where the packages published within a supported registry are
+// described by the details looked up from the registry (see pkg.RegistryInfo). Packages that cannot be looked up (or
+// that already have registry details) are left as they are.
+func (e *Enricher) Enrich(catalog *pkg.Catalog) *pkg.Catalog {
+	return e.EnrichWithContext(context.Background(), catalog)
+}
+
+// EnrichWithContext is the same as Enrich, where no more packages are looked up once the given context is done (the
+// packages not looked up are left as they are).
+func (e *Enricher) EnrichWithContext(ctx context.Context, catalog *pkg.Catalog) *pkg.Catalog {
+	packages := catalog.Sorted()
+
+	// each version of a package is looked up once, regardless of how many times the package was found
+	var keys []lookupKey
+	seen := make(map[lookupKey]bool)
+	for _, p := range packages {
+		if key, ok := e.lookupKey(p); ok && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	lookups := e.lookupAll(ctx, keys)
+
+	var found int
+	for i, p := range packages {
+		key, ok := e.lookupKey(p)
+		if !ok || lookups[key] == nil {
+			continue
+		}
+		info := *lookups[key]
+		packages[i].Registry = &info
+		found++
+	}
+	log.Debugf("found registry details for %d of %d packages", found, len(packages))
+
+	return pkg.NewCatalog(packages...)
+}
+
+type lookupKey struct {
+	ty      pkg.Type
+	name    string
+	version string
+}
+
+func (e *Enricher) lookupKey(p pkg.Package) (lookupKey, bool) {
+	if p.Registry != nil || p.Name == "" || p.Version == "" {
+		return lookupKey{}, false
+	}
+	if _, ok := e.registries[p.Type]; !ok {
+		return lookupKey{}, false
+	}
+	return lookupKey{ty: p.Type, name: p.Name, version: p.Version}, true
+}
+
+// lookupAll looks up the details of the given packages concurrently, returning the details found for each package.
+func (e *Enricher) lookupAll(ctx context.Context, keys []lookupKey) map[lookupKey]*pkg.RegistryInfo {
+	results := make(map[lookupKey]*pkg.RegistryInfo)
+	queue := make(chan lookupKey)
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < e.parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for key := range queue {
+				info := e.lookup(ctx, key)
+				lock.Lock()
+				results[key] = info
+				lock.Unlock()
+			}
+		}()
+	}
+queue:
+	for _, key := range keys {
+		select {
+		case queue <- key:
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(queue)
+	wg.Wait()
+	return results
+}
+
+// lookup returns the details of the given package from the cache or the registry, or nil if the package is not
+// published within the registry (or the registry cannot be reached).
+func (e *Enricher) lookup(ctx context.Context, key lookupKey) *pkg.RegistryInfo {
+	r := e.registries[key.ty]
+	if info, ok := e.cache.get(r.name(), key.name, key.version); ok {
+		return info
+	}
+
+	info, err := r.lookup(ctx, e.client, key.name, key.version)
+	switch {
+	case errors.Is(err, errNotFound):
+		log.WithFields("registry", r.name(), "package", key.name, "version", key.version).Trace("package not found within registry")
+		// remember that the package is not published, so the registry is not asked again
+		e.cache.put(r.name(), key.name, key.version, nil)
+		return nil
+	case err != nil:
+		log.WithFields("registry", r.name(), "package", key.name, "version", key.version, "error", err).Debug("unable to look up package within registry")
+		return nil
+	}
+
+	info.Registry = r.baseURL()
+	e.cache.put(r.name(), key.name, key.version, info)
+	return info
+}
+
+func urlOrDefault(u, defaultURL string) string {
+	if u == "" {
+		u = defaultURL
+	}
+	return strings.TrimSuffix(u, "/")
+}
+
+// client makes the requests to the registries.
+type client struct {
+	http      *http.Client
+	userAgent string
+}
+
+func newClient(timeout time.Duration) *client {
+	// note: crates.io rejects requests without a user agent
+	return &client{
+		http:      &http.Client{Timeout: timeout},
+		userAgent: fmt.Sprintf("%s/%s", internal.ApplicationName, version.FromBuild().Version),
+	}
+}
+
+// getJSON requests the given URL, decoding the JSON response into the given value. Returns errNotFound when the
+// registry responds that there is nothing at the URL.
+func (c *client) getJSON(ctx context.Context, u string, into interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", c.userAgent)
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return errNotFound
+	default:
+		return fmt.Errorf("unexpected status from %q: %s", u, resp.Status)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(into); err != nil {
+		return fmt.Errorf("unable to parse response from %q: %w", u, err)
+	}
+	return nil
+}
+
+// publishedTime normalizes the given timestamp of a registry to RFC 3339 (in UTC), or returns an empty string if the
+// timestamp cannot be parsed.
+func publishedTime(value string) string {
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,310 @@
+package enrich
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/source"
+)
+
+const npmPackumentDocument = `{
+  "name": "@actions/core",
+  "versions": {
+    "1.10.0": {
+      "author": "GitHub <opensource@github.com> (https://github.com)",
+      "homepage": "https://github.com/actions/toolkit/tree/main/packages/core",
+      "repository": {"type": "git", "url": "git+https://github.com/actions/toolkit.git"}
+    }
+  },
+  "time": {
+    "1.10.0": "2022-09-29T18:51:06.374Z"
+  }
+}`
+
+const pypiReleaseDocument = `{
+  "info": {
+    "author": "",
+    "maintainer": "",
+    "author_email": "Kenneth Reitz <me@kennethreitz.org>",
+    "home_page": "",
+    "project_urls": {
+      "Homepage": "https://requests.readthedocs.io",
+      "Source": "https://github.com/psf/requests"
+    }
+  },
+  "urls": [
+    {"upload_time_iso_8601": "2022-06-29T15:15:47.071186Z"},
+    {"upload_time_iso_8601": "2022-06-29T15:15:45.254051Z"}
+  ]
+}`
+
+const cratesCrateDocument = `{
+  "crate": {
+    "homepage": "https://serde.rs",
+    "repository": "https://github.com/serde-rs/serde"
+  },
+  "versions": [
+    {"num": "1.0.152", "created_at": "2022-12-26T18:31:19.366148+00:00", "published_by": {"login": "dtolnay", "name": "David Tolnay"}},
+    {"num": "1.0.151", "created_at": "2022-12-16T20:55:02.155932+00:00", "published_by": null}
+  ]
+}`
+
+// registryServer serves the documents of the npm, PyPI, and crates.io registries, counting the requests made.
+type registryServer struct {
+	*httptest.Server
+	lock     sync.Mutex
+	requests map[string]int
+}
+
+func newRegistryServer(t *testing.T) *registryServer {
+	s := &registryServer{requests: make(map[string]int)}
+	documents := map[string]string{
+		"/@actions%2fcore":           npmPackumentDocument,
+		"/pypi/requests/2.28.1/json": pypiReleaseDocument,
+		"/api/v1/crates/serde":       cratesCrateDocument,
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.lock.Lock()
+		s.requests[r.URL.EscapedPath()]++
+		s.lock.Unlock()
+
+		doc, ok := documents[r.URL.EscapedPath()]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(doc))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *registryServer) totalRequests() int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	var total int
+	for _, count := range s.requests {
+		total += count
+	}
+	return total
+}
+
+func testConfig(url, cacheDir string) Config {
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	cfg.NPMRegistry = url
+	cfg.PyPI = url
+	cfg.CratesIO = url
+	cfg.CacheDirectory = cacheDir
+	return cfg
+}
+
+func TestEnricher_Enrich(t *testing.T) {
+	server := newRegistryServer(t)
+
+	tests := []struct {
+		name     string
+		pkg      pkg.Package
+		expected *pkg.RegistryInfo
+	}{
+		{
+			name: "npm package",
+			pkg:  pkg.Package{Name: "@actions/core", Version: "1.10.0", Type: pkg.NpmPkg},
+			expected: &pkg.RegistryInfo{
+				Registry:   server.URL,
+				Supplier:   "GitHub",
+				Homepage:   "https://github.com/actions/toolkit/tree/main/packages/core",
+				Repository: "https://github.com/actions/toolkit.git",
+				Published:  "2022-09-29T18:51:06Z",
+			},
+		},
+		{
+			name:     "npm version not published",
+			pkg:      pkg.Package{Name: "@actions/core", Version: "0.0.1", Type: pkg.NpmPkg},
+			expected: nil,
+		},
+		{
+			name: "python package",
+			pkg:  pkg.Package{Name: "requests", Version: "2.28.1", Type: pkg.PythonPkg},
+			expected: &pkg.RegistryInfo{
+				Registry:   server.URL,
+				Supplier:   "Kenneth Reitz <me@kennethreitz.org>",
+				Homepage:   "https://requests.readthedocs.io",
+				Repository: "https://github.com/psf/requests",
+				Published:  "2022-06-29T15:15:45Z",
+			},
+		},
+		{
+			name: "rust crate",
+			pkg:  pkg.Package{Name: "serde", Version: "1.0.152", Type: pkg.RustPkg},
+			expected: &pkg.RegistryInfo{
+				Registry:   server.URL,
+				Supplier:   "David Tolnay",
+				Homepage:   "https://serde.rs",
+				Repository: "https://github.com/serde-rs/serde",
+				Published:  "2022-12-26T18:31:19Z",
+			},
+		},
+		{
+			name: "rust crate without publisher",
+			pkg:  pkg.Package{Name: "serde", Version: "1.0.151", Type: pkg.RustPkg},
+			expected: &pkg.RegistryInfo{
+				Registry:   server.URL,
+				Homepage:   "https://serde.rs",
+				Repository: "https://github.com/serde-rs/serde",
+				Published:  "2022-12-16T20:55:02Z",
+			},
+		},
+		{
+			name:     "crate not published",
+			pkg:      pkg.Package{Name: "not-a-real-crate", Version: "1.0.0", Type: pkg.RustPkg},
+			expected: nil,
+		},
+		{
+			name:     "unsupported ecosystem",
+			pkg:      pkg.Package{Name: "serde", Version: "1.0.152", Type: pkg.GemPkg},
+			expected: nil,
+		},
+		{
+			name: "already enriched",
+			pkg: pkg.Package{Name: "serde", Version: "1.0.152", Type: pkg.RustPkg, Registry: &pkg.RegistryInfo{
+				Registry: "https://another-registry.io",
+			}},
+			expected: &pkg.RegistryInfo{
+				Registry: "https://another-registry.io",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.pkg.SetID()
+			catalog := New(testConfig(server.URL, "")).Enrich(pkg.NewCatalog(test.pkg))
+			packages := catalog.Sorted()
+			require.Len(t, packages, 1)
+			assert.Equal(t, test.expected, packages[0].Registry)
+			// the identity of the package is unaffected by the details looked up
+			assert.Equal(t, test.pkg.ID(), packages[0].ID())
+			enriched := packages[0]
+			enriched.SetID()
+			assert.Equal(t, test.pkg.ID(), enriched.ID())
+		})
+	}
+}
+
+func TestEnricher_Enrich_LooksUpEachVersionOnce(t *testing.T) {
+	server := newRegistryServer(t)
+
+	// the same version of a crate found twice (as distinct packages)
+	catalog := pkg.NewCatalog(
+		pkg.Package{Name: "serde", Version: "1.0.152", Type: pkg.RustPkg, Locations: source.NewLocationSet(source.NewLocation("/a/Cargo.lock"))},
+		pkg.Package{Name: "serde", Version: "1.0.152", Type: pkg.RustPkg, Locations: source.NewLocationSet(source.NewLocation("/b/Cargo.lock"))},
+	)
+	catalog = New(testConfig(server.URL, "")).Enrich(catalog)
+
+	require.Len(t, catalog.Sorted(), 2)
+	for _, p := range catalog.Sorted() {
+		require.NotNil(t, p.Registry)
+		assert.Equal(t, "David Tolnay", p.Registry.Supplier)
+	}
+	assert.Equal(t, 1, server.totalRequests())
+}
+
+func TestEnricher_EnrichWithContext_Canceled(t *testing.T) {
+	server := newRegistryServer(t)
+	cacheDir := t.TempDir()
+	packages := []pkg.Package{
+		{Name: "requests", Version: "2.28.1", Type: pkg.PythonPkg},
+		{Name: "serde", Version: "1.0.152", Type: pkg.RustPkg},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	catalog := New(testConfig(server.URL, cacheDir)).EnrichWithContext(ctx, pkg.NewCatalog(packages...))
+
+	// the packages are kept without registry details, which are not cached as missing from the registry
+	require.Len(t, catalog.Sorted(), 2)
+	for _, p := range catalog.Sorted() {
+		assert.Nil(t, p.Registry)
+	}
+	assert.Zero(t, server.totalRequests())
+
+	catalog = New(testConfig(server.URL, cacheDir)).Enrich(pkg.NewCatalog(packages...))
+	for _, p := range catalog.Sorted() {
+		assert.NotNil(t, p.Registry)
+	}
+	assert.Equal(t, 2, server.totalRequests())
+}
+
+func TestEnricher_Enrich_Cache(t *testing.T) {
+	server := newRegistryServer(t)
+	cacheDir := t.TempDir()
+	packages := []pkg.Package{
+		{Name: "requests", Version: "2.28.1", Type: pkg.PythonPkg},
+		{Name: "not-a-real-crate", Version: "1.0.0", Type: pkg.RustPkg},
+	}
+
+	first := New(testConfig(server.URL, cacheDir)).Enrich(pkg.NewCatalog(packages...))
+	require.Equal(t, 2, server.totalRequests())
+
+	// both the details found and the packages not found are looked up from the cache
+	second := New(testConfig(server.URL, cacheDir)).Enrich(pkg.NewCatalog(packages...))
+	assert.Equal(t, 2, server.totalRequests())
+
+	var firstInfo, secondInfo []*pkg.RegistryInfo
+	for _, p := range first.Sorted() {
+		firstInfo = append(firstInfo, p.Registry)
+	}
+	for _, p := range second.Sorted() {
+		secondInfo = append(secondInfo, p.Registry)
+	}
+	assert.Equal(t, firstInfo, secondInfo)
+}
+
+func TestCache_Expiry(t *testing.T) {
+	c, err := newCache(t.TempDir(), time.Hour)
+	require.NoError(t, err)
+	now := time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	info := &pkg.RegistryInfo{Registry: "https://pypi.org", Supplier: "someone"}
+	c.put("pypi", "requests", "2.28.1", info)
+
+	got, ok := c.get("pypi", "requests", "2.28.1")
+	require.True(t, ok)
+	assert.Equal(t, info, got)
+
+	_, ok = c.get("pypi", "requests", "2.28.0")
+	assert.False(t, ok)
+
+	now = now.Add(2 * time.Hour)
+	_, ok = c.get("pypi", "requests", "2.28.1")
+	assert.False(t, ok)
+}
+
+func TestNpmPerson_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: `"Jane Doe <jane@example.com> (https://example.com)"`, expected: "Jane Doe"},
+		{input: `"Jane Doe"`, expected: "Jane Doe"},
+		{input: `{"name": "Jane Doe", "email": "jane@example.com"}`, expected: "Jane Doe"},
+		{input: `["not", "a", "person"]`, expected: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			var p npmPerson
+			require.NoError(t, p.UnmarshalJSON([]byte(test.input)))
+			assert.Equal(t, test.expected, p.Name)
+		})
+	}
+}
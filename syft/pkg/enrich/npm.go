@@ -0,0 +1,113 @@
+package enrich
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// npmRegistry looks up packages within an npm registry.
+type npmRegistry struct {
+	url string
+}
+
+// npmPackument is the document describing all published versions of a package.
+type npmPackument struct {
+	Versions map[string]npmVersion `json:"versions"`
+	// Time is when each version was published (keyed by version)
+	Time map[string]string `json:"time"`
+}
+
+// npmVersion is the package.json of a published version of a package.
+type npmVersion struct {
+	Author      npmPerson     `json:"author"`
+	Maintainers []npmPerson   `json:"maintainers"`
+	Homepage    string        `json:"homepage"`
+	Repository  npmRepository `json:"repository"`
+}
+
+// npmPerson is a person field of a package.json, given as either "name <email> (url)" or an object with a name.
+type npmPerson struct {
+	Name string `json:"name"`
+}
+
+func (p *npmPerson) UnmarshalJSON(b []byte) error {
+	var value string
+	if err := json.Unmarshal(b, &value); err == nil {
+		if i := strings.IndexAny(value, "<("); i >= 0 {
+			value = value[:i]
+		}
+		p.Name = strings.TrimSpace(value)
+		return nil
+	}
+
+	type npmPersonObject npmPerson
+	var obj npmPersonObject
+	if err := json.Unmarshal(b, &obj); err != nil {
+		// note: a malformed person field is not reason enough to ignore the rest of the package
+		return nil
+	}
+	*p = npmPerson(obj)
+	return nil
+}
+
+// npmRepository is the repository field of a package.json, given as either a URL or an object with a URL.
+type npmRepository struct {
+	URL string `json:"url"`
+}
+
+func (r *npmRepository) UnmarshalJSON(b []byte) error {
+	var value string
+	if err := json.Unmarshal(b, &value); err == nil {
+		r.URL = value
+		return nil
+	}
+
+	type npmRepositoryObject npmRepository
+	var obj npmRepositoryObject
+	if err := json.Unmarshal(b, &obj); err != nil {
+		return nil
+	}
+	*r = npmRepository(obj)
+	return nil
+}
+
+func (r npmRegistry) name() string {
+	return "npm"
+}
+
+func (r npmRegistry) baseURL() string {
+	return r.url
+}
+
+func (r npmRegistry) lookup(ctx context.Context, c *client, name, version string) (*pkg.RegistryInfo, error) {
+	// scoped package names are requested with an escaped separator (e.g. "@actions%2fcore")
+	var packument npmPackument
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/%s", r.url, strings.Replace(name, "/", "%2f", 1)), &packument); err != nil {
+		return nil, err
+	}
+	v, ok := packument.Versions[version]
+	if !ok {
+		return nil, errNotFound
+	}
+
+	supplier := v.Author.Name
+	if supplier == "" && len(v.Maintainers) > 0 {
+		supplier = v.Maintainers[0].Name
+	}
+	return &pkg.RegistryInfo{
+		Supplier:   supplier,
+		Homepage:   v.Homepage,
+		Repository: repositoryURL(v.Repository.URL),
+		Published:  publishedTime(packument.Time[version]),
+	}, nil
+}
+
+// repositoryURL normalizes the given repository URL of a package manifest (e.g. "git+https://github.com/a/b.git") to
+// the URL of the repository (e.g. "https://github.com/a/b.git").
+func repositoryURL(u string) string {
+	return strings.TrimPrefix(strings.TrimSpace(u), "git+")
+}
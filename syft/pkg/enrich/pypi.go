@@ -0,0 +1,96 @@
+package enrich
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/pkg"
+)
+
+// pypiRegistry looks up packages within a python package index (with the JSON API of PyPI).
+type pypiRegistry struct {
+	url string
+}
+
+// pypiRelease is the document describing a released version of a project.
+type pypiRelease struct {
+	Info struct {
+		Author          string            `json:"author"`
+		AuthorEmail     string            `json:"author_email"`
+		Maintainer      string            `json:"maintainer"`
+		MaintainerEmail string            `json:"maintainer_email"`
+		HomePage        string            `json:"home_page"`
+		ProjectURLs     map[string]string `json:"project_urls"`
+	} `json:"info"`
+	// URLs are the distributions (sdists and wheels) uploaded for the release
+	URLs []struct {
+		UploadTime string `json:"upload_time_iso_8601"`
+	} `json:"urls"`
+}
+
+// pypiRepositoryLabels are the (lowercase) labels of project URLs that link to the source code repository
+var pypiRepositoryLabels = []string{"source", "source code", "repository", "code", "github"}
+
+func (r pypiRegistry) name() string {
+	return "pypi"
+}
+
+func (r pypiRegistry) baseURL() string {
+	return r.url
+}
+
+func (r pypiRegistry) lookup(ctx context.Context, c *client, name, version string) (*pkg.RegistryInfo, error) {
+	var release pypiRelease
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/pypi/%s/%s/json", r.url, url.PathEscape(name), url.PathEscape(version)), &release); err != nil {
+		return nil, err
+	}
+
+	projectURLs := make(map[string]string)
+	for label, u := range release.Info.ProjectURLs {
+		projectURLs[strings.ToLower(label)] = u
+	}
+
+	homepage := release.Info.HomePage
+	if homepage == "" {
+		homepage = projectURLs["homepage"]
+	}
+	var repository string
+	for _, label := range pypiRepositoryLabels {
+		if u, ok := projectURLs[label]; ok {
+			repository = u
+			break
+		}
+	}
+
+	// the distributions of a release may be uploaded at different times, the release is published with the first
+	var uploads []string
+	for _, u := range release.URLs {
+		if t := publishedTime(u.UploadTime); t != "" {
+			uploads = append(uploads, t)
+		}
+	}
+	sort.Strings(uploads)
+	var published string
+	if len(uploads) > 0 {
+		published = uploads[0]
+	}
+
+	return &pkg.RegistryInfo{
+		Supplier:   firstNonEmpty(release.Info.Author, release.Info.Maintainer, release.Info.AuthorEmail, release.Info.MaintainerEmail),
+		Homepage:   homepage,
+		Repository: repositoryURL(repository),
+		Published:  published,
+	}, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
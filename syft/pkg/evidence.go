@@ -0,0 +1,46 @@
+package pkg
+
+import "github.com/anchore/syft/syft/source"
+
+// Evidence describes the data a package was identified from, so that the package can be verified against the source.
+type Evidence struct {
+	// Location is the file that was parsed or matched to identify the package
+	Location source.Coordinates `json:"location" cyclonedx:"location"`
+	// Offset is the position of the first byte of the matched content within the file (only when Length is set)
+	Offset int64 `json:"offset,omitempty" cyclonedx:"offset"`
+	// Length is the number of bytes of matched content, or zero when the package was parsed from the whole file
+	Length int64 `json:"length,omitempty" cyclonedx:"length"`
+	// Rule is the name of the classifier or signature that matched the content (empty when the file was parsed)
+	Rule string `json:"rule,omitempty" cyclonedx:"rule"`
+	// Confidence is how likely the evidence identifies the package, from 0 (unlikely) to 1 (certain)
+	Confidence float64 `json:"confidence" cyclonedx:"confidence"`
+}
+
+// NewFileEvidence returns the evidence of packages parsed from the given files (e.g. a package manager database or a
+// lock file), which identify the package with certainty.
+func NewFileEvidence(locations ...source.Location) []Evidence {
+	var evidence []Evidence
+	for _, l := range locations {
+		evidence = append(evidence, Evidence{
+			Location:   l.Coordinates,
+			Confidence: 1,
+		})
+	}
+	return evidence
+}
+
+func mergeEvidence(evidence []Evidence, others []Evidence) []Evidence {
+	for _, other := range others {
+		exists := false
+		for _, e := range evidence {
+			if e == other {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			evidence = append(evidence, other)
+		}
+	}
+	return evidence
+}
@@ -0,0 +1,23 @@
+package pkg
+
+// VSCodeExtensionMetadata represents an extension installed for Visual Studio Code (or an editor derived from it, such
+// as VSCodium), as described by the package.json of the extension.
+type VSCodeExtensionMetadata struct {
+	Publisher      string `mapstructure:"publisher" json:"publisher" cyclonedx:"publisher"`
+	Name           string `mapstructure:"name" json:"name" cyclonedx:"name"`
+	DisplayName    string `mapstructure:"displayName" json:"displayName,omitempty" cyclonedx:"displayName"`
+	VSCodeVersion  string `mapstructure:"vscodeVersion" json:"vscodeVersion,omitempty" cyclonedx:"vscodeVersion"`    // the constraint on the supported editor versions (e.g. "^1.82.0")
+	TargetPlatform string `mapstructure:"targetPlatform" json:"targetPlatform,omitempty" cyclonedx:"targetPlatform"` // the platform of a platform-specific extension (e.g. "linux-x64")
+	Repository     string `mapstructure:"repository" json:"repository,omitempty" cyclonedx:"repository"`
+}
+
+// BrowserExtensionMetadata represents an extension installed within a browser profile.
+type BrowserExtensionMetadata struct {
+	Browser         string   `mapstructure:"browser" json:"browser" cyclonedx:"browser"` // either "chrome" (including browsers based on Chromium) or "firefox"
+	ID              string   `mapstructure:"id" json:"id" cyclonedx:"id"`                // the extension ID assigned by the browser or extension store (e.g. "cjpalhdlnbpafiamejdnhcphjbkeiagm" or "uBlock0@raymondhill.net")
+	Author          string   `mapstructure:"author" json:"author,omitempty" cyclonedx:"author"`
+	ManifestVersion int      `mapstructure:"manifestVersion" json:"manifestVersion,omitempty" cyclonedx:"manifestVersion"`
+	Permissions     []string `mapstructure:"permissions" json:"permissions,omitempty" cyclonedx:"permissions"`             // the API permissions of the extension (e.g. "tabs" or "webRequest")
+	HostPermissions []string `mapstructure:"hostPermissions" json:"hostPermissions,omitempty" cyclonedx:"hostPermissions"` // the URL match patterns the extension can access (e.g. "<all_urls>")
+	Disabled        bool     `mapstructure:"disabled" json:"disabled,omitempty" cyclonedx:"disabled"`
+}
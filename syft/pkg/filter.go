@@ -0,0 +1,213 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/anchore/syft/syft/artifact"
+)
+
+// FilterField is the package field that a filter expression matches against.
+type FilterField string
+
+const (
+	// NameFilterField matches the package name against a glob (e.g. "name:*-test"), which is the default field.
+	NameFilterField FilterField = "name"
+	// TypeFilterField matches the package type exactly (e.g. "type:npm").
+	TypeFilterField FilterField = "type"
+	// PURLFilterField matches the package URL against a glob (e.g. "purl:pkg:golang/example.com/*").
+	PURLFilterField FilterField = "purl"
+	// LicenseFilterField matches any license of the package (SPDX expression or value) against a glob (e.g. "license:GPL-*").
+	LicenseFilterField FilterField = "license"
+	// PathFilterField matches any location of the package against a path glob (e.g. "path:**/vendor/**").
+	PathFilterField FilterField = "path"
+)
+
+// AllFilterFields are the package fields that filter expressions can match against.
+var AllFilterFields = []FilterField{
+	NameFilterField,
+	TypeFilterField,
+	PURLFilterField,
+	LicenseFilterField,
+	PathFilterField,
+}
+
+// FilterConfig selects the packages kept in the final catalog by expressions of the form "<field>:<pattern>" (see
+// FilterField), where an expression without a field matches the package name.
+type FilterConfig struct {
+	// Include are expressions of the packages to keep (all packages are kept when empty)
+	Include []string
+	// Exclude are expressions of the packages to drop, even when they are included
+	Exclude []string
+	// MinConfidence is the lowest confidence level of the packages to keep (all packages are kept when empty)
+	MinConfidence Confidence
+}
+
+// Filter selects the packages kept in the final catalog (see FilterConfig).
+type Filter struct {
+	include       []filterExpression
+	exclude       []filterExpression
+	minConfidence Confidence
+}
+
+type filterExpression struct {
+	field   FilterField
+	pattern string
+	glob    *regexp.Regexp
+}
+
+// NewFilter parses the expressions of the given configuration, returning nil when there are none.
+func NewFilter(cfg FilterConfig) (*Filter, error) {
+	if len(cfg.Include) == 0 && len(cfg.Exclude) == 0 && cfg.MinConfidence == "" {
+		return nil, nil
+	}
+
+	var err error
+	f := &Filter{minConfidence: cfg.MinConfidence}
+	if f.include, err = parseFilterExpressions(cfg.Include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = parseFilterExpressions(cfg.Exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func parseFilterExpressions(expressions []string) ([]filterExpression, error) {
+	var results []filterExpression
+	for _, expression := range expressions {
+		e, err := parseFilterExpression(expression)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, e)
+	}
+	return results, nil
+}
+
+func parseFilterExpression(expression string) (filterExpression, error) {
+	field, pattern := NameFilterField, strings.TrimSpace(expression)
+	if prefix, rest, ok := strings.Cut(pattern, ":"); ok {
+		field, pattern = FilterField(strings.ToLower(strings.TrimSpace(prefix))), strings.TrimSpace(rest)
+		if !isFilterField(field) {
+			return filterExpression{}, fmt.Errorf("unknown field in package filter %q (options: %v)", expression, AllFilterFields)
+		}
+	}
+	if pattern == "" {
+		return filterExpression{}, fmt.Errorf("package filter %q has no pattern", expression)
+	}
+
+	e := filterExpression{field: field, pattern: pattern}
+	switch field {
+	case PathFilterField:
+		if !doublestar.ValidatePattern(pattern) {
+			return filterExpression{}, fmt.Errorf("invalid path glob in package filter %q", expression)
+		}
+	case TypeFilterField:
+		// types are matched exactly
+	default:
+		e.glob = globPattern(pattern)
+	}
+	return e, nil
+}
+
+func isFilterField(field FilterField) bool {
+	for _, f := range AllFilterFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// globPattern compiles a glob where "*" matches any characters (including "/") and "?" matches any single character.
+func globPattern(glob string) *regexp.Regexp {
+	var sb strings.Builder
+	sb.WriteString("^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			sb.WriteString(".*")
+		case '?':
+			sb.WriteString(".")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.MustCompile(sb.String())
+}
+
+// Keep indicates whether the package is kept by the filter: it must match an include expression (if there are any),
+// must not match any exclude expression, and must have at least the minimum confidence level (if there is one).
+func (f *Filter) Keep(p Package) bool {
+	if f == nil {
+		return true
+	}
+	if f.minConfidence != "" && !p.Confidence.AtLeast(f.minConfidence) {
+		return false
+	}
+	if len(f.include) > 0 && !matchesAnyExpression(p, f.include) {
+		return false
+	}
+	return !matchesAnyExpression(p, f.exclude)
+}
+
+// Apply returns the catalog with only the packages kept by the filter, along with the IDs of the packages removed.
+func (f *Filter) Apply(catalog *Catalog) (*Catalog, map[artifact.ID]struct{}) {
+	removed := make(map[artifact.ID]struct{})
+	if f == nil {
+		return catalog, removed
+	}
+
+	var packages []Package
+	for _, p := range catalog.Sorted() {
+		if !f.Keep(p) {
+			removed[p.ID()] = struct{}{}
+			continue
+		}
+		packages = append(packages, p)
+	}
+	return NewCatalog(packages...), removed
+}
+
+func matchesAnyExpression(p Package, expressions []filterExpression) bool {
+	for _, e := range expressions {
+		if e.matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e filterExpression) matches(p Package) bool {
+	switch e.field {
+	case NameFilterField:
+		return e.glob.MatchString(p.Name)
+	case TypeFilterField:
+		return strings.EqualFold(string(p.Type), e.pattern)
+	case PURLFilterField:
+		return p.PURL != "" && e.glob.MatchString(p.PURL)
+	case LicenseFilterField:
+		for _, l := range p.Licenses {
+			if (l.SPDXExpression != "" && e.glob.MatchString(l.SPDXExpression)) || e.glob.MatchString(l.Value) {
+				return true
+			}
+		}
+	case PathFilterField:
+		for _, l := range p.Locations.ToSlice() {
+			if e.matchesPath(l.RealPath) || (l.VirtualPath != "" && e.matchesPath(l.VirtualPath)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (e filterExpression) matchesPath(path string) bool {
+	matches, err := doublestar.Match(e.pattern, path)
+	return err == nil && matches
+}
@@ -0,0 +1,173 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestFilter_Keep(t *testing.T) {
+	lodash := Package{
+		Name:      "lodash",
+		Version:   "4.17.21",
+		Type:      NpmPkg,
+		PURL:      "pkg:npm/lodash@4.17.21",
+		Licenses:  NewLicensesFromValues("MIT"),
+		Locations: source.NewLocationSet(source.NewLocation("/app/node_modules/lodash/package.json")),
+	}
+	mocha := Package{
+		Name:      "mocha-test-utils",
+		Version:   "1.0.0",
+		Type:      NpmPkg,
+		PURL:      "pkg:npm/%40acme/mocha-test-utils@1.0.0",
+		Licenses:  NewLicensesFromValues("GPL-3.0-only"),
+		Locations: source.NewLocationSet(source.NewLocation("/app/test/node_modules/mocha-test-utils/package.json")),
+	}
+	vendored := Package{
+		Name:      "github.com/pkg/errors",
+		Version:   "v0.9.1",
+		Type:      GoModulePkg,
+		PURL:      "pkg:golang/github.com/pkg/errors@v0.9.1",
+		Locations: source.NewLocationSet(source.NewVirtualLocation("/src/vendor/modules.txt", "/app/vendor/modules.txt")),
+	}
+	all := []Package{lodash, mocha, vendored}
+
+	tests := []struct {
+		name     string
+		cfg      FilterConfig
+		expected []Package
+	}{
+		{
+			name:     "no expressions",
+			expected: all,
+		},
+		{
+			name:     "exclude by name glob",
+			cfg:      FilterConfig{Exclude: []string{"*-test-*"}},
+			expected: []Package{lodash, vendored},
+		},
+		{
+			name:     "exclude by type",
+			cfg:      FilterConfig{Exclude: []string{"type:NPM"}},
+			expected: []Package{vendored},
+		},
+		{
+			name:     "exclude by purl glob across path separators",
+			cfg:      FilterConfig{Exclude: []string{"purl:pkg:npm/%40acme/*"}},
+			expected: []Package{lodash, vendored},
+		},
+		{
+			name:     "exclude by license",
+			cfg:      FilterConfig{Exclude: []string{"license:GPL-*"}},
+			expected: []Package{lodash, vendored},
+		},
+		{
+			name:     "exclude by location path",
+			cfg:      FilterConfig{Exclude: []string{"path:**/test/**"}},
+			expected: []Package{lodash, vendored},
+		},
+		{
+			name:     "exclude by virtual path",
+			cfg:      FilterConfig{Exclude: []string{"path:/app/vendor/**"}},
+			expected: []Package{lodash, mocha},
+		},
+		{
+			name:     "include only",
+			cfg:      FilterConfig{Include: []string{"type:go-module", "name:lodash"}},
+			expected: []Package{lodash, vendored},
+		},
+		{
+			name:     "exclude takes precedence over include",
+			cfg:      FilterConfig{Include: []string{"type:npm"}, Exclude: []string{"path:**/test/**"}},
+			expected: []Package{lodash},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := NewFilter(test.cfg)
+			require.NoError(t, err)
+
+			var kept []Package
+			for _, p := range all {
+				if f.Keep(p) {
+					kept = append(kept, p)
+				}
+			}
+			assert.Equal(t, test.expected, kept)
+		})
+	}
+}
+
+func TestFilter_KeepMinConfidence(t *testing.T) {
+	authoritative := Package{Name: "zlib", Confidence: AuthoritativeConfidence}
+	heuristic := Package{Name: "busybox", Confidence: HeuristicConfidence}
+	unknown := Package{Name: "decoded"}
+
+	f, err := NewFilter(FilterConfig{MinConfidence: AuthoritativeConfidence})
+	require.NoError(t, err)
+	assert.True(t, f.Keep(authoritative))
+	assert.False(t, f.Keep(heuristic))
+	// packages without a confidence level cannot be judged, so are kept
+	assert.True(t, f.Keep(unknown))
+
+	f, err = NewFilter(FilterConfig{MinConfidence: HeuristicConfidence})
+	require.NoError(t, err)
+	assert.True(t, f.Keep(authoritative))
+	assert.True(t, f.Keep(heuristic))
+}
+
+func TestFilter_Apply(t *testing.T) {
+	kept := Package{Name: "kept", Version: "1.0.0", Type: NpmPkg}
+	kept.SetID()
+	removed := Package{Name: "removed", Version: "1.0.0", Type: NpmPkg}
+	removed.SetID()
+
+	f, err := NewFilter(FilterConfig{Exclude: []string{"removed"}})
+	require.NoError(t, err)
+
+	catalog, ids := f.Apply(NewCatalog(kept, removed))
+	assert.Equal(t, 1, catalog.PackageCount())
+	assert.NotNil(t, catalog.Package(kept.ID()))
+	assert.Contains(t, ids, removed.ID())
+	assert.NotContains(t, ids, kept.ID())
+}
+
+func TestNewFilter_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  FilterConfig
+		err  string
+	}{
+		{
+			name: "unknown field",
+			cfg:  FilterConfig{Exclude: []string{"version:1.*"}},
+			err:  "unknown field in package filter",
+		},
+		{
+			name: "no pattern",
+			cfg:  FilterConfig{Include: []string{"type:"}},
+			err:  "has no pattern",
+		},
+		{
+			name: "invalid path glob",
+			cfg:  FilterConfig{Exclude: []string{"path:/app/[vendor"}},
+			err:  "invalid path glob",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewFilter(test.cfg)
+			assert.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestNewFilter_Empty(t *testing.T) {
+	f, err := NewFilter(FilterConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, f)
+	assert.True(t, f.Keep(Package{Name: "anything"}))
+}
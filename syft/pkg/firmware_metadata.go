@@ -0,0 +1,22 @@
+package pkg
+
+// FirmwareMetadata represents all captured data for a component of the boot chain: a UEFI binary (e.g. shim, GRUB, or
+// systemd-boot) identified by its SBAT section, or a CPU microcode update.
+type FirmwareMetadata struct {
+	Format              string      `mapstructure:"format" json:"format" cyclonedx:"format"` // e.g. "uefi", "intel-microcode", or "amd-microcode"
+	Vendor              string      `mapstructure:"vendor" json:"vendor,omitempty" cyclonedx:"vendor"`
+	Date                string      `mapstructure:"date" json:"date,omitempty" cyclonedx:"date"`                                              // the release date of a microcode update (YYYY-MM-DD)
+	ProcessorSignatures []string    `mapstructure:"processorSignatures" json:"processorSignatures,omitempty" cyclonedx:"processorSignatures"` // the CPUID signatures of the processors a microcode update applies to
+	SBAT                []SBATEntry `mapstructure:"sbat" json:"sbat,omitempty" cyclonedx:"sbat"`
+}
+
+// SBATEntry is a single component within the SBAT (UEFI Secure Boot Advanced Targeting) section of a UEFI binary,
+// which identifies the upstream project and the vendor builds of the binary for revocation purposes.
+type SBATEntry struct {
+	ComponentName       string `mapstructure:"componentName" json:"componentName" cyclonedx:"componentName"`
+	ComponentGeneration int    `mapstructure:"componentGeneration" json:"componentGeneration" cyclonedx:"componentGeneration"`
+	VendorName          string `mapstructure:"vendorName" json:"vendorName,omitempty" cyclonedx:"vendorName"`
+	VendorPackageName   string `mapstructure:"vendorPackageName" json:"vendorPackageName,omitempty" cyclonedx:"vendorPackageName"`
+	VendorVersion       string `mapstructure:"vendorVersion" json:"vendorVersion,omitempty" cyclonedx:"vendorVersion"`
+	VendorURL           string `mapstructure:"vendorURL" json:"vendorURL,omitempty" cyclonedx:"vendorURL"`
+}
@@ -1,5 +1,12 @@
 package pkg
 
+const (
+	// GemGitSource indicates that a gem was resolved from a GIT section of a Gemfile.lock
+	GemGitSource = "git"
+	// GemPathSource indicates that a gem was resolved from a PATH section of a Gemfile.lock
+	GemPathSource = "path"
+)
+
 // GemMetadata represents all metadata parsed from the gemspec file
 type GemMetadata struct {
 	Name     string   `mapstructure:"name" json:"name"`
@@ -8,4 +15,8 @@ type GemMetadata struct {
 	Authors  []string `mapstructure:"authors" json:"authors,omitempty"`
 	Licenses []string `mapstructure:"licenses" json:"licenses,omitempty"`
 	Homepage string   `mapstructure:"homepage" json:"homepage,omitempty"`
+	Source   string   `mapstructure:"source" json:"source,omitempty"`     // the kind of non-registry source the gem was resolved from (git or path)
+	Remote   string   `mapstructure:"remote" json:"remote,omitempty"`     // the git repository URL or local path the gem was resolved from
+	Revision string   `mapstructure:"revision" json:"revision,omitempty"` // the git commit the gem was pinned to
+	Ref      string   `mapstructure:"ref" json:"ref,omitempty"`           // the branch, tag, or ref requested in the Gemfile (if any)
 }
@@ -0,0 +1,8 @@
+package pkg
+
+// GitHubActionsUseMetadata represents a single "uses" statement of a GitHub Actions workflow or composite action,
+// referencing an action or a reusable workflow.
+type GitHubActionsUseMetadata struct {
+	Value   string `mapstructure:"value" json:"value" cyclonedx:"value"`                 // the statement as written (e.g. "actions/checkout@v4")
+	Comment string `mapstructure:"comment" json:"comment,omitempty" cyclonedx:"comment"` // the comment on the statement, which conventionally holds the version of a ref pinned to a commit (e.g. "v4.1.1")
+}
@@ -0,0 +1,25 @@
+package pkg
+
+// HelmChartMetadata represents all captured data for a Helm chart, as described by its Chart.yaml (or, for the
+// dependencies of a chart, by the Chart.lock of the depending chart).
+type HelmChartMetadata struct {
+	Name        string           `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Version     string           `mapstructure:"version" json:"version" cyclonedx:"version"`
+	AppVersion  string           `mapstructure:"appVersion" json:"appVersion,omitempty" cyclonedx:"appVersion"` // the version of the application deployed by the chart
+	APIVersion  string           `mapstructure:"apiVersion" json:"apiVersion,omitempty" cyclonedx:"apiVersion"` // "v1" (Helm 2) or "v2" (Helm 3)
+	Type        string           `mapstructure:"type" json:"type,omitempty" cyclonedx:"type"`                   // either "application" or "library"
+	Description string           `mapstructure:"description" json:"description,omitempty" cyclonedx:"description"`
+	Home        string           `mapstructure:"home" json:"home,omitempty" cyclonedx:"home"`
+	Sources     []string         `mapstructure:"sources" json:"sources,omitempty" cyclonedx:"sources"`
+	KubeVersion string           `mapstructure:"kubeVersion" json:"kubeVersion,omitempty" cyclonedx:"kubeVersion"` // the constraint on the supported Kubernetes versions
+	Maintainers []HelmMaintainer `mapstructure:"maintainers" json:"maintainers,omitempty" cyclonedx:"maintainers"`
+	Repository  string           `mapstructure:"repository" json:"repository,omitempty" cyclonedx:"repository"` // the repository a dependency is fetched from (e.g. "https://charts.bitnami.com/bitnami" or "oci://registry-1.docker.io/bitnamicharts")
+	Images      []string         `mapstructure:"images" json:"images,omitempty" cyclonedx:"images"`             // the container images referenced by the default values of the chart
+}
+
+// HelmMaintainer is a single maintainer of a Helm chart.
+type HelmMaintainer struct {
+	Name  string `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Email string `mapstructure:"email" json:"email,omitempty" cyclonedx:"email"`
+	URL   string `mapstructure:"url" json:"url,omitempty" cyclonedx:"url"`
+}
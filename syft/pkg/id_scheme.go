@@ -0,0 +1,87 @@
+package pkg
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+// IDScheme is a version of the algorithm that derives package IDs from the package fields. Package IDs are only
+// stable across syft releases while the scheme (and the fields participating in it) are unchanged, so consumers that
+// compare package IDs across SBOMs (e.g. when diffing SBOMs or verifying attestations) can pin the scheme.
+type IDScheme string
+
+const (
+	// IDSchemeV1 hashes all package fields that are not tagged with `hash:"ignore"`: the name, version, real paths of
+	// the locations, license values, language, type, metadata type, and metadata. The ID changes whenever any of these
+	// change, including when a cataloger records additional metadata.
+	IDSchemeV1 IDScheme = "v1"
+	// IDSchemeV2 hashes a fixed set of fields: the name, version, type, pURL (in canonical form), and real paths of the
+	// locations. The metadata and licenses do not participate, so the ID does not change when a cataloger records
+	// additional details about a package.
+	IDSchemeV2 IDScheme = "v2"
+)
+
+// DefaultIDScheme is the scheme used by SetID.
+const DefaultIDScheme = IDSchemeV1
+
+// AllIDSchemes are the supported schemes for deriving package IDs.
+var AllIDSchemes = []IDScheme{
+	IDSchemeV1,
+	IDSchemeV2,
+}
+
+// ParseIDScheme returns the package ID scheme described by the given string.
+func ParseIDScheme(s string) (IDScheme, error) {
+	for _, scheme := range AllIDSchemes {
+		if strings.EqualFold(s, string(scheme)) {
+			return scheme, nil
+		}
+	}
+	return "", fmt.Errorf("unknown package ID scheme: %q (options: %v)", s, AllIDSchemes)
+}
+
+// idV2 are the package fields participating in the ID of IDSchemeV2. Fields must not be added to (or removed from)
+// this struct, since that changes the IDs of the scheme; instead a new scheme should be added.
+type idV2 struct {
+	Name      string
+	Version   string
+	Type      Type
+	PURL      string
+	Locations source.LocationSet
+}
+
+// SetIDWithScheme sets the ID of the package as derived by the given scheme (the default scheme when empty).
+func (p *Package) SetIDWithScheme(scheme IDScheme) {
+	var (
+		id  artifact.ID
+		err error
+	)
+	switch scheme {
+	case IDSchemeV1, "":
+		id, err = artifact.IDByHash(p)
+	case IDSchemeV2:
+		purl := p.PURL
+		if canonical, _, purlErr := canonicalPURL(p.PURL); purlErr == nil {
+			purl = canonical
+		}
+		id, err = artifact.IDByHash(idV2{
+			Name:      p.Name,
+			Version:   p.Version,
+			Type:      p.Type,
+			PURL:      purl,
+			Locations: p.Locations,
+		})
+	default:
+		err = fmt.Errorf("unknown package ID scheme: %q", scheme)
+	}
+	if err != nil {
+		// TODO: what to do in this case?
+		log.Warnf("unable to get fingerprint of package=%s@%s: %+v", p.Name, p.Version, err)
+		return
+	}
+	p.id = id
+}
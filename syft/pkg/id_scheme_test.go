@@ -0,0 +1,103 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestPackage_SetIDWithScheme(t *testing.T) {
+	newPackage := func() Package {
+		return Package{
+			Name:         "libc6",
+			Version:      "2.31",
+			Type:         DebPkg,
+			PURL:         "pkg:deb/debian/libc6@2.31?arch=amd64&distro=debian-11",
+			Locations:    source.NewLocationSet(source.NewLocation("/var/lib/dpkg/status")),
+			Licenses:     NewLicensesFromValues("LGPL-2.1"),
+			MetadataType: DpkgMetadataType,
+			Metadata:     DpkgMetadata{Package: "libc6", Version: "2.31", Architecture: "amd64"},
+		}
+	}
+
+	// the default scheme is the scheme of SetID
+	p := newPackage()
+	p.SetID()
+	v1 := p.ID()
+	p.SetIDWithScheme(IDSchemeV1)
+	assert.Equal(t, v1, p.ID())
+	p.SetIDWithScheme("")
+	assert.Equal(t, v1, p.ID())
+
+	p.SetIDWithScheme(IDSchemeV2)
+	v2 := p.ID()
+	require.NotEmpty(t, v2)
+	assert.NotEqual(t, v1, v2)
+
+	tests := []struct {
+		name     string
+		modify   func(p *Package)
+		v1Stable bool
+		v2Stable bool
+	}{
+		{
+			name: "metadata recorded by a cataloger",
+			modify: func(p *Package) {
+				p.Metadata = DpkgMetadata{Package: "libc6", Version: "2.31", Architecture: "amd64", Maintainer: "GNU Libc Maintainers"}
+			},
+			v2Stable: true,
+		},
+		{
+			name: "licenses",
+			modify: func(p *Package) {
+				p.Licenses = NewLicensesFromValues("LGPL-2.1", "GPL-2.0")
+			},
+			v2Stable: true,
+		},
+		{
+			name: "order of the pURL qualifiers",
+			modify: func(p *Package) {
+				p.PURL = "pkg:deb/debian/libc6@2.31?distro=debian-11&arch=amd64"
+			},
+			v1Stable: true,
+			v2Stable: true,
+		},
+		{
+			name: "pURL",
+			modify: func(p *Package) {
+				p.PURL = "pkg:deb/debian/libc6@2.31?arch=i386&distro=debian-11"
+			},
+			v1Stable: true,
+		},
+		{
+			name: "version",
+			modify: func(p *Package) {
+				p.Version = "2.32"
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := newPackage()
+			test.modify(&p)
+
+			p.SetIDWithScheme(IDSchemeV1)
+			assert.Equal(t, test.v1Stable, p.ID() == v1, "v1 ID stability")
+
+			p.SetIDWithScheme(IDSchemeV2)
+			assert.Equal(t, test.v2Stable, p.ID() == v2, "v2 ID stability")
+		})
+	}
+}
+
+func TestParseIDScheme(t *testing.T) {
+	scheme, err := ParseIDScheme("V2")
+	require.NoError(t, err)
+	assert.Equal(t, IDSchemeV2, scheme)
+
+	_, err = ParseIDScheme("v0")
+	assert.Error(t, err)
+}
@@ -27,6 +27,9 @@ type JavaMetadata struct {
 	ArchiveDigests []file.Digest  `hash:"ignore" json:"digest,omitempty"`
 	PURL           string         `hash:"ignore" json:"-"` // pURLs and CPEs are ignored for package IDs
 	Parent         *Package       `hash:"ignore" json:"-"` // note: the parent cannot be included in the minimal definition of uniqueness since this field is not reproducible in an encode-decode cycle (is lossy).
+	// Shaded is whether the package was bundled into the classes of its parent archive (e.g. a shaded or uber jar)
+	// instead of as a nested archive of its own
+	Shaded bool `hash:"ignore" json:"shaded,omitempty"`
 }
 
 // PomProperties represents the fields of interest extracted from a Java archive's pom.properties file.
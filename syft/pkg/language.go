@@ -4,6 +4,7 @@ import (
 	"strings"
 
 	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 // Language represents a single programming language.
@@ -48,12 +49,12 @@ func (l Language) String() string {
 }
 
 func LanguageFromPURL(p string) Language {
-	purl, err := packageurl.FromString(p)
+	pURL, err := packageurl.FromString(p)
 	if err != nil {
 		return UnknownLanguage
 	}
 
-	return LanguageByName(purl.Type)
+	return LanguageByName(pURL.Type)
 }
 
 func LanguageByName(name string) Language {
@@ -70,11 +71,11 @@ func LanguageByName(name string) Language {
 		return Python
 	case packageurl.TypeGem, string(Ruby):
 		return Ruby
-	case purlCargoPkgType, string(RustPkg), string(Rust):
+	case purl.TypeCargo, string(RustPkg), string(Rust):
 		return Rust
 	case packageurl.TypePub, string(DartPubPkg), string(Dart):
 		return Dart
-	case packageurl.TypeDotnet:
+	case purl.TypeNuGet, packageurl.TypeDotnet:
 		return Dotnet
 	case packageurl.TypeCocoapods, packageurl.TypeSwift, string(CocoapodsPkg):
 		return Swift
@@ -38,6 +38,10 @@ func TestLanguageFromPURL(t *testing.T) {
 			purl: "pkg:dotnet/Microsoft.CodeAnalysis.Razor@2.2.0",
 			want: Dotnet,
 		},
+		{
+			purl: "pkg:nuget/Microsoft.CodeAnalysis.Razor@2.2.0",
+			want: Dotnet,
+		},
 		{
 			purl: "pkg:cargo/clap@2.33.0",
 			want: Rust,
@@ -74,7 +78,7 @@ func TestLanguageFromPURL(t *testing.T) {
 		t.Run(tt.purl, func(t *testing.T) {
 			actual := LanguageFromPURL(tt.purl)
 
-			if actual != "" {
+			if actual != "" && !contains(languages, string(actual)) {
 				languages = append(languages, string(actual))
 			}
 
@@ -0,0 +1,93 @@
+package pkg
+
+import (
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+// LayerAttribution describes the container image layer that introduced a package.
+type LayerAttribution struct {
+	Digest    string `json:"digest"`              // the layer digest (the same as the layerID of locations within the layer)
+	Index     int    `json:"index"`               // the position of the layer within the image, where 0 is the lowest layer
+	BaseImage bool   `json:"baseImage,omitempty"` // the layer is provided by the base image the image was built from
+}
+
+// AttributeLayers records the image layer that introduced each package, given the layers of the image ordered from the
+// base layer up. For packages that own files (e.g. dpkg, rpm, and apk packages) this is the lowest layer providing any of
+// the files owned by the package, since the shared package database is rewritten whenever any package is installed.
+// Otherwise, this is the lowest layer providing any of the locations the package was discovered by. A catalog of the
+// attributed packages is returned along with relationships from each layer to the packages it introduced.
+func AttributeLayers(catalog *Catalog, layers []source.LayerMetadata, relationships []artifact.Relationship) (*Catalog, []artifact.Relationship) {
+	indexes := make(map[string]int, len(layers))
+	for idx, layer := range layers {
+		indexes[layer.Digest] = idx
+	}
+
+	graph := artifact.NewGraph(relationships...)
+
+	var packages []Package
+	var layerRelationships []artifact.Relationship
+	for _, p := range catalog.Sorted() {
+		var ownedFiles []source.Coordinates
+		for _, r := range graph.From(p.ID(), artifact.ContainsRelationship) {
+			if coordinates, ok := r.To.(source.Coordinates); ok {
+				ownedFiles = append(ownedFiles, coordinates)
+			}
+		}
+
+		idx, ok := lowestLayer(ownedFiles, indexes)
+		if !ok {
+			idx, ok = lowestLayer(p.Locations.CoordinateSet().ToSlice(), indexes)
+		}
+		if ok {
+			p.Layer = &LayerAttribution{
+				Digest: layers[idx].Digest,
+				Index:  idx,
+			}
+			layerRelationships = append(layerRelationships, artifact.Relationship{
+				From: layers[idx],
+				To:   p,
+				Type: artifact.IntroducesRelationship,
+			})
+		}
+		packages = append(packages, p)
+	}
+
+	return NewCatalog(packages...), layerRelationships
+}
+
+func lowestLayer(coordinates []source.Coordinates, indexes map[string]int) (int, bool) {
+	lowest, found := 0, false
+	for _, c := range coordinates {
+		idx, ok := indexes[c.FileSystemID]
+		if !ok {
+			continue
+		}
+		if !found || idx < lowest {
+			lowest, found = idx, true
+		}
+	}
+	return lowest, found
+}
+
+// AttributeBaseImage marks the packages introduced by the given number of base image layers (from the bottom of the
+// image) as part of the base image. When excluding the base image, the base image packages are removed from the
+// returned catalog instead, and the IDs of the removed packages are returned.
+func AttributeBaseImage(catalog *Catalog, baseLayers int, exclude bool) (*Catalog, map[artifact.ID]struct{}) {
+	var packages []Package
+	excluded := make(map[artifact.ID]struct{})
+	for _, p := range catalog.Sorted() {
+		if p.Layer != nil && p.Layer.Index < baseLayers {
+			if exclude {
+				excluded[p.ID()] = struct{}{}
+				continue
+			}
+			layer := *p.Layer
+			layer.BaseImage = true
+			p.Layer = &layer
+		}
+		packages = append(packages, p)
+	}
+
+	return NewCatalog(packages...), excluded
+}
@@ -0,0 +1,127 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestAttributeLayers(t *testing.T) {
+	layers := []source.LayerMetadata{
+		{Digest: "sha256:base"},
+		{Digest: "sha256:packages"},
+		{Digest: "sha256:app"},
+	}
+
+	// the package database was rewritten when another package was installed, but the files are from the base layer
+	dpkg := Package{
+		Name:      "libc6",
+		Version:   "2.31-13",
+		Type:      DebPkg,
+		Locations: source.NewLocationSet(source.NewLocationFromCoordinates(source.Coordinates{RealPath: "/var/lib/dpkg/status", FileSystemID: "sha256:packages"})),
+	}
+	dpkg.SetID()
+
+	jar := Package{
+		Name:    "log4j-core",
+		Version: "2.14.1",
+		Type:    JavaPkg,
+		Locations: source.NewLocationSet(
+			source.NewLocationFromCoordinates(source.Coordinates{RealPath: "/app/lib/log4j-core-2.14.1.jar", FileSystemID: "sha256:app"}),
+		),
+	}
+	jar.SetID()
+
+	unknown := Package{
+		Name:      "not-in-an-image",
+		Version:   "1.0.0",
+		Type:      NpmPkg,
+		Locations: source.NewLocationSet(source.NewLocation("/package.json")),
+	}
+	unknown.SetID()
+
+	relationships := []artifact.Relationship{
+		{
+			From: dpkg,
+			To:   source.Coordinates{RealPath: "/lib/x86_64-linux-gnu/libc.so.6", FileSystemID: "sha256:base"},
+			Type: artifact.ContainsRelationship,
+		},
+		{
+			From: dpkg,
+			To:   source.Coordinates{RealPath: "/etc/ld.so.conf", FileSystemID: "sha256:app"},
+			Type: artifact.ContainsRelationship,
+		},
+	}
+
+	catalog, layerRelationships := AttributeLayers(NewCatalog(dpkg, jar, unknown), layers, relationships)
+
+	expected := map[string]*LayerAttribution{
+		"libc6":           {Digest: "sha256:base", Index: 0},
+		"log4j-core":      {Digest: "sha256:app", Index: 2},
+		"not-in-an-image": nil,
+	}
+	require.Equal(t, 3, catalog.PackageCount())
+	for p := range catalog.Enumerate() {
+		assert.Equal(t, expected[p.Name], p.Layer, p.Name)
+	}
+
+	var introduced []string
+	for _, r := range layerRelationships {
+		assert.Equal(t, artifact.IntroducesRelationship, r.Type)
+		p, ok := r.To.(Package)
+		require.True(t, ok)
+		introduced = append(introduced, string(r.From.ID())+" "+p.Name)
+	}
+	assert.ElementsMatch(t, []string{"sha256:base libc6", "sha256:app log4j-core"}, introduced)
+}
+
+func TestAttributeBaseImage(t *testing.T) {
+	base := Package{
+		Name:    "musl",
+		Version: "1.2.3-r4",
+		Type:    ApkPkg,
+		Layer:   &LayerAttribution{Digest: "sha256:base", Index: 0},
+	}
+	base.SetID()
+
+	app := Package{
+		Name:    "express",
+		Version: "4.18.2",
+		Type:    NpmPkg,
+		Layer:   &LayerAttribution{Digest: "sha256:app", Index: 1},
+	}
+	app.SetID()
+
+	unattributed := Package{
+		Name:    "unattributed",
+		Version: "1.0.0",
+		Type:    NpmPkg,
+	}
+	unattributed.SetID()
+
+	t.Run("mark base image packages", func(t *testing.T) {
+		catalog, excluded := AttributeBaseImage(NewCatalog(base, app, unattributed), 1, false)
+		assert.Empty(t, excluded)
+		require.Equal(t, 3, catalog.PackageCount())
+
+		assert.True(t, catalog.Package(base.ID()).Layer.BaseImage)
+		assert.False(t, catalog.Package(app.ID()).Layer.BaseImage)
+		assert.Nil(t, catalog.Package(unattributed.ID()).Layer)
+		// the original package is not modified
+		assert.False(t, base.Layer.BaseImage)
+	})
+
+	t.Run("exclude base image packages", func(t *testing.T) {
+		catalog, excluded := AttributeBaseImage(NewCatalog(base, app, unattributed), 1, true)
+		assert.Equal(t, map[artifact.ID]struct{}{base.ID(): {}}, excluded)
+		require.Equal(t, 2, catalog.PackageCount())
+
+		assert.Nil(t, catalog.Package(base.ID()))
+		assert.False(t, catalog.Package(app.ID()).Layer.BaseImage)
+		assert.NotNil(t, catalog.Package(unattributed.ID()))
+	})
+}
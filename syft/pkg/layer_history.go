@@ -0,0 +1,122 @@
+package pkg
+
+import (
+	"sort"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+// LayerChangeType describes how a package changed within an image layer.
+type LayerChangeType string
+
+const (
+	// AddedLayerChange indicates the package was introduced by the layer.
+	AddedLayerChange LayerChangeType = "added"
+	// RemovedLayerChange indicates the package was removed by the layer.
+	RemovedLayerChange LayerChangeType = "removed"
+	// UpgradedLayerChange indicates the layer replaced the package with a different version (usually an upgrade).
+	UpgradedLayerChange LayerChangeType = "upgraded"
+)
+
+// LayerChange describes a single change to a package within an image layer.
+type LayerChange struct {
+	Type            LayerChangeType
+	Name            string
+	Version         string // the version of the package after the change (or the version removed)
+	PreviousVersion string // the version of the package before an upgrade
+	PackageType     Type
+	PURL            string
+}
+
+// LayerHistory describes the packages changed by an image layer.
+type LayerHistory struct {
+	Digest  string
+	Index   int
+	Changes []LayerChange
+}
+
+// NewLayerHistory returns the package changes made by each image layer, given the catalog of packages visible from
+// each layer (the squashed representation of the layer and all layers below it), ordered from the lowest layer up.
+func NewLayerHistory(layers []source.LayerMetadata, catalogs []*Catalog) []LayerHistory {
+	history := make([]LayerHistory, 0, len(catalogs))
+	previous := make(map[string]Package)
+	for idx, catalog := range catalogs {
+		current := layerPackages(catalog)
+
+		var changes []LayerChange
+		for key, p := range current {
+			before, ok := previous[key]
+			switch {
+			case !ok:
+				changes = append(changes, newLayerChange(AddedLayerChange, p))
+			case before.Version != p.Version:
+				change := newLayerChange(UpgradedLayerChange, p)
+				change.PreviousVersion = before.Version
+				changes = append(changes, change)
+			}
+		}
+		for key, p := range previous {
+			if _, ok := current[key]; !ok {
+				changes = append(changes, newLayerChange(RemovedLayerChange, p))
+			}
+		}
+		sortLayerChanges(changes)
+
+		var digest string
+		if idx < len(layers) {
+			digest = layers[idx].Digest
+		}
+		history = append(history, LayerHistory{
+			Digest:  digest,
+			Index:   idx,
+			Changes: changes,
+		})
+		previous = current
+	}
+	return history
+}
+
+// layerPackages indexes the packages of a catalog by identity across layers: the package type, name, and the path of
+// the package (e.g. the package database or manifest) regardless of the layer the path was found in.
+func layerPackages(catalog *Catalog) map[string]Package {
+	packages := make(map[string]Package)
+	if catalog == nil {
+		return packages
+	}
+	for _, p := range catalog.Sorted() {
+		var path string
+		if locations := p.Locations.ToSlice(); len(locations) > 0 {
+			path = locations[0].RealPath
+		}
+		key := string(p.Type) + ":" + p.Name + ":" + path
+		if _, exists := packages[key]; !exists {
+			packages[key] = p
+		}
+	}
+	return packages
+}
+
+func newLayerChange(ty LayerChangeType, p Package) LayerChange {
+	return LayerChange{
+		Type:        ty,
+		Name:        p.Name,
+		Version:     p.Version,
+		PackageType: p.Type,
+		PURL:        p.PURL,
+	}
+}
+
+func sortLayerChanges(changes []LayerChange) {
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].PackageType != changes[j].PackageType {
+			return changes[i].PackageType < changes[j].PackageType
+		}
+		if changes[i].Name != changes[j].Name {
+			return changes[i].Name < changes[j].Name
+		}
+		if changes[i].Type != changes[j].Type {
+			return changes[i].Type < changes[j].Type
+		}
+		return changes[i].Version < changes[j].Version
+	})
+}
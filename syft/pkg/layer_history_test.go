@@ -0,0 +1,94 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestNewLayerHistory(t *testing.T) {
+	layers := []source.LayerMetadata{
+		{Digest: "sha256:base"},
+		{Digest: "sha256:update"},
+		{Digest: "sha256:app"},
+	}
+
+	newPackage := func(name, version, path, layer string) Package {
+		return Package{
+			Name:      name,
+			Version:   version,
+			Type:      DebPkg,
+			Locations: source.NewLocationSet(source.NewLocationFromCoordinates(source.Coordinates{RealPath: path, FileSystemID: layer})),
+		}
+	}
+
+	catalogs := []*Catalog{
+		NewCatalog(
+			newPackage("libc6", "2.31-13", "/var/lib/dpkg/status", "sha256:base"),
+			newPackage("openssl", "1.1.1n-0", "/var/lib/dpkg/status", "sha256:base"),
+			newPackage("curl", "7.74.0-1", "/var/lib/dpkg/status", "sha256:base"),
+		),
+		NewCatalog(
+			// the package database is rewritten, but only openssl was upgraded and curl removed
+			newPackage("libc6", "2.31-13", "/var/lib/dpkg/status", "sha256:update"),
+			newPackage("openssl", "1.1.1n-0+deb11u4", "/var/lib/dpkg/status", "sha256:update"),
+		),
+		NewCatalog(
+			newPackage("libc6", "2.31-13", "/var/lib/dpkg/status", "sha256:update"),
+			newPackage("openssl", "1.1.1n-0+deb11u4", "/var/lib/dpkg/status", "sha256:update"),
+			Package{
+				Name:      "express",
+				Version:   "4.18.2",
+				Type:      NpmPkg,
+				PURL:      "pkg:npm/express@4.18.2",
+				Locations: source.NewLocationSet(source.NewLocationFromCoordinates(source.Coordinates{RealPath: "/app/node_modules/express/package.json", FileSystemID: "sha256:app"})),
+			},
+		),
+	}
+
+	expected := []LayerHistory{
+		{
+			Digest: "sha256:base",
+			Index:  0,
+			Changes: []LayerChange{
+				{Type: AddedLayerChange, Name: "curl", Version: "7.74.0-1", PackageType: DebPkg},
+				{Type: AddedLayerChange, Name: "libc6", Version: "2.31-13", PackageType: DebPkg},
+				{Type: AddedLayerChange, Name: "openssl", Version: "1.1.1n-0", PackageType: DebPkg},
+			},
+		},
+		{
+			Digest: "sha256:update",
+			Index:  1,
+			Changes: []LayerChange{
+				{Type: RemovedLayerChange, Name: "curl", Version: "7.74.0-1", PackageType: DebPkg},
+				{Type: UpgradedLayerChange, Name: "openssl", Version: "1.1.1n-0+deb11u4", PreviousVersion: "1.1.1n-0", PackageType: DebPkg},
+			},
+		},
+		{
+			Digest: "sha256:app",
+			Index:  2,
+			Changes: []LayerChange{
+				{Type: AddedLayerChange, Name: "express", Version: "4.18.2", PackageType: NpmPkg, PURL: "pkg:npm/express@4.18.2"},
+			},
+		},
+	}
+
+	assert.Equal(t, expected, NewLayerHistory(layers, catalogs))
+}
+
+func TestNewLayerHistory_NoChanges(t *testing.T) {
+	layers := []source.LayerMetadata{
+		{Digest: "sha256:base"},
+		{Digest: "sha256:config"},
+	}
+
+	p := Package{Name: "musl", Version: "1.2.3-r4", Type: ApkPkg, Locations: source.NewLocationSet(source.NewLocation("/lib/apk/db/installed"))}
+	history := NewLayerHistory(layers, []*Catalog{NewCatalog(p), NewCatalog(p)})
+
+	assert.Len(t, history, 2)
+	assert.Len(t, history[0].Changes, 1)
+	assert.Empty(t, history[1].Changes)
+	assert.Equal(t, "sha256:config", history[1].Digest)
+}
@@ -0,0 +1,144 @@
+package pkg
+
+import (
+	"strings"
+
+	"github.com/mitchellh/hashstructure/v2"
+
+	"github.com/anchore/syft/internal/spdxlicense"
+	"github.com/anchore/syft/syft/source"
+)
+
+// LicenseType describes how a license of a package was determined.
+type LicenseType string
+
+const (
+	// DeclaredLicense is a license stated by the package authors within the package metadata.
+	DeclaredLicense LicenseType = "declared"
+	// ConcludedLicense is a license concluded from analyzing the package contents (e.g. the text of license files).
+	ConcludedLicense LicenseType = "concluded"
+)
+
+// License is a single license of a package along with where (and how) it was found.
+type License struct {
+	Value          string              `json:"value"`                    // the license as found (e.g. "GPLv2+" or "Apache License 2.0")
+	SPDXExpression string              `json:"spdxExpression,omitempty"` // the normalized SPDX license expression of the value (empty if the value is not a valid expression)
+	Type           LicenseType         `json:"type"`                     // whether the license was declared by the package or concluded from its contents
+	URL            string              `json:"url,omitempty"`            // where the license text can be found, as referenced by the package metadata
+	Location       *source.Coordinates `json:"location,omitempty"`       // the file the license was found in
+	Confidence     float64             `json:"confidence,omitempty"`     // how closely the file content matches the license text, from 0 (no match) to 1 (exact match) (concluded licenses only)
+}
+
+// NewLicense creates a declared license from the given value, deriving the SPDX expression when possible.
+func NewLicense(value string) License {
+	value = strings.TrimSpace(value)
+	expression, err := spdxlicense.NormalizeExpression(value)
+	if err != nil {
+		expression = ""
+	}
+	return License{
+		Value:          value,
+		SPDXExpression: expression,
+		Type:           DeclaredLicense,
+	}
+}
+
+// NewLicenseFromURL creates a declared license from the given value with a reference to the license text.
+func NewLicenseFromURL(value, url string) License {
+	l := NewLicense(value)
+	l.URL = strings.TrimSpace(url)
+	return l
+}
+
+// NewLicenseFromLocation creates a declared license from the given value as found within the given file.
+func NewLicenseFromLocation(value string, location source.Location) License {
+	l := NewLicense(value)
+	coordinates := location.Coordinates
+	l.Location = &coordinates
+	return l
+}
+
+// NewLicensesFromValues creates declared licenses from the given values, ignoring empty values.
+func NewLicensesFromValues(values ...string) []License {
+	var licenses []License
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		licenses = append(licenses, NewLicense(v))
+	}
+	return licenses
+}
+
+// NewLicensesFromLocation creates declared licenses from the given values as found within the given file, ignoring
+// empty values.
+func NewLicensesFromLocation(location source.Location, values ...string) []License {
+	var licenses []License
+	for _, v := range values {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		licenses = append(licenses, NewLicenseFromLocation(v, location))
+	}
+	return licenses
+}
+
+// Hash returns the fingerprint of the license value alone (see hashstructure.Hashable), so that the package ID does not
+// depend on where the license was found and matches the package ID from when licenses were plain strings.
+func (l License) Hash() (uint64, error) {
+	return hashstructure.Hash(l.Value, hashstructure.FormatV2, nil)
+}
+
+// DeclaredLicenses returns the licenses stated within the package metadata.
+func (p Package) DeclaredLicenses() []License {
+	return p.licensesOfType(DeclaredLicense)
+}
+
+// ConcludedLicenses returns the licenses concluded from analyzing the package contents (e.g. license files).
+func (p Package) ConcludedLicenses() []License {
+	return p.licensesOfType(ConcludedLicense)
+}
+
+// LicenseValues returns the distinct values of the given licenses, in order.
+func LicenseValues(licenses []License) []string {
+	var values []string
+	seen := make(map[string]struct{})
+	for _, l := range licenses {
+		if _, exists := seen[l.Value]; exists {
+			continue
+		}
+		seen[l.Value] = struct{}{}
+		values = append(values, l.Value)
+	}
+	return values
+}
+
+func (p Package) licensesOfType(licenseType LicenseType) []License {
+	var licenses []License
+	for _, l := range p.Licenses {
+		if l.Type == licenseType {
+			licenses = append(licenses, l)
+		}
+	}
+	return licenses
+}
+
+func (l License) equals(other License) bool {
+	return l.Value == other.Value && l.Type == other.Type
+}
+
+func mergeLicenses(licenses []License, others []License) []License {
+	for _, other := range others {
+		exists := false
+		for _, l := range licenses {
+			if l.equals(other) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			licenses = append(licenses, other)
+		}
+	}
+	return licenses
+}
@@ -0,0 +1,82 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/mitchellh/hashstructure/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/source"
+)
+
+func TestNewLicense(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected License
+	}{
+		{
+			value: "MIT",
+			expected: License{
+				Value:          "MIT",
+				SPDXExpression: "MIT",
+				Type:           DeclaredLicense,
+			},
+		},
+		{
+			value: " GPLv2+ ",
+			expected: License{
+				Value:          "GPLv2+",
+				SPDXExpression: "GPL-2.0-or-later",
+				Type:           DeclaredLicense,
+			},
+		},
+		{
+			value: "Acme Commercial License",
+			expected: License{
+				Value: "Acme Commercial License",
+				Type:  DeclaredLicense,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			actual := NewLicense(test.value)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func TestLicense_Hash(t *testing.T) {
+	// the license fingerprint matches the fingerprint of the plain license string
+	expected, err := hashstructure.Hash("MIT", hashstructure.FormatV2, nil)
+	require.NoError(t, err)
+
+	l := NewLicenseFromURL("MIT", "https://opensource.org/licenses/MIT")
+	l.Location = &source.Coordinates{RealPath: "/LICENSE"}
+	actual, err := l.Hash()
+	require.NoError(t, err)
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestPackage_LicensesByType(t *testing.T) {
+	declared := NewLicensesFromValues("BSD-3-Clause", "MIT")
+	concluded := []License{
+		{
+			Value:          "MIT",
+			SPDXExpression: "MIT",
+			Type:           ConcludedLicense,
+			Location:       &source.Coordinates{RealPath: "/LICENSE"},
+			Confidence:     1,
+		},
+	}
+
+	p := Package{
+		Licenses: append(append([]License{}, declared...), concluded...),
+	}
+
+	assert.Equal(t, declared, p.DeclaredLicenses())
+	assert.Equal(t, concluded, p.ConcludedLicenses())
+	assert.Equal(t, []string{"BSD-3-Clause", "MIT"}, LicenseValues(p.Licenses))
+}
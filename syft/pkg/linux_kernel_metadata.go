@@ -0,0 +1,46 @@
+package pkg
+
+// LinuxKernelMetadata represents all captured data for a Linux kernel image (e.g. /boot/vmlinuz-<version>).
+type LinuxKernelMetadata struct {
+	Name            string `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Architecture    string `mapstructure:"architecture" json:"architecture" cyclonedx:"architecture"`
+	Version         string `mapstructure:"version" json:"version" cyclonedx:"version"`
+	ExtendedVersion string `mapstructure:"extendedVersion" json:"extendedVersion,omitempty" cyclonedx:"extendedVersion"` // the full version string of the kernel (as in /proc/version)
+	BuildTime       string `mapstructure:"buildTime" json:"buildTime,omitempty" cyclonedx:"buildTime"`
+	Author          string `mapstructure:"author" json:"author,omitempty" cyclonedx:"author"` // the user and host that built the kernel
+	Format          string `mapstructure:"format" json:"format,omitempty" cyclonedx:"format"` // the boot image format (e.g. "bzImage")
+	RWRootFS        bool   `mapstructure:"rwRootFS" json:"rwRootFS,omitempty" cyclonedx:"rwRootFS"`
+	RootDevice      int    `mapstructure:"rootDevice" json:"rootDevice,omitempty" cyclonedx:"rootDevice"`
+	VideoMode       string `mapstructure:"videoMode" json:"videoMode,omitempty" cyclonedx:"videoMode"`
+}
+
+// LinuxKernelModuleMetadata represents all captured data for a loadable Linux kernel module (a .ko file), as found
+// within the .modinfo section and the signature appended to the module.
+type LinuxKernelModuleMetadata struct {
+	Name          string                       `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Version       string                       `mapstructure:"version" json:"version,omitempty" cyclonedx:"version"`
+	SourceVersion string                       `mapstructure:"sourceVersion" json:"sourceVersion,omitempty" cyclonedx:"sourceVersion"`
+	Path          string                       `mapstructure:"path" json:"path" cyclonedx:"path"`
+	Description   string                       `mapstructure:"description" json:"description,omitempty" cyclonedx:"description"`
+	Author        string                       `mapstructure:"author" json:"author,omitempty" cyclonedx:"author"`
+	License       string                       `mapstructure:"license" json:"license,omitempty" cyclonedx:"license"`
+	KernelVersion string                       `mapstructure:"kernelVersion" json:"kernelVersion,omitempty" cyclonedx:"kernelVersion"` // the kernel the module was built for
+	VersionMagic  string                       `mapstructure:"versionMagic" json:"versionMagic,omitempty" cyclonedx:"versionMagic"`
+	Parameters    []LinuxKernelModuleParameter `mapstructure:"parameters" json:"parameters,omitempty" cyclonedx:"parameters"`
+	Signature     *LinuxKernelModuleSignature  `mapstructure:"signature" json:"signature,omitempty" cyclonedx:"signature"`
+}
+
+// LinuxKernelModuleParameter is a single parameter that may be given to a kernel module when it is loaded.
+type LinuxKernelModuleParameter struct {
+	Name        string `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Type        string `mapstructure:"type" json:"type,omitempty" cyclonedx:"type"`
+	Description string `mapstructure:"description" json:"description,omitempty" cyclonedx:"description"`
+}
+
+// LinuxKernelModuleSignature describes the signature appended to a kernel module (as by the kernel's scripts/sign-file).
+type LinuxKernelModuleSignature struct {
+	Type          string `mapstructure:"type" json:"type" cyclonedx:"type"` // the signature format (e.g. "PKCS#7")
+	Signer        string `mapstructure:"signer" json:"signer,omitempty" cyclonedx:"signer"`
+	KeyID         string `mapstructure:"keyId" json:"keyId,omitempty" cyclonedx:"keyId"` // the serial number (or subject key identifier) of the signing certificate
+	HashAlgorithm string `mapstructure:"hashAlgorithm" json:"hashAlgorithm,omitempty" cyclonedx:"hashAlgorithm"`
+}
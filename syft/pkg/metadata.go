@@ -10,26 +10,42 @@ type MetadataType string
 const (
 	// this is the full set of data shapes that can be represented within the pkg.Package.Metadata field
 
-	UnknownMetadataType          MetadataType = "UnknownMetadata"
-	ApkMetadataType              MetadataType = "ApkMetadata"
-	AlpmMetadataType             MetadataType = "AlpmMetadata"
-	DpkgMetadataType             MetadataType = "DpkgMetadata"
-	GemMetadataType              MetadataType = "GemMetadata"
-	JavaMetadataType             MetadataType = "JavaMetadata"
-	NpmPackageJSONMetadataType   MetadataType = "NpmPackageJsonMetadata"
-	RpmMetadataType              MetadataType = "RpmMetadata"
-	DartPubMetadataType          MetadataType = "DartPubMetadata"
-	DotnetDepsMetadataType       MetadataType = "DotnetDepsMetadata"
-	PythonPackageMetadataType    MetadataType = "PythonPackageMetadata"
-	RustCargoPackageMetadataType MetadataType = "RustCargoPackageMetadata"
-	KbPackageMetadataType        MetadataType = "KbPackageMetadata"
-	GolangBinMetadataType        MetadataType = "GolangBinMetadata"
-	PhpComposerJSONMetadataType  MetadataType = "PhpComposerJsonMetadata"
-	CocoapodsMetadataType        MetadataType = "CocoapodsMetadataType"
-	ConanMetadataType            MetadataType = "ConanMetadataType"
-	ConanLockMetadataType        MetadataType = "ConanLockMetadataType"
-	PortageMetadataType          MetadataType = "PortageMetadata"
-	HackageMetadataType          MetadataType = "HackageMetadataType"
+	UnknownMetadataType           MetadataType = "UnknownMetadata"
+	ApkMetadataType               MetadataType = "ApkMetadata"
+	AlpmMetadataType              MetadataType = "AlpmMetadata"
+	DpkgMetadataType              MetadataType = "DpkgMetadata"
+	GemMetadataType               MetadataType = "GemMetadata"
+	JavaMetadataType              MetadataType = "JavaMetadata"
+	NpmPackageJSONMetadataType    MetadataType = "NpmPackageJsonMetadata"
+	RpmMetadataType               MetadataType = "RpmMetadata"
+	DartPubMetadataType           MetadataType = "DartPubMetadata"
+	DotnetDepsMetadataType        MetadataType = "DotnetDepsMetadata"
+	PythonPackageMetadataType     MetadataType = "PythonPackageMetadata"
+	RustCargoPackageMetadataType  MetadataType = "RustCargoPackageMetadata"
+	KbPackageMetadataType         MetadataType = "KbPackageMetadata"
+	GolangBinMetadataType         MetadataType = "GolangBinMetadata"
+	PhpComposerJSONMetadataType   MetadataType = "PhpComposerJsonMetadata"
+	CocoapodsMetadataType         MetadataType = "CocoapodsMetadataType"
+	ConanMetadataType             MetadataType = "ConanMetadataType"
+	ConanLockMetadataType         MetadataType = "ConanLockMetadataType"
+	PortageMetadataType           MetadataType = "PortageMetadata"
+	HackageMetadataType           MetadataType = "HackageMetadataType"
+	BinaryMetadataType            MetadataType = "BinaryMetadata"
+	StaticLibraryMetadataType     MetadataType = "StaticLibraryMetadata"
+	LinuxKernelMetadataType       MetadataType = "LinuxKernelMetadata"
+	LinuxKernelModuleMetadataType MetadataType = "LinuxKernelModuleMetadata"
+	FirmwareMetadataType          MetadataType = "FirmwareMetadata"
+	WasmMetadataType              MetadataType = "WasmMetadata"
+	HelmChartMetadataType         MetadataType = "HelmChartMetadata"
+	TerraformProviderMetadataType MetadataType = "TerraformProviderMetadata"
+	TerraformModuleMetadataType   MetadataType = "TerraformModuleMetadata"
+	GitHubActionsUseMetadataType  MetadataType = "GitHubActionsUseMetadata"
+	VSCodeExtensionMetadataType   MetadataType = "VSCodeExtensionMetadata"
+	BrowserExtensionMetadataType  MetadataType = "BrowserExtensionMetadata"
+	WordpressMetadataType         MetadataType = "WordpressMetadata"
+	DrupalMetadataType            MetadataType = "DrupalMetadata"
+	AIModelMetadataType           MetadataType = "AIModelMetadata"
+	ContainerImageMetadataType    MetadataType = "ContainerImageMetadata"
 )
 
 var AllMetadataTypes = []MetadataType{
@@ -52,28 +68,60 @@ var AllMetadataTypes = []MetadataType{
 	ConanLockMetadataType,
 	PortageMetadataType,
 	HackageMetadataType,
+	BinaryMetadataType,
+	StaticLibraryMetadataType,
+	LinuxKernelMetadataType,
+	LinuxKernelModuleMetadataType,
+	FirmwareMetadataType,
+	WasmMetadataType,
+	HelmChartMetadataType,
+	TerraformProviderMetadataType,
+	TerraformModuleMetadataType,
+	GitHubActionsUseMetadataType,
+	VSCodeExtensionMetadataType,
+	BrowserExtensionMetadataType,
+	WordpressMetadataType,
+	DrupalMetadataType,
+	AIModelMetadataType,
+	ContainerImageMetadataType,
 }
 
 var MetadataTypeByName = map[MetadataType]reflect.Type{
-	ApkMetadataType:              reflect.TypeOf(ApkMetadata{}),
-	AlpmMetadataType:             reflect.TypeOf(AlpmMetadata{}),
-	DpkgMetadataType:             reflect.TypeOf(DpkgMetadata{}),
-	GemMetadataType:              reflect.TypeOf(GemMetadata{}),
-	JavaMetadataType:             reflect.TypeOf(JavaMetadata{}),
-	NpmPackageJSONMetadataType:   reflect.TypeOf(NpmPackageJSONMetadata{}),
-	RpmMetadataType:              reflect.TypeOf(RpmMetadata{}),
-	DartPubMetadataType:          reflect.TypeOf(DartPubMetadata{}),
-	DotnetDepsMetadataType:       reflect.TypeOf(DotnetDepsMetadata{}),
-	PythonPackageMetadataType:    reflect.TypeOf(PythonPackageMetadata{}),
-	RustCargoPackageMetadataType: reflect.TypeOf(CargoMetadata{}),
-	KbPackageMetadataType:        reflect.TypeOf(KbPackageMetadata{}),
-	GolangBinMetadataType:        reflect.TypeOf(GolangBinMetadata{}),
-	PhpComposerJSONMetadataType:  reflect.TypeOf(PhpComposerJSONMetadata{}),
-	CocoapodsMetadataType:        reflect.TypeOf(CocoapodsMetadata{}),
-	ConanMetadataType:            reflect.TypeOf(ConanMetadata{}),
-	ConanLockMetadataType:        reflect.TypeOf(ConanLockMetadata{}),
-	PortageMetadataType:          reflect.TypeOf(PortageMetadata{}),
-	HackageMetadataType:          reflect.TypeOf(HackageMetadata{}),
+	ApkMetadataType:               reflect.TypeOf(ApkMetadata{}),
+	AlpmMetadataType:              reflect.TypeOf(AlpmMetadata{}),
+	DpkgMetadataType:              reflect.TypeOf(DpkgMetadata{}),
+	GemMetadataType:               reflect.TypeOf(GemMetadata{}),
+	JavaMetadataType:              reflect.TypeOf(JavaMetadata{}),
+	NpmPackageJSONMetadataType:    reflect.TypeOf(NpmPackageJSONMetadata{}),
+	RpmMetadataType:               reflect.TypeOf(RpmMetadata{}),
+	DartPubMetadataType:           reflect.TypeOf(DartPubMetadata{}),
+	DotnetDepsMetadataType:        reflect.TypeOf(DotnetDepsMetadata{}),
+	PythonPackageMetadataType:     reflect.TypeOf(PythonPackageMetadata{}),
+	RustCargoPackageMetadataType:  reflect.TypeOf(CargoMetadata{}),
+	KbPackageMetadataType:         reflect.TypeOf(KbPackageMetadata{}),
+	GolangBinMetadataType:         reflect.TypeOf(GolangBinMetadata{}),
+	PhpComposerJSONMetadataType:   reflect.TypeOf(PhpComposerJSONMetadata{}),
+	CocoapodsMetadataType:         reflect.TypeOf(CocoapodsMetadata{}),
+	ConanMetadataType:             reflect.TypeOf(ConanMetadata{}),
+	ConanLockMetadataType:         reflect.TypeOf(ConanLockMetadata{}),
+	PortageMetadataType:           reflect.TypeOf(PortageMetadata{}),
+	HackageMetadataType:           reflect.TypeOf(HackageMetadata{}),
+	BinaryMetadataType:            reflect.TypeOf(BinaryMetadata{}),
+	StaticLibraryMetadataType:     reflect.TypeOf(StaticLibraryMetadata{}),
+	LinuxKernelMetadataType:       reflect.TypeOf(LinuxKernelMetadata{}),
+	LinuxKernelModuleMetadataType: reflect.TypeOf(LinuxKernelModuleMetadata{}),
+	FirmwareMetadataType:          reflect.TypeOf(FirmwareMetadata{}),
+	WasmMetadataType:              reflect.TypeOf(WasmMetadata{}),
+	HelmChartMetadataType:         reflect.TypeOf(HelmChartMetadata{}),
+	TerraformProviderMetadataType: reflect.TypeOf(TerraformProviderMetadata{}),
+	TerraformModuleMetadataType:   reflect.TypeOf(TerraformModuleMetadata{}),
+	GitHubActionsUseMetadataType:  reflect.TypeOf(GitHubActionsUseMetadata{}),
+	VSCodeExtensionMetadataType:   reflect.TypeOf(VSCodeExtensionMetadata{}),
+	BrowserExtensionMetadataType:  reflect.TypeOf(BrowserExtensionMetadata{}),
+	WordpressMetadataType:         reflect.TypeOf(WordpressMetadata{}),
+	DrupalMetadataType:            reflect.TypeOf(DrupalMetadata{}),
+	AIModelMetadataType:           reflect.TypeOf(AIModelMetadata{}),
+	ContainerImageMetadataType:    reflect.TypeOf(ContainerImageMetadata{}),
 }
 
 func CleanMetadataType(typ MetadataType) MetadataType {
@@ -1,10 +1,8 @@
 package pkg
 
 import (
-	"strings"
-
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 var _ urlIdentifier = (*NpmPackageJSONMetadata)(nil)
@@ -20,25 +18,10 @@ type NpmPackageJSONMetadata struct {
 	Description string   `mapstructure:"description" json:"description"`
 	URL         string   `mapstructure:"url" json:"url"`
 	Private     bool     `mapstructure:"private" json:"private"`
+	Workspace   string   `mapstructure:"workspace" json:"workspace,omitempty"` // the directory of the project the package is installed for (or of the package itself when it is not installed within node_modules)
 }
 
 // PackageURL returns the PURL for the specific NPM package (see https://github.com/package-url/purl-spec)
 func (p NpmPackageJSONMetadata) PackageURL(_ *linux.Release) string {
-	var namespace string
-	name := p.Name
-
-	fields := strings.SplitN(p.Name, "/", 2)
-	if len(fields) > 1 {
-		namespace = fields[0]
-		name = fields[1]
-	}
-
-	return packageurl.NewPackageURL(
-		packageurl.TypeNPM,
-		namespace,
-		name,
-		p.Version,
-		nil,
-		"",
-	).ToString()
+	return purl.Npm(p.Name, p.Version)
 }
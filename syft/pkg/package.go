@@ -14,32 +14,33 @@ import (
 // Package represents an application or library that has been bundled into a distributable format.
 // TODO: if we ignore FoundBy for ID generation should we merge the field to show it was found in two places?
 type Package struct {
-	id           artifact.ID        `hash:"ignore"`
-	Name         string             // the package name
-	Version      string             // the version of the package
-	FoundBy      string             `hash:"ignore" cyclonedx:"foundBy"` // the specific cataloger that discovered this package
-	Locations    source.LocationSet // the locations that lead to the discovery of this package (note: this is not necessarily the locations that make up this package)
-	Licenses     []string           // licenses discovered with the package metadata
-	Language     Language           `cyclonedx:"language"`     // the language ecosystem this package belongs to (e.g. JavaScript, Python, etc)
-	Type         Type               `cyclonedx:"type"`         // the package type (e.g. Npm, Yarn, Python, Rpm, Deb, etc)
-	CPEs         []CPE              `hash:"ignore"`            // all possible Common Platform Enumerators (note: this is NOT included in the definition of the ID since all fields on a CPE are derived from other fields)
-	PURL         string             `hash:"ignore"`            // the Package URL (see https://github.com/package-url/purl-spec)
-	MetadataType MetadataType       `cyclonedx:"metadataType"` // the shape of the additional data in the "metadata" field
-	Metadata     interface{}        // additional data found while parsing the package source
+	id             artifact.ID              `hash:"ignore"`
+	Name           string                   // the package name
+	Version        string                   // the version of the package
+	FoundBy        string                   `hash:"ignore" cyclonedx:"foundBy"` // the specific cataloger that discovered this package
+	Locations      source.LocationSet       // the locations that lead to the discovery of this package (note: this is not necessarily the locations that make up this package)
+	Licenses       []License                // licenses declared by the package metadata or concluded from the package contents
+	LicenseTexts   map[string]string        `hash:"ignore"`            // verbatim text of the licenses that are not SPDX license expressions (keyed by license), captured from license files alongside the package
+	Language       Language                 `cyclonedx:"language"`     // the language ecosystem this package belongs to (e.g. JavaScript, Python, etc)
+	Type           Type                     `cyclonedx:"type"`         // the package type (e.g. Npm, Yarn, Python, Rpm, Deb, etc)
+	CPEs           []CPE                    `hash:"ignore"`            // all possible Common Platform Enumerators (note: this is NOT included in the definition of the ID since all fields on a CPE are derived from other fields)
+	CPEAnnotations map[string]CPEAnnotation `hash:"ignore"`            // the source and confidence of each CPE (keyed by CPE string)
+	PURL           string                   `hash:"ignore"`            // the Package URL (see https://github.com/package-url/purl-spec)
+	MetadataType   MetadataType             `cyclonedx:"metadataType"` // the shape of the additional data in the "metadata" field
+	Metadata       interface{}              // additional data found while parsing the package source
+	Layer          *LayerAttribution        `hash:"ignore"`                        // the container image layer that introduced the package (image only)
+	Registry       *RegistryInfo            `hash:"ignore"`                        // the details of the package as published within the registry of its ecosystem (only when enriched)
+	Evidence       []Evidence               `hash:"ignore"`                        // the data the package was identified from (files parsed, matched content, classifier rules)
+	Confidence     Confidence               `hash:"ignore" cyclonedx:"confidence"` // whether the package was parsed from a package manager record (authoritative) or guessed from file contents (heuristic)
 }
 
 func (p *Package) OverrideID(id artifact.ID) {
 	p.id = id
 }
 
+// SetID sets the ID of the package as derived by the default scheme (see DefaultIDScheme).
 func (p *Package) SetID() {
-	id, err := artifact.IDByHash(p)
-	if err != nil {
-		// TODO: what to do in this case?
-		log.Warnf("unable to get fingerprint of package=%s@%s: %+v", p.Name, p.Version, err)
-		return
-	}
-	p.id = id
+	p.SetIDWithScheme(DefaultIDScheme)
 }
 
 func (p Package) ID() artifact.ID {
@@ -60,14 +61,65 @@ func (p *Package) merge(other Package) error {
 		log.Warnf("merging packages have with different pURLs: %q=%q vs %q=%q", p.id, p.PURL, other.id, other.PURL)
 	}
 
+	p.combine(other)
+
+	return nil
+}
+
+// unshare copies the sets, maps, and slices of the package that are modified when combining packages, so that combining
+// packages does not modify the other copies of the package (e.g. within a clone of a catalog).
+func (p *Package) unshare() {
+	p.Locations = source.NewLocationSet(p.Locations.ToSlice()...)
+	p.Licenses = append([]License(nil), p.Licenses...)
+	p.CPEs = append([]CPE(nil), p.CPEs...)
+	p.Evidence = append([]Evidence(nil), p.Evidence...)
+	if p.CPEAnnotations != nil {
+		annotations := make(map[string]CPEAnnotation, len(p.CPEAnnotations))
+		for cpe, annotation := range p.CPEAnnotations {
+			annotations[cpe] = annotation
+		}
+		p.CPEAnnotations = annotations
+	}
+	if p.LicenseTexts != nil {
+		texts := make(map[string]string, len(p.LicenseTexts))
+		for license, text := range p.LicenseTexts {
+			texts[license] = text
+		}
+		p.LicenseTexts = texts
+	}
+}
+
+// combine adds the locations, CPEs, licenses, and evidence of the other package to this package, filling in the pURL and
+// registry details when missing, and keeping the highest confidence level.
+func (p *Package) combine(other Package) {
 	p.Locations.Add(other.Locations.ToSlice()...)
 
 	p.CPEs = mergeCPEs(p.CPEs, other.CPEs)
+	p.CPEAnnotations = mergeCPEAnnotations(p.CPEAnnotations, other.CPEAnnotations)
 
 	if p.PURL == "" {
 		p.PURL = other.PURL
 	}
-	return nil
+
+	if p.Registry == nil {
+		p.Registry = other.Registry
+	}
+
+	for license, text := range other.LicenseTexts {
+		if _, exists := p.LicenseTexts[license]; exists {
+			continue
+		}
+		if p.LicenseTexts == nil {
+			p.LicenseTexts = make(map[string]string)
+		}
+		p.LicenseTexts[license] = text
+	}
+
+	p.Licenses = mergeLicenses(p.Licenses, other.Licenses)
+	p.Evidence = mergeEvidence(p.Evidence, other.Evidence)
+	if other.Confidence.rank() > p.Confidence.rank() {
+		p.Confidence = other.Confidence
+	}
 }
 
 // IsValid checks whether a package has the minimum necessary info
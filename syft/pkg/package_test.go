@@ -26,10 +26,10 @@ func TestIDUniqueness(t *testing.T) {
 		Locations: source.NewLocationSet(
 			originalLocation,
 		),
-		Licenses: []string{
+		Licenses: NewLicensesFromValues(
 			"cc0-1.0",
 			"MIT",
-		},
+		),
 		Language: "math",
 		Type:     PythonPkg,
 		CPEs: []CPE{
@@ -83,10 +83,10 @@ func TestIDUniqueness(t *testing.T) {
 			name: "licenses order is ignored",
 			transform: func(pkg Package) Package {
 				// note: same as the original package, only a different order
-				pkg.Licenses = []string{
+				pkg.Licenses = NewLicensesFromValues(
 					"MIT",
 					"cc0-1.0",
-				}
+				)
 				return pkg
 			},
 			expectedIDComparison: assert.Equal,
@@ -145,11 +145,27 @@ func TestIDUniqueness(t *testing.T) {
 		{
 			name: "licenses is reflected",
 			transform: func(pkg Package) Package {
-				pkg.Licenses = []string{"new!"}
+				pkg.Licenses = NewLicensesFromValues("new!")
 				return pkg
 			},
 			expectedIDComparison: assert.NotEqual,
 		},
+		{
+			name: "license provenance is ignored",
+			transform: func(pkg Package) Package {
+				pkg.Licenses = []License{
+					NewLicenseFromURL("cc0-1.0", "https://creativecommons.org/publicdomain/zero/1.0/"),
+					{
+						Value:      "MIT",
+						Type:       ConcludedLicense,
+						Location:   &source.Coordinates{RealPath: "/somewhere/LICENSE"},
+						Confidence: 0.9,
+					},
+				}
+				return pkg
+			},
+			expectedIDComparison: assert.Equal,
+		},
 		{
 			name: "type is reflected",
 			transform: func(pkg Package) Package {
@@ -174,6 +190,16 @@ func TestIDUniqueness(t *testing.T) {
 			},
 			expectedIDComparison: assert.Equal,
 		},
+		{
+			name: "CPE annotations are ignored",
+			transform: func(pkg Package) Package {
+				pkg.CPEAnnotations = map[string]CPEAnnotation{
+					"cpe:2.3:a:Archimedes:pi:3.14:*:*:*:*:math:*:*": {Source: GeneratedCPESource, Confidence: 0.5},
+				}
+				return pkg
+			},
+			expectedIDComparison: assert.Equal,
+		},
 		{
 			name: "pURL is ignored",
 			transform: func(pkg Package) Package {
@@ -263,10 +289,10 @@ func TestPackage_Merge(t *testing.T) {
 				Locations: source.NewLocationSet(
 					originalLocation,
 				),
-				Licenses: []string{
+				Licenses: NewLicensesFromValues(
 					"cc0-1.0",
 					"MIT",
-				},
+				),
 				Language: "math",
 				Type:     PythonPkg,
 				CPEs: []CPE{
@@ -291,10 +317,10 @@ func TestPackage_Merge(t *testing.T) {
 				Locations: source.NewLocationSet(
 					similarLocation, // NOTE: difference; we have a different layer but the same path
 				),
-				Licenses: []string{
+				Licenses: NewLicensesFromValues(
 					"cc0-1.0",
 					"MIT",
-				},
+				),
 				Language: "math",
 				Type:     PythonPkg,
 				CPEs: []CPE{
@@ -320,10 +346,10 @@ func TestPackage_Merge(t *testing.T) {
 					originalLocation,
 					similarLocation, // NOTE: merge!
 				),
-				Licenses: []string{
+				Licenses: NewLicensesFromValues(
 					"cc0-1.0",
 					"MIT",
-				},
+				),
 				Language: "math",
 				Type:     PythonPkg,
 				CPEs: []CPE{
@@ -352,10 +378,10 @@ func TestPackage_Merge(t *testing.T) {
 				Locations: source.NewLocationSet(
 					originalLocation,
 				),
-				Licenses: []string{
+				Licenses: NewLicensesFromValues(
 					"cc0-1.0",
 					"MIT",
-				},
+				),
 				Language: "math",
 				Type:     PythonPkg,
 				CPEs: []CPE{
@@ -380,10 +406,10 @@ func TestPackage_Merge(t *testing.T) {
 				Locations: source.NewLocationSet(
 					originalLocation,
 				),
-				Licenses: []string{
+				Licenses: NewLicensesFromValues(
 					"cc0-1.0",
 					"MIT",
-				},
+				),
 				Language: "math",
 				Type:     PythonPkg,
 				CPEs: []CPE{
@@ -1,10 +1,8 @@
 package pkg
 
 import (
-	"strings"
-
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 var _ urlIdentifier = (*PhpComposerJSONMetadata)(nil)
@@ -44,27 +42,5 @@ type PhpComposerAuthors struct {
 }
 
 func (m PhpComposerJSONMetadata) PackageURL(_ *linux.Release) string {
-	var name, vendor string
-	fields := strings.Split(m.Name, "/")
-	switch len(fields) {
-	case 0:
-		return ""
-	case 1:
-		name = m.Name
-	case 2:
-		vendor = fields[0]
-		name = fields[1]
-	default:
-		vendor = fields[0]
-		name = strings.Join(fields[1:], "-")
-	}
-
-	pURL := packageurl.NewPackageURL(
-		packageurl.TypeComposer,
-		vendor,
-		name,
-		m.Version,
-		nil,
-		"")
-	return pURL.ToString()
+	return purl.Composer(m.Name, m.Version)
 }
@@ -0,0 +1,164 @@
+package purl
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/syft/linux"
+)
+
+// Deb returns the purl of a debian package, which is namespaced by the distro (e.g. "debian" or "ubuntu"). An empty
+// string is returned if the distro is not debian (or debian-like).
+func Deb(distro *linux.Release, name, version, arch, source, sourceVersion string) string {
+	if distro == nil || (distro.ID != "debian" && !internal.StringInSlice("debian", distro.IDLike)) {
+		return ""
+	}
+
+	upstream := source
+	if source != "" && sourceVersion != "" {
+		upstream = fmt.Sprintf("%s@%s", source, sourceVersion)
+	}
+
+	return New(TypeDeb, distro.ID, name, version, Qualifiers{
+		ArchQualifier:     arch,
+		DistroQualifier:   Distro(distro),
+		UpstreamQualifier: upstream,
+	}, "")
+}
+
+// RPM returns the purl of an RPM package, which is namespaced by the distro (e.g. "centos" or "fedora"). The epoch is
+// a qualifier rather than part of the version (see https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#rpm).
+func RPM(distro *linux.Release, name, version, release string, epoch *int, arch, sourceRpm string) string {
+	var namespace string
+	if distro != nil {
+		namespace = distro.ID
+	}
+
+	qualifiers := Qualifiers{
+		ArchQualifier:     arch,
+		DistroQualifier:   Distro(distro),
+		UpstreamQualifier: sourceRpm,
+	}
+	if epoch != nil {
+		qualifiers[EpochQualifier] = strconv.Itoa(*epoch)
+	}
+
+	return New(TypeRPM, namespace, name, fmt.Sprintf("%s-%s", version, release), qualifiers, "")
+}
+
+// Apk returns the purl of an alpine package. An empty string is returned if the distro is not alpine.
+func Apk(distro *linux.Release, name, version, arch, originPackage string) string {
+	if distro == nil || distro.ID != "alpine" {
+		// note: there is no namespace variation (like with debian ID_LIKE for ubuntu ID, for example)
+		return ""
+	}
+
+	return New(TypeApk, distro.ID, name, version, Qualifiers{
+		ArchQualifier:     arch,
+		DistroQualifier:   Distro(distro),
+		UpstreamQualifier: originPackage,
+	}, "")
+}
+
+// Alpm returns the purl of an Arch Linux package. An empty string is returned if the distro is not Arch Linux.
+func Alpm(distro *linux.Release, name, version, arch, basePackage string) string {
+	if distro == nil || distro.ID != "arch" {
+		// note: there is no namespace variation (like with debian ID_LIKE for ubuntu ID, for example)
+		return ""
+	}
+
+	return New(TypeAlpm, distro.ID, name, version, Qualifiers{
+		ArchQualifier:     arch,
+		DistroQualifier:   Distro(distro),
+		UpstreamQualifier: basePackage,
+	}, "")
+}
+
+// Golang returns the purl of a go module, where the last element of the module path is the name and the remaining
+// elements are the namespace (e.g. "pkg:golang/github.com/anchore/syft@v0.1.0").
+func Golang(modulePath, version string) string {
+	namespace := ""
+	name := modulePath
+	if idx := strings.LastIndex(modulePath, "/"); idx >= 0 {
+		namespace = modulePath[:idx]
+		name = modulePath[idx+1:]
+	}
+
+	// TODO: the subpath pointing within a module (e.g. pkg:golang/google.golang.org/genproto#googleapis/api/annotations) is not implemented
+	return New(TypeGolang, namespace, name, version, nil, "")
+}
+
+// Npm returns the purl of an npm package, where the scope of scoped packages is the namespace (e.g. "@babel/core").
+func Npm(name, version string) string {
+	namespace := ""
+	fields := strings.SplitN(name, "/", 2)
+	if len(fields) > 1 {
+		namespace = fields[0]
+		name = fields[1]
+	}
+	return New(TypeNPM, namespace, name, version, nil, "")
+}
+
+// Composer returns the purl of a PHP composer package, where the vendor is the namespace (e.g. "symfony/console").
+func Composer(name, version string) string {
+	var vendor string
+	fields := strings.Split(name, "/")
+	switch len(fields) {
+	case 1:
+		name = fields[0]
+	case 2:
+		vendor = fields[0]
+		name = fields[1]
+	default:
+		vendor = fields[0]
+		name = strings.Join(fields[1:], "-")
+	}
+	if name == "" {
+		return ""
+	}
+	return New(TypeComposer, vendor, name, version, nil, "")
+}
+
+// Maven returns the purl of a maven artifact, which is namespaced by the group ID.
+func Maven(groupID, artifactID, version string) string {
+	return New(TypeMaven, groupID, artifactID, version, nil, "")
+}
+
+// PyPI returns the purl of a python package, optionally qualified by the VCS URL the package was installed from.
+func PyPI(name, version, vcsURL string) string {
+	return New(TypePyPI, "", name, version, Qualifiers{
+		VCSURLQualifier: vcsURL,
+	}, "")
+}
+
+// Gem returns the purl of a ruby gem, optionally qualified by the VCS URL the gem was installed from.
+func Gem(name, version, vcsURL string) string {
+	return New(TypeGem, "", name, version, Qualifiers{
+		VCSURLQualifier: vcsURL,
+	}, "")
+}
+
+// Pub returns the purl of a dart package, qualified by the repository the package is hosted in or (otherwise) the VCS
+// URL the package was installed from.
+func Pub(name, version, repositoryURL, vcsURL string) string {
+	qualifiers := Qualifiers{
+		RepositoryURLQualifier: repositoryURL,
+	}
+	if repositoryURL == "" {
+		qualifiers[VCSURLQualifier] = vcsURL
+	}
+	return New(TypePub, "", name, version, qualifiers, "")
+}
+
+// OCI returns the purl of a container image, which is named after the last element of the repository (e.g.
+// "docker.io/bitnami/nginx") and is versioned by the digest of the image, where the tag is a qualifier (see
+// https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst#oci).
+func OCI(repository, tag, digest string) string {
+	name := repository[strings.LastIndex(repository, "/")+1:]
+	return New(TypeOCI, "", name, digest, Qualifiers{
+		RepositoryURLQualifier: repository,
+		TagQualifier:           tag,
+	}, "")
+}
@@ -0,0 +1,258 @@
+package purl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/linux"
+)
+
+func TestDeb(t *testing.T) {
+	tests := []struct {
+		name     string
+		distro   *linux.Release
+		source   string
+		srcVer   string
+		expected string
+	}{
+		{
+			name:     "no distro",
+			expected: "",
+		},
+		{
+			name: "not debian",
+			distro: &linux.Release{
+				ID:        "fedora",
+				VersionID: "36",
+			},
+			expected: "",
+		},
+		{
+			name: "debian",
+			distro: &linux.Release{
+				ID:        "debian",
+				VersionID: "11",
+			},
+			expected: "pkg:deb/debian/libc6@2.31-13?arch=amd64&distro=debian-11",
+		},
+		{
+			name: "debian-like",
+			distro: &linux.Release{
+				ID:        "ubuntu",
+				IDLike:    []string{"debian"},
+				VersionID: "22.04",
+			},
+			source:   "glibc",
+			expected: "pkg:deb/ubuntu/libc6@2.31-13?arch=amd64&distro=ubuntu-22.04&upstream=glibc",
+		},
+		{
+			name: "source version",
+			distro: &linux.Release{
+				ID:        "debian",
+				VersionID: "11",
+			},
+			source:   "glibc",
+			srcVer:   "2.31-12",
+			expected: "pkg:deb/debian/libc6@2.31-13?arch=amd64&distro=debian-11&upstream=glibc%402.31-12",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Deb(test.distro, "libc6", "2.31-13", "amd64", test.source, test.srcVer))
+		})
+	}
+}
+
+func TestRPM(t *testing.T) {
+	epoch := 2
+	tests := []struct {
+		name     string
+		distro   *linux.Release
+		epoch    *int
+		expected string
+	}{
+		{
+			name:     "no distro",
+			expected: "pkg:rpm/bash@5.1-1?arch=x86_64&upstream=bash-5.1-1.src.rpm",
+		},
+		{
+			name: "distro",
+			distro: &linux.Release{
+				ID:        "centos",
+				VersionID: "7",
+			},
+			expected: "pkg:rpm/centos/bash@5.1-1?arch=x86_64&distro=centos-7&upstream=bash-5.1-1.src.rpm",
+		},
+		{
+			name: "epoch",
+			distro: &linux.Release{
+				ID:        "centos",
+				VersionID: "7",
+			},
+			epoch:    &epoch,
+			expected: "pkg:rpm/centos/bash@5.1-1?arch=x86_64&distro=centos-7&epoch=2&upstream=bash-5.1-1.src.rpm",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, RPM(test.distro, "bash", "5.1", "1", test.epoch, "x86_64", "bash-5.1-1.src.rpm"))
+		})
+	}
+}
+
+func TestApk(t *testing.T) {
+	tests := []struct {
+		name     string
+		distro   *linux.Release
+		expected string
+	}{
+		{
+			name:     "no distro",
+			expected: "",
+		},
+		{
+			name: "not alpine",
+			distro: &linux.Release{
+				ID:        "debian",
+				VersionID: "11",
+			},
+			expected: "",
+		},
+		{
+			name: "alpine",
+			distro: &linux.Release{
+				ID:        "alpine",
+				VersionID: "3.16.2",
+			},
+			expected: "pkg:apk/alpine/libcrypto1.1@1.1.1q-r0?arch=x86_64&distro=alpine-3.16.2&upstream=openssl",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Apk(test.distro, "libcrypto1.1", "1.1.1q-r0", "x86_64", "openssl"))
+		})
+	}
+}
+
+func TestAlpm(t *testing.T) {
+	tests := []struct {
+		name     string
+		distro   *linux.Release
+		expected string
+	}{
+		{
+			name:     "no distro",
+			expected: "",
+		},
+		{
+			name: "not arch",
+			distro: &linux.Release{
+				ID: "alpine",
+			},
+			expected: "",
+		},
+		{
+			name: "arch",
+			distro: &linux.Release{
+				ID:      "arch",
+				BuildID: "rolling",
+			},
+			expected: "pkg:alpm/arch/gcc-libs@12.2.0-1?arch=x86_64&distro=arch-rolling&upstream=gcc",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Alpm(test.distro, "gcc-libs", "12.2.0-1", "x86_64", "gcc"))
+		})
+	}
+}
+
+func TestLanguageEcosystems(t *testing.T) {
+	tests := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{
+			name:     "golang",
+			actual:   Golang("github.com/anchore/syft", "v0.60.0"),
+			expected: "pkg:golang/github.com/anchore/syft@v0.60.0",
+		},
+		{
+			name:     "golang short module path",
+			actual:   Golang("stdlib", "go1.19"),
+			expected: "pkg:golang/stdlib@go1.19",
+		},
+		{
+			name:     "npm",
+			actual:   Npm("lodash", "4.17.21"),
+			expected: "pkg:npm/lodash@4.17.21",
+		},
+		{
+			name:     "npm scoped",
+			actual:   Npm("@npmcli/arborist", "6.0.0"),
+			expected: "pkg:npm/%40npmcli/arborist@6.0.0",
+		},
+		{
+			name:     "composer",
+			actual:   Composer("Symfony/Console", "v6.0.0"),
+			expected: "pkg:composer/symfony/console@v6.0.0",
+		},
+		{
+			name:     "composer without vendor",
+			actual:   Composer("monolog", "3.0.0"),
+			expected: "pkg:composer/monolog@3.0.0",
+		},
+		{
+			name:     "composer without name",
+			actual:   Composer("symfony/", "v6.0.0"),
+			expected: "",
+		},
+		{
+			name:     "maven",
+			actual:   Maven("org.apache.commons", "commons-lang3", "3.12.0"),
+			expected: "pkg:maven/org.apache.commons/commons-lang3@3.12.0",
+		},
+		{
+			name:     "pypi",
+			actual:   PyPI("Flask_Cors", "3.0.10", ""),
+			expected: "pkg:pypi/flask-cors@3.0.10",
+		},
+		{
+			name:     "pypi with vcs url",
+			actual:   PyPI("requests", "2.28.1", "git+https://github.com/psf/requests.git@abc"),
+			expected: "pkg:pypi/requests@2.28.1?vcs_url=git+https://github.com/psf/requests.git%40abc",
+		},
+		{
+			name:     "gem with vcs url",
+			actual:   Gem("rails", "7.0.0", "git+https://github.com/rails/rails.git"),
+			expected: "pkg:gem/rails@7.0.0?vcs_url=git+https://github.com/rails/rails.git",
+		},
+		{
+			name:     "pub prefers the repository url",
+			actual:   Pub("ale", "3.3.0", "pub.hosted.org", "git@github.com:dart/ale.git"),
+			expected: "pkg:pub/ale@3.3.0?repository_url=pub.hosted.org",
+		},
+		{
+			name:     "pub with vcs url",
+			actual:   Pub("ale", "3.3.0", "", "git@github.com:dart/ale.git"),
+			expected: "pkg:pub/ale@3.3.0?vcs_url=git%40github.com:dart/ale.git",
+		},
+		{
+			name:     "oci",
+			actual:   OCI("docker.io/bitnami/nginx", "1.25.3", "sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31"),
+			expected: "pkg:oci/nginx@sha256:0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31?repository_url=docker.io/bitnami/nginx&tag=1.25.3",
+		},
+		{
+			name:     "oci without a digest",
+			actual:   OCI("registry.example.com:5000/Team/App", "v2", ""),
+			expected: "pkg:oci/app?repository_url=registry.example.com:5000/Team/App&tag=v2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.actual)
+		})
+	}
+}
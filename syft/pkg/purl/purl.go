@@ -0,0 +1,124 @@
+/*
+Package purl generates package URLs (see https://github.com/package-url/purl-spec) for all package ecosystems, applying
+the namespace, name, and qualifier rules of each package URL type so that every cataloger produces conforming purls.
+*/
+package purl
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/syft/linux"
+)
+
+// package URL types (see https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst)
+const (
+	TypeAlpm      = "alpm" // Arch Linux and other users of the libalpm/pacman package manager (see https://github.com/package-url/purl-spec/pull/164)
+	TypeApk       = "apk"
+	TypeCargo     = "cargo"
+	TypeCocoapods = "cocoapods"
+	TypeComposer  = "composer"
+	TypeConan     = "conan"
+	TypeDeb       = "deb"
+	TypeGem       = "gem"
+	TypeGeneric   = "generic"
+	TypeGitHub    = "github"
+	TypeGolang    = "golang"
+	TypeHackage   = "hackage"
+	TypeMaven     = "maven"
+	TypeNPM       = "npm"
+	TypeNuGet     = "nuget"
+	TypeOCI       = "oci"
+	TypePortage   = "portage" // not within the purl spec
+	TypePub       = "pub"
+	TypePyPI      = "pypi"
+	TypeRPM       = "rpm"
+)
+
+// package URL qualifier keys (see https://github.com/package-url/purl-spec/blob/master/PURL-SPECIFICATION.rst#known-qualifiers-keyvalue-pairs)
+const (
+	ArchQualifier          = "arch"
+	DistroQualifier        = "distro"
+	EpochQualifier         = "epoch"
+	RepositoryURLQualifier = "repository_url"
+	TagQualifier           = "tag"
+	VCSURLQualifier        = "vcs_url"
+
+	// UpstreamQualifier is not in the purl spec, but is used by grype to perform indirect matching based on source information
+	UpstreamQualifier = "upstream"
+)
+
+// Qualifiers are the qualifiers of a package URL keyed by qualifier key, where empty values are omitted from the purl.
+type Qualifiers map[string]string
+
+// New returns the canonical form of the package URL with the given components, normalizing the namespace and name
+// according to the rules of the package URL type and ordering the qualifiers by key.
+func New(purlType, namespace, name, version string, qualifiers Qualifiers, subpath string) string {
+	namespace, name = normalize(purlType, namespace, name)
+	return packageurl.NewPackageURL(
+		purlType,
+		namespace,
+		name,
+		version,
+		qualifiers.list(),
+		subpath,
+	).ToString()
+}
+
+// Distro returns the value of the "distro" qualifier for the given release (e.g. "debian-11"), which is empty if the
+// release is unknown.
+func Distro(release *linux.Release) string {
+	if release == nil {
+		return ""
+	}
+
+	var fields []string
+	if release.ID != "" {
+		fields = append(fields, release.ID)
+	}
+
+	if release.VersionID != "" {
+		fields = append(fields, release.VersionID)
+	} else if release.BuildID != "" {
+		fields = append(fields, release.BuildID)
+	}
+
+	return strings.Join(fields, "-")
+}
+
+func (q Qualifiers) list() packageurl.Qualifiers {
+	keys := make([]string, 0, len(q))
+	for k, v := range q {
+		if v == "" {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var result packageurl.Qualifiers
+	for _, k := range keys {
+		result = append(result, packageurl.Qualifier{
+			Key:   k,
+			Value: q[k],
+		})
+	}
+	return result
+}
+
+// normalize applies the case and separator rules of the given package URL type to the namespace and name.
+func normalize(purlType, namespace, name string) (string, string) {
+	switch purlType {
+	case TypeAlpm, TypeApk, TypeComposer, TypeDeb, TypeGitHub, TypeOCI:
+		// the namespace and name are not case sensitive and must be lowercased
+		return strings.ToLower(namespace), strings.ToLower(name)
+	case TypeRPM:
+		// the namespace (vendor) is not case sensitive, however, the name is
+		return strings.ToLower(namespace), name
+	case TypePyPI:
+		// the name is not case sensitive and must be lowercased, where underscores must be replaced with dashes
+		return namespace, strings.ReplaceAll(strings.ToLower(name), "_", "-")
+	}
+	return namespace, name
+}
@@ -0,0 +1,149 @@
+package purl
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/anchore/syft/syft/linux"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		purlType   string
+		namespace  string
+		pkgName    string
+		version    string
+		qualifiers Qualifiers
+		subpath    string
+		expected   string
+	}{
+		{
+			name:     "no namespace or qualifiers",
+			purlType: TypeCargo,
+			pkgName:  "clap",
+			version:  "4.0.0",
+			expected: "pkg:cargo/clap@4.0.0",
+		},
+		{
+			name:     "qualifiers are ordered by key",
+			purlType: TypeRPM,
+			pkgName:  "bash",
+			version:  "5.1-1",
+			qualifiers: Qualifiers{
+				UpstreamQualifier: "bash-5.1-1.src.rpm",
+				EpochQualifier:    "1",
+				DistroQualifier:   "fedora-36",
+				ArchQualifier:     "x86_64",
+			},
+			expected: "pkg:rpm/bash@5.1-1?arch=x86_64&distro=fedora-36&epoch=1&upstream=bash-5.1-1.src.rpm",
+		},
+		{
+			name:     "empty qualifiers are omitted",
+			purlType: TypeGem,
+			pkgName:  "rails",
+			version:  "7.0.0",
+			qualifiers: Qualifiers{
+				ArchQualifier:   "",
+				VCSURLQualifier: "",
+			},
+			expected: "pkg:gem/rails@7.0.0",
+		},
+		{
+			name:      "deb namespace and name are lowercased",
+			purlType:  TypeDeb,
+			namespace: "Debian",
+			pkgName:   "LibC6",
+			version:   "2.31-13",
+			expected:  "pkg:deb/debian/libc6@2.31-13",
+		},
+		{
+			name:      "composer namespace and name are lowercased",
+			purlType:  TypeComposer,
+			namespace: "Symfony",
+			pkgName:   "Console",
+			version:   "v6.0.0",
+			expected:  "pkg:composer/symfony/console@v6.0.0",
+		},
+		{
+			name:      "rpm name is case sensitive",
+			purlType:  TypeRPM,
+			namespace: "RedHat",
+			pkgName:   "PackageKit",
+			version:   "1.2.4-2",
+			expected:  "pkg:rpm/redhat/PackageKit@1.2.4-2",
+		},
+		{
+			name:     "pypi name is lowercased with dashes",
+			purlType: TypePyPI,
+			pkgName:  "Typing_Extensions",
+			version:  "4.4.0",
+			expected: "pkg:pypi/typing-extensions@4.4.0",
+		},
+		{
+			name:      "maven is case sensitive",
+			purlType:  TypeMaven,
+			namespace: "org.apache.logging.log4j",
+			pkgName:   "log4j-API",
+			version:   "2.17.0",
+			expected:  "pkg:maven/org.apache.logging.log4j/log4j-API@2.17.0",
+		},
+		{
+			name:      "subpath",
+			purlType:  TypeGolang,
+			namespace: "google.golang.org",
+			pkgName:   "genproto",
+			version:   "v0.0.0",
+			subpath:   "googleapis/api/annotations",
+			expected:  "pkg:golang/google.golang.org/genproto@v0.0.0#googleapis/api/annotations",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, New(test.purlType, test.namespace, test.pkgName, test.version, test.qualifiers, test.subpath))
+		})
+	}
+}
+
+func TestDistro(t *testing.T) {
+	tests := []struct {
+		name     string
+		release  *linux.Release
+		expected string
+	}{
+		{
+			name:     "no release",
+			expected: "",
+		},
+		{
+			name: "version ID",
+			release: &linux.Release{
+				ID:        "debian",
+				VersionID: "11",
+				BuildID:   "ignored",
+			},
+			expected: "debian-11",
+		},
+		{
+			name: "build ID without version ID",
+			release: &linux.Release{
+				ID:      "arch",
+				BuildID: "rolling",
+			},
+			expected: "arch-rolling",
+		},
+		{
+			name: "ID only",
+			release: &linux.Release{
+				ID: "wolfi",
+			},
+			expected: "wolfi",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, Distro(test.release))
+		})
+	}
+}
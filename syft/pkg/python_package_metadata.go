@@ -6,8 +6,8 @@ import (
 
 	"github.com/scylladb/go-set/strset"
 
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 var (
@@ -44,6 +44,8 @@ type PythonPackageMetadata struct {
 	Platform             string                     `json:"platform" mapstruct:"Platform"`
 	Files                []PythonFileRecord         `json:"files,omitempty"`
 	SitePackagesRootPath string                     `json:"sitePackagesRootPath"`
+	PythonVersion        string                     `json:"pythonVersion,omitempty"`  // the version of the interpreter the package is installed for (e.g. "3.11" or "3.11.4")
+	VirtualEnvPath       string                     `json:"virtualEnvPath,omitempty"` // the root of the virtual environment the package is installed within (if any)
 	TopLevelPackages     []string                   `json:"topLevelPackages,omitempty"`
 	DirectURLOrigin      *PythonDirectURLOriginInfo `json:"directUrlOrigin,omitempty"`
 }
@@ -82,31 +84,18 @@ func (m PythonPackageMetadata) OwnedFiles() (result []string) {
 }
 
 func (m PythonPackageMetadata) PackageURL(_ *linux.Release) string {
-	// generate a purl from the package data
-	pURL := packageurl.NewPackageURL(
-		packageurl.TypePyPi,
-		"",
-		m.Name,
-		m.Version,
-		m.purlQualifiers(),
-		"")
-
-	return pURL.ToString()
-}
-
-func (m PythonPackageMetadata) purlQualifiers() packageurl.Qualifiers {
-	q := packageurl.Qualifiers{}
+	var vcsURL string
 	if m.DirectURLOrigin != nil {
-		q = append(q, m.DirectURLOrigin.vcsURLQualifier()...)
+		vcsURL = m.DirectURLOrigin.vcsURL()
 	}
-	return q
+	return purl.PyPI(m.Name, m.Version, vcsURL)
 }
 
-func (p PythonDirectURLOriginInfo) vcsURLQualifier() packageurl.Qualifiers {
-	if p.VCS != "" {
-		// Taken from https://github.com/package-url/purl-spec/blob/master/PURL-SPECIFICATION.rst#known-qualifiers-keyvalue-pairs
-		// packageurl-go still doesn't support all qualifier names
-		return packageurl.Qualifiers{{Key: PURLQualifierVCSURL, Value: fmt.Sprintf("%s+%s@%s", p.VCS, p.URL, p.CommitID)}}
+// vcsURL returns the VCS URL of the package in the form of "<vcs>+<url>@<commit>" (see
+// https://github.com/package-url/purl-spec/blob/master/PURL-SPECIFICATION.rst#known-qualifiers-keyvalue-pairs).
+func (p PythonDirectURLOriginInfo) vcsURL() string {
+	if p.VCS == "" {
+		return ""
 	}
-	return nil
+	return fmt.Sprintf("%s+%s@%s", p.VCS, p.URL, p.CommitID)
 }
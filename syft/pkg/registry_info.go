@@ -0,0 +1,11 @@
+package pkg
+
+// RegistryInfo describes a package as published within the package registry of its ecosystem (e.g. npm, PyPI, or
+// crates.io), as looked up when enriching the cataloged packages (see the enrich package).
+type RegistryInfo struct {
+	Registry   string `json:"registry"`             // the base URL of the registry the details were looked up from
+	Supplier   string `json:"supplier,omitempty"`   // the person or organization that published the package
+	Homepage   string `json:"homepage,omitempty"`   // the homepage of the project
+	Repository string `json:"repository,omitempty"` // the URL of the source code repository of the project
+	Published  string `json:"published,omitempty"`  // when the version of the package was published (RFC 3339)
+}
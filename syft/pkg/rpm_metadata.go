@@ -1,15 +1,13 @@
 package pkg
 
 import (
-	"fmt"
 	"sort"
-	"strconv"
 
 	"github.com/scylladb/go-set/strset"
 
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/file"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
 // Packages is the legacy Berkely db based format
@@ -37,6 +35,8 @@ type RpmMetadata struct {
 	License         string            `json:"license"`
 	Vendor          string            `json:"vendor"`
 	ModularityLabel string            `json:"modularityLabel"`
+	Provides        []string          `json:"provides,omitempty"` // capabilities provided by the package (e.g. "libc.so.6()(64bit)")
+	Requires        []string          `json:"requires,omitempty"` // capabilities required by the package, which may be file paths (e.g. "/bin/sh")
 	Files           []RpmdbFileRecord `json:"files"`
 }
 
@@ -56,36 +56,7 @@ type RpmdbFileMode uint16
 
 // PackageURL returns the PURL for the specific RHEL package (see https://github.com/package-url/purl-spec)
 func (m RpmMetadata) PackageURL(distro *linux.Release) string {
-	var namespace string
-	if distro != nil {
-		namespace = distro.ID
-	}
-
-	qualifiers := map[string]string{
-		PURLQualifierArch: m.Arch,
-	}
-
-	if m.Epoch != nil {
-		qualifiers[PURLQualifierEpoch] = strconv.Itoa(*m.Epoch)
-	}
-
-	if m.SourceRpm != "" {
-		qualifiers[PURLQualifierUpstream] = m.SourceRpm
-	}
-
-	return packageurl.NewPackageURL(
-		packageurl.TypeRPM,
-		namespace,
-		m.Name,
-		// for purl the epoch is a qualifier, not part of the version
-		// see https://github.com/package-url/purl-spec/blob/master/PURL-TYPES.rst under the RPM section
-		fmt.Sprintf("%s-%s", m.Version, m.Release),
-		PURLQualifiers(
-			qualifiers,
-			distro,
-		),
-		"",
-	).ToString()
+	return purl.RPM(distro, m.Name, m.Version, m.Release, m.Epoch, m.Arch, m.SourceRpm)
 }
 
 func (m RpmMetadata) OwnedFiles() (result []string) {
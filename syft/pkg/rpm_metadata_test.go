@@ -44,7 +44,7 @@ func TestRpmMetadata_pURL(t *testing.T) {
 				Release: "r",
 				Epoch:   intRef(1),
 			},
-			expected: "pkg:rpm/centos/p@v-r?arch=a&epoch=1&distro=centos-7",
+			expected: "pkg:rpm/centos/p@v-r?arch=a&distro=centos-7&epoch=1",
 		},
 		{
 			name: "missing distro",
@@ -68,7 +68,7 @@ func TestRpmMetadata_pURL(t *testing.T) {
 				Release:   "r",
 				SourceRpm: "sourcerpm",
 			},
-			expected: "pkg:rpm/rhel/p@v-r?upstream=sourcerpm&distro=rhel-8.4",
+			expected: "pkg:rpm/rhel/p@v-r?distro=rhel-8.4&upstream=sourcerpm",
 		},
 	}
 
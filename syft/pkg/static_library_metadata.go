@@ -0,0 +1,10 @@
+package pkg
+
+// StaticLibraryMetadata represents the evidence that a library has been statically linked into (or archived within)
+// a binary file, as found by matching symbol and string signatures.
+type StaticLibraryMetadata struct {
+	Signature      string   `mapstructure:"Signature" json:"signature"`
+	Confidence     float64  `mapstructure:"Confidence" json:"confidence"` // a score between 0 and 1 indicating how much of the signature matched
+	MatchedSymbols []string `mapstructure:"MatchedSymbols" json:"matchedSymbols,omitempty"`
+	MatchedStrings []string `mapstructure:"MatchedStrings" json:"matchedStrings,omitempty"`
+}
@@ -0,0 +1,18 @@
+package pkg
+
+// TerraformProviderMetadata represents a provider as locked by the dependency lock file (.terraform.lock.hcl) of a
+// Terraform configuration.
+type TerraformProviderMetadata struct {
+	Source      string   `mapstructure:"source" json:"source" cyclonedx:"source"`                          // the provider address (e.g. "registry.terraform.io/hashicorp/aws")
+	Version     string   `mapstructure:"version" json:"version" cyclonedx:"version"`                       // the selected version of the provider
+	Constraints string   `mapstructure:"constraints" json:"constraints,omitempty" cyclonedx:"constraints"` // the version constraints of the configuration the version was selected by
+	Hashes      []string `mapstructure:"hashes" json:"hashes,omitempty" cyclonedx:"hashes"`                // the checksums of the provider packages (e.g. "h1:..." or "zh:...")
+}
+
+// TerraformModuleMetadata represents a module installed by "terraform init", as recorded in .terraform/modules/modules.json.
+type TerraformModuleMetadata struct {
+	Key     string `mapstructure:"key" json:"key" cyclonedx:"key"`                       // the path of the module call within the configuration (e.g. "vpc" or "vpc.subnets")
+	Source  string `mapstructure:"source" json:"source" cyclonedx:"source"`              // the source address of the module as written in the configuration
+	Version string `mapstructure:"version" json:"version,omitempty" cyclonedx:"version"` // the selected version of a module from a registry
+	Dir     string `mapstructure:"dir" json:"dir,omitempty" cyclonedx:"dir"`             // the directory the module is installed in
+}
@@ -1,32 +1,53 @@
 package pkg
 
-import "github.com/anchore/packageurl-go"
+import (
+	"github.com/anchore/packageurl-go"
+	"github.com/anchore/syft/syft/pkg/purl"
+)
 
 // Type represents a Package Type for or within a language ecosystem (there may be multiple package types within a language ecosystem)
 type Type string
 
 const (
 	// the full set of supported packages
-	UnknownPkg       Type = "UnknownPackage"
-	ApkPkg           Type = "apk"
-	AlpmPkg          Type = "alpm"
-	GemPkg           Type = "gem"
-	DebPkg           Type = "deb"
-	RpmPkg           Type = "rpm"
-	NpmPkg           Type = "npm"
-	PythonPkg        Type = "python"
-	PhpComposerPkg   Type = "php-composer"
-	JavaPkg          Type = "java-archive"
-	JenkinsPluginPkg Type = "jenkins-plugin"
-	GoModulePkg      Type = "go-module"
-	RustPkg          Type = "rust-crate"
-	KbPkg            Type = "msrc-kb"
-	DartPubPkg       Type = "dart-pub"
-	DotnetPkg        Type = "dotnet"
-	CocoapodsPkg     Type = "pod"
-	ConanPkg         Type = "conan"
-	PortagePkg       Type = "portage"
-	HackagePkg       Type = "hackage"
+	UnknownPkg              Type = "UnknownPackage"
+	ApkPkg                  Type = "apk"
+	AlpmPkg                 Type = "alpm"
+	GemPkg                  Type = "gem"
+	DebPkg                  Type = "deb"
+	RpmPkg                  Type = "rpm"
+	NpmPkg                  Type = "npm"
+	PythonPkg               Type = "python"
+	PhpComposerPkg          Type = "php-composer"
+	JavaPkg                 Type = "java-archive"
+	JenkinsPluginPkg        Type = "jenkins-plugin"
+	GoModulePkg             Type = "go-module"
+	RustPkg                 Type = "rust-crate"
+	KbPkg                   Type = "msrc-kb"
+	DartPubPkg              Type = "dart-pub"
+	DotnetPkg               Type = "dotnet"
+	CocoapodsPkg            Type = "pod"
+	ConanPkg                Type = "conan"
+	PortagePkg              Type = "portage"
+	HackagePkg              Type = "hackage"
+	BinaryPkg               Type = "binary"
+	LinuxKernelPkg          Type = "linux-kernel"
+	LinuxKernelModulePkg    Type = "linux-kernel-module"
+	FirmwarePkg             Type = "firmware"
+	WasmPkg                 Type = "wasm"
+	HelmPkg                 Type = "helm"
+	ContainerImagePkg       Type = "container-image"
+	TerraformPkg            Type = "terraform"
+	GithubActionPkg         Type = "github-action"
+	GithubActionWorkflowPkg Type = "github-action-workflow"
+	VSCodeExtensionPkg      Type = "vscode-extension"
+	ChromeExtensionPkg      Type = "chrome-extension"
+	FirefoxExtensionPkg     Type = "firefox-extension"
+	WordpressPluginPkg      Type = "wordpress-plugin"
+	WordpressThemePkg       Type = "wordpress-theme"
+	DrupalModulePkg         Type = "drupal-module"
+	DrupalThemePkg          Type = "drupal-theme"
+	AIModelPkg              Type = "ai-model"
 )
 
 // AllPkgs represents all supported package types
@@ -50,19 +71,37 @@ var AllPkgs = []Type{
 	ConanPkg,
 	PortagePkg,
 	HackagePkg,
+	BinaryPkg,
+	LinuxKernelPkg,
+	LinuxKernelModulePkg,
+	FirmwarePkg,
+	WasmPkg,
+	HelmPkg,
+	ContainerImagePkg,
+	TerraformPkg,
+	GithubActionPkg,
+	GithubActionWorkflowPkg,
+	VSCodeExtensionPkg,
+	ChromeExtensionPkg,
+	FirefoxExtensionPkg,
+	WordpressPluginPkg,
+	WordpressThemePkg,
+	DrupalModulePkg,
+	DrupalThemePkg,
+	AIModelPkg,
 }
 
 // PackageURLType returns the PURL package type for the current package.
 func (t Type) PackageURLType() string {
 	switch t {
 	case ApkPkg:
-		return "alpine"
+		return purl.TypeApk
 	case AlpmPkg:
-		return "alpm"
+		return purl.TypeAlpm
 	case GemPkg:
 		return packageurl.TypeGem
 	case DebPkg:
-		return "deb"
+		return purl.TypeDeb
 	case PythonPkg:
 		return packageurl.TypePyPi
 	case PhpComposerPkg:
@@ -76,19 +115,23 @@ func (t Type) PackageURLType() string {
 	case GoModulePkg:
 		return packageurl.TypeGolang
 	case RustPkg:
-		return "cargo"
+		return purl.TypeCargo
 	case DartPubPkg:
 		return packageurl.TypePub
 	case DotnetPkg:
-		return packageurl.TypeDotnet
+		return purl.TypeNuGet
 	case CocoapodsPkg:
 		return packageurl.TypeCocoapods
 	case ConanPkg:
 		return packageurl.TypeConan
 	case PortagePkg:
-		return "portage"
+		return purl.TypePortage
 	case HackagePkg:
 		return packageurl.TypeHackage
+	case ContainerImagePkg:
+		return purl.TypeOCI
+	case GithubActionPkg, GithubActionWorkflowPkg:
+		return purl.TypeGitHub
 	default:
 		// TODO: should this be a "generic" purl type instead?
 		return ""
@@ -96,12 +139,12 @@ func (t Type) PackageURLType() string {
 }
 
 func TypeFromPURL(p string) Type {
-	purl, err := packageurl.FromString(p)
+	pURL, err := packageurl.FromString(p)
 	if err != nil {
 		return UnknownPkg
 	}
 
-	return TypeByName(purl.Type)
+	return TypeByName(pURL.Type)
 }
 
 func TypeByName(name string) Type {
@@ -110,9 +153,9 @@ func TypeByName(name string) Type {
 		return DebPkg
 	case packageurl.TypeRPM:
 		return RpmPkg
-	case "alpm":
+	case purl.TypeAlpm:
 		return AlpmPkg
-	case "alpine":
+	case purl.TypeApk, "alpine":
 		return ApkPkg
 	case packageurl.TypeMaven:
 		return JavaPkg
@@ -126,11 +169,11 @@ func TypeByName(name string) Type {
 		return PythonPkg
 	case packageurl.TypeGem:
 		return GemPkg
-	case "cargo", "crate":
+	case purl.TypeCargo, "crate":
 		return RustPkg
 	case packageurl.TypePub:
 		return DartPubPkg
-	case packageurl.TypeDotnet:
+	case purl.TypeNuGet, packageurl.TypeDotnet:
 		return DotnetPkg
 	case packageurl.TypeCocoapods:
 		return CocoapodsPkg
@@ -138,8 +181,12 @@ func TypeByName(name string) Type {
 		return ConanPkg
 	case packageurl.TypeHackage:
 		return HackagePkg
-	case "portage":
+	case purl.TypePortage:
 		return PortagePkg
+	case purl.TypeOCI:
+		return ContainerImagePkg
+	case purl.TypeGitHub:
+		return GithubActionPkg
 	default:
 		return UnknownPkg
 	}
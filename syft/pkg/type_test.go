@@ -18,6 +18,10 @@ func TestTypeFromPURL(t *testing.T) {
 			purl:     "pkg:rpm/fedora/util-linux@2.32.1-27.el8-?arch=amd64",
 			expected: RpmPkg,
 		},
+		{
+			purl:     "pkg:apk/alpine/util-linux@2.32.1",
+			expected: ApkPkg,
+		},
 		{
 			purl:     "pkg:alpine/util-linux@2.32.1",
 			expected: ApkPkg,
@@ -47,7 +51,7 @@ func TestTypeFromPURL(t *testing.T) {
 			expected: RustPkg,
 		},
 		{
-			purl:     "pkg:pub/util@1.2.34?hosted_url=pub.hosted.org",
+			purl:     "pkg:pub/util@1.2.34?repository_url=pub.hosted.org",
 			expected: DartPubPkg,
 		},
 
@@ -55,6 +59,10 @@ func TestTypeFromPURL(t *testing.T) {
 			purl:     "pkg:dotnet/Microsoft.CodeAnalysis.Razor@2.2.0",
 			expected: DotnetPkg,
 		},
+		{
+			purl:     "pkg:nuget/Microsoft.CodeAnalysis.Razor@2.2.0",
+			expected: DotnetPkg,
+		},
 		{
 			purl:     "pkg:composer/laravel/laravel@5.5.0",
 			expected: PhpComposerPkg,
@@ -79,6 +87,14 @@ func TestTypeFromPURL(t *testing.T) {
 			purl:     "pkg:hackage/HTTP@4000.3.16",
 			expected: HackagePkg,
 		},
+		{
+			purl:     "pkg:oci/nginx@sha256%3A0d17b565c37bcbd895e9d92315a05c1c3c9a29f762b011a10c54a66cd53c9b31?repository_url=docker.io/bitnami/nginx&tag=1.25.3",
+			expected: ContainerImagePkg,
+		},
+		{
+			purl:     "pkg:github/actions/checkout@v4",
+			expected: GithubActionPkg,
+		},
 	}
 
 	var pkgTypes []string
@@ -91,12 +107,28 @@ func TestTypeFromPURL(t *testing.T) {
 	expectedTypes.Remove(string(KbPkg))
 	expectedTypes.Remove(string(JenkinsPluginPkg))
 	expectedTypes.Remove(string(PortagePkg))
+	expectedTypes.Remove(string(BinaryPkg))
+	expectedTypes.Remove(string(LinuxKernelPkg))
+	expectedTypes.Remove(string(LinuxKernelModulePkg))
+	expectedTypes.Remove(string(FirmwarePkg))
+	expectedTypes.Remove(string(WasmPkg))
+	expectedTypes.Remove(string(HelmPkg))
+	expectedTypes.Remove(string(TerraformPkg))
+	expectedTypes.Remove(string(VSCodeExtensionPkg))
+	expectedTypes.Remove(string(ChromeExtensionPkg))
+	expectedTypes.Remove(string(FirefoxExtensionPkg))
+	expectedTypes.Remove(string(WordpressPluginPkg))
+	expectedTypes.Remove(string(WordpressThemePkg))
+	expectedTypes.Remove(string(DrupalModulePkg))
+	expectedTypes.Remove(string(DrupalThemePkg))
+	expectedTypes.Remove(string(AIModelPkg))
+	expectedTypes.Remove(string(GithubActionWorkflowPkg))
 
 	for _, test := range tests {
 		t.Run(string(test.expected), func(t *testing.T) {
 			actual := TypeFromPURL(test.purl)
 
-			if actual != "" {
+			if actual != "" && !contains(pkgTypes, string(actual)) {
 				pkgTypes = append(pkgTypes, string(actual))
 			}
 
@@ -1,25 +1,11 @@
 package pkg
 
 import (
-	"sort"
-	"strings"
-
-	"github.com/anchore/packageurl-go"
 	"github.com/anchore/syft/syft/linux"
+	"github.com/anchore/syft/syft/pkg/purl"
 )
 
-const (
-	PURLQualifierArch   = "arch"
-	PURLQualifierDistro = "distro"
-	PURLQualifierEpoch  = "epoch"
-	PURLQualifierVCSURL = "vcs_url"
-
-	// PURLQualifierUpstream this qualifier is not in the pURL spec, but is used by grype to perform indirect matching based on source information
-	PURLQualifierUpstream = "upstream"
-
-	purlCargoPkgType  = "cargo"
-	purlGradlePkgType = "gradle"
-)
+const purlGradlePkgType = "gradle"
 
 type urlIdentifier interface {
 	PackageURL(*linux.Release) string
@@ -34,69 +20,16 @@ func URL(p Package, release *linux.Release) string {
 
 	// the remaining cases are primarily reserved for packages without metadata struct instances
 
-	var purlType = p.Type.PackageURLType()
-	var name = p.Name
-	var namespace = ""
-
-	switch {
+	switch purlType := p.Type.PackageURLType(); {
 	case purlType == "":
-		purlType = packageurl.TypeGeneric
+		return purl.New(purl.TypeGeneric, "", p.Name, p.Version, nil, "")
 	case p.Type == NpmPkg:
-		fields := strings.SplitN(p.Name, "/", 2)
-		if len(fields) > 1 {
-			namespace = fields[0]
-			name = fields[1]
-		}
+		return purl.Npm(p.Name, p.Version)
+	case p.Type == PhpComposerPkg:
+		return purl.Composer(p.Name, p.Version)
+	case p.Type == GoModulePkg:
+		return purl.Golang(p.Name, p.Version)
+	default:
+		return purl.New(purlType, "", p.Name, p.Version, nil, "")
 	}
-	// generate a purl from the package data
-	return packageurl.NewPackageURL(
-		purlType,
-		namespace,
-		name,
-		p.Version,
-		nil,
-		"",
-	).ToString()
-}
-
-func PURLQualifiers(vars map[string]string, release *linux.Release) (q packageurl.Qualifiers) {
-	keys := make([]string, 0, len(vars))
-	for k := range vars {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-
-	for _, k := range keys {
-		val := vars[k]
-		if val == "" {
-			continue
-		}
-		q = append(q, packageurl.Qualifier{
-			Key:   k,
-			Value: vars[k],
-		})
-	}
-
-	distroQualifiers := []string{}
-
-	if release == nil {
-		return q
-	}
-
-	if release.ID != "" {
-		distroQualifiers = append(distroQualifiers, release.ID)
-	}
-
-	if release.VersionID != "" {
-		distroQualifiers = append(distroQualifiers, release.VersionID)
-	} else if release.BuildID != "" {
-		distroQualifiers = append(distroQualifiers, release.BuildID)
-	}
-
-	q = append(q, packageurl.Qualifier{
-		Key:   PURLQualifierDistro,
-		Value: strings.Join(distroQualifiers, "-"),
-	})
-
-	return q
 }
@@ -66,7 +66,7 @@ func TestPackageURL(t *testing.T) {
 					Release: "3",
 				},
 			},
-			expected: "pkg:rpm/centos/name@0.1.0-3?arch=amd64&epoch=2&distro=centos-7",
+			expected: "pkg:rpm/centos/name@0.1.0-3?arch=amd64&distro=centos-7&epoch=2",
 		},
 		{
 			name: "cargo",
@@ -148,6 +148,24 @@ func TestPackageURL(t *testing.T) {
 	expectedTypes.Remove(string(ConanPkg))
 	expectedTypes.Remove(string(DartPubPkg))
 	expectedTypes.Remove(string(DotnetPkg))
+	expectedTypes.Remove(string(BinaryPkg))
+	expectedTypes.Remove(string(LinuxKernelPkg))
+	expectedTypes.Remove(string(LinuxKernelModulePkg))
+	expectedTypes.Remove(string(FirmwarePkg))
+	expectedTypes.Remove(string(WasmPkg))
+	expectedTypes.Remove(string(HelmPkg))
+	expectedTypes.Remove(string(ContainerImagePkg))
+	expectedTypes.Remove(string(TerraformPkg))
+	expectedTypes.Remove(string(VSCodeExtensionPkg))
+	expectedTypes.Remove(string(ChromeExtensionPkg))
+	expectedTypes.Remove(string(FirefoxExtensionPkg))
+	expectedTypes.Remove(string(WordpressPluginPkg))
+	expectedTypes.Remove(string(WordpressThemePkg))
+	expectedTypes.Remove(string(DrupalModulePkg))
+	expectedTypes.Remove(string(DrupalThemePkg))
+	expectedTypes.Remove(string(AIModelPkg))
+	expectedTypes.Remove(string(GithubActionPkg))
+	expectedTypes.Remove(string(GithubActionWorkflowPkg))
 	expectedTypes.Remove(string(DebPkg))
 	expectedTypes.Remove(string(GoModulePkg))
 	expectedTypes.Remove(string(HackagePkg))
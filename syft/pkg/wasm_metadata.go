@@ -0,0 +1,17 @@
+package pkg
+
+// WasmMetadata represents all captured data for a WebAssembly module or component, as found in its custom sections.
+type WasmMetadata struct {
+	Name        string         `mapstructure:"name" json:"name,omitempty" cyclonedx:"name"`                      // the name from the "name" (or "component-name") section
+	Format      string         `mapstructure:"format" json:"format" cyclonedx:"format"`                          // either "module" or "component"
+	Languages   []WasmProducer `mapstructure:"languages" json:"languages,omitempty" cyclonedx:"languages"`       // the source languages of the binary (e.g. "Rust" or "C11")
+	ProcessedBy []WasmProducer `mapstructure:"processedBy" json:"processedBy,omitempty" cyclonedx:"processedBy"` // the tools that produced or transformed the binary (e.g. "rustc" or "wit-component")
+	SDKs        []WasmProducer `mapstructure:"sdks" json:"sdks,omitempty" cyclonedx:"sdks"`                      // the SDKs the binary was built with (e.g. "Emscripten")
+	Imports     []string       `mapstructure:"imports" json:"imports,omitempty" cyclonedx:"imports"`             // the modules imported by a core module, or the interfaces imported by a component
+}
+
+// WasmProducer is a single entry of the "producers" section of a WebAssembly binary.
+type WasmProducer struct {
+	Name    string `mapstructure:"name" json:"name" cyclonedx:"name"`
+	Version string `mapstructure:"version" json:"version,omitempty" cyclonedx:"version"`
+}
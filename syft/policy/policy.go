@@ -0,0 +1,117 @@
+/*
+Package policy evaluates the packages of an SBOM against conditions that should fail a scan (such as a disallowed
+license or a package without a version), so that a CI pipeline can gate on the result of the scan.
+*/
+package policy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+const (
+	// NoVersionCondition is violated by packages without a version.
+	NoVersionCondition = "no-version"
+	// NoLicenseCondition is violated by packages without any license.
+	NoLicenseCondition = "no-license"
+)
+
+// builtinConditions are the conditions that are not package filter expressions.
+var builtinConditions = map[string]func(p pkg.Package) bool{
+	NoVersionCondition: func(p pkg.Package) bool {
+		return strings.TrimSpace(p.Version) == ""
+	},
+	NoLicenseCondition: func(p pkg.Package) bool {
+		return len(p.Licenses) == 0
+	},
+}
+
+// BuiltinConditions returns the names of the conditions that are not package filter expressions.
+func BuiltinConditions() []string {
+	var names []string
+	for name := range builtinConditions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Violation is a package matching a condition of the policy.
+type Violation struct {
+	// Condition is the condition matched, as given to the policy (e.g. "license:GPL-3.0*")
+	Condition string `json:"condition"`
+	// Package is the package matching the condition (as "name@version")
+	Package string `json:"package"`
+	// PackageID is the ID of the package matching the condition
+	PackageID artifact.ID `json:"packageId"`
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s matches %q", v.Package, v.Condition)
+}
+
+// Policy is a set of conditions that no package of an SBOM may match.
+type Policy struct {
+	rules []rule
+}
+
+type rule struct {
+	condition string
+	matches   func(p pkg.Package) bool
+}
+
+// New parses the given conditions, returning nil when there are none. Each condition is either a built-in condition
+// (see BuiltinConditions) or a package filter expression (see pkg.FilterField), such as "license:GPL-3.0*" or
+// "type:npm".
+func New(conditions []string) (*Policy, error) {
+	var rules []rule
+	for _, condition := range conditions {
+		condition = strings.TrimSpace(condition)
+		if condition == "" {
+			continue
+		}
+
+		if matches, ok := builtinConditions[strings.ToLower(condition)]; ok {
+			rules = append(rules, rule{condition: condition, matches: matches})
+			continue
+		}
+
+		filter, err := pkg.NewFilter(pkg.FilterConfig{Include: []string{condition}})
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy condition %q (expected one of %v or a package filter expression): %w", condition, BuiltinConditions(), err)
+		}
+		rules = append(rules, rule{condition: condition, matches: filter.Keep})
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	return &Policy{rules: rules}, nil
+}
+
+// Evaluate returns the violations of each package of the SBOM (in package order), which is empty when the SBOM
+// complies with the policy.
+func (p *Policy) Evaluate(s sbom.SBOM) []Violation {
+	if p == nil || s.Artifacts.PackageCatalog == nil {
+		return nil
+	}
+
+	var violations []Violation
+	for _, candidate := range s.Artifacts.PackageCatalog.Sorted() {
+		for _, r := range p.rules {
+			if r.matches(candidate) {
+				violations = append(violations, Violation{
+					Condition: r.condition,
+					Package:   fmt.Sprintf("%s@%s", candidate.Name, candidate.Version),
+					PackageID: candidate.ID(),
+				})
+			}
+		}
+	}
+	return violations
+}
@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/syft/syft/pkg"
+	"github.com/anchore/syft/syft/sbom"
+)
+
+func newSBOM(packages ...pkg.Package) sbom.SBOM {
+	return sbom.SBOM{
+		Artifacts: sbom.Artifacts{
+			PackageCatalog: pkg.NewCatalog(packages...),
+		},
+	}
+}
+
+func TestPolicy_Evaluate(t *testing.T) {
+	lodash := pkg.Package{
+		Name:     "lodash",
+		Version:  "4.17.21",
+		Type:     pkg.NpmPkg,
+		Licenses: pkg.NewLicensesFromValues("MIT"),
+	}
+	lodash.SetID()
+	readline := pkg.Package{
+		Name:     "readline",
+		Version:  "8.1",
+		Type:     pkg.DebPkg,
+		Licenses: pkg.NewLicensesFromValues("GPL-3.0-only"),
+	}
+	readline.SetID()
+	unversioned := pkg.Package{
+		Name: "unversioned",
+		Type: pkg.BinaryPkg,
+	}
+	unversioned.SetID()
+	s := newSBOM(lodash, readline, unversioned)
+
+	tests := []struct {
+		name       string
+		conditions []string
+		expected   []Violation
+	}{
+		{
+			name: "no conditions",
+		},
+		{
+			name:       "disallowed license",
+			conditions: []string{"license:GPL-3.0*"},
+			expected: []Violation{
+				{Condition: "license:GPL-3.0*", Package: "readline@8.1", PackageID: readline.ID()},
+			},
+		},
+		{
+			name:       "package without version",
+			conditions: []string{NoVersionCondition},
+			expected: []Violation{
+				{Condition: NoVersionCondition, Package: "unversioned@", PackageID: unversioned.ID()},
+			},
+		},
+		{
+			name:       "multiple conditions",
+			conditions: []string{"type:binary", NoLicenseCondition},
+			expected: []Violation{
+				{Condition: "type:binary", Package: "unversioned@", PackageID: unversioned.ID()},
+				{Condition: NoLicenseCondition, Package: "unversioned@", PackageID: unversioned.ID()},
+			},
+		},
+		{
+			name:       "no matches",
+			conditions: []string{"type:rpm"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := New(test.conditions)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, p.Evaluate(s))
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	p, err := New([]string{"", " "})
+	require.NoError(t, err)
+	assert.Nil(t, p)
+
+	_, err = New([]string{"version:1.*"})
+	assert.ErrorContains(t, err, `invalid policy condition "version:1.*"`)
+}
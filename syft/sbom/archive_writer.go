@@ -0,0 +1,220 @@
+package sbom
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/mitchellh/go-homedir"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// archiveWriter implements sbom.Writer by bundling the outputs written to files into a single tar or zip archive
+type archiveWriter struct {
+	path    string
+	entries []archiveEntry
+	stdout  []Writer
+}
+
+// archiveEntry is an output that is written as a file within the archive
+type archiveEntry struct {
+	name   string
+	format Format
+}
+
+// NewArchiveWriter creates a sbom.Writer that bundles all outputs with a file into a single archive, named after the
+// base name of each output file. The archive is a zip or (optionally compressed) tar archive, as chosen by the
+// extension of the archive path: ".zip", ".tar", ".tar.gz" (".tgz"), or ".tar.zst" (".tzst"). Outputs without a file
+// are written to os.Stdout as usual.
+func NewArchiveWriter(archivePath string, options ...WriterOption) (Writer, error) {
+	if len(options) == 0 {
+		return nil, fmt.Errorf("no output options provided")
+	}
+
+	expandedPath, err := homedir.Expand(archivePath)
+	if err != nil {
+		log.Warnf("could not expand given archive path=%q: %w", archivePath, err)
+		expandedPath = archivePath
+	}
+	if !isZipArchive(expandedPath) && !isTarArchive(expandedPath) {
+		return nil, fmt.Errorf("unsupported archive %q: the file must end with .zip, .tar, .tar.gz, .tgz, .tar.zst, or .tzst", archivePath)
+	}
+
+	out := &archiveWriter{path: expandedPath}
+	names := make(map[string]bool)
+	for _, option := range options {
+		if option.Path == "" {
+			out.stdout = append(out.stdout, &streamWriter{
+				format: option.Format,
+				out:    os.Stdout,
+			})
+			continue
+		}
+
+		name := filepath.Base(option.Path)
+		if names[name] {
+			return nil, fmt.Errorf("more than one output would be written to %q within the archive", name)
+		}
+		names[name] = true
+		out.entries = append(out.entries, archiveEntry{
+			name:   name,
+			format: option.Format,
+		})
+	}
+
+	return out, nil
+}
+
+// Write writes the SBOM to os.Stdout for each output without a file, and all other outputs to the archive
+func (w *archiveWriter) Write(s SBOM) (errs error) {
+	for _, sw := range w.stdout {
+		if err := sw.Write(s); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+
+	if len(w.entries) > 0 {
+		if err := w.writeArchive(s); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Close is a no-op, since the archive is written (and closed) as a whole by Write
+func (w *archiveWriter) Close() error {
+	return nil
+}
+
+func (w *archiveWriter) writeArchive(s SBOM) error {
+	contents := make([][]byte, len(w.entries))
+	for i, entry := range w.entries {
+		var err error
+		contents[i], err = encodeEntry(entry, s)
+		if err != nil {
+			return fmt.Errorf("unable to encode %s for the archive: %w", entry.name, err)
+		}
+	}
+
+	if err := os.MkdirAll(path.Dir(w.path), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(w.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("unable to create archive: %w", err)
+	}
+
+	if isZipArchive(w.path) {
+		err = writeZip(f, w.entries, contents)
+	} else {
+		err = writeTar(f, w.path, w.entries, contents)
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write archive %s: %w", w.path, err)
+	}
+	return nil
+}
+
+// encodeEntry encodes the SBOM for the given entry, compressing it when the entry is of a compressed file
+func encodeEntry(entry archiveEntry, s SBOM) ([]byte, error) {
+	var buf bytes.Buffer
+	compressed, err := compressor(entry.name, &buf)
+	if err != nil {
+		return nil, err
+	}
+	if compressed == nil {
+		err = entry.format.Encode(&buf, s)
+		return buf.Bytes(), err
+	}
+
+	if err := entry.format.Encode(compressed, s); err != nil {
+		return nil, err
+	}
+	if err := compressed.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeTar(out io.Writer, archivePath string, entries []archiveEntry, contents [][]byte) error {
+	compressed, err := compressor(archivePath, out)
+	if err != nil {
+		return err
+	}
+	if compressed != nil {
+		out = compressed
+	}
+
+	tw := tar.NewWriter(out)
+	now := time.Now()
+	for i, entry := range entries {
+		header := &tar.Header{
+			Typeflag: tar.TypeReg,
+			Name:     entry.name,
+			Mode:     0644,
+			Size:     int64(len(contents[i])),
+			ModTime:  now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tw.Write(contents[i]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	if compressed != nil {
+		return compressed.Close()
+	}
+	return nil
+}
+
+func writeZip(out io.Writer, entries []archiveEntry, contents [][]byte) error {
+	zw := zip.NewWriter(out)
+	now := time.Now()
+	for i, entry := range entries {
+		header := &zip.FileHeader{
+			Name:     entry.name,
+			Method:   zip.Deflate,
+			Modified: now,
+		}
+		header.SetMode(0644)
+		w, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(contents[i]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+func isZipArchive(archivePath string) bool {
+	return strings.HasSuffix(strings.ToLower(archivePath), ".zip")
+}
+
+func isTarArchive(archivePath string) bool {
+	lower := strings.ToLower(archivePath)
+	for _, ext := range []string{".tar", ".tar.gz", ".tgz", ".tar.zst", ".tzst"} {
+		if strings.HasSuffix(lower, ext) {
+			return true
+		}
+	}
+	return false
+}
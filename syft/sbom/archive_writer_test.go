@@ -0,0 +1,132 @@
+package sbom
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveWriter(t *testing.T) {
+	options := func(dir string) []WriterOption {
+		return []WriterOption{
+			{Format: contentsFormat("json", "syft json"), Path: filepath.Join(dir, "sbom.syft.json")},
+			{Format: contentsFormat("spdx-json", "spdx json"), Path: filepath.Join(dir, "out", "sbom.spdx.json.gz")},
+			{Format: contentsFormat("table", "")},
+		}
+	}
+	expected := map[string]string{
+		"sbom.syft.json":    "syft json",
+		"sbom.spdx.json.gz": "spdx json",
+	}
+
+	tests := []struct {
+		name    string
+		archive string
+		read    func(t *testing.T, path string) map[string]string
+	}{
+		{
+			name:    "tar",
+			archive: "sboms.tar",
+			read: func(t *testing.T, path string) map[string]string {
+				f, err := os.Open(path)
+				require.NoError(t, err)
+				defer f.Close()
+				return readTar(t, f)
+			},
+		},
+		{
+			name:    "tar.gz",
+			archive: "sboms.tar.gz",
+			read: func(t *testing.T, path string) map[string]string {
+				f, err := os.Open(path)
+				require.NoError(t, err)
+				defer f.Close()
+				gz, err := gzip.NewReader(f)
+				require.NoError(t, err)
+				return readTar(t, gz)
+			},
+		},
+		{
+			name:    "zip",
+			archive: "sboms.zip",
+			read: func(t *testing.T, path string) map[string]string {
+				zr, err := zip.OpenReader(path)
+				require.NoError(t, err)
+				defer zr.Close()
+
+				entries := make(map[string]string)
+				for _, f := range zr.File {
+					r, err := f.Open()
+					require.NoError(t, err)
+					entries[f.Name] = readEntry(t, f.Name, r)
+					require.NoError(t, r.Close())
+				}
+				return entries
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			archivePath := filepath.Join(tmp, "release", test.archive)
+
+			writer, err := NewArchiveWriter(archivePath, options(tmp)...)
+			require.NoError(t, err)
+			require.NoError(t, writer.Write(SBOM{}))
+			require.NoError(t, writer.Close())
+
+			assert.Equal(t, expected, test.read(t, archivePath))
+			// the outputs are only written within the archive
+			assert.NoFileExists(t, filepath.Join(tmp, "sbom.syft.json"))
+		})
+	}
+}
+
+func TestArchiveWriter_Invalid(t *testing.T) {
+	tmp := t.TempDir()
+
+	_, err := NewArchiveWriter(filepath.Join(tmp, "sboms.rar"), WriterOption{Format: dummyFormat("json"), Path: "sbom.json"})
+	assert.ErrorContains(t, err, "unsupported archive")
+
+	_, err = NewArchiveWriter(filepath.Join(tmp, "sboms.zip"),
+		WriterOption{Format: dummyFormat("json"), Path: "a/sbom.json"},
+		WriterOption{Format: dummyFormat("spdx-json"), Path: "b/sbom.json"},
+	)
+	assert.ErrorContains(t, err, `more than one output would be written to "sbom.json"`)
+
+	_, err = NewArchiveWriter(filepath.Join(tmp, "sboms.zip"))
+	assert.Error(t, err)
+}
+
+func readTar(t *testing.T, r io.Reader) map[string]string {
+	entries := make(map[string]string)
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		entries[header.Name] = readEntry(t, header.Name, tr)
+	}
+	return entries
+}
+
+// readEntry reads the contents of an archive entry, decompressing it when the entry is gzipped
+func readEntry(t *testing.T, name string, r io.Reader) string {
+	if filepath.Ext(name) == ".gz" {
+		gz, err := gzip.NewReader(r)
+		require.NoError(t, err)
+		r = gz
+	}
+	contents, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return string(contents)
+}
@@ -0,0 +1,23 @@
+package sbom
+
+import (
+	"compress/gzip"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressor returns a writer that compresses to the given writer, as chosen by the extension of the given path
+// (".gz" for gzip or ".zst" for zstd). When the path is not of a compressed file nil is returned. Closing the
+// compressor flushes it but does not close the underlying writer.
+func compressor(path string, out io.Writer) (io.WriteCloser, error) {
+	lower := strings.ToLower(path)
+	switch {
+	case strings.HasSuffix(lower, ".gz"), strings.HasSuffix(lower, ".tgz"):
+		return gzip.NewWriter(out), nil
+	case strings.HasSuffix(lower, ".zst"), strings.HasSuffix(lower, ".tzst"):
+		return zstd.NewWriter(out)
+	}
+	return nil, nil
+}
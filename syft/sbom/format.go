@@ -25,6 +25,14 @@ type Format interface {
 	Validate(io.Reader) error
 }
 
+// ConfigurableFormat is a format with options that can be set for each output (e.g. the namespace of an SPDX document).
+type ConfigurableFormat interface {
+	Format
+	// WithOptions returns the format configured with the given options, or an error for an option the format does not
+	// support.
+	WithOptions(options map[string]string) (Format, error)
+}
+
 type format struct {
 	id        FormatID
 	encoder   Encoder
@@ -0,0 +1,32 @@
+package sbom
+
+import "fmt"
+
+// the kinds of minimal images, as detected from the markers found within the image
+const (
+	// DistrolessImage is an image built from the distroless base images (which record the installed debian packages
+	// without a package manager database)
+	DistrolessImage = "distroless"
+	// BusyboxImage is an image providing its userland with a busybox binary
+	BusyboxImage = "busybox"
+	// StaticImage is an image without any known markers, typically a scratch image with static binaries
+	StaticImage = "static"
+)
+
+// MinimalImageReport describes an image where no operating system packages were found, since the image does not
+// contain a package manager database (as is the case for distroless, busybox-based, and static images).
+type MinimalImageReport struct {
+	// Kind is the kind of image detected (see DistrolessImage, BusyboxImage, and StaticImage)
+	Kind string
+	// Markers are the paths of the files that the kind of image was detected from
+	Markers []string
+	// Binaries is the number of executable files within the image
+	Binaries int
+	// ClassifiedBinaries is the number of executable files that packages were found within
+	ClassifiedBinaries int
+}
+
+// Summary describes the report in a single line (e.g. "no package manager database found, 2 of 3 binaries classified").
+func (r MinimalImageReport) Summary() string {
+	return fmt.Sprintf("no package manager database found, %d of %d binaries classified", r.ClassifiedBinaries, r.Binaries)
+}
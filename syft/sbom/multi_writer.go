@@ -77,11 +77,30 @@ func NewWriter(options ...WriterOption) (_ Writer, err error) {
 			if err != nil {
 				return nil, fmt.Errorf("unable to create report file: %w", err)
 			}
-			out.writers = append(out.writers, &streamWriter{
+			writer := &streamWriter{
 				format: option.Format,
 				out:    fileOut,
 				close:  fileOut.Close,
-			})
+			}
+
+			// outputs to compressed files (e.g. "sbom.json.gz") are compressed as they are written
+			compressed, err := compressor(option.Path, fileOut)
+			if err != nil {
+				_ = fileOut.Close()
+				return nil, fmt.Errorf("unable to compress report file: %w", err)
+			}
+			if compressed != nil {
+				writer.out = compressed
+				writer.close = func() error {
+					if err := compressed.Close(); err != nil {
+						_ = fileOut.Close()
+						return err
+					}
+					return fileOut.Close()
+				}
+			}
+
+			out.writers = append(out.writers, writer)
 		}
 	}
 
@@ -1,13 +1,17 @@
 package sbom
 
 import (
+	"compress/gzip"
 	"io"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/docker/docker/pkg/homedir"
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func dummyEncoder(io.Writer, SBOM) error {
@@ -202,3 +206,45 @@ func TestNewWriterOption(t *testing.T) {
 		})
 	}
 }
+
+func contentsFormat(name, contents string) Format {
+	return NewFormat(FormatID(name), func(w io.Writer, _ SBOM) error {
+		_, err := io.WriteString(w, contents)
+		return err
+	}, nil, nil)
+}
+
+func TestOutputWriter_Compressed(t *testing.T) {
+	tmp := t.TempDir()
+
+	writer, err := NewWriter(
+		WriterOption{Format: contentsFormat("json", "plain"), Path: filepath.Join(tmp, "sbom.json")},
+		WriterOption{Format: contentsFormat("json", "gzipped"), Path: filepath.Join(tmp, "sbom.json.gz")},
+		WriterOption{Format: contentsFormat("json", "zstd compressed"), Path: filepath.Join(tmp, "sbom.json.zst")},
+	)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(SBOM{}))
+	require.NoError(t, writer.Close())
+
+	contents, err := os.ReadFile(filepath.Join(tmp, "sbom.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "plain", string(contents))
+
+	f, err := os.Open(filepath.Join(tmp, "sbom.json.gz"))
+	require.NoError(t, err)
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	contents, err = io.ReadAll(gz)
+	require.NoError(t, err)
+	assert.Equal(t, "gzipped", string(contents))
+
+	compressed, err := os.ReadFile(filepath.Join(tmp, "sbom.json.zst"))
+	require.NoError(t, err)
+	zr, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer zr.Close()
+	contents, err = zr.DecodeAll(compressed, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "zstd compressed", string(contents))
+}
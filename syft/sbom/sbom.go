@@ -18,13 +18,29 @@ type SBOM struct {
 }
 
 type Artifacts struct {
-	PackageCatalog      *pkg.Catalog
-	FileMetadata        map[source.Coordinates]source.FileMetadata
-	FileDigests         map[source.Coordinates][]file.Digest
-	FileClassifications map[source.Coordinates][]file.Classification
-	FileContents        map[source.Coordinates]string
-	Secrets             map[source.Coordinates][]file.SearchResult
-	LinuxDistribution   *linux.Release
+	PackageCatalog       *pkg.Catalog
+	FileMetadata         map[source.Coordinates]source.FileMetadata
+	FileDigests          map[source.Coordinates][]file.Digest
+	FileClassifications  map[source.Coordinates][]file.Classification
+	FileContents         map[source.Coordinates]string
+	FileContentsEncoding file.ContentsEncoding
+	Secrets              map[source.Coordinates][]file.SearchResult
+	FileELFMetadata      map[source.Coordinates]file.ELFMetadata
+	Executables          map[source.Coordinates]file.Executable
+	LinuxDistribution    *linux.Release
+	LayerHistory         []pkg.LayerHistory
+	// Diagnostics describe the problems encountered while cataloging, where the results are incomplete
+	Diagnostics []Diagnostic
+	// MinimalImage describes an image without any operating system packages (e.g. a distroless or static image)
+	MinimalImage *MinimalImageReport
+}
+
+// Diagnostic describes the failure of a cataloger, whose results are missing from the SBOM.
+type Diagnostic struct {
+	// Cataloger is the name of the cataloger that failed
+	Cataloger string
+	// Message describes the failure
+	Message string
 }
 
 type Descriptor struct {
@@ -47,6 +63,42 @@ func (s SBOM) RelationshipsSorted() []artifact.Relationship {
 	return relationships
 }
 
+// RelationshipGraph returns a graph of the relationships within the SBOM, for traversing the relationships between
+// artifacts (e.g. finding the transitive dependencies of a package).
+func (s SBOM) RelationshipGraph() *artifact.Graph {
+	return artifact.NewGraph(s.Relationships...)
+}
+
+// PackagesOwningPath returns the packages that contain the file at the given path (within any layer), ordered by ID.
+func (s SBOM) PackagesOwningPath(path string) []pkg.Package {
+	graph := s.RelationshipGraph()
+
+	var results []pkg.Package
+	seen := make(map[artifact.ID]struct{})
+	for _, n := range graph.Nodes() {
+		coordinates, ok := n.(source.Coordinates)
+		if !ok || coordinates.RealPath != path {
+			continue
+		}
+		for _, owner := range graph.Owners(coordinates.ID()) {
+			p, ok := owner.(pkg.Package)
+			if !ok {
+				continue
+			}
+			if _, exists := seen[p.ID()]; exists {
+				continue
+			}
+			seen[p.ID()] = struct{}{}
+			results = append(results, p)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].ID() < results[j].ID()
+	})
+	return results
+}
+
 func (s SBOM) AllCoordinates() []source.Coordinates {
 	set := source.NewCoordinateSet()
 	for coordinates := range s.Artifacts.FileMetadata {
@@ -61,6 +113,15 @@ func (s SBOM) AllCoordinates() []source.Coordinates {
 	for coordinates := range s.Artifacts.FileDigests {
 		set.Add(coordinates)
 	}
+	for coordinates := range s.Artifacts.FileELFMetadata {
+		set.Add(coordinates)
+	}
+	for coordinates := range s.Artifacts.Executables {
+		set.Add(coordinates)
+	}
+	for coordinates := range s.Artifacts.Secrets {
+		set.Add(coordinates)
+	}
 	for _, relationship := range s.Relationships {
 		for _, coordinates := range extractCoordinates(relationship) {
 			set.Add(coordinates)
@@ -0,0 +1,133 @@
+package source
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/registryclient"
+)
+
+const (
+	// baseImageNameAnnotation and baseImageDigestAnnotation are the OCI pre-defined annotations describing the base image
+	// an image was built from (see https://github.com/opencontainers/image-spec/blob/main/annotations.md).
+	baseImageNameAnnotation   = "org.opencontainers.image.base.name"
+	baseImageDigestAnnotation = "org.opencontainers.image.base.digest"
+)
+
+// BaseImageMetadata describes the base image that a container image was built from.
+type BaseImageMetadata struct {
+	Reference string `json:"reference"` // the base image reference (e.g. "docker.io/library/alpine:3.17")
+	Layers    int    `json:"layers"`    // the number of layers (from the bottom of the image) provided by the base image
+}
+
+// DetectBaseImage determines the layers of the image provided by the given base image within a registry, recording
+// the result on the image metadata. When no reference is given the base image declared by the image manifest
+// annotations is used. The base image must be for the same platform as the image.
+func (s *Source) DetectBaseImage(ctx context.Context, reference string, registryOptions *image.RegistryOptions) error {
+	if s.Metadata.Scheme != ImageScheme {
+		return fmt.Errorf("base image detection is only supported for images")
+	}
+
+	if reference == "" {
+		reference = baseImageReferenceFromManifest(s.Metadata.ImageMetadata.RawManifest)
+		if reference == "" {
+			return fmt.Errorf("no base image given and image=%q does not declare a base image", s.Metadata.ImageMetadata.UserInput)
+		}
+		log.Debugf("using base image=%q declared by the image manifest", reference)
+	}
+
+	diffIDs, err := baseImageDiffIDs(ctx, reference, s.Metadata.ImageMetadata, registryOptions)
+	if err != nil {
+		return err
+	}
+
+	layers := baseImageLayerCount(s.Metadata.ImageMetadata.Layers, diffIDs)
+	if layers == 0 {
+		return fmt.Errorf("image=%q is not built from base image=%q", s.Metadata.ImageMetadata.UserInput, reference)
+	}
+
+	s.Metadata.ImageMetadata.BaseImage = &BaseImageMetadata{
+		Reference: reference,
+		Layers:    layers,
+	}
+	return nil
+}
+
+// baseImageReferenceFromManifest returns the base image reference declared within the annotations of the given image
+// manifest, pinned to the base image digest when one is declared.
+func baseImageReferenceFromManifest(rawManifest []byte) string {
+	var manifest struct {
+		Annotations map[string]string `json:"annotations"`
+	}
+	if len(rawManifest) == 0 || json.Unmarshal(rawManifest, &manifest) != nil {
+		return ""
+	}
+
+	reference := manifest.Annotations[baseImageNameAnnotation]
+	if reference == "" {
+		return ""
+	}
+	if digest := manifest.Annotations[baseImageDigestAnnotation]; digest != "" {
+		ref, err := name.ParseReference(reference)
+		if err != nil {
+			return reference
+		}
+		return ref.Context().Digest(digest).String()
+	}
+	return reference
+}
+
+// baseImageDiffIDs fetches the layer diff IDs of the base image from a registry, selecting the image for the platform
+// of the given image.
+func baseImageDiffIDs(ctx context.Context, reference string, metadata ImageMetadata, registryOptions *image.RegistryOptions) ([]string, error) {
+	ref, err := name.ParseReference(reference, registryclient.NameOptions(registryOptions)...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse base image reference=%q: %w", reference, err)
+	}
+
+	opts := registryclient.RemoteOptions(ctx, registryOptions)
+	if metadata.OS != "" && metadata.Architecture != "" {
+		opts = append(opts, remote.WithPlatform(v1.Platform{
+			OS:           metadata.OS,
+			Architecture: metadata.Architecture,
+			Variant:      metadata.Variant,
+		}))
+	}
+
+	img, err := remote.Image(ref, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch base image=%q: %w", reference, err)
+	}
+	config, err := img.ConfigFile()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config for base image=%q: %w", reference, err)
+	}
+
+	diffIDs := make([]string, len(config.RootFS.DiffIDs))
+	for idx, diffID := range config.RootFS.DiffIDs {
+		diffIDs[idx] = diffID.String()
+	}
+	return diffIDs, nil
+}
+
+// baseImageLayerCount returns the number of layers provided by the base image with the given layer diff IDs. Since an
+// image built from a base image starts with all the base image layers, no layers are attributed to the base image when
+// any base image layer does not match.
+func baseImageLayerCount(layers []LayerMetadata, baseDiffIDs []string) int {
+	if len(baseDiffIDs) > len(layers) {
+		return 0
+	}
+	for idx, diffID := range baseDiffIDs {
+		if layers[idx].Digest != diffID {
+			return 0
+		}
+	}
+	return len(baseDiffIDs)
+}
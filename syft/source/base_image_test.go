@@ -0,0 +1,98 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_baseImageReferenceFromManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{
+			name:     "no manifest",
+			manifest: "",
+			expected: "",
+		},
+		{
+			name:     "no annotations",
+			manifest: `{"schemaVersion": 2, "mediaType": "application/vnd.oci.image.manifest.v1+json"}`,
+			expected: "",
+		},
+		{
+			name:     "base image name",
+			manifest: `{"annotations": {"org.opencontainers.image.base.name": "docker.io/library/alpine:3.17"}}`,
+			expected: "docker.io/library/alpine:3.17",
+		},
+		{
+			name: "base image name and digest",
+			manifest: `{"annotations": {
+				"org.opencontainers.image.base.name": "docker.io/library/alpine:3.17",
+				"org.opencontainers.image.base.digest": "sha256:ff6bdca1701f3a8a67e328815ff2346b0e4067d32ec36b7992c1fdc001dc8517"
+			}}`,
+			expected: "index.docker.io/library/alpine@sha256:ff6bdca1701f3a8a67e328815ff2346b0e4067d32ec36b7992c1fdc001dc8517",
+		},
+		{
+			name:     "base image digest only",
+			manifest: `{"annotations": {"org.opencontainers.image.base.digest": "sha256:ff6bdca1701f3a8a67e328815ff2346b0e4067d32ec36b7992c1fdc001dc8517"}}`,
+			expected: "",
+		},
+		{
+			name:     "invalid manifest",
+			manifest: `{"annotations": [`,
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, baseImageReferenceFromManifest([]byte(test.manifest)))
+		})
+	}
+}
+
+func Test_baseImageLayerCount(t *testing.T) {
+	layers := []LayerMetadata{
+		{Digest: "sha256:base-1"},
+		{Digest: "sha256:base-2"},
+		{Digest: "sha256:app"},
+	}
+	tests := []struct {
+		name        string
+		baseDiffIDs []string
+		expected    int
+	}{
+		{
+			name:        "base image layers",
+			baseDiffIDs: []string{"sha256:base-1", "sha256:base-2"},
+			expected:    2,
+		},
+		{
+			name:        "the image is the base image",
+			baseDiffIDs: []string{"sha256:base-1", "sha256:base-2", "sha256:app"},
+			expected:    3,
+		},
+		{
+			name:        "different base image",
+			baseDiffIDs: []string{"sha256:base-1", "sha256:other"},
+			expected:    0,
+		},
+		{
+			name:        "base image layers are not at the bottom of the image",
+			baseDiffIDs: []string{"sha256:base-2", "sha256:app"},
+			expected:    0,
+		},
+		{
+			name:        "base image with more layers than the image",
+			baseDiffIDs: []string{"sha256:base-1", "sha256:base-2", "sha256:app", "sha256:more"},
+			expected:    0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, baseImageLayerCount(layers, test.baseDiffIDs))
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/images/archive"
+	"github.com/containerd/containerd/namespaces"
+	"github.com/containerd/containerd/platforms"
+	"github.com/containerd/containerd/reference/docker"
+
+	"github.com/anchore/stereoscope"
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+const (
+	// containerdScheme is the user input prefix for images within a containerd image store (e.g. "containerd:alpine")
+	containerdScheme           = "containerd"
+	defaultContainerdAddress   = "/run/containerd/containerd.sock"
+	defaultContainerdNamespace = "default"
+)
+
+// ContainerdOptions describes how to reach the containerd image store for images given with the "containerd:" scheme.
+type ContainerdOptions struct {
+	Address   string // the containerd socket (default: $CONTAINERD_ADDRESS or /run/containerd/containerd.sock)
+	Namespace string // the namespace the image is stored within (default: $CONTAINERD_NAMESPACE or "default")
+}
+
+func (o ContainerdOptions) address() string {
+	if o.Address != "" {
+		return o.Address
+	}
+	if address := os.Getenv("CONTAINERD_ADDRESS"); address != "" {
+		return address
+	}
+	return defaultContainerdAddress
+}
+
+func (o ContainerdOptions) namespace() string {
+	if o.Namespace != "" {
+		return o.Namespace
+	}
+	if namespace := os.Getenv("CONTAINERD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+	return defaultContainerdNamespace
+}
+
+// generateContainerdSource exports an image from the containerd image store to a (docker compatible) image archive,
+// which is then read like any other image archive.
+func generateContainerdSource(ctx context.Context, in Input) (*Source, func(), error) {
+	archivePath, err := exportContainerdImage(ctx, in)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("could not export image %q from containerd: %w", in.Location, err)
+	}
+	removeArchive := func() {
+		if err := os.Remove(archivePath); err != nil {
+			log.Warnf("unable to remove exported image archive=%q: %+v", archivePath, err)
+		}
+	}
+
+	img, err := stereoscope.GetImageFromSource(ctx, archivePath, image.DockerTarballSource)
+	if err != nil || img == nil {
+		return nil, removeArchive, fmt.Errorf("could not read image %q exported from containerd: %w", in.Location, err)
+	}
+	cleanup := func() {
+		if err := img.Cleanup(); err != nil {
+			log.Warnf("unable to cleanup image=%q: %+v", in.UserInput, err)
+		}
+		removeArchive()
+	}
+
+	s, err := NewFromImage(img, in.Location)
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("could not populate source with image: %w", err)
+	}
+
+	return &s, cleanup, nil
+}
+
+// exportContainerdImage writes the content of the requested image (for a single platform) to a temporary archive,
+// returning the path to the archive.
+func exportContainerdImage(ctx context.Context, in Input) (string, error) {
+	var opts ContainerdOptions
+	if in.Containerd != nil {
+		opts = *in.Containerd
+	}
+	address, namespace := opts.address(), opts.namespace()
+
+	ref, err := docker.ParseDockerRef(in.Location)
+	if err != nil {
+		return "", fmt.Errorf("invalid image reference %q: %w", in.Location, err)
+	}
+
+	platform := platforms.DefaultStrict()
+	if in.Platform != "" {
+		p, err := platforms.Parse(in.Platform)
+		if err != nil {
+			return "", fmt.Errorf("invalid platform=%q: %w", in.Platform, err)
+		}
+		platform = platforms.OnlyStrict(p)
+	}
+
+	client, err := containerd.New(address, containerd.WithDefaultNamespace(namespace))
+	if err != nil {
+		return "", fmt.Errorf("unable to connect to containerd at %q: %w", address, err)
+	}
+	defer internal.CloseAndLogError(client, address)
+
+	ctx = namespaces.WithNamespace(ctx, namespace)
+	imageStore := client.ImageService()
+	img, err := imageStore.Get(ctx, ref.String())
+	if err != nil {
+		return "", fmt.Errorf("unable to find image %q within namespace %q: %w", ref.String(), namespace, err)
+	}
+
+	fh, err := os.CreateTemp("", "syft-containerd-image-*.tar")
+	if err != nil {
+		return "", fmt.Errorf("unable to create image archive: %w", err)
+	}
+	defer internal.CloseAndLogError(fh, fh.Name())
+
+	log.Debugf("exporting image=%q from containerd namespace=%q", img.Name, namespace)
+	// note: the archive includes a docker manifest (manifest.json) for the selected platform in addition to the OCI index
+	if err := client.Export(ctx, fh, archive.WithImage(imageStore, img.Name), archive.WithPlatform(platform)); err != nil {
+		if removeErr := os.Remove(fh.Name()); removeErr != nil {
+			log.Warnf("unable to remove image archive=%q: %+v", fh.Name(), removeErr)
+		}
+		return "", fmt.Errorf("unable to export image %q: %w", img.Name, err)
+	}
+
+	return fh.Name(), nil
+}
@@ -0,0 +1,62 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInput_Containerd(t *testing.T) {
+	in, err := ParseInput("containerd:alpine:3.16", "linux/arm64", true)
+	require.NoError(t, err)
+
+	assert.Equal(t, ImageScheme, in.Scheme)
+	assert.Equal(t, "alpine:3.16", in.Location)
+	assert.Equal(t, "linux/arm64", in.Platform)
+	assert.NotNil(t, in.Containerd)
+}
+
+func TestContainerdOptions_Defaults(t *testing.T) {
+	tests := []struct {
+		name              string
+		opts              ContainerdOptions
+		env               map[string]string
+		expectedAddress   string
+		expectedNamespace string
+	}{
+		{
+			name:              "defaults",
+			expectedAddress:   defaultContainerdAddress,
+			expectedNamespace: defaultContainerdNamespace,
+		},
+		{
+			name: "from environment",
+			env: map[string]string{
+				"CONTAINERD_ADDRESS":   "/run/k3s/containerd/containerd.sock",
+				"CONTAINERD_NAMESPACE": "k8s.io",
+			},
+			expectedAddress:   "/run/k3s/containerd/containerd.sock",
+			expectedNamespace: "k8s.io",
+		},
+		{
+			name: "explicit options take precedence",
+			opts: ContainerdOptions{Address: "/tmp/containerd.sock", Namespace: "moby"},
+			env: map[string]string{
+				"CONTAINERD_ADDRESS":   "/run/k3s/containerd/containerd.sock",
+				"CONTAINERD_NAMESPACE": "k8s.io",
+			},
+			expectedAddress:   "/tmp/containerd.sock",
+			expectedNamespace: "moby",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Setenv("CONTAINERD_ADDRESS", test.env["CONTAINERD_ADDRESS"])
+			t.Setenv("CONTAINERD_NAMESPACE", test.env["CONTAINERD_NAMESPACE"])
+
+			assert.Equal(t, test.expectedAddress, test.opts.address())
+			assert.Equal(t, test.expectedNamespace, test.opts.namespace())
+		})
+	}
+}
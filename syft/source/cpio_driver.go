@@ -0,0 +1,344 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+const (
+	cpioNewcMagic   = "070701"
+	cpioCRCMagic    = "070702"
+	cpioOdcMagic    = "070707"
+	cpioTrailer     = "TRAILER!!!"
+	cpioNewcHdrSize = 110
+	cpioOdcHdrSize  = 76
+
+	cpioModeTypeMask = 0170000
+	cpioModeDir      = 0040000
+	cpioModeRegular  = 0100000
+	cpioModeSymlink  = 0120000
+)
+
+var _ filesystemDriver = cpioDriver{}
+
+// cpioDriver extracts cpio archives (newc, crc, and odc formats), such as initramfs images. Archives may be compressed,
+// and may be a concatenation of multiple archives (e.g. an uncompressed early microcode archive followed by a
+// compressed root filesystem archive).
+type cpioDriver struct{}
+
+type cpioEntry struct {
+	name   string
+	mode   int64
+	size   int64
+	nlink  int64
+	inode  int64
+	device int64
+	isNewc bool
+}
+
+func (d cpioDriver) String() string {
+	return "cpio"
+}
+
+func (d cpioDriver) detect(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	r, closer := decompressedReader(bufio.NewReader(f))
+	defer closer()
+
+	header, err := r.Peek(len(cpioNewcMagic))
+	if err != nil {
+		return false
+	}
+	return isCPIOMagic(header)
+}
+
+func (d cpioDriver) extract(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	r := bufio.NewReader(f)
+	for {
+		// each (possibly compressed) archive within the file is extracted on top of the previous archives
+		archive, closer := decompressedReader(r)
+		err := extractCPIOArchive(archive, dir)
+		if err != nil {
+			closer()
+			return err
+		}
+
+		if archive != r {
+			// there is no reliable way to find the end of a compressed stream, so stop after the first compressed archive
+			closer()
+			return nil
+		}
+
+		if !skipPadding(r) {
+			return nil
+		}
+	}
+}
+
+// skipPadding consumes any NUL padding between concatenated archives, returning true if another archive follows.
+func skipPadding(r *bufio.Reader) bool {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return false
+		}
+		if b[0] != 0 {
+			return true
+		}
+		if _, err := r.Discard(1); err != nil {
+			return false
+		}
+	}
+}
+
+func isCPIOMagic(header []byte) bool {
+	switch string(header) {
+	case cpioNewcMagic, cpioCRCMagic, cpioOdcMagic:
+		return true
+	}
+	return false
+}
+
+// extractCPIOArchive extracts all entries of a single archive (up to and including the trailer entry) into the given
+// directory. Device files and named pipes are skipped, since they hold no content to catalog.
+func extractCPIOArchive(r *bufio.Reader, dir string) error {
+	cr := &countingReader{reader: r}
+	hardlinks := make(map[[2]int64][]string)
+
+	for {
+		entry, err := readCPIOHeader(cr)
+		if err != nil {
+			return err
+		}
+		if entry.name == cpioTrailer {
+			// the trailer (and any alignment padding) ends this archive
+			return nil
+		}
+
+		target, err := safeJoin(dir, entry.name)
+		if err != nil {
+			log.Debugf("skipping cpio entry: %+v", err)
+			if err := discard(cr, entry.size, entry.isNewc); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := extractCPIOEntry(cr, entry, target, hardlinks); err != nil {
+			return fmt.Errorf("unable to extract cpio entry=%q: %w", entry.name, err)
+		}
+	}
+}
+
+func extractCPIOEntry(cr *countingReader, entry *cpioEntry, target string, hardlinks map[[2]int64][]string) error {
+	switch entry.mode & cpioModeTypeMask {
+	case cpioModeDir:
+		if err := os.MkdirAll(target, 0755); err != nil {
+			return err
+		}
+		return discard(cr, entry.size, entry.isNewc)
+
+	case cpioModeSymlink:
+		linkTarget := make([]byte, entry.size)
+		if _, err := io.ReadFull(cr, linkTarget); err != nil {
+			return err
+		}
+		if err := alignTo4(cr, entry.isNewc); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		_ = os.Remove(target)
+		return os.Symlink(string(linkTarget), target)
+
+	case cpioModeRegular:
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		key := [2]int64{entry.device, entry.inode}
+		if entry.size == 0 && entry.nlink > 1 {
+			// newc hardlinks carry the file contents only on the last entry of the set
+			hardlinks[key] = append(hardlinks[key], target)
+		}
+
+		if err := writeCPIOFile(cr, target, entry); err != nil {
+			return err
+		}
+
+		if entry.size > 0 {
+			for _, link := range hardlinks[key] {
+				_ = os.Remove(link)
+				if err := os.Link(target, link); err != nil {
+					log.Debugf("unable to create hardlink=%q for cpio entry=%q: %+v", link, entry.name, err)
+				}
+			}
+			delete(hardlinks, key)
+		}
+		return nil
+
+	default:
+		// device files, named pipes, and sockets
+		return discard(cr, entry.size, entry.isNewc)
+	}
+}
+
+func writeCPIOFile(cr *countingReader, target string, entry *cpioEntry) error {
+	_ = os.Remove(target)
+	fh, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(entry.mode&0777|0600))
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(fh, target)
+
+	if _, err := io.CopyN(fh, cr, entry.size); err != nil {
+		return err
+	}
+	return alignTo4(cr, entry.isNewc)
+}
+
+func readCPIOHeader(cr *countingReader) (*cpioEntry, error) {
+	magic := make([]byte, len(cpioNewcMagic))
+	if _, err := io.ReadFull(cr, magic); err != nil {
+		return nil, fmt.Errorf("unable to read cpio header: %w", err)
+	}
+
+	switch string(magic) {
+	case cpioNewcMagic, cpioCRCMagic:
+		return readNewcHeader(cr)
+	case cpioOdcMagic:
+		return readOdcHeader(cr)
+	}
+	return nil, fmt.Errorf("invalid cpio header magic=%q at offset=%d", magic, cr.count-int64(len(magic)))
+}
+
+func readNewcHeader(cr *countingReader) (*cpioEntry, error) {
+	buf := make([]byte, cpioNewcHdrSize-len(cpioNewcMagic))
+	if _, err := io.ReadFull(cr, buf); err != nil {
+		return nil, err
+	}
+
+	// fields: ino, mode, uid, gid, nlink, mtime, filesize, devmajor, devminor, rdevmajor, rdevminor, namesize, check
+	fields := make([]int64, 13)
+	for i := range fields {
+		v, err := strconv.ParseInt(string(buf[i*8:(i+1)*8]), 16, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpio header field: %w", err)
+		}
+		fields[i] = v
+	}
+
+	name, err := readCPIOName(cr, fields[11])
+	if err != nil {
+		return nil, err
+	}
+	if err := alignTo4(cr, true); err != nil {
+		return nil, err
+	}
+
+	return &cpioEntry{
+		name:   name,
+		inode:  fields[0],
+		mode:   fields[1],
+		nlink:  fields[4],
+		size:   fields[6],
+		device: fields[7]<<32 | fields[8],
+		isNewc: true,
+	}, nil
+}
+
+func readOdcHeader(cr *countingReader) (*cpioEntry, error) {
+	buf := make([]byte, cpioOdcHdrSize-len(cpioOdcMagic))
+	if _, err := io.ReadFull(cr, buf); err != nil {
+		return nil, err
+	}
+
+	// fields: dev(6), ino(6), mode(6), uid(6), gid(6), nlink(6), rdev(6), mtime(11), namesize(6), filesize(11)
+	widths := []int{6, 6, 6, 6, 6, 6, 6, 11, 6, 11}
+	fields := make([]int64, len(widths))
+	offset := 0
+	for i, width := range widths {
+		v, err := strconv.ParseInt(string(buf[offset:offset+width]), 8, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cpio header field: %w", err)
+		}
+		fields[i] = v
+		offset += width
+	}
+
+	name, err := readCPIOName(cr, fields[8])
+	if err != nil {
+		return nil, err
+	}
+
+	return &cpioEntry{
+		name:   name,
+		device: fields[0],
+		inode:  fields[1],
+		mode:   fields[2],
+		nlink:  fields[5],
+		size:   fields[9],
+	}, nil
+}
+
+func readCPIOName(r io.Reader, size int64) (string, error) {
+	if size <= 0 || size > 4096 {
+		return "", fmt.Errorf("invalid cpio entry name size=%d", size)
+	}
+	name := make([]byte, size)
+	if _, err := io.ReadFull(r, name); err != nil {
+		return "", err
+	}
+	return string(bytes.TrimRight(name, "\x00")), nil
+}
+
+func discard(cr *countingReader, size int64, isNewc bool) error {
+	if _, err := io.CopyN(io.Discard, cr, size); err != nil {
+		return err
+	}
+	return alignTo4(cr, isNewc)
+}
+
+// alignTo4 consumes padding to the next 4 byte boundary (only the newc formats are aligned).
+func alignTo4(cr *countingReader, isNewc bool) error {
+	if !isNewc {
+		return nil
+	}
+	padding := (4 - cr.count%4) % 4
+	if _, err := io.CopyN(io.Discard, cr, padding); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+type countingReader struct {
+	reader io.Reader
+	count  int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.reader.Read(p)
+	c.count += int64(n)
+	return n, err
+}
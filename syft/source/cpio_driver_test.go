@@ -0,0 +1,96 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromFile_WithInitramfs(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      string
+		inputPaths []string
+		expRefs    int
+	}{
+		{
+			name:       "newc archive",
+			input:      "test-fixtures/initramfs/newc.cpio",
+			inputPaths: []string{"/etc/os-release", "/usr/lib/libfoo.so.1", "/bin/init"},
+			expRefs:    3,
+		},
+		{
+			name:       "odc archive",
+			input:      "test-fixtures/initramfs/odc.cpio",
+			inputPaths: []string{"/etc/os-release", "/usr/lib/libfoo.so.1", "/bin/init"},
+			expRefs:    3,
+		},
+		{
+			name:       "gzip compressed newc archive",
+			input:      "test-fixtures/initramfs/newc.cpio.gz",
+			inputPaths: []string{"/etc/os-release", "/usr/lib/libfoo.so.1", "/bin/init"},
+			expRefs:    3,
+		},
+		{
+			name:       "concatenated microcode and compressed archives",
+			input:      "test-fixtures/initramfs/concatenated.img",
+			inputPaths: []string{"/etc/os-release", "/kernel/x86/microcode/GenuineIntel.bin"},
+			expRefs:    2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, cleanup := NewFromFile(test.input)
+			if cleanup != nil {
+				t.Cleanup(cleanup)
+			}
+
+			assert.Equal(t, test.input, src.Metadata.Path)
+			assert.NotEqual(t, src.Metadata.Path, src.path)
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			refs, err := resolver.FilesByPath(test.inputPaths...)
+			require.NoError(t, err)
+			assert.Len(t, refs, test.expRefs)
+
+			// symlinks within the archive are preserved
+			links, err := resolver.FilesByPath("/bin/libfoo-link")
+			require.NoError(t, err)
+			if assert.Len(t, links, 1) {
+				assert.Equal(t, "usr/lib/libfoo.so.1", links[0].RealPath)
+			}
+		})
+	}
+}
+
+func TestFilesystemDriverFor(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected filesystemDriver
+	}{
+		{input: "test-fixtures/initramfs/newc.cpio", expected: cpioDriver{}},
+		{input: "test-fixtures/initramfs/newc.cpio.gz", expected: cpioDriver{}},
+		{input: "test-fixtures/initramfs/concatenated.img", expected: cpioDriver{}},
+		{input: "test-fixtures/path-detected/.vimrc", expected: nil},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, filesystemDriverFor(test.input))
+		})
+	}
+}
+
+func TestSafeJoin(t *testing.T) {
+	dir := t.TempDir()
+
+	target, err := safeJoin(dir, "../../etc/passwd")
+	require.NoError(t, err)
+	assert.Equal(t, dir+"/etc/passwd", target)
+
+	target, err = safeJoin(dir, "./usr/lib/libfoo.so.1")
+	require.NoError(t, err)
+	assert.Equal(t, dir+"/usr/lib/libfoo.so.1", target)
+}
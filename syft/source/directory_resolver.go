@@ -36,7 +36,10 @@ type pathFilterFn func(string, os.FileInfo) bool
 
 // directoryResolver implements path and content access for the directory data source.
 type directoryResolver struct {
-	path                    string
+	path string
+	// rootfs indicates that path is the root filesystem of another mount namespace (e.g. /proc/<pid>/root), where
+	// absolute symlinks and runtime paths (such as /proc) are relative to path instead of the host root
+	rootfs                  bool
 	currentWdRelativeToRoot string
 	currentWd               string
 	fileTree                *filetree.FileTree
@@ -48,22 +51,49 @@ type directoryResolver struct {
 }
 
 func newDirectoryResolver(root string, pathFilters ...pathFilterFn) (*directoryResolver, error) {
+	// we have to account for the root being accessed through a symlink path and always resolve the real path. Otherwise
+	// we will not be able to normalize given paths that fall under the resolver
+	cleanRoot, err := filepath.EvalSymlinks(root)
+	if err != nil {
+		return nil, fmt.Errorf("could not evaluate root=%q symlinks: %w", root, err)
+	}
+
+	resolver, err := newDirectoryResolverAt(cleanRoot, false, append([]pathFilterFn{isUnixSystemRuntimePath}, pathFilters...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return resolver, indexAllRoots(cleanRoot, resolver.indexTree)
+}
+
+// newRootfsResolver creates a resolver for the root filesystem of another mount namespace, such as the merged
+// filesystem of a running container (/proc/<pid>/root). Unlike a directory, the root is not resolved (it is typically a
+// magic link) and symlinks are resolved as if the root were "/".
+func newRootfsResolver(root string, pathFilters ...pathFilterFn) (*directoryResolver, error) {
+	cleanRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("could not determine absolute path of root=%q: %w", root, err)
+	}
+
+	resolver, err := newDirectoryResolverAt(cleanRoot, true, pathFilters...)
+	if err != nil {
+		return nil, err
+	}
+	resolver.pathFilterFns = append(resolver.pathFilterFns, resolver.isRootfsRuntimePath)
+
+	return resolver, indexAllRoots(cleanRoot, resolver.indexTree)
+}
+
+func newDirectoryResolverAt(cleanRoot string, rootfs bool, pathFilters ...pathFilterFn) (*directoryResolver, error) {
 	currentWD, err := os.Getwd()
 	if err != nil {
 		return nil, fmt.Errorf("could not gret CWD: %w", err)
 	}
-	// we have to account for the root being accessed through a symlink path and always resolve the real path. Otherwise
-	// we will not be able to normalize given paths that fall under the resolver
 	cleanCWD, err := filepath.EvalSymlinks(currentWD)
 	if err != nil {
 		return nil, fmt.Errorf("could not evaluate CWD symlinks: %w", err)
 	}
 
-	cleanRoot, err := filepath.EvalSymlinks(root)
-	if err != nil {
-		return nil, fmt.Errorf("could not evaluate root=%q symlinks: %w", root, err)
-	}
-
 	var currentWdRelRoot string
 	if path.IsAbs(cleanRoot) {
 		currentWdRelRoot, err = filepath.Rel(cleanCWD, cleanRoot)
@@ -76,16 +106,17 @@ func newDirectoryResolver(root string, pathFilters ...pathFilterFn) (*directoryR
 
 	resolver := directoryResolver{
 		path:                    cleanRoot,
+		rootfs:                  rootfs,
 		currentWd:               cleanCWD,
 		currentWdRelativeToRoot: currentWdRelRoot,
 		fileTree:                filetree.NewFileTree(),
 		metadata:                make(map[file.ID]FileMetadata),
-		pathFilterFns:           append([]pathFilterFn{isUnallowableFileType, isUnixSystemRuntimePath}, pathFilters...),
+		pathFilterFns:           append([]pathFilterFn{isUnallowableFileType}, pathFilters...),
 		refsByMIMEType:          make(map[string][]file.Reference),
 		errPaths:                make(map[string]error),
 	}
 
-	return &resolver, indexAllRoots(cleanRoot, resolver.indexTree)
+	return &resolver, nil
 }
 
 func (r *directoryResolver) indexTree(root string, stager *progress.Stage) ([]string, error) {
@@ -113,8 +144,15 @@ func (r *directoryResolver) indexTree(root string, stager *progress.Stage) ([]st
 		return roots, nil
 	}
 
-	return roots, filepath.Walk(root,
+	walkRoot := root
+	if r.rootfs && root == r.path {
+		// the root may be a magic link (e.g. /proc/<pid>/root), which must be followed to walk the filesystem beneath it
+		walkRoot += string(filepath.Separator)
+	}
+
+	return roots, filepath.Walk(walkRoot,
 		func(path string, info os.FileInfo, err error) error {
+			path = filepath.Clean(path)
 			stager.Current = path
 
 			newRoot, err := r.indexPath(path, info, err)
@@ -246,7 +284,10 @@ func (r directoryResolver) addSymlinkToIndex(p string, info os.FileInfo) (string
 
 	// note: if the link is not absolute (e.g, /dev/stderr -> fd/2 ) we need to resolve it relative to the directory
 	// in question (e.g. resolve to /dev/fd/2)
-	if !filepath.IsAbs(linkTarget) {
+	switch {
+	case r.rootfs:
+		linkTarget = r.rootfsLinkTarget(p, linkTarget)
+	case !filepath.IsAbs(linkTarget):
 		linkTarget = filepath.Join(filepath.Dir(p), linkTarget)
 	}
 
@@ -336,7 +377,7 @@ func (r directoryResolver) FilesByPath(userPaths ...string) ([]Location, error)
 		}
 
 		// we should be resolving symlinks and preserving this information as a VirtualPath to the real file
-		evaluatedPath, err := filepath.EvalSymlinks(userStrPath)
+		evaluatedPath, err := r.evalSymlinks(userStrPath)
 		if err != nil {
 			log.Debugf("directory resolver unable to evaluate symlink for path=%q : %+v", userPath, err)
 			continue
@@ -382,6 +423,23 @@ func (r directoryResolver) FilesByPath(userPaths ...string) ([]Location, error)
 	return references, nil
 }
 
+// evalSymlinks returns the given path after resolving all symlinks. For a root filesystem the index is used, since the
+// OS would resolve absolute symlinks (and the root itself, when a magic link) relative to the host root.
+func (r directoryResolver) evalSymlinks(p string) (string, error) {
+	if !r.rootfs {
+		return filepath.EvalSymlinks(p)
+	}
+
+	exists, ref, err := r.fileTree.File(file.Path(p), filetree.FollowBasenameLinks)
+	if err != nil {
+		return "", err
+	}
+	if !exists || ref == nil {
+		return "", fmt.Errorf("path=%q: %w", p, os.ErrNotExist)
+	}
+	return string(ref.RealPath), nil
+}
+
 // FilesByGlob returns all file.References that match the given path glob pattern from any layer in the image.
 func (r directoryResolver) FilesByGlob(patterns ...string) ([]Location, error) {
 	result := make([]Location, 0)
@@ -511,6 +569,30 @@ func isUnixSystemRuntimePath(path string, _ os.FileInfo) bool {
 	return internal.HasAnyOfPrefixes(path, unixSystemRuntimePrefixes...)
 }
 
+// isRootfsRuntimePath indicates if the given path is a runtime path (such as /proc) within the root filesystem.
+func (r directoryResolver) isRootfsRuntimePath(p string, info os.FileInfo) bool {
+	return isUnixSystemRuntimePath(r.rootfsPath(p), info)
+}
+
+// rootfsPath returns the given path as seen from within the root filesystem (that is, relative to the resolver root).
+func (r directoryResolver) rootfsPath(p string) string {
+	rel, err := filepath.Rel(r.path, p)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return p
+	}
+	return path.Join("/", filepath.ToSlash(rel))
+}
+
+// rootfsLinkTarget resolves the target of the symlink at the given path as if the resolver root were "/", such that
+// neither absolute targets nor ".." components can escape the root filesystem.
+func (r directoryResolver) rootfsLinkTarget(p, linkTarget string) string {
+	if !path.IsAbs(linkTarget) {
+		linkTarget = path.Join(path.Dir(r.rootfsPath(p)), linkTarget)
+	}
+	// note: joining to "/" cleans any leading ".." components (as is done by the kernel at the root)
+	return filepath.Join(r.path, path.Join("/", linkTarget))
+}
+
 func isUnallowableFileType(_ string, info os.FileInfo) bool {
 	if info == nil {
 		// we can't filter out by filetype for non-existent files
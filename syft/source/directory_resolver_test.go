@@ -885,3 +885,55 @@ func TestDirectoryResolver_indexPath(t *testing.T) {
 		})
 	})
 }
+
+func Test_RootfsResolver(t *testing.T) {
+	root := t.TempDir()
+	for p, contents := range map[string]string{
+		"etc/os-release":       "ID=alpine\n",
+		"usr/lib/libfoo.so.1":  "libfoo",
+		"proc/1/status":        "Name: init\n",
+		"outside-links/.keep":  "",
+		"usr/share/doc/README": "readme",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, filepath.Dir(p)), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(root, p), []byte(contents), 0644))
+	}
+	// absolute links and links with excess ".." components must resolve within the root filesystem
+	require.NoError(t, os.Symlink("/usr/lib", filepath.Join(root, "lib")))
+	require.NoError(t, os.Symlink("../../../../../../etc/os-release", filepath.Join(root, "usr/share/doc/os-release")))
+	require.NoError(t, os.Symlink("/", filepath.Join(root, "outside-links/root")))
+
+	resolver, err := newRootfsResolver(root)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		realPath string
+	}{
+		{path: "/etc/os-release", realPath: "etc/os-release"},
+		{path: "/lib/libfoo.so.1", realPath: "usr/lib/libfoo.so.1"},
+		{path: "/usr/share/doc/os-release", realPath: "etc/os-release"},
+		{path: "/outside-links/root/usr/share/doc/README", realPath: "usr/share/doc/README"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			locations, err := resolver.FilesByPath(test.path)
+			require.NoError(t, err)
+			require.Len(t, locations, 1)
+			assert.Equal(t, test.realPath, locations[0].RealPath)
+		})
+	}
+
+	// runtime paths are relative to the root filesystem
+	assert.False(t, resolver.HasPath("/proc/1/status"))
+	// nothing outside of the root filesystem is indexed
+	assert.False(t, resolver.fileTree.HasPath("/usr/lib"))
+}
+
+func Test_isProcessRootfs(t *testing.T) {
+	assert.True(t, isProcessRootfs("/proc/1234/root"))
+	assert.True(t, isProcessRootfs("/proc/1234/root/"))
+	assert.False(t, isProcessRootfs("/proc/self/root"))
+	assert.False(t, isProcessRootfs("/proc/1234/root/etc"))
+	assert.False(t, isProcessRootfs("/"))
+}
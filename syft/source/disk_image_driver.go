@@ -0,0 +1,191 @@
+package source
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source/internal/diskimage"
+	"github.com/anchore/syft/syft/source/internal/ext4"
+)
+
+var _ filesystemDriver = diskImageDriver{}
+
+// diskImageDriver extracts the filesystems of virtual machine disk images (raw, qcow2, VHD, and VMDK), such as cloud
+// golden images. Partitions are mounted at the mount points described by the /etc/fstab of the root partition.
+// Currently only ext2/3/4 filesystems can be read (XFS and NTFS partitions are detected but skipped).
+type diskImageDriver struct{}
+
+// mountedPartition is a readable partition of a disk image, along with where it is mounted within the guest.
+type mountedPartition struct {
+	partition  diskimage.Partition
+	fsys       *ext4.FS
+	mountPoint string
+}
+
+func (d diskImageDriver) String() string {
+	return "disk image"
+}
+
+func (d diskImageDriver) detect(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	_, ok := diskimage.Detect(f, info.Size())
+	return ok
+}
+
+func (d diskImageDriver) extract(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	disk, err := diskimage.Open(f, info.Size())
+	if err != nil {
+		return err
+	}
+
+	partitions, err := diskimage.Partitions(disk)
+	if err != nil {
+		return err
+	}
+
+	mounts := readablePartitions(disk, partitions)
+	if len(mounts) == 0 {
+		return fmt.Errorf("no supported filesystems found within %s disk image", disk.Format)
+	}
+
+	assignMountPoints(mounts)
+
+	// extract parent mount points before the mount points nested within them
+	sort.SliceStable(mounts, func(i, j int) bool {
+		return mounts[i].mountPoint < mounts[j].mountPoint
+	})
+
+	for _, m := range mounts {
+		if m.mountPoint == "" {
+			log.Warnf("skipping %s of disk image (unable to determine mount point)", m.partition)
+			continue
+		}
+
+		target, err := safeJoin(dir, m.mountPoint)
+		if err == nil {
+			if info, statErr := os.Lstat(target); statErr == nil && info.Mode()&os.ModeSymlink != 0 {
+				err = fmt.Errorf("mount point is a symlink: %q", m.mountPoint)
+			}
+		}
+		if err != nil {
+			log.Warnf("skipping %s of disk image: %+v", m.partition, err)
+			continue
+		}
+
+		log.Debugf("extracting %s of %s disk image to %q", m.partition, disk.Format, m.mountPoint)
+		if err := extractFS(m.fsys, target); err != nil {
+			return fmt.Errorf("unable to extract %s: %w", m.partition, err)
+		}
+	}
+	return nil
+}
+
+func readablePartitions(disk *diskimage.Disk, partitions []diskimage.Partition) []*mountedPartition {
+	var mounts []*mountedPartition
+	for _, p := range partitions {
+		switch p.Filesystem {
+		case diskimage.ExtFilesystem:
+			fsys, err := ext4.New(p)
+			if err != nil {
+				log.Warnf("unable to read ext filesystem on %s of %s disk image: %+v", p, disk.Format, err)
+				continue
+			}
+			mounts = append(mounts, &mountedPartition{partition: p, fsys: fsys})
+		case diskimage.XFSFilesystem, diskimage.NTFSFilesystem:
+			log.Warnf("skipping %s of %s disk image (%s filesystems are not supported)", p, disk.Format, p.Filesystem)
+		default:
+			log.Debugf("skipping %s of %s disk image (filesystem=%q)", p, disk.Format, p.Filesystem)
+		}
+	}
+	return mounts
+}
+
+// assignMountPoints finds the root partition (the partition with an /etc directory) and mounts the remaining partitions
+// according to the /etc/fstab of the root partition. If there is no root partition then every partition is mounted at
+// the root of the extraction directory.
+func assignMountPoints(mounts []*mountedPartition) {
+	var root *mountedPartition
+	for _, m := range mounts {
+		if info, err := fs.Stat(m.fsys, "etc"); err == nil && info.IsDir() {
+			root = m
+			break
+		}
+	}
+
+	if root == nil {
+		for _, m := range mounts {
+			m.mountPoint = "/"
+		}
+		return
+	}
+	root.mountPoint = "/"
+
+	fstab := readFstab(root.fsys)
+	for _, m := range mounts {
+		if m == root {
+			continue
+		}
+		keys := []string{"uuid=" + strings.ToLower(m.fsys.UUID())}
+		if m.fsys.Label() != "" {
+			keys = append(keys, "label="+strings.ToLower(m.fsys.Label()))
+		}
+		for _, key := range keys {
+			if mountPoint, ok := fstab[key]; ok && mountPoint != "/" {
+				m.mountPoint = mountPoint
+				break
+			}
+		}
+	}
+}
+
+// readFstab returns the mount point of each (lowercase) UUID= and LABEL= device found within /etc/fstab.
+func readFstab(fsys fs.FS) map[string]string {
+	mounts := make(map[string]string)
+
+	f, err := fsys.Open("etc/fstab")
+	if err != nil {
+		return mounts
+	}
+	defer internal.CloseAndLogError(f, "/etc/fstab")
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		device := strings.ToLower(fields[0])
+		if strings.HasPrefix(device, "uuid=") || strings.HasPrefix(device, "label=") {
+			mounts[device] = path.Clean("/" + fields[1])
+		}
+	}
+	return mounts
+}
@@ -0,0 +1,99 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromFile_WithDiskImage(t *testing.T) {
+	rootPaths := []string{"/etc/os-release", "/etc/fstab", "/usr/lib/libfoo.so.1", "/usr/lib/large.txt"}
+	tests := []struct {
+		name       string
+		input      string
+		inputPaths []string
+		expRefs    int
+	}{
+		{
+			name:       "unpartitioned ext2 filesystem",
+			input:      "test-fixtures/disk-images/ext2.img",
+			inputPaths: rootPaths,
+			expRefs:    4,
+		},
+		{
+			name:       "raw disk with MBR partitions",
+			input:      "test-fixtures/disk-images/mbr.raw",
+			inputPaths: append([]string{"/boot/vmlinuz"}, rootPaths...),
+			expRefs:    5,
+		},
+		{
+			name:       "qcow2 image with GPT partitions",
+			input:      "test-fixtures/disk-images/gpt.qcow2",
+			inputPaths: append([]string{"/boot/vmlinuz"}, rootPaths...),
+			expRefs:    5,
+		},
+		{
+			name:       "dynamic VHD image with GPT partitions",
+			input:      "test-fixtures/disk-images/gpt.vhd",
+			inputPaths: append([]string{"/boot/vmlinuz"}, rootPaths...),
+			expRefs:    5,
+		},
+		{
+			name:       "fixed VHD image with GPT partitions",
+			input:      "test-fixtures/disk-images/gpt-fixed.vhd",
+			inputPaths: append([]string{"/boot/vmlinuz"}, rootPaths...),
+			expRefs:    5,
+		},
+		{
+			name:       "sparse VMDK image with GPT partitions",
+			input:      "test-fixtures/disk-images/gpt.vmdk",
+			inputPaths: append([]string{"/boot/vmlinuz"}, rootPaths...),
+			expRefs:    5,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, cleanup := NewFromFile(test.input)
+			if cleanup != nil {
+				t.Cleanup(cleanup)
+			}
+
+			assert.Equal(t, test.input, src.Metadata.Path)
+			assert.NotEqual(t, src.Metadata.Path, src.path)
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			refs, err := resolver.FilesByPath(test.inputPaths...)
+			require.NoError(t, err)
+			assert.Len(t, refs, test.expRefs)
+
+			// symlinks within the filesystem are preserved
+			links, err := resolver.FilesByPath("/bin/libfoo-link")
+			require.NoError(t, err)
+			if assert.Len(t, links, 1) {
+				assert.Equal(t, "usr/lib/libfoo.so.1", links[0].RealPath)
+			}
+		})
+	}
+}
+
+func TestFilesystemDriverFor_DiskImages(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected filesystemDriver
+	}{
+		{input: "test-fixtures/disk-images/ext2.img", expected: diskImageDriver{}},
+		{input: "test-fixtures/disk-images/mbr.raw", expected: diskImageDriver{}},
+		{input: "test-fixtures/disk-images/gpt.qcow2", expected: diskImageDriver{}},
+		{input: "test-fixtures/disk-images/gpt.vhd", expected: diskImageDriver{}},
+		{input: "test-fixtures/disk-images/gpt.vmdk", expected: diskImageDriver{}},
+		{input: "test-fixtures/disk-images/root/etc/os-release", expected: nil},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, filesystemDriverFor(test.input))
+		})
+	}
+}
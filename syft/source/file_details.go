@@ -19,3 +19,11 @@ func GetXid(info os.FileInfo) (uid, gid int) {
 
 	return uid, gid
 }
+
+// GetInode is the inode number of the file for unix
+func GetInode(info os.FileInfo) uint64 {
+	if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+		return stat.Ino
+	}
+	return 0
+}
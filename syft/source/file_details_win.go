@@ -11,3 +11,8 @@ import (
 func GetXid(info os.FileInfo) (uid, gid int) {
 	return -1, -1
 }
+
+// GetInode is a placeholder for windows file information
+func GetInode(info os.FileInfo) uint64 {
+	return 0
+}
@@ -2,6 +2,7 @@ package source
 
 import (
 	"os"
+	"time"
 
 	"github.com/anchore/stereoscope/pkg/file"
 	"github.com/anchore/stereoscope/pkg/image"
@@ -16,6 +17,24 @@ type FileMetadata struct {
 	LinkDestination string
 	Size            int64
 	MIMEType        string
+	// ExtendedAttributes are the extended attributes of the file (binary values are hex encoded, prefixed with "0x")
+	ExtendedAttributes map[string]string
+	// Capabilities are the Linux capabilities granted to the file, in the form shown by getcap (e.g. "cap_net_bind_service=ep")
+	Capabilities []string
+	// ModTime is the last modification time of the file (only available for files read from a directory)
+	ModTime time.Time
+	// Inode is the inode number of the file (only available for files read from a directory on unix)
+	Inode uint64
+}
+
+// IsSetuid indicates the file runs as the owner of the file when executed.
+func (m FileMetadata) IsSetuid() bool {
+	return m.Mode&os.ModeSetuid != 0
+}
+
+// IsSetgid indicates the file runs as the group of the file when executed.
+func (m FileMetadata) IsSetgid() bool {
+	return m.Mode&os.ModeSetgid != 0
 }
 
 func fileMetadataByLocation(img *image.Image, location Location) (FileMetadata, error) {
@@ -55,13 +74,24 @@ func fileMetadataFromPath(path string, info os.FileInfo, withMIMEType bool) File
 		mimeType = file.MIMEType(f)
 	}
 
+	var xattrs map[string][]byte
+	if info.Mode().IsRegular() {
+		// note: extended attributes are only read from regular files, since links would be followed
+		xattrs = readXattrs(path)
+	}
+	attributes, capabilities := newExtendedAttributes(xattrs)
+
 	return FileMetadata{
 		Mode: info.Mode(),
 		Type: newFileTypeFromMode(info.Mode()),
 		// unsupported across platforms
-		UserID:   uid,
-		GroupID:  gid,
-		Size:     info.Size(),
-		MIMEType: mimeType,
+		UserID:             uid,
+		GroupID:            gid,
+		Size:               info.Size(),
+		MIMEType:           mimeType,
+		ExtendedAttributes: attributes,
+		Capabilities:       capabilities,
+		ModTime:            info.ModTime(),
+		Inode:              GetInode(info),
 	}
 }
@@ -5,6 +5,19 @@ import (
 )
 
 // FileResolver is an interface that encompasses how to get specific file references and file contents for a generic data source.
+//
+// Library users may implement a FileResolver over their own storage (e.g. an artifact store or content-addressable
+// blobs) and catalog it with NewFromResolver. Catalogers rely on the following semantics:
+//
+//   - Paths are absolute and slash-separated (e.g. "/usr/lib/os-release"), regardless of the operating system.
+//   - A path may resolve to more than one Location (e.g. the same path within multiple image layers), each with
+//     distinct Coordinates. Paths that do not exist resolve to no locations without an error.
+//   - Symlinks (and hardlinks) are followed: the RealPath of the returned Location is the path of the file the link
+//     resolves to, while the VirtualPath is the path that was requested. Locations are only returned for files, not
+//     for directories or links that cannot be resolved.
+//   - Globs are matched against both the real and virtual path of each file, with the doublestar syntax (a "**"
+//     segment matches any number of directories).
+//   - Catalogers may run concurrently over the same resolver, so all methods must be safe for concurrent use.
 type FileResolver interface {
 	FileContentResolver
 	FilePathResolver
@@ -14,10 +27,14 @@ type FileResolver interface {
 
 // FileContentResolver knows how to get file content for a given Location
 type FileContentResolver interface {
+	// FileContentsByLocation returns the contents of the file at the given location (as returned by the resolver), which
+	// the caller must close. An error is returned when the location does not belong to the resolver.
 	FileContentsByLocation(Location) (io.ReadCloser, error)
 }
 
+// FileMetadataResolver knows how to get the metadata of a file for a given Location
 type FileMetadataResolver interface {
+	// FileMetadataByLocation returns the metadata (e.g. mode, type, size, and MIME type) of the file at the given location.
 	FileMetadataByLocation(Location) (FileMetadata, error)
 }
 
@@ -32,10 +49,14 @@ type FilePathResolver interface {
 	// FilesByMIMEType fetches a set of file references which the contents have been classified as one of the given MIME Types
 	FilesByMIMEType(types ...string) ([]Location, error)
 	// RelativeFileByPath fetches a single file at the given path relative to the layer squash of the given reference.
-	// This is helpful when attempting to find a file that is in the same layer or lower as another file.
+	// This is helpful when attempting to find a file that is in the same layer or lower as another file. Returns nil
+	// when there is no such file.
 	RelativeFileByPath(_ Location, path string) *Location
 }
 
+// FileLocationResolver knows how to enumerate all files of a source
 type FileLocationResolver interface {
+	// AllLocations returns the location of every file, closing the channel once all locations have been sent. Callers
+	// must read every location from the channel.
 	AllLocations() <-chan Location
 }
@@ -0,0 +1,151 @@
+package source
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// capabilityXattr is the extended attribute holding the Linux capabilities of a file
+	capabilityXattr = "security.capability"
+
+	vfsCapRevisionMask     = 0xFF000000
+	vfsCapRevision1        = 0x01000000
+	vfsCapRevision2        = 0x02000000
+	vfsCapRevision3        = 0x03000000
+	vfsCapFlagsEffective   = 0x000001
+	vfsCapRevision1Entries = 1
+	vfsCapRevision2Entries = 2
+)
+
+// capabilityNames are the names of the Linux capabilities, indexed by capability number (see capabilities(7)).
+var capabilityNames = []string{
+	"cap_chown",
+	"cap_dac_override",
+	"cap_dac_read_search",
+	"cap_fowner",
+	"cap_fsetid",
+	"cap_kill",
+	"cap_setgid",
+	"cap_setuid",
+	"cap_setpcap",
+	"cap_linux_immutable",
+	"cap_net_bind_service",
+	"cap_net_broadcast",
+	"cap_net_admin",
+	"cap_net_raw",
+	"cap_ipc_lock",
+	"cap_ipc_owner",
+	"cap_sys_module",
+	"cap_sys_rawio",
+	"cap_sys_chroot",
+	"cap_sys_ptrace",
+	"cap_sys_pacct",
+	"cap_sys_admin",
+	"cap_sys_boot",
+	"cap_sys_nice",
+	"cap_sys_resource",
+	"cap_sys_time",
+	"cap_sys_tty_config",
+	"cap_mknod",
+	"cap_lease",
+	"cap_audit_write",
+	"cap_audit_control",
+	"cap_setfcap",
+	"cap_mac_override",
+	"cap_mac_admin",
+	"cap_syslog",
+	"cap_wake_alarm",
+	"cap_block_suspend",
+	"cap_audit_read",
+	"cap_perfmon",
+	"cap_bpf",
+	"cap_checkpoint_restore",
+}
+
+// newExtendedAttributes returns the printable form of the given raw extended attributes along with the Linux
+// capabilities described by them (if any).
+func newExtendedAttributes(xattrs map[string][]byte) (map[string]string, []string) {
+	if len(xattrs) == 0 {
+		return nil, nil
+	}
+
+	attributes := make(map[string]string, len(xattrs))
+	var capabilities []string
+	for name, value := range xattrs {
+		attributes[name] = xattrValueString(value)
+		if name == capabilityXattr {
+			capabilities = capabilitiesFromXattr(value)
+		}
+	}
+	return attributes, capabilities
+}
+
+// xattrValueString returns text values as-is (without any NUL terminator) and binary values hex encoded.
+func xattrValueString(value []byte) string {
+	text := strings.TrimRight(string(value), "\x00")
+	if utf8.ValidString(text) && !strings.ContainsRune(text, 0) {
+		return text
+	}
+	return "0x" + hex.EncodeToString(value)
+}
+
+// capabilitiesFromXattr parses the capabilities from the value of the "security.capability" extended attribute (a
+// vfs_cap_data structure), returning each capability in the form shown by getcap (e.g. "cap_net_bind_service=ep").
+func capabilitiesFromXattr(value []byte) []string {
+	if len(value) < 4 {
+		return nil
+	}
+	magic := binary.LittleEndian.Uint32(value)
+
+	var entries int
+	switch magic & vfsCapRevisionMask {
+	case vfsCapRevision1:
+		entries = vfsCapRevision1Entries
+	case vfsCapRevision2, vfsCapRevision3:
+		entries = vfsCapRevision2Entries
+	default:
+		return nil
+	}
+	if len(value) < 4+entries*8 {
+		return nil
+	}
+	effective := magic&vfsCapFlagsEffective != 0
+
+	var capabilities []string
+	for idx := 0; idx < entries; idx++ {
+		offset := 4 + idx*8
+		permitted := binary.LittleEndian.Uint32(value[offset:])
+		inheritable := binary.LittleEndian.Uint32(value[offset+4:])
+		for bit := 0; bit < 32; bit++ {
+			isPermitted := permitted&(1<<bit) != 0
+			isInheritable := inheritable&(1<<bit) != 0
+			if !isPermitted && !isInheritable {
+				continue
+			}
+
+			flags := ""
+			if effective {
+				flags += "e"
+			}
+			if isInheritable {
+				flags += "i"
+			}
+			if isPermitted {
+				flags += "p"
+			}
+			capabilities = append(capabilities, fmt.Sprintf("%s=%s", capabilityName(idx*32+bit), flags))
+		}
+	}
+	return capabilities
+}
+
+func capabilityName(capability int) string {
+	if capability < len(capabilityNames) {
+		return capabilityNames[capability]
+	}
+	return fmt.Sprintf("cap_%d", capability)
+}
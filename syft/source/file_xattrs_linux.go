@@ -0,0 +1,67 @@
+//go:build linux
+// +build linux
+
+package source
+
+import (
+	"bytes"
+	"syscall"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// readXattrs returns the extended attributes of the file at the given path (following links).
+func readXattrs(path string) map[string][]byte {
+	names, err := xattrNames(path)
+	if err != nil {
+		log.Tracef("unable to list extended attributes for path=%q: %+v", path, err)
+		return nil
+	}
+
+	var xattrs map[string][]byte
+	for _, name := range names {
+		value, err := xattrValue(path, name)
+		if err != nil {
+			log.Tracef("unable to read extended attribute=%q for path=%q: %+v", name, path, err)
+			continue
+		}
+		if xattrs == nil {
+			xattrs = make(map[string][]byte)
+		}
+		xattrs[name] = value
+	}
+	return xattrs
+}
+
+func xattrNames(path string) ([]string, error) {
+	size, err := syscall.Listxattr(path, nil)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+	buf := make([]byte, size)
+	size, err = syscall.Listxattr(path, buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range bytes.Split(buf[:size], []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	return names, nil
+}
+
+func xattrValue(path, name string) ([]byte, error) {
+	size, err := syscall.Getxattr(path, name, nil)
+	if err != nil || size == 0 {
+		return nil, err
+	}
+	buf := make([]byte, size)
+	size, err = syscall.Getxattr(path, name, buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:size], nil
+}
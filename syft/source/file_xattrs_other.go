@@ -0,0 +1,9 @@
+//go:build !linux
+// +build !linux
+
+package source
+
+// readXattrs is a placeholder for platforms where extended attributes are not read
+func readXattrs(string) map[string][]byte {
+	return nil
+}
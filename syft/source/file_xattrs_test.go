@@ -0,0 +1,101 @@
+package source
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_capabilitiesFromXattr(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    []byte
+		expected []string
+	}{
+		{
+			name: "revision 2 effective and permitted",
+			value: []byte{
+				0x01, 0x00, 0x00, 0x02, // revision 2 + effective
+				0x00, 0x04, 0x00, 0x00, // permitted: cap_net_bind_service
+				0x00, 0x00, 0x00, 0x00, // inheritable
+				0x00, 0x00, 0x00, 0x00, // permitted (upper)
+				0x00, 0x00, 0x00, 0x00, // inheritable (upper)
+			},
+			expected: []string{"cap_net_bind_service=ep"},
+		},
+		{
+			name: "revision 3 with upper capabilities",
+			value: []byte{
+				0x00, 0x00, 0x00, 0x03, // revision 3
+				0x00, 0x20, 0x00, 0x00, // permitted: cap_net_raw
+				0x00, 0x20, 0x00, 0x00, // inheritable: cap_net_raw
+				0x80, 0x00, 0x00, 0x00, // permitted (upper): cap_bpf
+				0x00, 0x00, 0x00, 0x00, // inheritable (upper)
+				0x00, 0x00, 0x00, 0x00, // root ID
+			},
+			expected: []string{"cap_net_raw=ip", "cap_bpf=p"},
+		},
+		{
+			name: "revision 1",
+			value: []byte{
+				0x01, 0x00, 0x00, 0x01, // revision 1 + effective
+				0x01, 0x00, 0x00, 0x00, // permitted: cap_chown
+				0x00, 0x00, 0x00, 0x00, // inheritable
+			},
+			expected: []string{"cap_chown=ep"},
+		},
+		{
+			name:  "truncated",
+			value: []byte{0x01, 0x00, 0x00, 0x02, 0x00, 0x04},
+		},
+		{
+			name: "unknown revision",
+			value: []byte{
+				0x00, 0x00, 0x00, 0x09,
+				0x00, 0x04, 0x00, 0x00,
+				0x00, 0x00, 0x00, 0x00,
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, capabilitiesFromXattr(test.value))
+		})
+	}
+}
+
+func Test_newExtendedAttributes(t *testing.T) {
+	attributes, capabilities := newExtendedAttributes(map[string][]byte{
+		"user.comment":     []byte("built by ci"),
+		"security.selinux": []byte("system_u:object_r:bin_t:s0\x00"),
+		"security.capability": {
+			0x01, 0x00, 0x00, 0x02,
+			0x00, 0x04, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00,
+			0x00, 0x00, 0x00, 0x00,
+		},
+	})
+
+	assert.Equal(t, map[string]string{
+		"user.comment":        "built by ci",
+		"security.selinux":    "system_u:object_r:bin_t:s0",
+		"security.capability": "0x0100000200040000000000000000000000000000",
+	}, attributes)
+	assert.Equal(t, []string{"cap_net_bind_service=ep"}, capabilities)
+
+	attributes, capabilities = newExtendedAttributes(nil)
+	assert.Nil(t, attributes)
+	assert.Nil(t, capabilities)
+}
+
+func TestFileMetadata_IsSetuid(t *testing.T) {
+	metadata := FileMetadata{Mode: 0755 | os.ModeSetuid}
+	assert.True(t, metadata.IsSetuid())
+	assert.False(t, metadata.IsSetgid())
+
+	metadata = FileMetadata{Mode: 0755 | os.ModeSetgid}
+	assert.False(t, metadata.IsSetuid())
+	assert.True(t, metadata.IsSetgid())
+}
@@ -0,0 +1,211 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mholt/archiver/v3"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+// filesystemDriver is capable of extracting the contents of a filesystem image (e.g. a squashfs image or initramfs
+// archive commonly found within firmware, a virtual machine disk image, a Windows image, or an archive that cannot be
+// unarchived by extension) so that it may be cataloged like any other directory.
+type filesystemDriver interface {
+	fmt.Stringer
+	// detect indicates if the file at the given path is a filesystem image supported by this driver
+	detect(path string) bool
+	// extract writes the contents of the filesystem image at the given path into the given directory
+	extract(path, dir string) error
+}
+
+var filesystemDrivers = []filesystemDriver{
+	squashfsDriver{},
+	cpioDriver{},
+	wimDriver{},
+	diskImageDriver{},
+	sevenZipDriver{},
+	tarDriver{},
+}
+
+// filesystemDriverFor returns the first driver that supports the filesystem image at the given path (or nil if
+// the file is not a supported filesystem image).
+func filesystemDriverFor(path string) filesystemDriver {
+	for _, driver := range filesystemDrivers {
+		if driver.detect(path) {
+			return driver
+		}
+	}
+	return nil
+}
+
+func extractFilesystemToTmp(path string, driver filesystemDriver) (string, func(), error) {
+	tempDir, err := os.MkdirTemp("", "syft-filesystem-contents-")
+	if err != nil {
+		return "", func() {}, fmt.Errorf("unable to create tempdir for filesystem image processing: %w", err)
+	}
+
+	cleanupFn := func() {
+		if err := os.RemoveAll(tempDir); err != nil {
+			log.Warnf("unable to cleanup filesystem image tempdir: %+v", err)
+		}
+	}
+
+	if err := driver.extract(path, tempDir); err != nil {
+		return tempDir, cleanupFn, fmt.Errorf("unable to extract %s filesystem image: %w", driver, err)
+	}
+	return tempDir, cleanupFn, nil
+}
+
+// symlinkFile is implemented by files (opened from a filesystem image) that are symlinks
+type symlinkFile interface {
+	SymlinkPath() string
+}
+
+// extractFS writes all directories, regular files, and symlinks of the given filesystem into the given directory.
+func extractFS(fsys fs.FS, dir string) error {
+	return fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+
+		target, err := safeJoin(dir, name)
+		if err != nil {
+			log.Debugf("skipping filesystem image entry: %+v", err)
+			if entry.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		switch {
+		case entry.IsDir():
+			return os.MkdirAll(target, 0755)
+		case entry.Type()&fs.ModeSymlink != 0:
+			return extractSymlink(fsys, name, target)
+		case entry.Type().IsRegular():
+			return extractFile(fsys, name, target)
+		default:
+			// device files, named pipes, and sockets hold no content to catalog
+			return nil
+		}
+	})
+}
+
+func extractSymlink(fsys fs.FS, name, target string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(f, name)
+
+	link, ok := f.(symlinkFile)
+	if !ok || link.SymlinkPath() == "" {
+		log.Debugf("unable to determine symlink destination for %q", name)
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	_ = os.Remove(target)
+	return os.Symlink(link.SymlinkPath(), target)
+}
+
+func extractFile(fsys fs.FS, name, target string) error {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(f, name)
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+
+	_ = os.Remove(target)
+	fh, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm()|0600)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(fh, target)
+
+	_, err = io.Copy(fh, f)
+	return err
+}
+
+type compression struct {
+	magic        []byte
+	decompressor archiver.Decompressor
+}
+
+// compressions are the compression formats that are commonly applied to initramfs archives.
+var compressions = []compression{
+	{magic: []byte{0x1f, 0x8b}, decompressor: archiver.NewGz()},
+	{magic: []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, decompressor: archiver.NewXz()},
+	{magic: []byte{0x28, 0xb5, 0x2f, 0xfd}, decompressor: archiver.NewZstd()},
+	{magic: []byte("BZh"), decompressor: archiver.NewBz2()},
+}
+
+// decompressedReader returns a reader of the decompressed contents if the given reader begins with a known compression
+// magic number, otherwise the reader is returned as-is. The returned close function must always be called.
+func decompressedReader(r *bufio.Reader) (*bufio.Reader, func()) {
+	for _, c := range compressions {
+		header, err := r.Peek(len(c.magic))
+		if err != nil || !bytes.Equal(header, c.magic) {
+			continue
+		}
+
+		pr, pw := io.Pipe()
+		go func(d archiver.Decompressor) {
+			_ = pw.CloseWithError(d.Decompress(r, pw))
+		}(c.decompressor)
+
+		return bufio.NewReader(pr), func() {
+			// unblock the decompressor in case not all contents were consumed
+			_ = pr.CloseWithError(io.ErrClosedPipe)
+		}
+	}
+	return r, func() {}
+}
+
+// safeJoin returns the path of the given (archive provided) name within the given directory, ensuring that the path
+// cannot escape the directory, either directly or by traversing a previously extracted symlink.
+func safeJoin(dir, name string) (string, error) {
+	cleaned := filepath.Clean(string(filepath.Separator) + filepath.FromSlash(name))
+	target := filepath.Join(dir, cleaned)
+
+	if target != dir && !strings.HasPrefix(target, dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("path escapes extraction directory: %q", name)
+	}
+
+	// ensure that no parent of the target is a symlink (which could point outside of the extraction directory)
+	for parent := filepath.Dir(target); parent != dir && strings.HasPrefix(parent, dir); parent = filepath.Dir(parent) {
+		info, err := os.Lstat(parent)
+		if err != nil {
+			// the parent does not exist yet, which is not a symlink
+			continue
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return "", fmt.Errorf("path traverses a symlink: %q", name)
+		}
+	}
+
+	return target, nil
+}
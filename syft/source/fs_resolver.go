@@ -0,0 +1,256 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/anchore/stereoscope/pkg/file"
+	"github.com/anchore/stereoscope/pkg/filetree"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+var _ FileResolver = (*fsResolver)(nil)
+
+// fsResolver implements path and content access for an io/fs.FS (such as an embedded or in-memory filesystem). Paths
+// are absolute, relative to the root of the filesystem. Symlinks are not supported by io/fs and are not indexed.
+type fsResolver struct {
+	fsys           fs.FS
+	fileTree       *filetree.FileTree
+	metadata       map[file.ID]FileMetadata
+	pathFilterFns  []pathFilterFn
+	refsByMIMEType map[string][]file.Reference
+}
+
+func newFSResolver(fsys fs.FS, pathFilters ...pathFilterFn) (*fsResolver, error) {
+	r := &fsResolver{
+		fsys:           fsys,
+		fileTree:       filetree.NewFileTree(),
+		metadata:       make(map[file.ID]FileMetadata),
+		pathFilterFns:  append([]pathFilterFn{isUnallowableFileType}, pathFilters...),
+		refsByMIMEType: make(map[string][]file.Reference),
+	}
+	return r, r.index()
+}
+
+func (r *fsResolver) index() error {
+	return fs.WalkDir(r.fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		p := fsAbsPath(name)
+		if err != nil {
+			if name == "." {
+				return fmt.Errorf("unable to index filesystem: %w", err)
+			}
+			// don't allow for errors to stop indexing (e.g. a permission denied)
+			log.Warnf("unable to access path=%q: %+v", p, err)
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			log.Warnf("unable to stat path=%q: %+v", p, err)
+			return nil
+		}
+
+		if r.isFiltered(p, info) {
+			if d.IsDir() && name != "." {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		if err := r.addPathToIndex(name, info); err != nil {
+			log.Warnf("unable to index path=%q: %+v", p, err)
+		}
+		return nil
+	})
+}
+
+func (r *fsResolver) isFiltered(p string, info os.FileInfo) bool {
+	for _, filterFn := range r.pathFilterFns {
+		if filterFn != nil && filterFn(p, info) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *fsResolver) addPathToIndex(name string, info os.FileInfo) error {
+	p := fsAbsPath(name)
+	metadata := FileMetadata{
+		Mode: info.Mode(),
+		Type: newFileTypeFromMode(info.Mode()),
+		Size: info.Size(),
+	}
+
+	var ref *file.Reference
+	var err error
+	switch metadata.Type {
+	case Directory:
+		ref, err = r.fileTree.AddDir(file.Path(p))
+	case RegularFile:
+		ref, err = r.fileTree.AddFile(file.Path(p))
+	default:
+		log.Tracef("skipping unsupported file type=%q path=%q", metadata.Type, p)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if ref == nil {
+		return nil
+	}
+
+	if metadata.Type == RegularFile {
+		metadata.MIMEType = r.mimeType(name)
+		if metadata.MIMEType != "" {
+			r.refsByMIMEType[metadata.MIMEType] = append(r.refsByMIMEType[metadata.MIMEType], *ref)
+		}
+	}
+	r.metadata[ref.ID()] = metadata
+	return nil
+}
+
+func (r *fsResolver) mimeType(name string) string {
+	f, err := r.fsys.Open(name)
+	if err != nil {
+		log.Tracef("unable to open path=%q to determine MIME type: %+v", name, err)
+		return ""
+	}
+	defer internal.CloseAndLogError(f, name)
+	return file.MIMEType(f)
+}
+
+func (r *fsResolver) isInIndex(ref file.Reference) bool {
+	_, ok := r.metadata[ref.ID()]
+	return ok
+}
+
+// HasPath indicates if the given path exists in the underlying source.
+func (r *fsResolver) HasPath(userPath string) bool {
+	return r.fileTree.HasPath(file.Path(fsAbsPath(userPath)))
+}
+
+// Stringer to represent an io/fs.FS data source
+func (r *fsResolver) String() string {
+	return fmt.Sprintf("fs:%T", r.fsys)
+}
+
+// FilesByPath returns all file.References that match the given paths from the filesystem.
+func (r *fsResolver) FilesByPath(userPaths ...string) ([]Location, error) {
+	var references = make([]Location, 0)
+
+	for _, userPath := range userPaths {
+		exists, ref, err := r.fileTree.File(file.Path(fsAbsPath(userPath)))
+		if err != nil {
+			return nil, err
+		}
+		if !exists || ref == nil || !r.isInIndex(*ref) {
+			continue
+		}
+
+		// don't consider directories
+		if r.metadata[ref.ID()].Type == Directory {
+			continue
+		}
+
+		references = append(references, NewLocationFromDirectory(string(ref.RealPath), *ref))
+	}
+
+	return references, nil
+}
+
+// FilesByGlob returns all file.References that match the given path glob pattern from the filesystem.
+func (r *fsResolver) FilesByGlob(patterns ...string) ([]Location, error) {
+	result := make([]Location, 0)
+
+	for _, pattern := range patterns {
+		globResults, err := r.fileTree.FilesByGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, globResult := range globResults {
+			if !r.isInIndex(globResult.Reference) || r.metadata[globResult.Reference.ID()].Type == Directory {
+				continue
+			}
+			result = append(result, NewLocationFromDirectory(string(globResult.Reference.RealPath), globResult.Reference))
+		}
+	}
+
+	return result, nil
+}
+
+// RelativeFileByPath fetches a single file at the given path relative to the layer squash of the given reference.
+// This is helpful when attempting to find a file that is in the same layer or lower as another file. For the
+// fsResolver, this is a simple path lookup.
+func (r *fsResolver) RelativeFileByPath(_ Location, path string) *Location {
+	paths, err := r.FilesByPath(path)
+	if err != nil {
+		return nil
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	return &paths[0]
+}
+
+// FileContentsByLocation fetches file contents for a single file reference from the filesystem.
+// If the path does not exist an error is returned.
+func (r *fsResolver) FileContentsByLocation(location Location) (io.ReadCloser, error) {
+	if location.ref.RealPath == "" {
+		return nil, errors.New("empty path given")
+	}
+	if !r.isInIndex(location.ref) {
+		// paths that have been excluded from the index (by preference or being inaccessible) are denied
+		return nil, fmt.Errorf("file content is inaccessible path=%q", location.ref.RealPath)
+	}
+	return r.fsys.Open(fsName(string(location.ref.RealPath)))
+}
+
+func (r *fsResolver) AllLocations() <-chan Location {
+	results := make(chan Location)
+	go func() {
+		defer close(results)
+		for _, ref := range r.fileTree.AllFiles(file.AllTypes...) {
+			results <- NewLocationFromDirectory(string(ref.RealPath), ref)
+		}
+	}()
+	return results
+}
+
+func (r *fsResolver) FileMetadataByLocation(location Location) (FileMetadata, error) {
+	metadata, exists := r.metadata[location.ref.ID()]
+	if !exists {
+		return FileMetadata{}, fmt.Errorf("location: %+v : %w", location, os.ErrNotExist)
+	}
+	return metadata, nil
+}
+
+func (r *fsResolver) FilesByMIMEType(types ...string) ([]Location, error) {
+	var locations []Location
+	for _, ty := range types {
+		for _, ref := range r.refsByMIMEType[ty] {
+			locations = append(locations, NewLocationFromDirectory(string(ref.RealPath), ref))
+		}
+	}
+	return locations, nil
+}
+
+// fsAbsPath converts an io/fs path (which is unrooted, e.g. "etc/os-release") to an absolute path.
+func fsAbsPath(name string) string {
+	return path.Clean("/" + name)
+}
+
+// fsName converts an absolute path to an io/fs path.
+func fsName(p string) string {
+	name := strings.TrimPrefix(path.Clean("/"+p), "/")
+	if name == "" {
+		return "."
+	}
+	return name
+}
@@ -0,0 +1,72 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+	"testing/fstest"
+)
+
+// NewFromFS creates a new source object tailored to catalog the given filesystem (e.g. an embed.FS or an in-memory
+// filesystem) without writing it to disk. The name describes the filesystem within the SBOM.
+func NewFromFS(fsys fs.FS, name string) (Source, error) {
+	if fsys == nil {
+		return Source{}, fmt.Errorf("no filesystem given")
+	}
+
+	s := Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Scheme: DirectoryScheme,
+			Path:   name,
+		},
+		fsys: fsys,
+	}
+	s.SetID()
+	return s, nil
+}
+
+// NewFromFiles creates a new source object tailored to catalog a synthetic filesystem made up of the given files,
+// keyed by path (e.g. "/etc/os-release"). The readers are read fully when the source is created.
+func NewFromFiles(name string, files map[string]io.Reader) (Source, error) {
+	fsys := make(fstest.MapFS, len(files))
+	for p, reader := range files {
+		key := fsName(p)
+		if key == "." || !fs.ValidPath(key) {
+			return Source{}, fmt.Errorf("invalid file path=%q", p)
+		}
+		if reader == nil {
+			return Source{}, fmt.Errorf("no contents given for path=%q", p)
+		}
+
+		contents, err := io.ReadAll(reader)
+		if err != nil {
+			return Source{}, fmt.Errorf("unable to read contents of path=%q: %w", p, err)
+		}
+		if _, exists := fsys[key]; exists {
+			return Source{}, fmt.Errorf("duplicate file path=%q", p)
+		}
+		fsys[key] = &fstest.MapFile{Data: contents, Mode: 0o644}
+	}
+
+	// note: fstest.MapFS synthesizes the parent directories of each file
+	return NewFromFS(fsys, name)
+}
+
+// fsFileResolver returns the resolver for an io/fs.FS, indexing the filesystem on first use. Note: the caller must hold
+// the source mutex.
+func (s *Source) fsFileResolver() (FileResolver, error) {
+	if s.fsResolver == nil {
+		exclusionFunctions, err := getDirectoryExclusionFunctions("/", s.Exclusions)
+		if err != nil {
+			return nil, err
+		}
+		resolver, err := newFSResolver(s.fsys, exclusionFunctions...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create filesystem resolver: %w", err)
+		}
+		s.fsResolver = resolver
+	}
+	return s.fsResolver, nil
+}
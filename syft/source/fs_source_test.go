@@ -0,0 +1,111 @@
+package source
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromFiles(t *testing.T) {
+	src, err := NewFromFiles("synthetic", map[string]io.Reader{
+		"/etc/os-release":          strings.NewReader("ID=alpine\n"),
+		"lib/apk/db/installed":     strings.NewReader("P:busybox\n"),
+		"/usr/share/doc/README.md": strings.NewReader("# readme\n"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, DirectoryScheme, src.Metadata.Scheme)
+	assert.Equal(t, "synthetic", src.Metadata.Path)
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+
+	locations, err := resolver.FilesByPath("/etc/os-release", "lib/apk/db/installed", "/etc", "/missing")
+	require.NoError(t, err)
+	var paths []string
+	for _, location := range locations {
+		paths = append(paths, location.RealPath)
+	}
+	assert.Equal(t, []string{"/etc/os-release", "/lib/apk/db/installed"}, paths)
+
+	contents, err := resolver.FileContentsByLocation(locations[0])
+	require.NoError(t, err)
+	b, err := io.ReadAll(contents)
+	require.NoError(t, err)
+	require.NoError(t, contents.Close())
+	assert.Equal(t, "ID=alpine\n", string(b))
+
+	metadata, err := resolver.FileMetadataByLocation(locations[0])
+	require.NoError(t, err)
+	assert.Equal(t, RegularFile, metadata.Type)
+	assert.Equal(t, int64(len("ID=alpine\n")), metadata.Size)
+	assert.Equal(t, "text/plain", metadata.MIMEType)
+
+	globbed, err := resolver.FilesByGlob("**/*.md")
+	require.NoError(t, err)
+	require.Len(t, globbed, 1)
+	assert.Equal(t, "/usr/share/doc/README.md", globbed[0].RealPath)
+
+	byMIMEType, err := resolver.FilesByMIMEType("text/plain")
+	require.NoError(t, err)
+	assert.Len(t, byMIMEType, 3)
+}
+
+func TestNewFromFiles_InvalidPaths(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]io.Reader
+	}{
+		{
+			name:  "root",
+			files: map[string]io.Reader{"/": strings.NewReader("")},
+		},
+		{
+			name: "duplicate",
+			files: map[string]io.Reader{
+				"/etc/passwd": strings.NewReader(""),
+				"etc/passwd":  strings.NewReader(""),
+			},
+		},
+		{
+			name:  "no contents",
+			files: map[string]io.Reader{"/etc/passwd": nil},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewFromFiles("synthetic", test.files)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewFromFS_Exclusions(t *testing.T) {
+	src, err := NewFromFS(fstest.MapFS{
+		"app/package.json":                    {Data: []byte(`{}`)},
+		"app/node_modules/left-pad/index.js":  {Data: []byte(`module.exports = {}`)},
+		"app/node_modules/left-pad/README.md": {Data: []byte(`# left-pad`)},
+	}, "embedded")
+	require.NoError(t, err)
+	src.Exclusions = []string{"**/node_modules/**"}
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+
+	var paths []string
+	for location := range resolver.AllLocations() {
+		paths = append(paths, location.RealPath)
+	}
+	assert.Contains(t, paths, "/app/package.json")
+	assert.NotContains(t, paths, "/app/node_modules/left-pad/index.js")
+	assert.False(t, resolver.HasPath("/app/node_modules/left-pad/README.md"))
+}
+
+func TestNewFromFS_NoFilesystem(t *testing.T) {
+	_, err := NewFromFS(nil, "nothing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,175 @@
+package source
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// gitSchemePrefix is the user input prefix for a git repository, which is followed by the repository URL and an
+// optional branch, tag, or commit (e.g. "git+https://github.com/anchore/syft@v0.60.0").
+const gitSchemePrefix = "git+"
+
+var gitTransports = []string{"https", "http", "ssh", "file"}
+
+// GitMetadata describes the repository revision that was cataloged.
+type GitMetadata struct {
+	URL    string `json:"url"`           // the repository URL (without any credentials)
+	Ref    string `json:"ref,omitempty"` // the requested branch, tag, or commit (if any)
+	Commit string `json:"commit"`        // the SHA of the commit that was cataloged
+}
+
+// gitLocation is a repository URL (as given to git) and the optional revision to check out.
+type gitLocation struct {
+	URL string
+	Ref string
+}
+
+func isGitLocation(userInput string) bool {
+	for _, transport := range gitTransports {
+		if strings.HasPrefix(userInput, gitSchemePrefix+transport+"://") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseGitLocation parses user input of the form "git+<transport>://<repository>[@<ref>]". The ref is taken after the
+// last "@" within the repository path, so credentials or users (e.g. "git+ssh://git@github.com/...") are allowed.
+func parseGitLocation(userInput string) (gitLocation, error) {
+	if !isGitLocation(userInput) {
+		return gitLocation{}, fmt.Errorf("invalid git location %q: expected git+{%s}://<repository>[@<ref>]", userInput, strings.Join(gitTransports, ","))
+	}
+
+	u, err := url.Parse(strings.TrimPrefix(userInput, gitSchemePrefix))
+	if err != nil {
+		return gitLocation{}, fmt.Errorf("unable to parse git location %q: %w", userInput, err)
+	}
+
+	var ref string
+	if idx := strings.LastIndex(u.Path, "@"); idx >= 0 {
+		u.Path, ref = u.Path[:idx], u.Path[idx+1:]
+		u.RawPath = ""
+		if ref == "" {
+			return gitLocation{}, fmt.Errorf("invalid git location %q: empty ref given", userInput)
+		}
+	}
+	if u.Path == "" || u.Path == "/" {
+		return gitLocation{}, fmt.Errorf("invalid git location %q: no repository given", userInput)
+	}
+
+	return gitLocation{URL: u.String(), Ref: ref}, nil
+}
+
+// redactedURL returns the repository URL without any password, which is suitable to record in the SBOM.
+func (l gitLocation) redactedURL() string {
+	u, err := url.Parse(l.URL)
+	if err != nil {
+		return l.URL
+	}
+	return u.Redacted()
+}
+
+func (l gitLocation) String() string {
+	location := gitSchemePrefix + l.redactedURL()
+	if l.Ref != "" {
+		location += "@" + l.Ref
+	}
+	return location
+}
+
+// generateGitSource creates a directory source from a shallow clone of the requested repository revision.
+func generateGitSource(ctx context.Context, in Input) (*Source, func(), error) {
+	location, err := parseGitLocation(in.Location)
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	dir, err := os.MkdirTemp("", "syft-git-")
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("unable to create directory for git repository: %w", err)
+	}
+	cleanup := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Warnf("unable to remove git repository dir=%q: %+v", dir, err)
+		}
+	}
+
+	commit, err := shallowClone(ctx, location, dir)
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("unable to clone %q: %w", location, err)
+	}
+
+	s := Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Scheme: DirectoryScheme,
+			Path:   location.String(),
+			Git: &GitMetadata{
+				URL:    location.redactedURL(),
+				Ref:    location.Ref,
+				Commit: commit,
+			},
+		},
+		path: dir,
+	}
+	s.SetID()
+
+	return &s, cleanup, nil
+}
+
+// shallowClone fetches only the requested revision (or the default branch) into the given directory, returning the
+// SHA of the commit checked out. Fetching the ref directly (instead of cloning) allows for branches, tags, and commits
+// to be handled the same way.
+func shallowClone(ctx context.Context, location gitLocation, dir string) (string, error) {
+	ref := location.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	for _, args := range [][]string{
+		{"init", "--quiet"},
+		{"remote", "add", "origin", location.URL},
+		{"fetch", "--quiet", "--depth", "1", "origin", ref},
+		{"checkout", "--quiet", "FETCH_HEAD"},
+	} {
+		if _, err := runGit(ctx, dir, args...); err != nil {
+			return "", err
+		}
+	}
+
+	commit, err := runGit(ctx, dir, "rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	// only the working tree should be cataloged (this also removes the remote URL, which may have credentials)
+	if err := os.RemoveAll(filepath.Join(dir, ".git")); err != nil {
+		return "", fmt.Errorf("unable to remove git metadata: %w", err)
+	}
+	log.Debugf("cloned git repository=%q ref=%q commit=%q", location.redactedURL(), location.Ref, commit)
+	return commit, nil
+}
+
+func runGit(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	// never prompt for credentials, which would otherwise block indefinitely
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("git %s failed: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
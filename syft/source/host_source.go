@@ -0,0 +1,32 @@
+package source
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// hostScheme is the user input prefix for the live root filesystem of the host ("host:") or of a running process,
+// such as a container ("host:<pid>").
+const hostScheme = "host"
+
+// processRootfsPattern matches the (magic) link to the root filesystem of a process, as seen from its mount namespace.
+var processRootfsPattern = regexp.MustCompile(`^/proc/\d+/root/?$`)
+
+// hostLocation returns the path to catalog for the given "host:" input: the host root filesystem when no process ID is
+// given, otherwise the root filesystem of the process (for a container this is the merged container filesystem).
+func hostLocation(pid string) (string, error) {
+	if pid == "" {
+		return "/", nil
+	}
+	if _, err := strconv.ParseUint(pid, 10, 32); err != nil {
+		return "", fmt.Errorf("invalid host input %q: expected no value (for the host root filesystem) or a process ID", pid)
+	}
+	return fmt.Sprintf("/proc/%s/root", pid), nil
+}
+
+// isProcessRootfs indicates if the given path is the root filesystem of a process, which must be cataloged as a root
+// filesystem (not resolving the root path itself and keeping symlinks within it).
+func isProcessRootfs(path string) bool {
+	return processRootfsPattern.MatchString(path)
+}
@@ -0,0 +1,94 @@
+package source
+
+import (
+	"archive/tar"
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+
+	"github.com/spf13/afero"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+// imageArchiveMarkers are the files that identify a tar archive as an image archive (as detected by stereoscope).
+var imageArchiveMarkers = map[string]image.Source{
+	"manifest.json": image.DockerTarballSource,
+	"oci-layout":    image.OciTarballSource,
+}
+
+// detectCompressedImageArchive returns the image source of the given file when it is a compressed (e.g. gzip or zstd)
+// docker or OCI image archive, otherwise image.UnknownSource is returned. Stereoscope only detects (and reads)
+// uncompressed image archives, so without this compressed image archives would be cataloged as plain filesystems.
+func detectCompressedImageArchive(fs afero.Fs, location string) image.Source {
+	f, err := fs.Open(location)
+	if err != nil {
+		return image.UnknownSource
+	}
+	defer internal.CloseAndLogError(f, location)
+
+	compressed := bufio.NewReader(f)
+	r, closer := decompressedReader(compressed)
+	defer closer()
+	if r == compressed {
+		return image.UnknownSource
+	}
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err != nil {
+			return image.UnknownSource
+		}
+		if src, ok := imageArchiveMarkers[path.Clean(header.Name)]; ok {
+			return src
+		}
+	}
+}
+
+// decompressImageArchive decompresses the image archive of the given input (when compressed) into a temporary file for
+// stereoscope to read, returning the input referring to the decompressed archive. The returned cleanup function must
+// always be called.
+func decompressImageArchive(in Input) (Input, func(), error) {
+	noop := func() {}
+	if in.ImageSource != image.DockerTarballSource && in.ImageSource != image.OciTarballSource {
+		return in, noop, nil
+	}
+
+	f, err := os.Open(in.Location)
+	if err != nil {
+		// leave reporting the problem to stereoscope
+		return in, noop, nil
+	}
+	defer internal.CloseAndLogError(f, in.Location)
+
+	compressed := bufio.NewReader(f)
+	r, closer := decompressedReader(compressed)
+	defer closer()
+	if r == compressed {
+		return in, noop, nil
+	}
+
+	tempFile, err := os.CreateTemp("", "syft-image-archive-*.tar")
+	if err != nil {
+		return in, noop, fmt.Errorf("unable to create temp file for image archive: %w", err)
+	}
+	cleanup := func() {
+		if err := os.Remove(tempFile.Name()); err != nil {
+			log.Warnf("unable to cleanup decompressed image archive: %+v", err)
+		}
+	}
+	defer internal.CloseAndLogError(tempFile, tempFile.Name())
+
+	if _, err := io.Copy(tempFile, r); err != nil {
+		return in, cleanup, fmt.Errorf("unable to decompress image archive: %w", err)
+	}
+
+	log.Debugf("decompressed image archive=%q", in.Location)
+	in.Location = tempFile.Name()
+	return in, cleanup, nil
+}
@@ -0,0 +1,108 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/spf13/afero"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/stereoscope/pkg/image"
+)
+
+func TestDetectScheme_CompressedImageArchive(t *testing.T) {
+	tests := []struct {
+		input          string
+		expectedScheme Scheme
+		expectedSource image.Source
+	}{
+		{
+			input:          "test-fixtures/archives/image.tar.gz",
+			expectedScheme: ImageScheme,
+			expectedSource: image.DockerTarballSource,
+		},
+		{
+			input:          "test-fixtures/archives/image.tar.zst",
+			expectedScheme: ImageScheme,
+			expectedSource: image.DockerTarballSource,
+		},
+		{
+			// a compressed filesystem is not an image archive
+			input:          "test-fixtures/archives/rootfs.tar.zst",
+			expectedScheme: FileScheme,
+			expectedSource: image.UnknownSource,
+		},
+		{
+			input:          "test-fixtures/archives/rootfs.7z",
+			expectedScheme: FileScheme,
+			expectedSource: image.UnknownSource,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			imageDetector := func(string) (image.Source, string, error) {
+				return image.UnknownSource, "", nil
+			}
+
+			scheme, src, location, err := DetectScheme(afero.NewOsFs(), imageDetector, test.input)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedScheme, scheme)
+			assert.Equal(t, test.expectedSource, src)
+			assert.Equal(t, test.input, location)
+		})
+	}
+}
+
+func TestNew_CompressedImageArchive(t *testing.T) {
+	for _, input := range []string{"test-fixtures/archives/image.tar.gz", "test-fixtures/archives/image.tar.zst"} {
+		t.Run(input, func(t *testing.T) {
+			in, err := ParseInput(input, "", false)
+			require.NoError(t, err)
+			require.Equal(t, ImageScheme, in.Scheme)
+
+			src, cleanup, err := New(*in, nil, nil)
+			t.Cleanup(cleanup)
+			require.NoError(t, err)
+
+			// the source describes the archive given, not the decompressed archive
+			assert.Equal(t, input, src.Metadata.ImageMetadata.UserInput)
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			refs, err := resolver.FilesByPath("/etc/os-release", "/usr/lib/libfoo.so.1")
+			require.NoError(t, err)
+			assert.Len(t, refs, 2)
+		})
+	}
+}
+
+func TestDecompressImageArchive(t *testing.T) {
+	t.Run("uncompressed archives are read as-is", func(t *testing.T) {
+		in := Input{ImageSource: image.DockerTarballSource, Location: "test-fixtures/wsl/export"}
+		actual, cleanup, err := decompressImageArchive(in)
+		t.Cleanup(cleanup)
+		require.NoError(t, err)
+		assert.Equal(t, in, actual)
+	})
+
+	t.Run("only image archives are decompressed", func(t *testing.T) {
+		in := Input{ImageSource: image.OciRegistrySource, Location: "test-fixtures/archives/image.tar.gz"}
+		actual, cleanup, err := decompressImageArchive(in)
+		t.Cleanup(cleanup)
+		require.NoError(t, err)
+		assert.Equal(t, in, actual)
+	})
+
+	t.Run("compressed archives are decompressed", func(t *testing.T) {
+		in := Input{ImageSource: image.DockerTarballSource, Location: "test-fixtures/archives/image.tar.gz"}
+		actual, cleanup, err := decompressImageArchive(in)
+		t.Cleanup(cleanup)
+		require.NoError(t, err)
+		assert.NotEqual(t, in.Location, actual.Location)
+
+		src, _, err := image.DetectSource(actual.Location)
+		require.NoError(t, err)
+		assert.Equal(t, image.DockerTarballSource, src)
+	})
+}
@@ -0,0 +1,90 @@
+package source
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+// redactedEnvValue replaces the value of an environment variable that holds a secret.
+const redactedEnvValue = "****"
+
+var (
+	// secretEnvNamePattern matches the names of environment variables that conventionally hold a credential
+	// (e.g. "POSTGRES_PASSWORD", "AWS_SECRET_ACCESS_KEY", or "NPM_TOKEN").
+	secretEnvNamePattern = regexp.MustCompile(`(?i)(^|_)(pass|password|passwd|pwd|secret|token|api_?key|access_?key|private_?key|credentials?|auth)(_|$)`)
+
+	// secretFileEnvNamePattern matches the names of environment variables referencing a file holding a credential
+	// (e.g. "POSTGRES_PASSWORD_FILE"), where the value is a path and is safe to show.
+	secretFileEnvNamePattern = regexp.MustCompile(`(?i)_FILE$`)
+)
+
+// ImageConfigMetadata describes how a container image is run, as given by the runtime configuration of the image.
+type ImageConfigMetadata struct {
+	User         string            `json:"user,omitempty"`
+	Entrypoint   []string          `json:"entrypoint,omitempty"`
+	Cmd          []string          `json:"cmd,omitempty"`
+	WorkingDir   string            `json:"workingDir,omitempty"`
+	Env          []string          `json:"env,omitempty"`          // as "NAME=value", where the values of secrets are redacted
+	ExposedPorts []string          `json:"exposedPorts,omitempty"` // e.g. "8080/tcp"
+	Volumes      []string          `json:"volumes,omitempty"`
+	StopSignal   string            `json:"stopSignal,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+}
+
+// newImageConfigMetadata creates the runtime configuration of an image from its config file, returning nil when the
+// image does not configure anything.
+func newImageConfigMetadata(config v1.Config) *ImageConfigMetadata {
+	metadata := ImageConfigMetadata{
+		User:         config.User,
+		Entrypoint:   config.Entrypoint,
+		Cmd:          config.Cmd,
+		WorkingDir:   config.WorkingDir,
+		ExposedPorts: sortedKeys(config.ExposedPorts),
+		Volumes:      sortedKeys(config.Volumes),
+		StopSignal:   config.StopSignal,
+	}
+	for _, env := range config.Env {
+		metadata.Env = append(metadata.Env, redactEnv(env))
+	}
+	if len(config.Labels) > 0 {
+		metadata.Labels = config.Labels
+	}
+
+	if metadata.User == "" && len(metadata.Entrypoint) == 0 && len(metadata.Cmd) == 0 && metadata.WorkingDir == "" &&
+		len(metadata.Env) == 0 && len(metadata.ExposedPorts) == 0 && len(metadata.Volumes) == 0 &&
+		metadata.StopSignal == "" && len(metadata.Labels) == 0 {
+		return nil
+	}
+	return &metadata
+}
+
+// redactEnv redacts the value of the given environment variable ("NAME=value") when the value is (or contains) a
+// secret: either the name suggests a credential or the value is a URL with a password.
+func redactEnv(env string) string {
+	name, value, ok := strings.Cut(env, "=")
+	if !ok || value == "" {
+		return env
+	}
+	if secretEnvNamePattern.MatchString(name) && !secretFileEnvNamePattern.MatchString(name) {
+		return name + "=" + redactedEnvValue
+	}
+	if u, err := url.Parse(value); err == nil && u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			return name + "=" + u.Redacted()
+		}
+	}
+	return env
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	var keys []string
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
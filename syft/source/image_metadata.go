@@ -1,23 +1,36 @@
 package source
 
-import "github.com/anchore/stereoscope/pkg/image"
+import (
+	"encoding/json"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/artifact"
+)
 
 // ImageMetadata represents all static metadata that defines what a container image is. This is useful to later describe
 // "what" was cataloged without needing the more complicated stereoscope Image objects or FileResolver objects.
 type ImageMetadata struct {
-	UserInput      string          `json:"userInput"`
-	ID             string          `json:"imageID"`
-	ManifestDigest string          `json:"manifestDigest"`
-	MediaType      string          `json:"mediaType"`
-	Tags           []string        `json:"tags"`
-	Size           int64           `json:"imageSize"`
-	Layers         []LayerMetadata `json:"layers"`
-	RawManifest    []byte          `json:"manifest"`
-	RawConfig      []byte          `json:"config"`
-	RepoDigests    []string        `json:"repoDigests"`
-	Architecture   string          `json:"architecture"`
-	Variant        string          `json:"architectureVariant,omitempty"`
-	OS             string          `json:"os"`
+	UserInput       string               `json:"userInput"`
+	ID              string               `json:"imageID"`
+	ManifestDigest  string               `json:"manifestDigest"`
+	MediaType       string               `json:"mediaType"`                 // the media type of the image manifest
+	ConfigMediaType string               `json:"configMediaType,omitempty"` // the media type of the image config (as referenced by the manifest)
+	Tags            []string             `json:"tags"`
+	Size            int64                `json:"imageSize"`
+	Layers          []LayerMetadata      `json:"layers"`
+	RawManifest     []byte               `json:"manifest"`
+	RawConfig       []byte               `json:"config"`
+	RepoDigests     []string             `json:"repoDigests"`
+	RegistryHost    string               `json:"registryHost,omitempty"` // the registry the image was pulled from (when the image has a repo digest)
+	Architecture    string               `json:"architecture"`
+	Variant         string               `json:"architectureVariant,omitempty"`
+	OS              string               `json:"os"`
+	Config          *ImageConfigMetadata `json:"imageConfig,omitempty"`
+	BaseImage       *BaseImageMetadata   `json:"baseImage,omitempty"`
+	Provenance      *ProvenanceMetadata  `json:"provenance,omitempty"`
 }
 
 // LayerMetadata represents all static metadata that defines what a container image layer is.
@@ -27,6 +40,11 @@ type LayerMetadata struct {
 	Size      int64  `json:"size"`
 }
 
+// ID returns the layer digest, which is the same ID used for the layer within file locations.
+func (l LayerMetadata) ID() artifact.ID {
+	return artifact.ID(l.Digest)
+}
+
 // NewImageMetadata creates a new ImageMetadata object populated from the given stereoscope Image object and user configuration.
 func NewImageMetadata(img *image.Image, userInput string) ImageMetadata {
 	// populate artifacts...
@@ -35,19 +53,22 @@ func NewImageMetadata(img *image.Image, userInput string) ImageMetadata {
 		tags[idx] = tag.String()
 	}
 	theImg := ImageMetadata{
-		ID:             img.Metadata.ID,
-		UserInput:      userInput,
-		ManifestDigest: img.Metadata.ManifestDigest,
-		Size:           img.Metadata.Size,
-		MediaType:      string(img.Metadata.MediaType),
-		Tags:           tags,
-		Layers:         make([]LayerMetadata, len(img.Layers)),
-		RawConfig:      img.Metadata.RawConfig,
-		RawManifest:    img.Metadata.RawManifest,
-		RepoDigests:    img.Metadata.RepoDigests,
-		Architecture:   img.Metadata.Architecture,
-		Variant:        img.Metadata.Variant,
-		OS:             img.Metadata.OS,
+		ID:              img.Metadata.ID,
+		UserInput:       userInput,
+		ManifestDigest:  img.Metadata.ManifestDigest,
+		Size:            img.Metadata.Size,
+		MediaType:       string(img.Metadata.MediaType),
+		ConfigMediaType: configMediaType(img.Metadata.RawManifest),
+		Tags:            tags,
+		Layers:          make([]LayerMetadata, len(img.Layers)),
+		RawConfig:       img.Metadata.RawConfig,
+		RawManifest:     img.Metadata.RawManifest,
+		RepoDigests:     img.Metadata.RepoDigests,
+		RegistryHost:    registryHost(img.Metadata.RepoDigests),
+		Architecture:    img.Metadata.Architecture,
+		Variant:         img.Metadata.Variant,
+		OS:              img.Metadata.OS,
+		Config:          newImageConfigMetadata(img.Metadata.Config.Config),
 	}
 
 	// populate image metadata
@@ -60,3 +81,36 @@ func NewImageMetadata(img *image.Image, userInput string) ImageMetadata {
 	}
 	return theImg
 }
+
+// configMediaType returns the media type of the config referenced by the given image manifest (empty when the
+// manifest is missing or does not reference a config).
+func configMediaType(rawManifest []byte) string {
+	if len(rawManifest) == 0 {
+		return ""
+	}
+	var manifest struct {
+		Config struct {
+			MediaType string `json:"mediaType"`
+		} `json:"config"`
+	}
+	if err := json.Unmarshal(rawManifest, &manifest); err != nil {
+		log.Debugf("unable to read the config media type from the image manifest: %+v", err)
+		return ""
+	}
+	return manifest.Config.MediaType
+}
+
+// registryHost returns the host of the registry the first of the given repo digests refers to (e.g.
+// "index.docker.io" for "alpine@sha256:..."). Images only have repo digests once pulled from (or pushed to) a
+// registry, so the registry of local images (e.g. built by the docker daemon) is not known.
+func registryHost(repoDigests []string) string {
+	for _, repoDigest := range repoDigests {
+		ref, err := name.ParseReference(repoDigest)
+		if err != nil {
+			log.Debugf("unable to parse repo digest=%q: %+v", repoDigest, err)
+			continue
+		}
+		return ref.Context().RegistryStr()
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_configMediaType(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest string
+		expected string
+	}{
+		{
+			name:     "docker manifest",
+			manifest: `{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.v2+json","config":{"mediaType":"application/vnd.docker.container.image.v1+json","size":673,"digest":"sha256:3c51b06feb0cda8ee62d0e3755ef2a8496a6b71f8a55b245f07f31c4bb813d31"}}`,
+			expected: "application/vnd.docker.container.image.v1+json",
+		},
+		{
+			name:     "oci manifest",
+			manifest: `{"schemaVersion":2,"config":{"mediaType":"application/vnd.oci.image.config.v1+json","size":673,"digest":"sha256:3c51b06feb0cda8ee62d0e3755ef2a8496a6b71f8a55b245f07f31c4bb813d31"}}`,
+			expected: "application/vnd.oci.image.config.v1+json",
+		},
+		{
+			name:     "no manifest",
+			expected: "",
+		},
+		{
+			name:     "malformed manifest",
+			manifest: `{"schemaVersion":`,
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, configMediaType([]byte(test.manifest)))
+		})
+	}
+}
+
+func Test_registryHost(t *testing.T) {
+	tests := []struct {
+		name        string
+		repoDigests []string
+		expected    string
+	}{
+		{
+			name:        "docker hub",
+			repoDigests: []string{"alpine@sha256:f271e74b17ced29b915d351685fd4644785c6d1559dd1f2d4189a5e851ef753a"},
+			expected:    "index.docker.io",
+		},
+		{
+			name:        "other registry",
+			repoDigests: []string{"ghcr.io/anchore/syft@sha256:f271e74b17ced29b915d351685fd4644785c6d1559dd1f2d4189a5e851ef753a"},
+			expected:    "ghcr.io",
+		},
+		{
+			name:        "registry with port",
+			repoDigests: []string{"localhost:5000/syft@sha256:f271e74b17ced29b915d351685fd4644785c6d1559dd1f2d4189a5e851ef753a"},
+			expected:    "localhost:5000",
+		},
+		{
+			name:        "skips unparsable digests",
+			repoDigests: []string{"not a reference", "ghcr.io/anchore/syft@sha256:f271e74b17ced29b915d351685fd4644785c6d1559dd1f2d4189a5e851ef753a"},
+			expected:    "ghcr.io",
+		},
+		{
+			name:     "no repo digests",
+			expected: "",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, registryHost(test.repoDigests))
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package source
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/registryclient"
+)
+
+// ImagePlatforms returns the platforms of all images within a multi-platform image index in a registry (e.g.
+// "linux/arm64/v8"), in the order given by the index. No platforms are returned when the image is not multi-platform.
+func ImagePlatforms(ctx context.Context, in Input, registryOptions *image.RegistryOptions) ([]string, error) {
+	if in.Scheme != ImageScheme || in.ImageSource != image.OciRegistrySource {
+		return nil, fmt.Errorf("listing image platforms is only supported for images within a registry")
+	}
+
+	ref, err := name.ParseReference(in.Location, registryclient.NameOptions(registryOptions)...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse image reference=%q: %w", in.Location, err)
+	}
+
+	desc, err := remote.Get(ref, registryclient.RemoteOptions(ctx, registryOptions)...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch manifest for image=%q: %w", in.Location, err)
+	}
+	if !desc.MediaType.IsIndex() {
+		return nil, nil
+	}
+
+	index, err := desc.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read image index for image=%q: %w", in.Location, err)
+	}
+	manifest, err := index.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read image index for image=%q: %w", in.Location, err)
+	}
+
+	return indexPlatforms(manifest), nil
+}
+
+func indexPlatforms(manifest *v1.IndexManifest) []string {
+	var platforms []string
+	seen := make(map[string]bool)
+	for _, m := range manifest.Manifests {
+		if m.Platform == nil || !m.MediaType.IsImage() {
+			continue
+		}
+		// build attestations (e.g. provenance from buildkit) are stored as images with an unknown platform
+		if m.Platform.OS == "unknown" || m.Platform.Architecture == "unknown" {
+			continue
+		}
+
+		platform := m.Platform.OS + "/" + m.Platform.Architecture
+		if m.Platform.Variant != "" {
+			platform += "/" + m.Platform.Variant
+		}
+		if seen[platform] {
+			// images for the same platform that differ only by OS version cannot be selected individually
+			continue
+		}
+		seen[platform] = true
+		platforms = append(platforms, platform)
+	}
+	return platforms
+}
@@ -0,0 +1,47 @@
+package source
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_indexPlatforms(t *testing.T) {
+	manifest := &v1.IndexManifest{
+		Manifests: []v1.Descriptor{
+			{
+				MediaType: types.OCIManifestSchema1,
+				Platform:  &v1.Platform{OS: "linux", Architecture: "amd64"},
+			},
+			{
+				MediaType: types.OCIManifestSchema1,
+				Platform:  &v1.Platform{OS: "linux", Architecture: "arm64", Variant: "v8"},
+			},
+			{
+				// build attestations should not be cataloged
+				MediaType: types.OCIManifestSchema1,
+				Platform:  &v1.Platform{OS: "unknown", Architecture: "unknown"},
+			},
+			{
+				MediaType: types.DockerManifestSchema2,
+				Platform:  &v1.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.17763.3532"},
+			},
+			{
+				MediaType: types.DockerManifestSchema2,
+				Platform:  &v1.Platform{OS: "windows", Architecture: "amd64", OSVersion: "10.0.20348.1129"},
+			},
+			{
+				// nested indexes are not supported
+				MediaType: types.OCIImageIndex,
+				Platform:  &v1.Platform{OS: "linux", Architecture: "s390x"},
+			},
+			{
+				MediaType: types.OCIManifestSchema1,
+			},
+		},
+	}
+
+	assert.Equal(t, []string{"linux/amd64", "linux/arm64/v8", "windows/amd64"}, indexPlatforms(manifest))
+}
@@ -13,24 +13,48 @@ import (
 var _ FileResolver = (*imageSquashResolver)(nil)
 
 // imageSquashResolver implements path and content access for the Squashed source option for container image data sources.
+// The squashed representation may be of any layer, including all layers below it (the PerLayer source option).
 type imageSquashResolver struct {
-	img *image.Image
+	img   *image.Image
+	layer int
 }
 
 // newImageSquashResolver returns a new resolver from the perspective of the squashed representation for the given image.
 func newImageSquashResolver(img *image.Image) (*imageSquashResolver, error) {
-	if img.SquashedTree() == nil {
+	if len(img.Layers) == 0 || img.SquashedTree() == nil {
 		return nil, fmt.Errorf("the image does not have have a squashed tree")
 	}
 
 	return &imageSquashResolver{
-		img: img,
+		img:   img,
+		layer: len(img.Layers) - 1,
 	}, nil
 }
 
+// newLayerSquashResolver returns a new resolver from the perspective of the squashed representation of the given image
+// layer (and all layers below it).
+func newLayerSquashResolver(img *image.Image, layer int) (*imageSquashResolver, error) {
+	if layer < 0 || layer >= len(img.Layers) {
+		return nil, fmt.Errorf("the image does not have a layer at index=%d", layer)
+	}
+	if img.Layers[layer].SquashedTree == nil {
+		return nil, fmt.Errorf("the image layer at index=%d does not have a squashed tree", layer)
+	}
+
+	return &imageSquashResolver{
+		img:   img,
+		layer: layer,
+	}, nil
+}
+
+// squashedTree returns the squashed representation of the resolver layer.
+func (r *imageSquashResolver) squashedTree() *filetree.FileTree {
+	return r.img.Layers[r.layer].SquashedTree
+}
+
 // HasPath indicates if the given path exists in the underlying source.
 func (r *imageSquashResolver) HasPath(path string) bool {
-	return r.img.SquashedTree().HasPath(file.Path(path))
+	return r.squashedTree().HasPath(file.Path(path))
 }
 
 // FilesByPath returns all file.References that match the given paths within the squashed representation of the image.
@@ -39,7 +63,7 @@ func (r *imageSquashResolver) FilesByPath(paths ...string) ([]Location, error) {
 	uniqueLocations := make([]Location, 0)
 
 	for _, path := range paths {
-		tree := r.img.SquashedTree()
+		tree := r.squashedTree()
 		_, ref, err := tree.File(file.Path(path), filetree.FollowBasenameLinks)
 		if err != nil {
 			return nil, err
@@ -63,7 +87,7 @@ func (r *imageSquashResolver) FilesByPath(paths ...string) ([]Location, error) {
 		}
 
 		// a file may be a symlink, process it as such and resolve it
-		resolvedRef, err := r.img.ResolveLinkByImageSquash(*ref)
+		resolvedRef, err := r.img.ResolveLinkByLayerSquash(*ref, r.layer)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve link from img (ref=%+v): %w", ref, err)
 		}
@@ -83,7 +107,7 @@ func (r *imageSquashResolver) FilesByGlob(patterns ...string) ([]Location, error
 	uniqueLocations := make([]Location, 0)
 
 	for _, pattern := range patterns {
-		results, err := r.img.SquashedTree().FilesByGlob(pattern, filetree.FollowBasenameLinks)
+		results, err := r.squashedTree().FilesByGlob(pattern, filetree.FollowBasenameLinks)
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve files by glob (%s): %w", pattern, err)
 		}
@@ -168,7 +192,7 @@ func (r *imageSquashResolver) AllLocations() <-chan Location {
 	results := make(chan Location)
 	go func() {
 		defer close(results)
-		for _, ref := range r.img.SquashedTree().AllFiles(file.AllTypes...) {
+		for _, ref := range r.squashedTree().AllFiles(file.AllTypes...) {
 			results <- NewLocationFromImage(string(ref.RealPath), ref, r.img)
 		}
 	}()
@@ -176,7 +200,7 @@ func (r *imageSquashResolver) AllLocations() <-chan Location {
 }
 
 func (r *imageSquashResolver) FilesByMIMEType(types ...string) ([]Location, error) {
-	refs, err := r.img.FilesByMIMETypeFromSquash(types...)
+	refs, err := r.img.Layers[r.layer].FilesByMIMETypeFromSquash(types...)
 	if err != nil {
 		return nil, err
 	}
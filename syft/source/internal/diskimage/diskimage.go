@@ -0,0 +1,100 @@
+/*
+Package diskimage provides read-only access to the guest disk within virtual machine disk images (raw, qcow2, VHD, and
+VMDK), along with the partitions found on that disk.
+*/
+package diskimage
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Format is a virtual machine disk image container format.
+type Format string
+
+const (
+	RawFormat   Format = "raw"
+	QCOW2Format Format = "qcow2"
+	VHDFormat   Format = "vhd"
+	VMDKFormat  Format = "vmdk"
+)
+
+// maxTableSize bounds the metadata tables (qcow2 L1 tables, VHD block allocation tables, and VMDK grain directories)
+// read into memory, since the number of entries is taken from the (untrusted) image header.
+const maxTableSize = 64 << 20
+
+var errNegativeOffset = errors.New("negative offset")
+
+// Disk is the guest view of a virtual machine disk image.
+type Disk struct {
+	io.ReaderAt
+	Format Format
+	Size   int64
+}
+
+// Detect returns the container format of the given image. Raw images are only reported when a partition table (or
+// a supported filesystem) is found at the start of the image, since any file could otherwise be a raw disk.
+func Detect(r io.ReaderAt, size int64) (Format, bool) {
+	header := make([]byte, 4)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return "", false
+	}
+
+	switch {
+	case bytes.Equal(header, []byte(qcow2Magic)):
+		return QCOW2Format, true
+	case bytes.Equal(header, []byte(vmdkSparseMagic)):
+		return VMDKFormat, true
+	case isVHD(r, size):
+		return VHDFormat, true
+	}
+
+	disk := &Disk{ReaderAt: r, Format: RawFormat, Size: size}
+	if partitions, err := Partitions(disk); err == nil && len(partitions) > 0 && partitions[0].Table != NoPartitionTable {
+		return RawFormat, true
+	}
+	if FilesystemOf(io.NewSectionReader(r, 0, size)) != UnknownFilesystem {
+		return RawFormat, true
+	}
+	return "", false
+}
+
+// Open returns the guest disk within the given image.
+func Open(r io.ReaderAt, size int64) (*Disk, error) {
+	format, ok := Detect(r, size)
+	if !ok {
+		return nil, fmt.Errorf("not a supported disk image")
+	}
+
+	switch format {
+	case QCOW2Format:
+		return openQCOW2(r, size)
+	case VHDFormat:
+		return openVHD(r, size)
+	case VMDKFormat:
+		return openVMDK(r, size)
+	}
+	return &Disk{ReaderAt: r, Format: RawFormat, Size: size}, nil
+}
+
+// tableSize returns the size in bytes of a metadata table of the given number of entries, ensuring the table is bounded
+// by maxTableSize and is found entirely within the image.
+func tableSize(kind string, entries, entrySize, offset uint64, imageSize int64) (int, error) {
+	if entries > maxTableSize/entrySize {
+		return 0, fmt.Errorf("%s with %d entries exceeds the maximum table size", kind, entries)
+	}
+	size := entries * entrySize
+	if imageSize < 0 || offset > uint64(imageSize) || size > uint64(imageSize)-offset {
+		return 0, fmt.Errorf("%s (offset=%d, size=%d) extends beyond the end of the image", kind, offset, size)
+	}
+	return int(size), nil
+}
+
+// zeroFill sets every byte of the given slice to zero (used for unallocated regions of sparse images).
+func zeroFill(p []byte) {
+	for i := range p {
+		p[i] = 0
+	}
+}
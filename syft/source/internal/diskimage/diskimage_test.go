@@ -0,0 +1,211 @@
+package diskimage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func qcow2Header(l1Size uint32, l1Offset uint64) []byte {
+	image := make([]byte, 4096)
+	be := binary.BigEndian
+	copy(image, qcow2Magic)
+	be.PutUint32(image[4:8], 3)       // version
+	be.PutUint32(image[20:24], 16)    // cluster bits
+	be.PutUint64(image[24:32], 1<<20) // virtual size
+	be.PutUint32(image[36:40], l1Size)
+	be.PutUint64(image[40:48], l1Offset)
+	return image
+}
+
+func vmdkHeader(capacity, grainSize uint64, gtEntries uint32, gdOffset uint64) []byte {
+	image := make([]byte, 4096)
+	le := binary.LittleEndian
+	copy(image, vmdkSparseMagic)
+	le.PutUint32(image[4:8], 1) // version
+	le.PutUint64(image[12:20], capacity)
+	le.PutUint64(image[20:28], grainSize)
+	le.PutUint32(image[44:48], gtEntries)
+	le.PutUint64(image[56:64], gdOffset)
+	return image
+}
+
+func vhdImage(entries uint32) []byte {
+	image := make([]byte, 4096)
+	be := binary.BigEndian
+
+	footer := image[len(image)-vhdFooterSize:]
+	copy(footer, vhdCookie)
+	be.PutUint64(footer[16:24], 512)   // data offset (of the dynamic disk header)
+	be.PutUint64(footer[48:56], 1<<20) // current size
+	be.PutUint32(footer[60:64], vhdDynamicDisk)
+
+	header := image[512:]
+	copy(header, vhdSparseCookie)
+	be.PutUint64(header[16:24], 1536) // block allocation table offset
+	be.PutUint32(header[28:32], entries)
+	be.PutUint32(header[32:36], 2<<20) // block size
+	return image
+}
+
+// fixedGPTImage returns the fixed VHD fixture (a raw GPT disk followed by a footer) with the given bytes replaced.
+func fixedGPTImage(t *testing.T, offset int, patch uint64) []byte {
+	t.Helper()
+	image := fixture(t, "gpt-fixed.vhd")
+	binary.LittleEndian.PutUint64(image[offset:], patch)
+	return image
+}
+
+func fixture(t *testing.T, name string) []byte {
+	t.Helper()
+	image, err := os.ReadFile("../../test-fixtures/disk-images/" + name)
+	require.NoError(t, err)
+	return image
+}
+
+func readAt(off int64) func(*Disk) error {
+	return func(d *Disk) error {
+		_, err := d.ReadAt(make([]byte, 512), off)
+		return err
+	}
+}
+
+func partitions(d *Disk) error {
+	_, err := Partitions(d)
+	return err
+}
+
+func TestOpen_InvalidHeaders(t *testing.T) {
+	tests := []struct {
+		name  string
+		image []byte
+		// read is called on the disk when the image is expected to be opened (the error is from the read instead)
+		read    func(*Disk) error
+		wantErr string
+	}{
+		{
+			name:    "truncated qcow2 header",
+			image:   []byte(qcow2Magic),
+			wantErr: "unable to read qcow2 header",
+		},
+		{
+			name:    "qcow2 L1 table larger than the maximum table size",
+			image:   qcow2Header(0x7fffffff, 65536),
+			wantErr: "exceeds the maximum table size",
+		},
+		{
+			name:    "qcow2 L1 table beyond the end of the image",
+			image:   qcow2Header(16, 1<<40),
+			wantErr: "extends beyond the end of the image",
+		},
+		{
+			name:    "VMDK capacity overflowing when converted to bytes",
+			image:   vmdkHeader(1<<62, 128, 512, 1),
+			wantErr: "invalid VMDK capacity",
+		},
+		{
+			name:    "VMDK grain directory offset overflowing when converted to bytes",
+			image:   vmdkHeader(2048, 128, 512, 1<<60),
+			wantErr: "invalid VMDK grain directory offset",
+		},
+		{
+			name:    "VMDK grain directory larger than the image",
+			image:   vmdkHeader(1<<40, 128, 512, 1),
+			wantErr: "extends beyond the end of the image",
+		},
+		{
+			name:    "VHD block allocation table larger than the maximum table size",
+			image:   vhdImage(0xffffffff),
+			wantErr: "exceeds the maximum table size",
+		},
+		{
+			name:    "VHD block allocation table beyond the end of the image",
+			image:   vhdImage(1024),
+			wantErr: "extends beyond the end of the image",
+		},
+		{
+			name:    "GPT partition entries beyond the end of the disk",
+			image:   fixedGPTImage(t, 512+72, 0xe7e7e7e7e7e7e7f3),
+			read:    partitions,
+			wantErr: "invalid GPT header (entries LBA=16710579925595711475)",
+		},
+		{
+			name:    "qcow2 read at a negative offset",
+			image:   fixture(t, "gpt.qcow2"),
+			read:    readAt(-827867578560 * 512),
+			wantErr: "negative offset",
+		},
+		{
+			name:    "VHD read at a negative offset",
+			image:   fixture(t, "gpt.vhd"),
+			read:    readAt(-512),
+			wantErr: "negative offset",
+		},
+		{
+			name:    "VMDK read at a negative offset",
+			image:   fixture(t, "gpt.vmdk"),
+			read:    readAt(-512),
+			wantErr: "negative offset",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			disk, err := Open(bytes.NewReader(test.image), int64(len(test.image)))
+			if test.read != nil {
+				require.NoError(t, err)
+				err = test.read(disk)
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
+
+func TestPartitions_SkipsPartitionsBeyondTheDisk(t *testing.T) {
+	tests := []struct {
+		name        string
+		offset      int
+		lba         uint64
+		wantIndexes []int
+	}{
+		{
+			name:        "first LBA that overflows when converted to an offset",
+			offset:      1024 + 32,
+			lba:         0xe7e7e7e7e7e7e7f3,
+			wantIndexes: []int{2},
+		},
+		{
+			name:        "first LBA beyond the end of the disk",
+			offset:      1024 + 128 + 32,
+			lba:         1 << 20,
+			wantIndexes: []int{1},
+		},
+		{
+			name:        "last LBA beyond the end of the disk",
+			offset:      1024 + 128 + 40,
+			lba:         0xffffffffffffffff,
+			wantIndexes: []int{1, 2},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			image := fixedGPTImage(t, test.offset, test.lba)
+			disk, err := Open(bytes.NewReader(image), int64(len(image)))
+			require.NoError(t, err)
+
+			partitions, err := Partitions(disk)
+			require.NoError(t, err)
+			var indexes []int
+			for _, p := range partitions {
+				assert.GreaterOrEqual(t, p.Offset, int64(0))
+				assert.LessOrEqual(t, p.Offset+p.Size(), disk.Size)
+				indexes = append(indexes, p.Index)
+			}
+			assert.Equal(t, test.wantIndexes, indexes)
+		})
+	}
+}
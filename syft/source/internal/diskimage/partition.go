@@ -0,0 +1,259 @@
+package diskimage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// PartitionTable is the scheme used to describe the partitions of a disk.
+type PartitionTable string
+
+const (
+	NoPartitionTable  PartitionTable = ""
+	MBRPartitionTable PartitionTable = "mbr"
+	GPTPartitionTable PartitionTable = "gpt"
+)
+
+const (
+	sectorSize         = 512
+	mbrSignatureOffset = 510
+	mbrEntriesOffset   = 446
+	mbrEntrySize       = 16
+	mbrEntries         = 4
+	mbrProtectiveGPT   = 0xEE
+	gptSignature       = "EFI PART"
+	gptMaxEntries      = 1024
+
+	// the maximum number of logical partitions followed within an extended partition (guards against loops)
+	maxLogicalPartitions = 128
+)
+
+// Filesystem is the filesystem found within a partition.
+type Filesystem string
+
+const (
+	UnknownFilesystem Filesystem = ""
+	ExtFilesystem     Filesystem = "ext"
+	XFSFilesystem     Filesystem = "xfs"
+	NTFSFilesystem    Filesystem = "ntfs"
+	FATFilesystem     Filesystem = "fat"
+)
+
+// Partition is a region of the disk that may hold a filesystem.
+type Partition struct {
+	*io.SectionReader
+	Table      PartitionTable
+	Index      int
+	Offset     int64
+	Filesystem Filesystem
+}
+
+func (p Partition) String() string {
+	if p.Table == NoPartitionTable {
+		return "whole disk"
+	}
+	return fmt.Sprintf("%s partition %d", p.Table, p.Index)
+}
+
+// Partitions returns the partitions described by the GPT or MBR partition table of the disk. When there is no partition
+// table, the whole disk is returned as a single partition.
+func Partitions(disk *Disk) ([]Partition, error) {
+	mbr := make([]byte, sectorSize)
+	if _, err := disk.ReadAt(mbr, 0); err != nil {
+		return nil, fmt.Errorf("unable to read master boot record: %w", err)
+	}
+
+	var partitions []Partition
+	var err error
+	switch {
+	case isGPT(disk):
+		partitions, err = gptPartitions(disk)
+	case isMBR(mbr):
+		partitions, err = mbrPartitions(disk, mbr)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(partitions) == 0 {
+		partitions = []Partition{{SectionReader: io.NewSectionReader(disk, 0, disk.Size)}}
+	}
+
+	for i := range partitions {
+		partitions[i].Filesystem = FilesystemOf(partitions[i].SectionReader)
+	}
+	return partitions, nil
+}
+
+func isMBR(mbr []byte) bool {
+	if mbr[mbrSignatureOffset] != 0x55 || mbr[mbrSignatureOffset+1] != 0xAA {
+		return false
+	}
+	// FAT and NTFS boot sectors carry the same signature, but do not describe sane partition entries
+	for i := 0; i < mbrEntries; i++ {
+		entry := mbr[mbrEntriesOffset+i*mbrEntrySize:]
+		if entry[0] != 0x00 && entry[0] != 0x80 {
+			return false
+		}
+	}
+	return true
+}
+
+func isGPT(disk *Disk) bool {
+	header := make([]byte, len(gptSignature))
+	if _, err := disk.ReadAt(header, sectorSize); err != nil {
+		return false
+	}
+	return string(header) == gptSignature
+}
+
+func mbrPartitions(disk *Disk, mbr []byte) ([]Partition, error) {
+	var partitions []Partition
+	for i := 0; i < mbrEntries; i++ {
+		entry := mbr[mbrEntriesOffset+i*mbrEntrySize:]
+		kind := entry[4]
+		start := int64(binary.LittleEndian.Uint32(entry[8:12])) * sectorSize
+		length := int64(binary.LittleEndian.Uint32(entry[12:16])) * sectorSize
+
+		if kind == 0 || length == 0 {
+			continue
+		}
+		if isExtendedPartition(kind) {
+			logical, err := logicalPartitions(disk, start, len(partitions)+1)
+			if err != nil {
+				return nil, err
+			}
+			partitions = append(partitions, logical...)
+			continue
+		}
+
+		if p, ok := newPartition(disk, MBRPartitionTable, i+1, start, length); ok {
+			partitions = append(partitions, p)
+		}
+	}
+	return partitions, nil
+}
+
+func isExtendedPartition(kind byte) bool {
+	return kind == 0x05 || kind == 0x0F || kind == 0x85
+}
+
+// logicalPartitions follows the chain of extended boot records within an extended partition.
+func logicalPartitions(disk *Disk, extendedStart int64, index int) ([]Partition, error) {
+	var partitions []Partition
+	ebrOffset := extendedStart
+	for i := 0; i < maxLogicalPartitions; i++ {
+		ebr := make([]byte, sectorSize)
+		if _, err := disk.ReadAt(ebr, ebrOffset); err != nil {
+			return nil, fmt.Errorf("unable to read extended boot record: %w", err)
+		}
+		if ebr[mbrSignatureOffset] != 0x55 || ebr[mbrSignatureOffset+1] != 0xAA {
+			break
+		}
+
+		entry := ebr[mbrEntriesOffset:]
+		start := int64(binary.LittleEndian.Uint32(entry[8:12])) * sectorSize
+		length := int64(binary.LittleEndian.Uint32(entry[12:16])) * sectorSize
+		if entry[4] != 0 && length > 0 {
+			if p, ok := newPartition(disk, MBRPartitionTable, index, ebrOffset+start, length); ok {
+				partitions = append(partitions, p)
+			}
+			index++
+		}
+
+		// the second entry points to the next extended boot record (relative to the start of the extended partition)
+		next := ebr[mbrEntriesOffset+mbrEntrySize:]
+		nextStart := int64(binary.LittleEndian.Uint32(next[8:12])) * sectorSize
+		if !isExtendedPartition(next[4]) || nextStart == 0 {
+			break
+		}
+		ebrOffset = extendedStart + nextStart
+	}
+	return partitions, nil
+}
+
+func gptPartitions(disk *Disk) ([]Partition, error) {
+	header := make([]byte, 92)
+	if _, err := disk.ReadAt(header, sectorSize); err != nil {
+		return nil, fmt.Errorf("unable to read GPT header: %w", err)
+	}
+
+	le := binary.LittleEndian
+	// LBAs are checked against the size of the disk before being converted to offsets, so they cannot overflow
+	maxLBA := uint64(disk.Size) / sectorSize
+	entriesLBA := le.Uint64(header[72:80])
+	if entriesLBA >= maxLBA {
+		return nil, fmt.Errorf("invalid GPT header (entries LBA=%d)", entriesLBA)
+	}
+	entriesOffset := int64(entriesLBA) * sectorSize
+	count := le.Uint32(header[80:84])
+	entrySize := le.Uint32(header[84:88])
+	if count > gptMaxEntries || entrySize < 128 || entrySize > 4096 {
+		return nil, fmt.Errorf("invalid GPT header (entries=%d, size=%d)", count, entrySize)
+	}
+
+	entries := make([]byte, int(count)*int(entrySize))
+	if _, err := disk.ReadAt(entries, entriesOffset); err != nil {
+		return nil, fmt.Errorf("unable to read GPT partition entries: %w", err)
+	}
+
+	var partitions []Partition
+	unused := make([]byte, 16)
+	for i := 0; i < int(count); i++ {
+		entry := entries[i*int(entrySize):]
+		if bytes.Equal(entry[0:16], unused) {
+			continue
+		}
+		first := le.Uint64(entry[32:40])
+		last := le.Uint64(entry[40:48])
+		if last < first || first >= maxLBA {
+			continue
+		}
+		if last >= maxLBA {
+			last = maxLBA - 1
+		}
+		p, ok := newPartition(disk, GPTPartitionTable, i+1, int64(first)*sectorSize, int64(last-first+1)*sectorSize)
+		if ok {
+			partitions = append(partitions, p)
+		}
+	}
+	return partitions, nil
+}
+
+// newPartition returns the partition at the given offset, which is truncated to the end of the disk. Partitions that do
+// not start within the disk are not returned.
+func newPartition(disk *Disk, table PartitionTable, index int, offset, length int64) (Partition, bool) {
+	if offset < 0 || offset >= disk.Size || length <= 0 {
+		return Partition{}, false
+	}
+	if length > disk.Size-offset {
+		length = disk.Size - offset
+	}
+	return Partition{
+		SectionReader: io.NewSectionReader(disk, offset, length),
+		Table:         table,
+		Index:         index,
+		Offset:        offset,
+	}, true
+}
+
+// FilesystemOf identifies the filesystem at the start of the given reader.
+func FilesystemOf(r io.ReaderAt) Filesystem {
+	buf := make([]byte, 2048)
+	n, _ := r.ReadAt(buf, 0)
+	buf = buf[:n]
+
+	switch {
+	case len(buf) >= 1082 && binary.LittleEndian.Uint16(buf[1080:1082]) == 0xEF53:
+		return ExtFilesystem
+	case bytes.HasPrefix(buf, []byte("XFSB")):
+		return XFSFilesystem
+	case len(buf) >= 11 && string(buf[3:11]) == "NTFS    ":
+		return NTFSFilesystem
+	case len(buf) >= 90 && (string(buf[54:59]) == "FAT12" || string(buf[54:59]) == "FAT16" || string(buf[82:87]) == "FAT32"):
+		return FATFilesystem
+	}
+	return UnknownFilesystem
+}
@@ -0,0 +1,195 @@
+package diskimage
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"sync"
+)
+
+const (
+	qcow2Magic        = "QFI\xfb"
+	qcow2HeaderSize   = 72
+	qcow2OffsetMask   = 0x00fffffffffffe00
+	qcow2Compressed   = 1 << 62
+	qcow2ZeroCluster  = 1
+	qcow2DirtyFeature = 1
+	qcow2MinClusterSz = 9
+	qcow2MaxClusterSz = 21
+)
+
+// qcow2Disk reads the guest disk from a (non-encrypted, standalone) qcow2 image.
+type qcow2Disk struct {
+	r           io.ReaderAt
+	clusterBits uint32
+	clusterSize int64
+	l2Entries   int64
+	l1          []uint64
+
+	// the most recently decompressed cluster is cached, since reads are typically sequential
+	lock          sync.Mutex
+	cachedOffset  uint64
+	cachedCluster []byte
+}
+
+func openQCOW2(r io.ReaderAt, imageSize int64) (*Disk, error) {
+	header := make([]byte, qcow2HeaderSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("unable to read qcow2 header: %w", err)
+	}
+
+	be := binary.BigEndian
+	version := be.Uint32(header[4:8])
+	backingFileOffset := be.Uint64(header[8:16])
+	clusterBits := be.Uint32(header[20:24])
+	size := be.Uint64(header[24:32])
+	cryptMethod := be.Uint32(header[32:36])
+	l1Size := be.Uint32(header[36:40])
+	l1Offset := be.Uint64(header[40:48])
+
+	switch {
+	case version != 2 && version != 3:
+		return nil, fmt.Errorf("unsupported qcow2 version=%d", version)
+	case backingFileOffset != 0:
+		return nil, fmt.Errorf("qcow2 images with a backing file are not supported")
+	case cryptMethod != 0:
+		return nil, fmt.Errorf("encrypted qcow2 images are not supported")
+	case clusterBits < qcow2MinClusterSz || clusterBits > qcow2MaxClusterSz:
+		return nil, fmt.Errorf("invalid qcow2 cluster bits=%d", clusterBits)
+	case size > math.MaxInt64:
+		return nil, fmt.Errorf("invalid qcow2 virtual size=%d", size)
+	}
+
+	if version == 3 {
+		// version 3 images may declare an external data file, a compression type other than deflate, or extended L2
+		// entries, none of which are supported (only the "dirty" bit is safe to ignore for reading)
+		features := make([]byte, 8)
+		if _, err := r.ReadAt(features, qcow2HeaderSize); err != nil {
+			return nil, fmt.Errorf("unable to read qcow2 features: %w", err)
+		}
+		if incompatible := be.Uint64(features); incompatible&^qcow2DirtyFeature != 0 {
+			return nil, fmt.Errorf("unsupported qcow2 incompatible features=%#x", incompatible)
+		}
+	}
+
+	tableLen, err := tableSize("qcow2 L1 table", uint64(l1Size), 8, l1Offset, imageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &qcow2Disk{
+		r:           r,
+		clusterBits: clusterBits,
+		clusterSize: 1 << clusterBits,
+		l2Entries:   (1 << clusterBits) / 8,
+		l1:          make([]uint64, l1Size),
+	}
+
+	table := make([]byte, tableLen)
+	if _, err := r.ReadAt(table, int64(l1Offset)); err != nil {
+		return nil, fmt.Errorf("unable to read qcow2 L1 table: %w", err)
+	}
+	for i := range d.l1 {
+		d.l1[i] = be.Uint64(table[i*8:])
+	}
+
+	return &Disk{ReaderAt: d, Format: QCOW2Format, Size: int64(size)}, nil
+}
+
+func (d *qcow2Disk) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errNegativeOffset
+	}
+
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		within := pos % d.clusterSize
+		chunk := d.clusterSize - within
+		if rest := int64(len(p) - n); chunk > rest {
+			chunk = rest
+		}
+
+		if err := d.readCluster(p[n:n+int(chunk)], pos/d.clusterSize, within); err != nil {
+			return n, err
+		}
+		n += int(chunk)
+	}
+	return n, nil
+}
+
+func (d *qcow2Disk) readCluster(p []byte, cluster, within int64) error {
+	l1Index := cluster / d.l2Entries
+	l2Index := cluster % d.l2Entries
+	if l1Index >= int64(len(d.l1)) {
+		return io.EOF
+	}
+
+	l2Offset := d.l1[l1Index] & qcow2OffsetMask
+	if l2Offset == 0 {
+		zeroFill(p)
+		return nil
+	}
+
+	entry := make([]byte, 8)
+	if _, err := d.r.ReadAt(entry, int64(l2Offset)+l2Index*8); err != nil {
+		return fmt.Errorf("unable to read qcow2 L2 entry: %w", err)
+	}
+	l2 := binary.BigEndian.Uint64(entry)
+
+	if l2&qcow2Compressed != 0 {
+		data, err := d.decompress(l2)
+		if err != nil {
+			return err
+		}
+		copy(p, data[within:])
+		return nil
+	}
+
+	offset := l2 & qcow2OffsetMask
+	if offset == 0 || l2&qcow2ZeroCluster != 0 {
+		zeroFill(p)
+		return nil
+	}
+
+	_, err := d.r.ReadAt(p, int64(offset)+within)
+	if err == io.EOF {
+		// the final cluster of an image may be truncated
+		err = nil
+	}
+	return err
+}
+
+func (d *qcow2Disk) decompress(l2 uint64) ([]byte, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	sizeBits := d.clusterBits - 8
+	offsetBits := 62 - sizeBits
+	offset := l2 & (1<<offsetBits - 1)
+	sectors := (l2>>offsetBits)&(1<<sizeBits-1) + 1
+
+	if d.cachedCluster != nil && d.cachedOffset == offset {
+		return d.cachedCluster, nil
+	}
+
+	compressedSize := int64(sectors)*sectorSize - int64(offset%sectorSize)
+	compressed := make([]byte, compressedSize)
+	n, err := d.r.ReadAt(compressed, int64(offset))
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to read compressed qcow2 cluster: %w", err)
+	}
+
+	data := make([]byte, d.clusterSize)
+	reader := flate.NewReader(bytes.NewReader(compressed[:n]))
+	if _, err := io.ReadFull(reader, data); err != nil && err != io.ErrUnexpectedEOF {
+		return nil, fmt.Errorf("unable to decompress qcow2 cluster: %w", err)
+	}
+
+	d.cachedOffset = offset
+	d.cachedCluster = data
+	return data, nil
+}
@@ -0,0 +1,150 @@
+package diskimage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	vhdCookie         = "conectix"
+	vhdSparseCookie   = "cxsparse"
+	vhdFooterSize     = 512
+	vhdDynamicHdrSize = 1024
+	vhdFixedDisk      = 2
+	vhdDynamicDisk    = 3
+	vhdDifferencing   = 4
+	vhdUnallocated    = 0xFFFFFFFF
+)
+
+// vhdDisk reads the guest disk from a dynamic VHD image (fixed images are the raw disk followed by a footer).
+type vhdDisk struct {
+	r          io.ReaderAt
+	blockSize  int64
+	bitmapSize int64
+	bat        []uint32
+}
+
+func isVHD(r io.ReaderAt, size int64) bool {
+	_, err := vhdFooter(r, size)
+	return err == nil
+}
+
+// vhdFooter returns the footer of the image, which is found at the end of the image (and, for dynamic images, also
+// at the start of the image, which is only used when the end of the image has been truncated).
+func vhdFooter(r io.ReaderAt, size int64) ([]byte, error) {
+	footer := make([]byte, vhdFooterSize)
+	for _, offset := range []int64{size - vhdFooterSize, 0} {
+		if offset < 0 {
+			continue
+		}
+		if _, err := r.ReadAt(footer, offset); err != nil {
+			continue
+		}
+		if string(footer[:len(vhdCookie)]) == vhdCookie {
+			return footer, nil
+		}
+	}
+	return nil, fmt.Errorf("no VHD footer found")
+}
+
+func openVHD(r io.ReaderAt, size int64) (*Disk, error) {
+	footer, err := vhdFooter(r, size)
+	if err != nil {
+		return nil, err
+	}
+
+	be := binary.BigEndian
+	dataOffset := be.Uint64(footer[16:24])
+	currentSize := int64(be.Uint64(footer[48:56]))
+	diskType := be.Uint32(footer[60:64])
+
+	if currentSize < 0 {
+		return nil, fmt.Errorf("invalid VHD disk size=%d", uint64(currentSize))
+	}
+
+	switch diskType {
+	case vhdFixedDisk:
+		if currentSize > size-vhdFooterSize {
+			currentSize = size - vhdFooterSize
+		}
+		return &Disk{ReaderAt: io.NewSectionReader(r, 0, currentSize), Format: VHDFormat, Size: currentSize}, nil
+	case vhdDynamicDisk:
+		// handled below
+	case vhdDifferencing:
+		return nil, fmt.Errorf("differencing VHD images are not supported")
+	default:
+		return nil, fmt.Errorf("unsupported VHD disk type=%d", diskType)
+	}
+
+	header := make([]byte, vhdDynamicHdrSize)
+	if _, err := r.ReadAt(header, int64(dataOffset)); err != nil {
+		return nil, fmt.Errorf("unable to read VHD dynamic disk header: %w", err)
+	}
+	if string(header[:len(vhdSparseCookie)]) != vhdSparseCookie {
+		return nil, fmt.Errorf("invalid VHD dynamic disk header")
+	}
+
+	tableOffset := be.Uint64(header[16:24])
+	entries := be.Uint32(header[28:32])
+	blockSize := int64(be.Uint32(header[32:36]))
+	if blockSize == 0 || blockSize%sectorSize != 0 {
+		return nil, fmt.Errorf("invalid VHD block size=%d", blockSize)
+	}
+
+	tableLen, err := tableSize("VHD block allocation table", uint64(entries), 4, tableOffset, size)
+	if err != nil {
+		return nil, err
+	}
+
+	table := make([]byte, tableLen)
+	if _, err := r.ReadAt(table, int64(tableOffset)); err != nil {
+		return nil, fmt.Errorf("unable to read VHD block allocation table: %w", err)
+	}
+
+	d := &vhdDisk{
+		r:         r,
+		blockSize: blockSize,
+		// each block starts with a bitmap of its sectors (one bit per sector), padded to a sector boundary
+		bitmapSize: ((blockSize/sectorSize/8 + sectorSize - 1) / sectorSize) * sectorSize,
+		bat:        make([]uint32, entries),
+	}
+	for i := range d.bat {
+		d.bat[i] = be.Uint32(table[i*4:])
+	}
+
+	return &Disk{ReaderAt: d, Format: VHDFormat, Size: currentSize}, nil
+}
+
+func (d *vhdDisk) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errNegativeOffset
+	}
+
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		block := pos / d.blockSize
+		within := pos % d.blockSize
+		chunk := d.blockSize - within
+		if rest := int64(len(p) - n); chunk > rest {
+			chunk = rest
+		}
+
+		if block >= int64(len(d.bat)) {
+			return n, io.EOF
+		}
+
+		buf := p[n : n+int(chunk)]
+		if d.bat[block] == vhdUnallocated {
+			zeroFill(buf)
+		} else {
+			offset := int64(d.bat[block])*sectorSize + d.bitmapSize + within
+			if _, err := d.r.ReadAt(buf, offset); err != nil && err != io.EOF {
+				return n, err
+			}
+		}
+		n += int(chunk)
+	}
+	return n, nil
+}
@@ -0,0 +1,129 @@
+package diskimage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+const (
+	vmdkSparseMagic     = "KDMV"
+	vmdkHeaderSize      = 79
+	vmdkGDAtEnd         = 0xFFFFFFFFFFFFFFFF
+	vmdkFlagCompressed  = 1 << 16
+	vmdkGrainZeroed     = 1
+	vmdkMaxGrainEntries = 1 << 16
+)
+
+// vmdkDisk reads the guest disk from a monolithic sparse (hosted) VMDK extent.
+type vmdkDisk struct {
+	r         io.ReaderAt
+	grainSize int64
+	gtEntries int64
+	grainDir  []uint32
+}
+
+func openVMDK(r io.ReaderAt, imageSize int64) (*Disk, error) {
+	header := make([]byte, vmdkHeaderSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("unable to read VMDK header: %w", err)
+	}
+
+	le := binary.LittleEndian
+	flags := le.Uint32(header[8:12])
+	capacitySectors := le.Uint64(header[12:20])
+	grainSectors := le.Uint64(header[20:28])
+	gtEntries := int64(le.Uint32(header[44:48]))
+	gdOffset := le.Uint64(header[56:64])
+
+	// sector counts and offsets are converted to bytes, which must not overflow
+	const maxSectors = math.MaxInt64 / sectorSize
+	switch {
+	case flags&vmdkFlagCompressed != 0 || gdOffset == vmdkGDAtEnd:
+		return nil, fmt.Errorf("stream optimized VMDK images are not supported")
+	case grainSectors == 0 || grainSectors > maxSectors || gtEntries == 0 || gtEntries > vmdkMaxGrainEntries:
+		return nil, fmt.Errorf("invalid VMDK geometry (grain size=%d sectors, grain table entries=%d)", grainSectors, gtEntries)
+	case capacitySectors > maxSectors:
+		return nil, fmt.Errorf("invalid VMDK capacity=%d sectors", capacitySectors)
+	case gdOffset > maxSectors:
+		return nil, fmt.Errorf("invalid VMDK grain directory offset=%d sectors", gdOffset)
+	}
+
+	capacity := int64(capacitySectors) * sectorSize
+	grainSize := int64(grainSectors) * sectorSize
+	grains := capacity / grainSize
+	if capacity%grainSize != 0 {
+		grains++
+	}
+	tables := (grains + gtEntries - 1) / gtEntries
+
+	directoryLen, err := tableSize("VMDK grain directory", uint64(tables), 4, gdOffset*sectorSize, imageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	directory := make([]byte, directoryLen)
+	if _, err := r.ReadAt(directory, int64(gdOffset)*sectorSize); err != nil {
+		return nil, fmt.Errorf("unable to read VMDK grain directory: %w", err)
+	}
+
+	d := &vmdkDisk{
+		r:         r,
+		grainSize: grainSize,
+		gtEntries: gtEntries,
+		grainDir:  make([]uint32, tables),
+	}
+	for i := range d.grainDir {
+		d.grainDir[i] = le.Uint32(directory[i*4:])
+	}
+
+	return &Disk{ReaderAt: d, Format: VMDKFormat, Size: capacity}, nil
+}
+
+func (d *vmdkDisk) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, errNegativeOffset
+	}
+
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		grain := pos / d.grainSize
+		within := pos % d.grainSize
+		chunk := d.grainSize - within
+		if rest := int64(len(p) - n); chunk > rest {
+			chunk = rest
+		}
+
+		table := grain / d.gtEntries
+		if table >= int64(len(d.grainDir)) {
+			return n, io.EOF
+		}
+
+		buf := p[n : n+int(chunk)]
+		sector, err := d.grainSector(d.grainDir[table], grain%d.gtEntries)
+		if err != nil {
+			return n, err
+		}
+
+		if sector <= vmdkGrainZeroed {
+			zeroFill(buf)
+		} else if _, err := d.r.ReadAt(buf, int64(sector)*sectorSize+within); err != nil && err != io.EOF {
+			return n, err
+		}
+		n += int(chunk)
+	}
+	return n, nil
+}
+
+func (d *vmdkDisk) grainSector(tableSector uint32, index int64) (uint32, error) {
+	if tableSector == 0 {
+		return 0, nil
+	}
+	entry := make([]byte, 4)
+	if _, err := d.r.ReadAt(entry, int64(tableSector)*sectorSize+index*4); err != nil {
+		return 0, fmt.Errorf("unable to read VMDK grain table entry: %w", err)
+	}
+	return binary.LittleEndian.Uint32(entry), nil
+}
@@ -0,0 +1,261 @@
+/*
+Package ext4 provides read-only access to ext2, ext3, and ext4 filesystems as an fs.FS. Only the structures needed to
+walk directories and read file contents are supported (journals are not replayed, and inline data is not supported).
+*/
+package ext4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// directories and symlink destinations are read into memory whole, so their (untrusted) sizes are bounded
+	maxDirectorySize = 256 << 20
+	maxSymlinkSize   = 4096
+)
+
+var (
+	_ fs.FS        = (*FS)(nil)
+	_ fs.ReadDirFS = (*FS)(nil)
+)
+
+// FS is a read-only view of an ext2/3/4 filesystem.
+type FS struct {
+	r  io.ReaderAt
+	sb *superblock
+}
+
+// New returns a read-only filesystem from the given reader (which must start at the first byte of the filesystem).
+func New(r io.ReaderAt) (*FS, error) {
+	sb, err := readSuperblock(r)
+	if err != nil {
+		return nil, err
+	}
+	return &FS{r: r, sb: sb}, nil
+}
+
+// UUID returns the filesystem UUID (as referenced by "UUID=" entries within /etc/fstab).
+func (f *FS) UUID() string {
+	return f.sb.uuid
+}
+
+// Label returns the volume label (as referenced by "LABEL=" entries within /etc/fstab).
+func (f *FS) Label() string {
+	return f.sb.label
+}
+
+// Open opens the named file. Symlinks are not followed; the returned file for a symlink provides the link destination
+// via SymlinkPath().
+func (f *FS) Open(name string) (fs.File, error) {
+	in, err := f.lookup(name, "open")
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{name: path.Base(name), inode: in}
+	switch {
+	case in.isDir():
+		return file, nil
+	case in.isSymlink():
+		target, err := f.readlink(in)
+		if err != nil {
+			return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+		}
+		file.symlink = target
+		return file, nil
+	}
+
+	extents, err := f.extents(in)
+	if err != nil {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: err}
+	}
+	file.reader = io.NewSectionReader(&inodeReader{fs: f, extents: extents, size: int64(in.size)}, 0, int64(in.size))
+	return file, nil
+}
+
+// ReadDir reads the named directory and returns all entries sorted by filename.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	in, err := f.lookup(name, "readdir")
+	if err != nil {
+		return nil, err
+	}
+	if !in.isDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fmt.Errorf("not a directory")}
+	}
+
+	entries, err := f.dirEntries(in)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+	return entries, nil
+}
+
+func (f *FS) lookup(name, op string) (*inode, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	in, err := f.readInode(rootInode)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if name == "." {
+		return in, nil
+	}
+
+	for _, part := range strings.Split(name, "/") {
+		if !in.isDir() {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		entries, err := f.dirEntries(in)
+		if err != nil {
+			return nil, &fs.PathError{Op: op, Path: name, Err: err}
+		}
+		i := sort.Search(len(entries), func(i int) bool { return entries[i].Name() >= part })
+		if i == len(entries) || entries[i].Name() != part {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		in = entries[i].(*dirEntry).inode
+	}
+	return in, nil
+}
+
+// dirEntries reads all entries of a directory (excluding "." and ".."), sorted by name. Hashed (htree) directories are
+// read linearly, which is possible since the index nodes are stored as empty directory entries.
+func (f *FS) dirEntries(dir *inode) ([]fs.DirEntry, error) {
+	size, err := f.boundedSize(dir, maxDirectorySize)
+	if err != nil {
+		return nil, err
+	}
+	extents, err := f.extents(dir)
+	if err != nil {
+		return nil, err
+	}
+	data := make([]byte, size)
+	reader := &inodeReader{fs: f, extents: extents, size: int64(dir.size)}
+	if _, err := reader.ReadAt(data, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	le := binary.LittleEndian
+	var entries []fs.DirEntry
+	for offset := 0; offset+8 <= len(data); {
+		number := le.Uint32(data[offset : offset+4])
+		recordLength := int(le.Uint16(data[offset+4 : offset+6]))
+		nameLength := int(data[offset+6])
+
+		if recordLength < 8 || offset+recordLength > len(data) {
+			return nil, fmt.Errorf("invalid directory entry length=%d (inode=%d)", recordLength, dir.number)
+		}
+
+		if number != 0 && 8+nameLength <= recordLength {
+			name := string(data[offset+8 : offset+8+nameLength])
+			if name != "." && name != ".." {
+				in, err := f.readInode(number)
+				if err != nil {
+					return nil, err
+				}
+				entries = append(entries, &dirEntry{name: name, inode: in})
+			}
+		}
+		offset += recordLength
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+func (f *FS) readlink(in *inode) (string, error) {
+	// "fast" symlinks store the destination within the block pointer area of the inode itself
+	if in.size < inlineBlockDataBytes && in.flags&inodeFlagExtents == 0 && in.blocks == 0 {
+		return string(in.block[:in.size]), nil
+	}
+
+	size, err := f.boundedSize(in, maxSymlinkSize)
+	if err != nil {
+		return "", err
+	}
+	extents, err := f.extents(in)
+	if err != nil {
+		return "", err
+	}
+	target := make([]byte, size)
+	reader := &inodeReader{fs: f, extents: extents, size: int64(in.size)}
+	if _, err := reader.ReadAt(target, 0); err != nil && err != io.EOF {
+		return "", err
+	}
+	return string(target), nil
+}
+
+// boundedSize returns the size of an inode that is read into memory whole, ensuring the size is within the given limit
+// and within the blocks allocated to the inode.
+func (f *FS) boundedSize(in *inode, limit uint64) (int, error) {
+	// the allocated block count is in 512 byte units, unless the inode is marked as a huge file
+	allocated := in.blocks * 512
+	if in.flags&inodeFlagHugeFile != 0 {
+		allocated = in.blocks * f.sb.blockSize
+	}
+	if in.size > limit || in.size > allocated {
+		return 0, fmt.Errorf("invalid size=%d (inode=%d, allocated=%d)", in.size, in.number, allocated)
+	}
+	return int(in.size), nil
+}
+
+type dirEntry struct {
+	name  string
+	inode *inode
+}
+
+func (d *dirEntry) Name() string               { return d.name }
+func (d *dirEntry) IsDir() bool                { return d.inode.isDir() }
+func (d *dirEntry) Type() fs.FileMode          { return d.inode.fileMode().Type() }
+func (d *dirEntry) Info() (fs.FileInfo, error) { return &fileInfo{name: d.name, inode: d.inode}, nil }
+
+type fileInfo struct {
+	name  string
+	inode *inode
+}
+
+func (i *fileInfo) Name() string       { return i.name }
+func (i *fileInfo) Size() int64        { return int64(i.inode.size) }
+func (i *fileInfo) Mode() fs.FileMode  { return i.inode.fileMode() }
+func (i *fileInfo) ModTime() time.Time { return time.Time{} }
+func (i *fileInfo) IsDir() bool        { return i.inode.isDir() }
+func (i *fileInfo) Sys() interface{}   { return nil }
+
+// File is an open file (or directory, or symlink) within the filesystem.
+type File struct {
+	name    string
+	inode   *inode
+	reader  *io.SectionReader
+	symlink string
+}
+
+func (f *File) Stat() (fs.FileInfo, error) {
+	return &fileInfo{name: f.name, inode: f.inode}, nil
+}
+
+func (f *File) Read(p []byte) (int, error) {
+	if f.reader == nil {
+		return 0, &fs.PathError{Op: "read", Path: f.name, Err: fmt.Errorf("not a regular file")}
+	}
+	return f.reader.Read(p)
+}
+
+func (f *File) Close() error {
+	return nil
+}
+
+// SymlinkPath returns the link destination when the file is a symlink (otherwise an empty string).
+func (f *File) SymlinkPath() string {
+	return f.symlink
+}
@@ -0,0 +1,75 @@
+package ext4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withInodeSize returns a copy of the filesystem image with the size of the inode at the given path replaced.
+func withInodeSize(t *testing.T, image []byte, name string, size uint64) []byte {
+	t.Helper()
+
+	f, err := New(bytes.NewReader(image))
+	require.NoError(t, err)
+	in, err := f.lookup(name, "open")
+	require.NoError(t, err)
+
+	table, err := f.inodeTable((in.number - 1) / f.sb.inodesPerGroup)
+	require.NoError(t, err)
+	offset := table*f.sb.blockSize + uint64((in.number-1)%f.sb.inodesPerGroup)*uint64(f.sb.inodeSize)
+
+	patched := append([]byte(nil), image...)
+	le := binary.LittleEndian
+	le.PutUint32(patched[offset+4:], uint32(size))
+	le.PutUint32(patched[offset+0x6C:], uint32(size>>32))
+	return patched
+}
+
+func TestFS_OversizedInodes(t *testing.T) {
+	image, err := os.ReadFile("../../test-fixtures/disk-images/ext2.img")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		inode   string
+		size    uint64
+		read    func(f *FS) error
+		wantErr string
+	}{
+		{
+			name:  "directory larger than its allocated blocks",
+			inode: ".",
+			size:  1 << 40,
+			read: func(f *FS) error {
+				_, err := f.ReadDir(".")
+				return err
+			},
+			wantErr: "invalid size=1099511627776",
+		},
+		{
+			name:  "symlink destination larger than the maximum symlink size",
+			inode: "bin/libfoo-link",
+			size:  maxSymlinkSize + 1,
+			read: func(f *FS) error {
+				_, err := f.Open("bin/libfoo-link")
+				return err
+			},
+			wantErr: "invalid size=4097",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := New(bytes.NewReader(withInodeSize(t, image, test.inode, test.size)))
+			require.NoError(t, err)
+
+			err = test.read(f)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
@@ -0,0 +1,315 @@
+package ext4
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+)
+
+const (
+	rootInode = 2
+
+	inodeFlagHugeFile   = 0x40000
+	inodeFlagExtents    = 0x80000
+	inodeFlagInlineData = 0x10000000
+
+	modeTypeMask = 0xF000
+	modeFIFO     = 0x1000
+	modeCharDev  = 0x2000
+	modeDir      = 0x4000
+	modeBlockDev = 0x6000
+	modeRegular  = 0x8000
+	modeSymlink  = 0xA000
+	modeSocket   = 0xC000
+
+	extentMagic          = 0xF30A
+	extentUninitialized  = 32768
+	directBlocks         = 12
+	inlineBlockDataBytes = 60
+)
+
+type inode struct {
+	number uint32
+	mode   uint16
+	size   uint64
+	flags  uint32
+	blocks uint64
+	block  [inlineBlockDataBytes]byte
+}
+
+// extent maps a run of logical file blocks to physical filesystem blocks.
+type extent struct {
+	logical  uint64
+	physical uint64
+	length   uint64
+	zeroed   bool
+}
+
+func (i inode) isDir() bool {
+	return i.mode&modeTypeMask == modeDir
+}
+
+func (i inode) isSymlink() bool {
+	return i.mode&modeTypeMask == modeSymlink
+}
+
+func (i inode) fileMode() fs.FileMode {
+	mode := fs.FileMode(i.mode & 0777)
+	switch i.mode & modeTypeMask {
+	case modeDir:
+		mode |= fs.ModeDir
+	case modeSymlink:
+		mode |= fs.ModeSymlink
+	case modeFIFO:
+		mode |= fs.ModeNamedPipe
+	case modeCharDev:
+		mode |= fs.ModeDevice | fs.ModeCharDevice
+	case modeBlockDev:
+		mode |= fs.ModeDevice
+	case modeSocket:
+		mode |= fs.ModeSocket
+	}
+	return mode
+}
+
+func (f *FS) readInode(number uint32) (*inode, error) {
+	if number == 0 || number > f.sb.inodesCount {
+		return nil, fmt.Errorf("invalid inode number=%d", number)
+	}
+
+	group := (number - 1) / f.sb.inodesPerGroup
+	index := (number - 1) % f.sb.inodesPerGroup
+
+	table, err := f.inodeTable(group)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := make([]byte, goodOldInodeSize)
+	offset := int64(table*f.sb.blockSize) + int64(index)*int64(f.sb.inodeSize)
+	if _, err := f.r.ReadAt(buf, offset); err != nil {
+		return nil, fmt.Errorf("unable to read inode=%d: %w", number, err)
+	}
+
+	le := binary.LittleEndian
+	in := &inode{
+		number: number,
+		mode:   le.Uint16(buf[0:2]),
+		size:   uint64(le.Uint32(buf[4:8])) | uint64(le.Uint32(buf[0x6C:0x70]))<<32,
+		blocks: uint64(le.Uint32(buf[0x1C:0x20])),
+		flags:  le.Uint32(buf[0x20:0x24]),
+	}
+	copy(in.block[:], buf[0x28:0x28+inlineBlockDataBytes])
+	return in, nil
+}
+
+func (f *FS) inodeTable(group uint32) (uint64, error) {
+	buf := make([]byte, f.sb.groupDescSize)
+	offset := int64(f.sb.groupDescriptorAt) + int64(group)*int64(f.sb.groupDescSize)
+	if _, err := f.r.ReadAt(buf, offset); err != nil {
+		return 0, fmt.Errorf("unable to read group descriptor=%d: %w", group, err)
+	}
+
+	le := binary.LittleEndian
+	table := uint64(le.Uint32(buf[8:12]))
+	if f.sb.is64Bit() && len(buf) >= 0x2C {
+		table |= uint64(le.Uint32(buf[0x28:0x2C])) << 32
+	}
+	return table, nil
+}
+
+// extents returns the mapping of logical to physical blocks for the given inode (sorted by logical block).
+func (f *FS) extents(in *inode) ([]extent, error) {
+	if in.flags&inodeFlagInlineData != 0 {
+		return nil, fmt.Errorf("inline data is not supported (inode=%d)", in.number)
+	}
+
+	var results []extent
+	var err error
+	if in.flags&inodeFlagExtents != 0 {
+		results, err = f.extentTree(in.block[:], 0)
+	} else {
+		results, err = f.blockMap(in)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].logical < results[j].logical
+	})
+	return results, nil
+}
+
+func (f *FS) extentTree(node []byte, level int) ([]extent, error) {
+	// guard against cycles within a corrupt tree (the maximum valid depth is 5)
+	if level > 5 {
+		return nil, fmt.Errorf("extent tree is too deep")
+	}
+
+	le := binary.LittleEndian
+	if len(node) < 12 || le.Uint16(node[0:2]) != extentMagic {
+		return nil, fmt.Errorf("invalid extent header")
+	}
+	entries := int(le.Uint16(node[2:4]))
+	depth := le.Uint16(node[6:8])
+
+	var results []extent
+	for i := 0; i < entries; i++ {
+		entry := node[12+i*12:]
+		if len(entry) < 12 {
+			return nil, fmt.Errorf("truncated extent entry")
+		}
+
+		if depth == 0 {
+			length := uint64(le.Uint16(entry[4:6]))
+			e := extent{
+				logical:  uint64(le.Uint32(entry[0:4])),
+				physical: uint64(le.Uint16(entry[6:8]))<<32 | uint64(le.Uint32(entry[8:12])),
+				length:   length,
+			}
+			if length > extentUninitialized {
+				// preallocated (but unwritten) blocks read as zeros
+				e.length = length - extentUninitialized
+				e.zeroed = true
+			}
+			results = append(results, e)
+			continue
+		}
+
+		leaf := uint64(le.Uint16(entry[8:10]))<<32 | uint64(le.Uint32(entry[4:8]))
+		child, err := f.readBlock(leaf)
+		if err != nil {
+			return nil, err
+		}
+		childExtents, err := f.extentTree(child, level+1)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, childExtents...)
+	}
+	return results, nil
+}
+
+// blockMap reads the (ext2/ext3 style) direct and indirect block pointers of an inode.
+func (f *FS) blockMap(in *inode) ([]extent, error) {
+	le := binary.LittleEndian
+	totalBlocks := (in.size + f.sb.blockSize - 1) / f.sb.blockSize
+
+	var results []extent
+	var logical uint64
+
+	add := func(physical uint64) {
+		if physical != 0 {
+			results = append(results, extent{logical: logical, physical: physical, length: 1})
+		}
+		logical++
+	}
+
+	var walk func(block uint64, level int) error
+	walk = func(block uint64, level int) error {
+		if block == 0 {
+			// a sparse region, skip over every block that this pointer would have addressed
+			perBlock := f.sb.blockSize / 4
+			span := uint64(1)
+			for i := 0; i < level; i++ {
+				span *= perBlock
+			}
+			logical += span
+			return nil
+		}
+		if level == 0 {
+			add(block)
+			return nil
+		}
+		data, err := f.readBlock(block)
+		if err != nil {
+			return err
+		}
+		for i := 0; i+4 <= len(data) && logical < totalBlocks; i += 4 {
+			if err := walk(uint64(le.Uint32(data[i:i+4])), level-1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	for i := 0; i < directBlocks && logical < totalBlocks; i++ {
+		add(uint64(le.Uint32(in.block[i*4 : i*4+4])))
+	}
+	for level := 1; level <= 3 && logical < totalBlocks; level++ {
+		pointer := uint64(le.Uint32(in.block[(directBlocks+level-1)*4 : (directBlocks+level)*4]))
+		if err := walk(pointer, level); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func (f *FS) readBlock(block uint64) ([]byte, error) {
+	buf := make([]byte, f.sb.blockSize)
+	if _, err := f.r.ReadAt(buf, int64(block*f.sb.blockSize)); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to read block=%d: %w", block, err)
+	}
+	return buf, nil
+}
+
+// inodeReader provides random access to the contents of an inode through its extents.
+type inodeReader struct {
+	fs      *FS
+	extents []extent
+	size    int64
+}
+
+func (r *inodeReader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= r.size {
+		return 0, io.EOF
+	}
+	if remaining := r.size - off; int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	blockSize := int64(r.fs.sb.blockSize)
+	n := 0
+	for n < len(p) {
+		pos := off + int64(n)
+		logical := uint64(pos / blockSize)
+		within := pos % blockSize
+
+		chunk := blockSize - within
+		if rest := int64(len(p) - n); chunk > rest {
+			chunk = rest
+		}
+
+		e := r.find(logical)
+		if e == nil || e.zeroed {
+			// holes (and unwritten extents) are read as zeros
+			for i := int64(0); i < chunk; i++ {
+				p[n+int(i)] = 0
+			}
+		} else {
+			physical := int64(e.physical+(logical-e.logical))*blockSize + within
+			if _, err := r.fs.r.ReadAt(p[n:n+int(chunk)], physical); err != nil && err != io.EOF {
+				return n, err
+			}
+		}
+		n += int(chunk)
+	}
+
+	if n < len(p) || off+int64(n) >= r.size {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (r *inodeReader) find(logical uint64) *extent {
+	i := sort.Search(len(r.extents), func(i int) bool {
+		return r.extents[i].logical+r.extents[i].length > logical
+	})
+	if i < len(r.extents) && r.extents[i].logical <= logical {
+		return &r.extents[i]
+	}
+	return nil
+}
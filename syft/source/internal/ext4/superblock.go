@@ -0,0 +1,100 @@
+package ext4
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	superblockOffset = 1024
+	superblockSize   = 1024
+	superblockMagic  = 0xEF53
+
+	// the incompatible feature flag indicating 64 bit block numbers (and larger group descriptors)
+	featureIncompat64Bit = 0x0080
+
+	groupDescriptorSize32 = 32
+	goodOldInodeSize      = 128
+)
+
+type superblock struct {
+	inodesCount       uint32
+	blocksCount       uint64
+	firstDataBlock    uint32
+	blockSize         uint64
+	blocksPerGroup    uint32
+	inodesPerGroup    uint32
+	inodeSize         uint16
+	featureIncompat   uint32
+	groupDescSize     uint16
+	groupDescriptorAt uint64
+	uuid              string
+	label             string
+}
+
+// IsExt indicates if the given reader contains an ext2, ext3, or ext4 filesystem (at offset 0).
+func IsExt(r io.ReaderAt) bool {
+	magic := make([]byte, 2)
+	if _, err := r.ReadAt(magic, superblockOffset+56); err != nil {
+		return false
+	}
+	return binary.LittleEndian.Uint16(magic) == superblockMagic
+}
+
+func readSuperblock(r io.ReaderAt) (*superblock, error) {
+	buf := make([]byte, superblockSize)
+	if _, err := r.ReadAt(buf, superblockOffset); err != nil {
+		return nil, fmt.Errorf("unable to read superblock: %w", err)
+	}
+
+	le := binary.LittleEndian
+	if le.Uint16(buf[56:58]) != superblockMagic {
+		return nil, fmt.Errorf("not an ext filesystem (bad superblock magic)")
+	}
+
+	sb := &superblock{
+		inodesCount:     le.Uint32(buf[0:4]),
+		blocksCount:     uint64(le.Uint32(buf[4:8])),
+		firstDataBlock:  le.Uint32(buf[20:24]),
+		blockSize:       1024 << le.Uint32(buf[24:28]),
+		blocksPerGroup:  le.Uint32(buf[32:36]),
+		inodesPerGroup:  le.Uint32(buf[40:44]),
+		inodeSize:       goodOldInodeSize,
+		featureIncompat: le.Uint32(buf[96:100]),
+		groupDescSize:   groupDescriptorSize32,
+	}
+
+	sb.uuid = formatUUID(buf[0x68:0x78])
+	sb.label = string(bytes.TrimRight(buf[0x78:0x88], "\x00"))
+
+	// revision 0 filesystems always have 128 byte inodes
+	if le.Uint32(buf[76:80]) > 0 {
+		sb.inodeSize = le.Uint16(buf[88:90])
+	}
+
+	if sb.featureIncompat&featureIncompat64Bit != 0 {
+		sb.blocksCount |= uint64(le.Uint32(buf[0x150:0x154])) << 32
+		if size := le.Uint16(buf[254:256]); size >= groupDescriptorSize32 {
+			sb.groupDescSize = size
+		}
+	}
+
+	if sb.inodesPerGroup == 0 || sb.blocksPerGroup == 0 || sb.inodeSize == 0 {
+		return nil, fmt.Errorf("invalid superblock geometry")
+	}
+
+	// the group descriptor table starts in the block following the superblock
+	sb.groupDescriptorAt = (uint64(sb.firstDataBlock) + 1) * sb.blockSize
+
+	return sb, nil
+}
+
+func (sb *superblock) is64Bit() bool {
+	return sb.featureIncompat&featureIncompat64Bit != 0
+}
+
+func formatUUID(b []byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
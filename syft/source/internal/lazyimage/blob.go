@@ -0,0 +1,74 @@
+package lazyimage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/anchore/syft/internal"
+)
+
+// errRangesUnsupported is returned when the registry (or the blob storage it redirects to) ignores ranged reads.
+var errRangesUnsupported = errors.New("ranged reads are not supported")
+
+// blob is a layer blob that supports random access.
+type blob interface {
+	io.ReaderAt
+	Size() int64
+}
+
+var _ blob = (*remoteBlob)(nil)
+
+// remoteBlob reads a layer blob from the registry with ranged reads.
+type remoteBlob struct {
+	ctx    context.Context
+	client *http.Client
+	url    string
+	size   int64
+}
+
+func (b *remoteBlob) Size() int64 {
+	return b.size
+}
+
+func (b *remoteBlob) ReadAt(p []byte, off int64) (int, error) {
+	if off >= b.size {
+		return 0, io.EOF
+	}
+	end := off + int64(len(p))
+	if end > b.size {
+		end = b.size
+	}
+
+	req, err := http.NewRequestWithContext(b.ctx, http.MethodGet, b.url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Range", fmt.Sprintf("bytes=%d-%d", off, end-1))
+
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("unable to fetch blob range: %w", err)
+	}
+	defer internal.CloseAndLogError(resp.Body, b.url)
+
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+	case http.StatusOK:
+		// the entire blob would be returned, which defeats the purpose of ranged reads
+		return 0, errRangesUnsupported
+	default:
+		return 0, fmt.Errorf("unable to fetch blob range: unexpected status %q", resp.Status)
+	}
+
+	n, err := io.ReadFull(resp.Body, p[:end-off])
+	if err != nil {
+		return n, fmt.Errorf("unable to read blob range: %w", err)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
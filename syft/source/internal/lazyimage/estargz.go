@@ -0,0 +1,345 @@
+package lazyimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// see https://github.com/containerd/stargz-snapshotter/blob/main/docs/estargz.md
+const (
+	stargzTOCName = "stargz.index.json"
+	// the largest possible footer (the legacy stargz footer is smaller)
+	stargzFooterSize  = 51
+	stargzFooterMagic = "STARGZ"
+	// the most compressed bytes of a chunk to fetch when only the leading bytes of a file are needed
+	maxCompressedHeadSize = 16 * 1024
+)
+
+var stargzMetadataPaths = map[string]bool{
+	"/" + stargzTOCName:      true,
+	"/.prefetch.landmark":    true,
+	"/.no.prefetch.landmark": true,
+}
+
+func isStargzMetadata(p string) bool {
+	return stargzMetadataPaths[p]
+}
+
+type stargzTOC struct {
+	Version int              `json:"version"`
+	Entries []stargzTOCEntry `json:"entries"`
+	offset  int64
+}
+
+type stargzTOCEntry struct {
+	Name        string `json:"name"`
+	Type        string `json:"type"`
+	Size        int64  `json:"size,omitempty"`
+	LinkName    string `json:"linkName,omitempty"`
+	Mode        int64  `json:"mode,omitempty"`
+	UID         int    `json:"uid,omitempty"`
+	GID         int    `json:"gid,omitempty"`
+	Offset      int64  `json:"offset,omitempty"`
+	ChunkOffset int64  `json:"chunkOffset,omitempty"`
+	ChunkSize   int64  `json:"chunkSize,omitempty"`
+	// Xattrs are the extended attributes of the entry (values are base64 encoded within the TOC)
+	Xattrs map[string][]byte `json:"xattrs,omitempty"`
+}
+
+// readStargzTOC reads the table of contents of an eStargz (or legacy stargz) layer blob.
+func readStargzTOC(b blob) (*stargzTOC, error) {
+	tocOffset, footerSize, err := readStargzFooter(b)
+	if err != nil {
+		return nil, err
+	}
+
+	compressed := make([]byte, b.Size()-footerSize-tocOffset)
+	if _, err := b.ReadAt(compressed, tocOffset); err != nil {
+		return nil, fmt.Errorf("unable to read stargz TOC: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress stargz TOC: %w", err)
+	}
+	tr := tar.NewReader(zr)
+	header, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read stargz TOC: %w", err)
+	}
+	if header.Name != stargzTOCName {
+		return nil, fmt.Errorf("unexpected stargz TOC entry=%q", header.Name)
+	}
+
+	toc := stargzTOC{offset: tocOffset}
+	if err := json.NewDecoder(tr).Decode(&toc); err != nil {
+		return nil, fmt.Errorf("unable to decode stargz TOC: %w", err)
+	}
+	return &toc, nil
+}
+
+// readStargzFooter returns the offset of the TOC and the size of the footer. The footer is an empty gzip stream with
+// the TOC offset within the gzip extra field, however, the size of the footer depends on how the (empty) deflate stream
+// was written, so the gzip header is searched for within the tail of the blob.
+func readStargzFooter(b blob) (int64, int64, error) {
+	tail := int64(stargzFooterSize)
+	if b.Size() < tail {
+		tail = b.Size()
+	}
+	footer := make([]byte, tail)
+	if _, err := b.ReadAt(footer, b.Size()-tail); err != nil {
+		return 0, 0, err
+	}
+
+	for i := 0; i+4 <= len(footer); i++ {
+		// the gzip magic, deflate, and the FEXTRA flag
+		if footer[i] != 0x1f || footer[i+1] != 0x8b || footer[i+2] != 8 || footer[i+3]&0x04 == 0 {
+			continue
+		}
+		zr, err := gzip.NewReader(bytes.NewReader(footer[i:]))
+		if err != nil {
+			continue
+		}
+		tocOffset, err := parseStargzFooterExtra(zr.Header.Extra)
+		if err != nil {
+			continue
+		}
+		footerSize := tail - int64(i)
+		if tocOffset <= 0 || tocOffset >= b.Size()-footerSize {
+			return 0, 0, fmt.Errorf("stargz TOC offset=%d is out of bounds", tocOffset)
+		}
+		return tocOffset, footerSize, nil
+	}
+	return 0, 0, fmt.Errorf("no stargz footer found")
+}
+
+func parseStargzFooterExtra(extra []byte) (int64, error) {
+	// eStargz stores a single "SG" subfield, whereas legacy stargz stores the payload as the entire extra field
+	if len(extra) >= 4 && string(extra[0:2]) == "SG" && int(binary.LittleEndian.Uint16(extra[2:4])) == len(extra)-4 {
+		extra = extra[4:]
+	}
+	if len(extra) != 16+len(stargzFooterMagic) || !strings.HasSuffix(string(extra), stargzFooterMagic) {
+		return 0, fmt.Errorf("invalid stargz footer magic")
+	}
+	return strconv.ParseInt(string(extra[:16]), 16, 64)
+}
+
+// stargzChunk is a gzip stream holding a portion of a file, starting at the given offset within the file.
+type stargzChunk struct {
+	offset      int64
+	end         int64
+	chunkOffset int64
+	chunkSize   int64
+}
+
+func indexStargzLayer(b blob, toc *stargzTOC, diffID string, s *squash) error {
+	ends := stargzChunkEnds(toc)
+
+	// chunks follow the regular file they belong to; hardlinks refer to files earlier within the same layer
+	var current *stargzContents
+	regularFiles := make(map[string]*File)
+	for _, entry := range toc.Entries {
+		if entry.Type == "chunk" {
+			if current != nil {
+				current.chunks = append(current.chunks, newStargzChunk(entry, ends))
+			}
+			continue
+		}
+		current = nil
+
+		header, err := entry.header()
+		if err != nil {
+			log.Debugf("skipping stargz TOC entry=%q: %+v", entry.Name, err)
+			continue
+		}
+		p := cleanPath(header.Name)
+		if isStargzMetadata(p) || s.whiteout(p) {
+			continue
+		}
+		visible := s.visible(p)
+
+		f := newFile(header, diffID)
+		f.Xattrs = entry.Xattrs
+		switch header.Typeflag {
+		case tar.TypeReg:
+			if entry.Size > 0 {
+				current = &stargzContents{blob: b, size: entry.Size}
+				current.chunks = append(current.chunks, newStargzChunk(entry, ends))
+				f.contents = current
+			}
+			// hidden files are kept aside, since they may still be the target of a visible hardlink
+			regularFiles[p] = f
+		case tar.TypeLink:
+			target, ok := regularFiles[cleanPath(entry.LinkName)]
+			if !ok {
+				log.Debugf("unable to find contents of hardlink=%q to target=%q", p, entry.LinkName)
+				break
+			}
+			f.Type = tar.TypeReg
+			f.Linkname = ""
+			f.Size = target.Size
+			f.contents = target.contents
+		}
+		if visible {
+			s.add(f)
+		}
+	}
+	return nil
+}
+
+func (e stargzTOCEntry) header() (*tar.Header, error) {
+	h := &tar.Header{
+		Name:     e.Name,
+		Size:     e.Size,
+		Linkname: e.LinkName,
+		Mode:     e.Mode,
+		Uid:      e.UID,
+		Gid:      e.GID,
+	}
+	switch e.Type {
+	case "dir":
+		h.Typeflag = tar.TypeDir
+	case "reg":
+		h.Typeflag = tar.TypeReg
+	case "symlink":
+		h.Typeflag = tar.TypeSymlink
+	case "hardlink":
+		h.Typeflag = tar.TypeLink
+	case "char":
+		h.Typeflag = tar.TypeChar
+	case "block":
+		h.Typeflag = tar.TypeBlock
+	case "fifo":
+		h.Typeflag = tar.TypeFifo
+	default:
+		return nil, fmt.Errorf("unknown entry type=%q", e.Type)
+	}
+	return h, nil
+}
+
+// stargzChunkEnds maps the offset of each gzip stream to the offset of the following stream (or the TOC).
+func stargzChunkEnds(toc *stargzTOC) map[int64]int64 {
+	var offsets []int64
+	for _, entry := range toc.Entries {
+		if entry.Offset > 0 {
+			offsets = append(offsets, entry.Offset)
+		}
+	}
+	sort.Slice(offsets, func(i, j int) bool { return offsets[i] < offsets[j] })
+
+	ends := make(map[int64]int64)
+	for i, offset := range offsets {
+		end := toc.offset
+		for _, next := range offsets[i+1:] {
+			if next > offset {
+				end = next
+				break
+			}
+		}
+		ends[offset] = end
+	}
+	return ends
+}
+
+func newStargzChunk(entry stargzTOCEntry, ends map[int64]int64) stargzChunk {
+	return stargzChunk{
+		offset:      entry.Offset,
+		end:         ends[entry.Offset],
+		chunkOffset: entry.ChunkOffset,
+		chunkSize:   entry.ChunkSize,
+	}
+}
+
+// stargzContents are file contents stored as one or more gzip streams within an eStargz layer blob.
+type stargzContents struct {
+	blob   blob
+	size   int64
+	chunks []stargzChunk
+}
+
+func (c *stargzContents) open() (io.ReadCloser, error) {
+	return &stargzReader{contents: c}, nil
+}
+
+func (c *stargzContents) head(n int) ([]byte, error) {
+	r, err := c.chunkReader(c.chunks[0], maxCompressedHeadSize)
+	if err != nil {
+		return nil, err
+	}
+	return readHead(r, n)
+}
+
+// chunkReader fetches the gzip stream of the given chunk (or only its leading compressed bytes when limited), returning
+// the uncompressed contents of the chunk.
+func (c *stargzContents) chunkReader(chunk stargzChunk, limit int64) (io.Reader, error) {
+	if chunk.end <= chunk.offset {
+		return nil, fmt.Errorf("invalid stargz chunk at offset=%d", chunk.offset)
+	}
+	length := chunk.end - chunk.offset
+	if limit > 0 && length > limit {
+		length = limit
+	}
+
+	compressed := make([]byte, length)
+	if _, err := c.blob.ReadAt(compressed, chunk.offset); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unable to fetch stargz chunk: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, fmt.Errorf("unable to decompress stargz chunk: %w", err)
+	}
+
+	size := chunk.chunkSize
+	if size == 0 {
+		// the last (or only) chunk spans the remainder of the file
+		size = c.size - chunk.chunkOffset
+	}
+	return io.LimitReader(zr, size), nil
+}
+
+// stargzReader reads each chunk of a file in turn, fetching a chunk only once the previous chunk has been read.
+type stargzReader struct {
+	contents *stargzContents
+	next     int
+	current  io.Reader
+}
+
+func (r *stargzReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if r.next >= len(r.contents.chunks) {
+				return 0, io.EOF
+			}
+			current, err := r.contents.chunkReader(r.contents.chunks[r.next], 0)
+			if err != nil {
+				return 0, err
+			}
+			r.current = current
+			r.next++
+		}
+
+		n, err := r.current.Read(p)
+		if errors.Is(err, io.EOF) {
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *stargzReader) Close() error {
+	return nil
+}
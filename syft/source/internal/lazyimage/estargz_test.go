@@ -0,0 +1,220 @@
+package lazyimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testEntry struct {
+	name     string
+	typ      string
+	linkname string
+	contents string
+}
+
+// countingWriter tracks the offset of each gzip stream written to the blob.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+// gzipStreams writes to the current gzip stream, allowing a new stream to be started between tar entries.
+type gzipStreams struct {
+	out *countingWriter
+	gz  *gzip.Writer
+}
+
+func (g *gzipStreams) Write(p []byte) (int, error) {
+	return g.gz.Write(p)
+}
+
+func (g *gzipStreams) next(t *testing.T) int64 {
+	require.NoError(t, g.gz.Close())
+	g.gz = gzip.NewWriter(g.out)
+	return g.out.n
+}
+
+// newStargzBlob writes the given entries as an eStargz layer, where each chunk of each regular file starts a new gzip
+// stream (as described by the TOC).
+func newStargzBlob(t *testing.T, entries []testEntry, chunkSize int, legacyFooter bool) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	out := &countingWriter{w: &buf}
+	streams := &gzipStreams{out: out, gz: gzip.NewWriter(out)}
+	tw := tar.NewWriter(streams)
+
+	var toc []stargzTOCEntry
+	for _, e := range entries {
+		header := &tar.Header{Name: e.name, Linkname: e.linkname, Mode: 0644, Size: int64(len(e.contents))}
+		switch e.typ {
+		case "dir":
+			header.Typeflag = tar.TypeDir
+		case "reg":
+			header.Typeflag = tar.TypeReg
+		case "symlink":
+			header.Typeflag = tar.TypeSymlink
+		case "hardlink":
+			header.Typeflag = tar.TypeLink
+		}
+		require.NoError(t, tw.WriteHeader(header))
+
+		entry := stargzTOCEntry{Name: e.name, Type: e.typ, LinkName: e.linkname, Mode: 0644, Size: header.Size}
+		if len(e.contents) == 0 {
+			toc = append(toc, entry)
+			continue
+		}
+		for offset := 0; offset < len(e.contents); offset += chunkSize {
+			end := offset + chunkSize
+			if end > len(e.contents) {
+				end = len(e.contents)
+			}
+			chunk := entry
+			if offset > 0 {
+				chunk = stargzTOCEntry{Name: e.name, Type: "chunk", ChunkOffset: int64(offset)}
+			}
+			chunk.Offset = streams.next(t)
+			if end < len(e.contents) {
+				chunk.ChunkSize = int64(end - offset)
+			}
+			toc = append(toc, chunk)
+
+			_, err := tw.Write([]byte(e.contents[offset:end]))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+
+	tocOffset := streams.next(t)
+	tocJSON, err := json.Marshal(stargzTOC{Version: 1, Entries: toc})
+	require.NoError(t, err)
+	tocWriter := tar.NewWriter(streams.gz)
+	require.NoError(t, tocWriter.WriteHeader(&tar.Header{Name: stargzTOCName, Typeflag: tar.TypeReg, Mode: 0644, Size: int64(len(tocJSON))}))
+	_, err = tocWriter.Write(tocJSON)
+	require.NoError(t, err)
+	require.NoError(t, tocWriter.Close())
+	require.NoError(t, streams.gz.Close())
+
+	footer, err := gzip.NewWriterLevel(out, gzip.NoCompression)
+	require.NoError(t, err)
+	payload := fmt.Sprintf("%016x%s", tocOffset, stargzFooterMagic)
+	if legacyFooter {
+		footer.Header.Extra = []byte(payload)
+	} else {
+		footer.Header.Extra = []byte{'S', 'G', 0, 0}
+		binary.LittleEndian.PutUint16(footer.Header.Extra[2:], uint16(len(payload)))
+		footer.Header.Extra = append(footer.Header.Extra, payload...)
+	}
+	require.NoError(t, footer.Close())
+	return buf.Bytes()
+}
+
+func readContents(t *testing.T, f *File) string {
+	t.Helper()
+	require.NotNil(t, f)
+	rc, err := f.Open()
+	require.NoError(t, err)
+	contents, err := io.ReadAll(rc)
+	require.NoError(t, err)
+	return string(contents)
+}
+
+func TestIndexStargzLayer(t *testing.T) {
+	large := strings.Repeat("0123456789abcdef", 256) + "remainder"
+	entries := []testEntry{
+		{name: "etc/", typ: "dir"},
+		{name: "etc/os-release", typ: "reg", contents: "ID=alpine\n"},
+		{name: "etc/empty", typ: "reg"},
+		{name: "etc/.wh.removed", typ: "reg"},
+		{name: "usr/bin/large", typ: "reg", contents: large},
+		{name: "usr/bin/large-link", typ: "hardlink", linkname: "usr/bin/large"},
+		{name: "bin", typ: "symlink", linkname: "usr/bin"},
+		{name: "lib/hidden", typ: "reg", contents: "hidden by an upper layer"},
+	}
+
+	for _, legacyFooter := range []bool{false, true} {
+		t.Run(fmt.Sprintf("legacy footer=%v", legacyFooter), func(t *testing.T) {
+			b := bytes.NewReader(newStargzBlob(t, entries, 1000, legacyFooter))
+
+			toc, err := readStargzTOC(b)
+			require.NoError(t, err)
+
+			s := newSquash()
+			s.add(&File{Path: "/lib", Type: tar.TypeSymlink, Linkname: "usr/lib"})
+			require.NoError(t, indexStargzLayer(b, toc, "sha256:layer", s))
+			s.endLayer()
+
+			assert.True(t, s.removed["/etc/removed"])
+			assert.NotContains(t, s.files, "/lib/hidden")
+			assert.Equal(t, byte(tar.TypeDir), s.files["/etc"].Type)
+			assert.Equal(t, "usr/bin", s.files["/bin"].Linkname)
+			assert.Equal(t, "sha256:layer", s.files["/etc/os-release"].Layer)
+
+			assert.Equal(t, "ID=alpine\n", readContents(t, s.files["/etc/os-release"]))
+			assert.Equal(t, "", readContents(t, s.files["/etc/empty"]))
+			assert.Equal(t, large, readContents(t, s.files["/usr/bin/large"]))
+			assert.Equal(t, large, readContents(t, s.files["/usr/bin/large-link"]))
+			assert.Equal(t, int64(len(large)), s.files["/usr/bin/large-link"].Size)
+			assert.NotEmpty(t, s.files["/usr/bin/large"].MIMEType())
+		})
+	}
+}
+
+func TestReadStargzTOC_NotStargz(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write(bytes.Repeat([]byte("not a stargz layer"), 100))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	_, err = readStargzTOC(bytes.NewReader(buf.Bytes()))
+	assert.Error(t, err)
+}
+
+func TestSquash_Visible(t *testing.T) {
+	s := newSquash()
+	s.add(&File{Path: "/lib", Type: tar.TypeSymlink})
+	s.add(&File{Path: "/etc", Type: tar.TypeDir})
+	s.add(&File{Path: "/etc/os-release", Type: tar.TypeReg})
+	assert.True(t, s.whiteout("/etc/.wh.removed"))
+	assert.True(t, s.whiteout("/opt/.wh..wh..opq"))
+	assert.False(t, s.whiteout("/etc/passwd"))
+
+	// whiteouts only apply to lower layers
+	assert.True(t, s.visible("/etc/removed"))
+	s.endLayer()
+
+	tests := []struct {
+		path     string
+		expected bool
+	}{
+		{path: "/", expected: false},
+		{path: "/etc/os-release", expected: false},
+		{path: "/etc/passwd", expected: true},
+		{path: "/etc/removed", expected: false},
+		{path: "/etc/removed/nested", expected: false},
+		{path: "/lib/libc.so", expected: false},
+		{path: "/opt", expected: true},
+		{path: "/opt/app", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.expected, s.visible(test.path))
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package lazyimage
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/anchore/stereoscope/pkg/file"
+	"github.com/anchore/syft/internal"
+)
+
+// paxXattrPrefix is the prefix of the PAX records holding the extended attributes of a tar entry.
+const paxXattrPrefix = "SCHILY.xattr."
+
+// mimeTypeHeadSize is the number of leading bytes of file contents considered when detecting the MIME type.
+const mimeTypeHeadSize = 3072
+
+// File is a single entry within the squashed filesystem of an image. Hardlinks are represented as regular files
+// that share the contents of the link target (when the target can be found within the same layer).
+type File struct {
+	Path     string
+	Type     byte
+	Linkname string
+	Mode     os.FileMode
+	UID      int
+	GID      int
+	Size     int64
+	// Layer is the diff ID of the layer the file was found in.
+	Layer string
+	// Xattrs are the extended attributes of the file.
+	Xattrs map[string][]byte
+
+	contents contents
+	mimeOnce sync.Once
+	mimeType string
+}
+
+// contents provides access to the contents of a regular file, wherever they are stored.
+type contents interface {
+	open() (io.ReadCloser, error)
+	head(n int) ([]byte, error)
+}
+
+func newFile(header *tar.Header, layer string) *File {
+	typeflag := header.Typeflag
+	if typeflag == tar.TypeRegA {
+		typeflag = tar.TypeReg
+	}
+	return &File{
+		Path:     cleanPath(header.Name),
+		Type:     typeflag,
+		Linkname: header.Linkname,
+		Mode:     header.FileInfo().Mode(),
+		UID:      header.Uid,
+		GID:      header.Gid,
+		Size:     header.Size,
+		Layer:    layer,
+		Xattrs:   xattrsFromPAXRecords(header.PAXRecords),
+	}
+}
+
+// xattrsFromPAXRecords returns the extended attributes recorded within the PAX records of a tar entry.
+func xattrsFromPAXRecords(records map[string]string) map[string][]byte {
+	var xattrs map[string][]byte
+	for key, value := range records {
+		if !strings.HasPrefix(key, paxXattrPrefix) {
+			continue
+		}
+		if xattrs == nil {
+			xattrs = make(map[string][]byte)
+		}
+		xattrs[strings.TrimPrefix(key, paxXattrPrefix)] = []byte(value)
+	}
+	return xattrs
+}
+
+// Open returns the contents of a regular file (fetching them from the registry if needed).
+func (f *File) Open() (io.ReadCloser, error) {
+	if !f.isRegular() {
+		return nil, fmt.Errorf("not a regular file: %s", f.Path)
+	}
+	if f.contents == nil {
+		if f.Size > 0 {
+			return nil, fmt.Errorf("contents are unavailable for file: %s", f.Path)
+		}
+		return io.NopCloser(bytes.NewReader(nil)), nil
+	}
+	return f.contents.open()
+}
+
+// MIMEType returns the MIME type of a regular file, as detected from the leading bytes of the file contents.
+func (f *File) MIMEType() string {
+	f.mimeOnce.Do(func() {
+		if !f.isRegular() || f.contents == nil {
+			return
+		}
+		head, err := f.contents.head(mimeTypeHeadSize)
+		if err != nil {
+			return
+		}
+		f.mimeType = file.MIMEType(bytes.NewReader(head))
+	})
+	return f.mimeType
+}
+
+func (f *File) isRegular() bool {
+	return f.Type == tar.TypeReg
+}
+
+// spooledContents are file contents that have been written to a local file.
+type spooledContents struct {
+	path string
+}
+
+func (c spooledContents) open() (io.ReadCloser, error) {
+	return os.Open(c.path)
+}
+
+func (c spooledContents) head(n int) ([]byte, error) {
+	f, err := os.Open(c.path)
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(f, c.path)
+	return readHead(f, n)
+}
+
+func readHead(r io.Reader, n int) ([]byte, error) {
+	buf := make([]byte, n)
+	read, err := io.ReadFull(r, buf)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, err
+	}
+	return buf[:read], nil
+}
+
+func cleanPath(p string) string {
+	return path.Clean("/" + p)
+}
@@ -0,0 +1,30 @@
+package lazyimage
+
+import (
+	"archive/tar"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_newFile(t *testing.T) {
+	f := newFile(&tar.Header{
+		Name:     "usr/bin/ping",
+		Typeflag: tar.TypeReg,
+		Mode:     04755,
+		Size:     3,
+		PAXRecords: map[string]string{
+			"SCHILY.xattr.security.capability": "\x01\x00\x00\x02\x00\x20\x00\x00",
+			"SCHILY.xattr.user.comment":        "hello",
+			"mtime":                            "1666000000",
+		},
+	}, "sha256:abc")
+
+	assert.Equal(t, "/usr/bin/ping", f.Path)
+	assert.Equal(t, os.FileMode(0755)|os.ModeSetuid, f.Mode)
+	assert.Equal(t, map[string][]byte{
+		"security.capability": []byte("\x01\x00\x00\x02\x00\x20\x00\x00"),
+		"user.comment":        []byte("hello"),
+	}, f.Xattrs)
+}
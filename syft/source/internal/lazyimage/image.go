@@ -0,0 +1,198 @@
+/*
+Package lazyimage provides access to the squashed filesystem of a container image within an OCI registry without
+pulling (and unpacking) the entire image. Layers are indexed from the top of the image down so that only files that are
+visible in the squashed filesystem are kept. Layers in the eStargz format are indexed from their table of contents, and
+file contents are fetched with ranged reads only when requested. All other layers are streamed once, spooling only the
+contents of visible regular files to a temporary directory.
+*/
+package lazyimage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// Options configures how the registry is accessed.
+type Options struct {
+	// Keychain provides credentials for the registry (the default docker keychain is used when nil).
+	Keychain authn.Keychain
+	// Transport is the base transport for all registry requests (http.DefaultTransport is used when nil).
+	Transport http.RoundTripper
+	// Platform selects the image from a multi-platform index (linux/amd64 is used when nil).
+	Platform *v1.Platform
+	// InsecureUseHTTP uses http instead of https when connecting to the registry.
+	InsecureUseHTTP bool
+}
+
+// Image is a container image within a registry, of which only the manifest and config have been fetched.
+type Image struct {
+	Reference name.Reference
+	Remote    v1.Image
+	ctx       context.Context
+	client    *http.Client
+	tempDir   string
+}
+
+// Fetch resolves the given image reference and fetches the image manifest and config (but no layers).
+func Fetch(ctx context.Context, reference string, opts Options) (*Image, error) {
+	var nameOpts []name.Option
+	if opts.InsecureUseHTTP {
+		nameOpts = append(nameOpts, name.Insecure)
+	}
+	ref, err := name.ParseReference(reference, nameOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse image reference=%q: %w", reference, err)
+	}
+
+	keychain := opts.Keychain
+	if keychain == nil {
+		keychain = authn.DefaultKeychain
+	}
+	base := opts.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	remoteOpts := []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(keychain),
+		remote.WithTransport(base),
+	}
+	if opts.Platform != nil {
+		remoteOpts = append(remoteOpts, remote.WithPlatform(*opts.Platform))
+	}
+
+	img, err := remote.Image(ref, remoteOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch image manifest for reference=%q: %w", reference, err)
+	}
+
+	// ranged reads of layer blobs are made outside of the remote package, so an authenticated transport is needed
+	repo := ref.Context()
+	auth, err := keychain.Resolve(repo)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve credentials for registry=%q: %w", repo.RegistryStr(), err)
+	}
+	rt, err := transport.NewWithContext(ctx, repo.Registry, auth, base, []string{repo.Scope(transport.PullScope)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create registry transport: %w", err)
+	}
+
+	return &Image{
+		Reference: ref,
+		Remote:    img,
+		ctx:       ctx,
+		client:    &http.Client{Transport: rt},
+	}, nil
+}
+
+// Squash indexes all layers of the image, returning the files within the squashed filesystem sorted by path.
+func (i *Image) Squash() ([]*File, error) {
+	layers, err := i.Remote.Layers()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get image layers: %w", err)
+	}
+
+	if i.tempDir == "" {
+		i.tempDir, err = os.MkdirTemp("", "syft-lazy-image-")
+		if err != nil {
+			return nil, fmt.Errorf("unable to create tempdir for image contents: %w", err)
+		}
+	}
+
+	s := newSquash()
+	for idx := len(layers) - 1; idx >= 0; idx-- {
+		if err := i.indexLayer(layers[idx], s); err != nil {
+			return nil, fmt.Errorf("unable to index layer=%d: %w", idx, err)
+		}
+		s.endLayer()
+	}
+
+	files := make([]*File, 0, len(s.files))
+	for _, f := range s.files {
+		files = append(files, f)
+	}
+	sort.Slice(files, func(a, b int) bool {
+		return files[a].Path < files[b].Path
+	})
+	return files, nil
+}
+
+// Cleanup removes any spooled file contents.
+func (i *Image) Cleanup() error {
+	if i.tempDir == "" {
+		return nil
+	}
+	return os.RemoveAll(i.tempDir)
+}
+
+func (i *Image) indexLayer(layer v1.Layer, s *squash) error {
+	diffID, err := layer.DiffID()
+	if err != nil {
+		return fmt.Errorf("unable to get layer diff ID: %w", err)
+	}
+
+	if isGzipLayer(layer) {
+		blob, err := i.blob(layer)
+		if err != nil {
+			return err
+		}
+		toc, err := readStargzTOC(blob)
+		switch {
+		case err == nil:
+			log.Debugf("indexing eStargz layer=%q from table of contents", diffID)
+			return indexStargzLayer(blob, toc, diffID.String(), s)
+		case errors.Is(err, errRangesUnsupported):
+			log.Debugf("registry does not support ranged reads, streaming layer=%q", diffID)
+		default:
+			log.Tracef("layer=%q is not an eStargz layer: %+v", diffID, err)
+		}
+	}
+
+	log.Debugf("indexing layer=%q by streaming", diffID)
+	return i.indexTarLayer(layer, diffID.String(), s)
+}
+
+func (i *Image) blob(layer v1.Layer) (*remoteBlob, error) {
+	digest, err := layer.Digest()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get layer digest: %w", err)
+	}
+	size, err := layer.Size()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get layer size: %w", err)
+	}
+
+	repo := i.Reference.Context()
+	return &remoteBlob{
+		ctx:    i.ctx,
+		client: i.client,
+		url:    fmt.Sprintf("%s://%s/v2/%s/blobs/%s", repo.Registry.Scheme(), repo.RegistryStr(), repo.RepositoryStr(), digest),
+		size:   size,
+	}, nil
+}
+
+func isGzipLayer(layer v1.Layer) bool {
+	mediaType, err := layer.MediaType()
+	if err != nil {
+		return false
+	}
+	switch mediaType {
+	case types.DockerLayer, types.OCILayer:
+		return true
+	}
+	return false
+}
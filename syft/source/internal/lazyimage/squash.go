@@ -0,0 +1,165 @@
+package lazyimage
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	opaqueWhiteout = ".wh..wh..opq"
+)
+
+// squash tracks the files visible in the squashed filesystem while layers are indexed from the top layer down. Since
+// upper layers are indexed first, a file is only added if no upper layer has already provided, removed, or hidden it.
+type squash struct {
+	files map[string]*File
+	// removed are paths (and everything beneath them) that have been whited out by an upper layer
+	removed map[string]bool
+	// opaque are directories whose contents from lower layers have been hidden by an upper layer
+	opaque map[string]bool
+	// whiteouts only apply to lower layers, so they are kept aside until the current layer has been indexed
+	pendingRemoved []string
+	pendingOpaque  []string
+}
+
+func newSquash() *squash {
+	return &squash{
+		files:   make(map[string]*File),
+		removed: make(map[string]bool),
+		opaque:  make(map[string]bool),
+	}
+}
+
+// whiteout records the given path if it is a whiteout entry, indicating if it was.
+func (s *squash) whiteout(p string) bool {
+	base := path.Base(p)
+	if !strings.HasPrefix(base, whiteoutPrefix) {
+		return false
+	}
+
+	dir := path.Dir(p)
+	if base == opaqueWhiteout {
+		s.pendingOpaque = append(s.pendingOpaque, dir)
+	} else {
+		s.pendingRemoved = append(s.pendingRemoved, path.Join(dir, strings.TrimPrefix(base, whiteoutPrefix)))
+	}
+	return true
+}
+
+// visible indicates if the given path from the layer being indexed would be visible in the squashed filesystem.
+func (s *squash) visible(p string) bool {
+	if p == "/" {
+		return false
+	}
+	if _, exists := s.files[p]; exists {
+		return false
+	}
+
+	for dir := p; ; dir = path.Dir(dir) {
+		if s.removed[dir] {
+			return false
+		}
+		if dir != p {
+			if s.opaque[dir] {
+				return false
+			}
+			// an upper layer may have replaced a directory with a file or a symlink
+			if f, exists := s.files[dir]; exists && f.Type != tar.TypeDir {
+				return false
+			}
+		}
+		if dir == "/" {
+			return true
+		}
+	}
+}
+
+func (s *squash) add(f *File) {
+	s.files[f.Path] = f
+}
+
+func (s *squash) endLayer() {
+	for _, p := range s.pendingRemoved {
+		s.removed[p] = true
+	}
+	for _, p := range s.pendingOpaque {
+		s.opaque[p] = true
+	}
+	s.pendingRemoved = nil
+	s.pendingOpaque = nil
+}
+
+// indexTarLayer streams the uncompressed layer once, spooling the contents of all visible regular files.
+func (i *Image) indexTarLayer(layer v1.Layer, diffID string, s *squash) error {
+	rc, err := layer.Uncompressed()
+	if err != nil {
+		return fmt.Errorf("unable to fetch layer: %w", err)
+	}
+	defer internal.CloseAndLogError(rc, diffID)
+
+	// hardlinks refer to files earlier within the same layer
+	regularFiles := make(map[string]*File)
+
+	tr := tar.NewReader(rc)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read layer entry: %w", err)
+		}
+
+		p := cleanPath(header.Name)
+		if isStargzMetadata(p) || s.whiteout(p) || !s.visible(p) {
+			continue
+		}
+
+		f := newFile(header, diffID)
+		switch header.Typeflag {
+		case tar.TypeReg, tar.TypeRegA:
+			spooled, err := i.spool(tr)
+			if err != nil {
+				return fmt.Errorf("unable to spool contents of path=%q: %w", p, err)
+			}
+			f.contents = spooled
+			regularFiles[p] = f
+		case tar.TypeLink:
+			target, ok := regularFiles[cleanPath(header.Linkname)]
+			if !ok {
+				// the target is either not a regular file or has been hidden by an upper layer (and not spooled)
+				log.Debugf("unable to find contents of hardlink=%q to target=%q", p, header.Linkname)
+				break
+			}
+			f.Type = tar.TypeReg
+			f.Linkname = ""
+			f.Size = target.Size
+			f.contents = target.contents
+		}
+		s.add(f)
+	}
+}
+
+func (i *Image) spool(r io.Reader) (contents, error) {
+	fh, err := os.CreateTemp(i.tempDir, "file-")
+	if err != nil {
+		return nil, err
+	}
+	defer internal.CloseAndLogError(fh, fh.Name())
+
+	if _, err := io.Copy(fh, r); err != nil {
+		return nil, err
+	}
+	return spooledContents{path: fh.Name()}, nil
+}
@@ -0,0 +1,71 @@
+package sevenzip
+
+import (
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ulikunitz/xz/lzma"
+)
+
+// the IDs of the supported coders
+var (
+	methodCopy    = []byte{0x00}
+	methodLZMA    = []byte{0x03, 0x01, 0x01}
+	methodLZMA2   = []byte{0x21}
+	methodDeflate = []byte{0x04, 0x01, 0x08}
+	methodBZip2   = []byte{0x04, 0x02, 0x02}
+)
+
+// newDecoder returns a reader of the (given number of) uncompressed bytes of the given compressed stream.
+func newDecoder(c coder, r io.Reader, size int64) (io.Reader, error) {
+	switch {
+	case bytes.Equal(c.id, methodCopy):
+		return r, nil
+
+	case bytes.Equal(c.id, methodLZMA):
+		// the properties are the LZMA properties and dictionary size, which (along with the uncompressed size) make up
+		// the header of the classic LZMA format
+		if len(c.properties) != 5 {
+			return nil, fmt.Errorf("invalid LZMA properties")
+		}
+		header := make([]byte, lzma.HeaderLen)
+		header[0] = c.properties[0]
+		binary.LittleEndian.PutUint32(header[1:5], uint32(dictCap(int64(binary.LittleEndian.Uint32(c.properties[1:5])), size)))
+		binary.LittleEndian.PutUint64(header[5:], uint64(size))
+		return lzma.NewReader(io.MultiReader(bytes.NewReader(header), r))
+
+	case bytes.Equal(c.id, methodLZMA2):
+		if len(c.properties) != 1 {
+			return nil, fmt.Errorf("invalid LZMA2 properties")
+		}
+		declared, err := lzma.DecodeDictCap(c.properties[0])
+		if err != nil {
+			return nil, err
+		}
+		return lzma.Reader2Config{DictCap: dictCap(declared, size)}.NewReader2(r)
+
+	case bytes.Equal(c.id, methodDeflate):
+		return flate.NewReader(r), nil
+
+	case bytes.Equal(c.id, methodBZip2):
+		return bzip2.NewReader(r), nil
+	}
+	return nil, fmt.Errorf("%w: method=%x", ErrUnsupportedMethod, c.id)
+}
+
+// dictCap returns the dictionary capacity needed to decompress the given number of bytes. The dictionary never needs
+// to be larger than the uncompressed contents, which avoids allocating the (up to 4 GiB) dictionary that the
+// archive declares for small contents.
+func dictCap(declared, size int64) int {
+	if size < declared {
+		declared = size
+	}
+	if declared < lzma.MinDictCap {
+		declared = lzma.MinDictCap
+	}
+	return int(declared)
+}
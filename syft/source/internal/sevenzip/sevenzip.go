@@ -0,0 +1,887 @@
+/*
+Package sevenzip provides read-only, sequential access to the entries of 7z archives. Only contents compressed with a
+single Copy, LZMA, LZMA2, Deflate, or BZip2 coder are supported: filters (e.g. BCJ), multi-coder methods (e.g. BCJ2),
+and encryption are not supported, and multi-volume archives are not supported.
+*/
+package sevenzip
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+	"unicode/utf16"
+)
+
+// Magic is found at the start of every 7z archive.
+const Magic = "7z\xbc\xaf\x27\x1c"
+
+const (
+	signatureHeaderSize = 32
+
+	// maxHeaderSize bounds the size of the (possibly compressed) header, which is held in memory
+	maxHeaderSize = 64 << 20
+
+	maxInt64 = 1<<63 - 1
+)
+
+// property IDs of the header
+const (
+	idEnd                   = 0x00
+	idHeader                = 0x01
+	idArchiveProperties     = 0x02
+	idAdditionalStreamsInfo = 0x03
+	idMainStreamsInfo       = 0x04
+	idFilesInfo             = 0x05
+	idPackInfo              = 0x06
+	idUnpackInfo            = 0x07
+	idSubStreamsInfo        = 0x08
+	idSize                  = 0x09
+	idCRC                   = 0x0A
+	idFolder                = 0x0B
+	idCodersUnpackSize      = 0x0C
+	idNumUnpackStream       = 0x0D
+	idEmptyStream           = 0x0E
+	idEmptyFile             = 0x0F
+	idName                  = 0x11
+	idWinAttributes         = 0x15
+	idEncodedHeader         = 0x17
+)
+
+const (
+	attributeDirectory     = 0x10
+	attributeUnixExtension = 0x8000
+)
+
+// ErrUnsupportedMethod is returned when reading contents that are compressed (or encrypted) with an unsupported method.
+var ErrUnsupportedMethod = errors.New("unsupported 7z compression method")
+
+// IsSevenZip indicates if the given reader is a 7z archive.
+func IsSevenZip(r io.ReaderAt) bool {
+	header := make([]byte, len(Magic))
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return false
+	}
+	return string(header) == Magic
+}
+
+// Entry describes a file, directory, or symlink within the archive. The contents of a symlink are its destination.
+type Entry struct {
+	Name string // slash separated path of the entry within the archive
+	Mode fs.FileMode
+	Size int64 // the size of the (uncompressed) contents
+}
+
+type entry struct {
+	Entry
+	hasStream bool
+}
+
+type coder struct {
+	id         []byte
+	properties []byte
+}
+
+// folder is a unit of compressed data, holding the contents of one or more entries (several for "solid" archives).
+type folder struct {
+	coders      []coder
+	packStreams int
+	unpackSize  int64
+	crcDefined  bool
+	// the location of the (first) packed stream of the folder within the archive
+	packOffset int64
+	packSize   int64
+}
+
+type streamsInfo struct {
+	packPos   int64
+	packSizes []int64
+	folders   []*folder
+	// the sizes of the entry contents held by each folder
+	subStreamSizes [][]int64
+}
+
+// Archive is an open 7z archive.
+type Archive struct {
+	r       io.ReaderAt
+	streams *streamsInfo
+	entries []entry
+}
+
+// Open reads the header of the 7z archive.
+func Open(r io.ReaderAt) (*Archive, error) {
+	signature := make([]byte, signatureHeaderSize)
+	if _, err := r.ReadAt(signature, 0); err != nil {
+		return nil, fmt.Errorf("unable to read 7z signature header: %w", err)
+	}
+	if string(signature[:len(Magic)]) != Magic {
+		return nil, fmt.Errorf("not a 7z archive")
+	}
+
+	le := binary.LittleEndian
+	nextHeaderOffset := le.Uint64(signature[12:20])
+	nextHeaderSize := le.Uint64(signature[20:28])
+
+	a := &Archive{r: r, streams: &streamsInfo{}}
+	if nextHeaderSize == 0 {
+		// an empty archive
+		return a, nil
+	}
+	if nextHeaderSize > maxHeaderSize || nextHeaderOffset > maxInt64-signatureHeaderSize {
+		return nil, fmt.Errorf("7z header is too large")
+	}
+
+	header := make([]byte, nextHeaderSize)
+	if _, err := r.ReadAt(header, signatureHeaderSize+int64(nextHeaderOffset)); err != nil {
+		return nil, fmt.Errorf("unable to read 7z header: %w", err)
+	}
+
+	hr := bytes.NewReader(header)
+	id, err := hr.ReadByte()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read 7z header: %w", err)
+	}
+	if id == idEncodedHeader {
+		// the header is compressed (and described by streams info of its own). Like 7-Zip, only a single level of
+		// encoding is followed, since an encoded header may otherwise decode to itself indefinitely.
+		if header, err = a.decodeHeader(hr); err != nil {
+			return nil, fmt.Errorf("unable to decode 7z header: %w", err)
+		}
+		hr = bytes.NewReader(header)
+		if id, err = hr.ReadByte(); err != nil {
+			return nil, fmt.Errorf("unable to read 7z header: %w", err)
+		}
+		if id == idEncodedHeader {
+			return nil, fmt.Errorf("unsupported 7z header: the encoded header is encoded again")
+		}
+	}
+	if id != idHeader {
+		return nil, fmt.Errorf("unexpected 7z header property=0x%02x", id)
+	}
+
+	if err := a.readHeader(hr); err != nil {
+		return nil, fmt.Errorf("unable to read 7z header: %w", err)
+	}
+	return a, nil
+}
+
+func (a *Archive) decodeHeader(r *bytes.Reader) ([]byte, error) {
+	streams, err := readStreamsInfo(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(streams.folders) == 0 {
+		return nil, fmt.Errorf("no streams")
+	}
+	f := streams.folders[0]
+	if f.unpackSize > maxHeaderSize {
+		return nil, fmt.Errorf("7z header is too large")
+	}
+	header := make([]byte, f.unpackSize)
+	if _, err := io.ReadFull(a.folderReader(f), header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+func (a *Archive) readHeader(r *bytes.Reader) error {
+	for {
+		id, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch id {
+		case idEnd:
+			return nil
+		case idArchiveProperties:
+			if err := skipProperties(r); err != nil {
+				return err
+			}
+		case idAdditionalStreamsInfo:
+			// additional streams are only used by (rarely used) external properties, which are not supported
+			if _, err := readStreamsInfo(r); err != nil {
+				return err
+			}
+		case idMainStreamsInfo:
+			if a.streams, err = readStreamsInfo(r); err != nil {
+				return err
+			}
+		case idFilesInfo:
+			if a.entries, err = readFilesInfo(r); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected header property=0x%02x", id)
+		}
+	}
+}
+
+// Walk calls the given function for every entry of the archive (in archive order) with a reader of the contents of the
+// entry, which is only valid until the function returns. Reading contents compressed with an unsupported method
+// returns ErrUnsupportedMethod (as does reading the contents of all other entries held by the same folder).
+func (a *Archive) Walk(fn func(entry Entry, contents io.Reader) error) error {
+	folderIndex, subStreamIndex := 0, 0
+	var contents io.Reader
+	for _, e := range a.entries {
+		if !e.hasStream {
+			if err := fn(e.Entry, bytes.NewReader(nil)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// move on to the next folder holding contents (folders may be empty)
+		for folderIndex < len(a.streams.folders) && subStreamIndex >= len(a.streams.subStreamSizes[folderIndex]) {
+			folderIndex++
+			subStreamIndex = 0
+			contents = nil
+		}
+		if folderIndex >= len(a.streams.folders) {
+			return fmt.Errorf("7z archive holds fewer streams than entries")
+		}
+		if contents == nil {
+			contents = a.folderReader(a.streams.folders[folderIndex])
+		}
+
+		e.Size = a.streams.subStreamSizes[folderIndex][subStreamIndex]
+		subStreamIndex++
+		stream := io.LimitReader(contents, e.Size)
+		if err := fn(e.Entry, stream); err != nil {
+			return err
+		}
+
+		// skip over any contents that were not read, so the contents of the next entry of the folder are in place
+		if _, err := io.Copy(io.Discard, stream); err != nil {
+			contents = errReader{err: err}
+		}
+	}
+	return nil
+}
+
+// folderReader returns a reader of the uncompressed contents of the given folder.
+func (a *Archive) folderReader(f *folder) io.Reader {
+	if len(f.coders) != 1 || f.packStreams != 1 {
+		return errReader{err: ErrUnsupportedMethod}
+	}
+	packed := io.NewSectionReader(a.r, f.packOffset, f.packSize)
+	r, err := newDecoder(f.coders[0], bufio.NewReader(packed), f.unpackSize)
+	if err != nil {
+		return errReader{err: err}
+	}
+	return io.LimitReader(r, f.unpackSize)
+}
+
+type errReader struct {
+	err error
+}
+
+func (r errReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func readStreamsInfo(r *bytes.Reader) (*streamsInfo, error) {
+	s := &streamsInfo{}
+	for {
+		id, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		switch id {
+		case idEnd:
+			return s, s.locateFolders()
+		case idPackInfo:
+			if err := s.readPackInfo(r); err != nil {
+				return nil, err
+			}
+		case idUnpackInfo:
+			if err := s.readUnpackInfo(r); err != nil {
+				return nil, err
+			}
+		case idSubStreamsInfo:
+			if err := s.readSubStreamsInfo(r); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected streams info property=0x%02x", id)
+		}
+	}
+}
+
+// locateFolders determines where the packed streams of each folder are, and the size of the entry contents held by
+// each folder (when not described by substreams info, each folder holds the contents of a single entry).
+func (s *streamsInfo) locateFolders() error {
+	offset := signatureHeaderSize + s.packPos
+	packIndex := 0
+	for _, f := range s.folders {
+		if packIndex >= len(s.packSizes) {
+			return fmt.Errorf("folder refers to a missing packed stream")
+		}
+		f.packOffset = offset
+		f.packSize = s.packSizes[packIndex]
+		for i := 0; i < f.packStreams && packIndex < len(s.packSizes); i++ {
+			offset += s.packSizes[packIndex]
+			packIndex++
+		}
+	}
+
+	if s.subStreamSizes == nil {
+		for _, f := range s.folders {
+			s.subStreamSizes = append(s.subStreamSizes, []int64{f.unpackSize})
+		}
+	}
+	return nil
+}
+
+func (s *streamsInfo) readPackInfo(r *bytes.Reader) error {
+	packPos, err := readSize(r)
+	if err != nil {
+		return err
+	}
+	s.packPos = packPos
+
+	count, err := readCount(r)
+	if err != nil {
+		return err
+	}
+
+	for {
+		id, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch id {
+		case idEnd:
+			if len(s.packSizes) != count {
+				return fmt.Errorf("missing packed stream sizes")
+			}
+			return nil
+		case idSize:
+			s.packSizes = make([]int64, count)
+			for i := range s.packSizes {
+				if s.packSizes[i], err = readSize(r); err != nil {
+					return err
+				}
+			}
+		case idCRC:
+			if _, err := readDigests(r, count); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected pack info property=0x%02x", id)
+		}
+	}
+}
+
+func (s *streamsInfo) readUnpackInfo(r *bytes.Reader) error {
+	if err := expect(r, idFolder); err != nil {
+		return err
+	}
+	count, err := readCount(r)
+	if err != nil {
+		return err
+	}
+	if external, err := r.ReadByte(); err != nil {
+		return err
+	} else if external != 0 {
+		return fmt.Errorf("external folders are not supported")
+	}
+
+	outStreams := make([]int, count)
+	unbound := make([]int, count)
+	s.folders = make([]*folder, count)
+	for i := range s.folders {
+		if s.folders[i], outStreams[i], unbound[i], err = readFolder(r); err != nil {
+			return err
+		}
+	}
+
+	if err := expect(r, idCodersUnpackSize); err != nil {
+		return err
+	}
+	for i, f := range s.folders {
+		for j := 0; j < outStreams[i]; j++ {
+			size, err := readSize(r)
+			if err != nil {
+				return err
+			}
+			// the contents of the folder are the output of the coder that is not bound to the input of another coder
+			if j == unbound[i] {
+				f.unpackSize = size
+			}
+		}
+	}
+
+	for {
+		id, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		switch id {
+		case idEnd:
+			return nil
+		case idCRC:
+			defined, err := readDigests(r, count)
+			if err != nil {
+				return err
+			}
+			for i, f := range s.folders {
+				f.crcDefined = defined[i]
+			}
+		default:
+			return fmt.Errorf("unexpected unpack info property=0x%02x", id)
+		}
+	}
+}
+
+// readFolder reads the coders of a folder, returning the number of coder outputs and which output is the (unbound)
+// output of the folder.
+func readFolder(r *bytes.Reader) (*folder, int, int, error) {
+	count, err := readCount(r)
+	if err != nil {
+		return nil, 0, 0, err
+	}
+
+	f := &folder{}
+	inStreams, outStreams := 0, 0
+	for i := 0; i < count; i++ {
+		flags, err := r.ReadByte()
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		if flags&0x80 != 0 {
+			return nil, 0, 0, fmt.Errorf("alternative coder methods are not supported")
+		}
+
+		c := coder{id: make([]byte, flags&0x0F)}
+		if _, err := io.ReadFull(r, c.id); err != nil {
+			return nil, 0, 0, err
+		}
+
+		in, out := 1, 1
+		if flags&0x10 != 0 {
+			if in, err = readCount(r); err != nil {
+				return nil, 0, 0, err
+			}
+			if out, err = readCount(r); err != nil {
+				return nil, 0, 0, err
+			}
+		}
+		inStreams += in
+		outStreams += out
+
+		if flags&0x20 != 0 {
+			size, err := readCount(r)
+			if err != nil {
+				return nil, 0, 0, err
+			}
+			c.properties = make([]byte, size)
+			if _, err := io.ReadFull(r, c.properties); err != nil {
+				return nil, 0, 0, err
+			}
+		}
+		f.coders = append(f.coders, c)
+	}
+
+	if outStreams == 0 {
+		return nil, 0, 0, fmt.Errorf("folder without coder outputs")
+	}
+
+	bindPairs := outStreams - 1
+	bound := make(map[int]bool)
+	for i := 0; i < bindPairs; i++ {
+		if _, err := readCount(r); err != nil {
+			return nil, 0, 0, err
+		}
+		out, err := readCount(r)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		bound[out] = true
+	}
+
+	f.packStreams = inStreams - bindPairs
+	if f.packStreams < 1 {
+		return nil, 0, 0, fmt.Errorf("folder without packed streams")
+	}
+	if f.packStreams > 1 {
+		for i := 0; i < f.packStreams; i++ {
+			if _, err := readCount(r); err != nil {
+				return nil, 0, 0, err
+			}
+		}
+	}
+
+	unbound := 0
+	for bound[unbound] {
+		unbound++
+	}
+	return f, outStreams, unbound, nil
+}
+
+func (s *streamsInfo) readSubStreamsInfo(r *bytes.Reader) error {
+	counts := make([]int, len(s.folders))
+	for i := range counts {
+		counts[i] = 1
+	}
+
+	id, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if id == idNumUnpackStream {
+		for i := range counts {
+			if counts[i], err = readCount(r); err != nil {
+				return err
+			}
+		}
+		if id, err = r.ReadByte(); err != nil {
+			return err
+		}
+	}
+
+	s.subStreamSizes = make([][]int64, len(s.folders))
+	for i, f := range s.folders {
+		if counts[i] == 0 {
+			continue
+		}
+		var sum int64
+		// the size of the last stream is the remainder of the folder
+		if id == idSize {
+			for j := 0; j < counts[i]-1; j++ {
+				size, err := readSize(r)
+				if err != nil {
+					return err
+				}
+				s.subStreamSizes[i] = append(s.subStreamSizes[i], size)
+				sum += size
+			}
+		} else if counts[i] > 1 {
+			return fmt.Errorf("missing substream sizes")
+		}
+		if sum > f.unpackSize {
+			return fmt.Errorf("substreams are larger than the folder")
+		}
+		s.subStreamSizes[i] = append(s.subStreamSizes[i], f.unpackSize-sum)
+	}
+	if id == idSize {
+		if id, err = r.ReadByte(); err != nil {
+			return err
+		}
+	}
+
+	for {
+		switch id {
+		case idEnd:
+			return nil
+		case idCRC:
+			// the digests of the streams not already described by the digest of the folder
+			digests := 0
+			for i, f := range s.folders {
+				if counts[i] != 1 || !f.crcDefined {
+					digests += counts[i]
+				}
+			}
+			if _, err := readDigests(r, digests); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected substreams info property=0x%02x", id)
+		}
+		if id, err = r.ReadByte(); err != nil {
+			return err
+		}
+	}
+}
+
+func readFilesInfo(r *bytes.Reader) ([]entry, error) {
+	count, err := readCount(r)
+	if err != nil {
+		return nil, err
+	}
+	if count > r.Len() {
+		// every entry takes at least a byte (of its name)
+		return nil, fmt.Errorf("too many entries")
+	}
+
+	var names []string
+	var emptyStream, emptyFile, attributesDefined []bool
+	var attributes []uint32
+	for {
+		id, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		if id == idEnd {
+			break
+		}
+		size, err := readSize(r)
+		if err != nil {
+			return nil, err
+		}
+		if size > int64(r.Len()) {
+			return nil, fmt.Errorf("file property exceeds the header")
+		}
+		data := make([]byte, size)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, err
+		}
+		pr := bytes.NewReader(data)
+
+		switch id {
+		case idEmptyStream:
+			if emptyStream, err = readBits(pr, count); err != nil {
+				return nil, err
+			}
+		case idEmptyFile:
+			if emptyFile, err = readBits(pr, countTrue(emptyStream)); err != nil {
+				return nil, err
+			}
+		case idName:
+			if names, err = readNames(pr, count); err != nil {
+				return nil, err
+			}
+		case idWinAttributes:
+			if attributesDefined, attributes, err = readAttributes(pr, count); err != nil {
+				return nil, err
+			}
+		default:
+			// timestamps, anti-items, and padding are not needed
+		}
+	}
+
+	if len(names) != count {
+		return nil, fmt.Errorf("missing entry names")
+	}
+
+	entries := make([]entry, count)
+	emptyIndex := 0
+	for i := range entries {
+		e := &entries[i]
+		e.Name = strings.ReplaceAll(names[i], "\\", "/")
+		e.hasStream = emptyStream == nil || !emptyStream[i]
+
+		isDir := false
+		if !e.hasStream {
+			// entries without contents are directories, unless marked as empty files
+			isDir = emptyIndex >= len(emptyFile) || !emptyFile[emptyIndex]
+			emptyIndex++
+		}
+
+		var attribute uint32
+		if attributesDefined != nil && attributesDefined[i] {
+			attribute = attributes[i]
+		}
+		e.Mode = fileMode(attribute, isDir)
+	}
+	return entries, nil
+}
+
+// fileMode returns the mode of an entry from its attributes, which hold the unix mode in the high 16 bits when the
+// archive was created on a unix system.
+func fileMode(attribute uint32, isDir bool) fs.FileMode {
+	if attribute&attributeDirectory != 0 {
+		isDir = true
+	}
+	if attribute&attributeUnixExtension != 0 {
+		unixMode := attribute >> 16
+		mode := fs.FileMode(unixMode & 0777)
+		switch unixMode & 0170000 {
+		case 0040000:
+			mode |= fs.ModeDir
+		case 0120000:
+			mode |= fs.ModeSymlink
+		}
+		if isDir {
+			mode |= fs.ModeDir
+		}
+		return mode
+	}
+	if isDir {
+		return fs.ModeDir | 0755
+	}
+	return 0644
+}
+
+func readNames(r *bytes.Reader, count int) ([]string, error) {
+	if external, err := r.ReadByte(); err != nil {
+		return nil, err
+	} else if external != 0 {
+		return nil, fmt.Errorf("external names are not supported")
+	}
+
+	names := make([]string, 0, count)
+	var name []uint16
+	for len(names) < count {
+		var c uint16
+		if err := binary.Read(r, binary.LittleEndian, &c); err != nil {
+			return nil, err
+		}
+		if c == 0 {
+			names = append(names, string(utf16.Decode(name)))
+			name = name[:0]
+			continue
+		}
+		name = append(name, c)
+	}
+	return names, nil
+}
+
+func readAttributes(r *bytes.Reader, count int) ([]bool, []uint32, error) {
+	defined, err := readOptionalBits(r, count)
+	if err != nil {
+		return nil, nil, err
+	}
+	if external, err := r.ReadByte(); err != nil {
+		return nil, nil, err
+	} else if external != 0 {
+		return nil, nil, fmt.Errorf("external attributes are not supported")
+	}
+
+	attributes := make([]uint32, count)
+	for i := range attributes {
+		if !defined[i] {
+			continue
+		}
+		if err := binary.Read(r, binary.LittleEndian, &attributes[i]); err != nil {
+			return nil, nil, err
+		}
+	}
+	return defined, attributes, nil
+}
+
+// readDigests skips over the CRCs of the given number of streams, returning which streams have a CRC.
+func readDigests(r *bytes.Reader, count int) ([]bool, error) {
+	defined, err := readOptionalBits(r, count)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(int64(4*countTrue(defined)), io.SeekCurrent); err != nil {
+		return nil, err
+	}
+	return defined, nil
+}
+
+// readOptionalBits reads a bit vector that is preceded by a flag indicating that all bits are set (so it is omitted).
+func readOptionalBits(r *bytes.Reader, count int) ([]bool, error) {
+	allDefined, err := r.ReadByte()
+	if err != nil {
+		return nil, err
+	}
+	if allDefined == 0 {
+		return readBits(r, count)
+	}
+	bits := make([]bool, count)
+	for i := range bits {
+		bits[i] = true
+	}
+	return bits, nil
+}
+
+func readBits(r *bytes.Reader, count int) ([]bool, error) {
+	if (count+7)/8 > r.Len() {
+		return nil, io.ErrUnexpectedEOF
+	}
+	bits := make([]bool, count)
+	var b byte
+	for i := range bits {
+		if i%8 == 0 {
+			var err error
+			if b, err = r.ReadByte(); err != nil {
+				return nil, err
+			}
+		}
+		bits[i] = b&(0x80>>(i%8)) != 0
+	}
+	return bits, nil
+}
+
+func countTrue(bits []bool) int {
+	count := 0
+	for _, b := range bits {
+		if b {
+			count++
+		}
+	}
+	return count
+}
+
+func skipProperties(r *bytes.Reader) error {
+	for {
+		id, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if id == idEnd {
+			return nil
+		}
+		size, err := readSize(r)
+		if err != nil {
+			return err
+		}
+		if _, err := r.Seek(size, io.SeekCurrent); err != nil {
+			return err
+		}
+	}
+}
+
+func expect(r *bytes.Reader, id byte) error {
+	actual, err := r.ReadByte()
+	if err != nil {
+		return err
+	}
+	if actual != id {
+		return fmt.Errorf("expected property=0x%02x but found 0x%02x", id, actual)
+	}
+	return nil
+}
+
+// readNumber reads a variable length integer, where the number of leading one bits of the first byte is the number of
+// (little endian) bytes that follow, and the remaining bits of the first byte are the most significant bits.
+func readNumber(r io.ByteReader) (uint64, error) {
+	first, err := r.ReadByte()
+	if err != nil {
+		return 0, err
+	}
+	var value uint64
+	mask := byte(0x80)
+	for i := 0; i < 8; i++ {
+		if first&mask == 0 {
+			high := uint64(first & (mask - 1))
+			return value | high<<(8*uint(i)), nil
+		}
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		value |= uint64(b) << (8 * uint(i))
+		mask >>= 1
+	}
+	return value, nil
+}
+
+func readSize(r io.ByteReader) (int64, error) {
+	n, err := readNumber(r)
+	if err != nil {
+		return 0, err
+	}
+	if n > maxInt64 {
+		return 0, fmt.Errorf("size out of range")
+	}
+	return int64(n), nil
+}
+
+// readCount reads a number of items, which is bounded so that malformed archives cannot cause large allocations.
+func readCount(r io.ByteReader) (int, error) {
+	n, err := readNumber(r)
+	if err != nil {
+		return 0, err
+	}
+	if n > maxHeaderSize {
+		return 0, fmt.Errorf("count out of range")
+	}
+	return int(n), nil
+}
@@ -0,0 +1,123 @@
+package sevenzip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveWithHeader returns an archive whose signature header references the given header, which is stored right after
+// the signature header (unless the given offset points elsewhere).
+func archiveWithHeader(offset, size uint64, header []byte) []byte {
+	archive := make([]byte, signatureHeaderSize, signatureHeaderSize+len(header))
+	le := binary.LittleEndian
+	copy(archive, Magic)
+	le.PutUint64(archive[12:20], offset)
+	le.PutUint64(archive[20:28], size)
+	return append(archive, header...)
+}
+
+func TestOpen_InvalidHeaders(t *testing.T) {
+	// an encoded header (of a single Copy coder) whose packed stream is the encoded header itself
+	selfReferencing := []byte{
+		idEncodedHeader,
+		idPackInfo, 0x00, 0x01, idSize, 0x12, idEnd,
+		idUnpackInfo, idFolder, 0x01, 0x00, 0x01, 0x01, 0x00, idCodersUnpackSize, 0x12, idEnd,
+		idEnd,
+	}
+
+	tooLarge := []byte{
+		idEncodedHeader,
+		idPackInfo, 0x00, 0x01, idSize, 0x12, idEnd,
+		idUnpackInfo, idFolder, 0x01, 0x00, 0x01, 0x01, 0x00, idCodersUnpackSize, 0xFF,
+	}
+	unpackSize := make([]byte, 8)
+	binary.LittleEndian.PutUint64(unpackSize, maxHeaderSize+1)
+	tooLarge = append(append(tooLarge, unpackSize...), idEnd, idEnd)
+
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr string
+	}{
+		{
+			name:    "truncated signature header",
+			archive: []byte(Magic),
+			wantErr: "unable to read 7z signature header",
+		},
+		{
+			name:    "missing magic",
+			archive: make([]byte, signatureHeaderSize),
+			wantErr: "not a 7z archive",
+		},
+		{
+			name:    "header larger than the maximum header size",
+			archive: archiveWithHeader(0, maxHeaderSize+1, nil),
+			wantErr: "7z header is too large",
+		},
+		{
+			name:    "header beyond the end of the archive",
+			archive: archiveWithHeader(1<<40, 16, nil),
+			wantErr: "unable to read 7z header",
+		},
+		{
+			name:    "truncated header",
+			archive: archiveWithHeader(0, 1, []byte{idHeader}),
+			wantErr: "unable to read 7z header: EOF",
+		},
+		{
+			name:    "unexpected header property",
+			archive: archiveWithHeader(0, 1, []byte{0x42}),
+			wantErr: "unexpected 7z header property=0x42",
+		},
+		{
+			name:    "encoded header without streams",
+			archive: archiveWithHeader(0, 2, []byte{idEncodedHeader, idEnd}),
+			wantErr: "unable to decode 7z header: no streams",
+		},
+		{
+			name:    "encoded header larger than the maximum header size",
+			archive: archiveWithHeader(0, uint64(len(tooLarge)), tooLarge),
+			wantErr: "7z header is too large",
+		},
+		{
+			name:    "encoded header that decodes to itself",
+			archive: archiveWithHeader(0, uint64(len(selfReferencing)), selfReferencing),
+			wantErr: "the encoded header is encoded again",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := make(chan error, 1)
+			go func() {
+				_, err := Open(bytes.NewReader(test.archive))
+				errs <- err
+			}()
+
+			select {
+			case err := <-errs:
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.wantErr)
+			case <-time.After(5 * time.Second):
+				t.Fatal("opening the archive did not return")
+			}
+		})
+	}
+}
+
+func TestOpen_EmptyArchive(t *testing.T) {
+	a, err := Open(bytes.NewReader(archiveWithHeader(0, 0, nil)))
+	require.NoError(t, err)
+
+	var entries []Entry
+	require.NoError(t, a.Walk(func(entry Entry, _ io.Reader) error {
+		entries = append(entries, entry)
+		return nil
+	}))
+	assert.Empty(t, entries)
+}
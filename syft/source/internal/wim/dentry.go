@@ -0,0 +1,160 @@
+package wim
+
+import (
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf16"
+)
+
+const (
+	attributeDirectory    = 0x10
+	attributeReparsePoint = 0x400
+
+	dentryFixedSize      = 102
+	streamEntryFixedSize = 38
+)
+
+// dentry is a file or directory within an image.
+type dentry struct {
+	name       string
+	attributes uint32
+	hash       [sha1.Size]byte
+	subdir     int
+	children   []*dentry
+}
+
+func (d *dentry) isDir() bool {
+	return d.attributes&attributeDirectory != 0 && d.attributes&attributeReparsePoint == 0
+}
+
+func (d *dentry) isReparsePoint() bool {
+	return d.attributes&attributeReparsePoint != 0
+}
+
+// parseMetadata reads the directory tree of an image from its metadata resource (which starts with the security data,
+// followed by the root directory entry).
+func parseMetadata(data []byte) (*dentry, error) {
+	if len(data) < 8 {
+		return nil, fmt.Errorf("WIM image metadata is too small")
+	}
+	securityLength := int(binary.LittleEndian.Uint32(data[0:4]))
+	rootOffset := align8(securityLength)
+	if securityLength == 0 {
+		// an empty security data block still occupies 8 bytes
+		rootOffset = 8
+	}
+
+	root, _, err := parseDentry(data, rootOffset)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, fmt.Errorf("WIM image has no root directory")
+	}
+	if err := readChildren(data, root, make(map[int]bool)); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+func readChildren(data []byte, dir *dentry, seen map[int]bool) error {
+	if !dir.isDir() || dir.subdir == 0 {
+		return nil
+	}
+	if seen[dir.subdir] {
+		// guard against cycles within corrupt directory trees
+		return fmt.Errorf("WIM directory listing at offset=%d is referenced more than once", dir.subdir)
+	}
+	seen[dir.subdir] = true
+
+	offset := dir.subdir
+	for {
+		child, next, err := parseDentry(data, offset)
+		if err != nil {
+			return err
+		}
+		if child == nil {
+			break
+		}
+		offset = next
+
+		if child.name == "" || child.name == "." || child.name == ".." || strings.ContainsAny(child.name, "/\x00") {
+			continue
+		}
+		if err := readChildren(data, child, seen); err != nil {
+			return err
+		}
+		dir.children = append(dir.children, child)
+	}
+
+	sort.Slice(dir.children, func(i, j int) bool {
+		return dir.children[i].name < dir.children[j].name
+	})
+	return nil
+}
+
+// parseDentry reads the directory entry at the given offset, returning the entry and the offset of the following
+// sibling entry. A nil entry is returned at the end of a directory listing.
+func parseDentry(data []byte, offset int) (*dentry, int, error) {
+	if offset < 0 || offset+8 > len(data) {
+		return nil, 0, fmt.Errorf("WIM directory entry offset=%d is out of bounds", offset)
+	}
+
+	le := binary.LittleEndian
+	length := int(le.Uint64(data[offset:]))
+	if length == 0 {
+		return nil, 0, nil
+	}
+	if length < dentryFixedSize || offset+length > len(data) {
+		return nil, 0, fmt.Errorf("invalid WIM directory entry length=%d at offset=%d", length, offset)
+	}
+
+	entry := data[offset : offset+length]
+	d := &dentry{
+		attributes: le.Uint32(entry[8:12]),
+		subdir:     int(le.Uint64(entry[16:24])),
+	}
+	copy(d.hash[:], entry[64:84])
+
+	streams := int(le.Uint16(entry[96:98]))
+	nameLength := int(le.Uint16(entry[100:102]))
+	if dentryFixedSize+nameLength > length {
+		return nil, 0, fmt.Errorf("invalid WIM directory entry name length=%d", nameLength)
+	}
+	d.name = decodeUTF16(entry[dentryFixedSize : dentryFixedSize+nameLength])
+
+	// alternate data streams follow the entry (the unnamed stream may be stored here instead of within the entry)
+	next := offset + align8(length)
+	for i := 0; i < streams; i++ {
+		if next+streamEntryFixedSize > len(data) {
+			return nil, 0, fmt.Errorf("truncated WIM stream entry")
+		}
+		stream := data[next:]
+		streamLength := int(le.Uint64(stream[0:8]))
+		streamNameLength := int(le.Uint16(stream[36:38]))
+		if streamLength < streamEntryFixedSize {
+			return nil, 0, fmt.Errorf("invalid WIM stream entry length=%d", streamLength)
+		}
+		if streamNameLength == 0 {
+			copy(d.hash[:], stream[16:36])
+		}
+		next += align8(streamLength)
+	}
+
+	return d, next, nil
+}
+
+func decodeUTF16(b []byte) string {
+	units := make([]uint16, len(b)/2)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(b[i*2:])
+	}
+	return string(utf16.Decode(units))
+}
+
+func align8(n int) int {
+	return (n + 7) &^ 7
+}
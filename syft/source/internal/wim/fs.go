@@ -0,0 +1,145 @@
+package wim
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	_ fs.FS        = (*FS)(nil)
+	_ fs.ReadDirFS = (*FS)(nil)
+)
+
+// FS is a read-only view of a single image within a WIM archive. Reparse points (symlinks and junctions) are reported
+// as irregular files, since their targets are Windows paths.
+type FS struct {
+	archive *Archive
+	root    *dentry
+}
+
+// Open opens the named file.
+func (f *FS) Open(name string) (fs.File, error) {
+	d, err := f.lookup(name, "open")
+	if err != nil {
+		return nil, err
+	}
+
+	file := &File{entry: d, size: f.size(d)}
+	if d.isDir() || d.isReparsePoint() || isEmptyHash(d.hash) {
+		return file, nil
+	}
+
+	resource, ok := f.archive.streams[d.hash]
+	if !ok {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fmt.Errorf("stream %x not found in WIM lookup table", d.hash)}
+	}
+	file.reader = io.NewSectionReader(f.archive.resourceReader(resource), 0, resource.originalSize)
+	return file, nil
+}
+
+// ReadDir reads the named directory and returns all entries sorted by filename.
+func (f *FS) ReadDir(name string) ([]fs.DirEntry, error) {
+	d, err := f.lookup(name, "readdir")
+	if err != nil {
+		return nil, err
+	}
+	if !d.isDir() {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fmt.Errorf("not a directory")}
+	}
+
+	entries := make([]fs.DirEntry, 0, len(d.children))
+	for _, child := range d.children {
+		entries = append(entries, &dirEntry{entry: child, size: f.size(child)})
+	}
+	return entries, nil
+}
+
+func (f *FS) lookup(name, op string) (*dentry, error) {
+	if !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+
+	d := f.root
+	if name == "." {
+		return d, nil
+	}
+
+	for _, part := range strings.Split(name, "/") {
+		children := d.children
+		i := sort.Search(len(children), func(i int) bool { return children[i].name >= part })
+		if i == len(children) || children[i].name != part {
+			return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+		}
+		d = children[i]
+	}
+	return d, nil
+}
+
+func (f *FS) size(d *dentry) int64 {
+	if d.isDir() || isEmptyHash(d.hash) {
+		return 0
+	}
+	return f.archive.streams[d.hash].originalSize
+}
+
+func mode(d *dentry) fs.FileMode {
+	switch {
+	case d.isDir():
+		return fs.ModeDir | 0755
+	case d.isReparsePoint():
+		return fs.ModeIrregular | 0644
+	}
+	return 0644
+}
+
+type dirEntry struct {
+	entry *dentry
+	size  int64
+}
+
+func (d *dirEntry) Name() string               { return d.entry.name }
+func (d *dirEntry) IsDir() bool                { return d.entry.isDir() }
+func (d *dirEntry) Type() fs.FileMode          { return mode(d.entry).Type() }
+func (d *dirEntry) Info() (fs.FileInfo, error) { return &fileInfo{entry: d.entry, size: d.size}, nil }
+
+type fileInfo struct {
+	entry *dentry
+	size  int64
+}
+
+func (i *fileInfo) Name() string       { return i.entry.name }
+func (i *fileInfo) Size() int64        { return i.size }
+func (i *fileInfo) Mode() fs.FileMode  { return mode(i.entry) }
+func (i *fileInfo) ModTime() time.Time { return time.Time{} }
+func (i *fileInfo) IsDir() bool        { return i.entry.isDir() }
+func (i *fileInfo) Sys() interface{}   { return nil }
+
+// File is an open file (or directory) within a WIM image.
+type File struct {
+	entry  *dentry
+	size   int64
+	reader *io.SectionReader
+}
+
+func (f *File) Stat() (fs.FileInfo, error) {
+	return &fileInfo{entry: f.entry, size: f.size}, nil
+}
+
+func (f *File) Read(p []byte) (int, error) {
+	if f.reader == nil {
+		if f.entry.isDir() || f.entry.isReparsePoint() {
+			return 0, &fs.PathError{Op: "read", Path: f.entry.name, Err: fmt.Errorf("not a regular file")}
+		}
+		// files without any contents
+		return 0, io.EOF
+	}
+	return f.reader.Read(p)
+}
+
+func (f *File) Close() error {
+	return nil
+}
@@ -0,0 +1,349 @@
+/*
+Package wim provides read-only access to the images within Windows Imaging Format (WIM) archives as an fs.FS. Only
+uncompressed and XPRESS compressed archives are supported (LZX and LZMS compression are not supported), and split
+archives are not supported.
+*/
+package wim
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	headerSize       = 208
+	lookupEntrySize  = 50
+	defaultChunkSize = 32768
+	minChunkSize     = 4096
+
+	// the lookup table and image metadata are read into memory whole, so their (untrusted) sizes are bounded
+	maxMetadataSize = 256 << 20
+
+	headerFlagCompression = 0x00000002
+	headerFlagXPRESS      = 0x00020000
+	headerFlagLZX         = 0x00040000
+	headerFlagLZMS        = 0x00080000
+
+	resourceFlagMetadata   = 0x02
+	resourceFlagCompressed = 0x04
+	resourceFlagSpanned    = 0x08
+)
+
+// Magic is found at the start of every WIM archive.
+const Magic = "MSWIM\x00\x00\x00"
+
+// IsWIM indicates if the given reader is a WIM archive.
+func IsWIM(r io.ReaderAt) bool {
+	header := make([]byte, len(Magic))
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return false
+	}
+	return string(header) == Magic
+}
+
+// resourceHeader describes where a (possibly compressed) resource is stored within the archive.
+type resourceHeader struct {
+	size         int64
+	flags        byte
+	offset       int64
+	originalSize int64
+}
+
+func parseResourceHeader(b []byte) resourceHeader {
+	le := binary.LittleEndian
+	sizeAndFlags := le.Uint64(b[0:8])
+	return resourceHeader{
+		size:         int64(sizeAndFlags & 0x00FFFFFFFFFFFFFF),
+		flags:        byte(sizeAndFlags >> 56),
+		offset:       int64(le.Uint64(b[8:16])),
+		originalSize: int64(le.Uint64(b[16:24])),
+	}
+}
+
+// Archive is an open WIM archive.
+type Archive struct {
+	r          io.ReaderAt
+	size       int64
+	compressed bool
+	chunkSize  int64
+	bootIndex  int
+	// streams are indexed by the SHA1 digest of the uncompressed contents
+	streams  map[[sha1.Size]byte]resourceHeader
+	metadata []resourceHeader
+}
+
+// Open reads the header and lookup table of the WIM archive (of the given size).
+func Open(r io.ReaderAt, size int64) (*Archive, error) {
+	header := make([]byte, headerSize)
+	if _, err := r.ReadAt(header, 0); err != nil {
+		return nil, fmt.Errorf("unable to read WIM header: %w", err)
+	}
+	if string(header[:len(Magic)]) != Magic {
+		return nil, fmt.Errorf("not a WIM archive")
+	}
+
+	le := binary.LittleEndian
+	flags := le.Uint32(header[16:20])
+	a := &Archive{
+		r:         r,
+		size:      size,
+		chunkSize: int64(le.Uint32(header[20:24])),
+		bootIndex: int(le.Uint32(header[120:124])),
+		streams:   make(map[[sha1.Size]byte]resourceHeader),
+	}
+	if a.chunkSize == 0 {
+		a.chunkSize = defaultChunkSize
+	}
+
+	if totalParts := le.Uint16(header[42:44]); totalParts > 1 {
+		return nil, fmt.Errorf("split WIM archives are not supported (parts=%d)", totalParts)
+	}
+
+	if flags&headerFlagCompression != 0 {
+		switch {
+		case flags&headerFlagXPRESS != 0:
+			a.compressed = true
+		case flags&headerFlagLZX != 0:
+			return nil, fmt.Errorf("LZX compressed WIM archives are not supported")
+		case flags&headerFlagLZMS != 0:
+			return nil, fmt.Errorf("LZMS compressed WIM archives (ESD) are not supported")
+		default:
+			return nil, fmt.Errorf("unsupported WIM compression (flags=%#x)", flags)
+		}
+
+		// XPRESS chunks are a power of two no larger than the largest XPRESS block
+		if a.chunkSize < minChunkSize || a.chunkSize > xpressMaxBlockSize || a.chunkSize&(a.chunkSize-1) != 0 {
+			return nil, fmt.Errorf("invalid WIM chunk size=%d", a.chunkSize)
+		}
+	}
+
+	if err := a.readLookupTable(parseResourceHeader(header[48:72])); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *Archive) readLookupTable(table resourceHeader) error {
+	data, err := a.readResource(table)
+	if err != nil {
+		return fmt.Errorf("unable to read WIM lookup table: %w", err)
+	}
+
+	for offset := 0; offset+lookupEntrySize <= len(data); offset += lookupEntrySize {
+		entry := data[offset : offset+lookupEntrySize]
+		resource := parseResourceHeader(entry[0:24])
+		if resource.flags&resourceFlagSpanned != 0 {
+			return fmt.Errorf("spanned WIM resources are not supported")
+		}
+		if err := a.checkResource(resource); err != nil {
+			return err
+		}
+		if resource.flags&resourceFlagMetadata != 0 {
+			a.metadata = append(a.metadata, resource)
+			continue
+		}
+		var hash [sha1.Size]byte
+		copy(hash[:], entry[30:50])
+		a.streams[hash] = resource
+	}
+	return nil
+}
+
+// ImageCount returns the number of images within the archive.
+func (a *Archive) ImageCount() int {
+	return len(a.metadata)
+}
+
+// BootIndex returns the (1-based) index of the bootable image, or 0 if no image is marked as bootable.
+func (a *Archive) BootIndex() int {
+	return a.bootIndex
+}
+
+// Image returns the filesystem of the image at the given (1-based) index.
+func (a *Archive) Image(index int) (*FS, error) {
+	if index < 1 || index > len(a.metadata) {
+		return nil, fmt.Errorf("invalid WIM image index=%d (images=%d)", index, len(a.metadata))
+	}
+	data, err := a.readResource(a.metadata[index-1])
+	if err != nil {
+		return nil, fmt.Errorf("unable to read WIM image metadata: %w", err)
+	}
+	root, err := parseMetadata(data)
+	if err != nil {
+		return nil, err
+	}
+	return &FS{archive: a, root: root}, nil
+}
+
+// checkResource ensures the stored resource is found entirely within the archive (and, when stored uncompressed, is no
+// larger than what is stored).
+func (a *Archive) checkResource(resource resourceHeader) error {
+	if resource.offset < 0 || resource.size < 0 || resource.offset > a.size || resource.size > a.size-resource.offset {
+		return fmt.Errorf("WIM resource (offset=%d, size=%d) extends beyond the end of the archive", resource.offset, resource.size)
+	}
+	if resource.originalSize < 0 || (!a.isCompressed(resource) && resource.originalSize > resource.size) {
+		return fmt.Errorf("invalid WIM resource size=%d (stored size=%d)", resource.originalSize, resource.size)
+	}
+	return nil
+}
+
+func (a *Archive) isCompressed(resource resourceHeader) bool {
+	return a.compressed && resource.flags&resourceFlagCompressed != 0
+}
+
+// resourceReader returns random access to the uncompressed contents of the given resource.
+func (a *Archive) resourceReader(resource resourceHeader) io.ReaderAt {
+	if !a.isCompressed(resource) {
+		return io.NewSectionReader(a.r, resource.offset, resource.originalSize)
+	}
+	return &chunkReader{
+		r:         io.NewSectionReader(a.r, resource.offset, resource.size),
+		size:      resource.originalSize,
+		chunkSize: a.chunkSize,
+	}
+}
+
+func (a *Archive) readResource(resource resourceHeader) ([]byte, error) {
+	if err := a.checkResource(resource); err != nil {
+		return nil, err
+	}
+	if resource.originalSize > maxMetadataSize {
+		return nil, fmt.Errorf("WIM metadata resource is too large (size=%d)", resource.originalSize)
+	}
+	data := make([]byte, resource.originalSize)
+	if _, err := a.resourceReader(resource).ReadAt(data, 0); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return data, nil
+}
+
+// chunkReader decompresses a resource that is stored as a table of independently compressed chunks.
+type chunkReader struct {
+	r         *io.SectionReader
+	size      int64
+	chunkSize int64
+	offsets   []int64
+
+	cachedIndex int64
+	cachedChunk []byte
+}
+
+func (c *chunkReader) ReadAt(p []byte, off int64) (int, error) {
+	if off >= c.size {
+		return 0, io.EOF
+	}
+	if err := c.readChunkTable(); err != nil {
+		return 0, err
+	}
+
+	n := 0
+	for n < len(p) && off+int64(n) < c.size {
+		pos := off + int64(n)
+		chunk, err := c.chunk(pos / c.chunkSize)
+		if err != nil {
+			return n, err
+		}
+		n += copy(p[n:], chunk[pos%c.chunkSize:])
+	}
+
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// readChunkTable reads the offsets of each chunk (the first chunk implicitly starts immediately after the table).
+func (c *chunkReader) readChunkTable() error {
+	if c.offsets != nil {
+		return nil
+	}
+
+	chunks := (c.size + c.chunkSize - 1) / c.chunkSize
+	entrySize := int64(4)
+	if c.size > 0xFFFFFFFF {
+		entrySize = 8
+	}
+
+	// the table (of every chunk but the first) must be within the stored resource
+	if chunks-1 > c.r.Size()/entrySize {
+		return fmt.Errorf("WIM chunk table of %d chunks exceeds the resource size=%d", chunks, c.r.Size())
+	}
+
+	table := make([]byte, (chunks-1)*entrySize)
+	if _, err := c.r.ReadAt(table, 0); err != nil && err != io.EOF {
+		return fmt.Errorf("unable to read WIM chunk table: %w", err)
+	}
+
+	offsets := make([]int64, chunks+1)
+	for i := int64(1); i < chunks; i++ {
+		entry := table[(i-1)*entrySize:]
+		if entrySize == 8 {
+			offsets[i] = int64(binary.LittleEndian.Uint64(entry))
+		} else {
+			offsets[i] = int64(binary.LittleEndian.Uint32(entry))
+		}
+	}
+	offsets[chunks] = c.r.Size() - int64(len(table))
+
+	for i := int64(1); i <= chunks; i++ {
+		if offsets[i] < offsets[i-1] || offsets[i] > offsets[chunks] {
+			return fmt.Errorf("invalid WIM chunk table (chunk=%d offset=%d)", i, offsets[i])
+		}
+	}
+
+	// offsets are relative to the end of the chunk table
+	for i := range offsets {
+		offsets[i] += int64(len(table))
+	}
+	c.offsets = offsets
+	c.cachedIndex = -1
+	return nil
+}
+
+func (c *chunkReader) chunk(index int64) ([]byte, error) {
+	if index == c.cachedIndex {
+		return c.cachedChunk, nil
+	}
+
+	uncompressedSize := c.chunkSize
+	if remaining := c.size - index*c.chunkSize; remaining < uncompressedSize {
+		uncompressedSize = remaining
+	}
+
+	// a chunk is never stored larger than its uncompressed size
+	length := c.offsets[index+1] - c.offsets[index]
+	if length > uncompressedSize {
+		return nil, fmt.Errorf("invalid WIM chunk=%d (size=%d, uncompressed size=%d)", index, length, uncompressedSize)
+	}
+
+	compressed := make([]byte, length)
+	if _, err := c.r.ReadAt(compressed, c.offsets[index]); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to read WIM chunk=%d: %w", index, err)
+	}
+
+	var chunk []byte
+	if int64(len(compressed)) == uncompressedSize {
+		// chunks that do not compress are stored as-is
+		chunk = compressed
+	} else {
+		var err error
+		chunk, err = decompressXPRESS(compressed, int(uncompressedSize))
+		if err != nil {
+			return nil, fmt.Errorf("unable to decompress WIM chunk=%d: %w", index, err)
+		}
+	}
+
+	c.cachedIndex = index
+	c.cachedChunk = chunk
+	return chunk, nil
+}
+
+// emptyHash is the (all zero) hash used by entries without any contents.
+var emptyHash [sha1.Size]byte
+
+func isEmptyHash(hash [sha1.Size]byte) bool {
+	return bytes.Equal(hash[:], emptyHash[:])
+}
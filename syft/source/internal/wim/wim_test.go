@@ -0,0 +1,121 @@
+package wim
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveWithLookupTable returns a (4 KB) archive whose header references a lookup table with the given resource header.
+func archiveWithLookupTable(flags, chunkSize uint32, resourceFlags byte, size, offset, originalSize uint64) []byte {
+	archive := make([]byte, 4096)
+	le := binary.LittleEndian
+	copy(archive, Magic)
+	le.PutUint32(archive[16:20], flags)
+	le.PutUint32(archive[20:24], chunkSize)
+	le.PutUint64(archive[48:56], size|uint64(resourceFlags)<<56)
+	le.PutUint64(archive[56:64], offset)
+	le.PutUint64(archive[64:72], originalSize)
+	return archive
+}
+
+func TestOpen_InvalidHeaders(t *testing.T) {
+	const xpress = headerFlagCompression | headerFlagXPRESS
+	tests := []struct {
+		name    string
+		archive []byte
+		wantErr string
+	}{
+		{
+			name:    "truncated header",
+			archive: []byte(Magic),
+			wantErr: "unable to read WIM header",
+		},
+		{
+			name:    "chunk size that is not a power of two",
+			archive: archiveWithLookupTable(xpress, 5000, 0, 0, headerSize, 0),
+			wantErr: "invalid WIM chunk size=5000",
+		},
+		{
+			name:    "chunk size larger than an XPRESS block",
+			archive: archiveWithLookupTable(xpress, 1<<20, 0, 0, headerSize, 0),
+			wantErr: "invalid WIM chunk size=1048576",
+		},
+		{
+			name:    "lookup table beyond the end of the archive",
+			archive: archiveWithLookupTable(0, 0, 0, 1<<30, headerSize, 1<<30),
+			wantErr: "extends beyond the end of the archive",
+		},
+		{
+			name:    "uncompressed lookup table larger than what is stored",
+			archive: archiveWithLookupTable(0, 0, 0, 16, headerSize, 1<<40),
+			wantErr: "invalid WIM resource size=1099511627776",
+		},
+		{
+			name:    "compressed lookup table larger than the metadata limit",
+			archive: archiveWithLookupTable(xpress, 0, resourceFlagCompressed, 16, headerSize, 1<<40),
+			wantErr: "WIM metadata resource is too large",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := Open(bytes.NewReader(test.archive), int64(len(test.archive)))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
+
+func TestChunkReader_InvalidChunkTable(t *testing.T) {
+	le := binary.LittleEndian
+	tests := []struct {
+		name    string
+		stored  []byte
+		size    int64
+		wantErr string
+	}{
+		{
+			name: "decreasing chunk offsets",
+			stored: func() []byte {
+				stored := make([]byte, 1024)
+				le.PutUint32(stored[0:4], 512)
+				le.PutUint32(stored[4:8], 256)
+				return stored
+			}(),
+			size:    3 * defaultChunkSize,
+			wantErr: "invalid WIM chunk table (chunk=2 offset=256)",
+		},
+		{
+			name: "chunk offset beyond the end of the resource",
+			stored: func() []byte {
+				stored := make([]byte, 1024)
+				le.PutUint32(stored[0:4], 1<<20)
+				return stored
+			}(),
+			size:    2 * defaultChunkSize,
+			wantErr: "invalid WIM chunk table (chunk=1 offset=1048576)",
+		},
+		{
+			name:    "more chunks than fit within the resource",
+			stored:  make([]byte, 16),
+			size:    1 << 40,
+			wantErr: "exceeds the resource size=16",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &chunkReader{
+				r:         io.NewSectionReader(bytes.NewReader(test.stored), 0, int64(len(test.stored))),
+				size:      test.size,
+				chunkSize: defaultChunkSize,
+			}
+			_, err := c.ReadAt(make([]byte, 16), 0)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), test.wantErr)
+		})
+	}
+}
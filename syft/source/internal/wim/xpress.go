@@ -0,0 +1,138 @@
+package wim
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	xpressSymbols      = 512
+	xpressTableSize    = xpressSymbols / 2
+	xpressMaxCodeBits  = 15
+	xpressMaxBlockSize = 65536
+)
+
+// decompressXPRESS decompresses a single chunk of XPRESS Huffman (LZ77+Huffman, as described in [MS-XCA]) data.
+func decompressXPRESS(in []byte, size int) ([]byte, error) {
+	if size > xpressMaxBlockSize {
+		return nil, fmt.Errorf("XPRESS chunk is too large (size=%d)", size)
+	}
+	if len(in) < xpressTableSize+4 {
+		return nil, fmt.Errorf("XPRESS chunk is too small (size=%d)", len(in))
+	}
+
+	table, err := xpressDecodingTable(in[:xpressTableSize])
+	if err != nil {
+		return nil, err
+	}
+
+	pos := xpressTableSize
+	read16 := func() uint32 {
+		if pos+2 > len(in) {
+			// the final words of the stream may be omitted by some encoders
+			pos += 2
+			return 0
+		}
+		v := uint32(binary.LittleEndian.Uint16(in[pos:]))
+		pos += 2
+		return v
+	}
+
+	nextBits := read16()<<16 | read16()
+	extraBits := 16
+
+	consume := func(n int) {
+		nextBits <<= n
+		extraBits -= n
+		if extraBits < 0 {
+			nextBits |= read16() << -extraBits
+			extraBits += 16
+		}
+	}
+
+	out := make([]byte, 0, size)
+	for len(out) < size {
+		entry := table[nextBits>>(32-xpressMaxCodeBits)]
+		symbol, length := int(entry>>4), int(entry&0xF)
+		if length == 0 {
+			return nil, fmt.Errorf("invalid XPRESS huffman code")
+		}
+		consume(length)
+
+		if symbol < 256 {
+			out = append(out, byte(symbol))
+			continue
+		}
+
+		symbol -= 256
+		matchLength := symbol & 0xF
+		offsetBits := symbol >> 4
+
+		if matchLength == 15 {
+			if pos >= len(in) {
+				return nil, fmt.Errorf("truncated XPRESS match length")
+			}
+			matchLength = int(in[pos])
+			pos++
+			if matchLength == 255 {
+				if pos+2 > len(in) {
+					return nil, fmt.Errorf("truncated XPRESS match length")
+				}
+				matchLength = int(binary.LittleEndian.Uint16(in[pos:]))
+				pos += 2
+				if matchLength < 15 {
+					return nil, fmt.Errorf("invalid XPRESS match length")
+				}
+				matchLength -= 15
+			}
+			matchLength += 15
+		}
+		matchLength += 3
+
+		offset := int(nextBits>>(32-offsetBits)) + 1<<offsetBits
+		consume(offsetBits)
+
+		if offset > len(out) {
+			return nil, fmt.Errorf("invalid XPRESS match offset=%d (position=%d)", offset, len(out))
+		}
+		// matches may overlap with the bytes being written, so copy byte by byte
+		start := len(out) - offset
+		for i := 0; i < matchLength && len(out) < size; i++ {
+			out = append(out, out[start+i])
+		}
+	}
+	return out, nil
+}
+
+// xpressDecodingTable builds a lookup table (indexed by the next 15 bits of input) of symbol<<4 | code length, from the
+// 4-bit code lengths of all 512 symbols.
+func xpressDecodingTable(lengths []byte) ([]uint16, error) {
+	var codeLengths [xpressSymbols]int
+	var counts [xpressMaxCodeBits + 1]int
+	for i := 0; i < xpressSymbols; i++ {
+		codeLengths[i] = int(lengths[i/2]>>(4*(i%2))) & 0xF
+		counts[codeLengths[i]]++
+	}
+
+	table := make([]uint16, 1<<xpressMaxCodeBits)
+	code := 0
+	for length := 1; length <= xpressMaxCodeBits; length++ {
+		for symbol := 0; symbol < xpressSymbols; symbol++ {
+			if codeLengths[symbol] != length {
+				continue
+			}
+			// every entry that shares this code as a prefix decodes to this symbol
+			span := 1 << (xpressMaxCodeBits - length)
+			start := code << (xpressMaxCodeBits - length)
+			if start+span > len(table) {
+				return nil, fmt.Errorf("invalid XPRESS huffman table (over-subscribed)")
+			}
+			for i := start; i < start+span; i++ {
+				table[i] = uint16(symbol<<4 | length)
+			}
+			code++
+		}
+		code <<= 1
+	}
+	return table, nil
+}
@@ -2,8 +2,10 @@ package source
 
 // Metadata represents any static source data that helps describe "what" was cataloged.
 type Metadata struct {
-	ID            string        `hash:"ignore"` // the id generated from the parent source struct
-	Scheme        Scheme        // the source data scheme type (directory or image)
-	ImageMetadata ImageMetadata // all image info (image only)
-	Path          string        // the root path to be cataloged (directory only)
+	ID            string          `hash:"ignore"` // the id generated from the parent source struct
+	Scheme        Scheme          // the source data scheme type (directory or image)
+	ImageMetadata ImageMetadata   // all image info (image only)
+	Path          string          // the root path to be cataloged (directory only)
+	Git           *GitMetadata    // the repository revision cataloged (git repositories only)
+	Origin        *OriginMetadata // where the artifact was downloaded from (remote artifacts only)
 }
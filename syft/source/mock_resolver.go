@@ -133,6 +133,10 @@ func (r MockResolver) AllLocations() <-chan Location {
 }
 
 func (r MockResolver) FileMetadataByLocation(l Location) (FileMetadata, error) {
+	if metadata, ok := r.metadata[l]; ok {
+		return metadata, nil
+	}
+
 	info, err := os.Stat(l.RealPath)
 	if err != nil {
 		return FileMetadata{}, err
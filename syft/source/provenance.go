@@ -0,0 +1,314 @@
+package source
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/registryclient"
+)
+
+const (
+	slsaProvenanceV02 = "https://slsa.dev/provenance/v0.2"
+	slsaProvenanceV1  = "https://slsa.dev/provenance/v1"
+
+	// inTotoMediaType is the media type of the (unsigned) in-toto statements within BuildKit attestation manifests, and
+	// dsseMediaType is the media type of the (signed) DSSE envelopes attached by cosign.
+	inTotoMediaType = "application/vnd.in-toto+json"
+	dsseMediaType   = "application/vnd.dsse.envelope.v1+json"
+
+	// the annotations of the attestation manifests added to an image index by BuildKit, referring to the image they describe
+	attestationTypeAnnotation   = "vnd.docker.reference.type"
+	attestationDigestAnnotation = "vnd.docker.reference.digest"
+	attestationManifestType     = "attestation-manifest"
+)
+
+// ProvenanceMetadata describes how a container image was built, as attested by the SLSA provenance attached to the image.
+type ProvenanceMetadata struct {
+	PredicateType    string `json:"predicateType"`              // the SLSA provenance version (e.g. "https://slsa.dev/provenance/v0.2")
+	BuilderID        string `json:"builderId"`                  // the builder that produced the image (e.g. a CI workflow)
+	BuildType        string `json:"buildType,omitempty"`        // the kind of build that was run (defined by the builder)
+	SourceRepository string `json:"sourceRepository,omitempty"` // the repository the image was built from (e.g. "git+https://github.com/org/repo")
+	SourceDigest     string `json:"sourceDigest,omitempty"`     // the revision of the source repository (e.g. "sha1:<commit>")
+}
+
+// DetectProvenance finds the SLSA provenance attached to the image within its registry (either by BuildKit within the
+// image index or by cosign), recording the builder and source repository of the image on the image metadata. Note:
+// the signatures of the attestations are not verified (see "syft verify").
+func (s *Source) DetectProvenance(ctx context.Context, registryOptions *image.RegistryOptions) error {
+	if s.Metadata.Scheme != ImageScheme {
+		return fmt.Errorf("provenance detection is only supported for images")
+	}
+
+	statements, err := provenanceStatements(ctx, s.Metadata.ImageMetadata, registryOptions)
+	if err != nil {
+		return err
+	}
+
+	for _, statement := range statements {
+		provenance, err := parseProvenance(statement)
+		if err != nil {
+			log.Debugf("skipping attestation: %+v", err)
+			continue
+		}
+		if provenance != nil {
+			s.Metadata.ImageMetadata.Provenance = provenance
+			return nil
+		}
+	}
+	return fmt.Errorf("no provenance found for image=%q", s.Metadata.ImageMetadata.UserInput)
+}
+
+// provenanceStatements fetches the in-toto statements attached to the image within its registry: the attestation
+// manifests added to the image index by BuildKit and the attestations attached by cosign (tagged "sha256-<hex>.att").
+func provenanceStatements(ctx context.Context, metadata ImageMetadata, registryOptions *image.RegistryOptions) ([][]byte, error) {
+	if len(metadata.RepoDigests) == 0 {
+		return nil, fmt.Errorf("image=%q has no repo digest (only images from a registry have attestations)", metadata.UserInput)
+	}
+
+	opts := registryclient.RemoteOptions(ctx, registryOptions)
+
+	var statements [][]byte
+	seen := make(map[string]struct{})
+	for _, repoDigest := range metadata.RepoDigests {
+		ref, err := name.NewDigest(repoDigest, registryclient.NameOptions(registryOptions)...)
+		if err != nil {
+			log.Debugf("unable to parse repo digest=%q: %+v", repoDigest, err)
+			continue
+		}
+
+		desc, err := remote.Get(ref, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch image=%q: %w", repoDigest, err)
+		}
+		if desc.MediaType.IsIndex() {
+			found, err := buildkitStatements(desc, metadata.ManifestDigest)
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, found...)
+		}
+
+		// cosign attestations may be attached to the image index or to the image itself
+		digests := []string{ref.DigestStr()}
+		if metadata.ManifestDigest != "" {
+			digests = append(digests, metadata.ManifestDigest)
+		}
+		for _, digest := range digests {
+			tag := ref.Context().Tag(strings.Replace(digest, ":", "-", 1) + ".att")
+			if _, ok := seen[tag.String()]; ok {
+				continue
+			}
+			seen[tag.String()] = struct{}{}
+
+			img, err := remote.Image(tag, opts...)
+			if err != nil {
+				log.Debugf("no cosign attestations found at=%q: %+v", tag, err)
+				continue
+			}
+			found, err := layerStatements(img)
+			if err != nil {
+				return nil, err
+			}
+			statements = append(statements, found...)
+		}
+	}
+	return statements, nil
+}
+
+// buildkitStatements returns the statements of the attestation manifests within the image index that refer to the
+// image with the given manifest digest.
+func buildkitStatements(desc *remote.Descriptor, manifestDigest string) ([][]byte, error) {
+	idx, err := desc.ImageIndex()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read image index=%q: %w", desc.Digest, err)
+	}
+	manifest, err := idx.IndexManifest()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read image index=%q: %w", desc.Digest, err)
+	}
+
+	var statements [][]byte
+	for _, m := range manifest.Manifests {
+		if m.Annotations[attestationTypeAnnotation] != attestationManifestType {
+			continue
+		}
+		if manifestDigest != "" && m.Annotations[attestationDigestAnnotation] != manifestDigest {
+			continue
+		}
+		img, err := idx.Image(m.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch attestation manifest=%q: %w", m.Digest, err)
+		}
+		found, err := layerStatements(img)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, found...)
+	}
+	return statements, nil
+}
+
+// layerStatements returns the in-toto statements within the layers of the given attestation image, unwrapping any
+// DSSE envelopes.
+func layerStatements(img v1.Image) ([][]byte, error) {
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read attestation manifest: %w", err)
+	}
+
+	var statements [][]byte
+	for _, desc := range manifest.Layers {
+		if desc.MediaType != inTotoMediaType && desc.MediaType != dsseMediaType {
+			continue
+		}
+
+		layer, err := img.LayerByDigest(desc.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch attestation=%q: %w", desc.Digest, err)
+		}
+		// note: attestations are not compressed, so the blob is the attestation itself
+		rc, err := layer.Compressed()
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch attestation=%q: %w", desc.Digest, err)
+		}
+		by, err := io.ReadAll(rc)
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("unable to read attestation=%q: %w", desc.Digest, err)
+		}
+
+		if desc.MediaType == dsseMediaType {
+			by, err = dssePayload(by)
+			if err != nil {
+				log.Debugf("skipping attestation=%q: %+v", desc.Digest, err)
+				continue
+			}
+		}
+		statements = append(statements, by)
+	}
+	return statements, nil
+}
+
+func dssePayload(by []byte) ([]byte, error) {
+	var envelope struct {
+		PayloadType string `json:"payloadType"`
+		Payload     string `json:"payload"`
+	}
+	if err := json.Unmarshal(by, &envelope); err != nil {
+		return nil, fmt.Errorf("unable to decode DSSE envelope: %w", err)
+	}
+	return base64.StdEncoding.DecodeString(envelope.Payload)
+}
+
+// resourceDescriptor is a material of a SLSA v0.2 provenance or a resolved dependency of a SLSA v1 provenance.
+type resourceDescriptor struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+// parseProvenance returns the provenance described by the given in-toto statement, or nil when the statement is not a
+// SLSA provenance.
+func parseProvenance(statement []byte) (*ProvenanceMetadata, error) {
+	var st struct {
+		PredicateType string          `json:"predicateType"`
+		Predicate     json.RawMessage `json:"predicate"`
+	}
+	if err := json.Unmarshal(statement, &st); err != nil {
+		return nil, fmt.Errorf("unable to decode in-toto statement: %w", err)
+	}
+
+	switch st.PredicateType {
+	case slsaProvenanceV02:
+		var predicate struct {
+			Builder struct {
+				ID string `json:"id"`
+			} `json:"builder"`
+			BuildType  string `json:"buildType"`
+			Invocation struct {
+				ConfigSource resourceDescriptor `json:"configSource"`
+			} `json:"invocation"`
+			Materials []resourceDescriptor `json:"materials"`
+		}
+		if err := json.Unmarshal(st.Predicate, &predicate); err != nil {
+			return nil, fmt.Errorf("unable to decode provenance: %w", err)
+		}
+
+		source := predicate.Invocation.ConfigSource
+		if source.URI == "" {
+			source = sourceMaterial(predicate.Materials)
+		}
+		return &ProvenanceMetadata{
+			PredicateType:    st.PredicateType,
+			BuilderID:        predicate.Builder.ID,
+			BuildType:        predicate.BuildType,
+			SourceRepository: source.URI,
+			SourceDigest:     sourceDigest(source.Digest),
+		}, nil
+
+	case slsaProvenanceV1:
+		var predicate struct {
+			BuildDefinition struct {
+				BuildType            string               `json:"buildType"`
+				ResolvedDependencies []resourceDescriptor `json:"resolvedDependencies"`
+			} `json:"buildDefinition"`
+			RunDetails struct {
+				Builder struct {
+					ID string `json:"id"`
+				} `json:"builder"`
+			} `json:"runDetails"`
+		}
+		if err := json.Unmarshal(st.Predicate, &predicate); err != nil {
+			return nil, fmt.Errorf("unable to decode provenance: %w", err)
+		}
+
+		source := sourceMaterial(predicate.BuildDefinition.ResolvedDependencies)
+		return &ProvenanceMetadata{
+			PredicateType:    st.PredicateType,
+			BuilderID:        predicate.RunDetails.Builder.ID,
+			BuildType:        predicate.BuildDefinition.BuildType,
+			SourceRepository: source.URI,
+			SourceDigest:     sourceDigest(source.Digest),
+		}, nil
+	}
+	return nil, nil
+}
+
+// sourceMaterial returns the first git repository within the given materials (the others are typically base images
+// and other build inputs).
+func sourceMaterial(materials []resourceDescriptor) resourceDescriptor {
+	for _, m := range materials {
+		if strings.HasPrefix(m.URI, "git+") {
+			return m
+		}
+	}
+	return resourceDescriptor{}
+}
+
+// sourceDigest returns the digest of the source revision as "<algorithm>:<value>", preferring the git commit.
+func sourceDigest(digests map[string]string) string {
+	for _, algorithm := range []string{"gitCommit", "sha1"} {
+		if value, ok := digests[algorithm]; ok {
+			return algorithm + ":" + value
+		}
+	}
+	var algorithms []string
+	for algorithm := range digests {
+		algorithms = append(algorithms, algorithm)
+	}
+	if len(algorithms) == 0 {
+		return ""
+	}
+	sort.Strings(algorithms)
+	return algorithms[0] + ":" + digests[algorithms[0]]
+}
@@ -0,0 +1,190 @@
+package source
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const provenanceV02 = `{
+  "_type": "https://in-toto.io/Statement/v0.1",
+  "predicateType": "https://slsa.dev/provenance/v0.2",
+  "predicate": {
+    "builder": {"id": "https://github.com/org/repo/.github/workflows/release.yaml@refs/heads/main"},
+    "buildType": "https://github.com/slsa-framework/slsa-github-generator/container@v1",
+    "invocation": {
+      "configSource": {
+        "uri": "git+https://github.com/org/repo@refs/heads/main",
+        "digest": {"sha1": "a94a8fe5ccb19ba61c4c0873d391e987982fbbd3"}
+      }
+    }
+  }
+}`
+
+func Test_parseProvenance(t *testing.T) {
+	tests := []struct {
+		name      string
+		statement string
+		expected  *ProvenanceMetadata
+		wantErr   require.ErrorAssertionFunc
+	}{
+		{
+			name:      "SLSA v0.2 provenance",
+			statement: provenanceV02,
+			expected: &ProvenanceMetadata{
+				PredicateType:    "https://slsa.dev/provenance/v0.2",
+				BuilderID:        "https://github.com/org/repo/.github/workflows/release.yaml@refs/heads/main",
+				BuildType:        "https://github.com/slsa-framework/slsa-github-generator/container@v1",
+				SourceRepository: "git+https://github.com/org/repo@refs/heads/main",
+				SourceDigest:     "sha1:a94a8fe5ccb19ba61c4c0873d391e987982fbbd3",
+			},
+		},
+		{
+			name: "SLSA v0.2 provenance with the source within the materials",
+			statement: `{
+				"predicateType": "https://slsa.dev/provenance/v0.2",
+				"predicate": {
+					"builder": {"id": "https://mobyproject.org/buildkit@v1"},
+					"materials": [
+						{"uri": "pkg:docker/alpine@3.17", "digest": {"sha256": "ff6bdca1701f"}},
+						{"uri": "git+https://github.com/org/repo", "digest": {"sha1": "a94a8fe5ccb1"}}
+					]
+				}
+			}`,
+			expected: &ProvenanceMetadata{
+				PredicateType:    "https://slsa.dev/provenance/v0.2",
+				BuilderID:        "https://mobyproject.org/buildkit@v1",
+				SourceRepository: "git+https://github.com/org/repo",
+				SourceDigest:     "sha1:a94a8fe5ccb1",
+			},
+		},
+		{
+			name: "SLSA v1 provenance",
+			statement: `{
+				"predicateType": "https://slsa.dev/provenance/v1",
+				"predicate": {
+					"buildDefinition": {
+						"buildType": "https://actions.github.io/buildtypes/workflow/v1",
+						"resolvedDependencies": [
+							{"uri": "git+https://github.com/org/repo@refs/tags/v1.0.0", "digest": {"gitCommit": "a94a8fe5ccb1"}}
+						]
+					},
+					"runDetails": {"builder": {"id": "https://github.com/actions/runner"}}
+				}
+			}`,
+			expected: &ProvenanceMetadata{
+				PredicateType:    "https://slsa.dev/provenance/v1",
+				BuilderID:        "https://github.com/actions/runner",
+				BuildType:        "https://actions.github.io/buildtypes/workflow/v1",
+				SourceRepository: "git+https://github.com/org/repo@refs/tags/v1.0.0",
+				SourceDigest:     "gitCommit:a94a8fe5ccb1",
+			},
+		},
+		{
+			name:      "not a provenance",
+			statement: `{"predicateType": "https://spdx.dev/Document", "predicate": {}}`,
+			expected:  nil,
+		},
+		{
+			name:      "invalid statement",
+			statement: `{"predicateType": [`,
+			wantErr:   require.Error,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.wantErr == nil {
+				test.wantErr = require.NoError
+			}
+			actual, err := parseProvenance([]byte(test.statement))
+			test.wantErr(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
+
+func attestationImage(t *testing.T, mediaType types.MediaType, content []byte) v1.Image {
+	t.Helper()
+
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer: static.NewLayer(content, mediaType),
+	})
+	require.NoError(t, err)
+	return img
+}
+
+func Test_provenanceStatements(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	repo, err := name.NewRepository(fmt.Sprintf("%s/test/image", u.Host))
+	require.NoError(t, err)
+
+	img, err := random.Image(64, 1)
+	require.NoError(t, err)
+	imgDigest, err := img.Digest()
+	require.NoError(t, err)
+
+	// BuildKit adds the (unsigned) provenance to the image index as an attestation manifest
+	idx := mutate.AppendManifests(empty.Index,
+		mutate.IndexAddendum{Add: img},
+		mutate.IndexAddendum{
+			Add: attestationImage(t, inTotoMediaType, []byte(provenanceV02)),
+			Descriptor: v1.Descriptor{
+				Annotations: map[string]string{
+					attestationTypeAnnotation:   attestationManifestType,
+					attestationDigestAnnotation: imgDigest.String(),
+				},
+			},
+		},
+	)
+	require.NoError(t, remote.WriteIndex(repo.Tag("latest"), idx))
+	idxDigest, err := idx.Digest()
+	require.NoError(t, err)
+
+	// cosign attaches the (signed) provenance of the image as a DSSE envelope
+	envelope, err := json.Marshal(map[string]string{
+		"payloadType": "application/vnd.in-toto+json",
+		"payload":     base64.StdEncoding.EncodeToString([]byte(`{"predicateType": "https://example.com/cosign"}`)),
+	})
+	require.NoError(t, err)
+	attTag := repo.Tag(strings.Replace(imgDigest.String(), ":", "-", 1) + ".att")
+	require.NoError(t, remote.Write(attTag, attestationImage(t, dsseMediaType, envelope)))
+
+	metadata := ImageMetadata{
+		ManifestDigest: imgDigest.String(),
+		RepoDigests:    []string{repo.Digest(idxDigest.String()).String()},
+	}
+	statements, err := provenanceStatements(context.Background(), metadata, nil)
+	require.NoError(t, err)
+	require.Len(t, statements, 2)
+	assert.JSONEq(t, provenanceV02, string(statements[0]))
+	assert.JSONEq(t, `{"predicateType": "https://example.com/cosign"}`, string(statements[1]))
+
+	_, err = provenanceStatements(context.Background(), ImageMetadata{UserInput: "image:latest"}, nil)
+	require.Error(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = provenanceStatements(ctx, metadata, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
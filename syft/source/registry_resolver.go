@@ -0,0 +1,287 @@
+package source
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/anchore/stereoscope/pkg/file"
+	"github.com/anchore/stereoscope/pkg/filetree"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source/internal/lazyimage"
+)
+
+// mimeTypeWorkers is the number of files whose MIME type is detected concurrently (which may require a ranged read
+// from the registry for each file).
+const mimeTypeWorkers = 8
+
+var _ FileResolver = (*registryResolver)(nil)
+
+// registryResolver implements path and content access for the squashed representation of an image within a registry,
+// where file contents are only fetched when requested.
+type registryResolver struct {
+	fileTree       *filetree.FileTree
+	files          map[file.ID]*lazyimage.File
+	regularFiles   []file.Reference
+	mimeTypeOnce   sync.Once
+	refsByMIMEType map[string][]file.Reference
+}
+
+// newRegistryResolver indexes the layers of the given image and returns a resolver for the squashed filesystem.
+func newRegistryResolver(img *lazyimage.Image) (*registryResolver, error) {
+	files, err := img.Squash()
+	if err != nil {
+		return nil, fmt.Errorf("unable to index image layers: %w", err)
+	}
+
+	r := &registryResolver{
+		fileTree: filetree.NewFileTree(),
+		files:    make(map[file.ID]*lazyimage.File),
+	}
+	for _, f := range files {
+		if err := r.addFileToIndex(f); err != nil {
+			log.Debugf("unable to index path=%q: %+v", f.Path, err)
+		}
+	}
+	return r, nil
+}
+
+func (r *registryResolver) addFileToIndex(f *lazyimage.File) error {
+	var ref *file.Reference
+	var err error
+	switch f.Type {
+	case tar.TypeDir:
+		ref, err = r.fileTree.AddDir(file.Path(f.Path))
+	case tar.TypeSymlink:
+		// note: if the link is not absolute (e.g, /dev/stderr -> fd/2 ) we need to resolve it relative to the directory
+		linkTarget := f.Linkname
+		if !path.IsAbs(linkTarget) {
+			linkTarget = path.Join(path.Dir(f.Path), linkTarget)
+		}
+		ref, err = r.fileTree.AddSymLink(file.Path(f.Path), file.Path(linkTarget))
+	default:
+		ref, err = r.fileTree.AddFile(file.Path(f.Path))
+	}
+	if err != nil {
+		return err
+	}
+	if ref != nil {
+		r.files[ref.ID()] = f
+		if f.Type == tar.TypeReg {
+			r.regularFiles = append(r.regularFiles, *ref)
+		}
+	}
+	return nil
+}
+
+func (r *registryResolver) newLocation(realPath, virtualPath string, ref file.Reference) Location {
+	location := Location{
+		Coordinates: Coordinates{
+			RealPath: realPath,
+		},
+		ref: ref,
+	}
+	if f, ok := r.files[ref.ID()]; ok {
+		location.FileSystemID = f.Layer
+	}
+	if virtualPath != realPath {
+		location.VirtualPath = virtualPath
+	}
+	return location
+}
+
+// HasPath indicates if the given path exists in the underlying source.
+func (r *registryResolver) HasPath(p string) bool {
+	return r.fileTree.HasPath(file.Path(p))
+}
+
+// FilesByPath returns all file.References that match the given paths within the squashed representation of the image.
+func (r *registryResolver) FilesByPath(paths ...string) ([]Location, error) {
+	uniqueFileIDs := file.NewFileReferenceSet()
+	uniqueLocations := make([]Location, 0)
+
+	for _, p := range paths {
+		exists, ref, err := r.fileTree.File(file.Path(p), filetree.FollowBasenameLinks)
+		if err != nil {
+			return nil, err
+		}
+		if !exists || ref == nil {
+			continue
+		}
+
+		// don't consider directories (special case: there is no path information for /)
+		if ref.RealPath == "/" {
+			continue
+		}
+		if f, ok := r.files[ref.ID()]; ok && f.Type == tar.TypeDir {
+			continue
+		}
+
+		if !uniqueFileIDs.Contains(*ref) {
+			uniqueFileIDs.Add(*ref)
+			uniqueLocations = append(uniqueLocations, r.newLocation(string(ref.RealPath), p, *ref))
+		}
+	}
+
+	return uniqueLocations, nil
+}
+
+// FilesByGlob returns all file.References that match the given path glob pattern within the squashed representation of the image.
+func (r *registryResolver) FilesByGlob(patterns ...string) ([]Location, error) {
+	uniqueFileIDs := file.NewFileReferenceSet()
+	uniqueLocations := make([]Location, 0)
+
+	for _, pattern := range patterns {
+		results, err := r.fileTree.FilesByGlob(pattern, filetree.FollowBasenameLinks)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve files by glob (%s): %w", pattern, err)
+		}
+
+		for _, result := range results {
+			if result.MatchPath == "/" {
+				continue
+			}
+			if f, ok := r.files[result.Reference.ID()]; ok && f.Type == tar.TypeDir {
+				continue
+			}
+
+			if !uniqueFileIDs.Contains(result.Reference) {
+				uniqueFileIDs.Add(result.Reference)
+				uniqueLocations = append(uniqueLocations, r.newLocation(string(result.Reference.RealPath), string(result.MatchPath), result.Reference))
+			}
+		}
+	}
+
+	return uniqueLocations, nil
+}
+
+// RelativeFileByPath fetches a single file at the given path relative to the layer squash of the given reference.
+// For the registryResolver, this is a simple path lookup.
+func (r *registryResolver) RelativeFileByPath(_ Location, p string) *Location {
+	paths, err := r.FilesByPath(p)
+	if err != nil {
+		return nil
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	return &paths[0]
+}
+
+// FileContentsByLocation fetches file contents for a single file reference, fetching them from the registry if needed.
+// If the path does not exist an error is returned.
+func (r *registryResolver) FileContentsByLocation(location Location) (io.ReadCloser, error) {
+	f, ok := r.files[location.ref.ID()]
+	if !ok {
+		return nil, fmt.Errorf("unable to find path=%q in image index", location.RealPath)
+	}
+
+	if f.Type == tar.TypeSymlink {
+		// the location we are searching may be a symlink, we should always work with the resolved file
+		locations, err := r.FilesByPath(location.RealPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve content location at location=%+v: %w", location, err)
+		}
+
+		switch len(locations) {
+		case 0:
+			return nil, fmt.Errorf("link resolution failed while resolving content location: %+v", location)
+		case 1:
+			f, ok = r.files[locations[0].ref.ID()]
+			if !ok {
+				return nil, fmt.Errorf("unable to find path=%q in image index", locations[0].RealPath)
+			}
+		default:
+			return nil, fmt.Errorf("link resolution resulted in multiple results while resolving content location: %+v", location)
+		}
+	}
+
+	return f.Open()
+}
+
+func (r *registryResolver) AllLocations() <-chan Location {
+	results := make(chan Location)
+	go func() {
+		defer close(results)
+		for _, ref := range r.fileTree.AllFiles(file.AllTypes...) {
+			results <- r.newLocation(string(ref.RealPath), string(ref.RealPath), ref)
+		}
+	}()
+	return results
+}
+
+func (r *registryResolver) FilesByMIMEType(types ...string) ([]Location, error) {
+	r.mimeTypeOnce.Do(r.indexMIMETypes)
+
+	var locations []Location
+	for _, ty := range types {
+		for _, ref := range r.refsByMIMEType[ty] {
+			locations = append(locations, r.newLocation(string(ref.RealPath), string(ref.RealPath), ref))
+		}
+	}
+	return locations, nil
+}
+
+// indexMIMETypes detects the MIME type of all regular files. This is deferred until first requested since it requires
+// the leading bytes of each file.
+func (r *registryResolver) indexMIMETypes() {
+	refs := make(chan file.Reference)
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+
+	r.refsByMIMEType = make(map[string][]file.Reference)
+	for i := 0; i < mimeTypeWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ref := range refs {
+				mimeType := r.files[ref.ID()].MIMEType()
+				if mimeType == "" {
+					continue
+				}
+				lock.Lock()
+				r.refsByMIMEType[mimeType] = append(r.refsByMIMEType[mimeType], ref)
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for _, ref := range r.regularFiles {
+		refs <- ref
+	}
+	close(refs)
+	wg.Wait()
+
+	// keep results stable regardless of the order files were processed in
+	for _, refs := range r.refsByMIMEType {
+		sort.Slice(refs, func(i, j int) bool {
+			return refs[i].RealPath < refs[j].RealPath
+		})
+	}
+}
+
+func (r *registryResolver) FileMetadataByLocation(location Location) (FileMetadata, error) {
+	f, exists := r.files[location.ref.ID()]
+	if !exists {
+		return FileMetadata{}, fmt.Errorf("location: %+v : %w", location, os.ErrNotExist)
+	}
+
+	attributes, capabilities := newExtendedAttributes(f.Xattrs)
+
+	return FileMetadata{
+		Mode:               f.Mode,
+		Type:               newFileTypeFromTarHeaderTypeFlag(f.Type),
+		UserID:             f.UID,
+		GroupID:            f.GID,
+		LinkDestination:    f.Linkname,
+		Size:               f.Size,
+		MIMEType:           f.MIMEType(),
+		ExtendedAttributes: attributes,
+		Capabilities:       capabilities,
+	}, nil
+}
@@ -0,0 +1,137 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/anchore/stereoscope/pkg/image"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/internal/registryclient"
+	"github.com/anchore/syft/syft/source/internal/lazyimage"
+)
+
+// generateLazyRegistrySource creates an image source for an image within a registry without pulling the image. Only the
+// manifest and config are fetched up front; layers are fetched (or partially read) when the file resolver is created,
+// until the given context is canceled.
+func generateLazyRegistrySource(ctx context.Context, in Input, registryOptions *image.RegistryOptions) (*Source, func(), error) {
+	opts := lazyimage.Options{
+		Transport: registryclient.Transport(registryOptions),
+		Keychain:  registryclient.NewKeychain(registryOptions),
+	}
+	if registryOptions != nil {
+		opts.InsecureUseHTTP = registryOptions.InsecureUseHTTP
+	}
+	if in.Platform != "" {
+		platform, err := v1.ParsePlatform(in.Platform)
+		if err != nil {
+			return nil, func() {}, fmt.Errorf("invalid platform=%q: %w", in.Platform, err)
+		}
+		opts.Platform = platform
+	}
+
+	img, err := lazyimage.Fetch(ctx, in.Location, opts)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("could not fetch image %q: %w", in.Location, err)
+	}
+	cleanup := func() {
+		if err := img.Cleanup(); err != nil {
+			log.Warnf("unable to cleanup image=%q: %+v", in.UserInput, err)
+		}
+	}
+
+	metadata, err := newLazyImageMetadata(img, in.Location)
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("could not populate source with image: %w", err)
+	}
+
+	s := Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Scheme:        ImageScheme,
+			ImageMetadata: metadata,
+		},
+		lazyImage: img,
+	}
+	s.SetID()
+	return &s, cleanup, nil
+}
+
+// newLazyImageMetadata creates image metadata from the manifest and config of an image within a registry. Since layers
+// have not been pulled, layer (and image) sizes are the compressed sizes from the manifest.
+func newLazyImageMetadata(img *lazyimage.Image, userInput string) (ImageMetadata, error) {
+	remote := img.Remote
+	rawManifest, err := remote.RawManifest()
+	if err != nil {
+		return ImageMetadata{}, fmt.Errorf("unable to get image manifest: %w", err)
+	}
+	rawConfig, err := remote.RawConfigFile()
+	if err != nil {
+		return ImageMetadata{}, fmt.Errorf("unable to get image config: %w", err)
+	}
+	config, err := remote.ConfigFile()
+	if err != nil {
+		return ImageMetadata{}, fmt.Errorf("unable to parse image config: %w", err)
+	}
+	configDigest, err := remote.ConfigName()
+	if err != nil {
+		return ImageMetadata{}, fmt.Errorf("unable to get image ID: %w", err)
+	}
+	manifestDigest, err := remote.Digest()
+	if err != nil {
+		return ImageMetadata{}, fmt.Errorf("unable to get image manifest digest: %w", err)
+	}
+	mediaType, err := remote.MediaType()
+	if err != nil {
+		return ImageMetadata{}, fmt.Errorf("unable to get image media type: %w", err)
+	}
+	layers, err := remote.Layers()
+	if err != nil {
+		return ImageMetadata{}, fmt.Errorf("unable to get image layers: %w", err)
+	}
+
+	var tags []string
+	if tag, ok := img.Reference.(name.Tag); ok {
+		tags = append(tags, tag.String())
+	}
+
+	metadata := ImageMetadata{
+		UserInput:      userInput,
+		ID:             configDigest.String(),
+		ManifestDigest: manifestDigest.String(),
+		MediaType:      string(mediaType),
+		Tags:           tags,
+		Layers:         make([]LayerMetadata, len(layers)),
+		RawManifest:    rawManifest,
+		RawConfig:      rawConfig,
+		RepoDigests:    []string{fmt.Sprintf("%s@%s", img.Reference.Context().Name(), manifestDigest)},
+		Architecture:   config.Architecture,
+		Variant:        config.Variant,
+		OS:             config.OS,
+	}
+
+	for idx, l := range layers {
+		diffID, err := l.DiffID()
+		if err != nil {
+			return ImageMetadata{}, fmt.Errorf("unable to get layer diff ID: %w", err)
+		}
+		layerMediaType, err := l.MediaType()
+		if err != nil {
+			return ImageMetadata{}, fmt.Errorf("unable to get layer media type: %w", err)
+		}
+		size, err := l.Size()
+		if err != nil {
+			return ImageMetadata{}, fmt.Errorf("unable to get layer size: %w", err)
+		}
+		metadata.Layers[idx] = LayerMetadata{
+			MediaType: string(layerMediaType),
+			Digest:    diffID.String(),
+			Size:      size,
+		}
+		metadata.Size += size
+	}
+	return metadata, nil
+}
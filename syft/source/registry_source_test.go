@@ -0,0 +1,198 @@
+package source
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/anchore/stereoscope/pkg/image"
+)
+
+type testLayerEntry struct {
+	name     string
+	typeflag byte
+	linkname string
+	contents string
+}
+
+func newTestLayer(t *testing.T, entries ...testLayerEntry) v1.Layer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		header := &tar.Header{
+			Name:     e.name,
+			Typeflag: e.typeflag,
+			Linkname: e.linkname,
+			Mode:     0644,
+			Size:     int64(len(e.contents)),
+		}
+		if e.typeflag == tar.TypeDir {
+			header.Mode = 0755
+		}
+		require.NoError(t, tw.WriteHeader(header))
+		_, err := tw.Write([]byte(e.contents))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+	require.NoError(t, err)
+	return layer
+}
+
+func TestNew_WithLazyRegistryLayers(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	lower := newTestLayer(t,
+		testLayerEntry{name: "etc/", typeflag: tar.TypeDir},
+		testLayerEntry{name: "etc/os-release", typeflag: tar.TypeReg, contents: "ID=lower\n"},
+		testLayerEntry{name: "etc/removed", typeflag: tar.TypeReg, contents: "removed"},
+		testLayerEntry{name: "opt/app/", typeflag: tar.TypeDir},
+		testLayerEntry{name: "opt/app/hidden", typeflag: tar.TypeReg, contents: "hidden"},
+		testLayerEntry{name: "usr/lib/libfoo.so.1", typeflag: tar.TypeReg, contents: "libfoo"},
+		testLayerEntry{name: "usr/lib/libfoo.so", typeflag: tar.TypeSymlink, linkname: "libfoo.so.1"},
+	)
+	upper := newTestLayer(t,
+		testLayerEntry{name: "etc/os-release", typeflag: tar.TypeReg, contents: "ID=upper\n"},
+		testLayerEntry{name: "etc/.wh.removed", typeflag: tar.TypeReg},
+		testLayerEntry{name: "opt/app/.wh..wh..opq", typeflag: tar.TypeReg},
+		testLayerEntry{name: "opt/app/new", typeflag: tar.TypeReg, contents: "new"},
+		testLayerEntry{name: "usr/lib/libbar.so.1", typeflag: tar.TypeLink, linkname: "opt/app/new"},
+	)
+	img, err := mutate.AppendLayers(empty.Image, lower, upper)
+	require.NoError(t, err)
+
+	reference := fmt.Sprintf("%s/lazy/image:latest", u.Host)
+	ref, err := name.ParseReference(reference)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	upperDiffID, err := upper.DiffID()
+	require.NoError(t, err)
+
+	in := Input{
+		UserInput:   "registry:" + reference,
+		Scheme:      ImageScheme,
+		ImageSource: image.OciRegistrySource,
+		Location:    reference,
+		LazyLayers:  true,
+	}
+	src, cleanup, err := New(in, &image.RegistryOptions{InsecureUseHTTP: true}, nil)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+	require.NoError(t, err)
+
+	assert.Nil(t, src.Image)
+	assert.Equal(t, ImageScheme, src.Metadata.Scheme)
+	assert.Len(t, src.Metadata.ImageMetadata.Layers, 2)
+	assert.Equal(t, upperDiffID.String(), src.Metadata.ImageMetadata.Layers[1].Digest)
+	assert.NotEmpty(t, src.Metadata.ID)
+
+	_, err = src.FileResolver(AllLayersScope)
+	assert.Error(t, err)
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+
+	contentsOf := func(p string) string {
+		locations, err := resolver.FilesByPath(p)
+		require.NoError(t, err)
+		require.Len(t, locations, 1, p)
+		reader, err := resolver.FileContentsByLocation(locations[0])
+		require.NoError(t, err)
+		defer reader.Close()
+		contents, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		return string(contents)
+	}
+
+	assert.Equal(t, "ID=upper\n", contentsOf("/etc/os-release"))
+	assert.Equal(t, "new", contentsOf("/opt/app/new"))
+	assert.Equal(t, "new", contentsOf("/usr/lib/libbar.so.1"))
+	assert.Equal(t, "libfoo", contentsOf("/usr/lib/libfoo.so"))
+
+	locations, err := resolver.FilesByPath("/etc/os-release")
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, upperDiffID.String(), locations[0].FileSystemID)
+
+	for _, p := range []string{"/etc/removed", "/opt/app/hidden"} {
+		assert.False(t, resolver.HasPath(p), p)
+	}
+
+	links, err := resolver.FilesByPath("/usr/lib/libfoo.so")
+	require.NoError(t, err)
+	if assert.Len(t, links, 1) {
+		assert.Equal(t, "/usr/lib/libfoo.so.1", links[0].RealPath)
+		assert.Equal(t, "/usr/lib/libfoo.so", links[0].VirtualPath)
+	}
+
+	globbed, err := resolver.FilesByGlob("**/etc/*")
+	require.NoError(t, err)
+	assert.Len(t, globbed, 1)
+
+	metadata, err := resolver.FileMetadataByLocation(locations[0])
+	require.NoError(t, err)
+	assert.Equal(t, RegularFile, metadata.Type)
+	assert.Equal(t, int64(len("ID=upper\n")), metadata.Size)
+}
+
+func TestNewWithContext_CanceledLazyRegistryLayers(t *testing.T) {
+	server := httptest.NewServer(registry.New())
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	layer := newTestLayer(t,
+		testLayerEntry{name: "etc/os-release", typeflag: tar.TypeReg, contents: "ID=lazy\n"},
+	)
+	img, err := mutate.AppendLayers(empty.Image, layer)
+	require.NoError(t, err)
+
+	reference := fmt.Sprintf("%s/lazy/canceled:latest", u.Host)
+	ref, err := name.ParseReference(reference)
+	require.NoError(t, err)
+	require.NoError(t, remote.Write(ref, img))
+
+	in := Input{
+		UserInput:   "registry:" + reference,
+		Scheme:      ImageScheme,
+		ImageSource: image.OciRegistrySource,
+		Location:    reference,
+		LazyLayers:  true,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	src, cleanup, err := NewWithContext(ctx, in, &image.RegistryOptions{InsecureUseHTTP: true}, nil)
+	if cleanup != nil {
+		t.Cleanup(cleanup)
+	}
+	require.NoError(t, err)
+
+	// layers are fetched when the resolver is created, which no longer happens once the context is canceled
+	cancel()
+	_, err = src.FileResolver(SquashedScope)
+	assert.ErrorIs(t, err, context.Canceled)
+}
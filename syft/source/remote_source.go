@@ -0,0 +1,282 @@
+package source
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"golang.org/x/oauth2/google"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+const (
+	s3Scheme = "s3"
+	gsScheme = "gs"
+
+	// s3UnsignedPayload is used as the payload hash when signing S3 requests, since GET requests have no body
+	s3UnsignedPayload     = "UNSIGNED-PAYLOAD"
+	defaultS3Region       = "us-east-1"
+	gcsReadOnlyScope      = "https://www.googleapis.com/auth/devstorage.read_only"
+	defaultRemoteArtifact = "artifact"
+)
+
+// remoteArtifactSchemes are the URL schemes of artifacts that are downloaded and cataloged as a file.
+var remoteArtifactSchemes = []string{s3Scheme, gsScheme, "https", "http"}
+
+// OriginMetadata describes where a downloaded artifact was retrieved from.
+type OriginMetadata struct {
+	URL    string `json:"url"`    // the artifact URL (without any credentials or query parameters)
+	Digest string `json:"digest"` // the digest of the artifact contents as downloaded
+}
+
+func isRemoteArtifactLocation(userInput string) bool {
+	for _, scheme := range remoteArtifactSchemes {
+		if strings.HasPrefix(userInput, scheme+"://") {
+			return true
+		}
+	}
+	return false
+}
+
+// originURL returns the given URL without any secrets (passwords or query parameters, such as pre-signed URL
+// signatures), which is suitable to record in the SBOM.
+func originURL(u *url.URL) string {
+	origin := *u
+	origin.RawQuery = ""
+	origin.Fragment = ""
+	if origin.User != nil {
+		origin.User = url.User(origin.User.Username())
+	}
+	return origin.String()
+}
+
+// generateRemoteFileSource creates a file source from an artifact referenced by URL. The artifact is streamed to a
+// temporary file (hashing along the way) and, like any other file source, archives are unarchived before cataloging.
+func generateRemoteFileSource(ctx context.Context, in Input) (*Source, func(), error) {
+	u, err := url.Parse(in.Location)
+	if err != nil || u.Host == "" {
+		return nil, func() {}, fmt.Errorf("invalid artifact URL %q", in.Location)
+	}
+	origin := originURL(u)
+
+	dir, err := os.MkdirTemp("", "syft-remote-")
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("unable to create directory for artifact: %w", err)
+	}
+	removeDir := func() {
+		if err := os.RemoveAll(dir); err != nil {
+			log.Warnf("unable to remove artifact dir=%q: %+v", dir, err)
+		}
+	}
+
+	artifactPath, digest, err := downloadArtifact(ctx, u, dir)
+	if err != nil {
+		return nil, removeDir, fmt.Errorf("unable to download %q: %w", origin, err)
+	}
+
+	analysisPath, analysisCleanup := fileAnalysisPath(artifactPath)
+	cleanup := func() {
+		analysisCleanup()
+		removeDir()
+	}
+
+	s := Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Scheme: FileScheme,
+			Path:   origin,
+			Origin: &OriginMetadata{
+				URL:    origin,
+				Digest: digest,
+			},
+		},
+		path: analysisPath,
+	}
+	s.SetID()
+
+	return &s, cleanup, nil
+}
+
+// downloadArtifact streams the artifact into the given directory, returning the path to the artifact and the digest of
+// its contents. The artifact file name is kept (when known) since archives are detected by file extension.
+func downloadArtifact(ctx context.Context, u *url.URL, dir string) (string, string, error) {
+	resp, err := openArtifact(ctx, u)
+	if err != nil {
+		return "", "", err
+	}
+	defer internal.CloseAndLogError(resp.Body, originURL(u))
+
+	artifactPath := filepath.Join(dir, artifactName(u, resp.Header))
+	fh, err := os.Create(artifactPath)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to create artifact file: %w", err)
+	}
+	defer internal.CloseAndLogError(fh, artifactPath)
+
+	hasher := sha256.New()
+	size, err := io.Copy(io.MultiWriter(fh, hasher), resp.Body)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to read artifact: %w", err)
+	}
+	if resp.ContentLength >= 0 && size != resp.ContentLength {
+		return "", "", fmt.Errorf("incomplete artifact: read %d of %d bytes", size, resp.ContentLength)
+	}
+
+	digest := fmt.Sprintf("sha256:%x", hasher.Sum(nil))
+	log.Debugf("downloaded artifact=%q size=%d digest=%q", originURL(u), size, digest)
+	return artifactPath, digest, nil
+}
+
+// artifactName returns the file name of the artifact, preferring the name given by the server (if any).
+func artifactName(u *url.URL, header http.Header) string {
+	if _, params, err := mime.ParseMediaType(header.Get("Content-Disposition")); err == nil {
+		if name := path.Base(filepath.ToSlash(params["filename"])); params["filename"] != "" && isSafeFileName(name) {
+			return name
+		}
+	}
+	if name := path.Base(u.Path); isSafeFileName(name) {
+		return name
+	}
+	return defaultRemoteArtifact
+}
+
+func isSafeFileName(name string) bool {
+	switch name {
+	case "", ".", "..", "/":
+		return false
+	}
+	return true
+}
+
+// openArtifact requests the artifact contents, authenticating with the ambient cloud credentials for s3:// and gs://
+// URLs when available (otherwise the object must be publicly readable).
+func openArtifact(ctx context.Context, u *url.URL) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+	switch u.Scheme {
+	case s3Scheme:
+		resp, err = openS3Object(ctx, u.Host, strings.TrimPrefix(u.Path, "/"))
+	case gsScheme:
+		resp, err = openGCSObject(ctx, u.Host, strings.TrimPrefix(u.Path, "/"))
+	default:
+		resp, err = getArtifact(ctx, u.String(), nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		internal.CloseAndLogError(resp.Body, originURL(u))
+		return nil, fmt.Errorf("unexpected response: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+func getArtifact(ctx context.Context, location string, prepare func(*http.Request) error) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, err
+	}
+	if prepare != nil {
+		if err := prepare(req); err != nil {
+			return nil, err
+		}
+	}
+	return http.DefaultClient.Do(req)
+}
+
+func openS3Object(ctx context.Context, bucket, key string) (*http.Response, error) {
+	cfg, err := config.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load AWS configuration: %w", err)
+	}
+
+	var creds *aws.Credentials
+	if cfg.Credentials != nil {
+		c, err := cfg.Credentials.Retrieve(ctx)
+		if err != nil {
+			log.Debugf("no AWS credentials found, requesting s3 object anonymously: %+v", err)
+		} else {
+			creds = &c
+		}
+	}
+
+	region := cfg.Region
+	if region == "" {
+		region = defaultS3Region
+	}
+
+	resp, err := getS3Object(ctx, bucket, key, region, creds)
+	if err != nil {
+		return nil, err
+	}
+
+	// the bucket may be in a different region than configured, which S3 indicates in the response
+	if bucketRegion := resp.Header.Get("X-Amz-Bucket-Region"); resp.StatusCode != http.StatusOK && bucketRegion != "" && bucketRegion != region {
+		internal.CloseAndLogError(resp.Body, bucket)
+		log.Debugf("retrying s3 request for bucket=%q in region=%q", bucket, bucketRegion)
+		return getS3Object(ctx, bucket, key, bucketRegion, creds)
+	}
+	return resp, nil
+}
+
+func getS3Object(ctx context.Context, bucket, key, region string, creds *aws.Credentials) (*http.Response, error) {
+	// note: buckets with dots in the name cannot be used with virtual-hosted style URLs over https
+	location := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", bucket, region, escapeObjectKey(key))
+	if strings.Contains(bucket, ".") {
+		location = fmt.Sprintf("https://s3.%s.amazonaws.com/%s/%s", region, bucket, escapeObjectKey(key))
+	}
+
+	return getArtifact(ctx, location, func(req *http.Request) error {
+		if creds == nil {
+			return nil
+		}
+		req.Header.Set("X-Amz-Content-Sha256", s3UnsignedPayload)
+		// note: S3 expects the object key to be escaped only once within the canonical request
+		return v4.NewSigner().SignHTTP(ctx, *creds, req, s3UnsignedPayload, s3Scheme, region, time.Now(), func(o *v4.SignerOptions) {
+			o.DisableURIPathEscaping = true
+		})
+	})
+}
+
+func openGCSObject(ctx context.Context, bucket, object string) (*http.Response, error) {
+	location := fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, escapeObjectKey(object))
+
+	return getArtifact(ctx, location, func(req *http.Request) error {
+		tokenSource, err := google.DefaultTokenSource(ctx, gcsReadOnlyScope)
+		if err != nil {
+			log.Debugf("no Google Cloud credentials found, requesting gcs object anonymously: %+v", err)
+			return nil
+		}
+		token, err := tokenSource.Token()
+		if err != nil {
+			return fmt.Errorf("unable to get Google Cloud access token: %w", err)
+		}
+		token.SetAuthHeader(req)
+		return nil
+	})
+}
+
+// escapeObjectKey escapes each segment of an object key, keeping the "/" separators.
+func escapeObjectKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,34 @@
+package source
+
+import (
+	"fmt"
+	"sync"
+)
+
+// NewFromResolver creates a new source object tailored to catalog the files of the given resolver, for library users
+// that implement a FileResolver over their own storage (see FileResolver for the semantics catalogers rely on). The
+// files are cataloged as a directory, and the name describes the files within the SBOM.
+func NewFromResolver(resolver FileResolver, name string) (Source, error) {
+	if resolver == nil {
+		return Source{}, fmt.Errorf("no file resolver given")
+	}
+
+	s := Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Scheme: DirectoryScheme,
+			Path:   name,
+		},
+		resolver: resolver,
+	}
+	s.SetID()
+	return s, nil
+}
+
+// customFileResolver returns the resolver given by the library user, leaving out the excluded paths.
+func (s *Source) customFileResolver() FileResolver {
+	if len(s.Exclusions) == 0 {
+		return s.resolver
+	}
+	return NewExcludingResolver(s.resolver, getImageExclusionFunction(s.Exclusions))
+}
@@ -0,0 +1,34 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromResolver(t *testing.T) {
+	mock := NewMockResolverForPaths("/app/package.json", "/app/node_modules/left-pad/package.json")
+
+	src, err := NewFromResolver(mock, "artifact-store")
+	require.NoError(t, err)
+	assert.Equal(t, DirectoryScheme, src.Metadata.Scheme)
+	assert.Equal(t, "artifact-store", src.Metadata.Path)
+	assert.NotEmpty(t, src.ID())
+
+	resolver, err := src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+	assert.Same(t, mock, resolver)
+
+	// exclusions are applied over the given resolver
+	src.Exclusions = []string{"/app/node_modules"}
+	resolver, err = src.FileResolver(SquashedScope)
+	require.NoError(t, err)
+	locations, err := resolver.FilesByGlob("**/package.json")
+	require.NoError(t, err)
+	require.Len(t, locations, 1)
+	assert.Equal(t, "/app/package.json", locations[0].RealPath)
+
+	_, err = NewFromResolver(nil, "missing")
+	assert.Error(t, err)
+}
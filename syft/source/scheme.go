@@ -45,6 +45,29 @@ func DetectScheme(fs afero.Fs, imageDetector sourceDetector, userInput string) (
 			return UnknownScheme, image.UnknownSource, "", fmt.Errorf("unable to expand directory path: %w", err)
 		}
 		return FileScheme, image.UnknownSource, fileLocation, nil
+
+	case strings.HasPrefix(userInput, hostScheme+":"):
+		hostLocation, err := hostLocation(strings.TrimPrefix(userInput, hostScheme+":"))
+		if err != nil {
+			return UnknownScheme, image.UnknownSource, "", err
+		}
+		return DirectoryScheme, image.UnknownSource, hostLocation, nil
+
+	case isGitLocation(userInput):
+		// note: the repository is cloned when the source is created
+		return DirectoryScheme, image.UnknownSource, userInput, nil
+
+	case isRemoteArtifactLocation(userInput):
+		// note: the artifact is downloaded when the source is created
+		return FileScheme, image.UnknownSource, userInput, nil
+
+	case strings.HasPrefix(userInput, sshScheme+"://"):
+		// note: the remote location is parsed (and validated) when the source is created
+		return DirectoryScheme, image.UnknownSource, userInput, nil
+
+	case strings.HasPrefix(userInput, containerdScheme+":"):
+		// note: stereoscope does not have a provider for containerd, so the image is exported by syft instead
+		return ImageScheme, image.UnknownSource, strings.TrimPrefix(userInput, containerdScheme+":"), nil
 	}
 
 	// try the most specific sources first and move out towards more generic sources.
@@ -70,5 +93,10 @@ func DetectScheme(fs afero.Fs, imageDetector sourceDetector, userInput string) (
 		return DirectoryScheme, source, location, nil
 	}
 
+	// compressed image archives are not detected by stereoscope (which only reads uncompressed archives)
+	if archiveSource := detectCompressedImageArchive(fs, location); archiveSource != image.UnknownSource {
+		return ImageScheme, archiveSource, location, nil
+	}
+
 	return FileScheme, source, location, nil
 }
@@ -153,6 +153,51 @@ func TestDetectScheme(t *testing.T) {
 			expectedScheme:   ImageScheme,
 			expectedLocation: "something:latest",
 		},
+		{
+			name:      "explicit-host",
+			userInput: "host:",
+			detection: detectorResult{
+				src: image.UnknownSource,
+			},
+			expectedScheme:   DirectoryScheme,
+			expectedLocation: "/",
+		},
+		{
+			name:      "explicit-host-process",
+			userInput: "host:1234",
+			detection: detectorResult{
+				src: image.UnknownSource,
+			},
+			expectedScheme:   DirectoryScheme,
+			expectedLocation: "/proc/1234/root",
+		},
+		{
+			name:      "explicit-git",
+			userInput: "git+https://github.com/anchore/syft@v0.60.0",
+			detection: detectorResult{
+				src: image.UnknownSource,
+			},
+			expectedScheme:   DirectoryScheme,
+			expectedLocation: "git+https://github.com/anchore/syft@v0.60.0",
+		},
+		{
+			name:      "remote-artifact",
+			userInput: "s3://bucket/path/to/artifact.tar.gz",
+			detection: detectorResult{
+				src: image.UnknownSource,
+			},
+			expectedScheme:   FileScheme,
+			expectedLocation: "s3://bucket/path/to/artifact.tar.gz",
+		},
+		{
+			name:      "found-containerd-image-scheme",
+			userInput: "containerd:something:latest",
+			detection: detectorResult{
+				src: image.UnknownSource,
+			},
+			expectedScheme:   ImageScheme,
+			expectedLocation: "something:latest",
+		},
 		{
 			name:      "explicit-dir",
 			userInput: "dir:some/path-to-dir",
@@ -12,12 +12,16 @@ const (
 	SquashedScope Scope = "Squashed"
 	// AllLayersScope indicates to catalog content on all layers, irregardless if it is visible from the container at runtime.
 	AllLayersScope Scope = "AllLayers"
+	// PerLayerScope indicates to catalog the content visible from each layer independently (the squashed filesystem
+	// representation up to and including the layer), tracking how content changes across layers.
+	PerLayerScope Scope = "PerLayer"
 )
 
 // AllScopes is a slice containing all possible scope options
 var AllScopes = []Scope{
 	SquashedScope,
 	AllLayersScope,
+	PerLayerScope,
 }
 
 // ParseScope returns a scope as indicated from the given string.
@@ -27,6 +31,8 @@ func ParseScope(userStr string) Scope {
 		return SquashedScope
 	case "all-layers", strings.ToLower(AllLayersScope.String()):
 		return AllLayersScope
+	case "per-layer", strings.ToLower(PerLayerScope.String()):
+		return PerLayerScope
 	}
 	return UnknownScope
 }
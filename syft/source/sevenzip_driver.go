@@ -0,0 +1,105 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source/internal/sevenzip"
+)
+
+// maxSymlinkDestinationSize bounds the contents of archive entries that are symlinks (which is the symlink destination)
+const maxSymlinkDestinationSize = 4096
+
+var _ filesystemDriver = sevenZipDriver{}
+
+// sevenZipDriver extracts 7z archives, which (unlike other archive formats) cannot be unarchived by extension.
+type sevenZipDriver struct{}
+
+func (d sevenZipDriver) String() string {
+	return "7z"
+}
+
+func (d sevenZipDriver) detect(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	return sevenzip.IsSevenZip(f)
+}
+
+func (d sevenZipDriver) extract(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	archive, err := sevenzip.Open(f)
+	if err != nil {
+		return err
+	}
+
+	return archive.Walk(func(entry sevenzip.Entry, contents io.Reader) error {
+		target, err := safeJoin(dir, entry.Name)
+		if err != nil {
+			log.Debugf("skipping 7z entry: %+v", err)
+			return nil
+		}
+
+		err = extractSevenZipEntry(entry, contents, target)
+		if errors.Is(err, sevenzip.ErrUnsupportedMethod) {
+			// the remaining entries may still be extracted
+			log.Debugf("skipping 7z entry=%q: %+v", entry.Name, err)
+			_ = os.Remove(target)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to extract 7z entry=%q: %w", entry.Name, err)
+		}
+		return nil
+	})
+}
+
+func extractSevenZipEntry(entry sevenzip.Entry, contents io.Reader, target string) error {
+	switch {
+	case entry.Mode.IsDir():
+		return os.MkdirAll(target, 0755)
+
+	case entry.Mode&fs.ModeSymlink != 0:
+		destination, err := io.ReadAll(io.LimitReader(contents, maxSymlinkDestinationSize))
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		_ = os.Remove(target)
+		return os.Symlink(string(destination), target)
+
+	case entry.Mode.IsRegular():
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		_ = os.Remove(target)
+		fh, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, entry.Mode.Perm()|0600)
+		if err != nil {
+			return err
+		}
+		defer internal.CloseAndLogError(fh, target)
+
+		_, err = io.Copy(fh, contents)
+		return err
+
+	default:
+		// device files, named pipes, and sockets hold no content to catalog
+		return nil
+	}
+}
@@ -0,0 +1,91 @@
+package source
+
+import (
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromFile_WithCompressedArchive(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "zstd compressed tar archive",
+			input: "test-fixtures/archives/rootfs.tar.zst",
+		},
+		{
+			name:  "xz compressed tar archive",
+			input: "test-fixtures/archives/rootfs.tar.xz",
+		},
+		{
+			name:  "bzip2 compressed tar archive",
+			input: "test-fixtures/archives/rootfs.tar.bz2",
+		},
+		{
+			name:  "solid LZMA2 7z archive with a compressed header",
+			input: "test-fixtures/archives/rootfs.7z",
+		},
+		{
+			name:  "LZMA 7z archive",
+			input: "test-fixtures/archives/rootfs-lzma.7z",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, cleanup := NewFromFile(test.input)
+			if cleanup != nil {
+				t.Cleanup(cleanup)
+			}
+
+			assert.Equal(t, test.input, src.Metadata.Path)
+			assert.NotEqual(t, src.Metadata.Path, src.path)
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			refs, err := resolver.FilesByPath("/etc/os-release", "/usr/lib/libfoo.so.1", "/usr/share/doc/foo/empty")
+			require.NoError(t, err)
+			assert.Len(t, refs, 3)
+
+			links, err := resolver.FilesByPath("/bin/libfoo-link")
+			require.NoError(t, err)
+			if assert.Len(t, links, 1) {
+				assert.Equal(t, "usr/lib/libfoo.so.1", links[0].RealPath)
+			}
+
+			osRelease, err := resolver.FilesByPath("/etc/os-release")
+			require.NoError(t, err)
+			require.Len(t, osRelease, 1)
+
+			reader, err := resolver.FileContentsByLocation(osRelease[0])
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = reader.Close() })
+
+			contents, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, "NAME=\"Alpine Linux\"\nID=alpine\nVERSION_ID=3.17.3\n", string(contents))
+		})
+	}
+}
+
+func TestSevenZipDriver_Detect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "test-fixtures/archives/rootfs.7z", expected: true},
+		{input: "test-fixtures/archives/rootfs-lzma.7z", expected: true},
+		{input: "test-fixtures/archives/rootfs.tar.xz", expected: false},
+		{input: "test-fixtures/wim/xpress.wim", expected: false},
+		{input: "test-fixtures/path-detected/.vimrc", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, sevenZipDriver{}.detect(test.input))
+		})
+	}
+}
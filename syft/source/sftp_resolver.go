@@ -0,0 +1,320 @@
+package source
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"sync"
+
+	"github.com/pkg/sftp"
+
+	"github.com/anchore/stereoscope/pkg/file"
+	"github.com/anchore/stereoscope/pkg/filetree"
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+var _ FileResolver = (*sftpResolver)(nil)
+
+// sftpResolver implements path and content access for a directory on a remote host over SFTP. Paths are the absolute
+// paths on the remote host; symlinks that resolve outside of the indexed directory are not followed.
+type sftpResolver struct {
+	client         *sftp.Client
+	path           string
+	fileTree       *filetree.FileTree
+	metadata       map[file.ID]FileMetadata
+	pathFilterFns  []pathFilterFn
+	regularFiles   []file.Reference
+	mimeTypeOnce   sync.Once
+	refsByMIMEType map[string][]file.Reference
+	mimeTypes      map[file.ID]string
+}
+
+func newSFTPResolver(client *sftp.Client, root string, pathFilters ...pathFilterFn) (*sftpResolver, error) {
+	r := &sftpResolver{
+		client:        client,
+		path:          path.Clean(root),
+		fileTree:      filetree.NewFileTree(),
+		metadata:      make(map[file.ID]FileMetadata),
+		pathFilterFns: append([]pathFilterFn{isUnallowableFileType, isUnixSystemRuntimePath}, pathFilters...),
+	}
+	return r, r.index()
+}
+
+func (r *sftpResolver) index() error {
+	log.Debugf("indexing remote filesystem path=%q", r.path)
+
+	stager, prog := indexingProgress(r.path)
+	defer prog.SetCompleted()
+
+	walker := r.client.Walk(r.path)
+	for walker.Step() {
+		p := walker.Path()
+		stager.Current = p
+		if err := walker.Err(); err != nil {
+			if p == r.path {
+				return fmt.Errorf("unable to index remote path=%q: %w", p, err)
+			}
+			// don't allow for errors to stop indexing (e.g. a permission denied)
+			log.Warnf("unable to access remote path=%q: %+v", p, err)
+			continue
+		}
+
+		info := walker.Stat()
+		if r.isFiltered(p, info) {
+			if info.IsDir() {
+				walker.SkipDir()
+			}
+			continue
+		}
+
+		if err := r.addPathToIndex(p, info); err != nil {
+			log.Warnf("unable to index remote path=%q: %+v", p, err)
+		}
+	}
+	return nil
+}
+
+func (r *sftpResolver) isFiltered(p string, info os.FileInfo) bool {
+	for _, filterFn := range r.pathFilterFns {
+		if filterFn != nil && filterFn(p, info) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *sftpResolver) addPathToIndex(p string, info os.FileInfo) error {
+	metadata := FileMetadata{
+		Mode: info.Mode(),
+		Type: newFileTypeFromMode(info.Mode()),
+		Size: info.Size(),
+	}
+	if stat, ok := info.Sys().(*sftp.FileStat); ok {
+		metadata.UserID = int(stat.UID)
+		metadata.GroupID = int(stat.GID)
+	}
+
+	var ref *file.Reference
+	var err error
+	switch metadata.Type {
+	case SymbolicLink:
+		var linkTarget string
+		linkTarget, err = r.client.ReadLink(p)
+		if err != nil {
+			return fmt.Errorf("unable to readlink: %w", err)
+		}
+		// note: if the link is not absolute (e.g, /dev/stderr -> fd/2 ) we need to resolve it relative to the directory
+		if !path.IsAbs(linkTarget) {
+			linkTarget = path.Join(path.Dir(p), linkTarget)
+		}
+		metadata.LinkDestination = linkTarget
+		ref, err = r.fileTree.AddSymLink(file.Path(p), file.Path(linkTarget))
+	case Directory:
+		ref, err = r.fileTree.AddDir(file.Path(p))
+	case RegularFile:
+		ref, err = r.fileTree.AddFile(file.Path(p))
+	default:
+		return fmt.Errorf("unsupported file type: %s", metadata.Type)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ref != nil {
+		r.metadata[ref.ID()] = metadata
+		if metadata.Type == RegularFile {
+			r.regularFiles = append(r.regularFiles, *ref)
+		}
+	}
+	return nil
+}
+
+func (r *sftpResolver) isInIndex(ref file.Reference) bool {
+	_, ok := r.metadata[ref.ID()]
+	return ok
+}
+
+// requestPath returns the remote path for the given user path, where relative paths are relative to the indexed directory.
+func (r *sftpResolver) requestPath(userPath string) string {
+	if path.IsAbs(userPath) {
+		return path.Clean(userPath)
+	}
+	return path.Join(r.path, userPath)
+}
+
+// HasPath indicates if the given path exists in the underlying source.
+func (r *sftpResolver) HasPath(userPath string) bool {
+	return r.fileTree.HasPath(file.Path(r.requestPath(userPath)))
+}
+
+// Stringer to represent a remote directory data source
+func (r *sftpResolver) String() string {
+	return fmt.Sprintf("sftp:%s", r.path)
+}
+
+// FilesByPath returns all file.References that match the given paths from the remote directory.
+func (r *sftpResolver) FilesByPath(userPaths ...string) ([]Location, error) {
+	var references = make([]Location, 0)
+
+	for _, userPath := range userPaths {
+		requestPath := r.requestPath(userPath)
+		exists, ref, err := r.fileTree.File(file.Path(requestPath), filetree.FollowBasenameLinks)
+		if err != nil {
+			return nil, err
+		}
+		if !exists || ref == nil || !r.isInIndex(*ref) {
+			continue
+		}
+
+		// don't consider directories
+		if r.metadata[ref.ID()].Type == Directory {
+			continue
+		}
+
+		references = append(references, NewVirtualLocationFromDirectory(string(ref.RealPath), requestPath, *ref))
+	}
+
+	return references, nil
+}
+
+// FilesByGlob returns all file.References that match the given path glob pattern from the remote directory.
+func (r *sftpResolver) FilesByGlob(patterns ...string) ([]Location, error) {
+	result := make([]Location, 0)
+
+	for _, pattern := range patterns {
+		globResults, err := r.fileTree.FilesByGlob(pattern, filetree.FollowBasenameLinks)
+		if err != nil {
+			return nil, err
+		}
+		for _, globResult := range globResults {
+			if !r.isInIndex(globResult.Reference) || r.metadata[globResult.Reference.ID()].Type == Directory {
+				continue
+			}
+			result = append(result, NewVirtualLocationFromDirectory(string(globResult.Reference.RealPath), string(globResult.MatchPath), globResult.Reference))
+		}
+	}
+
+	return result, nil
+}
+
+// RelativeFileByPath fetches a single file at the given path relative to the layer squash of the given reference.
+// This is helpful when attempting to find a file that is in the same layer or lower as another file. For the
+// sftpResolver, this is a simple path lookup.
+func (r *sftpResolver) RelativeFileByPath(_ Location, path string) *Location {
+	paths, err := r.FilesByPath(path)
+	if err != nil {
+		return nil
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	return &paths[0]
+}
+
+// FileContentsByLocation fetches file contents for a single file reference from the remote host.
+// If the path does not exist an error is returned.
+func (r *sftpResolver) FileContentsByLocation(location Location) (io.ReadCloser, error) {
+	if location.ref.RealPath == "" {
+		return nil, errors.New("empty path given")
+	}
+	if !r.isInIndex(location.ref) {
+		// paths that have been excluded from the index (by preference or being inaccessible) are denied
+		return nil, fmt.Errorf("file content is inaccessible path=%q", location.ref.RealPath)
+	}
+	f, err := r.client.Open(string(location.ref.RealPath))
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (r *sftpResolver) AllLocations() <-chan Location {
+	results := make(chan Location)
+	go func() {
+		defer close(results)
+		for _, ref := range r.fileTree.AllFiles(file.AllTypes...) {
+			results <- NewLocationFromDirectory(string(ref.RealPath), ref)
+		}
+	}()
+	return results
+}
+
+func (r *sftpResolver) FileMetadataByLocation(location Location) (FileMetadata, error) {
+	metadata, exists := r.metadata[location.ref.ID()]
+	if !exists {
+		return FileMetadata{}, fmt.Errorf("location: %+v : %w", location, os.ErrNotExist)
+	}
+
+	if metadata.Type == RegularFile {
+		r.mimeTypeOnce.Do(r.indexMIMETypes)
+		metadata.MIMEType = r.mimeTypes[location.ref.ID()]
+	}
+	return metadata, nil
+}
+
+func (r *sftpResolver) FilesByMIMEType(types ...string) ([]Location, error) {
+	r.mimeTypeOnce.Do(r.indexMIMETypes)
+
+	var locations []Location
+	for _, ty := range types {
+		for _, ref := range r.refsByMIMEType[ty] {
+			locations = append(locations, NewLocationFromDirectory(string(ref.RealPath), ref))
+		}
+	}
+	return locations, nil
+}
+
+// indexMIMETypes detects the MIME type of all regular files. This is deferred until first requested since it requires
+// reading the leading bytes of each file from the remote host.
+func (r *sftpResolver) indexMIMETypes() {
+	refs := make(chan file.Reference)
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+
+	r.refsByMIMEType = make(map[string][]file.Reference)
+	r.mimeTypes = make(map[file.ID]string)
+	for i := 0; i < mimeTypeWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ref := range refs {
+				mimeType := r.mimeType(ref)
+				if mimeType == "" {
+					continue
+				}
+				lock.Lock()
+				r.refsByMIMEType[mimeType] = append(r.refsByMIMEType[mimeType], ref)
+				r.mimeTypes[ref.ID()] = mimeType
+				lock.Unlock()
+			}
+		}()
+	}
+
+	for _, ref := range r.regularFiles {
+		refs <- ref
+	}
+	close(refs)
+	wg.Wait()
+
+	// keep results stable regardless of the order files were processed in
+	for _, refs := range r.refsByMIMEType {
+		sort.Slice(refs, func(i, j int) bool {
+			return refs[i].RealPath < refs[j].RealPath
+		})
+	}
+}
+
+func (r *sftpResolver) mimeType(ref file.Reference) string {
+	f, err := r.client.Open(string(ref.RealPath))
+	if err != nil {
+		log.Tracef("unable to open remote path=%q to determine MIME type: %+v", ref.RealPath, err)
+		return ""
+	}
+	defer internal.CloseAndLogError(f, string(ref.RealPath))
+	return file.MIMEType(f)
+}
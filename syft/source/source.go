@@ -8,6 +8,7 @@ package source
 import (
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -22,6 +23,7 @@ import (
 	"github.com/anchore/stereoscope/pkg/image"
 	"github.com/anchore/syft/internal/log"
 	"github.com/anchore/syft/syft/artifact"
+	"github.com/anchore/syft/syft/source/internal/lazyimage"
 )
 
 // Source is an object that captures the data source to be cataloged, configuration, and a specific resolver used
@@ -31,6 +33,13 @@ type Source struct {
 	Image             *image.Image `hash:"ignore"` // the image object to be cataloged (image only)
 	Metadata          Metadata
 	directoryResolver *directoryResolver `hash:"ignore"`
+	lazyImage         *lazyimage.Image   `hash:"ignore"` // the registry image to be cataloged without pulling (image only)
+	registryResolver  *registryResolver  `hash:"ignore"`
+	sftpClient        *sftpConnection    `hash:"ignore"` // the connection to the remote host (ssh only)
+	sftpResolver      *sftpResolver      `hash:"ignore"`
+	fsys              fs.FS              `hash:"ignore"` // the filesystem to be cataloged without touching disk (library use only)
+	fsResolver        *fsResolver        `hash:"ignore"`
+	resolver          FileResolver       `hash:"ignore"` // the resolver over storage of the library user (library use only)
 	path              string
 	mutex             *sync.Mutex
 	Exclusions        []string `hash:"ignore"`
@@ -44,6 +53,9 @@ type Input struct {
 	ImageSource                     image.Source
 	Location                        string
 	Platform                        string
+	LazyLayers                      bool               // fetch only the image contents needed for cataloging (registry images only)
+	Containerd                      *ContainerdOptions // set when the image should be exported from containerd (containerd: scheme only)
+	SSH                             *SSHOptions        // set when the directory is on a remote host (ssh:// scheme only)
 	autoDetectAvailableImageSources bool
 }
 
@@ -56,7 +68,17 @@ func ParseInput(userInput string, platform string, detectAvailableImageSources b
 		return nil, err
 	}
 
-	if source == image.UnknownSource {
+	var containerd *ContainerdOptions
+	if scheme == ImageScheme && parseScheme(userInput) == containerdScheme {
+		containerd = &ContainerdOptions{}
+	}
+
+	var ssh *SSHOptions
+	if scheme == DirectoryScheme && parseScheme(userInput) == sshScheme {
+		ssh = &SSHOptions{}
+	}
+
+	if source == image.UnknownSource && containerd == nil {
 		// only run for these two scheme
 		// only check on packages command, attest we automatically try to pull from userInput
 		switch scheme {
@@ -86,6 +108,8 @@ func ParseInput(userInput string, platform string, detectAvailableImageSources b
 		ImageSource:                     source,
 		Location:                        location,
 		Platform:                        platform,
+		Containerd:                      containerd,
+		SSH:                             ssh,
 		autoDetectAvailableImageSources: detectAvailableImageSources,
 	}, nil
 }
@@ -93,7 +117,7 @@ func ParseInput(userInput string, platform string, detectAvailableImageSources b
 type sourceDetector func(string) (image.Source, string, error)
 
 func NewFromRegistry(in Input, registryOptions *image.RegistryOptions, exclusions []string) (*Source, func(), error) {
-	source, cleanupFn, err := generateImageSource(in, registryOptions)
+	source, cleanupFn, err := generateImageSource(context.Background(), in, registryOptions)
 	if source != nil {
 		source.Exclusions = exclusions
 	}
@@ -102,6 +126,14 @@ func NewFromRegistry(in Input, registryOptions *image.RegistryOptions, exclusion
 
 // New produces a Source based on userInput like dir: or image:tag
 func New(in Input, registryOptions *image.RegistryOptions, exclusions []string) (*Source, func(), error) {
+	return NewWithContext(context.Background(), in, registryOptions, exclusions)
+}
+
+// NewWithContext produces a Source as with New, where fetching the source (pulling an image, downloading an artifact,
+// cloning a repository, or connecting to a remote host) stops when the given context is canceled. For images whose
+// layers are fetched lazily from the registry the context also applies to the layers fetched while the source is
+// cataloged, so callers can bound the whole scan with a deadline.
+func NewWithContext(ctx context.Context, in Input, registryOptions *image.RegistryOptions, exclusions []string) (*Source, func(), error) {
 	var err error
 	fs := afero.NewOsFs()
 	var source *Source
@@ -109,11 +141,32 @@ func New(in Input, registryOptions *image.RegistryOptions, exclusions []string)
 
 	switch in.Scheme {
 	case FileScheme:
-		source, cleanupFn, err = generateFileSource(fs, in.Location)
+		if isRemoteArtifactLocation(in.Location) {
+			source, cleanupFn, err = generateRemoteFileSource(ctx, in)
+		} else {
+			source, cleanupFn, err = generateFileSource(fs, in.Location)
+		}
 	case DirectoryScheme:
-		source, cleanupFn, err = generateDirectorySource(fs, in.Location)
+		switch {
+		case in.SSH != nil:
+			source, cleanupFn, err = generateSSHSource(ctx, in)
+		case isGitLocation(in.Location):
+			source, cleanupFn, err = generateGitSource(ctx, in)
+		default:
+			source, cleanupFn, err = generateDirectorySource(fs, in.Location)
+		}
 	case ImageScheme:
-		source, cleanupFn, err = generateImageSource(in, registryOptions)
+		switch {
+		case in.Containerd != nil:
+			source, cleanupFn, err = generateContainerdSource(ctx, in)
+		case in.LazyLayers && in.ImageSource == image.OciRegistrySource:
+			source, cleanupFn, err = generateLazyRegistrySource(ctx, in, registryOptions)
+		default:
+			if in.LazyLayers {
+				log.Debugf("lazy layer fetching is only supported for registry images, pulling image=%q", in.Location)
+			}
+			source, cleanupFn, err = generateImageSource(ctx, in, registryOptions)
+		}
 	default:
 		err = fmt.Errorf("unable to process input for scanning: %q", in.UserInput)
 	}
@@ -125,13 +178,25 @@ func New(in Input, registryOptions *image.RegistryOptions, exclusions []string)
 	return source, cleanupFn, err
 }
 
-func generateImageSource(in Input, registryOptions *image.RegistryOptions) (*Source, func(), error) {
-	img, cleanup, err := getImageWithRetryStrategy(in, registryOptions)
+func generateImageSource(ctx context.Context, in Input, registryOptions *image.RegistryOptions) (*Source, func(), error) {
+	location := in.Location
+	in, decompressCleanup, err := decompressImageArchive(in)
+	if err != nil {
+		return nil, decompressCleanup, fmt.Errorf("could not read image archive %q: %w", location, err)
+	}
+
+	img, imgCleanup, err := getImageWithRetryStrategy(ctx, in, registryOptions)
+	cleanup := func() {
+		if imgCleanup != nil {
+			imgCleanup()
+		}
+		decompressCleanup()
+	}
 	if err != nil || img == nil {
-		return nil, cleanup, fmt.Errorf("could not fetch image %q: %w", in.Location, err)
+		return nil, cleanup, fmt.Errorf("could not fetch image %q: %w", location, err)
 	}
 
-	s, err := NewFromImage(img, in.Location)
+	s, err := NewFromImage(img, location)
 	if err != nil {
 		return nil, cleanup, fmt.Errorf("could not populate source with image: %w", err)
 	}
@@ -148,9 +213,7 @@ func parseScheme(userInput string) string {
 	return parts[0]
 }
 
-func getImageWithRetryStrategy(in Input, registryOptions *image.RegistryOptions) (*image.Image, func(), error) {
-	ctx := context.TODO()
-
+func getImageWithRetryStrategy(ctx context.Context, in Input, registryOptions *image.RegistryOptions) (*image.Image, func(), error) {
 	var opts []stereoscope.Option
 	if registryOptions != nil {
 		opts = append(opts, stereoscope.WithRegistryOptions(*registryOptions))
@@ -276,6 +339,18 @@ func fileAnalysisPath(path string) (string, func()) {
 	var analysisPath = path
 	var cleanupFn = func() {}
 
+	// if the given file is a filesystem image (as indicated by the file contents) then extract it and use the contents
+	// as the source (e.g. squashfs or initramfs images from firmware).
+	if driver := filesystemDriverFor(path); driver != nil {
+		extractedPath, tmpCleanup, err := extractFilesystemToTmp(path, driver)
+		if err == nil {
+			log.Debugf("source path is a %s filesystem image", driver)
+			return extractedPath, tmpCleanup
+		}
+		log.Warnf("%s filesystem image could not be extracted: %+v", driver, err)
+		tmpCleanup()
+	}
+
 	// if the given file is an archive (as indicated by the file extension and not MIME type) then unarchive it and
 	// use the contents as the source. Note: this does NOT recursively unarchive contents, only the given path is
 	// unarchived.
@@ -327,6 +402,11 @@ func (s *Source) SetID() {
 	case DirectoryScheme:
 		d = digest.FromString(s.Metadata.Path).String()
 	case FileScheme:
+		if s.Metadata.Origin != nil {
+			// the downloaded artifact is no longer at the path given
+			d = s.Metadata.Origin.Digest
+			break
+		}
 		// attempt to use the digest of the contents of the file as the ID
 		file, err := os.Open(s.Metadata.Path)
 		if err != nil {
@@ -388,14 +468,35 @@ func chain(chainID string, layers []LayerMetadata) string {
 func (s *Source) FileResolver(scope Scope) (FileResolver, error) {
 	switch s.Metadata.Scheme {
 	case DirectoryScheme, FileScheme:
+		if s.resolver != nil {
+			return s.customFileResolver(), nil
+		}
 		s.mutex.Lock()
 		defer s.mutex.Unlock()
+		if s.sftpClient != nil {
+			return s.remoteFileResolver()
+		}
+		if s.fsys != nil {
+			return s.fsFileResolver()
+		}
 		if s.directoryResolver == nil {
 			exclusionFunctions, err := getDirectoryExclusionFunctions(s.path, s.Exclusions)
 			if err != nil {
 				return nil, err
 			}
-			resolver, err := newDirectoryResolver(s.path, exclusionFunctions...)
+			if s.Metadata.Scheme == DirectoryScheme {
+				// the exclusions declared within the directory (.syftignore files) are merged with the given exclusions
+				ignoreFn, err := newSyftIgnoreFilter(s.path)
+				if err != nil {
+					return nil, err
+				}
+				exclusionFunctions = append(exclusionFunctions, ignoreFn)
+			}
+			newResolver := newDirectoryResolver
+			if isProcessRootfs(s.path) {
+				newResolver = newRootfsResolver
+			}
+			resolver, err := newResolver(s.path, exclusionFunctions...)
 			if err != nil {
 				return nil, fmt.Errorf("unable to create directory resolver: %w", err)
 			}
@@ -405,13 +506,19 @@ func (s *Source) FileResolver(scope Scope) (FileResolver, error) {
 	case ImageScheme:
 		var resolver FileResolver
 		var err error
-		switch scope {
-		case SquashedScope:
-			resolver, err = newImageSquashResolver(s.Image)
-		case AllLayersScope:
-			resolver, err = newAllLayersResolver(s.Image)
-		default:
-			return nil, fmt.Errorf("bad image scope provided: %+v", scope)
+		if s.lazyImage != nil {
+			resolver, err = s.lazyFileResolver(scope)
+		} else {
+			switch scope {
+			case SquashedScope, PerLayerScope:
+				// packages are cataloged for each layer independently (see LayerFileResolver), while all other content
+				// is cataloged from the squashed perspective
+				resolver, err = newImageSquashResolver(s.Image)
+			case AllLayersScope:
+				resolver, err = newAllLayersResolver(s.Image)
+			default:
+				return nil, fmt.Errorf("bad image scope provided: %+v", scope)
+			}
 		}
 		if err != nil {
 			return nil, err
@@ -425,6 +532,63 @@ func (s *Source) FileResolver(scope Scope) (FileResolver, error) {
 	return nil, fmt.Errorf("unable to determine FilePathResolver with current scheme=%q", s.Metadata.Scheme)
 }
 
+// LayerFileResolver returns a resolver from the perspective of the squashed representation of the given image layer
+// (and all layers below it), where 0 is the lowest layer of the image.
+func (s *Source) LayerFileResolver(layer int) (FileResolver, error) {
+	if s.Metadata.Scheme != ImageScheme {
+		return nil, fmt.Errorf("layers can only be resolved for image sources (scheme=%q)", s.Metadata.Scheme)
+	}
+	if s.lazyImage != nil {
+		return nil, fmt.Errorf("layers cannot be resolved when lazily fetching image layers")
+	}
+
+	squashResolver, err := newLayerSquashResolver(s.Image, layer)
+	if err != nil {
+		return nil, err
+	}
+	var resolver FileResolver = squashResolver
+	// image tree contains all paths, so we filter out the excluded entries afterwards
+	if len(s.Exclusions) > 0 {
+		resolver = NewExcludingResolver(resolver, getImageExclusionFunction(s.Exclusions))
+	}
+	return resolver, nil
+}
+
+// remoteFileResolver returns the resolver for a directory on a remote host, indexing the directory on first use. Note:
+// the caller must hold the source mutex.
+func (s *Source) remoteFileResolver() (FileResolver, error) {
+	if s.sftpResolver == nil {
+		exclusionFunctions, err := getDirectoryExclusionFunctions(s.path, s.Exclusions)
+		if err != nil {
+			return nil, err
+		}
+		resolver, err := newSFTPResolver(s.sftpClient.Client, s.path, exclusionFunctions...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create remote directory resolver: %w", err)
+		}
+		s.sftpResolver = resolver
+	}
+	return s.sftpResolver, nil
+}
+
+// lazyFileResolver returns the resolver for a registry image, indexing the image layers on first use.
+func (s *Source) lazyFileResolver(scope Scope) (FileResolver, error) {
+	if scope != SquashedScope {
+		return nil, fmt.Errorf("only the %q scope is supported when lazily fetching image layers (scope=%q)", SquashedScope, scope)
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.registryResolver == nil {
+		resolver, err := newRegistryResolver(s.lazyImage)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create registry resolver: %w", err)
+		}
+		s.registryResolver = resolver
+	}
+	return s.registryResolver, nil
+}
+
 func unarchiveToTmp(path string, unarchiver archiver.Unarchiver) (string, func(), error) {
 	tempDir, err := os.MkdirTemp("", "syft-archive-contents-")
 	if err != nil {
@@ -120,7 +120,7 @@ func TestSetID(t *testing.T) {
 					Path:   "test-fixtures/image-simple",
 				},
 			},
-			expected: artifact.ID("26e8f4daad203793"),
+			expected: artifact.ID("912e73e6b60ce03"),
 		},
 	}
 
@@ -0,0 +1,52 @@
+package source
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sylabs/squashfs"
+
+	"github.com/anchore/syft/internal"
+)
+
+// squashfsMagic is the (little endian) magic number found at the start of every squashfs 4.0 superblock
+const squashfsMagic = "hsqs"
+
+var _ filesystemDriver = squashfsDriver{}
+
+// squashfsDriver extracts squashfs filesystem images (e.g. firmware root filesystems and snap packages).
+type squashfsDriver struct{}
+
+func (d squashfsDriver) String() string {
+	return "squashfs"
+}
+
+func (d squashfsDriver) detect(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	header := make([]byte, len(squashfsMagic))
+	if _, err := io.ReadFull(f, header); err != nil {
+		return false
+	}
+	return string(header) == squashfsMagic
+}
+
+func (d squashfsDriver) extract(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	reader, err := squashfs.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("unable to read squashfs image: %w", err)
+	}
+
+	return extractFS(reader, dir)
+}
@@ -0,0 +1,292 @@
+package source
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"sync"
+
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+const (
+	// sshScheme is the user input prefix for a directory on a remote host read over SFTP (e.g. "ssh://user@host/path")
+	sshScheme      = "ssh"
+	defaultSSHPort = "22"
+)
+
+// SSHOptions describes how to authenticate to (and verify the identity of) hosts given with the "ssh://" scheme. Keys
+// from a running SSH agent (SSH_AUTH_SOCK) are always offered.
+type SSHOptions struct {
+	IdentityFile          string // an unencrypted private key to authenticate with (default: ~/.ssh/id_ed25519, id_ecdsa, or id_rsa)
+	KnownHostsFile        string // the known hosts used to verify the host key (default: ~/.ssh/known_hosts)
+	InsecureIgnoreHostKey bool   // do not verify the host key (not recommended)
+}
+
+// sshLocation is a directory on a remote host.
+type sshLocation struct {
+	User string
+	Host string // host:port
+	Path string
+}
+
+// parseSSHLocation parses user input of the form "ssh://[user@]host[:port][/path]". When not given the user defaults to
+// the current user and the path defaults to the root of the remote filesystem.
+func parseSSHLocation(userInput string) (sshLocation, error) {
+	u, err := url.Parse(userInput)
+	if err != nil {
+		return sshLocation{}, fmt.Errorf("unable to parse ssh location %q: %w", userInput, err)
+	}
+	if u.Scheme != sshScheme || u.Hostname() == "" {
+		return sshLocation{}, fmt.Errorf("invalid ssh location %q: expected ssh://[user@]host[:port][/path]", userInput)
+	}
+
+	location := sshLocation{
+		Host: u.Host,
+		Path: path.Clean("/" + u.Path),
+	}
+	if u.Port() == "" {
+		location.Host = net.JoinHostPort(u.Hostname(), defaultSSHPort)
+	}
+
+	if u.User != nil {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			return sshLocation{}, fmt.Errorf("passwords are not supported within ssh locations, use an SSH agent or identity file instead")
+		}
+		location.User = u.User.Username()
+	}
+	if location.User == "" {
+		current, err := user.Current()
+		if err != nil {
+			return sshLocation{}, fmt.Errorf("no user given in ssh location %q and unable to determine the current user: %w", userInput, err)
+		}
+		location.User = current.Username
+	}
+
+	return location, nil
+}
+
+func (l sshLocation) String() string {
+	return fmt.Sprintf("ssh://%s@%s%s", l.User, l.Host, l.Path)
+}
+
+// generateSSHSource creates a directory source for a directory on a remote host. The directory is indexed and read
+// over SFTP when the file resolver is created, so nothing needs to be installed on the remote host.
+func generateSSHSource(ctx context.Context, in Input) (*Source, func(), error) {
+	location, err := parseSSHLocation(in.Location)
+	if err != nil {
+		return nil, func() {}, err
+	}
+
+	var opts SSHOptions
+	if in.SSH != nil {
+		opts = *in.SSH
+	}
+
+	client, err := dialSFTP(ctx, location, opts)
+	if err != nil {
+		return nil, func() {}, fmt.Errorf("unable to connect to %q: %w", location, err)
+	}
+	cleanup := func() {
+		if err := client.Close(); err != nil {
+			log.Warnf("unable to close connection to %q: %+v", location, err)
+		}
+	}
+
+	stop := closeOnCancel(ctx, client)
+	info, err := client.Stat(location.Path)
+	stop()
+	if err := ctx.Err(); err != nil {
+		return nil, cleanup, err
+	}
+	if err != nil {
+		return nil, cleanup, fmt.Errorf("unable to stat remote dir=%q: %w", location, err)
+	}
+	if !info.IsDir() {
+		return nil, cleanup, fmt.Errorf("given remote path is not a directory (path=%q)", location)
+	}
+
+	s := Source{
+		mutex: &sync.Mutex{},
+		Metadata: Metadata{
+			Scheme: DirectoryScheme,
+			Path:   location.String(),
+		},
+		path:       location.Path,
+		sftpClient: client,
+	}
+	s.SetID()
+
+	return &s, cleanup, nil
+}
+
+// sftpConnection closes both the SFTP session and the underlying SSH connection.
+type sftpConnection struct {
+	*sftp.Client
+	conn *ssh.Client
+}
+
+func (c sftpConnection) Close() error {
+	if err := c.Client.Close(); err != nil {
+		return err
+	}
+	return c.conn.Close()
+}
+
+func dialSFTP(ctx context.Context, location sshLocation, opts SSHOptions) (*sftpConnection, error) {
+	hostKeyCallback, err := sshHostKeyCallback(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	auth, err := sshAuthMethods(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var dialer net.Dialer
+	netConn, err := dialer.DialContext(ctx, "tcp", location.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	// the SSH handshake and SFTP session setup have no notion of a context, so the connection is closed instead
+	stop := closeOnCancel(ctx, netConn)
+	defer stop()
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, location.Host, &ssh.ClientConfig{
+		User:            location.User,
+		Auth:            auth,
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		if closeErr := netConn.Close(); closeErr != nil {
+			log.Tracef("unable to close connection: %+v", closeErr)
+		}
+		return nil, err
+	}
+	conn := ssh.NewClient(sshConn, chans, reqs)
+
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		if closeErr := conn.Close(); closeErr != nil {
+			log.Warnf("unable to close ssh connection: %+v", closeErr)
+		}
+		return nil, fmt.Errorf("unable to start sftp session: %w", err)
+	}
+
+	return &sftpConnection{Client: client, conn: conn}, nil
+}
+
+// closeOnCancel closes the given connection if the context is canceled before the returned function is called, which
+// unblocks any pending calls on the connection.
+func closeOnCancel(ctx context.Context, c io.Closer) func() {
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := c.Close(); err != nil {
+				log.Tracef("unable to close connection: %+v", err)
+			}
+		case <-done:
+		}
+	}()
+	return func() { close(done) }
+}
+
+func sshHostKeyCallback(opts SSHOptions) (ssh.HostKeyCallback, error) {
+	if opts.InsecureIgnoreHostKey {
+		log.Warn("not verifying the identity of the remote host")
+		return ssh.InsecureIgnoreHostKey(), nil //nolint:gosec
+	}
+
+	knownHostsFile := opts.KnownHostsFile
+	if knownHostsFile == "" {
+		knownHostsFile = filepath.Join("~", ".ssh", "known_hosts")
+	}
+	knownHostsFile, err := homedir.Expand(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to expand known hosts path: %w", err)
+	}
+
+	callback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read known hosts file=%q: %w", knownHostsFile, err)
+	}
+	return callback, nil
+}
+
+func sshAuthMethods(opts SSHOptions) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			log.Warnf("unable to connect to ssh agent: %+v", err)
+		} else {
+			methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(conn).Signers))
+		}
+	}
+
+	identityFiles := []string{opts.IdentityFile}
+	if opts.IdentityFile == "" {
+		for _, name := range []string{"id_ed25519", "id_ecdsa", "id_rsa"} {
+			identityFiles = append(identityFiles, filepath.Join("~", ".ssh", name))
+		}
+	}
+
+	var signers []ssh.Signer
+	for _, identityFile := range identityFiles {
+		if identityFile == "" {
+			continue
+		}
+		signer, err := readSSHIdentity(identityFile)
+		switch {
+		case err == nil:
+			signers = append(signers, signer)
+		case opts.IdentityFile != "":
+			// an explicitly configured identity must be usable
+			return nil, err
+		default:
+			log.Tracef("skipping ssh identity: %+v", err)
+		}
+	}
+	if len(signers) > 0 {
+		methods = append(methods, ssh.PublicKeys(signers...))
+	}
+
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no ssh credentials found (start an SSH agent or configure an identity file)")
+	}
+	return methods, nil
+}
+
+func readSSHIdentity(identityFile string) (ssh.Signer, error) {
+	identityFile, err := homedir.Expand(identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to expand identity path: %w", err)
+	}
+
+	contents, err := os.ReadFile(identityFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read identity file=%q: %w", identityFile, err)
+	}
+
+	signer, err := ssh.ParsePrivateKey(contents)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse identity file=%q (note: passphrase protected keys must be provided via an SSH agent): %w", identityFile, err)
+	}
+	return signer, nil
+}
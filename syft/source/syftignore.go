@@ -0,0 +1,148 @@
+package source
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/bmatcuk/doublestar/v4"
+
+	"github.com/anchore/syft/internal/log"
+)
+
+// SyftIgnoreFile is the name of the file declaring the paths to exclude from a directory scan (in gitignore syntax).
+// A .syftignore file may be placed at the scan root or within any directory below it, in which case its patterns are
+// relative to that directory.
+const SyftIgnoreFile = ".syftignore"
+
+// ignoreRule is a single pattern of a .syftignore file.
+type ignoreRule struct {
+	// pattern is a doublestar glob relative to the directory of the .syftignore file
+	pattern string
+	// negate re-includes paths matched by an earlier rule (a pattern starting with "!")
+	negate bool
+	// dirOnly only matches directories (a pattern ending with "/")
+	dirOnly bool
+}
+
+// syftIgnore excludes the paths matched by the .syftignore files found under the scan root. The files are read as the
+// directories are walked (parents are always walked before their children), and are only read once.
+type syftIgnore struct {
+	root  string
+	rules map[string][]ignoreRule
+}
+
+// newSyftIgnoreFilter returns a path filter excluding the paths matched by the .syftignore files under the given root.
+func newSyftIgnoreFilter(root string) (pathFilterFn, error) {
+	// the directory resolver walks the real path of the root (see newDirectoryResolver)
+	if cleanRoot, err := filepath.EvalSymlinks(root); err == nil {
+		root = cleanRoot
+	}
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &syftIgnore{
+		root:  filepath.ToSlash(root),
+		rules: make(map[string][]ignoreRule),
+	}
+	return i.ignored, nil
+}
+
+// ignored indicates if the given path is matched by the .syftignore files of any of its parent directories. As with
+// gitignore, rules in deeper directories take precedence over their parents, and the last matching rule of a file wins.
+func (i *syftIgnore) ignored(p string, info os.FileInfo) bool {
+	p = filepath.ToSlash(p)
+	rel := strings.TrimPrefix(p, i.root+"/")
+	if rel == p || rel == "" {
+		// the root itself and paths outside of the root (e.g. symlink targets) are never ignored
+		return false
+	}
+	isDir := info != nil && info.IsDir()
+
+	ignored := false
+	dir := i.root
+	segments := strings.Split(rel, "/")
+	for idx := range segments {
+		relToDir := strings.Join(segments[idx:], "/")
+		for _, rule := range i.rulesFor(dir) {
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			if matches, err := doublestar.Match(rule.pattern, relToDir); err == nil && matches {
+				ignored = !rule.negate
+			}
+		}
+		dir = path.Join(dir, segments[idx])
+	}
+	return ignored
+}
+
+// rulesFor returns the rules of the .syftignore file within the given directory (if any).
+func (i *syftIgnore) rulesFor(dir string) []ignoreRule {
+	if rules, ok := i.rules[dir]; ok {
+		return rules
+	}
+
+	contents, err := os.ReadFile(filepath.Join(filepath.FromSlash(dir), SyftIgnoreFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Warnf("unable to read %s in %q: %+v", SyftIgnoreFile, dir, err)
+	}
+	rules := parseIgnoreRules(contents)
+	if len(rules) > 0 {
+		log.Debugf("excluding %d pattern(s) from %s in %q", len(rules), SyftIgnoreFile, dir)
+	}
+	i.rules[dir] = rules
+	return rules
+}
+
+// parseIgnoreRules reads patterns in gitignore syntax (see https://git-scm.com/docs/gitignore#_pattern_format).
+func parseIgnoreRules(contents []byte) []ignoreRule {
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		var rule ignoreRule
+		switch {
+		case strings.HasPrefix(line, `\#`), strings.HasPrefix(line, `\!`):
+			line = line[1:]
+		case strings.HasPrefix(line, "!"):
+			rule.negate = true
+			line = line[1:]
+		}
+
+		if strings.HasSuffix(line, "/") {
+			rule.dirOnly = true
+			line = strings.TrimRight(line, "/")
+		}
+		if line == "" {
+			continue
+		}
+
+		// patterns with a separator are relative to the directory of the .syftignore file, while patterns without a
+		// separator match a name at any depth below it
+		if strings.Contains(line, "/") {
+			line = strings.TrimPrefix(line, "/")
+		} else {
+			line = "**/" + line
+		}
+
+		if !doublestar.ValidatePattern(line) {
+			log.Warnf("ignoring invalid %s pattern: %q", SyftIgnoreFile, scanner.Text())
+			continue
+		}
+		rule.pattern = line
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,83 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyftIgnore(t *testing.T) {
+	tests := []struct {
+		name       string
+		exclusions []string
+		expected   []string
+	}{
+		{
+			name: "syftignore files only",
+			expected: []string{
+				".syftignore",
+				"docs/readme.md",
+				"keep.log",
+				"src/build",
+				"src/main.txt",
+				"sub/.syftignore",
+				"sub/important.txt",
+			},
+		},
+		{
+			name:       "merged with exclusions",
+			exclusions: []string{"./src/**"},
+			expected: []string{
+				".syftignore",
+				"docs/readme.md",
+				"keep.log",
+				"sub/.syftignore",
+				"sub/important.txt",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sourceInput, err := ParseInput("dir:test-fixtures/syftignore", "", false)
+			require.NoError(t, err)
+			src, cleanup, err := New(*sourceInput, nil, test.exclusions)
+			require.NoError(t, err)
+			if cleanup != nil {
+				t.Cleanup(cleanup)
+			}
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			locations, err := resolver.FilesByGlob("**")
+			require.NoError(t, err)
+
+			var paths []string
+			for _, l := range locations {
+				paths = append(paths, l.RealPath)
+			}
+			assert.ElementsMatch(t, test.expected, paths)
+		})
+	}
+}
+
+func TestParseIgnoreRules(t *testing.T) {
+	contents := `
+# comments and blank lines are skipped
+*.log
+!keep.log
+build/
+/docs/internal
+vendor/**/testdata
+\#literal
+`
+	assert.Equal(t, []ignoreRule{
+		{pattern: "**/*.log"},
+		{pattern: "**/keep.log", negate: true},
+		{pattern: "**/build", dirOnly: true},
+		{pattern: "docs/internal"},
+		{pattern: "vendor/**/testdata"},
+		{pattern: "**/#literal"},
+	}, parseIgnoreRules([]byte(contents)))
+}
@@ -0,0 +1,134 @@
+package source
+
+import (
+	"archive/tar"
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/mholt/archiver/v3"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+)
+
+const (
+	tarMagicOffset = 257
+	tarMagic       = "ustar"
+)
+
+var _ filesystemDriver = tarDriver{}
+
+// tarDriver extracts (possibly compressed) tar archives that cannot be identified by file extension, such as exported
+// WSL distributions (e.g. "wsl --export" output or ".wsl" distribution files). Archives with a well-known archive
+// extension are left to be unarchived by extension.
+type tarDriver struct{}
+
+func (d tarDriver) String() string {
+	return "tar"
+}
+
+func (d tarDriver) detect(path string) bool {
+	if byExtension, err := archiver.ByExtension(path); err == nil {
+		if _, ok := byExtension.(archiver.Unarchiver); ok {
+			return false
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	r, closer := decompressedReader(bufio.NewReader(f))
+	defer closer()
+
+	header, err := r.Peek(tarMagicOffset + len(tarMagic))
+	if err != nil {
+		return false
+	}
+	return string(header[tarMagicOffset:]) == tarMagic
+}
+
+func (d tarDriver) extract(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	r, closer := decompressedReader(bufio.NewReader(f))
+	defer closer()
+
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to read tar entry: %w", err)
+		}
+
+		target, err := safeJoin(dir, header.Name)
+		if err != nil {
+			log.Debugf("skipping tar entry: %+v", err)
+			continue
+		}
+
+		if err := extractTarEntry(tr, header, dir, target); err != nil {
+			return fmt.Errorf("unable to extract tar entry=%q: %w", header.Name, err)
+		}
+	}
+}
+
+func extractTarEntry(tr *tar.Reader, header *tar.Header, dir, target string) error {
+	switch header.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, 0755)
+
+	case tar.TypeSymlink:
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		_ = os.Remove(target)
+		return os.Symlink(header.Linkname, target)
+
+	case tar.TypeLink:
+		source, err := safeJoin(dir, header.Linkname)
+		if err != nil {
+			log.Debugf("skipping tar hardlink: %+v", err)
+			return nil
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		_ = os.Remove(target)
+		if err := os.Link(source, target); err != nil {
+			log.Debugf("unable to create hardlink=%q for tar entry=%q: %+v", target, header.Name, err)
+		}
+		return nil
+
+	case tar.TypeReg, tar.TypeRegA:
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		_ = os.Remove(target)
+		fh, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(header.Mode&0777|0600))
+		if err != nil {
+			return err
+		}
+		defer internal.CloseAndLogError(fh, target)
+
+		_, err = io.Copy(fh, tr)
+		return err
+
+	default:
+		// device files, named pipes, and sockets hold no content to catalog
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package source
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromFile_WithWSLExport(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "uncompressed tar export without an extension",
+			input: "test-fixtures/wsl/export",
+		},
+		{
+			name:  "compressed WSL distribution file",
+			input: "test-fixtures/wsl/ubuntu.wsl",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, cleanup := NewFromFile(test.input)
+			if cleanup != nil {
+				t.Cleanup(cleanup)
+			}
+
+			assert.Equal(t, test.input, src.Metadata.Path)
+			assert.NotEqual(t, src.Metadata.Path, src.path)
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			refs, err := resolver.FilesByPath("/etc/os-release", "/usr/lib/libfoo.so.1")
+			require.NoError(t, err)
+			assert.Len(t, refs, 2)
+
+			links, err := resolver.FilesByPath("/bin/libfoo-link")
+			require.NoError(t, err)
+			if assert.Len(t, links, 1) {
+				assert.Equal(t, "usr/lib/libfoo.so.1", links[0].RealPath)
+			}
+		})
+	}
+}
+
+func TestTarDriver_Detect(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{input: "test-fixtures/wsl/export", expected: true},
+		{input: "test-fixtures/wsl/ubuntu.wsl", expected: true},
+		{input: "test-fixtures/initramfs/newc.cpio.gz", expected: false},
+		{input: "test-fixtures/wim/xpress.wim", expected: false},
+		{input: "test-fixtures/path-detected/.vimrc", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.input, func(t *testing.T) {
+			assert.Equal(t, test.expected, tarDriver{}.detect(test.input))
+		})
+	}
+}
@@ -0,0 +1,186 @@
+#!/usr/bin/env python3
+"""
+Writes a 7z archive from a directory (since 7-Zip is not always available). Only the subset of the format needed for
+testing is written: no CRCs of the contents, no timestamps, and no filters (e.g. BCJ).
+
+usage:
+    mk7z.py [--lzma] [--encode-header] <directory> <output.7z>
+
+By default all files are compressed together with LZMA2 (a "solid" archive), with --lzma every file is compressed on
+its own with LZMA instead. With --encode-header the header is LZMA compressed (as 7-Zip does by default).
+"""
+import lzma
+import os
+import stat
+import struct
+import sys
+import zlib
+
+SIGNATURE = b"7z\xbc\xaf\x27\x1c"
+
+K_END = 0x00
+K_HEADER = 0x01
+K_MAIN_STREAMS_INFO = 0x04
+K_FILES_INFO = 0x05
+K_PACK_INFO = 0x06
+K_UNPACK_INFO = 0x07
+K_SUBSTREAMS_INFO = 0x08
+K_SIZE = 0x09
+K_FOLDER = 0x0B
+K_CODERS_UNPACK_SIZE = 0x0C
+K_NUM_UNPACK_STREAM = 0x0D
+K_EMPTY_STREAM = 0x0E
+K_EMPTY_FILE = 0x0F
+K_NAME = 0x11
+K_WIN_ATTRIBUTES = 0x15
+K_ENCODED_HEADER = 0x17
+
+ATTRIBUTE_DIRECTORY = 0x10
+ATTRIBUTE_UNIX_EXTENSION = 0x8000
+
+LZMA_DICT_SIZE = 1 << 16
+LZMA_PROPERTIES = (2 * 5 + 0) * 9 + 3  # pb=2, lp=0, lc=3
+
+
+def number(n):
+    """the variable length encoding of integers: the leading one bits of the first byte count the bytes that follow"""
+    for extra in range(8):
+        if n < 1 << (8 * extra + 7 - extra):
+            first = ((0xFF00 >> extra) & 0xFF) | (n >> (8 * extra))
+            return bytes([first]) + (n & ((1 << (8 * extra)) - 1)).to_bytes(extra, "little")
+    return b"\xff" + n.to_bytes(8, "little")
+
+
+def bits(values):
+    out = bytearray((len(values) + 7) // 8)
+    for i, v in enumerate(values):
+        if v:
+            out[i // 8] |= 0x80 >> (i % 8)
+    return bytes(out)
+
+
+def compress_lzma(data):
+    return lzma.compress(data, format=lzma.FORMAT_RAW,
+                         filters=[{"id": lzma.FILTER_LZMA1, "dict_size": LZMA_DICT_SIZE}])
+
+
+def compress_lzma2(data):
+    return lzma.compress(data, format=lzma.FORMAT_RAW,
+                         filters=[{"id": lzma.FILTER_LZMA2, "dict_size": LZMA_DICT_SIZE}])
+
+
+def lzma_coder():
+    properties = bytes([LZMA_PROPERTIES]) + struct.pack("<I", LZMA_DICT_SIZE)
+    return b"\x23" + b"\x03\x01\x01" + number(len(properties)) + properties
+
+
+def lzma2_coder():
+    # the dictionary size of 2^16 is encoded as 2^(11 + 10/2)
+    return b"\x21" + b"\x21" + number(1) + bytes([10])
+
+
+def streams_info(pack_pos, pack_sizes, folders):
+    """folders are (coder, unpack size, substream sizes) tuples"""
+    out = bytearray([K_PACK_INFO]) + number(pack_pos) + number(len(pack_sizes))
+    out += bytes([K_SIZE]) + b"".join(number(s) for s in pack_sizes) + bytes([K_END])
+
+    out += bytes([K_UNPACK_INFO, K_FOLDER]) + number(len(folders)) + b"\x00"
+    for coder, _, _ in folders:
+        out += number(1) + coder
+    out += bytes([K_CODERS_UNPACK_SIZE]) + b"".join(number(size) for _, size, _ in folders) + bytes([K_END])
+
+    if any(len(substreams) != 1 for _, _, substreams in folders):
+        out += bytes([K_SUBSTREAMS_INFO, K_NUM_UNPACK_STREAM])
+        out += b"".join(number(len(substreams)) for _, _, substreams in folders)
+        out += bytes([K_SIZE])
+        for _, _, substreams in folders:
+            out += b"".join(number(s) for s in substreams[:-1])
+        out += bytes([K_END])
+    return bytes(out + bytes([K_END]))
+
+
+def files_info(entries):
+    out = bytearray([K_FILES_INFO]) + number(len(entries))
+
+    empty_stream = [data is None or len(data) == 0 for _, _, data in entries]
+    if any(empty_stream):
+        vector = bits(empty_stream)
+        out += bytes([K_EMPTY_STREAM]) + number(len(vector)) + vector
+        empty_file = [data is not None for (_, _, data), empty in zip(entries, empty_stream) if empty]
+        if any(empty_file):
+            vector = bits(empty_file)
+            out += bytes([K_EMPTY_FILE]) + number(len(vector)) + vector
+
+    names = b"".join(name.encode("utf-16-le") + b"\x00\x00" for name, _, _ in entries)
+    out += bytes([K_NAME]) + number(len(names) + 1) + b"\x00" + names
+
+    attributes = b"".join(struct.pack("<I", attribute) for _, attribute, _ in entries)
+    out += bytes([K_WIN_ATTRIBUTES]) + number(len(attributes) + 2) + b"\x01\x00" + attributes
+    return bytes(out + bytes([K_END]))
+
+
+def walk(directory):
+    """returns (name, attributes, contents) of every entry, where the contents of directories are None"""
+    entries = []
+    for root, dirs, files in os.walk(directory):
+        dirs.sort()
+        for name in sorted(dirs) + sorted(files):
+            path = os.path.join(root, name)
+            info = os.lstat(path)
+            attribute = ATTRIBUTE_UNIX_EXTENSION | (info.st_mode << 16)
+            if stat.S_ISDIR(info.st_mode):
+                attribute |= ATTRIBUTE_DIRECTORY
+                contents = None
+            elif stat.S_ISLNK(info.st_mode):
+                contents = os.readlink(path).encode()
+            else:
+                with open(path, "rb") as f:
+                    contents = f.read()
+            entries.append((os.path.relpath(path, directory), attribute, contents))
+    return entries
+
+
+def main(args):
+    solid = "--lzma" not in args
+    encode_header = "--encode-header" in args
+    directory, output = [a for a in args if not a.startswith("--")]
+
+    entries = walk(directory)
+    streams = [data for _, _, data in entries if data]
+
+    packed = bytearray()
+    pack_sizes = []
+    folders = []
+    if solid:
+        data = b"".join(streams)
+        compressed = compress_lzma2(data)
+        packed += compressed
+        pack_sizes.append(len(compressed))
+        folders.append((lzma2_coder(), len(data), [len(s) for s in streams]))
+    else:
+        for data in streams:
+            compressed = compress_lzma(data)
+            packed += compressed
+            pack_sizes.append(len(compressed))
+            folders.append((lzma_coder(), len(data), [len(data)]))
+
+    header = bytes([K_HEADER, K_MAIN_STREAMS_INFO]) + streams_info(0, pack_sizes, folders) + files_info(entries)
+    header += bytes([K_END])
+
+    if encode_header:
+        compressed = compress_lzma(header)
+        # the packed header follows the packed contents
+        header_info = streams_info(len(packed), [len(compressed)], [(lzma_coder(), len(header), [len(header)])])
+        packed += compressed
+        header = bytes([K_ENCODED_HEADER]) + header_info
+
+    start_header = struct.pack("<QQI", len(packed), len(header), zlib.crc32(header))
+    with open(output, "wb") as f:
+        f.write(SIGNATURE + b"\x00\x04")
+        f.write(struct.pack("<I", zlib.crc32(start_header)) + start_header)
+        f.write(packed)
+        f.write(header)
+
+
+if __name__ == "__main__":
+    main(sys.argv[1:])
@@ -0,0 +1,56 @@
+#!/usr/bin/env python3
+"""
+Writes a single layer docker image archive (as "docker save" does) from a directory (since docker is not always
+available).
+
+usage:
+    mkimage.py <directory> <output.tar>
+"""
+import hashlib
+import io
+import json
+import sys
+import tarfile
+
+TAG = "syft-fixture-archive:latest"
+
+
+def deterministic(info):
+    info.uid = info.gid = 0
+    info.uname = info.gname = ""
+    info.mtime = 1672531200
+    return info
+
+
+def add_file(archive, name, data):
+    info = deterministic(tarfile.TarInfo(name))
+    info.size = len(data)
+    archive.addfile(info, io.BytesIO(data))
+
+
+def main(directory, output):
+    layer = io.BytesIO()
+    with tarfile.open(fileobj=layer, mode="w", format=tarfile.USTAR_FORMAT) as archive:
+        archive.add(directory, arcname=".", filter=deterministic)
+    layer = layer.getvalue()
+    layer_digest = hashlib.sha256(layer).hexdigest()
+
+    config = json.dumps({
+        "architecture": "amd64",
+        "os": "linux",
+        "config": {"Env": ["PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin:/sbin:/bin"]},
+        "rootfs": {"type": "layers", "diff_ids": ["sha256:" + layer_digest]},
+    }, sort_keys=True).encode()
+    config_name = hashlib.sha256(config).hexdigest() + ".json"
+    layer_name = layer_digest + "/layer.tar"
+
+    manifest = json.dumps([{"Config": config_name, "RepoTags": [TAG], "Layers": [layer_name]}]).encode()
+
+    with tarfile.open(output, mode="w", format=tarfile.USTAR_FORMAT) as archive:
+        add_file(archive, config_name, config)
+        add_file(archive, layer_name, layer)
+        add_file(archive, "manifest.json", manifest)
+
+
+if __name__ == "__main__":
+    main(*sys.argv[1:])
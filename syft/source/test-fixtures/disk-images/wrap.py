@@ -0,0 +1,211 @@
+#!/usr/bin/env python3
+"""
+Builds partitioned disks from filesystem images and wraps raw disks into VM disk image formats (since qemu-img and
+sfdisk are not always available). Only the subset of each format that is needed for testing is written.
+
+usage:
+    wrap.py mbr <output> <partition.img>...
+    wrap.py gpt <output> <partition.img>...
+    wrap.py qcow2|vhd|vhd-fixed|vmdk <input.raw> <output>
+"""
+import struct
+import sys
+import uuid
+import zlib
+
+SECTOR = 512
+# partitions start at this sector (real disks use 2048, but fixtures should be small)
+FIRST_SECTOR = 64
+
+
+def read(path):
+    with open(path, "rb") as f:
+        return f.read()
+
+
+def write(path, data):
+    with open(path, "wb") as f:
+        f.write(data)
+
+
+def pad(data, size):
+    return data + b"\x00" * ((size - len(data) % size) % size)
+
+
+def layout(partitions):
+    offset = FIRST_SECTOR
+    placed = []
+    for p in partitions:
+        data = pad(read(p), SECTOR)
+        placed.append((offset, data))
+        offset += len(data) // SECTOR
+    return placed, offset
+
+
+def mbr(output, partitions):
+    placed, end = layout(partitions)
+    disk = bytearray(end * SECTOR)
+    for i, (start, data) in enumerate(placed):
+        entry = struct.pack("<B3sB3sII", 0x80 if i == 0 else 0, b"\x00" * 3, 0x83, b"\x00" * 3, start, len(data) // SECTOR)
+        disk[446 + i * 16:446 + (i + 1) * 16] = entry
+        disk[start * SECTOR:start * SECTOR + len(data)] = data
+    disk[510:512] = b"\x55\xaa"
+    write(output, disk)
+
+
+def gpt(output, partitions):
+    placed, end = layout(partitions)
+    # leave room for the backup GPT header at the end of the disk
+    disk = bytearray((end + 1) * SECTOR)
+
+    linux_fs = uuid.UUID("0FC63DAF-8483-4772-8E79-3D69D8477DE4").bytes_le
+    entries = bytearray(128 * 4)
+    for i, (start, data) in enumerate(placed):
+        unique = uuid.UUID(int=i + 1).bytes_le
+        entries[i * 128:(i + 1) * 128] = struct.pack("<16s16sQQQ72s", linux_fs, unique, start,
+                                                      start + len(data) // SECTOR - 1, 0, b"\x00" * 72)
+        disk[start * SECTOR:start * SECTOR + len(data)] = data
+
+    header = struct.pack("<8sIIIIQQQQ16sQIII", b"EFI PART", 0x00010000, 92, 0, 0, 1, end, FIRST_SECTOR, end - 1,
+                         uuid.UUID(int=42).bytes_le, 2, 4, 128, zlib.crc32(entries))
+    header = header[:16] + struct.pack("<I", zlib.crc32(header)) + header[20:]
+    disk[SECTOR:SECTOR + len(header)] = header
+    disk[2 * SECTOR:2 * SECTOR + len(entries)] = entries
+
+    # protective MBR
+    disk[446:462] = struct.pack("<B3sB3sII", 0, b"\x00" * 3, 0xEE, b"\x00" * 3, 1, end)
+    disk[510:512] = b"\x55\xaa"
+    write(output, disk)
+
+
+def qcow2(raw, output):
+    """writes a version 2 qcow2 image where every other allocated cluster is compressed"""
+    cluster_bits = 12
+    cluster = 1 << cluster_bits
+    data = pad(raw, cluster)
+    clusters = len(data) // cluster
+    l2_entries = cluster // 8
+    l1_size = (clusters + l2_entries - 1) // l2_entries
+
+    # layout: header, L1 table, refcount table (unused by readers here), L2 tables, then data
+    l1_offset = cluster
+    refcount_offset = 2 * cluster
+    l2_offset = 3 * cluster
+    out = bytearray((3 + l1_size) * cluster)
+
+    l1 = bytearray(l1_size * 8)
+    for i in range(l1_size):
+        struct.pack_into(">Q", l1, i * 8, (1 << 63) | (l2_offset + i * cluster))
+    out[l1_offset:l1_offset + len(l1)] = l1
+
+    size_bits = cluster_bits - 8
+    offset_bits = 62 - size_bits
+    for i in range(clusters):
+        chunk = data[i * cluster:(i + 1) * cluster]
+        if chunk == b"\x00" * cluster:
+            continue
+        offset = len(out)
+        if i % 2 == 0:
+            compressor = zlib.compressobj(9, zlib.DEFLATED, -15)
+            compressed = compressor.compress(chunk) + compressor.flush()
+            sectors = (len(compressed) + SECTOR - 1) // SECTOR
+            entry = (1 << 62) | ((sectors - 1) << offset_bits) | offset
+            out += pad(compressed, cluster)
+        else:
+            entry = (1 << 63) | offset
+            out += chunk
+        struct.pack_into(">Q", out, l2_offset + i * 8, entry)
+
+    header = struct.pack(">4sIQIIQIIQQIIQ", b"QFI\xfb", 2, 0, 0, cluster_bits, len(raw), 0, l1_size, l1_offset,
+                         refcount_offset, 1, 0, 0)
+    out[0:len(header)] = header
+    write(output, out)
+
+
+def vhd_footer(size, disk_type, data_offset):
+    footer = bytearray(struct.pack(">8sIIQI4sI4sQQHBBII16sB427s", b"conectix", 2, 0x00010000, data_offset, 0, b"test",
+                                   0x00010000, b"Wi2k", size, size, 0, 0, 0, disk_type, 0, uuid.UUID(int=7).bytes, 0,
+                                   b"\x00" * 427))
+    checksum = ~sum(footer) & 0xFFFFFFFF
+    struct.pack_into(">I", footer, 64, checksum)
+    return bytes(footer)
+
+
+def vhd_fixed(raw, output):
+    data = pad(raw, SECTOR)
+    write(output, data + vhd_footer(len(data), 2, 0xFFFFFFFFFFFFFFFF))
+
+
+def vhd(raw, output):
+    """writes a dynamic VHD image with small (4 KiB) blocks, where empty blocks are left unallocated"""
+    block = 4096
+    data = pad(raw, block)
+    blocks = len(data) // block
+    footer = vhd_footer(len(data), 3, SECTOR)
+
+    table_offset = 3 * SECTOR
+    table = bytearray(b"\xff" * pad_len(blocks * 4, SECTOR))
+    header = bytearray(struct.pack(">8sQQIIII16sII4s", b"cxsparse", 0xFFFFFFFFFFFFFFFF, table_offset, 0x00010000,
+                                   blocks, block, 0, b"\x00" * 16, 0, 0, b"\x00" * 4))
+    header = pad(bytes(header), 1024)
+
+    out = bytearray(footer + header + table)
+    for i in range(blocks):
+        chunk = data[i * block:(i + 1) * block]
+        if chunk == b"\x00" * block:
+            continue
+        struct.pack_into(">I", out, table_offset + i * 4, len(out) // SECTOR)
+        # one sector bitmap (all sectors present) followed by the block data
+        out += b"\xff" * SECTOR + chunk
+    out += footer
+    write(output, out)
+
+
+def pad_len(size, alignment):
+    return size + (alignment - size % alignment) % alignment
+
+
+def vmdk(raw, output):
+    """writes a monolithic sparse VMDK extent with 4 KiB grains"""
+    grain_sectors = 8
+    grain = grain_sectors * SECTOR
+    gt_entries = 512
+    data = pad(raw, grain)
+    grains = len(data) // grain
+    tables = (grains + gt_entries - 1) // gt_entries
+
+    gd_sector = 1
+    gt_sector = gd_sector + (pad_len(tables * 4, SECTOR) // SECTOR)
+    first_grain_sector = gt_sector + tables * (gt_entries * 4 // SECTOR)
+
+    out = bytearray(first_grain_sector * SECTOR)
+    for t in range(tables):
+        struct.pack_into("<I", out, gd_sector * SECTOR + t * 4, gt_sector + t * (gt_entries * 4 // SECTOR))
+
+    for i in range(grains):
+        chunk = data[i * grain:(i + 1) * grain]
+        if chunk == b"\x00" * grain:
+            continue
+        sector = len(out) // SECTOR
+        struct.pack_into("<I", out, gt_sector * SECTOR + i * 4, sector)
+        out += chunk
+
+    header = struct.pack("<4sIIQQQQIQQQBccccH", b"KDMV", 1, 0x3, len(data) // SECTOR, grain_sectors, 0, 0, gt_entries,
+                         0, gd_sector, first_grain_sector, 0, b"\n", b" ", b"\r", b"\n", 0)
+    out[0:len(header)] = header
+    write(output, out)
+
+
+def main():
+    command = sys.argv[1]
+    if command == "mbr":
+        mbr(sys.argv[2], sys.argv[3:])
+    elif command == "gpt":
+        gpt(sys.argv[2], sys.argv[3:])
+    else:
+        formats = {"qcow2": qcow2, "vhd": vhd, "vhd-fixed": vhd_fixed, "vmdk": vmdk}
+        formats[command](read(sys.argv[2]), sys.argv[3])
+
+
+if __name__ == "__main__":
+    main()
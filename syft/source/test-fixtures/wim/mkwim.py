@@ -0,0 +1,267 @@
+#!/usr/bin/env python3
+"""
+Writes a single image WIM archive from a directory (since wimlib is not always available). Only the subset of the
+format needed for testing is written: no security descriptors, timestamps, short names, or XML data.
+
+usage:
+    mkwim.py [--xpress] <directory> <output.wim>
+"""
+import hashlib
+import heapq
+import os
+import struct
+import sys
+
+CHUNK_SIZE = 32768
+HEADER_SIZE = 208
+ATTRIBUTE_DIRECTORY = 0x10
+ATTRIBUTE_NORMAL = 0x80
+
+
+class BitWriter:
+    """the XPRESS bitstream: 16-bit little endian words of bits interleaved with literal bytes (as in wimlib)"""
+
+    def __init__(self):
+        self.out = bytearray(4)
+        self.next_bits = 0
+        self.next_bits2 = 2
+        self.bitbuf = 0
+        self.bitcount = 0
+
+    def bits(self, value, count):
+        self.bitbuf = (self.bitbuf << count) | value
+        self.bitcount += count
+        if self.bitcount > 16:
+            self.bitcount -= 16
+            struct.pack_into("<H", self.out, self.next_bits, (self.bitbuf >> self.bitcount) & 0xFFFF)
+            self.next_bits = self.next_bits2
+            self.next_bits2 = len(self.out)
+            self.out += b"\x00\x00"
+
+    def byte(self, value):
+        self.out.append(value)
+
+    def u16(self, value):
+        self.out += struct.pack("<H", value)
+
+    def flush(self):
+        struct.pack_into("<H", self.out, self.next_bits, (self.bitbuf << (16 - self.bitcount)) & 0xFFFF)
+        return bytes(self.out)
+
+
+def lz77(data):
+    """greedy LZ77 parse into (literal) and (length, offset) items"""
+    items = []
+    table = {}
+    i = 0
+    while i < len(data):
+        best_len, best_off = 0, 0
+        if i + 3 <= len(data):
+            for candidate in reversed(table.get(data[i:i + 3], [])[-16:]):
+                length = 0
+                while i + length < len(data) and length < 1000 and data[candidate + length] == data[i + length]:
+                    length += 1
+                if length > best_len:
+                    best_len, best_off = length, i - candidate
+        if best_len >= 3:
+            items.append((best_len, best_off))
+            for j in range(i, i + best_len):
+                table.setdefault(data[j:j + 3], []).append(j)
+            i += best_len
+        else:
+            items.append(data[i])
+            table.setdefault(data[i:i + 3], []).append(i)
+            i += 1
+    return items
+
+
+def match_symbol(length, offset):
+    offset_bits = offset.bit_length() - 1
+    return 256 + (offset_bits << 4) + min(length - 3, 15), offset_bits
+
+
+def huffman_lengths(freqs, max_bits=15):
+    heap = [(f, [s]) for s, f in enumerate(freqs) if f > 0]
+    lengths = [0] * len(freqs)
+    heapq.heapify(heap)
+    while len(heap) > 1:
+        f1, s1 = heapq.heappop(heap)
+        f2, s2 = heapq.heappop(heap)
+        for s in s1 + s2:
+            lengths[s] += 1
+        heapq.heappush(heap, (f1 + f2, s1 + s2))
+    if max(lengths) > max_bits:
+        # fall back to a flat code (every symbol is 9 bits)
+        return [9] * len(freqs)
+    return lengths
+
+
+def canonical_codes(lengths):
+    codes = [0] * len(lengths)
+    code = 0
+    for length in range(1, 16):
+        for symbol, l in enumerate(lengths):
+            if l == length:
+                codes[symbol] = code
+                code += 1
+        code <<= 1
+    return codes
+
+
+def xpress(data):
+    items = lz77(data)
+    freqs = [0] * 512
+    # ensure there are always at least two symbols so that every symbol has a code
+    freqs[0] = freqs[1] = 1
+    for item in items:
+        if isinstance(item, int):
+            freqs[item] += 1
+        else:
+            freqs[match_symbol(*item)[0]] += 1
+
+    lengths = huffman_lengths(freqs)
+    codes = canonical_codes(lengths)
+
+    table = bytes(lengths[i] | (lengths[i + 1] << 4) for i in range(0, 512, 2))
+    w = BitWriter()
+    for item in items:
+        if isinstance(item, int):
+            w.bits(codes[item], lengths[item])
+            continue
+        length, offset = item
+        symbol, offset_bits = match_symbol(length, offset)
+        w.bits(codes[symbol], lengths[symbol])
+        adjusted = length - 3
+        if adjusted >= 15:
+            if adjusted - 15 < 255:
+                w.byte(adjusted - 15)
+            else:
+                w.byte(255)
+                w.u16(adjusted)
+        w.bits(offset - (1 << offset_bits), offset_bits)
+    return table + w.flush()
+
+
+def resource(data, compress):
+    """returns the stored bytes and flags of a resource"""
+    if not compress or len(data) == 0:
+        return data, 0
+    chunks = []
+    for i in range(0, len(data), CHUNK_SIZE):
+        chunk = data[i:i + CHUNK_SIZE]
+        compressed = xpress(chunk)
+        chunks.append(compressed if len(compressed) < len(chunk) else chunk)
+    offsets = []
+    total = 0
+    for c in chunks[:-1]:
+        total += len(c)
+        offsets.append(total)
+    stored = b"".join(struct.pack("<I", o) for o in offsets) + b"".join(chunks)
+    if len(stored) >= len(data):
+        return data, 0
+    return stored, 0x04
+
+
+def reshdr(size, flags, offset, original):
+    return struct.pack("<QQQ", size | (flags << 56), offset, original)
+
+
+class Entry:
+    def __init__(self, name, path):
+        self.name = name
+        self.path = path
+        self.is_dir = os.path.isdir(path)
+        self.children = []
+        self.subdir = 0
+        self.hash = b"\x00" * 20
+        if self.is_dir:
+            self.children = [Entry(n, os.path.join(path, n)) for n in sorted(os.listdir(path))]
+        else:
+            with open(path, "rb") as f:
+                self.data = f.read()
+            if self.data:
+                self.hash = hashlib.sha1(self.data).digest()
+
+    def size(self):
+        name = self.name.encode("utf-16-le")
+        length = 102 + (len(name) + 2 if name else 0)
+        return (length + 7) & ~7
+
+    def encode(self):
+        name = self.name.encode("utf-16-le")
+        attributes = ATTRIBUTE_DIRECTORY if self.is_dir else ATTRIBUTE_NORMAL
+        entry = struct.pack("<QIiQQQQQQ20sIQHHH", self.size(), attributes, -1, self.subdir, 0, 0, 0, 0, 0, self.hash,
+                            0, 0, 0, 0, len(name))
+        entry += name + (b"\x00\x00" if name else b"")
+        return entry + b"\x00" * (self.size() - len(entry))
+
+
+def metadata(root):
+    # empty security data (total length, number of entries), followed by the root directory entry (as a list of one)
+    offset = 8 + root.size() + 8
+    queue = [root]
+    lists = []
+    while queue:
+        directory = queue.pop(0)
+        directory.subdir = offset
+        lists.append(directory)
+        offset += sum(c.size() for c in directory.children) + 8
+        queue.extend(c for c in directory.children if c.is_dir)
+
+    out = struct.pack("<II", 8, 0) + root.encode() + b"\x00" * 8
+    for directory in lists:
+        out += b"".join(c.encode() for c in directory.children) + b"\x00" * 8
+    return out
+
+
+def files(entry):
+    if not entry.is_dir:
+        yield entry
+    for c in entry.children:
+        yield from files(c)
+
+
+def main():
+    args = sys.argv[1:]
+    compress = args[0] == "--xpress"
+    if compress:
+        args = args[1:]
+    directory, output = args
+
+    root = Entry("", directory)
+    out = bytearray(HEADER_SIZE)
+    lookup = bytearray()
+    written = set()
+    for f in files(root):
+        if not f.data or f.hash in written:
+            continue
+        written.add(f.hash)
+        stored, flags = resource(f.data, compress)
+        lookup += reshdr(len(stored), flags, len(out), len(f.data)) + struct.pack("<HI20s", 1, 1, f.hash)
+        out += stored
+
+    meta = metadata(root)
+    stored, flags = resource(meta, compress)
+    lookup += reshdr(len(stored), 0x02 | flags, len(out), len(meta)) + struct.pack("<HI20s", 1, 1,
+                                                                                 hashlib.sha1(meta).digest())
+    out += stored
+
+    lookup_offset = len(out)
+    out += lookup
+
+    flags = 0x00020002 if compress else 0
+    header = struct.pack("<8sIIII16sHHI", b"MSWIM\x00\x00\x00", HEADER_SIZE, 0x00010d00, flags, CHUNK_SIZE,
+                         b"syft-test-fixtur", 1, 1, 1)
+    header += reshdr(len(lookup), 0, lookup_offset, len(lookup))
+    header += reshdr(0, 0, 0, 0)  # XML data
+    header += reshdr(0, 0, 0, 0)  # boot metadata
+    header += struct.pack("<I", 0)  # boot index
+    header += reshdr(0, 0, 0, 0)  # integrity table
+    out[0:len(header)] = header
+
+    with open(output, "wb") as f:
+        f.write(out)
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,62 @@
+package source
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/anchore/syft/internal"
+	"github.com/anchore/syft/internal/log"
+	"github.com/anchore/syft/syft/source/internal/wim"
+)
+
+var _ filesystemDriver = wimDriver{}
+
+// wimDriver extracts Windows Imaging Format (WIM) archives (e.g. Windows installation and deployment images). When
+// the archive holds several images, the bootable image (or the first image) is extracted.
+type wimDriver struct{}
+
+func (d wimDriver) String() string {
+	return "wim"
+}
+
+func (d wimDriver) detect(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	return wim.IsWIM(f)
+}
+
+func (d wimDriver) extract(path, dir string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer internal.CloseAndLogError(f, path)
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	archive, err := wim.Open(f, info.Size())
+	if err != nil {
+		return err
+	}
+
+	index := archive.BootIndex()
+	if index == 0 {
+		index = 1
+	}
+	if archive.ImageCount() > 1 {
+		log.Infof("WIM archive contains %d images, cataloging image %d", archive.ImageCount(), index)
+	}
+
+	image, err := archive.Image(index)
+	if err != nil {
+		return fmt.Errorf("unable to read WIM image=%d: %w", index, err)
+	}
+	return extractFS(image, dir)
+}
@@ -0,0 +1,66 @@
+package source
+
+import (
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromFile_WithWIM(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{
+			name:  "uncompressed WIM archive",
+			input: "test-fixtures/wim/uncompressed.wim",
+		},
+		{
+			name:  "XPRESS compressed WIM archive",
+			input: "test-fixtures/wim/xpress.wim",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			src, cleanup := NewFromFile(test.input)
+			if cleanup != nil {
+				t.Cleanup(cleanup)
+			}
+
+			assert.Equal(t, test.input, src.Metadata.Path)
+			assert.NotEqual(t, src.Metadata.Path, src.path)
+
+			resolver, err := src.FileResolver(SquashedScope)
+			require.NoError(t, err)
+
+			refs, err := resolver.FilesByPath(
+				"/Windows/System32/drivers/etc/hosts",
+				"/Windows/System32/license.txt",
+				"/Windows/System32/padding.dat",
+				"/Windows/empty.txt",
+				"/Program Files/Vendor/product.json",
+			)
+			require.NoError(t, err)
+			assert.Len(t, refs, 5)
+
+			// contents that span several compressed chunks are decompressed intact
+			license, err := resolver.FilesByPath("/Windows/System32/license.txt")
+			require.NoError(t, err)
+			require.Len(t, license, 1)
+
+			expected, err := os.ReadFile("test-fixtures/wim/root/Windows/System32/license.txt")
+			require.NoError(t, err)
+
+			reader, err := resolver.FileContentsByLocation(license[0])
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = reader.Close() })
+
+			actual, err := io.ReadAll(reader)
+			require.NoError(t, err)
+			assert.Equal(t, expected, actual)
+		})
+	}
+}
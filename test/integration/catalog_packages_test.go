@@ -41,7 +41,7 @@ func BenchmarkImagePackageCatalogers(b *testing.B) {
 
 		b.Run(c.Name(), func(b *testing.B) {
 			for i := 0; i < b.N; i++ {
-				pc, _, err = cataloger.Catalog(resolver, theDistro, c)
+				pc, _, err = cataloger.Catalog(resolver, theDistro, 1, c)
 				if err != nil {
 					b.Fatalf("failure during benchmark: %+v", err)
 				}
@@ -78,6 +78,24 @@ func TestPkgCoverageImage(t *testing.T) {
 
 	// for image scans we should not expect to see any of the following package types
 	definedPkgs.Remove(string(pkg.KbPkg))
+	definedPkgs.Remove(string(pkg.BinaryPkg))
+	definedPkgs.Remove(string(pkg.LinuxKernelPkg))
+	definedPkgs.Remove(string(pkg.LinuxKernelModulePkg))
+	definedPkgs.Remove(string(pkg.FirmwarePkg))
+	definedPkgs.Remove(string(pkg.WasmPkg))
+	definedPkgs.Remove(string(pkg.HelmPkg))
+	definedPkgs.Remove(string(pkg.ContainerImagePkg))
+	definedPkgs.Remove(string(pkg.TerraformPkg))
+	definedPkgs.Remove(string(pkg.VSCodeExtensionPkg))
+	definedPkgs.Remove(string(pkg.ChromeExtensionPkg))
+	definedPkgs.Remove(string(pkg.FirefoxExtensionPkg))
+	definedPkgs.Remove(string(pkg.WordpressPluginPkg))
+	definedPkgs.Remove(string(pkg.WordpressThemePkg))
+	definedPkgs.Remove(string(pkg.DrupalModulePkg))
+	definedPkgs.Remove(string(pkg.DrupalThemePkg))
+	definedPkgs.Remove(string(pkg.AIModelPkg))
+	definedPkgs.Remove(string(pkg.GithubActionPkg))
+	definedPkgs.Remove(string(pkg.GithubActionWorkflowPkg))
 	definedPkgs.Remove(string(pkg.GoModulePkg))
 	definedPkgs.Remove(string(pkg.RustPkg))
 	definedPkgs.Remove(string(pkg.DartPubPkg))
@@ -210,6 +228,24 @@ func TestPkgCoverageDirectory(t *testing.T) {
 
 	// for directory scans we should not expect to see any of the following package types
 	definedPkgs.Remove(string(pkg.KbPkg))
+	definedPkgs.Remove(string(pkg.BinaryPkg))
+	definedPkgs.Remove(string(pkg.LinuxKernelPkg))
+	definedPkgs.Remove(string(pkg.LinuxKernelModulePkg))
+	definedPkgs.Remove(string(pkg.FirmwarePkg))
+	definedPkgs.Remove(string(pkg.WasmPkg))
+	definedPkgs.Remove(string(pkg.HelmPkg))
+	definedPkgs.Remove(string(pkg.ContainerImagePkg))
+	definedPkgs.Remove(string(pkg.TerraformPkg))
+	definedPkgs.Remove(string(pkg.VSCodeExtensionPkg))
+	definedPkgs.Remove(string(pkg.ChromeExtensionPkg))
+	definedPkgs.Remove(string(pkg.FirefoxExtensionPkg))
+	definedPkgs.Remove(string(pkg.WordpressPluginPkg))
+	definedPkgs.Remove(string(pkg.WordpressThemePkg))
+	definedPkgs.Remove(string(pkg.DrupalModulePkg))
+	definedPkgs.Remove(string(pkg.DrupalThemePkg))
+	definedPkgs.Remove(string(pkg.AIModelPkg))
+	definedPkgs.Remove(string(pkg.GithubActionPkg))
+	definedPkgs.Remove(string(pkg.GithubActionWorkflowPkg))
 
 	// ensure that integration test commonTestCases stay in sync with the available catalogers
 	if len(observedLanguages) < len(definedLanguages) {
@@ -20,6 +20,7 @@ import (
 	"github.com/anchore/syft/internal"
 	"github.com/anchore/syft/internal/ui/components"
 	syftEventParsers "github.com/anchore/syft/syft/event/parsers"
+	"github.com/anchore/syft/syft/pkg/cataloger"
 )
 
 const maxBarWidth = 50
@@ -314,7 +315,8 @@ func ReadImageHandler(ctx context.Context, fr *frame.Frame, event partybus.Event
 	return nil
 }
 
-// PackageCatalogerStartedHandler periodically writes catalog statistics to a single line.
+// PackageCatalogerStartedHandler periodically writes catalog statistics (the packages found, files read, and catalogers
+// finished) to a single line, followed by a line naming the catalogers that are running (and finally, any that failed).
 func PackageCatalogerStartedHandler(ctx context.Context, fr *frame.Frame, event partybus.Event, wg *sync.WaitGroup) error {
 	monitor, err := syftEventParsers.ParsePackageCatalogerStarted(event)
 	if err != nil {
@@ -325,36 +327,76 @@ func PackageCatalogerStartedHandler(ctx context.Context, fr *frame.Frame, event
 	if err != nil {
 		return err
 	}
+	catalogersLine, err := fr.Append()
+	if err != nil {
+		return err
+	}
 
 	wg.Add(1)
 
 	_, spinner := startProcess()
-	stream := progress.StreamMonitors(ctx, []progress.Monitorable{monitor.FilesProcessed, monitor.PackagesDiscovered}, interval)
 	title := tileFormat.Sprint("Cataloging packages")
 
-	formatFn := func(p int64) {
+	formatFn := func() {
 		spin := color.Magenta.Sprint(spinner.Next())
-		auxInfo := auxInfoFormat.Sprintf("[packages %d]", p)
+		auxInfo := auxInfoFormat.Sprintf("[packages %d, files %d, catalogers %d/%d]", monitor.PackagesDiscovered.Current(),
+			monitor.FilesProcessed.Current(), monitor.CatalogersProcessed.Current(), monitor.CatalogersProcessed.Size())
 		_, _ = io.WriteString(line, fmt.Sprintf(statusTitleTemplate+"%s", spin, title, auxInfo))
+
+		var running []string
+		for _, state := range catalogerStates(monitor) {
+			if state.Status == cataloger.CatalogerRunning {
+				running = append(running, fmt.Sprintf("%s (%d files)", state.Name, state.FilesRead))
+			}
+		}
+		_, _ = io.WriteString(catalogersLine, auxInfoFormat.Sprintf("   └── running: %s", internal.TruncateMiddleEllipsis(strings.Join(running, ", "), 100)))
 	}
 
 	go func() {
 		defer wg.Done()
 
-		formatFn(0)
-		for p := range stream {
-			formatFn(p[1])
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		formatFn()
+		for !progress.IsErrCompleted(monitor.PackagesDiscovered.Error()) {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				formatFn()
+			}
+		}
+
+		var failed []string
+		for _, state := range catalogerStates(monitor) {
+			if state.Status == cataloger.CatalogerFailed {
+				failed = append(failed, state.Name)
+			}
 		}
 
 		spin := color.Green.Sprint(completedStatus)
 		title = tileFormat.Sprint("Cataloged packages")
 		auxInfo := auxInfoFormat.Sprintf("[%d packages]", monitor.PackagesDiscovered.Current())
 		_, _ = io.WriteString(line, fmt.Sprintf(statusTitleTemplate+"%s", spin, title, auxInfo))
+		if len(failed) > 0 {
+			_, _ = io.WriteString(catalogersLine, color.Red.Sprintf("   └── failed (results are incomplete): %s", strings.Join(failed, ", ")))
+		} else {
+			_, _ = io.WriteString(catalogersLine, auxInfoFormat.Sprintf("   └── %d catalogers finished", monitor.CatalogersProcessed.Size()))
+		}
 	}()
 
 	return nil
 }
 
+// catalogerStates returns the state of each cataloger of the monitor (if the monitor tracks them).
+func catalogerStates(monitor *cataloger.Monitor) []cataloger.CatalogerState {
+	if monitor.Catalogers == nil {
+		return nil
+	}
+	return monitor.Catalogers.States()
+}
+
 // SecretsCatalogerStartedHandler shows the intermittent secrets searching progress.
 func SecretsCatalogerStartedHandler(ctx context.Context, fr *frame.Frame, event partybus.Event, wg *sync.WaitGroup) error {
 	prog, err := syftEventParsers.ParseSecretsCatalogingStarted(event)